@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/badge"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/injection"
+)
+
+func main() {
+	cacheTTL := flag.Duration("cache-ttl", 30*time.Second, "How long the status of a Pipeline is cached, and can be cached by the clients.")
+	namespaces := flag.String("namespaces", "", "Comma-separated list of the namespaces whose Pipelines are exposed. The statuses of the other namespaces are not found.")
+	pathPrefix := flag.String("path-prefix", "", "Prefix of the paths the badges are served under, e.g. when the service is exposed under a path of an Ingress or a Gateway.")
+	cfg := injection.ParseAndGetRESTConfigOrDie()
+	if *namespaces == "" {
+		log.Fatal("The namespaces whose Pipelines are exposed must be set with -namespaces")
+	}
+
+	client, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		log.Fatalf("Unable to create the Tekton client: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	prefix := strings.TrimSuffix(*pathPrefix, "/")
+	mux.Handle(prefix+"/badges/", http.StripPrefix(prefix, badge.NewHandler(client, strings.Split(*namespaces, ","), *cacheTTL, clock.RealClock{})))
+	mux.HandleFunc("/health", handler)
+	mux.HandleFunc("/readiness", handler)
+
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	log.Printf("Badge server listening on port %s", port)
+	log.Fatal(http.ListenAndServe(":"+port, mux)) // #nosec G114 -- see https://github.com/securego/gosec#available-rules
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
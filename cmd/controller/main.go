@@ -20,30 +20,41 @@ import (
 	"context"
 	"flag"
 	"log"
+	"net"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
 	"github.com/tektoncd/pipeline/pkg/reconciler/customrun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pruner"
 	"github.com/tektoncd/pipeline/pkg/reconciler/resolutionrequest"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
+	"github.com/tektoncd/pipeline/pkg/reconciler/tektonhealth"
+	"github.com/tektoncd/pipeline/pkg/sharding"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
 	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/utils/clock"
 	filteredinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory/filtered"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
+	pkgleaderelection "knative.dev/pkg/leaderelection"
 	"knative.dev/pkg/signals"
 )
 
@@ -54,6 +65,8 @@ const (
 	TracerProviderPipelineRun = "pipeline-reconciler"
 	// TracerProviderTaskRun is the name of TracerProvider used in taskrun reconciler
 	TracerProviderTaskRun = "taskrun-reconciler"
+	// exitReserve is the time left to the controller to exit at the end of the termination grace period
+	exitReserve = 2 * time.Second
 )
 
 func main() {
@@ -62,10 +75,22 @@ func main() {
 	disableHighAvailability := flag.Bool("disable-ha", false, "Whether to disable high-availability functionality for this component.  This flag will be deprecated "+
 		"and removed when we have promoted this feature to stable, so do not pass it without filing an "+
 		"issue upstream!")
+	terminationGracePeriod := flag.Duration("termination-grace-period", 30*time.Second, "The time given to the controller to shut down "+
+		"gracefully once it is asked to terminate, which must match the terminationGracePeriodSeconds of its pod.")
+	shards := flag.Int("shards", 1, "The number of shards splitting the namespaces between the replicas of the controller, "+
+		"which each reconcile the namespaces of their shard instead of electing leaders, so a shard fails over only when "+
+		"the pod of its replica is restarted. Optional, defaults to a single shard.")
+	shardIndex := flag.Int("shard-index", -1, "The index of the shard of this replica. Optional, defaults to the ordinal ending "+
+		"the name of its pod, e.g. in a StatefulSet.")
+	shardLabel := flag.String("shard-label", sharding.DefaultLabel, "The label of the namespaces whose value is hashed to select "+
+		"their shard, the namespaces without it are selected by the hash of their name.")
+	debugPort := flag.Int("debug-port", 0, "The port the debug endpoints of the controller are served on, on localhost only so that "+
+		"they are only reachable with kubectl port-forward. Optional, defaults to not serving them.")
 
 	opts := &pipeline.Options{}
 	flag.StringVar(&opts.Images.EntrypointImage, "entrypoint-image", "", "The container image containing our entrypoint binary.")
 	flag.StringVar(&opts.Images.SidecarLogResultsImage, "sidecarlogresults-image", "", "The container image containing the binary for accessing results.")
+	flag.StringVar(&opts.Images.LogForwarderImage, "logforwarder-image", "", "The container image containing the binary for shipping the logs of the steps.")
 	flag.StringVar(&opts.Images.NopImage, "nop-image", "", "The container image used to stop sidecars")
 	flag.StringVar(&opts.Images.ShellImage, "shell-image", "", "The container image containing a shell")
 	flag.StringVar(&opts.Images.ShellImageWin, "shell-image-win", "", "The container image containing a windows shell")
@@ -93,12 +118,29 @@ func main() {
 		ctx = sharedmain.WithHADisabled(ctx)
 	}
 
+	kubeClient := kubernetes.NewForConfigOrDie(cfg)
+	var electionManager *leaderelection.Manager
+	if !*disableHighAvailability && *shards <= 1 {
+		identity, err := pkgleaderelection.UniqueID()
+		if err != nil {
+			log.Fatalf("Unable to get the identity of the controller in the leader election: %v", err)
+		}
+		// The leader election of the buckets of the reconcilers reloads the leader election
+		// configmap, instead of the leader election of knative reading it once at startup.
+		electionManager = leaderelection.NewManager(kubeClient, ControllerLogKey, identity)
+		ctx = leaderelection.WithManager(sharedmain.WithHADisabled(ctx), electionManager)
+	}
+
 	// sets up liveness and readiness probes.
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/", handler)
 	mux.HandleFunc("/health", handler)
 	mux.HandleFunc("/readiness", handler)
+	if electionManager != nil {
+		// serves the buckets led by this replica, for debugging stuck reconciles.
+		mux.Handle(leaderelection.StatusPath, electionManager.StatusHandler())
+	}
 
 	port := os.Getenv("PROBES_PORT")
 	if port == "" {
@@ -111,6 +153,19 @@ func main() {
 		log.Fatal(http.ListenAndServe(":"+port, mux)) // #nosec G114 -- see https://github.com/securego/gosec#available-rules
 	}()
 
+	if *debugPort != 0 {
+		// serves the result reference reports of the running PipelineRuns, for debugging stalled runs.
+		resultRefReports := pipelinerun.NewResultRefReports()
+		ctx = pipelinerun.WithResultRefReports(ctx, resultRefReports)
+		debugMux := http.NewServeMux()
+		debugMux.Handle(pipelinerun.ResultRefsDebugPath, resultRefReports.Handler())
+		go func() {
+			addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(*debugPort))
+			log.Printf("Debug server listening on %s", addr)
+			log.Fatal(http.ListenAndServe(addr, debugMux)) // #nosec G114 -- see https://github.com/securego/gosec#available-rules
+		}()
+	}
+
 	// initialize opentelemetry
 	tpPipelineRun, err := tracerProvider(TracerProviderPipelineRun)
 	if err != nil {
@@ -125,6 +180,29 @@ func main() {
 	otel.SetTextMapPropagator(propagation.TraceContext{})
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	shutdownCtx, cancelShutdown := shutdownContext(ctx, *terminationGracePeriod)
+	defer cancelShutdown()
+
+	if *shards > 1 {
+		index := *shardIndex
+		if index < 0 {
+			hostname, err := os.Hostname()
+			if err != nil {
+				log.Fatalf("Unable to get the name of the pod to select its shard: %v", err)
+			}
+			if index, err = sharding.IndexFromPodName(hostname); err != nil {
+				log.Fatal(err)
+			}
+		}
+		shard, err := sharding.Start(ctx, kubeClient, index, *shards, *shardLabel)
+		if err != nil {
+			log.Fatalf("Unable to start the shard %d of %d: %v", index, *shards, err)
+		}
+		// Each replica reconciles the namespaces of its shard, instead of the leaders of the buckets of all the namespaces.
+		// Leader election is disabled for all the controllers, so a shard has no standby and fails over only when
+		// its pod is restarted.
+		ctx = sharding.WithShard(sharedmain.WithHADisabled(ctx), shard)
+	}
 
 	ctx = filteredinformerfactory.WithSelectors(ctx, v1beta1.ManagedByLabelKey)
 	sharedmain.MainWithConfig(ctx, ControllerLogKey, cfg,
@@ -132,27 +210,56 @@ func main() {
 		pipelinerun.NewController(opts, clock.RealClock{}, tpPipelineRun),
 		resolutionrequest.NewController(clock.RealClock{}),
 		customrun.NewController(),
+		tektonhealth.NewController(clock.RealClock{}),
+		pruner.NewPipelineRunController(clock.RealClock{}),
+		pruner.NewTaskRunController(clock.RealClock{}),
 	)
 
-	// Cleanly shutdown and flush telemetry when the application exits.
-	defer func(ctx context.Context) {
-		// Do not make the application hang when it is shutdown.
-		ctx, cancel = context.WithTimeout(ctx, time.Second*5)
-		defer cancel()
-
-		// shutdown is only needed when tracerProvider is inialized with jaeger
-		// not needed when tracerProvider is NewNoopTracerProvider
-		if tp, ok := tpPipelineRun.(*tracesdk.TracerProvider); ok {
-			if err := tp.Shutdown(ctx); err != nil {
-				log.Printf("Unable to shutdown tracerProvider for pipelinerun, %s", err.Error())
-			}
+	// The reconciles in flight have completed and the metrics have been flushed. Deliver the
+	// cloud events they emitted and flush telemetry before the termination grace period elapses.
+	if err := cloudevent.Flush(shutdownCtx); err != nil {
+		log.Printf("Unable to flush the pending cloud events before the termination grace period elapses, %s", err.Error())
+	}
+
+	// Do not make the application hang when it is shutdown.
+	tracingCtx, cancelTracing := context.WithTimeout(shutdownCtx, time.Second*5)
+	defer cancelTracing()
+
+	// shutdown is only needed when tracerProvider is inialized with an exporter
+	// not needed when tracerProvider is NewNoopTracerProvider
+	if tp, ok := tpPipelineRun.(*tracesdk.TracerProvider); ok {
+		if err := tp.Shutdown(tracingCtx); err != nil {
+			log.Printf("Unable to shutdown tracerProvider for pipelinerun, %s", err.Error())
 		}
-		if tp, ok := tpTaskrun.(*tracesdk.TracerProvider); ok {
-			if err := tp.Shutdown(ctx); err != nil {
-				log.Printf("Unable to shutdown tracerProvider for taskrun, %s", err.Error())
-			}
+	}
+	if tp, ok := tpTaskrun.(*tracesdk.TracerProvider); ok {
+		if err := tp.Shutdown(tracingCtx); err != nil {
+			log.Printf("Unable to shutdown tracerProvider for taskrun, %s", err.Error())
+		}
+	}
+}
+
+// shutdownContext returns a context which is done when the termination grace period, which
+// starts when ctx is done, is about to elapse. Shutdown steps bounded by it can complete
+// without the controller being killed.
+func shutdownContext(ctx context.Context, gracePeriod time.Duration) (context.Context, context.CancelFunc) {
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-shutdownCtx.Done():
+			return
+		}
+		timer := time.NewTimer(gracePeriod - exitReserve)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			log.Printf("The termination grace period of %s is about to elapse, aborting the graceful shutdown", gracePeriod)
+			cancel()
+		case <-shutdownCtx.Done():
 		}
-	}(ctx)
+	}()
+	return shutdownCtx, cancel
 }
 
 func handler(w http.ResponseWriter, r *http.Request) {
@@ -160,28 +267,24 @@ func handler(w http.ResponseWriter, r *http.Request) {
 }
 
 // tracerProvider returns an OpenTelemetry TracerProvider configured to use
-// the Jaeger exporter that will send spans to the provided url. The returned
+// the exporter configured by the env, see spanExporter. The returned
 // TracerProvider will also use a Resource configured with all the information
-// about the application.
+// about the application, and the IDGenerator recording the spans of the runs
+// with the span contexts allocated when they started.
 func tracerProvider(service string) (trace.TracerProvider, error) {
-	// Create the Jaeger exporter
-	// The following env variables are used by the sdk for creating the exporter
-	// - OTEL_EXPORTER_JAEGER_ENDPOINT is the HTTP endpoint for sending spans directly to a collector.
-	// - OTEL_EXPORTER_JAEGER_USER is the username to be sent as authentication to the collector endpoint.
-	// - OTEL_EXPORTER_JAEGER_PASSWORD is the password to be sent as authentication to the collector endpoint.
-
-	if _, e := os.LookupEnv("OTEL_EXPORTER_JAEGER_ENDPOINT"); !e {
-		// jaeger endpoint is not defined, disable tracing and return no-op tracerProvider
-		return trace.NewNoopTracerProvider(), nil
-	}
-
-	exp, err := jaeger.New(jaeger.WithCollectorEndpoint())
+	exp, err := spanExporter()
 	if err != nil {
 		return nil, err
 	}
-	// Initialize tracerProvider with the jaeger exporter
+	if exp == nil {
+		// no endpoint is defined, disable tracing and return no-op tracerProvider
+		return trace.NewNoopTracerProvider(), nil
+	}
+
+	// Initialize tracerProvider with the exporter
 	tp := tracesdk.NewTracerProvider(
 		tracesdk.WithBatcher(exp),
+		tracesdk.WithIDGenerator(tracing.NewIDGenerator()),
 		// Record information about the service in a Resource.
 		tracesdk.WithResource(resource.NewWithAttributes(
 			semconv.SchemaURL,
@@ -190,3 +293,27 @@ func tracerProvider(service string) (trace.TracerProvider, error) {
 	)
 	return tp, nil
 }
+
+// spanExporter returns the OTLP exporter when an OTLP endpoint is defined, else the
+// Jaeger exporter when a Jaeger endpoint is defined, else nil.
+func spanExporter() (tracesdk.SpanExporter, error) {
+	// Create the OTLP exporter
+	// The following env variables are used by the sdk for creating the exporter
+	// - OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT is the HTTP endpoint of the collector.
+	// - OTEL_EXPORTER_OTLP_HEADERS or OTEL_EXPORTER_OTLP_TRACES_HEADERS are the headers sent to the collector.
+	for _, env := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+		if _, e := os.LookupEnv(env); e {
+			return otlptracehttp.New(context.Background())
+		}
+	}
+
+	// Create the Jaeger exporter
+	// The following env variables are used by the sdk for creating the exporter
+	// - OTEL_EXPORTER_JAEGER_ENDPOINT is the HTTP endpoint for sending spans directly to a collector.
+	// - OTEL_EXPORTER_JAEGER_USER is the username to be sent as authentication to the collector endpoint.
+	// - OTEL_EXPORTER_JAEGER_PASSWORD is the password to be sent as authentication to the collector endpoint.
+	if _, e := os.LookupEnv("OTEL_EXPORTER_JAEGER_ENDPOINT"); e {
+		return jaeger.New(jaeger.WithCollectorEndpoint())
+	}
+	return nil, nil
+}
@@ -22,8 +22,10 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
@@ -36,6 +38,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/spire/config"
 	"github.com/tektoncd/pipeline/pkg/termination"
@@ -58,8 +61,28 @@ var (
 	enableSpire            = flag.Bool("enable_spire", false, "If specified by configmap, this enables spire signing and verification")
 	socketPath             = flag.String("spire_socket_path", "unix:///spiffe-workload-api/spire-agent.sock", "Experimental: The SPIRE agent socket for SPIFFE workload API.")
 	resultExtractionMethod = flag.String("result_from", featureFlags.ResultExtractionMethodTerminationMessage, "The method using which to extract results from tasks. Default is using the termination message.")
+	executionLog           = flag.Bool("execution_log", false, "If specified, record the executed command and the time it finished in the termination message")
+	stopFile               = flag.String("stop_file", "", "If specified, file whose content requests the command to stop")
+	preStop                = flag.String("pre_stop", "", "If specified, JSON list of the command to run before stopping the command")
+	stopSignal             = flag.String("stop_signal", "", "If specified, name of the signal sent to stop the command, defaults to SIGTERM")
+	stopGracePeriod        = flag.Duration("stop_grace_period", 30*time.Second, "If specified, time the command has to exit once the stop is requested")
+	fileResults            = flag.String("file_results", "", "If specified, list of the results of type file, whose files are uploaded to the result_file_store")
+	forwardLogs            = flag.Bool("forward_logs", false, "If specified, copy stdout and stderr to the log file next to the post_file, for the log forwarder to ship")
+	resultFileStore        = flag.String("result_file_store", "", "If specified, http(s) URL of the store to upload the files of the results of type file to")
+	stepProgress           = flag.Bool("step_progress", false, "If specified, write progress markers to stdout when the command starts and exits, and when the progress file next to the post_file changes")
+	stepStatus             = flag.Bool("step_status", false, "If specified, report the reason and the message written to the status files next to the post_file in the termination message")
+	debug                  = flag.Bool("debug", false, "If specified, log the details of the execution of the step, such as the files it waits for and the command it runs")
+	secretParams           = flag.Bool("secret_params", false, "If specified, mask the values of the secret params mounted in the secret params directory in the outputs of the step")
+
+	startWorkspaceDigests = entrypoint.WorkspaceDigestPaths{}
+	endWorkspaceDigests   = entrypoint.WorkspaceDigestPaths{}
 )
 
+func init() {
+	flag.Var(startWorkspaceDigests, "start_workspace_digest", "If specified, name=path of a workspace to compute a digest of before the step runs, repeated for each workspace")
+	flag.Var(endWorkspaceDigests, "end_workspace_digest", "If specified, name=path of a workspace to compute a digest of after the step runs, repeated for each workspace")
+}
+
 const (
 	defaultWaitPollingInterval = time.Second
 	breakpointExitSuffix       = ".breakpointexit"
@@ -145,6 +168,27 @@ func main() {
 		spireWorkloadAPI = spire.NewEntrypointerAPIClient(&spireConfig)
 	}
 
+	var preStopCommand []string
+	if *preStop != "" {
+		if err := json.Unmarshal([]byte(*preStop), &preStopCommand); err != nil {
+			log.Fatalf("Error parsing the pre-stop command: %v", err)
+		}
+	}
+	signal, err := parseSignal(*stopSignal)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var logPath string
+	if *forwardLogs && *postFile != "" {
+		logPath = filepath.Join(filepath.Dir(*postFile), pod.StepLogFile)
+	}
+	var secretValues []string
+	if *secretParams {
+		if secretValues, err = entrypoint.ReadSecretValues(pod.SecretParamsDir); err != nil {
+			log.Fatalf("Error reading the values of the secret params: %v", err)
+		}
+	}
+
 	e := entrypoint.Entrypointer{
 		Command:         append(cmd, commandArgs...),
 		WaitFiles:       strings.Split(*waitFiles, ","),
@@ -153,8 +197,10 @@ func main() {
 		TerminationPath: *terminationPath,
 		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
 		Runner: &realRunner{
-			stdoutPath: *stdoutPath,
-			stderrPath: *stderrPath,
+			stdoutPath:   *stdoutPath,
+			stderrPath:   *stderrPath,
+			logPath:      logPath,
+			secretValues: secretValues,
 		},
 		PostWriter:             &realPostWriter{},
 		Results:                strings.Split(*results, ","),
@@ -164,6 +210,42 @@ func main() {
 		StepMetadataDir:        *stepMetadataDir,
 		SpireWorkloadAPI:       spireWorkloadAPI,
 		ResultExtractionMethod: *resultExtractionMethod,
+		StartWorkspaceDigests:  startWorkspaceDigests,
+		EndWorkspaceDigests:    endWorkspaceDigests,
+		ExecutionLog:           *executionLog,
+		StopFile:               *stopFile,
+		PreStop:                preStopCommand,
+		StopSignal:             signal,
+		StopGracePeriod:        *stopGracePeriod,
+		Debug:                  *debug,
+		SecretValues:           secretValues,
+	}
+	if *fileResults != "" {
+		e.FileResults = strings.Split(*fileResults, ",")
+	}
+	if *resultFileStore != "" {
+		e.FileUploader = &realUploader{store: *resultFileStore, client: http.DefaultClient}
+	}
+	if *stepProgress && *postFile != "" {
+		e.ProgressFile = filepath.Join(filepath.Dir(*postFile), pod.StepProgressFile)
+		e.ProgressReporter = &realProgressReporter{w: os.Stdout, now: time.Now}
+		// The command finds the progress file through the environment it inherits.
+		if err := os.Setenv(pod.StepProgressFileEnvVar, e.ProgressFile); err != nil {
+			log.Fatalf("Error setting %s: %v", pod.StepProgressFileEnvVar, err)
+		}
+	}
+	if *stepStatus && *postFile != "" {
+		e.StatusReasonFile = filepath.Join(filepath.Dir(*postFile), pod.StepStatusReasonFile)
+		e.StatusMessageFile = filepath.Join(filepath.Dir(*postFile), pod.StepStatusMessageFile)
+		// The command finds the status files through the environment it inherits.
+		for env, file := range map[string]string{
+			pod.StepStatusReasonFileEnvVar:  e.StatusReasonFile,
+			pod.StepStatusMessageFileEnvVar: e.StatusMessageFile,
+		} {
+			if err := os.Setenv(env, file); err != nil {
+				log.Fatalf("Error setting %s: %v", env, err)
+			}
+		}
 	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
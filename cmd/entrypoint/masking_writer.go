@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// maxMaskedLineSize is the size in bytes from which a line without a newline is written
+// rather than held, so that a command writing no newline isn't buffered entirely.
+const maxMaskedLineSize = 64 * 1024
+
+// maskingWriter masks the values of the secret params in the lines written to w. The lines
+// are held until they are complete, so that the values written in several chunks are masked.
+type maskingWriter struct {
+	sync.Mutex
+	w            io.Writer
+	secretValues []string
+	line         []byte
+}
+
+// newMaskingWriter returns the writer masking the secret values in the lines written to w.
+func newMaskingWriter(w io.Writer, secretValues []string) *maskingWriter {
+	return &maskingWriter{w: w, secretValues: secretValues}
+}
+
+// Write writes the complete lines of p, masked, and holds the rest until it is complete.
+func (mw *maskingWriter) Write(p []byte) (int, error) {
+	mw.Lock()
+	defer mw.Unlock()
+	mw.line = append(mw.line, p...)
+	for {
+		i := bytes.IndexByte(mw.line, '\n')
+		if i < 0 {
+			break
+		}
+		if err := mw.write(mw.line[:i+1]); err != nil {
+			return 0, err
+		}
+		mw.line = mw.line[i+1:]
+	}
+	if len(mw.line) >= maxMaskedLineSize {
+		if err := mw.write(mw.line); err != nil {
+			return 0, err
+		}
+		mw.line = nil
+	}
+	return len(p), nil
+}
+
+// Flush writes the line held by the writer, masked.
+func (mw *maskingWriter) Flush() error {
+	mw.Lock()
+	defer mw.Unlock()
+	if len(mw.line) == 0 {
+		return nil
+	}
+	err := mw.write(mw.line)
+	mw.line = nil
+	return err
+}
+
+func (mw *maskingWriter) write(line []byte) error {
+	_, err := io.WriteString(mw.w, entrypoint.Mask(string(line), mw.secretValues))
+	return err
+}
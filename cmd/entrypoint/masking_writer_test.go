@@ -0,0 +1,43 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMaskingWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := newMaskingWriter(&out, []string{"hunter2"})
+	for _, chunk := range []string{"logging in with hun", "ter2\nlogged", " in as hunter2"} {
+		if n, err := w.Write([]byte(chunk)); err != nil || n != len(chunk) {
+			t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(chunk))
+		}
+	}
+	if d := cmp.Diff("logging in with ***\n", out.String()); d != "" {
+		t.Errorf("output before the flush (-want, +got): %s", d)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() returned an unexpected error: %v", err)
+	}
+	if d := cmp.Diff("logging in with ***\nlogged in as ***", out.String()); d != "" {
+		t.Errorf("output (-want, +got): %s", d)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"log"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/pod"
+)
+
+// realProgressReporter writes the progress markers of the step to its stdout, where
+// the controller reads them from the container logs.
+type realProgressReporter struct {
+	w   io.Writer
+	now func() time.Time
+}
+
+var _ entrypoint.ProgressReporter = (*realProgressReporter)(nil)
+
+// Started writes the marker of the start of the command.
+func (r *realProgressReporter) Started() {
+	r.write(pod.StepProgressMarker{Event: pod.StepProgressStarted})
+}
+
+// Progress writes the marker of a percent-complete hint.
+func (r *realProgressReporter) Progress(percent int32) {
+	r.write(pod.StepProgressMarker{Event: pod.StepProgressPercent, Percent: &percent})
+}
+
+// Finished writes the marker of the exit of the command.
+func (r *realProgressReporter) Finished() {
+	r.write(pod.StepProgressMarker{Event: pod.StepProgressFinished})
+}
+
+func (r *realProgressReporter) write(m pod.StepProgressMarker) {
+	m.Time = r.now().UTC()
+	if err := pod.WriteStepProgressMarker(r.w, m); err != nil {
+		log.Printf("Error writing the progress marker: %v", err)
+	}
+}
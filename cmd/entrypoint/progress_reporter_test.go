@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRealProgressReporter(t *testing.T) {
+	var out bytes.Buffer
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	r := &realProgressReporter{w: &out, now: func() time.Time { return now }}
+	r.Started()
+	r.Progress(40)
+	r.Finished()
+
+	want := `{"tekton.dev/stepProgress":{"event":"started","time":"2023-05-01T10:00:00Z"}}
+{"tekton.dev/stepProgress":{"event":"progress","percent":40,"time":"2023-05-01T10:00:00Z"}}
+{"tekton.dev/stepProgress":{"event":"finished","time":"2023-05-01T10:00:00Z"}}
+`
+	if d := cmp.Diff(want, out.String()); d != "" {
+		t.Errorf("progress markers (-want, +got): %s", d)
+	}
+}
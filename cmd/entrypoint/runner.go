@@ -32,6 +32,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/pod"
+	"golang.org/x/sys/unix"
 )
 
 // TODO(jasonhall): Test that original exit code is propagated and that
@@ -44,9 +45,16 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	// logPath is the file both stdout and stderr are copied to, for the log forwarder to ship.
+	logPath string
+	// secretValues are the values of the secret params masked in stdout and stderr.
+	secretValues []string
 }
 
-var _ entrypoint.Runner = (*realRunner)(nil)
+var (
+	_ entrypoint.Runner   = (*realRunner)(nil)
+	_ entrypoint.Signaler = (*realRunner)(nil)
+)
 
 // close closes the signals channel which is used to receive system signals.
 func (rr *realRunner) close() {
@@ -67,6 +75,23 @@ func (rr *realRunner) signal(signal os.Signal) {
 	}
 }
 
+// Signal forwards a signal to the command being run.
+func (rr *realRunner) Signal(sig os.Signal) {
+	rr.signal(sig)
+}
+
+// parseSignal returns the signal with the given name, SIGTERM if it is empty.
+func parseSignal(name string) (os.Signal, error) {
+	if name == "" {
+		return syscall.SIGTERM, nil
+	}
+	sig := unix.SignalNum(name)
+	if sig == 0 {
+		return nil, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
+
 // Run executes the entrypoint.
 func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if len(args) == 0 {
@@ -86,25 +111,39 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	// if a standard output file is specified
 	// create the log file and add to the std multi writer
+	stdout, stderr := []io.Writer{os.Stdout}, []io.Writer{os.Stderr}
 	if rr.stdoutPath != "" {
-		stdout, err := newStdLogWriter(rr.stdoutPath)
+		f, err := newStdLogWriter(rr.stdoutPath)
 		if err != nil {
 			return err
 		}
-		defer stdout.Close()
-		cmd.Stdout = io.MultiWriter(os.Stdout, stdout)
-	} else {
-		cmd.Stdout = os.Stdout
+		defer f.Close()
+		stdout = append(stdout, f)
 	}
 	if rr.stderrPath != "" {
-		stderr, err := newStdLogWriter(rr.stderrPath)
+		f, err := newStdLogWriter(rr.stderrPath)
 		if err != nil {
 			return err
 		}
-		defer stderr.Close()
-		cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
-	} else {
-		cmd.Stderr = os.Stderr
+		defer f.Close()
+		stderr = append(stderr, f)
+	}
+	if rr.logPath != "" {
+		f, err := newStdLogWriter(rr.logPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		stdout = append(stdout, f)
+		stderr = append(stderr, f)
+	}
+	cmd.Stdout, cmd.Stderr = stdWriter(stdout), stdWriter(stderr)
+	if len(rr.secretValues) != 0 {
+		stdoutMasker, stderrMasker := newMaskingWriter(cmd.Stdout, rr.secretValues), newMaskingWriter(cmd.Stderr, rr.secretValues)
+		// The lines left without a newline are written once the command exited.
+		defer stdoutMasker.Flush()
+		defer stderrMasker.Flush()
+		cmd.Stdout, cmd.Stderr = stdoutMasker, stderrMasker
 	}
 
 	// dedicated PID group used to forward signals to
@@ -144,6 +183,15 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	return nil
 }
 
+// stdWriter returns the writer copying to all the writers, the standard stream
+// itself if there is no other writer so that the command writes to it directly.
+func stdWriter(writers []io.Writer) io.Writer {
+	if len(writers) == 1 {
+		return writers[0]
+	}
+	return io.MultiWriter(writers...)
+}
+
 // newStdLogWriter create a new file writer that used for collecting std log
 // the file is opened with os.O_WRONLY|os.O_CREATE|os.O_APPEND, and will not
 // override any existing content in the path. This means that the same file can
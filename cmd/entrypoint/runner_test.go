@@ -134,6 +134,30 @@ func TestRealRunnerStdoutAndStderrSamePath(t *testing.T) {
 	}
 }
 
+func TestRealRunnerLogPath(t *testing.T) {
+	tmp := t.TempDir()
+	stdoutPath := filepath.Join(tmp, "stdout")
+	logPath := filepath.Join(tmp, "run/0/log")
+	rr := realRunner{
+		stdoutPath: stdoutPath,
+		logPath:    logPath,
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", "echo out && sleep 0.1 && echo err >&2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for path, want := range map[string]string{
+		stdoutPath: "out\n",
+		logPath:    "out\nerr\n",
+	} {
+		if got, err := os.ReadFile(path); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		} else if string(got) != want {
+			t.Errorf("%v: got: %q, wanted: %q", path, got, want)
+		}
+	}
+}
+
 func TestRealRunnerStdoutPathWithSignal(t *testing.T) {
 	tmp, err := os.MkdirTemp("", "")
 	if err != nil {
@@ -33,16 +33,30 @@ import (
 
 // realRunner actually runs commands.
 type realRunner struct {
-	stdoutPath string
-	stderrPath string
+	stdoutPath   string
+	stderrPath   string
+	logPath      string
+	secretValues []string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
 
+// parseSignal returns nil if name is empty: the commands can't be sent a signal
+// on Windows, and are killed when they are stopped.
+func parseSignal(name string) (os.Signal, error) {
+	if name != "" {
+		return nil, errors.New("stop signals are not supported on Windows")
+	}
+	return nil, nil //nolint:nilnil // no signal is sent on Windows
+}
+
 func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	if rr.stdoutPath != "" || rr.stderrPath != "" {
 		return errors.New("step.StdoutPath and step.StderrPath not supported on Windows")
 	}
+	if rr.logPath != "" {
+		return errors.New("log forwarding is not supported on Windows")
+	}
 	if len(args) == 0 {
 		return nil
 	}
@@ -51,6 +65,12 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	if len(rr.secretValues) != 0 {
+		stdoutMasker, stderrMasker := newMaskingWriter(os.Stdout, rr.secretValues), newMaskingWriter(os.Stderr, rr.secretValues)
+		defer stdoutMasker.Flush()
+		defer stderrMasker.Flush()
+		cmd.Stdout, cmd.Stderr = stdoutMasker, stderrMasker
+	}
 
 	// Run the defined command
 	if err := cmd.Run(); err != nil {
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
+)
+
+// realUploader uploads files to an http(s) object store, at a path derived from
+// their digest: <store>/sha256/<hex>. A file already in the store is not uploaded
+// again.
+type realUploader struct {
+	store  string
+	client *http.Client
+}
+
+var _ entrypoint.FileUploader = (*realUploader)(nil)
+
+// Upload uploads the file with a PUT request unless a HEAD request finds it in the store.
+func (u *realUploader) Upload(ctx context.Context, file, digest string) (string, error) {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return "", fmt.Errorf("invalid digest %q", digest)
+	}
+	uri := fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(u.store, "/"), algorithm, hex)
+
+	head, err := http.NewRequestWithContext(ctx, http.MethodHead, uri, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := u.client.Do(head)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return uri, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	put, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, f)
+	if err != nil {
+		return "", err
+	}
+	put.ContentLength = info.Size()
+	put.Header.Set("Content-Type", "application/octet-stream")
+	resp, err = u.client.Do(put)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("uploading %s to %s: %s", file, uri, resp.Status)
+	}
+	return uri, nil
+}
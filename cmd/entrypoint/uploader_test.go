@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestRealUploader_Upload(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string]string{}
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodHead:
+			if _, ok := objects[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			objects[r.URL.Path] = string(b)
+			puts++
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "report")
+	if err := os.WriteFile(file, []byte("all tests passed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	u := &realUploader{store: server.URL + "/tekton/default/", client: server.Client()}
+	for i := 0; i < 2; i++ {
+		uri, err := u.Upload(context.Background(), file, "sha256:abc123")
+		if err != nil {
+			t.Fatalf("Upload: %v", err)
+		}
+		if want := server.URL + "/tekton/default/sha256/abc123"; uri != want {
+			t.Errorf("got uri %q, want %q", uri, want)
+		}
+	}
+	if got := objects["/tekton/default/sha256/abc123"]; got != "all tests passed" {
+		t.Errorf("got object %q, want %q", got, "all tests passed")
+	}
+	if puts != 1 {
+		t.Errorf("expected the file to be uploaded once but it was uploaded %d times", puts)
+	}
+}
+
+func TestRealUploader_UploadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	file := filepath.Join(t.TempDir(), "report")
+	if err := os.WriteFile(file, []byte("all tests passed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	u := &realUploader{store: server.URL, client: server.Client()}
+	if _, err := u.Upload(context.Background(), file, "sha256:abc123"); err == nil {
+		t.Error("expected an error uploading to a store refusing the file")
+	}
+	if _, err := u.Upload(context.Background(), file, "abc123"); err == nil {
+		t.Error("expected an error uploading a file with an invalid digest")
+	}
+}
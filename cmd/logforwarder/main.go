@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/internal/logforwarder"
+	"github.com/tektoncd/pipeline/pkg/pod"
+)
+
+func main() {
+	var runDir, stepNames, sink, url, labels string
+	var pollInterval time.Duration
+	flag.StringVar(&runDir, "run-dir", pod.RunDir, "Path to the run directory of the steps. Default is /tekton/run")
+	flag.StringVar(&stepNames, "step-names", "", "comma separated names of the steps running in the pod, in order. eg. build,test")
+	flag.StringVar(&sink, "sink", "", "kind of sink the logs are shipped to: loki, http, gcs or cloudwatch")
+	flag.StringVar(&url, "url", "", "address of the sink")
+	flag.StringVar(&labels, "labels", "{}", "JSON object of the labels of the logs")
+	flag.DurationVar(&pollInterval, "poll-interval", time.Second, "time waited for more logs once the log files are read to their end")
+	flag.Parse()
+	if stepNames == "" {
+		log.Fatal("step-names were not provided")
+	}
+
+	s, err := logforwarder.NewSink(sink, url, http.DefaultClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+	f := &logforwarder.Forwarder{
+		RunDir:       runDir,
+		LogFile:      pod.StepLogFile,
+		Steps:        strings.Split(stepNames, ","),
+		Sink:         s,
+		PollInterval: pollInterval,
+	}
+	if err := json.Unmarshal([]byte(labels), &f.Labels); err != nil {
+		log.Fatalf("error parsing the labels: %v", err)
+	}
+	if f.Labels == nil {
+		f.Labels = map[string]string{}
+	}
+	if podName := os.Getenv(logforwarder.PodNameEnvVar); podName != "" {
+		f.Labels[logforwarder.PodLabel] = podName
+	}
+	if err := f.Forward(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -31,12 +31,26 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/resolution"
 	resolutionv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1alpha1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/pkg/client/informers/externalversions"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	beta1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/clustervisibility"
+	"github.com/tektoncd/pipeline/pkg/lint"
+	"github.com/tektoncd/pipeline/pkg/resourcemigration"
+	"github.com/tektoncd/pipeline/pkg/serviceaccountpolicy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/injection"
 	"knative.dev/pkg/injection/sharedmain"
-	pkgleaderelection "knative.dev/pkg/leaderelection"
 	"knative.dev/pkg/logging"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/system"
@@ -51,7 +65,15 @@ import (
 
 var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1alpha1
-	v1alpha1.SchemeGroupVersion.WithKind("VerificationPolicy"): &v1alpha1.VerificationPolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("VerificationPolicy"):    &v1alpha1.VerificationPolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("ServiceAccountPolicy"):  &v1alpha1.ServiceAccountPolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("CloudEventSink"):        &v1alpha1.CloudEventSink{},
+	v1alpha1.SchemeGroupVersion.WithKind("NotificationPolicy"):    &v1alpha1.NotificationPolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("ExecutionWindowPolicy"): &v1alpha1.ExecutionWindowPolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("TektonHealth"):          &v1alpha1.TektonHealth{},
+	v1alpha1.SchemeGroupVersion.WithKind("PipelineRunArchive"):    &v1alpha1.PipelineRunArchive{},
+	v1alpha1.SchemeGroupVersion.WithKind("TaskGroup"):             &v1alpha1.TaskGroup{},
+	v1alpha1.SchemeGroupVersion.WithKind("WorkspacePool"):         &v1alpha1.WorkspacePool{},
 	// v1beta1
 	v1beta1.SchemeGroupVersion.WithKind("Pipeline"):    &v1beta1.Pipeline{},
 	v1beta1.SchemeGroupVersion.WithKind("Task"):        &v1beta1.Task{},
@@ -60,10 +82,12 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	v1beta1.SchemeGroupVersion.WithKind("PipelineRun"): &v1beta1.PipelineRun{},
 	v1beta1.SchemeGroupVersion.WithKind("CustomRun"):   &v1beta1.CustomRun{},
 	// v1
-	v1.SchemeGroupVersion.WithKind("Task"):        &v1.Task{},
-	v1.SchemeGroupVersion.WithKind("Pipeline"):    &v1.Pipeline{},
-	v1.SchemeGroupVersion.WithKind("TaskRun"):     &v1.TaskRun{},
-	v1.SchemeGroupVersion.WithKind("PipelineRun"): &v1.PipelineRun{},
+	v1.SchemeGroupVersion.WithKind("Task"):            &v1.Task{},
+	v1.SchemeGroupVersion.WithKind("ClusterTask"):     &v1.ClusterTask{},
+	v1.SchemeGroupVersion.WithKind("Pipeline"):        &v1.Pipeline{},
+	v1.SchemeGroupVersion.WithKind("ClusterPipeline"): &v1.ClusterPipeline{},
+	v1.SchemeGroupVersion.WithKind("TaskRun"):         &v1.TaskRun{},
+	v1.SchemeGroupVersion.WithKind("PipelineRun"):     &v1.PipelineRun{},
 
 	// resolution
 	// v1alpha1
@@ -77,6 +101,7 @@ func newDefaultingAdmissionController(name string) func(context.Context, configm
 		// Decorate contexts with the current state of the config.
 		store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 		store.WatchConfigs(cmw)
+		namespaceDefaults := newNamespaceDefaultsLister(ctx)
 		return defaulting.NewAdmissionController(ctx,
 
 			// Name of the resource webhook, it is the value of the environment variable WEBHOOK_ADMISSION_CONTROLLER_NAME
@@ -91,7 +116,7 @@ func newDefaultingAdmissionController(name string) func(context.Context, configm
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 			func(ctx context.Context) context.Context {
-				return store.ToContext(ctx)
+				return defaultconfig.WithNamespaceDefaults(store.ToContext(ctx), namespaceDefaults)
 			},
 
 			// Whether to disallow unknown fields.
@@ -100,11 +125,51 @@ func newDefaultingAdmissionController(name string) func(context.Context, configm
 	}
 }
 
+// newNamespaceDefaultsLister returns a lister of the configmaps holding the
+// defaults of the namespaces. Unlike the other informers of the webhook, it
+// watches all the namespaces.
+func newNamespaceDefaultsLister(ctx context.Context) corev1listers.ConfigMapLister {
+	factory := informers.NewSharedInformerFactoryWithOptions(kubeclient.Get(ctx), controller.GetResyncPeriod(ctx),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", defaultconfig.NamespaceDefaultsConfigName).String()
+		}))
+	lister := factory.Core().V1().ConfigMaps().Lister()
+	factory.Start(ctx.Done())
+	return lister
+}
+
+// newServiceAccountPolicyLister returns a lister of the ServiceAccountPolicies,
+// to check the service accounts of the PipelineRuns and the TaskRuns being created.
+func newServiceAccountPolicyLister(ctx context.Context) alpha1listers.ServiceAccountPolicyLister {
+	factory := externalversions.NewSharedInformerFactory(pipelineclient.Get(ctx), controller.GetResyncPeriod(ctx))
+	lister := factory.Tekton().V1alpha1().ServiceAccountPolicies().Lister()
+	factory.Start(ctx.Done())
+	// The policies deny by default once there is one in a namespace, so the
+	// webhook must not admit runs before it knows all of them.
+	factory.WaitForCacheSync(ctx.Done())
+	return lister
+}
+
+// newClusterListers returns listers of the ClusterTasks and of the ClusterPipelines,
+// to check the visibility of the ones referenced by the resources being created.
+func newClusterListers(ctx context.Context) (beta1listers.ClusterTaskLister, listers.ClusterPipelineLister) {
+	factory := externalversions.NewSharedInformerFactory(pipelineclient.Get(ctx), controller.GetResyncPeriod(ctx))
+	clusterTasks := factory.Tekton().V1beta1().ClusterTasks().Lister()
+	clusterPipelines := factory.Tekton().V1().ClusterPipelines().Lister()
+	factory.Start(ctx.Done())
+	// The visibility of a ClusterTask or a ClusterPipeline missing from the cache is
+	// unknown, so the webhook must not validate references before it knows all of them.
+	factory.WaitForCacheSync(ctx.Done())
+	return clusterTasks, clusterPipelines
+}
+
 func newValidationAdmissionController(name string) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
 		// Decorate contexts with the current state of the config.
 		store := defaultconfig.NewStore(logging.FromContext(ctx).Named("config-store"))
 		store.WatchConfigs(cmw)
+		serviceAccountPolicies := serviceaccountpolicy.NewValidateFunc(newServiceAccountPolicyLister(ctx))
+		clusterVisibility := clustervisibility.NewValidateFunc(newClusterListers(ctx))
 		return validation.NewAdmissionController(ctx,
 
 			// Name of the validation webhook, it is based on the value of the environment variable WEBHOOK_ADMISSION_CONTROLLER_NAME
@@ -119,7 +184,9 @@ func newValidationAdmissionController(name string) func(context.Context, configm
 
 			// A function that infuses the context passed to Validate/SetDefaults with custom metadata.
 			func(ctx context.Context) context.Context {
-				return store.ToContext(ctx)
+				ctx = validate.WithWarnings(store.ToContext(ctx), validate.CombineWarnings(lint.Warnings, resourcemigration.Warnings))
+				ctx = validate.WithServiceAccountPolicy(ctx, serviceAccountPolicies)
+				return validate.WithClusterVisibility(ctx, clusterVisibility)
 			},
 
 			// Whether to disallow unknown fields.
@@ -141,9 +208,9 @@ func newConfigValidationController(name string) func(context.Context, configmap.
 
 			// The configmaps to validate.
 			configmap.Constructors{
-				logging.ConfigMapName():               logging.NewConfigFromConfigMap,
-				defaultconfig.GetDefaultsConfigName(): defaultconfig.NewDefaultsFromConfigMap,
-				pkgleaderelection.ConfigMapName():     pkgleaderelection.NewConfigFromConfigMap,
+				logging.ConfigMapName():                     logging.NewConfigFromConfigMap,
+				defaultconfig.GetDefaultsConfigName():       defaultconfig.NewDefaultsFromConfigMap,
+				defaultconfig.GetLeaderElectionConfigName(): defaultconfig.NewLeaderElectionFromConfigMap,
 			},
 		)
 	}
@@ -184,6 +251,14 @@ func newConversionController(ctx context.Context, cmw configmap.Watcher) *contro
 					v1GroupVersion:      &v1.Pipeline{},
 				},
 			},
+			v1.Kind("ClusterTask"): {
+				DefinitionName: pipeline.ClusterTaskResource.String(),
+				HubVersion:     v1beta1GroupVersion,
+				Zygotes: map[string]conversion.ConvertibleObject{
+					v1beta1GroupVersion: &v1beta1.ClusterTask{},
+					v1GroupVersion:      &v1.ClusterTask{},
+				},
+			},
 			v1.Kind("TaskRun"): {
 				DefinitionName: pipeline.TaskRunResource.String(),
 				HubVersion:     v1beta1GroupVersion,
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logforwarder
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// The labels of the logs. Their names are valid Loki label names.
+const (
+	// NamespaceLabel is the label holding the namespace of the TaskRun.
+	NamespaceLabel = "namespace"
+	// TaskRunLabel is the label holding the name of the TaskRun.
+	TaskRunLabel = "taskrun"
+	// PipelineRunLabel is the label holding the name of the PipelineRun of the TaskRun, if any.
+	PipelineRunLabel = "pipelinerun"
+	// PipelineTaskLabel is the label holding the name of the pipeline task of the TaskRun, if any.
+	PipelineTaskLabel = "pipeline_task"
+	// PodLabel is the label holding the name of the pod of the TaskRun.
+	PodLabel = "pod"
+	// StepLabel is the label holding the name of the step which logged a line.
+	StepLabel = "step"
+)
+
+// PodNameEnvVar is the environment variable holding the name of the pod, set
+// with the downward API since it is generated when the pod is created.
+const PodNameEnvVar = "TEKTON_POD_NAME"
+
+// Entry is a line logged by a step.
+type Entry struct {
+	Time time.Time
+	Line string
+}
+
+// Sink ships the logs of the steps.
+type Sink interface {
+	// Write ships the lines logged by a step since the previous call.
+	Write(ctx context.Context, labels map[string]string, entries []Entry) error
+	// Close is called once the step has completed, with the path of its log
+	// file, which doesn't exist if the step was skipped.
+	Close(ctx context.Context, labels map[string]string, logFile string) error
+}
+
+// Forwarder tails the log files the entrypoint binary copies the output of the
+// steps to, in their run directories, and ships them to its sink.
+type Forwarder struct {
+	// RunDir is the directory holding the run directories of the steps.
+	RunDir string
+	// LogFile is the name of the log file in the run directory of each step.
+	LogFile string
+	// Steps are the names of the steps, in the order they run.
+	Steps []string
+	// Labels are the labels of the logs of all the steps.
+	Labels map[string]string
+	// Sink is where the logs are shipped.
+	Sink Sink
+	// PollInterval is the time waited for more logs once the log file is read to its end.
+	PollInterval time.Duration
+}
+
+// Forward ships the logs of the steps as they run, until they have all
+// completed. The steps run one after the other, so they are forwarded in turn.
+// Forwarding goes on when the sink fails, so that the following logs are still
+// shipped, and the last error is returned.
+func (f *Forwarder) Forward(ctx context.Context) error {
+	var lastErr error
+	for i, step := range f.Steps {
+		labels := make(map[string]string, len(f.Labels)+1)
+		for k, v := range f.Labels {
+			labels[k] = v
+		}
+		labels[StepLabel] = step
+		if err := f.forwardStep(ctx, filepath.Join(f.RunDir, strconv.Itoa(i)), labels); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			lastErr = fmt.Errorf("error forwarding the logs of step %q: %w", step, err)
+		}
+	}
+	return lastErr
+}
+
+func (f *Forwarder) forwardStep(ctx context.Context, stepDir string, labels map[string]string) error {
+	logFile := filepath.Join(stepDir, f.LogFile)
+	var offset int64
+	var partial []byte
+	var lastErr error
+	for {
+		// Check whether the step has completed before reading, so that all the
+		// logs are read once it has.
+		done := stepDone(stepDir)
+		data, err := readFrom(logFile, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(data))
+		var entries []Entry
+		entries, partial = splitLines(append(partial, data...))
+		if done && len(partial) > 0 {
+			entries = append(entries, Entry{Time: time.Now(), Line: string(partial)})
+			partial = nil
+		}
+		if len(entries) > 0 {
+			if err := f.Sink.Write(ctx, labels, entries); err != nil {
+				lastErr = err
+			}
+		}
+		if done {
+			if err := f.Sink.Close(ctx, labels, logFile); err != nil {
+				lastErr = err
+			}
+			return lastErr
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(f.PollInterval):
+		}
+	}
+}
+
+// stepDone returns true if the entrypoint binary has written the post file of
+// the step, with or without error.
+func stepDone(stepDir string) bool {
+	for _, name := range []string{"out", "out.err"} {
+		if _, err := os.Stat(filepath.Join(stepDir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// readFrom reads the file from offset to its end, nothing if it doesn't exist yet.
+func readFrom(path string, offset int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(file)
+}
+
+// splitLines returns the complete lines of data and what follows the last one.
+func splitLines(data []byte) ([]Entry, []byte) {
+	var entries []Entry
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			return entries, data
+		}
+		entries = append(entries, Entry{Time: time.Now(), Line: string(bytes.TrimSuffix(data[:i], []byte("\r")))})
+		data = data[i+1:]
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logforwarder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// fakeSink records the lines and the closed log files by step.
+type fakeSink struct {
+	mu     sync.Mutex
+	lines  map[string][]string
+	closed map[string]string
+	err    error
+}
+
+func (s *fakeSink) Write(_ context.Context, labels map[string]string, entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range entries {
+		s.lines[labels[StepLabel]] = append(s.lines[labels[StepLabel]], e.Line)
+	}
+	return s.err
+}
+
+func (s *fakeSink) Close(_ context.Context, labels map[string]string, logFile string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed[labels[StepLabel]] = filepath.Base(filepath.Dir(logFile))
+	return nil
+}
+
+func appendFile(t *testing.T, path, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestForward(t *testing.T) {
+	runDir := t.TempDir()
+	sink := &fakeSink{lines: map[string][]string{}, closed: map[string]string{}}
+	f := &Forwarder{
+		RunDir:       runDir,
+		LogFile:      "log",
+		Steps:        []string{"build", "test", "publish"},
+		Labels:       map[string]string{TaskRunLabel: "taskrun"},
+		Sink:         sink,
+		PollInterval: 10 * time.Millisecond,
+	}
+	done := make(chan error)
+	go func() { done <- f.Forward(context.Background()) }()
+
+	// The first step logs a partial line, completes it, then succeeds.
+	appendFile(t, filepath.Join(runDir, "0", "log"), "compiling\r\nlinking")
+	time.Sleep(50 * time.Millisecond)
+	appendFile(t, filepath.Join(runDir, "0", "log"), " done\n")
+	appendFile(t, filepath.Join(runDir, "0", "out"), "")
+	// The second step fails without a trailing newline, so the last one is skipped.
+	appendFile(t, filepath.Join(runDir, "1", "log"), "1 test failed")
+	appendFile(t, filepath.Join(runDir, "1", "out.err"), "")
+	appendFile(t, filepath.Join(runDir, "2", "out.err"), "")
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Forward: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the logs to be forwarded")
+	}
+	wantLines := map[string][]string{
+		"build": {"compiling", "linking done"},
+		"test":  {"1 test failed"},
+	}
+	if d := cmp.Diff(wantLines, sink.lines); d != "" {
+		t.Errorf("lines %s", diff.PrintWantGot(d))
+	}
+	wantClosed := map[string]string{"build": "0", "test": "1", "publish": "2"}
+	if d := cmp.Diff(wantClosed, sink.closed); d != "" {
+		t.Errorf("closed log files %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestForward_SinkError(t *testing.T) {
+	runDir := t.TempDir()
+	appendFile(t, filepath.Join(runDir, "0", "log"), "hello\n")
+	appendFile(t, filepath.Join(runDir, "0", "out"), "")
+	sink := &fakeSink{lines: map[string][]string{}, closed: map[string]string{}, err: errors.New("unavailable")}
+	f := &Forwarder{
+		RunDir:       runDir,
+		LogFile:      "log",
+		Steps:        []string{"build"},
+		Sink:         sink,
+		PollInterval: 10 * time.Millisecond,
+	}
+	if err := f.Forward(context.Background()); err == nil {
+		t.Error("expected an error forwarding the logs to a failing sink")
+	}
+	if _, ok := sink.closed["build"]; !ok {
+		t.Error("expected the step to be closed despite the sink error")
+	}
+}
@@ -0,0 +1,379 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logforwarder
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+const (
+	gcsUploadEndpoint = "https://storage.googleapis.com/upload/storage/v1"
+	gcsTokenEndpoint  = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// The limits of the PutLogEvents requests of CloudWatch Logs, in which each event
+	// counts for the size of its message plus cloudWatchEventOverhead bytes.
+	cloudWatchMaxBatchEvents = 10000
+	cloudWatchMaxBatchBytes  = 1048576
+	cloudWatchMaxEventBytes  = 262144
+	cloudWatchEventOverhead  = 26
+)
+
+// NewSink returns the Sink of the given kind, shipping the logs to url.
+func NewSink(sink, url string, client *http.Client) (Sink, error) {
+	switch sink {
+	case config.LogForwardingSinkLoki:
+		return &LokiSink{URL: url, Client: client}, nil
+	case config.LogForwardingSinkHTTP:
+		return &HTTPSink{URL: url, Client: client}, nil
+	case config.LogForwardingSinkGCS:
+		return &GCSSink{URL: url, Client: client, UploadEndpoint: gcsUploadEndpoint, TokenEndpoint: gcsTokenEndpoint}, nil
+	case config.LogForwardingSinkCloudWatch:
+		return newCloudWatchSink(url, client)
+	default:
+		return nil, fmt.Errorf("unknown log forwarding sink %q", sink)
+	}
+}
+
+// LokiSink pushes the logs to the push API of Loki, in a stream with the labels of the step.
+type LokiSink struct {
+	URL    string
+	Client *http.Client
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// Write pushes the entries to Loki.
+func (s *LokiSink) Write(ctx context.Context, labels map[string]string, entries []Entry) error {
+	stream := lokiStream{Stream: labels}
+	for _, e := range entries {
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(e.Time.UnixNano(), 10), e.Line})
+	}
+	body, err := json.Marshal(lokiPush{Streams: []lokiStream{stream}})
+	if err != nil {
+		return err
+	}
+	return post(ctx, s.Client, s.URL, "application/json", body)
+}
+
+// Close does nothing, the logs are pushed as they are written.
+func (s *LokiSink) Close(context.Context, map[string]string, string) error {
+	return nil
+}
+
+// HTTPSink posts the logs to an http(s) endpoint as newline delimited JSON
+// objects holding the time, the line and the labels of each entry.
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+}
+
+type httpEntry struct {
+	Time   string            `json:"time"`
+	Line   string            `json:"line"`
+	Labels map[string]string `json:"labels"`
+}
+
+// Write posts the entries to the endpoint.
+func (s *HTTPSink) Write(ctx context.Context, labels map[string]string, entries []Entry) error {
+	var body bytes.Buffer
+	enc := json.NewEncoder(&body)
+	for _, e := range entries {
+		if err := enc.Encode(httpEntry{Time: e.Time.UTC().Format("2006-01-02T15:04:05.000000000Z"), Line: e.Line, Labels: labels}); err != nil {
+			return err
+		}
+	}
+	return post(ctx, s.Client, s.URL, "application/x-ndjson", body.Bytes())
+}
+
+// Close does nothing, the logs are posted as they are written.
+func (s *HTTPSink) Close(context.Context, map[string]string, string) error {
+	return nil
+}
+
+// GCSSink uploads the log file of each step to a Google Cloud Storage bucket
+// once the step has completed, at gs://<bucket>/<prefix>/<namespace>/<taskrun>/<pod>/<step>.log,
+// with the labels as the metadata of the object. It authenticates with the
+// token of the service account of the pod, from the metadata server.
+type GCSSink struct {
+	// URL is the gs://<bucket>/<prefix> location of the logs.
+	URL            string
+	Client         *http.Client
+	UploadEndpoint string
+	TokenEndpoint  string
+}
+
+// Write does nothing, the log file is uploaded once the step has completed.
+func (s *GCSSink) Write(context.Context, map[string]string, []Entry) error {
+	return nil
+}
+
+// Close uploads the log file of the step.
+func (s *GCSSink) Close(ctx context.Context, labels map[string]string, logFile string) error {
+	f, err := os.Open(logFile)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	location, err := url.Parse(s.URL)
+	if err != nil {
+		return err
+	}
+	name := path.Join(strings.TrimPrefix(location.Path, "/"), labels[NamespaceLabel],
+		labels[TaskRunLabel], labels[PodLabel], labels[StepLabel]+".log")
+	token, err := s.token(ctx)
+	if err != nil {
+		return err
+	}
+	u := fmt.Sprintf("%s/b/%s/o?uploadType=media&name=%s", s.UploadEndpoint, url.PathEscape(location.Host), url.QueryEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, f)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	for k, v := range labels {
+		req.Header.Set("x-goog-meta-"+k, v)
+	}
+	return do(s.Client, req)
+}
+
+// token returns an access token of the service account of the pod.
+func (s *GCSSink) token(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.TokenEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error getting an access token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error getting an access token: %s", resp.Status)
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("error decoding the access token: %w", err)
+	}
+	return token.AccessToken, nil
+}
+
+// CloudWatchSink puts the logs to a log group of CloudWatch Logs, in a log stream per
+// step named <namespace>/<taskrun>/<pod>/<step>, which is created by its first write.
+// It authenticates with the default credentials chain of the AWS SDK, e.g. with the
+// web identity token of the service account of the pod with IAM roles for service
+// accounts.
+type CloudWatchSink struct {
+	// Region is the AWS region of the log group.
+	Region string
+	// LogGroup is the name of the log group, which must exist.
+	LogGroup string
+	Client   *http.Client
+	// Endpoint is the endpoint of the CloudWatch Logs API in the region.
+	Endpoint string
+	// Credentials sign the requests, they are loaded from the default chain if nil.
+	Credentials aws.CredentialsProvider
+
+	// streams are the log streams which are known to exist.
+	streams map[string]bool
+}
+
+// newCloudWatchSink returns the sink of the cloudwatch://<region>/<log group> location.
+func newCloudWatchSink(location string, client *http.Client) (*CloudWatchSink, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := fmt.Sprintf("https://logs.%s.amazonaws.com", u.Host)
+	if strings.HasPrefix(u.Host, "cn-") {
+		endpoint += ".cn"
+	}
+	return &CloudWatchSink{Region: u.Host, LogGroup: strings.TrimPrefix(u.Path, "/"), Client: client, Endpoint: endpoint}, nil
+}
+
+type cloudWatchEvent struct {
+	Timestamp int64  `json:"timestamp"`
+	Message   string `json:"message"`
+}
+
+// Write puts the entries to the log stream of the step, in as many requests as the
+// limits of CloudWatch Logs require. The empty lines are dropped, since CloudWatch
+// Logs rejects empty messages, and the lines too long to be an event are truncated.
+func (s *CloudWatchSink) Write(ctx context.Context, labels map[string]string, entries []Entry) error {
+	stream := path.Join(labels[NamespaceLabel], labels[TaskRunLabel], labels[PodLabel], labels[StepLabel])
+	if !s.streams[stream] {
+		err := s.call(ctx, "CreateLogStream", map[string]string{"logGroupName": s.LogGroup, "logStreamName": stream})
+		var cwErr *cloudWatchError
+		if err != nil && !(errors.As(err, &cwErr) && cwErr.is("ResourceAlreadyExistsException")) {
+			return err
+		}
+		if s.streams == nil {
+			s.streams = map[string]bool{}
+		}
+		s.streams[stream] = true
+	}
+
+	var events []cloudWatchEvent
+	size := 0
+	put := func() error {
+		if len(events) == 0 {
+			return nil
+		}
+		err := s.call(ctx, "PutLogEvents", map[string]interface{}{"logGroupName": s.LogGroup, "logStreamName": stream, "logEvents": events})
+		events, size = nil, 0
+		return err
+	}
+	for _, e := range entries {
+		line := e.Line
+		if line == "" {
+			continue
+		}
+		if len(line) > cloudWatchMaxEventBytes-cloudWatchEventOverhead {
+			line = strings.ToValidUTF8(line[:cloudWatchMaxEventBytes-cloudWatchEventOverhead], "")
+		}
+		if len(events) == cloudWatchMaxBatchEvents || size+len(line)+cloudWatchEventOverhead > cloudWatchMaxBatchBytes {
+			if err := put(); err != nil {
+				return err
+			}
+		}
+		events = append(events, cloudWatchEvent{Timestamp: e.Time.UnixMilli(), Message: line})
+		size += len(line) + cloudWatchEventOverhead
+	}
+	return put()
+}
+
+// Close does nothing, the logs are put as they are written.
+func (s *CloudWatchSink) Close(context.Context, map[string]string, string) error {
+	return nil
+}
+
+// cloudWatchError is an error returned by the CloudWatch Logs API.
+type cloudWatchError struct {
+	Type    string `json:"__type"`
+	Message string `json:"message"`
+	status  string
+}
+
+func (e *cloudWatchError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.status, e.Type, e.Message)
+}
+
+// is returns true if the error is of the type, which may be prefixed by its namespace.
+func (e *cloudWatchError) is(errorType string) bool {
+	return e.Type == errorType || strings.HasSuffix(e.Type, "#"+errorType)
+}
+
+// call calls the action of the CloudWatch Logs API with the input, in a request signed
+// with Signature Version 4.
+func (s *CloudWatchSink) call(ctx context.Context, action string, input interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "Logs_20140328."+action)
+	if s.Credentials == nil {
+		cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(s.Region), awsconfig.WithHTTPClient(s.Client))
+		if err != nil {
+			return fmt.Errorf("error loading the AWS credentials: %w", err)
+		}
+		s.Credentials = cfg.Credentials
+	}
+	if s.Credentials == nil {
+		return errors.New("no AWS credentials were found")
+	}
+	credentials, err := s.Credentials.Retrieve(ctx)
+	if err != nil {
+		return fmt.Errorf("error retrieving the AWS credentials: %w", err)
+	}
+	hash := sha256.Sum256(body)
+	if err := v4.NewSigner().SignHTTP(ctx, credentials, req, hex.EncodeToString(hash[:]), "logs", s.Region, time.Now()); err != nil {
+		return err
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	cwErr := &cloudWatchError{status: resp.Status}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1024)).Decode(cwErr); err != nil {
+		cwErr.Message = err.Error()
+	}
+	return fmt.Errorf("%s %s: %w", action, s.LogGroup, cwErr)
+}
+
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return do(client, req)
+}
+
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s %s: %s %s", req.Method, req.URL.Redacted(), resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
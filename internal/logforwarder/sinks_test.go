@@ -0,0 +1,301 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logforwarder
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+var (
+	testLabels = map[string]string{
+		NamespaceLabel: "default",
+		TaskRunLabel:   "taskrun",
+		PodLabel:       "taskrun-pod",
+		StepLabel:      "build",
+	}
+	testEntries = []Entry{
+		{Time: time.Unix(1, 0), Line: "compiling"},
+		{Time: time.Unix(2, 0), Line: "done"},
+	}
+)
+
+// recordingServer records the requests it receives.
+type recordingServer struct {
+	*httptest.Server
+	requests []*http.Request
+	bodies   []string
+}
+
+func newRecordingServer(t *testing.T, status int) *recordingServer {
+	t.Helper()
+	s := &recordingServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("error reading the request body: %v", err)
+		}
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, string(b))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestLokiSink(t *testing.T) {
+	server := newRecordingServer(t, http.StatusNoContent)
+	sink, err := NewSink("loki", server.URL+"/loki/api/v1/push", server.Client())
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Write(context.Background(), testLabels, testEntries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := `{"streams":[{"stream":{"namespace":"default","pod":"taskrun-pod","step":"build","taskrun":"taskrun"},"values":[["1000000000","compiling"],["2000000000","done"]]}]}`
+	if d := cmp.Diff([]string{want}, server.bodies); d != "" {
+		t.Errorf("pushed streams %s", diff.PrintWantGot(d))
+	}
+	if got := server.requests[0].URL.Path; got != "/loki/api/v1/push" {
+		t.Errorf("got path %q, want %q", got, "/loki/api/v1/push")
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	server := newRecordingServer(t, http.StatusOK)
+	sink, err := NewSink("http", server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("NewSink: %v", err)
+	}
+	if err := sink.Write(context.Background(), map[string]string{StepLabel: "build"}, testEntries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	want := `{"time":"1970-01-01T00:00:01.000000000Z","line":"compiling","labels":{"step":"build"}}
+{"time":"1970-01-01T00:00:02.000000000Z","line":"done","labels":{"step":"build"}}
+`
+	if d := cmp.Diff([]string{want}, server.bodies); d != "" {
+		t.Errorf("posted entries %s", diff.PrintWantGot(d))
+	}
+	if got := server.requests[0].Header.Get("Content-Type"); got != "application/x-ndjson" {
+		t.Errorf("got content type %q", got)
+	}
+}
+
+func TestHTTPSink_Error(t *testing.T) {
+	server := newRecordingServer(t, http.StatusServiceUnavailable)
+	sink := &HTTPSink{URL: server.URL, Client: server.Client()}
+	if err := sink.Write(context.Background(), testLabels, testEntries); err == nil {
+		t.Error("expected an error posting to an unavailable endpoint")
+	}
+}
+
+func TestGCSSink(t *testing.T) {
+	server := newRecordingServer(t, http.StatusOK)
+	token := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"access_token":"secret","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer token.Close()
+	sink := &GCSSink{URL: "gs://tekton-logs/ci", Client: server.Client(), UploadEndpoint: server.URL, TokenEndpoint: token.URL}
+
+	logFile := filepath.Join(t.TempDir(), "log")
+	if err := os.WriteFile(logFile, []byte("compiling\ndone\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Write(context.Background(), testLabels, testEntries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := sink.Close(context.Background(), testLabels, logFile); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := sink.Close(context.Background(), testLabels, filepath.Join(t.TempDir(), "skipped")); err != nil {
+		t.Fatalf("Close of a skipped step: %v", err)
+	}
+
+	if len(server.requests) != 1 {
+		t.Fatalf("expected the log file to be uploaded once but got %d requests", len(server.requests))
+	}
+	r := server.requests[0]
+	if got, want := r.URL.Path, "/b/tekton-logs/o"; got != want {
+		t.Errorf("got path %q, want %q", got, want)
+	}
+	if got, want := r.URL.Query().Get("name"), "ci/default/taskrun/taskrun-pod/build.log"; got != want {
+		t.Errorf("got object name %q, want %q", got, want)
+	}
+	if got, want := r.Header.Get("Authorization"), "Bearer secret"; got != want {
+		t.Errorf("got authorization %q, want %q", got, want)
+	}
+	if got, want := r.Header.Get("x-goog-meta-step"), "build"; got != want {
+		t.Errorf("got step metadata %q, want %q", got, want)
+	}
+	if got, want := server.bodies[0], "compiling\ndone\n"; got != want {
+		t.Errorf("got object %q, want %q", got, want)
+	}
+}
+
+func newCloudWatchTestSink(server *recordingServer) *CloudWatchSink {
+	return &CloudWatchSink{
+		Region:      "us-east-1",
+		LogGroup:    "/tekton/steps",
+		Client:      server.Client(),
+		Endpoint:    server.URL,
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+	}
+}
+
+func TestCloudWatchSink(t *testing.T) {
+	server := newRecordingServer(t, http.StatusOK)
+	sink := newCloudWatchTestSink(server)
+	entries := append([]Entry{{Time: time.Unix(0, 0), Line: ""}}, testEntries...)
+	if err := sink.Write(context.Background(), testLabels, entries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := sink.Write(context.Background(), testLabels, testEntries[1:]); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var targets []string
+	for _, r := range server.requests {
+		targets = append(targets, r.Header.Get("X-Amz-Target"))
+		if got := r.Header.Get("Content-Type"); got != "application/x-amz-json-1.1" {
+			t.Errorf("got content type %q", got)
+		}
+		if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(got, "/us-east-1/logs/aws4_request") {
+			t.Errorf("got authorization %q", got)
+		}
+	}
+	wantTargets := []string{"Logs_20140328.CreateLogStream", "Logs_20140328.PutLogEvents", "Logs_20140328.PutLogEvents"}
+	if d := cmp.Diff(wantTargets, targets); d != "" {
+		t.Errorf("actions %s", diff.PrintWantGot(d))
+	}
+	wantBodies := []string{
+		`{"logGroupName":"/tekton/steps","logStreamName":"default/taskrun/taskrun-pod/build"}`,
+		`{"logEvents":[{"timestamp":1000,"message":"compiling"},{"timestamp":2000,"message":"done"}],"logGroupName":"/tekton/steps","logStreamName":"default/taskrun/taskrun-pod/build"}`,
+		`{"logEvents":[{"timestamp":2000,"message":"done"}],"logGroupName":"/tekton/steps","logStreamName":"default/taskrun/taskrun-pod/build"}`,
+	}
+	if d := cmp.Diff(wantBodies, server.bodies); d != "" {
+		t.Errorf("request bodies %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCloudWatchSink_Batches(t *testing.T) {
+	server := newRecordingServer(t, http.StatusOK)
+	sink := newCloudWatchTestSink(server)
+	var entries []Entry
+	for i := 0; i < cloudWatchMaxBatchEvents+1; i++ {
+		entries = append(entries, Entry{Time: time.Unix(1, 0), Line: "x"})
+	}
+	entries = append(entries, Entry{Time: time.Unix(1, 0), Line: strings.Repeat("y", 2*cloudWatchMaxEventBytes)})
+	if err := sink.Write(context.Background(), testLabels, entries); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	var sizes []int
+	for _, body := range server.bodies[1:] {
+		var input struct {
+			LogEvents []cloudWatchEvent `json:"logEvents"`
+		}
+		if err := json.Unmarshal([]byte(body), &input); err != nil {
+			t.Fatal(err)
+		}
+		sizes = append(sizes, len(input.LogEvents))
+		if last := input.LogEvents[len(input.LogEvents)-1]; len(last.Message) > cloudWatchMaxEventBytes-cloudWatchEventOverhead {
+			t.Errorf("got an event of %d bytes", len(last.Message))
+		}
+	}
+	if d := cmp.Diff([]int{cloudWatchMaxBatchEvents, 2}, sizes); d != "" {
+		t.Errorf("batch sizes %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCloudWatchSink_ExistingStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") == "Logs_20140328.CreateLogStream" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"com.amazonaws.logs#ResourceAlreadyExistsException","message":"The specified log stream already exists"}`))
+		}
+	}))
+	defer server.Close()
+	sink := newCloudWatchTestSink(&recordingServer{Server: server})
+	if err := sink.Write(context.Background(), testLabels, testEntries); err != nil {
+		t.Errorf("Write to an existing stream: %v", err)
+	}
+}
+
+func TestCloudWatchSink_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"The specified log group does not exist."}`))
+	}))
+	defer server.Close()
+	sink := newCloudWatchTestSink(&recordingServer{Server: server})
+	err := sink.Write(context.Background(), testLabels, testEntries)
+	if err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("expected an error writing to a missing log group but got %v", err)
+	}
+}
+
+func TestNewSink_CloudWatch(t *testing.T) {
+	for _, tc := range []struct {
+		url, region, logGroup, endpoint string
+	}{{
+		url:      "cloudwatch://us-east-1//tekton/steps",
+		region:   "us-east-1",
+		logGroup: "/tekton/steps",
+		endpoint: "https://logs.us-east-1.amazonaws.com",
+	}, {
+		url:      "cloudwatch://cn-north-1/tekton",
+		region:   "cn-north-1",
+		logGroup: "tekton",
+		endpoint: "https://logs.cn-north-1.amazonaws.com.cn",
+	}} {
+		t.Run(tc.url, func(t *testing.T) {
+			sink, err := NewSink("cloudwatch", tc.url, http.DefaultClient)
+			if err != nil {
+				t.Fatalf("NewSink: %v", err)
+			}
+			cw, ok := sink.(*CloudWatchSink)
+			if !ok {
+				t.Fatalf("got a %T sink", sink)
+			}
+			if cw.Region != tc.region || cw.LogGroup != tc.logGroup || cw.Endpoint != tc.endpoint {
+				t.Errorf("got region %q, log group %q and endpoint %q", cw.Region, cw.LogGroup, cw.Endpoint)
+			}
+		})
+	}
+}
+
+func TestNewSink_Unknown(t *testing.T) {
+	if _, err := NewSink("splunk", "https://splunk.example.com:8088", http.DefaultClient); err == nil {
+		t.Error("expected an error creating an unknown sink")
+	}
+}
@@ -27,6 +27,8 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/yaml"
 )
@@ -46,6 +48,14 @@ const (
 	DefaultMaxMatrixCombinationsCount = 256
 	// DefaultResolverTypeValue is used when no default resolver type is specified
 	DefaultResolverTypeValue = ""
+	// CloudEventsFormatFull is the value used for sending CloudEvents whose
+	// payload is the whole TaskRun, PipelineRun or CustomRun.
+	CloudEventsFormatFull = "full"
+	// CloudEventsFormatSlim is the value used for sending CloudEvents whose
+	// payload follows the versioned Tekton event data schema.
+	CloudEventsFormatSlim = "slim"
+	// DefaultCloudEventsFormatValue is the default format of CloudEvents payloads.
+	DefaultCloudEventsFormatValue = CloudEventsFormatFull
 
 	defaultTimeoutMinutesKey             = "default-timeout-minutes"
 	defaultServiceAccountKey             = "default-service-account"
@@ -57,6 +67,9 @@ const (
 	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
 	defaultForbiddenEnv                  = "default-forbidden-env"
 	defaultResolverTypeKey               = "default-resolver-type"
+	defaultCloudEventsFormatKey          = "default-cloud-events-format"
+	defaultMaxScratchSizeKey             = "default-max-scratch-size"
+	defaultBuildHeavyStepMemoryKey       = "default-build-heavy-step-memory"
 )
 
 // DefaultConfig holds all the default configurations for the config.
@@ -75,6 +88,13 @@ type Defaults struct {
 	DefaultMaxMatrixCombinationsCount int
 	DefaultForbiddenEnv               []string
 	DefaultResolverType               string
+	DefaultCloudEventsFormat          string
+	// DefaultMaxScratchSize is the maximum total size of the scratch volumes
+	// of the Steps of a TaskRun. It is unbounded if nil.
+	DefaultMaxScratchSize *resource.Quantity
+	// DefaultBuildHeavyStepMemory is the memory requested and limited for the Steps
+	// with the buildHeavy memory profile which set neither. They are left as is if nil.
+	DefaultBuildHeavyStepMemory *resource.Quantity
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -105,6 +125,9 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
 		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
 		other.DefaultResolverType == cfg.DefaultResolverType &&
+		other.DefaultCloudEventsFormat == cfg.DefaultCloudEventsFormat &&
+		equality.Semantic.DeepEqual(other.DefaultMaxScratchSize, cfg.DefaultMaxScratchSize) &&
+		equality.Semantic.DeepEqual(other.DefaultBuildHeavyStepMemory, cfg.DefaultBuildHeavyStepMemory) &&
 		reflect.DeepEqual(other.DefaultForbiddenEnv, cfg.DefaultForbiddenEnv)
 }
 
@@ -117,6 +140,7 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
 		DefaultResolverType:               DefaultResolverTypeValue,
+		DefaultCloudEventsFormat:          DefaultCloudEventsFormatValue,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -179,6 +203,34 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultResolverType = defaultResolverType
 	}
 
+	if defaultCloudEventsFormat, ok := cfgMap[defaultCloudEventsFormatKey]; ok {
+		switch defaultCloudEventsFormat {
+		case CloudEventsFormatFull, CloudEventsFormatSlim:
+			tc.DefaultCloudEventsFormat = defaultCloudEventsFormat
+		default:
+			return nil, fmt.Errorf("invalid value for %q: %q, expected %q or %q", defaultCloudEventsFormatKey, defaultCloudEventsFormat, CloudEventsFormatFull, CloudEventsFormatSlim)
+		}
+	}
+
+	if defaultMaxScratchSize, ok := cfgMap[defaultMaxScratchSizeKey]; ok && defaultMaxScratchSize != "" {
+		size, err := resource.ParseQuantity(defaultMaxScratchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultMaxScratchSizeKey, err)
+		}
+		tc.DefaultMaxScratchSize = &size
+	}
+
+	if defaultBuildHeavyStepMemory, ok := cfgMap[defaultBuildHeavyStepMemoryKey]; ok && defaultBuildHeavyStepMemory != "" {
+		memory, err := resource.ParseQuantity(defaultBuildHeavyStepMemory)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing default config %q: %w", defaultBuildHeavyStepMemoryKey, err)
+		}
+		if memory.Sign() <= 0 {
+			return nil, fmt.Errorf("default config %q must be greater than zero but it is %q", defaultBuildHeavyStepMemoryKey, defaultBuildHeavyStepMemory)
+		}
+		tc.DefaultBuildHeavyStepMemory = &memory
+	}
+
 	return &tc, nil
 }
 
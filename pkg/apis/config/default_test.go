@@ -24,9 +24,12 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewDefaultsFromConfigMap(t *testing.T) {
+	maxScratchSize := resource.MustParse("10Gi")
+	buildHeavyStepMemory := resource.MustParse("4Gi")
 	type testCase struct {
 		expectedConfig *config.Defaults
 		expectedError  bool
@@ -40,6 +43,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        "something-else",
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.CloudEventsFormatSlim,
 				DefaultResolverType:               "git",
 			},
 			fileName: config.GetDefaultsConfigName(),
@@ -60,6 +64,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 					},
 				},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -79,6 +84,7 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultPodTemplate:                &pod.Template{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 			},
 		},
 		{
@@ -90,17 +96,23 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultAAPodTemplate:              &pod.AffinityAssistantTemplate{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 			},
 		},
 		{
 			expectedError: true,
 			fileName:      "config-defaults-matrix-err",
 		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-cloud-events-format-err",
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-matrix",
 			expectedConfig: &config.Defaults{
 				DefaultMaxMatrixCombinationsCount: 1024,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -113,10 +125,43 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultTimeoutMinutes:             50,
 				DefaultServiceAccount:             "tekton",
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 				DefaultManagedByLabelValue:        "tekton-pipelines",
 				DefaultForbiddenEnv:               []string{"TEKTON_POWER_MODE", "TEST_ENV", "TEST_TEKTON"},
 			},
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-scratch-size",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxScratchSize:             &maxScratchSize,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-scratch-size-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-build-heavy-step-memory",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultBuildHeavyStepMemory:       &buildHeavyStepMemory,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-build-heavy-step-memory-err",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -137,11 +182,13 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultManagedByLabelValue:        "tekton-pipelines",
 		DefaultServiceAccount:             "default",
 		DefaultMaxMatrixCombinationsCount: 256,
+		DefaultCloudEventsFormat:          config.DefaultCloudEventsFormatValue,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
 
 func TestEquals(t *testing.T) {
+	oneGi, twoGi, oneGiInMi := resource.MustParse("1Gi"), resource.MustParse("2Gi"), resource.MustParse("1024Mi")
 	testCases := []struct {
 		name     string
 		left     *config.Defaults
@@ -286,6 +333,46 @@ func TestEquals(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "different max scratch size",
+			left: &config.Defaults{
+				DefaultMaxScratchSize: &oneGi,
+			},
+			right: &config.Defaults{
+				DefaultMaxScratchSize: &twoGi,
+			},
+			expected: false,
+		},
+		{
+			name: "same max scratch size",
+			left: &config.Defaults{
+				DefaultMaxScratchSize: &oneGi,
+			},
+			right: &config.Defaults{
+				DefaultMaxScratchSize: &oneGiInMi,
+			},
+			expected: true,
+		},
+		{
+			name: "different build heavy step memory",
+			left: &config.Defaults{
+				DefaultBuildHeavyStepMemory: &oneGi,
+			},
+			right: &config.Defaults{
+				DefaultBuildHeavyStepMemory: &twoGi,
+			},
+			expected: false,
+		},
+		{
+			name: "different default cloud events format",
+			left: &config.Defaults{
+				DefaultCloudEventsFormat: config.CloudEventsFormatFull,
+			},
+			right: &config.Defaults{
+				DefaultCloudEventsFormat: config.CloudEventsFormatSlim,
+			},
+			expected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// EventsConfigMapName is the name of the events configmap
+	EventsConfigMapName = "config-events"
+
+	eventsKafkaBrokersKey = "kafka-brokers"
+	eventsKafkaTopicKey   = "kafka-topic"
+	eventsNATSURLKey      = "nats-url"
+	eventsNATSSubjectKey  = "nats-subject"
+
+	eventsFilterEventTypesKey    = "filter-event-types"
+	eventsFilterNamespacesKey    = "filter-namespaces"
+	eventsFilterLabelSelectorKey = "filter-label-selector"
+	eventsRedactParamsKey        = "redact-params"
+	eventsDropResultsKey         = "drop-results"
+
+	eventsSinkAudiencesKey = "sink-audiences"
+)
+
+// DefaultEvents holds the default events configuration, which publishes no CloudEvents
+// over Kafka or NATS, and emits all the events without redacting them.
+var DefaultEvents, _ = NewEventsFromMap(map[string]string{})
+
+// Events holds the configuration of the transports the CloudEvents are published
+// with, in addition to the http sinks.
+// +k8s:deepcopy-gen=true
+type Events struct {
+	// KafkaBrokers are the addresses of the Kafka brokers the CloudEvents are published to.
+	KafkaBrokers []string
+	// KafkaTopic is the Kafka topic the CloudEvents are published to, empty if they aren't.
+	KafkaTopic string
+	// NATSURL is the URL of the NATS servers the CloudEvents are published to.
+	NATSURL string
+	// NATSSubject is the NATS subject the CloudEvents are published to, empty if they aren't.
+	NATSSubject string
+
+	// FilterEventTypes are the types of the CloudEvents which are emitted, all of
+	// them if empty. The Kubernetes events match the type of the CloudEvent emitted
+	// for the same change of the condition of the run.
+	FilterEventTypes []string
+	// FilterNamespaces are the namespaces of the runs the events are emitted for,
+	// all of them if empty.
+	FilterNamespaces []string
+	// FilterLabelSelector selects the runs the events are emitted for by their
+	// labels, all of them if empty.
+	FilterLabelSelector string
+	// RedactParams is a regular expression matching the names of the params which
+	// are stripped from the runs sent in the CloudEvents, none of them if empty.
+	RedactParams string
+	// DropResults strips the results from the runs sent in the CloudEvents.
+	DropResults bool
+
+	// SinkAudiences are the audiences the OIDC tokens of the CloudEventSinks may be
+	// requested for, in addition to the URIs of the sinks.
+	SinkAudiences []string
+}
+
+// KafkaEnabled returns true if the CloudEvents are published to a Kafka topic.
+func (e *Events) KafkaEnabled() bool {
+	return e != nil && e.KafkaTopic != ""
+}
+
+// NATSEnabled returns true if the CloudEvents are published to a NATS subject.
+func (e *Events) NATSEnabled() bool {
+	return e != nil && e.NATSSubject != ""
+}
+
+// Emits returns true if the events of the given type are emitted for a run in the
+// namespace with the labels.
+func (e *Events) Emits(eventType, namespace string, runLabels map[string]string) bool {
+	if e == nil {
+		return true
+	}
+	if len(e.FilterEventTypes) > 0 && !contains(e.FilterEventTypes, eventType) {
+		return false
+	}
+	if len(e.FilterNamespaces) > 0 && !contains(e.FilterNamespaces, namespace) {
+		return false
+	}
+	if e.FilterLabelSelector != "" {
+		// The selector is validated when the config map is loaded.
+		selector, err := labels.Parse(e.FilterLabelSelector)
+		if err != nil || !selector.Matches(labels.Set(runLabels)) {
+			return false
+		}
+	}
+	return true
+}
+
+// RedactsParam returns true if the param with the given name is stripped from the
+// runs sent in the CloudEvents.
+func (e *Events) RedactsParam(name string) bool {
+	if e == nil || e.RedactParams == "" {
+		return false
+	}
+	// The expression is validated when the config map is loaded.
+	re, err := regexp.Compile(e.RedactParams)
+	return err == nil && re.MatchString(name)
+}
+
+// AllowsSinkAudience returns true if an OIDC token may be requested for the audience
+// to authenticate to the sink with the URI.
+func (e *Events) AllowsSinkAudience(audience, uri string) bool {
+	return audience == uri || (e != nil && contains(e.SinkAudiences, audience))
+}
+
+// Redacts returns true if anything is stripped from the runs sent in the CloudEvents.
+func (e *Events) Redacts() bool {
+	return e != nil && (e.RedactParams != "" || e.DropResults)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList returns the non-empty values of a comma separated list.
+func splitList(list string) []string {
+	var values []string
+	for _, v := range strings.Split(list, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// NewEventsFromMap returns an Events given a map corresponding to a ConfigMap
+func NewEventsFromMap(cfgMap map[string]string) (*Events, error) {
+	e := &Events{
+		KafkaBrokers:        splitList(cfgMap[eventsKafkaBrokersKey]),
+		KafkaTopic:          strings.TrimSpace(cfgMap[eventsKafkaTopicKey]),
+		NATSURL:             strings.TrimSpace(cfgMap[eventsNATSURLKey]),
+		NATSSubject:         strings.TrimSpace(cfgMap[eventsNATSSubjectKey]),
+		FilterEventTypes:    splitList(cfgMap[eventsFilterEventTypesKey]),
+		FilterNamespaces:    splitList(cfgMap[eventsFilterNamespacesKey]),
+		FilterLabelSelector: strings.TrimSpace(cfgMap[eventsFilterLabelSelectorKey]),
+		RedactParams:        strings.TrimSpace(cfgMap[eventsRedactParamsKey]),
+		SinkAudiences:       splitList(cfgMap[eventsSinkAudiencesKey]),
+	}
+	if (len(e.KafkaBrokers) == 0) != (e.KafkaTopic == "") {
+		return nil, fmt.Errorf("events config %q and %q must be set together", eventsKafkaBrokersKey, eventsKafkaTopicKey)
+	}
+	if (e.NATSURL == "") != (e.NATSSubject == "") {
+		return nil, fmt.Errorf("events config %q and %q must be set together", eventsNATSURLKey, eventsNATSSubjectKey)
+	}
+	if e.NATSURL != "" {
+		for _, server := range strings.Split(e.NATSURL, ",") {
+			u, err := url.Parse(strings.TrimSpace(server))
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing events config %q: %w", eventsNATSURLKey, err)
+			}
+			if (u.Scheme != "nats" && u.Scheme != "tls") || u.Host == "" {
+				return nil, fmt.Errorf("events config %q must be a comma separated list of nats:// or tls:// URLs but it is %q", eventsNATSURLKey, e.NATSURL)
+			}
+		}
+	}
+	if strings.ContainsAny(e.NATSSubject, " \t*>") {
+		return nil, fmt.Errorf("events config %q must be a subject without spaces or wildcards but it is %q", eventsNATSSubjectKey, e.NATSSubject)
+	}
+	if _, err := labels.Parse(e.FilterLabelSelector); err != nil {
+		return nil, fmt.Errorf("failed parsing events config %q: %w", eventsFilterLabelSelectorKey, err)
+	}
+	if _, err := regexp.Compile(e.RedactParams); err != nil {
+		return nil, fmt.Errorf("failed parsing events config %q: %w", eventsRedactParamsKey, err)
+	}
+	if dropResults, ok := cfgMap[eventsDropResultsKey]; ok {
+		var err error
+		if e.DropResults, err = strconv.ParseBool(strings.TrimSpace(dropResults)); err != nil {
+			return nil, fmt.Errorf("failed parsing events config %q: %w", eventsDropResultsKey, err)
+		}
+	}
+	return e, nil
+}
+
+// NewEventsFromConfigMap returns an Events for the given configmap
+func NewEventsFromConfigMap(config *corev1.ConfigMap) (*Events, error) {
+	return NewEventsFromMap(config.Data)
+}
+
+// GetEventsConfigName returns the name of the configmap containing the events
+// configuration.
+func GetEventsConfigName() string {
+	if e := os.Getenv("CONFIG_EVENTS_NAME"); e != "" {
+		return e
+	}
+	return EventsConfigMapName
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewEventsFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.Events
+		fileName string
+	}{{
+		want: &config.Events{
+			KafkaBrokers: []string{"kafka-0.kafka:9092", "kafka-1.kafka:9092"},
+			KafkaTopic:   "tekton-events",
+			NATSURL:      "nats://nats.nats:4222",
+			NATSSubject:  "tekton.events",
+			FilterEventTypes: []string{
+				"dev.tekton.event.pipelinerun.successful.v1",
+				"dev.tekton.event.pipelinerun.failed.v1",
+			},
+			FilterNamespaces:    []string{"ci", "release"},
+			FilterLabelSelector: "tekton.dev/pipeline in (build,deploy)",
+			RedactParams:        "(?i)(token|password)",
+			DropResults:         true,
+			SinkAudiences:       []string{"events.example.com"},
+		},
+		fileName: config.GetEventsConfigName(),
+	}, {
+		want:     &config.Events{},
+		fileName: "config-events-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewEventsFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+			if got.KafkaEnabled() != (tc.want.KafkaTopic != "") || got.NATSEnabled() != (tc.want.NATSSubject != "") {
+				t.Errorf("KafkaEnabled() = %t and NATSEnabled() = %t for %v", got.KafkaEnabled(), got.NATSEnabled(), got)
+			}
+		} else {
+			t.Errorf("NewEventsFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewEventsFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-events-no-kafka-topic",
+		"config-events-no-nats-url",
+		"config-events-invalid-nats-url",
+		"config-events-invalid-nats-subject",
+		"config-events-invalid-label-selector",
+		"config-events-invalid-redact-params",
+		"config-events-invalid-drop-results",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewEventsFromConfigMap(cm); err == nil {
+			t.Errorf("NewEventsFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestEventsEmits(t *testing.T) {
+	events, err := config.NewEventsFromMap(map[string]string{
+		"filter-event-types":    "dev.tekton.event.pipelinerun.failed.v1",
+		"filter-namespaces":     "ci",
+		"filter-label-selector": "team=platform",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		desc      string
+		events    *config.Events
+		eventType string
+		namespace string
+		labels    map[string]string
+		want      bool
+	}{{
+		desc:      "no filter",
+		events:    config.DefaultEvents,
+		eventType: "dev.tekton.event.taskrun.started.v1",
+		namespace: "default",
+		want:      true,
+	}, {
+		desc:      "nil events",
+		eventType: "dev.tekton.event.taskrun.started.v1",
+		namespace: "default",
+		want:      true,
+	}, {
+		desc:      "matching",
+		events:    events,
+		eventType: "dev.tekton.event.pipelinerun.failed.v1",
+		namespace: "ci",
+		labels:    map[string]string{"team": "platform"},
+		want:      true,
+	}, {
+		desc:      "other type",
+		events:    events,
+		eventType: "dev.tekton.event.pipelinerun.successful.v1",
+		namespace: "ci",
+		labels:    map[string]string{"team": "platform"},
+	}, {
+		desc:      "other namespace",
+		events:    events,
+		eventType: "dev.tekton.event.pipelinerun.failed.v1",
+		namespace: "default",
+		labels:    map[string]string{"team": "platform"},
+	}, {
+		desc:      "other labels",
+		events:    events,
+		eventType: "dev.tekton.event.pipelinerun.failed.v1",
+		namespace: "ci",
+		labels:    map[string]string{"team": "apps"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.events.Emits(tc.eventType, tc.namespace, tc.labels); got != tc.want {
+				t.Errorf("Emits() = %t but wanted %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEventsRedactsParam(t *testing.T) {
+	events, err := config.NewEventsFromMap(map[string]string{"redact-params": "(?i)(token|password)"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !events.Redacts() {
+		t.Error("Redacts() = false but wanted true")
+	}
+	for name, want := range map[string]bool{"github-token": true, "PASSWORD": true, "revision": false} {
+		if got := events.RedactsParam(name); got != want {
+			t.Errorf("RedactsParam(%q) = %t but wanted %t", name, got, want)
+		}
+	}
+	if config.DefaultEvents.Redacts() || config.DefaultEvents.RedactsParam("token") {
+		t.Error("the default events config was expected not to redact anything")
+	}
+}
+
+func TestEventsAllowsSinkAudience(t *testing.T) {
+	events, err := config.NewEventsFromMap(map[string]string{"sink-audiences": "events.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		events   *config.Events
+		audience string
+		want     bool
+	}{
+		{events: events, audience: "https://sink", want: true},
+		{events: events, audience: "events.example.com", want: true},
+		{events: events, audience: "kubernetes.default.svc", want: false},
+		{events: config.DefaultEvents, audience: "https://sink", want: true},
+		{events: config.DefaultEvents, audience: "events.example.com", want: false},
+	} {
+		if got := tc.events.AllowsSinkAudience(tc.audience, "https://sink"); got != tc.want {
+			t.Errorf("AllowsSinkAudience(%q) = %t but wanted %t", tc.audience, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// FaultInjectionConfigMapName is the name of the fault injection configmap
+	FaultInjectionConfigMapName = "config-fault-injection"
+
+	// FaultPodStartDelay delays the creation of the pod of a TaskRun.
+	FaultPodStartDelay = "pod-start-delay"
+	// FaultStepFailure fails a TaskRun once one of its steps has started, as a
+	// failure of the step which is retried if the TaskRun has retries left.
+	FaultStepFailure = "step-failure"
+	// FaultDroppedEvents drops the events emitted for a state transition of a TaskRun.
+	FaultDroppedEvents = "dropped-events"
+
+	// DefaultFaultProbability is the default probability of each fault.
+	DefaultFaultProbability = 0.1
+	// DefaultMaxPodStartDelay is the default maximum delay of the pod start faults.
+	DefaultMaxPodStartDelay = 30 * time.Second
+
+	faultProbabilityKey = "probability"
+	faultsKey           = "faults"
+	maxPodStartDelayKey = "max-pod-start-delay"
+	faultSeedKey        = "seed"
+)
+
+// DefaultFaultInjection holds the default fault injection configuration.
+var DefaultFaultInjection, _ = NewFaultInjectionFromMap(map[string]string{})
+
+// FaultInjection holds the configuration of the faults injected in the TaskRuns
+// when the "enable-fault-injection" feature flag is set.
+// +k8s:deepcopy-gen=true
+type FaultInjection struct {
+	// Probability is the probability, between 0 and 1, of each fault to be
+	// injected in an attempt of a TaskRun.
+	Probability float64
+	// Faults are the kinds of faults injected.
+	Faults []string
+	// MaxPodStartDelay is the maximum delay of the pod start faults.
+	MaxPodStartDelay time.Duration
+	// Seed is the seed of the faults of all the TaskRuns, to reproduce them. A
+	// random seed is drawn for each TaskRun when it is nil.
+	Seed *int64
+}
+
+// Injects returns true if the fault is one of the faults injected.
+func (fi *FaultInjection) Injects(fault string) bool {
+	for _, f := range fi.Faults {
+		if f == fault {
+			return true
+		}
+	}
+	return false
+}
+
+// NewFaultInjectionFromMap returns a FaultInjection given a map corresponding to a ConfigMap
+func NewFaultInjectionFromMap(cfgMap map[string]string) (*FaultInjection, error) {
+	fi := &FaultInjection{
+		Probability:      DefaultFaultProbability,
+		Faults:           []string{FaultPodStartDelay, FaultStepFailure, FaultDroppedEvents},
+		MaxPodStartDelay: DefaultMaxPodStartDelay,
+	}
+	if v, ok := cfgMap[faultProbabilityKey]; ok {
+		p, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || p < 0 || p > 1 {
+			return nil, fmt.Errorf("fault injection config %q must be a number between 0 and 1 but it is %q", faultProbabilityKey, v)
+		}
+		fi.Probability = p
+	}
+	if v, ok := cfgMap[faultsKey]; ok {
+		fi.Faults = nil
+		for _, f := range strings.Split(v, ",") {
+			f = strings.TrimSpace(f)
+			switch f {
+			case "":
+				continue
+			case FaultPodStartDelay, FaultStepFailure, FaultDroppedEvents:
+				fi.Faults = append(fi.Faults, f)
+			default:
+				return nil, fmt.Errorf("fault injection config %q must be a list of %q, %q and %q but it has %q", faultsKey,
+					FaultPodStartDelay, FaultStepFailure, FaultDroppedEvents, f)
+			}
+		}
+	}
+	if v, ok := cfgMap[maxPodStartDelayKey]; ok {
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("fault injection config %q must be a positive duration but it is %q", maxPodStartDelayKey, v)
+		}
+		fi.MaxPodStartDelay = d
+	}
+	if v := strings.TrimSpace(cfgMap[faultSeedKey]); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("fault injection config %q must be an integer but it is %q", faultSeedKey, v)
+		}
+		fi.Seed = &seed
+	}
+	return fi, nil
+}
+
+// NewFaultInjectionFromConfigMap returns a FaultInjection for the given configmap
+func NewFaultInjectionFromConfigMap(config *corev1.ConfigMap) (*FaultInjection, error) {
+	return NewFaultInjectionFromMap(config.Data)
+}
+
+// GetFaultInjectionConfigName returns the name of the configmap containing the
+// fault injection configuration.
+func GetFaultInjectionConfigName() string {
+	if e := os.Getenv("CONFIG_FAULT_INJECTION_NAME"); e != "" {
+		return e
+	}
+	return FaultInjectionConfigMapName
+}
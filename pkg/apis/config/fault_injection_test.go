@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewFaultInjectionFromConfigMap(t *testing.T) {
+	seed := int64(42)
+	for _, tc := range []struct {
+		want     *config.FaultInjection
+		fileName string
+	}{{
+		want: &config.FaultInjection{
+			Probability:      0.5,
+			Faults:           []string{config.FaultStepFailure, config.FaultDroppedEvents},
+			MaxPodStartDelay: time.Minute,
+			Seed:             &seed,
+		},
+		fileName: config.GetFaultInjectionConfigName(),
+	}, {
+		want: &config.FaultInjection{
+			Probability:      config.DefaultFaultProbability,
+			Faults:           []string{config.FaultPodStartDelay, config.FaultStepFailure, config.FaultDroppedEvents},
+			MaxPodStartDelay: config.DefaultMaxPodStartDelay,
+		},
+		fileName: "config-fault-injection-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewFaultInjectionFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		} else {
+			t.Errorf("NewFaultInjectionFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewFaultInjectionFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-fault-injection-invalid-probability",
+		"config-fault-injection-invalid-fault",
+		"config-fault-injection-invalid-delay",
+		"config-fault-injection-invalid-seed",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewFaultInjectionFromConfigMap(cm); err == nil {
+			t.Errorf("NewFaultInjectionFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestFaultInjection_Injects(t *testing.T) {
+	fi := &config.FaultInjection{Faults: []string{config.FaultStepFailure}}
+	if !fi.Injects(config.FaultStepFailure) {
+		t.Errorf("expected %q to be injected", config.FaultStepFailure)
+	}
+	if fi.Injects(config.FaultPodStartDelay) {
+		t.Errorf("expected %q not to be injected", config.FaultPodStartDelay)
+	}
+}
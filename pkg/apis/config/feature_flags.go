@@ -19,6 +19,7 @@ package config
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -46,6 +47,23 @@ const (
 	ResultExtractionMethodTerminationMessage = "termination-message"
 	// ResultExtractionMethodSidecarLogs is the value used for "results-from" as a way to extract results from tasks using sidecar logs.
 	ResultExtractionMethodSidecarLogs = "sidecar-logs"
+	// StepResourceUsageSourceNone is the value used for "step-resource-usage-source" to not sample the resource usage of steps.
+	StepResourceUsageSourceNone = ""
+	// StepResourceUsageSourceMetricsAPI is the value used for "step-resource-usage-source" to sample the resource usage
+	// of steps from the resource metrics API (metrics.k8s.io), served by metrics-server.
+	StepResourceUsageSourceMetricsAPI = "metrics-api"
+	// StepResourceUsageSourceSummaryAPI is the value used for "step-resource-usage-source" to sample the resource usage
+	// of steps from the summary API of the kubelets, which reports the cAdvisor statistics.
+	StepResourceUsageSourceSummaryAPI = "summary-api"
+	// StepImageDigestsAllowTags is the value used for "step-image-digests" to run the step and sidecar images
+	// referenced by tag as they are.
+	StepImageDigestsAllowTags = ""
+	// StepImageDigestsRequire is the value used for "step-image-digests" to reject the step and sidecar images
+	// which aren't referenced by digest.
+	StepImageDigestsRequire = "require"
+	// StepImageDigestsPin is the value used for "step-image-digests" to resolve the tags of the step and sidecar
+	// images to their digests, which the pods of the TaskRuns are pinned to.
+	StepImageDigestsPin = "pin"
 	// DefaultDisableAffinityAssistant is the default value for "disable-affinity-assistant".
 	DefaultDisableAffinityAssistant = false
 	// DefaultDisableCredsInit is the default value for "disable-creds-init".
@@ -76,6 +94,44 @@ const (
 	DefaultResultExtractionMethod = ResultExtractionMethodTerminationMessage
 	// DefaultMaxResultSize is the default value in bytes for the size of a result
 	DefaultMaxResultSize = 4096
+	// DefaultEnableExecutionLog is the default value for "enable-execution-log".
+	DefaultEnableExecutionLog = false
+	// DefaultEnableLintWarnings is the default value for "enable-lint-warnings".
+	DefaultEnableLintWarnings = false
+	// DefaultStepResourceUsageSource is the default value for "step-resource-usage-source".
+	DefaultStepResourceUsageSource = StepResourceUsageSourceNone
+	// DefaultResultFileStore is the default value for "result-file-store".
+	DefaultResultFileStore = ""
+	// DefaultEnableFaultInjection is the default value for "enable-fault-injection".
+	DefaultEnableFaultInjection = false
+	// DefaultStepLogStore is the default value for "step-log-store".
+	DefaultStepLogStore = ""
+	// DefaultEnableStepProgress is the default value for "enable-step-progress".
+	DefaultEnableStepProgress = false
+	// DefaultSendCloudEventsForSteps is the default value for "send-cloudevents-for-steps".
+	DefaultSendCloudEventsForSteps = false
+	// DefaultEnableStrictResultValidation is the default value for "enable-strict-result-validation".
+	DefaultEnableStrictResultValidation = false
+	// DefaultRunArchive is the default value for "run-archive".
+	DefaultRunArchive = ""
+	// RunArchiveCRD is the value of "run-archive" archiving the runs to PipelineRunArchives.
+	RunArchiveCRD = "crd"
+	// DefaultStatusOffload is the default value for "status-offload".
+	DefaultStatusOffload = ""
+	// StatusOffloadConfigMap is the value of "status-offload" offloading the statuses to ConfigMaps.
+	StatusOffloadConfigMap = "configmap"
+	// DefaultEnableStepStatus is the default value for "enable-step-status".
+	DefaultEnableStepStatus = false
+	// DefaultPropagateParamsToReferencedTasks is the default value for "propagate-params-to-referenced-tasks".
+	DefaultPropagateParamsToReferencedTasks = false
+	// DefaultProvenanceSigningKey is the default value for "provenance-signing-key".
+	DefaultProvenanceSigningKey = ""
+	// DefaultSLSABuilderID is the default value for "slsa-builder-id".
+	DefaultSLSABuilderID = ""
+	// DefaultStepImageDigests is the default value for "step-image-digests".
+	DefaultStepImageDigests = StepImageDigestsAllowTags
+	// DefaultVerifyStepImageSignatures is the default value for "verify-step-image-signatures".
+	DefaultVerifyStepImageSignatures = false
 
 	disableAffinityAssistantKey         = "disable-affinity-assistant"
 	disableCredsInitKey                 = "disable-creds-init"
@@ -90,6 +146,23 @@ const (
 	enableProvenanceInStatus            = "enable-provenance-in-status"
 	resultExtractionMethod              = "results-from"
 	maxResultSize                       = "max-result-size"
+	enableExecutionLog                  = "enable-execution-log"
+	enableLintWarnings                  = "enable-lint-warnings"
+	stepResourceUsageSource             = "step-resource-usage-source"
+	resultFileStore                     = "result-file-store"
+	enableFaultInjection                = "enable-fault-injection"
+	stepLogStore                        = "step-log-store"
+	enableStepProgress                  = "enable-step-progress"
+	sendCloudEventsForSteps             = "send-cloudevents-for-steps"
+	enableStrictResultValidation        = "enable-strict-result-validation"
+	runArchive                          = "run-archive"
+	statusOffload                       = "status-offload"
+	enableStepStatus                    = "enable-step-status"
+	propagateParamsToReferencedTasks    = "propagate-params-to-referenced-tasks"
+	provenanceSigningKey                = "provenance-signing-key"
+	slsaBuilderID                       = "slsa-builder-id"
+	stepImageDigests                    = "step-image-digests"
+	verifyStepImageSignatures           = "verify-step-image-signatures"
 )
 
 // DefaultFeatureFlags holds all the default configurations for the feature flags configmap.
@@ -119,6 +192,72 @@ type FeatureFlags struct {
 	EnableProvenanceInStatus  bool
 	ResultExtractionMethod    string
 	MaxResultSize             int
+	// EnableExecutionLog is the feature flag for "enable-execution-log". When set, the
+	// entrypoint reports the command executed by each step, which is recorded in the
+	// provenance of the TaskRun status.
+	EnableExecutionLog bool
+	// EnableLintWarnings is the feature flag for "enable-lint-warnings". When set, the
+	// webhook returns the lint findings about the Tasks and Pipelines as warnings.
+	EnableLintWarnings bool
+	// StepResourceUsageSource is the feature flag for "step-resource-usage-source". It can be
+	// set to "metrics-api" or "summary-api" to record the peak resource usage of each step in
+	// the TaskRun status. It is disabled when empty.
+	StepResourceUsageSource string
+	// ResultFileStore is the feature flag for "result-file-store". It is the http(s) URL of
+	// the object store the entrypoint uploads the results of type file to.
+	ResultFileStore string
+	// EnableFaultInjection is the feature flag for "enable-fault-injection". When set, the
+	// faults configured in the "config-fault-injection" ConfigMap are randomly injected in
+	// the TaskRuns. It is meant for test clusters only.
+	EnableFaultInjection bool
+	// StepLogStore is the feature flag for "step-log-store". It is the http(s) URL of the
+	// object store the controller archives the logs of the steps to when the TaskRuns complete.
+	StepLogStore string
+	// EnableStepProgress is the feature flag for "enable-step-progress". When set, the
+	// entrypoint emits progress markers in the logs of the steps, which the controller
+	// reports in the TaskRun status and as events.
+	EnableStepProgress bool
+	// SendCloudEventsForSteps is the feature flag for "send-cloudevents-for-steps". When set,
+	// CloudEvents are sent when the steps of TaskRuns start and terminate, as long as a
+	// CloudEvents sink is configured.
+	SendCloudEventsForSteps bool
+	// EnableStrictResultValidation is the feature flag for "enable-strict-result-validation".
+	// When set, the webhook rejects the Pipelines referencing results which aren't declared
+	// by the embedded taskSpec of the producing PipelineTask.
+	EnableStrictResultValidation bool
+	// RunArchive is the feature flag for "run-archive". It is either "crd" or the http(s)
+	// URL of an object store, which the controller archives the PipelineRuns to, with their
+	// TaskRuns, before pruning them.
+	RunArchive string
+	// StatusOffload is the feature flag for "status-offload". It is either "configmap" or
+	// the http(s) URL of an object store, which the controller offloads the largest fields
+	// of the statuses of the PipelineRuns to.
+	StatusOffload string
+	// EnableStepStatus is the feature flag for "enable-step-status". When set, the reason
+	// and the message the steps write to their status files are reported in the
+	// condition of the TaskRun.
+	EnableStepStatus bool
+	// PropagateParamsToReferencedTasks is the feature flag for "propagate-params-to-referenced-tasks".
+	// When set, the params of the PipelineRun are propagated to the referenced Tasks declaring
+	// params of the same names which their PipelineTasks don't pass.
+	PropagateParamsToReferencedTasks bool
+	// ProvenanceSigningKey is the feature flag for "provenance-signing-key". It is the
+	// reference of the KMS key, e.g. "azurekms://[VAULT_NAME][VAULT_URI]/[KEY]", the
+	// controller signs the resolved specs of the runs with, recording the digests and
+	// the signatures in their provenance.
+	ProvenanceSigningKey string
+	// SLSABuilderID is the feature flag for "slsa-builder-id". It is the URI identifying
+	// the controller as the builder of the SLSA v1 provenance it generates in the status
+	// of the runs when they are done.
+	SLSABuilderID string
+	// StepImageDigests is the feature flag for "step-image-digests". It can be set to "require"
+	// to reject the step and sidecar images which aren't referenced by digest, or to "pin" to
+	// resolve their tags to their digests when the pods of the TaskRuns are created.
+	StepImageDigests string
+	// VerifyStepImageSignatures is the feature flag for "verify-step-image-signatures". When set,
+	// the cosign signatures of the step and sidecar images, pinned to their digests, are verified
+	// against the VerificationPolicies matching them before the pods of the TaskRuns are created.
+	VerifyStepImageSignatures bool
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -179,6 +318,57 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setMaxResultSize(cfgMap, DefaultMaxResultSize, &tc.MaxResultSize); err != nil {
 		return nil, err
 	}
+	if err := setFeature(enableExecutionLog, DefaultEnableExecutionLog, &tc.EnableExecutionLog); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableLintWarnings, DefaultEnableLintWarnings, &tc.EnableLintWarnings); err != nil {
+		return nil, err
+	}
+	if err := setStepResourceUsageSource(cfgMap, DefaultStepResourceUsageSource, &tc.StepResourceUsageSource); err != nil {
+		return nil, err
+	}
+	if err := setObjectStore(cfgMap, resultFileStore, DefaultResultFileStore, &tc.ResultFileStore); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableFaultInjection, DefaultEnableFaultInjection, &tc.EnableFaultInjection); err != nil {
+		return nil, err
+	}
+	if err := setObjectStore(cfgMap, stepLogStore, DefaultStepLogStore, &tc.StepLogStore); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStepProgress, DefaultEnableStepProgress, &tc.EnableStepProgress); err != nil {
+		return nil, err
+	}
+	if err := setFeature(sendCloudEventsForSteps, DefaultSendCloudEventsForSteps, &tc.SendCloudEventsForSteps); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStrictResultValidation, DefaultEnableStrictResultValidation, &tc.EnableStrictResultValidation); err != nil {
+		return nil, err
+	}
+	if err := setObjectStoreOrMode(cfgMap, runArchive, RunArchiveCRD, DefaultRunArchive, &tc.RunArchive); err != nil {
+		return nil, err
+	}
+	if err := setObjectStoreOrMode(cfgMap, statusOffload, StatusOffloadConfigMap, DefaultStatusOffload, &tc.StatusOffload); err != nil {
+		return nil, err
+	}
+	if err := setFeature(enableStepStatus, DefaultEnableStepStatus, &tc.EnableStepStatus); err != nil {
+		return nil, err
+	}
+	if err := setFeature(propagateParamsToReferencedTasks, DefaultPropagateParamsToReferencedTasks, &tc.PropagateParamsToReferencedTasks); err != nil {
+		return nil, err
+	}
+	if err := setProvenanceSigningKey(cfgMap, DefaultProvenanceSigningKey, &tc.ProvenanceSigningKey); err != nil {
+		return nil, err
+	}
+	if err := setSLSABuilderID(cfgMap, DefaultSLSABuilderID, &tc.SLSABuilderID); err != nil {
+		return nil, err
+	}
+	if err := setStepImageDigests(cfgMap, DefaultStepImageDigests, &tc.StepImageDigests); err != nil {
+		return nil, err
+	}
+	if err := setFeature(verifyStepImageSignatures, DefaultVerifyStepImageSignatures, &tc.VerifyStepImageSignatures); err != nil {
+		return nil, err
+	}
 	if err := setEnforceNonFalsifiability(cfgMap, tc.EnableAPIFields, &tc.EnforceNonfalsifiability); err != nil {
 		return nil, err
 	}
@@ -260,6 +450,102 @@ func setResultExtractionMethod(cfgMap map[string]string, defaultValue string, fe
 	return nil
 }
 
+// setStepResourceUsageSource sets the "step-resource-usage-source" flag based on the content of a given map.
+// If the feature gate is invalid then an error is returned.
+func setStepResourceUsageSource(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[stepResourceUsageSource]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case StepResourceUsageSourceNone, StepResourceUsageSourceMetricsAPI, StepResourceUsageSourceSummaryAPI:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", stepResourceUsageSource, value)
+	}
+	return nil
+}
+
+// setStepImageDigests sets the "step-image-digests" flag based on the content of a given map.
+// If the feature gate is invalid then an error is returned.
+func setStepImageDigests(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[stepImageDigests]; ok {
+		value = strings.ToLower(strings.TrimSpace(cfg))
+	}
+	switch value {
+	case StepImageDigestsAllowTags, StepImageDigestsRequire, StepImageDigestsPin:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", stepImageDigests, value)
+	}
+	return nil
+}
+
+// setObjectStore sets a flag holding the URL of an object store, such as "result-file-store",
+// based on the content of a given map. If the feature gate is not an absolute http(s) URL then
+// an error is returned.
+func setObjectStore(cfgMap map[string]string, key string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[key]; ok {
+		value = strings.TrimSuffix(strings.TrimSpace(cfg), "/")
+	}
+	if value != "" {
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid value for feature flag %q: %q is not an http(s) URL", key, value)
+		}
+	}
+	*feature = value
+	return nil
+}
+
+// setObjectStoreOrMode sets a flag holding either the URL of an object store or a mode, such
+// as "crd" for "run-archive", based on the content of a given map. If the feature gate is
+// neither the mode nor an absolute http(s) URL then an error is returned.
+func setObjectStoreOrMode(cfgMap map[string]string, key string, mode string, defaultValue string, feature *string) error {
+	if strings.TrimSpace(cfgMap[key]) == mode {
+		*feature = mode
+		return nil
+	}
+	if err := setObjectStore(cfgMap, key, defaultValue, feature); err != nil {
+		return fmt.Errorf("%w, nor %q", err, mode)
+	}
+	return nil
+}
+
+// setProvenanceSigningKey sets the "provenance-signing-key" flag based on the content of a given map.
+// If the feature gate is not the reference of a KMS key, such as "gcpkms://...", then an error is returned.
+func setProvenanceSigningKey(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[provenanceSigningKey]; ok {
+		value = strings.TrimSpace(cfg)
+	}
+	if value != "" {
+		if scheme, ref, ok := strings.Cut(value, "://"); !ok || scheme == "" || ref == "" {
+			return fmt.Errorf("invalid value for feature flag %q: %q is not the reference of a KMS key", provenanceSigningKey, value)
+		}
+	}
+	*feature = value
+	return nil
+}
+
+// setSLSABuilderID sets the "slsa-builder-id" flag based on the content of a given map.
+// If the feature gate is not an absolute URI then an error is returned.
+func setSLSABuilderID(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[slsaBuilderID]; ok {
+		value = strings.TrimSpace(cfg)
+	}
+	if value != "" {
+		if u, err := url.Parse(value); err != nil || !u.IsAbs() {
+			return fmt.Errorf("invalid value for feature flag %q: %q is not an absolute URI", slsaBuilderID, value)
+		}
+	}
+	*feature = value
+	return nil
+}
+
 // setMaxResultSize sets the "max-result-size" flag based on the content of a given map.
 // If the feature gate is invalid or missing then an error is returned.
 func setMaxResultSize(cfgMap map[string]string, defaultValue int, feature *int) error {
@@ -67,6 +67,23 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				VerificationNoMatchPolicy:        config.FailNoMatchPolicy,
 				EnableProvenanceInStatus:         false,
 				ResultExtractionMethod:           "termination-message",
+				EnableExecutionLog:               true,
+				EnableLintWarnings:               true,
+				StepResourceUsageSource:          config.StepResourceUsageSourceMetricsAPI,
+				ResultFileStore:                  "https://artifacts.example.com/tekton",
+				EnableFaultInjection:             true,
+				StepLogStore:                     "https://logs.example.com/tekton",
+				EnableStepProgress:               true,
+				SendCloudEventsForSteps:          true,
+				EnableStrictResultValidation:     true,
+				RunArchive:                       "https://archive.example.com/tekton",
+				StatusOffload:                    config.StatusOffloadConfigMap,
+				EnableStepStatus:                 true,
+				PropagateParamsToReferencedTasks: true,
+				ProvenanceSigningKey:             "azurekms://tekton-vault.vault.azure.net/provenance",
+				SLSABuilderID:                    "https://tekton.example.com/builders/tekton-pipelines",
+				StepImageDigests:                 config.StepImageDigestsPin,
+				VerifyStepImageSignatures:        true,
 
 				MaxResultSize: 4096,
 			},
@@ -228,6 +245,30 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 	}, {
 		fileName: "feature-flags-invalid-results-from",
 		want:     `invalid value for feature flag "results-from": "im-not-a-valid-results-from"`,
+	}, {
+		fileName: "feature-flags-invalid-step-resource-usage-source",
+		want:     `invalid value for feature flag "step-resource-usage-source": "prometheus"`,
+	}, {
+		fileName: "feature-flags-invalid-result-file-store",
+		want:     `invalid value for feature flag "result-file-store": "s3://bucket" is not an http(s) URL`,
+	}, {
+		fileName: "feature-flags-invalid-step-log-store",
+		want:     `invalid value for feature flag "step-log-store": "logs.example.com" is not an http(s) URL`,
+	}, {
+		fileName: "feature-flags-invalid-run-archive",
+		want:     `invalid value for feature flag "run-archive": "etcd" is not an http(s) URL, nor "crd"`,
+	}, {
+		fileName: "feature-flags-invalid-status-offload",
+		want:     `invalid value for feature flag "status-offload": "secret" is not an http(s) URL, nor "configmap"`,
+	}, {
+		fileName: "feature-flags-invalid-provenance-signing-key",
+		want:     `invalid value for feature flag "provenance-signing-key": "provenance.pem" is not the reference of a KMS key`,
+	}, {
+		fileName: "feature-flags-invalid-slsa-builder-id",
+		want:     `invalid value for feature flag "slsa-builder-id": "tekton-pipelines" is not an absolute URI`,
+	}, {
+		fileName: "feature-flags-invalid-step-image-digests",
+		want:     `invalid value for feature flag "step-image-digests": "resolve"`,
 	}, {
 		fileName: "feature-flags-invalid-max-result-size-too-large",
 		want:     `invalid value for feature flag "results-from": "10000000000000". This is exceeding the CRD limit`,
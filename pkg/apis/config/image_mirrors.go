@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ImageMirrorsConfigMapName is the name of the image mirrors configmap
+	ImageMirrorsConfigMapName = "config-image-mirrors"
+
+	imageMirrorsKey = "mirrors"
+)
+
+// DefaultImageMirrors holds the default image mirrors configuration, with no mirrors.
+var DefaultImageMirrors, _ = NewImageMirrorsFromMap(map[string]string{})
+
+// ImageMirrors holds the mirrors the images of the steps and the sidecars are pulled
+// through, e.g. in air-gapped clusters.
+// +k8s:deepcopy-gen=true
+type ImageMirrors struct {
+	// Mirrors maps the prefixes of the image references, a registry such as "docker.io"
+	// optionally followed by a repository path such as "gcr.io/tekton-releases", to the
+	// repositories mirroring them, e.g. "registry.internal/dockerhub".
+	Mirrors map[string]string
+}
+
+// MirrorImage returns the reference of the image in the mirror of the longest prefix
+// matching the image, keeping its path under the prefix and its tag or digest, or the
+// image if no prefix matches it. The image references of Docker Hub are matched in
+// their canonical form, e.g. "alpine" matches "docker.io/library".
+func (m *ImageMirrors) MirrorImage(image string) string {
+	if m == nil || len(m.Mirrors) == 0 {
+		return image
+	}
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return image
+	}
+	repository := ref.Context().Name()
+	var prefix, mirror string
+	for source, to := range m.Mirrors {
+		p, err := canonicalImagePrefix(source)
+		if err != nil || len(p) <= len(prefix) {
+			continue
+		}
+		if repository == p || strings.HasPrefix(repository, p+"/") {
+			prefix, mirror = p, to
+		}
+	}
+	if prefix == "" {
+		return image
+	}
+	mirrored := strings.TrimSuffix(mirror, "/") + strings.TrimPrefix(repository, prefix)
+	if _, ok := ref.(name.Digest); ok {
+		return mirrored + "@" + ref.Identifier()
+	}
+	return mirrored + ":" + ref.Identifier()
+}
+
+// canonicalImagePrefix returns the canonical form of a registry or a repository prefix,
+// e.g. "index.docker.io" for "docker.io".
+func canonicalImagePrefix(prefix string) (string, error) {
+	if !strings.Contains(prefix, "/") {
+		r, err := name.NewRegistry(prefix, name.WeakValidation)
+		if err != nil {
+			return "", err
+		}
+		return r.Name(), nil
+	}
+	r, err := name.NewRepository(prefix, name.WeakValidation)
+	if err != nil {
+		return "", err
+	}
+	return r.Name(), nil
+}
+
+// NewImageMirrorsFromMap returns an ImageMirrors given a map corresponding to a ConfigMap.
+// The "mirrors" key holds a YAML map of the prefixes of the image references to their mirrors.
+func NewImageMirrorsFromMap(cfgMap map[string]string) (*ImageMirrors, error) {
+	mirrors := &ImageMirrors{Mirrors: map[string]string{}}
+	if v, ok := cfgMap[imageMirrorsKey]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &mirrors.Mirrors); err != nil {
+			return nil, fmt.Errorf("failed parsing image mirrors config %q: %w", imageMirrorsKey, err)
+		}
+		if mirrors.Mirrors == nil {
+			mirrors.Mirrors = map[string]string{}
+		}
+	}
+	sources := map[string]string{}
+	for source, mirror := range mirrors.Mirrors {
+		prefix, err := canonicalImagePrefix(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image mirror source %q: %w", source, err)
+		}
+		if other, ok := sources[prefix]; ok {
+			return nil, fmt.Errorf("image mirror sources %q and %q both match %q", other, source, prefix)
+		}
+		sources[prefix] = source
+		if mirror == "" {
+			return nil, fmt.Errorf("image mirror source %q must specify a mirror", source)
+		}
+		if _, err := name.NewRepository(strings.TrimSuffix(mirror, "/")+"/image", name.WeakValidation); err != nil {
+			return nil, fmt.Errorf("invalid mirror %q of image mirror source %q: %w", mirror, source, err)
+		}
+	}
+	return mirrors, nil
+}
+
+// NewImageMirrorsFromConfigMap returns an ImageMirrors for the given configmap
+func NewImageMirrorsFromConfigMap(config *corev1.ConfigMap) (*ImageMirrors, error) {
+	return NewImageMirrorsFromMap(config.Data)
+}
+
+// GetImageMirrorsConfigName returns the name of the configmap containing the
+// image mirrors.
+func GetImageMirrorsConfigName() string {
+	if e := os.Getenv("CONFIG_IMAGE_MIRRORS_NAME"); e != "" {
+		return e
+	}
+	return ImageMirrorsConfigMapName
+}
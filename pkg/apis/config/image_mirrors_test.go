@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewImageMirrorsFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.ImageMirrors
+		fileName string
+	}{{
+		want: &config.ImageMirrors{
+			Mirrors: map[string]string{
+				"docker.io":              "registry.internal/dockerhub",
+				"gcr.io/tekton-releases": "registry.internal/tekton/",
+			},
+		},
+		fileName: config.GetImageMirrorsConfigName(),
+	}, {
+		want:     &config.ImageMirrors{Mirrors: map[string]string{}},
+		fileName: "config-image-mirrors-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewImageMirrorsFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		} else {
+			t.Errorf("NewImageMirrorsFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewImageMirrorsFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-image-mirrors-duplicate-source",
+		"config-image-mirrors-invalid-source",
+		"config-image-mirrors-no-mirror",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewImageMirrorsFromConfigMap(cm); err == nil {
+			t.Errorf("NewImageMirrorsFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	mirrors := &config.ImageMirrors{Mirrors: map[string]string{
+		"docker.io":              "registry.internal/dockerhub",
+		"gcr.io":                 "registry.internal/gcr",
+		"gcr.io/tekton-releases": "registry.internal/tekton/",
+	}}
+	digest := "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	for _, tc := range []struct {
+		desc    string
+		mirrors *config.ImageMirrors
+		image   string
+		want    string
+	}{{
+		desc:    "docker hub official image",
+		mirrors: mirrors,
+		image:   "alpine:3.18",
+		want:    "registry.internal/dockerhub/library/alpine:3.18",
+	}, {
+		desc:    "docker hub image without tag",
+		mirrors: mirrors,
+		image:   "docker.io/bitnami/kubectl",
+		want:    "registry.internal/dockerhub/bitnami/kubectl:latest",
+	}, {
+		desc:    "longest prefix",
+		mirrors: mirrors,
+		image:   "gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/git-init@" + digest,
+		want:    "registry.internal/tekton/github.com/tektoncd/pipeline/cmd/git-init@" + digest,
+	}, {
+		desc:    "registry prefix",
+		mirrors: mirrors,
+		image:   "gcr.io/distroless/base:nonroot",
+		want:    "registry.internal/gcr/distroless/base:nonroot",
+	}, {
+		desc:    "prefix matching a part of a path segment",
+		mirrors: &config.ImageMirrors{Mirrors: map[string]string{"gcr.io/tekton": "registry.internal/tekton"}},
+		image:   "gcr.io/tekton-releases/git-init:v0.40.2",
+		want:    "gcr.io/tekton-releases/git-init:v0.40.2",
+	}, {
+		desc:    "image without mirror",
+		mirrors: mirrors,
+		image:   "quay.io/buildah/stable",
+		want:    "quay.io/buildah/stable",
+	}, {
+		desc:  "no mirrors",
+		image: "alpine:3.18",
+		want:  "alpine:3.18",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.mirrors.MirrorImage(tc.image); got != tc.want {
+				t.Errorf("MirrorImage(%q) = %q, want %q", tc.image, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ImagePullSecretsConfigMapName is the name of the image pull secrets configmap
+	ImagePullSecretsConfigMapName = "config-image-pull-secrets"
+
+	imagePullSecretsPoliciesKey = "policies"
+)
+
+// DefaultImagePullSecrets holds the default image pull secrets configuration, with no policies.
+var DefaultImagePullSecrets, _ = NewImagePullSecretsFromMap(map[string]string{})
+
+// ImagePullSecrets holds the policies appending image pull secrets to the pods of the
+// TaskRuns they select, so that the service accounts of every namespace don't have to
+// be patched with the secrets of the private registries.
+// +k8s:deepcopy-gen=true
+type ImagePullSecrets struct {
+	// Policies are the image pull secrets policies, in the order their secrets are appended.
+	Policies []ImagePullSecretsPolicy
+}
+
+// ImagePullSecretsPolicy appends image pull secrets to the pods of the TaskRuns it selects.
+// +k8s:deepcopy-gen=true
+type ImagePullSecretsPolicy struct {
+	// Name identifies the policy.
+	Name string `json:"name"`
+	// NamespaceSelector selects the namespaces of the TaskRuns the policy applies to by
+	// their labels, e.g. "kubernetes.io/metadata.name". All the namespaces are selected
+	// if it is not set.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Selector selects the TaskRuns the policy applies to by their labels. All the
+	// TaskRuns of the selected namespaces are selected if it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// ImagePullSecrets are the secrets of the namespace of the TaskRun appended to the
+	// image pull secrets of its pod.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// ServiceAccount is the name of a service account of the namespace of the TaskRun
+	// whose image pull secrets are appended to the image pull secrets of its pod. The pod
+	// keeps running with the service account of the TaskRun.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// SelectsNamespaces returns true if any of the policies selects the namespaces by
+// their labels.
+func (s *ImagePullSecrets) SelectsNamespaces() bool {
+	if s == nil {
+		return false
+	}
+	for _, p := range s.Policies {
+		if p.NamespaceSelector != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Selects returns true if the policy applies to the TaskRuns with the given labels in
+// the namespace with the given labels.
+func (p *ImagePullSecretsPolicy) Selects(namespaceLabels, taskRunLabels map[string]string) bool {
+	return matchesLabelSelector(p.NamespaceSelector, namespaceLabels) && matchesLabelSelector(p.Selector, taskRunLabels)
+}
+
+// matchesLabelSelector returns true if the labels match the selector, or if the selector
+// is not set. Invalid selectors are rejected when the configmap is parsed.
+func matchesLabelSelector(selector *metav1.LabelSelector, l map[string]string) bool {
+	if selector == nil {
+		return true
+	}
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(labels.Set(l))
+}
+
+// NewImagePullSecretsFromMap returns an ImagePullSecrets given a map corresponding to a
+// ConfigMap. The "policies" key holds a YAML list of the image pull secrets policies.
+func NewImagePullSecretsFromMap(cfgMap map[string]string) (*ImagePullSecrets, error) {
+	s := &ImagePullSecrets{}
+	if v, ok := cfgMap[imagePullSecretsPoliciesKey]; ok {
+		if err := yaml.UnmarshalStrict([]byte(v), &s.Policies); err != nil {
+			return nil, fmt.Errorf("failed parsing image pull secrets config %q: %w", imagePullSecretsPoliciesKey, err)
+		}
+	}
+	names := map[string]bool{}
+	for _, p := range s.Policies {
+		if p.Name == "" {
+			return nil, fmt.Errorf("image pull secrets policies must have a name")
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("image pull secrets policy %q is defined more than once", p.Name)
+		}
+		names[p.Name] = true
+		if len(p.ImagePullSecrets) == 0 && p.ServiceAccount == "" {
+			return nil, fmt.Errorf("image pull secrets policy %q must specify imagePullSecrets or a serviceAccount", p.Name)
+		}
+		for _, ref := range p.ImagePullSecrets {
+			if ref.Name == "" {
+				return nil, fmt.Errorf("the imagePullSecrets of image pull secrets policy %q must have a name", p.Name)
+			}
+		}
+		if p.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(p.NamespaceSelector); err != nil {
+				return nil, fmt.Errorf("invalid namespaceSelector of image pull secrets policy %q: %w", p.Name, err)
+			}
+		}
+		if p.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(p.Selector); err != nil {
+				return nil, fmt.Errorf("invalid selector of image pull secrets policy %q: %w", p.Name, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+// NewImagePullSecretsFromConfigMap returns an ImagePullSecrets for the given configmap
+func NewImagePullSecretsFromConfigMap(config *corev1.ConfigMap) (*ImagePullSecrets, error) {
+	return NewImagePullSecretsFromMap(config.Data)
+}
+
+// GetImagePullSecretsConfigName returns the name of the configmap containing the
+// image pull secrets policies.
+func GetImagePullSecretsConfigName() string {
+	if e := os.Getenv("CONFIG_IMAGE_PULL_SECRETS_NAME"); e != "" {
+		return e
+	}
+	return ImagePullSecretsConfigMapName
+}
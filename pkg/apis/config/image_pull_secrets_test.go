@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewImagePullSecretsFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.ImagePullSecrets
+		fileName string
+	}{{
+		want: &config.ImagePullSecrets{
+			Policies: []config.ImagePullSecretsPolicy{{
+				Name:              "private-registry",
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "builds"}},
+				ImagePullSecrets:  []corev1.LocalObjectReference{{Name: "private-registry-credentials"}},
+			}, {
+				Name: "signed-images",
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      "app.kubernetes.io/part-of",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"release"},
+				}}},
+				ServiceAccount: "puller",
+			}},
+		},
+		fileName: config.GetImagePullSecretsConfigName(),
+	}, {
+		want:     &config.ImagePullSecrets{},
+		fileName: "config-image-pull-secrets-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewImagePullSecretsFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		} else {
+			t.Errorf("NewImagePullSecretsFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewImagePullSecretsFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-image-pull-secrets-duplicate-name",
+		"config-image-pull-secrets-invalid-selector",
+		"config-image-pull-secrets-no-name",
+		"config-image-pull-secrets-no-secrets",
+		"config-image-pull-secrets-unknown-field",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewImagePullSecretsFromConfigMap(cm); err == nil {
+			t.Errorf("NewImagePullSecretsFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestImagePullSecretsPolicySelects(t *testing.T) {
+	policy := config.ImagePullSecretsPolicy{
+		Name:              "private-registry",
+		NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "builds"}},
+		Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      "app",
+			Operator: metav1.LabelSelectorOpExists,
+		}}},
+	}
+	for _, tc := range []struct {
+		desc            string
+		policy          config.ImagePullSecretsPolicy
+		namespaceLabels map[string]string
+		taskRunLabels   map[string]string
+		want            bool
+	}{{
+		desc:            "namespace and taskrun selected",
+		policy:          policy,
+		namespaceLabels: map[string]string{"team": "builds"},
+		taskRunLabels:   map[string]string{"app": "release"},
+		want:            true,
+	}, {
+		desc:            "namespace not selected",
+		policy:          policy,
+		namespaceLabels: map[string]string{"team": "tests"},
+		taskRunLabels:   map[string]string{"app": "release"},
+		want:            false,
+	}, {
+		desc:            "taskrun not selected",
+		policy:          policy,
+		namespaceLabels: map[string]string{"team": "builds"},
+		want:            false,
+	}, {
+		desc:   "no selectors select all the taskruns",
+		policy: config.ImagePullSecretsPolicy{Name: "all"},
+		want:   true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := tc.policy.Selects(tc.namespaceLabels, tc.taskRunLabels); got != tc.want {
+				t.Errorf("Selects() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
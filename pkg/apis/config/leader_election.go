@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	pkgleaderelection "knative.dev/pkg/leaderelection"
+)
+
+const (
+	// LeaderElectionConfigMapName is the name of the leader election configmap
+	LeaderElectionConfigMapName = "config-leader-election"
+
+	// LeaderElectionBucketsKeyPrefix prefixes the keys overriding the number of
+	// buckets of a reconciler, e.g. "buckets.taskrun".
+	LeaderElectionBucketsKeyPrefix = "buckets."
+
+	leaderElectionResyncPeriodKey = "resync-period"
+)
+
+// DefaultLeaderElection holds the default leader election configuration.
+var DefaultLeaderElection, _ = NewLeaderElectionFromMap(map[string]string{})
+
+// LeaderElection holds the configuration of the leader election of the buckets
+// partitioning the keys of the reconcilers between the replicas of the controller.
+// +k8s:deepcopy-gen=true
+type LeaderElection struct {
+	// Buckets is the number of buckets of the reconcilers without an override.
+	Buckets uint32
+	// ReconcilerBuckets overrides the number of buckets of the named reconcilers.
+	ReconcilerBuckets map[string]uint32
+	// LeaseDuration is how long non-leaders wait to try to acquire a bucket.
+	LeaseDuration time.Duration
+	// RenewDeadline is how long a leader tries to renew the lease of a bucket
+	// before giving it up.
+	RenewDeadline time.Duration
+	// RetryPeriod is how long the electors wait between tries of actions.
+	RetryPeriod time.Duration
+	// ResyncPeriod is the period of the resyncs of the keys of the buckets a
+	// replica leads, zero disables them.
+	ResyncPeriod time.Duration
+}
+
+// BucketsOf returns the number of buckets of the named reconciler.
+func (le *LeaderElection) BucketsOf(reconciler string) uint32 {
+	if b, ok := le.ReconcilerBuckets[reconciler]; ok {
+		return b
+	}
+	return le.Buckets
+}
+
+// NewLeaderElectionFromMap returns a LeaderElection given a map corresponding to a ConfigMap
+func NewLeaderElectionFromMap(cfgMap map[string]string) (*LeaderElection, error) {
+	base, err := pkgleaderelection.NewConfigFromMap(cfgMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the leader election config: %w", err)
+	}
+	le := &LeaderElection{
+		Buckets:       base.Buckets,
+		LeaseDuration: base.LeaseDuration,
+		RenewDeadline: base.RenewDeadline,
+		RetryPeriod:   base.RetryPeriod,
+	}
+	for k, v := range cfgMap {
+		if !strings.HasPrefix(k, LeaderElectionBucketsKeyPrefix) {
+			continue
+		}
+		b, err := strconv.ParseUint(strings.TrimSpace(v), 10, 32)
+		if err != nil || b < 1 || uint32(b) > pkgleaderelection.MaxBuckets {
+			return nil, fmt.Errorf("leader election config %q must be a number between 1 and %d but it is %q", k, pkgleaderelection.MaxBuckets, v)
+		}
+		if le.ReconcilerBuckets == nil {
+			le.ReconcilerBuckets = map[string]uint32{}
+		}
+		le.ReconcilerBuckets[strings.TrimPrefix(k, LeaderElectionBucketsKeyPrefix)] = uint32(b)
+	}
+	if v, ok := cfgMap[leaderElectionResyncPeriodKey]; ok {
+		d, err := time.ParseDuration(strings.TrimSpace(v))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("leader election config %q must be a positive duration but it is %q", leaderElectionResyncPeriodKey, v)
+		}
+		le.ResyncPeriod = d
+	}
+	if le.RetryPeriod <= 0 {
+		return nil, fmt.Errorf("leader election config retry-period must be positive but it is %s", le.RetryPeriod)
+	}
+	if le.RenewDeadline <= 0 || le.RenewDeadline >= le.LeaseDuration {
+		return nil, fmt.Errorf("leader election config renew-deadline must be positive and less than lease-duration %s but it is %s", le.LeaseDuration, le.RenewDeadline)
+	}
+	return le, nil
+}
+
+// NewLeaderElectionFromConfigMap returns a LeaderElection for the given configmap
+func NewLeaderElectionFromConfigMap(config *corev1.ConfigMap) (*LeaderElection, error) {
+	return NewLeaderElectionFromMap(config.Data)
+}
+
+// GetLeaderElectionConfigName returns the name of the configmap containing the
+// leader election configuration.
+func GetLeaderElectionConfigName() string {
+	if e := os.Getenv("CONFIG_LEADERELECTION_NAME"); e != "" {
+		return e
+	}
+	return LeaderElectionConfigMapName
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewLeaderElectionFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.LeaderElection
+		fileName string
+	}{{
+		want: &config.LeaderElection{
+			Buckets:           3,
+			ReconcilerBuckets: map[string]uint32{"taskrun": 5},
+			LeaseDuration:     30 * time.Second,
+			RenewDeadline:     20 * time.Second,
+			RetryPeriod:       5 * time.Second,
+			ResyncPeriod:      10 * time.Minute,
+		},
+		fileName: config.GetLeaderElectionConfigName(),
+	}, {
+		want: &config.LeaderElection{
+			Buckets:       1,
+			LeaseDuration: 60 * time.Second,
+			RenewDeadline: 40 * time.Second,
+			RetryPeriod:   10 * time.Second,
+		},
+		fileName: "config-leader-election-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewLeaderElectionFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		} else {
+			t.Errorf("NewLeaderElectionFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewLeaderElectionFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-leader-election-invalid-buckets",
+		"config-leader-election-invalid-reconciler-buckets",
+		"config-leader-election-invalid-renew-deadline",
+		"config-leader-election-invalid-resync-period",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewLeaderElectionFromConfigMap(cm); err == nil {
+			t.Errorf("NewLeaderElectionFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestLeaderElectionBucketsOf(t *testing.T) {
+	le := &config.LeaderElection{Buckets: 3, ReconcilerBuckets: map[string]uint32{"taskrun": 5}}
+	if got := le.BucketsOf("taskrun"); got != 5 {
+		t.Errorf("BucketsOf(taskrun) = %d, want 5", got)
+	}
+	if got := le.BucketsOf("pipelinerun"); got != 3 {
+		t.Errorf("BucketsOf(pipelinerun) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// LogForwardingConfigMapName is the name of the log forwarding configmap
+	LogForwardingConfigMapName = "config-log-forwarding"
+
+	// LogForwardingSinkLoki ships the step logs to the push API of Loki.
+	LogForwardingSinkLoki = "loki"
+	// LogForwardingSinkHTTP ships the step logs as newline delimited JSON to an http(s) endpoint.
+	LogForwardingSinkHTTP = "http"
+	// LogForwardingSinkGCS uploads the log of each step to a Google Cloud Storage bucket once it completes.
+	LogForwardingSinkGCS = "gcs"
+	// LogForwardingSinkCloudWatch puts the step logs to a log group of CloudWatch Logs, in one log stream per step.
+	LogForwardingSinkCloudWatch = "cloudwatch"
+
+	logForwardingSinkKey = "sink"
+	logForwardingURLKey  = "url"
+)
+
+var (
+	// awsRegionRegex matches the names of the AWS regions, e.g. us-east-1 or us-gov-west-1.
+	awsRegionRegex = regexp.MustCompile(`^[a-z]{2}(-[a-z]+)+-[0-9]+$`)
+	// cloudWatchLogGroupRegex matches the names of the log groups of CloudWatch Logs.
+	cloudWatchLogGroupRegex = regexp.MustCompile(`^[.\-_/#A-Za-z0-9]{1,512}$`)
+)
+
+// DefaultLogForwarding holds the default log forwarding configuration, with log forwarding disabled.
+var DefaultLogForwarding, _ = NewLogForwardingFromMap(map[string]string{})
+
+// LogForwarding holds the configuration of the sidecar injected in the TaskRun
+// pods to ship the logs of their steps to a sink.
+// +k8s:deepcopy-gen=true
+type LogForwarding struct {
+	// Sink is the kind of sink the logs are shipped to, empty if log forwarding is disabled.
+	Sink string
+	// URL is the address of the sink: the Loki push API URL, the http(s)
+	// endpoint, the gs://<bucket>/<prefix> location, or the
+	// cloudwatch://<region>/<log group> log group.
+	URL string
+}
+
+// Enabled returns true if the logs of the steps are forwarded to a sink.
+func (lf *LogForwarding) Enabled() bool {
+	return lf != nil && lf.Sink != ""
+}
+
+// NewLogForwardingFromMap returns a LogForwarding given a map corresponding to a ConfigMap
+func NewLogForwardingFromMap(cfgMap map[string]string) (*LogForwarding, error) {
+	lf := &LogForwarding{
+		Sink: strings.TrimSpace(cfgMap[logForwardingSinkKey]),
+		URL:  strings.TrimSpace(cfgMap[logForwardingURLKey]),
+	}
+	if lf.Sink == "" {
+		return lf, nil
+	}
+	if lf.URL == "" {
+		return nil, fmt.Errorf("log forwarding config %q must be set when %q is %q", logForwardingURLKey, logForwardingSinkKey, lf.Sink)
+	}
+	u, err := url.Parse(lf.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing log forwarding config %q: %w", logForwardingURLKey, err)
+	}
+	switch lf.Sink {
+	case LogForwardingSinkLoki, LogForwardingSinkHTTP:
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("log forwarding config %q must be an http(s) URL for the %q sink but it is %q", logForwardingURLKey, lf.Sink, lf.URL)
+		}
+	case LogForwardingSinkGCS:
+		if u.Scheme != "gs" || u.Host == "" {
+			return nil, fmt.Errorf("log forwarding config %q must be a gs://<bucket> URL for the %q sink but it is %q", logForwardingURLKey, lf.Sink, lf.URL)
+		}
+	case LogForwardingSinkCloudWatch:
+		// A # in the name of the log group is escaped as %23, not to be taken as a fragment
+		if u.Scheme != "cloudwatch" || !awsRegionRegex.MatchString(u.Host) || u.Fragment != "" || u.RawQuery != "" ||
+			!cloudWatchLogGroupRegex.MatchString(strings.TrimPrefix(u.Path, "/")) {
+			return nil, fmt.Errorf("log forwarding config %q must be a cloudwatch://<region>/<log group> URL for the %q sink but it is %q", logForwardingURLKey, lf.Sink, lf.URL)
+		}
+	default:
+		return nil, fmt.Errorf("log forwarding config %q must be one of %q, %q, %q or %q but it is %q", logForwardingSinkKey,
+			LogForwardingSinkLoki, LogForwardingSinkHTTP, LogForwardingSinkGCS, LogForwardingSinkCloudWatch, lf.Sink)
+	}
+	return lf, nil
+}
+
+// NewLogForwardingFromConfigMap returns a LogForwarding for the given configmap
+func NewLogForwardingFromConfigMap(config *corev1.ConfigMap) (*LogForwarding, error) {
+	return NewLogForwardingFromMap(config.Data)
+}
+
+// GetLogForwardingConfigName returns the name of the configmap containing the
+// log forwarding configuration.
+func GetLogForwardingConfigName() string {
+	if e := os.Getenv("CONFIG_LOG_FORWARDING_NAME"); e != "" {
+		return e
+	}
+	return LogForwardingConfigMapName
+}
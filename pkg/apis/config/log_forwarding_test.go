@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewLogForwardingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.LogForwarding
+		fileName string
+	}{{
+		want: &config.LogForwarding{
+			Sink: config.LogForwardingSinkLoki,
+			URL:  "https://loki.example.com/loki/api/v1/push",
+		},
+		fileName: config.GetLogForwardingConfigName(),
+	}, {
+		want: &config.LogForwarding{
+			Sink: config.LogForwardingSinkGCS,
+			URL:  "gs://tekton-logs/ci",
+		},
+		fileName: "config-log-forwarding-gcs",
+	}, {
+		want: &config.LogForwarding{
+			Sink: config.LogForwardingSinkCloudWatch,
+			URL:  "cloudwatch://us-east-1//tekton/steps",
+		},
+		fileName: "config-log-forwarding-cloudwatch",
+	}, {
+		want:     &config.LogForwarding{},
+		fileName: "config-log-forwarding-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewLogForwardingFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+			if got.Enabled() != (tc.want.Sink != "") {
+				t.Errorf("Enabled() = %t for sink %q", got.Enabled(), got.Sink)
+			}
+		} else {
+			t.Errorf("NewLogForwardingFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewLogForwardingFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-log-forwarding-invalid-sink",
+		"config-log-forwarding-invalid-url",
+		"config-log-forwarding-invalid-cloudwatch-url",
+		"config-log-forwarding-no-url",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewLogForwardingFromConfigMap(cm); err == nil {
+			t.Errorf("NewLogForwardingFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
+
+func TestNewLogForwardingFromMap_CloudWatch(t *testing.T) {
+	for url, valid := range map[string]bool{
+		"cloudwatch://us-east-1/tekton":                true,
+		"cloudwatch://us-gov-west-1//aws/tekton/ci":    true,
+		"cloudwatch://eu-central-1/tekton%23steps_1.0": true,
+		"cloudwatch://eu-central-1/tekton#steps_1.0":   false,
+		"cloudwatch://us-east-1/":                      false,
+		"cloudwatch://logs.example.com/tekton":         false,
+		"cloudwatch://us-east-1/tekton:steps":          false,
+		"https://logs.us-east-1.amazonaws.com":         false,
+	} {
+		_, err := config.NewLogForwardingFromMap(map[string]string{"sink": "cloudwatch", "url": url})
+		if (err == nil) != valid {
+			t.Errorf("NewLogForwardingFromMap(%q) returned error %v, want valid %t", url, err, valid)
+		}
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	"knative.dev/pkg/metrics"
 )
@@ -35,6 +39,20 @@ const (
 	// metricsDurationPipelinerunType determines what type of
 	// metrics to use for aggregating duration for pipelinerun
 	metricsDurationPipelinerunType = "metrics.pipelinerun.duration-type"
+	// metricsNamespaceLabelKey determines whether the metrics have the namespace label
+	metricsNamespaceLabelKey = "metrics.labels.namespace"
+	// metricsPipelineLabelKey determines whether the metrics have the pipeline and
+	// pipelinerun labels, as selected by the pipelinerun level
+	metricsPipelineLabelKey = "metrics.labels.pipeline"
+	// metricsTaskLabelKey determines whether the metrics have the task and taskrun
+	// labels, as selected by the taskrun level
+	metricsTaskLabelKey = "metrics.labels.task"
+	// metricsReasonLabelKey determines whether the metrics of the runs which complete
+	// have the reason label
+	metricsReasonLabelKey = "metrics.labels.reason"
+	// metricsLabelCardinalityLimitKey is the maximum number of distinct values of each
+	// label of the metrics
+	metricsLabelCardinalityLimitKey = "metrics.labels.cardinality-limit"
 
 	// DefaultTaskrunLevel determines to what level to aggregate metrics
 	// when it isn't specified in configmap
@@ -80,6 +98,10 @@ const (
 	// DurationPipelinerunTypeLastValue specify that lastValue or
 	// gauge type metrics need to be use for Duration of Pipelinerun
 	DurationPipelinerunTypeLastValue = "lastvalue"
+
+	// DefaultLabelCardinalityLimit is the maximum number of distinct values of each
+	// label of the metrics when it isn't specified in configmap, 0 for no limit
+	DefaultLabelCardinalityLimit = 0
 )
 
 // DefaultMetrics holds all the default configurations for the metrics.
@@ -92,6 +114,17 @@ type Metrics struct {
 	PipelinerunLevel        string
 	DurationTaskrunType     string
 	DurationPipelinerunType string
+	// DisableNamespaceLabel, DisablePipelineLabel and DisableTaskLabel remove the
+	// namespace, the pipeline and pipelinerun, and the task and taskrun labels from
+	// the metrics, whatever their level.
+	DisableNamespaceLabel bool
+	DisablePipelineLabel  bool
+	DisableTaskLabel      bool
+	// ReasonLabel adds the reason label to the metrics of the runs which complete.
+	ReasonLabel bool
+	// LabelCardinalityLimit is the maximum number of distinct values recorded for each
+	// label, the values beyond it are recorded as "other". 0 means no limit.
+	LabelCardinalityLimit int
 }
 
 // GetMetricsConfigName returns the name of the configmap containing all
@@ -113,7 +146,12 @@ func (cfg *Metrics) Equals(other *Metrics) bool {
 	return other.TaskrunLevel == cfg.TaskrunLevel &&
 		other.PipelinerunLevel == cfg.PipelinerunLevel &&
 		other.DurationTaskrunType == cfg.DurationTaskrunType &&
-		other.DurationPipelinerunType == cfg.DurationPipelinerunType
+		other.DurationPipelinerunType == cfg.DurationPipelinerunType &&
+		other.DisableNamespaceLabel == cfg.DisableNamespaceLabel &&
+		other.DisablePipelineLabel == cfg.DisablePipelineLabel &&
+		other.DisableTaskLabel == cfg.DisableTaskLabel &&
+		other.ReasonLabel == cfg.ReasonLabel &&
+		other.LabelCardinalityLimit == cfg.LabelCardinalityLimit
 }
 
 // newMetricsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -123,6 +161,7 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 		PipelinerunLevel:        DefaultPipelinerunLevel,
 		DurationTaskrunType:     DefaultDurationTaskrunType,
 		DurationPipelinerunType: DefaultDurationPipelinerunType,
+		LabelCardinalityLimit:   DefaultLabelCardinalityLimit,
 	}
 
 	if taskrunLevel, ok := cfgMap[metricsTaskrunLevelKey]; ok {
@@ -138,6 +177,30 @@ func newMetricsFromMap(cfgMap map[string]string) (*Metrics, error) {
 	if durationPipelinerun, ok := cfgMap[metricsDurationPipelinerunType]; ok {
 		tc.DurationPipelinerunType = durationPipelinerun
 	}
+	for key, set := range map[string]func(enabled bool){
+		metricsNamespaceLabelKey: func(enabled bool) { tc.DisableNamespaceLabel = !enabled },
+		metricsPipelineLabelKey:  func(enabled bool) { tc.DisablePipelineLabel = !enabled },
+		metricsTaskLabelKey:      func(enabled bool) { tc.DisableTaskLabel = !enabled },
+		metricsReasonLabelKey:    func(enabled bool) { tc.ReasonLabel = enabled },
+	} {
+		if value, ok := cfgMap[key]; ok {
+			enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing metrics config %q: %w", key, err)
+			}
+			set(enabled)
+		}
+	}
+	if limit, ok := cfgMap[metricsLabelCardinalityLimitKey]; ok {
+		value, err := strconv.Atoi(strings.TrimSpace(limit))
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing metrics config %q: %w", metricsLabelCardinalityLimitKey, err)
+		}
+		if value < 0 {
+			return nil, fmt.Errorf("metrics config %q must be positive but it is %d", metricsLabelCardinalityLimitKey, value)
+		}
+		tc.LabelCardinalityLimit = value
+	}
 	return &tc, nil
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestNewMetricsFromConfigMap(t *testing.T) {
@@ -50,6 +51,19 @@ func TestNewMetricsFromConfigMap(t *testing.T) {
 			},
 			fileName: "config-observability-namespacelevel",
 		},
+		{
+			expectedConfig: &config.Metrics{
+				TaskrunLevel:            config.TaskrunLevelAtTask,
+				PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
+				DurationTaskrunType:     config.DurationTaskrunTypeHistogram,
+				DurationPipelinerunType: config.DurationPipelinerunTypeHistogram,
+				DisableNamespaceLabel:   true,
+				DisableTaskLabel:        true,
+				ReasonLabel:             true,
+				LabelCardinalityLimit:   100,
+			},
+			fileName: "config-observability-labels",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -68,6 +82,19 @@ func TestNewMetricsFromEmptyConfigMap(t *testing.T) {
 	verifyConfigFileWithExpectedMetricsConfig(t, MetricsConfigEmptyName, expectedConfig)
 }
 
+func TestNewMetricsFromConfigMapErrors(t *testing.T) {
+	for _, data := range []map[string]string{
+		{"metrics.labels.namespace": "maybe"},
+		{"metrics.labels.reason": "1.5"},
+		{"metrics.labels.cardinality-limit": "unlimited"},
+		{"metrics.labels.cardinality-limit": "-1"},
+	} {
+		if _, err := config.NewMetricsFromConfigMap(&corev1.ConfigMap{Data: data}); err == nil {
+			t.Errorf("expected NewMetricsFromConfigMap(%v) to fail", data)
+		}
+	}
+}
+
 func verifyConfigFileWithExpectedMetricsConfig(t *testing.T, fileName string, expectedConfig *config.Metrics) {
 	t.Helper()
 	cm := test.ConfigMapFromTestFile(t, fileName)
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/logging"
+)
+
+// NamespaceDefaultsConfigName is the name of the configmap holding the defaults
+// of the namespace it is created in.
+const NamespaceDefaultsConfigName = "tekton-defaults"
+
+// NamespaceDefaults holds the defaults of a namespace, which take precedence over
+// the cluster wide Defaults for the TaskRuns and PipelineRuns created in it.
+// +k8s:deepcopy-gen=true
+type NamespaceDefaults struct {
+	// DefaultTimeoutMinutes is the default timeout of the runs, if set.
+	DefaultTimeoutMinutes *int
+	// DefaultServiceAccount is the default service account of the runs, if set.
+	DefaultServiceAccount string
+	// DefaultPodTemplate is merged into the cluster wide default pod template.
+	DefaultPodTemplate *pod.Template
+}
+
+// NewNamespaceDefaultsFromMap returns a NamespaceDefaults given a map corresponding to a ConfigMap
+func NewNamespaceDefaultsFromMap(cfgMap map[string]string) (*NamespaceDefaults, error) {
+	nd := &NamespaceDefaults{}
+	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
+		timeout, err := strconv.ParseInt(defaultTimeoutMin, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing namespace defaults config %q: %w", defaultTimeoutMinutesKey, err)
+		}
+		t := int(timeout)
+		nd.DefaultTimeoutMinutes = &t
+	}
+	if defaultServiceAccount, ok := cfgMap[defaultServiceAccountKey]; ok {
+		nd.DefaultServiceAccount = defaultServiceAccount
+	}
+	if defaultPodTemplate, ok := cfgMap[defaultPodTemplateKey]; ok {
+		var podTemplate pod.Template
+		if err := yamlUnmarshal(defaultPodTemplate, defaultPodTemplateKey, &podTemplate); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v", defaultPodTemplate)
+		}
+		nd.DefaultPodTemplate = &podTemplate
+	}
+	return nd, nil
+}
+
+// NewNamespaceDefaultsFromConfigMap returns a NamespaceDefaults for the given configmap
+func NewNamespaceDefaultsFromConfigMap(config *corev1.ConfigMap) (*NamespaceDefaults, error) {
+	return NewNamespaceDefaultsFromMap(config.Data)
+}
+
+// Apply returns a copy of the given cluster wide defaults overridden by the
+// namespace defaults.
+func (nd *NamespaceDefaults) Apply(defaults *Defaults) *Defaults {
+	d := defaults.DeepCopy()
+	if nd.DefaultTimeoutMinutes != nil {
+		d.DefaultTimeoutMinutes = *nd.DefaultTimeoutMinutes
+	}
+	if nd.DefaultServiceAccount != "" {
+		d.DefaultServiceAccount = nd.DefaultServiceAccount
+	}
+	if nd.DefaultPodTemplate != nil {
+		d.DefaultPodTemplate = pod.MergePodTemplateWithOverride(d.DefaultPodTemplate, nd.DefaultPodTemplate)
+	}
+	return d
+}
+
+type namespaceDefaultsListerKey struct{}
+
+// WithNamespaceDefaults returns a context in which the namespace defaults are
+// looked up with the given configmap lister.
+func WithNamespaceDefaults(ctx context.Context, lister corev1listers.ConfigMapLister) context.Context {
+	return context.WithValue(ctx, namespaceDefaultsListerKey{}, lister)
+}
+
+// DefaultsForNamespace returns the defaults of the given namespace: the cluster
+// wide defaults of the context, overridden by the ones of the namespace
+// defaults configmap if the context has a lister for it and it exists. An
+// invalid namespace defaults configmap is ignored.
+func DefaultsForNamespace(ctx context.Context, namespace string) *Defaults {
+	defaults := FromContextOrDefaults(ctx).Defaults
+	lister, ok := ctx.Value(namespaceDefaultsListerKey{}).(corev1listers.ConfigMapLister)
+	if !ok || namespace == "" {
+		return defaults
+	}
+	cm, err := lister.ConfigMaps(namespace).Get(NamespaceDefaultsConfigName)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			logging.FromContext(ctx).Warnf("Failed to get the defaults of namespace %s: %v", namespace, err)
+		}
+		return defaults
+	}
+	nd, err := NewNamespaceDefaultsFromConfigMap(cm)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Ignoring invalid defaults of namespace %s: %v", namespace, err)
+		return defaults
+	}
+	return nd.Apply(defaults)
+}
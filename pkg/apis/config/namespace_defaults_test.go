@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewNamespaceDefaultsFromMap(t *testing.T) {
+	zero := 0
+	for _, tc := range []struct {
+		name   string
+		data   map[string]string
+		want   *config.NamespaceDefaults
+		hasErr bool
+	}{{
+		name: "empty",
+		data: map[string]string{},
+		want: &config.NamespaceDefaults{},
+	}, {
+		name: "all defaults",
+		data: map[string]string{
+			"default-timeout-minutes": "0",
+			"default-service-account": "team-a",
+			"default-pod-template":    "nodeSelector:\n  team: a\n",
+		},
+		want: &config.NamespaceDefaults{
+			DefaultTimeoutMinutes: &zero,
+			DefaultServiceAccount: "team-a",
+			DefaultPodTemplate:    &pod.Template{NodeSelector: map[string]string{"team": "a"}},
+		},
+	}, {
+		name:   "invalid timeout",
+		data:   map[string]string{"default-timeout-minutes": "ten"},
+		hasErr: true,
+	}, {
+		name:   "invalid pod template",
+		data:   map[string]string{"default-pod-template": "nodeSelector: a"},
+		hasErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := config.NewNamespaceDefaultsFromMap(tc.data)
+			if tc.hasErr {
+				if err == nil {
+					t.Fatalf("expected an error but got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("NewNamespaceDefaultsFromMap %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestDefaultsForNamespace(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cm := range []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceDefaultsConfigName, Namespace: "team-a"},
+		Data: map[string]string{
+			"default-timeout-minutes": "120",
+			"default-service-account": "team-a",
+			"default-pod-template":    "nodeSelector:\n  team: a\n",
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceDefaultsConfigName, Namespace: "invalid"},
+		Data:       map[string]string{"default-timeout-minutes": "ten"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "other"},
+		Data:       map[string]string{"default-timeout-minutes": "120"},
+	}} {
+		if err := indexer.Add(cm); err != nil {
+			t.Fatalf("failed to add configmap: %v", err)
+		}
+	}
+	lister := corev1listers.NewConfigMapLister(indexer)
+
+	clusterDefaults := &config.Defaults{
+		DefaultTimeoutMinutes: 60,
+		DefaultServiceAccount: "default",
+		DefaultPodTemplate:    &pod.Template{SchedulerName: "cluster-scheduler"},
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{Defaults: clusterDefaults})
+
+	for _, tc := range []struct {
+		name      string
+		ctx       context.Context
+		namespace string
+		want      *config.Defaults
+	}{{
+		name:      "no lister",
+		ctx:       ctx,
+		namespace: "team-a",
+		want:      clusterDefaults,
+	}, {
+		name:      "namespace defaults",
+		ctx:       config.WithNamespaceDefaults(ctx, lister),
+		namespace: "team-a",
+		want: &config.Defaults{
+			DefaultTimeoutMinutes: 120,
+			DefaultServiceAccount: "team-a",
+			DefaultPodTemplate: &pod.Template{
+				SchedulerName: "cluster-scheduler",
+				NodeSelector:  map[string]string{"team": "a"},
+			},
+		},
+	}, {
+		name:      "invalid namespace defaults",
+		ctx:       config.WithNamespaceDefaults(ctx, lister),
+		namespace: "invalid",
+		want:      clusterDefaults,
+	}, {
+		name:      "no namespace defaults",
+		ctx:       config.WithNamespaceDefaults(ctx, lister),
+		namespace: "other",
+		want:      clusterDefaults,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := config.DefaultsForNamespace(tc.ctx, tc.namespace)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("DefaultsForNamespace %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+	if d := cmp.Diff(&pod.Template{SchedulerName: "cluster-scheduler"}, clusterDefaults.DefaultPodTemplate); d != "" {
+		t.Errorf("DefaultsForNamespace modified the cluster defaults %s", diff.PrintWantGot(d))
+	}
+}
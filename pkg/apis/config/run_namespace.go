@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RunNamespaceConfigMapName is the name of the run namespace configmap
+	RunNamespaceConfigMapName = "config-run-namespace"
+
+	// DefaultRunNamespaceClusterRole is the ClusterRole bound by default to the
+	// service accounts of a PipelineRun in its run namespace, installed with the
+	// permissions of the controller to provision run namespaces.
+	DefaultRunNamespaceClusterRole = "tekton-pipelines-run-namespace"
+
+	runNamespaceEnabledKey       = "enabled"
+	runNamespaceClusterRoleKey   = "cluster-role"
+	runNamespaceResourceQuotaKey = "resource-quota"
+	runNamespaceLimitRangeKey    = "limit-range"
+)
+
+// DefaultRunNamespace holds the default run namespace configuration, with run namespaces disabled.
+var DefaultRunNamespace, _ = NewRunNamespaceFromMap(map[string]string{})
+
+// RunNamespace holds the configuration of the temporary namespaces provisioned
+// for the PipelineRuns requesting one, and deleted when they are done.
+// +k8s:deepcopy-gen=true
+type RunNamespace struct {
+	// Enabled is whether PipelineRuns can request a run namespace.
+	Enabled bool
+	// ClusterRole is the ClusterRole bound to the service accounts of the
+	// PipelineRun in its run namespace.
+	ClusterRole string
+	// ResourceQuota is the spec of the ResourceQuota created in the run
+	// namespace, if any.
+	ResourceQuota *corev1.ResourceQuotaSpec
+	// LimitRange is the spec of the LimitRange created in the run namespace,
+	// if any.
+	LimitRange *corev1.LimitRangeSpec
+}
+
+// NewRunNamespaceFromMap returns a RunNamespace given a map corresponding to a ConfigMap
+func NewRunNamespaceFromMap(cfgMap map[string]string) (*RunNamespace, error) {
+	rn := &RunNamespace{ClusterRole: DefaultRunNamespaceClusterRole}
+	if enabled, ok := cfgMap[runNamespaceEnabledKey]; ok {
+		b, err := strconv.ParseBool(enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing run namespace config %q: %w", runNamespaceEnabledKey, err)
+		}
+		rn.Enabled = b
+	}
+	if clusterRole, ok := cfgMap[runNamespaceClusterRoleKey]; ok {
+		if clusterRole == "" {
+			return nil, fmt.Errorf("run namespace config %q must not be empty", runNamespaceClusterRoleKey)
+		}
+		rn.ClusterRole = clusterRole
+	}
+	if resourceQuota, ok := cfgMap[runNamespaceResourceQuotaKey]; ok {
+		rn.ResourceQuota = &corev1.ResourceQuotaSpec{}
+		if err := yaml.UnmarshalStrict([]byte(resourceQuota), rn.ResourceQuota); err != nil {
+			return nil, fmt.Errorf("failed parsing run namespace config %q: %w", runNamespaceResourceQuotaKey, err)
+		}
+	}
+	if limitRange, ok := cfgMap[runNamespaceLimitRangeKey]; ok {
+		rn.LimitRange = &corev1.LimitRangeSpec{}
+		if err := yaml.UnmarshalStrict([]byte(limitRange), rn.LimitRange); err != nil {
+			return nil, fmt.Errorf("failed parsing run namespace config %q: %w", runNamespaceLimitRangeKey, err)
+		}
+	}
+	return rn, nil
+}
+
+// NewRunNamespaceFromConfigMap returns a RunNamespace for the given configmap
+func NewRunNamespaceFromConfigMap(config *corev1.ConfigMap) (*RunNamespace, error) {
+	return NewRunNamespaceFromMap(config.Data)
+}
+
+// GetRunNamespaceConfigName returns the name of the configmap containing the
+// run namespace configuration.
+func GetRunNamespaceConfigName() string {
+	if e := os.Getenv("CONFIG_RUN_NAMESPACE_NAME"); e != "" {
+		return e
+	}
+	return RunNamespaceConfigMapName
+}
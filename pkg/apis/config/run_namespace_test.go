@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestNewRunNamespaceFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		want     *config.RunNamespace
+		fileName string
+	}{{
+		want: &config.RunNamespace{
+			Enabled:     true,
+			ClusterRole: "integration-tests",
+			ResourceQuota: &corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{
+					corev1.ResourcePods:        resource.MustParse("10"),
+					corev1.ResourceRequestsCPU: resource.MustParse("4"),
+				},
+			},
+			LimitRange: &corev1.LimitRangeSpec{
+				Limits: []corev1.LimitRangeItem{{
+					Type: corev1.LimitTypeContainer,
+					DefaultRequest: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("100m"),
+					},
+				}},
+			},
+		},
+		fileName: config.GetRunNamespaceConfigName(),
+	}, {
+		want:     &config.RunNamespace{ClusterRole: config.DefaultRunNamespaceClusterRole},
+		fileName: "config-run-namespace-empty",
+	}} {
+		cm := test.ConfigMapFromTestFile(t, tc.fileName)
+		if got, err := config.NewRunNamespaceFromConfigMap(cm); err == nil {
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		} else {
+			t.Errorf("NewRunNamespaceFromConfigMap(actual) = %v", err)
+		}
+	}
+}
+
+func TestNewRunNamespaceFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-run-namespace-invalid-enabled",
+		"config-run-namespace-invalid-resource-quota",
+	} {
+		cm := test.ConfigMapFromTestFile(t, fileName)
+		if _, err := config.NewRunNamespaceFromConfigMap(cm); err == nil {
+			t.Errorf("NewRunNamespaceFromConfigMap(%s) was expected to return an error", fileName)
+		}
+	}
+}
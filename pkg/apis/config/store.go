@@ -28,10 +28,16 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	Defaults     *Defaults
-	FeatureFlags *FeatureFlags
-	Metrics      *Metrics
-	SpireConfig  *sc.SpireConfig
+	Defaults         *Defaults
+	FeatureFlags     *FeatureFlags
+	Metrics          *Metrics
+	SpireConfig      *sc.SpireConfig
+	RunNamespace     *RunNamespace
+	LogForwarding    *LogForwarding
+	FaultInjection   *FaultInjection
+	Events           *Events
+	ImageMirrors     *ImageMirrors
+	ImagePullSecrets *ImagePullSecrets
 }
 
 // FromContext extracts a Config from the provided context.
@@ -51,10 +57,16 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	}
 
 	return &Config{
-		Defaults:     DefaultConfig.DeepCopy(),
-		FeatureFlags: DefaultFeatureFlags.DeepCopy(),
-		Metrics:      DefaultMetrics.DeepCopy(),
-		SpireConfig:  DefaultSpire.DeepCopy(),
+		Defaults:         DefaultConfig.DeepCopy(),
+		FeatureFlags:     DefaultFeatureFlags.DeepCopy(),
+		Metrics:          DefaultMetrics.DeepCopy(),
+		SpireConfig:      DefaultSpire.DeepCopy(),
+		RunNamespace:     DefaultRunNamespace.DeepCopy(),
+		LogForwarding:    DefaultLogForwarding.DeepCopy(),
+		FaultInjection:   DefaultFaultInjection.DeepCopy(),
+		Events:           DefaultEvents.DeepCopy(),
+		ImageMirrors:     DefaultImageMirrors.DeepCopy(),
+		ImagePullSecrets: DefaultImagePullSecrets.DeepCopy(),
 	}
 }
 
@@ -76,12 +88,7 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 		UntypedStore: configmap.NewUntypedStore(
 			"defaults/features/artifacts",
 			logger,
-			configmap.Constructors{
-				GetDefaultsConfigName():     NewDefaultsFromConfigMap,
-				GetFeatureFlagsConfigName(): NewFeatureFlagsFromConfigMap,
-				GetMetricsConfigName():      NewMetricsFromConfigMap,
-				GetSpireConfigName():        NewSpireConfigFromConfigMap,
-			},
+			Constructors(),
 			onAfterStore...,
 		),
 	}
@@ -89,6 +96,23 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 	return store
 }
 
+// Constructors returns the constructors of the configmaps of the Store, keyed by
+// the names of the configmaps.
+func Constructors() configmap.Constructors {
+	return configmap.Constructors{
+		GetDefaultsConfigName():         NewDefaultsFromConfigMap,
+		GetFeatureFlagsConfigName():     NewFeatureFlagsFromConfigMap,
+		GetMetricsConfigName():          NewMetricsFromConfigMap,
+		GetSpireConfigName():            NewSpireConfigFromConfigMap,
+		GetRunNamespaceConfigName():     NewRunNamespaceFromConfigMap,
+		GetLogForwardingConfigName():    NewLogForwardingFromConfigMap,
+		GetFaultInjectionConfigName():   NewFaultInjectionFromConfigMap,
+		GetEventsConfigName():           NewEventsFromConfigMap,
+		GetImageMirrorsConfigName():     NewImageMirrorsFromConfigMap,
+		GetImagePullSecretsConfigName(): NewImagePullSecretsFromConfigMap,
+	}
+}
+
 // ToContext attaches the current Config state to the provided context.
 func (s *Store) ToContext(ctx context.Context) context.Context {
 	return ToContext(ctx, s.Load())
@@ -113,11 +137,41 @@ func (s *Store) Load() *Config {
 	if spireconfig == nil {
 		spireconfig = DefaultSpire.DeepCopy()
 	}
+	runNamespace := s.UntypedLoad(GetRunNamespaceConfigName())
+	if runNamespace == nil {
+		runNamespace = DefaultRunNamespace.DeepCopy()
+	}
+	logForwarding := s.UntypedLoad(GetLogForwardingConfigName())
+	if logForwarding == nil {
+		logForwarding = DefaultLogForwarding.DeepCopy()
+	}
+	faultInjection := s.UntypedLoad(GetFaultInjectionConfigName())
+	if faultInjection == nil {
+		faultInjection = DefaultFaultInjection.DeepCopy()
+	}
+	events := s.UntypedLoad(GetEventsConfigName())
+	if events == nil {
+		events = DefaultEvents.DeepCopy()
+	}
+	imageMirrors := s.UntypedLoad(GetImageMirrorsConfigName())
+	if imageMirrors == nil {
+		imageMirrors = DefaultImageMirrors.DeepCopy()
+	}
+	imagePullSecrets := s.UntypedLoad(GetImagePullSecretsConfigName())
+	if imagePullSecrets == nil {
+		imagePullSecrets = DefaultImagePullSecrets.DeepCopy()
+	}
 
 	return &Config{
-		Defaults:     defaults.(*Defaults).DeepCopy(),
-		FeatureFlags: featureFlags.(*FeatureFlags).DeepCopy(),
-		Metrics:      metrics.(*Metrics).DeepCopy(),
-		SpireConfig:  spireconfig.(*sc.SpireConfig).DeepCopy(),
+		Defaults:         defaults.(*Defaults).DeepCopy(),
+		FeatureFlags:     featureFlags.(*FeatureFlags).DeepCopy(),
+		Metrics:          metrics.(*Metrics).DeepCopy(),
+		SpireConfig:      spireconfig.(*sc.SpireConfig).DeepCopy(),
+		RunNamespace:     runNamespace.(*RunNamespace).DeepCopy(),
+		LogForwarding:    logForwarding.(*LogForwarding).DeepCopy(),
+		FaultInjection:   faultInjection.(*FaultInjection).DeepCopy(),
+		Events:           events.(*Events).DeepCopy(),
+		ImageMirrors:     imageMirrors.(*ImageMirrors).DeepCopy(),
+		ImagePullSecrets: imagePullSecrets.(*ImagePullSecrets).DeepCopy(),
 	}
 }
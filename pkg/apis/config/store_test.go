@@ -32,17 +32,35 @@ func TestStoreLoadWithContext(t *testing.T) {
 	featuresConfig := test.ConfigMapFromTestFile(t, "feature-flags-all-flags-set")
 	metricsConfig := test.ConfigMapFromTestFile(t, "config-observability")
 	spireConfig := test.ConfigMapFromTestFile(t, "config-spire")
+	runNamespaceConfig := test.ConfigMapFromTestFile(t, "config-run-namespace")
+	logForwardingConfig := test.ConfigMapFromTestFile(t, "config-log-forwarding")
+	faultInjectionConfig := test.ConfigMapFromTestFile(t, "config-fault-injection")
+	eventsConfig := test.ConfigMapFromTestFile(t, "config-events")
+	imageMirrorsConfig := test.ConfigMapFromTestFile(t, "config-image-mirrors")
+	imagePullSecretsConfig := test.ConfigMapFromTestFile(t, "config-image-pull-secrets")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
 	metrics, _ := config.NewMetricsFromConfigMap(metricsConfig)
 	expectedSpireConfig, _ := config.NewSpireConfigFromConfigMap(spireConfig)
+	expectedRunNamespace, _ := config.NewRunNamespaceFromConfigMap(runNamespaceConfig)
+	expectedLogForwarding, _ := config.NewLogForwardingFromConfigMap(logForwardingConfig)
+	expectedFaultInjection, _ := config.NewFaultInjectionFromConfigMap(faultInjectionConfig)
+	expectedEvents, _ := config.NewEventsFromConfigMap(eventsConfig)
+	expectedImageMirrors, _ := config.NewImageMirrorsFromConfigMap(imageMirrorsConfig)
+	expectedImagePullSecrets, _ := config.NewImagePullSecretsFromConfigMap(imagePullSecretsConfig)
 
 	expected := &config.Config{
-		Defaults:     expectedDefaults,
-		FeatureFlags: expectedFeatures,
-		Metrics:      metrics,
-		SpireConfig:  expectedSpireConfig,
+		Defaults:         expectedDefaults,
+		FeatureFlags:     expectedFeatures,
+		Metrics:          metrics,
+		SpireConfig:      expectedSpireConfig,
+		RunNamespace:     expectedRunNamespace,
+		LogForwarding:    expectedLogForwarding,
+		FaultInjection:   expectedFaultInjection,
+		Events:           expectedEvents,
+		ImageMirrors:     expectedImageMirrors,
+		ImagePullSecrets: expectedImagePullSecrets,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -50,6 +68,12 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(featuresConfig)
 	store.OnConfigChanged(metricsConfig)
 	store.OnConfigChanged(spireConfig)
+	store.OnConfigChanged(runNamespaceConfig)
+	store.OnConfigChanged(logForwardingConfig)
+	store.OnConfigChanged(faultInjectionConfig)
+	store.OnConfigChanged(eventsConfig)
+	store.OnConfigChanged(imageMirrorsConfig)
+	store.OnConfigChanged(imagePullSecretsConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
@@ -60,10 +84,16 @@ func TestStoreLoadWithContext(t *testing.T) {
 
 func TestStoreLoadWithContext_Empty(t *testing.T) {
 	want := &config.Config{
-		Defaults:     config.DefaultConfig.DeepCopy(),
-		FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
-		Metrics:      config.DefaultMetrics.DeepCopy(),
-		SpireConfig:  config.DefaultSpire.DeepCopy(),
+		Defaults:         config.DefaultConfig.DeepCopy(),
+		FeatureFlags:     config.DefaultFeatureFlags.DeepCopy(),
+		Metrics:          config.DefaultMetrics.DeepCopy(),
+		SpireConfig:      config.DefaultSpire.DeepCopy(),
+		RunNamespace:     config.DefaultRunNamespace.DeepCopy(),
+		LogForwarding:    config.DefaultLogForwarding.DeepCopy(),
+		FaultInjection:   config.DefaultFaultInjection.DeepCopy(),
+		Events:           config.DefaultEvents.DeepCopy(),
+		ImageMirrors:     config.DefaultImageMirrors.DeepCopy(),
+		ImagePullSecrets: config.DefaultImagePullSecrets.DeepCopy(),
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -23,6 +23,8 @@ package config
 
 import (
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -43,6 +45,16 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DefaultMaxScratchSize != nil {
+		in, out := &in.DefaultMaxScratchSize, &out.DefaultMaxScratchSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DefaultBuildHeavyStepMemory != nil {
+		in, out := &in.DefaultBuildHeavyStepMemory, &out.DefaultBuildHeavyStepMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 	return
 }
 
@@ -56,6 +68,68 @@ func (in *Defaults) DeepCopy() *Defaults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Events) DeepCopyInto(out *Events) {
+	*out = *in
+	if in.KafkaBrokers != nil {
+		in, out := &in.KafkaBrokers, &out.KafkaBrokers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterEventTypes != nil {
+		in, out := &in.FilterEventTypes, &out.FilterEventTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterNamespaces != nil {
+		in, out := &in.FilterNamespaces, &out.FilterNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SinkAudiences != nil {
+		in, out := &in.SinkAudiences, &out.SinkAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Events.
+func (in *Events) DeepCopy() *Events {
+	if in == nil {
+		return nil
+	}
+	out := new(Events)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjection) DeepCopyInto(out *FaultInjection) {
+	*out = *in
+	if in.Faults != nil {
+		in, out := &in.Faults, &out.Faults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjection.
+func (in *FaultInjection) DeepCopy() *FaultInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureFlags) DeepCopyInto(out *FeatureFlags) {
 	*out = *in
@@ -72,6 +146,122 @@ func (in *FeatureFlags) DeepCopy() *FeatureFlags {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageMirrors) DeepCopyInto(out *ImageMirrors) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirrors.
+func (in *ImageMirrors) DeepCopy() *ImageMirrors {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageMirrors)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecrets) DeepCopyInto(out *ImagePullSecrets) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]ImagePullSecretsPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecrets.
+func (in *ImagePullSecrets) DeepCopy() *ImagePullSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePullSecretsPolicy) DeepCopyInto(out *ImagePullSecretsPolicy) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]v1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullSecretsPolicy.
+func (in *ImagePullSecretsPolicy) DeepCopy() *ImagePullSecretsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullSecretsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LeaderElection) DeepCopyInto(out *LeaderElection) {
+	*out = *in
+	if in.ReconcilerBuckets != nil {
+		in, out := &in.ReconcilerBuckets, &out.ReconcilerBuckets
+		*out = make(map[string]uint32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LeaderElection.
+func (in *LeaderElection) DeepCopy() *LeaderElection {
+	if in == nil {
+		return nil
+	}
+	out := new(LeaderElection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogForwarding) DeepCopyInto(out *LogForwarding) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogForwarding.
+func (in *LogForwarding) DeepCopy() *LogForwarding {
+	if in == nil {
+		return nil
+	}
+	out := new(LogForwarding)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -87,3 +277,55 @@ func (in *Metrics) DeepCopy() *Metrics {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaults) DeepCopyInto(out *NamespaceDefaults) {
+	*out = *in
+	if in.DefaultTimeoutMinutes != nil {
+		in, out := &in.DefaultTimeoutMinutes, &out.DefaultTimeoutMinutes
+		*out = new(int)
+		**out = **in
+	}
+	if in.DefaultPodTemplate != nil {
+		in, out := &in.DefaultPodTemplate, &out.DefaultPodTemplate
+		*out = new(pod.Template)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaults.
+func (in *NamespaceDefaults) DeepCopy() *NamespaceDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunNamespace) DeepCopyInto(out *RunNamespace) {
+	*out = *in
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(v1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunNamespace.
+func (in *RunNamespace) DeepCopy() *RunNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(RunNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
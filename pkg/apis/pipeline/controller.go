@@ -32,6 +32,9 @@ const (
 	// ClusterTaskControllerName holds the name of the Task controller
 	ClusterTaskControllerName = "ClusterTask"
 
+	// ClusterPipelineControllerName holds the name of the ClusterPipeline controller
+	ClusterPipelineControllerName = "ClusterPipeline"
+
 	// RunControllerName holds the name of the Custom Task controller
 	RunControllerName = "Run"
 
@@ -28,6 +28,8 @@ type Images struct {
 	EntrypointImage string
 	// SidecarLogResultsImage is container image containing the binary that fetches results from the steps and logs it to stdout.
 	SidecarLogResultsImage string
+	// LogForwarderImage is container image containing the binary that ships the logs of the steps to the configured sink.
+	LogForwarderImage string
 	// NopImage is the container image used to kill sidecars.
 	NopImage string
 	// ShellImage is the container image containing bash shell.
@@ -48,6 +50,7 @@ func (i Images) Validate() error {
 	}{
 		{i.EntrypointImage, "entrypoint-image"},
 		{i.SidecarLogResultsImage, "sidecarlogresults-image"},
+		{i.LogForwarderImage, "logforwarder-image"},
 		{i.NopImage, "nop-image"},
 		{i.ShellImage, "shell-image"},
 		{i.ShellImageWin, "shell-image-win"},
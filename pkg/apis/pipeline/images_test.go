@@ -26,6 +26,7 @@ func TestValidate(t *testing.T) {
 	valid := pipeline.Images{
 		EntrypointImage:        "set",
 		SidecarLogResultsImage: "set",
+		LogForwarderImage:      "set",
 		NopImage:               "set",
 		ShellImage:             "set",
 		ShellImageWin:          "set",
@@ -39,6 +40,7 @@ func TestValidate(t *testing.T) {
 		EntrypointImage:        "set",
 		SidecarLogResultsImage: "set",
 		NopImage:               "set",
+		LogForwarderImage:      "set",
 		ShellImage:             "", // unset!
 		ShellImageWin:          "set",
 	}
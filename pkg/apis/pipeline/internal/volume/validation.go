@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volume validates the volume sources of the workspace bindings of all the API versions.
+package volume
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
+)
+
+// allProjectionFields is a list of all the projection field paths that a source
+// of a projected WorkspaceBinding may include.
+var allProjectionFields = []string{
+	"secret",
+	"configMap",
+	"downwardAPI",
+	"serviceAccountToken",
+}
+
+const (
+	// minTokenExpirationSeconds and maxTokenExpirationSeconds bound the expirationSeconds
+	// of a projected service account token, as enforced by Kubernetes.
+	minTokenExpirationSeconds = 10 * 60
+	maxTokenExpirationSeconds = 1 << 32
+)
+
+// ValidateProjectedVolumeSource validates that every source of a projected volume sets exactly
+// one valid projection, and that the files projected by the sources have distinct paths. The
+// serviceAccountToken and downwardAPI projections are only supported when the beta feature gate
+// is enabled.
+func ValidateProjectedVolumeSource(ctx context.Context, p *corev1.ProjectedVolumeSource) (errs *apis.FieldError) {
+	if len(p.Sources) == 0 {
+		return apis.ErrMissingField("sources")
+	}
+	paths := sets.NewString()
+	for i, source := range p.Sources {
+		errs = errs.Also(validateVolumeProjection(ctx, source, paths).ViaFieldIndex("sources", i))
+	}
+	return errs
+}
+
+func validateVolumeProjection(ctx context.Context, source corev1.VolumeProjection, paths sets.String) (errs *apis.FieldError) {
+	var projections []string
+	if source.Secret != nil {
+		projections = append(projections, "secret")
+	}
+	if source.ConfigMap != nil {
+		projections = append(projections, "configMap")
+	}
+	if source.DownwardAPI != nil {
+		projections = append(projections, "downwardAPI")
+	}
+	if source.ServiceAccountToken != nil {
+		projections = append(projections, "serviceAccountToken")
+	}
+	if len(projections) == 0 {
+		return apis.ErrMissingOneOf(allProjectionFields...)
+	}
+	if len(projections) > 1 {
+		return apis.ErrMultipleOneOf(projections...)
+	}
+
+	switch {
+	case source.Secret != nil:
+		if source.Secret.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("secret.name"))
+		}
+		errs = errs.Also(validateKeyToPaths(source.Secret.Items, paths).ViaField("secret"))
+	case source.ConfigMap != nil:
+		if source.ConfigMap.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("configMap.name"))
+		}
+		errs = errs.Also(validateKeyToPaths(source.ConfigMap.Items, paths).ViaField("configMap"))
+	case source.DownwardAPI != nil:
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "projected workspace downwardAPI source", config.BetaAPIFields).ViaField("downwardAPI"))
+		for i, item := range source.DownwardAPI.Items {
+			errs = errs.Also(validateDownwardAPIVolumeFile(item, paths).ViaFieldIndex("items", i).ViaField("downwardAPI"))
+		}
+	case source.ServiceAccountToken != nil:
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "projected workspace serviceAccountToken source", config.BetaAPIFields).ViaField("serviceAccountToken"))
+		errs = errs.Also(validateServiceAccountTokenProjection(source.ServiceAccountToken, paths).ViaField("serviceAccountToken"))
+	}
+	return errs
+}
+
+func validateKeyToPaths(items []corev1.KeyToPath, paths sets.String) (errs *apis.FieldError) {
+	for i, item := range items {
+		if item.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("key").ViaFieldIndex("items", i))
+		}
+		errs = errs.Also(validateProjectedPath(item.Path, paths).ViaFieldIndex("items", i))
+	}
+	return errs
+}
+
+func validateDownwardAPIVolumeFile(item corev1.DownwardAPIVolumeFile, paths sets.String) (errs *apis.FieldError) {
+	errs = errs.Also(validateProjectedPath(item.Path, paths))
+	switch {
+	case item.FieldRef == nil && item.ResourceFieldRef == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("fieldRef", "resourceFieldRef"))
+	case item.FieldRef != nil && item.ResourceFieldRef != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("fieldRef", "resourceFieldRef"))
+	case item.FieldRef != nil && item.FieldRef.FieldPath == "":
+		errs = errs.Also(apis.ErrMissingField("fieldRef.fieldPath"))
+	case item.ResourceFieldRef != nil && item.ResourceFieldRef.Resource == "":
+		errs = errs.Also(apis.ErrMissingField("resourceFieldRef.resource"))
+	}
+	return errs
+}
+
+func validateServiceAccountTokenProjection(token *corev1.ServiceAccountTokenProjection, paths sets.String) (errs *apis.FieldError) {
+	errs = errs.Also(validateProjectedPath(token.Path, paths))
+	if token.ExpirationSeconds != nil && (*token.ExpirationSeconds < minTokenExpirationSeconds || *token.ExpirationSeconds > maxTokenExpirationSeconds) {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(*token.ExpirationSeconds, minTokenExpirationSeconds, maxTokenExpirationSeconds, "expirationSeconds"))
+	}
+	return errs
+}
+
+// validateProjectedPath validates that the path of a projected file is relative, does not
+// escape the volume, and is not the path of another projected file, which it records in paths.
+func validateProjectedPath(path string, paths sets.String) *apis.FieldError {
+	switch {
+	case path == "":
+		return apis.ErrMissingField("path")
+	case strings.HasPrefix(path, "/"):
+		return apis.ErrInvalidValue(path, "path", "must be a relative path")
+	case sets.NewString(strings.Split(path, "/")...).Has(".."):
+		return apis.ErrInvalidValue(path, "path", "must not contain '..'")
+	case paths.Has(path):
+		return apis.ErrInvalidValue(path, "path", "conflicts with the path of another projection")
+	}
+	paths.Insert(path)
+	return nil
+}
+
+// ValidateSubPath validates that the subPath of a workspace is relative and does not escape
+// its volume, as the Pods mounting it would otherwise be rejected.
+func ValidateSubPath(subPath string) *apis.FieldError {
+	switch {
+	case strings.HasPrefix(subPath, "/"):
+		return apis.ErrInvalidValue(subPath, "subPath", "must be a relative path")
+	case sets.NewString(strings.Split(subPath, "/")...).Has(".."):
+		return apis.ErrInvalidValue(subPath, "subPath", "must not contain '..'")
+	}
+	return nil
+}
+
+// ValidateCSIVolumeSource validates that a CSI volume names its driver and, when it
+// references a secret to publish the volume, the name of that secret.
+func ValidateCSIVolumeSource(csi *corev1.CSIVolumeSource) (errs *apis.FieldError) {
+	if csi.Driver == "" {
+		errs = errs.Also(apis.ErrMissingField("driver"))
+	}
+	if csi.NodePublishSecretRef != nil && csi.NodePublishSecretRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("nodePublishSecretRef.name"))
+	}
+	return errs
+}
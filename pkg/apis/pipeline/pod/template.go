@@ -254,3 +254,166 @@ func MergeAAPodTemplateWithDefault(tpl, defaultTpl *AAPodTemplate) *AAPodTemplat
 		return tpl
 	}
 }
+
+// MergePodTemplateWithOverride deep merges 2 PodTemplates together, with the
+// values from overrideTpl refining the values from tpl rather than replacing
+// them. It is used to merge the pod template of a PipelineTask with the pod
+// template of its PipelineRun. Neither template is modified.
+//
+//   - Scalar fields set in overrideTpl overwrite the ones from tpl.
+//   - NodeSelector entries are merged, overrideTpl winning on the same key.
+//   - Env, Volumes and ImagePullSecrets are merged by name, HostAliases by IP
+//     and TopologySpreadConstraints by topology key, overrideTpl winning.
+//   - Tolerations are added to the ones of tpl.
+//   - The fields of SecurityContext and DNSConfig set in overrideTpl overwrite
+//     the ones from tpl.
+//   - The node affinity, pod affinity and pod anti-affinity set in overrideTpl
+//     overwrite the ones from tpl.
+//   - HostNetwork is enabled if it is enabled in either template.
+func MergePodTemplateWithOverride(tpl, overrideTpl *PodTemplate) *PodTemplate {
+	switch {
+	case overrideTpl == nil:
+		return tpl.DeepCopy()
+	case tpl == nil:
+		return overrideTpl.DeepCopy()
+	}
+	merged := tpl.DeepCopy()
+	override := overrideTpl.DeepCopy()
+
+	if override.NodeSelector != nil {
+		if merged.NodeSelector == nil {
+			merged.NodeSelector = map[string]string{}
+		}
+		for k, v := range override.NodeSelector {
+			merged.NodeSelector[k] = v
+		}
+	}
+	for _, e := range override.Env {
+		merged.Env = mergeEnvVar(merged.Env, e)
+	}
+	for _, t := range override.Tolerations {
+		merged.Tolerations = addToleration(merged.Tolerations, t)
+	}
+	if override.Affinity != nil {
+		if merged.Affinity == nil {
+			merged.Affinity = &corev1.Affinity{}
+		}
+		mergeSetFields(merged.Affinity, override.Affinity)
+	}
+	if override.SecurityContext != nil {
+		if merged.SecurityContext == nil {
+			merged.SecurityContext = &corev1.PodSecurityContext{}
+		}
+		mergeSetFields(merged.SecurityContext, override.SecurityContext)
+	}
+	for _, v := range override.Volumes {
+		merged.Volumes = mergeVolume(merged.Volumes, v)
+	}
+	if override.RuntimeClassName != nil {
+		merged.RuntimeClassName = override.RuntimeClassName
+	}
+	if override.AutomountServiceAccountToken != nil {
+		merged.AutomountServiceAccountToken = override.AutomountServiceAccountToken
+	}
+	if override.DNSPolicy != nil {
+		merged.DNSPolicy = override.DNSPolicy
+	}
+	if override.DNSConfig != nil {
+		if merged.DNSConfig == nil {
+			merged.DNSConfig = &corev1.PodDNSConfig{}
+		}
+		mergeSetFields(merged.DNSConfig, override.DNSConfig)
+	}
+	if override.EnableServiceLinks != nil {
+		merged.EnableServiceLinks = override.EnableServiceLinks
+	}
+	if override.PriorityClassName != nil {
+		merged.PriorityClassName = override.PriorityClassName
+	}
+	if override.SchedulerName != "" {
+		merged.SchedulerName = override.SchedulerName
+	}
+	for _, s := range override.ImagePullSecrets {
+		merged.ImagePullSecrets = mergeImagePullSecret(merged.ImagePullSecrets, s)
+	}
+	for _, h := range override.HostAliases {
+		merged.HostAliases = mergeHostAlias(merged.HostAliases, h)
+	}
+	if override.HostNetwork {
+		merged.HostNetwork = true
+	}
+	for _, c := range override.TopologySpreadConstraints {
+		merged.TopologySpreadConstraints = mergeTopologySpreadConstraint(merged.TopologySpreadConstraints, c)
+	}
+	return merged
+}
+
+// mergeSetFields sets the fields of the struct pointed to by dst to the
+// fields of the struct pointed to by src which are not zero.
+func mergeSetFields(dst, src interface{}) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Field(i).IsZero() {
+			d.Field(i).Set(s.Field(i))
+		}
+	}
+}
+
+func mergeEnvVar(envs []corev1.EnvVar, env corev1.EnvVar) []corev1.EnvVar {
+	for i := range envs {
+		if envs[i].Name == env.Name {
+			envs[i] = env
+			return envs
+		}
+	}
+	return append(envs, env)
+}
+
+func addToleration(tolerations []corev1.Toleration, toleration corev1.Toleration) []corev1.Toleration {
+	for _, t := range tolerations {
+		if reflect.DeepEqual(t, toleration) {
+			return tolerations
+		}
+	}
+	return append(tolerations, toleration)
+}
+
+func mergeVolume(volumes []corev1.Volume, volume corev1.Volume) []corev1.Volume {
+	for i := range volumes {
+		if volumes[i].Name == volume.Name {
+			volumes[i] = volume
+			return volumes
+		}
+	}
+	return append(volumes, volume)
+}
+
+func mergeImagePullSecret(secrets []corev1.LocalObjectReference, secret corev1.LocalObjectReference) []corev1.LocalObjectReference {
+	for _, s := range secrets {
+		if s.Name == secret.Name {
+			return secrets
+		}
+	}
+	return append(secrets, secret)
+}
+
+func mergeHostAlias(aliases []corev1.HostAlias, alias corev1.HostAlias) []corev1.HostAlias {
+	for i := range aliases {
+		if aliases[i].IP == alias.IP {
+			aliases[i] = alias
+			return aliases
+		}
+	}
+	return append(aliases, alias)
+}
+
+func mergeTopologySpreadConstraint(constraints []corev1.TopologySpreadConstraint, constraint corev1.TopologySpreadConstraint) []corev1.TopologySpreadConstraint {
+	for i := range constraints {
+		if constraints[i].TopologyKey == constraint.TopologyKey {
+			constraints[i] = constraint
+			return constraints
+		}
+	}
+	return append(constraints, constraint)
+}
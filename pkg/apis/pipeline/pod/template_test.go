@@ -0,0 +1,237 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMergePodTemplateWithOverride(t *testing.T) {
+	runAsUser := int64(1000)
+	runAsGroup := int64(2000)
+	otherRunAsUser := int64(3000)
+	runAsNonRoot := true
+	runtimeClassName := "gvisor"
+	otherRuntimeClassName := "kata"
+	priorityClassName := "high"
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "zone",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"a"},
+				}},
+			}},
+		},
+	}
+	podAntiAffinity := &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{
+			Weight:          100,
+			PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: "kubernetes.io/hostname"},
+		}},
+	}
+	otherNodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "zone",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"b"},
+				}},
+			}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name          string
+		tpl, override *pod.PodTemplate
+		want          *pod.PodTemplate
+	}{{
+		name: "nil templates",
+	}, {
+		name: "nil override",
+		tpl:  &pod.PodTemplate{SchedulerName: "default"},
+		want: &pod.PodTemplate{SchedulerName: "default"},
+	}, {
+		name:     "nil template",
+		override: &pod.PodTemplate{SchedulerName: "task"},
+		want:     &pod.PodTemplate{SchedulerName: "task"},
+	}, {
+		name: "scalars",
+		tpl: &pod.PodTemplate{
+			SchedulerName:     "default",
+			RuntimeClassName:  &runtimeClassName,
+			PriorityClassName: &priorityClassName,
+			HostNetwork:       true,
+		},
+		override: &pod.PodTemplate{
+			SchedulerName:    "task",
+			RuntimeClassName: &otherRuntimeClassName,
+		},
+		want: &pod.PodTemplate{
+			SchedulerName:     "task",
+			RuntimeClassName:  &otherRuntimeClassName,
+			PriorityClassName: &priorityClassName,
+			HostNetwork:       true,
+		},
+	}, {
+		name: "node selector and tolerations",
+		tpl: &pod.PodTemplate{
+			NodeSelector: map[string]string{"arch": "amd64", "disktype": "hdd"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "ci",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+		override: &pod.PodTemplate{
+			NodeSelector: map[string]string{"disktype": "ssd"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "ci",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}, {
+				Key:      "gpu",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+		want: &pod.PodTemplate{
+			NodeSelector: map[string]string{"arch": "amd64", "disktype": "ssd"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "ci",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}, {
+				Key:      "gpu",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+	}, {
+		name: "security context, affinity and dns config",
+		tpl: &pod.PodTemplate{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:    &runAsUser,
+				RunAsGroup:   &runAsGroup,
+				RunAsNonRoot: &runAsNonRoot,
+			},
+			Affinity: &corev1.Affinity{
+				NodeAffinity:    nodeAffinity,
+				PodAntiAffinity: podAntiAffinity,
+			},
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"1.1.1.1"},
+				Searches:    []string{"svc.cluster.local"},
+			},
+		},
+		override: &pod.PodTemplate{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser: &otherRunAsUser,
+			},
+			Affinity: &corev1.Affinity{
+				NodeAffinity: otherNodeAffinity,
+			},
+			DNSConfig: &corev1.PodDNSConfig{
+				Searches: []string{"ns.svc.cluster.local"},
+			},
+		},
+		want: &pod.PodTemplate{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsUser:    &otherRunAsUser,
+				RunAsGroup:   &runAsGroup,
+				RunAsNonRoot: &runAsNonRoot,
+			},
+			Affinity: &corev1.Affinity{
+				NodeAffinity:    otherNodeAffinity,
+				PodAntiAffinity: podAntiAffinity,
+			},
+			DNSConfig: &corev1.PodDNSConfig{
+				Nameservers: []string{"1.1.1.1"},
+				Searches:    []string{"ns.svc.cluster.local"},
+			},
+		},
+	}, {
+		name: "lists merged by key",
+		tpl: &pod.PodTemplate{
+			Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "proxy:3128"}, {Name: "LOG_LEVEL", Value: "info"}},
+			Volumes: []corev1.Volume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			HostAliases:      []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry"}}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           1,
+				TopologyKey:       "zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			}},
+		},
+		override: &pod.PodTemplate{
+			Env: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, {Name: "GOFLAGS", Value: "-mod=vendor"}},
+			Volumes: []corev1.Volume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}},
+			}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+			HostAliases:      []corev1.HostAlias{{IP: "10.0.0.2", Hostnames: []string{"mirror"}}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           2,
+				TopologyKey:       "zone",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			}},
+		},
+		want: &pod.PodTemplate{
+			Env: []corev1.EnvVar{{Name: "HTTP_PROXY", Value: "proxy:3128"}, {Name: "LOG_LEVEL", Value: "debug"}, {Name: "GOFLAGS", Value: "-mod=vendor"}},
+			Volumes: []corev1.Volume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/cache"}},
+			}},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+			HostAliases:      []corev1.HostAlias{{IP: "10.0.0.1", Hostnames: []string{"registry"}}, {IP: "10.0.0.2", Hostnames: []string{"mirror"}}},
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+				MaxSkew:           2,
+				TopologyKey:       "zone",
+				WhenUnsatisfiable: corev1.DoNotSchedule,
+			}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tpl := tc.tpl.DeepCopy()
+			override := tc.override.DeepCopy()
+			got := pod.MergePodTemplateWithOverride(tpl, override)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("MergePodTemplateWithOverride %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.tpl, tpl); d != "" {
+				t.Errorf("MergePodTemplateWithOverride modified the template %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.override, override); d != "" {
+				t.Errorf("MergePodTemplateWithOverride modified the override template %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
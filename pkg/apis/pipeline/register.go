@@ -31,6 +31,9 @@ const (
 	// TaskRunLabelKey is used as the label identifier for a TaskRun
 	TaskRunLabelKey = GroupName + "/taskRun"
 
+	// ClusterPipelineLabelKey is used as the label identifier for a ClusterPipeline
+	ClusterPipelineLabelKey = GroupName + "/clusterPipeline"
+
 	// PipelineLabelKey is used as the label identifier for a Pipeline
 	PipelineLabelKey = GroupName + "/pipeline"
 
@@ -49,6 +52,28 @@ const (
 	// MemberOfLabelKey is used as the label identifier for a PipelineTask
 	// Set to Tasks/Finally depending on the position of the PipelineTask
 	MemberOfLabelKey = GroupName + "/memberOf"
+
+	// DisplayNameKey is used as the label identifier for the display name of a PipelineRun,
+	// in a form allowed in label values, and as the annotation identifier for its display
+	// name with its variables substituted
+	DisplayNameKey = GroupName + "/displayName"
+
+	// DescriptionAnnotationKey is used as the annotation identifier for the description of
+	// a PipelineRun with its variables substituted
+	DescriptionAnnotationKey = GroupName + "/description"
+
+	// CreatorAnnotationKey is used as the annotation identifier for the user who created a
+	// PipelineRun, propagated to its TaskRuns
+	CreatorAnnotationKey = GroupName + "/creator"
+
+	// MatrixCombinationAnnotationKey is used as the annotation identifier for the params of
+	// the combination of the matrix a TaskRun runs, encoded as a JSON object
+	MatrixCombinationAnnotationKey = GroupName + "/matrixCombination"
+
+	// PipelineAncestryAnnotationKey is used as the annotation identifier for the Pipelines
+	// run by the ancestors of a child PipelineRun, from the root PipelineRun, encoded as a
+	// JSON array
+	PipelineAncestryAnnotationKey = GroupName + "/pipelineAncestry"
 )
 
 var (
@@ -77,6 +102,11 @@ var (
 		Group:    GroupName,
 		Resource: "pipelines",
 	}
+	// ClusterPipelineResource represents a Tekton ClusterPipeline
+	ClusterPipelineResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "clusterpipelines",
+	}
 	// PipelineRunResource represents a Tekton PipelineRun
 	PipelineRunResource = schema.GroupResource{
 		Group:    GroupName,
@@ -24,4 +24,12 @@ const (
 	// ReservedResultsSidecarContainerName is the name of the results sidecar container that is injected
 	// by the reconciler.
 	ReservedResultsSidecarContainerName = "sidecar-tekton-log-results"
+
+	// ReservedLogForwarderSidecarName is the name of the sidecar that ships the logs of the steps
+	// to the sink configured in the config-log-forwarding ConfigMap.
+	ReservedLogForwarderSidecarName = "tekton-log-forwarder"
+
+	// ReservedLogForwarderSidecarContainerName is the name of the log forwarder sidecar container
+	// that is injected by the reconciler.
+	ReservedLogForwarderSidecarContainerName = "sidecar-tekton-log-forwarder"
 )
@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPipeline is a Pipeline with a cluster scope. ClusterPipelines are shared
+// Pipelines which the PipelineRuns of the namespaces they are visible in can
+// reference with the ClusterPipeline kind.
+//
+// +k8s:openapi-gen=true
+type ClusterPipeline struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec holds the desired state of the ClusterPipeline from the client
+	// +optional
+	Spec ClusterPipelineSpec `json:"spec"`
+}
+
+var _ kmeta.OwnerRefable = (*ClusterPipeline)(nil)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ClusterPipeline) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.ClusterPipelineControllerName)
+}
+
+// Pipeline returns the Pipeline defined by the ClusterPipeline, with its metadata.
+func (p *ClusterPipeline) Pipeline() *Pipeline {
+	return &Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: pipeline.PipelineControllerName},
+		ObjectMeta: *p.ObjectMeta.DeepCopy(),
+		Spec:       *p.Spec.PipelineSpec.DeepCopy(),
+	}
+}
+
+// ClusterPipelineSpec defines the desired state of a ClusterPipeline.
+type ClusterPipelineSpec struct {
+	PipelineSpec `json:",inline"`
+
+	// Visibility restricts the namespaces in which the ClusterPipeline can be
+	// referenced. The ClusterPipeline can be referenced in all the namespaces
+	// if it is not set.
+	// +optional
+	Visibility *ClusterVisibility `json:"visibility,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterPipelineList contains a list of ClusterPipelines
+type ClusterPipelineList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterPipeline `json:"items"`
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClusterPipeline_Pipeline(t *testing.T) {
+	cp := &v1.ClusterPipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Labels: map[string]string{"app": "release"}},
+		Spec: v1.ClusterPipelineSpec{
+			PipelineSpec: v1.PipelineSpec{Tasks: []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}}},
+			Visibility:   &v1.ClusterVisibility{Namespaces: []string{"ci"}},
+		},
+	}
+	want := &v1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Labels: map[string]string{"app": "release"}},
+		Spec:       v1.PipelineSpec{Tasks: []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}}},
+	}
+	if d := cmp.Diff(want, cp.Pipeline()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
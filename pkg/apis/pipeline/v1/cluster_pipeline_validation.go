@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var _ apis.Validatable = (*ClusterPipeline)(nil)
+var _ resourcesemantics.VerbLimited = (*ClusterPipeline)(nil)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (p *ClusterPipeline) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate checks that the ClusterPipeline structure is valid but does not validate
+// that any references resources exist, that is done at run time.
+func (p *ClusterPipeline) Validate(ctx context.Context) *apis.FieldError {
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	errs := validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(p.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	// Like for Pipelines, we do not support propagated parameters and workspaces.
+	// Validate that all params and workspaces it uses are declared.
+	errs = errs.Also(p.Spec.validatePipelineParameterUsage(ctx).ViaField("spec"))
+	return errs.Also(p.Spec.validatePipelineWorkspacesUsage().ViaField("spec"))
+}
+
+// Validate implements apis.Validatable
+func (ps *ClusterPipelineSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := ps.PipelineSpec.Validate(ctx)
+	return errs.Also(ps.Visibility.Validate(ctx).ViaField("visibility"))
+}
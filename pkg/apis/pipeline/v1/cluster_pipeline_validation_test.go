@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestClusterPipeline_Validate(t *testing.T) {
+	tasks := []v1.PipelineTask{{
+		Name:    "build",
+		TaskRef: &v1.TaskRef{Name: "build", Kind: v1.ClusterTaskRefKind},
+		Params:  v1.Params{{Name: "target", Value: *v1.NewStructuredValues("$(params.target)")}},
+	}}
+	params := v1.ParamSpecs{{Name: "target", Type: v1.ParamTypeString}}
+	for _, tc := range []struct {
+		name string
+		cp   *v1.ClusterPipeline
+		want *apis.FieldError
+	}{{
+		name: "visible in all namespaces",
+		cp: &v1.ClusterPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release"},
+			Spec:       v1.ClusterPipelineSpec{PipelineSpec: v1.PipelineSpec{Tasks: tasks, Params: params}},
+		},
+	}, {
+		name: "visible in some namespaces",
+		cp: &v1.ClusterPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release"},
+			Spec: v1.ClusterPipelineSpec{
+				PipelineSpec: v1.PipelineSpec{Tasks: tasks, Params: params},
+				Visibility:   &v1.ClusterVisibility{Namespaces: []string{"ci", "release"}},
+			},
+		},
+	}, {
+		name: "invalid visibility",
+		cp: &v1.ClusterPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release"},
+			Spec: v1.ClusterPipelineSpec{
+				PipelineSpec: v1.PipelineSpec{Tasks: tasks, Params: params},
+				Visibility:   &v1.ClusterVisibility{Namespaces: []string{"ci", "ci"}},
+			},
+		},
+		want: apis.ErrInvalidArrayValue(`namespace "ci" is listed more than once`, "spec.visibility.namespaces", 1),
+	}, {
+		name: "undeclared parameter",
+		cp: &v1.ClusterPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release"},
+			Spec:       v1.ClusterPipelineSpec{PipelineSpec: v1.PipelineSpec{Tasks: tasks}},
+		},
+		want: &apis.FieldError{
+			Message: `non-existent variable in "$(params.target)"`,
+			Paths:   []string{"spec.tasks[0].params[target]"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cp.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
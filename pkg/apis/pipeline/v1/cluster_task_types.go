@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genclient:nonNamespaced
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTask is a Task with a cluster scope. ClusterTasks are shared Tasks which
+// the TaskRuns and Pipelines of the namespaces they are visible in can reference
+// with the ClusterTask kind.
+//
+// +k8s:openapi-gen=true
+type ClusterTask struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the ClusterTask from the client
+	// +optional
+	Spec ClusterTaskSpec `json:"spec"`
+}
+
+var _ kmeta.OwnerRefable = (*ClusterTask)(nil)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ClusterTask) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind(pipeline.ClusterTaskControllerName)
+}
+
+// ClusterTaskSpec defines the desired state of a ClusterTask.
+type ClusterTaskSpec struct {
+	TaskSpec `json:",inline"`
+
+	// Visibility restricts the namespaces in which the ClusterTask can be
+	// referenced. The ClusterTask can be referenced in all the namespaces
+	// if it is not set.
+	// +optional
+	Visibility *ClusterVisibility `json:"visibility,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterTaskList contains a list of ClusterTasks
+type ClusterTaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterTask `json:"items"`
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/webhook/resourcesemantics"
+)
+
+var _ apis.Validatable = (*ClusterTask)(nil)
+var _ resourcesemantics.VerbLimited = (*ClusterTask)(nil)
+
+// SupportedVerbs returns the operations that validation should be called for
+func (t *ClusterTask) SupportedVerbs() []admissionregistrationv1.OperationType {
+	return []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update}
+}
+
+// Validate implements apis.Validatable
+func (t *ClusterTask) Validate(ctx context.Context) *apis.FieldError {
+	if apis.IsInDelete(ctx) {
+		return nil
+	}
+	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	// We do not support propagated parameters in ClusterTasks.
+	// Validate that all params the ClusterTask uses are declared.
+	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+}
+
+// Validate implements apis.Validatable
+func (ts *ClusterTaskSpec) Validate(ctx context.Context) *apis.FieldError {
+	errs := ts.TaskSpec.Validate(ctx)
+	return errs.Also(ts.Visibility.Validate(ctx).ViaField("visibility"))
+}
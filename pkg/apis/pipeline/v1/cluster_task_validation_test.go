@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestClusterTask_Validate(t *testing.T) {
+	steps := []v1.Step{{Name: "build", Image: "busybox", Script: "echo $(params.target)"}}
+	params := v1.ParamSpecs{{Name: "target", Type: v1.ParamTypeString}}
+	for _, tc := range []struct {
+		name string
+		ct   *v1.ClusterTask
+		want *apis.FieldError
+	}{{
+		name: "visible in all namespaces",
+		ct: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec:       v1.ClusterTaskSpec{TaskSpec: v1.TaskSpec{Steps: steps, Params: params}},
+		},
+	}, {
+		name: "visible in some namespaces",
+		ct: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1.ClusterTaskSpec{
+				TaskSpec:   v1.TaskSpec{Steps: steps, Params: params},
+				Visibility: &v1.ClusterVisibility{Namespaces: []string{"ci"}},
+			},
+		},
+	}, {
+		name: "invalid visibility",
+		ct: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec: v1.ClusterTaskSpec{
+				TaskSpec:   v1.TaskSpec{Steps: steps, Params: params},
+				Visibility: &v1.ClusterVisibility{},
+			},
+		},
+		want: apis.ErrMissingField("spec.visibility.namespaces"),
+	}, {
+		name: "undeclared parameter",
+		ct: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build"},
+			Spec:       v1.ClusterTaskSpec{TaskSpec: v1.TaskSpec{Steps: steps}},
+		},
+		want: &apis.FieldError{
+			Message: `non-existent variable in "echo $(params.target)"`,
+			Paths:   []string{"spec.steps[0].script"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.ct.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
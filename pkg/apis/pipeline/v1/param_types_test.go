@@ -422,6 +422,7 @@ func TestParamValues_MarshalJSON(t *testing.T) {
 		{*v1.NewStructuredValues("123", "1234"), "{\"val\":[\"123\",\"1234\"]}"},
 		{*v1.NewStructuredValues("a", "a", "a"), "{\"val\":[\"a\",\"a\",\"a\"]}"},
 		{*v1.NewObject(map[string]string{"key1": "var1", "key2": "var2"}), "{\"val\":{\"key1\":\"var1\",\"key2\":\"var2\"}}"},
+		{v1.ParamValue{}, "{\"val\":null}"},
 	}
 
 	for _, c := range cases {
@@ -501,6 +502,91 @@ func TestExtractNames(t *testing.T) {
 	}
 }
 
+func TestParamSpecs_ValidateValues(t *testing.T) {
+	minimum, maximum := 1.0, 10.0
+	paramSpecs := v1.ParamSpecs{{
+		Name: "env",
+		Type: v1.ParamTypeString,
+		Enum: []string{"staging", "prod"},
+	}, {
+		Name:    "version",
+		Type:    v1.ParamTypeString,
+		Pattern: "^v[0-9]+$",
+	}, {
+		Name:    "replicas",
+		Type:    v1.ParamTypeString,
+		Minimum: &minimum,
+		Maximum: &maximum,
+	}, {
+		Name: "envs",
+		Type: v1.ParamTypeArray,
+		Enum: []string{"staging", "prod"},
+	}}
+	for _, tc := range []struct {
+		name    string
+		params  v1.Params
+		wantErr string
+	}{{
+		name: "valid values",
+		params: v1.Params{
+			{Name: "env", Value: *v1.NewStructuredValues("prod")},
+			{Name: "version", Value: *v1.NewStructuredValues("v2")},
+			{Name: "replicas", Value: *v1.NewStructuredValues("2.5")},
+			{Name: "envs", Value: *v1.NewStructuredValues("staging", "prod")},
+			{Name: "undeclared", Value: *v1.NewStructuredValues("any")},
+		},
+	}, {
+		name: "values referencing variables",
+		params: v1.Params{
+			{Name: "env", Value: *v1.NewStructuredValues("$(tasks.setup.results.env)")},
+			{Name: "replicas", Value: *v1.NewStructuredValues("$(params.replicas)")},
+		},
+	}, {
+		name:    "value not in enum",
+		params:  v1.Params{{Name: "env", Value: *v1.NewStructuredValues("qa")}},
+		wantErr: `param "env" value "qa" is not one of the allowed values [staging prod]`,
+	}, {
+		name:    "item not in enum",
+		params:  v1.Params{{Name: "envs", Value: *v1.NewStructuredValues("staging", "qa")}},
+		wantErr: `param "envs" value "qa" is not one of the allowed values [staging prod]`,
+	}, {
+		name:    "value not matching pattern",
+		params:  v1.Params{{Name: "version", Value: *v1.NewStructuredValues("1.0")}},
+		wantErr: `param "version" value "1.0" doesn't match the pattern "^v[0-9]+$"`,
+	}, {
+		name:    "value not a number",
+		params:  v1.Params{{Name: "replicas", Value: *v1.NewStructuredValues("two")}},
+		wantErr: `param "replicas" value "two" is not a number`,
+	}, {
+		name:    "value less than minimum",
+		params:  v1.Params{{Name: "replicas", Value: *v1.NewStructuredValues("0")}},
+		wantErr: `param "replicas" value "0" is less than the minimum 1`,
+	}, {
+		name: "several invalid values",
+		params: v1.Params{
+			{Name: "env", Value: *v1.NewStructuredValues("qa")},
+			{Name: "replicas", Value: *v1.NewStructuredValues("11")},
+		},
+		wantErr: `param "env" value "qa" is not one of the allowed values [staging prod]; param "replicas" value "11" is greater than the maximum 10`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := paramSpecs.ValidateValues(tc.params)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateValues() returned an unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("ValidateValues() didn't return an error")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("ValidateValues() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestParams_ReplaceVariables(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/volume"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
@@ -51,7 +52,9 @@ func (p *Pipeline) Validate(ctx context.Context) *apis.FieldError {
 	// we do not support propagated parameters and workspaces.
 	// Validate that all params and workspaces it uses are declared.
 	errs = errs.Also(p.Spec.validatePipelineParameterUsage(ctx).ViaField("spec"))
-	return errs.Also(p.Spec.validatePipelineWorkspacesUsage().ViaField("spec"))
+	errs = errs.Also(p.Spec.validatePipelineWorkspacesUsage().ViaField("spec"))
+	errs = errs.Also(p.Spec.validateClusterTaskRefs(ctx, p.Namespace).ViaField("spec"))
+	return errs.Also(validate.Warnings(ctx, p))
 }
 
 // Validate checks that taskNames in the Pipeline are valid and that the graph
@@ -73,14 +76,79 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(ps.ValidateBetaFields(ctx))
 	// Validate the pipeline's workspaces.
 	errs = errs.Also(validatePipelineWorkspacesDeclarations(ps.Workspaces))
+	errs = errs.Also(validatePipelineWorkspacesModes(ctx, ps))
+	errs = errs.Also(validatePipelineWorkspacesTypes(ctx, ps.Workspaces))
 	// Validate the pipeline's results
 	errs = errs.Also(validatePipelineResults(ps.Results, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
 	errs = errs.Also(validateFinalTasks(ps.Tasks, ps.Finally))
-	errs = errs.Also(validateWhenExpressions(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateWhenExpressions(ctx, ps.Tasks, ps.Finally))
 	errs = errs.Also(validateMatrix(ctx, ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validateMatrix(ctx, ps.Finally).ViaField("finally"))
-	errs = errs.Also(validateResultsFromMatrixedPipelineTasksNotConsumed(ps.Tasks, ps.Finally))
+	errs = errs.Also(validateLoops(ctx, ps.Tasks).ViaField("tasks"))
+	errs = errs.Also(validateLoops(ctx, ps.Finally).ViaField("finally"))
+	errs = errs.Also(validateResultsFromMatrixedPipelineTasksConsumed(ps.Tasks, ps.Finally))
+	if ps.TaskRunTemplate != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "taskRunTemplate", config.AlphaAPIFields).ViaField("taskRunTemplate"))
+	}
+	errs = errs.Also(ps.validateVars(ctx))
+	errs = errs.Also(validateRetention(ctx, "successfulRunsHistoryLimit", ps.SuccessfulRunsHistoryLimit))
+	errs = errs.Also(validateRetention(ctx, "failedRunsHistoryLimit", ps.FailedRunsHistoryLimit))
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStrictResultValidation {
+		errs = errs.Also(validateResultRefsDeclared(ps))
+	}
+	return errs
+}
+
+// validateVars validates the variables of the pipeline, whose values can reference its params
+// and the results of its tasks but not other variables, and the references to them in the
+// tasks and the finally tasks.
+func (ps *PipelineSpec) validateVars(ctx context.Context) (errs *apis.FieldError) {
+	if len(ps.Vars) == 0 {
+		return nil
+	}
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "vars", config.AlphaAPIFields).ViaField("vars"))
+	_, arrayParams, objectParams := ps.Params.sortByType()
+	paramNames := sets.NewString(ps.Params.getNames()...)
+	arrayParamNames := sets.NewString(arrayParams.getNames()...)
+	objectParamNameKeys := map[string][]string{}
+	for _, p := range objectParams {
+		for k := range p.Properties {
+			objectParamNameKeys[p.Name] = append(objectParamNameKeys[p.Name], k)
+		}
+	}
+	taskNames := getPipelineTasksNames(ps.Tasks)
+	varNames := sets.StringKeySet(ps.Vars)
+	for _, name := range varNames.List() {
+		value := ps.Vars[name]
+		if !objectVariableNameFormatRegex.MatchString(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "vars", "Must only contain alphanumeric characters, hyphens (-) and underscores (_), and must begin with a letter or an underscore (_)"))
+		}
+		errs = errs.Also(validateStringVariable(value, "params", paramNames, arrayParamNames, objectParamNameKeys).ViaFieldKey("vars", name))
+		if _, present, _ := substitution.ExtractVariablesFromString(value, "vars"); present {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("vars can't reference other vars, but got %q", value), "").ViaFieldKey("vars", name))
+		}
+		for _, ref := range NewResultRefs(validateString(value)) {
+			if !taskNames.Has(ref.PipelineTask) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline task %s is not defined in the pipeline", ref.PipelineTask), "").ViaFieldKey("vars", name))
+			}
+		}
+	}
+	errs = errs.Also(validatePipelineParametersVariables(ps.Tasks, "vars", varNames, sets.NewString(), map[string][]string{}).ViaField("tasks"))
+	errs = errs.Also(validatePipelineParametersVariables(ps.Finally, "vars", varNames, sets.NewString(), map[string][]string{}).ViaField("finally"))
+	return errs
+}
+
+// validateRetention validates a field of the retention of finished runs, which
+// is an alpha feature and must not be negative.
+func validateRetention(ctx context.Context, field string, value *int32) (errs *apis.FieldError) {
+	if value == nil {
+		return nil
+	}
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, field, config.AlphaAPIFields).ViaField(field))
+	if *value < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *value), field))
+	}
 	return errs
 }
 
@@ -193,15 +261,32 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(pt.validateRefOrSpec())
 
 	errs = errs.Also(pt.validateEmbeddedOrType())
+
+	errs = errs.Also(pt.validateRetriesAndTimeout())
+	// The params of the pipeline tasks take their values from the params of the pipeline
+	for i, p := range pt.Params {
+		if p.ValueFrom != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("valueFrom").ViaFieldIndex("params", i))
+		}
+	}
+	if pt.OnError != "" {
+		errs = errs.Also(pt.validateOnError(ctx))
+	}
+	if pt.SkipPolicy != "" {
+		errs = errs.Also(pt.validateSkipPolicy(ctx))
+	}
 	// taskKinds contains the kinds when the apiVersion is not set, they are not custom tasks,
 	// if apiVersion is set they are custom tasks.
 	taskKinds := map[TaskKind]bool{
 		"":                 true,
 		NamespacedTaskKind: true,
 		ClusterTaskRefKind: true,
+		TaskGroupKind:      true,
 	}
 	// Pipeline task having taskRef/taskSpec with APIVersion is classified as custom task
 	switch {
+	case pt.PipelineRef != nil:
+		errs = errs.Also(pt.validateChildPipeline(ctx))
 	case pt.TaskRef != nil && !taskKinds[pt.TaskRef.Kind]:
 		errs = errs.Also(pt.validateCustomTask())
 	case pt.TaskRef != nil && pt.TaskRef.APIVersion != "":
@@ -225,10 +310,51 @@ func (pt *PipelineTask) validateMatrix(ctx context.Context) (errs *apis.FieldErr
 		errs = errs.Also(pt.Matrix.validateNoWholeArrayResults())
 		errs = errs.Also(pt.Matrix.validateUniqueParams())
 	}
+	errs = errs.Also(pt.Matrix.validateSpread())
 	errs = errs.Also(pt.Matrix.validateParameterInOneOfMatrixOrParams(pt.Params))
 	return errs
 }
 
+// validateLoop validates the loop of the pipeline task over the items of withItems or of the
+// array param or result withParam refers to, and that $(item) is only used in looping tasks.
+func (pt *PipelineTask) validateLoop(ctx context.Context) (errs *apis.FieldError) {
+	if !pt.IsLooped() {
+		for _, value := range pt.Params.extractValues() {
+			if strings.Contains(value, "$("+LoopItemVariable+")") {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("$(%s) can only be used by tasks looping with withItems or withParam, but got %s", LoopItemVariable, value), "params"))
+			}
+		}
+		return errs
+	}
+	// This is an alpha feature and will fail validation if it's used in a pipeline spec
+	// when the enable-api-fields feature gate is anything but "alpha".
+	if len(pt.WithItems) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "withItems", config.AlphaAPIFields))
+	}
+	if pt.WithParam != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "withParam", config.AlphaAPIFields))
+	}
+	if len(pt.WithItems) > 0 && pt.WithParam != "" {
+		errs = errs.Also(apis.ErrMultipleOneOf("withItems", "withParam"))
+	}
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("looping tasks can't be matrixed", "matrix"))
+	}
+	if pt.WithParam != "" && !isWholeArrayReference(pt.WithParam) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must refer to a whole array param or result, e.g. $(params.items[*])", pt.WithParam), "withParam"))
+	}
+	return errs
+}
+
+// isWholeArrayReference returns whether the value is a single reference to a whole array param
+// or result, e.g. $(params.items[*]) or $(tasks.list.results.files[*]).
+func isWholeArrayReference(value string) bool {
+	if !exactVariableSubstitutionRegex.MatchString(value) || !strings.HasSuffix(value, "[*])") {
+		return false
+	}
+	return strings.HasPrefix(value, "$("+ParamsPrefix+".") || looksLikeResultRef(strings.TrimSuffix(strings.TrimPrefix(value, "$("), ")"))
+}
+
 func (pt PipelineTask) validateEmbeddedOrType() (errs *apis.FieldError) {
 	// Reject cases where APIVersion and/or Kind are specified alongside an embedded Task.
 	// We determine if this is an embedded Task by checking of TaskSpec.TaskSpec.Steps has items.
@@ -249,10 +375,20 @@ func (pt PipelineTask) validateEmbeddedOrType() (errs *apis.FieldError) {
 	return
 }
 
-func (pt *PipelineTask) validateResultsFromMatrixedPipelineTasksNotConsumed(matrixedPipelineTasks sets.String) (errs *apis.FieldError) {
-	for _, ref := range PipelineTaskResultRefs(pt) {
-		if matrixedPipelineTasks.Has(ref.PipelineTask) {
-			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("consuming results from matrixed task %s is not allowed", ref.PipelineTask), ""))
+// validateResultsFromMatrixedPipelineTasksConsumed validates that the results of matrixed tasks,
+// which are aggregated into arrays with a value per combination, are consumed as whole arrays
+// with [*] or by index.
+func (pt *PipelineTask) validateResultsFromMatrixedPipelineTasksConsumed(matrixedPipelineTasks sets.String) (errs *apis.FieldError) {
+	for _, expression := range pt.varSubstitutionExpressions() {
+		if !looksLikeResultRef(expression) {
+			continue
+		}
+		subExpressions := strings.Split(expression, ".")
+		if !matrixedPipelineTasks.Has(subExpressions[1]) {
+			continue
+		}
+		if _, stringIdx := ParseResultName(subExpressions[3]); len(subExpressions) != 4 || stringIdx == "" {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("results of matrixed task %s are arrays and must be consumed with [*] or by index, but got $(%s)", subExpressions[1], expression), ""))
 		}
 	}
 	return errs
@@ -279,25 +415,56 @@ func (pt *PipelineTask) validateWorkspaces(workspaceNames sets.String) (errs *ap
 				"",
 			).ViaFieldIndex("workspaces", i))
 		}
+		errs = errs.Also(volume.ValidateSubPath(ws.SubPath).ViaFieldIndex("workspaces", i))
 
 		workspaceBindingNames.Insert(ws.Name)
 	}
 	return errs
 }
 
-// validateRefOrSpec validates at least one of taskRef or taskSpec is specified
+// validateRefOrSpec validates at least one of taskRef, taskSpec or pipelineRef is specified
 func (pt PipelineTask) validateRefOrSpec() (errs *apis.FieldError) {
 	// can't have both taskRef and taskSpec at the same time
 	if pt.TaskRef != nil && pt.TaskSpec != nil {
 		errs = errs.Also(apis.ErrMultipleOneOf("taskRef", "taskSpec"))
 	}
-	// Check that one of TaskRef and TaskSpec is present
-	if pt.TaskRef == nil && pt.TaskSpec == nil {
+	// can't run a Task and a child Pipeline at the same time
+	if pt.PipelineRef != nil && (pt.TaskRef != nil || pt.TaskSpec != nil) {
+		errs = errs.Also(apis.ErrMultipleOneOf("pipelineRef", "taskRef", "taskSpec"))
+	}
+	// Check that one of TaskRef, TaskSpec and PipelineRef is present
+	if pt.TaskRef == nil && pt.TaskSpec == nil && pt.PipelineRef == nil {
 		errs = errs.Also(apis.ErrMissingOneOf("taskRef", "taskSpec"))
 	}
 	return errs
 }
 
+// validateChildPipeline validates a pipeline task running a Pipeline in a child PipelineRun,
+// failing the fields of the pipeline task which only apply to TaskRuns
+func (pt PipelineTask) validateChildPipeline(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "pipelineRef", config.AlphaAPIFields))
+	errs = errs.Also(pt.PipelineRef.Validate(ctx).ViaField("pipelineRef"))
+	if pt.IsMatrixed() {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines can't be matrixed", "matrix"))
+	}
+	if pt.IsLooped() {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines can't loop", "withItems"))
+	}
+	if pt.Retries != 0 || pt.RetriesFrom != "" {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines can't be retried", "retries"))
+	}
+	if pt.RepeatUntil != nil {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines can't be repeated", "repeatUntil"))
+	}
+	if pt.TasksFromResult != "" {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines can't generate tasks", "tasksFromResult"))
+	}
+	if len(pt.ResultFiles) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("child pipelines do not support result files", "resultFiles"))
+	}
+	return errs
+}
+
 // validateCustomTask validates custom task specifications - checking kind and fail if not yet supported features specified
 func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	if pt.TaskRef != nil && pt.TaskRef.Kind == "" {
@@ -312,6 +479,15 @@ func (pt PipelineTask) validateCustomTask() (errs *apis.FieldError) {
 	if pt.TaskSpec != nil && pt.TaskSpec.APIVersion == "" {
 		errs = errs.Also(apis.ErrInvalidValue("custom task spec must specify apiVersion", "taskSpec.apiVersion"))
 	}
+	if len(pt.ResultFiles) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks do not support result files", "resultFiles"))
+	}
+	if pt.RepeatUntil != nil {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks can't be repeated", "repeatUntil"))
+	}
+	if pt.TasksFromResult != "" {
+		errs = errs.Also(apis.ErrInvalidValue("custom tasks can't generate tasks", "tasksFromResult"))
+	}
 	return errs
 }
 
@@ -331,6 +507,61 @@ func (pt PipelineTask) validateTask(ctx context.Context) (errs *apis.FieldError)
 			errs = errs.Also(apis.ErrInvalidValue("taskRef must specify name", "taskRef.name"))
 		}
 	}
+	if pt.ResultFiles != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "resultFiles", config.AlphaAPIFields).ViaField("resultFiles"))
+		errs = errs.Also(validateResultFiles(pt.ResultFiles).ViaField("resultFiles"))
+	}
+	if pt.RepeatUntil != nil {
+		errs = errs.Also(pt.RepeatUntil.Validate(ctx).ViaField("repeatUntil"))
+	}
+	if pt.TasksFromResult != "" {
+		errs = errs.Also(pt.validateTasksFromResult(ctx))
+	}
+	return errs
+}
+
+// validateOnError validates how the pipeline behaves when the pipeline task fails, which is an
+// alpha feature.
+func (pt PipelineTask) validateOnError(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "onError", config.AlphaAPIFields))
+	switch pt.OnError {
+	case PipelineTaskStopAndFail, PipelineTaskContinue, PipelineTaskContinueAndFail:
+	default:
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %q", pt.OnError),
+			Paths:   []string{"onError"},
+			Details: "PipelineTask onError must be either \"stopAndFail\", \"continue\" or \"continueAndFail\"",
+		})
+	}
+	return errs
+}
+
+// validateSkipPolicy validates which tasks are skipped when the when expressions of the pipeline
+// task evaluate to false, which is an alpha feature.
+func (pt PipelineTask) validateSkipPolicy(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "skipPolicy", config.AlphaAPIFields))
+	switch pt.SkipPolicy {
+	case PipelineTaskSkipTask, PipelineTaskSkipDependents:
+	default:
+		errs = errs.Also(&apis.FieldError{
+			Message: fmt.Sprintf("invalid value: %q", pt.SkipPolicy),
+			Paths:   []string{"skipPolicy"},
+			Details: "PipelineTask skipPolicy must be either \"skipTask\" or \"skipDependents\"",
+		})
+	}
+	return errs
+}
+
+// validateTasksFromResult validates the result from which a pipeline task generates the tasks
+// added to the pipeline, which is an alpha feature.
+func (pt PipelineTask) validateTasksFromResult(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "tasksFromResult", config.AlphaAPIFields))
+	if !resultNameFormatRegex.MatchString(pt.TasksFromResult) {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must be the name of a result of the task", pt.TasksFromResult), "tasksFromResult"))
+	}
+	if pt.IsMatrixed() || pt.IsLooped() {
+		errs = errs.Also(apis.ErrInvalidValue("fanned out tasks can't generate tasks", "tasksFromResult"))
+	}
 	return errs
 }
 
@@ -353,6 +584,80 @@ func validatePipelineWorkspacesDeclarations(wss []PipelineWorkspaceDeclaration)
 	return errs
 }
 
+// validatePipelineWorkspacesModes validates the modes of the specified workspaces, and that the embedded Tasks
+// using a ReadOnly workspace declare it readOnly.
+func validatePipelineWorkspacesModes(ctx context.Context, ps *PipelineSpec) (errs *apis.FieldError) {
+	readOnlyWorkspaces := sets.NewString()
+	for i, ws := range ps.Workspaces {
+		switch ws.Mode {
+		case "", WorkspaceModeReadWrite:
+		case WorkspaceModeReadOnly:
+			readOnlyWorkspaces.Insert(ws.Name)
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(ws.Mode, "mode").ViaFieldIndex("workspaces", i))
+			continue
+		}
+		if ws.Mode != "" {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "workspace mode", config.AlphaAPIFields).ViaFieldIndex("workspaces", i))
+		}
+	}
+	if readOnlyWorkspaces.Len() == 0 {
+		return errs
+	}
+	errs = errs.Also(validatePipelineTasksWorkspacesModes(readOnlyWorkspaces, ps.Tasks).ViaField("tasks"))
+	errs = errs.Also(validatePipelineTasksWorkspacesModes(readOnlyWorkspaces, ps.Finally).ViaField("finally"))
+	return errs
+}
+
+// validatePipelineWorkspacesTypes validates the types of the workspaces of the Pipeline, and that only cache
+// workspaces, and all of them, specify a cache key.
+func validatePipelineWorkspacesTypes(ctx context.Context, wss []PipelineWorkspaceDeclaration) (errs *apis.FieldError) {
+	for i, ws := range wss {
+		switch ws.Type {
+		case "", WorkspaceTypeEphemeral, WorkspaceTypeCache, WorkspaceTypeArtifact, WorkspaceTypeSecret:
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(ws.Type, "type").ViaFieldIndex("workspaces", i))
+			continue
+		}
+		if ws.Type != "" {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "workspace type", config.AlphaAPIFields).ViaFieldIndex("workspaces", i))
+		}
+		switch {
+		case ws.Type == WorkspaceTypeCache && ws.CacheKey == "":
+			errs = errs.Also(apis.ErrMissingField("cacheKey").ViaFieldIndex("workspaces", i))
+		case ws.Type != WorkspaceTypeCache && ws.CacheKey != "":
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("cacheKey can only be specified for %s workspaces", WorkspaceTypeCache), "cacheKey").ViaFieldIndex("workspaces", i))
+		}
+	}
+	return errs
+}
+
+// validatePipelineTasksWorkspacesModes validates that the embedded Tasks declare the workspaces bound to the
+// ReadOnly workspaces of the Pipeline readOnly.
+func validatePipelineTasksWorkspacesModes(readOnlyWorkspaces sets.String, pts []PipelineTask) (errs *apis.FieldError) {
+	for i, pt := range pts {
+		if pt.TaskSpec == nil {
+			continue
+		}
+		for j, ws := range pt.Workspaces {
+			pipelineWorkspace := ws.Workspace
+			if pipelineWorkspace == "" {
+				pipelineWorkspace = ws.Name
+			}
+			if !readOnlyWorkspaces.Has(pipelineWorkspace) {
+				continue
+			}
+			for _, tws := range pt.TaskSpec.Workspaces {
+				if tws.Name == ws.Name && !tws.ReadOnly {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline workspace %q is ReadOnly but task workspace %q is not declared readOnly", pipelineWorkspace, ws.Name),
+						"").ViaFieldIndex("workspaces", j).ViaIndex(i))
+				}
+			}
+		}
+	}
+	return errs
+}
+
 // validatePipelineParameterUsage validates that parameters referenced in the Pipeline are declared by the Pipeline
 func (ps *PipelineSpec) validatePipelineParameterUsage(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(PipelineTaskList(ps.Tasks).validateUsageOfDeclaredPipelineTaskParameters(ctx, "tasks"))
@@ -419,7 +724,20 @@ func validatePipelineParametersVariables(tasks []PipelineTask, prefix string, pa
 		if task.IsMatrixed() {
 			errs = errs.Also(task.Matrix.validatePipelineParametersVariablesInMatrixParameters(prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaIndex(idx))
 		}
+		for i, item := range task.WithItems {
+			errs = errs.Also(validateStringVariable(item, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaFieldIndex("withItems", i).ViaIndex(idx))
+		}
+		if strings.HasPrefix(task.WithParam, "$("+prefix+".") {
+			if name := ArrayReference(task.WithParam); !arrayParamNames.Has(name) {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s must refer to an array param, but %s is not an array param of the pipeline", task.WithParam, name), "withParam").ViaIndex(idx))
+			}
+		}
 		errs = errs.Also(task.When.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaIndex(idx))
+		if task.RepeatUntil != nil {
+			errs = errs.Also(task.RepeatUntil.When.validatePipelineParametersVariables(prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("repeatUntil").ViaIndex(idx))
+		}
+		errs = errs.Also(validateStringVariable(task.RetriesFrom, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("retriesFrom").ViaIndex(idx))
+		errs = errs.Also(validateStringVariable(task.TimeoutFrom, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("timeoutFrom").ViaIndex(idx))
 	}
 	return errs
 }
@@ -429,6 +747,8 @@ func validatePipelineContextVariables(tasks []PipelineTask) *apis.FieldError {
 		"name",
 		"namespace",
 		"uid",
+		"runNamespace",
+		"creator",
 	)
 	pipelineContextNames := sets.NewString().Insert(
 		"name",
@@ -463,8 +783,21 @@ func (pt *PipelineTask) extractAllParams() Params {
 	return allParams
 }
 
+// aggregateExecutionStatusVariables are the variables describing the execution of all the dag tasks
+var aggregateExecutionStatusVariables = sets.NewString(PipelineTasksAggregateStatus, PipelineTasksFailed,
+	PipelineTasksFailedCount, PipelineTasksSkipped, PipelineTasksSkippedCount)
+
+// isAggregateExecutionStatusRef returns whether the variable refers to the execution of all the dag tasks,
+// e.g. tasks.status or tasks.failed[*]
+func isAggregateExecutionStatusRef(p string) bool {
+	return aggregateExecutionStatusVariables.Has(strings.TrimSuffix(p, "[*]"))
+}
+
 func containsExecutionStatusRef(p string) bool {
-	if strings.HasPrefix(p, "tasks.") && strings.HasSuffix(p, ".status") {
+	if isAggregateExecutionStatusRef(p) {
+		return true
+	}
+	if strings.HasPrefix(p, "tasks.") && (strings.HasSuffix(p, ".status") || strings.HasSuffix(p, ".reason")) {
 		return true
 	}
 	return false
@@ -552,14 +885,15 @@ func validateExecutionStatusVariablesExpressions(expressions []string, ptNames s
 	// validate tasks.pipelineTask.status if this expression is not a result reference
 	if !LooksLikeContainsResultRefs(expressions) {
 		for _, expression := range expressions {
-			// its a reference to aggregate status of dag tasks - $(tasks.status)
-			if expression == PipelineTasksAggregateStatus {
+			// its a reference to aggregate status of dag tasks - $(tasks.status), $(tasks.failed[*]), ...
+			if isAggregateExecutionStatusRef(expression) {
 				continue
 			}
 			// check if it contains context variable accessing execution status - $(tasks.taskname.status)
+			// or $(tasks.taskname.reason)
 			if containsExecutionStatusRef(expression) {
-				// strip tasks. and .status from tasks.taskname.status to further verify task name
-				pt := strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), ".status")
+				// strip tasks. and .status or .reason from tasks.taskname.status to further verify task name
+				pt := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(expression, "tasks."), ".status"), ".reason")
 				// report an error if the task name does not exist in the list of dag tasks
 				if !ptNames.Has(pt) {
 					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("pipeline task %s is not defined in the pipeline", pt), fieldPath))
@@ -618,6 +952,44 @@ func validatePipelineResults(results []PipelineResult, tasks []PipelineTask, fin
 	return errs
 }
 
+// validateResultRefsDeclared ensures that the results referenced by the tasks, the finally
+// tasks and the results of the pipeline are declared by the producing pipeline tasks which
+// embed their taskSpec. The other references can only be validated when the PipelineRun
+// starts, once the Tasks are resolved.
+func validateResultRefsDeclared(ps *PipelineSpec) (errs *apis.FieldError) {
+	declared := map[string]sets.String{}
+	for _, pt := range append(append([]PipelineTask{}, ps.Tasks...), ps.Finally...) {
+		if pt.TaskSpec == nil || pt.TaskSpec.IsCustomTask() {
+			continue
+		}
+		results := sets.NewString()
+		for _, r := range pt.TaskSpec.Results {
+			results.Insert(r.Name)
+		}
+		declared[pt.Name] = results
+	}
+	validateRefs := func(refs []*ResultRef) (errs *apis.FieldError) {
+		for _, ref := range refs {
+			if results, ok := declared[ref.PipelineTask]; ok && !results.Has(ref.Result) {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%q is not a result declared by pipeline task %q, which declares %v",
+					ref.Result, ref.PipelineTask, results.List())))
+			}
+		}
+		return errs
+	}
+	for i := range ps.Tasks {
+		errs = errs.Also(validateRefs(PipelineTaskResultRefs(&ps.Tasks[i])).ViaFieldIndex("tasks", i))
+	}
+	for i := range ps.Finally {
+		errs = errs.Also(validateRefs(PipelineTaskResultRefs(&ps.Finally[i])).ViaFieldIndex("finally", i))
+	}
+	for i, result := range ps.Results {
+		expressions, _ := GetVarSubstitutionExpressionsForPipelineResult(result)
+		errs = errs.Also(validateRefs(NewResultRefs(expressions)).ViaField("value").ViaFieldIndex("results", i))
+	}
+	return errs
+}
+
 // put task names in a set
 func getPipelineTasksNames(pipelineTasks []PipelineTask) sets.String {
 	pipelineTaskNames := make(sets.String)
@@ -666,6 +1038,9 @@ func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) (errs *
 		if len(f.RunAfter) != 0 {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("no runAfter allowed under spec.finally, final task %s has runAfter specified", f.Name), "").ViaFieldIndex("finally", idx))
 		}
+		if f.TasksFromResult != "" {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("final task %s can't generate tasks, there is no task left to run after it", f.Name), "tasksFromResult").ViaFieldIndex("finally", idx))
+		}
 	}
 
 	ts := PipelineTaskList(tasks).Names()
@@ -690,6 +1065,24 @@ func validateTaskResultReferenceInFinallyTasks(finalTasks []PipelineTask, ts set
 					"when", i).ViaFieldIndex("finally", idx))
 			}
 		}
+		for i, ws := range t.Workspaces {
+			if expressions := validateString(ws.SubPath); len(expressions) != 0 {
+				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "subPath").ViaFieldIndex(
+					"workspaces", i).ViaFieldIndex("finally", idx))
+			}
+		}
+		for i, rf := range t.ResultFiles {
+			if expressions := validateString(rf.Value); len(expressions) != 0 {
+				errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "value").ViaFieldIndex(
+					"resultFiles", i).ViaFieldIndex("finally", idx))
+			}
+		}
+		if expressions := validateString(t.RetriesFrom); len(expressions) != 0 {
+			errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "retriesFrom").ViaFieldIndex("finally", idx))
+		}
+		if expressions := validateString(t.TimeoutFrom); len(expressions) != 0 {
+			errs = errs.Also(validateResultsVariablesExpressionsInFinally(expressions, ts, fts, "timeoutFrom").ViaFieldIndex("finally", idx))
+		}
 	}
 	return errs
 }
@@ -711,12 +1104,12 @@ func validateResultsVariablesExpressionsInFinally(expressions []string, pipeline
 	return errs
 }
 
-func validateWhenExpressions(tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
+func validateWhenExpressions(ctx context.Context, tasks []PipelineTask, finalTasks []PipelineTask) (errs *apis.FieldError) {
 	for i, t := range tasks {
-		errs = errs.Also(t.When.validate().ViaFieldIndex("tasks", i))
+		errs = errs.Also(t.When.validate(ctx).ViaFieldIndex("tasks", i))
 	}
 	for i, t := range finalTasks {
-		errs = errs.Also(t.When.validate().ViaFieldIndex("finally", i))
+		errs = errs.Also(t.When.validate(ctx).ViaFieldIndex("finally", i))
 	}
 	return errs
 }
@@ -737,18 +1130,26 @@ func validateMatrix(ctx context.Context, tasks []PipelineTask) (errs *apis.Field
 	return errs
 }
 
-func validateResultsFromMatrixedPipelineTasksNotConsumed(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
+func validateLoops(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
+	for idx, task := range tasks {
+		errs = errs.Also(task.validateLoop(ctx).ViaIndex(idx))
+	}
+	return errs
+}
+
+func validateResultsFromMatrixedPipelineTasksConsumed(tasks []PipelineTask, finally []PipelineTask) (errs *apis.FieldError) {
 	matrixedPipelineTasks := sets.String{}
 	for _, pt := range tasks {
-		if pt.IsMatrixed() {
+		// The results of looping tasks are aggregated like the ones of matrixed tasks
+		if pt.IsMatrixed() || pt.IsLooped() {
 			matrixedPipelineTasks.Insert(pt.Name)
 		}
 	}
 	for idx, pt := range tasks {
-		errs = errs.Also(pt.validateResultsFromMatrixedPipelineTasksNotConsumed(matrixedPipelineTasks).ViaFieldIndex("tasks", idx))
+		errs = errs.Also(pt.validateResultsFromMatrixedPipelineTasksConsumed(matrixedPipelineTasks).ViaFieldIndex("tasks", idx))
 	}
 	for idx, pt := range finally {
-		errs = errs.Also(pt.validateResultsFromMatrixedPipelineTasksNotConsumed(matrixedPipelineTasks).ViaFieldIndex("finally", idx))
+		errs = errs.Also(pt.validateResultsFromMatrixedPipelineTasksConsumed(matrixedPipelineTasks).ViaFieldIndex("finally", idx))
 	}
 	return errs
 }
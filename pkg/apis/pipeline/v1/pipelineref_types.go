@@ -23,6 +23,10 @@ type PipelineRef struct {
 	// API version of the referent
 	// +optional
 	APIVersion string `json:"apiVersion,omitempty"`
+	// Kind indicates whether the referent is a namespaced Pipeline, the default,
+	// or a ClusterPipeline.
+	// +optional
+	Kind PipelineKind `json:"kind,omitempty"`
 
 	// ResolverRef allows referencing a Pipeline in a remote location
 	// like a git repo. This field is only supported when the alpha
@@ -30,3 +34,14 @@ type PipelineRef struct {
 	// +optional
 	ResolverRef `json:",omitempty"`
 }
+
+// PipelineKind defines the type of Pipeline referenced by a PipelineRun.
+type PipelineKind string
+
+const (
+	// NamespacedPipelineKind indicates that the Pipeline has a namespaced scope.
+	NamespacedPipelineKind PipelineKind = "Pipeline"
+	// ClusterPipelineKind indicates that the Pipeline is a ClusterPipeline, with a
+	// cluster scope. The ClusterPipeline must be visible in the namespace of the reference.
+	ClusterPipelineKind PipelineKind = "ClusterPipeline"
+)
@@ -51,5 +51,14 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 	} else if ref.Name == "" {
 		errs = errs.Also(apis.ErrMissingField("name"))
 	}
+	switch ref.Kind {
+	case "", NamespacedPipelineKind:
+	case ClusterPipelineKind:
+		if ref.Resolver != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("kind", "resolver"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(ref.Kind, "kind"))
+	}
 	return
 }
@@ -106,6 +106,20 @@ func TestPipelineRef_Invalid(t *testing.T) {
 			apis.ErrGeneric("resolver params requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"")).Also(
 			apis.ErrGeneric("object type parameter requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"")),
 		withContext: config.EnableStableAPIFields,
+	}, {
+		name:    "pipelineRef with an unknown kind",
+		ref:     &v1.PipelineRef{Name: "foo", Kind: "Task"},
+		wantErr: apis.ErrInvalidValue("Task", "kind"),
+	}, {
+		name: "ClusterPipeline kind disallowed in conjunction with resolver",
+		ref: &v1.PipelineRef{
+			Kind: v1.ClusterPipelineKind,
+			ResolverRef: v1.ResolverRef{
+				Resolver: "git",
+			},
+		},
+		wantErr:     apis.ErrMultipleOneOf("kind", "resolver"),
+		withContext: config.EnableBetaAPIFields,
 	}}
 
 	for _, tc := range tests {
@@ -154,6 +168,12 @@ func TestPipelineRef_Valid(t *testing.T) {
 			},
 		}}}},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "ClusterPipeline kind",
+		ref:  &v1.PipelineRef{Name: "foo", Kind: v1.ClusterPipelineKind},
+	}, {
+		name: "Pipeline kind",
+		ref:  &v1.PipelineRef{Name: "foo", Kind: v1.NamespacedPipelineKind},
 	}}
 
 	for _, ts := range tests {
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -30,14 +31,42 @@ var _ apis.Defaultable = (*PipelineRun)(nil)
 
 // SetDefaults implements apis.Defaultable
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, pr.ObjectMeta)
+	pr.setCreator(ctx)
 	pr.Spec.SetDefaults(ctx)
 }
 
+// setCreator records the user creating the PipelineRun in its annotations, and keeps the
+// recorded user when the PipelineRun is updated.
+func (pr *PipelineRun) setCreator(ctx context.Context) {
+	var creator string
+	switch {
+	case apis.IsInCreate(ctx):
+		if userInfo := apis.GetUserInfo(ctx); userInfo != nil {
+			creator = userInfo.Username
+		}
+	case apis.IsInUpdate(ctx):
+		if base, ok := apis.GetBaseline(ctx).(*PipelineRun); ok && base != nil {
+			creator = base.Annotations[pipeline.CreatorAnnotationKey]
+		}
+	default:
+		return
+	}
+	if creator == "" {
+		delete(pr.Annotations, pipeline.CreatorAnnotationKey)
+		return
+	}
+	if pr.Annotations == nil {
+		pr.Annotations = map[string]string{}
+	}
+	pr.Annotations[pipeline.CreatorAnnotationKey] = creator
+}
+
 // SetDefaults implements apis.Defaultable
 func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
-	cfg := config.FromContextOrDefaults(ctx)
+	defaults := config.DefaultsForNamespace(ctx, apis.ParentMeta(ctx).Namespace)
 	if prs.PipelineRef != nil && prs.PipelineRef.Name == "" && prs.PipelineRef.Resolver == "" {
-		prs.PipelineRef.Resolver = ResolverName(cfg.Defaults.DefaultResolverType)
+		prs.PipelineRef.Resolver = ResolverName(defaults.DefaultResolverType)
 	}
 
 	if prs.Timeouts == nil {
@@ -45,17 +74,19 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	}
 
 	if prs.Timeouts.Pipeline == nil {
-		prs.Timeouts.Pipeline = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
+		prs.Timeouts.Pipeline = &metav1.Duration{Duration: time.Duration(defaults.DefaultTimeoutMinutes) * time.Minute}
 	}
 
-	defaultSA := cfg.Defaults.DefaultServiceAccount
+	defaultSA := defaults.DefaultServiceAccount
 	if prs.TaskRunTemplate.ServiceAccountName == "" && defaultSA != "" {
 		prs.TaskRunTemplate.ServiceAccountName = defaultSA
 	}
 
-	defaultPodTemplate := cfg.Defaults.DefaultPodTemplate
+	defaultPodTemplate := defaults.DefaultPodTemplate
 	prs.TaskRunTemplate.PodTemplate = pod.MergePodTemplateWithDefault(prs.TaskRunTemplate.PodTemplate, defaultPodTemplate)
 
+	prs.Params.setValueFromDefaults()
+
 	if prs.PipelineSpec != nil {
 		prs.PipelineSpec.SetDefaults(ctx)
 	}
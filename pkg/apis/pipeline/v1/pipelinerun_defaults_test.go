@@ -26,11 +26,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
 )
 
 func TestPipelineRunSpec_SetDefaults(t *testing.T) {
@@ -414,3 +417,45 @@ func TestPipelineRunDefaulting(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunDefaultingCreator(t *testing.T) {
+	created := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+		},
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		in   map[string]string
+		want map[string]string
+	}{{
+		name: "created",
+		ctx:  apis.WithUserInfo(apis.WithinCreate(context.Background()), &authenticationv1.UserInfo{Username: "alice"}),
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "created with an annotation set by the user",
+		ctx:  apis.WithUserInfo(apis.WithinCreate(context.Background()), &authenticationv1.UserInfo{Username: "alice"}),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "updated",
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), created), &authenticationv1.UserInfo{Username: "bob"}),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "not in a request",
+		ctx:  context.Background(),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.in}}
+			pr.SetDefaults(tc.ctx)
+			if d := cmp.Diff(tc.want, pr.Annotations); d != "" {
+				t.Errorf("SetDefaults() annotations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -224,10 +224,25 @@ type PipelineRunSpec struct {
 	// Params is a list of parameter names and values.
 	// +listType=atomic
 	Params Params `json:"params,omitempty"`
+	// DisplayName is a user-facing name of the pipelinerun that may be used to
+	// populate a UI. It may reference the params and the context of the
+	// PipelineRun, which are substituted when the PipelineRun starts.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Description is a user-facing description of the pipelinerun that may be
+	// used to populate a UI. It may reference the same variables as DisplayName.
+	// +optional
+	Description string `json:"description,omitempty"`
 
 	// Used for cancelling a pipelinerun (and maybe more later on)
 	// +optional
 	Status PipelineRunSpecStatus `json:"status,omitempty"`
+	// FailurePolicy defines how the PipelineRun behaves when one of its tasks fails:
+	// "FailFast", the default, stops scheduling tasks once a task failed, while
+	// "FailAtEnd" runs the tasks which don't depend on the failed tasks to completion
+	// before failing the PipelineRun.
+	// +optional
+	FailurePolicy PipelineRunFailurePolicy `json:"failurePolicy,omitempty"`
 	// Time after which the Pipeline times out.
 	// Currently three keys are accepted in the map
 	// pipeline, tasks and finally
@@ -248,6 +263,11 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// TTLSecondsAfterFinished is the number of seconds the controller keeps the
+	// PipelineRun for after it finishes, before deleting it with its TaskRuns.
+	// The PipelineRun is kept until deleted when it is unset.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -282,6 +302,19 @@ const (
 	PipelineRunSpecStatusPending = "PipelineRunPending"
 )
 
+// PipelineRunFailurePolicy defines how the PipelineRun behaves when one of its tasks fails
+type PipelineRunFailurePolicy string
+
+const (
+	// PipelineRunFailFast stops scheduling tasks once a task failed, and fails the PipelineRun
+	// once the running tasks and the finally tasks are done
+	PipelineRunFailFast PipelineRunFailurePolicy = "FailFast"
+
+	// PipelineRunFailAtEnd keeps scheduling the tasks which don't depend on the failed tasks,
+	// and fails the PipelineRun once all of them are done
+	PipelineRunFailAtEnd PipelineRunFailurePolicy = "FailAtEnd"
+)
+
 // PipelineRunStatus defines the observed state of PipelineRun
 type PipelineRunStatus struct {
 	duckv1.Status `json:",inline"`
@@ -302,6 +335,9 @@ const (
 	PipelineRunReasonSuccessful PipelineRunReason = "Succeeded"
 	// PipelineRunReasonCompleted is the reason set when the PipelineRun completed successfully with one or more skipped Tasks
 	PipelineRunReasonCompleted PipelineRunReason = "Completed"
+	// PipelineRunReasonCompletedWithWarnings is the reason set when the PipelineRun completed successfully
+	// with one or more failed Tasks whose onError is continue
+	PipelineRunReasonCompletedWithWarnings PipelineRunReason = "CompletedWithWarnings"
 	// PipelineRunReasonFailed is the reason set when the PipelineRun completed with a failure
 	PipelineRunReasonFailed PipelineRunReason = "Failed"
 	// PipelineRunReasonCancelled is the reason set when the PipelineRun cancelled by the user
@@ -416,6 +452,12 @@ type PipelineRunStatusFields struct {
 	// +listType=atomic
 	SkippedTasks []SkippedTask `json:"skippedTasks,omitempty"`
 
+	// GeneratedTasks are the pipeline tasks added to the pipeline by the tasks generating
+	// tasks from one of their results, with tasksFromResult.
+	// +optional
+	// +listType=atomic
+	GeneratedTasks []PipelineTask `json:"generatedTasks,omitempty"`
+
 	// list of TaskRun and Run names, PipelineTask names, and API versions/kinds for children of this PipelineRun.
 	// +optional
 	// +listType=atomic
@@ -431,6 +473,34 @@ type PipelineRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// OffloadedStatus references where the pipelineSpec, and the full childReferences
+	// and skippedTasks, are offloaded to when "status-offload" is set, the status then
+	// only holding the names of the children and of the skipped tasks.
+	// +optional
+	OffloadedStatus *OffloadedStatus `json:"offloadedStatus,omitempty"`
+
+	// ResolvedParams are the values of the params taking their values from the keys
+	// of ConfigMaps, resolved once when the PipelineRun started.
+	// +optional
+	// +listType=atomic
+	ResolvedParams Params `json:"resolvedParams,omitempty"`
+}
+
+// OffloadedStatus references the fields of the status of a PipelineRun offloaded to
+// ConfigMaps or to an object store, so that the status of the PipelineRuns of large
+// Pipelines fits in etcd.
+type OffloadedStatus struct {
+	// ConfigMaps are the names of the ConfigMaps holding the chunks of the offloaded
+	// fields, in order.
+	// +optional
+	// +listType=atomic
+	ConfigMaps []string `json:"configMaps,omitempty"`
+	// URL is where the offloaded fields are stored in the object store.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Digest is the sha256 digest of the offloaded fields.
+	Digest string `json:"digest"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -453,6 +523,12 @@ type SkippingReason string
 const (
 	// WhenExpressionsSkip means the task was skipped due to at least one of its when expressions evaluating to false
 	WhenExpressionsSkip SkippingReason = "When Expressions evaluated to false"
+	// WhenExpressionsErrorSkip means the task was skipped because at least one of its CEL when expressions
+	// failed to be evaluated, which fails the pipeline run
+	WhenExpressionsErrorSkip SkippingReason = "When Expressions failed to be evaluated"
+	// ParentTasksFailedSkip means the task was skipped because its parent failed while the
+	// pipeline run keeps running the other tasks, with the FailAtEnd failure policy
+	ParentTasksFailedSkip SkippingReason = "Parent Tasks failed"
 	// ParentTasksSkip means the task was skipped because its parent was skipped
 	ParentTasksSkip SkippingReason = "Parent Tasks were skipped"
 	// StoppingSkip means the task was skipped because the pipeline run is stopping
@@ -547,6 +623,7 @@ type PipelineTaskRunSpec struct {
 
 // GetTaskRunSpec returns the task specific spec for a given
 // PipelineTask if configured, otherwise it returns the PipelineRun's default.
+// The task specific pod template is deep merged with the PipelineRun's one.
 func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSpec {
 	s := PipelineTaskRunSpec{
 		PipelineTaskName:   pipelineTaskName,
@@ -556,7 +633,7 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 	for _, task := range pr.Spec.TaskRunSpecs {
 		if task.PipelineTaskName == pipelineTaskName {
 			if task.PodTemplate != nil {
-				s.PodTemplate = task.PodTemplate
+				s.PodTemplate = pod.MergePodTemplateWithOverride(pr.Spec.TaskRunTemplate.PodTemplate, task.PodTemplate)
 			}
 			if task.ServiceAccountName != "" {
 				s.ServiceAccountName = task.ServiceAccountName
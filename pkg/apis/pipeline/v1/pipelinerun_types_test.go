@@ -401,3 +401,38 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetPodSpecMergesPodTemplates(t *testing.T) {
+	runAsUser := int64(1000)
+	runAsGroup := int64(2000)
+	pr := &v1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "prs"},
+			TaskRunTemplate: v1.PipelineTaskRunTemplate{
+				PodTemplate: &pod.Template{
+					NodeSelector:    map[string]string{"arch": "amd64"},
+					SecurityContext: &corev1.PodSecurityContext{RunAsUser: &runAsUser},
+				},
+			},
+			TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build-task",
+				PodTemplate: &pod.Template{
+					NodeSelector:    map[string]string{"disktype": "ssd"},
+					SecurityContext: &corev1.PodSecurityContext{RunAsGroup: &runAsGroup},
+				},
+			}},
+		},
+	}
+	want := &pod.Template{
+		NodeSelector: map[string]string{"arch": "amd64", "disktype": "ssd"},
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsUser:  &runAsUser,
+			RunAsGroup: &runAsGroup,
+		},
+	}
+	s := pr.GetTaskRunSpec("build-task")
+	if d := cmp.Diff(want, s.PodTemplate); d != "" {
+		t.Errorf("wrong task podtemplate %s", diff.PrintWantGot(d))
+	}
+}
@@ -19,9 +19,11 @@ package v1
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
@@ -46,9 +48,44 @@ func (pr *PipelineRun) Validate(ctx context.Context) *apis.FieldError {
 		errs = errs.Also(apis.ErrInvalidValue("PipelineRun cannot be Pending after it is started", "spec.status"))
 	}
 
+	errs = errs.Also(pr.validateServiceAccounts(ctx))
+	errs = errs.Also(validateClusterPipelineRef(ctx, pr.Namespace, pr.Spec.PipelineRef).ViaField("spec", "pipelineRef"))
+	if pr.Spec.PipelineSpec != nil {
+		errs = errs.Also(pr.Spec.PipelineSpec.validateClusterTaskRefs(ctx, pr.Namespace).ViaField("spec", "pipelineSpec"))
+	}
+
 	return errs.Also(pr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 }
 
+// validateServiceAccounts checks the service accounts of a PipelineRun being
+// created against the ServiceAccountPolicies of its namespace. The policies only
+// apply to the Pipelines referenced by name, so a PipelineRun with an embedded
+// pipelineSpec or a remote pipelineRef is checked with an empty Pipeline name.
+// The reconciler checks the service accounts again before running any task.
+func (pr *PipelineRun) validateServiceAccounts(ctx context.Context) (errs *apis.FieldError) {
+	if !apis.IsInCreate(ctx) {
+		return nil
+	}
+	name := ""
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Resolver == "" {
+		name = pr.Spec.PipelineRef.Name
+	}
+	if sa := pr.Spec.TaskRunTemplate.ServiceAccountName; sa != "" {
+		if err := validate.ServiceAccountAllowed(ctx, pr.Namespace, pipeline.PipelineControllerName, name, sa); err != nil {
+			errs = errs.Also(apis.ErrGeneric(err.Error(), "spec.taskRunTemplate.serviceAccountName"))
+		}
+	}
+	for i, trs := range pr.Spec.TaskRunSpecs {
+		if trs.ServiceAccountName == "" {
+			continue
+		}
+		if err := validate.ServiceAccountAllowed(ctx, pr.Namespace, pipeline.PipelineControllerName, name, trs.ServiceAccountName); err != nil {
+			errs = errs.Also(apis.ErrGeneric(err.Error(), "serviceAccountName").ViaFieldIndex("taskRunSpecs", i).ViaField("spec"))
+		}
+	}
+	return errs
+}
+
 // Validate pipelinerun spec
 func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// Must have exactly one of pipelineRef and pipelineSpec.
@@ -74,6 +111,15 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 
 	// Validate propagated parameters
 	errs = errs.Also(ps.validateInlineParameters(ctx))
+	// Validate the values of the parameters against the constraints of the embedded PipelineSpec
+	if ps.PipelineSpec != nil {
+		if err := ps.PipelineSpec.Params.ValidateValues(ps.Params); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "params"))
+		}
+	}
+
+	// Validate the variables of the display name and description
+	errs = errs.Also(ps.validateDisplayNameAndDescription(ctx))
 
 	if ps.Timeouts != nil {
 		// tasks timeout should be a valid duration of at least 0.
@@ -94,6 +140,10 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	}
 
 	errs = errs.Also(validateSpecStatus(ps.Status))
+	if ps.FailurePolicy != "" {
+		errs = errs.Also(validateFailurePolicy(ctx, ps.FailurePolicy))
+	}
+	errs = errs.Also(validateRetention(ctx, "ttlSecondsAfterFinished", ps.TTLSecondsAfterFinished))
 
 	if ps.Workspaces != nil {
 		wsNames := make(map[string]int)
@@ -112,6 +162,25 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 	return errs
 }
 
+// validateDisplayNameAndDescription validates that the display name and the description of
+// the PipelineRun only reference its params and context, which are known when it starts.
+func (ps *PipelineRunSpec) validateDisplayNameAndDescription(ctx context.Context) (errs *apis.FieldError) {
+	if ps.DisplayName == "" && ps.Description == "" {
+		return errs
+	}
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "pipelinerun displayName and description", config.AlphaAPIFields))
+	for _, f := range []struct{ name, value string }{{"displayName", ps.DisplayName}, {"description", ps.Description}} {
+		for _, expression := range validateString(f.value) {
+			isParam := strings.HasPrefix(expression, "params.") || strings.HasPrefix(expression, "params[")
+			isContext := strings.HasPrefix(expression, "context.pipelineRun.") || expression == "context.pipeline.name"
+			if !(isParam || isContext) || strings.HasSuffix(expression, "[*]") {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s can only reference string params and the context of the pipelinerun, but got $(%s)", f.name, expression), f.name))
+			}
+		}
+	}
+	return errs
+}
+
 func (ps *PipelineRunSpec) validatePipelineRunParameters(ctx context.Context) (errs *apis.FieldError) {
 	if len(ps.Params) == 0 {
 		return errs
@@ -119,6 +188,7 @@ func (ps *PipelineRunSpec) validatePipelineRunParameters(ctx context.Context) (e
 
 	// Validate parameter types and uniqueness
 	errs = errs.Also(ValidateParameters(ctx, ps.Params).ViaField("params"))
+	errs = errs.Also(validateParamValueSources(ctx, ps.Params).ViaField("params"))
 
 	// Validate that task results aren't used in param values
 	for _, param := range ps.Params {
@@ -224,6 +294,17 @@ func validateSpecStatus(status PipelineRunSpecStatus) *apis.FieldError {
 		PipelineRunSpecStatusPending), "status")
 }
 
+// validateFailurePolicy validates the failure policy of the PipelineRun, which is an alpha feature.
+func validateFailurePolicy(ctx context.Context, policy PipelineRunFailurePolicy) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "failurePolicy", config.AlphaAPIFields))
+	switch policy {
+	case PipelineRunFailFast, PipelineRunFailAtEnd:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be %s or %s", policy, PipelineRunFailFast, PipelineRunFailAtEnd), "failurePolicy"))
+	}
+	return errs
+}
+
 func validateTimeoutDuration(field string, d *metav1.Duration) (errs *apis.FieldError) {
 	if d != nil && d.Duration < 0 {
 		fieldPath := fmt.Sprintf("timeouts.%s", field)
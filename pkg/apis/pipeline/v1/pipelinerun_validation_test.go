@@ -18,6 +18,7 @@ package v1_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
@@ -686,7 +688,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoit",
 						Workspaces: []v1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
 							Workspaces: []v1.WorkspaceDeclaration{{
@@ -704,7 +706,7 @@ func TestPipelineRun_Validate(t *testing.T) {
 						Name: "echoitfinally",
 						Workspaces: []v1.WorkspacePipelineTaskBinding{{
 							Name:    "ws",
-							SubPath: "/foo",
+							SubPath: "foo",
 						}},
 						TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
 							Workspaces: []v1.WorkspaceDeclaration{{
@@ -894,8 +896,10 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			Message: "expected exactly one, got neither",
 			Paths: []string{
 				"workspaces[0].configmap",
+				"workspaces[0].csi",
 				"workspaces[0].emptydir",
 				"workspaces[0].persistentvolumeclaim",
+				"workspaces[0].projected",
 				"workspaces[0].secret",
 				"workspaces[0].volumeclaimtemplate",
 			},
@@ -1036,6 +1040,39 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrGeneric("computeResources requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("taskRunSpecs"),
+	}, {
+		name: "displayName and description disallowed without alpha feature gate",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			DisplayName: "Nightly build",
+			Description: "Nightly build of main",
+		},
+		wantErr: apis.ErrGeneric("pipelinerun displayName and description requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "displayName and description referencing variables unknown when the pipelinerun starts",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "foo"},
+			DisplayName: "Build of $(tasks.clone.results.commit)",
+			Description: "Build for $(params.targets[*]) in $(context.pipelineRun.namespace)",
+		},
+		wantErr: apis.ErrInvalidValue("displayName can only reference string params and the context of the pipelinerun, but got $(tasks.clone.results.commit)", "displayName").Also(
+			apis.ErrInvalidValue("description can only reference string params and the context of the pipelinerun, but got $(params.targets[*])", "description")),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "failurePolicy without alpha api fields",
+		spec: v1.PipelineRunSpec{
+			PipelineRef:   &v1.PipelineRef{Name: "foo"},
+			FailurePolicy: v1.PipelineRunFailAtEnd,
+		},
+		wantErr: apis.ErrGeneric(`failurePolicy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "invalid failurePolicy",
+		spec: v1.PipelineRunSpec{
+			PipelineRef:   &v1.PipelineRef{Name: "foo"},
+			FailurePolicy: "FailLater",
+		},
+		wantErr:     apis.ErrInvalidValue("FailLater should be FailFast or FailAtEnd", "failurePolicy"),
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -1107,6 +1144,21 @@ func TestPipelineRunSpec_Validate(t *testing.T) {
 			}},
 		},
 		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "displayName and description referencing params and context",
+		spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "pipeline"},
+			DisplayName: `Nightly build of $(params.repo.name) $(params["branch"])`,
+			Description: "Build for $(params.targets[0]) by $(context.pipelineRun.name) of $(context.pipeline.name)",
+		},
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "failurePolicy FailAtEnd",
+		spec: v1.PipelineRunSpec{
+			PipelineRef:   &v1.PipelineRef{Name: "pipeline"},
+			FailurePolicy: v1.PipelineRunFailAtEnd,
+		},
+		withContext: config.EnableAlphaAPIFields,
 	}}
 
 	for _, ps := range tests {
@@ -1391,3 +1443,75 @@ func TestPipelineRunWithTimeout_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRun_ServiceAccountPolicy(t *testing.T) {
+	policy := func(ctx context.Context, namespace, kind, name, serviceAccount string) error {
+		if kind != "Pipeline" || (name == "release" && serviceAccount == "deployer") {
+			return nil
+		}
+		return fmt.Errorf("%q is not allowed for %s %q in namespace %q", serviceAccount, kind, name, namespace)
+	}
+	pipelineRun := func(name string, ref *v1.PipelineRef, sa, taskSA string) *v1.PipelineRun {
+		pr := &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1.PipelineRunSpec{
+				PipelineRef:     ref,
+				TaskRunTemplate: v1.PipelineTaskRunTemplate{ServiceAccountName: sa},
+				TaskRunSpecs: []v1.PipelineTaskRunSpec{{
+					PipelineTaskName:   "deploy",
+					ServiceAccountName: taskSA,
+				}},
+			},
+		}
+		if ref == nil {
+			pr.Spec.PipelineSpec = &v1.PipelineSpec{
+				Tasks: []v1.PipelineTask{{Name: "deploy", TaskRef: &v1.TaskRef{Name: "deploy"}}},
+			}
+		}
+		return pr
+	}
+	for _, tc := range []struct {
+		desc     string
+		pr       *v1.PipelineRun
+		inCreate bool
+		want     *apis.FieldError
+	}{{
+		desc:     "allowed",
+		pr:       pipelineRun("pr", &v1.PipelineRef{Name: "release"}, "deployer", "deployer"),
+		inCreate: true,
+	}, {
+		desc:     "service account of the run not allowed",
+		pr:       pipelineRun("pr", &v1.PipelineRef{Name: "release"}, "admin", "deployer"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"admin" is not allowed for Pipeline "release" in namespace "ns"`, "spec.taskRunTemplate.serviceAccountName"),
+	}, {
+		desc:     "service account of a pipeline task not allowed",
+		pr:       pipelineRun("pr", &v1.PipelineRef{Name: "release"}, "deployer", "admin"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"admin" is not allowed for Pipeline "release" in namespace "ns"`, "spec.taskRunSpecs[0].serviceAccountName"),
+	}, {
+		desc:     "embedded pipeline not named after the run",
+		pr:       pipelineRun("release", nil, "deployer", ""),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"deployer" is not allowed for Pipeline "" in namespace "ns"`, "spec.taskRunTemplate.serviceAccountName"),
+	}, {
+		desc:     "remote pipeline",
+		pr:       pipelineRun("pr", &v1.PipelineRef{ResolverRef: v1.ResolverRef{Resolver: "git"}}, "", "deployer"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"deployer" is not allowed for Pipeline "" in namespace "ns"`, "spec.taskRunSpecs[0].serviceAccountName"),
+	}, {
+		desc: "not checked on update",
+		pr:   pipelineRun("pr", &v1.PipelineRef{Name: "release"}, "admin", "admin"),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := validate.WithServiceAccountPolicy(config.EnableBetaAPIFields(context.Background()), policy)
+			if tc.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			err := tc.pr.Validate(ctx)
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -13,7 +13,10 @@ limitations under the License.
 
 package v1
 
-import "github.com/tektoncd/pipeline/pkg/apis/config"
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // Provenance contains metadata about resources used in the TaskRun/PipelineRun
 // such as the source from where a remote build definition was fetched.
@@ -25,6 +28,198 @@ type Provenance struct {
 
 	// FeatureFlags identifies the feature flags that were used during the task/pipeline run
 	FeatureFlags *config.FeatureFlags `json:"featureFlags,omitempty"`
+
+	// Executions is the record of the commands executed by the steps of a TaskRun,
+	// reported by the entrypoint when the "enable-execution-log" feature flag is set.
+	// +optional
+	// +listType=atomic
+	Executions []StepExecution `json:"executions,omitempty"`
+
+	// ServiceAccounts is the record of the service accounts a PipelineRun runs
+	// its tasks with, and of the ServiceAccountPolicies which allowed them.
+	// +optional
+	// +listType=atomic
+	ServiceAccounts []ServiceAccountGrant `json:"serviceAccounts,omitempty"`
+
+	// ConsumedResults is the record of the runs which produced the results consumed
+	// by the pipeline tasks of a PipelineRun, and of the values they consumed.
+	// +optional
+	// +listType=atomic
+	ConsumedResults []ResultProvenance `json:"consumedResults,omitempty"`
+
+	// SpecAttestation is the digest and the signature of the resolved spec the run
+	// executed, recorded by the controller when the "provenance-signing-key" feature
+	// flag is set.
+	// +optional
+	SpecAttestation *SpecAttestation `json:"specAttestation,omitempty"`
+
+	// Hermetic is true when the Steps of the TaskRun ran without network access
+	// and without the token of its service account, as requested by its spec or
+	// by its execution mode annotation.
+	// +optional
+	Hermetic bool `json:"hermetic,omitempty"`
+
+	// SLSA is the SLSA v1 provenance of the run, generated by the controller when
+	// the run is done and the "slsa-builder-id" feature flag is set.
+	// +optional
+	SLSA *SLSAProvenance `json:"slsa,omitempty"`
+}
+
+// SLSAProvenancePredicateType is the predicate type of the SLSA v1 provenance.
+const SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+
+// SLSAProvenance is the SLSA v1 provenance of a run, shaped as the subject and the
+// predicate of an in-toto statement so that signers such as Tekton Chains can sign
+// it as is. See https://slsa.dev/spec/v1.0/provenance.
+type SLSAProvenance struct {
+	// Subject is the artifacts the run produced, read from its type-hinted results.
+	// +optional
+	// +listType=atomic
+	Subject []SLSAResourceDescriptor `json:"subject,omitempty"`
+	// PredicateType is the type of the predicate, "https://slsa.dev/provenance/v1".
+	PredicateType string `json:"predicateType"`
+	// Predicate is the SLSA v1 provenance predicate.
+	Predicate SLSAPredicate `json:"predicate"`
+}
+
+// SLSAPredicate is the SLSA v1 provenance predicate of a run.
+type SLSAPredicate struct {
+	// BuildDefinition is the definition of the build the run executed.
+	BuildDefinition SLSABuildDefinition `json:"buildDefinition"`
+	// RunDetails is the record of the controller which executed the run.
+	RunDetails SLSARunDetails `json:"runDetails"`
+}
+
+// SLSABuildDefinition is the definition of the build a run executed.
+type SLSABuildDefinition struct {
+	// BuildType is the URI of the template the build definition follows, which
+	// differs for TaskRuns and PipelineRuns.
+	BuildType string `json:"buildType"`
+	// ExternalParameters is the parameters of the run, keyed by their names.
+	// +optional
+	ExternalParameters map[string]ParamValue `json:"externalParameters,omitempty"`
+	// ResolvedDependencies is the resolved remote definitions and the images of
+	// the steps the run executed.
+	// +optional
+	// +listType=atomic
+	ResolvedDependencies []SLSAResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+// SLSARunDetails is the record of the controller which executed a run.
+type SLSARunDetails struct {
+	// Builder identifies the controller which executed the run.
+	Builder SLSABuilder `json:"builder"`
+	// Metadata is the identifier and the times of the run.
+	// +optional
+	Metadata SLSABuildMetadata `json:"metadata,omitempty"`
+}
+
+// SLSABuilder identifies the controller which executed a run.
+type SLSABuilder struct {
+	// ID is the URI identifying the controller, set by the "slsa-builder-id"
+	// feature flag.
+	ID string `json:"id"`
+	// Version is the version of the controller, keyed by "tekton-pipelines".
+	// +optional
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// SLSABuildMetadata is the identifier and the times of a run.
+type SLSABuildMetadata struct {
+	// InvocationID is the UID of the run.
+	// +optional
+	InvocationID string `json:"invocationID,omitempty"`
+	// StartedOn is the time the run started.
+	// +optional
+	StartedOn *metav1.Time `json:"startedOn,omitempty"`
+	// FinishedOn is the time the run finished.
+	// +optional
+	FinishedOn *metav1.Time `json:"finishedOn,omitempty"`
+}
+
+// SLSAResourceDescriptor describes an artifact consumed or produced by a run.
+type SLSAResourceDescriptor struct {
+	// Name is the name of the artifact, e.g. the reference of an image without its
+	// digest.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// URI identifies the artifact.
+	// Example: "oci://gcr.io/tekton-releases/git-init"
+	// +optional
+	URI string `json:"uri,omitempty"`
+	// Digest is a collection of cryptographic digests of the artifact.
+	// Example: {"sha256": "f2ca1bb6c7e907d06dafe4687e579fce76b37e4e93b7605022da52e6ccc26fd2"}
+	// +optional
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// SpecAttestation is the record, signed by the controller, of the resolved spec a
+// run executed, which is the taskSpec or the pipelineSpec of its status.
+type SpecAttestation struct {
+	// Digest is the digest of the JSON encoding of the spec.
+	// Example: {"sha256": "f2ca1bb6c7e907d06dafe4687e579fce76b37e4e93b7605022da52e6ccc26fd2"}
+	Digest map[string]string `json:"digest"`
+	// Signature is the base64 encoded signature of the JSON encoding of the spec.
+	Signature string `json:"signature"`
+	// KeyRef is the reference of the KMS key which signed the spec.
+	KeyRef string `json:"keyRef"`
+}
+
+// ResultProvenance is the record of the run which produced the value of a result
+// consumed by a pipeline task.
+type ResultProvenance struct {
+	// PipelineTask is the name of the pipeline task which consumed the result.
+	PipelineTask string `json:"pipelineTask"`
+	// Result is the reference to the consumed result, e.g. "tasks.build.results.image".
+	Result string `json:"result"`
+	// Run is the name of the TaskRun or CustomRun which produced the value. The
+	// result of a matrixed pipeline task is recorded for each of its runs.
+	Run string `json:"run"`
+	// Attempt is the attempt of the run which produced the value, 0 for its first
+	// attempt and the number of retries before it otherwise.
+	Attempt int `json:"attempt"`
+	// Digest is the digest of the JSON encoding of the value when it was consumed,
+	// e.g. "sha256:<hex>".
+	Digest string `json:"digest"`
+}
+
+// ServiceAccountGrant is the record of a service account used by a PipelineRun.
+type ServiceAccountGrant struct {
+	// PipelineTask is the name of the pipeline task the service account is
+	// selected for in taskRunSpecs, empty for the service account of the PipelineRun.
+	// +optional
+	PipelineTask string `json:"pipelineTask,omitempty"`
+	// ServiceAccount is the name of the service account.
+	ServiceAccount string `json:"serviceAccount"`
+	// Policy is the name of the ServiceAccountPolicy which allowed the service
+	// account, empty when no policy applies to the Pipeline.
+	// +optional
+	Policy string `json:"policy,omitempty"`
+}
+
+// StepExecution is the record of the command executed by a step.
+type StepExecution struct {
+	// Name is the name of the step.
+	Name string `json:"name"`
+	// Argv is the command and arguments executed by the step.
+	// +optional
+	// +listType=atomic
+	Argv []string `json:"argv,omitempty"`
+	// ArgvTruncated is true when the trailing arguments of Argv were dropped
+	// because it exceeded the size limit of the execution log.
+	// +optional
+	ArgvTruncated bool `json:"argvTruncated,omitempty"`
+	// ImageID is the digest of the image the step executed.
+	// +optional
+	ImageID string `json:"imageID,omitempty"`
+	// StartedAt is the time the command started.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// FinishedAt is the time the command finished.
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+	// ExitCode is the exit code of the command.
+	ExitCode int32 `json:"exitCode"`
 }
 
 // RefSource contains the information that can uniquely identify where a remote
@@ -48,8 +48,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Task{},
 		&TaskList{},
+		&ClusterTask{},
+		&ClusterTaskList{},
 		&Pipeline{},
 		&PipelineList{},
+		&ClusterPipeline{},
+		&ClusterPipelineList{},
 		&TaskRun{},
 		&TaskRunList{},
 		&PipelineRun{},
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+)
+
+func TestRepeatUntil_Passes(t *testing.T) {
+	repeatUntil := &RepeatUntil{
+		When: WhenExpressions{{
+			Input:    "$(results.status)",
+			Operator: selection.In,
+			Values:   []string{"ready"},
+		}, {
+			Input:    "$(results.replicas.available)",
+			Operator: selection.NotIn,
+			Values:   []string{"0"},
+		}},
+		Limit: 5,
+	}
+	for _, tc := range []struct {
+		name    string
+		results []TaskRunResult
+		want    bool
+	}{{
+		name: "ready",
+		results: []TaskRunResult{
+			{Name: "status", Value: *NewStructuredValues("ready")},
+			{Name: "replicas", Value: *NewObject(map[string]string{"available": "3"})},
+		},
+		want: true,
+	}, {
+		name: "not ready",
+		results: []TaskRunResult{
+			{Name: "status", Value: *NewStructuredValues("pending")},
+			{Name: "replicas", Value: *NewObject(map[string]string{"available": "3"})},
+		},
+	}, {
+		name: "no replica available",
+		results: []TaskRunResult{
+			{Name: "status", Value: *NewStructuredValues("ready")},
+			{Name: "replicas", Value: *NewObject(map[string]string{"available": "0"})},
+		},
+	}, {
+		name: "missing results",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := repeatUntil.Passes(tc.results); got != tc.want {
+				t.Errorf("Passes() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+	if repeatUntil.When[0].Input != "$(results.status)" {
+		t.Errorf("expected Passes() not to modify the when expressions, got %v", repeatUntil.When)
+	}
+}
+
+func TestRepeatUntil_GetDelay(t *testing.T) {
+	if got := (&RepeatUntil{}).GetDelay(); got != DefaultRepeatDelay {
+		t.Errorf("GetDelay() = %s, want %s", got, DefaultRepeatDelay)
+	}
+	if got := (&RepeatUntil{Delay: &metav1.Duration{Duration: time.Minute}}).GetDelay(); got != time.Minute {
+		t.Errorf("GetDelay() = %s, want %s", got, time.Minute)
+	}
+}
+
+func TestRepeatUntil_Validate(t *testing.T) {
+	ready := WhenExpressions{{Input: "$(results.status)", Operator: selection.In, Values: []string{"ready"}}}
+	for _, tc := range []struct {
+		name                 string
+		repeatUntil          *RepeatUntil
+		enableAlphaAPIFields bool
+		wantErr              *apis.FieldError
+	}{{
+		name:                 "valid",
+		repeatUntil:          &RepeatUntil{When: ready, Delay: &metav1.Duration{Duration: time.Minute}, Limit: 10},
+		enableAlphaAPIFields: true,
+	}, {
+		name:        "alpha api fields disabled",
+		repeatUntil: &RepeatUntil{When: ready, Limit: 10},
+		wantErr:     apis.ErrGeneric(`repeatUntil requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name:                 "missing condition",
+		repeatUntil:          &RepeatUntil{Limit: 10},
+		enableAlphaAPIFields: true,
+		wantErr:              apis.ErrMissingField("when"),
+	}, {
+		name: "results of other tasks",
+		repeatUntil: &RepeatUntil{
+			When:  WhenExpressions{{Input: "$(tasks.deploy.results.status)", Operator: selection.In, Values: []string{"ready"}}},
+			Limit: 10,
+		},
+		enableAlphaAPIFields: true,
+		wantErr:              apis.ErrInvalidValue("$(tasks.deploy.results.status) can't reference the results of other tasks, use $(results.<name>) for the results of the task", "when[0]"),
+	}, {
+		name:                 "negative delay and no limit",
+		repeatUntil:          &RepeatUntil{When: ready, Delay: &metav1.Duration{Duration: -time.Minute}},
+		enableAlphaAPIFields: true,
+		wantErr: apis.ErrInvalidValue("-1m0s should be >= 0", "delay").Also(
+			apis.ErrInvalidValue("0 should be >= 1", "limit")),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tc.repeatUntil.Validate(ctx)
+			if d := cmp.Diff(tc.wantErr.Error(), err.Error()); d != "" {
+				t.Errorf("Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
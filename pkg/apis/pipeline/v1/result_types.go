@@ -34,6 +34,12 @@ type TaskResult struct {
 	Description string `json:"description,omitempty"`
 }
 
+// SidecarResult declares that a Sidecar writes one of the results of its Task.
+type SidecarResult struct {
+	// Name is the name of the Task result written by the Sidecar.
+	Name string `json:"name"`
+}
+
 // TaskRunResult used to describe the results of a task
 type TaskRunResult struct {
 	// Name the given name
@@ -48,6 +54,17 @@ type TaskRunResult struct {
 	Value ResultValue `json:"value"`
 }
 
+// ResultFile declares a file written into the Steps of a TaskRun, usually with the
+// value of a result of another PipelineTask. Large values delivered as files avoid the
+// argument length limits and quoting hazards of variable substitution.
+type ResultFile struct {
+	// Path is the absolute path of the file in the Steps.
+	Path string `json:"path"`
+
+	// Value is the content of the file, e.g. $(tasks.<pipelineTask>.results.<result>).
+	Value string `json:"value"`
+}
+
 // ResultValue is a type alias of ParamValue
 type ResultValue = ParamValue
 
@@ -63,10 +80,20 @@ const (
 	ResultsTypeString ResultsType = "string"
 	ResultsTypeArray  ResultsType = "array"
 	ResultsTypeObject ResultsType = "object"
+	// ResultsTypeFile is the type of the results whose file is uploaded by the entrypoint
+	// to the "result-file-store", their value being an object holding the uri and the
+	// digest of the uploaded file.
+	ResultsTypeFile ResultsType = "file"
 )
 
 // AllResultsTypes can be used for ResultsTypes validation.
-var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeArray, ResultsTypeObject}
+var AllResultsTypes = []ResultsType{ResultsTypeString, ResultsTypeArray, ResultsTypeObject, ResultsTypeFile}
+
+// FileResultURIKey and FileResultDigestKey are the keys of the value of the results of type file.
+const (
+	FileResultURIKey    = "uri"
+	FileResultDigestKey = "digest"
+)
 
 // ResultsArrayReference returns the reference of the result. e.g. results.resultname from $(results.resultname[*])
 func ResultsArrayReference(a string) string {
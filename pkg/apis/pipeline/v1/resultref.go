@@ -200,13 +200,33 @@ func ParseResultName(resultName string) (string, string) {
 // in a PipelineTask and returns a list of any references that are found.
 func PipelineTaskResultRefs(pt *PipelineTask) []*ResultRef {
 	refs := []*ResultRef{}
+	refs = append(refs, NewResultRefs(pt.varSubstitutionExpressions())...)
+	return refs
+}
+
+// varSubstitutionExpressions returns the variable substitution expressions found in all the
+// places a result reference can be used in a PipelineTask.
+func (pt *PipelineTask) varSubstitutionExpressions() []string {
+	var allExpressions []string
 	for _, p := range pt.extractAllParams() {
 		expressions, _ := GetVarSubstitutionExpressionsForParam(p)
-		refs = append(refs, NewResultRefs(expressions)...)
+		allExpressions = append(allExpressions, expressions...)
 	}
 	for _, whenExpression := range pt.When {
 		expressions, _ := whenExpression.GetVarSubstitutionExpressions()
-		refs = append(refs, NewResultRefs(expressions)...)
+		allExpressions = append(allExpressions, expressions...)
 	}
-	return refs
+	for _, ws := range pt.Workspaces {
+		allExpressions = append(allExpressions, validateString(ws.SubPath)...)
+	}
+	for _, rf := range pt.ResultFiles {
+		allExpressions = append(allExpressions, validateString(rf.Value)...)
+	}
+	allExpressions = append(allExpressions, validateString(pt.RetriesFrom)...)
+	allExpressions = append(allExpressions, validateString(pt.TimeoutFrom)...)
+	for _, item := range pt.WithItems {
+		allExpressions = append(allExpressions, validateString(item)...)
+	}
+	allExpressions = append(allExpressions, validateString(pt.WithParam)...)
+	return allExpressions
 }
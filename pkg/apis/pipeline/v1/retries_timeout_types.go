@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// GetRetries returns the number of retries of the PipelineTask, set by Retries or by
+// RetriesFrom, whose variables must have been substituted.
+func (pt PipelineTask) GetRetries() (int, error) {
+	if pt.RetriesFrom == "" {
+		return pt.Retries, nil
+	}
+	retries, err := strconv.Atoi(pt.RetriesFrom)
+	if err != nil || retries < 0 {
+		return 0, fmt.Errorf("retries %q must be a non-negative integer", pt.RetriesFrom)
+	}
+	return retries, nil
+}
+
+// GetTimeout returns the timeout of the PipelineTask, set by Timeout or by TimeoutFrom,
+// whose variables must have been substituted. It returns nil when neither is set.
+func (pt PipelineTask) GetTimeout() (*metav1.Duration, error) {
+	if pt.TimeoutFrom == "" {
+		return pt.Timeout, nil
+	}
+	d, err := time.ParseDuration(pt.TimeoutFrom)
+	if err != nil || d < 0 {
+		return nil, fmt.Errorf("timeout %q must be a non-negative duration", pt.TimeoutFrom)
+	}
+	return &metav1.Duration{Duration: d}, nil
+}
+
+// validateRetriesAndTimeout validates that RetriesFrom and TimeoutFrom are not set along
+// with Retries and Timeout, and the ones which don't reference any variable. The others
+// are validated once substituted.
+func (pt PipelineTask) validateRetriesAndTimeout() (errs *apis.FieldError) {
+	if pt.RetriesFrom != "" {
+		if pt.Retries != 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("retries", "retriesFrom"))
+		} else if len(validateString(pt.RetriesFrom)) == 0 {
+			if _, err := pt.GetRetries(); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(pt.RetriesFrom, "retriesFrom", err.Error()))
+			}
+		}
+	}
+	if pt.TimeoutFrom != "" {
+		if pt.Timeout != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("timeout", "timeoutFrom"))
+		} else if len(validateString(pt.TimeoutFrom)) == 0 {
+			if _, err := pt.GetTimeout(); err != nil {
+				errs = errs.Also(apis.ErrInvalidValue(pt.TimeoutFrom, "timeoutFrom", err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateRetriesAndTimeout validates the retries and the timeout of the PipelineTask
+// once the parameters and task results of RetriesFrom and TimeoutFrom are substituted.
+func (pt PipelineTask) ValidateRetriesAndTimeout() error {
+	if _, err := pt.GetRetries(); err != nil {
+		return fmt.Errorf("invalid retries of pipeline task %q: %w", pt.Name, err)
+	}
+	if _, err := pt.GetTimeout(); err != nil {
+		return fmt.Errorf("invalid timeout of pipeline task %q: %w", pt.Name, err)
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -491,6 +492,17 @@ func TestTaskSpecValidate(t *testing.T) {
 				hello "$(context.taskRun.uid)"`,
 			}},
 		},
+	}, {
+		name: "valid taskrun retryCount context",
+		fields: fields{
+			Steps: []v1.Step{{
+				Image: "my-image",
+				Args:  []string{"arg"},
+				Script: `
+				#!/usr/bin/env  bash
+				echo "attempt $(context.taskRun.retryCount)"`,
+			}},
+		},
 	}, {
 		name: "valid context",
 		fields: fields{
@@ -1386,6 +1398,16 @@ func TestTaskSpecValidateErrorSidecarName(t *testing.T) {
 			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedResultsSidecarName),
 			Paths:   []string{"sidecars"},
 		},
+	}, {
+		name: "cannot use reserved log forwarder sidecar name",
+		sidecars: []v1.Sidecar{{
+			Name:  "tekton-log-forwarder",
+			Image: "my-image",
+		}},
+		expectedError: apis.FieldError{
+			Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", pipeline.ReservedLogForwarderSidecarName),
+			Paths:   []string{"sidecars"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1512,6 +1534,169 @@ func TestStepAndSidecarWorkspacesErrors(t *testing.T) {
 	}
 }
 
+func TestSidecarResults(t *testing.T) {
+	steps := []v1.Step{{Image: "my-image", Args: []string{"arg"}}}
+	results := []v1.TaskResult{{Name: "coverage"}, {Name: "requests"}}
+	ts := &v1.TaskSpec{
+		Steps:   steps,
+		Results: results,
+		Sidecars: []v1.Sidecar{{
+			Name:    "proxy",
+			Image:   "my-proxy",
+			Results: []v1.SidecarResult{{Name: "coverage"}, {Name: "requests"}},
+		}},
+	}
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	ts.SetDefaults(ctx)
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		results       []v1.TaskResult
+		sidecars      []v1.Sidecar
+		ctx           context.Context
+		expectedError apis.FieldError
+	}{{
+		name: "undefined result",
+		sidecars: []v1.Sidecar{{
+			Image:   "my-proxy",
+			Results: []v1.SidecarResult{{Name: "missing"}},
+		}},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: `undefined result "missing"`,
+			Paths:   []string{"sidecars[0].results[0].name"},
+		},
+	}, {
+		name: "result written by two sidecars",
+		sidecars: []v1.Sidecar{{
+			Name:    "proxy",
+			Image:   "my-proxy",
+			Results: []v1.SidecarResult{{Name: "coverage"}},
+		}, {
+			Name:    "agent",
+			Image:   "my-agent",
+			Results: []v1.SidecarResult{{Name: "requests"}, {Name: "coverage"}},
+		}},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: `result "coverage" is written by more than one sidecar`,
+			Paths:   []string{"sidecars[1].results[1].name"},
+		},
+	}, {
+		name:    "result of type file",
+		results: []v1.TaskResult{{Name: "report", Type: v1.ResultsTypeFile}},
+		sidecars: []v1.Sidecar{{
+			Image:   "my-proxy",
+			Results: []v1.SidecarResult{{Name: "report"}},
+		}},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: `result "report" of type file cannot be written by a sidecar`,
+			Paths:   []string{"sidecars[0].results[0].name"},
+		},
+	}, {
+		name: "not alpha",
+		sidecars: []v1.Sidecar{{
+			Image:   "my-proxy",
+			Results: []v1.SidecarResult{{Name: "coverage"}},
+		}},
+		ctx: context.Background(),
+		expectedError: apis.FieldError{
+			Message: `sidecar results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    steps,
+				Results:  append(results, tt.results...),
+				Sidecars: tt.sidecars,
+			}
+			ts.SetDefaults(tt.ctx)
+			err := ts.Validate(tt.ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSidecarShutdown(t *testing.T) {
+	steps := []v1.Step{{Image: "my-image", Args: []string{"arg"}}}
+	ts := &v1.TaskSpec{
+		Steps: steps,
+		Sidecars: []v1.Sidecar{{
+			Name:  "db",
+			Image: "postgres",
+			Shutdown: &v1.SidecarShutdown{
+				PreStop:     []string{"pg_ctl", "stop", "-m", "smart"},
+				StopSignal:  "SIGINT",
+				GracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+		}},
+	}
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	ts.SetDefaults(ctx)
+	if err := ts.Validate(ctx); err != nil {
+		t.Errorf("TaskSpec.Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		shutdown      *v1.SidecarShutdown
+		ctx           context.Context
+		expectedError apis.FieldError
+	}{{
+		name:     "unknown signal",
+		shutdown: &v1.SidecarShutdown{StopSignal: "SIGSTOP"},
+		ctx:      config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: "invalid value: SIGSTOP",
+			Paths:   []string{"sidecars[0].shutdown.stopSignal"},
+			Details: "must be one of SIGHUP, SIGINT, SIGQUIT, SIGTERM, SIGUSR1, SIGUSR2",
+		},
+	}, {
+		name:     "negative grace period",
+		shutdown: &v1.SidecarShutdown{GracePeriod: &metav1.Duration{Duration: -time.Second}},
+		ctx:      config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: "invalid value: -1s should be >= 0",
+			Paths:   []string{"sidecars[0].shutdown.gracePeriod"},
+		},
+	}, {
+		name:     "not alpha",
+		shutdown: &v1.SidecarShutdown{StopSignal: "SIGINT"},
+		ctx:      context.Background(),
+		expectedError: apis.FieldError{
+			Message: `sidecar shutdown requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:    steps,
+				Sidecars: []v1.Sidecar{{Image: "postgres", Shutdown: tt.shutdown}},
+			}
+			ts.SetDefaults(tt.ctx)
+			err := ts.Validate(tt.ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", ts)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestStepOnError(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -1578,6 +1763,597 @@ func TestStepOnError(t *testing.T) {
 	}
 }
 
+func TestStepInterpolation(t *testing.T) {
+	tests := []struct {
+		name          string
+		steps         []v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step - set to literal",
+		steps: []v1.Step{{
+			Interpolation: v1.LiteralInterpolation,
+			Image:         "image",
+			Script:        "echo hello",
+		}},
+	}, {
+		name: "valid step - set to shellSafe",
+		steps: []v1.Step{{
+			Interpolation: v1.ShellSafeInterpolation,
+			Image:         "image",
+			Script:        "echo hello",
+		}},
+	}, {
+		name: "invalid step - set to invalid value",
+		steps: []v1.Step{{
+			Interpolation: "shell",
+			Image:         "image",
+			Script:        "echo hello",
+		}},
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "shell"`,
+			Paths:   []string{"steps[0].interpolation"},
+			Details: `Task step interpolation must be either "literal" or "shellSafe"`,
+		},
+	}, {
+		name: "invalid step - set without script",
+		steps: []v1.Step{{
+			Interpolation: v1.ShellSafeInterpolation,
+			Image:         "image",
+			Args:          []string{"arg"},
+		}},
+		expectedError: &apis.FieldError{
+			Message: "interpolation can only be set with script",
+			Paths:   []string{"steps[0].interpolation"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: tt.steps,
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestStepMemoryProfile(t *testing.T) {
+	tests := []struct {
+		name          string
+		step          v1.Step
+		alpha         bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step - set to buildHeavy",
+		step: v1.Step{
+			Image:         "image",
+			MemoryProfile: v1.BuildHeavyMemoryProfile,
+		},
+		alpha: true,
+	}, {
+		name: "invalid step - set to invalid value",
+		step: v1.Step{
+			Image:         "image",
+			MemoryProfile: "huge",
+		},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "huge"`,
+			Paths:   []string{"steps[0].memoryProfile"},
+			Details: `Task step memoryProfile must be "buildHeavy"`,
+		},
+	}, {
+		name: "invalid step - not alpha",
+		step: v1.Step{
+			Image:         "image",
+			MemoryProfile: v1.BuildHeavyMemoryProfile,
+		},
+		expectedError: apis.ErrGeneric(`step memory profile requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{tt.step},
+			}
+			ctx := context.Background()
+			if tt.alpha {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			} else {
+				ctx = config.EnableBetaAPIFields(ctx)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestStepSandbox(t *testing.T) {
+	localhostProfile := "profiles/buildah.json"
+	tests := []struct {
+		name          string
+		step          v1.Step
+		alpha         bool
+		expectedError *apis.FieldError
+	}{{
+		name: "valid step - all profiles",
+		step: v1.Step{
+			Image: "image",
+			Sandbox: &v1.StepSandbox{
+				Seccomp:       &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost, LocalhostProfile: &localhostProfile},
+				AppArmor:      "localhost/buildah",
+				SELinux:       &corev1.SELinuxOptions{Type: "container_runtime_t"},
+				UserNamespace: true,
+			},
+			SecurityContext: &corev1.SecurityContext{RunAsUser: new(int64)},
+		},
+		alpha: true,
+	}, {
+		name: "valid step - runtime default profiles",
+		step: v1.Step{
+			Image: "image",
+			Sandbox: &v1.StepSandbox{
+				Seccomp:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				AppArmor: v1.AppArmorRuntimeDefault,
+			},
+		},
+		alpha: true,
+	}, {
+		name: "invalid step - seccomp profile type",
+		step: v1.Step{
+			Image:   "image",
+			Sandbox: &v1.StepSandbox{Seccomp: &corev1.SeccompProfile{Type: "Strict"}},
+		},
+		alpha:         true,
+		expectedError: apis.ErrInvalidValue("Strict", "steps[0].sandbox.seccomp.type", `type must be one of "RuntimeDefault", "Unconfined" or "Localhost"`),
+	}, {
+		name: "invalid step - localhost seccomp profile without a profile",
+		step: v1.Step{
+			Image:   "image",
+			Sandbox: &v1.StepSandbox{Seccomp: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeLocalhost}},
+		},
+		alpha:         true,
+		expectedError: apis.ErrMissingField("steps[0].sandbox.seccomp.localhostProfile"),
+	}, {
+		name: "invalid step - localhost profile of a runtime default seccomp profile",
+		step: v1.Step{
+			Image:   "image",
+			Sandbox: &v1.StepSandbox{Seccomp: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault, LocalhostProfile: &localhostProfile}},
+		},
+		alpha:         true,
+		expectedError: apis.ErrDisallowedFields("steps[0].sandbox.seccomp.localhostProfile"),
+	}, {
+		name: "invalid step - apparmor profile",
+		step: v1.Step{
+			Image:   "image",
+			Sandbox: &v1.StepSandbox{AppArmor: "localhost/"},
+		},
+		alpha:         true,
+		expectedError: apis.ErrInvalidValue("localhost/", "steps[0].sandbox.appArmor", `appArmor must be one of "runtime/default", "unconfined" or "localhost/<profile>"`),
+	}, {
+		name: "invalid step - profiles in the security context too",
+		step: v1.Step{
+			Image: "image",
+			Sandbox: &v1.StepSandbox{
+				Seccomp: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+				SELinux: &corev1.SELinuxOptions{Type: "container_runtime_t"},
+			},
+			SecurityContext: &corev1.SecurityContext{
+				SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				SELinuxOptions: &corev1.SELinuxOptions{Type: "container_t"},
+			},
+		},
+		alpha: true,
+		expectedError: apis.ErrMultipleOneOf("steps[0].securityContext.seccompProfile", "steps[0].sandbox.seccomp").Also(
+			apis.ErrMultipleOneOf("steps[0].securityContext.seLinuxOptions", "steps[0].sandbox.seLinux")),
+	}, {
+		name: "invalid step - not alpha",
+		step: v1.Step{
+			Image:   "image",
+			Sandbox: &v1.StepSandbox{UserNamespace: true},
+		},
+		expectedError: apis.ErrGeneric(`step sandbox requires "enable-api-fields" feature gate to be "alpha" but it is "beta"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{tt.step},
+			}
+			ctx := context.Background()
+			if tt.alpha {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			} else {
+				ctx = config.EnableBetaAPIFields(ctx)
+			}
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestStepImageDigests(t *testing.T) {
+	digest := "sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	tests := []struct {
+		name             string
+		stepImageDigests string
+		ts               v1.TaskSpec
+		expectedError    *apis.FieldError
+	}{{
+		name:             "tags allowed by default",
+		stepImageDigests: config.StepImageDigestsAllowTags,
+		ts: v1.TaskSpec{
+			Steps:    []v1.Step{{Image: "alpine:3.18"}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "registry"}},
+		},
+	}, {
+		name:             "tags allowed when pinned",
+		stepImageDigests: config.StepImageDigestsPin,
+		ts: v1.TaskSpec{
+			Steps: []v1.Step{{Image: "alpine:3.18"}},
+		},
+	}, {
+		name:             "digests and variables allowed when required",
+		stepImageDigests: config.StepImageDigestsRequire,
+		ts: v1.TaskSpec{
+			Params:   []v1.ParamSpec{{Name: "image", Type: v1.ParamTypeString}},
+			Steps:    []v1.Step{{Image: "alpine@" + digest}, {Image: "$(params.image)"}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "gcr.io/tekton/registry:2@" + digest}},
+		},
+	}, {
+		name:             "tags disallowed when required",
+		stepImageDigests: config.StepImageDigestsRequire,
+		ts: v1.TaskSpec{
+			Steps:    []v1.Step{{Image: "alpine@" + digest}, {Image: "alpine:3.18"}},
+			Sidecars: []v1.Sidecar{{Name: "registry", Image: "registry"}},
+		},
+		expectedError: apis.ErrInvalidValue("alpine:3.18", "steps[1].image", `image must be referenced by digest when "step-image-digests" is "require"`).Also(
+			apis.ErrInvalidValue("registry", "sidecars[0].image", `image must be referenced by digest when "step-image-digests" is "require"`)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{
+				FeatureFlags: &config.FeatureFlags{StepImageDigests: tt.stepImageDigests},
+			})
+			tt.ts.SetDefaults(ctx)
+			err := tt.ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestStepScratchVolumes(t *testing.T) {
+	scratch := func(name, mountPath, size string, medium corev1.StorageMedium) v1.StepScratchVolume {
+		return v1.StepScratchVolume{Name: name, MountPath: mountPath, Size: resource.MustParse(size), Medium: medium}
+	}
+	tests := []struct {
+		name          string
+		step          v1.Step
+		expectedError *apis.FieldError
+	}{{
+		name: "valid scratch volumes",
+		step: v1.Step{
+			Image: "image",
+			Scratch: []v1.StepScratchVolume{
+				scratch("cache", "/cache", "1Gi", ""),
+				scratch("tmp", "/tmp", "64Mi", corev1.StorageMediumMemory),
+			},
+		},
+	}, {
+		name: "duplicate name",
+		step: v1.Step{
+			Image: "image",
+			Scratch: []v1.StepScratchVolume{
+				scratch("cache", "/cache", "1Gi", ""),
+				scratch("cache", "/other-cache", "1Gi", ""),
+			},
+		},
+		expectedError: apis.ErrGeneric(`scratch volume name "cache" must be unique`, "steps[0].scratch[1].name"),
+	}, {
+		name: "relative mount path",
+		step: v1.Step{
+			Image:   "image",
+			Scratch: []v1.StepScratchVolume{scratch("cache", "cache", "1Gi", "")},
+		},
+		expectedError: apis.ErrInvalidValue("cache", "steps[0].scratch[0].mountPath", "mountPath must be an absolute path"),
+	}, {
+		name: "mounted under /tekton/",
+		step: v1.Step{
+			Image:   "image",
+			Scratch: []v1.StepScratchVolume{scratch("cache", "/tekton/cache", "1Gi", "")},
+		},
+		expectedError: apis.ErrGeneric(`scratch volume cannot be mounted under /tekton/ (scratch volume "cache" mounted at "/tekton/cache")`, "steps[0].scratch[0].mountPath"),
+	}, {
+		name: "mount path used by a volumeMount",
+		step: v1.Step{
+			Image:        "image",
+			VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data/"}},
+			Scratch:      []v1.StepScratchVolume{scratch("cache", "/data", "1Gi", "")},
+		},
+		expectedError: apis.ErrGeneric(`mountPath "/data" is already used by another volume of the step`, "steps[0].scratch[0].mountPath"),
+	}, {
+		name: "missing size",
+		step: v1.Step{
+			Image:   "image",
+			Scratch: []v1.StepScratchVolume{{Name: "cache", MountPath: "/cache"}},
+		},
+		expectedError: apis.ErrInvalidValue("0", "steps[0].scratch[0].size", "size must be greater than zero"),
+	}, {
+		name: "invalid medium",
+		step: v1.Step{
+			Image:   "image",
+			Scratch: []v1.StepScratchVolume{scratch("cache", "/cache", "1Gi", "HugePages")},
+		},
+		expectedError: apis.ErrInvalidValue("HugePages", "steps[0].scratch[0].medium", `medium must be either "" or "Memory"`),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps: []v1.Step{tt.step},
+			}
+			ctx := config.EnableAlphaAPIFields(context.Background())
+			ts.SetDefaults(ctx)
+			err := ts.Validate(ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestExitCodeMappings(t *testing.T) {
+	tests := []struct {
+		name          string
+		mappings      []v1.ExitCodeMapping
+		ctx           context.Context
+		expectedError *apis.FieldError
+	}{{
+		name: "valid exit code mappings",
+		mappings: []v1.ExitCodeMapping{
+			{ExitCode: 0, Reason: "Succeeded"},
+			{ExitCode: 3, Reason: "SucceededWithWarnings"},
+			{ExitCode: 4, Reason: "Skipped"},
+		},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+	}, {
+		name:          "alpha feature not enabled",
+		mappings:      []v1.ExitCodeMapping{{ExitCode: 3, Reason: "SucceededWithWarnings"}},
+		ctx:           context.Background(),
+		expectedError: apis.ErrGeneric(`exitCodeMappings requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`, ""),
+	}, {
+		name:          "exit code out of bounds",
+		mappings:      []v1.ExitCodeMapping{{ExitCode: 256, Reason: "SucceededWithWarnings"}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrOutOfBoundsValue(256, 0, 255, "exitCodeMappings[0].exitCode"),
+	}, {
+		name: "exit code mapped more than once",
+		mappings: []v1.ExitCodeMapping{
+			{ExitCode: 3, Reason: "SucceededWithWarnings"},
+			{ExitCode: 3, Reason: "Skipped"},
+		},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric("exit code 3 mapped more than once, at index 0 and 1", "exitCodeMappings[1].exitCode"),
+	}, {
+		name:          "reason not CamelCase",
+		mappings:      []v1.ExitCodeMapping{{ExitCode: 3, Reason: "succeeded with warnings"}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrInvalidValue(`"succeeded with warnings" must be a CamelCase reason, e.g. SucceededWithWarnings`, "exitCodeMappings[0].reason"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:            []v1.Step{{Image: "image"}},
+				ExitCodeMappings: tt.mappings,
+			}
+			ts.SetDefaults(tt.ctx)
+			err := ts.Validate(tt.ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestParamSpecConstraints(t *testing.T) {
+	minimum, maximum := 10.0, 1.0
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		ctx           context.Context
+		expectedError *apis.FieldError
+	}{{
+		name: "valid constraints",
+		params: []v1.ParamSpec{{
+			Name:    "env",
+			Type:    v1.ParamTypeString,
+			Enum:    []string{"staging", "prod"},
+			Default: v1.NewStructuredValues("staging"),
+		}, {
+			Name:    "version",
+			Type:    v1.ParamTypeArray,
+			Pattern: "^v[0-9]+$",
+			Default: v1.NewStructuredValues("v1", "v2"),
+		}, {
+			Name:    "replicas",
+			Type:    v1.ParamTypeString,
+			Minimum: &maximum,
+			Maximum: &minimum,
+		}},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+	}, {
+		name:          "alpha feature not enabled",
+		params:        []v1.ParamSpec{{Name: "env", Type: v1.ParamTypeString, Enum: []string{"staging", "prod"}}},
+		ctx:           context.Background(),
+		expectedError: apis.ErrGeneric(`env.enum requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`, ""),
+	}, {
+		name: "constraint on object param",
+		params: []v1.ParamSpec{{
+			Name:       "repo",
+			Type:       v1.ParamTypeObject,
+			Properties: map[string]v1.PropertySpec{"url": {Type: v1.ParamTypeString}},
+			Pattern:    "^https://",
+		}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric("pattern can't be set on a param of type object", "params.repo.pattern"),
+	}, {
+		name:          "duplicate enum values",
+		params:        []v1.ParamSpec{{Name: "env", Type: v1.ParamTypeString, Enum: []string{"prod", "prod"}}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric("enum values must be unique", "params.env.enum"),
+	}, {
+		name:          "invalid pattern",
+		params:        []v1.ParamSpec{{Name: "version", Type: v1.ParamTypeString, Pattern: "v[0-9"}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrInvalidValue("v[0-9", "params.version.pattern", "error parsing regexp: missing closing ]: `[0-9`"),
+	}, {
+		name:          "minimum greater than maximum",
+		params:        []v1.ParamSpec{{Name: "replicas", Type: v1.ParamTypeString, Minimum: &minimum, Maximum: &maximum}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric("minimum must be less than or equal to maximum", "params.replicas.maximum", "params.replicas.minimum"),
+	}, {
+		name: "default not satisfying the constraints",
+		params: []v1.ParamSpec{{
+			Name:    "env",
+			Type:    v1.ParamTypeString,
+			Enum:    []string{"staging", "prod"},
+			Default: v1.NewStructuredValues("qa"),
+		}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric(`param "env" value "qa" is not one of the allowed values [staging prod]`, "params.env.default"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:  []v1.Step{{Image: "image"}},
+				Params: tt.params,
+			}
+			ts.SetDefaults(tt.ctx)
+			err := ts.Validate(tt.ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
+func TestParamSpecSecretType(t *testing.T) {
+	tests := []struct {
+		name          string
+		params        []v1.ParamSpec
+		ctx           context.Context
+		expectedError *apis.FieldError
+	}{{
+		name: "valid secret param",
+		params: []v1.ParamSpec{{
+			Name:    "token",
+			Type:    v1.ParamTypeSecret,
+			Default: v1.NewObject(map[string]string{"name": "registry", "key": "token"}),
+		}, {
+			Name: "password",
+			Type: v1.ParamTypeSecret,
+		}},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+	}, {
+		name:          "alpha feature not enabled",
+		params:        []v1.ParamSpec{{Name: "token", Type: v1.ParamTypeSecret}},
+		ctx:           context.Background(),
+		expectedError: apis.ErrGeneric(`secret type parameter requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`, ""),
+	}, {
+		name:   "string default",
+		params: []v1.ParamSpec{{Name: "token", Type: v1.ParamTypeSecret, Default: v1.NewStructuredValues("s3cr3t")}},
+		ctx:    config.EnableAlphaAPIFields(context.Background()),
+		expectedError: &apis.FieldError{
+			Message: `"secret" type does not match default value's type: "string"`,
+			Paths:   []string{"params.token.default.type", "params.token.type"},
+		},
+	}, {
+		name: "default not referencing a key",
+		params: []v1.ParamSpec{{
+			Name:    "token",
+			Type:    v1.ParamTypeSecret,
+			Default: v1.NewObject(map[string]string{"name": "registry", "namespace": "ci"}),
+		}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrMissingField("params.token.default.key").Also(apis.ErrDisallowedFields("params.token.default.namespace")),
+	}, {
+		name:          "constraint on secret param",
+		params:        []v1.ParamSpec{{Name: "token", Type: v1.ParamTypeSecret, Pattern: "^ghp_"}},
+		ctx:           config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.ErrGeneric("pattern can't be set on a param of type secret", "params.token.pattern"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := &v1.TaskSpec{
+				Steps:  []v1.Step{{Image: "image", Script: "login --password-file $(params.token)"}},
+				Params: tt.params,
+			}
+			ts.SetDefaults(tt.ctx)
+			err := ts.Validate(tt.ctx)
+			if tt.expectedError == nil && err != nil {
+				t.Errorf("No error expected from TaskSpec.Validate() but got = %v", err)
+			} else if tt.expectedError != nil {
+				if err == nil {
+					t.Errorf("Expected error from TaskSpec.Validate() = %v, but got none", tt.expectedError)
+				} else if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+					t.Errorf("returned error from TaskSpec.Validate() does not match with the expected error: %s", diff.PrintWantGot(d))
+				}
+			}
+		})
+	}
+}
+
 // TestIncompatibleAPIVersions exercises validation of fields that
 // require a specific feature gate version in order to work.
 func TestIncompatibleAPIVersions(t *testing.T) {
@@ -1648,6 +2424,25 @@ func TestIncompatibleAPIVersions(t *testing.T) {
 					Path: "/tmp/stderr.txt",
 				},
 			}},
+		},
+	}, {
+		name:            "script interpolation requires alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:         "foo",
+				Script:        "echo $(context.task.name)",
+				Interpolation: v1.ShellSafeInterpolation,
+			}},
+		},
+	}, {
+		name:            "step scratch volumes require alpha",
+		requiredVersion: "alpha",
+		spec: v1.TaskSpec{
+			Steps: []v1.Step{{
+				Image:   "foo",
+				Scratch: []v1.StepScratchVolume{{Name: "cache", MountPath: "/cache", Size: resource.MustParse("1Gi")}},
+			}},
 		}},
 	}
 	versions := []string{"alpha", "stable"}
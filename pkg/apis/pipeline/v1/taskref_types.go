@@ -43,8 +43,11 @@ const (
 	// NamespacedTaskKind indicates that the task type has a namespaced scope.
 	NamespacedTaskKind TaskKind = "Task"
 	// ClusterTaskRefKind is the task type for a reference to a task with cluster scope.
-	// ClusterTasks are not supported in v1, but v1 types may reference ClusterTasks.
+	// The ClusterTask must be visible in the namespace of the reference.
 	ClusterTaskRefKind TaskKind = "ClusterTask"
+	// TaskGroupKind indicates that the task type is a TaskGroup, which inlines
+	// several Tasks into a single one.
+	TaskGroupKind TaskKind = "TaskGroup"
 )
 
 // IsCustomTask checks whether the reference is to a Custom Task
@@ -51,5 +51,11 @@ func (ref *TaskRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 	} else if ref.Name == "" {
 		errs = errs.Also(apis.ErrMissingField("name"))
 	}
+	if ref.Kind == TaskGroupKind && ref.APIVersion == "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "TaskGroup", config.AlphaAPIFields))
+		if ref.Resolver != "" {
+			errs = errs.Also(apis.ErrInvalidValue("TaskGroups can only be referred to by name", "kind"))
+		}
+	}
 	return
 }
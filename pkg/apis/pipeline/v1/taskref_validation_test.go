@@ -61,6 +61,10 @@ func TestTaskRef_Valid(t *testing.T) {
 			},
 		}}}},
 		wc: config.EnableBetaAPIFields,
+	}, {
+		name:    "alpha feature: taskgroup",
+		taskRef: &v1.TaskRef{Name: "clone-and-build", Kind: v1.TaskGroupKind},
+		wc:      config.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -154,6 +158,20 @@ func TestTaskRef_Invalid(t *testing.T) {
 		wantErr: apis.ErrGeneric("resolver requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"").Also(
 			apis.ErrGeneric("resolver params requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"")).Also(
 			apis.ErrGeneric("object type parameter requires \"enable-api-fields\" feature gate to be \"alpha\" or \"beta\" but it is \"stable\"")),
+	}, {
+		name:    "taskgroup disallowed without alpha feature gate",
+		taskRef: &v1.TaskRef{Name: "clone-and-build", Kind: v1.TaskGroupKind},
+		wantErr: apis.ErrGeneric("TaskGroup requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "taskgroup disallowed with resolver",
+		taskRef: &v1.TaskRef{
+			Kind: v1.TaskGroupKind,
+			ResolverRef: v1.ResolverRef{
+				Resolver: "git",
+			},
+		},
+		wantErr: apis.ErrInvalidValue("TaskGroups can only be referred to by name", "kind"),
+		wc:      config.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -49,28 +49,30 @@ func (tr *TaskRun) SetDefaults(ctx context.Context) {
 
 // SetDefaults implements apis.Defaultable
 func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
-	cfg := config.FromContextOrDefaults(ctx)
+	defaults := config.DefaultsForNamespace(ctx, apis.ParentMeta(ctx).Namespace)
 	if trs.TaskRef != nil {
 		if trs.TaskRef.Kind == "" {
 			trs.TaskRef.Kind = NamespacedTaskKind
 		}
 		if trs.TaskRef.Name == "" && trs.TaskRef.Resolver == "" {
-			trs.TaskRef.Resolver = ResolverName(cfg.Defaults.DefaultResolverType)
+			trs.TaskRef.Resolver = ResolverName(defaults.DefaultResolverType)
 		}
 	}
 
 	if trs.Timeout == nil {
-		trs.Timeout = &metav1.Duration{Duration: time.Duration(cfg.Defaults.DefaultTimeoutMinutes) * time.Minute}
+		trs.Timeout = &metav1.Duration{Duration: time.Duration(defaults.DefaultTimeoutMinutes) * time.Minute}
 	}
 
-	defaultSA := cfg.Defaults.DefaultServiceAccount
+	defaultSA := defaults.DefaultServiceAccount
 	if trs.ServiceAccountName == "" && defaultSA != "" {
 		trs.ServiceAccountName = defaultSA
 	}
 
-	defaultPodTemplate := cfg.Defaults.DefaultPodTemplate
+	defaultPodTemplate := defaults.DefaultPodTemplate
 	trs.PodTemplate = pod.MergePodTemplateWithDefault(trs.PodTemplate, defaultPodTemplate)
 
+	trs.Params.setValueFromDefaults()
+
 	// If this taskrun has an embedded task, apply the usual task defaults
 	if trs.TaskSpec != nil {
 		trs.TaskSpec.SetDefaults(ctx)
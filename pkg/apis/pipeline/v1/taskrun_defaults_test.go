@@ -29,6 +29,8 @@ import (
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 var (
@@ -402,3 +404,43 @@ func TestTaskRunDefaulting(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskRunDefaultingWithNamespaceDefaults(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceDefaultsConfigName, Namespace: "team-a"},
+		Data: map[string]string{
+			"default-timeout-minutes": "120",
+			"default-service-account": "team-a",
+			"default-pod-template":    "nodeSelector:\n  team: a\n",
+		},
+	}); err != nil {
+		t.Fatalf("failed to add configmap: %v", err)
+	}
+	ctx := cfgtesting.SetDefaults(context.Background(), t, map[string]string{})
+	ctx = config.WithNamespaceDefaults(ctx, corev1listers.NewConfigMapLister(indexer))
+
+	got := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "team-a"},
+		Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "foo"},
+		},
+	}
+	want := &v1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "run",
+			Namespace: "team-a",
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+		},
+		Spec: v1.TaskRunSpec{
+			TaskRef:            &v1.TaskRef{Name: "foo", Kind: v1.NamespacedTaskKind},
+			ServiceAccountName: "team-a",
+			PodTemplate:        &pod.Template{NodeSelector: map[string]string{"team": "a"}},
+			Timeout:            &metav1.Duration{Duration: 2 * time.Hour},
+		},
+	}
+	got.SetDefaults(ctx)
+	if d := cmp.Diff(want, got, ignoreUnexportedResources); d != "" {
+		t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+	}
+}
@@ -54,6 +54,9 @@ type TaskRunSpec struct {
 	// Retries represents how many times this TaskRun should be retried in the event of task failure.
 	// +optional
 	Retries int `json:"retries,omitempty"`
+	// RepeatUntil re-runs this TaskRun after it succeeded until a condition on its results passes.
+	// +optional
+	RepeatUntil *RepeatUntil `json:"repeatUntil,omitempty"`
 	// Time after which one retry attempt times out. Defaults to 1 hour.
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
@@ -64,6 +67,10 @@ type TaskRunSpec struct {
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspaceBinding `json:"workspaces,omitempty"`
+	// ResultFiles is a list of files written into the Steps of this TaskRun.
+	// +optional
+	// +listType=atomic
+	ResultFiles []ResultFile `json:"resultFiles,omitempty"`
 	// Specs to apply to Steps in this TaskRun.
 	// If a field is specified in both a Step and a StepSpec,
 	// the value from the StepSpec will be used.
@@ -80,6 +87,16 @@ type TaskRunSpec struct {
 	SidecarSpecs []TaskRunSidecarSpec `json:"sidecarSpecs,omitempty"`
 	// Compute resources to use for this TaskRun
 	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// TTLSecondsAfterFinished is the number of seconds the controller keeps the
+	// TaskRun for after it finishes, before deleting it. The TaskRun is kept until
+	// deleted when it is unset.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// Hermetic runs the Steps of this TaskRun without network access and without
+	// the token of its service account. It is recorded in its provenance.
+	// This field is only supported when the alpha feature gate is enabled.
+	// +optional
+	Hermetic bool `json:"hermetic,omitempty"`
 }
 
 // TaskRunSpecStatus defines the TaskRun spec status the user can provide
@@ -142,6 +159,12 @@ const (
 	TaskRunReasonFailed TaskRunReason = "Failed"
 	// TaskRunReasonToBeRetried is the reason set when the last TaskRun execution failed, and will be retried
 	TaskRunReasonToBeRetried TaskRunReason = "ToBeRetried"
+	// TaskRunReasonToBeRepeated is the reason set when the last TaskRun execution succeeded, but the
+	// condition of its repetitions doesn't pass yet
+	TaskRunReasonToBeRepeated TaskRunReason = "ToBeRepeated"
+	// TaskRunReasonRepeatLimitExceeded is the reason set when the condition of the repetitions of the
+	// TaskRun still doesn't pass after the last one
+	TaskRunReasonRepeatLimitExceeded TaskRunReason = "TaskRunRepeatLimitExceeded"
 	// TaskRunReasonCancelled is the reason set when the TaskRun is cancelled by the user
 	TaskRunReasonCancelled TaskRunReason = "TaskRunCancelled"
 	// TaskRunReasonTimedOut is the reason set when one TaskRun execution has timed out
@@ -153,6 +176,9 @@ const (
 	TaskRunReasonImagePullFailed TaskRunReason = "TaskRunImagePullFailed"
 	// TaskRunReasonResultLargerThanAllowedLimit is the reason set when one of the results exceeds its maximum allowed limit of 1 KB
 	TaskRunReasonResultLargerThanAllowedLimit TaskRunReason = "TaskRunResultLargerThanAllowedLimit"
+	// TaskRunReasonFaultInjected is the reason set when the TaskRun fails because of a step
+	// failure injected when the "enable-fault-injection" feature flag is set.
+	TaskRunReasonFaultInjected TaskRunReason = "TaskRunFaultInjected"
 	// TaskRunReasonStopSidecarFailed indicates that the sidecar is not properly stopped.
 	TaskRunReasonStopSidecarFailed = "TaskRunStopSidecarFailed"
 )
@@ -216,7 +242,8 @@ type TaskRunStatusFields struct {
 	// +listType=atomic
 	Steps []StepState `json:"steps,omitempty"`
 
-	// RetriesStatus contains the history of TaskRunStatus in case of a retry in order to keep record of failures.
+	// RetriesStatus contains the history of TaskRunStatus in case of a retry in order to keep record of failures,
+	// or of a repetition in order to keep record of the previous runs.
 	// All TaskRunStatus stored in RetriesStatus will have no date within the RetriesStatus as is redundant.
 	// +optional
 	// +listType=atomic
@@ -241,6 +268,64 @@ type TaskRunStatusFields struct {
 
 	// SpanContext contains tracing span context fields
 	SpanContext map[string]string `json:"spanContext,omitempty"`
+
+	// Workspaces contains the digests of the contents of workspaces that
+	// were declared with digest enabled.
+	// +optional
+	// +listType=atomic
+	Workspaces []WorkspaceStatus `json:"workspaces,omitempty"`
+
+	// StepResourceUsage contains the peak usage of compute resources by each
+	// Step, sampled while the TaskRun runs if "step-resource-usage-source" is set.
+	// +optional
+	// +listType=atomic
+	StepResourceUsage []StepResourceUsage `json:"stepResourceUsage,omitempty"`
+
+	// StepLogs locate the logs of the Steps, archived in an object store when
+	// the TaskRun completes if "step-log-store" is set.
+	// +optional
+	// +listType=atomic
+	StepLogs []StepLog `json:"stepLogs,omitempty"`
+
+	// StepProgress reports the progress of the Steps, from the markers their
+	// entrypoint emits when the "enable-step-progress" feature flag is set.
+	// +optional
+	// +listType=atomic
+	StepProgress []StepProgress `json:"stepProgress,omitempty"`
+
+	// FaultInjection records the faults injected in the TaskRun when the
+	// "enable-fault-injection" feature flag is set.
+	// +optional
+	FaultInjection *FaultInjectionStatus `json:"faultInjection,omitempty"`
+
+	// ResolvedParams are the values of the params taking their values from the keys
+	// of ConfigMaps, resolved once when the TaskRun started.
+	// +optional
+	// +listType=atomic
+	ResolvedParams Params `json:"resolvedParams,omitempty"`
+}
+
+// FaultInjectionStatus records the faults injected in a TaskRun.
+type FaultInjectionStatus struct {
+	// Seed is the seed the faults of the TaskRun are drawn from. It reproduces
+	// them with the same fault injection configuration.
+	Seed int64 `json:"seed"`
+	// Faults are the faults injected in the TaskRun.
+	// +optional
+	// +listType=atomic
+	Faults []InjectedFault `json:"faults,omitempty"`
+}
+
+// InjectedFault is a fault injected in a TaskRun.
+type InjectedFault struct {
+	// Type is the type of the fault: "pod-start-delay", "step-failure" or "dropped-events".
+	Type string `json:"type"`
+	// Attempt is the attempt of the TaskRun the fault was injected in: 0 for the
+	// first attempt and n for its n-th retry.
+	Attempt int `json:"attempt"`
+	// Message describes the fault.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // TaskRunStepSpec is used to override the values of a Step in the corresponding Task.
@@ -308,6 +393,45 @@ type StepState struct {
 	ImageID               string `json:"imageID,omitempty"`
 }
 
+// StepResourceUsage reports the peak usage of compute resources by a Step.
+type StepResourceUsage struct {
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	// Peak is the highest sampled usage of cpu and memory by the Step.
+	// +optional
+	Peak corev1.ResourceList `json:"peak,omitempty"`
+}
+
+// StepLog locates the log of a Step archived in an object store.
+type StepLog struct {
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	// URL is the address the log of the Step is archived at.
+	URL string `json:"url,omitempty"`
+}
+
+// StepProgress reports the progress of a Step.
+type StepProgress struct {
+	Name      string `json:"name,omitempty"`
+	Container string `json:"container,omitempty"`
+	// Phase is StepProgressStarted once the command of the Step has started
+	// and StepProgressFinished once it has exited.
+	Phase string `json:"phase,omitempty"`
+	// Percent is the last percent-complete hint the Step wrote to its progress file.
+	// +optional
+	Percent *int32 `json:"percent,omitempty"`
+	// LastUpdateTime is the time of the last progress marker of the Step.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+const (
+	// StepProgressStarted is the phase of a Step whose command has started.
+	StepProgressStarted = "Started"
+	// StepProgressFinished is the phase of a Step whose command has exited.
+	StepProgressFinished = "Finished"
+)
+
 // SidecarState reports the results of running a sidecar in a Task.
 type SidecarState struct {
 	corev1.ContainerState `json:",inline"`
@@ -392,7 +516,28 @@ func (tr *TaskRun) IsCancelled() bool {
 
 // IsRetriable returns true if the TaskRun's Retries is not exhausted.
 func (tr *TaskRun) IsRetriable() bool {
-	return len(tr.Status.RetriesStatus) < tr.Spec.Retries
+	return tr.retryCount() < tr.Spec.Retries
+}
+
+// retryCount returns the number of runs of the TaskRun which failed and were retried.
+func (tr *TaskRun) retryCount() int {
+	count := 0
+	for _, status := range tr.Status.RetriesStatus {
+		if !status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+			count++
+		}
+	}
+	return count
+}
+
+// RepeatCount returns the number of runs of the TaskRun which succeeded and were repeated.
+func (tr *TaskRun) RepeatCount() int {
+	return len(tr.Status.RetriesStatus) - tr.retryCount()
+}
+
+// IsRepeatable returns true if the TaskRun repeats until a condition passes and its Limit is not exhausted.
+func (tr *TaskRun) IsRepeatable() bool {
+	return tr.Spec.RepeatUntil != nil && tr.RepeatCount() < tr.Spec.RepeatUntil.Limit
 }
 
 // HasTimedOut returns true if the TaskRun runtime is beyond the allowed timeout
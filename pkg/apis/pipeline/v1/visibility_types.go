@@ -0,0 +1,41 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+// ClusterVisibility restricts the namespaces in which a cluster-scoped ClusterTask
+// or ClusterPipeline can be referenced. A ClusterTask or ClusterPipeline without
+// visibility can be referenced in all the namespaces.
+type ClusterVisibility struct {
+	// Namespaces is the list of the namespaces whose TaskRuns, Pipelines and
+	// PipelineRuns can reference the ClusterTask or ClusterPipeline.
+	// +listType=atomic
+	Namespaces []string `json:"namespaces"`
+}
+
+// VisibleIn returns true if a ClusterTask or ClusterPipeline with the visibility
+// can be referenced in the namespace.
+func (v *ClusterVisibility) VisibleIn(namespace string) bool {
+	if v == nil {
+		return true
+	}
+	for _, ns := range v.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate checks that the namespaces of the visibility are valid and unique.
+// No errors are returned for a nil ClusterVisibility.
+func (v *ClusterVisibility) Validate(context.Context) (errs *apis.FieldError) {
+	if v == nil {
+		return nil
+	}
+	if len(v.Namespaces) == 0 {
+		return apis.ErrMissingField("namespaces")
+	}
+	seen := sets.NewString()
+	for i, ns := range v.Namespaces {
+		if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidArrayValue(fmt.Sprintf("%q is not a valid namespace: %v", ns, msgs), "namespaces", i))
+		}
+		if seen.Has(ns) {
+			errs = errs.Also(apis.ErrInvalidArrayValue(fmt.Sprintf("namespace %q is listed more than once", ns), "namespaces", i))
+		}
+		seen.Insert(ns)
+	}
+	return errs
+}
+
+// validateClusterTaskRef checks that the ClusterTask referenced by a TaskRef of an
+// object being created in the namespace is visible in it.
+func validateClusterTaskRef(ctx context.Context, namespace string, ref *TaskRef) *apis.FieldError {
+	if !apis.IsInCreate(ctx) || ref == nil || ref.Kind != ClusterTaskRefKind || ref.Name == "" || ref.Resolver != "" {
+		return nil
+	}
+	if err := validate.ClusterRefVisible(ctx, namespace, string(ClusterTaskRefKind), ref.Name); err != nil {
+		return apis.ErrGeneric(err.Error(), "name")
+	}
+	return nil
+}
+
+// validateClusterTaskRefs checks that the ClusterTasks referenced by the tasks and
+// the finally tasks of a Pipeline being created in the namespace are visible in it.
+func (ps *PipelineSpec) validateClusterTaskRefs(ctx context.Context, namespace string) (errs *apis.FieldError) {
+	for i, pt := range ps.Tasks {
+		errs = errs.Also(validateClusterTaskRef(ctx, namespace, pt.TaskRef).ViaField("taskRef").ViaFieldIndex("tasks", i))
+	}
+	for i, pt := range ps.Finally {
+		errs = errs.Also(validateClusterTaskRef(ctx, namespace, pt.TaskRef).ViaField("taskRef").ViaFieldIndex("finally", i))
+	}
+	return errs
+}
+
+// validateClusterPipelineRef checks that the ClusterPipeline referenced by a
+// PipelineRef of a PipelineRun being created in the namespace is visible in it.
+func validateClusterPipelineRef(ctx context.Context, namespace string, ref *PipelineRef) *apis.FieldError {
+	if !apis.IsInCreate(ctx) || ref == nil || ref.Kind != ClusterPipelineKind || ref.Name == "" || ref.Resolver != "" {
+		return nil
+	}
+	if err := validate.ClusterRefVisible(ctx, namespace, string(ClusterPipelineKind), ref.Name); err != nil {
+		return apis.ErrGeneric(err.Error(), "name")
+	}
+	return nil
+}
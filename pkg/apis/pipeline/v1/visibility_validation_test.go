@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestClusterVisibility_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		visibility *v1.ClusterVisibility
+		want       *apis.FieldError
+	}{{
+		name: "no visibility",
+	}, {
+		name:       "valid namespaces",
+		visibility: &v1.ClusterVisibility{Namespaces: []string{"ci", "release"}},
+	}, {
+		name:       "no namespaces",
+		visibility: &v1.ClusterVisibility{},
+		want:       apis.ErrMissingField("namespaces"),
+	}, {
+		name:       "invalid namespace",
+		visibility: &v1.ClusterVisibility{Namespaces: []string{"ci", "Not_A_Namespace"}},
+		want: apis.ErrInvalidArrayValue(`"Not_A_Namespace" is not a valid namespace: [a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')]`,
+			"namespaces", 1),
+	}, {
+		name:       "duplicate namespace",
+		visibility: &v1.ClusterVisibility{Namespaces: []string{"ci", "release", "ci"}},
+		want:       apis.ErrInvalidArrayValue(`namespace "ci" is listed more than once`, "namespaces", 2),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.visibility.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestClusterVisibility_VisibleIn(t *testing.T) {
+	var all *v1.ClusterVisibility
+	if !all.VisibleIn("ci") {
+		t.Error("expected no visibility to be visible in all the namespaces")
+	}
+	visibility := &v1.ClusterVisibility{Namespaces: []string{"ci", "release"}}
+	if !visibility.VisibleIn("release") {
+		t.Error("expected the visibility to be visible in a listed namespace")
+	}
+	if visibility.VisibleIn("default") {
+		t.Error("expected the visibility not to be visible in a namespace not listed")
+	}
+}
+
+func TestClusterRefVisibility(t *testing.T) {
+	visibility := func(ctx context.Context, namespace, kind, name string) error {
+		if name == "internal" && namespace != "ci" {
+			return fmt.Errorf("%s %q is not visible in namespace %q", kind, name, namespace)
+		}
+		return nil
+	}
+	meta := metav1.ObjectMeta{Name: "run", Namespace: "ns"}
+	clusterTaskRef := &v1.TaskRef{Name: "internal", Kind: v1.ClusterTaskRefKind}
+	for _, tc := range []struct {
+		name     string
+		obj      apis.Validatable
+		inCreate bool
+		want     *apis.FieldError
+	}{{
+		name: "taskrun of a visible ClusterTask",
+		obj: &v1.TaskRun{ObjectMeta: meta, Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "shared", Kind: v1.ClusterTaskRefKind},
+		}},
+		inCreate: true,
+	}, {
+		name:     "taskrun of a ClusterTask not visible",
+		obj:      &v1.TaskRun{ObjectMeta: meta, Spec: v1.TaskRunSpec{TaskRef: clusterTaskRef}},
+		inCreate: true,
+		want:     apis.ErrGeneric(`ClusterTask "internal" is not visible in namespace "ns"`, "spec.taskRef.name"),
+	}, {
+		name: "taskrun of a Task named like a ClusterTask not visible",
+		obj: &v1.TaskRun{ObjectMeta: meta, Spec: v1.TaskRunSpec{
+			TaskRef: &v1.TaskRef{Name: "internal"},
+		}},
+		inCreate: true,
+	}, {
+		name: "not checked on update",
+		obj:  &v1.TaskRun{ObjectMeta: meta, Spec: v1.TaskRunSpec{TaskRef: clusterTaskRef}},
+	}, {
+		name: "pipeline of a ClusterTask not visible",
+		obj: &v1.Pipeline{ObjectMeta: meta, Spec: v1.PipelineSpec{
+			Tasks:   []v1.PipelineTask{{Name: "build", TaskRef: &v1.TaskRef{Name: "build"}}},
+			Finally: []v1.PipelineTask{{Name: "notify", TaskRef: clusterTaskRef}},
+		}},
+		inCreate: true,
+		want:     apis.ErrGeneric(`ClusterTask "internal" is not visible in namespace "ns"`, "spec.finally[0].taskRef.name"),
+	}, {
+		name: "pipelinerun of a ClusterPipeline not visible",
+		obj: &v1.PipelineRun{ObjectMeta: meta, Spec: v1.PipelineRunSpec{
+			PipelineRef: &v1.PipelineRef{Name: "internal", Kind: v1.ClusterPipelineKind},
+		}},
+		inCreate: true,
+		want:     apis.ErrGeneric(`ClusterPipeline "internal" is not visible in namespace "ns"`, "spec.pipelineRef.name"),
+	}, {
+		name: "pipelinerun of an embedded pipeline with a ClusterTask not visible",
+		obj: &v1.PipelineRun{ObjectMeta: meta, Spec: v1.PipelineRunSpec{
+			PipelineSpec: &v1.PipelineSpec{
+				Tasks: []v1.PipelineTask{{Name: "build", TaskRef: clusterTaskRef}},
+			},
+		}},
+		inCreate: true,
+		want:     apis.ErrGeneric(`ClusterTask "internal" is not visible in namespace "ns"`, "spec.pipelineSpec.tasks[0].taskRef.name"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := validate.WithClusterVisibility(context.Background(), visibility)
+			if tc.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			err := tc.obj.Validate(ctx)
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -77,6 +77,30 @@ func TestAllowsExecution(t *testing.T) {
 			},
 		},
 		expected: true,
+	}, {
+		name: "cel expression - true",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: `int("2") < 3 && "release-1".matches('^release-')`,
+			},
+		},
+		expected: true,
+	}, {
+		name: "cel expression - false",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: `int("5") < 3`,
+			},
+		},
+		expected: false,
+	}, {
+		name: "cel expression failing to evaluate",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: `"many" < 3`,
+			},
+		},
+		expected: false,
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -88,6 +112,43 @@ func TestAllowsExecution(t *testing.T) {
 	}
 }
 
+func TestEvaluationError(t *testing.T) {
+	tests := []struct {
+		name            string
+		whenExpressions WhenExpressions
+		wantError       bool
+	}{{
+		name: "cel expression evaluated",
+		whenExpressions: WhenExpressions{
+			{CEL: `int("5") < 3`},
+		},
+	}, {
+		name: "cel expression failing to evaluate",
+		whenExpressions: WhenExpressions{
+			{Input: "foo", Operator: selection.In, Values: []string{"foo"}},
+			{CEL: `int("many") < 3`},
+		},
+		wantError: true,
+	}, {
+		name: "cel expression with variables which aren't replaced",
+		whenExpressions: WhenExpressions{
+			{CEL: "int(tasks.scan.results.critical) < 3"},
+		},
+	}, {
+		name: "input, operator and values",
+		whenExpressions: WhenExpressions{
+			{Input: "foo", Operator: selection.NotIn, Values: []string{"foo"}},
+		},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.whenExpressions.EvaluationError(); (err != nil) != tc.wantError {
+				t.Errorf("EvaluationError() = %v, want error %t", err, tc.wantError)
+			}
+		})
+	}
+}
+
 func TestReplaceWhenExpressionsVariables(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -113,6 +174,22 @@ func TestReplaceWhenExpressionsVariables(t *testing.T) {
 				Values:   []string{"bar"},
 			},
 		},
+	}, {
+		name: "params and results replacement in cel",
+		whenExpressions: WhenExpressions{
+			{
+				CEL: "params.foo == 'bar' && int(tasks.scan.results.critical) < 3",
+			},
+		},
+		replacements: map[string]string{
+			"params.foo":                  "bar",
+			"tasks.scan.results.critical": "2",
+		},
+		expected: WhenExpressions{
+			{
+				CEL: `"bar" == 'bar' && int("2") < 3`,
+			},
+		},
 	}, {
 		name: "params replacement in values",
 		whenExpressions: WhenExpressions{
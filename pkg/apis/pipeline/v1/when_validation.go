@@ -17,9 +17,13 @@ limitations under the License.
 package v1
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	"github.com/tektoncd/pipeline/pkg/internal/cel"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,21 +35,24 @@ var validWhenOperators = []string{
 	string(selection.NotIn),
 }
 
-func (wes WhenExpressions) validate() *apis.FieldError {
-	return wes.validateWhenExpressionsFields().ViaField("when")
+func (wes WhenExpressions) validate(ctx context.Context) *apis.FieldError {
+	return wes.validateWhenExpressionsFields(ctx).ViaField("when")
 }
 
-func (wes WhenExpressions) validateWhenExpressionsFields() (errs *apis.FieldError) {
+func (wes WhenExpressions) validateWhenExpressionsFields(ctx context.Context) (errs *apis.FieldError) {
 	for idx, we := range wes {
-		errs = errs.Also(we.validateWhenExpressionFields().ViaIndex(idx))
+		errs = errs.Also(we.validateWhenExpressionFields(ctx).ViaIndex(idx))
 	}
 	return errs
 }
 
-func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
+func (we *WhenExpression) validateWhenExpressionFields(ctx context.Context) *apis.FieldError {
 	if equality.Semantic.DeepEqual(we, &WhenExpression{}) || we == nil {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
+	if we.CEL != "" {
+		return we.validateCEL(ctx)
+	}
 	if !sets.NewString(validWhenOperators...).Has(string(we.Operator)) {
 		message := fmt.Sprintf("operator %q is not recognized. valid operators: %s", we.Operator, strings.Join(validWhenOperators, ","))
 		return apis.ErrInvalidValue(message, apis.CurrentField)
@@ -56,8 +63,25 @@ func (we *WhenExpression) validateWhenExpressionFields() *apis.FieldError {
 	return nil
 }
 
+// validateCEL validates the CEL expression of a when expression, which is an alpha feature
+// and can't be used with the input, the operator and the values.
+func (we *WhenExpression) validateCEL(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "cel", config.AlphaAPIFields))
+	if we.Input != "" || we.Operator != "" || len(we.Values) != 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("cel", "input"))
+	}
+	if err := cel.Validate(we.CEL); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(err.Error(), "cel"))
+	}
+	return errs
+}
+
 func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, paramNames sets.String, arrayParamNames sets.String, objectParamNameKeys map[string][]string) (errs *apis.FieldError) {
 	for idx, we := range wes {
+		if we.CEL != "" {
+			errs = errs.Also(validateCELVariables(we.CEL, prefix, paramNames).ViaFieldIndex("when", idx))
+			continue
+		}
 		errs = errs.Also(validateStringVariable(we.Input, prefix, paramNames, arrayParamNames, objectParamNameKeys).ViaField("input").ViaFieldIndex("when", idx))
 		for _, val := range we.Values {
 			// one of the values could be a reference to an array param, such as, $(params.foo[*])
@@ -72,3 +96,17 @@ func (wes WhenExpressions) validatePipelineParametersVariables(prefix string, pa
 	}
 	return errs
 }
+
+// validateCELVariables validates that the params referenced by a CEL expression are declared.
+func validateCELVariables(expression, prefix string, paramNames sets.String) *apis.FieldError {
+	for _, variable := range cel.Variables(expression) {
+		if !strings.HasPrefix(variable, prefix+".") {
+			continue
+		}
+		name := strings.SplitN(strings.TrimPrefix(variable, prefix+"."), ".", 2)[0]
+		if !paramNames.Has(name) {
+			return apis.ErrGeneric(fmt.Sprintf("non-existent variable in %q", expression), "cel")
+		}
+	}
+	return nil
+}
@@ -17,9 +17,14 @@ limitations under the License.
 package v1
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
 	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
 )
 
 func TestWhenExpressions_Valid(t *testing.T) {
@@ -54,7 +59,7 @@ func TestWhenExpressions_Valid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err != nil {
+			if err := tt.wes.validate(context.Background()); err != nil {
 				t.Errorf("WhenExpressions.validate() returned an error for valid when expressions: %s", tt.wes)
 			}
 		})
@@ -97,9 +102,58 @@ func TestWhenExpressions_Invalid(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tt.wes.validate(); err == nil {
+			if err := tt.wes.validate(context.Background()); err == nil {
 				t.Errorf("WhenExpressions.validate() did not return error for invalid when expressions: %s, %s", tt.wes, err)
 			}
 		})
 	}
 }
+
+func TestWhenExpressions_CEL(t *testing.T) {
+	tests := []struct {
+		name          string
+		wes           WhenExpressions
+		alpha         bool
+		expectedError *apis.FieldError
+	}{{
+		name:  "valid cel",
+		wes:   []WhenExpression{{CEL: "int(tasks.scan.results.critical) < 3 && params.branch.matches('^release-')"}},
+		alpha: true,
+	}, {
+		name: "cel requires alpha",
+		wes:  []WhenExpression{{CEL: "int(tasks.scan.results.critical) < 3"}},
+		expectedError: &apis.FieldError{
+			Message: `cel requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "cel with input",
+		wes: []WhenExpression{{
+			CEL:      "int(tasks.scan.results.critical) < 3",
+			Input:    "foo",
+			Operator: selection.In,
+			Values:   []string{"foo"},
+		}},
+		alpha:         true,
+		expectedError: apis.ErrMultipleOneOf("when[0].cel", "when[0].input"),
+	}, {
+		name:  "cel not evaluating to a bool",
+		wes:   []WhenExpression{{CEL: "'main'"}},
+		alpha: true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "'main'" must evaluate to a bool, but evaluates to a string`,
+			Paths:   []string{"when[0].cel"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.alpha {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.wes.validate(ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("WhenExpressions.validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -18,8 +18,10 @@ package v1
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/volume"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
@@ -33,6 +35,8 @@ var allVolumeSourceFields = []string{
 	"emptydir",
 	"configmap",
 	"secret",
+	"projected",
+	"csi",
 }
 
 // Validate looks at the Volume provided in wb and makes sure that it is valid.
@@ -68,16 +72,9 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		return apis.ErrMissingField("secret.secretName")
 	}
 
-	// The projected workspace is only supported when the beta feature gate is enabled.
-	// For a Projected volume to work, you must provide at least one source.
-	if b.Projected != nil && len(b.Projected.Sources) == 0 {
-		errs := version.ValidateEnabledAPIFields(ctx, "projected workspace type", config.BetaAPIFields).ViaField("workspaces")
-		if errs != nil {
-			return errs
-		}
-		if len(b.Projected.Sources) == 0 {
-			return apis.ErrMissingField("projected.sources")
-		}
+	// For a Projected volume to work, you must provide at least one valid source.
+	if b.Projected != nil {
+		return volume.ValidateProjectedVolumeSource(ctx, b.Projected).ViaField("projected")
 	}
 
 	// The csi workspace is only supported when the beta feature gate is enabled.
@@ -87,9 +84,7 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		if errs != nil {
 			return errs
 		}
-		if b.CSI.Driver == "" {
-			return apis.ErrMissingField("csi.driver")
-		}
+		return volume.ValidateCSIVolumeSource(b.CSI).ViaField("csi")
 	}
 
 	return nil
@@ -122,3 +117,16 @@ func (b *WorkspaceBinding) numSources() int {
 	}
 	return n
 }
+
+// ValidateWorkspaceSubPaths validates the subPaths of the workspaces of the PipelineTask
+// once their parameters and task results are substituted.
+func (pt PipelineTask) ValidateWorkspaceSubPaths() error {
+	var errs *apis.FieldError
+	for i, ws := range pt.Workspaces {
+		errs = errs.Also(volume.ValidateSubPath(ws.SubPath).ViaFieldIndex("workspaces", i))
+	}
+	if errs != nil {
+		return fmt.Errorf("invalid workspaces of pipeline task %q: %w", pt.Name, errs)
+	}
+	return nil
+}
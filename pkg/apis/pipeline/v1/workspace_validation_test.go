@@ -28,6 +28,7 @@ import (
 )
 
 func TestWorkspaceBindingValidateValid(t *testing.T) {
+	tokenExpirationSeconds := int64(3600)
 	for _, tc := range []struct {
 		name    string
 		binding *v1.WorkspaceBinding
@@ -102,7 +103,6 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				}},
 			},
 		},
-		wc: config.EnableBetaAPIFields,
 	}, {
 		name: "Valid csi",
 		binding: &v1.WorkspaceBinding{
@@ -112,6 +112,49 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 			},
 		},
 		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Valid projected serviceAccountToken and downwardAPI",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "vault",
+						ExpirationSeconds: &tokenExpirationSeconds,
+						Path:              "token",
+					},
+				}, {
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{
+							Path:     "labels",
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+						}, {
+							Path:             "cpu-limit",
+							ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"},
+						}},
+					},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "my-secret",
+						},
+						Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "certs/ca.crt"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Valid csi with nodePublishSecretRef",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               "secrets-store.csi.k8s.io",
+				NodePublishSecretRef: &corev1.LocalObjectReference{Name: "secrets-store-creds"},
+				VolumeAttributes:     map[string]string{"secretProviderClass": "vault"},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -126,6 +169,7 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 }
 
 func TestWorkspaceBindingValidateInvalid(t *testing.T) {
+	tokenExpirationSeconds := int64(60)
 	for _, tc := range []struct {
 		name    string
 		binding *v1.WorkspaceBinding
@@ -171,6 +215,31 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			Name:      "beth",
 			Projected: &corev1.ProjectedVolumeSource{},
 		},
+	}, {
+		name: "projected workspace with sources should be disallowed without beta feature gate",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}},
+			},
+		},
+	}, {
+		name: "projected downwardAPI source should be disallowed without beta feature gate",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{
+							Path:     "labels",
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+						}},
+					},
+				}},
+			},
+		},
 	}, {
 		name: "Provide projected without sources",
 		binding: &v1.WorkspaceBinding{
@@ -195,6 +264,126 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			},
 		},
 		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected source without projection",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected source with multiple projections",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap-name"},
+					},
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected secret without a name",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					Secret: &corev1.SecretProjection{},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected serviceAccountToken without a path",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: "vault"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected serviceAccountToken expiring too soon",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Path:              "token",
+						ExpirationSeconds: &tokenExpirationSeconds,
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected downwardAPI item without a field",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{Path: "labels"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected file with an absolute path",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "/token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected file with a path escaping the volume",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "../token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected files with conflicting paths",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+						Items:                []corev1.KeyToPath{{Key: "token", Path: "token"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide csi with a nodePublishSecretRef without a name",
+		binding: &v1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               "secrets-store.csi.k8s.io",
+				NodePublishSecretRef: &corev1.LocalObjectReference{},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
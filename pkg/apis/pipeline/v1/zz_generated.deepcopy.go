@@ -54,6 +54,191 @@ func (in *ChildStatusReference) DeepCopy() *ChildStatusReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPipeline) DeepCopyInto(out *ClusterPipeline) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPipeline.
+func (in *ClusterPipeline) DeepCopy() *ClusterPipeline {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPipeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPipeline) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPipelineList) DeepCopyInto(out *ClusterPipelineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterPipeline, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPipelineList.
+func (in *ClusterPipelineList) DeepCopy() *ClusterPipelineList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPipelineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterPipelineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPipelineSpec) DeepCopyInto(out *ClusterPipelineSpec) {
+	*out = *in
+	in.PipelineSpec.DeepCopyInto(&out.PipelineSpec)
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(ClusterVisibility)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPipelineSpec.
+func (in *ClusterPipelineSpec) DeepCopy() *ClusterPipelineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPipelineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTask) DeepCopyInto(out *ClusterTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTask.
+func (in *ClusterTask) DeepCopy() *ClusterTask {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTask) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTaskList) DeepCopyInto(out *ClusterTaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTaskList.
+func (in *ClusterTaskList) DeepCopy() *ClusterTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTaskSpec) DeepCopyInto(out *ClusterTaskSpec) {
+	*out = *in
+	in.TaskSpec.DeepCopyInto(&out.TaskSpec)
+	if in.Visibility != nil {
+		in, out := &in.Visibility, &out.Visibility
+		*out = new(ClusterVisibility)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTaskSpec.
+func (in *ClusterTaskSpec) DeepCopy() *ClusterTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVisibility) DeepCopyInto(out *ClusterVisibility) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVisibility.
+func (in *ClusterVisibility) DeepCopy() *ClusterVisibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVisibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Combination) DeepCopyInto(out *Combination) {
 	{
@@ -124,6 +309,43 @@ func (in *EmbeddedTask) DeepCopy() *EmbeddedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExitCodeMapping) DeepCopyInto(out *ExitCodeMapping) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExitCodeMapping.
+func (in *ExitCodeMapping) DeepCopy() *ExitCodeMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ExitCodeMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjectionStatus) DeepCopyInto(out *FaultInjectionStatus) {
+	*out = *in
+	if in.Faults != nil {
+		in, out := &in.Faults, &out.Faults
+		*out = make([]InjectedFault, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjectionStatus.
+func (in *FaultInjectionStatus) DeepCopy() *FaultInjectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IncludeParams) DeepCopyInto(out *IncludeParams) {
 	*out = *in
@@ -169,6 +391,22 @@ func (in IncludeParamsList) DeepCopy() IncludeParamsList {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedFault) DeepCopyInto(out *InjectedFault) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedFault.
+func (in *InjectedFault) DeepCopy() *InjectedFault {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedFault)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Matrix) DeepCopyInto(out *Matrix) {
 	*out = *in
@@ -186,6 +424,11 @@ func (in *Matrix) DeepCopyInto(out *Matrix) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Spread != nil {
+		in, out := &in.Spread, &out.Spread
+		*out = make([]MatrixSpread, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -199,10 +442,52 @@ func (in *Matrix) DeepCopy() *Matrix {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixSpread) DeepCopyInto(out *MatrixSpread) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixSpread.
+func (in *MatrixSpread) DeepCopy() *MatrixSpread {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixSpread)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OffloadedStatus) DeepCopyInto(out *OffloadedStatus) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OffloadedStatus.
+func (in *OffloadedStatus) DeepCopy() *OffloadedStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OffloadedStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Param) DeepCopyInto(out *Param) {
 	*out = *in
 	in.Value.DeepCopyInto(&out.Value)
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ParamValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -231,6 +516,21 @@ func (in *ParamSpec) DeepCopyInto(out *ParamSpec) {
 		*out = new(ParamValue)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Minimum != nil {
+		in, out := &in.Minimum, &out.Minimum
+		*out = new(float64)
+		**out = **in
+	}
+	if in.Maximum != nil {
+		in, out := &in.Maximum, &out.Maximum
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -294,6 +594,32 @@ func (in *ParamValue) DeepCopy() *ParamValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamValueSource) DeepCopyInto(out *ParamValueSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamValueSource.
+func (in *ParamValueSource) DeepCopy() *ParamValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in Params) DeepCopyInto(out *Params) {
 	{
@@ -556,6 +882,11 @@ func (in *PipelineRunSpec) DeepCopyInto(out *PipelineRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -617,6 +948,13 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.GeneratedTasks != nil {
+		in, out := &in.GeneratedTasks, &out.GeneratedTasks
+		*out = make([]PipelineTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ChildReferences != nil {
 		in, out := &in.ChildReferences, &out.ChildReferences
 		*out = make([]ChildStatusReference, len(*in))
@@ -640,6 +978,18 @@ func (in *PipelineRunStatusFields) DeepCopyInto(out *PipelineRunStatusFields) {
 			(*out)[key] = val
 		}
 	}
+	if in.OffloadedStatus != nil {
+		in, out := &in.OffloadedStatus, &out.OffloadedStatus
+		*out = new(OffloadedStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResolvedParams != nil {
+		in, out := &in.ResolvedParams, &out.ResolvedParams
+		*out = make(Params, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -698,6 +1048,13 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Vars != nil {
+		in, out := &in.Vars, &out.Vars
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]PipelineWorkspaceDeclaration, len(*in))
@@ -717,6 +1074,21 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TaskRunTemplate != nil {
+		in, out := &in.TaskRunTemplate, &out.TaskRunTemplate
+		*out = new(PipelineTaskRunTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SuccessfulRunsHistoryLimit != nil {
+		in, out := &in.SuccessfulRunsHistoryLimit, &out.SuccessfulRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedRunsHistoryLimit != nil {
+		in, out := &in.FailedRunsHistoryLimit, &out.FailedRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -743,6 +1115,11 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 		*out = new(EmbeddedTask)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PipelineRef != nil {
+		in, out := &in.PipelineRef, &out.PipelineRef
+		*out = new(PipelineRef)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.When != nil {
 		in, out := &in.When, &out.When
 		*out = make(WhenExpressions, len(*in))
@@ -750,6 +1127,11 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RepeatUntil != nil {
+		in, out := &in.RepeatUntil, &out.RepeatUntil
+		*out = new(RepeatUntil)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RunAfter != nil {
 		in, out := &in.RunAfter, &out.RunAfter
 		*out = make([]string, len(*in))
@@ -767,11 +1149,21 @@ func (in *PipelineTask) DeepCopyInto(out *PipelineTask) {
 		*out = new(Matrix)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WithItems != nil {
+		in, out := &in.WithItems, &out.WithItems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Workspaces != nil {
 		in, out := &in.Workspaces, &out.Workspaces
 		*out = make([]WorkspacePipelineTaskBinding, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResultFiles != nil {
+		in, out := &in.ResultFiles, &out.ResultFiles
+		*out = make([]ResultFile, len(*in))
+		copy(*out, *in)
+	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(metav1.Duration)
@@ -909,97 +1301,365 @@ func (in *PipelineTaskRunSpec) DeepCopyInto(out *PipelineTaskRunSpec) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunSpec.
-func (in *PipelineTaskRunSpec) DeepCopy() *PipelineTaskRunSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunSpec.
+func (in *PipelineTaskRunSpec) DeepCopy() *PipelineTaskRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineTaskRunTemplate) DeepCopyInto(out *PipelineTaskRunTemplate) {
+	*out = *in
+	if in.PodTemplate != nil {
+		in, out := &in.PodTemplate, &out.PodTemplate
+		*out = new(pod.Template)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunTemplate.
+func (in *PipelineTaskRunTemplate) DeepCopy() *PipelineTaskRunTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineTaskRunTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PipelineWorkspaceDeclaration) DeepCopyInto(out *PipelineWorkspaceDeclaration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineWorkspaceDeclaration.
+func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration {
+	if in == nil {
+		return nil
+	}
+	out := new(PipelineWorkspaceDeclaration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySpec.
+func (in *PropertySpec) DeepCopy() *PropertySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PropertySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Provenance) DeepCopyInto(out *Provenance) {
+	*out = *in
+	if in.RefSource != nil {
+		in, out := &in.RefSource, &out.RefSource
+		*out = new(RefSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FeatureFlags != nil {
+		in, out := &in.FeatureFlags, &out.FeatureFlags
+		*out = new(config.FeatureFlags)
+		**out = **in
+	}
+	if in.Executions != nil {
+		in, out := &in.Executions, &out.Executions
+		*out = make([]StepExecution, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]ServiceAccountGrant, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConsumedResults != nil {
+		in, out := &in.ConsumedResults, &out.ConsumedResults
+		*out = make([]ResultProvenance, len(*in))
+		copy(*out, *in)
+	}
+	if in.SpecAttestation != nil {
+		in, out := &in.SpecAttestation, &out.SpecAttestation
+		*out = new(SpecAttestation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SLSA != nil {
+		in, out := &in.SLSA, &out.SLSA
+		*out = new(SLSAProvenance)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provenance.
+func (in *Provenance) DeepCopy() *Provenance {
+	if in == nil {
+		return nil
+	}
+	out := new(Provenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefSource) DeepCopyInto(out *RefSource) {
+	*out = *in
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefSource.
+func (in *RefSource) DeepCopy() *RefSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RefSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepeatUntil) DeepCopyInto(out *RepeatUntil) {
+	*out = *in
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make(WhenExpressions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepeatUntil.
+func (in *RepeatUntil) DeepCopy() *RepeatUntil {
+	if in == nil {
+		return nil
+	}
+	out := new(RepeatUntil)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(Params, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverRef.
+func (in *ResolverRef) DeepCopy() *ResolverRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolverRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultFile) DeepCopyInto(out *ResultFile) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultFile.
+func (in *ResultFile) DeepCopy() *ResultFile {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultProvenance) DeepCopyInto(out *ResultProvenance) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultProvenance.
+func (in *ResultProvenance) DeepCopy() *ResultProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResultRef) DeepCopyInto(out *ResultRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultRef.
+func (in *ResultRef) DeepCopy() *ResultRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ResultRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLSABuildDefinition) DeepCopyInto(out *SLSABuildDefinition) {
+	*out = *in
+	if in.ExternalParameters != nil {
+		in, out := &in.ExternalParameters, &out.ExternalParameters
+		*out = make(map[string]ParamValue, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ResolvedDependencies != nil {
+		in, out := &in.ResolvedDependencies, &out.ResolvedDependencies
+		*out = make([]SLSAResourceDescriptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSABuildDefinition.
+func (in *SLSABuildDefinition) DeepCopy() *SLSABuildDefinition {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskRunSpec)
+	out := new(SLSABuildDefinition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineTaskRunTemplate) DeepCopyInto(out *PipelineTaskRunTemplate) {
+func (in *SLSABuildMetadata) DeepCopyInto(out *SLSABuildMetadata) {
 	*out = *in
-	if in.PodTemplate != nil {
-		in, out := &in.PodTemplate, &out.PodTemplate
-		*out = new(pod.Template)
-		(*in).DeepCopyInto(*out)
+	if in.StartedOn != nil {
+		in, out := &in.StartedOn, &out.StartedOn
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedOn != nil {
+		in, out := &in.FinishedOn, &out.FinishedOn
+		*out = (*in).DeepCopy()
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineTaskRunTemplate.
-func (in *PipelineTaskRunTemplate) DeepCopy() *PipelineTaskRunTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSABuildMetadata.
+func (in *SLSABuildMetadata) DeepCopy() *SLSABuildMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineTaskRunTemplate)
+	out := new(SLSABuildMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PipelineWorkspaceDeclaration) DeepCopyInto(out *PipelineWorkspaceDeclaration) {
+func (in *SLSABuilder) DeepCopyInto(out *SLSABuilder) {
 	*out = *in
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PipelineWorkspaceDeclaration.
-func (in *PipelineWorkspaceDeclaration) DeepCopy() *PipelineWorkspaceDeclaration {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSABuilder.
+func (in *SLSABuilder) DeepCopy() *SLSABuilder {
 	if in == nil {
 		return nil
 	}
-	out := new(PipelineWorkspaceDeclaration)
+	out := new(SLSABuilder)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PropertySpec) DeepCopyInto(out *PropertySpec) {
+func (in *SLSAPredicate) DeepCopyInto(out *SLSAPredicate) {
 	*out = *in
+	in.BuildDefinition.DeepCopyInto(&out.BuildDefinition)
+	in.RunDetails.DeepCopyInto(&out.RunDetails)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropertySpec.
-func (in *PropertySpec) DeepCopy() *PropertySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSAPredicate.
+func (in *SLSAPredicate) DeepCopy() *SLSAPredicate {
 	if in == nil {
 		return nil
 	}
-	out := new(PropertySpec)
+	out := new(SLSAPredicate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Provenance) DeepCopyInto(out *Provenance) {
+func (in *SLSAProvenance) DeepCopyInto(out *SLSAProvenance) {
 	*out = *in
-	if in.RefSource != nil {
-		in, out := &in.RefSource, &out.RefSource
-		*out = new(RefSource)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FeatureFlags != nil {
-		in, out := &in.FeatureFlags, &out.FeatureFlags
-		*out = new(config.FeatureFlags)
-		**out = **in
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = make([]SLSAResourceDescriptor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
+	in.Predicate.DeepCopyInto(&out.Predicate)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Provenance.
-func (in *Provenance) DeepCopy() *Provenance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSAProvenance.
+func (in *SLSAProvenance) DeepCopy() *SLSAProvenance {
 	if in == nil {
 		return nil
 	}
-	out := new(Provenance)
+	out := new(SLSAProvenance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RefSource) DeepCopyInto(out *RefSource) {
+func (in *SLSAResourceDescriptor) DeepCopyInto(out *SLSAResourceDescriptor) {
 	*out = *in
 	if in.Digest != nil {
 		in, out := &in.Digest, &out.Digest
@@ -1011,51 +1671,46 @@ func (in *RefSource) DeepCopyInto(out *RefSource) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefSource.
-func (in *RefSource) DeepCopy() *RefSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSAResourceDescriptor.
+func (in *SLSAResourceDescriptor) DeepCopy() *SLSAResourceDescriptor {
 	if in == nil {
 		return nil
 	}
-	out := new(RefSource)
+	out := new(SLSAResourceDescriptor)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResolverRef) DeepCopyInto(out *ResolverRef) {
+func (in *SLSARunDetails) DeepCopyInto(out *SLSARunDetails) {
 	*out = *in
-	if in.Params != nil {
-		in, out := &in.Params, &out.Params
-		*out = make(Params, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Builder.DeepCopyInto(&out.Builder)
+	in.Metadata.DeepCopyInto(&out.Metadata)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolverRef.
-func (in *ResolverRef) DeepCopy() *ResolverRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSARunDetails.
+func (in *SLSARunDetails) DeepCopy() *SLSARunDetails {
 	if in == nil {
 		return nil
 	}
-	out := new(ResolverRef)
+	out := new(SLSARunDetails)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResultRef) DeepCopyInto(out *ResultRef) {
+func (in *ServiceAccountGrant) DeepCopyInto(out *ServiceAccountGrant) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResultRef.
-func (in *ResultRef) DeepCopy() *ResultRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountGrant.
+func (in *ServiceAccountGrant) DeepCopy() *ServiceAccountGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(ResultRef)
+	out := new(ServiceAccountGrant)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1135,6 +1790,16 @@ func (in *Sidecar) DeepCopyInto(out *Sidecar) {
 		*out = make([]WorkspaceUsage, len(*in))
 		copy(*out, *in)
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = make([]SidecarResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shutdown != nil {
+		in, out := &in.Shutdown, &out.Shutdown
+		*out = new(SidecarShutdown)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1148,6 +1813,48 @@ func (in *Sidecar) DeepCopy() *Sidecar {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarResult) DeepCopyInto(out *SidecarResult) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarResult.
+func (in *SidecarResult) DeepCopy() *SidecarResult {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SidecarShutdown) DeepCopyInto(out *SidecarShutdown) {
+	*out = *in
+	if in.PreStop != nil {
+		in, out := &in.PreStop, &out.PreStop
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarShutdown.
+func (in *SidecarShutdown) DeepCopy() *SidecarShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(SidecarShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarState) DeepCopyInto(out *SidecarState) {
 	*out = *in
@@ -1188,6 +1895,29 @@ func (in *SkippedTask) DeepCopy() *SkippedTask {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecAttestation) DeepCopyInto(out *SpecAttestation) {
+	*out = *in
+	if in.Digest != nil {
+		in, out := &in.Digest, &out.Digest
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecAttestation.
+func (in *SpecAttestation) DeepCopy() *SpecAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Step) DeepCopyInto(out *Step) {
 	*out = *in
@@ -1253,6 +1983,18 @@ func (in *Step) DeepCopyInto(out *Step) {
 		*out = new(StepOutputConfig)
 		**out = **in
 	}
+	if in.Scratch != nil {
+		in, out := &in.Scratch, &out.Scratch
+		*out = make([]StepScratchVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sandbox != nil {
+		in, out := &in.Sandbox, &out.Sandbox
+		*out = new(StepSandbox)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1266,6 +2008,51 @@ func (in *Step) DeepCopy() *Step {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepExecution) DeepCopyInto(out *StepExecution) {
+	*out = *in
+	if in.Argv != nil {
+		in, out := &in.Argv, &out.Argv
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepExecution.
+func (in *StepExecution) DeepCopy() *StepExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(StepExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepLog) DeepCopyInto(out *StepLog) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepLog.
+func (in *StepLog) DeepCopy() *StepLog {
+	if in == nil {
+		return nil
+	}
+	out := new(StepLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepOutputConfig) DeepCopyInto(out *StepOutputConfig) {
 	*out = *in
@@ -1282,6 +2069,97 @@ func (in *StepOutputConfig) DeepCopy() *StepOutputConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepProgress) DeepCopyInto(out *StepProgress) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepProgress.
+func (in *StepProgress) DeepCopy() *StepProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(StepProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepResourceUsage) DeepCopyInto(out *StepResourceUsage) {
+	*out = *in
+	if in.Peak != nil {
+		in, out := &in.Peak, &out.Peak
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepResourceUsage.
+func (in *StepResourceUsage) DeepCopy() *StepResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StepResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepSandbox) DeepCopyInto(out *StepSandbox) {
+	*out = *in
+	if in.Seccomp != nil {
+		in, out := &in.Seccomp, &out.Seccomp
+		*out = new(corev1.SeccompProfile)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SELinux != nil {
+		in, out := &in.SELinux, &out.SELinux
+		*out = new(corev1.SELinuxOptions)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepSandbox.
+func (in *StepSandbox) DeepCopy() *StepSandbox {
+	if in == nil {
+		return nil
+	}
+	out := new(StepSandbox)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepScratchVolume) DeepCopyInto(out *StepScratchVolume) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepScratchVolume.
+func (in *StepScratchVolume) DeepCopy() *StepScratchVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(StepScratchVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepState) DeepCopyInto(out *StepState) {
 	*out = *in
@@ -1621,6 +2499,11 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 		*out = new(TaskSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RepeatUntil != nil {
+		in, out := &in.RepeatUntil, &out.RepeatUntil
+		*out = new(RepeatUntil)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(metav1.Duration)
@@ -1638,6 +2521,11 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResultFiles != nil {
+		in, out := &in.ResultFiles, &out.ResultFiles
+		*out = make([]ResultFile, len(*in))
+		copy(*out, *in)
+	}
 	if in.StepSpecs != nil {
 		in, out := &in.StepSpecs, &out.StepSpecs
 		*out = make([]TaskRunStepSpec, len(*in))
@@ -1657,6 +2545,11 @@ func (in *TaskRunSpec) DeepCopyInto(out *TaskRunSpec) {
 		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -1744,6 +2637,42 @@ func (in *TaskRunStatusFields) DeepCopyInto(out *TaskRunStatusFields) {
 			(*out)[key] = val
 		}
 	}
+	if in.Workspaces != nil {
+		in, out := &in.Workspaces, &out.Workspaces
+		*out = make([]WorkspaceStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepResourceUsage != nil {
+		in, out := &in.StepResourceUsage, &out.StepResourceUsage
+		*out = make([]StepResourceUsage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StepLogs != nil {
+		in, out := &in.StepLogs, &out.StepLogs
+		*out = make([]StepLog, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepProgress != nil {
+		in, out := &in.StepProgress, &out.StepProgress
+		*out = make([]StepProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FaultInjection != nil {
+		in, out := &in.FaultInjection, &out.FaultInjection
+		*out = new(FaultInjectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResolvedParams != nil {
+		in, out := &in.ResolvedParams, &out.ResolvedParams
+		*out = make(Params, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1822,6 +2751,11 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ExitCodeMappings != nil {
+		in, out := &in.ExitCodeMappings, &out.ExitCodeMappings
+		*out = make([]ExitCodeMapping, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1992,6 +2926,22 @@ func (in *WorkspacePipelineTaskBinding) DeepCopy() *WorkspacePipelineTaskBinding
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStatus.
+func (in *WorkspaceStatus) DeepCopy() *WorkspaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceUsage) DeepCopyInto(out *WorkspaceUsage) {
 	*out = *in
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// CloudEventSinksAnnotation is the annotation of the Secrets and ServiceAccounts
+// which the CloudEventSinks of their namespace may authenticate with, listing the
+// names of these sinks separated by commas, or "*" for all of them.
+const CloudEventSinksAnnotation = "tekton.dev/cloud-event-sinks"
+
+// +genclient
+// +genclient:noStatus
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CloudEventSink is a sink the CloudEvents about the TaskRuns, PipelineRuns and
+// CustomRuns of its namespace are sent to. The CloudEventSinks of the namespace
+// of the controller are also used for the runs of the namespaces they select.
+// +k8s:openapi-gen=true
+type CloudEventSink struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the CloudEventSink.
+	Spec CloudEventSinkSpec `json:"spec"`
+}
+
+// CloudEventSinkList contains a list of CloudEventSink
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type CloudEventSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CloudEventSink `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*CloudEventSink) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("CloudEventSink")
+}
+
+// CloudEventSinkSpec defines where and how the CloudEvents are sent.
+type CloudEventSinkSpec struct {
+	// URI is the http(s) URI the CloudEvents are sent to.
+	URI string `json:"uri"`
+	// Namespaces are the namespaces of the runs whose CloudEvents are sent to the
+	// sink, when the sink is in the namespace of the controller. It selects all the
+	// namespaces when empty. It is ignored for the sinks of the other namespaces,
+	// which only get the CloudEvents of their own namespace.
+	// +optional
+	// +listType=atomic
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Types are the types of the CloudEvents sent to the sink, e.g.
+	// `dev.tekton.event.taskrun.successful.v1`. A type ending with `*` selects the
+	// types starting with the rest of it, e.g. `dev.tekton.event.pipelinerun.*`.
+	// All the CloudEvents are sent when empty.
+	// +optional
+	// +listType=atomic
+	Types []string `json:"types,omitempty"`
+	// Retry is the policy used to retry sending the CloudEvents which fail to be.
+	// +optional
+	Retry *CloudEventSinkRetry `json:"retry,omitempty"`
+	// Auth is how the requests to the sink are authenticated.
+	// +optional
+	Auth *CloudEventSinkAuth `json:"auth,omitempty"`
+}
+
+// CloudEventSinkBackoff is the backoff strategy between the attempts to send a CloudEvent.
+type CloudEventSinkBackoff string
+
+const (
+	// CloudEventSinkBackoffConstant waits the delay between the attempts.
+	CloudEventSinkBackoffConstant CloudEventSinkBackoff = "constant"
+	// CloudEventSinkBackoffLinear waits the delay times the number of attempts.
+	CloudEventSinkBackoffLinear CloudEventSinkBackoff = "linear"
+	// CloudEventSinkBackoffExponential waits the delay times 2 to the power of the number of attempts.
+	CloudEventSinkBackoffExponential CloudEventSinkBackoff = "exponential"
+)
+
+// CloudEventSinkRetry is the policy used to retry sending CloudEvents.
+type CloudEventSinkRetry struct {
+	// Attempts is the maximum number of attempts to send a CloudEvent. Defaults to 10.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+	// Backoff is the strategy between the attempts, one of "constant", "linear" and
+	// "exponential". Defaults to "exponential".
+	// +optional
+	Backoff CloudEventSinkBackoff `json:"backoff,omitempty"`
+	// Delay is the delay the backoff strategy is based on. Defaults to 10ms.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+}
+
+// CloudEventSinkAuth is how the requests to a sink are authenticated. At most one
+// of its fields may be set.
+type CloudEventSinkAuth struct {
+	// BasicAuth authenticates the requests with HTTP basic authentication.
+	// +optional
+	BasicAuth *CloudEventSinkBasicAuth `json:"basicAuth,omitempty"`
+	// OIDCToken authenticates the requests with an OIDC token of a service account
+	// as a bearer token.
+	// +optional
+	OIDCToken *CloudEventSinkOIDCToken `json:"oidcToken,omitempty"`
+}
+
+// CloudEventSinkBasicAuth configures the HTTP basic authentication to a sink.
+type CloudEventSinkBasicAuth struct {
+	// SecretName is the name of the secret in the namespace of the sink holding the
+	// `username` and `password`, e.g. a secret of type `kubernetes.io/basic-auth`.
+	// The secret must list the sink in its `tekton.dev/cloud-event-sinks` annotation.
+	SecretName string `json:"secretName"`
+}
+
+// CloudEventSinkOIDCToken configures the OIDC token authenticating to a sink.
+type CloudEventSinkOIDCToken struct {
+	// ServiceAccountName is the name of the service account in the namespace of the
+	// sink the token is requested for. The service account must list the sink in its
+	// `tekton.dev/cloud-event-sinks` annotation.
+	ServiceAccountName string `json:"serviceAccountName"`
+	// Audience is the audience of the token. Defaults to the URI of the sink, and
+	// must be one of the `sink-audiences` of the `config-events` otherwise.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+}
+
+// Selects returns true if the sink selects the namespace, given the namespace of the
+// controller.
+func (s *CloudEventSink) Selects(namespace, systemNamespace string) bool {
+	if s.Namespace == namespace {
+		return true
+	}
+	if s.Namespace != systemNamespace {
+		return false
+	}
+	if len(s.Spec.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range s.Spec.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedBy returns true if the sink may authenticate with the credentials of the
+// object, a Secret or a ServiceAccount of its namespace, which opts in with the
+// CloudEventSinksAnnotation.
+func (s *CloudEventSink) AllowedBy(obj metav1.Object) bool {
+	if obj.GetNamespace() != s.Namespace {
+		return false
+	}
+	for _, name := range strings.Split(obj.GetAnnotations()[CloudEventSinksAnnotation], ",") {
+		if name = strings.TrimSpace(name); name == "*" || name == s.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts returns true if the CloudEvents of the type are sent to the sink.
+func (s *CloudEventSink) Accepts(eventType string) bool {
+	if len(s.Spec.Types) == 0 {
+		return true
+	}
+	for _, t := range s.Spec.Types {
+		if t == eventType || strings.HasSuffix(t, "*") && strings.HasPrefix(eventType, strings.TrimSuffix(t, "*")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCloudEventSink_Selects(t *testing.T) {
+	tests := []struct {
+		name       string
+		sink       *v1alpha1.CloudEventSink
+		namespace  string
+		wantSelect bool
+	}{{
+		name:       "own namespace",
+		sink:       &v1alpha1.CloudEventSink{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"}},
+		namespace:  "team-a",
+		wantSelect: true,
+	}, {
+		name: "other namespace",
+		sink: &v1alpha1.CloudEventSink{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a"},
+			Spec:       v1alpha1.CloudEventSinkSpec{Namespaces: []string{"team-b"}},
+		},
+		namespace:  "team-b",
+		wantSelect: false,
+	}, {
+		name:       "all namespaces from the system namespace",
+		sink:       &v1alpha1.CloudEventSink{ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines"}},
+		namespace:  "team-b",
+		wantSelect: true,
+	}, {
+		name: "selected namespace from the system namespace",
+		sink: &v1alpha1.CloudEventSink{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines"},
+			Spec:       v1alpha1.CloudEventSinkSpec{Namespaces: []string{"team-a", "team-b"}},
+		},
+		namespace:  "team-b",
+		wantSelect: true,
+	}, {
+		name: "unselected namespace from the system namespace",
+		sink: &v1alpha1.CloudEventSink{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tekton-pipelines"},
+			Spec:       v1alpha1.CloudEventSinkSpec{Namespaces: []string{"team-a"}},
+		},
+		namespace:  "team-b",
+		wantSelect: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sink.Selects(tt.namespace, "tekton-pipelines"); got != tt.wantSelect {
+				t.Errorf("Selects() = %t, want %t", got, tt.wantSelect)
+			}
+		})
+	}
+}
+
+func TestCloudEventSink_Accepts(t *testing.T) {
+	sink := &v1alpha1.CloudEventSink{Spec: v1alpha1.CloudEventSinkSpec{
+		Types: []string{"dev.tekton.event.pipelinerun.*", "dev.tekton.event.taskrun.failed.v1"},
+	}}
+	for eventType, want := range map[string]bool{
+		"dev.tekton.event.pipelinerun.started.v1":    true,
+		"dev.tekton.event.pipelinerun.successful.v1": true,
+		"dev.tekton.event.taskrun.failed.v1":         true,
+		"dev.tekton.event.taskrun.successful.v1":     false,
+		"dev.tekton.event.step.started.v1":           false,
+	} {
+		if got := sink.Accepts(eventType); got != want {
+			t.Errorf("Accepts(%q) = %t, want %t", eventType, got, want)
+		}
+	}
+	if !(&v1alpha1.CloudEventSink{}).Accepts("dev.tekton.event.step.started.v1") {
+		t.Error("expected a sink without types to accept all the types")
+	}
+}
+
+func TestCloudEventSink_AllowedBy(t *testing.T) {
+	sink := &v1alpha1.CloudEventSink{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "sink"}}
+	for _, tc := range []struct {
+		name string
+		obj  metav1.ObjectMeta
+		want bool
+	}{{
+		name: "listed sink",
+		obj:  metav1.ObjectMeta{Namespace: "team-a", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "other, sink"}},
+		want: true,
+	}, {
+		name: "all the sinks",
+		obj:  metav1.ObjectMeta{Namespace: "team-a", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "*"}},
+		want: true,
+	}, {
+		name: "no annotation",
+		obj:  metav1.ObjectMeta{Namespace: "team-a"},
+		want: false,
+	}, {
+		name: "other sink",
+		obj:  metav1.ObjectMeta{Namespace: "team-a", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "other"}},
+		want: false,
+	}, {
+		name: "other namespace",
+		obj:  metav1.ObjectMeta{Namespace: "team-b", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "*"}},
+		want: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := tc.obj
+			if got := sink.AllowedBy(&obj); got != tc.want {
+				t.Errorf("AllowedBy() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*CloudEventSink)(nil)
+
+// Validate CloudEventSink
+func (s *CloudEventSink) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(s.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(s.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate CloudEventSinkSpec, the validation requires the URI to be an http(s) URI,
+// the namespaces to be valid names, the types not to be empty and the retry and auth
+// to be valid.
+func (ss *CloudEventSinkSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ss.URI == "" {
+		errs = errs.Also(apis.ErrMissingField("uri"))
+	} else if u, err := url.Parse(ss.URI); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errs.Also(apis.ErrInvalidValue(ss.URI, "uri", "uri must be an http(s) URI"))
+	}
+	for i, ns := range ss.Namespaces {
+		if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidArrayValue(ns, "namespaces", i))
+		}
+	}
+	for i, t := range ss.Types {
+		if t == "" || t == "*" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(t, "types", i))
+		}
+	}
+	if ss.Retry != nil {
+		errs = errs.Also(ss.Retry.Validate(ctx).ViaField("retry"))
+	}
+	if ss.Auth != nil {
+		errs = errs.Also(ss.Auth.Validate(ctx).ViaField("auth"))
+	}
+	return errs
+}
+
+// Validate CloudEventSinkRetry
+func (r *CloudEventSinkRetry) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Attempts < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Attempts, "attempts", "attempts must not be negative"))
+	}
+	switch r.Backoff {
+	case "", CloudEventSinkBackoffConstant, CloudEventSinkBackoffLinear, CloudEventSinkBackoffExponential:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.Backoff, "backoff",
+			`backoff must be one of "constant", "linear" and "exponential"`))
+	}
+	if r.Delay != nil && r.Delay.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Delay.Duration.String(), "delay", "delay must be positive"))
+	}
+	return errs
+}
+
+// Validate CloudEventSinkAuth
+func (a *CloudEventSinkAuth) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case a.BasicAuth != nil && a.OIDCToken != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("basicAuth", "oidcToken"))
+	case a.BasicAuth != nil:
+		if a.BasicAuth.SecretName == "" {
+			errs = errs.Also(apis.ErrMissingField("basicAuth.secretName"))
+		}
+	case a.OIDCToken != nil:
+		if a.OIDCToken.ServiceAccountName == "" {
+			errs = errs.Also(apis.ErrMissingField("oidcToken.serviceAccountName"))
+		}
+	}
+	return errs
+}
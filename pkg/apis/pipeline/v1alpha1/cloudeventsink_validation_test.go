@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestCloudEventSink_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.CloudEventSinkSpec
+		want *apis.FieldError
+	}{{
+		name: "missing uri",
+		spec: v1alpha1.CloudEventSinkSpec{},
+		want: apis.ErrMissingField("spec.uri"),
+	}, {
+		name: "invalid uri",
+		spec: v1alpha1.CloudEventSinkSpec{URI: "kafka://events"},
+		want: apis.ErrInvalidValue("kafka://events", "spec.uri", "uri must be an http(s) URI"),
+	}, {
+		name: "invalid namespace",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI:        "https://events.example.com",
+			Namespaces: []string{"team-a", "Team_B"},
+		},
+		want: apis.ErrInvalidArrayValue("Team_B", "spec.namespaces", 1),
+	}, {
+		name: "invalid type",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI:   "https://events.example.com",
+			Types: []string{"*"},
+		},
+		want: apis.ErrInvalidArrayValue("*", "spec.types", 0),
+	}, {
+		name: "invalid retry",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI: "https://events.example.com",
+			Retry: &v1alpha1.CloudEventSinkRetry{
+				Attempts: -1,
+				Backoff:  "random",
+				Delay:    &metav1.Duration{},
+			},
+		},
+		want: apis.ErrInvalidValue(-1, "spec.retry.attempts", "attempts must not be negative").Also(
+			apis.ErrInvalidValue("random", "spec.retry.backoff", `backoff must be one of "constant", "linear" and "exponential"`)).Also(
+			apis.ErrInvalidValue("0s", "spec.retry.delay", "delay must be positive")),
+	}, {
+		name: "multiple auths",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI: "https://events.example.com",
+			Auth: &v1alpha1.CloudEventSinkAuth{
+				BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{SecretName: "events"},
+				OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "events"},
+			},
+		},
+		want: apis.ErrMultipleOneOf("spec.auth.basicAuth", "spec.auth.oidcToken"),
+	}, {
+		name: "missing secret name",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI:  "https://events.example.com",
+			Auth: &v1alpha1.CloudEventSinkAuth{BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{}},
+		},
+		want: apis.ErrMissingField("spec.auth.basicAuth.secretName"),
+	}, {
+		name: "missing service account name",
+		spec: v1alpha1.CloudEventSinkSpec{
+			URI:  "https://events.example.com",
+			Auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{Audience: "events"}},
+		},
+		want: apis.ErrMissingField("spec.auth.oidcToken.serviceAccountName"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &v1alpha1.CloudEventSink{
+				ObjectMeta: metav1.ObjectMeta{Name: "sink"},
+				Spec:       tt.spec,
+			}
+			err := s.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCloudEventSink_Valid(t *testing.T) {
+	s := &v1alpha1.CloudEventSink{
+		ObjectMeta: metav1.ObjectMeta{Name: "sink"},
+		Spec: v1alpha1.CloudEventSinkSpec{
+			URI:        "https://events.example.com/tekton",
+			Namespaces: []string{"team-a", "team-b"},
+			Types:      []string{"dev.tekton.event.pipelinerun.*", "dev.tekton.event.taskrun.failed.v1"},
+			Retry: &v1alpha1.CloudEventSinkRetry{
+				Attempts: 5,
+				Backoff:  v1alpha1.CloudEventSinkBackoffLinear,
+				Delay:    &metav1.Duration{Duration: time.Second},
+			},
+			Auth: &v1alpha1.CloudEventSinkAuth{
+				OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "events", Audience: "events.example.com"},
+			},
+		},
+	}
+	if err := s.Validate(context.Background()); err != nil {
+		t.Errorf("validating a valid CloudEventSink: %v", err)
+	}
+}
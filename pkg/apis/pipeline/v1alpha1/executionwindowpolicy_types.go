@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExecutionWindowPolicy restricts when the PipelineRuns of its namespace start,
+// for the Pipelines it applies to. The PipelineRuns created outside of the windows
+// it allows, or within the windows it blocks, are held until a window opens.
+// +k8s:openapi-gen=true
+type ExecutionWindowPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the ExecutionWindowPolicy.
+	Spec ExecutionWindowPolicySpec `json:"spec"`
+}
+
+// ExecutionWindowPolicyList contains a list of ExecutionWindowPolicy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ExecutionWindowPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExecutionWindowPolicy `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ExecutionWindowPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ExecutionWindowPolicy")
+}
+
+// ExecutionWindowPolicySpec defines the Pipelines the policy applies to and the
+// windows their PipelineRuns may start in.
+type ExecutionWindowPolicySpec struct {
+	// Pipelines defines the patterns of the names of the Pipelines the policy applies to.
+	// The patterns are regex, e.g. `^deploy-.*` applies the policy to the Pipelines
+	// whose name starts with `deploy-`. The policy applies to all the Pipelines of its
+	// namespace when it is empty. The name of the Pipeline of a PipelineRun which
+	// doesn't refer to a Pipeline by name is the name of the PipelineRun.
+	// +optional
+	// +listType=atomic
+	Pipelines []ResourcePattern `json:"pipelines,omitempty"`
+	// TimeZone is the IANA name of the time zone of the windows, e.g. `Europe/Paris`.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Allowed are the windows the PipelineRuns may start in. The PipelineRuns may
+	// start at any time outside of the blocked windows when it is empty.
+	// +optional
+	// +listType=atomic
+	Allowed []ExecutionWindow `json:"allowed,omitempty"`
+	// Blocked are the windows the PipelineRuns may not start in, even within an
+	// allowed window.
+	// +optional
+	// +listType=atomic
+	Blocked []ExecutionWindow `json:"blocked,omitempty"`
+}
+
+// ExecutionWindow is a window of time recurring on some days of the week.
+type ExecutionWindow struct {
+	// Name describes the window in the reason the PipelineRuns are held, e.g. `weekend`.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Days are the days of the week the window starts on, e.g. `Saturday`. The
+	// window starts every day when it is empty.
+	// +optional
+	// +listType=atomic
+	Days []string `json:"days,omitempty"`
+	// Start is the time of the day the window starts at, as `HH:MM`. Defaults to `00:00`.
+	// +optional
+	Start string `json:"start,omitempty"`
+	// End is the time of the day the window ends at, as `HH:MM`. The window ends on
+	// the next day when End isn't after Start, and lasts the whole day when both are empty.
+	// +optional
+	End string `json:"end,omitempty"`
+}
+
+// AppliesTo returns true if the policy applies to the Pipeline with the given
+// name. A pattern which doesn't compile applies to all the Pipelines, so that
+// an invalid policy restricts rather than allows.
+func (p *ExecutionWindowPolicy) AppliesTo(pipeline string) bool {
+	if len(p.Spec.Pipelines) == 0 {
+		return true
+	}
+	for _, r := range p.Spec.Pipelines {
+		if matched, err := regexp.MatchString(r.Pattern, pipeline); matched || err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Location returns the time zone of the windows.
+func (ps *ExecutionWindowPolicySpec) Location() (*time.Location, error) {
+	if ps.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(ps.TimeZone)
+}
+
+// Weekdays returns the days of the week the window starts on, all of them when
+// Days is empty. The names of the days are case insensitive.
+func (w *ExecutionWindow) Weekdays() ([]time.Weekday, error) {
+	if len(w.Days) == 0 {
+		return []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, nil
+	}
+	days := make([]time.Weekday, 0, len(w.Days))
+	for _, name := range w.Days {
+		day, err := weekday(name)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+func weekday(name string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(d.String(), name) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a day of the week", name)
+}
+
+// Bounds returns the start and the end of the window as offsets from the
+// beginning of the day it starts on, the end being after the start.
+func (w *ExecutionWindow) Bounds() (start, end time.Duration, err error) {
+	if start, err = timeOfDay(w.Start); err != nil {
+		return 0, 0, err
+	}
+	if end, err = timeOfDay(w.End); err != nil {
+		return 0, 0, err
+	}
+	if end <= start {
+		end += 24 * time.Hour
+	}
+	return start, end, nil
+}
+
+func timeOfDay(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of the day as HH:MM", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*ExecutionWindowPolicy)(nil)
+
+// Validate ExecutionWindowPolicy
+func (p *ExecutionWindowPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(p.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate ExecutionWindowPolicySpec, the validation requires allowed or blocked
+// windows, the patterns to be valid regex and the time zone to be known.
+func (ps *ExecutionWindowPolicySpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for i, r := range ps.Pipelines {
+		errs = errs.Also(r.Validate(ctx).ViaFieldIndex("pipelines", i))
+	}
+	if _, err := ps.Location(); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s: %v", ps.TimeZone, err), "timeZone"))
+	}
+	if len(ps.Allowed) == 0 && len(ps.Blocked) == 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("allowed", "blocked"))
+	}
+	for i, w := range ps.Allowed {
+		errs = errs.Also(w.Validate(ctx).ViaFieldIndex("allowed", i))
+	}
+	for i, w := range ps.Blocked {
+		errs = errs.Also(w.Validate(ctx).ViaFieldIndex("blocked", i))
+	}
+	return errs
+}
+
+// Validate ExecutionWindow, the validation requires the days to be days of the
+// week and the start and the end to be times of the day.
+func (w *ExecutionWindow) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for i, d := range w.Days {
+		if _, err := weekday(d); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(d, "days", i))
+		}
+	}
+	if _, err := timeOfDay(w.Start); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.Start, "start", err.Error()))
+	}
+	if _, err := timeOfDay(w.End); err != nil {
+		errs = errs.Also(apis.ErrInvalidValue(w.End, "end", err.Error()))
+	}
+	return errs
+}
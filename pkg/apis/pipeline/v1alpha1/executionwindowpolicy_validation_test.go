@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestExecutionWindowPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.ExecutionWindowPolicySpec
+		want *apis.FieldError
+	}{{
+		name: "missing windows",
+		spec: v1alpha1.ExecutionWindowPolicySpec{
+			Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^deploy-"}},
+		},
+		want: apis.ErrMissingOneOf("spec.allowed", "spec.blocked"),
+	}, {
+		name: "invalid time zone",
+		spec: v1alpha1.ExecutionWindowPolicySpec{
+			TimeZone: "Mars/Olympus",
+			Blocked:  []v1alpha1.ExecutionWindow{{Days: []string{"Saturday"}}},
+		},
+		want: apis.ErrInvalidValue("Mars/Olympus: unknown time zone Mars/Olympus", "spec.timeZone"),
+	}, {
+		name: "invalid day",
+		spec: v1alpha1.ExecutionWindowPolicySpec{
+			Blocked: []v1alpha1.ExecutionWindow{{Days: []string{"Saturday", "Caturday"}}},
+		},
+		want: apis.ErrInvalidArrayValue("Caturday", "days", 1).ViaFieldIndex("blocked", 0).ViaField("spec"),
+	}, {
+		name: "invalid start and end",
+		spec: v1alpha1.ExecutionWindowPolicySpec{
+			Allowed: []v1alpha1.ExecutionWindow{{Start: "9am", End: "25:00"}},
+		},
+		want: apis.ErrInvalidValue("9am", "start", `"9am" is not a time of the day as HH:MM`).
+			Also(apis.ErrInvalidValue("25:00", "end", `"25:00" is not a time of the day as HH:MM`)).
+			ViaFieldIndex("allowed", 0).ViaField("spec"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &v1alpha1.ExecutionWindowPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "ewp"},
+				Spec:       tt.spec,
+			}
+			err := p.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestExecutionWindowPolicy_Valid(t *testing.T) {
+	p := &v1alpha1.ExecutionWindowPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "ewp"},
+		Spec: v1alpha1.ExecutionWindowPolicySpec{
+			Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^deploy-"}},
+			TimeZone:  "Europe/Paris",
+			Allowed:   []v1alpha1.ExecutionWindow{{Name: "office hours", Days: []string{"monday", "Friday"}, Start: "09:00", End: "17:30"}},
+			Blocked:   []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+		},
+	}
+	if err := p.Validate(context.Background()); err != nil {
+		t.Errorf("validating a valid ExecutionWindowPolicy: %v", err)
+	}
+}
+
+func TestExecutionWindow_Bounds(t *testing.T) {
+	for _, tc := range []struct {
+		window             v1alpha1.ExecutionWindow
+		wantStart, wantEnd time.Duration
+	}{{
+		window:  v1alpha1.ExecutionWindow{},
+		wantEnd: 24 * time.Hour,
+	}, {
+		window:    v1alpha1.ExecutionWindow{Start: "09:00", End: "17:30"},
+		wantStart: 9 * time.Hour,
+		wantEnd:   17*time.Hour + 30*time.Minute,
+	}, {
+		window:    v1alpha1.ExecutionWindow{Start: "22:00", End: "06:00"},
+		wantStart: 22 * time.Hour,
+		wantEnd:   30 * time.Hour,
+	}, {
+		window:    v1alpha1.ExecutionWindow{Start: "18:00"},
+		wantStart: 18 * time.Hour,
+		wantEnd:   24 * time.Hour,
+	}} {
+		start, end, err := tc.window.Bounds()
+		if err != nil {
+			t.Fatalf("Bounds() of %+v: %v", tc.window, err)
+		}
+		if start != tc.wantStart || end != tc.wantEnd {
+			t.Errorf("Bounds() of %+v = %s, %s, want %s, %s", tc.window, start, end, tc.wantStart, tc.wantEnd)
+		}
+	}
+}
+
+func TestExecutionWindowPolicy_AppliesTo(t *testing.T) {
+	all := &v1alpha1.ExecutionWindowPolicy{}
+	deploy := &v1alpha1.ExecutionWindowPolicy{Spec: v1alpha1.ExecutionWindowPolicySpec{
+		Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^deploy-"}},
+	}}
+	if !all.AppliesTo("build") {
+		t.Error("A policy without pipelines should apply to all the Pipelines")
+	}
+	if !deploy.AppliesTo("deploy-prod") || deploy.AppliesTo("build") {
+		t.Error("A policy should only apply to the Pipelines matching its patterns")
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NotificationPolicy posts messages about the status changes of the PipelineRuns
+// and TaskRuns of its namespace to chat services and webhooks.
+// +k8s:openapi-gen=true
+type NotificationPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the NotificationPolicy.
+	Spec NotificationPolicySpec `json:"spec"`
+}
+
+// NotificationPolicyList contains a list of NotificationPolicy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type NotificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationPolicy `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*NotificationPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("NotificationPolicy")
+}
+
+// NotificationPolicySpec defines which status changes of which runs are notified,
+// and to whom.
+type NotificationPolicySpec struct {
+	// Kinds are the kinds of the runs notified, "PipelineRun" and "TaskRun".
+	// Defaults to "PipelineRun".
+	// +optional
+	// +listType=atomic
+	Kinds []string `json:"kinds,omitempty"`
+	// Selector selects the runs notified by their labels. All the runs of the
+	// namespace are selected when it is not set.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Events are the status changes notified, "Started", "Succeeded" and "Failed".
+	// Defaults to "Succeeded" and "Failed".
+	// +optional
+	// +listType=atomic
+	Events []NotificationEvent `json:"events,omitempty"`
+	// LogURL is a template of the link to the logs of a run, e.g.
+	// `https://dashboard.example.com/#/namespaces/{{.Namespace}}/pipelineruns/{{.Name}}`.
+	// It is executed with the same data as the messages.
+	// +optional
+	LogURL string `json:"logURL,omitempty"`
+	// Receivers are the chat services and webhooks the messages are posted to.
+	// +listType=atomic
+	Receivers []NotificationReceiver `json:"receivers"`
+}
+
+// NotificationEvent is a status change of a run.
+type NotificationEvent string
+
+const (
+	// NotificationEventStarted is notified when a run starts.
+	NotificationEventStarted NotificationEvent = "Started"
+	// NotificationEventSucceeded is notified when a run succeeds.
+	NotificationEventSucceeded NotificationEvent = "Succeeded"
+	// NotificationEventFailed is notified when a run fails, is cancelled or times out.
+	NotificationEventFailed NotificationEvent = "Failed"
+)
+
+// NotificationReceiverType is the kind of service a receiver posts the messages to.
+type NotificationReceiverType string
+
+const (
+	// NotificationReceiverSlack posts the messages to a Slack incoming webhook.
+	NotificationReceiverSlack NotificationReceiverType = "slack"
+	// NotificationReceiverTeams posts the messages to a Microsoft Teams incoming webhook.
+	NotificationReceiverTeams NotificationReceiverType = "teams"
+	// NotificationReceiverWebhook posts the messages along with the data they are
+	// rendered from as JSON to a generic webhook.
+	NotificationReceiverWebhook NotificationReceiverType = "webhook"
+)
+
+// NotificationReceiver is a chat service or a webhook the messages are posted to.
+// Exactly one of URL and URLFrom must be set.
+type NotificationReceiver struct {
+	// Name identifies the receiver in the policy.
+	Name string `json:"name"`
+	// Type is the kind of the receiver, one of "slack", "teams" and "webhook".
+	Type NotificationReceiverType `json:"type"`
+	// URL is the http(s) URL the messages are posted to.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// URLFrom selects the key of a secret in the namespace of the policy holding the
+	// URL the messages are posted to, e.g. the URL of a Slack incoming webhook.
+	// +optional
+	URLFrom *corev1.SecretKeySelector `json:"urlFrom,omitempty"`
+	// Template is the Go template of the messages. It is executed with the kind, the
+	// name, the namespace, the event, the reason, the message, the duration, the
+	// failed tasks and steps and the log URL of the run. A message naming the run,
+	// its status and the failed tasks is posted when it is not set.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// NotifiesKind returns true if the policy notifies the status changes of the runs
+// of the kind.
+func (p *NotificationPolicy) NotifiesKind(kind string) bool {
+	if len(p.Spec.Kinds) == 0 {
+		return kind == "PipelineRun"
+	}
+	for _, k := range p.Spec.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifiesEvent returns true if the policy notifies the event.
+func (p *NotificationPolicy) NotifiesEvent(event NotificationEvent) bool {
+	if len(p.Spec.Events) == 0 {
+		return event == NotificationEventSucceeded || event == NotificationEventFailed
+	}
+	for _, e := range p.Spec.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"text/template"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*NotificationPolicy)(nil)
+
+// Validate NotificationPolicy
+func (p *NotificationPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(p.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate NotificationPolicySpec, the validation requires the kinds and the events
+// to be known, the selector and the log URL template to be valid and at least one
+// valid receiver with a unique name.
+func (ps *NotificationPolicySpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	for i, k := range ps.Kinds {
+		if k != "PipelineRun" && k != "TaskRun" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(k, "kinds", i))
+		}
+	}
+	if ps.Selector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(ps.Selector); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "selector"))
+		}
+	}
+	for i, e := range ps.Events {
+		switch e {
+		case NotificationEventStarted, NotificationEventSucceeded, NotificationEventFailed:
+		default:
+			errs = errs.Also(apis.ErrInvalidArrayValue(e, "events", i))
+		}
+	}
+	if ps.LogURL != "" {
+		if _, err := template.New("logURL").Parse(ps.LogURL); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(ps.LogURL, "logURL", err.Error()))
+		}
+	}
+	if len(ps.Receivers) == 0 {
+		errs = errs.Also(apis.ErrMissingField("receivers"))
+	}
+	names := map[string]struct{}{}
+	for i, r := range ps.Receivers {
+		if _, ok := names[r.Name]; ok && r.Name != "" {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("receiver name %q must be unique", r.Name), "name").ViaFieldIndex("receivers", i))
+		}
+		names[r.Name] = struct{}{}
+		errs = errs.Also(r.Validate(ctx).ViaFieldIndex("receivers", i))
+	}
+	return errs
+}
+
+// Validate NotificationReceiver
+func (r *NotificationReceiver) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	switch r.Type {
+	case NotificationReceiverSlack, NotificationReceiverTeams, NotificationReceiverWebhook:
+	case "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.Type, "type", `type must be one of "slack", "teams" and "webhook"`))
+	}
+	switch {
+	case r.URL != "" && r.URLFrom != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("url", "urlFrom"))
+	case r.URL != "":
+		if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = errs.Also(apis.ErrInvalidValue(r.URL, "url", "url must be an http(s) URL"))
+		}
+	case r.URLFrom != nil:
+		if r.URLFrom.Name == "" {
+			errs = errs.Also(apis.ErrMissingField("urlFrom.name"))
+		}
+		if r.URLFrom.Key == "" {
+			errs = errs.Also(apis.ErrMissingField("urlFrom.key"))
+		}
+	default:
+		errs = errs.Also(apis.ErrMissingOneOf("url", "urlFrom"))
+	}
+	if r.Template != "" {
+		if _, err := template.New(r.Name).Parse(r.Template); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(r.Template, "template", err.Error()))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+var slackReceiver = v1alpha1.NotificationReceiver{
+	Name: "slack",
+	Type: v1alpha1.NotificationReceiverSlack,
+	URLFrom: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "slack-webhook"},
+		Key:                  "url",
+	},
+}
+
+func TestNotificationPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.NotificationPolicySpec
+		want *apis.FieldError
+	}{{
+		name: "missing receivers",
+		spec: v1alpha1.NotificationPolicySpec{},
+		want: apis.ErrMissingField("spec.receivers"),
+	}, {
+		name: "invalid kind",
+		spec: v1alpha1.NotificationPolicySpec{
+			Kinds:     []string{"TaskRun", "CustomRun"},
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver},
+		},
+		want: apis.ErrInvalidArrayValue("CustomRun", "spec.kinds", 1),
+	}, {
+		name: "invalid selector",
+		spec: v1alpha1.NotificationPolicySpec{
+			Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "team",
+				Operator: "Near",
+			}}},
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver},
+		},
+		want: apis.ErrInvalidValue(`"Near" is not a valid label selector operator`, "spec.selector"),
+	}, {
+		name: "invalid event",
+		spec: v1alpha1.NotificationPolicySpec{
+			Events:    []v1alpha1.NotificationEvent{"Retried"},
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver},
+		},
+		want: apis.ErrInvalidArrayValue("Retried", "spec.events", 0),
+	}, {
+		name: "invalid log URL",
+		spec: v1alpha1.NotificationPolicySpec{
+			LogURL:    "https://dashboard.example.com/{{.Name}",
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver},
+		},
+		want: apis.ErrInvalidValue("https://dashboard.example.com/{{.Name}", "spec.logURL",
+			"template: logURL:1: bad character U+007D '}'"),
+	}, {
+		name: "duplicate receiver names",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver, slackReceiver},
+		},
+		want: apis.ErrGeneric(`receiver name "slack" must be unique`, "spec.receivers[1].name"),
+	}, {
+		name: "missing name and type",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{URL: "https://hooks.example.com"}},
+		},
+		want: apis.ErrMissingField("spec.receivers[0].name", "spec.receivers[0].type"),
+	}, {
+		name: "invalid type",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "mail", Type: "email", URL: "https://hooks.example.com"}},
+		},
+		want: apis.ErrInvalidValue("email", "spec.receivers[0].type", `type must be one of "slack", "teams" and "webhook"`),
+	}, {
+		name: "missing url",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "hook", Type: v1alpha1.NotificationReceiverWebhook}},
+		},
+		want: apis.ErrMissingOneOf("spec.receivers[0].url", "spec.receivers[0].urlFrom"),
+	}, {
+		name: "url and urlFrom",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{
+				Name:    "slack",
+				Type:    v1alpha1.NotificationReceiverSlack,
+				URL:     "https://hooks.slack.com/services/T0/B0/X",
+				URLFrom: slackReceiver.URLFrom,
+			}},
+		},
+		want: apis.ErrMultipleOneOf("spec.receivers[0].url", "spec.receivers[0].urlFrom"),
+	}, {
+		name: "invalid url",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "hook", Type: v1alpha1.NotificationReceiverWebhook, URL: "ftp://hooks"}},
+		},
+		want: apis.ErrInvalidValue("ftp://hooks", "spec.receivers[0].url", "url must be an http(s) URL"),
+	}, {
+		name: "incomplete urlFrom",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{
+				Name:    "slack",
+				Type:    v1alpha1.NotificationReceiverSlack,
+				URLFrom: &corev1.SecretKeySelector{},
+			}},
+		},
+		want: apis.ErrMissingField("spec.receivers[0].urlFrom.name", "spec.receivers[0].urlFrom.key"),
+	}, {
+		name: "invalid template",
+		spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{
+				Name:     "hook",
+				Type:     v1alpha1.NotificationReceiverWebhook,
+				URL:      "https://hooks.example.com",
+				Template: "{{if .FailedTasks}}failed",
+			}},
+		},
+		want: apis.ErrInvalidValue("{{if .FailedTasks}}failed", "spec.receivers[0].template",
+			"template: hook:1: unexpected EOF"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &v1alpha1.NotificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "notify"},
+				Spec:       tt.spec,
+			}
+			err := p.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNotificationPolicy_Valid(t *testing.T) {
+	p := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Kinds:    []string{"PipelineRun", "TaskRun"},
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "build"}},
+			Events:   []v1alpha1.NotificationEvent{v1alpha1.NotificationEventStarted, v1alpha1.NotificationEventFailed},
+			LogURL:   "https://dashboard.example.com/#/namespaces/{{.Namespace}}/pipelineruns/{{.Name}}",
+			Receivers: []v1alpha1.NotificationReceiver{slackReceiver, {
+				Name:     "hook",
+				Type:     v1alpha1.NotificationReceiverWebhook,
+				URL:      "https://hooks.example.com/tekton",
+				Template: "{{.Name}} {{.Event}}{{range .FailedTasks}} {{.}}{{end}}",
+			}},
+		},
+	}
+	if err := p.Validate(context.Background()); err != nil {
+		t.Errorf("validating a valid NotificationPolicy: %v", err)
+	}
+}
+
+func TestNotificationPolicy_Notifies(t *testing.T) {
+	p := &v1alpha1.NotificationPolicy{}
+	for kind, want := range map[string]bool{"PipelineRun": true, "TaskRun": false} {
+		if got := p.NotifiesKind(kind); got != want {
+			t.Errorf("NotifiesKind(%q) = %t, want %t", kind, got, want)
+		}
+	}
+	for event, want := range map[v1alpha1.NotificationEvent]bool{
+		v1alpha1.NotificationEventStarted:   false,
+		v1alpha1.NotificationEventSucceeded: true,
+		v1alpha1.NotificationEventFailed:    true,
+	} {
+		if got := p.NotifiesEvent(event); got != want {
+			t.Errorf("NotifiesEvent(%q) = %t, want %t", event, got, want)
+		}
+	}
+
+	p.Spec.Kinds = []string{"TaskRun"}
+	p.Spec.Events = []v1alpha1.NotificationEvent{v1alpha1.NotificationEventStarted}
+	if p.NotifiesKind("PipelineRun") || !p.NotifiesKind("TaskRun") {
+		t.Errorf("expected the policy to notify the TaskRuns only")
+	}
+	if p.NotifiesEvent(v1alpha1.NotificationEventFailed) || !p.NotifiesEvent(v1alpha1.NotificationEventStarted) {
+		t.Errorf("expected the policy to notify the starts only")
+	}
+}
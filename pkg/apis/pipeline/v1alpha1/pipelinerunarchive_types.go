@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PipelineRunArchive holds a PipelineRun, with its TaskRuns, archived by the
+// controller before pruning them, so that they survive their deletion.
+// +k8s:openapi-gen=true
+type PipelineRunArchive struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the archived runs.
+	Spec PipelineRunArchiveSpec `json:"spec"`
+}
+
+// PipelineRunArchiveList contains a list of PipelineRunArchive
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PipelineRunArchiveList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PipelineRunArchive `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*PipelineRunArchive) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("PipelineRunArchive")
+}
+
+// PipelineRunArchiveSpec holds the archived PipelineRun and TaskRuns, with their
+// metadata, spec and status, as they were when the PipelineRun was pruned.
+type PipelineRunArchiveSpec struct {
+	// PipelineRun is the archived PipelineRun.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	PipelineRun runtime.RawExtension `json:"pipelineRun"`
+	// TaskRuns are the archived TaskRuns of the PipelineRun.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:pruning:PreserveUnknownFields
+	TaskRuns []runtime.RawExtension `json:"taskRuns,omitempty"`
+	// ArchiveTime is the time the runs were archived at.
+	ArchiveTime metav1.Time `json:"archiveTime"`
+}
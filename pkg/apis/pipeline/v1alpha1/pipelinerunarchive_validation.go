@@ -0,0 +1,35 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*PipelineRunArchive)(nil)
+
+// Validate PipelineRunArchive, the validation requires the archived PipelineRun.
+func (a *PipelineRunArchive) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(a.GetObjectMeta()).ViaField("metadata"))
+	if len(a.Spec.PipelineRun.Raw) == 0 && a.Spec.PipelineRun.Object == nil {
+		errs = errs.Also(apis.ErrMissingField("spec.pipelineRun"))
+	}
+	return errs
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+func TestPipelineRunArchive_Invalid(t *testing.T) {
+	a := &v1alpha1.PipelineRunArchive{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr-0123"},
+	}
+	want := apis.ErrMissingField("spec.pipelineRun")
+	if d := cmp.Diff(want.Error(), a.Validate(context.Background()).Error()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestPipelineRunArchive_Valid(t *testing.T) {
+	a := &v1alpha1.PipelineRunArchive{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr-0123"},
+		Spec: v1alpha1.PipelineRunArchiveSpec{
+			PipelineRun: runtime.RawExtension{Raw: []byte(`{"apiVersion":"tekton.dev/v1beta1","kind":"PipelineRun","metadata":{"name":"pr"}}`)},
+		},
+	}
+	a.SetDefaults(context.Background())
+	if err := a.Validate(context.Background()); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
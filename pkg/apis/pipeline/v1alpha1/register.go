@@ -50,6 +50,22 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RunList{},
 		&VerificationPolicy{},
 		&VerificationPolicyList{},
+		&ServiceAccountPolicy{},
+		&ServiceAccountPolicyList{},
+		&CloudEventSink{},
+		&CloudEventSinkList{},
+		&NotificationPolicy{},
+		&NotificationPolicyList{},
+		&ExecutionWindowPolicy{},
+		&ExecutionWindowPolicyList{},
+		&TektonHealth{},
+		&TektonHealthList{},
+		&PipelineRunArchive{},
+		&PipelineRunArchiveList{},
+		&TaskGroup{},
+		&TaskGroupList{},
+		&WorkspacePool{},
+		&WorkspacePoolList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:noStatus
+// +genreconciler:krshapedlogic=false
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceAccountPolicy restricts the service accounts the PipelineRuns and the
+// TaskRuns of its namespace may run with, and select for their pipeline tasks, to
+// the ones it allows for the Pipelines and the Tasks it applies to. Once a
+// namespace has a ServiceAccountPolicy, the runs of the Pipelines and the Tasks
+// none of its policies applies to may not select a service account.
+// +k8s:openapi-gen=true
+type ServiceAccountPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the ServiceAccountPolicy.
+	Spec ServiceAccountPolicySpec `json:"spec"`
+}
+
+// ServiceAccountPolicyList contains a list of ServiceAccountPolicy
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ServiceAccountPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceAccountPolicy `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*ServiceAccountPolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ServiceAccountPolicy")
+}
+
+// ServiceAccountPolicySpec defines the Pipelines and the Tasks the policy applies
+// to and the service accounts their runs may use.
+type ServiceAccountPolicySpec struct {
+	// Pipelines defines the patterns of the names of the Pipelines the policy applies to.
+	// The patterns are regex, e.g. `^release-.*` applies the policy to the Pipelines
+	// whose name starts with `release-`. The policy only applies to the PipelineRuns
+	// referencing a Pipeline of the namespace by name, not to the ones with an
+	// embedded pipelineSpec or a remote pipelineRef.
+	// +optional
+	Pipelines []ResourcePattern `json:"pipelines,omitempty"`
+	// Tasks defines the patterns of the names of the Tasks the policy applies to, for
+	// the TaskRuns which are not run by a PipelineRun. The policy only applies to the
+	// TaskRuns referencing a Task by name, not to the ones with an embedded taskSpec
+	// or a remote taskRef.
+	// +optional
+	Tasks []ResourcePattern `json:"tasks,omitempty"`
+	// ServiceAccounts are the names of the service accounts the runs of the Pipelines
+	// and the Tasks the policy applies to may use, in `serviceAccountName` and
+	// `taskRunSpecs[].serviceAccountName`.
+	// +listType=atomic
+	ServiceAccounts []string `json:"serviceAccounts"`
+}
+
+// AppliesTo returns true if the policy applies to the Pipeline with the given
+// name. The runs of unnamed Pipelines, e.g. embedded ones, are never applied to.
+func (p *ServiceAccountPolicy) AppliesTo(pipeline string) bool {
+	return matchesAny(p.Spec.Pipelines, pipeline)
+}
+
+// AppliesToTask returns true if the policy applies to the Task with the given
+// name. The runs of unnamed Tasks, e.g. embedded ones, are never applied to.
+func (p *ServiceAccountPolicy) AppliesToTask(task string) bool {
+	return matchesAny(p.Spec.Tasks, task)
+}
+
+// matchesAny returns true if the name matches one of the patterns. A pattern
+// which doesn't compile, which the validation rejects, doesn't match.
+func matchesAny(patterns []ResourcePattern, name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range patterns {
+		if matched, err := regexp.MatchString(r.Pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Allows returns true if the policy allows the service account.
+func (p *ServiceAccountPolicy) Allows(serviceAccount string) bool {
+	for _, sa := range p.Spec.ServiceAccounts {
+		if sa == serviceAccount {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*ServiceAccountPolicy)(nil)
+
+// Validate ServiceAccountPolicy
+func (p *ServiceAccountPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(p.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate ServiceAccountPolicySpec, the validation requires one of Pipelines and Tasks and
+// ServiceAccounts not to be empty, the patterns to be valid regex and the service accounts
+// to be valid names.
+func (ps *ServiceAccountPolicySpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(ps.Pipelines) == 0 && len(ps.Tasks) == 0 {
+		errs = errs.Also(apis.ErrMissingOneOf("pipelines", "tasks"))
+	}
+	for i, r := range ps.Pipelines {
+		errs = errs.Also(r.Validate(ctx).ViaFieldIndex("pipelines", i))
+	}
+	for i, r := range ps.Tasks {
+		errs = errs.Also(r.Validate(ctx).ViaFieldIndex("tasks", i))
+	}
+	if len(ps.ServiceAccounts) == 0 {
+		errs = errs.Also(apis.ErrMissingField("serviceAccounts"))
+	}
+	for i, sa := range ps.ServiceAccounts {
+		if msgs := validation.IsDNS1123Subdomain(sa); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidArrayValue(sa, "serviceAccounts", i))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestServiceAccountPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.ServiceAccountPolicySpec
+		want *apis.FieldError
+	}{{
+		name: "missing pipelines and tasks",
+		spec: v1alpha1.ServiceAccountPolicySpec{
+			ServiceAccounts: []string{"deployer"},
+		},
+		want: apis.ErrMissingOneOf("spec.pipelines", "spec.tasks"),
+	}, {
+		name: "missing service accounts",
+		spec: v1alpha1.ServiceAccountPolicySpec{
+			Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^release-"}},
+		},
+		want: apis.ErrMissingField("spec.serviceAccounts"),
+	}, {
+		name: "invalid pattern",
+		spec: v1alpha1.ServiceAccountPolicySpec{
+			Pipelines:       []v1alpha1.ResourcePattern{{Pattern: "^["}},
+			ServiceAccounts: []string{"deployer"},
+		},
+		want: apis.ErrInvalidValue("^[", "ResourcePattern", fmt.Sprintf("%v: error parsing regexp: missing closing ]: `[`", v1alpha1.InvalidResourcePatternErr)).ViaFieldIndex("pipelines", 0).ViaField("spec"),
+	}, {
+		name: "invalid task pattern",
+		spec: v1alpha1.ServiceAccountPolicySpec{
+			Tasks:           []v1alpha1.ResourcePattern{{Pattern: "^["}},
+			ServiceAccounts: []string{"deployer"},
+		},
+		want: apis.ErrInvalidValue("^[", "ResourcePattern", fmt.Sprintf("%v: error parsing regexp: missing closing ]: `[`", v1alpha1.InvalidResourcePatternErr)).ViaFieldIndex("tasks", 0).ViaField("spec"),
+	}, {
+		name: "invalid service account",
+		spec: v1alpha1.ServiceAccountPolicySpec{
+			Pipelines:       []v1alpha1.ResourcePattern{{Pattern: "^release-"}},
+			ServiceAccounts: []string{"deployer", "Not_A_Name"},
+		},
+		want: apis.ErrInvalidArrayValue("Not_A_Name", "spec.serviceAccounts", 1),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &v1alpha1.ServiceAccountPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "sap"},
+				Spec:       tt.spec,
+			}
+			err := p.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestServiceAccountPolicy_Valid(t *testing.T) {
+	p := &v1alpha1.ServiceAccountPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "sap"},
+		Spec: v1alpha1.ServiceAccountPolicySpec{
+			Pipelines:       []v1alpha1.ResourcePattern{{Pattern: "^release-"}, {Pattern: "deploy"}},
+			Tasks:           []v1alpha1.ResourcePattern{{Pattern: "^kaniko$"}},
+			ServiceAccounts: []string{"default", "deployer"},
+		},
+	}
+	if err := p.Validate(context.Background()); err != nil {
+		t.Errorf("validating a valid ServiceAccountPolicy: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskGroup inlines a list of Tasks into a single Task, so that they run in the
+// pod of a single TaskRun and share its workspaces without PersistentVolumeClaims.
+// TaskGroups are referred to by the TaskRefs of kind TaskGroup.
+// +k8s:openapi-gen=true
+type TaskGroup struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the TaskGroup.
+	Spec TaskGroupSpec `json:"spec"`
+}
+
+// TaskGroupList contains a list of TaskGroup
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TaskGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TaskGroup `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*TaskGroup) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("TaskGroup")
+}
+
+// TaskGroupSpec defines the Tasks of a TaskGroup.
+type TaskGroupSpec struct {
+	// Description is a user-facing description of the TaskGroup that may be
+	// used to populate a UI.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Tasks are the Tasks inlined in the TaskGroup. Their steps run in the order
+	// of the list, and their params, results, steps and sidecars are prefixed with
+	// the names of the members, e.g. the param `url` of the member `clone` is the
+	// param `clone-url` of the TaskGroup. Their workspaces are shared by name.
+	// +listType=atomic
+	Tasks []TaskGroupTask `json:"tasks"`
+}
+
+// TaskGroupTask is a member Task of a TaskGroup.
+type TaskGroupTask struct {
+	// Name is the name of the member in the TaskGroup, which prefixes its params,
+	// results, steps and sidecars.
+	Name string `json:"name"`
+	// TaskRef refers to the Task or the ClusterTask of the member by name.
+	TaskRef *v1beta1.TaskRef `json:"taskRef"`
+}
+
+// Prefix returns the name of the param, result, step or sidecar of the member
+// in the TaskGroup.
+func (t *TaskGroupTask) Prefix(name string) string {
+	return t.Name + "-" + name
+}
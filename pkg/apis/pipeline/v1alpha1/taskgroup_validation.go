@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*TaskGroup)(nil)
+
+// Validate TaskGroup
+func (g *TaskGroup) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(g.GetObjectMeta()).ViaField("metadata"))
+	errs = errs.Also(g.Spec.Validate(ctx).ViaField("spec"))
+	return errs
+}
+
+// Validate TaskGroupSpec, the validation requires at least one member Task and
+// the names of the members to be unique DNS labels.
+func (gs *TaskGroupSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(gs.Tasks) == 0 {
+		errs = errs.Also(apis.ErrMissingField("tasks"))
+	}
+	names := sets.NewString()
+	for i, t := range gs.Tasks {
+		errs = errs.Also(t.Validate(ctx).ViaFieldIndex("tasks", i))
+		if names.Has(t.Name) {
+			errs = errs.Also(apis.ErrGeneric("expected names to be unique, found duplicate: "+t.Name, "name").ViaFieldIndex("tasks", i))
+		}
+		names.Insert(t.Name)
+	}
+	return errs
+}
+
+// Validate TaskGroupTask, the validation requires the member to refer to a Task
+// or a ClusterTask of the cluster by name.
+func (t *TaskGroupTask) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if t.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	} else if msgs := validation.IsDNS1123Label(t.Name); len(msgs) > 0 {
+		errs = errs.Also(apis.ErrInvalidValue(t.Name, "name", strings.Join(msgs, "; ")))
+	}
+	if t.TaskRef == nil {
+		return errs.Also(apis.ErrMissingField("taskRef"))
+	}
+	if t.TaskRef.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("taskRef.name"))
+	}
+	switch t.TaskRef.Kind {
+	case "", v1beta1.NamespacedTaskKind, v1beta1.ClusterTaskKind:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(t.TaskRef.Kind, "taskRef.kind", "the members of a TaskGroup must be Tasks or ClusterTasks"))
+	}
+	if t.TaskRef.APIVersion != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.apiVersion"))
+	}
+	if t.TaskRef.Bundle != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.bundle"))
+	}
+	if t.TaskRef.Resolver != "" || t.TaskRef.Params != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("taskRef.resolver", "taskRef.params"))
+	}
+	return errs
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTaskGroup_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec v1alpha1.TaskGroupSpec
+		want *apis.FieldError
+	}{{
+		name: "missing tasks",
+		spec: v1alpha1.TaskGroupSpec{},
+		want: apis.ErrMissingField("spec.tasks"),
+	}, {
+		name: "invalid name",
+		spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{{Name: "Clone", TaskRef: &v1beta1.TaskRef{Name: "git-clone"}}},
+		},
+		want: apis.ErrInvalidValue("Clone", "spec.tasks[0].name", "a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+	}, {
+		name: "duplicate names",
+		spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{
+				{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "golang-build"}},
+				{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "kaniko"}},
+			},
+		},
+		want: apis.ErrGeneric("expected names to be unique, found duplicate: build", "spec.tasks[1].name"),
+	}, {
+		name: "missing taskRef",
+		spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{{Name: "clone"}},
+		},
+		want: apis.ErrMissingField("spec.tasks[0].taskRef"),
+	}, {
+		name: "custom task",
+		spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{{Name: "wait", TaskRef: &v1beta1.TaskRef{Name: "wait", APIVersion: "example.dev/v0", Kind: "Wait"}}},
+		},
+		want: apis.ErrInvalidValue("Wait", "spec.tasks[0].taskRef.kind", "the members of a TaskGroup must be Tasks or ClusterTasks").
+			Also(apis.ErrDisallowedFields("spec.tasks[0].taskRef.apiVersion")),
+	}, {
+		name: "remote task",
+		spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{{Name: "clone", TaskRef: &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "hub"}}}},
+		},
+		want: apis.ErrMissingField("spec.tasks[0].taskRef.name").
+			Also(apis.ErrDisallowedFields("spec.tasks[0].taskRef.resolver", "spec.tasks[0].taskRef.params")),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &v1alpha1.TaskGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: "tg"},
+				Spec:       tt.spec,
+			}
+			err := g.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskGroup_Valid(t *testing.T) {
+	g := &v1alpha1.TaskGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "tg"},
+		Spec: v1alpha1.TaskGroupSpec{
+			Description: "Clone and build",
+			Tasks: []v1alpha1.TaskGroupTask{
+				{Name: "clone", TaskRef: &v1beta1.TaskRef{Name: "git-clone", Kind: v1beta1.ClusterTaskKind}},
+				{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "golang-build"}},
+			},
+		},
+	}
+	if err := g.Validate(context.Background()); err != nil {
+		t.Errorf("validating a valid TaskGroup: %v", err)
+	}
+}
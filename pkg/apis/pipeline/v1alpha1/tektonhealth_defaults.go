@@ -0,0 +1,38 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// DefaultTektonHealthCheckPeriod is the period the controller checks the
+// installation at when the TektonHealth doesn't set it.
+const DefaultTektonHealthCheckPeriod = time.Minute
+
+var _ apis.Defaultable = (*TektonHealth)(nil)
+
+// SetDefaults implements apis.Defaultable
+func (h *TektonHealth) SetDefaults(ctx context.Context) {
+	if h.Spec.CheckPeriod == nil {
+		h.Spec.CheckPeriod = &metav1.Duration{Duration: DefaultTektonHealthCheckPeriod}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+// TektonHealthName is the name of the TektonHealth of the installation, the
+// controller maintains the status of the TektonHealth with this name only.
+const TektonHealthName = "tekton"
+
+const (
+	// TektonHealthConditionWebhookReachable is True when the API server reaches the
+	// admission webhooks of the installation.
+	TektonHealthConditionWebhookReachable apis.ConditionType = "WebhookReachable"
+	// TektonHealthConditionResolversHealthy is True when the deployment of the remote
+	// resolvers is available.
+	TektonHealthConditionResolversHealthy apis.ConditionType = "ResolversHealthy"
+	// TektonHealthConditionConfigValid is True when the controller parses all of the
+	// configmaps of the installation.
+	TektonHealthConditionConfigValid apis.ConditionType = "ConfigValid"
+	// TektonHealthConditionVersionsConsistent is True when the deployments of the
+	// installation are all of the version of the installation.
+	TektonHealthConditionVersionsConsistent apis.ConditionType = "VersionsConsistent"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TektonHealth summarizes the readiness of the whole Tekton installation. The
+// controller creates the TektonHealth named `tekton` and keeps its status up to
+// date, so that the tooling of the cluster admins watches a single object.
+// +k8s:openapi-gen=true
+type TektonHealth struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the TektonHealth.
+	// +optional
+	Spec TektonHealthSpec `json:"spec"`
+	// Status holds the last observed health of the installation.
+	// +optional
+	Status TektonHealthStatus `json:"status,omitempty"`
+}
+
+// TektonHealthList contains a list of TektonHealth
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TektonHealthList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TektonHealth `json:"items"`
+}
+
+// TektonHealthSpec defines how often the controller checks the installation.
+type TektonHealthSpec struct {
+	// CheckPeriod is the period the controller checks the installation at. Defaults
+	// to one minute.
+	// +optional
+	CheckPeriod *metav1.Duration `json:"checkPeriod,omitempty"`
+}
+
+// TektonHealthStatus defines the observed health of the installation.
+type TektonHealthStatus struct {
+	duckv1.Status `json:",inline"`
+
+	// TektonHealthStatusFields inlines the status fields.
+	TektonHealthStatusFields `json:",inline"`
+}
+
+// TektonHealthStatusFields holds the fields of the status of a TektonHealth.
+type TektonHealthStatusFields struct {
+	// Version is the version of the installation, from the `pipelines-info` configmap.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Components are the deployments of the installation.
+	// +optional
+	// +listType=atomic
+	Components []ComponentHealth `json:"components,omitempty"`
+	// InvalidConfigs are the configmaps of the installation the controller fails to parse.
+	// +optional
+	// +listType=atomic
+	InvalidConfigs []InvalidConfig `json:"invalidConfigs,omitempty"`
+	// LastCheckTime is the time the controller last checked the installation at.
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+}
+
+// ComponentHealth is the observed state of a deployment of the installation.
+type ComponentHealth struct {
+	// Name is the name of the deployment.
+	Name string `json:"name"`
+	// Namespace is the namespace of the deployment.
+	Namespace string `json:"namespace"`
+	// Version is the release the deployment is labeled with.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Replicas is the number of the desired pods of the deployment.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// ReadyReplicas is the number of the ready pods of the deployment.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Available is true when the deployment has the minimum of its pods available.
+	Available bool `json:"available"`
+	// Message explains why the deployment isn't available.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// InvalidConfig is a configmap the controller fails to parse.
+type InvalidConfig struct {
+	// Name is the name of the configmap.
+	Name string `json:"name"`
+	// Error is the error parsing the configmap.
+	Error string `json:"error"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*TektonHealth) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("TektonHealth")
+}
+
+// GetStatus implements KRShaped.
+func (h *TektonHealth) GetStatus() *duckv1.Status {
+	return &h.Status.Status
+}
+
+// tektonHealthCondSet is Ready when all of the checks of the installation pass.
+var tektonHealthCondSet = apis.NewLivingConditionSet(
+	TektonHealthConditionWebhookReachable,
+	TektonHealthConditionResolversHealthy,
+	TektonHealthConditionConfigValid,
+	TektonHealthConditionVersionsConsistent,
+)
+
+// GetConditionSet implements KRShaped.
+func (*TektonHealth) GetConditionSet() apis.ConditionSet {
+	return tektonHealthCondSet
+}
+
+// InitializeConditions sets the initial values of the conditions.
+func (s *TektonHealthStatus) InitializeConditions() {
+	tektonHealthCondSet.Manage(s).InitializeConditions()
+}
+
+// MarkCheck sets the condition of a check to True when it passes, to False with
+// the given reason and message otherwise.
+func (s *TektonHealthStatus) MarkCheck(t apis.ConditionType, passed bool, reason, message string) {
+	if passed {
+		tektonHealthCondSet.Manage(s).MarkTrueWithReason(t, reason, message)
+		return
+	}
+	tektonHealthCondSet.Manage(s).MarkFalse(t, reason, message)
+}
+
+// MarkCheckUnknown sets the condition of a check to Unknown, when the controller
+// fails to run the check.
+func (s *TektonHealthStatus) MarkCheckUnknown(t apis.ConditionType, reason, message string) {
+	tektonHealthCondSet.Manage(s).MarkUnknown(t, reason, message)
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*TektonHealth)(nil)
+
+// Validate TektonHealth, the validation requires the name of the singleton so
+// that the installation has a single TektonHealth.
+func (h *TektonHealth) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(h.GetObjectMeta()).ViaField("metadata"))
+	if h.Name != TektonHealthName {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s: the TektonHealth of the installation is named %s", h.Name, TektonHealthName), "metadata.name"))
+	}
+	if apis.IsInStatusUpdate(ctx) {
+		return errs
+	}
+	return errs.Also(h.Spec.Validate(ctx).ViaField("spec"))
+}
+
+// Validate TektonHealthSpec, the validation requires a positive check period.
+func (hs *TektonHealthSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if hs.CheckPeriod != nil && hs.CheckPeriod.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be > 0", hs.CheckPeriod.Duration), "checkPeriod"))
+	}
+	return errs
+}
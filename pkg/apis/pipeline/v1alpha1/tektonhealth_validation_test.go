@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestTektonHealth_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		health *v1alpha1.TektonHealth
+		want   *apis.FieldError
+	}{{
+		name: "not the singleton",
+		health: &v1alpha1.TektonHealth{
+			ObjectMeta: metav1.ObjectMeta{Name: "health"},
+		},
+		want: apis.ErrInvalidValue("health: the TektonHealth of the installation is named tekton", "metadata.name"),
+	}, {
+		name: "negative check period",
+		health: &v1alpha1.TektonHealth{
+			ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.TektonHealthName},
+			Spec:       v1alpha1.TektonHealthSpec{CheckPeriod: &metav1.Duration{Duration: -time.Minute}},
+		},
+		want: apis.ErrInvalidValue("-1m0s should be > 0", "spec.checkPeriod"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.health.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTektonHealth_Valid(t *testing.T) {
+	h := &v1alpha1.TektonHealth{
+		ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.TektonHealthName},
+	}
+	h.SetDefaults(context.Background())
+	if err := h.Validate(context.Background()); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+	if d := cmp.Diff(&metav1.Duration{Duration: time.Minute}, h.Spec.CheckPeriod); d != "" {
+		t.Errorf("SetDefaults() %s", diff.PrintWantGot(d))
+	}
+}
@@ -67,6 +67,11 @@ type VerificationPolicySpec struct {
 	// warn - don't fail the taskrun/pipelinerun if verification fails but log warnings
 	// +optional
 	Mode ModeType `json:"mode,omitempty"`
+	// Kind is the kind of the artifacts the policy verifies:
+	// resources - the Tasks and Pipelines, matched by their source (default)
+	// images - the images of the steps and sidecars, matched by their repository
+	// +optional
+	Kind PolicyKind `json:"kind,omitempty"`
 }
 
 // ResourcePattern defines the pattern of the resource source
@@ -80,11 +85,17 @@ type ResourcePattern struct {
 }
 
 // The Authority block defines the keys for validating signatures.
+// One and only one of Key and Keyless must be set.
 type Authority struct {
 	// Name is the name for this authority.
 	Name string `json:"name"`
 	// Key contains the public key to validate the resource.
 	Key *KeyRef `json:"key,omitempty"`
+	// Keyless contains the identities and roots of trust to validate keyless
+	// signatures of the resource, made with short-lived certificates and
+	// recorded in a transparency log.
+	// +optional
+	Keyless *KeylessRef `json:"keyless,omitempty"`
 }
 
 // ModeType indicates the type of a mode for VerificationPolicy
@@ -96,6 +107,23 @@ const (
 	ModeEnforce ModeType = "enforce"
 )
 
+// PolicyKind indicates the kind of the artifacts verified by a VerificationPolicy
+type PolicyKind string
+
+// Valid PolicyKind:
+const (
+	PolicyKindResources PolicyKind = "resources"
+	PolicyKindImages    PolicyKind = "images"
+)
+
+// GetKind returns the kind of the policy, defaulting to resources.
+func (vs *VerificationPolicySpec) GetKind() PolicyKind {
+	if vs.Kind == "" {
+		return PolicyKindResources
+	}
+	return vs.Kind
+}
+
 // KeyRef defines the reference to a public key
 type KeyRef struct {
 	// SecretRef sets a reference to a secret with the key.
@@ -117,6 +145,44 @@ type KeyRef struct {
 	HashAlgorithm HashAlgorithm `json:"hashAlgorithm,omitempty"`
 }
 
+// KeylessRef defines how to validate keyless signatures, e.g. made with a
+// certificate issued by Fulcio and recorded in Rekor.
+type KeylessRef struct {
+	// Identities are the identities allowed to sign the resource. The
+	// signing certificate must match one of them.
+	Identities []Identity `json:"identities"`
+	// CARoots contains the PEM-encoded root and intermediate certificates of
+	// the certificate authority issuing the signing certificates.
+	CARoots string `json:"caRoots"`
+	// TransparencyLog contains the public key of the transparency log in
+	// which the signatures must be recorded.
+	TransparencyLog *KeyRef `json:"transparencyLog"`
+	// TransparencyLogOrigin is the origin of the checkpoints signed by the
+	// transparency log, e.g. "rekor.sigstore.dev - 2605736670972794746". It
+	// identifies the tree of the log the inclusion proofs must lead to.
+	TransparencyLogOrigin string `json:"transparencyLogOrigin"`
+}
+
+// Identity defines an identity allowed to sign resources by the issuer and
+// the subject of its signing certificates. Each of them is matched either
+// exactly or by a regular expression.
+type Identity struct {
+	// Issuer is the OIDC issuer which authenticated the signer,
+	// e.g. https://token.actions.githubusercontent.com.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// IssuerRegExp is a regular expression matching the OIDC issuer.
+	// +optional
+	IssuerRegExp string `json:"issuerRegExp,omitempty"`
+	// Subject is the identity of the signer, i.e. an email address or a URI
+	// in the subject alternative names of the certificate.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+	// SubjectRegExp is a regular expression matching the identity of the signer.
+	// +optional
+	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+}
+
 // HashAlgorithm defines the hash algorithm used for the public key
 type HashAlgorithm string
 
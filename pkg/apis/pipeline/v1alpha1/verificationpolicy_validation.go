@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"regexp"
 	"strings"
@@ -51,13 +52,22 @@ func (vs *VerificationPolicySpec) Validate(ctx context.Context) (errs *apis.Fiel
 		errs = errs.Also(apis.ErrMissingField("authorities"))
 	}
 	for i, a := range vs.Authorities {
+		if a.Key != nil && a.Keyless != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("key", "keyless").ViaFieldIndex("authorities", i))
+		}
 		if a.Key != nil {
 			errs = errs.Also(a.Key.Validate(ctx).ViaFieldIndex("key", i))
 		}
+		if a.Keyless != nil {
+			errs = errs.Also(a.Keyless.Validate(ctx).ViaFieldIndex("keyless", i))
+		}
 	}
 	if vs.Mode != "" && vs.Mode != ModeEnforce && vs.Mode != ModeWarn {
 		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, but got: %s", ModeEnforce, ModeWarn, vs.Mode), "mode"))
 	}
+	if vs.Kind != "" && vs.Kind != PolicyKindResources && vs.Kind != PolicyKindImages {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, but got: %s", PolicyKindResources, PolicyKindImages, vs.Kind), "kind"))
+	}
 	return errs
 }
 
@@ -90,6 +100,68 @@ func (key *KeyRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return errs
 }
 
+// Validate KeylessRef will check that it has identities, the certificates of the
+// certificate authority, and the key and the checkpoint origin of the transparency log.
+func (k *KeylessRef) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if len(k.Identities) == 0 {
+		errs = errs.Also(apis.ErrMissingField("identities"))
+	}
+	for i, id := range k.Identities {
+		errs = errs.Also(id.Validate(ctx).ViaFieldIndex("identities", i))
+	}
+	if k.CARoots == "" {
+		errs = errs.Also(apis.ErrMissingField("caRoots"))
+	} else if !containsPEMCertificate(k.CARoots) {
+		errs = errs.Also(apis.ErrInvalidValue("must contain PEM-encoded certificates", "caRoots"))
+	}
+	if k.TransparencyLog == nil {
+		errs = errs.Also(apis.ErrMissingField("transparencyLog"))
+	} else {
+		errs = errs.Also(k.TransparencyLog.Validate(ctx).ViaField("transparencyLog"))
+	}
+	if k.TransparencyLogOrigin == "" {
+		errs = errs.Also(apis.ErrMissingField("transparencyLogOrigin"))
+	}
+	return errs
+}
+
+// Validate Identity will check that one of Issuer and IssuerRegExp, and one of
+// Subject and SubjectRegExp are set, and that the regular expressions compile.
+func (id *Identity) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validateIdentityField(id.Issuer, id.IssuerRegExp, "issuer", "issuerRegExp"))
+	errs = errs.Also(validateIdentityField(id.Subject, id.SubjectRegExp, "subject", "subjectRegExp"))
+	return errs
+}
+
+func validateIdentityField(exact, regExp, exactField, regExpField string) *apis.FieldError {
+	switch {
+	case exact == "" && regExp == "":
+		return apis.ErrMissingOneOf(exactField, regExpField)
+	case exact != "" && regExp != "":
+		return apis.ErrMultipleOneOf(exactField, regExpField)
+	case regExp != "":
+		if _, err := regexp.Compile(regExp); err != nil {
+			return apis.ErrInvalidValue(regExp, regExpField, err.Error())
+		}
+	}
+	return nil
+}
+
+// containsPEMCertificate returns whether data contains a PEM-encoded certificate.
+func containsPEMCertificate(data string) bool {
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return false
+		}
+		if block.Type == "CERTIFICATE" {
+			return true
+		}
+	}
+}
+
 // Validate ResourcePattern and make sure the Pattern is valid regex expression
 func (r *ResourcePattern) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if _, err := regexp.Compile(r.Pattern); err != nil {
@@ -99,6 +99,26 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, but got: %s", v1alpha1.ModeEnforce, v1alpha1.ModeWarn, "wrongMode"), "mode"),
+	}, {
+		name: "wrong kind",
+		verificationPolicy: &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "vp",
+			},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Authorities: []v1alpha1.Authority{
+					{
+						Name: "foo",
+						Key: &v1alpha1.KeyRef{
+							Data: "inlinekey",
+						},
+					},
+				},
+				Kind: "wrongKind",
+			},
+		},
+		want: apis.ErrInvalidValue(fmt.Sprintf("available values are: %s, %s, but got: %s", v1alpha1.PolicyKindResources, v1alpha1.PolicyKindImages, "wrongKind"), "kind"),
 	}, {
 		name: "missing Authority key",
 		verificationPolicy: &v1alpha1.VerificationPolicy{
@@ -225,6 +245,73 @@ func TestVerificationPolicy_Invalid(t *testing.T) {
 			},
 		},
 		want: apis.ErrInvalidValue("sha1", "HashAlgorithm").ViaFieldIndex("key", 0),
+	}, {
+		name: "should not have both key and keyless",
+		verificationPolicy: &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "vp",
+			},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Authorities: []v1alpha1.Authority{
+					{
+						Name: "foo",
+						Key: &v1alpha1.KeyRef{
+							KMS: "kms://key/path",
+						},
+						Keyless: validKeyless(),
+					},
+				},
+			},
+		},
+		want: apis.ErrMultipleOneOf("key", "keyless").ViaFieldIndex("authorities", 0),
+	}, {
+		name: "keyless without identities, CA roots and transparency log",
+		verificationPolicy: &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "vp",
+			},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Authorities: []v1alpha1.Authority{
+					{
+						Name:    "foo",
+						Keyless: &v1alpha1.KeylessRef{},
+					},
+				},
+			},
+		},
+		want: apis.ErrMissingField("identities", "caRoots", "transparencyLog", "transparencyLogOrigin").ViaFieldIndex("keyless", 0),
+	}, {
+		name: "keyless with invalid identities and CA roots",
+		verificationPolicy: &v1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "vp",
+			},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources: []v1alpha1.ResourcePattern{{".*"}},
+				Authorities: []v1alpha1.Authority{
+					{
+						Name: "foo",
+						Keyless: &v1alpha1.KeylessRef{
+							Identities: []v1alpha1.Identity{{
+								Issuer:        "https://accounts.google.com",
+								IssuerRegExp:  ".*",
+								SubjectRegExp: "[",
+							}},
+							CARoots: "not a certificate",
+							TransparencyLog: &v1alpha1.KeyRef{
+								KMS: "kms://key/path",
+							},
+							TransparencyLogOrigin: "rekor.sigstore.dev - 2605736670972794746",
+						},
+					},
+				},
+			},
+		},
+		want: apis.ErrMultipleOneOf("issuer", "issuerRegExp").ViaFieldIndex("identities", 0).ViaFieldIndex("keyless", 0).Also(
+			apis.ErrInvalidValue("[", "subjectRegExp", "error parsing regexp: missing closing ]: `[`").ViaFieldIndex("identities", 0).ViaFieldIndex("keyless", 0)).Also(
+			apis.ErrInvalidValue("must contain PEM-encoded certificates", "caRoots").ViaFieldIndex("keyless", 0)),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -337,6 +424,22 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 					Mode: v1alpha1.ModeWarn,
 				},
 			},
+		}, {
+			name: "keyless",
+			verificationPolicy: &v1alpha1.VerificationPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "vp",
+				},
+				Spec: v1alpha1.VerificationPolicySpec{
+					Resources: []v1alpha1.ResourcePattern{{".*"}},
+					Authorities: []v1alpha1.Authority{
+						{
+							Name:    "foo",
+							Keyless: validKeyless(),
+						},
+					},
+				},
+			},
 		}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -347,3 +450,19 @@ func TestVerificationPolicy_Valid(t *testing.T) {
 		})
 	}
 }
+
+// validKeyless returns a valid keyless authority, with a placeholder CA certificate
+// which is only parsed as PEM by the validation.
+func validKeyless() *v1alpha1.KeylessRef {
+	return &v1alpha1.KeylessRef{
+		Identities: []v1alpha1.Identity{{
+			Issuer:        "https://token.actions.githubusercontent.com",
+			SubjectRegExp: `https://github\.com/tektoncd/.*`,
+		}},
+		CARoots: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n",
+		TransparencyLog: &v1alpha1.KeyRef{
+			KMS: "kms://key/path",
+		},
+		TransparencyLogOrigin: "rekor.sigstore.dev - 2605736670972794746",
+	}
+}
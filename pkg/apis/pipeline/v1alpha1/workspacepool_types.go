@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkspacePool is a pool of pre-provisioned PersistentVolumeClaims which the
+// workspaces declared with a volumeClaimTemplate of its StorageClass are allocated
+// from, instead of creating a PersistentVolumeClaim for each run. The claims of the
+// pool are the PersistentVolumeClaims labeled `tekton.dev/workspace-pool: <name>`
+// in the namespaces of the runs.
+// +k8s:openapi-gen=true
+type WorkspacePool struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the WorkspacePool.
+	Spec WorkspacePoolSpec `json:"spec"`
+}
+
+// WorkspacePoolList contains a list of WorkspacePool
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type WorkspacePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspacePool `json:"items"`
+}
+
+// GetGroupVersionKind implements kmeta.OwnerRefable.
+func (*WorkspacePool) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("WorkspacePool")
+}
+
+// WorkspacePoolSpec defines the claims served by a WorkspacePool.
+type WorkspacePoolSpec struct {
+	// StorageClassName is the name of the StorageClass of the volumeClaimTemplates
+	// allocated from the pool. If several pools serve a StorageClass, the first one
+	// by name is used.
+	StorageClassName string `json:"storageClassName"`
+}
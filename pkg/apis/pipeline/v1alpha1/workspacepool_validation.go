@@ -0,0 +1,42 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"knative.dev/pkg/apis"
+)
+
+var _ apis.Validatable = (*WorkspacePool)(nil)
+
+// Validate WorkspacePool, the name of the pool is a DNS label, so that it is
+// also a valid value of the label of the claims of the pool.
+func (p *WorkspacePool) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validate.ObjectMetadata(p.GetObjectMeta()).ViaField("metadata"))
+	return errs.Also(p.Spec.Validate(ctx).ViaField("spec"))
+}
+
+// Validate WorkspacePoolSpec, the validation requires the StorageClass served by
+// the pool.
+func (ps *WorkspacePoolSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if ps.StorageClassName == "" {
+		errs = errs.Also(apis.ErrMissingField("storageClassName"))
+	}
+	return errs
+}
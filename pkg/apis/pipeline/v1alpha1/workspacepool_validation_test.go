@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestWorkspacePool_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		pool *v1alpha1.WorkspacePool
+		want *apis.FieldError
+	}{{
+		name: "missing storage class",
+		pool: &v1alpha1.WorkspacePool{ObjectMeta: metav1.ObjectMeta{Name: "fast-pool"}},
+		want: apis.ErrMissingField("spec.storageClassName"),
+	}, {
+		name: "name not a DNS label",
+		pool: &v1alpha1.WorkspacePool{
+			ObjectMeta: metav1.ObjectMeta{Name: "fast_pool"},
+			Spec:       v1alpha1.WorkspacePoolSpec{StorageClassName: "fast-ssd"},
+		},
+		want: &apis.FieldError{
+			Message: `invalid resource name "fast_pool": must be a valid DNS label`,
+			Paths:   []string{"metadata.name"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pool.Validate(context.Background())
+			if d := cmp.Diff(tt.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestWorkspacePool_Valid(t *testing.T) {
+	p := &v1alpha1.WorkspacePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "fast-pool"},
+		Spec:       v1alpha1.WorkspacePoolSpec{StorageClassName: "fast-ssd"},
+	}
+	if err := p.Validate(context.Background()); err != nil {
+		t.Errorf("Validate() = %v", err)
+	}
+}
@@ -19,24 +19,66 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"knative.dev/pkg/apis"
 )
 
 var _ apis.Convertible = (*ClusterTask)(nil)
 
 // ConvertTo implements apis.Convertible
-func (ct *ClusterTask) ConvertTo(ctx context.Context, sink apis.Convertible) error {
+func (ct *ClusterTask) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest known version, got: %T", sink)
+	switch sink := to.(type) {
+	case *v1.ClusterTask:
+		sink.ObjectMeta = ct.ObjectMeta
+		if namespaces := ct.VisibleNamespaces(); namespaces != nil {
+			sink.Spec.Visibility = &v1.ClusterVisibility{Namespaces: namespaces}
+			sink.Annotations = withoutAnnotation(ct.Annotations, VisibleNamespacesAnnotationKey)
+		}
+		return ct.Spec.ConvertTo(ctx, &sink.Spec.TaskSpec, &sink.ObjectMeta, ct.Name)
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
 }
 
 // ConvertFrom implements apis.Convertible
-func (ct *ClusterTask) ConvertFrom(ctx context.Context, source apis.Convertible) error {
+func (ct *ClusterTask) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest known version, got: %T", source)
+	switch source := from.(type) {
+	case *v1.ClusterTask:
+		ct.ObjectMeta = source.ObjectMeta
+		if source.Spec.Visibility != nil {
+			annotations := map[string]string{}
+			for k, v := range source.Annotations {
+				annotations[k] = v
+			}
+			annotations[VisibleNamespacesAnnotationKey] = strings.Join(source.Spec.Visibility.Namespaces, ",")
+			ct.Annotations = annotations
+		}
+		return ct.Spec.ConvertFrom(ctx, &source.Spec.TaskSpec, &ct.ObjectMeta, ct.Name)
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// withoutAnnotation returns a copy of the annotations without the key, or nil if
+// it was the only one.
+func withoutAnnotation(annotations map[string]string, key string) map[string]string {
+	var copied map[string]string
+	for k, v := range annotations {
+		if k == key {
+			continue
+		}
+		if copied == nil {
+			copied = map[string]string{}
+		}
+		copied[k] = v
+	}
+	return copied
 }
@@ -20,7 +20,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestClusterTaskConversionBadType(t *testing.T) {
@@ -30,7 +34,63 @@ func TestClusterTaskConversionBadType(t *testing.T) {
 		t.Errorf("ConvertTo() = %#v, wanted error", bad)
 	}
 
-	if err := good.ConvertFrom(context.Background(), bad); err == nil {
+	err := good.ConvertFrom(context.Background(), bad)
+	if err == nil {
 		t.Errorf("ConvertFrom() = %#v, wanted error", good)
+	} else if want := "unknown version, got: *v1beta1.Pipeline"; err.Error() != want {
+		t.Errorf("ConvertFrom() = %v, wanted %q", err, want)
+	}
+}
+
+func TestClusterTaskConversion(t *testing.T) {
+	beta1Spec := v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "busybox"}}}
+	v1Spec := v1.TaskSpec{Steps: []v1.Step{{Name: "build", Image: "busybox"}}}
+	for _, tc := range []struct {
+		name   string
+		beta1  *v1beta1.ClusterTask
+		wantV1 *v1.ClusterTask
+	}{{
+		name: "visible in all namespaces",
+		beta1: &v1beta1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{"foo": "bar"}},
+			Spec:       beta1Spec,
+		},
+		wantV1: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{"foo": "bar"}},
+			Spec:       v1.ClusterTaskSpec{TaskSpec: v1Spec},
+		},
+	}, {
+		name: "visible in some namespaces",
+		beta1: &v1beta1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{
+				"foo":                                  "bar",
+				v1beta1.VisibleNamespacesAnnotationKey: "ci,release",
+			}},
+			Spec: beta1Spec,
+		},
+		wantV1: &v1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{"foo": "bar"}},
+			Spec: v1.ClusterTaskSpec{
+				TaskSpec:   v1Spec,
+				Visibility: &v1.ClusterVisibility{Namespaces: []string{"ci", "release"}},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			gotV1 := &v1.ClusterTask{}
+			if err := tc.beta1.ConvertTo(context.Background(), gotV1); err != nil {
+				t.Fatalf("ConvertTo() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantV1, gotV1); d != "" {
+				t.Errorf("ConvertTo() %s", diff.PrintWantGot(d))
+			}
+			gotBeta1 := &v1beta1.ClusterTask{}
+			if err := gotBeta1.ConvertFrom(context.Background(), gotV1); err != nil {
+				t.Fatalf("ConvertFrom() = %v", err)
+			}
+			if d := cmp.Diff(tc.beta1, gotBeta1); d != "" {
+				t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
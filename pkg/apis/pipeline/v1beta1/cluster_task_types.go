@@ -17,12 +17,19 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/kmeta"
 )
 
+// VisibleNamespacesAnnotationKey is the annotation holding the comma-separated list of
+// the namespaces in which a ClusterTask can be referenced. It holds the visibility of
+// the v1 ClusterTasks, and a ClusterTask without it can be referenced in all the namespaces.
+const VisibleNamespacesAnnotationKey = "tekton.dev/visible-namespaces"
+
 // +genclient
 // +genclient:noStatus
 // +genclient:nonNamespaced
@@ -56,6 +63,36 @@ type ClusterTaskList struct {
 	Items           []ClusterTask `json:"items"`
 }
 
+// VisibleNamespaces returns the namespaces in which the ClusterTask can be referenced,
+// or nil if it can be referenced in all the namespaces.
+func (t *ClusterTask) VisibleNamespaces() []string {
+	value, ok := t.Annotations[VisibleNamespacesAnnotationKey]
+	if !ok {
+		return nil
+	}
+	namespaces := []string{}
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	return namespaces
+}
+
+// VisibleIn returns true if the ClusterTask can be referenced in the namespace.
+func (t *ClusterTask) VisibleIn(namespace string) bool {
+	namespaces := t.VisibleNamespaces()
+	if namespaces == nil {
+		return true
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
 // TaskSpec returns the ClusterTask's Spec
 func (t *ClusterTask) TaskSpec() TaskSpec {
 	return t.Spec
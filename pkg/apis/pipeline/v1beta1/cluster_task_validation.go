@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"knative.dev/pkg/apis"
 )
@@ -31,8 +33,50 @@ func (t *ClusterTask) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
+	if namespaces := t.VisibleNamespaces(); namespaces != nil {
+		visibility := &v1.ClusterVisibility{Namespaces: namespaces}
+		// ViaFieldKey would render the path of the nested fields as annotations.[key]
+		errs = errs.Also(visibility.Validate(ctx).ViaField(fmt.Sprintf("annotations[%s]", VisibleNamespacesAnnotationKey)).ViaField("metadata"))
+	}
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// We do not support propagated parameters in ClusterTasks.
 	// Validate that all params the ClusterTask uses are declared.
-	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params))
+	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params))
+	return errs.Also(validate.Warnings(ctx, t))
+}
+
+// validateClusterTaskRef checks that the ClusterTask referenced by a TaskRef of an
+// object being created in the namespace is visible in it.
+func validateClusterTaskRef(ctx context.Context, namespace string, ref *TaskRef) *apis.FieldError {
+	if !apis.IsInCreate(ctx) || ref == nil || ref.Kind != ClusterTaskKind || ref.Name == "" || ref.Resolver != "" || ref.Bundle != "" {
+		return nil
+	}
+	if err := validate.ClusterRefVisible(ctx, namespace, string(ClusterTaskKind), ref.Name); err != nil {
+		return apis.ErrGeneric(err.Error(), "name")
+	}
+	return nil
+}
+
+// validateClusterTaskRefs checks that the ClusterTasks referenced by the tasks and
+// the finally tasks of a Pipeline being created in the namespace are visible in it.
+func (ps *PipelineSpec) validateClusterTaskRefs(ctx context.Context, namespace string) (errs *apis.FieldError) {
+	for i, pt := range ps.Tasks {
+		errs = errs.Also(validateClusterTaskRef(ctx, namespace, pt.TaskRef).ViaField("taskRef").ViaFieldIndex("tasks", i))
+	}
+	for i, pt := range ps.Finally {
+		errs = errs.Also(validateClusterTaskRef(ctx, namespace, pt.TaskRef).ViaField("taskRef").ViaFieldIndex("finally", i))
+	}
+	return errs
+}
+
+// validateClusterPipelineRef checks that the ClusterPipeline referenced by a
+// PipelineRef of a PipelineRun being created in the namespace is visible in it.
+func validateClusterPipelineRef(ctx context.Context, namespace string, ref *PipelineRef) *apis.FieldError {
+	if !apis.IsInCreate(ctx) || ref == nil || ref.Kind != ClusterPipelineKind || ref.Name == "" || ref.Resolver != "" {
+		return nil
+	}
+	if err := validate.ClusterRefVisible(ctx, namespace, string(ClusterPipelineKind), ref.Name); err != nil {
+		return apis.ErrGeneric(err.Error(), "name")
+	}
+	return nil
 }
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestClusterTask_VisibleNamespaces(t *testing.T) {
+	for _, tc := range []struct {
+		annotations map[string]string
+		want        []string
+	}{
+		{nil, nil},
+		{map[string]string{v1beta1.VisibleNamespacesAnnotationKey: ""}, []string{}},
+		{map[string]string{v1beta1.VisibleNamespacesAnnotationKey: "ci, release ,"}, []string{"ci", "release"}},
+	} {
+		ct := &v1beta1.ClusterTask{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+		if d := cmp.Diff(tc.want, ct.VisibleNamespaces()); d != "" {
+			t.Errorf("VisibleNamespaces() of %v %s", tc.annotations, diff.PrintWantGot(d))
+		}
+		if got, want := ct.VisibleIn("ci"), tc.want == nil || len(tc.want) > 0; got != want {
+			t.Errorf("VisibleIn() of %v = %t, want %t", tc.annotations, got, want)
+		}
+	}
+}
+
+func TestClusterTask_ValidateVisibility(t *testing.T) {
+	spec := v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "busybox"}}}
+	for _, tc := range []struct {
+		name       string
+		visibility string
+		want       *apis.FieldError
+	}{{
+		name:       "valid namespaces",
+		visibility: "ci,release",
+	}, {
+		name:       "no namespaces",
+		visibility: " ",
+		want:       apis.ErrMissingField("metadata.annotations[tekton.dev/visible-namespaces].namespaces"),
+	}, {
+		name:       "duplicate namespace",
+		visibility: "ci,ci",
+		want:       apis.ErrInvalidArrayValue(`namespace "ci" is listed more than once`, "metadata.annotations[tekton.dev/visible-namespaces].namespaces", 1),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ct := &v1beta1.ClusterTask{
+				ObjectMeta: metav1.ObjectMeta{Name: "build", Annotations: map[string]string{
+					v1beta1.VisibleNamespacesAnnotationKey: tc.visibility,
+				}},
+				Spec: spec,
+			}
+			err := ct.Validate(context.Background())
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestTaskRun_ClusterTaskVisibility(t *testing.T) {
+	visibility := func(ctx context.Context, namespace, kind, name string) error {
+		return fmt.Errorf("%s %q is not visible in namespace %q", kind, name, namespace)
+	}
+	ctx := apis.WithinCreate(validate.WithClusterVisibility(context.Background(), visibility))
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "ns"},
+		Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "internal", Kind: v1beta1.ClusterTaskKind}},
+	}
+	want := apis.ErrGeneric(`ClusterTask "internal" is not visible in namespace "ns"`, "spec.taskRef.name")
+	if d := cmp.Diff(want.Error(), tr.Validate(ctx).Error()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
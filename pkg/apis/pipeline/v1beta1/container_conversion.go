@@ -47,6 +47,13 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 	sink.OnError = (v1.OnErrorType)(s.OnError)
 	sink.StdoutConfig = (*v1.StepOutputConfig)(s.StdoutConfig)
 	sink.StderrConfig = (*v1.StepOutputConfig)(s.StderrConfig)
+	sink.Interpolation = (v1.InterpolationType)(s.Interpolation)
+	sink.Scratch = nil
+	for _, sv := range s.Scratch {
+		sink.Scratch = append(sink.Scratch, v1.StepScratchVolume(sv))
+	}
+	sink.MemoryProfile = (v1.MemoryProfileType)(s.MemoryProfile)
+	sink.Sandbox = (*v1.StepSandbox)(s.Sandbox)
 }
 
 func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
@@ -74,6 +81,13 @@ func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
 	s.OnError = (OnErrorType)(source.OnError)
 	s.StdoutConfig = (*StepOutputConfig)(source.StdoutConfig)
 	s.StderrConfig = (*StepOutputConfig)(source.StderrConfig)
+	s.Interpolation = (InterpolationType)(source.Interpolation)
+	s.Scratch = nil
+	for _, sv := range source.Scratch {
+		s.Scratch = append(s.Scratch, StepScratchVolume(sv))
+	}
+	s.MemoryProfile = (MemoryProfileType)(source.MemoryProfile)
+	s.Sandbox = (*StepSandbox)(source.Sandbox)
 }
 
 func (s StepTemplate) convertTo(ctx context.Context, sink *v1.StepTemplate) {
@@ -137,6 +151,18 @@ func (s Sidecar) convertTo(ctx context.Context, sink *v1.Sidecar) {
 		w.convertTo(ctx, &new)
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
+	sink.Results = nil
+	for _, r := range s.Results {
+		sink.Results = append(sink.Results, v1.SidecarResult{Name: r.Name})
+	}
+	sink.Shutdown = nil
+	if s.Shutdown != nil {
+		sink.Shutdown = &v1.SidecarShutdown{
+			PreStop:     s.Shutdown.PreStop,
+			StopSignal:  s.Shutdown.StopSignal,
+			GracePeriod: s.Shutdown.GracePeriod,
+		}
+	}
 }
 
 func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
@@ -169,4 +195,16 @@ func (s *Sidecar) convertFrom(ctx context.Context, source v1.Sidecar) {
 		new.convertFrom(ctx, w)
 		s.Workspaces = append(s.Workspaces, new)
 	}
+	s.Results = nil
+	for _, r := range source.Results {
+		s.Results = append(s.Results, SidecarResult{Name: r.Name})
+	}
+	s.Shutdown = nil
+	if source.Shutdown != nil {
+		s.Shutdown = &SidecarShutdown{
+			PreStop:     source.Shutdown.PreStop,
+			StopSignal:  source.Shutdown.StopSignal,
+			GracePeriod: source.Shutdown.GracePeriod,
+		}
+	}
 }
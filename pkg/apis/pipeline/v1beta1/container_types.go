@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -228,6 +229,56 @@ type Step struct {
 	// Stores configuration for the stderr stream of the step.
 	// +optional
 	StderrConfig *StepOutputConfig `json:"stderrConfig,omitempty"`
+	// Interpolation defines how the variables referenced in the Script are
+	// interpolated, can be set to [ literal | shellSafe ]. With shellSafe, the
+	// values are passed in environment variables referenced by the Script
+	// instead of being substituted in its text.
+	// +optional
+	Interpolation InterpolationType `json:"interpolation,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Scratch is a list of size-limited emptyDir volumes mounted only in this Step.
+	// Their sizes are added to the resource requests of the Step.
+	// +optional
+	// +listType=atomic
+	Scratch []StepScratchVolume `json:"scratch,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// MemoryProfile tunes the memory resources of the Step for its workload, can be
+	// set to [ buildHeavy ]. With buildHeavy, the memory requests of the Step are
+	// raised to its memory limits, so that the memory it may use is reserved for it
+	// and it is among the last containers killed when its node runs out of memory.
+	// +optional
+	MemoryProfile MemoryProfileType `json:"memoryProfile,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Sandbox declares the security profiles the Step runs with, so that the Steps
+	// of a Task needing more privileges can be confined differently from the others.
+	// The profiles override the equivalent fields of the SecurityContext of the Step
+	// and of the PodSecurityContext.
+	// +optional
+	Sandbox *StepSandbox `json:"sandbox,omitempty"`
+}
+
+// StepScratchVolume is a size-limited emptyDir volume mounted only in a Step.
+type StepScratchVolume struct {
+	// Name of the scratch volume, unique within the Step.
+	Name string `json:"name"`
+	// MountPath is the path at which the scratch volume is mounted in the Step.
+	MountPath string `json:"mountPath"`
+	// Size is the maximum size of the scratch volume.
+	Size resource.Quantity `json:"size"`
+	// Medium is the storage medium backing the scratch volume. It defaults to the
+	// storage of the node, and can be set to "Memory" to use a tmpfs, whose size
+	// counts against the memory of the Step.
+	// +optional
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
 }
 
 // OnErrorType defines a list of supported exiting behavior of a container on error
@@ -240,6 +291,55 @@ const (
 	Continue OnErrorType = "continue"
 )
 
+// InterpolationType defines a list of supported interpolations of the variables referenced in a script
+type InterpolationType string
+
+const (
+	// LiteralInterpolation indicates the values of the variables are substituted in the text of the script
+	LiteralInterpolation InterpolationType = "literal"
+	// ShellSafeInterpolation indicates the values of the variables are passed in environment variables
+	// referenced by the script, so that they are never parsed by the shell
+	ShellSafeInterpolation InterpolationType = "shellSafe"
+)
+
+// MemoryProfileType defines a list of supported profiles tuning the memory resources of a step
+type MemoryProfileType string
+
+const (
+	// BuildHeavyMemoryProfile indicates the step builds or compiles code, and needs the memory
+	// it may use to be reserved for it
+	BuildHeavyMemoryProfile MemoryProfileType = "buildHeavy"
+)
+
+// StepSandbox declares the security profiles a Step runs with.
+type StepSandbox struct {
+	// Seccomp is the seccomp profile the Step runs with.
+	// +optional
+	Seccomp *corev1.SeccompProfile `json:"seccomp,omitempty"`
+	// AppArmor is the AppArmor profile the Step runs with, can be set to
+	// [ runtime/default | unconfined | localhost/<profile> ].
+	// +optional
+	AppArmor string `json:"appArmor,omitempty"`
+	// SELinux is the SELinux context the Step runs with.
+	// +optional
+	SELinux *corev1.SELinuxOptions `json:"seLinux,omitempty"`
+	// UserNamespace runs the Step in a user namespace, mapping its root user to an
+	// unprivileged user of the node. Kubernetes creates a single user namespace for
+	// all the containers of a pod, so the pod of the TaskRun runs in a user namespace
+	// as soon as one of its Steps requests it.
+	// +optional
+	UserNamespace bool `json:"userNamespace,omitempty"`
+}
+
+const (
+	// AppArmorRuntimeDefault is the AppArmor profile of the container runtime
+	AppArmorRuntimeDefault = "runtime/default"
+	// AppArmorUnconfined runs a Step without AppArmor profile
+	AppArmorUnconfined = "unconfined"
+	// AppArmorLocalhostPrefix prefixes the AppArmor profiles loaded on the node
+	AppArmorLocalhostPrefix = "localhost/"
+)
+
 // StepOutputConfig stores configuration for a step output stream.
 type StepOutputConfig struct {
 	// Path to duplicate stdout stream to on container's local filesystem.
@@ -716,6 +816,41 @@ type Sidecar struct {
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspaceUsage `json:"workspaces,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Results is a list of the results of the Task that this Sidecar writes.
+	// They are collected once the Steps have completed and the Sidecar has
+	// stopped gracefully.
+	// +optional
+	// +listType=atomic
+	Results []SidecarResult `json:"results,omitempty"`
+
+	// This is an alpha field. You must set the "enable-api-fields" feature flag to "alpha"
+	// for this field to be supported.
+	//
+	// Shutdown configures how the Sidecar is stopped once the Steps have completed.
+	// Without it, the Sidecar is stopped by replacing its image with the nop image.
+	// +optional
+	Shutdown *SidecarShutdown `json:"shutdown,omitempty"`
+}
+
+// SidecarShutdown configures the graceful shutdown of a Sidecar, letting it
+// flush its state before the TaskRun completes.
+type SidecarShutdown struct {
+	// PreStop is a command run in the Sidecar before it is sent the StopSignal.
+	// +optional
+	// +listType=atomic
+	PreStop []string `json:"preStop,omitempty"`
+	// StopSignal is the name of the signal sent to the Sidecar to stop it.
+	// Defaults to "SIGTERM".
+	// +optional
+	StopSignal string `json:"stopSignal,omitempty"`
+	// GracePeriod is the time the Sidecar has to exit once it is stopped,
+	// including the PreStop command, before it is killed. Defaults to 30s.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
 }
 
 // ToK8sContainer converts the Sidecar to a Kubernetes Container struct
@@ -21,7 +21,10 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"golang.org/x/exp/maps"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/apis"
 )
@@ -39,6 +42,29 @@ type Matrix struct {
 	// +optional
 	// +listType=atomic
 	Include IncludeParamsList `json:"include,omitempty"`
+
+	// Spread spreads the TaskRuns of the combinations across failure domains, such as
+	// zones or nodes, so that the outage of a domain doesn't take out all of them.
+	// Each MatrixSpread is converted into a topology spread constraint of the pods of the TaskRuns.
+	// +optional
+	// +listType=atomic
+	Spread []MatrixSpread `json:"spread,omitempty"`
+}
+
+// MatrixSpread spreads the TaskRuns of the combinations of a Matrix across the
+// domains of a topology.
+type MatrixSpread struct {
+	// TopologyKey is the key of the node labels whose values are the domains, e.g.
+	// "topology.kubernetes.io/zone" or "kubernetes.io/hostname".
+	TopologyKey string `json:"topologyKey"`
+	// MaxSkew is the maximum difference between the numbers of TaskRuns of two domains.
+	// Defaults to 1.
+	// +optional
+	MaxSkew int32 `json:"maxSkew,omitempty"`
+	// WhenUnsatisfiable is how a TaskRun is scheduled when it can't satisfy the spread:
+	// "ScheduleAnyway", the default, or "DoNotSchedule".
+	// +optional
+	WhenUnsatisfiable corev1.UnsatisfiableConstraintAction `json:"whenUnsatisfiable,omitempty"`
 }
 
 // IncludeParamsList is a list of IncludeParams which allows passing in specific combinations of Parameters into the Matrix.
@@ -301,6 +327,66 @@ func (m *Matrix) validateCombinationsCount(ctx context.Context) (errs *apis.Fiel
 	return errs
 }
 
+// HasSpread returns true if the Matrix spreads its TaskRuns across failure domains
+func (m *Matrix) HasSpread() bool {
+	return m != nil && len(m.Spread) > 0
+}
+
+// TopologySpreadConstraints returns the topology spread constraints of the pods of the
+// TaskRuns of the combinations, which are selected by the labels.
+func (m *Matrix) TopologySpreadConstraints(labels map[string]string) []corev1.TopologySpreadConstraint {
+	if !m.HasSpread() {
+		return nil
+	}
+	constraints := make([]corev1.TopologySpreadConstraint, 0, len(m.Spread))
+	for _, s := range m.Spread {
+		c := corev1.TopologySpreadConstraint{
+			TopologyKey:       s.TopologyKey,
+			MaxSkew:           s.MaxSkew,
+			WhenUnsatisfiable: s.WhenUnsatisfiable,
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+		}
+		if c.MaxSkew == 0 {
+			c.MaxSkew = 1
+		}
+		if c.WhenUnsatisfiable == "" {
+			c.WhenUnsatisfiable = corev1.ScheduleAnyway
+		}
+		constraints = append(constraints, c)
+	}
+	return constraints
+}
+
+// validateSpread validates the topology keys, the skews and the actions of Matrix.Spread
+func (m *Matrix) validateSpread() (errs *apis.FieldError) {
+	if !m.HasSpread() {
+		return nil
+	}
+	if !m.HasParams() && !m.HasInclude() {
+		return apis.ErrGeneric("spread requires params or include", "matrix.spread")
+	}
+	seen := sets.NewString()
+	for i, s := range m.Spread {
+		switch {
+		case s.TopologyKey == "":
+			errs = errs.Also(apis.ErrMissingField("topologyKey").ViaFieldIndex("matrix.spread", i))
+		case len(validation.IsQualifiedName(s.TopologyKey)) > 0:
+			errs = errs.Also(apis.ErrInvalidValue(s.TopologyKey, "topologyKey", strings.Join(validation.IsQualifiedName(s.TopologyKey), ", ")).ViaFieldIndex("matrix.spread", i))
+		case seen.Has(s.TopologyKey):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("topologyKey %q must be unique", s.TopologyKey), "topologyKey").ViaFieldIndex("matrix.spread", i))
+		}
+		seen.Insert(s.TopologyKey)
+		if s.MaxSkew < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(s.MaxSkew, "maxSkew", "maxSkew must be positive").ViaFieldIndex("matrix.spread", i))
+		}
+		if s.WhenUnsatisfiable != "" && s.WhenUnsatisfiable != corev1.ScheduleAnyway && s.WhenUnsatisfiable != corev1.DoNotSchedule {
+			errs = errs.Also(apis.ErrInvalidValue(s.WhenUnsatisfiable, "whenUnsatisfiable",
+				fmt.Sprintf("whenUnsatisfiable must be %q or %q", corev1.ScheduleAnyway, corev1.DoNotSchedule)).ViaFieldIndex("matrix.spread", i))
+		}
+	}
+	return errs
+}
+
 // validateUniqueParams validates Matrix.Params for a unique list of params
 // and a unique list of params in each Matrix.Include.Params specification
 func (m *Matrix) validateUniqueParams() (errs *apis.FieldError) {
@@ -19,6 +19,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMatrix_FanOut(t *testing.T) {
@@ -924,3 +926,36 @@ func TestPipelineTask_CountCombinations(t *testing.T) {
 		})
 	}
 }
+
+func TestMatrix_TopologySpreadConstraints(t *testing.T) {
+	labels := map[string]string{"tekton.dev/pipelineRun": "pr", "tekton.dev/pipelineTask": "test"}
+	matrix := &v1beta1.Matrix{
+		Params: v1beta1.Params{{
+			Name: "platform", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+		}},
+		Spread: []v1beta1.MatrixSpread{{
+			TopologyKey: "topology.kubernetes.io/zone",
+		}, {
+			TopologyKey: "kubernetes.io/hostname", MaxSkew: 2, WhenUnsatisfiable: corev1.DoNotSchedule,
+		}},
+	}
+	want := []corev1.TopologySpreadConstraint{{
+		TopologyKey:       "topology.kubernetes.io/zone",
+		MaxSkew:           1,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+	}, {
+		TopologyKey:       "kubernetes.io/hostname",
+		MaxSkew:           2,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     &metav1.LabelSelector{MatchLabels: labels},
+	}}
+	if d := cmp.Diff(want, matrix.TopologySpreadConstraints(labels)); d != "" {
+		t.Errorf("TopologySpreadConstraints() %s", diff.PrintWantGot(d))
+	}
+
+	var noSpread *v1beta1.Matrix
+	if got := noSpread.TopologySpreadConstraints(labels); got != nil {
+		t.Errorf("expected no constraints without spread, got %v", got)
+	}
+}
@@ -44,6 +44,10 @@ func (p ParamSpec) convertTo(ctx context.Context, sink *v1.ParamSpec) {
 			ArrayVal: p.Default.ArrayVal, ObjectVal: p.Default.ObjectVal,
 		}
 	}
+	sink.Enum = p.Enum
+	sink.Pattern = p.Pattern
+	sink.Minimum = p.Minimum
+	sink.Maximum = p.Maximum
 }
 
 func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
@@ -68,6 +72,10 @@ func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
 			ArrayVal: source.Default.ArrayVal, ObjectVal: source.Default.ObjectVal,
 		}
 	}
+	p.Enum = source.Enum
+	p.Pattern = source.Pattern
+	p.Minimum = source.Minimum
+	p.Maximum = source.Maximum
 }
 
 func (p Param) convertTo(ctx context.Context, sink *v1.Param) {
@@ -75,6 +83,12 @@ func (p Param) convertTo(ctx context.Context, sink *v1.Param) {
 	newValue := v1.ParamValue{}
 	p.Value.convertTo(ctx, &newValue)
 	sink.Value = newValue
+	if p.ValueFrom != nil {
+		sink.ValueFrom = &v1.ParamValueSource{
+			ConfigMapKeyRef: p.ValueFrom.ConfigMapKeyRef,
+			SecretKeyRef:    p.ValueFrom.SecretKeyRef,
+		}
+	}
 }
 
 func (p *Param) convertFrom(ctx context.Context, source v1.Param) {
@@ -82,6 +96,12 @@ func (p *Param) convertFrom(ctx context.Context, source v1.Param) {
 	newValue := ParamValue{}
 	newValue.convertFrom(ctx, source.Value)
 	p.Value = newValue
+	if source.ValueFrom != nil {
+		p.ValueFrom = &ParamValueSource{
+			ConfigMapKeyRef: source.ValueFrom.ConfigMapKeyRef,
+			SecretKeyRef:    source.ValueFrom.SecretKeyRef,
+		}
+	}
 }
 
 func (v ParamValue) convertTo(ctx context.Context, sink *v1.ParamValue) {
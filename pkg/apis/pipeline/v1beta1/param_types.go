@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -38,7 +40,7 @@ type ParamSpec struct {
 	// Name declares the name by which a parameter is referenced.
 	Name string `json:"name"`
 	// Type is the user-specified type of the parameter. The possible types
-	// are currently "string", "array" and "object", and "string" is the default.
+	// are currently "string", "array", "object" and "secret", and "string" is the default.
 	// +optional
 	Type ParamType `json:"type,omitempty"`
 	// Description is a user-facing description of the parameter that may be
@@ -53,6 +55,23 @@ type ParamSpec struct {
 	// parameter.
 	// +optional
 	Default *ParamValue `json:"default,omitempty"`
+	// Enum declares the values a string parameter, or the items of an array
+	// parameter, are allowed to take.
+	// +optional
+	// +listType=atomic
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is the regular expression a string parameter, or the items of an
+	// array parameter, must match.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+	// Minimum is the minimum of the number held by a string parameter, or by
+	// the items of an array parameter.
+	// +optional
+	Minimum *float64 `json:"minimum,omitempty"`
+	// Maximum is the maximum of the number held by a string parameter, or by
+	// the items of an array parameter.
+	// +optional
+	Maximum *float64 `json:"maximum,omitempty"`
 }
 
 // ParamSpecs is a list of ParamSpec
@@ -139,6 +158,79 @@ func (ps ParamSpecs) validateNoDuplicateNames() *apis.FieldError {
 	return errs
 }
 
+// ValidateValues returns an error if the values of the params don't satisfy the enum, the
+// pattern, the minimum or the maximum of the declared params of the same names.
+func (ps ParamSpecs) ValidateValues(params Params) error {
+	var msgs []string
+	for _, p := range params {
+		for i := range ps {
+			if ps[i].Name != p.Name {
+				continue
+			}
+			if err := ps[i].ValidateValue(p.Value); err != nil {
+				msgs = append(msgs, err.Error())
+			}
+		}
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("%s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// ValidateValue returns an error if a value of the parameter isn't one of the values of its
+// enum, doesn't match its pattern or isn't a number between its minimum and its maximum. The
+// items of an array value are validated one by one, and the values referencing variables are
+// only validated once the variables are substituted.
+func (pp *ParamSpec) ValidateValue(value ParamValue) error {
+	var values []string
+	switch value.Type {
+	case ParamTypeString:
+		values = []string{value.StringVal}
+	case ParamTypeArray:
+		values = value.ArrayVal
+	}
+	for _, v := range values {
+		if VariableSubstitutionRegex.MatchString(v) {
+			continue
+		}
+		if err := pp.validateItem(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateItem validates a string value, or an item of an array value, of the parameter.
+func (pp *ParamSpec) validateItem(value string) error {
+	if len(pp.Enum) > 0 && !sets.NewString(pp.Enum...).Has(value) {
+		return fmt.Errorf("param %q value %q is not one of the allowed values %v", pp.Name, value, pp.Enum)
+	}
+	if pp.Pattern != "" {
+		re, err := regexp.Compile(pp.Pattern)
+		if err != nil {
+			return fmt.Errorf("param %q has an invalid pattern %q: %w", pp.Name, pp.Pattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("param %q value %q doesn't match the pattern %q", pp.Name, value, pp.Pattern)
+		}
+	}
+	if pp.Minimum == nil && pp.Maximum == nil {
+		return nil
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("param %q value %q is not a number", pp.Name, value)
+	}
+	if pp.Minimum != nil && n < *pp.Minimum {
+		return fmt.Errorf("param %q value %q is less than the minimum %s", pp.Name, value, strconv.FormatFloat(*pp.Minimum, 'g', -1, 64))
+	}
+	if pp.Maximum != nil && n > *pp.Maximum {
+		return fmt.Errorf("param %q value %q is greater than the maximum %s", pp.Name, value, strconv.FormatFloat(*pp.Maximum, 'g', -1, 64))
+	}
+	return nil
+}
+
 // setDefaultsForProperties sets default type for PropertySpec (string) if it's not specified
 func (pp *ParamSpec) setDefaultsForProperties() {
 	for key, propertySpec := range pp.Properties {
@@ -152,6 +244,32 @@ func (pp *ParamSpec) setDefaultsForProperties() {
 type Param struct {
 	Name  string     `json:"name"`
 	Value ParamValue `json:"value"`
+	// ValueFrom is the source of the value of the param, a key of a ConfigMap or of a
+	// Secret in the namespace of the run, which is resolved by the reconciler instead of
+	// the value.
+	// +optional
+	ValueFrom *ParamValueSource `json:"valueFrom,omitempty"`
+}
+
+// ParamValueSource selects the key of a ConfigMap or of a Secret the value of a param
+// is taken from. Exactly one of its fields must be set.
+type ParamValueSource struct {
+	// ConfigMapKeyRef selects a key of a ConfigMap, whose value is resolved when the run starts.
+	// +optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// SecretKeyRef selects a key of a Secret, passed to the steps as the value of a secret param.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// setValueFromDefaults sets the type of the values of the params taking their values from
+// a ConfigMap or a Secret, which are strings.
+func (ps Params) setValueFromDefaults() {
+	for i := range ps {
+		if ps[i].ValueFrom != nil && ps[i].Value.Type == "" {
+			ps[i].Value.Type = ParamTypeString
+		}
+	}
 }
 
 // Params is a list of Param
@@ -413,10 +531,22 @@ const (
 	ParamTypeString ParamType = "string"
 	ParamTypeArray  ParamType = "array"
 	ParamTypeObject ParamType = "object"
+	// ParamTypeSecret is the type of the params whose values are taken from a key of a
+	// Secret, referenced by an object value with the keys "name" and "key".
+	ParamTypeSecret ParamType = "secret"
 )
 
 // AllParamTypes can be used for ParamType validation.
-var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject}
+var AllParamTypes = []ParamType{ParamTypeString, ParamTypeArray, ParamTypeObject, ParamTypeSecret}
+
+// ValueType returns the type of the values of the params of the type, the values of the
+// secret params being the objects referencing the keys of the Secrets.
+func (t ParamType) ValueType() ParamType {
+	if t == ParamTypeSecret {
+		return ParamTypeObject
+	}
+	return t
+}
 
 // ParamValues is modeled after IntOrString in kubernetes/apimachinery:
 
@@ -486,9 +616,15 @@ func (paramValues ParamValue) MarshalJSON() ([]byte, error) {
 		return json.Marshal(paramValues.ArrayVal)
 	case ParamTypeObject:
 		return json.Marshal(paramValues.ObjectVal)
-	default:
-		return []byte{}, fmt.Errorf("impossible ParamValues.Type: %q", paramValues.Type)
+	case "":
+		// The value of a param missing from a PipelineTask has no type, it is
+		// marshalled as null, which a server-side apply omits, so that the
+		// status of the run embedding it can still be applied.
+		if paramValues.StringVal == "" && paramValues.ArrayVal == nil && paramValues.ObjectVal == nil {
+			return []byte("null"), nil
+		}
 	}
+	return []byte{}, fmt.Errorf("impossible ParamValues.Type: %q", paramValues.Type)
 }
 
 // ApplyReplacements applyes replacements for ParamValues type
@@ -61,6 +61,7 @@ func (ps *PipelineSpec) ConvertTo(ctx context.Context, sink *v1.PipelineSpec, me
 		p.convertTo(ctx, &new)
 		sink.Params = append(sink.Params, new)
 	}
+	sink.Vars = ps.Vars
 	sink.Workspaces = nil
 	for _, w := range ps.Workspaces {
 		new := v1.PipelineWorkspaceDeclaration{}
@@ -82,6 +83,9 @@ func (ps *PipelineSpec) ConvertTo(ctx context.Context, sink *v1.PipelineSpec, me
 		}
 		sink.Finally = append(sink.Finally, new)
 	}
+	sink.TaskRunTemplate = (*v1.PipelineTaskRunTemplate)(ps.TaskRunTemplate)
+	sink.SuccessfulRunsHistoryLimit = ps.SuccessfulRunsHistoryLimit
+	sink.FailedRunsHistoryLimit = ps.FailedRunsHistoryLimit
 	return nil
 }
 
@@ -115,6 +119,7 @@ func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec
 		new.convertFrom(ctx, p)
 		ps.Params = append(ps.Params, new)
 	}
+	ps.Vars = source.Vars
 	ps.Workspaces = nil
 	for _, w := range source.Workspaces {
 		new := PipelineWorkspaceDeclaration{}
@@ -136,6 +141,9 @@ func (ps *PipelineSpec) ConvertFrom(ctx context.Context, source *v1.PipelineSpec
 		}
 		ps.Finally = append(ps.Finally, new)
 	}
+	ps.TaskRunTemplate = (*PipelineTaskRunTemplate)(source.TaskRunTemplate)
+	ps.SuccessfulRunsHistoryLimit = source.SuccessfulRunsHistoryLimit
+	ps.FailedRunsHistoryLimit = source.FailedRunsHistoryLimit
 	return nil
 }
 
@@ -154,6 +162,10 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 			return err
 		}
 	}
+	if pt.PipelineRef != nil {
+		sink.PipelineRef = &v1.PipelineRef{}
+		pt.PipelineRef.convertTo(ctx, sink.PipelineRef)
+	}
 	sink.When = nil
 	for _, we := range pt.WhenExpressions {
 		new := v1.WhenExpression{}
@@ -161,6 +173,12 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 		sink.When = append(sink.When, new)
 	}
 	sink.Retries = pt.Retries
+	sink.RetriesFrom = pt.RetriesFrom
+	sink.RepeatUntil = nil
+	if pt.RepeatUntil != nil {
+		sink.RepeatUntil = &v1.RepeatUntil{}
+		pt.RepeatUntil.convertTo(ctx, sink.RepeatUntil)
+	}
 	sink.RunAfter = pt.RunAfter
 	sink.Params = nil
 	for _, p := range pt.Params {
@@ -174,14 +192,26 @@ func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask, met
 		pt.Matrix.convertTo(ctx, &new)
 		sink.Matrix = &new
 	}
+	sink.WithItems = pt.WithItems
+	sink.WithParam = pt.WithParam
+	sink.TasksFromResult = pt.TasksFromResult
+	sink.OnError = v1.PipelineTaskOnErrorType(pt.OnError)
+	sink.SkipPolicy = v1.PipelineTaskSkipPolicy(pt.SkipPolicy)
 	sink.Workspaces = nil
 	for _, w := range pt.Workspaces {
 		new := v1.WorkspacePipelineTaskBinding{}
 		w.convertTo(ctx, &new)
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
+	sink.ResultFiles = nil
+	for _, rf := range pt.ResultFiles {
+		new := v1.ResultFile{}
+		rf.convertTo(ctx, &new)
+		sink.ResultFiles = append(sink.ResultFiles, new)
+	}
 
 	sink.Timeout = pt.Timeout
+	sink.TimeoutFrom = pt.TimeoutFrom
 	return nil
 }
 
@@ -202,6 +232,11 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 			return err
 		}
 	}
+	if source.PipelineRef != nil {
+		newPipelineRef := PipelineRef{}
+		newPipelineRef.convertFrom(ctx, *source.PipelineRef)
+		pt.PipelineRef = &newPipelineRef
+	}
 	pt.WhenExpressions = nil
 	for _, we := range source.When {
 		new := WhenExpression{}
@@ -209,6 +244,12 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 		pt.WhenExpressions = append(pt.WhenExpressions, new)
 	}
 	pt.Retries = source.Retries
+	pt.RetriesFrom = source.RetriesFrom
+	pt.RepeatUntil = nil
+	if source.RepeatUntil != nil {
+		pt.RepeatUntil = &RepeatUntil{}
+		pt.RepeatUntil.convertFrom(ctx, *source.RepeatUntil)
+	}
 	pt.RunAfter = source.RunAfter
 	pt.Params = nil
 	for _, p := range source.Params {
@@ -222,14 +263,26 @@ func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask,
 		new.convertFrom(ctx, *source.Matrix)
 		pt.Matrix = &new
 	}
+	pt.WithItems = source.WithItems
+	pt.WithParam = source.WithParam
+	pt.TasksFromResult = source.TasksFromResult
+	pt.OnError = PipelineTaskOnErrorType(source.OnError)
+	pt.SkipPolicy = PipelineTaskSkipPolicy(source.SkipPolicy)
 	pt.Workspaces = nil
 	for _, w := range source.Workspaces {
 		new := WorkspacePipelineTaskBinding{}
 		new.convertFrom(ctx, w)
 		pt.Workspaces = append(pt.Workspaces, new)
 	}
+	pt.ResultFiles = nil
+	for _, rf := range source.ResultFiles {
+		new := ResultFile{}
+		new.convertFrom(ctx, rf)
+		pt.ResultFiles = append(pt.ResultFiles, new)
+	}
 
 	pt.Timeout = source.Timeout
+	pt.TimeoutFrom = source.TimeoutFrom
 	return nil
 }
 
@@ -253,12 +306,34 @@ func (we WhenExpression) convertTo(ctx context.Context, sink *v1.WhenExpression)
 	sink.Input = we.Input
 	sink.Operator = we.Operator
 	sink.Values = we.Values
+	sink.CEL = we.CEL
 }
 
 func (we *WhenExpression) convertFrom(ctx context.Context, source v1.WhenExpression) {
 	we.Input = source.Input
 	we.Operator = source.Operator
 	we.Values = source.Values
+	we.CEL = source.CEL
+}
+
+func (r *RepeatUntil) convertTo(ctx context.Context, sink *v1.RepeatUntil) {
+	for _, we := range r.When {
+		new := v1.WhenExpression{}
+		we.convertTo(ctx, &new)
+		sink.When = append(sink.When, new)
+	}
+	sink.Delay = r.Delay
+	sink.Limit = r.Limit
+}
+
+func (r *RepeatUntil) convertFrom(ctx context.Context, source v1.RepeatUntil) {
+	for _, we := range source.When {
+		new := WhenExpression{}
+		new.convertFrom(ctx, we)
+		r.When = append(r.When, new)
+	}
+	r.Delay = source.Delay
+	r.Limit = source.Limit
 }
 
 func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
@@ -275,6 +350,9 @@ func (m *Matrix) convertTo(ctx context.Context, sink *v1.Matrix) {
 			sink.Include[i].Params = append(sink.Include[i].Params, newIncludeParam)
 		}
 	}
+	for _, spread := range m.Spread {
+		sink.Spread = append(sink.Spread, v1.MatrixSpread(spread))
+	}
 }
 
 func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
@@ -292,6 +370,9 @@ func (m *Matrix) convertFrom(ctx context.Context, source v1.Matrix) {
 			m.Include[i].Params = append(m.Include[i].Params, new)
 		}
 	}
+	for _, spread := range source.Spread {
+		m.Spread = append(m.Spread, MatrixSpread(spread))
+	}
 }
 
 func (pr PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult) {
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
@@ -45,6 +46,7 @@ func TestPipelineConversionBadType(t *testing.T) {
 }
 
 func TestPipelineConversion(t *testing.T) {
+	successfulRunsHistoryLimit, failedRunsHistoryLimit := int32(3), int32(10)
 	for _, test := range []struct {
 		name string
 		in   *v1beta1.Pipeline
@@ -161,6 +163,8 @@ func TestPipelineConversion(t *testing.T) {
 						Input:    "foo",
 						Operator: selection.In,
 						Values:   []string{"foo", "bar"},
+					}, {
+						CEL: "params.branch == 'main'",
 					}},
 					Retries:  1,
 					RunAfter: []string{"task-1"},
@@ -186,12 +190,32 @@ func TestPipelineConversion(t *testing.T) {
 							}, {
 								Name: "flags", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "-cover -v"}}},
 						}},
+						Spread: []v1beta1.MatrixSpread{{
+							TopologyKey: "topology.kubernetes.io/zone",
+							MaxSkew:     2,
+						}},
 					},
 					Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
 						Name:      "my-task-workspace",
 						Workspace: "source",
 					}},
+					ResultFiles: []v1beta1.ResultFile{{
+						Path:  "/inputs/config.json",
+						Value: "$(tasks.task-1.results.config)",
+					}},
 					Timeout: &metav1.Duration{Duration: 5 * time.Minute},
+					RepeatUntil: &v1beta1.RepeatUntil{
+						When: v1beta1.WhenExpressions{{
+							Input:    "$(results.status)",
+							Operator: selection.In,
+							Values:   []string{"ready"},
+						}},
+						Delay: &metav1.Duration{Duration: 30 * time.Second},
+						Limit: 10,
+					},
+					TasksFromResult: "tasks",
+					OnError:         v1beta1.PipelineTaskContinueAndFail,
+					SkipPolicy:      v1beta1.PipelineTaskSkipDependents,
 				},
 				},
 				Params: []v1beta1.ParamSpec{{
@@ -205,6 +229,9 @@ func TestPipelineConversion(t *testing.T) {
 					Name:        "workspace",
 					Description: "description",
 					Optional:    true,
+					Mode:        v1beta1.WorkspaceModeReadOnly,
+					Type:        v1beta1.WorkspaceTypeCache,
+					CacheKey:    "cache-key",
 				}},
 				Results: []v1beta1.PipelineResult{{
 					Name:        "my-pipeline-result",
@@ -217,6 +244,78 @@ func TestPipelineConversion(t *testing.T) {
 					DisplayName: "final-task-display-name",
 					Description: "final-task-description",
 					TaskRef:     &v1beta1.TaskRef{Name: "foo-task"},
+					WithParam:   "$(params.platforms[*])",
+				}},
+			},
+		},
+	}, {
+		name: "pipeline with taskRunTemplate",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "foo",
+					TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				}},
+				TaskRunTemplate: &v1beta1.PipelineTaskRunTemplate{
+					ServiceAccountName: "pipeline-sa",
+					PodTemplate: &pod.Template{
+						NodeSelector: map[string]string{"disktype": "ssd"},
+						Env:          []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+					},
+				},
+			},
+		},
+	}, {
+		name: "pipeline with history limits",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "foo",
+					TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+				}},
+				SuccessfulRunsHistoryLimit: &successfulRunsHistoryLimit,
+				FailedRunsHistoryLimit:     &failedRunsHistoryLimit,
+			},
+		},
+	}, {
+		name: "pipeline with vars",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Params: []v1beta1.ParamSpec{{Name: "registry", Type: v1beta1.ParamTypeString}},
+				Vars:   map[string]string{"image": "$(params.registry)/app"},
+				Tasks: []v1beta1.PipelineTask{{
+					Name:    "foo",
+					TaskRef: &v1beta1.TaskRef{Name: "foo-task"},
+					Params:  v1beta1.Params{{Name: "image", Value: *v1beta1.NewStructuredValues("$(vars.image)")}},
+				}},
+			},
+		},
+	}, {
+		name: "pipeline with child pipeline",
+		in: &v1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineSpec{
+				Tasks: []v1beta1.PipelineTask{{
+					Name:        "foo",
+					PipelineRef: &v1beta1.PipelineRef{Name: "foo-pipeline"},
+					Params: v1beta1.Params{{
+						Name: "param-1", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: "value-1"},
+					}},
 				}},
 			},
 		},
@@ -29,10 +29,20 @@ import (
 const (
 	// PipelineTasksAggregateStatus is a param representing aggregate status of all dag pipelineTasks
 	PipelineTasksAggregateStatus = "tasks.status"
+	// PipelineTasksFailed is an array param listing the dag pipelineTasks which failed
+	PipelineTasksFailed = "tasks.failed"
+	// PipelineTasksFailedCount is a param representing the number of dag pipelineTasks which failed
+	PipelineTasksFailedCount = "tasks.failed.count"
+	// PipelineTasksSkipped is an array param listing the dag pipelineTasks which were skipped
+	PipelineTasksSkipped = "tasks.skipped"
+	// PipelineTasksSkippedCount is a param representing the number of dag pipelineTasks which were skipped
+	PipelineTasksSkippedCount = "tasks.skipped.count"
 	// PipelineTasks is a value representing a task is a member of "tasks" section of the pipeline
 	PipelineTasks = "tasks"
 	// PipelineFinallyTasks is a value representing a task is a member of "finally" section of the pipeline
 	PipelineFinallyTasks = "finally"
+	// LoopItemVariable is the variable referring to the item of a run of a looping pipeline task
+	LoopItemVariable = "item"
 )
 
 // +genclient
@@ -95,6 +105,11 @@ type PipelineSpec struct {
 	// this Pipeline is run.
 	// +listType=atomic
 	Params ParamSpecs `json:"params,omitempty"`
+	// Vars declares the variables available to all the Tasks of this Pipeline as
+	// $(vars.<name>). Their values can be composed from the params of the Pipeline
+	// and the results of its Tasks.
+	// +optional
+	Vars map[string]string `json:"vars,omitempty"`
 	// Workspaces declares a set of named workspaces that are expected to be
 	// provided by a PipelineRun.
 	// +optional
@@ -109,6 +124,20 @@ type PipelineSpec struct {
 	// or after a failure which would result in ending the Pipeline
 	// +listType=atomic
 	Finally []PipelineTask `json:"finally,omitempty"`
+	// TaskRunTemplate declares the defaults applied to the TaskRuns of all the
+	// Tasks of this Pipeline, unless the PipelineRun overrides them.
+	// +optional
+	TaskRunTemplate *PipelineTaskRunTemplate `json:"taskRunTemplate,omitempty"`
+	// SuccessfulRunsHistoryLimit is the number of the successful PipelineRuns of this
+	// Pipeline the controller keeps, deleting the older ones when a PipelineRun finishes.
+	// The successful PipelineRuns are kept until deleted when it is unset.
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+	// FailedRunsHistoryLimit is the number of the failed PipelineRuns of this Pipeline
+	// the controller keeps, deleting the older ones when a PipelineRun finishes. The
+	// failed PipelineRuns are kept until deleted when it is unset.
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
 }
 
 // PipelineResult used to describe the results of a pipeline
@@ -181,6 +210,13 @@ type PipelineTask struct {
 	// +optional
 	TaskSpec *EmbeddedTask `json:"taskSpec,omitempty"`
 
+	// PipelineRef is a reference to a Pipeline, run by a child PipelineRun of the
+	// PipelineRun instead of a TaskRun. The params and the workspaces of the
+	// PipelineTask are passed to the child PipelineRun, and its results are the
+	// results of the PipelineTask.
+	// +optional
+	PipelineRef *PipelineRef `json:"pipelineRef,omitempty"`
+
 	// WhenExpressions is a list of when expressions that need to be true for the task to run
 	// +optional
 	WhenExpressions WhenExpressions `json:"when,omitempty"`
@@ -189,6 +225,16 @@ type PipelineTask struct {
 	// +optional
 	Retries int `json:"retries,omitempty"`
 
+	// RetriesFrom is the number of retries given as a string referencing parameters or task results,
+	// e.g. "$(params.retryCount)", which must resolve to a non-negative integer. It can't be set along with Retries.
+	// +optional
+	RetriesFrom string `json:"retriesFrom,omitempty"`
+
+	// RepeatUntil re-runs the TaskRun of this task after it succeeded, with a delay, until a
+	// condition on its results passes, e.g. to poll for a deployment to be ready.
+	// +optional
+	RepeatUntil *RepeatUntil `json:"repeatUntil,omitempty"`
+
 	// RunAfter is the list of PipelineTask names that should be executed before
 	// this Task executes. (Used to force a specific ordering in graph execution.)
 	// +optional
@@ -208,16 +254,60 @@ type PipelineTask struct {
 	// +optional
 	Matrix *Matrix `json:"matrix,omitempty"`
 
+	// WithItems fans out this task into a run per item, in which the references
+	// to $(item) in the params of the task are replaced by the item. The results
+	// of the runs are aggregated into array results, in the order of the items.
+	// +optional
+	// +listType=atomic
+	WithItems []string `json:"withItems,omitempty"`
+
+	// WithParam fans out this task like WithItems, over the elements of the array
+	// param or the array result it refers to, e.g. $(params.platforms[*]) or
+	// $(tasks.list.results.files[*]).
+	// +optional
+	WithParam string `json:"withParam,omitempty"`
+
+	// TasksFromResult is the name of a result of this task holding a list of pipeline
+	// tasks, in JSON or YAML, which are added to the pipeline once this task succeeded.
+	// The added tasks run after this task, e.g. to build the modules of a monorepo
+	// affected by a change, discovered at runtime.
+	// +optional
+	TasksFromResult string `json:"tasksFromResult,omitempty"`
+
+	// OnError defines how the pipeline behaves when this task fails: "stopAndFail", the
+	// default, stops running tasks and fails the pipeline; "continue" runs the tasks
+	// depending on it as if it succeeded, and the pipeline succeeds with warnings;
+	// "continueAndFail" runs the tasks depending on it, but fails the pipeline at the end.
+	// +optional
+	OnError PipelineTaskOnErrorType `json:"onError,omitempty"`
+
+	// SkipPolicy defines which tasks are skipped when the when expressions of this task
+	// evaluate to false: "skipTask", the default, skips this task only and runs the tasks
+	// depending on it; "skipDependents" skips the tasks depending on it transitively too.
+	// +optional
+	SkipPolicy PipelineTaskSkipPolicy `json:"skipPolicy,omitempty"`
+
 	// Workspaces maps workspaces from the pipeline spec to the workspaces
 	// declared in the Task.
 	// +optional
 	// +listType=atomic
 	Workspaces []WorkspacePipelineTaskBinding `json:"workspaces,omitempty"`
 
+	// ResultFiles declares files written into the Steps of the TaskRun, usually
+	// with the values of results of other PipelineTasks.
+	// +optional
+	// +listType=atomic
+	ResultFiles []ResultFile `json:"resultFiles,omitempty"`
+
 	// Time after which the TaskRun times out. Defaults to 1 hour.
 	// Refer Go's ParseDuration documentation for expected format: https://golang.org/pkg/time/#ParseDuration
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// TimeoutFrom is the timeout given as a string referencing parameters or task results,
+	// e.g. "$(params.timeout)", which must resolve to a non-negative duration. It can't be set along with Timeout.
+	// +optional
+	TimeoutFrom string `json:"timeoutFrom,omitempty"`
 }
 
 // IsCustomTask checks whether an embedded TaskSpec is a Custom Task
@@ -227,6 +317,16 @@ func (et *EmbeddedTask) IsCustomTask() bool {
 	return et != nil && et.APIVersion != "" && et.Kind != ""
 }
 
+// IsChildPipeline returns whether the pipeline task runs a Pipeline in a child PipelineRun
+func (pt *PipelineTask) IsChildPipeline() bool {
+	return pt.PipelineRef != nil
+}
+
+// IsLooped returns whether the pipeline task loops over items
+func (pt *PipelineTask) IsLooped() bool {
+	return len(pt.WithItems) > 0 || pt.WithParam != ""
+}
+
 // IsMatrixed return whether pipeline task is matrixed
 func (pt *PipelineTask) IsMatrixed() bool {
 	return pt.Matrix.HasParams() || pt.Matrix.HasInclude()
@@ -260,6 +360,36 @@ func (pt PipelineTask) Deps() []string {
 	return deps.List()
 }
 
+// PipelineTaskOnErrorType defines how the pipeline behaves when a pipeline task fails
+type PipelineTaskOnErrorType string
+
+const (
+	// PipelineTaskStopAndFail stops running tasks and fails the pipeline when the pipeline task fails
+	PipelineTaskStopAndFail PipelineTaskOnErrorType = "stopAndFail"
+	// PipelineTaskContinue runs the tasks depending on the pipeline task when it fails, and the
+	// pipeline succeeds with warnings
+	PipelineTaskContinue PipelineTaskOnErrorType = "continue"
+	// PipelineTaskContinueAndFail runs the tasks depending on the pipeline task when it fails, and
+	// the pipeline fails once all the tasks are done
+	PipelineTaskContinueAndFail PipelineTaskOnErrorType = "continueAndFail"
+)
+
+// ContinuesOnError returns whether the tasks depending on the pipeline task run when it fails
+func (pt PipelineTask) ContinuesOnError() bool {
+	return pt.OnError == PipelineTaskContinue || pt.OnError == PipelineTaskContinueAndFail
+}
+
+// PipelineTaskSkipPolicy defines which tasks are skipped when the when expressions of a
+// pipeline task evaluate to false
+type PipelineTaskSkipPolicy string
+
+const (
+	// PipelineTaskSkipTask skips the pipeline task only, and runs the tasks depending on it
+	PipelineTaskSkipTask PipelineTaskSkipPolicy = "skipTask"
+	// PipelineTaskSkipDependents skips the pipeline task and the tasks depending on it transitively
+	PipelineTaskSkipDependents PipelineTaskSkipPolicy = "skipDependents"
+)
+
 // PipelineTaskList is a list of PipelineTasks
 type PipelineTaskList []PipelineTask
 
@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/apis"
@@ -87,6 +88,12 @@ func TestPipelineTask_ValidateRefOrSpec(t *testing.T) {
 			Name:     "foo",
 			TaskSpec: &EmbeddedTask{},
 		},
+	}, {
+		name: "valid pipeline task - with pipelineRef only",
+		p: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
 	}, {
 		name: "invalid pipeline task missing taskRef and taskSpec",
 		p: PipelineTask{
@@ -107,6 +114,17 @@ func TestPipelineTask_ValidateRefOrSpec(t *testing.T) {
 			Message: `expected exactly one, got both`,
 			Paths:   []string{"taskRef", "taskSpec"},
 		},
+	}, {
+		name: "invalid pipeline task with both pipelineRef and taskRef",
+		p: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		expectedError: &apis.FieldError{
+			Message: `expected exactly one, got both`,
+			Paths:   []string{"pipelineRef", "taskRef", "taskSpec"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -170,6 +188,17 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 			Message: `invalid value: custom task ref must specify apiVersion`,
 			Paths:   []string{"taskRef.apiVersion"},
 		},
+	}, {
+		name: "custom task - resultFiles",
+		task: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{APIVersion: "example.dev/v0", Kind: "Example", Name: "bar"},
+			ResultFiles: []ResultFile{{Path: "/inputs/config.json", Value: "$(tasks.bar.results.config)"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `invalid value: custom tasks do not support result files`,
+			Paths:   []string{"resultFiles"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -184,6 +213,182 @@ func TestPipelineTask_ValidateCustomTask(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_ValidateChildPipeline(t *testing.T) {
+	tests := []struct {
+		name                 string
+		task                 PipelineTask
+		enableAlphaAPIFields bool
+		expectedError        *apis.FieldError
+	}{{
+		name: "child pipeline - valid pipelineRef",
+		task: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "child pipeline - alpha api fields disabled",
+		task: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+		},
+		expectedError: apis.ErrGeneric(`pipelineRef requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "child pipeline - invalid pipelineRef",
+		task: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{},
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrMissingField("pipelineRef.name"),
+	}, {
+		name: "child pipeline - matrix and retries",
+		task: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			Retries:     1,
+			Matrix: &Matrix{
+				Params: Params{{Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}}}},
+			},
+		},
+		enableAlphaAPIFields: true,
+		expectedError: apis.ErrInvalidValue("child pipelines can't be matrixed", "matrix").Also(
+			apis.ErrInvalidValue("child pipelines can't be retried", "retries")),
+	}, {
+		name: "child pipeline - resultFiles",
+		task: PipelineTask{
+			Name:        "foo",
+			PipelineRef: &PipelineRef{Name: "foo-pipeline"},
+			ResultFiles: []ResultFile{{Path: "/inputs/config.json", Value: "$(tasks.bar.results.config)"}},
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrInvalidValue("child pipelines do not support result files", "resultFiles"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.task.validateChildPipeline(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.validateChildPipeline() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.validateChildPipeline() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.validateChildPipeline() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateLoop(t *testing.T) {
+	tests := []struct {
+		name                 string
+		task                 PipelineTask
+		enableAlphaAPIFields bool
+		expectedError        *apis.FieldError
+	}{{
+		name: "loop - withItems",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			Params:    Params{{Name: "platform", Value: ParamValue{Type: ParamTypeString, StringVal: "$(item)"}}},
+			WithItems: []string{"linux", "mac"},
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "loop - withParam referring to an array param",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithParam: "$(params.platforms[*])",
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "loop - withParam referring to an array result",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithParam: "$(tasks.list.results.platforms[*])",
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "loop - alpha api fields disabled",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithItems: []string{"linux", "mac"},
+		},
+		expectedError: apis.ErrGeneric(`withItems requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "loop - withItems and withParam",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithItems: []string{"linux", "mac"},
+			WithParam: "$(params.platforms[*])",
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrMultipleOneOf("withItems", "withParam"),
+	}, {
+		name: "loop - matrixed",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithItems: []string{"linux", "mac"},
+			Matrix: &Matrix{
+				Params: Params{{Name: "browser", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"chrome", "safari"}}}},
+			},
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrInvalidValue("looping tasks can't be matrixed", "matrix"),
+	}, {
+		name: "loop - withParam not referring to a whole array",
+		task: PipelineTask{
+			Name:      "foo",
+			TaskRef:   &TaskRef{Name: "foo-task"},
+			WithParam: "$(params.platforms[0])",
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrInvalidValue("$(params.platforms[0]) must refer to a whole array param or result, e.g. $(params.items[*])", "withParam"),
+	}, {
+		name: "item used without loop",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  Params{{Name: "platform", Value: ParamValue{Type: ParamTypeString, StringVal: "$(item)"}}},
+		},
+		expectedError: apis.ErrInvalidValue("$(item) can only be used by tasks looping with withItems or withParam, but got $(item)", "params"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.task.validateLoop(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.validateLoop() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.validateLoop() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.validateLoop() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTask_ValidateBundle_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -217,6 +422,205 @@ func TestPipelineTask_ValidateBundle_Failure(t *testing.T) {
 	}
 }
 
+func TestPipelineTask_ValidateTasksFromResult(t *testing.T) {
+	tests := []struct {
+		name                 string
+		task                 PipelineTask
+		enableAlphaAPIFields bool
+		expectedError        *apis.FieldError
+	}{{
+		name: "tasksFromResult",
+		task: PipelineTask{
+			Name:            "foo",
+			TaskRef:         &TaskRef{Name: "foo-task"},
+			TasksFromResult: "tasks",
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "tasksFromResult - alpha api fields disabled",
+		task: PipelineTask{
+			Name:            "foo",
+			TaskRef:         &TaskRef{Name: "foo-task"},
+			TasksFromResult: "tasks",
+		},
+		expectedError: apis.ErrGeneric(`tasksFromResult requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "tasksFromResult - invalid result name",
+		task: PipelineTask{
+			Name:            "foo",
+			TaskRef:         &TaskRef{Name: "foo-task"},
+			TasksFromResult: "$(tasks)",
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrInvalidValue("$(tasks) must be the name of a result of the task", "tasksFromResult"),
+	}, {
+		name: "tasksFromResult - looping task",
+		task: PipelineTask{
+			Name:            "foo",
+			TaskRef:         &TaskRef{Name: "foo-task"},
+			WithItems:       []string{"linux", "mac"},
+			TasksFromResult: "tasks",
+		},
+		enableAlphaAPIFields: true,
+		expectedError:        apis.ErrInvalidValue("fanned out tasks can't generate tasks", "tasksFromResult"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.task.validateTasksFromResult(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.validateTasksFromResult() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.validateTasksFromResult() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.validateTasksFromResult() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateOnError(t *testing.T) {
+	tests := []struct {
+		name                 string
+		task                 PipelineTask
+		enableAlphaAPIFields bool
+		expectedError        *apis.FieldError
+	}{{
+		name: "onError continue",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			OnError: PipelineTaskContinue,
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "onError continueAndFail",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			OnError: PipelineTaskContinueAndFail,
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "onError - alpha api fields disabled",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			OnError: PipelineTaskContinue,
+		},
+		expectedError: apis.ErrGeneric(`onError requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "onError - invalid value",
+		task: PipelineTask{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			OnError: "ignore",
+		},
+		enableAlphaAPIFields: true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "ignore"`,
+			Paths:   []string{"onError"},
+			Details: `PipelineTask onError must be either "stopAndFail", "continue" or "continueAndFail"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.task.validateOnError(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.validateOnError() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.validateOnError() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.validateOnError() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_ValidateSkipPolicy(t *testing.T) {
+	tests := []struct {
+		name                 string
+		task                 PipelineTask
+		enableAlphaAPIFields bool
+		expectedError        *apis.FieldError
+	}{{
+		name: "skipPolicy skipTask",
+		task: PipelineTask{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			SkipPolicy: PipelineTaskSkipTask,
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "skipPolicy skipDependents",
+		task: PipelineTask{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			SkipPolicy: PipelineTaskSkipDependents,
+		},
+		enableAlphaAPIFields: true,
+	}, {
+		name: "skipPolicy - alpha api fields disabled",
+		task: PipelineTask{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			SkipPolicy: PipelineTaskSkipDependents,
+		},
+		expectedError: apis.ErrGeneric(`skipPolicy requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`),
+	}, {
+		name: "skipPolicy - invalid value",
+		task: PipelineTask{
+			Name:       "foo",
+			TaskRef:    &TaskRef{Name: "foo-task"},
+			SkipPolicy: "skipAll",
+		},
+		enableAlphaAPIFields: true,
+		expectedError: &apis.FieldError{
+			Message: `invalid value: "skipAll"`,
+			Paths:   []string{"skipPolicy"},
+			Details: `PipelineTask skipPolicy must be either "skipTask" or "skipDependents"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.enableAlphaAPIFields {
+				ctx = config.EnableAlphaAPIFields(ctx)
+			}
+			err := tt.task.validateSkipPolicy(ctx)
+			if tt.expectedError == nil {
+				if err != nil {
+					t.Errorf("PipelineTask.validateSkipPolicy() returned error for valid pipeline task: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("PipelineTask.validateSkipPolicy() did not return error for invalid pipeline task")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error()); d != "" {
+				t.Errorf("PipelineTask.validateSkipPolicy() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -252,6 +656,14 @@ func TestPipelineTask_ValidateRegularTask_Success(t *testing.T) {
 			TaskRef: &TaskRef{Name: "bar", Bundle: "docker.io/foo"},
 		},
 		enableBundles: true,
+	}, {
+		name: "pipeline task - use of resultFiles with alpha api fields",
+		tasks: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "bar"},
+			ResultFiles: []ResultFile{{Path: "/inputs/config.json", Value: "$(tasks.bar.results.config)"}},
+		},
+		enableAPIFields: true,
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -316,6 +728,16 @@ func TestPipelineTask_ValidateRegularTask_Failure(t *testing.T) {
 			TaskRef: &TaskRef{Name: "bar", Bundle: "docker.io/foo"},
 		},
 		expectedError: *apis.ErrDisallowedFields("taskRef.bundle"),
+	}, {
+		name: "pipeline task - use of resultFiles without alpha api fields",
+		task: PipelineTask{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "bar"},
+			ResultFiles: []ResultFile{{Path: "/inputs/config.json", Value: "$(tasks.bar.results.config)"}},
+		},
+		expectedError: apis.FieldError{
+			Message: `resultFiles requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -719,6 +1141,51 @@ func TestPipelineTask_validateMatrix(t *testing.T) {
 					Name: "browser", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"chrome", "firefox"}},
 				}}},
 		},
+	}, {
+		name: "matrix spread across zones and nodes",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: &Matrix{
+				Params: Params{{
+					Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+				}},
+				Spread: []MatrixSpread{{
+					TopologyKey: "topology.kubernetes.io/zone",
+				}, {
+					TopologyKey: "kubernetes.io/hostname", MaxSkew: 2, WhenUnsatisfiable: corev1.DoNotSchedule,
+				}}},
+		},
+	}, {
+		name: "matrix spread without params",
+		pt: &PipelineTask{
+			Name:   "task",
+			Matrix: &Matrix{Spread: []MatrixSpread{{TopologyKey: "topology.kubernetes.io/zone"}}},
+		},
+		wantErrs: apis.ErrGeneric("spread requires params or include", "matrix.spread"),
+	}, {
+		name: "invalid matrix spread",
+		pt: &PipelineTask{
+			Name: "task",
+			Matrix: &Matrix{
+				Params: Params{{
+					Name: "platform", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"linux", "mac"}},
+				}},
+				Spread: []MatrixSpread{{
+					MaxSkew: -1,
+				}, {
+					TopologyKey: "topology.kubernetes.io/zone", WhenUnsatisfiable: "Evict",
+				}, {
+					TopologyKey: "topology.kubernetes.io/zone",
+				}, {
+					TopologyKey: "not a label",
+				}}},
+		},
+		wantErrs: apis.ErrMissingField("matrix.spread[0].topologyKey").
+			Also(apis.ErrInvalidValue(-1, "matrix.spread[0].maxSkew", "maxSkew must be positive")).
+			Also(apis.ErrInvalidValue("Evict", "matrix.spread[1].whenUnsatisfiable", `whenUnsatisfiable must be "ScheduleAnyway" or "DoNotSchedule"`)).
+			Also(apis.ErrGeneric(`topologyKey "topology.kubernetes.io/zone" must be unique`, "matrix.spread[2].topologyKey")).
+			Also(apis.ErrInvalidValue("not a label", "matrix.spread[3].topologyKey",
+				"name part must consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character (e.g. 'MyName',  or 'my.name',  or '123-abc', regex used for validation is '([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]')")),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -20,10 +20,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -88,6 +90,29 @@ func TestPipeline_Validate_Success(t *testing.T) {
 				Tasks: []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: "task", Kind: ClusterTaskKind}}},
 			},
 		},
+	}, {
+		name: "retries and timeout from params and results",
+		p: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline"},
+			Spec: PipelineSpec{
+				Params: []ParamSpec{{Name: "retry-count", Type: ParamTypeString}},
+				Tasks: []PipelineTask{{
+					Name:        "foo",
+					TaskRef:     &TaskRef{Name: "foo-task"},
+					RetriesFrom: "$(params.retry-count)",
+					TimeoutFrom: "5m",
+				}, {
+					Name:        "bar",
+					TaskRef:     &TaskRef{Name: "bar-task"},
+					TimeoutFrom: "$(tasks.foo.results.timeout)",
+				}},
+				Finally: []PipelineTask{{
+					Name:        "baz",
+					TaskRef:     &TaskRef{Name: "baz-task"},
+					RetriesFrom: "$(tasks.foo.results.retries)",
+				}},
+			},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -795,6 +820,28 @@ func TestValidatePipelineTasks_Failure(t *testing.T) {
 			Message: "taskSpec.kind cannot be specified when using taskSpec.steps",
 			Paths:   []string{"tasks[0].taskSpec.kind"},
 		},
+	}, {
+		name: "invalid retries and timeout",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			RetriesFrom: "-1",
+			TimeoutFrom: "1 hour",
+		}},
+		expectedError: *apis.ErrInvalidValue("-1", "tasks[0].retriesFrom", `retries "-1" must be a non-negative integer`).
+			Also(apis.ErrInvalidValue("1 hour", "tasks[0].timeoutFrom", `timeout "1 hour" must be a non-negative duration`)),
+	}, {
+		name: "retries and timeout set along with retriesFrom and timeoutFrom",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			Retries:     1,
+			RetriesFrom: "$(params.retry-count)",
+			Timeout:     &metav1.Duration{Duration: time.Hour},
+			TimeoutFrom: "$(params.timeout)",
+		}},
+		expectedError: *apis.ErrMultipleOneOf("tasks[0].retries", "tasks[0].retriesFrom").
+			Also(apis.ErrMultipleOneOf("tasks[0].timeout", "tasks[0].timeoutFrom")),
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1411,6 +1458,16 @@ func TestValidatePipelineDeclaredParameterUsage_Failure(t *testing.T) {
 			Message: `non-existent variable in "$(params.does-not-exist)"`,
 			Paths:   []string{"[0].params[a-param]"},
 		},
+	}, {
+		name: "invalid pipeline task with retries and a timeout referencing parameters missing from the param declarations",
+		tasks: []PipelineTask{{
+			Name:        "foo",
+			TaskRef:     &TaskRef{Name: "foo-task"},
+			RetriesFrom: "$(params.retry-count)",
+			TimeoutFrom: "$(params.timeout)",
+		}},
+		expectedError: *apis.ErrGeneric(`non-existent variable in "$(params.retry-count)"`, "[0].retriesFrom").
+			Also(apis.ErrGeneric(`non-existent variable in "$(params.timeout)"`, "[0].timeoutFrom")),
 	}, {
 		name: "invalid string parameter variables in when expression, missing input param from the param declarations",
 		tasks: []PipelineTask{{
@@ -1441,6 +1498,22 @@ func TestValidatePipelineDeclaredParameterUsage_Failure(t *testing.T) {
 			Message: `non-existent variable in "$(params.foo-is-baz)"`,
 			Paths:   []string{"[0].when[0].values"},
 		},
+	}, {
+		name: "invalid parameter variables in cel when expression, missing param from the param declarations",
+		params: []ParamSpec{{
+			Name: "foo", Type: ParamTypeString,
+		}},
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			WhenExpressions: []WhenExpression{{
+				CEL: "params.foo == 'bar' && params.baz.startsWith('release-')",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `non-existent variable in "params.foo == 'bar' && params.baz.startsWith('release-')"`,
+			Paths:   []string{"[0].when[0].cel"},
+		},
 	}, {
 		name: "invalid string parameter variables in when expression, array reference in input",
 		params: []ParamSpec{{
@@ -2031,6 +2104,381 @@ func TestValidatePipelineWorkspacesDeclarations_Failure(t *testing.T) {
 	}
 }
 
+func TestValidatePipelineWorkspacesModes(t *testing.T) {
+	alphaCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableAPIFields = config.AlphaAPIFields
+		return config.ToContext(ctx, cfg)
+	}
+	readOnlyTask := func(readOnly bool) *EmbeddedTask {
+		return &EmbeddedTask{TaskSpec: TaskSpec{
+			Steps:      []Step{{Name: "foo", Image: "bar"}},
+			Workspaces: []WorkspaceDeclaration{{Name: "src", ReadOnly: readOnly}},
+		}}
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		spec          *PipelineSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "no mode",
+		ctx:  context.Background(),
+		spec: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}},
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskSpec: readOnlyTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src", Workspace: "source"}},
+			}},
+		},
+	}, {
+		name: "read-only workspace used by read-only tasks",
+		ctx:  alphaCtx(),
+		spec: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source", Mode: WorkspaceModeReadOnly}, {Name: "out", Mode: WorkspaceModeReadWrite}},
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskSpec: readOnlyTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src", Workspace: "source"}},
+			}, {
+				Name: "bar", TaskRef: &TaskRef{Name: "bar"},
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src", Workspace: "source"}},
+			}, {
+				Name: "baz", TaskSpec: readOnlyTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src", Workspace: "out"}},
+			}},
+		},
+	}, {
+		name: "mode requires alpha",
+		ctx:  context.Background(),
+		spec: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source", Mode: WorkspaceModeReadOnly}},
+		},
+		expectedError: apis.ErrGeneric(`workspace mode requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaFieldIndex("workspaces", 0),
+	}, {
+		name: "invalid mode",
+		ctx:  alphaCtx(),
+		spec: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "source", Mode: "WriteOnly"}},
+		},
+		expectedError: apis.ErrInvalidValue("WriteOnly", "mode").ViaFieldIndex("workspaces", 0),
+	}, {
+		name: "read-only workspace used by a task writing to it",
+		ctx:  alphaCtx(),
+		spec: &PipelineSpec{
+			Workspaces: []PipelineWorkspaceDeclaration{{Name: "src", Mode: WorkspaceModeReadOnly}},
+			Tasks: []PipelineTask{{
+				Name: "foo", TaskSpec: readOnlyTask(true),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src"}},
+			}},
+			Finally: []PipelineTask{{
+				Name: "bar", TaskSpec: readOnlyTask(false),
+				Workspaces: []WorkspacePipelineTaskBinding{{Name: "src"}},
+			}},
+		},
+		expectedError: apis.ErrInvalidValue(`pipeline workspace "src" is ReadOnly but task workspace "src" is not declared readOnly`, "").ViaFieldIndex("workspaces", 0).ViaFieldIndex("finally", 0),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validatePipelineWorkspacesModes(tt.ctx, tt.spec)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("validatePipelineWorkspacesModes() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidatePipelineWorkspacesTypes(t *testing.T) {
+	alphaCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableAPIFields = config.AlphaAPIFields
+		return config.ToContext(ctx, cfg)
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		workspaces    []PipelineWorkspaceDeclaration
+		expectedError *apis.FieldError
+	}{{
+		name:       "no type",
+		ctx:        context.Background(),
+		workspaces: []PipelineWorkspaceDeclaration{{Name: "source"}},
+	}, {
+		name: "all types",
+		ctx:  alphaCtx(),
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "scratch", Type: WorkspaceTypeEphemeral,
+		}, {
+			Name: "deps", Type: WorkspaceTypeCache, CacheKey: "$(params.branch)-deps",
+		}, {
+			Name: "output", Type: WorkspaceTypeArtifact,
+		}, {
+			Name: "credentials", Type: WorkspaceTypeSecret,
+		}},
+	}, {
+		name:          "type requires alpha",
+		ctx:           context.Background(),
+		workspaces:    []PipelineWorkspaceDeclaration{{Name: "scratch", Type: WorkspaceTypeEphemeral}},
+		expectedError: apis.ErrGeneric(`workspace type requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaFieldIndex("workspaces", 0),
+	}, {
+		name:          "invalid type",
+		ctx:           alphaCtx(),
+		workspaces:    []PipelineWorkspaceDeclaration{{Name: "scratch", Type: "temporary"}},
+		expectedError: apis.ErrInvalidValue("temporary", "type").ViaFieldIndex("workspaces", 0),
+	}, {
+		name:          "cache without cache key",
+		ctx:           alphaCtx(),
+		workspaces:    []PipelineWorkspaceDeclaration{{Name: "deps", Type: WorkspaceTypeCache}},
+		expectedError: apis.ErrMissingField("cacheKey").ViaFieldIndex("workspaces", 0),
+	}, {
+		name:          "cache key without cache type",
+		ctx:           alphaCtx(),
+		workspaces:    []PipelineWorkspaceDeclaration{{Name: "deps", CacheKey: "deps"}},
+		expectedError: apis.ErrGeneric("cacheKey can only be specified for cache workspaces", "cacheKey").ViaFieldIndex("workspaces", 0),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validatePipelineWorkspacesTypes(tt.ctx, tt.workspaces)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("validatePipelineWorkspacesTypes() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateTaskRunTemplate(t *testing.T) {
+	alphaCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableAPIFields = config.AlphaAPIFields
+		return config.ToContext(ctx, cfg)
+	}
+	ps := &PipelineSpec{
+		Tasks: []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: "foo-task"}}},
+		TaskRunTemplate: &PipelineTaskRunTemplate{
+			ServiceAccountName: "pipeline-sa",
+			PodTemplate:        &pod.Template{NodeSelector: map[string]string{"disktype": "ssd"}},
+		},
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		expectedError *apis.FieldError
+	}{{
+		name: "alpha",
+		ctx:  alphaCtx(),
+	}, {
+		name:          "requires alpha",
+		ctx:           context.Background(),
+		expectedError: apis.ErrGeneric(`taskRunTemplate requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("taskRunTemplate"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ps.Validate(tt.ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateHistoryLimits(t *testing.T) {
+	alphaCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableAPIFields = config.AlphaAPIFields
+		return config.ToContext(ctx, cfg)
+	}
+	limit := func(i int32) *int32 { return &i }
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		successful    *int32
+		failed        *int32
+		expectedError *apis.FieldError
+	}{{
+		name:       "alpha",
+		ctx:        alphaCtx(),
+		successful: limit(3),
+		failed:     limit(0),
+	}, {
+		name:          "requires alpha",
+		ctx:           context.Background(),
+		successful:    limit(3),
+		expectedError: apis.ErrGeneric(`successfulRunsHistoryLimit requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("successfulRunsHistoryLimit"),
+	}, {
+		name:          "negative",
+		ctx:           alphaCtx(),
+		failed:        limit(-1),
+		expectedError: apis.ErrInvalidValue("-1 should be >= 0", "failedRunsHistoryLimit"),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &PipelineSpec{
+				Tasks:                      []PipelineTask{{Name: "foo", TaskRef: &TaskRef{Name: "foo-task"}}},
+				SuccessfulRunsHistoryLimit: tt.successful,
+				FailedRunsHistoryLimit:     tt.failed,
+			}
+			errs := ps.Validate(tt.ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateVars(t *testing.T) {
+	alphaCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableAPIFields = config.AlphaAPIFields
+		return config.ToContext(ctx, cfg)
+	}
+	task := func(name, value string) PipelineTask {
+		return PipelineTask{
+			Name:    name,
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params:  Params{{Name: "image", Value: *NewStructuredValues(value)}},
+		}
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		vars          map[string]string
+		tasks         []PipelineTask
+		expectedError *apis.FieldError
+	}{{
+		name:  "vars composed from params and results",
+		ctx:   alphaCtx(),
+		vars:  map[string]string{"image": "$(params.registry)/app@$(tasks.build.results.digest)"},
+		tasks: []PipelineTask{task("build", "app"), task("deploy", "$(vars.image)")},
+	}, {
+		name:          "requires alpha",
+		ctx:           context.Background(),
+		vars:          map[string]string{"image": "$(params.registry)/app"},
+		tasks:         []PipelineTask{task("deploy", "$(vars.image)")},
+		expectedError: apis.ErrGeneric(`vars requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`).ViaField("vars"),
+	}, {
+		name:          "invalid name",
+		ctx:           alphaCtx(),
+		vars:          map[string]string{"image.name": "app"},
+		tasks:         []PipelineTask{task("deploy", "app")},
+		expectedError: apis.ErrInvalidKeyName("image.name", "vars", "Must only contain alphanumeric characters, hyphens (-) and underscores (_), and must begin with a letter or an underscore (_)"),
+	}, {
+		name:  "reference to a non-existent param",
+		ctx:   alphaCtx(),
+		vars:  map[string]string{"image": "$(params.missing)/app"},
+		tasks: []PipelineTask{task("deploy", "$(vars.image)")},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(params.missing)/app"`,
+			Paths:   []string{"vars[image]"},
+		},
+	}, {
+		name:          "reference to another var",
+		ctx:           alphaCtx(),
+		vars:          map[string]string{"image": "$(vars.registry)/app", "registry": "$(params.registry)"},
+		tasks:         []PipelineTask{task("deploy", "$(vars.image)")},
+		expectedError: apis.ErrInvalidValue(`vars can't reference other vars, but got "$(vars.registry)/app"`, "vars[image]"),
+	}, {
+		name:          "reference to the results of a non-existent task",
+		ctx:           alphaCtx(),
+		vars:          map[string]string{"image": "app@$(tasks.build.results.digest)"},
+		tasks:         []PipelineTask{task("deploy", "$(vars.image)")},
+		expectedError: apis.ErrInvalidValue("pipeline task build is not defined in the pipeline", "vars[image]"),
+	}, {
+		name:  "reference to a non-existent var",
+		ctx:   alphaCtx(),
+		vars:  map[string]string{"image": "$(params.registry)/app"},
+		tasks: []PipelineTask{task("deploy", "$(vars.missing)")},
+		expectedError: &apis.FieldError{
+			Message: `non-existent variable in "$(vars.missing)"`,
+			Paths:   []string{"tasks[0].params[image]"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := &PipelineSpec{
+				Params: ParamSpecs{{Name: "registry", Type: ParamTypeString}},
+				Vars:   tt.vars,
+				Tasks:  tt.tasks,
+			}
+			errs := ps.Validate(tt.ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineSpec_ValidateResultRefsDeclared(t *testing.T) {
+	strictCtx := func() context.Context {
+		ctx := context.Background()
+		cfg := config.FromContextOrDefaults(ctx)
+		cfg.FeatureFlags.EnableStrictResultValidation = true
+		return config.ToContext(ctx, cfg)
+	}
+	producer := PipelineTask{
+		Name: "build",
+		TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+			Steps:   []Step{{Name: "build", Image: "busybox"}},
+			Results: []TaskResult{{Name: "digest"}, {Name: "url"}},
+		}},
+	}
+	consumer := func(name, value string) PipelineTask {
+		return PipelineTask{
+			Name:    name,
+			TaskRef: &TaskRef{Name: "deploy"},
+			Params:  Params{{Name: "image", Value: *NewStructuredValues(value)}},
+		}
+	}
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		ps            *PipelineSpec
+		expectedError *apis.FieldError
+	}{{
+		name: "declared results",
+		ctx:  strictCtx(),
+		ps: &PipelineSpec{
+			Tasks:   []PipelineTask{producer, consumer("deploy", "$(tasks.build.results.digest)")},
+			Finally: []PipelineTask{consumer("notify", "$(tasks.build.results.url)")},
+			Results: []PipelineResult{{Name: "digest", Value: *NewStructuredValues("$(tasks.build.results.digest)")}},
+		},
+	}, {
+		name: "results of referenced tasks are not validated",
+		ctx:  strictCtx(),
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{consumer("deploy", "image"), consumer("verify", "$(tasks.deploy.results.anything)")},
+		},
+	}, {
+		name: "undeclared results are valid without the feature flag",
+		ctx:  context.Background(),
+		ps: &PipelineSpec{
+			Tasks: []PipelineTask{producer, consumer("deploy", "$(tasks.build.results.digets)")},
+		},
+	}, {
+		name: "undeclared results",
+		ctx:  strictCtx(),
+		ps: &PipelineSpec{
+			Tasks:   []PipelineTask{producer, consumer("deploy", "$(tasks.build.results.digets)")},
+			Finally: []PipelineTask{consumer("notify", "$(tasks.build.results.uri)")},
+			Results: []PipelineResult{{Name: "digest", Value: *NewStructuredValues("$(tasks.build.results.sha)")}},
+		},
+		expectedError: apis.ErrGeneric(`"digets" is not a result declared by pipeline task "build", which declares [digest url]`).ViaFieldIndex("tasks", 1).Also(
+			apis.ErrGeneric(`"uri" is not a result declared by pipeline task "build", which declares [digest url]`).ViaFieldIndex("finally", 0)).Also(
+			apis.ErrGeneric(`"sha" is not a result declared by pipeline task "build", which declares [digest url]`).ViaField("value").ViaFieldIndex("results", 0)),
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.ps.Validate(tt.ctx)
+			if d := cmp.Diff(tt.expectedError.Error(), errs.Error()); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -2092,6 +2540,44 @@ func TestValidatePipelineWorkspacesUsage_Failure(t *testing.T) {
 			Message: `workspace name "repo" must be unique`,
 			Paths:   []string{"tasks[0].workspaces[1]"},
 		},
+	}, {
+		name: "invalid pipeline task workspace subPath escaping the workspace",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "foo",
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:      "repo",
+				Workspace: "foo",
+				SubPath:   "../$(tasks.bar.results.dir)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: ../$(tasks.bar.results.dir)`,
+			Details: `must not contain '..'`,
+			Paths:   []string{"tasks[0].workspaces[0].subPath"},
+		},
+	}, {
+		name: "invalid pipeline task absolute workspace subPath",
+		workspaces: []PipelineWorkspaceDeclaration{{
+			Name: "foo",
+		}},
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:      "repo",
+				Workspace: "foo",
+				SubPath:   "/$(context.pipelineRun.uid)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: /$(context.pipelineRun.uid)`,
+			Details: `must be a relative path`,
+			Paths:   []string{"tasks[0].workspaces[0].subPath"},
+		},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2532,6 +3018,54 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 			Message: `invalid value: invalid task result reference, final task has task result reference from a final task final-task-1`,
 			Paths:   []string{"finally[1].when[0]"},
 		},
+	}, {
+		name: "invalid pipeline with final tasks having task results reference from a final task in workspace subPath",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:    "final-task-2",
+			TaskRef: &TaskRef{Name: "final-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{
+				Name:    "shared",
+				SubPath: "$(tasks.final-task-1.results.output)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalid task result reference, final task has task result reference from a final task final-task-1`,
+			Paths:   []string{"finally[1].workspaces[0].subPath"},
+		},
+	}, {
+		name: "invalid pipeline with final tasks having task results reference from a final task in result files",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:    "final-task-2",
+			TaskRef: &TaskRef{Name: "final-task"},
+			ResultFiles: []ResultFile{{
+				Path:  "/inputs/output.json",
+				Value: "$(tasks.final-task-1.results.output)",
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalid task result reference, final task has task result reference from a final task final-task-1`,
+			Paths:   []string{"finally[1].resultFiles[0].value"},
+		},
+	}, {
+		name: "invalid pipeline with final tasks having task results reference from a final task in retries",
+		finalTasks: []PipelineTask{{
+			Name:    "final-task-1",
+			TaskRef: &TaskRef{Name: "final-task"},
+		}, {
+			Name:        "final-task-2",
+			TaskRef:     &TaskRef{Name: "final-task"},
+			RetriesFrom: "$(tasks.final-task-1.results.retries)",
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: invalid task result reference, final task has task result reference from a final task final-task-1`,
+			Paths:   []string{"finally[1].retriesFrom"},
+		},
 	}, {
 		name: "invalid pipeline with final tasks having task results reference from non existent dag task",
 		finalTasks: []PipelineTask{{
@@ -2615,6 +3149,24 @@ func TestContextValid(t *testing.T) {
 					Name: "a-param-mat", Value: ParamValue{ArrayVal: []string{"$(context.pipelineRun.uid)"}},
 				}}},
 		}},
+	}, {
+		name: "valid string context variable for PipelineRun runNamespace",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{StringVal: "$(context.pipelineRun.runNamespace)"},
+			}},
+		}},
+	}, {
+		name: "valid string context variable for PipelineRun creator",
+		tasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "a-param", Value: ParamValue{StringVal: "$(context.pipelineRun.creator)"},
+			}},
+		}},
 	}, {
 		name: "valid array context variables for Pipeline and PipelineRun names",
 		tasks: []PipelineTask{{
@@ -2831,7 +3383,7 @@ func TestContextInvalid(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			err := validatePipelineContextVariables(tt.tasks)
 			if err == nil {
-				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %s", tt.tasks[0].Params)
+				t.Errorf("Pipeline.validatePipelineContextVariables() did not return error for invalid pipeline parameters: %v", tt.tasks[0].Params)
 			}
 			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -2869,6 +3421,33 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				Values:   []string{"Success"},
 			}},
 		}},
+	}, {
+		name: "valid variables in finally accessing the reasons, the failed and the skipped pipelineTasks",
+		tasks: []PipelineTask{{
+			Name: "foo",
+		}},
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "foo-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.foo.reason)"},
+			}, {
+				Name: "failed", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"$(tasks.failed[*])"}},
+			}},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.failed.count)",
+				Operator: selection.NotIn,
+				Values:   []string{"0"},
+			}, {
+				Input:    "foo",
+				Operator: selection.NotIn,
+				Values:   []string{"$(tasks.skipped[*])"},
+			}, {
+				Input:    "$(tasks.skipped.count)",
+				Operator: selection.In,
+				Values:   []string{"0"},
+			}},
+		}},
 	}, {
 		name: "valid task result reference with status as a variable must not cause validation failure",
 		tasks: []PipelineTask{{
@@ -2938,6 +3517,34 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
 			Paths:   []string{"tasks[0].params[tasks-status].value"},
 		},
+	}, {
+		name: "invalid variables in dag task accessing the reasons and the failed pipelineTasks",
+		tasks: []PipelineTask{{
+			Name:    "foo",
+			TaskRef: &TaskRef{Name: "foo-task"},
+			Params: Params{{
+				Name: "bar-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.bar.reason)"},
+			}, {
+				Name: "failed", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"$(tasks.failed[*])"}},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline tasks can not refer to execution status of any other pipeline task or aggregate status of tasks`,
+			Paths:   []string{"tasks[0].params[bar-reason].value", "tasks[0].params[failed].value"},
+		},
+	}, {
+		name: "invalid string variable in finally accessing missing pipelineTask reason",
+		finalTasks: []PipelineTask{{
+			Name:    "bar",
+			TaskRef: &TaskRef{Name: "bar-task"},
+			Params: Params{{
+				Name: "notask-reason", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.notask.reason)"},
+			}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `invalid value: pipeline task notask is not defined in the pipeline`,
+			Paths:   []string{"finally[0].params[notask-reason].value"},
+		},
 	}, {
 		name: "invalid variable concatenated with extra string in dag task accessing pipelineTask status",
 		tasks: []PipelineTask{{
@@ -3062,7 +3669,7 @@ func TestPipelineTasksExecutionStatus(t *testing.T) {
 				}
 			} else {
 				if err == nil {
-					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %s", tt.name, tt.tasks[0].Params)
+					t.Errorf("Pipeline.validateExecutionStatusVariables() did not return error for invalid pipeline parameters accessing execution status: %s, %v", tt.name, tt.tasks[0].Params)
 				}
 				if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
 					t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
@@ -3215,7 +3822,7 @@ func Test_validateMatrix(t *testing.T) {
 	}
 }
 
-func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
+func Test_validateResultsFromMatrixedPipelineTasksConsumed(t *testing.T) {
 	tests := []struct {
 		name     string
 		tasks    []PipelineTask
@@ -3238,7 +3845,7 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"tasks[1]"},
 		},
 	}, {
@@ -3259,7 +3866,7 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"finally[0]"},
 		},
 	}, {
@@ -3286,7 +3893,7 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"tasks[1]", "finally[0]"},
 		},
 	}, {
@@ -3308,7 +3915,7 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"tasks[1]"},
 		},
 	}, {
@@ -3331,7 +3938,7 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"finally[0]"},
 		},
 	}, {
@@ -3362,14 +3969,59 @@ func Test_validateResultsFromMatrixedPipelineTasksNotConsumed(t *testing.T) {
 			}},
 		}},
 		wantErrs: &apis.FieldError{
-			Message: "invalid value: consuming results from matrixed task a-task is not allowed",
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result)",
 			Paths:   []string{"tasks[1]", "finally[0]"},
 		},
+	}, {
+		name: "object results from matrixed task consumed in tasks",
+		tasks: PipelineTaskList{{
+			Name:    "a-task",
+			TaskRef: &TaskRef{Name: "a-task"},
+			Matrix: &Matrix{
+				Params: Params{{
+					Name: "a-param", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+				}}},
+		}, {
+			Name:    "b-task",
+			TaskRef: &TaskRef{Name: "b-task"},
+			Params: Params{{
+				Name: "b-param", Value: ParamValue{Type: ParamTypeString, StringVal: "$(tasks.a-task.results.a-result.key)"},
+			}},
+		}},
+		wantErrs: &apis.FieldError{
+			Message: "invalid value: results of matrixed task a-task are arrays and must be consumed with [*] or by index, but got $(tasks.a-task.results.a-result.key)",
+			Paths:   []string{"tasks[1]"},
+		},
+	}, {
+		name: "results from matrixed task consumed as whole arrays and by index",
+		tasks: PipelineTaskList{{
+			Name:    "a-task",
+			TaskRef: &TaskRef{Name: "a-task"},
+			Matrix: &Matrix{
+				Params: Params{{
+					Name: "a-param", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"foo", "bar"}},
+				}}},
+		}, {
+			Name:    "b-task",
+			TaskRef: &TaskRef{Name: "b-task"},
+			Params: Params{{
+				Name: "b-param", Value: ParamValue{Type: ParamTypeArray, ArrayVal: []string{"$(tasks.a-task.results.a-result[*])"}},
+			}},
+		}},
+		finally: PipelineTaskList{{
+			Name:    "c-task",
+			TaskRef: &TaskRef{Name: "c-task"},
+			WhenExpressions: WhenExpressions{{
+				Input:    "$(tasks.a-task.results.a-result[0])",
+				Operator: selection.In,
+				Values:   []string{"foo", "bar"},
+			}},
+		}},
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if d := cmp.Diff(tt.wantErrs.Error(), validateResultsFromMatrixedPipelineTasksNotConsumed(tt.tasks, tt.finally).Error()); d != "" {
-				t.Errorf("validateResultsFromMatrixedPipelineTasksNotConsumed() errors diff %s", diff.PrintWantGot(d))
+			if d := cmp.Diff(tt.wantErrs.Error(), validateResultsFromMatrixedPipelineTasksConsumed(tt.tasks, tt.finally).Error()); d != "" {
+				t.Errorf("validateResultsFromMatrixedPipelineTasksConsumed() errors diff %s", diff.PrintWantGot(d))
 			}
 		})
 	}
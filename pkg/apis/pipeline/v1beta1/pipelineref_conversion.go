@@ -27,6 +27,7 @@ func (pr PipelineRef) convertTo(ctx context.Context, sink *v1.PipelineRef) {
 		sink.Name = pr.Name
 	}
 	sink.APIVersion = pr.APIVersion
+	sink.Kind = v1.PipelineKind(pr.Kind)
 	new := v1.ResolverRef{}
 	pr.ResolverRef.convertTo(ctx, &new)
 	sink.ResolverRef = new
@@ -36,6 +37,7 @@ func (pr PipelineRef) convertTo(ctx context.Context, sink *v1.PipelineRef) {
 func (pr *PipelineRef) convertFrom(ctx context.Context, source v1.PipelineRef) {
 	pr.Name = source.Name
 	pr.APIVersion = source.APIVersion
+	pr.Kind = PipelineKind(source.Kind)
 	new := ResolverRef{}
 	new.convertFrom(ctx, source.ResolverRef)
 	pr.ResolverRef = new
@@ -64,6 +64,18 @@ func (ref *PipelineRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 			}
 		}
 	}
+	switch ref.Kind {
+	case "", NamespacedPipelineKind:
+	case ClusterPipelineKind:
+		if ref.Resolver != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("kind", "resolver"))
+		}
+		if ref.Bundle != "" {
+			errs = errs.Also(apis.ErrMultipleOneOf("kind", "bundle"))
+		}
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(ref.Kind, "kind"))
+	}
 	return //nolint:nakedret
 }
 
@@ -120,6 +120,19 @@ func TestPipelineRef_Invalid(t *testing.T) {
 		},
 		wantErr:     apis.ErrMultipleOneOf("bundle", "params").Also(apis.ErrMissingField("resolver")),
 		withContext: enableTektonOCIBundles(t),
+	}, {
+		name:    "pipelineRef with an unknown kind",
+		ref:     &v1beta1.PipelineRef{Name: "foo", Kind: "Task"},
+		wantErr: apis.ErrInvalidValue("Task", "kind"),
+	}, {
+		name: "ClusterPipeline kind disallowed in conjunction with resolver",
+		ref: &v1beta1.PipelineRef{
+			Kind: v1beta1.ClusterPipelineKind,
+			ResolverRef: v1beta1.ResolverRef{
+				Resolver: "git",
+			},
+		},
+		wantErr: apis.ErrMultipleOneOf("kind", "resolver"),
 	}}
 
 	for _, tc := range tests {
@@ -162,6 +175,12 @@ func TestPipelineRef_Valid(t *testing.T) {
 				StringVal: "baz",
 			},
 		}}}},
+	}, {
+		name: "ClusterPipeline kind",
+		ref:  &v1beta1.PipelineRef{Name: "foo", Kind: v1beta1.ClusterPipelineKind},
+	}, {
+		name: "Pipeline kind",
+		ref:  &v1beta1.PipelineRef{Name: "foo", Kind: v1beta1.NamespacedPipelineKind},
 	}}
 
 	for _, ts := range tests {
@@ -64,7 +64,10 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		p.convertTo(ctx, &new)
 		sink.Params = append(sink.Params, new)
 	}
+	sink.DisplayName = prs.DisplayName
+	sink.Description = prs.Description
 	sink.Status = v1.PipelineRunSpecStatus(prs.Status)
+	sink.FailurePolicy = v1.PipelineRunFailurePolicy(prs.FailurePolicy)
 	if prs.Timeouts != nil {
 		sink.Timeouts = &v1.TimeoutFields{}
 		prs.Timeouts.convertTo(ctx, sink.Timeouts)
@@ -88,6 +91,7 @@ func (prs PipelineRunSpec) ConvertTo(ctx context.Context, sink *v1.PipelineRunSp
 		ptrs.convertTo(ctx, &new)
 		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
 	}
+	sink.TTLSecondsAfterFinished = prs.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -126,8 +130,11 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, p)
 		prs.Params = append(prs.Params, new)
 	}
+	prs.DisplayName = source.DisplayName
+	prs.Description = source.Description
 	prs.ServiceAccountName = source.TaskRunTemplate.ServiceAccountName
 	prs.Status = PipelineRunSpecStatus(source.Status)
+	prs.FailurePolicy = PipelineRunFailurePolicy(source.FailurePolicy)
 	if source.Timeouts != nil {
 		newTimeouts := &TimeoutFields{}
 		newTimeouts.convertFrom(ctx, *source.Timeouts)
@@ -146,6 +153,7 @@ func (prs *PipelineRunSpec) ConvertFrom(ctx context.Context, source *v1.Pipeline
 		new.convertFrom(ctx, trs)
 		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
 	}
+	prs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
 	return nil
 }
 
@@ -231,6 +239,14 @@ func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRu
 		st.convertTo(ctx, &new)
 		sink.SkippedTasks = append(sink.SkippedTasks, new)
 	}
+	sink.GeneratedTasks = nil
+	for _, t := range prs.GeneratedTasks {
+		new := v1.PipelineTask{}
+		if err := t.convertTo(ctx, &new, meta); err != nil {
+			return err
+		}
+		sink.GeneratedTasks = append(sink.GeneratedTasks, new)
+	}
 	sink.ChildReferences = nil
 	for _, cr := range prs.ChildReferences {
 		new := v1.ChildStatusReference{}
@@ -243,6 +259,15 @@ func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRu
 		prs.Provenance.convertTo(ctx, &new)
 		sink.Provenance = &new
 	}
+	if prs.OffloadedStatus != nil {
+		sink.OffloadedStatus = &v1.OffloadedStatus{ConfigMaps: prs.OffloadedStatus.ConfigMaps, URL: prs.OffloadedStatus.URL, Digest: prs.OffloadedStatus.Digest}
+	}
+	sink.ResolvedParams = nil
+	for _, p := range prs.ResolvedParams {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.ResolvedParams = append(sink.ResolvedParams, new)
+	}
 	return nil
 }
 
@@ -270,6 +295,14 @@ func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.Pipeli
 		new.convertFrom(ctx, st)
 		prs.SkippedTasks = append(prs.SkippedTasks, new)
 	}
+	prs.GeneratedTasks = nil
+	for _, t := range source.GeneratedTasks {
+		new := PipelineTask{}
+		if err := new.convertFrom(ctx, t, meta); err != nil {
+			return err
+		}
+		prs.GeneratedTasks = append(prs.GeneratedTasks, new)
+	}
 	prs.ChildReferences = nil
 	for _, cr := range source.ChildReferences {
 		new := ChildStatusReference{}
@@ -283,6 +316,15 @@ func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.Pipeli
 		new.convertFrom(ctx, *source.Provenance)
 		prs.Provenance = &new
 	}
+	if source.OffloadedStatus != nil {
+		prs.OffloadedStatus = &OffloadedStatus{ConfigMaps: source.OffloadedStatus.ConfigMaps, URL: source.OffloadedStatus.URL, Digest: source.OffloadedStatus.Digest}
+	}
+	prs.ResolvedParams = nil
+	for _, p := range source.ResolvedParams {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		prs.ResolvedParams = append(prs.ResolvedParams, new)
+	}
 	return nil
 }
 
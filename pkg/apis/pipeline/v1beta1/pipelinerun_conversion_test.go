@@ -138,6 +138,7 @@ func TestPipelineRunConversionBadType(t *testing.T) {
 }
 
 func TestPipelineRunConversion(t *testing.T) {
+	ttl := int32(3600)
 	tests := []struct {
 		name string
 		in   *v1beta1.PipelineRun
@@ -152,6 +153,30 @@ func TestPipelineRunConversion(t *testing.T) {
 				PipelineRef: &v1beta1.PipelineRef{Name: "pipeline-1"},
 			},
 		},
+	}, {
+		name: "pipelinerun with ttlSecondsAfterFinished",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:             &v1beta1.PipelineRef{Name: "pipeline-1"},
+				TTLSecondsAfterFinished: &ttl,
+			},
+		},
+	}, {
+		name: "pipelinerun with failurePolicy",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:   &v1beta1.PipelineRef{Name: "pipeline-1"},
+				FailurePolicy: v1beta1.PipelineRunFailAtEnd,
+			},
+		},
 	}, {
 		name: "pipelinerun with deprecated fields in step and stepTemplate",
 		in: &v1beta1.PipelineRun{
@@ -233,6 +258,8 @@ func TestPipelineRunConversion(t *testing.T) {
 					Name:  "bar",
 					Value: *v1beta1.NewStructuredValues("value"),
 				}},
+				DisplayName:        "Build of $(params.foo)",
+				Description:        "Build of $(params.foo) and $(params.bar)",
 				ServiceAccountName: "test-sa",
 				Status:             v1beta1.PipelineRunSpecStatusPending,
 				Timeouts: &v1beta1.TimeoutFields{
@@ -328,6 +355,11 @@ func TestPipelineRunConversion(t *testing.T) {
 							},
 						}},
 					},
+					GeneratedTasks: []v1beta1.PipelineTask{{
+						Name:     "build-api",
+						TaskRef:  &v1beta1.TaskRef{Name: "build"},
+						RunAfter: []string{"mytask"},
+					}},
 					SkippedTasks: []v1beta1.SkippedTask{
 						{
 							Name:   "skipped-1",
@@ -366,6 +398,58 @@ func TestPipelineRunConversion(t *testing.T) {
 							Digest: map[string]string{"sha256": "digest"},
 						},
 						FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+						ServiceAccounts: []v1beta1.ServiceAccountGrant{{
+							ServiceAccount: "default",
+						}, {
+							PipelineTask:   "deploy",
+							ServiceAccount: "deployer",
+							Policy:         "release",
+						}},
+						ConsumedResults: []v1beta1.ResultProvenance{{
+							PipelineTask: "deploy",
+							Result:       "tasks.build.results.image",
+							Run:          "test-build",
+							Attempt:      1,
+							Digest:       "sha256:d35e9d3e7cbd5e8a4c4b4e3c7a6e1e6d3c3b1c2e1f0a9b8c7d6e5f4a3b2c1d0e",
+						}},
+						SpecAttestation: &v1beta1.SpecAttestation{
+							Digest:    map[string]string{"sha256": "f2ca1bb6c7e907d06dafe4687e579fce76b37e4e93b7605022da52e6ccc26fd2"},
+							Signature: "MEUCIQDf",
+							KeyRef:    "azurekms://tekton-vault.vault.azure.net/provenance",
+						},
+						SLSA: &v1beta1.SLSAProvenance{
+							Subject: []v1beta1.SLSAResourceDescriptor{{
+								Name:   "gcr.io/foo/bar",
+								Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+							}},
+							PredicateType: v1beta1.SLSAProvenancePredicateType,
+							Predicate: v1beta1.SLSAPredicate{
+								BuildDefinition: v1beta1.SLSABuildDefinition{
+									BuildType: "https://tekton.dev/slsa-provenance/pipelinerun@v1",
+									ExternalParameters: map[string]v1beta1.ParamValue{
+										"revision": {Type: v1beta1.ParamTypeString, StringVal: "main"},
+									},
+									ResolvedDependencies: []v1beta1.SLSAResourceDescriptor{{
+										Name:   "pipeline",
+										URI:    "git+https://github.com/tektoncd/catalog.git",
+										Digest: map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+									}},
+								},
+								RunDetails: v1beta1.SLSARunDetails{
+									Builder: v1beta1.SLSABuilder{
+										ID:      "https://tekton.dev/pipelines/controller",
+										Version: map[string]string{"tekton-pipelines": "v0.47.0"},
+									},
+									Metadata: v1beta1.SLSABuildMetadata{
+										InvocationID: "8ef3d6fa-5d55-4f9c-9bc5-0b9e1d1f0b7a",
+									},
+								},
+							},
+						},
+					},
+					OffloadedStatus: &v1beta1.OffloadedStatus{
+						ConfigMaps: []string{"test-status-0", "test-status-1"},
+						Digest:     "sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
 					},
 				},
 			},
@@ -25,11 +25,16 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	cfgtesting "github.com/tektoncd/pipeline/pkg/apis/config/testing"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
 )
 
 func TestPipelineRunSpec_SetDefaults(t *testing.T) {
@@ -372,3 +377,81 @@ func TestPipelineRunDefaulting(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunDefaultingCreator(t *testing.T) {
+	created := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+		},
+	}
+	tests := []struct {
+		name string
+		ctx  context.Context
+		in   map[string]string
+		want map[string]string
+	}{{
+		name: "created",
+		ctx:  apis.WithUserInfo(apis.WithinCreate(context.Background()), &authenticationv1.UserInfo{Username: "alice"}),
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "created with an annotation set by the user",
+		ctx:  apis.WithUserInfo(apis.WithinCreate(context.Background()), &authenticationv1.UserInfo{Username: "alice"}),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "updated",
+		ctx:  apis.WithUserInfo(apis.WithinUpdate(context.Background(), created), &authenticationv1.UserInfo{Username: "bob"}),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "alice"},
+	}, {
+		name: "not in a request",
+		ctx:  context.Background(),
+		in:   map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+		want: map[string]string{pipeline.CreatorAnnotationKey: "bob"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.in}}
+			pr.SetDefaults(tc.ctx)
+			if d := cmp.Diff(tc.want, pr.Annotations); d != "" {
+				t.Errorf("SetDefaults() annotations %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunDefaultingWithNamespaceDefaults(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.NamespaceDefaultsConfigName, Namespace: "team-a"},
+		Data: map[string]string{
+			"default-timeout-minutes": "120",
+			"default-service-account": "team-a",
+			"default-pod-template":    "nodeSelector:\n  team: a\n",
+		},
+	}); err != nil {
+		t.Fatalf("failed to add configmap: %v", err)
+	}
+	ctx := cfgtesting.SetDefaults(context.Background(), t, map[string]string{})
+	ctx = config.WithNamespaceDefaults(ctx, corev1listers.NewConfigMapLister(indexer))
+
+	got := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "team-a"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+		},
+	}
+	want := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "team-a"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        &v1beta1.PipelineRef{Name: "foo"},
+			ServiceAccountName: "team-a",
+			PodTemplate:        &pod.Template{NodeSelector: map[string]string{"team": "a"}},
+			Timeout:            &metav1.Duration{Duration: 2 * time.Hour},
+		},
+	}
+	got.SetDefaults(ctx)
+	if d := cmp.Diff(want, got, ignoreUnexportedResources); d != "" {
+		t.Errorf("SetDefaults %s", diff.PrintWantGot(d))
+	}
+}
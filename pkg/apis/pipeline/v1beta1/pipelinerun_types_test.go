@@ -662,3 +662,36 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetPodSpecMergesPodTemplates(t *testing.T) {
+	runAsUser := int64(1000)
+	runAsGroup := int64(2000)
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "prs"},
+			PodTemplate: &pod.Template{
+				NodeSelector:    map[string]string{"arch": "amd64"},
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: &runAsUser},
+			},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build-task",
+				TaskPodTemplate: &pod.Template{
+					NodeSelector:    map[string]string{"disktype": "ssd"},
+					SecurityContext: &corev1.PodSecurityContext{RunAsGroup: &runAsGroup},
+				},
+			}},
+		},
+	}
+	want := &pod.Template{
+		NodeSelector: map[string]string{"arch": "amd64", "disktype": "ssd"},
+		SecurityContext: &corev1.PodSecurityContext{
+			RunAsUser:  &runAsUser,
+			RunAsGroup: &runAsGroup,
+		},
+	}
+	s := pr.GetTaskRunSpec("build-task")
+	if d := cmp.Diff(want, s.TaskPodTemplate); d != "" {
+		t.Errorf("wrong task podtemplate %s", diff.PrintWantGot(d))
+	}
+}
@@ -28,6 +28,25 @@ func (p Provenance) convertTo(ctx context.Context, sink *v1.Provenance) {
 	if p.FeatureFlags != nil {
 		sink.FeatureFlags = p.FeatureFlags
 	}
+	for _, e := range p.Executions {
+		sink.Executions = append(sink.Executions, v1.StepExecution(e))
+	}
+	for _, g := range p.ServiceAccounts {
+		sink.ServiceAccounts = append(sink.ServiceAccounts, v1.ServiceAccountGrant(g))
+	}
+	for _, r := range p.ConsumedResults {
+		sink.ConsumedResults = append(sink.ConsumedResults, v1.ResultProvenance(r))
+	}
+	if p.SpecAttestation != nil {
+		new := v1.SpecAttestation(*p.SpecAttestation)
+		sink.SpecAttestation = &new
+	}
+	sink.Hermetic = p.Hermetic
+	if p.SLSA != nil {
+		new := v1.SLSAProvenance{}
+		p.SLSA.convertTo(ctx, &new)
+		sink.SLSA = &new
+	}
 }
 
 func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
@@ -39,6 +58,69 @@ func (p *Provenance) convertFrom(ctx context.Context, source v1.Provenance) {
 	if source.FeatureFlags != nil {
 		p.FeatureFlags = source.FeatureFlags
 	}
+	for _, e := range source.Executions {
+		p.Executions = append(p.Executions, StepExecution(e))
+	}
+	for _, g := range source.ServiceAccounts {
+		p.ServiceAccounts = append(p.ServiceAccounts, ServiceAccountGrant(g))
+	}
+	for _, r := range source.ConsumedResults {
+		p.ConsumedResults = append(p.ConsumedResults, ResultProvenance(r))
+	}
+	if source.SpecAttestation != nil {
+		new := SpecAttestation(*source.SpecAttestation)
+		p.SpecAttestation = &new
+	}
+	p.Hermetic = source.Hermetic
+	if source.SLSA != nil {
+		new := SLSAProvenance{}
+		new.convertFrom(ctx, *source.SLSA)
+		p.SLSA = &new
+	}
+}
+
+func (s SLSAProvenance) convertTo(ctx context.Context, sink *v1.SLSAProvenance) {
+	for _, d := range s.Subject {
+		sink.Subject = append(sink.Subject, v1.SLSAResourceDescriptor(d))
+	}
+	sink.PredicateType = s.PredicateType
+	definition := s.Predicate.BuildDefinition
+	sink.Predicate.BuildDefinition.BuildType = definition.BuildType
+	if definition.ExternalParameters != nil {
+		sink.Predicate.BuildDefinition.ExternalParameters = map[string]v1.ParamValue{}
+		for name, value := range definition.ExternalParameters {
+			new := v1.ParamValue{}
+			value.convertTo(ctx, &new)
+			sink.Predicate.BuildDefinition.ExternalParameters[name] = new
+		}
+	}
+	for _, d := range definition.ResolvedDependencies {
+		sink.Predicate.BuildDefinition.ResolvedDependencies = append(sink.Predicate.BuildDefinition.ResolvedDependencies, v1.SLSAResourceDescriptor(d))
+	}
+	sink.Predicate.RunDetails.Builder = v1.SLSABuilder(s.Predicate.RunDetails.Builder)
+	sink.Predicate.RunDetails.Metadata = v1.SLSABuildMetadata(s.Predicate.RunDetails.Metadata)
+}
+
+func (s *SLSAProvenance) convertFrom(ctx context.Context, source v1.SLSAProvenance) {
+	for _, d := range source.Subject {
+		s.Subject = append(s.Subject, SLSAResourceDescriptor(d))
+	}
+	s.PredicateType = source.PredicateType
+	definition := source.Predicate.BuildDefinition
+	s.Predicate.BuildDefinition.BuildType = definition.BuildType
+	if definition.ExternalParameters != nil {
+		s.Predicate.BuildDefinition.ExternalParameters = map[string]ParamValue{}
+		for name, value := range definition.ExternalParameters {
+			new := ParamValue{}
+			new.convertFrom(ctx, value)
+			s.Predicate.BuildDefinition.ExternalParameters[name] = new
+		}
+	}
+	for _, d := range definition.ResolvedDependencies {
+		s.Predicate.BuildDefinition.ResolvedDependencies = append(s.Predicate.BuildDefinition.ResolvedDependencies, SLSAResourceDescriptor(d))
+	}
+	s.Predicate.RunDetails.Builder = SLSABuilder(source.Predicate.RunDetails.Builder)
+	s.Predicate.RunDetails.Metadata = SLSABuildMetadata(source.Predicate.RunDetails.Metadata)
 }
 
 func (cs RefSource) convertTo(ctx context.Context, sink *v1.RefSource) {
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// DefaultRepeatDelay is the delay between the runs of a repeated Task when none is specified.
+const DefaultRepeatDelay = 10 * time.Second
+
+// RepeatUntil re-runs a Task which succeeded until a condition on its results passes, e.g.
+// to poll for a deployment to be ready.
+type RepeatUntil struct {
+	// When is the condition to stop repeating the Task: the Task is run again while one of
+	// the when expressions is false. The when expressions reference the results of the Task
+	// as $(results.<name>).
+	// +listType=atomic
+	When WhenExpressions `json:"when"`
+
+	// Delay between the end of a run of the Task and the start of the next one.
+	// Defaults to 10s.
+	// +optional
+	Delay *metav1.Duration `json:"delay,omitempty"`
+
+	// Limit is the maximum number of times the Task is run again. The Task fails when the
+	// condition still doesn't pass after its last run.
+	Limit int `json:"limit"`
+}
+
+// GetDelay returns the delay between the runs of the Task.
+func (r *RepeatUntil) GetDelay() time.Duration {
+	if r.Delay == nil {
+		return DefaultRepeatDelay
+	}
+	return r.Delay.Duration
+}
+
+// Passes returns whether the condition to stop repeating the Task passes with the given results.
+func (r *RepeatUntil) Passes(results []TaskRunResult) bool {
+	replacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	for _, result := range results {
+		key := "results." + result.Name
+		switch result.Value.Type {
+		case ParamTypeArray:
+			arrayReplacements[key] = result.Value.ArrayVal
+		case ParamTypeObject:
+			for k, v := range result.Value.ObjectVal {
+				replacements[key+"."+k] = v
+			}
+		default:
+			replacements[key] = result.Value.StringVal
+		}
+	}
+	return r.When.DeepCopy().ReplaceVariables(replacements, arrayReplacements).AllowsExecution()
+}
+
+// Validate validates the condition, the delay and the limit of the repetitions.
+func (r *RepeatUntil) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "repeatUntil", config.AlphaAPIFields))
+	if len(r.When) == 0 {
+		errs = errs.Also(apis.ErrMissingField("when"))
+	}
+	errs = errs.Also(r.When.validate(ctx))
+	for i, we := range r.When {
+		if we.CEL != "" {
+			errs = errs.Also(apis.ErrInvalidValue("cel can't be used to repeat a task", "cel").ViaFieldIndex("when", i))
+		}
+		for _, expression := range append([]string{we.Input}, we.Values...) {
+			if strings.Contains(expression, "$(tasks.") {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s can't reference the results of other tasks, use $(results.<name>) for the results of the task", expression), "").ViaFieldIndex("when", i))
+			}
+		}
+	}
+	if r.Delay != nil && r.Delay.Duration < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", r.Delay.Duration), "delay"))
+	}
+	if r.Limit < 1 {
+		errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 1", r.Limit), "limit"))
+	}
+	return errs
+}
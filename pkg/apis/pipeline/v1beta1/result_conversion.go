@@ -47,3 +47,13 @@ func (r *TaskResult) convertFrom(ctx context.Context, source v1.TaskResult) {
 		r.Properties = properties
 	}
 }
+
+func (rf ResultFile) convertTo(ctx context.Context, sink *v1.ResultFile) {
+	sink.Path = rf.Path
+	sink.Value = rf.Value
+}
+
+func (rf *ResultFile) convertFrom(ctx context.Context, source v1.ResultFile) {
+	rf.Path = source.Path
+	rf.Value = source.Value
+}
@@ -16,7 +16,11 @@ package v1beta1
 import (
 	"context"
 	"fmt"
+	"path"
+	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
@@ -30,6 +34,12 @@ func (tr TaskResult) Validate(ctx context.Context) (errs *apis.FieldError) {
 	case tr.Type == ResultsTypeObject:
 		errs := validateObjectResult(tr)
 		return errs
+	case tr.Type == ResultsTypeFile:
+		errs := version.ValidateEnabledAPIFields(ctx, "file results", config.AlphaAPIFields)
+		if tr.Properties != nil {
+			errs = errs.Also(apis.ErrDisallowedFields(fmt.Sprintf("%s.properties", tr.Name)))
+		}
+		return errs
 	case tr.Type == ResultsTypeArray:
 		return errs
 	// Resources created before the result. Type was introduced may not have Type set
@@ -66,3 +76,23 @@ func validateObjectResult(tr TaskResult) (errs *apis.FieldError) {
 	}
 	return nil
 }
+
+// validateResultFiles validates that the paths of the result files are clean, absolute,
+// unique and not under the /tekton/ directory reserved by Tekton.
+func validateResultFiles(resultFiles []ResultFile) (errs *apis.FieldError) {
+	paths := make(map[string]bool, len(resultFiles))
+	for i, rf := range resultFiles {
+		switch {
+		case rf.Path == "":
+			errs = errs.Also(apis.ErrMissingField("path").ViaIndex(i))
+		case !path.IsAbs(rf.Path) || path.Clean(rf.Path) != rf.Path:
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("result file path %q must be a clean absolute path", rf.Path), "path").ViaIndex(i))
+		case rf.Path == "/tekton" || strings.HasPrefix(rf.Path, "/tekton/"):
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("result file path %q cannot be under /tekton/", rf.Path), "path").ViaIndex(i))
+		case paths[rf.Path]:
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result file path %q must be unique", rf.Path), "path").ViaIndex(i))
+		}
+		paths[rf.Path] = true
+	}
+	return errs
+}
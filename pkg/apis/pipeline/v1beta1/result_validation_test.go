@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	"knative.dev/pkg/apis"
@@ -124,3 +125,52 @@ func TestResultsValidateError(t *testing.T) {
 		})
 	}
 }
+
+func TestResultsValidate_File(t *testing.T) {
+	result := v1beta1.TaskResult{
+		Name:        "report",
+		Type:        v1beta1.ResultsTypeFile,
+		Description: "the test report",
+	}
+	if err := result.Validate(config.EnableAlphaAPIFields(context.Background())); err != nil {
+		t.Errorf("TaskResult.Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		result        v1beta1.TaskResult
+		ctx           context.Context
+		expectedError apis.FieldError
+	}{{
+		name:   "not in alpha",
+		result: result,
+		ctx:    context.Background(),
+		expectedError: apis.FieldError{
+			Message: `file results requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+			Paths:   []string{""},
+		},
+	}, {
+		name: "properties",
+		result: v1beta1.TaskResult{
+			Name:       "report",
+			Type:       v1beta1.ResultsTypeFile,
+			Properties: map[string]v1beta1.PropertySpec{"size": {Type: v1beta1.ParamTypeString}},
+		},
+		ctx: config.EnableAlphaAPIFields(context.Background()),
+		expectedError: apis.FieldError{
+			Message: "must not set the field(s)",
+			Paths:   []string{"report.properties"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.result.Validate(tt.ctx)
+			if err == nil {
+				t.Fatalf("Expected an error, got nothing for %v", tt.result)
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("TaskResult.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
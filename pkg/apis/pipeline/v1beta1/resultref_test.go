@@ -650,6 +650,16 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 			}, {
 				Value: *v1beta1.NewStructuredValues("$(tasks.pt7.results.r7)", "$(tasks.pt8.results.r8)"),
 			}}},
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+			Name:    "source",
+			SubPath: "$(context.pipelineRun.uid)/$(tasks.pt10.results.r10)",
+		}},
+		ResultFiles: []v1beta1.ResultFile{{
+			Path:  "/inputs/r11.json",
+			Value: "$(tasks.pt11.results.r11)",
+		}},
+		RetriesFrom: "$(tasks.pt12.results.r12)",
+		TimeoutFrom: "$(tasks.pt13.results.r13)m",
 	}
 	refs := v1beta1.PipelineTaskResultRefs(&pt)
 	expectedRefs := []*v1beta1.ResultRef{{
@@ -679,6 +689,18 @@ func TestPipelineTaskResultRefs(t *testing.T) {
 	}, {
 		PipelineTask: "pt9",
 		Result:       "r9",
+	}, {
+		PipelineTask: "pt10",
+		Result:       "r10",
+	}, {
+		PipelineTask: "pt11",
+		Result:       "r11",
+	}, {
+		PipelineTask: "pt12",
+		Result:       "r12",
+	}, {
+		PipelineTask: "pt13",
+		Result:       "r13",
 	}}
 	if d := cmp.Diff(refs, expectedRefs, cmpopts.SortSlices(lessResultRef)); d != "" {
 		t.Errorf("%v", d)
@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPipelineTask_RetriesAndTimeoutJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		json string
+		want PipelineTask
+	}{{
+		name: "integer retries and duration",
+		json: `{"name":"build","retries":3,"timeout":"1h30m0s"}`,
+		want: PipelineTask{Name: "build", Retries: 3, Timeout: &metav1.Duration{Duration: 90 * time.Minute}},
+	}, {
+		name: "variables",
+		json: `{"name":"build","retriesFrom":"$(params.retryCount)","timeoutFrom":"$(tasks.setup.results.timeout)"}`,
+		want: PipelineTask{Name: "build", RetriesFrom: "$(params.retryCount)", TimeoutFrom: "$(tasks.setup.results.timeout)"},
+	}, {
+		name: "unset",
+		json: `{"name":"build"}`,
+		want: PipelineTask{Name: "build"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got PipelineTask
+			if err := json.Unmarshal([]byte(tc.json), &got); err != nil {
+				t.Fatalf("Unmarshal() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unmarshal() %s", diff.PrintWantGot(d))
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatalf("Marshal() = %v", err)
+			}
+			if d := cmp.Diff(tc.json, string(b)); d != "" {
+				t.Errorf("Marshal() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineTask_GetRetries(t *testing.T) {
+	for _, tc := range []struct {
+		pt   PipelineTask
+		want int
+	}{
+		{pt: PipelineTask{}, want: 0},
+		{pt: PipelineTask{Retries: 2}, want: 2},
+		{pt: PipelineTask{RetriesFrom: "0"}, want: 0},
+		{pt: PipelineTask{RetriesFrom: "5"}, want: 5},
+	} {
+		got, err := tc.pt.GetRetries()
+		if err != nil {
+			t.Fatalf("GetRetries() of %+v = %v", tc.pt, err)
+		}
+		if got != tc.want {
+			t.Errorf("GetRetries() of %+v = %d, want %d", tc.pt, got, tc.want)
+		}
+	}
+	for _, r := range []string{"-1", "three", "$(params.retryCount)"} {
+		if _, err := (PipelineTask{RetriesFrom: r}).GetRetries(); err == nil {
+			t.Errorf("expected GetRetries() of retriesFrom %q to fail", r)
+		}
+	}
+}
+
+func TestPipelineTask_GetTimeout(t *testing.T) {
+	for _, tc := range []struct {
+		pt   PipelineTask
+		want *metav1.Duration
+	}{
+		{pt: PipelineTask{}, want: nil},
+		{pt: PipelineTask{Timeout: &metav1.Duration{Duration: time.Hour}}, want: &metav1.Duration{Duration: time.Hour}},
+		{pt: PipelineTask{TimeoutFrom: "0"}, want: &metav1.Duration{Duration: 0}},
+		{pt: PipelineTask{TimeoutFrom: "1h30m"}, want: &metav1.Duration{Duration: 90 * time.Minute}},
+	} {
+		got, err := tc.pt.GetTimeout()
+		if err != nil {
+			t.Fatalf("GetTimeout() of %+v = %v", tc.pt, err)
+		}
+		if d := cmp.Diff(tc.want, got); d != "" {
+			t.Errorf("GetTimeout() of %+v %s", tc.pt, diff.PrintWantGot(d))
+		}
+	}
+	for _, tv := range []string{"-1m", "1 hour", "$(params.timeout)"} {
+		if _, err := (PipelineTask{TimeoutFrom: tv}).GetTimeout(); err == nil {
+			t.Errorf("expected GetTimeout() of timeoutFrom %q to fail", tv)
+		}
+	}
+}
@@ -115,6 +115,10 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec, meta *meta
 	}
 	sink.DisplayName = ts.DisplayName
 	sink.Description = ts.Description
+	sink.ExitCodeMappings = nil
+	for _, m := range ts.ExitCodeMappings {
+		sink.ExitCodeMappings = append(sink.ExitCodeMappings, v1.ExitCodeMapping(m))
+	}
 	return nil
 }
 
@@ -175,6 +179,10 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec, meta *
 	}
 	ts.DisplayName = source.DisplayName
 	ts.Description = source.Description
+	ts.ExitCodeMappings = nil
+	for _, m := range source.ExitCodeMappings {
+		ts.ExitCodeMappings = append(ts.ExitCodeMappings, ExitCodeMapping(m))
+	}
 	return nil
 }
 
@@ -107,6 +107,20 @@ spec:
       path: /path
     stderrConfig:
       path: /another-path
+    scratch:
+    - name: scratch
+      mountPath: /scratch
+      size: 1Gi
+      medium: Memory
+    memoryProfile: buildHeavy
+    sandbox:
+      seccomp:
+        type: Localhost
+        localhostProfile: profiles/buildah.json
+      appArmor: unconfined
+      seLinux:
+        type: container_runtime_t
+      userNamespace: true
   stepTemplate:
     image: foo
     command: ["hello"]
@@ -160,6 +174,14 @@ spec:
     default:
       type: string
       stringVal: bar
+  - name: param-2
+    type: string
+    enum: [staging, prod]
+    pattern: "^[a-z]+$"
+  - name: param-3
+    type: string
+    minimum: 1
+    maximum: 2.5
   workspaces:
   - name: workspace
     description: a workspace
@@ -172,6 +194,9 @@ spec:
     properties:
       property: {type: string}
     description: description
+  exitCodeMappings:
+  - exitCode: 3
+    reason: SucceededWithWarnings
 `
 
 	taskWithDeprecatedFieldsV1beta1YAML := `
@@ -120,6 +120,23 @@ type TaskSpec struct {
 	// Results are values that this Task can output
 	// +listType=atomic
 	Results []TaskResult `json:"results,omitempty"`
+
+	// ExitCodeMappings map the exit codes of the last step to the reasons of the
+	// "Succeeded" condition of the TaskRun, which succeeds when the last step exits
+	// with one of these exit codes, e.g. 3 to SucceededWithWarnings.
+	// +optional
+	// +listType=atomic
+	ExitCodeMappings []ExitCodeMapping `json:"exitCodeMappings,omitempty"`
+}
+
+// ExitCodeMapping maps an exit code of the last step of a Task to the reason of the
+// "Succeeded" condition of the TaskRun
+type ExitCodeMapping struct {
+	// ExitCode is the exit code of the last step
+	ExitCode int32 `json:"exitCode"`
+	// Reason is the reason of the "Succeeded" condition of the TaskRun when the last
+	// step exits with the exit code
+	Reason string `json:"reason"`
 }
 
 // TaskList contains a list of Task
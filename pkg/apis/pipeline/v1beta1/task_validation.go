@@ -24,6 +24,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
@@ -59,13 +60,18 @@ func (t *Task) SupportedVerbs() []admissionregistrationv1.OperationType {
 var stringAndArrayVariableNameFormatRegex = regexp.MustCompile(stringAndArrayVariableNameFormat)
 var objectVariableNameFormatRegex = regexp.MustCompile(objectVariableNameFormat)
 
+// exitCodeReasonRegex matches the reasons the exit codes of the last step are mapped to,
+// which are CamelCase like the other reasons of conditions
+var exitCodeReasonRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
 // Validate implements apis.Validatable
 func (t *Task) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(t.GetObjectMeta()).ViaField("metadata")
 	errs = errs.Also(t.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
 	// When a Task is created directly, instead of declared inline in a TaskRun or PipelineRun,
 	// we do not support propagated parameters. Validate that all params it uses are declared.
-	return errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	errs = errs.Also(ValidateUsageOfDeclaredParameters(ctx, t.Spec.Steps, t.Spec.Params).ViaField("spec"))
+	return errs.Also(validate.Warnings(ctx, t))
 }
 
 // Validate implements apis.Validatable
@@ -76,6 +82,7 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(ValidateVolumes(ts.Volumes).ViaField("volumes"))
 	errs = errs.Also(validateDeclaredWorkspaces(ts.Workspaces, ts.Steps, ts.StepTemplate).ViaField("workspaces"))
+	errs = errs.Also(validateWorkspaceDigests(ctx, ts.Workspaces).ViaField("workspaces"))
 	errs = errs.Also(validateWorkspaceUsages(ctx, ts))
 	mergedSteps, err := MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
 	if err != nil {
@@ -87,12 +94,16 @@ func (ts *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(validateSteps(ctx, mergedSteps).ViaField("steps"))
+	errs = errs.Also(validateImageDigests(ctx, mergedSteps, ts.Sidecars))
 	errs = errs.Also(validateSidecarNames(ts.Sidecars))
+	errs = errs.Also(validateSidecarResults(ctx, ts.Sidecars, ts.Results))
+	errs = errs.Also(validateSidecarShutdown(ctx, ts.Sidecars))
 	errs = errs.Also(ValidateParameterTypes(ctx, ts.Params).ViaField("params"))
 	errs = errs.Also(ValidateParameterVariables(ctx, ts.Steps, ts.Params))
 	errs = errs.Also(validateTaskContextVariables(ctx, ts.Steps))
 	errs = errs.Also(validateTaskResultsVariables(ctx, ts.Steps, ts.Results))
 	errs = errs.Also(validateResults(ctx, ts.Results).ViaField("results"))
+	errs = errs.Also(validateExitCodeMappings(ctx, ts.ExitCodeMappings).ViaField("exitCodeMappings"))
 	if ts.Resources != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("resources"))
 	}
@@ -123,7 +134,7 @@ func validateObjectParamsHaveProperties(ctx context.Context, params ParamSpecs)
 
 func validateSidecarNames(sidecars []Sidecar) (errs *apis.FieldError) {
 	for _, sc := range sidecars {
-		if sc.Name == pipeline.ReservedResultsSidecarName {
+		if sc.Name == pipeline.ReservedResultsSidecarName || sc.Name == pipeline.ReservedLogForwarderSidecarName {
 			errs = errs.Also(&apis.FieldError{
 				Message: fmt.Sprintf("Invalid: cannot use reserved sidecar name %v ", sc.Name),
 				Paths:   []string{"sidecars"},
@@ -133,6 +144,81 @@ func validateSidecarNames(sidecars []Sidecar) (errs *apis.FieldError) {
 	return errs
 }
 
+// validateSidecarResults validates that the results written by the sidecars are
+// declared by the Task, and that each of them is written by a single sidecar.
+func validateSidecarResults(ctx context.Context, sidecars []Sidecar, results []TaskResult) (errs *apis.FieldError) {
+	declared := sets.NewString()
+	files := sets.NewString()
+	for _, r := range results {
+		declared.Insert(r.Name)
+		if r.Type == ResultsTypeFile {
+			files.Insert(r.Name)
+		}
+	}
+	written := sets.NewString()
+	for i, sc := range sidecars {
+		if len(sc.Results) == 0 {
+			continue
+		}
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "sidecar results", config.AlphaAPIFields).ViaFieldIndex("sidecars", i))
+		for j, r := range sc.Results {
+			switch {
+			case !declared.Has(r.Name):
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("undefined result %q", r.Name), "name").ViaFieldIndex("results", j).ViaFieldIndex("sidecars", i))
+			case files.Has(r.Name):
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q of type file cannot be written by a sidecar", r.Name), "name").ViaFieldIndex("results", j).ViaFieldIndex("sidecars", i))
+			case written.Has(r.Name):
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("result %q is written by more than one sidecar", r.Name), "name").ViaFieldIndex("results", j).ViaFieldIndex("sidecars", i))
+			}
+			written.Insert(r.Name)
+		}
+	}
+	return errs
+}
+
+// stopSignals are the signals which can be sent to stop a Sidecar.
+var stopSignals = sets.NewString("SIGHUP", "SIGINT", "SIGQUIT", "SIGTERM", "SIGUSR1", "SIGUSR2")
+
+// validateSidecarShutdown validates the graceful shutdown of the sidecars.
+func validateSidecarShutdown(ctx context.Context, sidecars []Sidecar) (errs *apis.FieldError) {
+	for i, sc := range sidecars {
+		if sc.Shutdown == nil {
+			continue
+		}
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "sidecar shutdown", config.AlphaAPIFields).ViaFieldIndex("sidecars", i))
+		if sc.Shutdown.StopSignal != "" && !stopSignals.Has(sc.Shutdown.StopSignal) {
+			errs = errs.Also(apis.ErrInvalidValue(sc.Shutdown.StopSignal, "stopSignal", fmt.Sprintf("must be one of %s", strings.Join(stopSignals.List(), ", "))).ViaField("shutdown").ViaFieldIndex("sidecars", i))
+		}
+		if sc.Shutdown.GracePeriod != nil && sc.Shutdown.GracePeriod.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", sc.Shutdown.GracePeriod.Duration.String()), "gracePeriod").ViaField("shutdown").ViaFieldIndex("sidecars", i))
+		}
+	}
+	return errs
+}
+
+// validateExitCodeMappings validates the mappings of the exit codes of the last step to the
+// reasons of the TaskRun's condition, which is an alpha feature.
+func validateExitCodeMappings(ctx context.Context, mappings []ExitCodeMapping) (errs *apis.FieldError) {
+	if len(mappings) == 0 {
+		return nil
+	}
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "exitCodeMappings", config.AlphaAPIFields))
+	exitCodes := make(map[int32]int)
+	for i, m := range mappings {
+		if m.ExitCode < 0 || m.ExitCode > 255 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(m.ExitCode, 0, 255, "exitCode").ViaIndex(i))
+		}
+		if prev, ok := exitCodes[m.ExitCode]; ok {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("exit code %d mapped more than once, at index %d and %d", m.ExitCode, prev, i), "exitCode").ViaIndex(i))
+		}
+		exitCodes[m.ExitCode] = i
+		if !exitCodeReasonRegex.MatchString(m.Reason) {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%q must be a CamelCase reason, e.g. SucceededWithWarnings", m.Reason), "reason").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
 func validateResults(ctx context.Context, results []TaskResult) (errs *apis.FieldError) {
 	for index, result := range results {
 		errs = errs.Also(result.Validate(ctx).ViaIndex(index))
@@ -173,6 +259,17 @@ func validateDeclaredWorkspaces(workspaces []WorkspaceDeclaration, steps []Step,
 	return errs
 }
 
+// validateWorkspaceDigests checks that workspace digests are only requested
+// when alpha API fields are enabled.
+func validateWorkspaceDigests(ctx context.Context, workspaces []WorkspaceDeclaration) (errs *apis.FieldError) {
+	for idx, w := range workspaces {
+		if w.Digest {
+			errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "workspace digest", config.AlphaAPIFields).ViaIndex(idx))
+		}
+	}
+	return errs
+}
+
 // validateWorkspaceUsages checks that all WorkspaceUsage objects in Steps
 // refer to workspaces that are defined in the Task.
 //
@@ -307,6 +404,139 @@ func validateStep(ctx context.Context, s Step, names sets.String) (errs *apis.Fi
 	if s.StderrConfig != nil {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step stderr stream support", config.AlphaAPIFields).ViaField("stderrconfig"))
 	}
+	// Interpolation is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.Interpolation != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step script interpolation", config.AlphaAPIFields).ViaField("interpolation"))
+		if s.Interpolation != LiteralInterpolation && s.Interpolation != ShellSafeInterpolation {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid value: \"%v\"", s.Interpolation),
+				Paths:   []string{"interpolation"},
+				Details: "Task step interpolation must be either \"literal\" or \"shellSafe\"",
+			})
+		}
+		if s.Script == "" {
+			errs = errs.Also(apis.ErrGeneric("interpolation can only be set with script", "interpolation"))
+		}
+	}
+	// Scratch is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if len(s.Scratch) > 0 {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step scratch volumes", config.AlphaAPIFields).ViaField("scratch"))
+		errs = errs.Also(validateStepScratchVolumes(s))
+	}
+	// MemoryProfile is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.MemoryProfile != "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step memory profile", config.AlphaAPIFields).ViaField("memoryProfile"))
+		if s.MemoryProfile != BuildHeavyMemoryProfile {
+			errs = errs.Also(&apis.FieldError{
+				Message: fmt.Sprintf("invalid value: \"%v\"", s.MemoryProfile),
+				Paths:   []string{"memoryProfile"},
+				Details: "Task step memoryProfile must be \"buildHeavy\"",
+			})
+		}
+	}
+	// Sandbox is an alpha feature and will fail validation if it's used in a task spec
+	// when the enable-api-fields feature gate is not "alpha".
+	if s.Sandbox != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "step sandbox", config.AlphaAPIFields).ViaField("sandbox"))
+		errs = errs.Also(validateStepSandbox(s))
+	}
+	return errs
+}
+
+// validateImageDigests validates that the images of the steps and the sidecars are referenced
+// by digest when the "step-image-digests" feature flag is set to "require". The images
+// referencing variables are validated once the variables are substituted.
+func validateImageDigests(ctx context.Context, steps []Step, sidecars []Sidecar) (errs *apis.FieldError) {
+	if config.FromContextOrDefaults(ctx).FeatureFlags.StepImageDigests != config.StepImageDigestsRequire {
+		return nil
+	}
+	for i, s := range steps {
+		errs = errs.Also(validateImageDigest(s.Image).ViaFieldIndex("steps", i))
+	}
+	for i, sc := range sidecars {
+		errs = errs.Also(validateImageDigest(sc.Image).ViaFieldIndex("sidecars", i))
+	}
+	return errs
+}
+
+func validateImageDigest(image string) *apis.FieldError {
+	if image == "" || strings.Contains(image, "$(") {
+		return nil
+	}
+	if _, err := name.NewDigest(image, name.WeakValidation); err != nil {
+		return apis.ErrInvalidValue(image, "image", `image must be referenced by digest when "step-image-digests" is "require"`)
+	}
+	return nil
+}
+
+// validateStepSandbox validates the security profiles of a Step, which must not be
+// declared in its securityContext too.
+func validateStepSandbox(s Step) (errs *apis.FieldError) {
+	sandbox := s.Sandbox
+	if sandbox.Seccomp != nil {
+		switch sandbox.Seccomp.Type {
+		case corev1.SeccompProfileTypeRuntimeDefault, corev1.SeccompProfileTypeUnconfined:
+			if sandbox.Seccomp.LocalhostProfile != nil {
+				errs = errs.Also(apis.ErrDisallowedFields("localhostProfile").ViaField("sandbox", "seccomp"))
+			}
+		case corev1.SeccompProfileTypeLocalhost:
+			if sandbox.Seccomp.LocalhostProfile == nil || *sandbox.Seccomp.LocalhostProfile == "" {
+				errs = errs.Also(apis.ErrMissingField("localhostProfile").ViaField("sandbox", "seccomp"))
+			}
+		default:
+			errs = errs.Also(apis.ErrInvalidValue(sandbox.Seccomp.Type, "type", `type must be one of "RuntimeDefault", "Unconfined" or "Localhost"`).ViaField("sandbox", "seccomp"))
+		}
+		if s.SecurityContext != nil && s.SecurityContext.SeccompProfile != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("securityContext.seccompProfile", "sandbox.seccomp"))
+		}
+	}
+	if sandbox.AppArmor != "" && sandbox.AppArmor != AppArmorRuntimeDefault && sandbox.AppArmor != AppArmorUnconfined &&
+		(!strings.HasPrefix(sandbox.AppArmor, AppArmorLocalhostPrefix) || sandbox.AppArmor == AppArmorLocalhostPrefix) {
+		errs = errs.Also(apis.ErrInvalidValue(sandbox.AppArmor, "appArmor", `appArmor must be one of "runtime/default", "unconfined" or "localhost/<profile>"`).ViaField("sandbox"))
+	}
+	if sandbox.SELinux != nil && s.SecurityContext != nil && s.SecurityContext.SELinuxOptions != nil {
+		errs = errs.Also(apis.ErrMultipleOneOf("securityContext.seLinuxOptions", "sandbox.seLinux"))
+	}
+	return errs
+}
+
+// validateStepScratchVolumes validates the scratch volumes of a Step, which are
+// mounted alongside its volumeMounts.
+func validateStepScratchVolumes(s Step) (errs *apis.FieldError) {
+	mountPaths := sets.NewString()
+	for _, vm := range s.VolumeMounts {
+		mountPaths.Insert(filepath.Clean(vm.MountPath))
+	}
+	names := sets.NewString()
+	for i, sv := range s.Scratch {
+		if e := validation.IsDNS1123Label(sv.Name); len(e) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(sv.Name, "name", strings.Join(e, ", ")).ViaFieldIndex("scratch", i))
+		} else if names.Has(sv.Name) {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("scratch volume name %q must be unique", sv.Name), "name").ViaFieldIndex("scratch", i))
+		}
+		names.Insert(sv.Name)
+
+		mountPath := filepath.Clean(sv.MountPath)
+		switch {
+		case !filepath.IsAbs(sv.MountPath):
+			errs = errs.Also(apis.ErrInvalidValue(sv.MountPath, "mountPath", "mountPath must be an absolute path").ViaFieldIndex("scratch", i))
+		case strings.HasPrefix(mountPath, "/tekton/") && !strings.HasPrefix(mountPath, "/tekton/home"):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("scratch volume cannot be mounted under /tekton/ (scratch volume %q mounted at %q)", sv.Name, sv.MountPath), "mountPath").ViaFieldIndex("scratch", i))
+		case mountPaths.Has(mountPath):
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("mountPath %q is already used by another volume of the step", sv.MountPath), "mountPath").ViaFieldIndex("scratch", i))
+		}
+		mountPaths.Insert(mountPath)
+
+		if sv.Size.Sign() <= 0 {
+			errs = errs.Also(apis.ErrInvalidValue(sv.Size.String(), "size", "size must be greater than zero").ViaFieldIndex("scratch", i))
+		}
+		if sv.Medium != corev1.StorageMediumDefault && sv.Medium != corev1.StorageMediumMemory {
+			errs = errs.Also(apis.ErrInvalidValue(sv.Medium, "medium", `medium must be either "" or "Memory"`).ViaFieldIndex("scratch", i))
+		}
+	}
 	return errs
 }
 
@@ -331,8 +561,14 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 		return apis.ErrInvalidValue(p.Type, fmt.Sprintf("%s.type", p.Name))
 	}
 
+	if p.Type == ParamTypeSecret {
+		if err := version.ValidateEnabledAPIFields(ctx, "secret type parameter", config.AlphaAPIFields); err != nil {
+			return err
+		}
+	}
+
 	// If a default value is provided, ensure its type matches param's declared type.
-	if (p.Default != nil) && (p.Default.Type != p.Type) {
+	if (p.Default != nil) && (p.Default.Type != p.Type.ValueType()) {
 		return &apis.FieldError{
 			Message: fmt.Sprintf(
 				"\"%v\" type does not match default value's type: \"%v\"", p.Type, p.Default.Type),
@@ -344,7 +580,68 @@ func (p ParamSpec) ValidateType(ctx context.Context) *apis.FieldError {
 	}
 
 	// Check object type and its PropertySpec type
-	return p.ValidateObjectType(ctx)
+	if err := p.ValidateObjectType(ctx); err != nil {
+		return err
+	}
+	if p.Type == ParamTypeSecret && p.Default != nil {
+		if err := ValidateSecretParamValue(*p.Default); err != nil {
+			return err.ViaField(fmt.Sprintf("%s.default", p.Name))
+		}
+	}
+	return p.validateConstraints(ctx)
+}
+
+// ValidateSecretParamValue checks that the value of a secret param references a key of a
+// Secret, with the keys "name" and "key" and no other key.
+func ValidateSecretParamValue(value ParamValue) (errs *apis.FieldError) {
+	for _, key := range []string{"name", "key"} {
+		if value.ObjectVal[key] == "" {
+			errs = errs.Also(apis.ErrMissingField(key))
+		}
+	}
+	for key := range value.ObjectVal {
+		if key != "name" && key != "key" {
+			errs = errs.Also(apis.ErrDisallowedFields(key))
+		}
+	}
+	return errs
+}
+
+// validateConstraints checks that the enum, the pattern, the minimum and the maximum of a
+// ParamSpec are enabled and valid, and that its default value satisfies them.
+func (p ParamSpec) validateConstraints(ctx context.Context) (errs *apis.FieldError) {
+	constraints := map[string]bool{
+		"enum":    len(p.Enum) > 0,
+		"pattern": p.Pattern != "",
+		"minimum": p.Minimum != nil,
+		"maximum": p.Maximum != nil,
+	}
+	for _, field := range []string{"enum", "pattern", "minimum", "maximum"} {
+		if !constraints[field] {
+			continue
+		}
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, fmt.Sprintf("%s.%s", p.Name, field), config.AlphaAPIFields))
+		if p.Type == ParamTypeObject || p.Type == ParamTypeSecret {
+			errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s can't be set on a param of type %s", field, p.Type), fmt.Sprintf("%s.%s", p.Name, field)))
+		}
+	}
+	if len(p.Enum) != len(sets.NewString(p.Enum...)) {
+		errs = errs.Also(apis.ErrGeneric("enum values must be unique", fmt.Sprintf("%s.enum", p.Name)))
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(p.Pattern, fmt.Sprintf("%s.pattern", p.Name), err.Error()))
+		}
+	}
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		errs = errs.Also(apis.ErrGeneric("minimum must be less than or equal to maximum", fmt.Sprintf("%s.minimum", p.Name), fmt.Sprintf("%s.maximum", p.Name)))
+	}
+	if errs == nil && p.Default != nil {
+		if err := p.ValidateValue(*p.Default); err != nil {
+			errs = errs.Also(apis.ErrGeneric(err.Error(), fmt.Sprintf("%s.default", p.Name)))
+		}
+	}
+	return errs
 }
 
 // ValidateObjectType checks that object type parameter does not miss the
@@ -385,6 +682,7 @@ func validateTaskContextVariables(ctx context.Context, steps []Step) *apis.Field
 		"name",
 		"namespace",
 		"uid",
+		"retryCount",
 	)
 	taskContextNames := sets.NewString().Insert(
 		"name",
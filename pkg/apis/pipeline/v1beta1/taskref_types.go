@@ -52,6 +52,9 @@ const (
 	NamespacedTaskKind TaskKind = "Task"
 	// ClusterTaskKind indicates that task type has a cluster scope.
 	ClusterTaskKind TaskKind = "ClusterTask"
+	// TaskGroupKind indicates that the task type is a TaskGroup, which inlines
+	// several Tasks into a single one.
+	TaskGroupKind TaskKind = "TaskGroup"
 )
 
 // IsCustomTask checks whether the reference is to a Custom Task
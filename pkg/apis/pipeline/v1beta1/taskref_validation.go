@@ -20,6 +20,8 @@ import (
 	"context"
 
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
@@ -62,5 +64,11 @@ func (ref *TaskRef) Validate(ctx context.Context) (errs *apis.FieldError) {
 			}
 		}
 	}
+	if ref.Kind == TaskGroupKind && ref.APIVersion == "" {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "TaskGroup", config.AlphaAPIFields))
+		if ref.Resolver != "" || ref.Bundle != "" {
+			errs = errs.Also(apis.ErrInvalidValue("TaskGroups can only be referred to by name", "kind"))
+		}
+	}
 	return //nolint:nakedret
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	"knative.dev/pkg/apis"
@@ -60,6 +61,10 @@ func TestTaskRef_Valid(t *testing.T) {
 			Name:   "bundled-task",
 			Bundle: "gcr.io/my-bundle"},
 		wc: enableTektonOCIBundles(t),
+	}, {
+		name:    "alpha feature: taskgroup",
+		taskRef: &v1beta1.TaskRef{Name: "clone-and-build", Kind: v1beta1.TaskGroupKind},
+		wc:      config.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
@@ -164,6 +169,20 @@ func TestTaskRef_Invalid(t *testing.T) {
 		},
 		wantErr: apis.ErrMultipleOneOf("bundle", "params").Also(apis.ErrMissingField("resolver")),
 		wc:      enableTektonOCIBundles(t),
+	}, {
+		name:    "taskgroup disallowed without alpha feature gate",
+		taskRef: &v1beta1.TaskRef{Name: "clone-and-build", Kind: v1beta1.TaskGroupKind},
+		wantErr: apis.ErrGeneric("TaskGroup requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "taskgroup disallowed with resolver",
+		taskRef: &v1beta1.TaskRef{
+			Kind: v1beta1.TaskGroupKind,
+			ResolverRef: v1beta1.ResolverRef{
+				Resolver: "git",
+			},
+		},
+		wantErr: apis.ErrInvalidValue("TaskGroups can only be referred to by name", "kind"),
+		wc:      config.EnableAlphaAPIFields,
 	}}
 	for _, ts := range tests {
 		t.Run(ts.name, func(t *testing.T) {
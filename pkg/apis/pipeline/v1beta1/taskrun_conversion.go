@@ -80,6 +80,11 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec, met
 	sink.Status = v1.TaskRunSpecStatus(trs.Status)
 	sink.StatusMessage = v1.TaskRunSpecStatusMessage(trs.StatusMessage)
 	sink.Retries = trs.Retries
+	sink.RepeatUntil = nil
+	if trs.RepeatUntil != nil {
+		sink.RepeatUntil = &v1.RepeatUntil{}
+		trs.RepeatUntil.convertTo(ctx, sink.RepeatUntil)
+	}
 	sink.Timeout = trs.Timeout
 	sink.PodTemplate = trs.PodTemplate
 	sink.Workspaces = nil
@@ -88,6 +93,12 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec, met
 		w.convertTo(ctx, &new)
 		sink.Workspaces = append(sink.Workspaces, new)
 	}
+	sink.ResultFiles = nil
+	for _, rf := range trs.ResultFiles {
+		new := v1.ResultFile{}
+		rf.convertTo(ctx, &new)
+		sink.ResultFiles = append(sink.ResultFiles, new)
+	}
 	sink.StepSpecs = nil
 	for _, so := range trs.StepOverrides {
 		new := v1.TaskRunStepSpec{}
@@ -101,6 +112,8 @@ func (trs *TaskRunSpec) ConvertTo(ctx context.Context, sink *v1.TaskRunSpec, met
 		sink.SidecarSpecs = append(sink.SidecarSpecs, new)
 	}
 	sink.ComputeResources = trs.ComputeResources
+	sink.TTLSecondsAfterFinished = trs.TTLSecondsAfterFinished
+	sink.Hermetic = trs.Hermetic
 	return nil
 }
 
@@ -154,6 +167,11 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec,
 	trs.Status = TaskRunSpecStatus(source.Status)
 	trs.StatusMessage = TaskRunSpecStatusMessage(source.StatusMessage)
 	trs.Retries = source.Retries
+	trs.RepeatUntil = nil
+	if source.RepeatUntil != nil {
+		trs.RepeatUntil = &RepeatUntil{}
+		trs.RepeatUntil.convertFrom(ctx, *source.RepeatUntil)
+	}
 	trs.Timeout = source.Timeout
 	trs.PodTemplate = source.PodTemplate
 	trs.Workspaces = nil
@@ -162,6 +180,12 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec,
 		new.convertFrom(ctx, w)
 		trs.Workspaces = append(trs.Workspaces, new)
 	}
+	trs.ResultFiles = nil
+	for _, rf := range source.ResultFiles {
+		new := ResultFile{}
+		new.convertFrom(ctx, rf)
+		trs.ResultFiles = append(trs.ResultFiles, new)
+	}
 	trs.StepOverrides = nil
 	for _, so := range source.StepSpecs {
 		new := TaskRunStepOverride{}
@@ -175,6 +199,8 @@ func (trs *TaskRunSpec) ConvertFrom(ctx context.Context, source *v1.TaskRunSpec,
 		trs.SidecarOverrides = append(trs.SidecarOverrides, new)
 	}
 	trs.ComputeResources = source.ComputeResources
+	trs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
+	trs.Hermetic = source.Hermetic
 	return nil
 }
 
@@ -252,6 +278,39 @@ func (trs *TaskRunStatus) ConvertTo(ctx context.Context, sink *v1.TaskRunStatus,
 		trs.Provenance.convertTo(ctx, &new)
 		sink.Provenance = &new
 	}
+	sink.Workspaces = nil
+	for _, ws := range trs.Workspaces {
+		new := v1.WorkspaceStatus{}
+		ws.convertTo(ctx, &new)
+		sink.Workspaces = append(sink.Workspaces, new)
+	}
+	sink.StepResourceUsage = nil
+	for _, u := range trs.StepResourceUsage {
+		new := v1.StepResourceUsage{}
+		u.convertTo(ctx, &new)
+		sink.StepResourceUsage = append(sink.StepResourceUsage, new)
+	}
+	sink.StepLogs = nil
+	for _, l := range trs.StepLogs {
+		sink.StepLogs = append(sink.StepLogs, v1.StepLog{Name: l.Name, Container: l.ContainerName, URL: l.URL})
+	}
+	sink.ResolvedParams = nil
+	for _, p := range trs.ResolvedParams {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.ResolvedParams = append(sink.ResolvedParams, new)
+	}
+	sink.StepProgress = nil
+	for _, p := range trs.StepProgress {
+		sink.StepProgress = append(sink.StepProgress, v1.StepProgress{Name: p.Name, Container: p.ContainerName, Phase: p.Phase, Percent: p.Percent, LastUpdateTime: p.LastUpdateTime})
+	}
+	if trs.FaultInjection != nil {
+		new := v1.FaultInjectionStatus{Seed: trs.FaultInjection.Seed}
+		for _, f := range trs.FaultInjection.Faults {
+			new.Faults = append(new.Faults, v1.InjectedFault(f))
+		}
+		sink.FaultInjection = &new
+	}
 	return nil
 }
 
@@ -301,9 +360,54 @@ func (trs *TaskRunStatus) ConvertFrom(ctx context.Context, source v1.TaskRunStat
 		new.convertFrom(ctx, *source.Provenance)
 		trs.Provenance = &new
 	}
+	trs.Workspaces = nil
+	for _, ws := range source.Workspaces {
+		new := WorkspaceStatus{}
+		new.convertFrom(ctx, ws)
+		trs.Workspaces = append(trs.Workspaces, new)
+	}
+	trs.StepResourceUsage = nil
+	for _, u := range source.StepResourceUsage {
+		new := StepResourceUsage{}
+		new.convertFrom(ctx, u)
+		trs.StepResourceUsage = append(trs.StepResourceUsage, new)
+	}
+	trs.StepLogs = nil
+	for _, l := range source.StepLogs {
+		trs.StepLogs = append(trs.StepLogs, StepLog{Name: l.Name, ContainerName: l.Container, URL: l.URL})
+	}
+	trs.ResolvedParams = nil
+	for _, p := range source.ResolvedParams {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		trs.ResolvedParams = append(trs.ResolvedParams, new)
+	}
+	trs.StepProgress = nil
+	for _, p := range source.StepProgress {
+		trs.StepProgress = append(trs.StepProgress, StepProgress{Name: p.Name, ContainerName: p.Container, Phase: p.Phase, Percent: p.Percent, LastUpdateTime: p.LastUpdateTime})
+	}
+	if source.FaultInjection != nil {
+		new := FaultInjectionStatus{Seed: source.FaultInjection.Seed}
+		for _, f := range source.FaultInjection.Faults {
+			new.Faults = append(new.Faults, InjectedFault(f))
+		}
+		trs.FaultInjection = &new
+	}
 	return nil
 }
 
+func (u StepResourceUsage) convertTo(ctx context.Context, sink *v1.StepResourceUsage) {
+	sink.Name = u.Name
+	sink.Container = u.ContainerName
+	sink.Peak = u.Peak
+}
+
+func (u *StepResourceUsage) convertFrom(ctx context.Context, source v1.StepResourceUsage) {
+	u.Name = source.Name
+	u.ContainerName = source.Container
+	u.Peak = source.Peak
+}
+
 func (ss StepState) convertTo(ctx context.Context, sink *v1.StepState) {
 	sink.ContainerState = ss.ContainerState
 	sink.Name = ss.Name
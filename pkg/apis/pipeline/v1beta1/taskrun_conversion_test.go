@@ -30,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
@@ -51,6 +52,8 @@ func TestTaskRunConversionBadType(t *testing.T) {
 }
 
 func TestTaskRunConversion(t *testing.T) {
+	percent := int32(40)
+	ttl := int32(3600)
 	tests := []struct {
 		name string
 		in   *v1beta1.TaskRun
@@ -65,6 +68,35 @@ func TestTaskRunConversion(t *testing.T) {
 				TaskRef: &v1beta1.TaskRef{Name: "test-task"},
 			},
 		},
+	}, {
+		name: "taskrun with ttlSecondsAfterFinished",
+		in: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef:                 &v1beta1.TaskRef{Name: "test-task"},
+				TTLSecondsAfterFinished: &ttl,
+			},
+		},
+	}, {
+		name: "hermetic taskrun",
+		in: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "foo",
+				Namespace: "bar",
+			},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef:  &v1beta1.TaskRef{Name: "test-task"},
+				Hermetic: true,
+			},
+			Status: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					Provenance: &v1beta1.Provenance{Hermetic: true},
+				},
+			},
+		},
 	}, {
 		name: "taskrun conversion deprecated step fields",
 		in: &v1beta1.TaskRun{
@@ -140,6 +172,18 @@ func TestTaskRunConversion(t *testing.T) {
 						"label": "value",
 					},
 				},
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/config.json",
+					Value: `{"replicas": 3}`,
+				}},
+				RepeatUntil: &v1beta1.RepeatUntil{
+					When: v1beta1.WhenExpressions{{
+						Input:    "$(results.status)",
+						Operator: selection.In,
+						Values:   []string{"ready"},
+					}},
+					Limit: 10,
+				},
 				Workspaces: []v1beta1.WorkspaceBinding{{
 					Name:    "workspace-volumeclaimtemplate",
 					SubPath: "/foo/bar/baz",
@@ -279,7 +323,44 @@ func TestTaskRunConversion(t *testing.T) {
 							Digest: map[string]string{"sha256": "digest"},
 						},
 						FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+						Executions: []v1beta1.StepExecution{{
+							Name:       "build",
+							Argv:       []string{"/tekton/scripts/script-0-abcde"},
+							ImageID:    "docker.io/library/alpine@sha256:1234",
+							StartedAt:  &metav1.Time{Time: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)},
+							FinishedAt: &metav1.Time{Time: time.Date(2023, 5, 1, 10, 1, 0, 0, time.UTC)},
+							ExitCode:   1,
+						}},
+					},
+					StepResourceUsage: []v1beta1.StepResourceUsage{{
+						Name:          "failure",
+						ContainerName: "step-failure",
+						Peak: corev1.ResourceList{
+							corev1.ResourceCPU:    corev1resources.MustParse("250m"),
+							corev1.ResourceMemory: corev1resources.MustParse("128Mi"),
+						},
+					}},
+					StepLogs: []v1beta1.StepLog{{
+						Name:          "failure",
+						ContainerName: "step-failure",
+						URL:           "https://store.example.com/foo/foo-pod/step-failure.log",
 					}},
+					StepProgress: []v1beta1.StepProgress{{
+						Name:           "failure",
+						ContainerName:  "step-failure",
+						Phase:          v1beta1.StepProgressFinished,
+						Percent:        &percent,
+						LastUpdateTime: &metav1.Time{Time: time.Date(2023, 5, 1, 10, 1, 0, 0, time.UTC)},
+					}},
+					FaultInjection: &v1beta1.FaultInjectionStatus{
+						Seed: 42,
+						Faults: []v1beta1.InjectedFault{{
+							Type:    "step-failure",
+							Attempt: 1,
+							Message: `TaskRun "foo" failed because of a fault injected in step "failure"`,
+						}},
+					},
+				},
 			},
 		},
 	}}
@@ -22,11 +22,13 @@ import (
 	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/pkg/apis/version"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/strings/slices"
 	"knative.dev/pkg/apis"
@@ -44,7 +46,33 @@ func (tr *TaskRun) SupportedVerbs() []admissionregistrationv1.OperationType {
 // Validate taskrun
 func (tr *TaskRun) Validate(ctx context.Context) *apis.FieldError {
 	errs := validate.ObjectMetadata(tr.GetObjectMeta()).ViaField("metadata")
-	return errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	errs = errs.Also(validateClusterTaskRef(ctx, tr.Namespace, tr.Spec.TaskRef).ViaField("spec", "taskRef"))
+	errs = errs.Also(tr.validateServiceAccount(ctx))
+	errs = errs.Also(tr.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+	return errs.Also(validate.Warnings(ctx, tr))
+}
+
+// validateServiceAccount checks the service account of a TaskRun being created
+// which is not run by a PipelineRun against the ServiceAccountPolicies of its
+// namespace. The policies only apply to the Tasks referenced by name, so a TaskRun
+// with an embedded taskSpec or a remote taskRef is checked with an empty Task name.
+// The reconciler checks the service account again, including the ones of the
+// TaskRuns run by a PipelineRun, before creating the pod.
+func (tr *TaskRun) validateServiceAccount(ctx context.Context) *apis.FieldError {
+	if !apis.IsInCreate(ctx) || tr.Spec.ServiceAccountName == "" {
+		return nil
+	}
+	if owner := metav1.GetControllerOf(tr); owner != nil && owner.Kind == pipeline.PipelineRunControllerName {
+		return nil
+	}
+	name := ""
+	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Resolver == "" {
+		name = tr.Spec.TaskRef.Name
+	}
+	if err := validate.ServiceAccountAllowed(ctx, tr.Namespace, pipeline.TaskControllerName, name, tr.Spec.ServiceAccountName); err != nil {
+		return apis.ErrGeneric(err.Error(), "spec.serviceAccountName")
+	}
+	return nil
 }
 
 // Validate taskrun spec
@@ -66,10 +94,24 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	}
 
 	errs = errs.Also(ValidateParameters(ctx, ts.Params).ViaField("params"))
+	errs = errs.Also(validateParamValueSources(ctx, ts.Params).ViaField("params"))
 
 	// Validate propagated parameters
 	errs = errs.Also(ts.validateInlineParameters(ctx))
+	// Validate the values of the parameters against the constraints of the embedded TaskSpec
+	if ts.TaskSpec != nil {
+		if err := ts.TaskSpec.Params.ValidateValues(ts.Params); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(err.Error(), "params"))
+		}
+	}
 	errs = errs.Also(ValidateWorkspaceBindings(ctx, ts.Workspaces).ViaField("workspaces"))
+	if ts.ResultFiles != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "resultFiles", config.AlphaAPIFields).ViaField("resultFiles"))
+		errs = errs.Also(validateResultFiles(ts.ResultFiles).ViaField("resultFiles"))
+	}
+	if ts.RepeatUntil != nil {
+		errs = errs.Also(ts.RepeatUntil.Validate(ctx).ViaField("repeatUntil"))
+	}
 	if ts.Debug != nil {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "debug", config.AlphaAPIFields).ViaField("debug"))
 		errs = errs.Also(validateDebug(ts.Debug).ViaField("debug"))
@@ -86,6 +128,10 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "computeResources", config.AlphaAPIFields).ViaField("computeResources"))
 		errs = errs.Also(validateTaskRunComputeResources(ts.ComputeResources, ts.StepOverrides))
 	}
+	errs = errs.Also(validateRetention(ctx, "ttlSecondsAfterFinished", ts.TTLSecondsAfterFinished))
+	if ts.Hermetic {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "hermetic", config.AlphaAPIFields).ViaField("hermetic"))
+	}
 
 	if ts.Status != "" {
 		if ts.Status != TaskRunSpecStatusCancelled {
@@ -181,6 +227,14 @@ func createParamSpecFromParam(p Param, paramSpecForValidation map[string]ParamSp
 
 func combineParamSpec(p ParamSpec, paramSpecForValidation map[string]ParamSpec) (map[string]ParamSpec, *apis.FieldError) {
 	if pSpec, ok := paramSpecForValidation[p.Name]; ok {
+		// The secret params have no properties, their values being the references to the
+		// keys of the Secrets.
+		if p.Type == ParamTypeSecret {
+			pSpec.Type = ParamTypeSecret
+			pSpec.Properties = nil
+			paramSpecForValidation[p.Name] = pSpec
+			return paramSpecForValidation, nil
+		}
 		// Merge defaults with provided values in the taskrun.
 		if p.Default != nil && p.Default.ObjectVal != nil {
 			for k, v := range p.Default.ObjectVal {
@@ -247,6 +301,49 @@ func ValidateParameters(ctx context.Context, params Params) (errs *apis.FieldErr
 	return errs.Also(validateNoDuplicateNames(names, false))
 }
 
+// validateParamValueSources checks that the params taking their values from a ConfigMap or a
+// Secret select exactly one key, and aren't given a value too.
+func validateParamValueSources(ctx context.Context, params Params) (errs *apis.FieldError) {
+	for i, p := range params {
+		if p.ValueFrom == nil {
+			continue
+		}
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "valueFrom", config.AlphaAPIFields).ViaIndex(i))
+		source := p.ValueFrom
+		switch {
+		case source.ConfigMapKeyRef != nil && source.SecretKeyRef != nil:
+			errs = errs.Also(apis.ErrMultipleOneOf("configMapKeyRef", "secretKeyRef").ViaField("valueFrom").ViaIndex(i))
+		case source.ConfigMapKeyRef != nil:
+			errs = errs.Also(validateKeySelector(source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key).ViaField("valueFrom.configMapKeyRef").ViaIndex(i))
+		case source.SecretKeyRef != nil:
+			errs = errs.Also(validateKeySelector(source.SecretKeyRef.Name, source.SecretKeyRef.Key).ViaField("valueFrom.secretKeyRef").ViaIndex(i))
+			// The key of the Secret is mounted in the steps rather than read by the controller,
+			// so it can't fall back to the default value when it doesn't exist.
+			if source.SecretKeyRef.Optional != nil && *source.SecretKeyRef.Optional {
+				errs = errs.Also(apis.ErrDisallowedFields("optional").ViaField("valueFrom.secretKeyRef").ViaIndex(i))
+			}
+		default:
+			errs = errs.Also(apis.ErrMissingOneOf("configMapKeyRef", "secretKeyRef").ViaField("valueFrom").ViaIndex(i))
+		}
+		if p.Value.StringVal != "" || p.Value.ArrayVal != nil || p.Value.ObjectVal != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("value", "valueFrom").ViaIndex(i))
+		}
+	}
+	return errs
+}
+
+// validateKeySelector checks that the selector of the key of a ConfigMap or of a Secret sets
+// the name and the key.
+func validateKeySelector(name, key string) (errs *apis.FieldError) {
+	if name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	if key == "" {
+		errs = errs.Also(apis.ErrMissingField("key"))
+	}
+	return errs
+}
+
 func validateStepOverrides(overrides []TaskRunStepOverride) (errs *apis.FieldError) {
 	var names []string
 	for i, o := range overrides {
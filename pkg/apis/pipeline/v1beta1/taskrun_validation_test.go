@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	corev1resources "k8s.io/apimachinery/pkg/api/resource"
@@ -464,6 +465,80 @@ func TestTaskRun_Validate(t *testing.T) {
 	}
 }
 
+func TestTaskRun_ServiceAccountPolicy(t *testing.T) {
+	policy := func(ctx context.Context, namespace, kind, name, serviceAccount string) error {
+		if kind != "Task" || (name == "kaniko" && serviceAccount == "builder") {
+			return nil
+		}
+		return fmt.Errorf("%q is not allowed for %s %q in namespace %q", serviceAccount, kind, name, namespace)
+	}
+	taskRun := func(name string, ref *v1beta1.TaskRef, sa string) *v1beta1.TaskRun {
+		tr := &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef:            ref,
+				ServiceAccountName: sa,
+			},
+		}
+		if ref == nil {
+			tr.Spec.TaskSpec = &v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{Name: "build", Image: "kaniko"}},
+			}
+		}
+		return tr
+	}
+	runByPipelineRun := taskRun("pr-build", nil, "admin")
+	runByPipelineRun.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "tekton.dev/v1beta1",
+		Kind:       "PipelineRun",
+		Name:       "pr",
+		Controller: &[]bool{true}[0],
+	}}
+	for _, tc := range []struct {
+		desc     string
+		tr       *v1beta1.TaskRun
+		inCreate bool
+		want     *apis.FieldError
+	}{{
+		desc:     "allowed",
+		tr:       taskRun("tr", &v1beta1.TaskRef{Name: "kaniko"}, "builder"),
+		inCreate: true,
+	}, {
+		desc:     "not allowed",
+		tr:       taskRun("tr", &v1beta1.TaskRef{Name: "kaniko"}, "admin"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"admin" is not allowed for Task "kaniko" in namespace "ns"`, "spec.serviceAccountName"),
+	}, {
+		desc:     "embedded task not named after the run",
+		tr:       taskRun("kaniko", nil, "builder"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"builder" is not allowed for Task "" in namespace "ns"`, "spec.serviceAccountName"),
+	}, {
+		desc:     "remote task",
+		tr:       taskRun("tr", &v1beta1.TaskRef{ResolverRef: v1beta1.ResolverRef{Resolver: "git"}}, "builder"),
+		inCreate: true,
+		want:     apis.ErrGeneric(`"builder" is not allowed for Task "" in namespace "ns"`, "spec.serviceAccountName"),
+	}, {
+		desc:     "run by a PipelineRun checked by the reconciler",
+		tr:       runByPipelineRun,
+		inCreate: true,
+	}, {
+		desc: "not checked on update",
+		tr:   taskRun("tr", &v1beta1.TaskRef{Name: "kaniko"}, "admin"),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := validate.WithServiceAccountPolicy(config.EnableAlphaAPIFields(context.Background()), policy)
+			if tc.inCreate {
+				ctx = apis.WithinCreate(ctx)
+			}
+			err := tc.tr.Validate(ctx)
+			if d := cmp.Diff(tc.want.Error(), err.Error()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -514,6 +589,7 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
 	invalidStatusMessage := "status message without status"
+	optional := true
 	tests := []struct {
 		name    string
 		spec    v1beta1.TaskRunSpec
@@ -729,6 +805,51 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 		},
 		wantErr: apis.ErrMissingField("sidecarOverrides[0].name"),
 		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "resultFiles disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:     &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{Path: "/inputs/config.json", Value: "{}"}},
+		},
+		wantErr: apis.ErrGeneric("resultFiles requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "missing resultFiles path",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:     &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{Value: "{}"}},
+		},
+		wantErr: apis.ErrMissingField("resultFiles[0].path"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "relative resultFiles path",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:     &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{Path: "inputs/config.json", Value: "{}"}},
+		},
+		wantErr: apis.ErrInvalidValue(`result file path "inputs/config.json" must be a clean absolute path`, "resultFiles[0].path"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "resultFiles path under /tekton/",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:     &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{Path: "/tekton/results/config.json", Value: "{}"}},
+		},
+		wantErr: apis.ErrInvalidValue(`result file path "/tekton/results/config.json" cannot be under /tekton/`, "resultFiles[0].path"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "duplicate resultFiles paths",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{
+				Path:  "/inputs/config.json",
+				Value: "{}",
+			}, {
+				Path:  "/inputs/config.json",
+				Value: "[]",
+			}},
+		},
+		wantErr: apis.ErrGeneric(`result file path "/inputs/config.json" must be unique`, "resultFiles[1].path"),
+		wc:      config.EnableAlphaAPIFields,
 	}, {
 		name: "invalid both step-level (stepOverrides.resources) and task-level (spec.computeResources) resource requirements",
 		spec: v1beta1.TaskRunSpec{
@@ -783,6 +904,74 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			},
 		},
 		wantErr: apis.ErrDisallowedFields("resources").ViaField("taskSpec"),
+	}, {
+		name: "param value not satisfying the constraints of the embedded task spec",
+		spec: v1beta1.TaskRunSpec{
+			Params: v1beta1.Params{{Name: "env", Value: *v1beta1.NewStructuredValues("qa")}},
+			TaskSpec: &v1beta1.TaskSpec{
+				Params: v1beta1.ParamSpecs{{Name: "env", Type: v1beta1.ParamTypeString, Enum: []string{"staging", "prod"}}},
+				Steps:  []v1beta1.Step{{Image: "my-image", Script: "deploy $(params.env)"}},
+			},
+		},
+		wantErr: apis.ErrInvalidValue(`param "env" value "qa" is not one of the allowed values [staging prod]`, "params"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "hermetic disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "foo"},
+			Hermetic: true,
+		},
+		wantErr: apis.ErrGeneric("hermetic requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\""),
+	}, {
+		name: "param valueFrom disallowed without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			Params: v1beta1.Params{{Name: "branch", ValueFrom: &v1beta1.ParamValueSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}, Key: "branch"},
+			}}},
+		},
+		wantErr: apis.ErrGeneric("valueFrom requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaIndex(0).ViaField("params"),
+	}, {
+		name: "param valueFrom with both a configMapKeyRef and a secretKeyRef",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			Params: v1beta1.Params{{Name: "branch", ValueFrom: &v1beta1.ParamValueSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}, Key: "branch"},
+				SecretKeyRef:    &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "branch"},
+			}}},
+		},
+		wantErr: apis.ErrMultipleOneOf("params[0].valueFrom.configMapKeyRef", "params[0].valueFrom.secretKeyRef"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "param valueFrom without a key",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			Params: v1beta1.Params{{Name: "token", ValueFrom: &v1beta1.ParamValueSource{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}},
+			}}},
+		},
+		wantErr: apis.ErrMissingField("params[0].valueFrom.secretKeyRef.key"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "param valueFrom with an optional secretKeyRef",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			Params: v1beta1.Params{{Name: "token", ValueFrom: &v1beta1.ParamValueSource{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}, Key: "token", Optional: &optional},
+			}}},
+		},
+		wantErr: apis.ErrDisallowedFields("params[0].valueFrom.secretKeyRef.optional"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "param with both a value and a valueFrom",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			Params: v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main"), ValueFrom: &v1beta1.ParamValueSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}, Key: "branch"},
+			}}},
+		},
+		wantErr: apis.ErrMultipleOneOf("params[0].value", "params[0].valueFrom"),
+		wc:      config.EnableAlphaAPIFields,
 	}}
 
 	for _, ts := range tests {
@@ -805,6 +994,35 @@ func TestTaskRunSpec_Validate(t *testing.T) {
 		spec v1beta1.TaskRunSpec
 		wc   func(context.Context) context.Context
 	}{{
+		name: "param valueFrom",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: v1beta1.Params{{Name: "branch", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: &v1beta1.ParamValueSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "config"}, Key: "branch"},
+			}}},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "hermetic",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef:  &v1beta1.TaskRef{Name: "task"},
+			Hermetic: true,
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "result files",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			ResultFiles: []v1beta1.ResultFile{{
+				Path:  "/inputs/config.json",
+				Value: `{"replicas": 3}`,
+			}, {
+				Path:  "/inputs/images.json",
+				Value: `["a", "b"]`,
+			}},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
 		name: "taskspec without a taskRef",
 		spec: v1beta1.TaskRunSpec{
 			TaskSpec: &v1beta1.TaskSpec{
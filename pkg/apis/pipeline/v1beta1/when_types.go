@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/internal/cel"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"k8s.io/apimachinery/pkg/selection"
 )
@@ -27,15 +28,24 @@ import (
 // to determine whether the Task should be executed or skipped
 type WhenExpression struct {
 	// Input is the string for guard checking which can be a static input or an output from a parent Task
-	Input string `json:"input"`
+	// +optional
+	Input string `json:"input,omitempty"`
 
 	// Operator that represents an Input's relationship to the values
-	Operator selection.Operator `json:"operator"`
+	// +optional
+	Operator selection.Operator `json:"operator,omitempty"`
 
 	// Values is an array of strings, which is compared against the input, for guard checking
-	// It must be non-empty
+	// It must be non-empty unless CEL is set
+	// +optional
 	// +listType=atomic
-	Values []string `json:"values"`
+	Values []string `json:"values,omitempty"`
+
+	// CEL is a CEL expression evaluating to a bool, used instead of the input, the operator
+	// and the values, in which the params and the results of the tasks are referenced as
+	// variables, e.g. "int(tasks.scan.results.critical) < 3".
+	// +optional
+	CEL string `json:"cel,omitempty"`
 }
 
 func (we *WhenExpression) isInputInValues() bool {
@@ -47,15 +57,21 @@ func (we *WhenExpression) isInputInValues() bool {
 	return false
 }
 
-func (we *WhenExpression) isTrue() bool {
+func (we *WhenExpression) isTrue() (bool, error) {
+	if we.CEL != "" {
+		return cel.Evaluate(we.CEL)
+	}
 	if we.Operator == selection.In {
-		return we.isInputInValues()
+		return we.isInputInValues(), nil
 	}
 	// selection.NotIn
-	return !we.isInputInValues()
+	return !we.isInputInValues(), nil
 }
 
 func (we *WhenExpression) applyReplacements(replacements map[string]string, arrayReplacements map[string][]string) WhenExpression {
+	if we.CEL != "" {
+		return WhenExpression{CEL: cel.ApplyReplacements(we.CEL, replacements, arrayReplacements)}
+	}
 	replacedInput := substitution.ApplyReplacements(we.Input, replacements)
 
 	var replacedValues []string
@@ -76,8 +92,13 @@ func (we *WhenExpression) applyReplacements(replacements map[string]string, arra
 	return WhenExpression{Input: replacedInput, Operator: we.Operator, Values: replacedValues}
 }
 
-// GetVarSubstitutionExpressions extracts all the values between "$(" and ")" in a When Expression
+// GetVarSubstitutionExpressions extracts all the values between "$(" and ")" in a When Expression,
+// or the params and results referenced by its CEL expression
 func (we *WhenExpression) GetVarSubstitutionExpressions() ([]string, bool) {
+	if we.CEL != "" {
+		expressions := cel.Variables(we.CEL)
+		return expressions, len(expressions) != 0
+	}
 	var allExpressions []string
 	allExpressions = append(allExpressions, validateString(we.Input)...)
 	for _, value := range we.Values {
@@ -92,16 +113,31 @@ type WhenExpressions []WhenExpression
 
 // AllowsExecution evaluates an Input's relationship to an array of Values, based on the Operator,
 // to determine whether all the When Expressions are True. If they are all True, the guarded Task is
-// executed, otherwise it is skipped.
+// executed, otherwise it is skipped. A CEL expression failing to be evaluated isn't True, see
+// EvaluationError.
 func (wes WhenExpressions) AllowsExecution() bool {
 	for _, we := range wes {
-		if !we.isTrue() {
+		if allowed, err := we.isTrue(); err != nil || !allowed {
 			return false
 		}
 	}
 	return true
 }
 
+// EvaluationError returns the error of the first CEL expression, whose variables are all replaced,
+// which fails to be evaluated, e.g. comparing a string to a number.
+func (wes WhenExpressions) EvaluationError() error {
+	for _, we := range wes {
+		if we.CEL == "" || len(cel.Variables(we.CEL)) != 0 {
+			continue
+		}
+		if _, err := we.isTrue(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ReplaceVariables interpolates variables, such as Parameters and Results, in
 // the Input and Values.
 func (wes WhenExpressions) ReplaceVariables(replacements map[string]string, arrayReplacements map[string][]string) WhenExpressions {
@@ -28,6 +28,7 @@ func (w WorkspaceDeclaration) convertTo(ctx context.Context, sink *v1.WorkspaceD
 	sink.MountPath = w.MountPath
 	sink.ReadOnly = w.ReadOnly
 	sink.Optional = w.Optional
+	sink.Digest = w.Digest
 }
 
 func (w *WorkspaceDeclaration) convertFrom(ctx context.Context, source v1.WorkspaceDeclaration) {
@@ -36,6 +37,19 @@ func (w *WorkspaceDeclaration) convertFrom(ctx context.Context, source v1.Worksp
 	w.MountPath = source.MountPath
 	w.ReadOnly = source.ReadOnly
 	w.Optional = source.Optional
+	w.Digest = source.Digest
+}
+
+func (w WorkspaceStatus) convertTo(ctx context.Context, sink *v1.WorkspaceStatus) {
+	sink.Name = w.Name
+	sink.StartDigest = w.StartDigest
+	sink.EndDigest = w.EndDigest
+}
+
+func (w *WorkspaceStatus) convertFrom(ctx context.Context, source v1.WorkspaceStatus) {
+	w.Name = source.Name
+	w.StartDigest = source.StartDigest
+	w.EndDigest = source.EndDigest
 }
 
 func (w WorkspaceUsage) convertTo(ctx context.Context, sink *v1.WorkspaceUsage) {
@@ -52,12 +66,18 @@ func (w PipelineWorkspaceDeclaration) convertTo(ctx context.Context, sink *v1.Pi
 	sink.Name = w.Name
 	sink.Description = w.Description
 	sink.Optional = w.Optional
+	sink.Mode = v1.WorkspaceMode(w.Mode)
+	sink.Type = v1.WorkspaceType(w.Type)
+	sink.CacheKey = w.CacheKey
 }
 
 func (w *PipelineWorkspaceDeclaration) convertFrom(ctx context.Context, source v1.PipelineWorkspaceDeclaration) {
 	w.Name = source.Name
 	w.Description = source.Description
 	w.Optional = source.Optional
+	w.Mode = WorkspaceMode(source.Mode)
+	w.Type = WorkspaceType(source.Type)
+	w.CacheKey = source.CacheKey
 }
 
 func (w WorkspacePipelineTaskBinding) convertTo(ctx context.Context, sink *v1.WorkspacePipelineTaskBinding) {
@@ -39,6 +39,11 @@ type WorkspaceDeclaration struct {
 	// Optional marks a Workspace as not being required in TaskRuns. By default
 	// this field is false and so declared workspaces are required.
 	Optional bool `json:"optional,omitempty"`
+	// Digest, if true, causes a digest of the workspace's contents to be
+	// computed when the Task starts and when it finishes. The digests are
+	// recorded in the TaskRun's status.
+	// +optional
+	Digest bool `json:"digest,omitempty"`
 }
 
 // GetMountPath returns the mountPath for w which is the MountPath if provided or the
@@ -104,8 +109,52 @@ type PipelineWorkspaceDeclaration struct {
 	// Optional marks a Workspace as not being required in PipelineRuns. By default
 	// this field is false and so declared workspaces are required.
 	Optional bool `json:"optional,omitempty"`
+	// Mode is the access mode of the workspace for the PipelineTasks using it,
+	// one of ReadOnly or ReadWrite. A ReadOnly workspace is mounted read-only
+	// into the TaskRuns, and every Task using it must declare it readOnly.
+	// Defaults to ReadWrite.
+	// +optional
+	Mode WorkspaceMode `json:"mode,omitempty"`
+	// Type is the lifecycle of the workspace, one of ephemeral, cache, artifact
+	// or secret. An ephemeral workspace defaults to an emptyDir, a cache workspace
+	// persists across PipelineRuns keyed by its CacheKey, an artifact workspace
+	// is snapshotted when the PipelineRun ends and a secret workspace must be
+	// bound to a Secret. Defaults to no particular lifecycle.
+	// +optional
+	Type WorkspaceType `json:"type,omitempty"`
+	// CacheKey identifies the volume a cache workspace persists to. PipelineRuns
+	// using the same key share the volume. Required for cache workspaces.
+	// +optional
+	CacheKey string `json:"cacheKey,omitempty"`
 }
 
+// WorkspaceMode is the access mode of a Pipeline workspace.
+type WorkspaceMode string
+
+const (
+	// WorkspaceModeReadOnly indicates that the PipelineTasks may only read from the workspace.
+	WorkspaceModeReadOnly WorkspaceMode = "ReadOnly"
+	// WorkspaceModeReadWrite indicates that the PipelineTasks may read from and write to the workspace.
+	WorkspaceModeReadWrite WorkspaceMode = "ReadWrite"
+)
+
+// WorkspaceType is the lifecycle of a Pipeline workspace.
+type WorkspaceType string
+
+const (
+	// WorkspaceTypeEphemeral indicates that the workspace only lives as long as the TaskRuns
+	// using it, and defaults to an emptyDir when the PipelineRun does not bind it.
+	WorkspaceTypeEphemeral WorkspaceType = "ephemeral"
+	// WorkspaceTypeCache indicates that the workspace persists across PipelineRuns: when bound
+	// to a volumeClaimTemplate, the claim is named after the CacheKey and outlives the PipelineRun.
+	WorkspaceTypeCache WorkspaceType = "cache"
+	// WorkspaceTypeArtifact indicates that the workspace is snapshotted into a new
+	// PersistentVolumeClaim, retained after the PipelineRun, when the PipelineRun ends.
+	WorkspaceTypeArtifact WorkspaceType = "artifact"
+	// WorkspaceTypeSecret indicates that the workspace holds credentials and must be bound to a Secret.
+	WorkspaceTypeSecret WorkspaceType = "secret"
+)
+
 // WorkspacePipelineTaskBinding describes how a workspace passed into the pipeline should be
 // mapped to a task's declared workspace.
 type WorkspacePipelineTaskBinding struct {
@@ -120,6 +169,27 @@ type WorkspacePipelineTaskBinding struct {
 	SubPath string `json:"subPath,omitempty"`
 }
 
+// WorkspaceStatus holds the digests of a workspace's contents that were
+// computed when the TaskRun started and when it finished.
+type WorkspaceStatus struct {
+	// Name is the name of the workspace as declared by the Task.
+	Name string `json:"name"`
+	// StartDigest is the digest of the workspace's contents before the
+	// first Step ran, in the form "sha256:<hex>".
+	// +optional
+	StartDigest string `json:"startDigest,omitempty"`
+	// EndDigest is the digest of the workspace's contents after the last
+	// Step ran, in the form "sha256:<hex>".
+	// +optional
+	EndDigest string `json:"endDigest,omitempty"`
+}
+
+// Modified returns true if both digests of the workspace are known and
+// they differ.
+func (w WorkspaceStatus) Modified() bool {
+	return w.StartDigest != "" && w.EndDigest != "" && w.StartDigest != w.EndDigest
+}
+
 // WorkspaceUsage is used by a Step or Sidecar to declare that it wants isolated access
 // to a Workspace defined in a Task.
 type WorkspaceUsage struct {
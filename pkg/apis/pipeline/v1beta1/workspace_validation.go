@@ -18,7 +18,9 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/internal/volume"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
@@ -31,6 +33,8 @@ var allVolumeSourceFields = []string{
 	"emptydir",
 	"configmap",
 	"secret",
+	"projected",
+	"csi",
 }
 
 // Validate looks at the Volume provided in wb and makes sure that it is valid.
@@ -66,14 +70,14 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		return apis.ErrMissingField("secret.secretName")
 	}
 
-	// For a Projected volume to work, you must provide at least one source.
-	if b.Projected != nil && len(b.Projected.Sources) == 0 {
-		return apis.ErrMissingField("projected.sources")
+	// For a Projected volume to work, you must provide at least one valid source.
+	if b.Projected != nil {
+		return volume.ValidateProjectedVolumeSource(ctx, b.Projected).ViaField("projected")
 	}
 
 	// For a CSI to work, you must provide and have installed the driver to use.
-	if b.CSI != nil && b.CSI.Driver == "" {
-		return apis.ErrMissingField("csi.driver")
+	if b.CSI != nil {
+		return volume.ValidateCSIVolumeSource(b.CSI).ViaField("csi")
 	}
 
 	return nil
@@ -106,3 +110,16 @@ func (b *WorkspaceBinding) numSources() int {
 	}
 	return n
 }
+
+// ValidateWorkspaceSubPaths validates the subPaths of the workspaces of the PipelineTask
+// once their parameters and task results are substituted.
+func (pt PipelineTask) ValidateWorkspaceSubPaths() error {
+	var errs *apis.FieldError
+	for i, ws := range pt.Workspaces {
+		errs = errs.Also(volume.ValidateSubPath(ws.SubPath).ViaFieldIndex("workspaces", i))
+	}
+	if errs != nil {
+		return fmt.Errorf("invalid workspaces of pipeline task %q: %w", pt.Name, errs)
+	}
+	return nil
+}
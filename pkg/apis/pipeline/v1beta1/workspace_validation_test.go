@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,6 +28,7 @@ import (
 )
 
 func TestWorkspaceBindingValidateValid(t *testing.T) {
+	tokenExpirationSeconds := int64(3600)
 	for _, tc := range []struct {
 		name    string
 		binding *v1beta1.WorkspaceBinding
@@ -109,6 +111,48 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 				Driver: "my-csi",
 			},
 		},
+	}, {
+		name: "Valid projected serviceAccountToken and downwardAPI",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Audience:          "vault",
+						ExpirationSeconds: &tokenExpirationSeconds,
+						Path:              "token",
+					},
+				}, {
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{
+							Path:     "labels",
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+						}, {
+							Path:             "cpu-limit",
+							ResourceFieldRef: &corev1.ResourceFieldSelector{Resource: "limits.cpu"},
+						}},
+					},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: "my-secret",
+						},
+						Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "certs/ca.crt"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Valid csi with nodePublishSecretRef",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               "secrets-store.csi.k8s.io",
+				NodePublishSecretRef: &corev1.LocalObjectReference{Name: "secrets-store-creds"},
+				VolumeAttributes:     map[string]string{"secretProviderClass": "vault"},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -123,6 +167,7 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 }
 
 func TestWorkspaceBindingValidateInvalid(t *testing.T) {
+	tokenExpirationSeconds := int64(60)
 	for _, tc := range []struct {
 		name    string
 		binding *v1beta1.WorkspaceBinding
@@ -162,6 +207,21 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			Name:   "beth",
 			Secret: &corev1.SecretVolumeSource{},
 		},
+	}, {
+		name: "projected downwardAPI source should be disallowed without beta feature gate",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{
+							Path:     "labels",
+							FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"},
+						}},
+					},
+				}},
+			},
+		},
 	}, {
 		name: "Provide projected without sources",
 		binding: &v1beta1.WorkspaceBinding{
@@ -176,6 +236,123 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 				Driver: "",
 			},
 		},
+	}, {
+		name: "Provide projected source without projection",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{}},
+			},
+		},
+	}, {
+		name: "Provide projected source with multiple projections",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ConfigMap: &corev1.ConfigMapProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "a-configmap-name"},
+					},
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected secret without a name",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					Secret: &corev1.SecretProjection{},
+				}},
+			},
+		},
+	}, {
+		name: "Provide projected serviceAccountToken without a path",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Audience: "vault"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected serviceAccountToken expiring too soon",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+						Path:              "token",
+						ExpirationSeconds: &tokenExpirationSeconds,
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected downwardAPI item without a field",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					DownwardAPI: &corev1.DownwardAPIProjection{
+						Items: []corev1.DownwardAPIVolumeFile{{Path: "labels"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected file with an absolute path",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "/token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected file with a path escaping the volume",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "../token"},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide projected files with conflicting paths",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}, {
+					Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "my-secret"},
+						Items:                []corev1.KeyToPath{{Key: "token", Path: "token"}},
+					},
+				}},
+			},
+		},
+		wc: config.EnableBetaAPIFields,
+	}, {
+		name: "Provide csi with a nodePublishSecretRef without a name",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               "secrets-store.csi.k8s.io",
+				NodePublishSecretRef: &corev1.LocalObjectReference{},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
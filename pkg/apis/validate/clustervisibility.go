@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+)
+
+// ClusterVisibilityFunc returns an error if the ClusterTask or ClusterPipeline of
+// the kind with the given name can't be referenced in the namespace.
+type ClusterVisibilityFunc func(ctx context.Context, namespace, kind, name string) error
+
+type clusterVisibilityKey struct{}
+
+// WithClusterVisibility returns a context in which the references to ClusterTasks
+// and ClusterPipelines are checked with the given function.
+func WithClusterVisibility(ctx context.Context, f ClusterVisibilityFunc) context.Context {
+	return context.WithValue(ctx, clusterVisibilityKey{}, f)
+}
+
+// ClusterRefVisible returns an error if the ClusterTask or ClusterPipeline of the
+// kind with the given name can't be referenced in the namespace, if the context
+// has a ClusterVisibilityFunc.
+func ClusterRefVisible(ctx context.Context, namespace, kind, name string) error {
+	f, ok := ctx.Value(clusterVisibilityKey{}).(ClusterVisibilityFunc)
+	if !ok {
+		return nil
+	}
+	return f(ctx, namespace, kind, name)
+}
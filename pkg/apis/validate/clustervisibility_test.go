@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+)
+
+func TestClusterRefVisible(t *testing.T) {
+	if err := validate.ClusterRefVisible(context.Background(), "ns", "ClusterTask", "build"); err != nil {
+		t.Errorf("expected all the ClusterTasks to be visible without a check but got %v", err)
+	}
+
+	notVisible := errors.New("not visible")
+	ctx := validate.WithClusterVisibility(context.Background(), func(ctx context.Context, namespace, kind, name string) error {
+		if namespace == "ns" && kind == "ClusterPipeline" && name == "release" {
+			return notVisible
+		}
+		return nil
+	})
+	if err := validate.ClusterRefVisible(ctx, "ns", "ClusterTask", "release"); err != nil {
+		t.Errorf("expected the ClusterTask to be visible but got %v", err)
+	}
+	if err := validate.ClusterRefVisible(ctx, "ns", "ClusterPipeline", "release"); !errors.Is(err, notVisible) {
+		t.Errorf("expected the ClusterPipeline not to be visible but got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+)
+
+// ServiceAccountPolicyFunc returns an error if the runs of the resource of the
+// given kind, Pipeline or Task, and name may not use the service account in the
+// namespace. The name is empty for the resources which aren't referenced by name.
+type ServiceAccountPolicyFunc func(ctx context.Context, namespace, kind, name, serviceAccount string) error
+
+type serviceAccountPolicyKey struct{}
+
+// WithServiceAccountPolicy returns a context in which the service accounts of
+// the PipelineRuns and the TaskRuns being created are checked with the given
+// function.
+func WithServiceAccountPolicy(ctx context.Context, f ServiceAccountPolicyFunc) context.Context {
+	return context.WithValue(ctx, serviceAccountPolicyKey{}, f)
+}
+
+// ServiceAccountAllowed returns an error if the runs of the resource of the given
+// kind and name may not use the service account in the namespace, if the context
+// has a ServiceAccountPolicyFunc.
+func ServiceAccountAllowed(ctx context.Context, namespace, kind, name, serviceAccount string) error {
+	f, ok := ctx.Value(serviceAccountPolicyKey{}).(ServiceAccountPolicyFunc)
+	if !ok {
+		return nil
+	}
+	return f(ctx, namespace, kind, name, serviceAccount)
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+)
+
+func TestServiceAccountAllowed(t *testing.T) {
+	if err := validate.ServiceAccountAllowed(context.Background(), "ns", pipeline.PipelineControllerName, "release", "admin"); err != nil {
+		t.Errorf("expected all the service accounts to be allowed without a policy but got %v", err)
+	}
+
+	notAllowed := errors.New("not allowed")
+	ctx := validate.WithServiceAccountPolicy(context.Background(), func(ctx context.Context, namespace, kind, name, serviceAccount string) error {
+		if namespace == "ns" && kind == pipeline.PipelineControllerName && name == "release" && serviceAccount == "admin" {
+			return notAllowed
+		}
+		return nil
+	})
+	if err := validate.ServiceAccountAllowed(ctx, "ns", pipeline.PipelineControllerName, "release", "deployer"); err != nil {
+		t.Errorf("expected the service account to be allowed but got %v", err)
+	}
+	if err := validate.ServiceAccountAllowed(ctx, "ns", pipeline.PipelineControllerName, "release", "admin"); !errors.Is(err, notAllowed) {
+		t.Errorf("expected the service account not to be allowed but got %v", err)
+	}
+	if err := validate.ServiceAccountAllowed(ctx, "ns", pipeline.TaskControllerName, "release", "admin"); err != nil {
+		t.Errorf("expected the service account to be allowed for the Task but got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// WarningsFunc returns warning level diagnostics about a resource being
+// validated, e.g. lint findings. They don't prevent the resource from being
+// admitted, but are returned to the client.
+type WarningsFunc func(ctx context.Context, obj runtime.Object) *apis.FieldError
+
+type warningsKey struct{}
+
+// WithWarnings returns a context in which the validation of resources is
+// complemented with the diagnostics of the given function.
+func WithWarnings(ctx context.Context, f WarningsFunc) context.Context {
+	return context.WithValue(ctx, warningsKey{}, f)
+}
+
+// CombineWarnings returns a WarningsFunc returning the diagnostics of all the
+// given functions.
+func CombineWarnings(fs ...WarningsFunc) WarningsFunc {
+	return func(ctx context.Context, obj runtime.Object) *apis.FieldError {
+		var errs *apis.FieldError
+		for _, f := range fs {
+			errs = errs.Also(f(ctx, obj))
+		}
+		return errs
+	}
+}
+
+// Warnings returns the warning level diagnostics about the resource, if the
+// context has a WarningsFunc.
+func Warnings(ctx context.Context, obj runtime.Object) *apis.FieldError {
+	f, ok := ctx.Value(warningsKey{}).(WarningsFunc)
+	if !ok {
+		return nil
+	}
+	return f(ctx, obj).Filter(apis.WarningLevel)
+}
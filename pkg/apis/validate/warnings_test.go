@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate_test
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+func TestWarnings(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1.TaskSpec{
+			Steps: []v1.Step{{Image: "alpine"}},
+		},
+	}
+	if err := task.Validate(context.Background()); err != nil {
+		t.Fatalf("unexpected validation error: %v", err)
+	}
+
+	ctx := validate.WithWarnings(context.Background(), func(ctx context.Context, obj runtime.Object) *apis.FieldError {
+		return apis.ErrGeneric("step image is not pinned", "spec.steps[0].image").At(apis.WarningLevel).
+			Also(apis.ErrGeneric("ignored error", "spec"))
+	})
+	err := task.Validate(ctx)
+	if err.Filter(apis.ErrorLevel) != nil {
+		t.Errorf("expected only warnings but got errors: %v", err.Filter(apis.ErrorLevel))
+	}
+	if want := "step image is not pinned: spec.steps[0].image"; err.Filter(apis.WarningLevel).Error() != want {
+		t.Errorf("expected warning %q but got %q", want, err.Filter(apis.WarningLevel).Error())
+	}
+}
+
+func TestCombineWarnings(t *testing.T) {
+	warning := func(message string) validate.WarningsFunc {
+		return func(ctx context.Context, obj runtime.Object) *apis.FieldError {
+			return apis.ErrGeneric(message, "spec").At(apis.WarningLevel)
+		}
+	}
+	none := func(ctx context.Context, obj runtime.Object) *apis.FieldError { return nil }
+
+	got := validate.CombineWarnings(warning("first"), none, warning("second"))(context.Background(), &v1.Task{})
+	if want := "first: spec\nsecond: spec"; got.Error() != want {
+		t.Errorf("expected warnings %q but got %q", want, got.Error())
+	}
+}
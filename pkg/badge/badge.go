@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package badge serves the status of the latest PipelineRun of each Pipeline as SVG
+// badges and JSON, so that READMEs and portals can show the health of the Pipelines.
+//
+// Only the Pipelines of the namespaces the service is configured with are exposed, and
+// the PipelineRuns are listed with the ServiceAccount of the service, which must be
+// granted access to these namespaces with RBAC.
+package badge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+)
+
+// The statuses of the latest PipelineRun of a Pipeline
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+	StatusRunning   = "running"
+	StatusPending   = "pending"
+	// StatusNoRuns is the status of a Pipeline which has no PipelineRun
+	StatusNoRuns = "no runs"
+)
+
+// maxCacheEntries bounds the number of cached statuses, which are keyed by the
+// namespaces and names of the requests.
+const maxCacheEntries = 4096
+
+// Status is the status of the latest PipelineRun of a Pipeline, served as JSON.
+type Status struct {
+	Namespace      string       `json:"namespace"`
+	Pipeline       string       `json:"pipeline"`
+	PipelineRun    string       `json:"pipelineRun,omitempty"`
+	Status         string       `json:"status"`
+	Reason         string       `json:"reason,omitempty"`
+	StartTime      *metav1.Time `json:"startTime,omitempty"`
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// Handler serves the statuses of the Pipelines under /badges/<namespace>/<pipeline>.svg
+// and /badges/<namespace>/<pipeline>.json for the namespaces it exposes. The statuses
+// are cached for the TTL, and served with the matching Cache-Control and ETag headers.
+type Handler struct {
+	client     versioned.Interface
+	namespaces sets.String
+	ttl        time.Duration
+	clock      clock.PassiveClock
+
+	mu    sync.Mutex
+	cache map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	status  Status
+	expires time.Time
+}
+
+// NewHandler returns a Handler exposing the Pipelines of the namespaces, listing
+// the PipelineRuns with the client, whose statuses are cached for the TTL.
+func NewHandler(client versioned.Interface, namespaces []string, ttl time.Duration, clock clock.PassiveClock) *Handler {
+	return &Handler{
+		client:     client,
+		namespaces: sets.NewString(namespaces...),
+		ttl:        ttl,
+		clock:      clock,
+		cache:      map[string]cacheEntry{},
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	namespace, name, format, ok := parsePath(r.URL.Path)
+	if !ok || !h.namespaces.Has(namespace) {
+		http.NotFound(w, r)
+		return
+	}
+	status, err := h.status(r.Context(), namespace, name)
+	switch {
+	case apierrors.IsForbidden(err) || apierrors.IsNotFound(err):
+		// Do not disclose the namespaces the service has no access to
+		http.NotFound(w, r)
+		return
+	case err != nil:
+		http.Error(w, "unable to get the status of the pipeline", http.StatusBadGateway)
+		return
+	}
+
+	var body []byte
+	switch format {
+	case "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		body = []byte(svg(name, status.Status))
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		if body, err = json.Marshal(status); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	// The statuses are not stored by shared caches, which would keep serving them
+	// once a namespace is no longer exposed
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.ttl.Seconds())))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(body)
+	}
+}
+
+// parsePath returns the namespace, the name of the Pipeline and the format of
+// a path /badges/<namespace>/<pipeline>.<svg|json>.
+func parsePath(path string) (namespace, name, format string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/badges/"), "/")
+	if len(parts) != 2 || !strings.HasPrefix(path, "/badges/") {
+		return "", "", "", false
+	}
+	namespace = parts[0]
+	for _, f := range []string{"svg", "json"} {
+		if n := strings.TrimSuffix(parts[1], "."+f); n != parts[1] {
+			name, format = n, f
+		}
+	}
+	if format == "" || len(validation.IsDNS1123Label(namespace)) > 0 || len(validation.IsDNS1123Subdomain(name)) > 0 {
+		return "", "", "", false
+	}
+	return namespace, name, format, true
+}
+
+func (h *Handler) status(ctx context.Context, namespace, name string) (Status, error) {
+	key := namespace + "/" + name
+	now := h.clock.Now()
+	h.mu.Lock()
+	entry, ok := h.cache[key]
+	h.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.status, nil
+	}
+
+	prs, err := h.client.TektonV1().PipelineRuns(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: pipeline.PipelineLabelKey + "=" + name,
+	})
+	if err != nil {
+		return Status{}, err
+	}
+	status := latestStatus(namespace, name, prs.Items)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.cache) >= maxCacheEntries {
+		for k, e := range h.cache {
+			if !now.Before(e.expires) {
+				delete(h.cache, k)
+			}
+		}
+		if len(h.cache) >= maxCacheEntries {
+			h.cache = map[string]cacheEntry{}
+		}
+	}
+	h.cache[key] = cacheEntry{status: status, expires: now.Add(h.ttl)}
+	return status, nil
+}
+
+// latestStatus returns the status of the PipelineRun of the Pipeline created last.
+func latestStatus(namespace, name string, prs []v1.PipelineRun) Status {
+	status := Status{Namespace: namespace, Pipeline: name, Status: StatusNoRuns}
+	var latest *v1.PipelineRun
+	for i := range prs {
+		pr := &prs[i]
+		if latest == nil || latest.CreationTimestamp.Before(&pr.CreationTimestamp) ||
+			(latest.CreationTimestamp.Equal(&pr.CreationTimestamp) && latest.Name < pr.Name) {
+			latest = pr
+		}
+	}
+	if latest == nil {
+		return status
+	}
+	status.PipelineRun = latest.Name
+	status.StartTime = latest.Status.StartTime
+	status.CompletionTime = latest.Status.CompletionTime
+	status.Status = StatusPending
+	if c := latest.Status.GetCondition(apis.ConditionSucceeded); c != nil {
+		status.Reason = c.Reason
+		switch {
+		case c.IsTrue():
+			status.Status = StatusSucceeded
+		case c.IsFalse() && c.Reason == v1.PipelineRunReasonCancelled.String():
+			status.Status = StatusCancelled
+		case c.IsFalse():
+			status.Status = StatusFailed
+		case c.Reason != v1.PipelineRunReasonPending.String():
+			status.Status = StatusRunning
+		}
+	}
+	return status
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package badge
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktesting "k8s.io/client-go/testing"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var now = time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+
+func pipelineRun(name, pipeline string, created time.Time, condition *apis.Condition) *v1.PipelineRun {
+	pr := &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         "ns",
+		Labels:            map[string]string{"tekton.dev/pipeline": pipeline},
+		CreationTimestamp: metav1.Time{Time: created},
+	}}
+	if condition != nil {
+		condition.Type = apis.ConditionSucceeded
+		pr.Status.Status = duckv1.Status{Conditions: duckv1.Conditions{*condition}}
+	}
+	return pr
+}
+
+func TestHandler_JSON(t *testing.T) {
+	start := metav1.Time{Time: now.Add(-time.Hour)}
+	older := pipelineRun("build-1", "build", now.Add(-2*time.Hour), &apis.Condition{Status: corev1.ConditionTrue, Reason: "Succeeded"})
+	latest := pipelineRun("build-2", "build", now.Add(-time.Hour), &apis.Condition{Status: corev1.ConditionFalse, Reason: "Failed"})
+	latest.Status.StartTime = &start
+	other := pipelineRun("deploy-1", "deploy", now, &apis.Condition{Status: corev1.ConditionUnknown, Reason: "Running"})
+	h := NewHandler(fake.NewSimpleClientset(older, latest, other), []string{"ns"}, 30*time.Second, testclock.NewFakePassiveClock(now))
+
+	for _, tc := range []struct {
+		path string
+		want Status
+	}{{
+		path: "/badges/ns/build.json",
+		want: Status{Namespace: "ns", Pipeline: "build", PipelineRun: "build-2", Status: StatusFailed, Reason: "Failed", StartTime: &start},
+	}, {
+		path: "/badges/ns/deploy.json",
+		want: Status{Namespace: "ns", Pipeline: "deploy", PipelineRun: "deploy-1", Status: StatusRunning, Reason: "Running"},
+	}, {
+		path: "/badges/ns/release.json",
+		want: Status{Namespace: "ns", Pipeline: "release", Status: StatusNoRuns},
+	}} {
+		t.Run(tc.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "private, max-age=30" {
+				t.Errorf("unexpected Cache-Control %q", got)
+			}
+			var got Status
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("unmarshalling the status: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestHandler_SVG(t *testing.T) {
+	pr := pipelineRun("build-1", "build", now, &apis.Condition{Status: corev1.ConditionTrue, Reason: "Succeeded"})
+	h := NewHandler(fake.NewSimpleClientset(pr), []string{"ns"}, time.Minute, testclock.NewFakePassiveClock(now))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/badges/ns/build.svg", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Fatalf("expected an SVG, got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "<title>build: succeeded</title>") || !strings.Contains(body, colors[StatusSucceeded]) {
+		t.Errorf("expected a green succeeded badge, got %s", body)
+	}
+
+	// The badge is not sent again when it didn't change
+	req := httptest.NewRequest(http.MethodGet, "/badges/ns/build.svg", nil)
+	req.Header.Set("If-None-Match", rec.Header().Get("ETag"))
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected status 304 without body, got %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestHandler_Cache(t *testing.T) {
+	clock := testclock.NewFakeClock(now)
+	client := fake.NewSimpleClientset()
+	lists := 0
+	client.PrependReactor("list", "pipelineruns", func(ktesting.Action) (bool, runtime.Object, error) {
+		lists++
+		return false, nil, nil
+	})
+	h := NewHandler(client, []string{"ns"}, time.Minute, clock)
+
+	for _, advance := range []time.Duration{0, 30 * time.Second, 31 * time.Second} {
+		clock.Step(advance)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/badges/ns/build.json", nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", rec.Code)
+		}
+	}
+	if lists != 2 {
+		t.Errorf("expected the PipelineRuns to be listed again once the cache expires, got %d lists", lists)
+	}
+}
+
+func TestHandler_Errors(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "pipelineruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		gr := schema.GroupResource{Group: "tekton.dev", Resource: "pipelineruns"}
+		if action.GetNamespace() == "private" {
+			return true, nil, apierrors.NewForbidden(gr, "", nil)
+		}
+		return true, nil, apierrors.NewServiceUnavailable("unavailable")
+	})
+	h := NewHandler(client, []string{"ns", "private"}, time.Minute, testclock.NewFakePassiveClock(now))
+
+	for _, tc := range []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodGet, "/badges/private/build.svg", http.StatusNotFound},
+		{http.MethodGet, "/badges/ns/build.svg", http.StatusBadGateway},
+		{http.MethodGet, "/badges/other/build.svg", http.StatusNotFound},
+		{http.MethodGet, "/badges/ns/build.png", http.StatusNotFound},
+		{http.MethodGet, "/badges/ns/build/latest.svg", http.StatusNotFound},
+		{http.MethodGet, "/badges/Not_A_Namespace/build.svg", http.StatusNotFound},
+		{http.MethodPost, "/badges/ns/build.svg", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+		if rec.Code != tc.want {
+			t.Errorf("%s %s: expected status %d, got %d", tc.method, tc.path, tc.want, rec.Code)
+		}
+	}
+}
+
+func TestLatestStatus(t *testing.T) {
+	for _, tc := range []struct {
+		condition *apis.Condition
+		want      string
+	}{
+		{nil, StatusPending},
+		{&apis.Condition{Status: corev1.ConditionUnknown, Reason: v1.PipelineRunReasonPending.String()}, StatusPending},
+		{&apis.Condition{Status: corev1.ConditionUnknown, Reason: v1.PipelineRunReasonStarted.String()}, StatusRunning},
+		{&apis.Condition{Status: corev1.ConditionTrue, Reason: v1.PipelineRunReasonCompleted.String()}, StatusSucceeded},
+		{&apis.Condition{Status: corev1.ConditionFalse, Reason: v1.PipelineRunReasonCancelled.String()}, StatusCancelled},
+		{&apis.Condition{Status: corev1.ConditionFalse, Reason: v1.PipelineRunReasonTimedOut.String()}, StatusFailed},
+	} {
+		got := latestStatus("ns", "build", []v1.PipelineRun{*pipelineRun("build-1", "build", now, tc.condition)})
+		if got.Status != tc.want {
+			t.Errorf("expected the status of %v to be %q, got %q", tc.condition, tc.want, got.Status)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package badge
+
+import (
+	"fmt"
+	"html"
+)
+
+var colors = map[string]string{
+	StatusSucceeded: "#4c1",
+	StatusFailed:    "#e05d44",
+	StatusRunning:   "#007ec6",
+	StatusPending:   "#dfb317",
+}
+
+// defaultColor is the color of the statuses without a color, e.g. cancelled.
+const defaultColor = "#9f9f9f"
+
+const svgTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[3]s: %[4]s">` +
+	`<title>%[3]s: %[4]s</title>` +
+	`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` +
+	`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>` +
+	`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[5]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>` +
+	`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+	`<text x="%[7]d" y="14">%[3]s</text><text x="%[8]d" y="14">%[4]s</text></g></svg>`
+
+// svg returns a flat badge with the name of the Pipeline as label and its status as
+// message, in the color of the status.
+func svg(label, message string) string {
+	color, ok := colors[message]
+	if !ok {
+		color = defaultColor
+	}
+	labelWidth, messageWidth := textWidth(label), textWidth(message)
+	return fmt.Sprintf(svgTemplate,
+		labelWidth+messageWidth, labelWidth, html.EscapeString(label), html.EscapeString(message),
+		messageWidth, color, labelWidth/2, labelWidth+messageWidth/2)
+}
+
+// textWidth approximates the width of the text in Verdana 11px, with padding.
+func textWidth(text string) int {
+	return len(text)*7 + 10
+}
@@ -28,6 +28,14 @@ type FakeTektonV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1) ClusterPipelines() v1.ClusterPipelineInterface {
+	return &FakeClusterPipelines{c}
+}
+
+func (c *FakeTektonV1) ClusterTasks() v1.ClusterTaskInterface {
+	return &FakeClusterTasks{c}
+}
+
 func (c *FakeTektonV1) Pipelines(namespace string) v1.PipelineInterface {
 	return &FakePipelines{c, namespace}
 }
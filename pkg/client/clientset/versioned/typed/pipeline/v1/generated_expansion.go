@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1
 
+type ClusterPipelineExpansion interface{}
+
+type ClusterTaskExpansion interface{}
+
 type PipelineExpansion interface{}
 
 type PipelineRunExpansion interface{}
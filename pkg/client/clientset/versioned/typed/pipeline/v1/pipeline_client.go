@@ -28,6 +28,8 @@ import (
 
 type TektonV1Interface interface {
 	RESTClient() rest.Interface
+	ClusterPipelinesGetter
+	ClusterTasksGetter
 	PipelinesGetter
 	PipelineRunsGetter
 	TasksGetter
@@ -39,6 +41,14 @@ type TektonV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1Client) ClusterPipelines() ClusterPipelineInterface {
+	return newClusterPipelines(c)
+}
+
+func (c *TektonV1Client) ClusterTasks() ClusterTaskInterface {
+	return newClusterTasks(c)
+}
+
 func (c *TektonV1Client) Pipelines(namespace string) PipelineInterface {
 	return newPipelines(c, namespace)
 }
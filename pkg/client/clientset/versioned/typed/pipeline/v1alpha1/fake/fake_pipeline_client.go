@@ -28,14 +28,46 @@ type FakeTektonV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1alpha1) CloudEventSinks(namespace string) v1alpha1.CloudEventSinkInterface {
+	return &FakeCloudEventSinks{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) ExecutionWindowPolicies(namespace string) v1alpha1.ExecutionWindowPolicyInterface {
+	return &FakeExecutionWindowPolicies{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) NotificationPolicies(namespace string) v1alpha1.NotificationPolicyInterface {
+	return &FakeNotificationPolicies{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) PipelineRunArchives(namespace string) v1alpha1.PipelineRunArchiveInterface {
+	return &FakePipelineRunArchives{c, namespace}
+}
+
 func (c *FakeTektonV1alpha1) Runs(namespace string) v1alpha1.RunInterface {
 	return &FakeRuns{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) ServiceAccountPolicies(namespace string) v1alpha1.ServiceAccountPolicyInterface {
+	return &FakeServiceAccountPolicies{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) TaskGroups(namespace string) v1alpha1.TaskGroupInterface {
+	return &FakeTaskGroups{c, namespace}
+}
+
+func (c *FakeTektonV1alpha1) TektonHealths() v1alpha1.TektonHealthInterface {
+	return &FakeTektonHealths{c}
+}
+
 func (c *FakeTektonV1alpha1) VerificationPolicies(namespace string) v1alpha1.VerificationPolicyInterface {
 	return &FakeVerificationPolicies{c, namespace}
 }
 
+func (c *FakeTektonV1alpha1) WorkspacePools() v1alpha1.WorkspacePoolInterface {
+	return &FakeWorkspacePools{c}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeTektonV1alpha1) RESTClient() rest.Interface {
@@ -18,6 +18,22 @@ limitations under the License.
 
 package v1alpha1
 
+type CloudEventSinkExpansion interface{}
+
+type ExecutionWindowPolicyExpansion interface{}
+
+type NotificationPolicyExpansion interface{}
+
+type PipelineRunArchiveExpansion interface{}
+
 type RunExpansion interface{}
 
+type ServiceAccountPolicyExpansion interface{}
+
+type TaskGroupExpansion interface{}
+
+type TektonHealthExpansion interface{}
+
 type VerificationPolicyExpansion interface{}
+
+type WorkspacePoolExpansion interface{}
@@ -28,8 +28,16 @@ import (
 
 type TektonV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	CloudEventSinksGetter
+	ExecutionWindowPoliciesGetter
+	NotificationPoliciesGetter
+	PipelineRunArchivesGetter
 	RunsGetter
+	ServiceAccountPoliciesGetter
+	TaskGroupsGetter
+	TektonHealthsGetter
 	VerificationPoliciesGetter
+	WorkspacePoolsGetter
 }
 
 // TektonV1alpha1Client is used to interact with features provided by the tekton.dev group.
@@ -37,14 +45,46 @@ type TektonV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *TektonV1alpha1Client) CloudEventSinks(namespace string) CloudEventSinkInterface {
+	return newCloudEventSinks(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) ExecutionWindowPolicies(namespace string) ExecutionWindowPolicyInterface {
+	return newExecutionWindowPolicies(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) NotificationPolicies(namespace string) NotificationPolicyInterface {
+	return newNotificationPolicies(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) PipelineRunArchives(namespace string) PipelineRunArchiveInterface {
+	return newPipelineRunArchives(c, namespace)
+}
+
 func (c *TektonV1alpha1Client) Runs(namespace string) RunInterface {
 	return newRuns(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) ServiceAccountPolicies(namespace string) ServiceAccountPolicyInterface {
+	return newServiceAccountPolicies(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) TaskGroups(namespace string) TaskGroupInterface {
+	return newTaskGroups(c, namespace)
+}
+
+func (c *TektonV1alpha1Client) TektonHealths() TektonHealthInterface {
+	return newTektonHealths(c)
+}
+
 func (c *TektonV1alpha1Client) VerificationPolicies(namespace string) VerificationPolicyInterface {
 	return newVerificationPolicies(c, namespace)
 }
 
+func (c *TektonV1alpha1Client) WorkspacePools() WorkspacePoolInterface {
+	return newWorkspacePools(c)
+}
+
 // NewForConfig creates a new TektonV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
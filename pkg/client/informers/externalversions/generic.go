@@ -55,6 +55,10 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=tekton.dev, Version=v1
+	case v1.SchemeGroupVersion.WithResource("clusterpipelines"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1().ClusterPipelines().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clustertasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1().ClusterTasks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("pipelines"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1().Pipelines().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("pipelineruns"):
@@ -65,10 +69,26 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1().TaskRuns().Informer()}, nil
 
 		// Group=tekton.dev, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("cloudeventsinks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().CloudEventSinks().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("executionwindowpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ExecutionWindowPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("notificationpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().NotificationPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("pipelinerunarchives"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().PipelineRunArchives().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("runs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().Runs().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("serviceaccountpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().ServiceAccountPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("taskgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().TaskGroups().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tektonhealths"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().TektonHealths().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("verificationpolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().VerificationPolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("workspacepools"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Tekton().V1alpha1().WorkspacePools().Informer()}, nil
 
 		// Group=tekton.dev, Version=v1beta1
 	case v1beta1.SchemeGroupVersion.WithResource("clustertasks"):
@@ -24,6 +24,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterPipelines returns a ClusterPipelineInformer.
+	ClusterPipelines() ClusterPipelineInformer
+	// ClusterTasks returns a ClusterTaskInformer.
+	ClusterTasks() ClusterTaskInformer
 	// Pipelines returns a PipelineInformer.
 	Pipelines() PipelineInformer
 	// PipelineRuns returns a PipelineRunInformer.
@@ -45,6 +49,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterPipelines returns a ClusterPipelineInformer.
+func (v *version) ClusterPipelines() ClusterPipelineInformer {
+	return &clusterPipelineInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ClusterTasks returns a ClusterTaskInformer.
+func (v *version) ClusterTasks() ClusterTaskInformer {
+	return &clusterTaskInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // Pipelines returns a PipelineInformer.
 func (v *version) Pipelines() PipelineInformer {
 	return &pipelineInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
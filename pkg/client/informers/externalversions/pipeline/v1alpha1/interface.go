@@ -24,10 +24,26 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// CloudEventSinks returns a CloudEventSinkInformer.
+	CloudEventSinks() CloudEventSinkInformer
+	// ExecutionWindowPolicies returns a ExecutionWindowPolicyInformer.
+	ExecutionWindowPolicies() ExecutionWindowPolicyInformer
+	// NotificationPolicies returns a NotificationPolicyInformer.
+	NotificationPolicies() NotificationPolicyInformer
+	// PipelineRunArchives returns a PipelineRunArchiveInformer.
+	PipelineRunArchives() PipelineRunArchiveInformer
 	// Runs returns a RunInformer.
 	Runs() RunInformer
+	// ServiceAccountPolicies returns a ServiceAccountPolicyInformer.
+	ServiceAccountPolicies() ServiceAccountPolicyInformer
+	// TaskGroups returns a TaskGroupInformer.
+	TaskGroups() TaskGroupInformer
+	// TektonHealths returns a TektonHealthInformer.
+	TektonHealths() TektonHealthInformer
 	// VerificationPolicies returns a VerificationPolicyInformer.
 	VerificationPolicies() VerificationPolicyInformer
+	// WorkspacePools returns a WorkspacePoolInformer.
+	WorkspacePools() WorkspacePoolInformer
 }
 
 type version struct {
@@ -41,12 +57,52 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// CloudEventSinks returns a CloudEventSinkInformer.
+func (v *version) CloudEventSinks() CloudEventSinkInformer {
+	return &cloudEventSinkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// ExecutionWindowPolicies returns a ExecutionWindowPolicyInformer.
+func (v *version) ExecutionWindowPolicies() ExecutionWindowPolicyInformer {
+	return &executionWindowPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// NotificationPolicies returns a NotificationPolicyInformer.
+func (v *version) NotificationPolicies() NotificationPolicyInformer {
+	return &notificationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PipelineRunArchives returns a PipelineRunArchiveInformer.
+func (v *version) PipelineRunArchives() PipelineRunArchiveInformer {
+	return &pipelineRunArchiveInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Runs returns a RunInformer.
 func (v *version) Runs() RunInformer {
 	return &runInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ServiceAccountPolicies returns a ServiceAccountPolicyInformer.
+func (v *version) ServiceAccountPolicies() ServiceAccountPolicyInformer {
+	return &serviceAccountPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TaskGroups returns a TaskGroupInformer.
+func (v *version) TaskGroups() TaskGroupInformer {
+	return &taskGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TektonHealths returns a TektonHealthInformer.
+func (v *version) TektonHealths() TektonHealthInformer {
+	return &tektonHealthInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // VerificationPolicies returns a VerificationPolicyInformer.
 func (v *version) VerificationPolicies() VerificationPolicyInformer {
 	return &verificationPolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// WorkspacePools returns a WorkspacePoolInformer.
+func (v *version) WorkspacePools() WorkspacePoolInformer {
+	return &workspacePoolInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
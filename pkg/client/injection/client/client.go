@@ -115,31 +115,817 @@ func (w *wrapTektonV1alpha1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapTektonV1alpha1) CloudEventSinks(namespace string) typedtektonv1alpha1.CloudEventSinkInterface {
+	return &wrapTektonV1alpha1CloudEventSinkImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "cloudeventsinks",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1CloudEventSinkImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.CloudEventSinkInterface = (*wrapTektonV1alpha1CloudEventSinkImpl)(nil)
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Create(ctx context.Context, in *v1alpha1.CloudEventSink, opts v1.CreateOptions) (*v1alpha1.CloudEventSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "CloudEventSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.CloudEventSink, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.CloudEventSinkList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSinkList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.CloudEventSink, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Update(ctx context.Context, in *v1alpha1.CloudEventSink, opts v1.UpdateOptions) (*v1alpha1.CloudEventSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "CloudEventSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) UpdateStatus(ctx context.Context, in *v1alpha1.CloudEventSink, opts v1.UpdateOptions) (*v1alpha1.CloudEventSink, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "CloudEventSink",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.CloudEventSink{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1CloudEventSinkImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) ExecutionWindowPolicies(namespace string) typedtektonv1alpha1.ExecutionWindowPolicyInterface {
+	return &wrapTektonV1alpha1ExecutionWindowPolicyImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "executionwindowpolicies",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1ExecutionWindowPolicyImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.ExecutionWindowPolicyInterface = (*wrapTektonV1alpha1ExecutionWindowPolicyImpl)(nil)
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Create(ctx context.Context, in *v1alpha1.ExecutionWindowPolicy, opts v1.CreateOptions) (*v1alpha1.ExecutionWindowPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ExecutionWindowPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ExecutionWindowPolicy, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ExecutionWindowPolicyList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicyList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ExecutionWindowPolicy, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Update(ctx context.Context, in *v1alpha1.ExecutionWindowPolicy, opts v1.UpdateOptions) (*v1alpha1.ExecutionWindowPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ExecutionWindowPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.ExecutionWindowPolicy, opts v1.UpdateOptions) (*v1alpha1.ExecutionWindowPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ExecutionWindowPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ExecutionWindowPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ExecutionWindowPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) NotificationPolicies(namespace string) typedtektonv1alpha1.NotificationPolicyInterface {
+	return &wrapTektonV1alpha1NotificationPolicyImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "notificationpolicies",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1NotificationPolicyImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.NotificationPolicyInterface = (*wrapTektonV1alpha1NotificationPolicyImpl)(nil)
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Create(ctx context.Context, in *v1alpha1.NotificationPolicy, opts v1.CreateOptions) (*v1alpha1.NotificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "NotificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.NotificationPolicy, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.NotificationPolicyList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicyList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.NotificationPolicy, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Update(ctx context.Context, in *v1alpha1.NotificationPolicy, opts v1.UpdateOptions) (*v1alpha1.NotificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "NotificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.NotificationPolicy, opts v1.UpdateOptions) (*v1alpha1.NotificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "NotificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.NotificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1NotificationPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) PipelineRunArchives(namespace string) typedtektonv1alpha1.PipelineRunArchiveInterface {
+	return &wrapTektonV1alpha1PipelineRunArchiveImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "pipelinerunarchives",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1PipelineRunArchiveImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.PipelineRunArchiveInterface = (*wrapTektonV1alpha1PipelineRunArchiveImpl)(nil)
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Create(ctx context.Context, in *v1alpha1.PipelineRunArchive, opts v1.CreateOptions) (*v1alpha1.PipelineRunArchive, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunArchive",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchive{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.PipelineRunArchive, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchive{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.PipelineRunArchiveList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchiveList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.PipelineRunArchive, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchive{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Update(ctx context.Context, in *v1alpha1.PipelineRunArchive, opts v1.UpdateOptions) (*v1alpha1.PipelineRunArchive, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunArchive",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchive{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) UpdateStatus(ctx context.Context, in *v1alpha1.PipelineRunArchive, opts v1.UpdateOptions) (*v1alpha1.PipelineRunArchive, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "PipelineRunArchive",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.PipelineRunArchive{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1PipelineRunArchiveImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapTektonV1alpha1) Runs(namespace string) typedtektonv1alpha1.RunInterface {
 	return &wrapTektonV1alpha1RunImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
 			Group:    "tekton.dev",
 			Version:  "v1alpha1",
-			Resource: "runs",
+			Resource: "runs",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1RunImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.RunInterface = (*wrapTektonV1alpha1RunImpl)(nil)
+
+func (w *wrapTektonV1alpha1RunImpl) Create(ctx context.Context, in *v1alpha1.Run, opts v1.CreateOptions) (*v1alpha1.Run, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "Run",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Run{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1RunImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1RunImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Run, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Run{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RunList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.RunList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Run, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Run{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) Update(ctx context.Context, in *v1alpha1.Run, opts v1.UpdateOptions) (*v1alpha1.Run, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "Run",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Run{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) UpdateStatus(ctx context.Context, in *v1alpha1.Run, opts v1.UpdateOptions) (*v1alpha1.Run, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "Run",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.Run{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1RunImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) ServiceAccountPolicies(namespace string) typedtektonv1alpha1.ServiceAccountPolicyInterface {
+	return &wrapTektonV1alpha1ServiceAccountPolicyImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "serviceaccountpolicies",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1ServiceAccountPolicyImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.ServiceAccountPolicyInterface = (*wrapTektonV1alpha1ServiceAccountPolicyImpl)(nil)
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Create(ctx context.Context, in *v1alpha1.ServiceAccountPolicy, opts v1.CreateOptions) (*v1alpha1.ServiceAccountPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceAccountPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ServiceAccountPolicy, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ServiceAccountPolicyList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicyList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ServiceAccountPolicy, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Update(ctx context.Context, in *v1alpha1.ServiceAccountPolicy, opts v1.UpdateOptions) (*v1alpha1.ServiceAccountPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceAccountPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.ServiceAccountPolicy, opts v1.UpdateOptions) (*v1alpha1.ServiceAccountPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "ServiceAccountPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.ServiceAccountPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1ServiceAccountPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) TaskGroups(namespace string) typedtektonv1alpha1.TaskGroupInterface {
+	return &wrapTektonV1alpha1TaskGroupImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "taskgroups",
 		}),
 
 		namespace: namespace,
 	}
 }
 
-type wrapTektonV1alpha1RunImpl struct {
+type wrapTektonV1alpha1TaskGroupImpl struct {
 	dyn dynamic.NamespaceableResourceInterface
 
 	namespace string
 }
 
-var _ typedtektonv1alpha1.RunInterface = (*wrapTektonV1alpha1RunImpl)(nil)
+var _ typedtektonv1alpha1.TaskGroupInterface = (*wrapTektonV1alpha1TaskGroupImpl)(nil)
 
-func (w *wrapTektonV1alpha1RunImpl) Create(ctx context.Context, in *v1alpha1.Run, opts v1.CreateOptions) (*v1alpha1.Run, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Create(ctx context.Context, in *v1alpha1.TaskGroup, opts v1.CreateOptions) (*v1alpha1.TaskGroup, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "Run",
+		Kind:    "TaskGroup",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
@@ -149,62 +935,62 @@ func (w *wrapTektonV1alpha1RunImpl) Create(ctx context.Context, in *v1alpha1.Run
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.Run{}
+	out := &v1alpha1.TaskGroup{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
 }
 
-func (w *wrapTektonV1alpha1RunImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+func (w *wrapTektonV1alpha1TaskGroupImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
 	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
 }
 
-func (w *wrapTektonV1alpha1RunImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.Run, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TaskGroup, error) {
 	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.Run{}
+	out := &v1alpha1.TaskGroup{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.RunList, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TaskGroupList, error) {
 	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.RunList{}
+	out := &v1alpha1.TaskGroupList{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.Run, err error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TaskGroup, err error) {
 	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.Run{}
+	out := &v1alpha1.TaskGroup{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) Update(ctx context.Context, in *v1alpha1.Run, opts v1.UpdateOptions) (*v1alpha1.Run, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Update(ctx context.Context, in *v1alpha1.TaskGroup, opts v1.UpdateOptions) (*v1alpha1.TaskGroup, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "Run",
+		Kind:    "TaskGroup",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
@@ -214,18 +1000,18 @@ func (w *wrapTektonV1alpha1RunImpl) Update(ctx context.Context, in *v1alpha1.Run
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.Run{}
+	out := &v1alpha1.TaskGroup{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) UpdateStatus(ctx context.Context, in *v1alpha1.Run, opts v1.UpdateOptions) (*v1alpha1.Run, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) UpdateStatus(ctx context.Context, in *v1alpha1.TaskGroup, opts v1.UpdateOptions) (*v1alpha1.TaskGroup, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "Run",
+		Kind:    "TaskGroup",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
@@ -235,145 +1021,399 @@ func (w *wrapTektonV1alpha1RunImpl) UpdateStatus(ctx context.Context, in *v1alph
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.Run{}
+	out := &v1alpha1.TaskGroup{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1RunImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+func (w *wrapTektonV1alpha1TaskGroupImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) TektonHealths() typedtektonv1alpha1.TektonHealthInterface {
+	return &wrapTektonV1alpha1TektonHealthImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "tektonhealths",
+		}),
+	}
+}
+
+type wrapTektonV1alpha1TektonHealthImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedtektonv1alpha1.TektonHealthInterface = (*wrapTektonV1alpha1TektonHealthImpl)(nil)
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Create(ctx context.Context, in *v1alpha1.TektonHealth, opts v1.CreateOptions) (*v1alpha1.TektonHealth, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TektonHealth",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealth{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.TektonHealth, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealth{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.TektonHealthList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealthList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.TektonHealth, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealth{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Update(ctx context.Context, in *v1alpha1.TektonHealth, opts v1.UpdateOptions) (*v1alpha1.TektonHealth, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TektonHealth",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealth{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) UpdateStatus(ctx context.Context, in *v1alpha1.TektonHealth, opts v1.UpdateOptions) (*v1alpha1.TektonHealth, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "TektonHealth",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.TektonHealth{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1TektonHealthImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1alpha1) VerificationPolicies(namespace string) typedtektonv1alpha1.VerificationPolicyInterface {
+	return &wrapTektonV1alpha1VerificationPolicyImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1alpha1",
+			Resource: "verificationpolicies",
+		}),
+
+		namespace: namespace,
+	}
+}
+
+type wrapTektonV1alpha1VerificationPolicyImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+
+	namespace string
+}
+
+var _ typedtektonv1alpha1.VerificationPolicyInterface = (*wrapTektonV1alpha1VerificationPolicyImpl)(nil)
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Create(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.CreateOptions) (*v1alpha1.VerificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "VerificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VerificationPolicy, error) {
+	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VerificationPolicyList, error) {
+	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicyList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VerificationPolicy, err error) {
+	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Update(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.UpdateOptions) (*v1alpha1.VerificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "VerificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.UpdateOptions) (*v1alpha1.VerificationPolicy, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1alpha1",
+		Kind:    "VerificationPolicy",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &v1alpha1.VerificationPolicy{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1alpha1VerificationPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
 
-func (w *wrapTektonV1alpha1) VerificationPolicies(namespace string) typedtektonv1alpha1.VerificationPolicyInterface {
-	return &wrapTektonV1alpha1VerificationPolicyImpl{
+func (w *wrapTektonV1alpha1) WorkspacePools() typedtektonv1alpha1.WorkspacePoolInterface {
+	return &wrapTektonV1alpha1WorkspacePoolImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
 			Group:    "tekton.dev",
 			Version:  "v1alpha1",
-			Resource: "verificationpolicies",
+			Resource: "workspacepools",
 		}),
-
-		namespace: namespace,
 	}
 }
 
-type wrapTektonV1alpha1VerificationPolicyImpl struct {
+type wrapTektonV1alpha1WorkspacePoolImpl struct {
 	dyn dynamic.NamespaceableResourceInterface
-
-	namespace string
 }
 
-var _ typedtektonv1alpha1.VerificationPolicyInterface = (*wrapTektonV1alpha1VerificationPolicyImpl)(nil)
+var _ typedtektonv1alpha1.WorkspacePoolInterface = (*wrapTektonV1alpha1WorkspacePoolImpl)(nil)
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Create(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.CreateOptions) (*v1alpha1.VerificationPolicy, error) {
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Create(ctx context.Context, in *v1alpha1.WorkspacePool, opts v1.CreateOptions) (*v1alpha1.WorkspacePool, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "VerificationPolicy",
+		Kind:    "WorkspacePool",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
 		return nil, err
 	}
-	uo, err := w.dyn.Namespace(w.namespace).Create(ctx, uo, opts)
+	uo, err := w.dyn.Create(ctx, uo, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicy{}
+	out := &v1alpha1.WorkspacePool{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
-	return w.dyn.Namespace(w.namespace).Delete(ctx, name, opts)
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
-	return w.dyn.Namespace(w.namespace).DeleteCollection(ctx, opts, listOpts)
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VerificationPolicy, error) {
-	uo, err := w.dyn.Namespace(w.namespace).Get(ctx, name, opts)
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.WorkspacePool, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicy{}
+	out := &v1alpha1.WorkspacePool{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VerificationPolicyList, error) {
-	uo, err := w.dyn.Namespace(w.namespace).List(ctx, opts)
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.WorkspacePoolList, error) {
+	uo, err := w.dyn.List(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicyList{}
+	out := &v1alpha1.WorkspacePoolList{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VerificationPolicy, err error) {
-	uo, err := w.dyn.Namespace(w.namespace).Patch(ctx, name, pt, data, opts)
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.WorkspacePool, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicy{}
+	out := &v1alpha1.WorkspacePool{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Update(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.UpdateOptions) (*v1alpha1.VerificationPolicy, error) {
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Update(ctx context.Context, in *v1alpha1.WorkspacePool, opts v1.UpdateOptions) (*v1alpha1.WorkspacePool, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "VerificationPolicy",
+		Kind:    "WorkspacePool",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
 		return nil, err
 	}
-	uo, err := w.dyn.Namespace(w.namespace).Update(ctx, uo, opts)
+	uo, err := w.dyn.Update(ctx, uo, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicy{}
+	out := &v1alpha1.WorkspacePool{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) UpdateStatus(ctx context.Context, in *v1alpha1.VerificationPolicy, opts v1.UpdateOptions) (*v1alpha1.VerificationPolicy, error) {
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) UpdateStatus(ctx context.Context, in *v1alpha1.WorkspacePool, opts v1.UpdateOptions) (*v1alpha1.WorkspacePool, error) {
 	in.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "tekton.dev",
 		Version: "v1alpha1",
-		Kind:    "VerificationPolicy",
+		Kind:    "WorkspacePool",
 	})
 	uo := &unstructured.Unstructured{}
 	if err := convert(in, uo); err != nil {
 		return nil, err
 	}
-	uo, err := w.dyn.Namespace(w.namespace).UpdateStatus(ctx, uo, opts)
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
 	if err != nil {
 		return nil, err
 	}
-	out := &v1alpha1.VerificationPolicy{}
+	out := &v1alpha1.WorkspacePool{}
 	if err := convert(uo, out); err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (w *wrapTektonV1alpha1VerificationPolicyImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+func (w *wrapTektonV1alpha1WorkspacePoolImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
 	return nil, errors.New("NYI: Watch")
 }
 
@@ -1189,6 +2229,260 @@ func (w *wrapTektonV1) RESTClient() rest.Interface {
 	panic("RESTClient called on dynamic client!")
 }
 
+func (w *wrapTektonV1) ClusterPipelines() typedtektonv1.ClusterPipelineInterface {
+	return &wrapTektonV1ClusterPipelineImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1",
+			Resource: "clusterpipelines",
+		}),
+	}
+}
+
+type wrapTektonV1ClusterPipelineImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedtektonv1.ClusterPipelineInterface = (*wrapTektonV1ClusterPipelineImpl)(nil)
+
+func (w *wrapTektonV1ClusterPipelineImpl) Create(ctx context.Context, in *pipelinev1.ClusterPipeline, opts v1.CreateOptions) (*pipelinev1.ClusterPipeline, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterPipeline",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipeline{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*pipelinev1.ClusterPipeline, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipeline{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) List(ctx context.Context, opts v1.ListOptions) (*pipelinev1.ClusterPipelineList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipelineList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *pipelinev1.ClusterPipeline, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipeline{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) Update(ctx context.Context, in *pipelinev1.ClusterPipeline, opts v1.UpdateOptions) (*pipelinev1.ClusterPipeline, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterPipeline",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipeline{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) UpdateStatus(ctx context.Context, in *pipelinev1.ClusterPipeline, opts v1.UpdateOptions) (*pipelinev1.ClusterPipeline, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterPipeline",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterPipeline{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterPipelineImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
+func (w *wrapTektonV1) ClusterTasks() typedtektonv1.ClusterTaskInterface {
+	return &wrapTektonV1ClusterTaskImpl{
+		dyn: w.dyn.Resource(schema.GroupVersionResource{
+			Group:    "tekton.dev",
+			Version:  "v1",
+			Resource: "clustertasks",
+		}),
+	}
+}
+
+type wrapTektonV1ClusterTaskImpl struct {
+	dyn dynamic.NamespaceableResourceInterface
+}
+
+var _ typedtektonv1.ClusterTaskInterface = (*wrapTektonV1ClusterTaskImpl)(nil)
+
+func (w *wrapTektonV1ClusterTaskImpl) Create(ctx context.Context, in *pipelinev1.ClusterTask, opts v1.CreateOptions) (*pipelinev1.ClusterTask, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterTask",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Create(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTask{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return w.dyn.Delete(ctx, name, opts)
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	return w.dyn.DeleteCollection(ctx, opts, listOpts)
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) Get(ctx context.Context, name string, opts v1.GetOptions) (*pipelinev1.ClusterTask, error) {
+	uo, err := w.dyn.Get(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTask{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) List(ctx context.Context, opts v1.ListOptions) (*pipelinev1.ClusterTaskList, error) {
+	uo, err := w.dyn.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTaskList{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *pipelinev1.ClusterTask, err error) {
+	uo, err := w.dyn.Patch(ctx, name, pt, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTask{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) Update(ctx context.Context, in *pipelinev1.ClusterTask, opts v1.UpdateOptions) (*pipelinev1.ClusterTask, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterTask",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.Update(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTask{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) UpdateStatus(ctx context.Context, in *pipelinev1.ClusterTask, opts v1.UpdateOptions) (*pipelinev1.ClusterTask, error) {
+	in.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "tekton.dev",
+		Version: "v1",
+		Kind:    "ClusterTask",
+	})
+	uo := &unstructured.Unstructured{}
+	if err := convert(in, uo); err != nil {
+		return nil, err
+	}
+	uo, err := w.dyn.UpdateStatus(ctx, uo, opts)
+	if err != nil {
+		return nil, err
+	}
+	out := &pipelinev1.ClusterTask{}
+	if err := convert(uo, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (w *wrapTektonV1ClusterTaskImpl) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return nil, errors.New("NYI: Watch")
+}
+
 func (w *wrapTektonV1) Pipelines(namespace string) typedtektonv1.PipelineInterface {
 	return &wrapTektonV1PipelineImpl{
 		dyn: w.dyn.Resource(schema.GroupVersionResource{
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1
 
+// ClusterPipelineListerExpansion allows custom methods to be added to
+// ClusterPipelineLister.
+type ClusterPipelineListerExpansion interface{}
+
+// ClusterTaskListerExpansion allows custom methods to be added to
+// ClusterTaskLister.
+type ClusterTaskListerExpansion interface{}
+
 // PipelineListerExpansion allows custom methods to be added to
 // PipelineLister.
 type PipelineListerExpansion interface{}
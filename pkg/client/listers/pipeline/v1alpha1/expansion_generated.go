@@ -18,6 +18,38 @@ limitations under the License.
 
 package v1alpha1
 
+// CloudEventSinkListerExpansion allows custom methods to be added to
+// CloudEventSinkLister.
+type CloudEventSinkListerExpansion interface{}
+
+// CloudEventSinkNamespaceListerExpansion allows custom methods to be added to
+// CloudEventSinkNamespaceLister.
+type CloudEventSinkNamespaceListerExpansion interface{}
+
+// ExecutionWindowPolicyListerExpansion allows custom methods to be added to
+// ExecutionWindowPolicyLister.
+type ExecutionWindowPolicyListerExpansion interface{}
+
+// ExecutionWindowPolicyNamespaceListerExpansion allows custom methods to be added to
+// ExecutionWindowPolicyNamespaceLister.
+type ExecutionWindowPolicyNamespaceListerExpansion interface{}
+
+// NotificationPolicyListerExpansion allows custom methods to be added to
+// NotificationPolicyLister.
+type NotificationPolicyListerExpansion interface{}
+
+// NotificationPolicyNamespaceListerExpansion allows custom methods to be added to
+// NotificationPolicyNamespaceLister.
+type NotificationPolicyNamespaceListerExpansion interface{}
+
+// PipelineRunArchiveListerExpansion allows custom methods to be added to
+// PipelineRunArchiveLister.
+type PipelineRunArchiveListerExpansion interface{}
+
+// PipelineRunArchiveNamespaceListerExpansion allows custom methods to be added to
+// PipelineRunArchiveNamespaceLister.
+type PipelineRunArchiveNamespaceListerExpansion interface{}
+
 // RunListerExpansion allows custom methods to be added to
 // RunLister.
 type RunListerExpansion interface{}
@@ -26,6 +58,26 @@ type RunListerExpansion interface{}
 // RunNamespaceLister.
 type RunNamespaceListerExpansion interface{}
 
+// ServiceAccountPolicyListerExpansion allows custom methods to be added to
+// ServiceAccountPolicyLister.
+type ServiceAccountPolicyListerExpansion interface{}
+
+// ServiceAccountPolicyNamespaceListerExpansion allows custom methods to be added to
+// ServiceAccountPolicyNamespaceLister.
+type ServiceAccountPolicyNamespaceListerExpansion interface{}
+
+// TaskGroupListerExpansion allows custom methods to be added to
+// TaskGroupLister.
+type TaskGroupListerExpansion interface{}
+
+// TaskGroupNamespaceListerExpansion allows custom methods to be added to
+// TaskGroupNamespaceLister.
+type TaskGroupNamespaceListerExpansion interface{}
+
+// TektonHealthListerExpansion allows custom methods to be added to
+// TektonHealthLister.
+type TektonHealthListerExpansion interface{}
+
 // VerificationPolicyListerExpansion allows custom methods to be added to
 // VerificationPolicyLister.
 type VerificationPolicyListerExpansion interface{}
@@ -33,3 +85,7 @@ type VerificationPolicyListerExpansion interface{}
 // VerificationPolicyNamespaceListerExpansion allows custom methods to be added to
 // VerificationPolicyNamespaceLister.
 type VerificationPolicyNamespaceListerExpansion interface{}
+
+// WorkspacePoolListerExpansion allows custom methods to be added to
+// WorkspacePoolLister.
+type WorkspacePoolListerExpansion interface{}
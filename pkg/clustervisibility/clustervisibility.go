@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clustervisibility enforces the visibility of the ClusterTasks and of the
+// ClusterPipelines, which restricts the namespaces in which they can be referenced.
+package clustervisibility
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	beta1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// ErrNotVisible is returned when a ClusterTask or a ClusterPipeline is referenced
+// in a namespace it is not visible in.
+var ErrNotVisible = errors.New("not visible in the namespace")
+
+// ClusterTaskVisible returns an error wrapping ErrNotVisible if the ClusterTask
+// can't be referenced in the namespace.
+func ClusterTaskVisible(ct *v1beta1.ClusterTask, namespace string) error {
+	if ct.VisibleIn(namespace) {
+		return nil
+	}
+	return fmt.Errorf("%w: ClusterTask %q can't be referenced in namespace %q", ErrNotVisible, ct.Name, namespace)
+}
+
+// ClusterPipelineVisible returns an error wrapping ErrNotVisible if the ClusterPipeline
+// can't be referenced in the namespace.
+func ClusterPipelineVisible(cp *v1.ClusterPipeline, namespace string) error {
+	if cp.Spec.Visibility.VisibleIn(namespace) {
+		return nil
+	}
+	return fmt.Errorf("%w: ClusterPipeline %q can't be referenced in namespace %q", ErrNotVisible, cp.Name, namespace)
+}
+
+// NewValidateFunc returns a validate.ClusterVisibilityFunc checking the visibility of
+// the ClusterTasks and ClusterPipelines listed by the listers. The references to the
+// ones which don't exist yet are checked when the runs referencing them are reconciled.
+func NewValidateFunc(clusterTasks beta1listers.ClusterTaskLister, clusterPipelines listers.ClusterPipelineLister) validate.ClusterVisibilityFunc {
+	return func(ctx context.Context, namespace, kind, name string) error {
+		switch kind {
+		case string(v1beta1.ClusterTaskKind):
+			ct, err := clusterTasks.Get(name)
+			if apierrors.IsNotFound(err) {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("failed to get ClusterTask %s: %w", name, err)
+			}
+			return ClusterTaskVisible(ct, namespace)
+		case string(v1.ClusterPipelineKind):
+			cp, err := clusterPipelines.Get(name)
+			if apierrors.IsNotFound(err) {
+				return nil
+			} else if err != nil {
+				return fmt.Errorf("failed to get ClusterPipeline %s: %w", name, err)
+			}
+			return ClusterPipelineVisible(cp, namespace)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clustervisibility_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1"
+	beta1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/clustervisibility"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestNewValidateFunc(t *testing.T) {
+	clusterTasks := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ct := range []*v1beta1.ClusterTask{{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "restricted",
+			Annotations: map[string]string{v1beta1.VisibleNamespacesAnnotationKey: "team-a, team-b"},
+		},
+	}} {
+		if err := clusterTasks.Add(ct); err != nil {
+			t.Fatal(err)
+		}
+	}
+	clusterPipelines := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, cp := range []*v1.ClusterPipeline{{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "restricted"},
+		Spec: v1.ClusterPipelineSpec{
+			Visibility: &v1.ClusterVisibility{Namespaces: []string{"team-a"}},
+		},
+	}} {
+		if err := clusterPipelines.Add(cp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f := clustervisibility.NewValidateFunc(beta1listers.NewClusterTaskLister(clusterTasks), listers.NewClusterPipelineLister(clusterPipelines))
+
+	for _, tc := range []struct {
+		namespace, kind, name string
+		wantNotVisible        bool
+	}{
+		{"team-c", "ClusterTask", "shared", false},
+		{"team-b", "ClusterTask", "restricted", false},
+		{"team-c", "ClusterTask", "restricted", true},
+		{"team-c", "ClusterTask", "missing", false},
+		{"team-c", "ClusterPipeline", "shared", false},
+		{"team-a", "ClusterPipeline", "restricted", false},
+		{"team-b", "ClusterPipeline", "restricted", true},
+		{"team-b", "ClusterPipeline", "missing", false},
+		{"team-b", "Task", "restricted", false},
+	} {
+		err := f(context.Background(), tc.namespace, tc.kind, tc.name)
+		if got := errors.Is(err, clustervisibility.ErrNotVisible); got != tc.wantNotVisible || (err != nil && !got) {
+			t.Errorf("%s %s in namespace %s: expected not visible to be %t, got error %v", tc.kind, tc.name, tc.namespace, tc.wantNotVisible, err)
+		}
+	}
+}
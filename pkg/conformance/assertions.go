@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var errNoRun = errors.New("the case has no run")
+
+// Succeeded asserts the run succeeded.
+func Succeeded() Assertion {
+	return conditionStatus(corev1.ConditionTrue)
+}
+
+// Failed asserts the run failed.
+func Failed() Assertion {
+	return conditionStatus(corev1.ConditionFalse)
+}
+
+func conditionStatus(want corev1.ConditionStatus) Assertion {
+	return func(run Run) error {
+		status, err := runStatus(run)
+		if err != nil {
+			return err
+		}
+		c := status.GetCondition(apis.ConditionSucceeded)
+		if c == nil {
+			return errors.New("the run has no Succeeded condition")
+		}
+		if c.Status != want {
+			return fmt.Errorf("the Succeeded condition of the run is %q with reason %q and message %q, want %q", c.Status, c.Reason, c.Message, want)
+		}
+		return nil
+	}
+}
+
+// HasRequiredFields asserts the run has the fields the API specification
+// requires conformant implementations to populate.
+func HasRequiredFields() Assertion {
+	return func(run Run) error {
+		var missing []string
+		var meta interface {
+			GetName() string
+			GetLabels() map[string]string
+			GetAnnotations() map[string]string
+		}
+		var started, completed bool
+		switch {
+		case run.TaskRun != nil:
+			meta = run.TaskRun
+			started, completed = run.TaskRun.Status.StartTime != nil, run.TaskRun.Status.CompletionTime != nil
+			if len(run.TaskRun.Status.Steps) == 0 {
+				missing = append(missing, "status.steps")
+			}
+		case run.PipelineRun != nil:
+			meta = run.PipelineRun
+			started, completed = run.PipelineRun.Status.StartTime != nil, run.PipelineRun.Status.CompletionTime != nil
+		default:
+			return errNoRun
+		}
+		if meta.GetName() == "" {
+			missing = append(missing, "metadata.name")
+		}
+		if len(meta.GetLabels()) == 0 {
+			missing = append(missing, "metadata.labels")
+		}
+		if len(meta.GetAnnotations()) == 0 {
+			missing = append(missing, "metadata.annotations")
+		}
+		if !started {
+			missing = append(missing, "status.startTime")
+		}
+		if !completed {
+			missing = append(missing, "status.completionTime")
+		}
+		status, _ := runStatus(run)
+		if c := status.GetCondition(apis.ConditionSucceeded); c == nil {
+			missing = append(missing, "status.conditions")
+		} else {
+			if c.Reason == "" {
+				missing = append(missing, "status.conditions[Succeeded].reason")
+			}
+			if c.Message == "" {
+				missing = append(missing, "status.conditions[Succeeded].message")
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("the run is missing the required fields %s", strings.Join(missing, ", "))
+		}
+		return nil
+	}
+}
+
+// StepExitCodes asserts the steps of the TaskRun terminated with the exit codes,
+// in order.
+func StepExitCodes(codes ...int32) Assertion {
+	return func(run Run) error {
+		if run.TaskRun == nil {
+			return errors.New("the case has no TaskRun")
+		}
+		steps := run.TaskRun.Status.Steps
+		if len(steps) != len(codes) {
+			return fmt.Errorf("the TaskRun has %d steps, want %d", len(steps), len(codes))
+		}
+		for i, step := range steps {
+			if step.Terminated == nil {
+				return fmt.Errorf("step %d of the TaskRun has not terminated", i)
+			}
+			if step.Terminated.ExitCode != codes[i] {
+				return fmt.Errorf("step %d of the TaskRun exited with %d, want %d", i, step.Terminated.ExitCode, codes[i])
+			}
+		}
+		return nil
+	}
+}
+
+// TaskRunResult asserts the TaskRun has the result with the string value.
+func TaskRunResult(name, value string) Assertion {
+	return func(run Run) error {
+		if run.TaskRun == nil {
+			return errors.New("the case has no TaskRun")
+		}
+		for _, r := range run.TaskRun.Status.Results {
+			if r.Name == name {
+				if r.Value.StringVal != value {
+					return fmt.Errorf("the result %q of the TaskRun is %q, want %q", name, r.Value.StringVal, value)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("the TaskRun has no result %q", name)
+	}
+}
+
+// PipelineRunResult asserts the PipelineRun has the result with the string value.
+func PipelineRunResult(name, value string) Assertion {
+	return func(run Run) error {
+		if run.PipelineRun == nil {
+			return errors.New("the case has no PipelineRun")
+		}
+		for _, r := range run.PipelineRun.Status.Results {
+			if r.Name == name {
+				if r.Value.StringVal != value {
+					return fmt.Errorf("the result %q of the PipelineRun is %q, want %q", name, r.Value.StringVal, value)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("the PipelineRun has no result %q", name)
+	}
+}
+
+// ChildTaskRuns asserts the PipelineRun ran the number of TaskRuns.
+func ChildTaskRuns(count int) Assertion {
+	return func(run Run) error {
+		if run.PipelineRun == nil {
+			return errors.New("the case has no PipelineRun")
+		}
+		got := 0
+		for _, child := range run.PipelineRun.Status.ChildReferences {
+			if child.Kind == "TaskRun" {
+				got++
+			}
+		}
+		if got != count {
+			return fmt.Errorf("the PipelineRun ran %d TaskRuns, want %d", got, count)
+		}
+		return nil
+	}
+}
+
+func runStatus(run Run) (*duckv1.Status, error) {
+	switch {
+	case run.TaskRun != nil:
+		return &run.TaskRun.Status.Status, nil
+	case run.PipelineRun != nil:
+		return &run.PipelineRun.Status.Status, nil
+	default:
+		return &duckv1.Status{}, errNoRun
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"testing"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/conformance"
+	"github.com/tektoncd/pipeline/test/parse"
+)
+
+func TestAssertions(t *testing.T) {
+	tr := parse.MustParseV1TaskRun(t, `
+metadata:
+  name: tr
+  labels:
+    app.kubernetes.io/managed-by: tekton-pipelines
+  annotations:
+    pipeline.tekton.dev/release: devel
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+    reason: Succeeded
+    message: All Steps have completed executing
+  startTime: "2023-05-01T10:00:00Z"
+  completionTime: "2023-05-01T10:01:00Z"
+  steps:
+  - name: build
+    terminated:
+      exitCode: 0
+  - name: test
+    terminated:
+      exitCode: 1
+  results:
+  - name: greeting
+    type: string
+    value: hello
+`)
+	pr := parse.MustParseV1PipelineRun(t, `
+metadata:
+  name: pr
+status:
+  conditions:
+  - type: Succeeded
+    status: "False"
+    reason: Failed
+  results:
+  - name: greeting
+    value: hello
+  childReferences:
+  - kind: TaskRun
+    name: pr-build
+  - kind: CustomRun
+    name: pr-wait
+`)
+	for _, tc := range []struct {
+		name      string
+		assertion conformance.Assertion
+		run       conformance.Run
+		wantErr   string
+	}{{
+		name:      "succeeded",
+		assertion: conformance.Succeeded(),
+		run:       conformance.Run{TaskRun: tr},
+	}, {
+		name:      "not succeeded",
+		assertion: conformance.Succeeded(),
+		run:       conformance.Run{PipelineRun: pr},
+		wantErr:   `the Succeeded condition of the run is "False" with reason "Failed" and message "", want "True"`,
+	}, {
+		name:      "failed",
+		assertion: conformance.Failed(),
+		run:       conformance.Run{PipelineRun: pr},
+	}, {
+		name:      "no condition",
+		assertion: conformance.Failed(),
+		run:       conformance.Run{TaskRun: &v1.TaskRun{}},
+		wantErr:   "the run has no Succeeded condition",
+	}, {
+		name:      "no run",
+		assertion: conformance.Succeeded(),
+		wantErr:   "the case has no run",
+	}, {
+		name:      "required fields",
+		assertion: conformance.HasRequiredFields(),
+		run:       conformance.Run{TaskRun: tr},
+	}, {
+		name:      "missing required fields",
+		assertion: conformance.HasRequiredFields(),
+		run:       conformance.Run{PipelineRun: pr},
+		wantErr:   "the run is missing the required fields metadata.labels, metadata.annotations, status.startTime, status.completionTime, status.conditions[Succeeded].message",
+	}, {
+		name:      "step exit codes",
+		assertion: conformance.StepExitCodes(0, 1),
+		run:       conformance.Run{TaskRun: tr},
+	}, {
+		name:      "wrong step exit code",
+		assertion: conformance.StepExitCodes(0, 0),
+		run:       conformance.Run{TaskRun: tr},
+		wantErr:   "step 1 of the TaskRun exited with 1, want 0",
+	}, {
+		name:      "wrong number of steps",
+		assertion: conformance.StepExitCodes(0),
+		run:       conformance.Run{TaskRun: tr},
+		wantErr:   "the TaskRun has 2 steps, want 1",
+	}, {
+		name:      "task run result",
+		assertion: conformance.TaskRunResult("greeting", "hello"),
+		run:       conformance.Run{TaskRun: tr},
+	}, {
+		name:      "wrong task run result",
+		assertion: conformance.TaskRunResult("greeting", "bye"),
+		run:       conformance.Run{TaskRun: tr},
+		wantErr:   `the result "greeting" of the TaskRun is "hello", want "bye"`,
+	}, {
+		name:      "missing task run result",
+		assertion: conformance.TaskRunResult("missing", "hello"),
+		run:       conformance.Run{TaskRun: tr},
+		wantErr:   `the TaskRun has no result "missing"`,
+	}, {
+		name:      "task run result of a pipeline run",
+		assertion: conformance.TaskRunResult("greeting", "hello"),
+		run:       conformance.Run{PipelineRun: pr},
+		wantErr:   "the case has no TaskRun",
+	}, {
+		name:      "pipeline run result",
+		assertion: conformance.PipelineRunResult("greeting", "hello"),
+		run:       conformance.Run{PipelineRun: pr},
+	}, {
+		name:      "missing pipeline run result",
+		assertion: conformance.PipelineRunResult("missing", "hello"),
+		run:       conformance.Run{PipelineRun: pr},
+		wantErr:   `the PipelineRun has no result "missing"`,
+	}, {
+		name:      "child task runs",
+		assertion: conformance.ChildTaskRuns(1),
+		run:       conformance.Run{PipelineRun: pr},
+	}, {
+		name:      "wrong number of child task runs",
+		assertion: conformance.ChildTaskRuns(2),
+		run:       conformance.Run{PipelineRun: pr},
+		wantErr:   "the PipelineRun ran 1 TaskRuns, want 2",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.assertion(tc.run)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("expected the assertion to pass but got %v", err)
+			case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+				t.Errorf("expected the error %q but got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conformance runs the Tekton conformance corpus, or other cases, against
+// an implementation of the Tekton API and reports the results, so that products
+// embedding Tekton Pipelines can verify programmatically that they behave like it.
+package conformance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// Case is a conformance test case: a TaskRun or a PipelineRun, and the
+// assertions its completed run must satisfy.
+type Case struct {
+	// Name identifies the Case in the Report.
+	Name string
+	// TaskRun is the TaskRun run by the Case. Exactly one of TaskRun and
+	// PipelineRun must be set.
+	TaskRun *v1.TaskRun
+	// PipelineRun is the PipelineRun run by the Case.
+	PipelineRun *v1.PipelineRun
+	// Assertions are the assertions the completed run must satisfy.
+	Assertions []Assertion
+}
+
+// Run is the completed TaskRun or PipelineRun of a Case.
+type Run struct {
+	TaskRun     *v1.TaskRun
+	PipelineRun *v1.PipelineRun
+}
+
+// Assertion checks the completed run of a Case. It returns an error describing
+// how the run does not conform, or nil.
+type Assertion func(Run) error
+
+// Executor executes the TaskRuns and PipelineRuns of the Cases against an
+// implementation of the Tekton API, such as a cluster, and returns them once
+// they are done.
+type Executor interface {
+	ExecuteTaskRun(ctx context.Context, tr *v1.TaskRun) (*v1.TaskRun, error)
+	ExecutePipelineRun(ctx context.Context, pr *v1.PipelineRun) (*v1.PipelineRun, error)
+}
+
+// Runner runs conformance Cases with an Executor.
+type Runner struct {
+	Executor Executor
+	// Timeout is the time each Case has to complete. The Cases don't time out
+	// when it is zero.
+	Timeout time.Duration
+}
+
+// CaseResult is the outcome of a Case.
+type CaseResult struct {
+	Name     string
+	Duration time.Duration
+	// Error is the error executing the Case, whose run is then not asserted.
+	Error error
+	// Failures are the errors returned by the assertions of the Case.
+	Failures []error
+}
+
+// Passed returns true if the Case was executed and satisfied all its assertions.
+func (r CaseResult) Passed() bool {
+	return r.Error == nil && len(r.Failures) == 0
+}
+
+// Report is the outcome of running conformance Cases.
+type Report struct {
+	Results []CaseResult
+}
+
+// Passed returns true if all the Cases passed.
+func (r Report) Passed() bool {
+	for _, result := range r.Results {
+		if !result.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Run runs the Cases one after the other and reports their outcome. A Case
+// failing does not stop the next ones from running, but the Cases which have not
+// started when ctx is done are reported with its error.
+func (r Runner) Run(ctx context.Context, cases []Case) Report {
+	report := Report{}
+	for _, c := range cases {
+		report.Results = append(report.Results, r.runCase(ctx, c))
+	}
+	return report
+}
+
+func (r Runner) runCase(ctx context.Context, c Case) CaseResult {
+	result := CaseResult{Name: c.Name}
+	if err := ctx.Err(); err != nil {
+		result.Error = err
+		return result
+	}
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	run, err := r.execute(ctx, c)
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err
+		return result
+	}
+	for _, assert := range c.Assertions {
+		if err := assert(run); err != nil {
+			result.Failures = append(result.Failures, err)
+		}
+	}
+	return result
+}
+
+func (r Runner) execute(ctx context.Context, c Case) (Run, error) {
+	switch {
+	case c.TaskRun != nil && c.PipelineRun == nil:
+		tr, err := r.Executor.ExecuteTaskRun(ctx, c.TaskRun.DeepCopy())
+		return Run{TaskRun: tr}, err
+	case c.PipelineRun != nil && c.TaskRun == nil:
+		pr, err := r.Executor.ExecutePipelineRun(ctx, c.PipelineRun.DeepCopy())
+		return Run{PipelineRun: pr}, err
+	default:
+		return Run{}, errors.New("a conformance case must have exactly one of a TaskRun and a PipelineRun")
+	}
+}
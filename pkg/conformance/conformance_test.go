@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/conformance"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// fakeExecutor completes the runs with the conditions of the runs named after them.
+type fakeExecutor struct {
+	conditions map[string]corev1.ConditionStatus
+	err        error
+}
+
+func (e *fakeExecutor) ExecuteTaskRun(ctx context.Context, tr *v1.TaskRun) (*v1.TaskRun, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: e.conditions[tr.Name]})
+	return tr, nil
+}
+
+func (e *fakeExecutor) ExecutePipelineRun(ctx context.Context, pr *v1.PipelineRun) (*v1.PipelineRun, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: e.conditions[pr.Name]})
+	return pr, nil
+}
+
+func reportErrors(report conformance.Report) map[string][]string {
+	got := map[string][]string{}
+	for _, r := range report.Results {
+		got[r.Name] = []string{}
+		if r.Error != nil {
+			got[r.Name] = append(got[r.Name], "error: "+r.Error.Error())
+		}
+		for _, f := range r.Failures {
+			got[r.Name] = append(got[r.Name], f.Error())
+		}
+	}
+	return got
+}
+
+func TestRunner_Run(t *testing.T) {
+	cases := []conformance.Case{{
+		Name:       "passes",
+		TaskRun:    &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr-succeeded"}},
+		Assertions: []conformance.Assertion{conformance.Succeeded()},
+	}, {
+		Name:        "fails",
+		PipelineRun: &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr-failed"}},
+		Assertions:  []conformance.Assertion{conformance.Succeeded(), conformance.ChildTaskRuns(1)},
+	}, {
+		Name: "invalid",
+	}}
+	runner := conformance.Runner{Executor: &fakeExecutor{conditions: map[string]corev1.ConditionStatus{
+		"tr-succeeded": corev1.ConditionTrue,
+		"pr-failed":    corev1.ConditionFalse,
+	}}}
+	report := runner.Run(context.Background(), cases)
+
+	want := map[string][]string{
+		"passes": {},
+		"fails": {
+			`the Succeeded condition of the run is "False" with reason "" and message "", want "True"`,
+			"the PipelineRun ran 0 TaskRuns, want 1",
+		},
+		"invalid": {"error: a conformance case must have exactly one of a TaskRun and a PipelineRun"},
+	}
+	if d := cmp.Diff(want, reportErrors(report)); d != "" {
+		t.Errorf("report %s", diff.PrintWantGot(d))
+	}
+	if report.Passed() {
+		t.Error("expected the report not to pass")
+	}
+	if !report.Results[0].Passed() {
+		t.Error("expected the first case to pass")
+	}
+	if cases[0].TaskRun.Status.GetCondition(apis.ConditionSucceeded) != nil {
+		t.Error("expected the runs of the cases not to be modified")
+	}
+}
+
+func TestRunner_RunError(t *testing.T) {
+	cases := []conformance.Case{{
+		Name:       "error",
+		TaskRun:    &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr"}},
+		Assertions: []conformance.Assertion{conformance.Succeeded()},
+	}}
+	runner := conformance.Runner{Executor: &fakeExecutor{err: errors.New("connection refused")}, Timeout: time.Minute}
+	report := runner.Run(context.Background(), cases)
+	if d := cmp.Diff(map[string][]string{"error": {"error: connection refused"}}, reportErrors(report)); d != "" {
+		t.Errorf("report %s", diff.PrintWantGot(d))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runner = conformance.Runner{Executor: &fakeExecutor{}}
+	report = runner.Run(ctx, cases)
+	if d := cmp.Diff(map[string][]string{"error": {"error: context canceled"}}, reportErrors(report)); d != "" {
+		t.Errorf("report %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Corpus returns the Tekton conformance Cases. Their steps run the image, which
+// must provide a POSIX shell, e.g. busybox.
+func Corpus(image string) []Case {
+	return []Case{{
+		Name: "successful-task-run",
+		TaskRun: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "successful-task-run"},
+			Spec: v1.TaskRunSpec{TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{
+				Image:   image,
+				Command: []string{"echo", "hello"},
+			}}}},
+		},
+		Assertions: []Assertion{Succeeded(), HasRequiredFields(), StepExitCodes(0)},
+	}, {
+		Name: "failed-task-run",
+		TaskRun: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed-task-run"},
+			Spec: v1.TaskRunSpec{TaskSpec: &v1.TaskSpec{Steps: []v1.Step{{
+				Image:   image,
+				Command: []string{"/bin/sh"},
+				Args:    []string{"-c", "echo hello"},
+			}, {
+				Image:   image,
+				Command: []string{"/bin/sh"},
+				Args:    []string{"-c", "exit 1"},
+			}, {
+				Image:   image,
+				Command: []string{"/bin/sh"},
+				Args:    []string{"-c", "sleep 30s"},
+			}}}},
+		},
+		// The steps following a failed step are not run, and are reported as failed.
+		Assertions: []Assertion{Failed(), HasRequiredFields(), StepExitCodes(0, 1, 1)},
+	}, {
+		Name: "task-run-params-and-results",
+		TaskRun: &v1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "task-run-params-and-results"},
+			Spec: v1.TaskRunSpec{
+				Params: v1.Params{{Name: "who", Value: *v1.NewStructuredValues("tekton")}},
+				TaskSpec: &v1.TaskSpec{
+					Params:  []v1.ParamSpec{{Name: "who", Type: v1.ParamTypeString}},
+					Results: []v1.TaskResult{{Name: "greeting", Type: v1.ResultsTypeString}},
+					Steps: []v1.Step{{
+						Image:  image,
+						Script: `printf "hello $(params.who)" > $(results.greeting.path)`,
+					}},
+				},
+			},
+		},
+		Assertions: []Assertion{Succeeded(), HasRequiredFields(), TaskRunResult("greeting", "hello tekton")},
+	}, {
+		Name: "successful-pipeline-run",
+		PipelineRun: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "successful-pipeline-run"},
+			Spec: v1.PipelineRunSpec{PipelineSpec: &v1.PipelineSpec{
+				Results: []v1.PipelineResult{{
+					Name:  "greeting",
+					Value: *v1.NewStructuredValues("$(tasks.greet.results.greeting) again"),
+				}},
+				Tasks: []v1.PipelineTask{{
+					Name: "hello",
+					TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+						Results: []v1.TaskResult{{Name: "who", Type: v1.ResultsTypeString}},
+						Steps: []v1.Step{{
+							Image:  image,
+							Script: `printf "tekton" > $(results.who.path)`,
+						}},
+					}},
+				}, {
+					Name:   "greet",
+					Params: v1.Params{{Name: "who", Value: *v1.NewStructuredValues("$(tasks.hello.results.who)")}},
+					TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+						Params:  []v1.ParamSpec{{Name: "who", Type: v1.ParamTypeString}},
+						Results: []v1.TaskResult{{Name: "greeting", Type: v1.ResultsTypeString}},
+						Steps: []v1.Step{{
+							Image:  image,
+							Script: `printf "hello $(params.who)" > $(results.greeting.path)`,
+						}},
+					}},
+				}},
+			}},
+		},
+		Assertions: []Assertion{Succeeded(), HasRequiredFields(), ChildTaskRuns(2), PipelineRunResult("greeting", "hello tekton again")},
+	}, {
+		Name: "failed-pipeline-run",
+		PipelineRun: &v1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed-pipeline-run"},
+			Spec: v1.PipelineRunSpec{PipelineSpec: &v1.PipelineSpec{
+				Tasks: []v1.PipelineTask{{
+					Name: "fail",
+					TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{Steps: []v1.Step{{
+						Image:   image,
+						Command: []string{"/bin/sh"},
+						Args:    []string{"-c", "exit 1"},
+					}}}},
+				}, {
+					Name:     "skipped",
+					RunAfter: []string{"fail"},
+					TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{Steps: []v1.Step{{
+						Image:   image,
+						Command: []string{"echo", "hello"},
+					}}}},
+				}},
+			}},
+		},
+		// The tasks running after a failed task are not run.
+		Assertions: []Assertion{Failed(), HasRequiredFields(), ChildTaskRuns(1)},
+	}}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/conformance"
+)
+
+func TestCorpus_Valid(t *testing.T) {
+	ctx := context.Background()
+	for _, c := range conformance.Corpus("busybox") {
+		t.Run(c.Name, func(t *testing.T) {
+			if len(c.Assertions) == 0 {
+				t.Error("expected the case to have assertions")
+			}
+			switch {
+			case c.TaskRun != nil:
+				tr := c.TaskRun.DeepCopy()
+				tr.Namespace = "conformance"
+				tr.SetDefaults(ctx)
+				if err := tr.Validate(ctx); err != nil {
+					t.Errorf("invalid TaskRun: %v", err)
+				}
+			case c.PipelineRun != nil:
+				pr := c.PipelineRun.DeepCopy()
+				pr.Namespace = "conformance"
+				pr.SetDefaults(ctx)
+				if err := pr.Validate(ctx); err != nil {
+					t.Errorf("invalid PipelineRun: %v", err)
+				}
+			default:
+				t.Error("expected the case to have a run")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	clientv1 "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/typed/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultPollInterval is the default interval the cluster executor polls the runs at.
+const DefaultPollInterval = time.Second
+
+// clusterExecutor executes the runs by creating them with the Tekton API of a
+// cluster, and polling them until they are done.
+type clusterExecutor struct {
+	taskRuns     clientv1.TaskRunInterface
+	pipelineRuns clientv1.PipelineRunInterface
+	interval     time.Duration
+}
+
+var _ Executor = (*clusterExecutor)(nil)
+
+// NewClusterExecutor returns an Executor creating the runs with the clients of
+// the TaskRuns and PipelineRuns of a namespace, such as
+// clientset.TektonV1().TaskRuns(namespace), and polling them every interval
+// until they are done. The runs are created with a name generated from the name
+// of the run of the Case, so that the Cases can run more than once.
+func NewClusterExecutor(taskRuns clientv1.TaskRunInterface, pipelineRuns clientv1.PipelineRunInterface, interval time.Duration) Executor {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &clusterExecutor{taskRuns: taskRuns, pipelineRuns: pipelineRuns, interval: interval}
+}
+
+// ExecuteTaskRun implements Executor.
+func (e *clusterExecutor) ExecuteTaskRun(ctx context.Context, tr *v1.TaskRun) (*v1.TaskRun, error) {
+	generateName(&tr.ObjectMeta)
+	created, err := e.taskRuns.Create(ctx, tr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating TaskRun: %w", err)
+	}
+	done := created
+	err = wait.PollImmediateUntilWithContext(ctx, e.interval, func(ctx context.Context) (bool, error) {
+		got, err := e.taskRuns.Get(ctx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		done = got
+		return got.IsDone(), nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("error waiting for TaskRun %s to be done: %w", created.Name, err)
+	}
+	return done, nil
+}
+
+// ExecutePipelineRun implements Executor.
+func (e *clusterExecutor) ExecutePipelineRun(ctx context.Context, pr *v1.PipelineRun) (*v1.PipelineRun, error) {
+	generateName(&pr.ObjectMeta)
+	created, err := e.pipelineRuns.Create(ctx, pr, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error creating PipelineRun: %w", err)
+	}
+	done := created
+	err = wait.PollImmediateUntilWithContext(ctx, e.interval, func(ctx context.Context) (bool, error) {
+		got, err := e.pipelineRuns.Get(ctx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		done = got
+		return got.IsDone(), nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("error waiting for PipelineRun %s to be done: %w", created.Name, err)
+	}
+	return done, nil
+}
+
+// generateName replaces the name of a run with a prefix its name is generated from.
+func generateName(meta *metav1.ObjectMeta) {
+	if meta.Name != "" {
+		meta.GenerateName = meta.Name + "-"
+		meta.Name = ""
+	}
+	meta.Namespace = ""
+}
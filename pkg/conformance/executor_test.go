@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/conformance"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+// newFakeClientset returns a clientset generating the names of the runs, whose
+// runs are done with the condition once they have been polled twice.
+func newFakeClientset(status corev1.ConditionStatus) *fake.Clientset {
+	cs := fake.NewSimpleClientset()
+	cs.PrependReactor("create", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		obj := action.(ktesting.CreateAction).GetObject().(metav1.Object)
+		obj.SetName(obj.GetGenerateName() + "abcde")
+		return false, nil, nil
+	})
+	gets := 0
+	cs.PrependReactor("get", "*", func(action ktesting.Action) (bool, runtime.Object, error) {
+		gets++
+		if gets < 2 {
+			return false, nil, nil
+		}
+		get := action.(ktesting.GetAction)
+		obj, err := cs.Tracker().Get(get.GetResource(), get.GetNamespace(), get.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		condition := &apis.Condition{Type: apis.ConditionSucceeded, Status: status}
+		switch run := obj.(type) {
+		case *v1.TaskRun:
+			run.Status.SetCondition(condition)
+		case *v1.PipelineRun:
+			run.Status.SetCondition(condition)
+		}
+		return true, obj, nil
+	})
+	return cs
+}
+
+func TestClusterExecutor(t *testing.T) {
+	ctx := context.Background()
+	cs := newFakeClientset(corev1.ConditionTrue)
+	executor := conformance.NewClusterExecutor(cs.TektonV1().TaskRuns("conformance"), cs.TektonV1().PipelineRuns("conformance"), time.Millisecond)
+
+	tr, err := executor.ExecuteTaskRun(ctx, &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "default"}})
+	if err != nil {
+		t.Fatalf("ExecuteTaskRun: %v", err)
+	}
+	if tr.Name != "tr-abcde" || !tr.IsSuccessful() {
+		t.Errorf("expected the TaskRun tr-abcde to succeed but got %s with %v", tr.Name, tr.Status.Conditions)
+	}
+	if _, err := cs.TektonV1().TaskRuns("conformance").Get(ctx, "tr-abcde", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the TaskRun to be created in the namespace of the client: %v", err)
+	}
+
+	cs = newFakeClientset(corev1.ConditionFalse)
+	executor = conformance.NewClusterExecutor(cs.TektonV1().TaskRuns("conformance"), cs.TektonV1().PipelineRuns("conformance"), 0)
+	pr, err := executor.ExecutePipelineRun(ctx, &v1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr"}})
+	if err != nil {
+		t.Fatalf("ExecutePipelineRun: %v", err)
+	}
+	if pr.Name != "pr-abcde" || !pr.IsDone() || pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+		t.Errorf("expected the PipelineRun pr-abcde to fail but got %s with %v", pr.Name, pr.Status.Conditions)
+	}
+}
+
+func TestClusterExecutor_Timeout(t *testing.T) {
+	cs := newFakeClientset(corev1.ConditionUnknown)
+	executor := conformance.NewClusterExecutor(cs.TektonV1().TaskRuns("conformance"), cs.TektonV1().PipelineRuns("conformance"), time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := executor.ExecuteTaskRun(ctx, &v1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "tr"}}); err == nil {
+		t.Error("expected an error waiting for a TaskRun which is never done")
+	}
+}
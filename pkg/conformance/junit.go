@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// WriteJUnit writes the Report in the JUnit XML format, as a test suite with the
+// given name holding a test case per conformance Case.
+func (r Report) WriteJUnit(w io.Writer, suite string) error {
+	s := junitTestSuite{Name: suite, Tests: len(r.Results)}
+	var total float64
+	for _, result := range r.Results {
+		tc := junitTestCase{Name: result.Name, ClassName: suite, Time: seconds(result.Duration.Seconds())}
+		total += result.Duration.Seconds()
+		switch {
+		case result.Error != nil:
+			s.Errors++
+			tc.Error = &junitMessage{Message: result.Error.Error(), Contents: result.Error.Error()}
+		case len(result.Failures) > 0:
+			s.Failures++
+			var failures []string
+			for _, f := range result.Failures {
+				failures = append(failures, f.Error())
+			}
+			tc.Failure = &junitMessage{
+				Message:  fmt.Sprintf("%d assertions failed", len(failures)),
+				Contents: strings.Join(failures, "\n"),
+			}
+		}
+		s.Cases = append(s.Cases, tc)
+	}
+	s.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{s}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func seconds(s float64) string {
+	return fmt.Sprintf("%.3f", s)
+}
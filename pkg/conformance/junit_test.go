@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conformance_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/conformance"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestReport_WriteJUnit(t *testing.T) {
+	report := conformance.Report{Results: []conformance.CaseResult{{
+		Name:     "passes",
+		Duration: 1500 * time.Millisecond,
+	}, {
+		Name:     "fails",
+		Duration: 2 * time.Second,
+		Failures: []error{errors.New("the TaskRun has no result \"greeting\""), errors.New("step 0 of the TaskRun exited with 1, want 0")},
+	}, {
+		Name:  "errors",
+		Error: errors.New("connection refused"),
+	}}}
+	var b bytes.Buffer
+	if err := report.WriteJUnit(&b, "tekton-conformance"); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="tekton-conformance" tests="3" failures="1" errors="1" time="3.500">
+    <testcase name="passes" classname="tekton-conformance" time="1.500"></testcase>
+    <testcase name="fails" classname="tekton-conformance" time="2.000">
+      <failure message="2 assertions failed">the TaskRun has no result &#34;greeting&#34;&#xA;step 0 of the TaskRun exited with 1, want 0</failure>
+    </testcase>
+    <testcase name="errors" classname="tekton-conformance" time="0.000">
+      <error message="connection refused">connection refused</error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+	if d := cmp.Diff(want, b.String()); d != "" {
+		t.Errorf("JUnit report %s", diff.PrintWantGot(d))
+	}
+}
@@ -17,13 +17,30 @@
 package container
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
 )
 
+// shellSafeEnvPrefix is the prefix of the environment variables holding the values
+// of the variables referenced in the script of a shellSafe Step.
+const shellSafeEnvPrefix = "TEKTON_"
+
+var nonEnvNameCharacters = regexp.MustCompile(`[^A-Z0-9]+`)
+
 // ApplyStepReplacements applies variable interpolation on a Step.
 func ApplyStepReplacements(step *v1beta1.Step, stringReplacements map[string]string, arrayReplacements map[string][]string) {
-	step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
+	var scriptEnv []corev1.EnvVar
+	if step.Interpolation == v1beta1.ShellSafeInterpolation {
+		step.Script, scriptEnv = applyShellSafeScriptReplacements(step.Script, step.Env, stringReplacements)
+	} else {
+		step.Script = substitution.ApplyReplacements(step.Script, stringReplacements)
+	}
 	step.OnError = (v1beta1.OnErrorType)(substitution.ApplyReplacements(string(step.OnError), stringReplacements))
 	if step.StdoutConfig != nil {
 		step.StdoutConfig.Path = substitution.ApplyReplacements(step.StdoutConfig.Path, stringReplacements)
@@ -32,6 +49,55 @@ func ApplyStepReplacements(step *v1beta1.Step, stringReplacements map[string]str
 		step.StderrConfig.Path = substitution.ApplyReplacements(step.StderrConfig.Path, stringReplacements)
 	}
 	applyStepReplacements(step, stringReplacements, arrayReplacements)
+	// The environment variables holding the values referenced in the script are added once the
+	// replacements are applied to the environment of the Step, so that the values are kept verbatim.
+	step.Env = append(step.Env, scriptEnv...)
+}
+
+// applyShellSafeScriptReplacements replaces the references to variables in the script with
+// references to environment variables holding their values, so that the values are never
+// parsed by the shell. It returns the script and the environment variables to add to the Step.
+func applyShellSafeScriptReplacements(script string, env []corev1.EnvVar, stringReplacements map[string]string) (string, []corev1.EnvVar) {
+	keys := make([]string, 0, len(stringReplacements))
+	for k := range stringReplacements {
+		if strings.Contains(script, fmt.Sprintf("$(%s)", k)) {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return script, nil
+	}
+	sort.Strings(keys)
+
+	usedNames := map[string]bool{}
+	for _, e := range env {
+		usedNames[e.Name] = true
+	}
+	placeholders := make(map[string]string, len(keys))
+	scriptEnv := make([]corev1.EnvVar, 0, len(keys))
+	for _, k := range keys {
+		name := shellSafeEnvName(k, usedNames)
+		usedNames[name] = true
+		placeholders[k] = fmt.Sprintf("${%s}", name)
+		scriptEnv = append(scriptEnv, corev1.EnvVar{
+			Name: name,
+			// Kubernetes expands $(VAR) references in the values of environment variables,
+			// "$$" is the escape sequence for "$".
+			Value: strings.ReplaceAll(stringReplacements[k], "$", "$$"),
+		})
+	}
+	return substitution.ApplyReplacements(script, placeholders), scriptEnv
+}
+
+// shellSafeEnvName returns the name of the environment variable holding the value of the
+// variable with the given key, e.g. TEKTON_PARAMS_FOO for params.foo, which isn't in use.
+func shellSafeEnvName(key string, usedNames map[string]bool) string {
+	base := shellSafeEnvPrefix + strings.Trim(nonEnvNameCharacters.ReplaceAllString(strings.ToUpper(key), "_"), "_")
+	name := base
+	for i := 2; usedNames[name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	return name
 }
 
 // ApplyStepTemplateReplacements applies variable interpolation on a StepTemplate (aka a container)
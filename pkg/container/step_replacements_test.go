@@ -141,3 +141,44 @@ func TestApplyStepReplacements(t *testing.T) {
 		t.Errorf("Container replacements failed: %s", d)
 	}
 }
+
+func TestApplyStepReplacements_ShellSafeInterpolation(t *testing.T) {
+	replacements := map[string]string{
+		"params.message":     `"; echo injected $(id) $HOME`,
+		"params.message-2":   "second",
+		"params.unused":      "unused",
+		"context.task.name":  "build",
+		"params.env-in-step": "from-step",
+	}
+
+	s := v1beta1.Step{
+		Interpolation: v1beta1.ShellSafeInterpolation,
+		Script:        `echo "$(params.message)" $(params.message-2) $(context.task.name) $(params.message)`,
+		Env: []corev1.EnvVar{{
+			Name:  "TEKTON_PARAMS_MESSAGE",
+			Value: "$(params.env-in-step)",
+		}},
+	}
+
+	expected := v1beta1.Step{
+		Interpolation: v1beta1.ShellSafeInterpolation,
+		Script:        `echo "${TEKTON_PARAMS_MESSAGE_2}" ${TEKTON_PARAMS_MESSAGE_2_2} ${TEKTON_CONTEXT_TASK_NAME} ${TEKTON_PARAMS_MESSAGE_2}`,
+		Env: []corev1.EnvVar{{
+			Name:  "TEKTON_PARAMS_MESSAGE",
+			Value: "from-step",
+		}, {
+			Name:  "TEKTON_CONTEXT_TASK_NAME",
+			Value: "build",
+		}, {
+			Name:  "TEKTON_PARAMS_MESSAGE_2",
+			Value: `"; echo injected $$(id) $$HOME`,
+		}, {
+			Name:  "TEKTON_PARAMS_MESSAGE_2_2",
+			Value: "second",
+		}},
+	}
+	container.ApplyStepReplacements(&s, replacements, map[string][]string{})
+	if d := cmp.Diff(expected, s); d != "" {
+		t.Errorf("Container replacements failed: %s", d)
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+// DigestDirectory computes a digest of the contents of the directory tree
+// rooted at root. The digest covers the relative path, type and
+// permissions of every entry, the contents of regular files and the
+// targets of symlinks, so any change to the tree yields a new digest.
+// Symlinks are not followed. The digest is returned as "sha256:<hex>".
+func DigestDirectory(root string) (string, error) {
+	var paths []string
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root {
+			paths = append(paths, path)
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, path := range paths {
+		info, err := os.Lstat(path)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00", filepath.ToSlash(rel), info.Mode().String())
+		switch {
+		case info.Mode().IsRegular():
+			f, err := os.Open(path)
+			if err != nil {
+				return "", err
+			}
+			_, err = io.Copy(h, f)
+			f.Close()
+			if err != nil {
+				return "", err
+			}
+		case info.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(path)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprint(h, target)
+		}
+		fmt.Fprint(h, "\x00")
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WorkspaceDigestPaths maps the names of workspaces to their paths, as passed
+// to the entrypoint's workspace digest flags. It is a flag.Value set once per
+// workspace with name=path: the names of workspaces can't contain "=", so the
+// path is the rest of the value, whatever characters it contains.
+type WorkspaceDigestPaths map[string]string
+
+// String returns the name=path pairs of the workspaces, sorted by name.
+func (p WorkspaceDigestPaths) String() string {
+	pairs := make([]string, 0, len(p))
+	for name, path := range p {
+		pairs = append(pairs, name+"="+path)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
+
+// Set adds the path of a workspace from its name=path pair.
+func (p WorkspaceDigestPaths) Set(value string) error {
+	name, path, ok := strings.Cut(value, "=")
+	if !ok || name == "" || path == "" {
+		return fmt.Errorf("invalid workspace digest path %q, expected name=path", value)
+	}
+	p[name] = path
+	return nil
+}
+
+// digestWorkspaces computes the digest of each of the given workspaces and
+// returns them as internal results whose keys are prefixed by keyPrefix.
+// Workspaces whose path does not exist, such as unbound optional
+// workspaces, are skipped.
+func digestWorkspaces(paths map[string]string, keyPrefix string) ([]result.RunResult, error) {
+	names := make([]string, 0, len(paths))
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var output []result.RunResult
+	for _, name := range names {
+		if _, err := os.Stat(paths[name]); os.IsNotExist(err) {
+			continue
+		}
+		digest, err := DigestDirectory(paths[name])
+		if err != nil {
+			return nil, fmt.Errorf("error computing digest of workspace %q: %w", name, err)
+		}
+		output = append(output, result.RunResult{
+			Key:        keyPrefix + name,
+			Value:      digest,
+			ResultType: result.InternalTektonResultType,
+		})
+	}
+	return output, nil
+}
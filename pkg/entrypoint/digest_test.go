@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDigestDirectory(t *testing.T) {
+	files := map[string]string{
+		"a.txt":       "hello",
+		"dir/b.txt":   "world",
+		"dir/sub/c":   "",
+		"other/d.txt": "!",
+	}
+	first, second := t.TempDir(), t.TempDir()
+	writeTree(t, first, files)
+	writeTree(t, second, files)
+
+	firstDigest, err := DigestDirectory(first)
+	if err != nil {
+		t.Fatalf("DigestDirectory: %v", err)
+	}
+	if !strings.HasPrefix(firstDigest, "sha256:") {
+		t.Errorf("expected sha256 digest, got %q", firstDigest)
+	}
+	secondDigest, err := DigestDirectory(second)
+	if err != nil {
+		t.Fatalf("DigestDirectory: %v", err)
+	}
+	if firstDigest != secondDigest {
+		t.Errorf("expected identical trees to have the same digest, got %q and %q", firstDigest, secondDigest)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		mutate func(root string) error
+	}{{
+		name: "file content changed",
+		mutate: func(root string) error {
+			return os.WriteFile(filepath.Join(root, "a.txt"), []byte("goodbye"), 0o644)
+		},
+	}, {
+		name: "file added",
+		mutate: func(root string) error {
+			return os.WriteFile(filepath.Join(root, "dir", "new.txt"), nil, 0o644)
+		},
+	}, {
+		name: "file renamed",
+		mutate: func(root string) error {
+			return os.Rename(filepath.Join(root, "other", "d.txt"), filepath.Join(root, "other", "e.txt"))
+		},
+	}, {
+		name: "empty directory added",
+		mutate: func(root string) error {
+			return os.Mkdir(filepath.Join(root, "empty"), 0o755)
+		},
+	}, {
+		name: "symlink added",
+		mutate: func(root string) error {
+			return os.Symlink("a.txt", filepath.Join(root, "link"))
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeTree(t, root, files)
+			if err := tc.mutate(root); err != nil {
+				t.Fatal(err)
+			}
+			got, err := DigestDirectory(root)
+			if err != nil {
+				t.Fatalf("DigestDirectory: %v", err)
+			}
+			if got == firstDigest {
+				t.Errorf("expected digest to change")
+			}
+		})
+	}
+}
+
+func TestWorkspaceDigestPaths(t *testing.T) {
+	fs := flag.NewFlagSet("entrypoint", flag.ContinueOnError)
+	got := WorkspaceDigestPaths{}
+	fs.Var(got, "workspace_digest", "")
+	if err := fs.Parse([]string{"-workspace_digest", "source=/workspace/source", "-workspace_digest", "output=/out,v=1"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := WorkspaceDigestPaths{"source": "/workspace/source", "output": "/out,v=1"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+	if s := got.String(); s != "output=/out,v=1 source=/workspace/source" {
+		t.Errorf("String() = %q", s)
+	}
+
+	for _, invalid := range []string{"source", "=/out", "source="} {
+		if err := (WorkspaceDigestPaths{}).Set(invalid); err == nil {
+			t.Errorf("expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestEntrypointerWorkspaceDigests(t *testing.T) {
+	root := t.TempDir()
+	source := filepath.Join(root, "source")
+	writeTree(t, source, map[string]string{"file": "before"})
+	terminationPath := filepath.Join(root, "termination")
+
+	e := Entrypointer{
+		Command:         []string{"echo"},
+		TerminationPath: terminationPath,
+		Waiter:          &fakeWaiter{},
+		Runner: &fakeWriteRunner{write: func() error {
+			return os.WriteFile(filepath.Join(source, "file"), []byte("after"), 0o644)
+		}},
+		PostWriter: &fakePostWriter{},
+		StartWorkspaceDigests: map[string]string{
+			"source":   source,
+			"optional": filepath.Join(root, "missing"),
+		},
+		EndWorkspaceDigests: map[string]string{"source": source},
+	}
+	if err := e.Go(); err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatalf("error reading termination message: %v", err)
+	}
+	var results []result.RunResult
+	if err := json.Unmarshal(msg, &results); err != nil {
+		t.Fatalf("error parsing termination message: %v", err)
+	}
+	digests := map[string]string{}
+	for _, r := range results {
+		if strings.HasPrefix(r.Key, "Workspace") {
+			digests[r.Key] = r.Value
+		}
+	}
+	if len(digests) != 2 {
+		t.Fatalf("expected a start and end digest of the source workspace, got %v", digests)
+	}
+	start, end := digests[result.WorkspaceStartDigestKeyPrefix+"source"], digests[result.WorkspaceEndDigestKeyPrefix+"source"]
+	if start == "" || end == "" {
+		t.Errorf("unexpected workspace digests %v", digests)
+	}
+	if start == end {
+		t.Errorf("expected digest to change after the workspace was modified")
+	}
+}
+
+type fakeWriteRunner struct {
+	write func() error
+}
+
+func (f *fakeWriteRunner) Run(ctx context.Context, args ...string) error {
+	return f.write()
+}
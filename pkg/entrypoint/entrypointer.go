@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
@@ -71,6 +72,11 @@ type Entrypointer struct {
 
 	// Results is the set of files that might contain task results
 	Results []string
+	// FileResults is the subset of the Results of type file, whose files are uploaded
+	// with the FileUploader.
+	FileResults []string
+	// FileUploader encapsulates uploading the files of the FileResults.
+	FileUploader FileUploader
 	// Timeout is an optional user-specified duration within which the Step must complete
 	Timeout *time.Duration
 	// BreakpointOnFailure helps determine if entrypoint execution needs to adapt debugging requirements
@@ -87,6 +93,42 @@ type Entrypointer struct {
 	ResultsDirectory string
 	// ResultExtractionMethod is the method using which the controller extracts the results from the task pod.
 	ResultExtractionMethod string
+	// StartWorkspaceDigests maps the names of workspaces to their paths. A
+	// digest of each workspace's contents is computed before the command runs.
+	StartWorkspaceDigests map[string]string
+	// EndWorkspaceDigests maps the names of workspaces to their paths. A
+	// digest of each workspace's contents is computed after the command runs.
+	EndWorkspaceDigests map[string]string
+	// ExecutionLog records the command executed by the step and the time it
+	// finished in the termination message.
+	ExecutionLog bool
+	// StopFile is the file whose content requests the command to stop. The PreStop
+	// command is then run, the StopSignal is sent to the command, and the command is
+	// killed if it is still running after the StopGracePeriod. A command stopped
+	// this way is successful.
+	StopFile string
+	// PreStop is the command run before the StopSignal is sent to the command.
+	PreStop []string
+	// StopSignal is the signal sent to the command to stop it.
+	StopSignal os.Signal
+	// StopGracePeriod is the time the command has to exit once the stop is requested.
+	StopGracePeriod time.Duration
+	// ProgressFile is the file the command writes percent-complete hints to.
+	ProgressFile string
+	// ProgressReporter reports when the command starts and exits, and the hints
+	// written to the ProgressFile in between. The progress isn't reported if nil.
+	ProgressReporter ProgressReporter
+	// StatusReasonFile and StatusMessageFile are the files the command writes the reason
+	// and the message of the status of the TaskRun to. Their contents are reported in the
+	// termination message once the command exits.
+	StatusReasonFile  string
+	StatusMessageFile string
+	// Debug logs the details of the execution of the step, such as the files it
+	// waits for and the command it runs, for the runs requesting debug logs.
+	Debug bool
+	// SecretValues are the values of the secret params of the TaskRun, which are masked
+	// in the results, the status message and the execution log of the step.
+	SecretValues []string
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -100,6 +142,13 @@ type Runner interface {
 	Run(ctx context.Context, args ...string) error
 }
 
+// Signaler is implemented by the Runners which can send a signal to the
+// command they run.
+type Signaler interface {
+	// Signal sends a signal to the running command.
+	Signal(sig os.Signal)
+}
+
 // PostWriter encapsulates writing a file when complete.
 type PostWriter interface {
 	// Write writes to the path when complete.
@@ -109,8 +158,7 @@ type PostWriter interface {
 // Go optionally waits for a file, runs the command, and writes a
 // post file.
 func (e Entrypointer) Go() error {
-	prod, _ := zap.NewProduction()
-	logger := prod.Sugar()
+	logger := e.newLogger()
 
 	output := []result.RunResult{}
 	defer func() {
@@ -121,6 +169,7 @@ func (e Entrypointer) Go() error {
 	}()
 
 	for _, f := range e.WaitFiles {
+		logger.Debugf("Waiting for %q", f)
 		if err := e.Waiter.Wait(f, e.WaitFileContent, e.BreakpointOnFailure); err != nil {
 			// An error happened while waiting, so we bail
 			// *but* we write postfile to make next steps bail too.
@@ -143,6 +192,14 @@ func (e Entrypointer) Go() error {
 		ResultType: result.InternalTektonResultType,
 	})
 
+	if len(e.StartWorkspaceDigests) > 0 {
+		digests, err := digestWorkspaces(e.StartWorkspaceDigests, result.WorkspaceStartDigestKeyPrefix)
+		if err != nil {
+			logger.Errorf("Error computing workspace digests: %s", err)
+		}
+		output = append(output, digests...)
+	}
+
 	ctx := context.Background()
 	var err error
 
@@ -156,7 +213,37 @@ func (e Entrypointer) Go() error {
 			ctx, cancel = context.WithTimeout(ctx, *e.Timeout)
 			defer cancel()
 		}
+		stopRequested := func() bool { return false }
+		if e.StopFile != "" {
+			var kill context.CancelFunc
+			ctx, kill = context.WithCancel(ctx)
+			defer kill()
+			stopRequested = e.stopWhenRequested(ctx, kill)
+		}
+		argv := make([]string, 0, len(e.Command))
+		for _, arg := range e.Command {
+			argv = append(argv, e.mask(arg))
+		}
+		if e.ExecutionLog {
+			output = append(output, argvResults(argv)...)
+		}
+		finishProgress := e.reportProgress()
+		logger.Debugw("Running the command", "command", argv, "timeout", e.Timeout, "onError", e.OnError)
+		started := time.Now()
 		err = e.Runner.Run(ctx, e.Command...)
+		finishProgress()
+		logger.Debugw("The command exited", "duration", time.Since(started).String(), "error", err)
+		if err != nil && stopRequested() {
+			logger.Infof("Command stopped on request: %v", err)
+			err = nil
+		}
+		if e.ExecutionLog {
+			output = append(output, result.RunResult{
+				Key:        result.ExecutionFinishedAtKey,
+				Value:      time.Now().Format(timeFormat),
+				ResultType: result.InternalTektonResultType,
+			})
+		}
 		if errors.Is(err, context.DeadlineExceeded) {
 			output = append(output, result.RunResult{
 				Key:        "Reason",
@@ -164,6 +251,7 @@ func (e Entrypointer) Go() error {
 				ResultType: result.InternalTektonResultType,
 			})
 		}
+		output = append(output, e.statusResults()...)
 	}
 
 	var ee *exec.ExitError
@@ -189,14 +277,19 @@ func (e Entrypointer) Go() error {
 		e.WritePostFile(e.PostFile, err)
 	}
 
+	if len(e.EndWorkspaceDigests) > 0 {
+		digests, dErr := digestWorkspaces(e.EndWorkspaceDigests, result.WorkspaceEndDigestKeyPrefix)
+		if dErr != nil {
+			logger.Errorf("Error computing workspace digests: %s", dErr)
+		}
+		output = append(output, digests...)
+	}
+
 	// strings.Split(..) with an empty string returns an array that contains one element, an empty string.
 	// This creates an error when trying to open the result folder as a file.
 	if len(e.Results) >= 1 && e.Results[0] != "" {
-		resultPath := pipeline.DefaultResultPath
-		if e.ResultsDirectory != "" {
-			resultPath = e.ResultsDirectory
-		}
-		if err := e.readResultsFromDisk(ctx, resultPath); err != nil {
+		logger.Debugf("Reading the results %v from %q", e.Results, e.resultsDirectory())
+		if err := e.readResultsFromDisk(ctx, e.resultsDirectory()); err != nil {
 			logger.Fatalf("Error while handling results: %s", err)
 		}
 	}
@@ -204,22 +297,79 @@ func (e Entrypointer) Go() error {
 	return err
 }
 
+// newLogger returns the logger of the entrypoint, which writes debug entries when
+// Debug is set.
+func (e Entrypointer) newLogger() *zap.SugaredLogger {
+	cfg := zap.NewProductionConfig()
+	if e.Debug {
+		cfg.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
+	}
+	logger, err := cfg.Build()
+	if err != nil {
+		return zap.NewNop().Sugar()
+	}
+	return logger.Sugar()
+}
+
+// stopWhenRequested stops the command once the StopFile has content, calling kill
+// if it hasn't exited after the StopGracePeriod. It returns a function reporting
+// whether the stop has been requested.
+func (e Entrypointer) stopWhenRequested(ctx context.Context, kill context.CancelFunc) func() bool {
+	var requested int32
+	go func() {
+		if err := e.Waiter.Wait(e.StopFile, true, false); err != nil {
+			return
+		}
+		atomic.StoreInt32(&requested, 1)
+		ctx, cancel := context.WithTimeout(ctx, e.StopGracePeriod)
+		defer cancel()
+		if len(e.PreStop) > 0 {
+			cmd := exec.CommandContext(ctx, e.PreStop[0], e.PreStop[1:]...)
+			cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+			if err := cmd.Run(); err != nil {
+				log.Printf("Error running the pre-stop command: %v", err)
+			}
+		}
+		if s, ok := e.Runner.(Signaler); ok && e.StopSignal != nil {
+			s.Signal(e.StopSignal)
+			<-ctx.Done()
+		}
+		kill()
+	}()
+	return func() bool { return atomic.LoadInt32(&requested) == 1 }
+}
+
+// resultsDirectory returns the directory to read the results from.
+func (e Entrypointer) resultsDirectory() string {
+	if e.ResultsDirectory != "" {
+		return e.ResultsDirectory
+	}
+	return pipeline.DefaultResultPath
+}
+
 func (e Entrypointer) readResultsFromDisk(ctx context.Context, resultDir string) error {
 	output := []result.RunResult{}
 	for _, resultFile := range e.Results {
 		if resultFile == "" {
 			continue
 		}
-		fileContents, err := os.ReadFile(filepath.Join(resultDir, resultFile))
+		path := filepath.Join(resultDir, resultFile)
+		fileContents, err := os.ReadFile(path)
 		if os.IsNotExist(err) {
 			continue
 		} else if err != nil {
 			return err
 		}
+		value := string(fileContents)
+		if isFileResult(e.FileResults, resultFile) {
+			if value, err = e.uploadFileResult(ctx, resultFile, path); err != nil {
+				return err
+			}
+		}
 		// if the file doesn't exist, ignore it
 		output = append(output, result.RunResult{
 			Key:        resultFile,
-			Value:      string(fileContents),
+			Value:      e.mask(value),
 			ResultType: result.TaskRunResultType,
 		})
 	}
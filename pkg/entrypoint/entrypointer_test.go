@@ -26,6 +26,8 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"strconv"
+	"syscall"
 	"testing"
 	"time"
 
@@ -602,6 +604,116 @@ func TestEntrypointerResults(t *testing.T) {
 	}
 }
 
+func TestEntrypointer_Stop(t *testing.T) {
+	for _, c := range []struct {
+		desc          string
+		stopSignal    os.Signal
+		ignoreSignals bool
+		wantSignal    os.Signal
+	}{{
+		desc:       "stopped by the signal",
+		stopSignal: syscall.SIGINT,
+		wantSignal: syscall.SIGINT,
+	}, {
+		desc:          "killed after the grace period",
+		stopSignal:    syscall.SIGTERM,
+		ignoreSignals: true,
+		wantSignal:    syscall.SIGTERM,
+	}, {
+		desc: "killed without a signal",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			preStopFile := filepath.Join(createTmpDir(t, "pre-stop"), "flushed")
+			defer os.RemoveAll(filepath.Dir(preStopFile))
+			terminationFile, err := os.CreateTemp("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			fr := &fakeStoppableRunner{signals: make(chan os.Signal, 1), ignoreSignals: c.ignoreSignals}
+			if err := (Entrypointer{
+				Command:                []string{"db"},
+				Waiter:                 &fakeWaiter{},
+				Runner:                 fr,
+				PostWriter:             &fakePostWriter{},
+				ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+				TerminationPath:        terminationFile.Name(),
+				StopFile:               "stop",
+				PreStop:                []string{"touch", preStopFile},
+				StopSignal:             c.stopSignal,
+				StopGracePeriod:        100 * time.Millisecond,
+			}).Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if d := cmp.Diff(c.wantSignal, fr.received); d != "" {
+				t.Errorf("signal %s", diff.PrintWantGot(d))
+			}
+			if _, err := os.Stat(preStopFile); err != nil {
+				t.Errorf("expected the pre-stop command to run: %v", err)
+			}
+		})
+	}
+}
+
+func TestEntrypointer_Progress(t *testing.T) {
+	for _, c := range []struct {
+		desc        string
+		initial     string
+		written     string
+		wantReports []string
+	}{{
+		desc:        "without progress file",
+		wantReports: []string{"started", "finished"},
+	}, {
+		desc:        "progress written by the command",
+		initial:     "40",
+		written:     "75%\n",
+		wantReports: []string{"started", "40", "75", "finished"},
+	}, {
+		desc:        "unchanged progress",
+		initial:     "40",
+		written:     "40",
+		wantReports: []string{"started", "40", "finished"},
+	}, {
+		desc:        "invalid progress",
+		written:     "200",
+		wantReports: []string{"started", "finished"},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			progressFile := filepath.Join(createTmpDir(t, "progress"), "progress")
+			defer os.RemoveAll(filepath.Dir(progressFile))
+			if c.initial != "" {
+				if err := os.WriteFile(progressFile, []byte(c.initial), 0o666); err != nil {
+					t.Fatalf("writing the progress file: %v", err)
+				}
+			}
+			terminationFile, err := os.CreateTemp("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			reporter := &fakeProgressReporter{}
+			if err := (Entrypointer{
+				Command:                []string{"build"},
+				Waiter:                 &fakeWaiter{},
+				Runner:                 &fakeProgressRunner{file: progressFile, content: c.written},
+				PostWriter:             &fakePostWriter{},
+				ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+				TerminationPath:        terminationFile.Name(),
+				ProgressFile:           progressFile,
+				ProgressReporter:       reporter,
+			}).Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if d := cmp.Diff(c.wantReports, reporter.reports); d != "" {
+				t.Errorf("progress reports %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
@@ -631,6 +743,31 @@ func (f *fakePostWriter) Write(file, content string) {
 	}
 }
 
+// fakeStoppableRunner runs until it receives a signal or its context is done.
+type fakeStoppableRunner struct {
+	signals       chan os.Signal
+	ignoreSignals bool
+	received      os.Signal
+}
+
+func (f *fakeStoppableRunner) Run(ctx context.Context, args ...string) error {
+	select {
+	case sig := <-f.signals:
+		f.received = sig
+		if f.ignoreSignals {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return errors.New("signal: interrupt")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakeStoppableRunner) Signal(sig os.Signal) {
+	f.signals <- sig
+}
+
 type fakeErrorWaiter struct{ waited *string }
 
 func (f *fakeErrorWaiter) Wait(file string, expectContent bool, breakpointOnFailure bool) error {
@@ -724,3 +861,26 @@ func getMockSpireClient(ctx context.Context) (spire.EntrypointerAPIClient, spire
 
 	return sc, sc, tr
 }
+
+// fakeProgressRunner writes the content to the progress file, if any.
+type fakeProgressRunner struct {
+	file    string
+	content string
+}
+
+func (f *fakeProgressRunner) Run(ctx context.Context, args ...string) error {
+	if f.content == "" {
+		return nil
+	}
+	return os.WriteFile(f.file, []byte(f.content), 0o666)
+}
+
+type fakeProgressReporter struct{ reports []string }
+
+func (f *fakeProgressReporter) Started() { f.reports = append(f.reports, "started") }
+
+func (f *fakeProgressReporter) Progress(percent int32) {
+	f.reports = append(f.reports, strconv.Itoa(int(percent)))
+}
+
+func (f *fakeProgressReporter) Finished() { f.reports = append(f.reports, "finished") }
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+// maxExecutionArgvSize is the maximum size in bytes of the JSON encoded command and
+// arguments recorded in the termination message, which is shared with the results of
+// the step and limited to 4096 bytes.
+const maxExecutionArgvSize = 512
+
+// argvResults returns the internal results recording the command and arguments
+// executed by the step. The trailing arguments which don't fit in maxExecutionArgvSize
+// are dropped, in which case a result reporting the truncation is added.
+func argvResults(argv []string) []result.RunResult {
+	kept := argv
+	encoded, _ := json.Marshal(kept)
+	for len(encoded) > maxExecutionArgvSize {
+		kept = kept[:len(kept)-1]
+		encoded, _ = json.Marshal(kept)
+	}
+	output := []result.RunResult{{
+		Key:        result.ExecutionArgvKey,
+		Value:      string(encoded),
+		ResultType: result.InternalTektonResultType,
+	}}
+	if len(kept) < len(argv) {
+		output = append(output, result.RunResult{
+			Key:        result.ExecutionArgvTruncatedKey,
+			Value:      "true",
+			ResultType: result.InternalTektonResultType,
+		})
+	}
+	return output
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestArgvResults(t *testing.T) {
+	longArg := strings.Repeat("a", maxExecutionArgvSize)
+	for _, c := range []struct {
+		desc string
+		argv []string
+		want []result.RunResult
+	}{{
+		desc: "command and args",
+		argv: []string{"go", "test", "./..."},
+		want: []result.RunResult{{
+			Key:        result.ExecutionArgvKey,
+			Value:      `["go","test","./..."]`,
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc: "trailing args exceeding the size limit",
+		argv: []string{"echo", "short", longArg, "dropped"},
+		want: []result.RunResult{{
+			Key:        result.ExecutionArgvKey,
+			Value:      `["echo","short"]`,
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        result.ExecutionArgvTruncatedKey,
+			Value:      "true",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc: "command exceeding the size limit",
+		argv: []string{longArg},
+		want: []result.RunResult{{
+			Key:        result.ExecutionArgvKey,
+			Value:      `[]`,
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        result.ExecutionArgvTruncatedKey,
+			Value:      "true",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if d := cmp.Diff(c.want, argvResults(c.argv)); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEntrypointerExecutionLog(t *testing.T) {
+	terminationFile, err := os.CreateTemp("", "termination")
+	if err != nil {
+		t.Fatalf("unexpected error creating temporary termination file: %v", err)
+	}
+	defer os.Remove(terminationFile.Name())
+
+	timeout := time.Duration(0)
+	err = Entrypointer{
+		Command:         []string{"echo", "hello"},
+		Waiter:          &fakeWaiter{},
+		Runner:          &fakeRunner{},
+		PostWriter:      &fakePostWriter{},
+		TerminationPath: terminationFile.Name(),
+		Timeout:         &timeout,
+		ExecutionLog:    true,
+	}.Go()
+	if err != nil {
+		t.Fatalf("Entrypointer failed: %v", err)
+	}
+
+	fileContents, err := os.ReadFile(terminationFile.Name())
+	if err != nil {
+		t.Fatalf("unexpected error reading termination file: %v", err)
+	}
+	var entries []result.RunResult
+	if err := json.Unmarshal(fileContents, &entries); err != nil {
+		t.Fatalf("unexpected error unmarshalling termination message: %v", err)
+	}
+	got := map[string]string{}
+	for _, entry := range entries {
+		got[entry.Key] = entry.Value
+	}
+	if d := cmp.Diff(`["echo","hello"]`, got[result.ExecutionArgvKey]); d != "" {
+		t.Errorf("unexpected argv %s", diff.PrintWantGot(d))
+	}
+	startedAt, err := time.Parse(timeFormat, got["StartedAt"])
+	if err != nil {
+		t.Fatalf("unexpected error parsing start time: %v", err)
+	}
+	finishedAt, err := time.Parse(timeFormat, got[result.ExecutionFinishedAtKey])
+	if err != nil {
+		t.Fatalf("unexpected error parsing finish time: %v", err)
+	}
+	if finishedAt.Before(startedAt) {
+		t.Errorf("expected finish time %v not to be before start time %v", finishedAt, startedAt)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+// FileUploader encapsulates uploading the files of the results of type file.
+type FileUploader interface {
+	// Upload uploads the file with the given "sha256:<hex>" digest and returns
+	// the URI it can be downloaded from.
+	Upload(ctx context.Context, file, digest string) (string, error)
+}
+
+// DigestFile computes the digest of the contents of a file, returned as "sha256:<hex>".
+func DigestFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// uploadFileResult uploads the file of a result of type file and returns the
+// value of the result: a JSON object holding the uri and the digest of the file.
+func (e Entrypointer) uploadFileResult(ctx context.Context, name, path string) (string, error) {
+	if e.FileUploader == nil {
+		return "", errors.New("no store is configured for the results of type file")
+	}
+	digest, err := DigestFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error computing the digest of result %q: %w", name, err)
+	}
+	uri, err := e.FileUploader.Upload(ctx, path, digest)
+	if err != nil {
+		return "", fmt.Errorf("error uploading result %q: %w", name, err)
+	}
+	value, err := json.Marshal(map[string]string{
+		v1.FileResultURIKey:    uri,
+		v1.FileResultDigestKey: digest,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// isFileResult returns true if name is one of the fileResults.
+func isFileResult(fileResults []string, name string) bool {
+	for _, r := range fileResults {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
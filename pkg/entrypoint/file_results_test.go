@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/termination"
+	"github.com/tektoncd/pipeline/test/diff"
+	"knative.dev/pkg/logging"
+)
+
+// fakeUploader records the uploaded files by digest.
+type fakeUploader struct {
+	uploaded map[string]string
+	err      error
+}
+
+func (u *fakeUploader) Upload(_ context.Context, file, digest string) (string, error) {
+	if u.err != nil {
+		return "", u.err
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	u.uploaded[digest] = string(b)
+	return "https://store.example.com/" + digest, nil
+}
+
+func TestDigestFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := DigestFile(file)
+	if err != nil {
+		t.Fatalf("DigestFile: %v", err)
+	}
+	want := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("digest %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReadResultsFromDisk_FileResults(t *testing.T) {
+	resultsDir := t.TempDir()
+	writeTree(t, resultsDir, map[string]string{
+		"report": "hello",
+		"status": "passed",
+	})
+	terminationPath := filepath.Join(t.TempDir(), "termination")
+	uploader := &fakeUploader{uploaded: map[string]string{}}
+	e := Entrypointer{
+		Results:                []string{"report", "status", "missing"},
+		FileResults:            []string{"report", "missing"},
+		FileUploader:           uploader,
+		TerminationPath:        terminationPath,
+		ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+	}
+	if err := e.readResultsFromDisk(context.Background(), resultsDir); err != nil {
+		t.Fatalf("readResultsFromDisk: %v", err)
+	}
+
+	digest := "sha256:2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if d := cmp.Diff(map[string]string{digest: "hello"}, uploader.uploaded); d != "" {
+		t.Errorf("uploaded files %s", diff.PrintWantGot(d))
+	}
+	msg, err := os.ReadFile(terminationPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger, _ := logging.NewLogger("", "status")
+	got, err := termination.ParseMessage(logger, string(msg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []result.RunResult{{
+		Key:        "report",
+		Value:      `{"digest":"` + digest + `","uri":"https://store.example.com/` + digest + `"}`,
+		ResultType: result.TaskRunResultType,
+	}, {
+		Key:        "status",
+		Value:      "passed",
+		ResultType: result.TaskRunResultType,
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("results %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReadResultsFromDisk_FileResultsError(t *testing.T) {
+	resultsDir := t.TempDir()
+	writeTree(t, resultsDir, map[string]string{"report": "hello"})
+	for _, tc := range []struct {
+		desc     string
+		uploader FileUploader
+	}{{
+		desc: "no store",
+	}, {
+		desc:     "upload error",
+		uploader: &fakeUploader{err: errors.New("forbidden")},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			e := Entrypointer{
+				Results:                []string{"report"},
+				FileResults:            []string{"report"},
+				FileUploader:           tc.uploader,
+				TerminationPath:        filepath.Join(t.TempDir(), "termination"),
+				ResultExtractionMethod: config.ResultExtractionMethodTerminationMessage,
+			}
+			if err := e.readResultsFromDisk(context.Background(), resultsDir); err == nil {
+				t.Error("expected an error reading the results")
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// progressPollingInterval is how often the ProgressFile is read while the command runs.
+var progressPollingInterval = time.Second
+
+// ProgressReporter encapsulates reporting the progress of the step.
+type ProgressReporter interface {
+	// Started reports that the command has started.
+	Started()
+	// Progress reports a new percent-complete hint read from the ProgressFile.
+	Progress(percent int32)
+	// Finished reports that the command has exited.
+	Finished()
+}
+
+// reportProgress reports that the command has started and then the percent-complete
+// hints written to the ProgressFile, until the returned function is called once the
+// command has exited.
+func (e Entrypointer) reportProgress() func() {
+	if e.ProgressReporter == nil {
+		return func() {}
+	}
+	e.ProgressReporter.Started()
+	last := int32(-1)
+	report := func() {
+		if percent, ok := readProgressFile(e.ProgressFile); ok && percent != last {
+			last = percent
+			e.ProgressReporter.Progress(percent)
+		}
+	}
+	report()
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(progressPollingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				report()
+			}
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+		report()
+		e.ProgressReporter.Finished()
+	}
+}
+
+// readProgressFile returns the percent-complete hint in the file, an integer between
+// 0 and 100. It returns false if the file doesn't exist or has another content.
+func readProgressFile(file string) (int32, bool) {
+	if file == "" {
+		return 0, false
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return 0, false
+	}
+	percent, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimSpace(string(b)), "%"), 10, 32)
+	if err != nil || percent < 0 || percent > 100 {
+		return 0, false
+	}
+	return int32(percent), true
+}
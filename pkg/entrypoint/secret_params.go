@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SecretMask replaces the values of the secret params in the outputs of the step.
+const SecretMask = "***"
+
+// ReadSecretValues returns the values of the secret params mounted in the directory, each of
+// them in the file named value of the subdirectory of its param. It returns no value if the
+// directory doesn't exist.
+func ReadSecretValues(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*", "value"))
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		// The value is also masked without the trailing newline the Secrets often hold.
+		if value := string(b); value != "" {
+			values = append(values, value)
+		}
+		if value := strings.TrimSpace(string(b)); value != "" && value != string(b) {
+			values = append(values, value)
+		}
+	}
+	// The longest values are masked first, so that a value holding another one is masked
+	// entirely.
+	sort.SliceStable(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values, nil
+}
+
+// Mask replaces the secret values held by s with the SecretMask.
+func Mask(s string, secretValues []string) string {
+	for _, value := range secretValues {
+		s = strings.ReplaceAll(s, value, SecretMask)
+	}
+	return s
+}
+
+// mask replaces the values of the secret params of the step held by s with the SecretMask.
+func (e Entrypointer) mask(s string) string {
+	return Mask(s, e.SecretValues)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestReadSecretValues(t *testing.T) {
+	dir := t.TempDir()
+	for name, value := range map[string]string{"token": "ghp_123", "password": "hunter2\n"} {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatalf("unexpected error creating the directory of the param: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, "value"), []byte(value), 0o600); err != nil {
+			t.Fatalf("unexpected error writing the value of the param: %v", err)
+		}
+	}
+	got, err := ReadSecretValues(dir)
+	if err != nil {
+		t.Fatalf("ReadSecretValues() returned an unexpected error: %v", err)
+	}
+	if d := cmp.Diff([]string{"hunter2\n", "hunter2", "ghp_123"}, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	got, err = ReadSecretValues(filepath.Join(dir, "missing"))
+	if err != nil {
+		t.Fatalf("ReadSecretValues() returned an unexpected error for a missing directory: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("ReadSecretValues() returned values for a missing directory: %v", got)
+	}
+}
+
+func TestMask(t *testing.T) {
+	for _, c := range []struct {
+		desc string
+		s    string
+		want string
+	}{{
+		desc: "values",
+		s:    "logging in with ghp_123456 as admin:hunter2",
+		want: "logging in with *** as admin:***",
+	}, {
+		desc: "value holding another value",
+		s:    "token ghp_123456",
+		want: "token ***",
+	}, {
+		desc: "no value",
+		s:    "logged in",
+		want: "logged in",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			if d := cmp.Diff(c.want, Mask(c.s, []string{"ghp_123456", "hunter2", "123"})); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStatusResults_MaskedMessage(t *testing.T) {
+	dir := t.TempDir()
+	e := Entrypointer{
+		StatusMessageFile: filepath.Join(dir, "message"),
+		SecretValues:      []string{"hunter2"},
+	}
+	if err := os.WriteFile(e.StatusMessageFile, []byte("the password hunter2 expired"), 0o666); err != nil {
+		t.Fatalf("unexpected error writing the message file: %v", err)
+	}
+	want := []result.RunResult{{
+		Key:        result.StatusMessageKey,
+		Value:      "the password *** expired",
+		ResultType: result.InternalTektonResultType,
+	}}
+	if d := cmp.Diff(want, e.statusResults()); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
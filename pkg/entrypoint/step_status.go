@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/result"
+)
+
+// maxStatusMessageSize is the maximum size in bytes of the status message recorded in
+// the termination message, which is shared with the results of the step and limited
+// to 4096 bytes.
+const maxStatusMessageSize = 1024
+
+// statusResults returns the internal results holding the reason and the message the
+// command wrote to the StatusReasonFile and the StatusMessageFile, if any. The message
+// is masked and truncated to maxStatusMessageSize.
+func (e Entrypointer) statusResults() []result.RunResult {
+	var output []result.RunResult
+	if reason := readStatusFile(e.StatusReasonFile); reason != "" {
+		output = append(output, result.RunResult{
+			Key:        result.StatusReasonKey,
+			Value:      reason,
+			ResultType: result.InternalTektonResultType,
+		})
+	}
+	if message := e.mask(readStatusFile(e.StatusMessageFile)); message != "" {
+		if len(message) > maxStatusMessageSize {
+			message = strings.ToValidUTF8(message[:maxStatusMessageSize], "")
+		}
+		output = append(output, result.RunResult{
+			Key:        result.StatusMessageKey,
+			Value:      message,
+			ResultType: result.InternalTektonResultType,
+		})
+	}
+	return output
+}
+
+// readStatusFile returns the trimmed content of the file, or an empty string if it
+// doesn't exist.
+func readStatusFile(file string) string {
+	if file == "" {
+		return ""
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
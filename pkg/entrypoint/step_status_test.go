@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestStatusResults(t *testing.T) {
+	longMessage := strings.Repeat("a", maxStatusMessageSize+1)
+	for _, c := range []struct {
+		desc    string
+		reason  string
+		message string
+		want    []result.RunResult
+	}{{
+		desc:    "reason and message",
+		reason:  "MissingLicense\n",
+		message: "  The LICENSE file is missing, add it at the root of the repository.\n",
+		want: []result.RunResult{{
+			Key:        result.StatusReasonKey,
+			Value:      "MissingLicense",
+			ResultType: result.InternalTektonResultType,
+		}, {
+			Key:        result.StatusMessageKey,
+			Value:      "The LICENSE file is missing, add it at the root of the repository.",
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc:    "message exceeding the size limit",
+		message: longMessage,
+		want: []result.RunResult{{
+			Key:        result.StatusMessageKey,
+			Value:      longMessage[:maxStatusMessageSize],
+			ResultType: result.InternalTektonResultType,
+		}},
+	}, {
+		desc: "no status files",
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			e := Entrypointer{
+				StatusReasonFile:  filepath.Join(dir, "reason"),
+				StatusMessageFile: filepath.Join(dir, "message"),
+			}
+			if c.reason != "" {
+				if err := os.WriteFile(e.StatusReasonFile, []byte(c.reason), 0o666); err != nil {
+					t.Fatalf("unexpected error writing the reason file: %v", err)
+				}
+			}
+			if c.message != "" {
+				if err := os.WriteFile(e.StatusMessageFile, []byte(c.message), 0o666); err != nil {
+					t.Fatalf("unexpected error writing the message file: %v", err)
+				}
+			}
+			if d := cmp.Diff(c.want, e.statusResults()); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package executionwindow enforces the ExecutionWindowPolicies, which restrict
+// when the PipelineRuns of a Pipeline start.
+package executionwindow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// lookahead is how far the next opening of the windows is looked for, a week
+// covering all the windows recurring on days of the week.
+const lookahead = 8 * 24 * time.Hour
+
+// Hold is why a PipelineRun may not start yet.
+type Hold struct {
+	// Policy is the name of the ExecutionWindowPolicy holding the PipelineRun.
+	Policy string
+	// Reason describes why the policy holds the PipelineRun.
+	Reason string
+	// Until is when the windows of all the policies next allow the PipelineRun
+	// to start, or zero if they never do, e.g. because they are invalid.
+	Until time.Time
+}
+
+// Message describes the hold in the condition of a held PipelineRun.
+func (h *Hold) Message() string {
+	msg := fmt.Sprintf("ExecutionWindowPolicy %q holds the PipelineRun %s", h.Policy, h.Reason)
+	if h.Until.IsZero() {
+		return msg
+	}
+	return fmt.Sprintf("%s until %s", msg, h.Until.UTC().Format(time.RFC3339))
+}
+
+// PipelineName returns the name of the Pipeline of the PipelineRun the policies
+// are applied to: the name of its pipelineRef, or else the name of the PipelineRun.
+func PipelineName(pr *v1beta1.PipelineRun) string {
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
+		return pr.Spec.PipelineRef.Name
+	}
+	return pr.Name
+}
+
+// Check returns the hold of a PipelineRun of the Pipeline at the given time by
+// the first of the policies, ordered by name, applying to the Pipeline and not
+// allowing it to start, or nil if it may start.
+func Check(policies []*v1alpha1.ExecutionWindowPolicy, pipeline string, now time.Time) *Hold {
+	sorted := make([]*v1alpha1.ExecutionWindowPolicy, 0, len(policies))
+	for _, p := range policies {
+		if p.AppliesTo(pipeline) {
+			sorted = append(sorted, p)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	h := check(sorted, now)
+	if h == nil {
+		return nil
+	}
+	var bounds []time.Time
+	for _, p := range sorted {
+		bounds = append(bounds, boundaries(p, now)...)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i].Before(bounds[j]) })
+	for _, t := range bounds {
+		if check(sorted, t) == nil {
+			h.Until = t
+			break
+		}
+	}
+	return h
+}
+
+func check(policies []*v1alpha1.ExecutionWindowPolicy, t time.Time) *Hold {
+	for _, p := range policies {
+		if reason := holds(p, t); reason != "" {
+			return &Hold{Policy: p.Name, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// holds returns why the policy doesn't allow a PipelineRun to start at the given
+// time, or an empty string if it does. An invalid policy holds all the PipelineRuns
+// it applies to, so that it restricts rather than allows.
+func holds(p *v1alpha1.ExecutionWindowPolicy, t time.Time) string {
+	loc, err := p.Spec.Location()
+	if err != nil {
+		return fmt.Sprintf("because its time zone is invalid: %v", err)
+	}
+	for _, w := range p.Spec.Blocked {
+		in, err := contains(w, loc, t)
+		if err != nil {
+			return fmt.Sprintf("because its blocked window %s is invalid: %v", describe(w), err)
+		}
+		if in {
+			return fmt.Sprintf("within its blocked window %s", describe(w))
+		}
+	}
+	if len(p.Spec.Allowed) == 0 {
+		return ""
+	}
+	for _, w := range p.Spec.Allowed {
+		in, err := contains(w, loc, t)
+		if err != nil {
+			return fmt.Sprintf("because its allowed window %s is invalid: %v", describe(w), err)
+		}
+		if in {
+			return ""
+		}
+	}
+	return "outside of its allowed windows"
+}
+
+func describe(w v1alpha1.ExecutionWindow) string {
+	if w.Name != "" {
+		return fmt.Sprintf("%q", w.Name)
+	}
+	days, start, end := "every day", "00:00", "00:00"
+	if len(w.Days) > 0 {
+		days = strings.Join(w.Days, ", ")
+	}
+	if w.Start != "" {
+		start = w.Start
+	}
+	if w.End != "" {
+		end = w.End
+	}
+	return fmt.Sprintf("%s %s-%s", days, start, end)
+}
+
+// contains returns true if the window, in the time zone, contains the given time.
+func contains(w v1alpha1.ExecutionWindow, loc *time.Location, t time.Time) (bool, error) {
+	days, err := w.Weekdays()
+	if err != nil {
+		return false, err
+	}
+	start, end, err := w.Bounds()
+	if err != nil {
+		return false, err
+	}
+	t = t.In(loc)
+	// The windows last at most a day, so the one containing the time starts on
+	// the day of the time or on the day before.
+	for _, offset := range []int{-1, 0} {
+		day := time.Date(t.Year(), t.Month(), t.Day()+offset, 0, 0, 0, 0, loc)
+		if !startsOn(days, day.Weekday()) {
+			continue
+		}
+		opens, closes := at(day, start), at(day, end)
+		if !t.Before(opens) && t.Before(closes) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func startsOn(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// at returns the time at the offset from the beginning of the day, in wall clock
+// time so that the windows follow the daylight saving time changes.
+func at(day time.Time, offset time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, int(offset/time.Minute), 0, 0, day.Location())
+}
+
+// boundaries returns the times after now, within the lookahead, at which the
+// windows of the policy open or close, which are the only times at which the
+// policy may start allowing the PipelineRuns.
+func boundaries(p *v1alpha1.ExecutionWindowPolicy, now time.Time) []time.Time {
+	loc, err := p.Spec.Location()
+	if err != nil {
+		return nil
+	}
+	now = now.In(loc)
+	var bounds []time.Time
+	for _, w := range append(append([]v1alpha1.ExecutionWindow{}, p.Spec.Allowed...), p.Spec.Blocked...) {
+		days, err := w.Weekdays()
+		if err != nil {
+			continue
+		}
+		start, end, err := w.Bounds()
+		if err != nil {
+			continue
+		}
+		for offset := -1; time.Duration(offset)*24*time.Hour <= lookahead; offset++ {
+			day := time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, loc)
+			if !startsOn(days, day.Weekday()) {
+				continue
+			}
+			for _, t := range []time.Time{at(day, start), at(day, end)} {
+				if t.After(now) {
+					bounds = append(bounds, t)
+				}
+			}
+		}
+	}
+	return bounds
+}
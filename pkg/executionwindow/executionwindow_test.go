@@ -0,0 +1,188 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package executionwindow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/executionwindow"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func policy(name string, spec v1alpha1.ExecutionWindowPolicySpec) *v1alpha1.ExecutionWindowPolicy {
+	return &v1alpha1.ExecutionWindowPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       spec,
+	}
+}
+
+func TestCheck(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Fatal(err)
+	}
+	weekend := policy("weekend", v1alpha1.ExecutionWindowPolicySpec{
+		Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^deploy-"}},
+		Blocked:   []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+	})
+	officeHours := policy("office-hours", v1alpha1.ExecutionWindowPolicySpec{
+		TimeZone: "Europe/Paris",
+		Allowed: []v1alpha1.ExecutionWindow{{
+			Name:  "office hours",
+			Days:  []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday"},
+			Start: "09:00",
+			End:   "17:00",
+		}},
+	})
+	noFridayAfternoon := policy("no-friday-afternoon", v1alpha1.ExecutionWindowPolicySpec{
+		TimeZone: "Europe/Paris",
+		Blocked:  []v1alpha1.ExecutionWindow{{Days: []string{"Friday"}, Start: "12:00"}},
+	})
+	nights := policy("nights", v1alpha1.ExecutionWindowPolicySpec{
+		Allowed: []v1alpha1.ExecutionWindow{{Name: "night", Start: "22:00", End: "06:00"}},
+	})
+	invalid := policy("invalid", v1alpha1.ExecutionWindowPolicySpec{
+		TimeZone: "Mars/Olympus",
+		Blocked:  []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+	})
+
+	for _, tc := range []struct {
+		desc     string
+		policies []*v1alpha1.ExecutionWindowPolicy
+		pipeline string
+		now      time.Time
+		want     *executionwindow.Hold
+	}{{
+		desc:     "no policies",
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 10, 10, 0, 0, 0, time.UTC),
+	}, {
+		desc:     "policy not applying to the pipeline",
+		policies: []*v1alpha1.ExecutionWindowPolicy{weekend},
+		pipeline: "build",
+		now:      time.Date(2023, 6, 10, 10, 0, 0, 0, time.UTC),
+	}, {
+		desc:     "outside of the blocked window",
+		policies: []*v1alpha1.ExecutionWindowPolicy{weekend},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 9, 23, 59, 0, 0, time.UTC),
+	}, {
+		desc:     "within the blocked window",
+		policies: []*v1alpha1.ExecutionWindowPolicy{weekend},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 10, 10, 0, 0, 0, time.UTC),
+		want: &executionwindow.Hold{
+			Policy: "weekend",
+			Reason: `within its blocked window "weekend"`,
+			Until:  time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC),
+		},
+	}, {
+		desc:     "within the allowed window",
+		policies: []*v1alpha1.ExecutionWindowPolicy{officeHours},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 5, 9, 0, 0, 0, paris),
+	}, {
+		desc:     "outside of the allowed windows",
+		policies: []*v1alpha1.ExecutionWindowPolicy{officeHours},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 5, 17, 0, 0, 0, paris),
+		want: &executionwindow.Hold{
+			Policy: "office-hours",
+			Reason: "outside of its allowed windows",
+			Until:  time.Date(2023, 6, 6, 9, 0, 0, 0, paris),
+		},
+	}, {
+		desc:     "blocked window within an allowed window of another policy",
+		policies: []*v1alpha1.ExecutionWindowPolicy{officeHours, noFridayAfternoon},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 9, 14, 0, 0, 0, paris),
+		want: &executionwindow.Hold{
+			Policy: "no-friday-afternoon",
+			Reason: "within its blocked window Friday 12:00-00:00",
+			Until:  time.Date(2023, 6, 12, 9, 0, 0, 0, paris),
+		},
+	}, {
+		desc:     "within an allowed window starting the day before",
+		policies: []*v1alpha1.ExecutionWindowPolicy{nights},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 6, 3, 0, 0, 0, time.UTC),
+	}, {
+		desc:     "outside of an allowed window spanning midnight",
+		policies: []*v1alpha1.ExecutionWindowPolicy{nights},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 6, 12, 0, 0, 0, time.UTC),
+		want: &executionwindow.Hold{
+			Policy: "nights",
+			Reason: "outside of its allowed windows",
+			Until:  time.Date(2023, 6, 6, 22, 0, 0, 0, time.UTC),
+		},
+	}, {
+		desc:     "invalid policy",
+		policies: []*v1alpha1.ExecutionWindowPolicy{invalid},
+		pipeline: "deploy-prod",
+		now:      time.Date(2023, 6, 6, 12, 0, 0, 0, time.UTC),
+		want: &executionwindow.Hold{
+			Policy: "invalid",
+			Reason: "because its time zone is invalid: unknown time zone Mars/Olympus",
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := executionwindow.Check(tc.policies, tc.pipeline, tc.now)
+			if d := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Time) bool { return a.Equal(b) })); d != "" {
+				t.Errorf("Check() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestHoldMessage(t *testing.T) {
+	h := &executionwindow.Hold{
+		Policy: "weekend",
+		Reason: `within its blocked window "weekend"`,
+		Until:  time.Date(2023, 6, 12, 0, 0, 0, 0, time.UTC),
+	}
+	want := `ExecutionWindowPolicy "weekend" holds the PipelineRun within its blocked window "weekend" until 2023-06-12T00:00:00Z`
+	if got := h.Message(); got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	h.Until = time.Time{}
+	if got := h.Message(); got != `ExecutionWindowPolicy "weekend" holds the PipelineRun within its blocked window "weekend"` {
+		t.Errorf("Message() without an end = %q", got)
+	}
+}
+
+func TestPipelineName(t *testing.T) {
+	withRef := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run"},
+		Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "deploy-prod"}},
+	}
+	embedded := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "run"},
+		Spec:       v1beta1.PipelineRunSpec{PipelineSpec: &v1beta1.PipelineSpec{}},
+	}
+	if got := executionwindow.PipelineName(withRef); got != "deploy-prod" {
+		t.Errorf("PipelineName() with a pipelineRef = %q, want deploy-prod", got)
+	}
+	if got := executionwindow.PipelineName(embedded); got != "run" {
+		t.Errorf("PipelineName() with a pipelineSpec = %q, want run", got)
+	}
+}
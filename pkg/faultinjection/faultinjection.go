@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package faultinjection draws the faults injected in the TaskRuns when the
+// "enable-fault-injection" feature flag is set. The faults of an attempt of a
+// TaskRun are drawn from the seed recorded in its status, so that they can be
+// reproduced with the same configuration.
+package faultinjection
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// Plan holds the faults drawn for an attempt of a TaskRun.
+type Plan struct {
+	// Attempt is the attempt of the TaskRun: 0 for the first attempt and n for its n-th retry.
+	Attempt int
+	// PodStartDelay is the delay of the creation of the pod of the attempt,
+	// counted from its start time.
+	PodStartDelay time.Duration
+	// FailedStep is the index of the step failed once it has started, or -1
+	// when no step is failed.
+	FailedStep int
+
+	seed        int64
+	probability float64
+	dropsEvents bool
+}
+
+// NewSeed returns the seed configured for all the TaskRuns or, when there is
+// none, a seed drawn from the time.
+func NewSeed(cfg *config.FaultInjection, now time.Time) int64 {
+	if cfg.Seed != nil {
+		return *cfg.Seed
+	}
+	return rand.New(rand.NewSource(now.UnixNano())).Int63() //nolint:gosec
+}
+
+// NewPlan draws the faults of the current attempt of the TaskRun from the seed
+// recorded in its status. The same number of values is drawn whichever faults
+// are configured, so that a seed reproduces a fault with any configuration
+// injecting it.
+func NewPlan(cfg *config.FaultInjection, tr *v1beta1.TaskRun, steps int) Plan {
+	attempt := len(tr.Status.RetriesStatus)
+	p := Plan{
+		Attempt:     attempt,
+		FailedStep:  -1,
+		seed:        tr.Status.FaultInjection.Seed,
+		probability: cfg.Probability,
+		dropsEvents: cfg.Injects(config.FaultDroppedEvents),
+	}
+	r := rand.New(rand.NewSource(p.seed + int64(attempt))) //nolint:gosec
+	delays, delay := r.Float64() < cfg.Probability, r.Float64()
+	fails, step := r.Float64() < cfg.Probability, r.Float64()
+	if delays && cfg.Injects(config.FaultPodStartDelay) {
+		p.PodStartDelay = time.Duration(delay * float64(cfg.MaxPodStartDelay))
+	}
+	if fails && steps > 0 && cfg.Injects(config.FaultStepFailure) {
+		p.FailedStep = int(step * float64(steps))
+	}
+	return p
+}
+
+// DropsEvents returns true if the events emitted for the transition of the
+// TaskRun to a condition with the given reason are dropped.
+func (p Plan) DropsEvents(reason string) bool {
+	if !p.dropsEvents {
+		return false
+	}
+	h := fnv.New64a()
+	h.Write([]byte(strconv.FormatInt(p.seed, 10) + "/" + strconv.Itoa(p.Attempt) + "/" + reason))
+	return rand.New(rand.NewSource(int64(h.Sum64()))).Float64() < p.probability //nolint:gosec
+}
+
+// Record records a fault injected in the current attempt of the TaskRun in its
+// status, unless it has already been recorded.
+func Record(tr *v1beta1.TaskRun, p Plan, fault, message string) {
+	f := v1beta1.InjectedFault{Type: fault, Attempt: p.Attempt, Message: message}
+	for _, recorded := range tr.Status.FaultInjection.Faults {
+		if recorded == f {
+			return
+		}
+	}
+	tr.Status.FaultInjection.Faults = append(tr.Status.FaultInjection.Faults, f)
+}
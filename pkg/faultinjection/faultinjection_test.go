@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultinjection_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/faultinjection"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func taskRunWithSeed(seed int64, retries int) *v1beta1.TaskRun {
+	tr := &v1beta1.TaskRun{}
+	tr.Status.FaultInjection = &v1beta1.FaultInjectionStatus{Seed: seed}
+	tr.Status.RetriesStatus = make([]v1beta1.TaskRunStatus, retries)
+	return tr
+}
+
+func TestNewSeed(t *testing.T) {
+	seed := int64(42)
+	if got := faultinjection.NewSeed(&config.FaultInjection{Seed: &seed}, time.Now()); got != seed {
+		t.Errorf("expected the configured seed %d but got %d", seed, got)
+	}
+	now := time.Now()
+	if faultinjection.NewSeed(&config.FaultInjection{}, now) != faultinjection.NewSeed(&config.FaultInjection{}, now) {
+		t.Error("expected the seed drawn at a time to be the same")
+	}
+}
+
+func TestNewPlan_NoFault(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  *config.FaultInjection
+	}{{
+		name: "zero probability",
+		cfg: &config.FaultInjection{
+			Probability:      0,
+			Faults:           []string{config.FaultPodStartDelay, config.FaultStepFailure, config.FaultDroppedEvents},
+			MaxPodStartDelay: time.Minute,
+		},
+	}, {
+		name: "faults not configured",
+		cfg: &config.FaultInjection{
+			Probability:      1,
+			Faults:           []string{},
+			MaxPodStartDelay: time.Minute,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := faultinjection.NewPlan(tc.cfg, taskRunWithSeed(42, 0), 3)
+			if got.PodStartDelay != 0 {
+				t.Errorf("expected no pod start delay but got %s", got.PodStartDelay)
+			}
+			if got.FailedStep != -1 {
+				t.Errorf("expected no step failure but got step %d", got.FailedStep)
+			}
+			if got.DropsEvents("Running") {
+				t.Error("expected no events to be dropped")
+			}
+		})
+	}
+}
+
+func TestNewPlan_AllFaults(t *testing.T) {
+	cfg := &config.FaultInjection{
+		Probability:      1,
+		Faults:           []string{config.FaultPodStartDelay, config.FaultStepFailure, config.FaultDroppedEvents},
+		MaxPodStartDelay: time.Minute,
+	}
+	got := faultinjection.NewPlan(cfg, taskRunWithSeed(42, 0), 3)
+	if got.PodStartDelay < 0 || got.PodStartDelay >= time.Minute {
+		t.Errorf("expected a pod start delay shorter than a minute but got %s", got.PodStartDelay)
+	}
+	if got.FailedStep < 0 || got.FailedStep >= 3 {
+		t.Errorf("expected one of the 3 steps to fail but got %d", got.FailedStep)
+	}
+	if !got.DropsEvents("Running") {
+		t.Error("expected the events to be dropped")
+	}
+	if again := faultinjection.NewPlan(cfg, taskRunWithSeed(42, 0), 3); again != got {
+		t.Errorf("expected the plan to be reproduced from the seed but got %+v and %+v", got, again)
+	}
+	if retry := faultinjection.NewPlan(cfg, taskRunWithSeed(42, 1), 3); retry.Attempt != 1 {
+		t.Errorf("expected the plan of the first retry to be for attempt 1 but got %d", retry.Attempt)
+	}
+}
+
+func TestDropsEvents(t *testing.T) {
+	cfg := &config.FaultInjection{Probability: 0.5, Faults: []string{config.FaultDroppedEvents}}
+	plan := faultinjection.NewPlan(cfg, taskRunWithSeed(7, 0), 1)
+	dropped := 0
+	for _, reason := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m", "n", "o", "p"} {
+		if plan.DropsEvents(reason) != plan.DropsEvents(reason) {
+			t.Errorf("expected the events of %q to be dropped consistently", reason)
+		}
+		if plan.DropsEvents(reason) {
+			dropped++
+		}
+	}
+	if dropped == 0 || dropped == 16 {
+		t.Errorf("expected some of the events to be dropped with a probability of 0.5 but %d of 16 were", dropped)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	tr := taskRunWithSeed(42, 1)
+	plan := faultinjection.NewPlan(&config.FaultInjection{}, tr, 1)
+	faultinjection.Record(tr, plan, config.FaultPodStartDelay, "pod start delayed by 10s")
+	faultinjection.Record(tr, plan, config.FaultPodStartDelay, "pod start delayed by 10s")
+	faultinjection.Record(tr, plan, config.FaultDroppedEvents, `events of the transition to "Running" dropped`)
+	want := &v1beta1.FaultInjectionStatus{
+		Seed: 42,
+		Faults: []v1beta1.InjectedFault{{
+			Type:    config.FaultPodStartDelay,
+			Attempt: 1,
+			Message: "pod start delayed by 10s",
+		}, {
+			Type:    config.FaultDroppedEvents,
+			Attempt: 1,
+			Message: `events of the transition to "Running" dropped`,
+		}},
+	}
+	if d := cmp.Diff(want, tr.Status.FaultInjection); d != "" {
+		t.Errorf("fault injection status %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cardinality bounds the number of distinct values of the labels of the
+// metrics, so that labels such as the names of the Pipelines can't blow up the
+// number of time series exported by the controller.
+package cardinality
+
+import (
+	"sync"
+
+	"go.opencensus.io/tag"
+)
+
+// Overflow is the value recorded in place of the values of a label beyond its limit.
+const Overflow = "other"
+
+// Limiter records at most limit distinct values for each label. The values seen
+// first are kept, the following ones are recorded as Overflow.
+type Limiter struct {
+	mutex  sync.Mutex
+	limit  int
+	values map[tag.Key]map[string]struct{}
+}
+
+// NewLimiter returns a Limiter recording at most limit distinct values for each
+// label, or all the values if limit is 0.
+func NewLimiter(limit int) *Limiter {
+	return &Limiter{limit: limit, values: map[tag.Key]map[string]struct{}{}}
+}
+
+// Value returns the value to record for the label: the value itself if it was
+// recorded already or the limit isn't reached yet, Overflow otherwise.
+func (l *Limiter) Value(key tag.Key, value string) string {
+	if l == nil || l.limit <= 0 {
+		return value
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	values, ok := l.values[key]
+	if !ok {
+		values = map[string]struct{}{}
+		l.values[key] = values
+	}
+	if _, ok := values[value]; ok {
+		return value
+	}
+	if len(values) >= l.limit {
+		return Overflow
+	}
+	values[value] = struct{}{}
+	return value
+}
+
+// Insert returns the tag.Mutator inserting the value to record for the label.
+func (l *Limiter) Insert(key tag.Key, value string) tag.Mutator {
+	return tag.Insert(key, l.Value(key, value))
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cardinality_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/internal/cardinality"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opencensus.io/tag"
+)
+
+func TestLimiter(t *testing.T) {
+	pipeline, task := tag.MustNewKey("pipeline"), tag.MustNewKey("task")
+	for _, tc := range []struct {
+		name  string
+		limit int
+		want  []string
+	}{{
+		name:  "no limit",
+		limit: 0,
+		want:  []string{"build", "test", "deploy", "build", "lint"},
+	}, {
+		name:  "limit",
+		limit: 2,
+		want:  []string{"build", "test", "other", "build", "other"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := cardinality.NewLimiter(tc.limit)
+			var got []string
+			for _, v := range []string{"build", "test", "deploy", "build", "lint"} {
+				got = append(got, l.Value(pipeline, v))
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("pipeline values %s", diff.PrintWantGot(d))
+			}
+			// The values of each label are limited separately
+			if got := l.Value(task, "compile"); got != "compile" {
+				t.Errorf("expected the task value to be recorded, got %q", got)
+			}
+		})
+	}
+}
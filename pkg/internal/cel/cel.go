@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cel compiles and evaluates the CEL expressions of when expressions, in which the
+// params and the results of the tasks are referenced as variables, e.g.
+// int(tasks.scan.results.critical) < 3 or params.branch.matches('^release-').
+package cel
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+)
+
+// variableRegex matches the references to params, e.g. params.branch, and to the results of
+// tasks, e.g. tasks.scan.results.critical, optionally followed by the key of an object.
+var variableRegex = regexp.MustCompile(`(^|[^A-Za-z0-9_.$])((params|tasks\.[a-z0-9]([-a-z0-9]*[a-z0-9])?\.results)\.[A-Za-z0-9_-]+(\.[A-Za-z0-9_-]+)?)`)
+
+type variable struct {
+	name       string
+	start, end int
+}
+
+// variables returns the references to params and results of the expression, in order. The
+// last part of a reference followed by a call is a method called on the variable, e.g.
+// params.branch.startsWith('release'), rather than the key of an object. The text of the
+// string literals, e.g. 'params.branch', isn't a reference.
+func variables(expression string) []variable {
+	var vars []variable
+	literals := stringLiterals(expression)
+	for _, match := range variableRegex.FindAllStringSubmatchIndex(expression, -1) {
+		start, end := match[4], match[5]
+		if inRanges(start, literals) {
+			continue
+		}
+		if keyStart := match[10]; keyStart >= 0 && strings.HasPrefix(strings.TrimSpace(expression[end:]), "(") {
+			end = keyStart
+		}
+		vars = append(vars, variable{name: expression[start:end], start: start, end: end})
+	}
+	return vars
+}
+
+// stringLiterals returns the ranges of the string literals of the expression, quoted with
+// single, double or triple quotes, and optionally raw.
+func stringLiterals(expression string) [][2]int {
+	var ranges [][2]int
+	for i := 0; i < len(expression); i++ {
+		if expression[i] != '\'' && expression[i] != '"' {
+			continue
+		}
+		raw := i > 0 && (expression[i-1] == 'r' || expression[i-1] == 'R')
+		quote := expression[i : i+1]
+		if strings.HasPrefix(expression[i:], strings.Repeat(quote, 3)) {
+			quote = strings.Repeat(quote, 3)
+		}
+		start := i
+		for i += len(quote); i < len(expression) && !strings.HasPrefix(expression[i:], quote); i++ {
+			if expression[i] == '\\' && !raw {
+				i++
+			}
+		}
+		i += len(quote) - 1
+		if i >= len(expression) {
+			i = len(expression) - 1
+		}
+		ranges = append(ranges, [2]int{start, i + 1})
+	}
+	return ranges
+}
+
+func inRanges(pos int, ranges [][2]int) bool {
+	for _, r := range ranges {
+		if r[0] <= pos && pos < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// Variables returns the references to params and results of the expression, e.g.
+// params.branch or tasks.scan.results.critical.
+func Variables(expression string) []string {
+	var names []string
+	for _, v := range variables(expression) {
+		names = append(names, v.name)
+	}
+	return names
+}
+
+// ApplyReplacements replaces the references to params and results of the expression by the
+// literals of their values: the values are replaced by strings, like the params and results
+// they are the values of, and the arrays by lists of strings. The values are converted in the
+// expression to be compared to numbers, e.g. int(tasks.scan.results.critical) < 3.
+func ApplyReplacements(expression string, replacements map[string]string, arrayReplacements map[string][]string) string {
+	var b strings.Builder
+	last := 0
+	for _, v := range variables(expression) {
+		var value string
+		if values, ok := arrayReplacements[v.name]; ok {
+			literals := make([]string, 0, len(values))
+			for _, value := range values {
+				literals = append(literals, strconv.Quote(value))
+			}
+			value = "[" + strings.Join(literals, ", ") + "]"
+		} else if replacement, ok := replacements[v.name]; ok {
+			value = strconv.Quote(replacement)
+		} else {
+			continue
+		}
+		b.WriteString(expression[last:v.start])
+		b.WriteString(value)
+		last = v.end
+	}
+	b.WriteString(expression[last:])
+	return b.String()
+}
+
+// Validate compiles the expression, in which the references to params and results are
+// variables of any type, and checks that it evaluates to a bool.
+func Validate(expression string) error {
+	var b strings.Builder
+	opts := []cel.EnvOption{cel.CrossTypeNumericComparisons(true)}
+	last := 0
+	for i, v := range variables(expression) {
+		name := fmt.Sprintf("_var%d", i)
+		opts = append(opts, cel.Variable(name, cel.DynType))
+		b.WriteString(expression[last:v.start])
+		b.WriteString(name)
+		last = v.end
+	}
+	b.WriteString(expression[last:])
+	env, err := cel.NewEnv(opts...)
+	if err != nil {
+		return err
+	}
+	ast, iss := env.Compile(b.String())
+	if iss.Err() != nil {
+		return fmt.Errorf("failed to compile %q: %w", expression, iss.Err())
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return fmt.Errorf("%q must evaluate to a bool, but evaluates to a %s", expression, t)
+	}
+	return nil
+}
+
+// Evaluate evaluates the expression, once the references to params and results are replaced
+// by their values.
+func Evaluate(expression string) (bool, error) {
+	env, err := cel.NewEnv(cel.CrossTypeNumericComparisons(true))
+	if err != nil {
+		return false, err
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return false, fmt.Errorf("failed to compile %q: %w", expression, iss.Err())
+	}
+	prg, err := env.Program(ast)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(cel.NoVars())
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate %q: %w", expression, err)
+	}
+	allowed, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%q must evaluate to a bool, but evaluates to %v", expression, out.Value())
+	}
+	return allowed, nil
+}
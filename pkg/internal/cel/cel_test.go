@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cel_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/internal/cel"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestVariables(t *testing.T) {
+	for _, tc := range []struct {
+		expression string
+		want       []string
+	}{{
+		expression: "int(tasks.scan.results.critical) < 3",
+		want:       []string{"tasks.scan.results.critical"},
+	}, {
+		expression: "params.branch.matches('^release-') && tasks.build-image.results.image.digest != ''",
+		want:       []string{"params.branch", "tasks.build-image.results.image.digest"},
+	}, {
+		expression: "params.env in ['staging', 'prod']",
+		want:       []string{"params.env"},
+	}, {
+		expression: `params.env != 'params.env' && "tasks.a.results.b" != r'params.c\' && params.d == """params.e"""`,
+		want:       []string{"params.env", "params.d"},
+	}, {
+		expression: "true",
+	}} {
+		t.Run(tc.expression, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, cel.Variables(tc.expression)); d != "" {
+				t.Errorf("Variables() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyReplacements(t *testing.T) {
+	replacements := map[string]string{
+		"params.branch":               "release-1",
+		"tasks.scan.results.critical": "2",
+		"tasks.scan.results.score":    "7.5",
+		"tasks.scan.results.version":  "1.10",
+		"tasks.scan.results.id":       "007",
+		"tasks.scan.results.quoted":   `say "hi"`,
+	}
+	arrayReplacements := map[string][]string{
+		"params.envs": {"staging", "10"},
+	}
+	for _, tc := range []struct {
+		expression string
+		want       string
+	}{{
+		expression: "int(tasks.scan.results.critical) < 3",
+		want:       `int("2") < 3`,
+	}, {
+		expression: "double(tasks.scan.results.score) >= 7.0",
+		want:       `double("7.5") >= 7.0`,
+	}, {
+		expression: "tasks.scan.results.version == '1.10' && tasks.scan.results.id == '007'",
+		want:       `"1.10" == '1.10' && "007" == '007'`,
+	}, {
+		expression: "params.branch == 'params.branch'",
+		want:       `"release-1" == 'params.branch'`,
+	}, {
+		expression: "params.branch.startsWith('release-')",
+		want:       `"release-1".startsWith('release-')`,
+	}, {
+		expression: "'staging' in params.envs",
+		want:       `'staging' in ["staging", "10"]`,
+	}, {
+		expression: "tasks.scan.results.quoted != ''",
+		want:       `"say \"hi\"" != ''`,
+	}, {
+		expression: "params.unknown == 'a'",
+		want:       "params.unknown == 'a'",
+	}} {
+		t.Run(tc.expression, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, cel.ApplyReplacements(tc.expression, replacements, arrayReplacements)); d != "" {
+				t.Errorf("ApplyReplacements() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for _, expression := range []string{
+		"int(tasks.scan.results.critical) < 3",
+		"params.branch.matches('^release-')",
+		"params.env in ['staging', 'prod'] || params.force",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if err := cel.Validate(expression); err != nil {
+				t.Errorf("Validate() returned an unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidate_Error(t *testing.T) {
+	for _, expression := range []string{
+		"tasks.scan.results.critical <",
+		"params.count + 1",
+		"'main'",
+		"unknown == 'a'",
+	} {
+		t.Run(expression, func(t *testing.T) {
+			if err := cel.Validate(expression); err == nil {
+				t.Error("Validate() didn't return an error")
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	for _, tc := range []struct {
+		expression string
+		want       bool
+		wantErr    bool
+	}{{
+		expression: `int("2") < 3`,
+		want:       true,
+	}, {
+		expression: `"release-1".matches('^release-') && "staging" in ["staging", "prod"]`,
+		want:       true,
+	}, {
+		expression: `double("7.5") < 3`,
+		want:       false,
+	}, {
+		expression: `"1.10" == '1.10'`,
+		want:       true,
+	}, {
+		expression: `"2" < 3`,
+		wantErr:    true,
+	}, {
+		expression: `int("abc") < 3`,
+		wantErr:    true,
+	}, {
+		expression: `int("2") + 1`,
+		wantErr:    true,
+	}} {
+		t.Run(tc.expression, func(t *testing.T) {
+			got, err := cel.Evaluate(tc.expression)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Evaluate() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Evaluate() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package leaderelection elects the leaders of the buckets partitioning the keys of
+// the reconcilers between the replicas of the controller. Unlike the leader election
+// of knative, its parameters are reloaded when the leader election configmap changes
+// and the buckets each replica leads are reported for debugging.
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	k8sleaderelection "k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/hash"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+// Manager runs the leader election of the buckets of the reconcilers of a
+// component, with the parameters of the leader election configmap.
+type Manager struct {
+	component string
+	identity  string
+	client    kubernetes.Interface
+
+	mu          sync.Mutex
+	config      *config.LeaderElection
+	watching    bool
+	logger      *zap.SugaredLogger
+	reconcilers map[string]*electedReconciler
+}
+
+// NewManager returns a Manager electing the leaders of the buckets of the
+// reconcilers of the component with the leases of the client, as identity.
+func NewManager(client kubernetes.Interface, component, identity string) *Manager {
+	return &Manager{
+		component:   component,
+		identity:    identity,
+		client:      client,
+		config:      config.DefaultLeaderElection.DeepCopy(),
+		reconcilers: map[string]*electedReconciler{},
+	}
+}
+
+type managerKey struct{}
+
+// WithManager returns a context in which the controllers are leader elected by the manager.
+func WithManager(ctx context.Context, m *Manager) context.Context {
+	return context.WithValue(ctx, managerKey{}, m)
+}
+
+// FromContext returns the manager of the context, or nil if the controllers are
+// leader elected by knative.
+func FromContext(ctx context.Context) *Manager {
+	m, _ := ctx.Value(managerKey{}).(*Manager)
+	return m
+}
+
+// Apply hands the leader election of the reconciler of the controller, named by
+// the "buckets.<name>" keys of the leader election configmap, over to the manager
+// of the context, if any. The leader election stops when ctx is done.
+func Apply(ctx context.Context, cmw configmap.Watcher, impl *controller.Impl, informer cache.SharedInformer, name string) {
+	m := FromContext(ctx)
+	if m == nil {
+		return
+	}
+	la, ok := impl.Reconciler.(reconciler.LeaderAware)
+	if !ok {
+		return
+	}
+	// Hide the reconciler from the leader election of knative, which would otherwise
+	// promote it for all the buckets when high availability is disabled.
+	impl.Reconciler = &electedReconciler{
+		Reconciler: impl.Reconciler,
+		ctx:        ctx,
+		name:       name,
+		queueName:  impl.Name,
+		la:         la,
+		impl:       impl,
+		informer:   informer,
+		logger:     logging.FromContext(ctx).With(zap.String("reconciler", name)),
+	}
+	m.register(impl.Reconciler.(*electedReconciler), cmw)
+}
+
+func (m *Manager) register(r *electedReconciler, cmw configmap.Watcher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.reconcilers[r.name] = r
+	if m.logger == nil {
+		m.logger = logging.FromContext(r.ctx)
+	}
+	if !m.watching {
+		m.watching = true
+		// The watcher calls configChanged with the configmap when it starts, which
+		// starts the leader election of all the reconcilers.
+		cmw.Watch(config.GetLeaderElectionConfigName(), m.configChanged)
+	}
+}
+
+// configChanged restarts the leader election of the reconcilers whose parameters
+// changed. An invalid configuration is logged and ignored.
+func (m *Manager) configChanged(cm *corev1.ConfigMap) {
+	cfg, err := config.NewLeaderElectionFromConfigMap(cm)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		m.logger.Errorw("Ignoring the invalid leader election configuration", zap.Error(err))
+		return
+	}
+	m.config = cfg
+	for _, r := range m.reconcilers {
+		p := m.paramsOf(r.name)
+		if r.current() != nil && r.current().params == p {
+			continue
+		}
+		r.logger.Infof("Electing the leaders of %d buckets with %+v", p.buckets, p)
+		r.restart(m.newRun(r, p))
+	}
+}
+
+// params are the parameters of the leader election of a reconciler.
+type params struct {
+	buckets       uint32
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+	retryPeriod   time.Duration
+	resyncPeriod  time.Duration
+}
+
+func (m *Manager) paramsOf(name string) params {
+	return params{
+		buckets:       m.config.BucketsOf(name),
+		leaseDuration: m.config.LeaseDuration,
+		renewDeadline: m.config.RenewDeadline,
+		retryPeriod:   m.config.RetryPeriod,
+		resyncPeriod:  m.config.ResyncPeriod,
+	}
+}
+
+// newRun returns the run of the leader election of the buckets of the reconciler with the parameters.
+func (m *Manager) newRun(r *electedReconciler, p params) *run {
+	names := make(sets.String, p.buckets)
+	for i := uint32(0); i < p.buckets; i++ {
+		names.Insert(strings.ToLower(fmt.Sprintf("%s.%s.%02d-of-%02d", m.component, r.queueName, i, p.buckets)))
+	}
+	ru := &run{params: p, done: make(chan struct{})}
+	for _, bkt := range hash.NewBucketSet(names).Buckets() {
+		bkt := bkt
+		b := &bucketElector{bucket: bkt}
+		lock, err := resourcelock.New(resourcelock.LeasesResourceLock, system.Namespace(), bkt.Name(),
+			m.client.CoreV1(), m.client.CoordinationV1(), resourcelock.ResourceLockConfig{Identity: m.identity})
+		if err == nil {
+			b.elector, err = k8sleaderelection.NewLeaderElector(k8sleaderelection.LeaderElectionConfig{
+				Lock:          lock,
+				LeaseDuration: p.leaseDuration,
+				RenewDeadline: p.renewDeadline,
+				RetryPeriod:   p.retryPeriod,
+				Callbacks: k8sleaderelection.LeaderCallbacks{
+					OnStartedLeading: func(context.Context) {
+						r.logger.Infof("%q has started leading %q", m.identity, bkt.Name())
+						b.leading.Store(true)
+						if err := r.la.Promote(bkt, r.impl.MaybeEnqueueBucketKey); err != nil {
+							r.logger.Errorw(fmt.Sprintf("Failed to promote %q", bkt.Name()), zap.Error(err))
+						}
+					},
+					OnStoppedLeading: func() {
+						if b.leading.Swap(false) {
+							r.logger.Infof("%q has stopped leading %q", m.identity, bkt.Name())
+						}
+						r.la.Demote(bkt)
+					},
+				},
+				ReleaseOnCancel: true,
+				Name:            m.identity,
+			})
+		}
+		if err != nil {
+			// The parameters are validated when they are parsed, so this never happens.
+			r.logger.Errorw(fmt.Sprintf("Failed to create the elector of %q", bkt.Name()), zap.Error(err))
+			continue
+		}
+		ru.buckets = append(ru.buckets, b)
+	}
+	return ru
+}
+
+// Status is the status of the leader election of the buckets of the reconcilers of a replica.
+type Status struct {
+	// Identity is the identity of the replica in the leases of the buckets.
+	Identity string `json:"identity"`
+	// Reconcilers are the statuses of the reconcilers, sorted by name.
+	Reconcilers []ReconcilerStatus `json:"reconcilers"`
+}
+
+// ReconcilerStatus is the status of the leader election of the buckets of a reconciler.
+type ReconcilerStatus struct {
+	// Name is the name of the reconciler in the leader election configmap.
+	Name string `json:"name"`
+	// Buckets are the statuses of the buckets of the reconciler.
+	Buckets []BucketStatus `json:"buckets"`
+	// KeyBucket is the name of the bucket of the key the status was requested for, if any.
+	KeyBucket string `json:"keyBucket,omitempty"`
+}
+
+// BucketStatus is the status of the leader election of a bucket.
+type BucketStatus struct {
+	// Name is the name of the bucket, which is the name of its lease.
+	Name string `json:"name"`
+	// Leading is true if the replica leads the bucket.
+	Leading bool `json:"leading"`
+	// Holder is the identity of the leader of the bucket last observed by the replica.
+	Holder string `json:"holder,omitempty"`
+}
+
+// Status returns the status of the leader election of the reconcilers. The bucket
+// of the key, if not empty, is reported for each reconciler.
+func (m *Manager) Status(key types.NamespacedName) Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := Status{Identity: m.identity, Reconcilers: []ReconcilerStatus{}}
+	for _, r := range m.reconcilers {
+		rs := ReconcilerStatus{Name: r.name, Buckets: []BucketStatus{}}
+		if ru := r.current(); ru != nil {
+			for _, b := range ru.buckets {
+				rs.Buckets = append(rs.Buckets, BucketStatus{
+					Name:    b.bucket.Name(),
+					Leading: b.leading.Load(),
+					Holder:  b.elector.GetLeader(),
+				})
+				if key.Name != "" && b.bucket.Has(key) {
+					rs.KeyBucket = b.bucket.Name()
+				}
+			}
+		}
+		s.Reconcilers = append(s.Reconcilers, rs)
+	}
+	sort.Slice(s.Reconcilers, func(i, j int) bool {
+		return s.Reconcilers[i].Name < s.Reconcilers[j].Name
+	})
+	return s
+}
+
+// electedReconciler is a reconciler whose buckets are leader elected by the manager.
+// It isn't leader aware, so that knative doesn't elect the leaders of its buckets.
+type electedReconciler struct {
+	controller.Reconciler
+
+	ctx       context.Context
+	name      string
+	queueName string
+	la        reconciler.LeaderAware
+	impl      *controller.Impl
+	informer  cache.SharedInformer
+	logger    *zap.SugaredLogger
+
+	mu  sync.Mutex
+	run *run
+}
+
+func (r *electedReconciler) current() *run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.run
+}
+
+// restart stops the current run of the leader election, releasing the leases of
+// its buckets, and then starts the next one once the informer has synced.
+func (r *electedReconciler) restart(next *run) {
+	ctx, cancel := context.WithCancel(r.ctx)
+	next.cancel = cancel
+	r.mu.Lock()
+	previous := r.run
+	r.run = next
+	r.mu.Unlock()
+
+	if previous != nil {
+		previous.cancel()
+	}
+	go func() {
+		defer close(next.done)
+		if previous != nil {
+			<-previous.done
+		}
+		if !cache.WaitForCacheSync(ctx.Done(), r.informer.HasSynced) {
+			return
+		}
+		next.elect(ctx, r)
+	}()
+}
+
+// run is a run of the leader election of the buckets of a reconciler with some parameters.
+type run struct {
+	params  params
+	buckets []*bucketElector
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// bucketElector elects the leader of a bucket.
+type bucketElector struct {
+	bucket  reconciler.Bucket
+	elector *k8sleaderelection.LeaderElector
+	leading atomic.Bool
+}
+
+// elect runs the electors of the buckets until ctx is done, and periodically
+// resyncs the keys of the buckets the replica leads.
+func (ru *run) elect(ctx context.Context, r *electedReconciler) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for _, b := range ru.buckets {
+		wg.Add(1)
+		go func(b *bucketElector) {
+			defer wg.Done()
+			// Turn the single term elector into a continuous election cycle.
+			for ctx.Err() == nil {
+				b.elector.Run(ctx)
+			}
+		}(b)
+	}
+	if ru.params.resyncPeriod <= 0 {
+		return
+	}
+	ticker := time.NewTicker(ru.params.resyncPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.impl.FilteredGlobalResync(func(obj interface{}) bool {
+				key, err := cache.MetaNamespaceKeyFunc(obj)
+				if err != nil {
+					return false
+				}
+				namespace, name, err := cache.SplitMetaNamespaceKey(key)
+				return err == nil && ru.leads(types.NamespacedName{Namespace: namespace, Name: name})
+			}, r.informer)
+		}
+	}
+}
+
+// leads returns true if the replica leads the bucket of the key.
+func (ru *run) leads(key types.NamespacedName) bool {
+	for _, b := range ru.buckets {
+		if b.bucket.Has(key) {
+			return b.leading.Load()
+		}
+	}
+	return false
+}
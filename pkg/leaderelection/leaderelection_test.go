@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package leaderelection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+type fakeReconciler struct {
+	reconciler.LeaderAwareFuncs
+}
+
+func (*fakeReconciler) Reconcile(context.Context, string) error {
+	return nil
+}
+
+func newImpl(ctx context.Context, t *testing.T) (*controller.Impl, *fakeReconciler) {
+	t.Helper()
+	r := &fakeReconciler{}
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		WorkQueueName: "github.com.tektoncd.pipeline.pkg.reconciler.taskrun.Reconciler",
+		Logger:        logtesting.TestLogger(t),
+	})
+	return impl, r
+}
+
+func leaderElectionConfigMap(data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetLeaderElectionConfigName(), Namespace: system.Namespace()},
+		Data:       data,
+	}
+}
+
+func TestApplyWithoutManager(t *testing.T) {
+	ctx := logtesting.TestContextWithLogger(t)
+	impl, r := newImpl(ctx, t)
+	Apply(ctx, &configmap.ManualWatcher{Namespace: system.Namespace()}, impl, nil, "taskrun")
+	if impl.Reconciler != r {
+		t.Errorf("Apply() without a manager changed the reconciler to %T", impl.Reconciler)
+	}
+}
+
+func TestManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(logtesting.TestContextWithLogger(t))
+	defer cancel()
+	client := fakekubeclient.NewSimpleClientset()
+	factory := informers.NewSharedInformerFactory(client, 0)
+	informer := factory.Core().V1().ConfigMaps().Informer()
+	factory.Start(ctx.Done())
+
+	m := NewManager(client, "tekton-pipelines-controller", "replica-0")
+	ctx = WithManager(ctx, m)
+	cmw := &configmap.ManualWatcher{Namespace: system.Namespace()}
+	impl, r := newImpl(ctx, t)
+	Apply(ctx, cmw, impl, informer, "taskrun")
+	elected, ok := impl.Reconciler.(*electedReconciler)
+	if !ok {
+		t.Fatalf("Apply() didn't hand the leader election of the reconciler over to the manager, it is a %T", impl.Reconciler)
+	}
+	if _, ok := impl.Reconciler.(reconciler.LeaderAware); ok {
+		t.Fatal("Apply() left the reconciler leader aware, it would be leader elected by knative")
+	}
+	// Wait for the electors to release the leases, which they log, before the test completes.
+	defer func() {
+		cancel()
+		if ru := elected.current(); ru != nil {
+			<-ru.done
+		}
+	}()
+
+	key := types.NamespacedName{Namespace: "default", Name: "run"}
+	waitForBuckets := func(buckets int) Status {
+		t.Helper()
+		var s Status
+		if err := wait.PollImmediate(10*time.Millisecond, 10*time.Second, func() (bool, error) {
+			s = m.Status(key)
+			if len(s.Reconcilers) != 1 || len(s.Reconcilers[0].Buckets) != buckets {
+				return false, nil
+			}
+			for _, b := range s.Reconcilers[0].Buckets {
+				if !b.Leading || b.Holder != "replica-0" {
+					return false, nil
+				}
+			}
+			return true, nil
+		}); err != nil {
+			t.Fatalf("The replica doesn't lead %d buckets: %+v", buckets, s)
+		}
+		return s
+	}
+	timing := map[string]string{"lease-duration": "2s", "renew-deadline": "1s", "retry-period": "100ms"}
+	withBuckets := func(data map[string]string) map[string]string {
+		for k, v := range timing {
+			data[k] = v
+		}
+		return data
+	}
+
+	cmw.OnChange(leaderElectionConfigMap(withBuckets(map[string]string{"buckets": "2"})))
+	s := waitForBuckets(2)
+	if s.Identity != "replica-0" || s.Reconcilers[0].Name != "taskrun" {
+		t.Errorf("Unexpected status %+v", s)
+	}
+	if s.Reconcilers[0].KeyBucket == "" {
+		t.Errorf("The bucket of %s isn't reported: %+v", key, s)
+	}
+	if !r.IsLeaderFor(key) {
+		t.Errorf("The reconciler isn't promoted for %s", key)
+	}
+	for _, b := range s.Reconcilers[0].Buckets {
+		if _, err := client.CoordinationV1().Leases(system.Namespace()).Get(ctx, b.Name, metav1.GetOptions{}); err != nil {
+			t.Errorf("The lease of the bucket %s wasn't created: %v", b.Name, err)
+		}
+	}
+
+	// The number of buckets of the reconciler overrides the default one.
+	cmw.OnChange(leaderElectionConfigMap(withBuckets(map[string]string{"buckets": "2", "buckets.taskrun": "3"})))
+	s = waitForBuckets(3)
+	if got := s.Reconcilers[0].Buckets[0].Name; got != "tekton-pipelines-controller.github.com.tektoncd.pipeline.pkg.reconciler.taskrun.reconciler.00-of-03" {
+		t.Errorf("Unexpected bucket name %q", got)
+	}
+
+	// An invalid configuration is ignored.
+	cmw.OnChange(leaderElectionConfigMap(map[string]string{"buckets": "11"}))
+	waitForBuckets(3)
+
+	rec := httptest.NewRecorder()
+	m.StatusHandler().ServeHTTP(rec, httptest.NewRequest("GET", StatusPath+"?namespace=default&name=run", nil))
+	var got Status
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("Failed to decode the status: %v", err)
+	}
+	if d := cmp.Diff(m.Status(key), got); d != "" {
+		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lint reports likely mistakes and risky patterns in Tasks and
+// Pipelines which are valid, and thus accepted by the validation webhook.
+package lint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+// Severity is the severity of a Finding.
+type Severity string
+
+const (
+	// SeverityWarning is the severity of the findings which are likely mistakes.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo is the severity of the findings which are risky, but may be intended.
+	SeverityInfo Severity = "info"
+)
+
+// Finding is an issue reported by a Rule.
+type Finding struct {
+	// Rule is the name of the Rule which reported the issue.
+	Rule string `json:"rule"`
+	// Severity is the severity of the issue.
+	Severity Severity `json:"severity"`
+	// Path is the path of the field the issue is about, e.g. "spec.params[0]".
+	Path string `json:"path"`
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+// String returns the Finding in a human readable form.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", f.Path, f.Severity, f.Message, f.Rule)
+}
+
+// Rule is a lint rule. A Rule reports issues by implementing TaskRule,
+// PipelineRule or both, which allows adding custom rules to a Linter.
+type Rule interface {
+	// Name is the unique name of the rule, reported in its findings.
+	Name() string
+}
+
+// TaskRule is a Rule reporting issues in Tasks, and in the Tasks embedded in Pipelines.
+type TaskRule interface {
+	Rule
+	// LintTask returns the issues in the TaskSpec. The paths of the
+	// findings are relative to the TaskSpec and the Rule is set by the Linter.
+	LintTask(ctx context.Context, ts *v1.TaskSpec) []Finding
+}
+
+// PipelineRule is a Rule reporting issues in Pipelines.
+type PipelineRule interface {
+	Rule
+	// LintPipeline returns the issues in the PipelineSpec. The paths of the
+	// findings are relative to the PipelineSpec and the Rule is set by the Linter.
+	LintPipeline(ctx context.Context, ps *v1.PipelineSpec) []Finding
+}
+
+// Linter lints Tasks and Pipelines with a set of rules.
+type Linter struct {
+	rules []Rule
+}
+
+// New returns a Linter using the given rules.
+func New(rules ...Rule) *Linter {
+	return &Linter{rules: rules}
+}
+
+// NewDefault returns a Linter using the DefaultRules.
+func NewDefault() *Linter {
+	return New(DefaultRules()...)
+}
+
+// DefaultRules returns the rules shipped with Tekton Pipelines.
+func DefaultRules() []Rule {
+	return []Rule{
+		unusedParams{},
+		unreferencedResults{},
+		missingTimeouts{},
+		mutableImages{},
+		resultSize{},
+	}
+}
+
+// LintTask returns the issues in the Task, sorted by path.
+func (l *Linter) LintTask(ctx context.Context, t *v1.Task) []Finding {
+	findings := l.lintTaskSpec(ctx, &t.Spec, "spec")
+	sortFindings(findings)
+	return findings
+}
+
+// LintPipeline returns the issues in the Pipeline and in its embedded Tasks, sorted by path.
+func (l *Linter) LintPipeline(ctx context.Context, p *v1.Pipeline) []Finding {
+	findings := l.lintPipelineSpec(ctx, &p.Spec, "spec")
+	sortFindings(findings)
+	return findings
+}
+
+// Lint returns the issues in a v1 or v1beta1 Task or Pipeline, sorted by path.
+func (l *Linter) Lint(ctx context.Context, obj runtime.Object) ([]Finding, error) {
+	switch o := obj.(type) {
+	case *v1.Task:
+		return l.LintTask(ctx, o), nil
+	case *v1.Pipeline:
+		return l.LintPipeline(ctx, o), nil
+	case *v1beta1.Task:
+		t := &v1.Task{}
+		if err := o.ConvertTo(ctx, t); err != nil {
+			return nil, fmt.Errorf("failed to convert Task %s to v1: %w", o.Name, err)
+		}
+		return l.LintTask(ctx, t), nil
+	case *v1beta1.Pipeline:
+		p := &v1.Pipeline{}
+		if err := o.ConvertTo(ctx, p); err != nil {
+			return nil, fmt.Errorf("failed to convert Pipeline %s to v1: %w", o.Name, err)
+		}
+		return l.LintPipeline(ctx, p), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", obj)
+	}
+}
+
+func (l *Linter) lintTaskSpec(ctx context.Context, ts *v1.TaskSpec, path string) []Finding {
+	var findings []Finding
+	for _, r := range l.rules {
+		if tr, ok := r.(TaskRule); ok {
+			findings = append(findings, withRule(tr.LintTask(ctx, ts), r.Name(), path)...)
+		}
+	}
+	return findings
+}
+
+func (l *Linter) lintPipelineSpec(ctx context.Context, ps *v1.PipelineSpec, path string) []Finding {
+	var findings []Finding
+	for _, r := range l.rules {
+		if pr, ok := r.(PipelineRule); ok {
+			findings = append(findings, withRule(pr.LintPipeline(ctx, ps), r.Name(), path)...)
+		}
+	}
+	findings = append(findings, l.lintEmbeddedSpecs(ctx, ps.Tasks, path+".tasks")...)
+	return append(findings, l.lintEmbeddedSpecs(ctx, ps.Finally, path+".finally")...)
+}
+
+// lintEmbeddedSpecs returns the issues in the Tasks embedded in the PipelineTasks.
+func (l *Linter) lintEmbeddedSpecs(ctx context.Context, tasks []v1.PipelineTask, path string) []Finding {
+	var findings []Finding
+	for i, pt := range tasks {
+		if pt.TaskSpec != nil {
+			findings = append(findings, l.lintTaskSpec(ctx, &pt.TaskSpec.TaskSpec, fmt.Sprintf("%s[%d].taskSpec", path, i))...)
+		}
+	}
+	return findings
+}
+
+// withRule sets the rule of the findings and prefixes their paths.
+func withRule(findings []Finding, rule, path string) []Finding {
+	for i := range findings {
+		findings[i].Rule = rule
+		if findings[i].Path == "" {
+			findings[i].Path = path
+		} else {
+			findings[i].Path = path + "." + findings[i].Path
+		}
+	}
+	return findings
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Path != findings[j].Path {
+			return findings[i].Path < findings[j].Path
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+}
+
+// AsWarnings returns the findings as warning level diagnostics, as reported
+// by the validation webhook. It returns nil if there are no findings.
+func AsWarnings(findings []Finding) *apis.FieldError {
+	var errs *apis.FieldError
+	for _, f := range findings {
+		errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("%s (%s)", f.Message, f.Rule), f.Path).At(apis.WarningLevel))
+	}
+	return errs
+}
+
+// Warnings returns the findings of the DefaultRules about a Task or Pipeline as
+// warnings if "enable-lint-warnings" is set, and nil otherwise. It is one of the
+// validate.WarningsFunc of the validation webhook.
+func Warnings(ctx context.Context, obj runtime.Object) *apis.FieldError {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.EnableLintWarnings {
+		return nil
+	}
+	switch obj.(type) {
+	case *v1.Task, *v1.Pipeline, *v1beta1.Task, *v1beta1.Pipeline:
+	default:
+		// The runs are validated with the same warnings, but aren't linted.
+		return nil
+	}
+	findings, err := NewDefault().Lint(ctx, obj)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to lint %T: %v", obj, err)
+		return nil
+	}
+	return AsWarnings(findings)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/lint"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// noRoot is a custom rule reporting the steps which don't set runAsNonRoot.
+type noRoot struct{}
+
+func (noRoot) Name() string { return "no-root" }
+
+func (noRoot) LintTask(_ context.Context, ts *v1.TaskSpec) []lint.Finding {
+	var findings []lint.Finding
+	for i, s := range ts.Steps {
+		if s.SecurityContext == nil || s.SecurityContext.RunAsNonRoot == nil || !*s.SecurityContext.RunAsNonRoot {
+			findings = append(findings, lint.Finding{
+				Severity: lint.SeverityWarning,
+				Path:     fmt.Sprintf("steps[%d]", i),
+				Message:  "step may run as root",
+			})
+		}
+	}
+	return findings
+}
+
+func TestLinter_CustomRule(t *testing.T) {
+	nonRoot := true
+	p := &v1.Pipeline{
+		ObjectMeta: metav1.ObjectMeta{Name: "p"},
+		Spec: v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{
+				Name: "a",
+				TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+					Steps: []v1.Step{{
+						Image:           "alpine",
+						SecurityContext: &corev1.SecurityContext{RunAsNonRoot: &nonRoot},
+					}, {
+						Image: "alpine",
+					}},
+				}},
+			}},
+			Finally: []v1.PipelineTask{{
+				Name: "b",
+				TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+					Steps: []v1.Step{{Image: "alpine"}},
+				}},
+			}},
+		},
+	}
+	want := []lint.Finding{{
+		Rule:     "no-root",
+		Severity: lint.SeverityWarning,
+		Path:     "spec.finally[0].taskSpec.steps[0]",
+		Message:  "step may run as root",
+	}, {
+		Rule:     "no-root",
+		Severity: lint.SeverityWarning,
+		Path:     "spec.tasks[0].taskSpec.steps[1]",
+		Message:  "step may run as root",
+	}}
+	if d := cmp.Diff(want, lint.New(noRoot{}).LintPipeline(context.Background(), p)); d != "" {
+		t.Errorf("LintPipeline %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestLinter_Lint(t *testing.T) {
+	v1beta1Task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "t"},
+		Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "unused"}},
+			Steps:  []v1beta1.Step{{Image: "alpine", Script: "echo hello"}},
+		},
+	}
+	want := []lint.Finding{{
+		Rule:     "unused-params",
+		Severity: lint.SeverityWarning,
+		Path:     "spec.params[0]",
+		Message:  `param "unused" is never used`,
+	}, {
+		Rule:     "mutable-image-tags",
+		Severity: lint.SeverityWarning,
+		Path:     "spec.steps[0].image",
+		Message:  `image "alpine" is not pinned by digest`,
+	}}
+	got, err := lint.NewDefault().Lint(context.Background(), v1beta1Task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Lint %s", diff.PrintWantGot(d))
+	}
+
+	if _, err := lint.NewDefault().Lint(context.Background(), &v1.TaskRun{}); err == nil {
+		t.Error("expected an error linting a TaskRun")
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	task := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "t"},
+		Spec: v1.TaskSpec{
+			Params: v1.ParamSpecs{{Name: "unused"}},
+			Steps:  []v1.Step{{Image: "alpine@sha256:5c0b5ee5c2f6e3d7c5e0b5cb0d2fbcd1e3cd5d3b5a6dd1ef5d9df9ba2b27f3e8"}},
+		},
+	}
+	if got := lint.Warnings(context.Background(), task); got != nil {
+		t.Errorf("expected no warnings when lint warnings are disabled but got %v", got)
+	}
+
+	featureFlags, err := config.NewFeatureFlagsFromMap(map[string]string{"enable-lint-warnings": "true"})
+	if err != nil {
+		t.Fatalf("unexpected error parsing the feature flags: %v", err)
+	}
+	ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+	got := lint.Warnings(ctx, task)
+	want := apis.ErrGeneric(`param "unused" is never used (unused-params)`, "spec.params[0]").At(apis.WarningLevel)
+	if d := cmp.Diff(want.Error(), got.Error()); d != "" {
+		t.Errorf("Warnings %s", diff.PrintWantGot(d))
+	}
+	if got.Filter(apis.ErrorLevel) != nil {
+		t.Errorf("expected warnings only but got %v", got)
+	}
+	if got := lint.Warnings(ctx, &v1beta1.TaskRun{}); got != nil {
+		t.Errorf("expected no warnings for a TaskRun but got %v", got)
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// maxTerminationMessageSize is the size in bytes of the termination message
+	// shared by the results of all the steps of a Task.
+	maxTerminationMessageSize = 4096
+	// minResultSize is the size in bytes below which the share of the termination
+	// message of each result is considered at risk of being exceeded.
+	minResultSize = 256
+)
+
+// unusedParams reports the params which are declared but never referenced.
+type unusedParams struct{}
+
+func (unusedParams) Name() string { return "unused-params" }
+
+func (unusedParams) LintTask(_ context.Context, ts *v1.TaskSpec) []Finding {
+	spec := ts.DeepCopy()
+	spec.Params = nil
+	return unusedParamFindings(ts.Params, referencedVariables(spec, "params"))
+}
+
+func (unusedParams) LintPipeline(_ context.Context, ps *v1.PipelineSpec) []Finding {
+	spec := ps.DeepCopy()
+	spec.Params = nil
+	return unusedParamFindings(ps.Params, referencedVariables(spec, "params"))
+}
+
+func unusedParamFindings(params v1.ParamSpecs, referenced sets.String) []Finding {
+	var findings []Finding
+	for i, p := range params {
+		if !referenced.Has(p.Name) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Path:     fmt.Sprintf("params[%d]", i),
+				Message:  fmt.Sprintf("param %q is never used", p.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// unreferencedResults reports the results of Tasks which are never written
+// by their steps, and the results of the Tasks embedded in Pipelines which
+// are neither used by other PipelineTasks nor by the results of the Pipeline.
+type unreferencedResults struct{}
+
+func (unreferencedResults) Name() string { return "unreferenced-results" }
+
+func (unreferencedResults) LintTask(_ context.Context, ts *v1.TaskSpec) []Finding {
+	spec := ts.DeepCopy()
+	spec.Results = nil
+	strs := collectStrings(spec)
+	var findings []Finding
+	for i, r := range ts.Results {
+		if !containsAny(strs, fmt.Sprintf("$(results.%s.path)", r.Name), fmt.Sprintf("/tekton/results/%s", r.Name)) {
+			findings = append(findings, Finding{
+				Severity: SeverityWarning,
+				Path:     fmt.Sprintf("results[%d]", i),
+				Message:  fmt.Sprintf("result %q is never written by the steps", r.Name),
+			})
+		}
+	}
+	return findings
+}
+
+func (unreferencedResults) LintPipeline(_ context.Context, ps *v1.PipelineSpec) []Finding {
+	referenced := sets.NewString()
+	for _, tasks := range [][]v1.PipelineTask{ps.Tasks, ps.Finally} {
+		for i := range tasks {
+			for _, ref := range v1.PipelineTaskResultRefs(&tasks[i]) {
+				referenced.Insert(ref.PipelineTask + "." + ref.Result)
+			}
+		}
+	}
+	for _, r := range ps.Results {
+		expressions, _ := v1.GetVarSubstitutionExpressionsForPipelineResult(r)
+		for _, ref := range v1.NewResultRefs(expressions) {
+			referenced.Insert(ref.PipelineTask + "." + ref.Result)
+		}
+	}
+
+	var findings []Finding
+	for field, tasks := range map[string][]v1.PipelineTask{"tasks": ps.Tasks, "finally": ps.Finally} {
+		for i, pt := range tasks {
+			if pt.TaskSpec == nil {
+				// The results of referenced Tasks are unknown.
+				continue
+			}
+			for j, r := range pt.TaskSpec.Results {
+				if !referenced.Has(pt.Name + "." + r.Name) {
+					findings = append(findings, Finding{
+						Severity: SeverityInfo,
+						Path:     fmt.Sprintf("%s[%d].taskSpec.results[%d]", field, i, j),
+						Message:  fmt.Sprintf("result %q of %q is never used", r.Name, pt.Name),
+					})
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// missingTimeouts reports the PipelineTasks without a timeout, which run as long
+// as the timeout of the whole PipelineRun allows.
+type missingTimeouts struct{}
+
+func (missingTimeouts) Name() string { return "missing-timeouts" }
+
+func (missingTimeouts) LintPipeline(_ context.Context, ps *v1.PipelineSpec) []Finding {
+	var findings []Finding
+	for field, tasks := range map[string][]v1.PipelineTask{"tasks": ps.Tasks, "finally": ps.Finally} {
+		for i, pt := range tasks {
+			if pt.Timeout == nil && pt.TimeoutFrom == "" {
+				findings = append(findings, Finding{
+					Severity: SeverityInfo,
+					Path:     fmt.Sprintf("%s[%d].timeout", field, i),
+					Message:  fmt.Sprintf("%q has no timeout and is only bounded by the timeout of the PipelineRun", pt.Name),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// mutableImages reports the images of steps and sidecars which are not pinned
+// by digest, and thus may change between runs.
+type mutableImages struct{}
+
+func (mutableImages) Name() string { return "mutable-image-tags" }
+
+func (mutableImages) LintTask(_ context.Context, ts *v1.TaskSpec) []Finding {
+	var findings []Finding
+	lint := func(image, path string) {
+		if image == "" || strings.Contains(image, "$(") {
+			// The image is set by the step template, or by a variable.
+			return
+		}
+		if _, err := name.NewDigest(image, name.WeakValidation); err == nil {
+			return
+		}
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Path:     path,
+			Message:  fmt.Sprintf("image %q is not pinned by digest", image),
+		})
+	}
+	if ts.StepTemplate != nil {
+		lint(ts.StepTemplate.Image, "stepTemplate.image")
+	}
+	for i, s := range ts.Steps {
+		lint(s.Image, fmt.Sprintf("steps[%d].image", i))
+	}
+	for i, s := range ts.Sidecars {
+		lint(s.Image, fmt.Sprintf("sidecars[%d].image", i))
+	}
+	return findings
+}
+
+// resultSize reports the results at risk of exceeding the maximum size of results.
+type resultSize struct{}
+
+func (resultSize) Name() string { return "result-size" }
+
+func (resultSize) LintTask(ctx context.Context, ts *v1.TaskSpec) []Finding {
+	var findings []Finding
+	for i, r := range ts.Results {
+		if r.Type == v1.ResultsTypeArray || r.Type == v1.ResultsTypeObject {
+			findings = append(findings, Finding{
+				Severity: SeverityInfo,
+				Path:     fmt.Sprintf("results[%d]", i),
+				Message:  fmt.Sprintf("%s result %q has no bounded size", r.Type, r.Name),
+			})
+		}
+	}
+	// With sidecar logs, the size limit applies to each result rather than to all the results together.
+	if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs {
+		return findings
+	}
+	if n := len(ts.Results); n > 0 && maxTerminationMessageSize/n < minResultSize {
+		findings = append(findings, Finding{
+			Severity: SeverityWarning,
+			Path:     "results",
+			Message: fmt.Sprintf("the %d results share a termination message of %d bytes, leaving less than %d bytes to each",
+				n, maxTerminationMessageSize, minResultSize),
+		})
+	}
+	return findings
+}
+
+// referencedVariables returns the names of the variables with the given prefix
+// referenced in the strings of v, e.g. "foo" for "$(params.foo)".
+func referencedVariables(v interface{}, prefix string) sets.String {
+	names := sets.NewString()
+	for _, s := range collectStrings(v) {
+		vars, _, _ := substitution.ExtractVariablesFromString(s, prefix)
+		for _, v := range vars {
+			names.Insert(substitution.TrimArrayIndex(v))
+		}
+	}
+	return names
+}
+
+// collectStrings returns all the strings in the JSON representation of v.
+func collectStrings(v interface{}) []string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return nil
+	}
+	var strs []string
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch t := v.(type) {
+		case string:
+			strs = append(strs, t)
+		case []interface{}:
+			for _, e := range t {
+				walk(e)
+			}
+		case map[string]interface{}:
+			for _, e := range t {
+				walk(e)
+			}
+		}
+	}
+	walk(generic)
+	return strs
+}
+
+func containsAny(strs []string, substrs ...string) bool {
+	for _, s := range strs {
+		for _, sub := range substrs {
+			if strings.Contains(s, sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const pinnedImage = "gcr.io/tekton-releases/git-init@sha256:5c0b5ee5c2f6e3d7c5e0b5cb0d2fbcd1e3cd5d3b5a6dd1ef5d9df9ba2b27f3e8"
+
+func TestUnusedParams(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Params: v1.ParamSpecs{{Name: "used"}, {Name: "unused"}, {Name: "array", Type: v1.ParamTypeArray}, {Name: "bracket"}},
+		Steps: []v1.Step{{
+			Image:  pinnedImage,
+			Script: `echo $(params.used) $(params["bracket"])`,
+			Args:   []string{"$(params.array[*])"},
+		}},
+	}
+	want := []Finding{{Severity: SeverityWarning, Path: "params[1]", Message: `param "unused" is never used`}}
+	if d := cmp.Diff(want, unusedParams{}.LintTask(context.Background(), ts)); d != "" {
+		t.Errorf("LintTask %s", diff.PrintWantGot(d))
+	}
+
+	ps := &v1.PipelineSpec{
+		Params: v1.ParamSpecs{{Name: "in-params"}, {Name: "in-when"}, {Name: "in-embedded-task"}, {Name: "unused"}},
+		Tasks: []v1.PipelineTask{{
+			Name:    "a",
+			TaskRef: &v1.TaskRef{Name: "a"},
+			Params:  v1.Params{{Name: "p", Value: *v1.NewStructuredValues("$(params.in-params)")}},
+			When:    v1.WhenExpressions{{Input: "$(params.in-when)", Operator: "in", Values: []string{"yes"}}},
+		}, {
+			Name: "b",
+			TaskSpec: &v1.EmbeddedTask{TaskSpec: v1.TaskSpec{
+				Steps: []v1.Step{{Image: pinnedImage, Script: "echo $(params.in-embedded-task)"}},
+			}},
+		}},
+	}
+	want = []Finding{{Severity: SeverityWarning, Path: "params[3]", Message: `param "unused" is never used`}}
+	if d := cmp.Diff(want, unusedParams{}.LintPipeline(context.Background(), ps)); d != "" {
+		t.Errorf("LintPipeline %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestUnreferencedResults(t *testing.T) {
+	ts := &v1.TaskSpec{
+		Results: []v1.TaskResult{{Name: "by-variable"}, {Name: "by-path"}, {Name: "unwritten"}},
+		Steps: []v1.Step{{
+			Image:  pinnedImage,
+			Script: "date > $(results.by-variable.path)\necho ok > /tekton/results/by-path",
+		}},
+	}
+	want := []Finding{{Severity: SeverityWarning, Path: "results[2]", Message: `result "unwritten" is never written by the steps`}}
+	if d := cmp.Diff(want, unreferencedResults{}.LintTask(context.Background(), ts)); d != "" {
+		t.Errorf("LintTask %s", diff.PrintWantGot(d))
+	}
+
+	embedded := func(results ...string) *v1.EmbeddedTask {
+		et := &v1.EmbeddedTask{}
+		for _, r := range results {
+			et.Results = append(et.Results, v1.TaskResult{Name: r})
+		}
+		return et
+	}
+	ps := &v1.PipelineSpec{
+		Tasks: []v1.PipelineTask{{
+			Name:     "a",
+			TaskSpec: embedded("by-task", "by-pipeline-result", "unused"),
+		}, {
+			Name:    "b",
+			TaskRef: &v1.TaskRef{Name: "b"},
+			Params:  v1.Params{{Name: "p", Value: *v1.NewStructuredValues("$(tasks.a.results.by-task)")}},
+		}},
+		Results: []v1.PipelineResult{{Name: "r", Value: *v1.NewStructuredValues("$(tasks.a.results.by-pipeline-result)")}},
+	}
+	want = []Finding{{Severity: SeverityInfo, Path: "tasks[0].taskSpec.results[2]", Message: `result "unused" of "a" is never used`}}
+	if d := cmp.Diff(want, unreferencedResults{}.LintPipeline(context.Background(), ps)); d != "" {
+		t.Errorf("LintPipeline %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMissingTimeouts(t *testing.T) {
+	ps := &v1.PipelineSpec{
+		Tasks: []v1.PipelineTask{{
+			Name:    "with-timeout",
+			TaskRef: &v1.TaskRef{Name: "a"},
+			Timeout: &metav1.Duration{Duration: time.Hour},
+		}, {
+			Name:    "without-timeout",
+			TaskRef: &v1.TaskRef{Name: "b"},
+		}},
+	}
+	want := []Finding{{
+		Severity: SeverityInfo,
+		Path:     "tasks[1].timeout",
+		Message:  `"without-timeout" has no timeout and is only bounded by the timeout of the PipelineRun`,
+	}}
+	if d := cmp.Diff(want, missingTimeouts{}.LintPipeline(context.Background(), ps)); d != "" {
+		t.Errorf("LintPipeline %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMutableImages(t *testing.T) {
+	ts := &v1.TaskSpec{
+		StepTemplate: &v1.StepTemplate{Image: "alpine"},
+		Steps: []v1.Step{{
+			Image: pinnedImage,
+		}, {
+			Image: "$(params.image)",
+		}, {
+			Image: "golang:1.19",
+		}, {
+			// The image of the step template is used
+		}},
+		Sidecars: []v1.Sidecar{{Image: "docker:dind"}},
+	}
+	want := []Finding{{
+		Severity: SeverityWarning,
+		Path:     "stepTemplate.image",
+		Message:  `image "alpine" is not pinned by digest`,
+	}, {
+		Severity: SeverityWarning,
+		Path:     "steps[2].image",
+		Message:  `image "golang:1.19" is not pinned by digest`,
+	}, {
+		Severity: SeverityWarning,
+		Path:     "sidecars[0].image",
+		Message:  `image "docker:dind" is not pinned by digest`,
+	}}
+	if d := cmp.Diff(want, mutableImages{}.LintTask(context.Background(), ts)); d != "" {
+		t.Errorf("LintTask %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResultSize(t *testing.T) {
+	var manyResults []v1.TaskResult
+	for i := 0; i < 20; i++ {
+		manyResults = append(manyResults, v1.TaskResult{Name: fmt.Sprintf("r%d", i)})
+	}
+	for _, tc := range []struct {
+		name    string
+		results []v1.TaskResult
+		flags   map[string]string
+		want    []Finding
+	}{{
+		name:    "few string results",
+		results: []v1.TaskResult{{Name: "a"}, {Name: "b", Type: v1.ResultsTypeString}},
+	}, {
+		name:    "array and object results",
+		results: []v1.TaskResult{{Name: "a", Type: v1.ResultsTypeArray}, {Name: "b", Type: v1.ResultsTypeObject}},
+		want: []Finding{{
+			Severity: SeverityInfo,
+			Path:     "results[0]",
+			Message:  `array result "a" has no bounded size`,
+		}, {
+			Severity: SeverityInfo,
+			Path:     "results[1]",
+			Message:  `object result "b" has no bounded size`,
+		}},
+	}, {
+		name:    "many results",
+		results: manyResults,
+		want: []Finding{{
+			Severity: SeverityWarning,
+			Path:     "results",
+			Message:  "the 20 results share a termination message of 4096 bytes, leaving less than 256 bytes to each",
+		}},
+	}, {
+		name:    "many results with sidecar logs",
+		results: manyResults,
+		flags:   map[string]string{"results-from": config.ResultExtractionMethodSidecarLogs, "enable-api-fields": "alpha"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			featureFlags, err := config.NewFeatureFlagsFromMap(tc.flags)
+			if err != nil {
+				t.Fatalf("unexpected error parsing the feature flags: %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+			got := resultSize{}.LintTask(ctx, &v1.TaskSpec{Results: tc.results})
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("LintTask %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loglevel lets a run raise the verbosity of the logs written about it,
+// without changing the logging configuration of the controller.
+package loglevel
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// AnnotationKey is the annotation of a PipelineRun or a TaskRun holding the level
+// of the logs written by the controller about it, e.g. "debug". The annotations of
+// a PipelineRun are propagated to its TaskRuns, and those of a TaskRun to its pod.
+const AnnotationKey = "pipeline.tekton.dev/log-level"
+
+// FromObject returns the log level requested by the annotation of obj, and false if
+// the annotation isn't set or isn't a valid level.
+func FromObject(obj metav1.Object) (zapcore.Level, bool) {
+	value, ok := obj.GetAnnotations()[AnnotationKey]
+	if !ok {
+		return zapcore.InfoLevel, false
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return zapcore.InfoLevel, false
+	}
+	return level, true
+}
+
+// Debug returns true if obj requests debug logs, in which case the entrypoint of its
+// steps logs the details of their execution too.
+func Debug(obj metav1.Object) bool {
+	level, ok := FromObject(obj)
+	return ok && level == zapcore.DebugLevel
+}
+
+// WithLogger returns a context whose logger writes the entries of the level requested
+// by the annotation of obj, in addition to the ones the logger in ctx already writes.
+// The entries hold the requested level so that they can be told apart. The annotation
+// only raises the verbosity: a level less verbose than the one configured is ignored.
+func WithLogger(ctx context.Context, obj metav1.Object) context.Context {
+	logger := logging.FromContext(ctx)
+	if _, ok := obj.GetAnnotations()[AnnotationKey]; !ok {
+		return ctx
+	}
+	level, ok := FromObject(obj)
+	if !ok {
+		logger.Warnf("Ignoring the invalid value %q of the annotation %s", obj.GetAnnotations()[AnnotationKey], AnnotationKey)
+		return ctx
+	}
+	desugared := logger.Desugar().WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, level: level}
+	}))
+	return logging.WithLogger(ctx, desugared.Sugar().With(zap.String(AnnotationKey, level.String())))
+}
+
+// levelCore writes the entries of its level or above with the core it wraps, as well
+// as the entries the wrapped core enables.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level) || c.Core.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.level.Enabled(entry.Level) {
+		// The wrapped core writes the entry without checking its level again.
+		return checked.AddCore(entry, c)
+	}
+	return c.Core.Check(entry, checked)
+}
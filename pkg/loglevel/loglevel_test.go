@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loglevel_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/loglevel"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+func objectWithLevel(level string) metav1.Object {
+	return &metav1.ObjectMeta{Annotations: map[string]string{loglevel.AnnotationKey: level}}
+}
+
+func TestFromObject(t *testing.T) {
+	for _, tc := range []struct {
+		desc      string
+		obj       metav1.Object
+		wantLevel zapcore.Level
+		wantOK    bool
+		wantDebug bool
+	}{{
+		desc:      "no annotation",
+		obj:       &metav1.ObjectMeta{},
+		wantLevel: zapcore.InfoLevel,
+	}, {
+		desc:      "debug",
+		obj:       objectWithLevel("debug"),
+		wantLevel: zapcore.DebugLevel,
+		wantOK:    true,
+		wantDebug: true,
+	}, {
+		desc:      "upper case",
+		obj:       objectWithLevel(" DEBUG "),
+		wantLevel: zapcore.DebugLevel,
+		wantOK:    true,
+		wantDebug: true,
+	}, {
+		desc:      "warn",
+		obj:       objectWithLevel("warn"),
+		wantLevel: zapcore.WarnLevel,
+		wantOK:    true,
+	}, {
+		desc:      "invalid",
+		obj:       objectWithLevel("verbose"),
+		wantLevel: zapcore.InfoLevel,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			level, ok := loglevel.FromObject(tc.obj)
+			if level != tc.wantLevel || ok != tc.wantOK {
+				t.Errorf("FromObject() = %v, %t but wanted %v, %t", level, ok, tc.wantLevel, tc.wantOK)
+			}
+			if got := loglevel.Debug(tc.obj); got != tc.wantDebug {
+				t.Errorf("Debug() = %t but wanted %t", got, tc.wantDebug)
+			}
+		})
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		obj  metav1.Object
+		want []map[string]interface{}
+	}{{
+		desc: "no annotation",
+		obj:  &metav1.ObjectMeta{},
+		want: []map[string]interface{}{{"level": "info", "msg": "info", "knative.dev/key": "foo/bar"}},
+	}, {
+		desc: "debug",
+		obj:  objectWithLevel("debug"),
+		want: []map[string]interface{}{
+			{"level": "debug", "msg": "debug", "knative.dev/key": "foo/bar", loglevel.AnnotationKey: "debug"},
+			{"level": "info", "msg": "info", "knative.dev/key": "foo/bar", loglevel.AnnotationKey: "debug"},
+		},
+	}, {
+		desc: "less verbose than configured",
+		obj:  objectWithLevel("error"),
+		want: []map[string]interface{}{{"level": "info", "msg": "info", "knative.dev/key": "foo/bar", loglevel.AnnotationKey: "error"}},
+	}, {
+		desc: "invalid",
+		obj:  objectWithLevel("verbose"),
+		want: []map[string]interface{}{
+			{"level": "warn", "msg": `Ignoring the invalid value "verbose" of the annotation pipeline.tekton.dev/log-level`, "knative.dev/key": "foo/bar"},
+			{"level": "info", "msg": "info", "knative.dev/key": "foo/bar"},
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder})
+			core := zapcore.NewCore(encoder, zapcore.AddSync(&buf), zapcore.InfoLevel)
+			ctx := logging.WithLogger(context.Background(), zap.New(core).Sugar().With("knative.dev/key", "foo/bar"))
+
+			logger := logging.FromContext(loglevel.WithLogger(ctx, tc.obj))
+			logger.Debug("debug")
+			logger.Info("info")
+
+			got := []map[string]interface{}{}
+			decoder := json.NewDecoder(&buf)
+			for decoder.More() {
+				entry := map[string]interface{}{}
+				if err := decoder.Decode(&entry); err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, entry)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected log entries %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/cardinality"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
@@ -43,6 +44,7 @@ var (
 	pipelineTag    = tag.MustNewKey("pipeline")
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
+	reasonTag      = tag.MustNewKey("reason")
 
 	prDuration = stats.Float64(
 		"pipelinerun_duration_seconds",
@@ -59,11 +61,24 @@ var (
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
 	runningPRsCountView *view.View
+
+	runningPRsWaitingOnPipelineResolutionCount = stats.Float64("running_pipelineruns_waiting_on_pipeline_resolution_count",
+		"Number of pipelineruns executing currently that are waiting on resolution requests for their pipeline references.",
+		stats.UnitDimensionless)
+	runningPRsWaitingOnPipelineResolutionCountView *view.View
+
+	runningPRsWaitingOnTaskResolutionCount = stats.Float64("running_pipelineruns_waiting_on_task_resolution_count",
+		"Number of pipelineruns executing currently that are waiting on resolution requests for the task references of their taskrun children.",
+		stats.UnitDimensionless)
+	runningPRsWaitingOnTaskResolutionCountView *view.View
 )
 
 const (
 	// ReasonCancelled indicates that a PipelineRun was cancelled.
 	ReasonCancelled = "Cancelled"
+	// ReasonResolvingPipelineRef indicates that a PipelineRun is waiting for the
+	// resolution of its pipeline reference.
+	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
 )
 
 // Recorder holds keys for Tekton metrics
@@ -71,9 +86,17 @@ type Recorder struct {
 	mutex       sync.Mutex
 	initialized bool
 
-	insertTag func(pipeline,
+	insertTag func(limiter *cardinality.Limiter, pipeline,
 		pipelinerun string) []tag.Mutator
 
+	// namespaceLabel and reasonLabel are true if the views have the namespace and
+	// the reason tags
+	namespaceLabel bool
+	reasonLabel    bool
+
+	// limiter bounds the number of distinct values of the tags
+	limiter *cardinality.Limiter
+
 	ReportingPeriod time.Duration
 }
 
@@ -128,9 +151,24 @@ func viewRegister(cfg *config.Metrics) error {
 		return errors.New("invalid config for PipelinerunLevel: " + cfg.PipelinerunLevel)
 	}
 
+	if cfg.DisablePipelineLabel {
+		prunTag = []tag.Key{}
+		r.insertTag = nilInsertTag
+	}
+	var nsTag, reasonTags []tag.Key
+	r.namespaceLabel = !cfg.DisableNamespaceLabel
+	if r.namespaceLabel {
+		nsTag = []tag.Key{namespaceTag}
+	}
+	r.reasonLabel = cfg.ReasonLabel
+	if r.reasonLabel {
+		reasonTags = []tag.Key{reasonTag}
+	}
+	r.limiter = cardinality.NewLimiter(cfg.LabelCardinalityLimit)
+
 	distribution := view.Distribution(10, 30, 60, 300, 900, 1800, 3600, 5400, 10800, 21600, 43200, 86400)
 
-	if cfg.PipelinerunLevel == config.PipelinerunLevelAtPipelinerun {
+	if cfg.PipelinerunLevel == config.PipelinerunLevelAtPipelinerun && !cfg.DisablePipelineLabel {
 		distribution = view.LastValue()
 	} else {
 		switch cfg.DurationPipelinerunType {
@@ -146,30 +184,52 @@ func viewRegister(cfg *config.Metrics) error {
 		Description: prDuration.Description(),
 		Measure:     prDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, prunTag...),
+		TagKeys:     tagKeys([]tag.Key{statusTag}, nsTag, reasonTags, prunTag),
 	}
 
 	prCountView = &view.View{
 		Description: prCount.Description(),
 		Measure:     prCount,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{statusTag},
+		TagKeys:     tagKeys([]tag.Key{statusTag}, reasonTags),
 	}
 	runningPRsCountView = &view.View{
 		Description: runningPRsCount.Description(),
 		Measure:     runningPRsCount,
 		Aggregation: view.LastValue(),
 	}
+	runningPRsWaitingOnPipelineResolutionCountView = &view.View{
+		Description: runningPRsWaitingOnPipelineResolutionCount.Description(),
+		Measure:     runningPRsWaitingOnPipelineResolutionCount,
+		Aggregation: view.LastValue(),
+	}
+	runningPRsWaitingOnTaskResolutionCountView = &view.View{
+		Description: runningPRsWaitingOnTaskResolutionCount.Description(),
+		Measure:     runningPRsWaitingOnTaskResolutionCount,
+		Aggregation: view.LastValue(),
+	}
 
 	return view.Register(
 		prDurationView,
 		prCountView,
 		runningPRsCountView,
+		runningPRsWaitingOnPipelineResolutionCountView,
+		runningPRsWaitingOnTaskResolutionCountView,
 	)
 }
 
+// tagKeys returns the tag keys of the groups, in a new slice.
+func tagKeys(groups ...[]tag.Key) []tag.Key {
+	keys := []tag.Key{}
+	for _, g := range groups {
+		keys = append(keys, g...)
+	}
+	return keys
+}
+
 func viewUnregister() {
-	view.Unregister(prDurationView, prCountView, runningPRsCountView)
+	view.Unregister(prDurationView, prCountView, runningPRsCountView,
+		runningPRsWaitingOnPipelineResolutionCountView, runningPRsWaitingOnTaskResolutionCountView)
 }
 
 // MetricsOnStore returns a function that checks if metrics are configured for a config.Store, and registers it if so
@@ -192,16 +252,16 @@ func MetricsOnStore(logger *zap.SugaredLogger) func(name string,
 	}
 }
 
-func pipelinerunInsertTag(pipeline, pipelinerun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(pipelineTag, pipeline),
-		tag.Insert(pipelinerunTag, pipelinerun)}
+func pipelinerunInsertTag(limiter *cardinality.Limiter, pipeline, pipelinerun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(pipelineTag, pipeline),
+		limiter.Insert(pipelinerunTag, pipelinerun)}
 }
 
-func pipelineInsertTag(pipeline, pipelinerun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(pipelineTag, pipeline)}
+func pipelineInsertTag(limiter *cardinality.Limiter, pipeline, pipelinerun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(pipelineTag, pipeline)}
 }
 
-func nilInsertTag(task, taskrun string) []tag.Mutator {
+func nilInsertTag(limiter *cardinality.Limiter, task, taskrun string) []tag.Mutator {
 	return []tag.Mutator{}
 }
 
@@ -242,10 +302,16 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun, beforeCondition *ap
 	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Name != "" {
 		pipelineName = pr.Spec.PipelineRef.Name
 	}
+	tags := []tag.Mutator{tag.Insert(statusTag, status)}
+	if r.namespaceLabel {
+		tags = append(tags, r.limiter.Insert(namespaceTag, pr.Namespace))
+	}
+	if r.reasonLabel && afterCondition != nil {
+		tags = append(tags, r.limiter.Insert(reasonTag, afterCondition.Reason))
+	}
 	ctx, err := tag.New(
 		context.Background(),
-		append([]tag.Mutator{tag.Insert(namespaceTag, pr.Namespace),
-			tag.Insert(statusTag, status)}, r.insertTag(pipelineName, pr.Name)...)...)
+		append(tags, r.insertTag(r.limiter, pipelineName, pr.Name)...)...)
 	if err != nil {
 		return err
 	}
@@ -256,7 +322,8 @@ func (r *Recorder) DurationAndCount(pr *v1beta1.PipelineRun, beforeCondition *ap
 	return nil
 }
 
-// RunningPipelineRuns logs the number of PipelineRuns running right now
+// RunningPipelineRuns logs the number of PipelineRuns running right now, and the
+// number of them waiting on the resolution of their Pipeline or of their Tasks
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 	r.mutex.Lock()
@@ -271,10 +338,19 @@ func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 		return fmt.Errorf("failed to list pipelineruns while generating metrics : %w", err)
 	}
 
-	var runningPRs int
+	var runningPRs, waitingOnPipelineResolution, waitingOnTaskResolution int
 	for _, pr := range prs {
-		if !pr.IsDone() {
-			runningPRs++
+		if pr.IsDone() {
+			continue
+		}
+		runningPRs++
+		if cond := pr.Status.GetCondition(apis.ConditionSucceeded); cond != nil && cond.Status == corev1.ConditionUnknown {
+			switch cond.Reason {
+			case ReasonResolvingPipelineRef:
+				waitingOnPipelineResolution++
+			case v1beta1.TaskRunReasonResolvingTaskRef:
+				waitingOnTaskResolution++
+			}
 		}
 	}
 
@@ -283,6 +359,8 @@ func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 		return err
 	}
 	metrics.Record(ctx, runningPRsCount.M(float64(runningPRs)))
+	metrics.Record(ctx, runningPRsWaitingOnPipelineResolutionCount.M(float64(waitingOnPipelineResolution)))
+	metrics.Record(ctx, runningPRsWaitingOnTaskResolutionCount.M(float64(waitingOnTaskResolution)))
 
 	return nil
 }
@@ -28,6 +28,7 @@ import (
 	fakepipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun/fake"
 	"github.com/tektoncd/pipeline/pkg/names"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -336,7 +337,7 @@ func TestRecordPipelineRunDurationCount(t *testing.T) {
 func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	unregisterMetrics()
 
-	newPipelineRun := func(status corev1.ConditionStatus) *v1beta1.PipelineRun {
+	newPipelineRun := func(status corev1.ConditionStatus, reason string) *v1beta1.PipelineRun {
 		return &v1beta1.PipelineRun{
 			ObjectMeta: metav1.ObjectMeta{Name: names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("pipelinerun-")},
 			Status: v1beta1.PipelineRunStatus{
@@ -344,6 +345,7 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 					Conditions: duckv1.Conditions{{
 						Type:   apis.ConditionSucceeded,
 						Status: status,
+						Reason: reason,
 					}},
 				},
 			},
@@ -354,9 +356,12 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	informer := fakepipelineruninformer.Get(ctx)
 	// Add N randomly-named PipelineRuns with differently-succeeded statuses.
 	for _, tr := range []*v1beta1.PipelineRun{
-		newPipelineRun(corev1.ConditionTrue),
-		newPipelineRun(corev1.ConditionUnknown),
-		newPipelineRun(corev1.ConditionFalse),
+		newPipelineRun(corev1.ConditionTrue, ""),
+		newPipelineRun(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
+		newPipelineRun(corev1.ConditionUnknown, ReasonResolvingPipelineRef),
+		newPipelineRun(corev1.ConditionUnknown, v1beta1.TaskRunReasonResolvingTaskRef),
+		newPipelineRun(corev1.ConditionUnknown, v1beta1.TaskRunReasonResolvingTaskRef),
+		newPipelineRun(corev1.ConditionFalse, ""),
 	} {
 		if err := informer.Informer().GetIndexer().Add(tr); err != nil {
 			t.Fatalf("Adding TaskRun to informer: %v", err)
@@ -372,11 +377,114 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 	if err := metrics.RunningPipelineRuns(informer.Lister()); err != nil {
 		t.Errorf("RunningPipelineRuns: %v", err)
 	}
-	metricstest.CheckLastValueData(t, "running_pipelineruns_count", map[string]string{}, 1)
+	metricstest.CheckLastValueData(t, "running_pipelineruns_count", map[string]string{}, 4)
+	metricstest.CheckLastValueData(t, "running_pipelineruns_waiting_on_pipeline_resolution_count", map[string]string{}, 1)
+	metricstest.CheckLastValueData(t, "running_pipelineruns_waiting_on_task_resolution_count", map[string]string{}, 2)
+}
+
+func TestRecordPipelineRunDurationCountLabels(t *testing.T) {
+	pr := func(name, pipeline string) *v1beta1.PipelineRun {
+		return &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: pipeline},
+			},
+			Status: v1beta1.PipelineRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: "PipelineRunTimeout",
+					}},
+				},
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name                 string
+		cfg                  *config.Metrics
+		expectedDurationTags map[string]string
+		expectedCountTags    map[string]string
+	}{{
+		name: "reason label without namespace label",
+		cfg: &config.Metrics{
+			PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
+			DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
+			DisableNamespaceLabel:   true,
+			ReasonLabel:             true,
+		},
+		expectedDurationTags: map[string]string{"pipeline": "pipeline-2", "status": "failed", "reason": "PipelineRunTimeout"},
+		expectedCountTags:    map[string]string{"status": "failed", "reason": "PipelineRunTimeout"},
+	}, {
+		name: "pipeline label disabled at pipelinerun level",
+		cfg: &config.Metrics{
+			PipelinerunLevel:        config.PipelinerunLevelAtPipelinerun,
+			DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
+			DisablePipelineLabel:    true,
+		},
+		expectedDurationTags: map[string]string{"namespace": "ns", "status": "failed"},
+		expectedCountTags:    map[string]string{"status": "failed"},
+	}, {
+		name: "pipeline values beyond the cardinality limit",
+		cfg: &config.Metrics{
+			PipelinerunLevel:        config.PipelinerunLevelAtPipeline,
+			DurationPipelinerunType: config.DurationPipelinerunTypeLastValue,
+			LabelCardinalityLimit:   1,
+		},
+		expectedDurationTags: map[string]string{"pipeline": "other", "namespace": "ns", "status": "failed"},
+		expectedCountTags:    map[string]string{"status": "failed"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			ctx := config.ToContext(context.Background(), &config.Config{Metrics: tc.cfg})
+			metrics, err := NewRecorder(ctx)
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			for _, pr := range []*v1beta1.PipelineRun{pr("pipelinerun-1", "pipeline-1"), pr("pipelinerun-2", "pipeline-2")} {
+				if err := metrics.DurationAndCount(pr, nil); err != nil {
+					t.Errorf("DurationAndCount: %v", err)
+				}
+			}
+			checkLastValueRow(t, "pipelinerun_duration_seconds", tc.expectedDurationTags, 60)
+			metricstest.CheckCountData(t, "pipelinerun_count", tc.expectedCountTags, 2)
+		})
+	}
+}
+
+// checkLastValueRow checks the value of the row of a LastValue view with the tags, unlike
+// metricstest.CheckLastValueData which checks its last row, in no particular order.
+func checkLastValueRow(t *testing.T, name string, wantTags map[string]string, wantValue float64) {
+	t.Helper()
+	rows, err := view.RetrieveData(name)
+	if err != nil {
+		t.Fatalf("RetrieveData(%s): %v", name, err)
+	}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tg := range row.Tags {
+			tags[tg.Key.Name()] = tg.Value
+		}
+		if !reflect.DeepEqual(wantTags, tags) {
+			continue
+		}
+		if got := row.Data.(*view.LastValueData).Value; got != wantValue {
+			t.Errorf("expected the value of %s with tags %v to be %v, got %v", name, wantTags, wantValue, got)
+		}
+		return
+	}
+	t.Errorf("expected a row of %s with tags %v, got %v", name, wantTags, rows)
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count",
+		"running_pipelineruns_waiting_on_pipeline_resolution_count", "running_pipelineruns_waiting_on_task_resolution_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
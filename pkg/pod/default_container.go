@@ -0,0 +1,100 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// DefaultContainerAnnotation is the annotation used by kubectl to select
+	// the container of a Pod when none is specified, e.g. by `kubectl logs`.
+	// The TaskRun Pod is annotated with the step container being executed.
+	DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
+	// StepOrderAnnotation is the annotation listing the step containers of a
+	// TaskRun Pod, comma separated, in the order they are executed.
+	StepOrderAnnotation = "pipeline.tekton.dev/step-order"
+)
+
+// stepOrderAnnotationValue returns the value of the StepOrderAnnotation for the
+// given step containers.
+func stepOrderAnnotationValue(stepContainers []corev1.Container) string {
+	names := make([]string, 0, len(stepContainers))
+	for _, c := range stepContainers {
+		names = append(names, c.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// CurrentStepContainer returns the name of the step container of the Pod that
+// is being executed: the first step that has not terminated yet. Once all
+// steps have terminated, it returns the first step that failed, or the last
+// step if all of them succeeded. It returns "" if the Pod has no
+// StepOrderAnnotation.
+func CurrentStepContainer(pod *corev1.Pod) string {
+	order := pod.Annotations[StepOrderAnnotation]
+	if order == "" {
+		return ""
+	}
+	statuses := make(map[string]corev1.ContainerStatus, len(pod.Status.ContainerStatuses))
+	for _, s := range pod.Status.ContainerStatuses {
+		statuses[s.Name] = s
+	}
+	steps := strings.Split(order, ",")
+	for _, step := range steps {
+		s, ok := statuses[step]
+		if !ok || s.State.Terminated == nil {
+			return step
+		}
+		if s.State.Terminated.ExitCode != 0 {
+			return step
+		}
+	}
+	return steps[len(steps)-1]
+}
+
+// UpdateDefaultContainer updates the DefaultContainerAnnotation of the Pod to
+// the step container being executed, unless the TaskRun specifies its own
+// default container.
+func UpdateDefaultContainer(ctx context.Context, kubeclient kubernetes.Interface, tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	if _, ok := tr.Annotations[DefaultContainerAnnotation]; ok {
+		return nil
+	}
+	current := CurrentStepContainer(pod)
+	if current == "" || pod.Annotations[DefaultContainerAnnotation] == current {
+		return nil
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{DefaultContainerAnnotation: current},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = kubeclient.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func stepStatus(name string, state corev1.ContainerState) corev1.ContainerStatus {
+	return corev1.ContainerStatus{Name: name, State: state}
+}
+
+var (
+	stepRunning   = corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	stepWaiting   = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}
+	stepSucceeded = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+	stepFailed    = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}}
+)
+
+func TestCurrentStepContainer(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		order    string
+		statuses []corev1.ContainerStatus
+		want     string
+	}{{
+		desc: "no step order annotation",
+		statuses: []corev1.ContainerStatus{
+			stepStatus("step-a", stepRunning),
+		},
+		want: "",
+	}, {
+		desc:  "no container statuses",
+		order: "step-a,step-b",
+		want:  "step-a",
+	}, {
+		desc:  "first step running",
+		order: "step-a,step-b,step-c",
+		statuses: []corev1.ContainerStatus{
+			stepStatus("step-a", stepRunning),
+			stepStatus("step-b", stepWaiting),
+			stepStatus("step-c", stepWaiting),
+		},
+		want: "step-a",
+	}, {
+		desc:  "second step running",
+		order: "step-a,step-b,step-c",
+		statuses: []corev1.ContainerStatus{
+			stepStatus("step-c", stepRunning),
+			stepStatus("step-b", stepRunning),
+			stepStatus("step-a", stepSucceeded),
+			stepStatus("sidecar-x", stepRunning),
+		},
+		want: "step-b",
+	}, {
+		desc:  "step failed",
+		order: "step-a,step-b,step-c",
+		statuses: []corev1.ContainerStatus{
+			stepStatus("step-a", stepSucceeded),
+			stepStatus("step-b", stepFailed),
+			stepStatus("step-c", stepSucceeded),
+		},
+		want: "step-b",
+	}, {
+		desc:  "all steps succeeded",
+		order: "step-a,step-b,step-c",
+		statuses: []corev1.ContainerStatus{
+			stepStatus("step-a", stepSucceeded),
+			stepStatus("step-b", stepSucceeded),
+			stepStatus("step-c", stepSucceeded),
+		},
+		want: "step-c",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Status:     corev1.PodStatus{ContainerStatuses: tc.statuses},
+			}
+			if tc.order != "" {
+				pod.Annotations[StepOrderAnnotation] = tc.order
+			}
+			if got := CurrentStepContainer(pod); got != tc.want {
+				t.Errorf("CurrentStepContainer() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateDefaultContainer(t *testing.T) {
+	for _, tc := range []struct {
+		desc          string
+		trAnnotations map[string]string
+		want          string
+	}{{
+		desc: "default container follows the running step",
+		want: "step-b",
+	}, {
+		desc:          "default container set on the taskrun",
+		trAnnotations: map[string]string{DefaultContainerAnnotation: "step-a"},
+		want:          "step-a",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pod",
+					Namespace: "default",
+					Annotations: map[string]string{
+						StepOrderAnnotation:        "step-a,step-b",
+						DefaultContainerAnnotation: "step-a",
+					},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{
+						stepStatus("step-a", stepSucceeded),
+						stepStatus("step-b", stepRunning),
+					},
+				},
+			}
+			kubeclient := fakek8s.NewSimpleClientset(pod)
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.trAnnotations}}
+			if err := UpdateDefaultContainer(ctx, kubeclient, tr, pod); err != nil {
+				t.Fatalf("UpdateDefaultContainer: %v", err)
+			}
+			got, err := kubeclient.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("error getting pod: %v", err)
+			}
+			if got.Annotations[DefaultContainerAnnotation] != tc.want {
+				t.Errorf("expected default container %q, got %q", tc.want, got.Annotations[DefaultContainerAnnotation])
+			}
+			if got.Annotations[StepOrderAnnotation] != "step-a,step-b" {
+				t.Errorf("expected step order annotation to be preserved, got %q", got.Annotations[StepOrderAnnotation])
+			}
+		})
+	}
+}
@@ -50,6 +50,10 @@ const (
 	// for more details.
 	RunDir = "/tekton/run"
 
+	// StepLogFile is the name of the file in the run directory of a step which
+	// its stdout and stderr are copied to when the step logs are forwarded.
+	StepLogFile = "log"
+
 	downwardVolumeName     = "tekton-internal-downward"
 	downwardMountPoint     = "/tekton/downward"
 	terminationPath        = "/tekton/termination"
@@ -160,6 +164,12 @@ func orderContainers(commonExtraEntrypointArgs []string, steps []corev1.Containe
 				}
 			}
 			argsForEntrypoint = append(argsForEntrypoint, resultArgument(steps, taskSpec.Results)...)
+			if i == 0 {
+				argsForEntrypoint = append(argsForEntrypoint, workspaceDigestArgument("-start_workspace_digest", taskSpec.Workspaces)...)
+			}
+			if i == len(steps)-1 {
+				argsForEntrypoint = append(argsForEntrypoint, workspaceDigestArgument("-end_workspace_digest", taskSpec.Workspaces)...)
+			}
 		}
 
 		if breakpointConfig != nil && len(breakpointConfig.Breakpoint) > 0 {
@@ -209,6 +219,31 @@ func collectResultsName(results []v1beta1.TaskResult) string {
 	return strings.Join(resultNames, ",")
 }
 
+// fileResultNames returns the names of the results of type file.
+func fileResultNames(results []v1beta1.TaskResult) []string {
+	var names []string
+	for _, r := range results {
+		if r.Type == v1beta1.ResultsTypeFile {
+			names = append(names, r.Name)
+		}
+	}
+	return names
+}
+
+// workspaceDigestArgument returns the entrypoint flag, repeated with the name and
+// mount path of each workspace that requests a digest of its contents. The flag
+// is repeated rather than listing the workspaces, so that mount paths can
+// contain any character.
+func workspaceDigestArgument(flag string, workspaces []v1beta1.WorkspaceDeclaration) []string {
+	var args []string
+	for _, w := range workspaces {
+		if w.Digest {
+			args = append(args, flag, w.Name+"="+w.GetMountPath())
+		}
+	}
+	return args
+}
+
 var replaceReadyPatchBytes []byte
 
 func init() {
@@ -240,7 +275,8 @@ func UpdateReady(ctx context.Context, kubeclient kubernetes.Interface, pod corev
 }
 
 // StopSidecars updates sidecar containers in the Pod to a nop image, which
-// exits successfully immediately.
+// exits successfully immediately. The sidecars run with the entrypoint binary
+// are instead requested to stop gracefully by setting the stopSidecarsAnnotation.
 func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.Interface, namespace, name string) (*corev1.Pod, error) {
 	newPod, err := kubeclient.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
 	if k8serrors.IsNotFound(err) {
@@ -259,13 +295,28 @@ func StopSidecars(ctx context.Context, nopImage string, kubeclient kubernetes.In
 			if config.FromContextOrDefaults(ctx).FeatureFlags.ResultExtractionMethod == config.ResultExtractionMethodSidecarLogs && s.Name == pipeline.ReservedResultsSidecarContainerName {
 				continue
 			}
+			// The log forwarder sidecar exits once it has shipped the logs of all the steps.
+			if s.Name == pipeline.ReservedLogForwarderSidecarContainerName {
+				continue
+			}
 			// Stop any running container that isn't a step.
 			// An injected sidecar container might not have the
 			// "sidecar-" prefix, so we can't just look for that
 			// prefix.
 			if !IsContainerStep(s.Name) && s.State.Running != nil {
 				for j, c := range newPod.Spec.Containers {
-					if c.Name == s.Name && c.Image != nopImage {
+					if c.Name != s.Name {
+						continue
+					}
+					if stopsOnRequest(c) {
+						if newPod.Annotations[stopSidecarsAnnotation] != stopSidecarsAnnotationValue {
+							updated = true
+							if newPod.Annotations == nil {
+								newPod.Annotations = map[string]string{}
+							}
+							newPod.Annotations[stopSidecarsAnnotation] = stopSidecarsAnnotationValue
+						}
+					} else if c.Image != nopImage {
 						updated = true
 						newPod.Spec.Containers[j].Image = nopImage
 					}
@@ -297,9 +348,9 @@ func IsSidecarStatusRunning(tr *v1beta1.TaskRun) bool {
 // represents a step.
 func IsContainerStep(name string) bool { return strings.HasPrefix(name, stepPrefix) }
 
-// isContainerSidecar returns true if the container name indicates that it
+// IsContainerSidecar returns true if the container name indicates that it
 // represents a sidecar.
-func isContainerSidecar(name string) bool { return strings.HasPrefix(name, sidecarPrefix) }
+func IsContainerSidecar(name string) bool { return strings.HasPrefix(name, sidecarPrefix) }
 
 // trimStepPrefix returns the container name, stripped of its step prefix.
 func trimStepPrefix(name string) string { return strings.TrimPrefix(name, stepPrefix) }
@@ -370,6 +370,65 @@ func TestEntryPointResultsSingleStep(t *testing.T) {
 		t.Errorf("Diff %s", diff.PrintWantGot(d))
 	}
 }
+func TestEntryPointWorkspaceDigests(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Workspaces: []v1beta1.WorkspaceDeclaration{{
+			Name:   "source",
+			Digest: true,
+		}, {
+			Name: "cache",
+		}, {
+			Name:      "output",
+			MountPath: "/out,v=1",
+			Digest:    true,
+		}},
+	}
+
+	steps := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{"cmd"},
+	}, {
+		Image:   "step-2",
+		Command: []string{"cmd"},
+	}}
+	want := []corev1.Container{{
+		Image:   "step-1",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-start_workspace_digest", "source=/workspace/source",
+			"-start_workspace_digest", "output=/out,v=1",
+			"-entrypoint", "cmd", "--",
+		},
+		VolumeMounts:           []corev1.VolumeMount{downwardMount},
+		TerminationMessagePath: "/tekton/termination",
+	}, {
+		Image:   "step-2",
+		Command: []string{entrypointBinary},
+		Args: []string{
+			"-wait_file", "/tekton/run/0/out",
+			"-post_file", "/tekton/run/1/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/1/status",
+			"-end_workspace_digest", "source=/workspace/source",
+			"-end_workspace_digest", "output=/out,v=1",
+			"-entrypoint", "cmd", "--",
+		},
+		TerminationMessagePath: "/tekton/termination",
+	}}
+	got, err := orderContainers([]string{}, steps, &taskSpec, nil, true)
+	if err != nil {
+		t.Fatalf("orderContainers: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestEntryPointSingleResultsSingleStep(t *testing.T) {
 	taskSpec := v1beta1.TaskSpec{
 		Results: []v1beta1.TaskResult{{
@@ -731,6 +790,12 @@ func TestStopSidecars(t *testing.T) {
 		Name:  pipeline.ReservedResultsSidecarContainerName,
 		Image: nopImage,
 	}
+	// This is a container that is added by the controller to ship the logs of the steps.
+	// It exits on its own once it has shipped all the logs.
+	logForwarderSidecar := corev1.Container{
+		Name:  pipeline.ReservedLogForwarderSidecarContainerName,
+		Image: "original-injected-image",
+	}
 
 	for _, c := range []struct {
 		desc                   string
@@ -813,6 +878,31 @@ func TestStopSidecars(t *testing.T) {
 			},
 		},
 		wantContainers: []corev1.Container{stepContainer, stoppedSidecarContainer, stoppedResultsSidecar},
+	}, {
+		desc: "Log forwarder Sidecar should not be stopped",
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-pod",
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{stepContainer, sidecarContainer, logForwarderSidecar},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					// Step state doesn't matter.
+				}, {
+					Name: sidecarContainer.Name,
+					// Sidecar is running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}, {
+					Name: logForwarderSidecar.Name,
+					// Log forwarder sidecar is running.
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.NewTime(time.Now())}},
+				}},
+			},
+		},
+		wantContainers: []corev1.Container{stepContainer, stoppedSidecarContainer, logForwarderSidecar},
 	}, {
 		desc: "Pending Pod should not be updated",
 		pod: corev1.Pod{
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// pinImages resolves the images of the containers referenced by tag to their digests,
+// looking them up in the image registry with the credentials of the service account,
+// so that the pod runs the images the tags referenced when it was created.
+func pinImages(ctx context.Context, cache EntrypointCache, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, containers []corev1.Container) error {
+	for i, c := range containers {
+		ref, err := name.ParseReference(c.Image, name.WeakValidation)
+		if err != nil {
+			return err
+		}
+		if _, ok := ref.(name.Digest); ok {
+			continue
+		}
+		id, err := cache.get(ctx, ref, namespace, serviceAccountName, imagePullSecrets, len(c.Args) > 0)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the digest of the image %q of container %q: %w", c.Image, c.Name, err)
+		}
+		containers[i].Image = ref.Context().Digest(id.digest.String()).String()
+	}
+	return nil
+}
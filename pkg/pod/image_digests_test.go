@@ -0,0 +1,77 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPinImages(t *testing.T) {
+	img, err := random.Image(1, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	dig, err := img.Digest()
+	if err != nil {
+		t.Fatalf("image.Digest: %v", err)
+	}
+	cache := fakeCache{
+		"gcr.io/my/image:latest":              &data{id: &imageData{digest: dig}},
+		"index.docker.io/library/alpine:3.18": &data{id: &imageData{digest: dig}},
+	}
+
+	containers := []corev1.Container{{
+		Name:  "step-tag",
+		Image: "gcr.io/my/image",
+	}, {
+		Name:  "step-docker-hub",
+		Image: "alpine:3.18",
+	}, {
+		// Images referenced by digest are not looked up.
+		Name:  "step-digest",
+		Image: "reg.io/pinned/image@" + dig.String(),
+	}}
+	if err := pinImages(context.Background(), cache, "namespace", "serviceAccountName", nil, containers); err != nil {
+		t.Fatalf("pinImages: %v", err)
+	}
+	want := []corev1.Container{{
+		Name:  "step-tag",
+		Image: "gcr.io/my/image@" + dig.String(),
+	}, {
+		Name:  "step-docker-hub",
+		Image: "index.docker.io/library/alpine@" + dig.String(),
+	}, {
+		Name:  "step-digest",
+		Image: "reg.io/pinned/image@" + dig.String(),
+	}}
+	if d := cmp.Diff(want, containers); d != "" {
+		t.Errorf("pinImages %s", diff.PrintWantGot(d))
+	}
+
+	if err := pinImages(context.Background(), cache, "namespace", "serviceAccountName", nil, []corev1.Container{{
+		Name:  "step-unknown",
+		Image: "gcr.io/my/unknown",
+	}}); err == nil {
+		t.Error("expected pinImages to fail resolving the digest of an unknown image")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// policyImagePullSecrets returns the image pull secrets with the ones of the policies of
+// config-image-pull-secrets selecting the TaskRun appended, skipping the secrets already
+// in the list. The namespace of the TaskRun is only read when a policy selects namespaces.
+func policyImagePullSecrets(ctx context.Context, kubeclient kubernetes.Interface, taskRun *v1beta1.TaskRun, imagePullSecrets []corev1.LocalObjectReference) ([]corev1.LocalObjectReference, error) {
+	policies := config.FromContextOrDefaults(ctx).ImagePullSecrets
+	if policies == nil || len(policies.Policies) == 0 {
+		return imagePullSecrets, nil
+	}
+
+	var namespaceLabels map[string]string
+	if policies.SelectsNamespaces() {
+		ns, err := kubeclient.CoreV1().Namespaces().Get(ctx, taskRun.Namespace, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the namespace %q to select image pull secrets policies: %w", taskRun.Namespace, err)
+		}
+		namespaceLabels = ns.Labels
+	}
+
+	// Copy the secrets so that the pod template of the TaskRun is not modified.
+	secrets := append([]corev1.LocalObjectReference{}, imagePullSecrets...)
+	seen := map[string]bool{}
+	for _, s := range secrets {
+		seen[s.Name] = true
+	}
+	add := func(refs []corev1.LocalObjectReference) {
+		for _, s := range refs {
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				secrets = append(secrets, s)
+			}
+		}
+	}
+	for _, p := range policies.Policies {
+		if !p.Selects(namespaceLabels, taskRun.Labels) {
+			continue
+		}
+		add(p.ImagePullSecrets)
+		if p.ServiceAccount != "" {
+			sa, err := kubeclient.CoreV1().ServiceAccounts(taskRun.Namespace).Get(ctx, p.ServiceAccount, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the service account %q of image pull secrets policy %q: %w", p.ServiceAccount, p.Name, err)
+			}
+			add(sa.ImagePullSecrets)
+		}
+	}
+	return secrets, nil
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// applyMemoryProfiles tunes the memory resources of the step containers whose steps
+// have a memory profile. With the buildHeavy profile:
+//   - a step with neither a memory request nor a memory limit gets defaultMemory as
+//     both, if set;
+//   - the memory request of a step with a memory limit is raised to the limit.
+//
+// The memory the step may use is then reserved for it on its node. The kubelet
+// derives a lower OOM score adjustment from the larger request, the step isn't
+// evicted first when the node is under memory pressure, and where the memory QoS
+// of cgroup v2 is enabled, the requested memory is protected from reclaim and the
+// step isn't throttled before reaching its limit.
+func applyMemoryProfiles(steps []v1beta1.Step, stepContainers []corev1.Container, defaultMemory *resource.Quantity) {
+	for i, s := range steps {
+		if s.MemoryProfile != v1beta1.BuildHeavyMemoryProfile {
+			continue
+		}
+		c := &stepContainers[i]
+		// The resources may be shared with the Step, copy them before updating them.
+		requests, limits := c.Resources.Requests.DeepCopy(), c.Resources.Limits.DeepCopy()
+		limit, hasLimit := limits[corev1.ResourceMemory]
+		_, hasRequest := requests[corev1.ResourceMemory]
+		switch {
+		case hasLimit:
+			if requests == nil {
+				requests = corev1.ResourceList{}
+			}
+			requests[corev1.ResourceMemory] = limit.DeepCopy()
+		case !hasRequest && defaultMemory != nil:
+			if requests == nil {
+				requests = corev1.ResourceList{}
+			}
+			if limits == nil {
+				limits = corev1.ResourceList{}
+			}
+			requests[corev1.ResourceMemory] = defaultMemory.DeepCopy()
+			limits[corev1.ResourceMemory] = defaultMemory.DeepCopy()
+		default:
+			continue
+		}
+		c.Resources.Requests, c.Resources.Limits = requests, limits
+	}
+}
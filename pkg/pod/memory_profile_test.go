@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestApplyMemoryProfiles(t *testing.T) {
+	defaultMemory := resource.MustParse("4Gi")
+	for _, tc := range []struct {
+		desc          string
+		profile       v1beta1.MemoryProfileType
+		resources     corev1.ResourceRequirements
+		defaultMemory *resource.Quantity
+		want          corev1.ResourceRequirements
+	}{{
+		desc: "no profile",
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+		defaultMemory: &defaultMemory,
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}, {
+		desc:    "request raised to the limit",
+		profile: v1beta1.BuildHeavyMemoryProfile,
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi"), corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+		defaultMemory: &defaultMemory,
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi"), corev1.ResourceCPU: resource.MustParse("1")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}, {
+		desc:    "limit only",
+		profile: v1beta1.BuildHeavyMemoryProfile,
+		resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}, {
+		desc:          "default memory",
+		profile:       v1beta1.BuildHeavyMemoryProfile,
+		defaultMemory: &defaultMemory,
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		},
+	}, {
+		desc:    "request only",
+		profile: v1beta1.BuildHeavyMemoryProfile,
+		resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		defaultMemory: &defaultMemory,
+		want: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+	}, {
+		desc:    "no resources nor default memory",
+		profile: v1beta1.BuildHeavyMemoryProfile,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			original := *tc.resources.DeepCopy()
+			steps := []v1beta1.Step{{Name: "build", MemoryProfile: tc.profile, Resources: tc.resources}}
+			stepContainers := []corev1.Container{{Name: "step-build", Resources: tc.resources}}
+
+			applyMemoryProfiles(steps, stepContainers, tc.defaultMemory)
+			if d := cmp.Diff(tc.want, stepContainers[0].Resources, resourceQuantityCmp); d != "" {
+				t.Errorf("resources %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(original, steps[0].Resources, resourceQuantityCmp); d != "" {
+				t.Errorf("the resources of the step were modified %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuild_MemoryProfile(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:          "compile",
+			Image:         "image",
+			Command:       []string{"cmd"}, // avoid entrypoint lookup.
+			MemoryProfile: v1beta1.BuildHeavyMemoryProfile,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("6Gi")},
+			},
+		}, {
+			Name:          "link",
+			Image:         "image",
+			Command:       []string{"cmd"},
+			MemoryProfile: v1beta1.BuildHeavyMemoryProfile,
+		}, {
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": "alpha"},
+	})
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"default-build-heavy-step-memory": "4Gi"},
+	})
+	builder := Builder{
+		Images:     images,
+		KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+	}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	want := []corev1.ResourceRequirements{{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("6Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("6Gi")},
+	}, {
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+	}, {}}
+	for i, c := range got.Spec.Containers {
+		if d := cmp.Diff(want[i], c.Resources, resourceQuantityCmp); d != "" {
+			t.Errorf("resources of %s %s", c.Name, diff.PrintWantGot(d))
+		}
+	}
+}
@@ -18,6 +18,7 @@ package pod
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math"
@@ -25,11 +26,13 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/tektoncd/pipeline/internal/logforwarder"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources/tasklevel"
+	"github.com/tektoncd/pipeline/pkg/loglevel"
 	"github.com/tektoncd/pipeline/pkg/names"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	corev1 "k8s.io/api/core/v1"
@@ -138,7 +141,39 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	commonExtraEntrypointArgs := []string{}
 	// Entrypoint arg to enable or disable spire
 	if config.IsSpireEnabled(ctx) {
-		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-enable_spire")
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-enable_spire", "-spire_socket_path", config.FromContextOrDefaults(ctx).SpireConfig.SocketPath)
+	}
+	// Entrypoint arg to record the executed commands
+	if featureFlags.EnableExecutionLog {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-execution_log")
+	}
+	// Entrypoint arg to emit the progress markers of the steps
+	if featureFlags.EnableStepProgress {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-step_progress")
+	}
+	// Entrypoint arg to report the reason and the message written by the steps
+	if featureFlags.EnableStepStatus {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-step_status")
+	}
+	// Entrypoint arg to mask the values of the secret params in the outputs of the steps
+	if hasSecretParams(taskSpec.Params) {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-secret_params")
+	}
+	// Entrypoint arg to log the details of the execution of the steps
+	if loglevel.Debug(taskRun) {
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-debug")
+	}
+	// Entrypoint args to upload the files of the results of type file
+	if fileResults := fileResultNames(taskSpec.Results); alphaAPIEnabled && len(fileResults) > 0 {
+		switch {
+		case featureFlags.ResultFileStore == "":
+			return nil, fmt.Errorf("results of type file require %q to be set", "result-file-store")
+		case sidecarLogsResultsEnabled:
+			return nil, fmt.Errorf("results of type file are not supported when %q is %q", "results-from", config.ResultExtractionMethodSidecarLogs)
+		}
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs,
+			"-file_results", strings.Join(fileResults, ","),
+			"-result_file_store", featureFlags.ResultFileStore+"/"+taskRun.Namespace)
 	}
 	credEntrypointArgs, credVolumes, credVolumeMounts, err := credsInit(ctx, taskRun.Spec.ServiceAccountName, taskRun.Namespace, b.KubeClient)
 	if err != nil {
@@ -167,6 +202,16 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		taskSpec.Sidecars = append(taskSpec.Sidecars, resultsSidecar)
 		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-result_from", config.ResultExtractionMethodSidecarLogs)
 	}
+	logForwarding := config.FromContextOrDefaults(ctx).LogForwarding
+	if logForwarding.Enabled() {
+		// create a sidecar shipping the logs the steps copy to their run directories
+		logForwarderSidecar, err := createLogForwarderSidecar(taskRun, steps, logForwarding, b.Images.LogForwarderImage)
+		if err != nil {
+			return nil, err
+		}
+		taskSpec.Sidecars = append(taskSpec.Sidecars, logForwarderSidecar)
+		commonExtraEntrypointArgs = append(commonExtraEntrypointArgs, "-forward_logs")
+	}
 	sidecars, err := v1beta1.MergeSidecarsWithOverrides(taskSpec.Sidecars, taskRun.Spec.SidecarOverrides)
 	if err != nil {
 		return nil, err
@@ -203,12 +248,53 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		podTemplate = *taskRun.Spec.PodTemplate
 	}
 
+	// Append the image pull secrets of the policies selecting the TaskRun, which the images
+	// of the steps and the sidecars are also looked up with.
+	podTemplate.ImagePullSecrets, err = policyImagePullSecrets(ctx, b.KubeClient, taskRun, podTemplate.ImagePullSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pull the images of the steps and the sidecars through the mirrors of their registries,
+	// before their entrypoints and digests are looked up.
+	imageMirrors := config.FromContextOrDefaults(ctx).ImageMirrors
+	for i := range stepContainers {
+		stepContainers[i].Image = imageMirrors.MirrorImage(stepContainers[i].Image)
+	}
+	for i := range sidecarContainers {
+		sidecarContainers[i].Image = imageMirrors.MirrorImage(sidecarContainers[i].Image)
+	}
+
 	// Resolve entrypoint for any steps that don't specify command.
 	stepContainers, err = resolveEntrypoints(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers)
 	if err != nil {
 		return nil, err
 	}
 
+	// Pin the images of the steps and the sidecars referenced by tag to their digests, which
+	// the signatures of the images are verified for.
+	if featureFlags.StepImageDigests == config.StepImageDigestsPin || featureFlags.VerifyStepImageSignatures {
+		if err := pinImages(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, stepContainers); err != nil {
+			return nil, err
+		}
+		if err := pinImages(ctx, b.EntrypointCache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, podTemplate.ImagePullSecrets, sidecarContainers); err != nil {
+			return nil, err
+		}
+	}
+
+	if alphaAPIEnabled && hasEntrypointSidecars(sidecars) {
+		for _, s := range sidecars {
+			if len(s.Results) > 0 && sidecarLogsResultsEnabled {
+				return nil, fmt.Errorf("sidecar results are not supported when %q is %q", "results-from", config.ResultExtractionMethodSidecarLogs)
+			}
+		}
+		sidecarContainers, err = wrapSidecars(ctx, b.EntrypointCache, taskRun, podTemplate.ImagePullSecrets, sidecars, sidecarContainers)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, sidecarRunVolume, sidecarStopVolume)
+	}
+
 	readyImmediately := isPodReadyImmediately(*featureFlags, taskSpec.Sidecars)
 
 	if alphaAPIEnabled {
@@ -247,8 +333,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			stepContainers[i].Env = env
 		}
 	}
-	// Add env var if hermetic execution was requested & if the alpha API is enabled
-	if taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic && alphaAPIEnabled {
+	// Add env var if hermetic execution was requested
+	hermetic := IsHermetic(ctx, taskRun)
+	if hermetic {
 		for i, s := range stepContainers {
 			// Add it at the end so it overrides
 			env := append(s.Env, corev1.EnvVar{Name: TektonHermeticEnvVar, Value: "1"}) //nolint:gocritic
@@ -256,6 +343,22 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
+	// Mount the scratch volumes of the steps before the implicit volume
+	// mounts, so that they can be mounted at the same paths.
+	if alphaAPIEnabled {
+		scratch, err := scratchVolumes(steps, stepContainers, config.FromContextOrDefaults(ctx).Defaults.DefaultMaxScratchSize)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, scratch...)
+	}
+
+	// Tune the memory resources of the steps with a memory profile, once the
+	// scratch volumes backed by memory have been added to their requests.
+	if alphaAPIEnabled {
+		applyMemoryProfiles(steps, stepContainers, config.FromContextOrDefaults(ctx).Defaults.DefaultBuildHeavyStepMemory)
+	}
+
 	// Add implicit volume mounts to each step, unless the step specifies
 	// its own volume mount at that path.
 	for i, s := range stepContainers {
@@ -314,6 +417,21 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
+	if logForwarding.Enabled() {
+		// Mount the run directories of the steps onto the log forwarder
+		// sidecar so that it can read their log files.
+		for i, sc := range sidecarContainers {
+			if sc.Name != pipeline.ReservedLogForwarderSidecarName {
+				continue
+			}
+			for j := 0; j < len(stepContainers); j++ {
+				if vm := runMount(j, true); !hasMountPath(sc.VolumeMounts, vm.MountPath) {
+					sidecarContainers[i].VolumeMounts = append(sidecarContainers[i].VolumeMounts, vm)
+				}
+			}
+		}
+	}
+
 	// This loop:
 	// - sets container name to add "step-" prefix or "step-unnamed-#" if not specified.
 	// TODO(#1605): Remove this loop and make each transformation in
@@ -322,6 +440,14 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		stepContainers[i].Name = names.SimpleNameGenerator.RestrictLength(StepName(s.Name, i))
 	}
 
+	// Merge the security profiles of the steps with a sandbox, once their containers
+	// have been named so that their AppArmor profiles can be annotated.
+	var sandboxAnnotations map[string]string
+	var stepUserNamespace bool
+	if alphaAPIEnabled {
+		sandboxAnnotations, stepUserNamespace = applyStepSandboxes(steps, stepContainers)
+	}
+
 	// Add podTemplate Volumes to the explicitly declared use volumes
 	volumes = append(volumes, taskSpec.Volumes...)
 	volumes = append(volumes, podTemplate.Volumes...)
@@ -329,6 +455,11 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if err := v1beta1.ValidateVolumes(volumes); err != nil {
 		return nil, err
 	}
+	if hermetic {
+		if err := validateHermeticVolumes(volumes); err != nil {
+			return nil, err
+		}
+	}
 
 	readonly := true
 	if config.IsSpireEnabled(ctx) {
@@ -363,6 +494,14 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		}
 	}
 
+	if len(taskRun.Spec.ResultFiles) > 0 {
+		resultFilesVolume, resultFileMounts := resultFiles(taskRun)
+		volumes = append(volumes, resultFilesVolume)
+		for i := range stepContainers {
+			stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, resultFileMounts...)
+		}
+	}
+
 	mergedPodContainers := stepContainers
 
 	// Merge sidecar containers with step containers.
@@ -383,6 +522,15 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 
 	podAnnotations := kmeta.CopyMap(taskRun.Annotations)
 	podAnnotations[ReleaseAnnotation] = changeset.Get()
+	for k, v := range sandboxAnnotations {
+		podAnnotations[k] = v
+	}
+	if len(stepContainers) > 0 {
+		podAnnotations[StepOrderAnnotation] = stepOrderAnnotationValue(stepContainers)
+		if _, ok := podAnnotations[DefaultContainerAnnotation]; !ok {
+			podAnnotations[DefaultContainerAnnotation] = stepContainers[0].Name
+		}
+	}
 
 	if readyImmediately {
 		podAnnotations[readyAnnotation] = readyAnnotationValue
@@ -399,6 +547,20 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if taskRunRetries := len(taskRun.Status.RetriesStatus); taskRunRetries > 0 {
 		podNameSuffix = fmt.Sprintf("%s-retry%d", podNameSuffix, taskRunRetries)
 	}
+	// The steps of a hermetic TaskRun must not be able to authenticate as its service account.
+	automountServiceAccountToken := podTemplate.AutomountServiceAccountToken
+	if hermetic {
+		automount := false
+		automountServiceAccountToken = &automount
+	}
+
+	// Kubernetes creates a single user namespace for all the containers of the pod.
+	var hostUsers *bool
+	if stepUserNamespace {
+		shareHostUsers := false
+		hostUsers = &shareHostUsers
+	}
+
 	newPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			// We execute the build's pod in the same namespace as where the build was
@@ -426,7 +588,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			Affinity:                     podTemplate.Affinity,
 			SecurityContext:              podTemplate.SecurityContext,
 			RuntimeClassName:             podTemplate.RuntimeClassName,
-			AutomountServiceAccountToken: podTemplate.AutomountServiceAccountToken,
+			AutomountServiceAccountToken: automountServiceAccountToken,
 			SchedulerName:                podTemplate.SchedulerName,
 			HostNetwork:                  podTemplate.HostNetwork,
 			DNSPolicy:                    dnsPolicy,
@@ -436,6 +598,7 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 			ImagePullSecrets:             podTemplate.ImagePullSecrets,
 			HostAliases:                  podTemplate.HostAliases,
 			TopologySpreadConstraints:    podTemplate.TopologySpreadConstraints,
+			HostUsers:                    hostUsers,
 			ActiveDeadlineSeconds:        &activeDeadlineSeconds, // Set ActiveDeadlineSeconds to mark the pod as "terminating" (like a Job)
 		},
 	}
@@ -450,6 +613,33 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	return newPod, nil
 }
 
+// IsHermetic returns whether the Steps of the TaskRun run hermetic, as requested by its
+// spec, or by its execution mode annotation if the alpha API is enabled.
+func IsHermetic(ctx context.Context, taskRun *v1beta1.TaskRun) bool {
+	if taskRun.Spec.Hermetic {
+		return true
+	}
+	return taskRun.Annotations[ExecutionModeAnnotation] == ExecutionModeHermetic &&
+		config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
+}
+
+// validateHermeticVolumes returns an error if a volume of the pod of a hermetic TaskRun
+// projects the token of a service account, which would let its steps authenticate
+// as the service account despite its token not being automounted.
+func validateHermeticVolumes(volumes []corev1.Volume) error {
+	for _, v := range volumes {
+		if v.Projected == nil {
+			continue
+		}
+		for _, source := range v.Projected.Sources {
+			if source.ServiceAccountToken != nil {
+				return fmt.Errorf("the volume %q of a hermetic TaskRun can't project a service account token", v.Name)
+			}
+		}
+	}
+	return nil
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1beta1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -538,3 +728,45 @@ func createResultsSidecar(taskSpec v1beta1.TaskSpec, image string) v1beta1.Sidec
 		Command: command,
 	}
 }
+
+// createLogForwarderSidecar creates a sidecar that will run the logforwarder binary,
+// shipping the logs of the steps to the configured sink labelled with the TaskRun
+// and the PipelineRun they belong to.
+func createLogForwarderSidecar(taskRun *v1beta1.TaskRun, steps []v1beta1.Step, logForwarding *config.LogForwarding, image string) (v1beta1.Sidecar, error) {
+	stepNames := make([]string, 0, len(steps))
+	for i, s := range steps {
+		stepNames = append(stepNames, strings.TrimPrefix(StepName(s.Name, i), stepPrefix))
+	}
+	labels := map[string]string{
+		logforwarder.NamespaceLabel: taskRun.Namespace,
+		logforwarder.TaskRunLabel:   taskRun.Name,
+	}
+	if pr := taskRun.Labels[pipeline.PipelineRunLabelKey]; pr != "" {
+		labels[logforwarder.PipelineRunLabel] = pr
+	}
+	if pt := taskRun.Labels[pipeline.PipelineTaskLabelKey]; pt != "" {
+		labels[logforwarder.PipelineTaskLabel] = pt
+	}
+	labelsJSON, err := json.Marshal(labels)
+	if err != nil {
+		return v1beta1.Sidecar{}, err
+	}
+	return v1beta1.Sidecar{
+		Name:  pipeline.ReservedLogForwarderSidecarName,
+		Image: image,
+		Command: []string{
+			"/ko-app/logforwarder",
+			"-run-dir", RunDir,
+			"-step-names", strings.Join(stepNames, ","),
+			"-sink", logForwarding.Sink,
+			"-url", logForwarding.URL,
+			"-labels", string(labelsJSON),
+		},
+		Env: []corev1.EnvVar{{
+			Name: logforwarder.PodNameEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+			},
+		}},
+	}, nil
+}
@@ -140,6 +140,253 @@ func TestPodBuild(t *testing.T) {
 			}, runVolume(0)),
 			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
+	}, {
+		desc: "simple with execution log",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		featureFlags: map[string]string{
+			"enable-execution-log": "true",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-execution_log",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}, runVolume(0)),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+	}, {
+		desc: "simple with step progress",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		featureFlags: map[string]string{
+			"enable-step-progress": "true",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-step_progress",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}, runVolume(0)),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+	}, {
+		desc: "simple with step status",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		featureFlags: map[string]string{
+			"enable-step-status": "true",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-step_status",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}, runVolume(0)),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+	}, {
+		desc: "simple with debug logs",
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		trAnnotation: map[string]string{
+			"pipeline.tekton.dev/log-level": "debug",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-debug",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}, runMount(0, false), binROMount}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}, runVolume(0)),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
+	}, {
+		desc: "with result files",
+		trs: v1beta1.TaskRunSpec{
+			ResultFiles: []v1beta1.ResultFile{{
+				Path:  "/inputs/config.json",
+				Value: `{"replicas": 3}`,
+			}},
+		},
+		ts: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name:    "name",
+				Image:   "image",
+				Command: []string{"cmd"}, // avoid entrypoint lookup.
+			}},
+		},
+		wantAnnotations: map[string]string{
+			DefaultContainerAnnotation: "step-name",
+			StepOrderAnnotation:        "step-name",
+		},
+		want: &corev1.PodSpec{
+			RestartPolicy:  corev1.RestartPolicyNever,
+			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+			Containers: []corev1.Container{{
+				Name:    "step-name",
+				Image:   "image",
+				Command: []string{"/tekton/bin/entrypoint"},
+				Args: []string{
+					"-wait_file",
+					"/tekton/downward/ready",
+					"-wait_file_content",
+					"-post_file",
+					"/tekton/run/0/out",
+					"-termination_path",
+					"/tekton/termination",
+					"-step_metadata_dir",
+					"/tekton/run/0/status",
+					"-entrypoint",
+					"cmd",
+					"--",
+				},
+				VolumeMounts: append([]corev1.VolumeMount{downwardMount, {
+					Name:      "tekton-creds-init-home-0",
+					MountPath: "/tekton/creds",
+				}, runMount(0, false), binROMount, {
+					Name:      "tekton-internal-result-files",
+					MountPath: "/inputs/config.json",
+					SubPath:   "result-file-0",
+					ReadOnly:  true,
+				}}, implicitVolumeMounts...),
+				TerminationMessagePath: "/tekton/termination",
+			}},
+			Volumes: append(implicitVolumes, binVolume, downwardVolume, corev1.Volume{
+				Name:         "tekton-creds-init-home-0",
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory}},
+			}, runVolume(0), corev1.Volume{
+				Name: "tekton-internal-result-files",
+				VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "taskrun-name-result-files"},
+					Items:                []corev1.KeyToPath{{Key: "result-file-0", Path: "result-file-0"}},
+				}},
+			}),
+			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
+		},
 	}, {
 		desc: "simple with breakpoint onFailure enabled, alpha api fields disabled",
 		trs: v1beta1.TaskRunSpec{
@@ -230,7 +477,9 @@ func TestPodBuild(t *testing.T) {
 			ActiveDeadlineSeconds: &defaultActiveDeadlineSeconds,
 		},
 		wantAnnotations: map[string]string{
-			readyAnnotation: readyAnnotationValue,
+			DefaultContainerAnnotation: "step-name",
+			StepOrderAnnotation:        "step-name",
+			readyAnnotation:            readyAnnotationValue,
 		},
 	}, {
 		desc: "with service account",
@@ -511,7 +760,10 @@ func TestPodBuild(t *testing.T) {
 				Image: "sidecar-image",
 			}},
 		},
-		wantAnnotations: map[string]string{},
+		wantAnnotations: map[string]string{
+			DefaultContainerAnnotation: "step-primary-name",
+			StepOrderAnnotation:        "step-primary-name",
+		},
 		want: &corev1.PodSpec{
 			RestartPolicy:  corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "primary-name"}})},
@@ -565,7 +817,10 @@ func TestPodBuild(t *testing.T) {
 				Script: "#!/bin/sh\necho hello from sidecar",
 			}},
 		},
-		wantAnnotations: map[string]string{},
+		wantAnnotations: map[string]string{
+			DefaultContainerAnnotation: "step-primary-name",
+			StepOrderAnnotation:        "step-primary-name",
+		},
 		want: &corev1.PodSpec{
 			RestartPolicy: corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{
@@ -635,7 +890,11 @@ _EOF_
 		featureFlags: map[string]string{
 			featureFlagSetReadyAnnotationOnPodCreate: "true",
 		},
-		wantAnnotations: map[string]string{}, // no ready annotations on pod create since sidecars are present
+		// no ready annotations on pod create since sidecars are present
+		wantAnnotations: map[string]string{
+			DefaultContainerAnnotation: "step-primary-name",
+			StepOrderAnnotation:        "step-primary-name",
+		},
 		want: &corev1.PodSpec{
 			RestartPolicy:  corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "primary-name"}})},
@@ -934,7 +1193,10 @@ _EOF_
 				},
 			}},
 		},
-		wantAnnotations: map[string]string{},
+		wantAnnotations: map[string]string{
+			DefaultContainerAnnotation: "step-primary-name",
+			StepOrderAnnotation:        "step-primary-name",
+		},
 		want: &corev1.PodSpec{
 			RestartPolicy:  corev1.RestartPolicyNever,
 			InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "primary-name"}})},
@@ -1629,8 +1891,9 @@ _EOF_
 				"experimental.tekton.dev/execution-mode": "hermetic",
 			},
 			want: &corev1.PodSpec{
-				RestartPolicy:  corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+				RestartPolicy:                corev1.RestartPolicyNever,
+				AutomountServiceAccountToken: &automountServiceAccountToken,
+				InitContainers:               []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -1679,8 +1942,9 @@ _EOF_
 				"experimental.tekton.dev/execution-mode": "hermetic",
 			},
 			want: &corev1.PodSpec{
-				RestartPolicy:  corev1.RestartPolicyNever,
-				InitContainers: []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
+				RestartPolicy:                corev1.RestartPolicyNever,
+				AutomountServiceAccountToken: &automountServiceAccountToken,
+				InitContainers:               []corev1.Container{entrypointInitContainer(images.EntrypointImage, []v1beta1.Step{{Name: "name"}})},
 				Containers: []corev1.Container{{
 					Name:    "step-name",
 					Image:   "image",
@@ -2502,6 +2766,8 @@ func TestPodBuildwithSpireEnabled(t *testing.T) {
 					"-step_metadata_dir",
 					"/tekton/run/0/status",
 					"-enable_spire",
+					"-spire_socket_path",
+					"unix:///spiffe-workload-api/spire-agent.sock",
 					"-entrypoint",
 					"cmd",
 					"--",
@@ -2799,3 +3065,352 @@ func TestPrepareInitContainers(t *testing.T) {
 		})
 	}
 }
+
+func TestPodBuild_FileResults(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{Name: "status"}, {Name: "report", Type: v1beta1.ResultsTypeFile}},
+		Steps: []v1beta1.Step{{
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}},
+	}
+	for _, tc := range []struct {
+		desc     string
+		flags    map[string]string
+		wantArgs []string
+		wantErr  bool
+	}{{
+		desc:  "store configured",
+		flags: map[string]string{"enable-api-fields": "alpha", "result-file-store": "https://store.example.com/tekton"},
+		wantArgs: []string{
+			"-wait_file", "/tekton/downward/ready",
+			"-wait_file_content",
+			"-post_file", "/tekton/run/0/out",
+			"-termination_path", "/tekton/termination",
+			"-step_metadata_dir", "/tekton/run/0/status",
+			"-file_results", "report",
+			"-result_file_store", "https://store.example.com/tekton/default",
+			"-results", "status,report",
+			"-entrypoint", "cmd",
+			"--",
+		},
+	}, {
+		desc:    "no store",
+		flags:   map[string]string{"enable-api-fields": "alpha"},
+		wantErr: true,
+	}, {
+		desc:    "sidecar logs",
+		flags:   map[string]string{"enable-api-fields": "alpha", "result-file-store": "https://store.example.com/tekton", "results-from": "sidecar-logs"},
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.flags,
+			})
+			builder := Builder{
+				Images:     images,
+				KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+			}
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if d := cmp.Diff(tc.wantArgs, got.Spec.Containers[0].Args); d != "" {
+				t.Errorf("args %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuild_Hermetic(t *testing.T) {
+	automount := true
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}},
+	}
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		podTemplate *pod.Template
+		wantErr     bool
+	}{{
+		desc: "hermetic",
+	}, {
+		desc:        "hermetic by the execution mode annotation",
+		annotations: map[string]string{ExecutionModeAnnotation: ExecutionModeHermetic},
+	}, {
+		desc:        "token automount requested by the pod template",
+		podTemplate: &pod.Template{AutomountServiceAccountToken: &automount},
+	}, {
+		desc: "projected service account token",
+		podTemplate: &pod.Template{Volumes: []corev1.Volume{{
+			Name: "token",
+			VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+			}}}},
+		}}},
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"enable-api-fields": "alpha"},
+			})
+			builder := Builder{
+				Images:     images,
+				KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+			}
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default", Annotations: tc.annotations},
+				Spec:       v1beta1.TaskRunSpec{Hermetic: tc.annotations == nil, PodTemplate: tc.podTemplate},
+			}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			if d := cmp.Diff([]corev1.EnvVar{{Name: TektonHermeticEnvVar, Value: "1"}}, got.Spec.Containers[0].Env); d != "" {
+				t.Errorf("env %s", diff.PrintWantGot(d))
+			}
+			if got.Spec.AutomountServiceAccountToken == nil || *got.Spec.AutomountServiceAccountToken {
+				t.Errorf("expected the service account token not to be mounted but got %v", got.Spec.AutomountServiceAccountToken)
+			}
+		})
+	}
+}
+
+func TestIsHermetic(t *testing.T) {
+	annotations := map[string]string{ExecutionModeAnnotation: ExecutionModeHermetic}
+	for _, tc := range []struct {
+		desc        string
+		annotations map[string]string
+		spec        v1beta1.TaskRunSpec
+		apiFields   string
+		want        bool
+	}{{
+		desc:      "not hermetic",
+		apiFields: "alpha",
+	}, {
+		desc:      "hermetic spec",
+		spec:      v1beta1.TaskRunSpec{Hermetic: true},
+		apiFields: "alpha",
+		want:      true,
+	}, {
+		desc:        "hermetic annotation",
+		annotations: annotations,
+		apiFields:   "alpha",
+		want:        true,
+	}, {
+		desc:        "hermetic annotation without the alpha API",
+		annotations: annotations,
+		apiFields:   "beta",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"enable-api-fields": tc.apiFields},
+			})
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}, Spec: tc.spec}
+			if got := IsHermetic(store.ToContext(context.Background()), tr); got != tc.want {
+				t.Errorf("IsHermetic() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPodBuild_LogForwarding(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}, {
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetLogForwardingConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"sink": "loki", "url": "http://loki:3100/loki/api/v1/push"},
+	})
+	builder := Builder{
+		Images:     images,
+		KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+	}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{
+		Name:      "taskrun-name",
+		Namespace: "default",
+		Labels: map[string]string{
+			pipeline.PipelineRunLabelKey:  "pipelinerun-name",
+			pipeline.PipelineTaskLabelKey: "compile",
+		},
+	}}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	for _, c := range got.Spec.Containers[:2] {
+		forwardsLogs := false
+		for _, arg := range c.Args {
+			forwardsLogs = forwardsLogs || arg == "-forward_logs"
+		}
+		if !forwardsLogs {
+			t.Errorf("expected step %s to copy its logs to its run directory but its args are %v", c.Name, c.Args)
+		}
+	}
+	want := corev1.Container{
+		Name:  pipeline.ReservedLogForwarderSidecarContainerName,
+		Image: images.LogForwarderImage,
+		Command: []string{
+			"/ko-app/logforwarder",
+			"-run-dir", "/tekton/run",
+			"-step-names", "build,unnamed-1",
+			"-sink", "loki",
+			"-url", "http://loki:3100/loki/api/v1/push",
+			"-labels", `{"namespace":"default","pipeline_task":"compile","pipelinerun":"pipelinerun-name","taskrun":"taskrun-name"}`,
+		},
+		Env: []corev1.EnvVar{{
+			Name:      "TEKTON_POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+		}},
+		VolumeMounts: []corev1.VolumeMount{runMount(0, true), runMount(1, true)},
+	}
+	if len(got.Spec.Containers) != 3 {
+		t.Fatalf("expected the log forwarder sidecar to be added to the two steps but got containers %v", got.Spec.Containers)
+	}
+	if d := cmp.Diff(want, got.Spec.Containers[2], cmpopts.IgnoreFields(corev1.Container{}, "Resources")); d != "" {
+		t.Errorf("log forwarder sidecar %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPodBuild_ImageMirrors(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "clone",
+			Image:   "gcr.io/tekton-releases/github.com/tektoncd/pipeline/cmd/git-init:v0.40.2",
+			Command: []string{"/ko-app/git-init"}, // avoid entrypoint lookup.
+		}, {
+			Name:    "build",
+			Image:   "quay.io/buildah/stable",
+			Command: []string{"buildah"},
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Name:  "registry",
+			Image: "registry:2",
+		}},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetImageMirrorsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"mirrors": "docker.io: registry.internal/dockerhub\ngcr.io: registry.internal/gcr\n"},
+	})
+	builder := Builder{
+		Images:     images,
+		KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+	}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	var gotImages []string
+	for _, c := range got.Spec.Containers {
+		gotImages = append(gotImages, c.Image)
+	}
+	want := []string{
+		"registry.internal/gcr/tekton-releases/github.com/tektoncd/pipeline/cmd/git-init:v0.40.2",
+		"quay.io/buildah/stable",
+		"registry.internal/dockerhub/library/registry:2",
+	}
+	if d := cmp.Diff(want, gotImages); d != "" {
+		t.Errorf("images %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPodBuild_ImagePullSecretsPolicies(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "registry.internal/builder",
+			Command: []string{"build"}, // avoid entrypoint lookup.
+		}},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetImagePullSecretsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{"policies": `
+- name: builds
+  namespaceSelector:
+    matchLabels:
+      team: builds
+  imagePullSecrets:
+  - name: builds-credentials
+  - name: own-credentials
+- name: release
+  selector:
+    matchLabels:
+      app: release
+  serviceAccount: puller
+- name: tests
+  namespaceSelector:
+    matchLabels:
+      team: tests
+  imagePullSecrets:
+  - name: tests-credentials
+`},
+	})
+	builder := Builder{
+		Images: images,
+		KubeClient: fakek8s.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "builds"}}},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			&corev1.ServiceAccount{
+				ObjectMeta:       metav1.ObjectMeta{Name: "puller", Namespace: "default"},
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "puller-credentials"}},
+			},
+		),
+	}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default", Labels: map[string]string{"app": "release"}},
+		Spec: v1beta1.TaskRunSpec{
+			PodTemplate: &pod.Template{ImagePullSecrets: []corev1.LocalObjectReference{{Name: "own-credentials"}}},
+		},
+	}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	want := []corev1.LocalObjectReference{{Name: "own-credentials"}, {Name: "builds-credentials"}, {Name: "puller-credentials"}}
+	if d := cmp.Diff(want, got.Spec.ImagePullSecrets); d != "" {
+		t.Errorf("imagePullSecrets %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]corev1.LocalObjectReference{{Name: "own-credentials"}}, tr.Spec.PodTemplate.ImagePullSecrets); d != "" {
+		t.Errorf("pod template of the TaskRun was modified %s", diff.PrintWantGot(d))
+	}
+}
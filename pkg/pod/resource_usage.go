@@ -0,0 +1,214 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// ResourceRecommendationsAnnotation is the annotation of completed TaskRuns holding
+	// the cpu and memory requests recommended for their steps, by step name, in JSON.
+	ResourceRecommendationsAnnotation = "pipeline.tekton.dev/step-resource-recommendations"
+
+	// ResourceUsageSamplingPeriod is the period at which the resource usage of the
+	// steps of running TaskRuns is sampled.
+	ResourceUsageSamplingPeriod = 30 * time.Second
+
+	// resourceRecommendationHeadroom is the ratio added to the peak usage of the
+	// steps to absorb the variations of their usage between runs.
+	resourceRecommendationHeadroom = 0.2
+)
+
+// ResourceUsageReader reads the current usage of compute resources by the containers of Pods.
+type ResourceUsageReader interface {
+	// ContainerUsage returns the cpu and memory usage of the containers of the Pod,
+	// by container name, read from the source set by "step-resource-usage-source".
+	ContainerUsage(ctx context.Context, pod *corev1.Pod) (map[string]corev1.ResourceList, error)
+}
+
+// NewResourceUsageReader returns a ResourceUsageReader querying the resource metrics
+// API or the summary API of the kubelets through the API server with the given client.
+func NewResourceUsageReader(client rest.Interface) ResourceUsageReader {
+	return &resourceUsageReader{client: client}
+}
+
+type resourceUsageReader struct {
+	client rest.Interface
+}
+
+// podMetrics is the subset of the PodMetrics of the resource metrics API used to read the usage of containers.
+type podMetrics struct {
+	Containers []struct {
+		Name  string              `json:"name"`
+		Usage corev1.ResourceList `json:"usage"`
+	} `json:"containers"`
+}
+
+// summary is the subset of the Summary of the kubelet summary API used to read the usage of containers.
+type summary struct {
+	Pods []struct {
+		PodRef struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"podRef"`
+		Containers []struct {
+			Name string `json:"name"`
+			CPU  *struct {
+				UsageNanoCores *uint64 `json:"usageNanoCores"`
+			} `json:"cpu"`
+			Memory *struct {
+				WorkingSetBytes *uint64 `json:"workingSetBytes"`
+			} `json:"memory"`
+		} `json:"containers"`
+	} `json:"pods"`
+}
+
+func (r *resourceUsageReader) ContainerUsage(ctx context.Context, pod *corev1.Pod) (map[string]corev1.ResourceList, error) {
+	switch source := config.FromContextOrDefaults(ctx).FeatureFlags.StepResourceUsageSource; source {
+	case config.StepResourceUsageSourceMetricsAPI:
+		return r.metricsAPIUsage(ctx, pod)
+	case config.StepResourceUsageSourceSummaryAPI:
+		return r.summaryAPIUsage(ctx, pod)
+	default:
+		return nil, fmt.Errorf("unsupported resource usage source %q", source)
+	}
+}
+
+func (r *resourceUsageReader) metricsAPIUsage(ctx context.Context, pod *corev1.Pod) (map[string]corev1.ResourceList, error) {
+	body, err := r.client.Get().AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", pod.Namespace, "pods", pod.Name).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the metrics of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	var metrics podMetrics
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return nil, fmt.Errorf("failed to parse the metrics of pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+	usage := make(map[string]corev1.ResourceList, len(metrics.Containers))
+	for _, c := range metrics.Containers {
+		usage[c.Name] = c.Usage
+	}
+	return usage, nil
+}
+
+func (r *resourceUsageReader) summaryAPIUsage(ctx context.Context, pod *corev1.Pod) (map[string]corev1.ResourceList, error) {
+	if pod.Spec.NodeName == "" {
+		return nil, fmt.Errorf("pod %s/%s is not scheduled", pod.Namespace, pod.Name)
+	}
+	body, err := r.client.Get().AbsPath("/api/v1/nodes", pod.Spec.NodeName, "proxy/stats/summary").DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the summary of node %s: %w", pod.Spec.NodeName, err)
+	}
+	var s summary
+	if err := json.Unmarshal(body, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse the summary of node %s: %w", pod.Spec.NodeName, err)
+	}
+	for _, p := range s.Pods {
+		if p.PodRef.Namespace != pod.Namespace || p.PodRef.Name != pod.Name {
+			continue
+		}
+		usage := make(map[string]corev1.ResourceList, len(p.Containers))
+		for _, c := range p.Containers {
+			l := corev1.ResourceList{}
+			if c.CPU != nil && c.CPU.UsageNanoCores != nil {
+				l[corev1.ResourceCPU] = *resource.NewScaledQuantity(int64(*c.CPU.UsageNanoCores), resource.Nano)
+			}
+			if c.Memory != nil && c.Memory.WorkingSetBytes != nil {
+				l[corev1.ResourceMemory] = *resource.NewQuantity(int64(*c.Memory.WorkingSetBytes), resource.BinarySI)
+			}
+			usage[c.Name] = l
+		}
+		return usage, nil
+	}
+	return nil, fmt.Errorf("pod %s/%s is not in the summary of node %s", pod.Namespace, pod.Name, pod.Spec.NodeName)
+}
+
+// UpdateStepResourceUsage returns the peak usage of the steps updated with a sample of
+// the usage of the containers of their Pod. The usage of other containers is ignored.
+func UpdateStepResourceUsage(usage []v1beta1.StepResourceUsage, sample map[string]corev1.ResourceList) []v1beta1.StepResourceUsage {
+	byContainer := make(map[string]int, len(usage))
+	for i, u := range usage {
+		byContainer[u.ContainerName] = i
+	}
+	// Iterate over the containers in a deterministic order.
+	var containers []string
+	for name := range sample {
+		if IsContainerStep(name) {
+			containers = append(containers, name)
+		}
+	}
+	sort.Strings(containers)
+	for _, name := range containers {
+		i, ok := byContainer[name]
+		if !ok {
+			usage = append(usage, v1beta1.StepResourceUsage{Name: trimStepPrefix(name), ContainerName: name})
+			i = len(usage) - 1
+			byContainer[name] = i
+		}
+		for _, r := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			q, ok := sample[name][r]
+			if !ok {
+				continue
+			}
+			if peak, ok := usage[i].Peak[r]; ok && peak.Cmp(q) >= 0 {
+				continue
+			}
+			if usage[i].Peak == nil {
+				usage[i].Peak = corev1.ResourceList{}
+			}
+			usage[i].Peak[r] = q.DeepCopy()
+		}
+	}
+	return usage
+}
+
+// StepResourceRecommendations returns the value of the ResourceRecommendationsAnnotation
+// for the peak usage of the steps: their peak usage plus some headroom, rounded up to
+// millicores of cpu and mebibytes of memory.
+func StepResourceRecommendations(usage []v1beta1.StepResourceUsage) (string, error) {
+	recommendations := make(map[string]corev1.ResourceList, len(usage))
+	for _, u := range usage {
+		l := corev1.ResourceList{}
+		if cpu, ok := u.Peak[corev1.ResourceCPU]; ok {
+			milli := int64(math.Ceil(float64(cpu.MilliValue()) * (1 + resourceRecommendationHeadroom)))
+			l[corev1.ResourceCPU] = *resource.NewMilliQuantity(milli, resource.DecimalSI)
+		}
+		if memory, ok := u.Peak[corev1.ResourceMemory]; ok {
+			mebi := int64(math.Ceil(float64(memory.Value()) * (1 + resourceRecommendationHeadroom) / (1 << 20)))
+			l[corev1.ResourceMemory] = *resource.NewQuantity(mebi<<20, resource.BinarySI)
+		}
+		if len(l) > 0 {
+			recommendations[u.Name] = l
+		}
+	}
+	b, err := json.Marshal(recommendations)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
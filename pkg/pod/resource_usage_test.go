@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	restfake "k8s.io/client-go/rest/fake"
+)
+
+func TestResourceUsageReader(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+		Spec:       corev1.PodSpec{NodeName: "node"},
+	}
+	for _, tc := range []struct {
+		source   string
+		wantPath string
+		body     string
+	}{{
+		source:   config.StepResourceUsageSourceMetricsAPI,
+		wantPath: "/apis/metrics.k8s.io/v1beta1/namespaces/ns/pods/pod",
+		body:     `{"containers":[{"name":"step-build","usage":{"cpu":"250m","memory":"128Mi"}}]}`,
+	}, {
+		source:   config.StepResourceUsageSourceSummaryAPI,
+		wantPath: "/api/v1/nodes/node/proxy/stats/summary",
+		body: `{"pods":[
+			{"podRef":{"name":"pod","namespace":"other"},"containers":[{"name":"step-build","cpu":{"usageNanoCores":1}}]},
+			{"podRef":{"name":"pod","namespace":"ns"},"containers":[{"name":"step-build","cpu":{"usageNanoCores":250000000},"memory":{"workingSetBytes":134217728}}]}
+		]}`,
+	}} {
+		t.Run(tc.source, func(t *testing.T) {
+			client := &restfake.RESTClient{
+				NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+				Client: restfake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != tc.wantPath {
+						return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+					}
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(tc.body))}, nil
+				}),
+			}
+			featureFlags, err := config.NewFeatureFlagsFromMap(map[string]string{"step-resource-usage-source": tc.source})
+			if err != nil {
+				t.Fatalf("unexpected error parsing the feature flags: %v", err)
+			}
+			ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: featureFlags})
+
+			got, err := NewResourceUsageReader(client).ContainerUsage(ctx, pod)
+			if err != nil {
+				t.Fatalf("ContainerUsage: %v", err)
+			}
+			want := map[string]corev1.ResourceList{"step-build": {
+				corev1.ResourceCPU:    resource.MustParse("250m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			}}
+			if d := cmp.Diff(want, got, resourceQuantityCmp); d != "" {
+				t.Errorf("ContainerUsage %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestUpdateStepResourceUsage(t *testing.T) {
+	usage := UpdateStepResourceUsage(nil, map[string]corev1.ResourceList{
+		"step-build": {corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+		"step-test":  {corev1.ResourceCPU: resource.MustParse("1")},
+		"sidecar-db": {corev1.ResourceCPU: resource.MustParse("2")},
+	})
+	usage = UpdateStepResourceUsage(usage, map[string]corev1.ResourceList{
+		"step-build": {corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+		"step-test":  {corev1.ResourceMemory: resource.MustParse("32Mi")},
+	})
+	want := []v1beta1.StepResourceUsage{{
+		Name:          "build",
+		ContainerName: "step-build",
+		Peak:          corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}, {
+		Name:          "test",
+		ContainerName: "step-test",
+		Peak:          corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("32Mi")},
+	}}
+	if d := cmp.Diff(want, usage, resourceQuantityCmp); d != "" {
+		t.Errorf("UpdateStepResourceUsage %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStepResourceRecommendations(t *testing.T) {
+	got, err := StepResourceRecommendations([]v1beta1.StepResourceUsage{{
+		Name: "build",
+		Peak: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("100Mi")},
+	}, {
+		Name: "test",
+		Peak: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1m")},
+	}, {
+		Name: "no-samples",
+	}})
+	if err != nil {
+		t.Fatalf("StepResourceRecommendations: %v", err)
+	}
+	want := `{"build":{"cpu":"300m","memory":"120Mi"},"test":{"cpu":"2m"}}`
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("StepResourceRecommendations %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const resultFilesVolumeName = "tekton-internal-result-files"
+
+// ResultFilesConfigMapName returns the name of the ConfigMap holding the values of
+// the result files of the TaskRun.
+func ResultFilesConfigMapName(tr *v1beta1.TaskRun) string {
+	return kmeta.ChildName(tr.Name, "-result-files")
+}
+
+// ResultFilesConfigMap returns the ConfigMap holding the values of the result files
+// of the TaskRun, which is owned by the TaskRun. A ConfigMap holds up to 1MiB, unlike
+// the annotations of the Pod which share 256KiB with the other annotations.
+func ResultFilesConfigMap(tr *v1beta1.TaskRun) *corev1.ConfigMap {
+	data := make(map[string]string, len(tr.Spec.ResultFiles))
+	for i, rf := range tr.Spec.ResultFiles {
+		data[resultFileKey(i)] = rf.Value
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            ResultFilesConfigMapName(tr),
+			Namespace:       tr.Namespace,
+			Labels:          map[string]string{pipeline.TaskRunLabelKey: tr.Name},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(tr)},
+		},
+		Data: data,
+	}
+}
+
+// resultFiles returns the volume projecting the ConfigMap of the result files of the
+// TaskRun, and the mounts of these files at their paths. The files are read-only and
+// written before any step starts, so the values are delivered without variable substitution.
+func resultFiles(tr *v1beta1.TaskRun) (corev1.Volume, []corev1.VolumeMount) {
+	items := make([]corev1.KeyToPath, 0, len(tr.Spec.ResultFiles))
+	mounts := make([]corev1.VolumeMount, 0, len(tr.Spec.ResultFiles))
+	for i, rf := range tr.Spec.ResultFiles {
+		key := resultFileKey(i)
+		items = append(items, corev1.KeyToPath{Key: key, Path: key})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      resultFilesVolumeName,
+			MountPath: rf.Path,
+			SubPath:   key,
+			ReadOnly:  true,
+		})
+	}
+	volume := corev1.Volume{
+		Name: resultFilesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: ResultFilesConfigMapName(tr)},
+				Items:                items,
+			},
+		},
+	}
+	return volume, mounts
+}
+
+func resultFileKey(i int) string {
+	return fmt.Sprintf("result-file-%d", i)
+}
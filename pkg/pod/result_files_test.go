@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResultFilesConfigMap(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ns", UID: "uid"},
+		Spec: v1beta1.TaskRunSpec{
+			ResultFiles: []v1beta1.ResultFile{{
+				Path:  "/inputs/config.json",
+				Value: `{"replicas": 3}`,
+			}, {
+				Path:  "/inputs/images.json",
+				Value: `["a","b"]`,
+			}},
+		},
+	}
+	want := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "build-result-files",
+			Namespace: "ns",
+			Labels:    map[string]string{"tekton.dev/taskRun": "build"},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion:         "tekton.dev/v1beta1",
+				Kind:               "TaskRun",
+				Name:               "build",
+				UID:                "uid",
+				Controller:         &[]bool{true}[0],
+				BlockOwnerDeletion: &[]bool{true}[0],
+			}},
+		},
+		Data: map[string]string{
+			"result-file-0": `{"replicas": 3}`,
+			"result-file-1": `["a","b"]`,
+		},
+	}
+	if d := cmp.Diff(want, ResultFilesConfigMap(tr)); d != "" {
+		t.Errorf("ResultFilesConfigMap() %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// appArmorAnnotationPrefix prefixes the annotations setting the AppArmor profiles of
+// the containers of a pod, suffixed by their names.
+const appArmorAnnotationPrefix = "container.apparmor.security.beta.kubernetes.io/"
+
+// applyStepSandboxes merges the security profiles of the steps with a sandbox into the
+// security contexts of their containers, which override the pod security context.
+// It returns the annotations setting the AppArmor profiles of the step containers, and
+// whether a step requested to run in a user namespace, which Kubernetes only supports
+// for the whole pod.
+func applyStepSandboxes(steps []v1beta1.Step, stepContainers []corev1.Container) (map[string]string, bool) {
+	annotations := map[string]string{}
+	userNamespace := false
+	for i, s := range steps {
+		if s.Sandbox == nil {
+			continue
+		}
+		c := &stepContainers[i]
+		if s.Sandbox.Seccomp != nil || s.Sandbox.SELinux != nil {
+			// The security context may be shared with the Step, copy it before updating it.
+			securityContext := c.SecurityContext.DeepCopy()
+			if securityContext == nil {
+				securityContext = &corev1.SecurityContext{}
+			}
+			if s.Sandbox.Seccomp != nil {
+				securityContext.SeccompProfile = s.Sandbox.Seccomp.DeepCopy()
+			}
+			if s.Sandbox.SELinux != nil {
+				securityContext.SELinuxOptions = s.Sandbox.SELinux.DeepCopy()
+			}
+			c.SecurityContext = securityContext
+		}
+		if s.Sandbox.AppArmor != "" {
+			annotations[appArmorAnnotationPrefix+c.Name] = s.Sandbox.AppArmor
+		}
+		userNamespace = userNamespace || s.Sandbox.UserNamespace
+	}
+	return annotations, userNamespace
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestApplyStepSandboxes(t *testing.T) {
+	runAsUser := int64(1000)
+	stepSecurityContext := &corev1.SecurityContext{
+		RunAsUser:      &runAsUser,
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
+	steps := []v1beta1.Step{{
+		Name: "build",
+		Sandbox: &v1beta1.StepSandbox{
+			Seccomp:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+			AppArmor: v1beta1.AppArmorUnconfined,
+			SELinux:  &corev1.SELinuxOptions{Type: "container_runtime_t"},
+		},
+		SecurityContext: stepSecurityContext,
+	}, {
+		Name:    "test",
+		Sandbox: &v1beta1.StepSandbox{AppArmor: v1beta1.AppArmorRuntimeDefault},
+	}, {
+		Name: "push",
+	}}
+	stepContainers := []corev1.Container{
+		{Name: "step-build", SecurityContext: stepSecurityContext},
+		{Name: "step-test"},
+		{Name: "step-push"},
+	}
+
+	annotations, userNamespace := applyStepSandboxes(steps, stepContainers)
+	wantAnnotations := map[string]string{
+		"container.apparmor.security.beta.kubernetes.io/step-build": "unconfined",
+		"container.apparmor.security.beta.kubernetes.io/step-test":  "runtime/default",
+	}
+	if d := cmp.Diff(wantAnnotations, annotations); d != "" {
+		t.Errorf("annotations %s", diff.PrintWantGot(d))
+	}
+	if userNamespace {
+		t.Error("expected no user namespace to be requested")
+	}
+	wantSecurityContexts := []*corev1.SecurityContext{{
+		RunAsUser:      &runAsUser,
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+		SELinuxOptions: &corev1.SELinuxOptions{Type: "container_runtime_t"},
+	}, nil, nil}
+	for i, c := range stepContainers {
+		if d := cmp.Diff(wantSecurityContexts[i], c.SecurityContext); d != "" {
+			t.Errorf("security context of %s %s", c.Name, diff.PrintWantGot(d))
+		}
+	}
+	if d := cmp.Diff(corev1.SeccompProfileTypeRuntimeDefault, steps[0].SecurityContext.SeccompProfile.Type); d != "" {
+		t.Errorf("the security context of the step was modified %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPodBuild_StepSandbox(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "quay.io/buildah/stable",
+			Command: []string{"buildah"}, // avoid entrypoint lookup.
+			Sandbox: &v1beta1.StepSandbox{
+				Seccomp:       &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined},
+				AppArmor:      v1beta1.AppArmorUnconfined,
+				UserNamespace: true,
+			},
+		}, {
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data:       map[string]string{"enable-api-fields": "alpha"},
+	})
+	builder := Builder{
+		Images:     images,
+		KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+	}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+		Spec: v1beta1.TaskRunSpec{PodTemplate: &pod.Template{SecurityContext: &corev1.PodSecurityContext{
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}}},
+	}
+
+	got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+	if err != nil {
+		t.Fatalf("builder.Build: %v", err)
+	}
+	if d := cmp.Diff(&corev1.SecurityContext{SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeUnconfined}}, got.Spec.Containers[0].SecurityContext); d != "" {
+		t.Errorf("security context of the build step %s", diff.PrintWantGot(d))
+	}
+	if got.Spec.Containers[1].SecurityContext != nil {
+		t.Errorf("expected the test step to run with the pod security context but got %v", got.Spec.Containers[1].SecurityContext)
+	}
+	if d := cmp.Diff(corev1.SeccompProfileTypeRuntimeDefault, got.Spec.SecurityContext.SeccompProfile.Type); d != "" {
+		t.Errorf("pod seccomp profile %s", diff.PrintWantGot(d))
+	}
+	if got := got.Annotations["container.apparmor.security.beta.kubernetes.io/step-build"]; got != "unconfined" {
+		t.Errorf("expected the AppArmor profile of the build step to be unconfined but got %q", got)
+	}
+	if got.Spec.HostUsers == nil || *got.Spec.HostUsers {
+		t.Errorf("expected the pod to run in a user namespace but got hostUsers %v", got.Spec.HostUsers)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const scratchVolumeNamePrefix = "tekton-internal-scratch-"
+
+// scratchVolumes returns the size-limited emptyDir volumes backing the scratch
+// volumes of the steps, and mounts them in the matching step containers. The
+// sizes of the scratch volumes are added to the requests of the steps, and an
+// error is returned if they exceed the limits of the steps or maxSize.
+func scratchVolumes(steps []v1beta1.Step, stepContainers []corev1.Container, maxSize *resource.Quantity) ([]corev1.Volume, error) {
+	var volumes []corev1.Volume
+	var total resource.Quantity
+	for i, s := range steps {
+		var disk, memory resource.Quantity
+		for j, sv := range s.Scratch {
+			name := fmt.Sprintf("%s%d-%d", scratchVolumeNamePrefix, i, j)
+			size := sv.Size.DeepCopy()
+			volumes = append(volumes, corev1.Volume{
+				Name: name,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{Medium: sv.Medium, SizeLimit: &size},
+				},
+			})
+			stepContainers[i].VolumeMounts = append(stepContainers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      name,
+				MountPath: sv.MountPath,
+			})
+			// tmpfs pages are charged to the memory of the container writing them,
+			// while disk-backed emptyDirs count towards the ephemeral storage.
+			if sv.Medium == corev1.StorageMediumMemory {
+				memory.Add(size)
+			} else {
+				disk.Add(size)
+			}
+			total.Add(size)
+		}
+		stepName := StepName(s.Name, i)
+		if err := addScratchRequest(&stepContainers[i], stepName, corev1.ResourceEphemeralStorage, disk); err != nil {
+			return nil, err
+		}
+		if err := addScratchRequest(&stepContainers[i], stepName, corev1.ResourceMemory, memory); err != nil {
+			return nil, err
+		}
+	}
+	if maxSize != nil && total.Cmp(*maxSize) > 0 {
+		return nil, fmt.Errorf("the scratch volumes of the steps have a total size of %s, exceeding the maximum of %s", total.String(), maxSize.String())
+	}
+	return volumes, nil
+}
+
+// addScratchRequest adds the size of the scratch volumes of a step to its request
+// of the resource backing them, so that the scheduler reserves it. The request is
+// capped to the limit of the step, which must be large enough for the volumes.
+func addScratchRequest(c *corev1.Container, stepName string, name corev1.ResourceName, size resource.Quantity) error {
+	if size.IsZero() {
+		return nil
+	}
+	limit, hasLimit := c.Resources.Limits[name]
+	if hasLimit && size.Cmp(limit) > 0 {
+		return fmt.Errorf("the scratch volumes of %q need %s of %s, exceeding the limit of %s", stepName, size.String(), name, limit.String())
+	}
+	if _, hasRequest := c.Resources.Requests[name]; !hasRequest && hasLimit {
+		// The request defaults to the limit, which fits the volumes.
+		return nil
+	}
+	// The requests may be shared with the Step, copy them before updating them.
+	requests := c.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	request := requests[name]
+	request.Add(size)
+	if hasLimit && request.Cmp(limit) > 0 {
+		request = limit.DeepCopy()
+	}
+	requests[name] = request
+	c.Resources.Requests = requests
+	return nil
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestScratchVolumes(t *testing.T) {
+	gi, mi := resource.MustParse("1Gi"), resource.MustParse("64Mi")
+	steps := []v1beta1.Step{{
+		Name: "build",
+		Scratch: []v1beta1.StepScratchVolume{{
+			Name:      "cache",
+			MountPath: "/cache",
+			Size:      gi,
+		}, {
+			Name:      "tmp",
+			MountPath: "/tmp",
+			Size:      mi,
+			Medium:    corev1.StorageMediumMemory,
+		}},
+	}, {
+		Name: "no-scratch",
+	}, {
+		Name: "limited",
+		Scratch: []v1beta1.StepScratchVolume{{
+			Name:      "cache",
+			MountPath: "/cache",
+			Size:      gi,
+		}},
+	}}
+	stepContainers := []corev1.Container{{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		},
+	}, {
+		Name: "no-scratch",
+	}, {
+		Name: "limited",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("2Gi")},
+		},
+	}}
+
+	gotVolumes, err := scratchVolumes(steps, stepContainers, nil)
+	if err != nil {
+		t.Fatalf("scratchVolumes: %v", err)
+	}
+	wantVolumes := []corev1.Volume{{
+		Name:         "tekton-internal-scratch-0-0",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &gi}},
+	}, {
+		Name:         "tekton-internal-scratch-0-1",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: &mi}},
+	}, {
+		Name:         "tekton-internal-scratch-2-0",
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: &gi}},
+	}}
+	if d := cmp.Diff(wantVolumes, gotVolumes, resourceQuantityCmp); d != "" {
+		t.Errorf("Volumes %s", diff.PrintWantGot(d))
+	}
+	wantContainers := []corev1.Container{{
+		Name: "build",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				corev1.ResourceMemory:           resource.MustParse("192Mi"),
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "tekton-internal-scratch-0-0", MountPath: "/cache"},
+			{Name: "tekton-internal-scratch-0-1", MountPath: "/tmp"},
+		},
+	}, {
+		Name: "no-scratch",
+	}, {
+		// The request defaults to the limit, which fits the scratch volume.
+		Name: "limited",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("2Gi")},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "tekton-internal-scratch-2-0", MountPath: "/cache"}},
+	}}
+	if d := cmp.Diff(wantContainers, stepContainers, resourceQuantityCmp); d != "" {
+		t.Errorf("Containers %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestScratchVolumes_CappedRequest(t *testing.T) {
+	steps := []v1beta1.Step{{
+		Scratch: []v1beta1.StepScratchVolume{{Name: "cache", MountPath: "/cache", Size: resource.MustParse("1Gi")}},
+	}}
+	stepContainers := []corev1.Container{{
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1536Mi")},
+		},
+	}}
+	if _, err := scratchVolumes(steps, stepContainers, nil); err != nil {
+		t.Fatalf("scratchVolumes: %v", err)
+	}
+	want := corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("1536Mi")}
+	if d := cmp.Diff(want, stepContainers[0].Resources.Requests, resourceQuantityCmp); d != "" {
+		t.Errorf("Requests %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestScratchVolumes_Error(t *testing.T) {
+	maxSize := resource.MustParse("1Gi")
+	for _, tc := range []struct {
+		desc           string
+		scratch        []v1beta1.StepScratchVolume
+		resources      corev1.ResourceRequirements
+		maxSize        *resource.Quantity
+		wantErrMessage string
+	}{{
+		desc: "exceeds the memory limit of the step",
+		scratch: []v1beta1.StepScratchVolume{{
+			Name:      "tmp",
+			MountPath: "/tmp",
+			Size:      resource.MustParse("256Mi"),
+			Medium:    corev1.StorageMediumMemory,
+		}},
+		resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+		},
+		wantErrMessage: `the scratch volumes of "step-unnamed-0" need 256Mi of memory, exceeding the limit of 128Mi`,
+	}, {
+		desc: "exceeds the maximum size",
+		scratch: []v1beta1.StepScratchVolume{{
+			Name:      "cache",
+			MountPath: "/cache",
+			Size:      resource.MustParse("1Gi"),
+		}, {
+			Name:      "tmp",
+			MountPath: "/tmp",
+			Size:      resource.MustParse("1Mi"),
+			Medium:    corev1.StorageMediumMemory,
+		}},
+		maxSize:        &maxSize,
+		wantErrMessage: "the scratch volumes of the steps have a total size of 1025Mi, exceeding the maximum of 1Gi",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			steps := []v1beta1.Step{{Scratch: tc.scratch}}
+			stepContainers := []corev1.Container{{Resources: tc.resources}}
+			_, err := scratchVolumes(steps, stepContainers, tc.maxSize)
+			if err == nil {
+				t.Fatal("expected an error but got none")
+			}
+			if d := cmp.Diff(tc.wantErrMessage, err.Error()); d != "" {
+				t.Errorf("error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPodBuild_ScratchVolumes(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "build",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+			Scratch: []v1beta1.StepScratchVolume{{
+				Name:      "cache",
+				MountPath: "/workspace",
+				Size:      resource.MustParse("2Gi"),
+			}},
+		}, {
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}},
+	}
+	for _, tc := range []struct {
+		desc           string
+		maxScratchSize string
+		wantErr        bool
+	}{{
+		desc: "unbounded",
+	}, {
+		desc:           "within the maximum size",
+		maxScratchSize: "10Gi",
+	}, {
+		desc:           "exceeding the maximum size",
+		maxScratchSize: "1Gi",
+		wantErr:        true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"enable-api-fields": "alpha"},
+			})
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+				Data:       map[string]string{"default-max-scratch-size": tc.maxScratchSize},
+			})
+			builder := Builder{
+				Images:     images,
+				KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+			}
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, ts)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			// The scratch volume replaces the implicit workspace volume in the first step only.
+			mountedAt := func(c corev1.Container, mountPath string) string {
+				for _, vm := range c.VolumeMounts {
+					if vm.MountPath == mountPath {
+						return vm.Name
+					}
+				}
+				return ""
+			}
+			if d := cmp.Diff("tekton-internal-scratch-0-0", mountedAt(got.Spec.Containers[0], "/workspace")); d != "" {
+				t.Errorf("first step /workspace mount %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff("tekton-internal-workspace", mountedAt(got.Spec.Containers[1], "/workspace")); d != "" {
+				t.Errorf("second step /workspace mount %s", diff.PrintWantGot(d))
+			}
+			wantRequests := corev1.ResourceList{corev1.ResourceEphemeralStorage: resource.MustParse("2Gi")}
+			if d := cmp.Diff(wantRequests, got.Spec.Containers[0].Resources.Requests, resourceQuantityCmp); d != "" {
+				t.Errorf("first step requests %s", diff.PrintWantGot(d))
+			}
+			var found bool
+			for _, v := range got.Spec.Volumes {
+				if v.Name == "tekton-internal-scratch-0-0" {
+					found = v.EmptyDir != nil && v.EmptyDir.SizeLimit != nil && v.EmptyDir.SizeLimit.Cmp(resource.MustParse("2Gi")) == 0
+				}
+			}
+			if !found {
+				t.Errorf("expected a 2Gi emptyDir volume tekton-internal-scratch-0-0 in %v", got.Spec.Volumes)
+			}
+		})
+	}
+}
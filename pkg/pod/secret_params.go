@@ -0,0 +1,47 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// SecretParamsDir is the directory the keys of the Secrets referenced by the secret
+	// params are mounted in, each of them in the subdirectory of its param.
+	SecretParamsDir = "/tekton/secret-params"
+	// SecretParamFile is the name of the file holding the value of a secret param in the
+	// subdirectory of its param.
+	SecretParamFile = "value"
+)
+
+// SecretParamPath returns the path of the file holding the value of the secret param.
+func SecretParamPath(name string) string {
+	return filepath.Join(SecretParamsDir, name, SecretParamFile)
+}
+
+// hasSecretParams returns whether any of the params is a secret param.
+func hasSecretParams(params []v1beta1.ParamSpec) bool {
+	for _, p := range params {
+		if p.Type == v1beta1.ParamTypeSecret {
+			return true
+		}
+	}
+	return false
+}
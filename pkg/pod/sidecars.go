@@ -0,0 +1,222 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	sidecarRunVolumeName = "tekton-internal-sidecar-run"
+	sidecarRunDir        = "/tekton/sidecar-run"
+
+	sidecarStopVolumeName       = "tekton-internal-sidecar-stop"
+	sidecarStopDir              = "/tekton/sidecar-stop"
+	sidecarStopFile             = "stop"
+	stopSidecarsAnnotation      = "tekton.dev/stop-sidecars"
+	stopSidecarsAnnotationValue = "STOP"
+)
+
+var (
+	sidecarRunVolume = corev1.Volume{
+		Name:         sidecarRunVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}
+	sidecarRunMount = corev1.VolumeMount{
+		Name:      sidecarRunVolumeName,
+		MountPath: sidecarRunDir,
+	}
+	// sidecarStopVolume holds the value of the stopSidecarsAnnotation, set by
+	// StopSidecars to request the sidecars run by the entrypoint to stop.
+	sidecarStopVolume = corev1.Volume{
+		Name: sidecarStopVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{{
+					Path: sidecarStopFile,
+					FieldRef: &corev1.ObjectFieldSelector{
+						FieldPath: fmt.Sprintf("metadata.annotations['%s']", stopSidecarsAnnotation),
+					},
+				}},
+			},
+		},
+	}
+	sidecarStopMount = corev1.VolumeMount{
+		Name:      sidecarStopVolumeName,
+		MountPath: sidecarStopDir,
+		ReadOnly:  true,
+	}
+	resultsMount = corev1.VolumeMount{
+		Name:      "tekton-internal-results",
+		MountPath: pipeline.DefaultResultPath,
+	}
+)
+
+// runsWithEntrypoint returns true if the sidecar is run with the entrypoint
+// binary, to write results or to be shut down gracefully.
+func runsWithEntrypoint(s v1beta1.Sidecar) bool {
+	return len(s.Results) > 0 || s.Shutdown != nil
+}
+
+// hasEntrypointSidecars returns true if any of the sidecars runs with the entrypoint binary.
+func hasEntrypointSidecars(sidecars []v1beta1.Sidecar) bool {
+	for _, s := range sidecars {
+		if runsWithEntrypoint(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// entrypointSidecars returns the names of the containers of the sidecars of the
+// Task which run with the entrypoint binary.
+func entrypointSidecars(ts *v1beta1.TaskSpec) sets.String {
+	containers := sets.NewString()
+	if ts == nil {
+		return containers
+	}
+	for _, s := range ts.Sidecars {
+		if runsWithEntrypoint(s) {
+			containers.Insert(names.SimpleNameGenerator.RestrictLength(sidecarPrefix + s.Name))
+		}
+	}
+	return containers
+}
+
+// stopsOnRequest returns true if the container is a sidecar run with the
+// entrypoint binary, which stops once the stopSidecarsAnnotation is set.
+func stopsOnRequest(c corev1.Container) bool {
+	for _, vm := range c.VolumeMounts {
+		if vm.Name == sidecarStopVolumeName {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapSidecars runs the sidecars which write results or are shut down gracefully
+// with the entrypoint binary. Once the stopSidecarsAnnotation is set, it runs their
+// pre-stop command, sends them their stop signal and reports their results in
+// their termination message when they exit. The sidecar containers without a
+// command are resolved with cache.
+func wrapSidecars(ctx context.Context, cache EntrypointCache, taskRun *v1beta1.TaskRun, imagePullSecrets []corev1.LocalObjectReference, sidecars []v1beta1.Sidecar, sidecarContainers []corev1.Container) ([]corev1.Container, error) {
+	var toResolve []corev1.Container
+	var indices []int
+	for i, s := range sidecars {
+		if runsWithEntrypoint(s) && len(sidecarContainers[i].Command) == 0 {
+			toResolve = append(toResolve, sidecarContainers[i])
+			indices = append(indices, i)
+		}
+	}
+	resolved, err := resolveEntrypoints(ctx, cache, taskRun.Namespace, taskRun.Spec.ServiceAccountName, imagePullSecrets, toResolve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the entrypoint of the sidecars: %w", err)
+	}
+	for j, i := range indices {
+		sidecarContainers[i] = resolved[j]
+	}
+
+	for i, s := range sidecars {
+		if !runsWithEntrypoint(s) {
+			continue
+		}
+		c := &sidecarContainers[i]
+		args := []string{
+			"-stop_file", filepath.Join(sidecarStopDir, sidecarStopFile),
+			"-termination_path", terminationPath,
+			"-step_metadata_dir", filepath.Join(sidecarRunDir, strconv.Itoa(i), "status"),
+		}
+		if len(s.Results) > 0 {
+			var resultNames []string
+			for _, r := range s.Results {
+				resultNames = append(resultNames, r.Name)
+			}
+			args = append(args, "-results", strings.Join(resultNames, ","))
+		}
+		if s.Shutdown != nil {
+			if len(s.Shutdown.PreStop) > 0 {
+				preStop, err := json.Marshal(s.Shutdown.PreStop)
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, "-pre_stop", string(preStop))
+			}
+			if s.Shutdown.StopSignal != "" {
+				args = append(args, "-stop_signal", s.Shutdown.StopSignal)
+			}
+			if s.Shutdown.GracePeriod != nil {
+				args = append(args, "-stop_grace_period", s.Shutdown.GracePeriod.Duration.String())
+			}
+		}
+		cmd, cmdArgs := c.Command, c.Args
+		if len(cmd) > 0 {
+			args = append(args, "-entrypoint", cmd[0])
+		}
+		if len(cmd) > 1 {
+			cmdArgs = append(cmd[1:], cmdArgs...)
+		}
+		args = append(args, "--")
+		args = append(args, cmdArgs...)
+
+		c.Command = []string{entrypointBinary}
+		c.Args = args
+		c.TerminationMessagePath = terminationPath
+		c.VolumeMounts = append(c.VolumeMounts, binROMount, sidecarRunMount, sidecarStopMount)
+		if len(s.Results) > 0 && !hasMountPath(c.VolumeMounts, pipeline.DefaultResultPath) {
+			c.VolumeMounts = append(c.VolumeMounts, resultsMount)
+		}
+	}
+	return sidecarContainers, nil
+}
+
+// hasMountPath returns true if one of the volume mounts is mounted at path.
+func hasMountPath(mounts []corev1.VolumeMount, path string) bool {
+	for _, vm := range mounts {
+		if filepath.Clean(vm.MountPath) == filepath.Clean(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAwaitingSidecars returns true if the steps of the Pod have completed and the
+// sidecars of the Task run with the entrypoint binary haven't stopped yet: the
+// TaskRun only completes once they are stopped by StopSidecars, which lets them
+// write their results and flush their state.
+func IsAwaitingSidecars(pod *corev1.Pod, ts *v1beta1.TaskSpec) bool {
+	containers := entrypointSidecars(ts)
+	if len(containers) == 0 || !areStepsComplete(pod) {
+		return false
+	}
+	for _, s := range pod.Status.ContainerStatuses {
+		if containers.Has(s.Name) && s.State.Terminated == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	logtesting "knative.dev/pkg/logging/testing"
+	"knative.dev/pkg/system"
+)
+
+func TestPodBuild_EntrypointSidecars(t *testing.T) {
+	ts := v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{Name: "coverage"}, {Name: "requests"}},
+		Steps: []v1beta1.Step{{
+			Name:    "test",
+			Image:   "image",
+			Command: []string{"cmd"}, // avoid entrypoint lookup.
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Name:  "db",
+			Image: "db",
+		}, {
+			Name:    "proxy",
+			Image:   "proxy",
+			Command: []string{"proxy", "--listen"},
+			Args:    []string{":8080"},
+			Results: []v1beta1.SidecarResult{{Name: "coverage"}, {Name: "requests"}},
+		}, {
+			Name:    "cache",
+			Image:   "cache",
+			Command: []string{"cache"},
+			Shutdown: &v1beta1.SidecarShutdown{
+				PreStop:     []string{"cache", "flush"},
+				StopSignal:  "SIGINT",
+				GracePeriod: &metav1.Duration{Duration: time.Minute},
+			},
+		}},
+	}
+	for _, tc := range []struct {
+		desc        string
+		resultsFrom string
+		wantErr     bool
+	}{{
+		desc: "termination message",
+	}, {
+		desc:        "sidecar logs",
+		resultsFrom: config.ResultExtractionMethodSidecarLogs,
+		wantErr:     true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			store := config.NewStore(logtesting.TestLogger(t))
+			flags := map[string]string{"enable-api-fields": "alpha"}
+			if tc.resultsFrom != "" {
+				flags["results-from"] = tc.resultsFrom
+			}
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       flags,
+			})
+			builder := Builder{
+				Images:     images,
+				KubeClient: fakek8s.NewSimpleClientset(&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}}),
+			}
+			tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"}}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, *ts.DeepCopy())
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+
+			containers := map[string]corev1.Container{}
+			for _, c := range got.Spec.Containers {
+				containers[c.Name] = c
+			}
+			if db := containers["sidecar-db"]; db.TerminationMessagePath != "" || len(db.Command) != 0 {
+				t.Errorf("expected the sidecar without results to be unchanged, got %v", db)
+			}
+			proxy := containers["sidecar-proxy"]
+			if d := cmp.Diff([]string{entrypointBinary}, proxy.Command); d != "" {
+				t.Errorf("command %s", diff.PrintWantGot(d))
+			}
+			wantArgs := []string{
+				"-stop_file", "/tekton/sidecar-stop/stop",
+				"-termination_path", "/tekton/termination",
+				"-step_metadata_dir", "/tekton/sidecar-run/1/status",
+				"-results", "coverage,requests",
+				"-entrypoint", "proxy",
+				"--",
+				"--listen", ":8080",
+			}
+			if d := cmp.Diff(wantArgs, proxy.Args); d != "" {
+				t.Errorf("args %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff("/tekton/termination", proxy.TerminationMessagePath); d != "" {
+				t.Errorf("termination message path %s", diff.PrintWantGot(d))
+			}
+			wantMounts := []corev1.VolumeMount{binROMount, sidecarRunMount, sidecarStopMount, resultsMount}
+			if d := cmp.Diff(wantMounts, proxy.VolumeMounts); d != "" {
+				t.Errorf("volume mounts %s", diff.PrintWantGot(d))
+			}
+			cache := containers["sidecar-cache"]
+			wantArgs = []string{
+				"-stop_file", "/tekton/sidecar-stop/stop",
+				"-termination_path", "/tekton/termination",
+				"-step_metadata_dir", "/tekton/sidecar-run/2/status",
+				"-pre_stop", `["cache","flush"]`,
+				"-stop_signal", "SIGINT",
+				"-stop_grace_period", "1m0s",
+				"-entrypoint", "cache",
+				"--",
+			}
+			if d := cmp.Diff(wantArgs, cache.Args); d != "" {
+				t.Errorf("args %s", diff.PrintWantGot(d))
+			}
+			wantMounts = []corev1.VolumeMount{binROMount, sidecarRunMount, sidecarStopMount}
+			if d := cmp.Diff(wantMounts, cache.VolumeMounts); d != "" {
+				t.Errorf("volume mounts %s", diff.PrintWantGot(d))
+			}
+			volumes := sets.NewString()
+			for _, v := range got.Spec.Volumes {
+				volumes.Insert(v.Name)
+			}
+			if !volumes.HasAll(sidecarRunVolumeName, sidecarStopVolumeName) {
+				t.Errorf("expected the volumes %s and %s in %v", sidecarRunVolumeName, sidecarStopVolumeName, got.Spec.Volumes)
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_EntrypointSidecars(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Results: []v1beta1.TaskResult{{Name: "coverage", Type: v1beta1.ResultsTypeString}, {Name: "version", Type: v1beta1.ResultsTypeString}},
+		Sidecars: []v1beta1.Sidecar{{
+			Name: "db",
+		}, {
+			Name:    "proxy",
+			Results: []v1beta1.SidecarResult{{Name: "coverage"}},
+		}},
+	}
+	step := corev1.ContainerStatus{
+		Name: "step-test",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Message: `[{"key":"coverage","value":"0%","type":1},{"key":"version","value":"v1","type":1}]`,
+		}},
+	}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	for _, tc := range []struct {
+		desc        string
+		phase       corev1.PodPhase
+		statuses    []corev1.ContainerStatus
+		wantStatus  corev1.ConditionStatus
+		wantResults []v1beta1.TaskRunResult
+	}{{
+		desc:  "awaiting the sidecars",
+		phase: corev1.PodRunning,
+		statuses: []corev1.ContainerStatus{step, {
+			Name:  "sidecar-db",
+			State: running,
+		}, {
+			Name:  "sidecar-proxy",
+			State: running,
+		}},
+		wantStatus: corev1.ConditionUnknown,
+	}, {
+		desc:  "sidecars stopped",
+		phase: corev1.PodFailed,
+		statuses: []corev1.ContainerStatus{step, {
+			// The sidecar without results fails to start on the nop image.
+			Name:  "sidecar-db",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 128, Reason: "StartError"}},
+		}, {
+			Name: "sidecar-proxy",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				Message: `[{"key":"coverage","value":"87%","type":1}]`,
+			}},
+		}},
+		wantStatus: corev1.ConditionTrue,
+		wantResults: []v1beta1.TaskRunResult{{
+			Name:  "coverage",
+			Type:  v1beta1.ResultsTypeString,
+			Value: *v1beta1.NewStructuredValues("87%"),
+		}, {
+			Name:  "version",
+			Type:  v1beta1.ResultsTypeString,
+			Value: *v1beta1.NewStructuredValues("v1"),
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Status:     corev1.PodStatus{Phase: tc.phase, ContainerStatuses: tc.statuses},
+			}
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			got, err := MakeTaskRunStatus(context.Background(), logtesting.TestLogger(t), tr, pod, fakek8s.NewSimpleClientset(), ts)
+			if err != nil {
+				t.Fatalf("MakeTaskRunStatus: %v", err)
+			}
+			if d := cmp.Diff(tc.wantStatus, got.GetCondition(apis.ConditionSucceeded).Status); d != "" {
+				t.Errorf("status %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantResults, got.TaskRunResults); d != "" {
+				t.Errorf("results %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStopSidecars_StopOnRequest(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "step-test",
+				Image: "image",
+			}, {
+				Name:         "sidecar-db",
+				Image:        "postgres",
+				VolumeMounts: []corev1.VolumeMount{sidecarStopMount},
+			}, {
+				Name:  "sidecar-proxy",
+				Image: "proxy",
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-test",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:  "sidecar-db",
+				State: running,
+			}, {
+				Name:  "sidecar-proxy",
+				State: running,
+			}},
+		},
+	}
+	kubeclient := fakek8s.NewSimpleClientset(pod)
+	got, err := StopSidecars(context.Background(), nopImage, kubeclient, pod.Namespace, pod.Name)
+	if err != nil {
+		t.Fatalf("StopSidecars: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{stopSidecarsAnnotation: stopSidecarsAnnotationValue}, got.Annotations); d != "" {
+		t.Errorf("annotations %s", diff.PrintWantGot(d))
+	}
+	// Only the sidecar which doesn't stop on request is stopped with the nop image.
+	var images []string
+	for _, c := range got.Spec.Containers {
+		images = append(images, c.Image)
+	}
+	if d := cmp.Diff([]string{"image", "postgres", nopImage}, images); d != "" {
+		t.Errorf("images %s", diff.PrintWantGot(d))
+	}
+}
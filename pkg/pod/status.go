@@ -81,6 +81,14 @@ const (
 	// it could be the content has changed, signature is invalid or public key is invalid
 	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
 
+	// ReasonImageVerificationFailed indicates that the image of a step or a sidecar fails
+	// the verification of its signatures when "verify-step-image-signatures" is enabled
+	ReasonImageVerificationFailed = "ImageVerificationFailed"
+
+	// ReasonServiceAccountNotAllowed indicates that the service account of the TaskRun
+	// is not allowed by the ServiceAccountPolicies of its namespace
+	ReasonServiceAccountNotAllowed = "ServiceAccountNotAllowed"
+
 	// timeFormat is RFC3339 with millisecond
 	timeFormat = "2006-01-02T15:04:05.000Z07:00"
 )
@@ -127,22 +135,29 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1beta
 
 	complete := areStepsComplete(pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
 
-	if complete {
-		updateCompletedTaskRunStatus(logger, trs, pod)
-	} else {
+	switch {
+	case IsAwaitingSidecars(pod, ts):
+		markStatusRunning(trs, v1beta1.TaskRunReasonRunning.String(), "Waiting for the Sidecars to stop")
+	case complete:
+		updateCompletedTaskRunStatus(logger, trs, pod, ts)
+	default:
 		updateIncompleteTaskRunStatus(trs, pod)
 	}
 
 	trs.PodName = pod.Name
 	trs.Steps = []v1beta1.StepState{}
 	trs.Sidecars = []v1beta1.SidecarState{}
+	trs.Workspaces = nil
+	if trs.Provenance != nil {
+		trs.Provenance.Executions = nil
+	}
 
 	var stepStatuses []corev1.ContainerStatus
 	var sidecarStatuses []corev1.ContainerStatus
 	for _, s := range pod.Status.ContainerStatuses {
 		if IsContainerStep(s.Name) {
 			stepStatuses = append(stepStatuses, s)
-		} else if isContainerSidecar(s.Name) {
+		} else if IsContainerSidecar(s.Name) {
 			sidecarStatuses = append(sidecarStatuses, s)
 		}
 	}
@@ -153,6 +168,9 @@ func MakeTaskRunStatus(ctx context.Context, logger *zap.SugaredLogger, tr v1beta
 	}
 
 	setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses, trs)
+	if err := setTaskRunResultsFromSidecars(logger, sidecarStatuses, &tr, ts); err != nil {
+		merr = multierror.Append(merr, err)
+	}
 
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
 
@@ -210,6 +228,8 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 					merr = multierror.Append(merr, err)
 				}
 
+				trs.Workspaces = updateWorkspaceDigestsFromResults(trs.Workspaces, results)
+
 				taskResults, filteredResults := filterResults(results, specResults)
 				if tr.IsDone() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
@@ -227,6 +247,17 @@ func setTaskRunStatusBasedOnStepStatus(ctx context.Context, logger *zap.SugaredL
 				if exitCode != nil {
 					state.Terminated.ExitCode = *exitCode
 				}
+				execution, err := extractStepExecutionFromResults(trimStepPrefix(s.Name), s.ImageID, state.Terminated, results)
+				if err != nil {
+					logger.Errorf("error extracting the execution of step %q in taskrun %q: %v", s.Name, tr.Name, err)
+					merr = multierror.Append(merr, err)
+				}
+				if execution != nil {
+					if trs.Provenance == nil {
+						trs.Provenance = &v1beta1.Provenance{}
+					}
+					trs.Provenance.Executions = append(trs.Provenance.Executions, *execution)
+				}
 			}
 		}
 		trs.Steps = append(trs.Steps, v1beta1.StepState{
@@ -251,6 +282,30 @@ func setTaskRunStatusBasedOnSidecarStatus(sidecarStatuses []corev1.ContainerStat
 	}
 }
 
+// setTaskRunResultsFromSidecars adds the results written by the sidecars, read
+// from their termination message, to the results of the TaskRun once it is done.
+func setTaskRunResultsFromSidecars(logger *zap.SugaredLogger, sidecarStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun, ts *v1beta1.TaskSpec) *multierror.Error {
+	containers := entrypointSidecars(ts)
+	if len(containers) == 0 || !tr.IsDone() {
+		return nil
+	}
+	var merr *multierror.Error
+	for _, s := range sidecarStatuses {
+		if !containers.Has(s.Name) || s.State.Terminated == nil || len(s.State.Terminated.Message) == 0 {
+			continue
+		}
+		results, err := termination.ParseMessage(logger, s.State.Terminated.Message)
+		if err != nil {
+			logger.Errorf("termination message of sidecar %q could not be parsed as JSON: %v", s.Name, err)
+			merr = multierror.Append(merr, err)
+			continue
+		}
+		taskResults, _ := filterResults(results, ts.Results)
+		tr.Status.TaskRunResults = append(tr.Status.TaskRunResults, taskResults...)
+	}
+	return merr
+}
+
 func createMessageFromResults(results []result.RunResult) (string, error) {
 	if len(results) == 0 {
 		return "", nil
@@ -293,6 +348,10 @@ func filterResults(results []result.RunResult, specResults []v1beta1.TaskResult)
 					Type:  v1beta1.ResultsType(v.Type),
 					Value: v,
 				}
+				// The value of a result of type file is the object written by the entrypoint.
+				if neededTypes[r.Key] == v1beta1.ResultsTypeFile && v.Type == v1beta1.ParamTypeObject {
+					taskRunResult.Type = v1beta1.ResultsTypeFile
+				}
 			}
 			taskResults = append(taskResults, taskRunResult)
 			filteredResults = append(filteredResults, r)
@@ -340,6 +399,82 @@ func extractStartedAtTimeFromResults(results []result.RunResult) (*metav1.Time,
 	return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
 }
 
+// updateWorkspaceDigestsFromResults records the workspace digests found in
+// the internal results of a step's termination message.
+func updateWorkspaceDigestsFromResults(workspaces []v1beta1.WorkspaceStatus, results []result.RunResult) []v1beta1.WorkspaceStatus {
+	for _, r := range results {
+		if r.ResultType != result.InternalTektonResultType {
+			continue
+		}
+		var name string
+		var start bool
+		switch {
+		case strings.HasPrefix(r.Key, result.WorkspaceStartDigestKeyPrefix):
+			name, start = strings.TrimPrefix(r.Key, result.WorkspaceStartDigestKeyPrefix), true
+		case strings.HasPrefix(r.Key, result.WorkspaceEndDigestKeyPrefix):
+			name = strings.TrimPrefix(r.Key, result.WorkspaceEndDigestKeyPrefix)
+		default:
+			continue
+		}
+		idx := -1
+		for i := range workspaces {
+			if workspaces[i].Name == name {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			workspaces = append(workspaces, v1beta1.WorkspaceStatus{Name: name})
+			idx = len(workspaces) - 1
+		}
+		if start {
+			workspaces[idx].StartDigest = r.Value
+		} else {
+			workspaces[idx].EndDigest = r.Value
+		}
+	}
+	return workspaces
+}
+
+// extractStepExecutionFromResults returns the record of the command executed by a step,
+// if the entrypoint reported it in the internal results of the step's termination message.
+func extractStepExecutionFromResults(name, imageID string, state *corev1.ContainerStateTerminated, results []result.RunResult) (*v1beta1.StepExecution, error) {
+	var execution *v1beta1.StepExecution
+	var argvTruncated bool
+	finishedAt := state.FinishedAt
+	for _, r := range results {
+		if r.ResultType != result.InternalTektonResultType {
+			continue
+		}
+		switch r.Key {
+		case result.ExecutionArgvKey:
+			var argv []string
+			if err := json.Unmarshal([]byte(r.Value), &argv); err != nil {
+				return nil, fmt.Errorf("could not parse argv value %q in %s field: %w", r.Value, r.Key, err)
+			}
+			execution = &v1beta1.StepExecution{Argv: argv}
+		case result.ExecutionArgvTruncatedKey:
+			argvTruncated = true
+		case result.ExecutionFinishedAtKey:
+			t, err := time.Parse(timeFormat, r.Value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse time value %q in %s field: %w", r.Value, r.Key, err)
+			}
+			finishedAt = metav1.NewTime(t)
+		}
+	}
+	if execution == nil {
+		return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
+	}
+	execution.Name = name
+	execution.ArgvTruncated = argvTruncated
+	execution.ImageID = imageID
+	execution.StartedAt = state.StartedAt.DeepCopy()
+	execution.FinishedAt = finishedAt.DeepCopy()
+	execution.ExitCode = state.ExitCode
+	return execution, nil
+}
+
 func extractExitCodeFromResults(results []result.RunResult) (*int32, error) {
 	for _, result := range results {
 		if result.Key == "ExitCode" {
@@ -355,13 +490,18 @@ func extractExitCodeFromResults(results []result.RunResult) (*int32, error) {
 	return nil, nil //nolint:nilnil // would be more ergonomic to return a sentinel error
 }
 
-func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
-	if DidTaskRunFail(pod) {
+func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod, ts *v1beta1.TaskSpec) {
+	// The sidecars run with the entrypoint are stopped before the TaskRun completes, and
+	// the other sidecars may then fail to restart on the nop image, failing the Pod.
+	if reason, exitCode, ok := mappedExitCodeReason(pod, ts); ok {
+		markStatusSuccessWithReason(trs, reason, fmt.Sprintf("The last Step exited with the code %d", exitCode))
+	} else if DidTaskRunFail(pod) && !(len(entrypointSidecars(ts)) > 0 && areStepsSuccessful(pod)) {
 		msg := getFailureMessage(logger, pod)
 		markStatusFailure(trs, v1beta1.TaskRunReasonFailed.String(), msg)
 	} else {
 		markStatusSuccess(trs)
 	}
+	updateConditionFromStepStatus(logger, trs, pod)
 
 	// update tr completed time
 	trs.CompletionTime = &metav1.Time{Time: time.Now()}
@@ -427,6 +567,51 @@ func areStepsComplete(pod *corev1.Pod) bool {
 	return stepsComplete
 }
 
+// areStepsSuccessful returns true if all the steps of the Pod have terminated successfully.
+func areStepsSuccessful(pod *corev1.Pod) bool {
+	succeeded := false
+	for _, s := range pod.Status.ContainerStatuses {
+		if IsContainerStep(s.Name) {
+			if s.State.Terminated == nil || s.State.Terminated.ExitCode != 0 || isOOMKilled(s) {
+				return false
+			}
+			succeeded = true
+		}
+	}
+	return succeeded
+}
+
+// mappedExitCodeReason returns the reason the exit code of the last step of the Pod is mapped to
+// by the exitCodeMappings of the Task, when all the other steps succeeded.
+func mappedExitCodeReason(pod *corev1.Pod, ts *v1beta1.TaskSpec) (string, int32, bool) {
+	if ts == nil || len(ts.ExitCodeMappings) == 0 {
+		return "", 0, false
+	}
+	var last *corev1.ContainerStatus
+	for i, s := range pod.Status.ContainerStatuses {
+		if !IsContainerStep(s.Name) {
+			continue
+		}
+		if last != nil && last.State.Terminated.ExitCode != 0 {
+			return "", 0, false
+		}
+		if s.State.Terminated == nil || isOOMKilled(s) {
+			return "", 0, false
+		}
+		last = &pod.Status.ContainerStatuses[i]
+	}
+	if last == nil {
+		return "", 0, false
+	}
+	exitCode := last.State.Terminated.ExitCode
+	for _, m := range ts.ExitCodeMappings {
+		if m.ExitCode == exitCode {
+			return m.Reason, exitCode, true
+		}
+	}
+	return "", 0, false
+}
+
 func getFailureMessage(logger *zap.SugaredLogger, pod *corev1.Pod) string {
 	// If a pod was evicted, use the pods status message before trying to
 	// determine a failure message from the pod's container statuses. A
@@ -599,6 +784,16 @@ func markStatusSuccess(trs *v1beta1.TaskRunStatus) {
 	})
 }
 
+// markStatusSuccessWithReason sets taskrun status to success with the specified reason
+func markStatusSuccessWithReason(trs *v1beta1.TaskRunStatus, reason, message string) {
+	trs.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
 // sortPodContainerStatuses reorders a pod's container statuses so that
 // they're in the same order as the step containers from the TaskSpec.
 func sortPodContainerStatuses(podContainerStatuses []corev1.ContainerStatus, podSpecContainers []corev1.Container) {
@@ -966,6 +966,116 @@ func TestMakeTaskRunStatus(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "workspace digests",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-first",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"WorkspaceStartDigest.source","value":"sha256:aaa","type":3}]`,
+					},
+				},
+			}, {
+				Name: "step-last",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"WorkspaceEndDigest.source","value":"sha256:bbb","type":3}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{}},
+					Name:          "first",
+					ContainerName: "step-first",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{}},
+					Name:          "last",
+					ContainerName: "step-last",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				Workspaces: []v1beta1.WorkspaceStatus{{
+					Name:        "source",
+					StartDigest: "sha256:aaa",
+					EndDigest:   "sha256:bbb",
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
+	}, {
+		desc: "execution log",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "step-build",
+				ImageID: "docker.io/library/golang@sha256:aaa",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"StartedAt","value":"2023-05-01T10:00:00.000Z","type":3},{"key":"Argv","value":"[\"go\",\"build\",\"./...\"]","type":3},{"key":"FinishedAt","value":"2023-05-01T10:01:00.000Z","type":3}]`,
+					},
+				},
+			}, {
+				Name:    "step-test",
+				ImageID: "docker.io/library/golang@sha256:aaa",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Message:  `[{"key":"StartedAt","value":"2023-05-01T10:01:00.000Z","type":3},{"key":"Argv","value":"[\"go\"]","type":3},{"key":"ArgvTruncated","value":"true","type":3},{"key":"FinishedAt","value":"2023-05-01T10:02:00.000Z","type":3}]`,
+					},
+				},
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusFailure(v1beta1.TaskRunReasonFailed.String(), "\"step-test\" exited with code 1 (image: \"docker.io/library/golang@sha256:aaa\"); for logs run: kubectl -n foo logs pod -c step-test\n"),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							StartedAt: metav1.NewTime(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)),
+						}},
+					Name:          "build",
+					ContainerName: "step-build",
+					ImageID:       "docker.io/library/golang@sha256:aaa",
+				}, {
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:  1,
+							StartedAt: metav1.NewTime(time.Date(2023, 5, 1, 10, 1, 0, 0, time.UTC)),
+						}},
+					Name:          "test",
+					ContainerName: "step-test",
+					ImageID:       "docker.io/library/golang@sha256:aaa",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				Provenance: &v1beta1.Provenance{
+					Executions: []v1beta1.StepExecution{{
+						Name:       "build",
+						Argv:       []string{"go", "build", "./..."},
+						ImageID:    "docker.io/library/golang@sha256:aaa",
+						StartedAt:  &metav1.Time{Time: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)},
+						FinishedAt: &metav1.Time{Time: time.Date(2023, 5, 1, 10, 1, 0, 0, time.UTC)},
+					}, {
+						Name:          "test",
+						Argv:          []string{"go"},
+						ArgvTruncated: true,
+						ImageID:       "docker.io/library/golang@sha256:aaa",
+						StartedAt:     &metav1.Time{Time: time.Date(2023, 5, 1, 10, 1, 0, 0, time.UTC)},
+						FinishedAt:    &metav1.Time{Time: time.Date(2023, 5, 1, 10, 2, 0, 0, time.UTC)},
+						ExitCode:      1,
+					}},
+				},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}, {
 		desc: "correct TaskRun status step order regardless of pod container status order",
 		pod: corev1.Pod{
@@ -1519,6 +1629,49 @@ func TestMakeTaskRunStatusAlpha(t *testing.T) {
 				CompletionTime: &metav1.Time{Time: time.Now()},
 			},
 		},
+	}, {
+		desc: "test file result",
+		podStatus: corev1.PodStatus{
+			Phase: corev1.PodSucceeded,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "step-bar",
+				State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{
+						Message: `[{"key":"report","value":"{\"digest\":\"sha256:1234\",\"uri\":\"https://store/default/sha256/1234\"}","type":1}]`,
+					},
+				},
+			}},
+		},
+		taskSpec: v1beta1.TaskSpec{
+			Results: []v1beta1.TaskResult{{
+				Name: "report",
+				Type: v1beta1.ResultsTypeFile,
+			}},
+		},
+		want: v1beta1.TaskRunStatus{
+			Status: statusSuccess(),
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps: []v1beta1.StepState{{
+					ContainerState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							Message: `[{"key":"report","value":"{\"digest\":\"sha256:1234\",\"uri\":\"https://store/default/sha256/1234\"}","type":1}]`,
+						}},
+					Name:          "bar",
+					ContainerName: "step-bar",
+				}},
+				Sidecars: []v1beta1.SidecarState{},
+				TaskRunResults: []v1beta1.TaskRunResult{{
+					Name: "report",
+					Type: v1beta1.ResultsTypeFile,
+					Value: *v1beta1.NewObject(map[string]string{
+						v1beta1.FileResultURIKey:    "https://store/default/sha256/1234",
+						v1beta1.FileResultDigestKey: "sha256:1234",
+					}),
+				}},
+				// We don't actually care about the time, just that it's not nil
+				CompletionTime: &metav1.Time{Time: time.Now()},
+			},
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			now := metav1.Now()
@@ -1683,6 +1836,146 @@ func TestMakeRunStatusJSONError(t *testing.T) {
 	}
 }
 
+func TestMakeTaskRunStatus_ExitCodeMappings(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		ExitCodeMappings: []v1beta1.ExitCodeMapping{{
+			ExitCode: 3,
+			Reason:   "SucceededWithWarnings",
+		}, {
+			ExitCode: 4,
+			Reason:   "Skipped",
+		}},
+	}
+	terminated := func(name string, exitCode int32) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		}
+	}
+	for _, c := range []struct {
+		desc         string
+		stepStatuses []corev1.ContainerStatus
+		want         *apis.Condition
+	}{{
+		desc:         "last step exits with a mapped exit code",
+		stepStatuses: []corev1.ContainerStatus{terminated("step-lint", 0), terminated("step-report", 3)},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  "SucceededWithWarnings",
+			Message: "The last Step exited with the code 3",
+		},
+	}, {
+		desc:         "last step exits with an unmapped exit code",
+		stepStatuses: []corev1.ContainerStatus{terminated("step-lint", 0), terminated("step-report", 5)},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.TaskRunReasonFailed.String(),
+			Message: `"step-report" exited with code 5 (image: ""); for logs run: kubectl -n foo logs pod -c step-report` + "\n",
+		},
+	}, {
+		desc:         "step before the last one exits with a mapped exit code",
+		stepStatuses: []corev1.ContainerStatus{terminated("step-lint", 4), terminated("step-report", 0)},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.TaskRunReasonFailed.String(),
+			Message: `"step-lint" exited with code 4 (image: ""); for logs run: kubectl -n foo logs pod -c step-lint` + "\n",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodFailed,
+					ContainerStatuses: c.stepStatuses,
+				},
+			}
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, pod, fakek8s.NewSimpleClientset(), ts)
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+			if d := cmp.Diff(c.want, got.GetCondition(apis.ConditionSucceeded), ignoreVolatileTime); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatus_StepStatus(t *testing.T) {
+	terminated := func(name string, exitCode int32, message string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Message: message}},
+		}
+	}
+	for _, c := range []struct {
+		desc         string
+		phase        corev1.PodPhase
+		stepStatuses []corev1.ContainerStatus
+		want         *apis.Condition
+	}{{
+		desc:  "failed step writing a reason and a message",
+		phase: corev1.PodFailed,
+		stepStatuses: []corev1.ContainerStatus{
+			terminated("step-lint", 1, `[{"key":"StatusReason","value":"MissingLicense","type":3},{"key":"StatusMessage","value":"The LICENSE file is missing, add it at the root of the repository.","type":3}]`),
+		},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  "MissingLicense",
+			Message: "The LICENSE file is missing, add it at the root of the repository.",
+		},
+	}, {
+		desc:  "successful steps writing messages",
+		phase: corev1.PodSucceeded,
+		stepStatuses: []corev1.ContainerStatus{
+			terminated("step-build", 0, `[{"key":"StatusMessage","value":"Built 3 images","type":3}]`),
+			terminated("step-push", 0, `[{"key":"StatusMessage","value":"Pushed 3 images","type":3}]`),
+		},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.TaskRunReasonSuccessful.String(),
+			Message: "Pushed 3 images",
+		},
+	}, {
+		desc:  "reason which isn't CamelCase",
+		phase: corev1.PodSucceeded,
+		stepStatuses: []corev1.ContainerStatus{
+			terminated("step-build", 0, `[{"key":"StatusReason","value":"built with warnings","type":3}]`),
+		},
+		want: &apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  v1beta1.TaskRunReasonSuccessful.String(),
+			Message: "All Steps have completed executing",
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+				Status: corev1.PodStatus{
+					Phase:             c.phase,
+					ContainerStatuses: c.stepStatuses,
+				},
+			}
+			tr := v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"}}
+			logger, _ := logging.NewLogger("", "status")
+			got, err := MakeTaskRunStatus(context.Background(), logger, tr, pod, fakek8s.NewSimpleClientset(), &v1beta1.TaskSpec{})
+			if err != nil {
+				t.Errorf("MakeTaskRunResult: %s", err)
+			}
+			if d := cmp.Diff(c.want, got.GetCondition(apis.ConditionSucceeded), ignoreVolatileTime); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestSidecarsReady(t *testing.T) {
 	for _, c := range []struct {
 		desc     string
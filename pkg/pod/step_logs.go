@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ArchiveStepLogs uploads the logs of the steps of the TaskRun pod to the http(s)
+// object store with PUT requests, at <store>/<namespace>/<pod>/<container>.log,
+// and returns where they are archived. The steps which never started are skipped.
+func ArchiveStepLogs(ctx context.Context, kubeclient kubernetes.Interface, client *http.Client, store string, tr *v1beta1.TaskRun) ([]v1beta1.StepLog, error) {
+	var archived []v1beta1.StepLog
+	for _, step := range tr.Status.Steps {
+		if step.Running == nil && step.Terminated == nil {
+			continue
+		}
+		url := fmt.Sprintf("%s/%s/%s/%s.log", store, tr.Namespace, tr.Status.PodName, step.ContainerName)
+		if err := archiveContainerLog(ctx, kubeclient, client, tr.Namespace, tr.Status.PodName, step.ContainerName, url); err != nil {
+			return nil, fmt.Errorf("error archiving the log of step %q: %w", step.Name, err)
+		}
+		archived = append(archived, v1beta1.StepLog{Name: step.Name, ContainerName: step.ContainerName, URL: url})
+	}
+	return archived, nil
+}
+
+func archiveContainerLog(ctx context.Context, kubeclient kubernetes.Interface, client *http.Client, namespace, pod, container, url string) error {
+	logs, err := kubeclient.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+	put, err := http.NewRequestWithContext(ctx, http.MethodPut, url, logs)
+	if err != nil {
+		return err
+	}
+	put.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	resp, err := client.Do(put)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("uploading to %s: %s", url, resp.Status)
+	}
+	return nil
+}
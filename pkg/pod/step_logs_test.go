@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func stepLogsTaskRun() *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "taskrun-pod",
+			Steps: []v1beta1.StepState{{
+				Name:           "build",
+				ContainerName:  "step-build",
+				ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:           "test",
+				ContainerName:  "step-test",
+				ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				Name:           "push",
+				ContainerName:  "step-push",
+				ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+			}},
+		}},
+	}
+}
+
+func TestArchiveStepLogs(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		objects[r.URL.Path] = string(b)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	got, err := ArchiveStepLogs(context.Background(), fakek8s.NewSimpleClientset(), server.Client(), server.URL+"/logs", stepLogsTaskRun())
+	if err != nil {
+		t.Fatalf("ArchiveStepLogs: %v", err)
+	}
+	want := []v1beta1.StepLog{{
+		Name:          "build",
+		ContainerName: "step-build",
+		URL:           server.URL + "/logs/foo/taskrun-pod/step-build.log",
+	}, {
+		Name:          "test",
+		ContainerName: "step-test",
+		URL:           server.URL + "/logs/foo/taskrun-pod/step-test.log",
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("step logs %s", diff.PrintWantGot(d))
+	}
+	wantObjects := map[string]string{
+		"/logs/foo/taskrun-pod/step-build.log": "fake logs",
+		"/logs/foo/taskrun-pod/step-test.log":  "fake logs",
+	}
+	if d := cmp.Diff(wantObjects, objects); d != "" {
+		t.Errorf("archived logs %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestArchiveStepLogs_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	if _, err := ArchiveStepLogs(context.Background(), fakek8s.NewSimpleClientset(), server.Client(), server.URL, stepLogsTaskRun()); err == nil {
+		t.Error("expected an error archiving the logs to a store refusing them")
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// StepProgressFile is the name of the file in the run directory of a step which
+	// its command writes percent-complete hints to when the step progress is reported.
+	StepProgressFile = "progress"
+	// StepProgressFileEnvVar is the environment variable the entrypoint sets to the
+	// path of the progress file of the step.
+	StepProgressFileEnvVar = "TEKTON_STEP_PROGRESS_FILE"
+
+	// StepProgressStarted is the event of the marker written when the command of a step starts.
+	StepProgressStarted = "started"
+	// StepProgressPercent is the event of the marker written when the percent-complete
+	// hint in the progress file of a step changes.
+	StepProgressPercent = "progress"
+	// StepProgressFinished is the event of the marker written when the command of a step exits.
+	StepProgressFinished = "finished"
+
+	// StepProgressPollingPeriod is how often the progress of the steps of running
+	// TaskRuns is read.
+	StepProgressPollingPeriod = 10 * time.Second
+
+	stepProgressMarkerKey = "tekton.dev/stepProgress"
+)
+
+// stepProgressMarkerPrefix starts the lines of the progress markers, which are
+// single JSON objects keyed by stepProgressMarkerKey.
+var stepProgressMarkerPrefix = fmt.Sprintf("{%q:", stepProgressMarkerKey)
+
+// StepProgressMarker is a progress marker the entrypoint writes to the stdout of a step.
+type StepProgressMarker struct {
+	// Event is one of StepProgressStarted, StepProgressPercent and StepProgressFinished.
+	Event string `json:"event"`
+	// Percent is the percent-complete hint of StepProgressPercent markers.
+	Percent *int32 `json:"percent,omitempty"`
+	// Time is when the marker was written.
+	Time time.Time `json:"time"`
+}
+
+// WriteStepProgressMarker writes the marker to w as a single line.
+func WriteStepProgressMarker(w io.Writer, m StepProgressMarker) error {
+	b, err := json.Marshal(map[string]StepProgressMarker{stepProgressMarkerKey: m})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", b)
+	return err
+}
+
+// ParseStepProgressMarkers returns the progress markers in the log read from r,
+// skipping its other lines.
+func ParseStepProgressMarkers(r io.Reader) ([]StepProgressMarker, error) {
+	var markers []StepProgressMarker
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if strings.HasPrefix(line, stepProgressMarkerPrefix) {
+			var marker map[string]StepProgressMarker
+			if jsonErr := json.Unmarshal([]byte(line), &marker); jsonErr == nil {
+				markers = append(markers, marker[stepProgressMarkerKey])
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return markers, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ReadStepProgress returns the progress of the steps of the TaskRun, updated with the
+// markers written to the logs of their containers since their last reported progress.
+// The steps which haven't started or whose progress is finished aren't read again.
+func ReadStepProgress(ctx context.Context, kubeclient kubernetes.Interface, tr *v1beta1.TaskRun) ([]v1beta1.StepProgress, error) {
+	previous := map[string]v1beta1.StepProgress{}
+	for _, p := range tr.Status.StepProgress {
+		previous[p.ContainerName] = p
+	}
+	var progress []v1beta1.StepProgress
+	for _, step := range tr.Status.Steps {
+		p, ok := previous[step.ContainerName]
+		if !ok {
+			p = v1beta1.StepProgress{Name: step.Name, ContainerName: step.ContainerName}
+		}
+		if p.Phase != v1beta1.StepProgressFinished && (step.Running != nil || step.Terminated != nil) {
+			markers, err := readStepProgressMarkers(ctx, kubeclient, tr.Namespace, tr.Status.PodName, step.ContainerName, p.LastUpdateTime)
+			if err != nil {
+				return nil, fmt.Errorf("error reading the progress of step %q: %w", step.Name, err)
+			}
+			p = applyStepProgressMarkers(p, markers)
+		}
+		if p.Phase != "" {
+			progress = append(progress, p)
+		}
+	}
+	return progress, nil
+}
+
+func readStepProgressMarkers(ctx context.Context, kubeclient kubernetes.Interface, namespace, pod, container string, since *metav1.Time) ([]StepProgressMarker, error) {
+	logs, err := kubeclient.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{Container: container, SinceTime: since}).Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer logs.Close()
+	return ParseStepProgressMarkers(logs)
+}
+
+// applyStepProgressMarkers returns the progress after the markers. The markers older
+// than the last update are skipped, while the ones written in the same second are
+// applied again since the time of the last update is truncated to the second.
+func applyStepProgressMarkers(p v1beta1.StepProgress, markers []StepProgressMarker) v1beta1.StepProgress {
+	for _, m := range markers {
+		if p.LastUpdateTime != nil && m.Time.Before(p.LastUpdateTime.Time) {
+			continue
+		}
+		switch m.Event {
+		case StepProgressStarted:
+			p.Phase = v1beta1.StepProgressStarted
+		case StepProgressPercent:
+			if m.Percent == nil {
+				continue
+			}
+			p.Phase = v1beta1.StepProgressStarted
+			percent := *m.Percent
+			p.Percent = &percent
+		case StepProgressFinished:
+			p.Phase = v1beta1.StepProgressFinished
+		default:
+			continue
+		}
+		p.LastUpdateTime = &metav1.Time{Time: m.Time}
+	}
+	return p
+}
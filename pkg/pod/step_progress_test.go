@@ -0,0 +1,156 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStepProgressMarkers(t *testing.T) {
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	percent := int32(40)
+	markers := []StepProgressMarker{{
+		Event: StepProgressStarted,
+		Time:  start,
+	}, {
+		Event:   StepProgressPercent,
+		Percent: &percent,
+		Time:    start.Add(time.Second),
+	}, {
+		Event: StepProgressFinished,
+		Time:  start.Add(2 * time.Second),
+	}}
+	var log bytes.Buffer
+	log.WriteString("building...\n")
+	for _, m := range markers {
+		if err := WriteStepProgressMarker(&log, m); err != nil {
+			t.Fatalf("WriteStepProgressMarker: %v", err)
+		}
+		log.WriteString(`{"tekton.dev/stepProgress": not a marker}` + "\n")
+	}
+	log.WriteString("done")
+
+	got, err := ParseStepProgressMarkers(&log)
+	if err != nil {
+		t.Fatalf("ParseStepProgressMarkers: %v", err)
+	}
+	if d := cmp.Diff(markers, got); d != "" {
+		t.Errorf("markers %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyStepProgressMarkers(t *testing.T) {
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	forty, sixty := int32(40), int32(60)
+	for _, tc := range []struct {
+		desc    string
+		before  v1beta1.StepProgress
+		markers []StepProgressMarker
+		want    v1beta1.StepProgress
+	}{{
+		desc: "started",
+		markers: []StepProgressMarker{{
+			Event: StepProgressStarted,
+			Time:  start,
+		}},
+		want: v1beta1.StepProgress{Phase: v1beta1.StepProgressStarted, LastUpdateTime: &metav1.Time{Time: start}},
+	}, {
+		desc: "percent complete",
+		markers: []StepProgressMarker{{
+			Event: StepProgressStarted,
+			Time:  start,
+		}, {
+			Event:   StepProgressPercent,
+			Percent: &forty,
+			Time:    start.Add(time.Second),
+		}, {
+			Event: "unknown",
+			Time:  start.Add(2 * time.Second),
+		}},
+		want: v1beta1.StepProgress{Phase: v1beta1.StepProgressStarted, Percent: &forty, LastUpdateTime: &metav1.Time{Time: start.Add(time.Second)}},
+	}, {
+		desc:   "markers older than the last update skipped",
+		before: v1beta1.StepProgress{Phase: v1beta1.StepProgressStarted, Percent: &sixty, LastUpdateTime: &metav1.Time{Time: start.Add(time.Second)}},
+		markers: []StepProgressMarker{{
+			Event:   StepProgressPercent,
+			Percent: &forty,
+			Time:    start,
+		}, {
+			Event:   StepProgressPercent,
+			Percent: &sixty,
+			Time:    start.Add(1500 * time.Millisecond),
+		}, {
+			Event: StepProgressFinished,
+			Time:  start.Add(2 * time.Second),
+		}},
+		want: v1beta1.StepProgress{Phase: v1beta1.StepProgressFinished, Percent: &sixty, LastUpdateTime: &metav1.Time{Time: start.Add(2 * time.Second)}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := applyStepProgressMarkers(tc.before, tc.markers)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("progress %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReadStepProgress(t *testing.T) {
+	finished := v1beta1.StepProgress{
+		Name:           "build",
+		ContainerName:  "step-build",
+		Phase:          v1beta1.StepProgressFinished,
+		LastUpdateTime: &metav1.Time{Time: time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			PodName: "taskrun-pod",
+			Steps: []v1beta1.StepState{{
+				Name:           "build",
+				ContainerName:  "step-build",
+				ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:           "test",
+				ContainerName:  "step-test",
+				ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}, {
+				Name:           "push",
+				ContainerName:  "step-push",
+				ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+			}},
+			StepProgress: []v1beta1.StepProgress{finished},
+		}},
+	}
+	// The logs of the fake clientset have no progress markers.
+	got, err := ReadStepProgress(context.Background(), fakek8s.NewSimpleClientset(), tr)
+	if err != nil {
+		t.Fatalf("ReadStepProgress: %v", err)
+	}
+	if d := cmp.Diff([]v1beta1.StepProgress{finished}, got); d != "" {
+		t.Errorf("progress %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"regexp"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/termination"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// StepStatusReasonFile is the name of the file in the run directory of a step which
+	// its command writes the reason of the status of the TaskRun to.
+	StepStatusReasonFile = "reason"
+	// StepStatusMessageFile is the name of the file in the run directory of a step which
+	// its command writes the message of the status of the TaskRun to.
+	StepStatusMessageFile = "message"
+	// StepStatusReasonFileEnvVar is the environment variable the entrypoint sets to the
+	// path of the status reason file of the step.
+	StepStatusReasonFileEnvVar = "TEKTON_STEP_STATUS_REASON_FILE"
+	// StepStatusMessageFileEnvVar is the environment variable the entrypoint sets to the
+	// path of the status message file of the step.
+	StepStatusMessageFileEnvVar = "TEKTON_STEP_STATUS_MESSAGE_FILE"
+)
+
+// stepStatusReasonRegex matches the reasons the steps can write, which are CamelCase
+// like the other reasons of conditions.
+var stepStatusReasonRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// getStepStatus returns the reason and the message written by the last step which wrote
+// any, as reported in the internal results of the termination messages of the steps. A
+// reason which isn't CamelCase is ignored.
+func getStepStatus(logger *zap.SugaredLogger, pod *corev1.Pod) (string, string) {
+	var reason, message string
+	for _, s := range pod.Status.ContainerStatuses {
+		if !IsContainerStep(s.Name) || s.State.Terminated == nil || len(s.State.Terminated.Message) == 0 {
+			continue
+		}
+		results, err := termination.ParseMessage(logger, s.State.Terminated.Message)
+		if err != nil {
+			continue
+		}
+		var stepReason, stepMessage string
+		for _, r := range results {
+			if r.ResultType != result.InternalTektonResultType {
+				continue
+			}
+			switch r.Key {
+			case result.StatusReasonKey:
+				if stepStatusReasonRegex.MatchString(r.Value) {
+					stepReason = r.Value
+				} else {
+					logger.Warnf("ignoring the status reason %q written by step %q, which isn't CamelCase", r.Value, s.Name)
+				}
+			case result.StatusMessageKey:
+				stepMessage = r.Value
+			}
+		}
+		if stepReason != "" || stepMessage != "" {
+			reason, message = stepReason, stepMessage
+		}
+	}
+	return reason, message
+}
+
+// updateConditionFromStepStatus replaces the reason and the message of the condition of
+// the completed TaskRun by those written by the steps, if any.
+func updateConditionFromStepStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
+	reason, message := getStepStatus(logger, pod)
+	cond := trs.GetCondition(apis.ConditionSucceeded)
+	if cond == nil || (reason == "" && message == "") {
+		return
+	}
+	updated := cond.DeepCopy()
+	if reason != "" {
+		updated.Reason = reason
+	}
+	if message != "" {
+		updated.Message = message
+	}
+	trs.SetCondition(updated)
+}
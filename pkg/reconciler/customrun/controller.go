@@ -21,10 +21,14 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	cloudeventsinkinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/cloudeventsink"
 	customruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun"
 	customrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/customrun"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
 	cacheclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cache"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/sharding"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
@@ -42,6 +46,7 @@ func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
 
 		c := &Reconciler{
 			cloudEventClient: cloudeventclient.Get(ctx),
+			cloudEventSinks:  cloudeventclient.NewSinks(cloudeventsinkinformer.Get(ctx).Lister(), kubeclient.Get(ctx)),
 			cacheClient:      cacheclient.Get(ctx),
 		}
 		impl := customrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
@@ -52,6 +57,9 @@ func NewController() func(context.Context, configmap.Watcher) *controller.Impl {
 			}
 		})
 
+		sharding.Apply(ctx, impl, customRunInformer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, customRunInformer.Informer(), "customrun")
+
 		customRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
 		return impl
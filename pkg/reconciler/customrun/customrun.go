@@ -34,6 +34,7 @@ import (
 // Reconciler implements controller.Reconciler for Configuration resources.
 type Reconciler struct {
 	cloudEventClient cloudevent.CEClient
+	cloudEventSinks  *cloudevent.Sinks
 	cacheClient      *lru.Cache
 }
 
@@ -49,6 +50,7 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, customRun *v1beta1.Custo
 	logger := logging.FromContext(ctx)
 	configs := config.FromContextOrDefaults(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx = cloudevent.WithSinks(ctx, c.cloudEventSinks)
 	ctx = cache.ToContext(ctx, c.cacheClient)
 	logger.Infof("Reconciling %s", customRun.Name)
 
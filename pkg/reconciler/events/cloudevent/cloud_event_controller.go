@@ -19,11 +19,13 @@ package cloudevent
 import (
 	"context"
 	"errors"
-	"time"
+	"net/http"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
-	lru "github.com/hashicorp/golang-lru"
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	cehttp "github.com/cloudevents/sdk-go/v2/protocol/http"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cache"
 	corev1 "k8s.io/api/core/v1"
@@ -34,40 +36,24 @@ import (
 	"knative.dev/pkg/logging"
 )
 
-// EmitCloudEvents emits CloudEvents (only) for object
+// EmitCloudEvents emits CloudEvents (only) for object to the "default-cloud-events-sink"
+// and to the CloudEventSinks selecting its namespace.
 func EmitCloudEvents(ctx context.Context, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
-
-	if sendCloudEvents {
-		err := SendCloudEventWithRetries(ctx, object)
-		if err != nil {
-			logger.Warnf("Failed to emit cloud events %v", err.Error())
-		}
+	if err := sendCloudEventToSinks(ctx, object); err != nil {
+		logger.Warnf("Failed to emit cloud events %v", err.Error())
 	}
 }
 
 // EmitCloudEventsWhenConditionChange emits CloudEvents when there is a change in condition
 func EmitCloudEventsWhenConditionChange(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	logger := logging.FromContext(ctx)
-	configs := config.FromContextOrDefaults(ctx)
-	sendCloudEvents := (configs.Defaults.DefaultCloudEventsSink != "")
-	if sendCloudEvents {
-		ctx = cloudevents.ContextWithTarget(ctx, configs.Defaults.DefaultCloudEventsSink)
-	}
-
-	if sendCloudEvents {
-		// Only send events if the new condition represents a change
-		if !equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
-			err := SendCloudEventWithRetries(ctx, object)
-			if err != nil {
-				logger.Warnf("Failed to emit cloud events %v", err.Error())
-			}
-		}
+	// Only send events if the new condition represents a change
+	if equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return
+	}
+	if err := sendCloudEventToSinks(ctx, object); err != nil {
+		logger.Warnf("Failed to emit cloud events %v", err.Error())
 	}
 }
 
@@ -77,28 +63,106 @@ func EmitCloudEventsWhenConditionChange(ctx context.Context, beforeCondition *ap
 // It accepts a runtime.Object to avoid making objectWithCondition public since
 // it's only used within the events/cloudevents packages.
 func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error {
-	var (
-		o           objectWithCondition
-		ok          bool
-		cacheClient *lru.Cache
-	)
-	if o, ok = object.(objectWithCondition); !ok {
+	ceClient, event, err := newCloudEvent(ctx, object)
+	if err != nil {
+		return err
+	}
+	// Events for CustomRuns require a cache of events that have been sent
+	if !emits(ctx, object, event.Type()) {
+		return nil
+	}
+	_, isCustomRun := object.(*v1beta1.CustomRun)
+	if isCustomRun && alreadySent(ctx, event) {
+		return nil
+	}
+	return sendWithRetries(ctx, ceClient, object, event)
+}
+
+// sendCloudEventToSinks sends a cloud event for the specified resource to the sinks
+// selecting its namespace which accept its type, like SendCloudEventWithRetries.
+func sendCloudEventToSinks(ctx context.Context, object runtime.Object) error {
+	o, ok := object.(objectWithCondition)
+	if !ok {
 		return errors.New("input object does not satisfy objectWithCondition")
 	}
-	logger := logging.FromContext(ctx)
-	ceClient := Get(ctx)
-	if ceClient == nil {
-		return errors.New("no cloud events client found in the context")
+	sinks := sinksFor(ctx, o.GetObjectMeta().GetNamespace())
+	if len(sinks) == 0 && !hasTransports(ctx) {
+		return nil
 	}
-	event, err := eventForObjectWithCondition(o)
+	ceClient, event, err := newCloudEvent(ctx, object)
 	if err != nil {
 		return err
 	}
-	// Events for CustomRuns require a cache of events that have been sent
 	_, isCustomRun := object.(*v1beta1.CustomRun)
-	if isCustomRun {
-		cacheClient = cache.Get(ctx)
+	return sendToSinks(ctx, ceClient, object, event, isCustomRun, sinks)
+}
+
+func newCloudEvent(ctx context.Context, object runtime.Object) (CEClient, *cloudevents.Event, error) {
+	o, ok := object.(objectWithCondition)
+	if !ok {
+		return nil, nil, errors.New("input object does not satisfy objectWithCondition")
+	}
+	ceClient := Get(ctx)
+	if ceClient == nil {
+		return nil, nil, errors.New("no cloud events client found in the context")
+	}
+	event, err := eventForObjectWithCondition(redact(ctx, o), config.FromContextOrDefaults(ctx).Defaults.DefaultCloudEventsFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ceClient, event, nil
+}
+
+// sendToSinks sends the event about object to each of the sinks which accepts its
+// type, in the background and with the retries of the sink, and publishes it to the
+// configured transports, unless the events config map filters it out. When useCache
+// is true, the event is not sent if the cache of the events that have been sent
+// already has it.
+func sendToSinks(ctx context.Context, ceClient CEClient, object runtime.Object, event *cloudevents.Event, useCache bool, sinks []*v1alpha1.CloudEventSink) error {
+	if !emits(ctx, object, event.Type()) {
+		return nil
+	}
+	var accepting []*v1alpha1.CloudEventSink
+	for _, sink := range sinks {
+		if sink.Accepts(event.Type()) {
+			accepting = append(accepting, sink)
+		}
+	}
+	if len(accepting) == 0 && !hasTransports(ctx) || useCache && alreadySent(ctx, event) {
+		return nil
+	}
+	for _, sink := range accepting {
+		if err := sendWithRetries(withSink(ctx, sink), ceClient, object, event); err != nil {
+			return err
+		}
+	}
+	publishToTransports(ctx, ceClient, object, event)
+	return nil
+}
+
+// alreadySent returns true if the cache of the events that have been sent has the
+// event, and adds it to the cache otherwise.
+func alreadySent(ctx context.Context, event *cloudevents.Event) bool {
+	logger := logging.FromContext(ctx)
+	cloudEventSent, err := cache.ContainsOrAddCloudEvent(cache.Get(ctx), event)
+	if err != nil {
+		logger.Errorf("error while checking cache: %s", err)
+	}
+	if cloudEventSent {
+		logger.Infof("cloudevent %v already sent", event)
+	}
+	return cloudEventSent
+}
+
+// sendWithRetries sends the event about object in the background, with the retries
+// and the authentication of the sink in the context. The event is retried with an
+// exponential backoff when the context has no retries.
+func sendWithRetries(ctx context.Context, ceClient CEClient, object runtime.Object, event *cloudevents.Event) error {
+	logger := logging.FromContext(ctx)
+	if cecontext.RetriesFrom(ctx).Strategy == cecontext.BackoffStrategyNone {
+		ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, defaultSinkDelay, defaultSinkAttempts)
 	}
+	auth, _ := ctx.Value(sinkAuthKey{}).(sinkAuth)
 
 	wasIn := make(chan error)
 
@@ -107,25 +171,26 @@ func SendCloudEventWithRetries(ctx context.Context, object runtime.Object) error
 		defer ceClient.decreaseCount()
 		wasIn <- nil
 		logger.Debugf("Sending cloudevent of type %q", event.Type())
-		// In case of Run event, check cache if cloudevent is already sent
-		if isCustomRun {
-			cloudEventSent, err := cache.ContainsOrAddCloudEvent(cacheClient, event)
-			if err != nil {
-				logger.Errorf("error while checking cache: %s", err)
+		var err error
+		if auth != nil {
+			var header http.Header
+			if header, err = auth(ctx); err == nil {
+				ctx = cehttp.WithCustomHeader(ctx, header)
 			}
-			if cloudEventSent {
-				logger.Infof("cloudevent %v already sent", event)
-				return
+		}
+		if err == nil {
+			if result := ceClient.Send(ctx, *event); !cloudevents.IsACK(result) {
+				err = result
 			}
 		}
-		if result := ceClient.Send(cloudevents.ContextWithRetriesExponentialBackoff(ctx, 10*time.Millisecond, 10), *event); !cloudevents.IsACK(result) {
-			logger.Warnf("Failed to send cloudevent: %s", result.Error())
+		if err != nil {
+			logger.Warnf("Failed to send cloudevent: %s", err.Error())
 			recorder := controller.GetEventRecorder(ctx)
 			if recorder == nil {
 				logger.Warnf("No recorder in context, cannot emit error event")
 				return
 			}
-			recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", result.Error())
+			recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", err.Error())
 		}
 	}()
 
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"knative.dev/pkg/apis"
 )
@@ -69,6 +70,12 @@ const (
 	CustomRunSuccessfulEventV1 TektonEventType = "dev.tekton.event.customrun.successful.v1"
 	// CustomRunFailedEventV1 is sent for CustomRuns with "ConditionSucceeded" "False"
 	CustomRunFailedEventV1 TektonEventType = "dev.tekton.event.customrun.failed.v1"
+	// StepStartedEventV1 is sent when a step of a TaskRun starts running
+	StepStartedEventV1 TektonEventType = "dev.tekton.event.step.started.v1"
+	// StepSuccessfulEventV1 is sent when a step of a TaskRun terminates with a zero exit code
+	StepSuccessfulEventV1 TektonEventType = "dev.tekton.event.step.successful.v1"
+	// StepFailedEventV1 is sent when a step of a TaskRun terminates with a non-zero exit code
+	StepFailedEventV1 TektonEventType = "dev.tekton.event.step.failed.v1"
 )
 
 func (t TektonEventType) String() string {
@@ -108,23 +115,13 @@ func newTektonCloudEventData(runObject objectWithCondition) TektonCloudEventData
 }
 
 // eventForObjectWithCondition creates a new event based for a objectWithCondition,
-// or return an error if not possible.
-func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Event, error) {
+// or return an error if not possible. The data of the event is a RunSummary when
+// format is "slim", and the whole objectWithCondition otherwise.
+func eventForObjectWithCondition(runObject objectWithCondition, format string) (*cloudevents.Event, error) {
 	event := cloudevents.NewEvent()
 	event.SetID(uuid.New().String())
 	event.SetSubject(runObject.GetObjectMeta().GetName())
-	// TODO: SelfLink is deprecated https://github.com/tektoncd/pipeline/issues/2676
-	source := runObject.GetObjectMeta().GetSelfLink()
-	if source == "" {
-		gvk := runObject.GetObjectKind().GroupVersionKind()
-		source = fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s",
-			gvk.Group,
-			gvk.Version,
-			runObject.GetObjectMeta().GetNamespace(),
-			gvk.Kind,
-			runObject.GetObjectMeta().GetName())
-	}
-	event.SetSource(source)
+	event.SetSource(eventSource(runObject))
 	eventType, err := getEventType(runObject)
 	if err != nil {
 		return nil, err
@@ -134,12 +131,35 @@ func eventForObjectWithCondition(runObject objectWithCondition) (*cloudevents.Ev
 	}
 	event.SetType(eventType.String())
 
+	if format == config.CloudEventsFormatSlim {
+		event.SetDataSchema(RunSummarySchemaV1)
+		if err := event.SetData(cloudevents.ApplicationJSON, newRunSummary(runObject)); err != nil {
+			return nil, err
+		}
+		return &event, nil
+	}
 	if err := event.SetData(cloudevents.ApplicationJSON, newTektonCloudEventData(runObject)); err != nil {
 		return nil, err
 	}
 	return &event, nil
 }
 
+// eventSource returns the source of the events about runObject.
+func eventSource(runObject objectWithCondition) string {
+	// TODO: SelfLink is deprecated https://github.com/tektoncd/pipeline/issues/2676
+	source := runObject.GetObjectMeta().GetSelfLink()
+	if source == "" {
+		gvk := runObject.GetObjectKind().GroupVersionKind()
+		source = fmt.Sprintf("/apis/%s/%s/namespaces/%s/%s/%s",
+			gvk.Group,
+			gvk.Version,
+			runObject.GetObjectMeta().GetNamespace(),
+			gvk.Kind,
+			runObject.GetObjectMeta().GetName())
+	}
+	return source
+}
+
 func getEventType(runObject objectWithCondition) (*TektonEventType, error) {
 	var eventType TektonEventType
 	c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded)
@@ -19,6 +19,7 @@ package cloudevent
 import (
 	"context"
 	"net/http"
+	"sync"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/cloudevents/sdk-go/v2/client"
@@ -68,17 +69,40 @@ func withCloudEventClient(ctx context.Context) context.Context {
 	return context.WithValue(ctx, ceKey{}, celient)
 }
 
+// inFlight counts the cloud events being sent in the background by CloudClients,
+// so that they can be flushed before the controller exits.
+var inFlight sync.WaitGroup
+
 // CloudClient is a wrapper of CloudEvents client and implements addCount and decreaseCount
 type CloudClient struct {
 	client client.Client
 }
 
-// AddCount does nothing
+// addCount counts a cloud event which is going to be sent
 func (c CloudClient) addCount() {
+	inFlight.Add(1)
 }
 
-// DecreaseCount does nothing
+// decreaseCount uncounts a cloud event which has been sent, or failed to be
 func (c CloudClient) decreaseCount() {
+	inFlight.Done()
+}
+
+// Flush blocks until the cloud events being sent have been delivered or have failed to be,
+// or until ctx is done. It is called when the controller shuts down, so that the events sent
+// by the last reconciles are not lost.
+func Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(flushed)
+	}()
+	select {
+	case <-flushed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // Send invokes call client.Send
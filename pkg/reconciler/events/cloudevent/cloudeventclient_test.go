@@ -0,0 +1,40 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFlush(t *testing.T) {
+	c := CloudClient{}
+	c.addCount()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the flush of a pending event to time out but got %v", err)
+	}
+
+	c.decreaseCount()
+	if err := Flush(context.Background()); err != nil {
+		t.Errorf("expected the flush to succeed without pending events but got %v", err)
+	}
+}
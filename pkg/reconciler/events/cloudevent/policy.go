@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Emits returns true if the filters of the events config map let the events about
+// the current condition of object be emitted. The Kubernetes events are filtered
+// with the type of the CloudEvent emitted for the same condition.
+func Emits(ctx context.Context, object runtime.Object) bool {
+	o, ok := object.(objectWithCondition)
+	if !ok {
+		return true
+	}
+	eventType, err := getEventType(o)
+	if err != nil || eventType == nil {
+		return true
+	}
+	return emits(ctx, object, eventType.String())
+}
+
+// emits returns true if the filters of the events config map let the event of the
+// given type about object be emitted.
+func emits(ctx context.Context, object runtime.Object, eventType string) bool {
+	o, err := meta.Accessor(object)
+	if err != nil {
+		return true
+	}
+	return config.FromContextOrDefaults(ctx).Events.Emits(eventType, o.GetNamespace(), o.GetLabels())
+}
+
+// redact returns a copy of runObject without the params and the results the events
+// config map strips from the runs sent in the CloudEvents, or runObject itself when
+// nothing is stripped.
+func redact(ctx context.Context, runObject objectWithCondition) objectWithCondition {
+	events := config.FromContextOrDefaults(ctx).Events
+	if !events.Redacts() {
+		return runObject
+	}
+	switch v := runObject.(type) {
+	case *v1beta1.TaskRun:
+		tr := v.DeepCopy()
+		tr.Spec.Params = redactParams(events, tr.Spec.Params)
+		if events.DropResults {
+			tr.Status.TaskRunResults = nil
+		}
+		return tr
+	case *v1beta1.PipelineRun:
+		pr := v.DeepCopy()
+		pr.Spec.Params = redactParams(events, pr.Spec.Params)
+		if events.DropResults {
+			pr.Status.PipelineResults = nil
+		}
+		return pr
+	case *v1beta1.CustomRun:
+		cr := v.DeepCopy()
+		cr.Spec.Params = redactParams(events, cr.Spec.Params)
+		if events.DropResults {
+			cr.Status.Results = nil
+		}
+		return cr
+	}
+	return runObject
+}
+
+func redactParams(events *config.Events, params v1beta1.Params) v1beta1.Params {
+	var kept v1beta1.Params
+	for _, p := range params {
+		if !events.RedactsParam(p.Name) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
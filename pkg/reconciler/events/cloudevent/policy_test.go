@@ -0,0 +1,125 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	runv1beta1 "github.com/tektoncd/pipeline/pkg/apis/run/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestRedact(t *testing.T) {
+	params := v1beta1.Params{
+		{Name: "revision", Value: *v1beta1.NewStructuredValues("main")},
+		{Name: "github-token", Value: *v1beta1.NewStructuredValues("s3cr3t")},
+	}
+	redactedParams := v1beta1.Params{{Name: "revision", Value: *v1beta1.NewStructuredValues("main")}}
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo"},
+		Spec:       v1beta1.TaskRunSpec{Params: params},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			TaskRunResults: []v1beta1.TaskRunResult{{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:abc")}},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo"},
+		Spec:       v1beta1.PipelineRunSpec{Params: params},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			PipelineResults: []v1beta1.PipelineRunResult{{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:abc")}},
+		}},
+	}
+	cr := &v1beta1.CustomRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "foo"},
+		Spec:       v1beta1.CustomRunSpec{Params: params},
+		Status: v1beta1.CustomRunStatus{CustomRunStatusFields: runv1beta1.CustomRunStatusFields{
+			Results: []runv1beta1.CustomRunResult{{Name: "digest", Value: "sha256:abc"}},
+		}},
+	}
+	for _, tc := range []struct {
+		desc string
+		cfg  map[string]string
+		run  objectWithCondition
+		want objectWithCondition
+	}{{
+		desc: "nothing redacted",
+		cfg:  map[string]string{},
+		run:  tr,
+		want: tr,
+	}, {
+		desc: "taskrun",
+		cfg:  map[string]string{"redact-params": "token$", "drop-results": "true"},
+		run:  tr,
+		want: &v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta, Spec: v1beta1.TaskRunSpec{Params: redactedParams}},
+	}, {
+		desc: "taskrun params only",
+		cfg:  map[string]string{"redact-params": "token$"},
+		run:  tr,
+		want: &v1beta1.TaskRun{ObjectMeta: tr.ObjectMeta, Spec: v1beta1.TaskRunSpec{Params: redactedParams}, Status: tr.Status},
+	}, {
+		desc: "pipelinerun",
+		cfg:  map[string]string{"redact-params": "token$", "drop-results": "true"},
+		run:  pr,
+		want: &v1beta1.PipelineRun{ObjectMeta: pr.ObjectMeta, Spec: v1beta1.PipelineRunSpec{Params: redactedParams}},
+	}, {
+		desc: "customrun results only",
+		cfg:  map[string]string{"drop-results": "true"},
+		run:  cr,
+		want: &v1beta1.CustomRun{ObjectMeta: cr.ObjectMeta, Spec: cr.Spec},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			before := tc.run.(runtime.Object).DeepCopyObject()
+			got := redact(eventsContext(t, tc.cfg), tc.run)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("redact() %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(before, tc.run); d != "" {
+				t.Errorf("redact() modified the run %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestEmitCloudEventsFiltered(t *testing.T) {
+	created := withFakePublishers(t, nil)
+	ctx := eventsContext(t, map[string]string{
+		"kafka-brokers":         "kafka:9092",
+		"kafka-topic":           "tekton",
+		"filter-namespaces":     "ci",
+		"filter-label-selector": "team=platform",
+	})
+	fakeClient := Get(ctx).(FakeClient)
+
+	for _, tr := range []*v1beta1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "other-namespace", Labels: map[string]string{"team": "platform"}},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "other-labels"},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: "selected", Labels: map[string]string{"team": "platform"}},
+	}} {
+		tr.Status.InitializeConditions()
+		EmitCloudEvents(ctx, tr)
+	}
+	fakeClient.CheckCloudEventsUnordered(t, "filtered", nil)
+	if d := cmp.Diff([]string{"dev.tekton.event.taskrun.started.v1"}, created["kafka/tekton"].published); d != "" {
+		t.Errorf("published %s", diff.PrintWantGot(d))
+	}
+}
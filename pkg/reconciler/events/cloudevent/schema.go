@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const (
+	// RunSummarySchemaVersionV1 is the version of the schema of the data of
+	// CloudEvents sent with the "slim" format. Within a version the schema only
+	// changes in backwards compatible ways, i.e. by adding optional fields.
+	RunSummarySchemaVersionV1 = "v1"
+	// RunSummarySchemaV1 identifies the JSON schema of RunSummary in version v1.
+	// It is set as the "dataschema" attribute of CloudEvents sent with the "slim"
+	// format. The schema is available in schema/v1/run-summary.json.
+	RunSummarySchemaV1 = "https://tekton.dev/schemas/events/v1/run-summary.json"
+)
+
+// RunSummary is the payload of the CloudEvents sent with the "slim" format.
+// Unlike TektonCloudEventData, it does not embed the API types, so that event
+// consumers are not affected by changes to the TaskRun, PipelineRun or
+// CustomRun status.
+type RunSummary struct {
+	// SchemaVersion is the version of the schema of the summary.
+	SchemaVersion string `json:"schemaVersion"`
+	// Kind is the kind of the run: TaskRun, PipelineRun or CustomRun.
+	Kind string `json:"kind"`
+	// Name is the name of the run.
+	Name string `json:"name"`
+	// Namespace is the namespace of the run.
+	Namespace string `json:"namespace"`
+	// UID is the unique identifier of the run.
+	UID string `json:"uid"`
+	// Condition is the "Succeeded" condition of the run, if set.
+	Condition *RunSummaryCondition `json:"condition,omitempty"`
+	// StartTime is the time the run started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the run completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Results are the results produced by the run.
+	Results []RunSummaryResult `json:"results,omitempty"`
+	// Provenance identifies where the definition of the run came from.
+	Provenance *RunSummaryProvenance `json:"provenance,omitempty"`
+}
+
+// RunSummaryCondition is the "Succeeded" condition of a run.
+type RunSummaryCondition struct {
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RunSummaryResult is a result produced by a run. The value is a string,
+// a []string or a map[string]string, i.e. a string, an array of strings or
+// an object of strings in JSON.
+type RunSummaryResult struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// RunSummaryProvenance identifies the source of the definition of a run,
+// together with the digest of its content.
+type RunSummaryProvenance struct {
+	URI        string            `json:"uri,omitempty"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// newRunSummary returns the RunSummary of a TaskRun, PipelineRun or CustomRun
+func newRunSummary(runObject objectWithCondition) RunSummary {
+	meta := runObject.GetObjectMeta()
+	summary := RunSummary{
+		SchemaVersion: RunSummarySchemaVersionV1,
+		Name:          meta.GetName(),
+		Namespace:     meta.GetNamespace(),
+		UID:           string(meta.GetUID()),
+	}
+	if c := runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded); c != nil {
+		summary.Condition = &RunSummaryCondition{
+			Status:  string(c.Status),
+			Reason:  c.Reason,
+			Message: c.Message,
+		}
+	}
+	switch v := runObject.(type) {
+	case *v1beta1.TaskRun:
+		summary.Kind = "TaskRun"
+		summary.StartTime = v.Status.StartTime
+		summary.CompletionTime = v.Status.CompletionTime
+		for _, r := range v.Status.TaskRunResults {
+			summary.Results = append(summary.Results, RunSummaryResult{Name: r.Name, Value: newRunSummaryResultValue(r.Value)})
+		}
+		summary.Provenance = newRunSummaryProvenance(v.Status.Provenance)
+	case *v1beta1.PipelineRun:
+		summary.Kind = "PipelineRun"
+		summary.StartTime = v.Status.StartTime
+		summary.CompletionTime = v.Status.CompletionTime
+		for _, r := range v.Status.PipelineResults {
+			summary.Results = append(summary.Results, RunSummaryResult{Name: r.Name, Value: newRunSummaryResultValue(r.Value)})
+		}
+		summary.Provenance = newRunSummaryProvenance(v.Status.Provenance)
+	case *v1beta1.CustomRun:
+		summary.Kind = "CustomRun"
+		summary.StartTime = v.Status.StartTime
+		summary.CompletionTime = v.Status.CompletionTime
+		for _, r := range v.Status.Results {
+			summary.Results = append(summary.Results, RunSummaryResult{Name: r.Name, Value: r.Value})
+		}
+	}
+	return summary
+}
+
+// newRunSummaryResultValue returns the plain value of a result, so that the summary
+// doesn't depend on the representation of the values of the API.
+func newRunSummaryResultValue(v v1beta1.ResultValue) interface{} {
+	switch v.Type {
+	case v1beta1.ParamTypeArray:
+		return v.ArrayVal
+	case v1beta1.ParamTypeObject:
+		return v.ObjectVal
+	}
+	return v.StringVal
+}
+
+func newRunSummaryProvenance(p *v1beta1.Provenance) *RunSummaryProvenance {
+	switch {
+	case p == nil:
+		return nil
+	case p.RefSource != nil:
+		return &RunSummaryProvenance{URI: p.RefSource.URI, Digest: p.RefSource.Digest, EntryPoint: p.RefSource.EntryPoint}
+	case p.ConfigSource != nil:
+		return &RunSummaryProvenance{URI: p.ConfigSource.URI, Digest: p.ConfigSource.Digest, EntryPoint: p.ConfigSource.EntryPoint}
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+var (
+	startTime      = metav1.NewTime(time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC))
+	completionTime = metav1.NewTime(time.Date(2023, 1, 1, 10, 5, 0, 0, time.UTC))
+)
+
+func TestEventForObjectWithConditionSlim(t *testing.T) {
+	status := duckv1.Status{
+		Conditions: []apis.Condition{{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Succeeded",
+			Message: "All Steps have completed executing",
+		}},
+	}
+	for _, tc := range []struct {
+		name   string
+		object objectWithCondition
+		want   RunSummary
+	}{{
+		name: "taskrun",
+		object: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "ns", UID: types.UID("tr-uid")},
+			Status: v1beta1.TaskRunStatus{
+				Status: status,
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+					TaskRunResults: []v1beta1.TaskRunResult{{
+						Name:  "digest",
+						Value: *v1beta1.NewStructuredValues("sha256:abc"),
+					}},
+					Provenance: &v1beta1.Provenance{
+						RefSource: &v1beta1.RefSource{
+							URI:        "git+https://github.com/tektoncd/catalog.git",
+							Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+							EntryPoint: "task/git-clone/0.8/git-clone.yaml",
+						},
+					},
+				},
+			},
+		},
+		want: RunSummary{
+			SchemaVersion: RunSummarySchemaVersionV1,
+			Kind:          "TaskRun",
+			Name:          "tr",
+			Namespace:     "ns",
+			UID:           "tr-uid",
+			Condition: &RunSummaryCondition{
+				Status:  "True",
+				Reason:  "Succeeded",
+				Message: "All Steps have completed executing",
+			},
+			StartTime:      &startTime,
+			CompletionTime: &completionTime,
+			Results: []RunSummaryResult{{
+				Name:  "digest",
+				Value: "sha256:abc",
+			}},
+			Provenance: &RunSummaryProvenance{
+				URI:        "git+https://github.com/tektoncd/catalog.git",
+				Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+				EntryPoint: "task/git-clone/0.8/git-clone.yaml",
+			},
+		},
+	}, {
+		name: "pipelinerun",
+		object: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", UID: types.UID("pr-uid")},
+			Status: v1beta1.PipelineRunStatus{
+				Status: status,
+				PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+					StartTime: &startTime,
+					PipelineResults: []v1beta1.PipelineRunResult{{
+						Name:  "images",
+						Value: *v1beta1.NewStructuredValues("a", "b"),
+					}},
+				},
+			},
+		},
+		want: RunSummary{
+			SchemaVersion: RunSummarySchemaVersionV1,
+			Kind:          "PipelineRun",
+			Name:          "pr",
+			Namespace:     "ns",
+			UID:           "pr-uid",
+			Condition: &RunSummaryCondition{
+				Status:  "True",
+				Reason:  "Succeeded",
+				Message: "All Steps have completed executing",
+			},
+			StartTime: &startTime,
+			Results: []RunSummaryResult{{
+				Name: "images",
+				// The event data is decoded from JSON.
+				Value: []interface{}{"a", "b"},
+			}},
+		},
+	}, {
+		name: "customrun without condition",
+		object: &v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "cr", Namespace: "ns", UID: types.UID("cr-uid")},
+		},
+		want: RunSummary{
+			SchemaVersion: RunSummarySchemaVersionV1,
+			Kind:          "CustomRun",
+			Name:          "cr",
+			Namespace:     "ns",
+			UID:           "cr-uid",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			event, err := eventForObjectWithCondition(tc.object, config.CloudEventsFormatSlim)
+			if err != nil {
+				t.Fatalf("eventForObjectWithCondition: %v", err)
+			}
+			if event.DataSchema() != RunSummarySchemaV1 {
+				t.Errorf("expected dataschema %q, got %q", RunSummarySchemaV1, event.DataSchema())
+			}
+			var got RunSummary
+			if err := event.DataAs(&got); err != nil {
+				t.Fatalf("error decoding event data: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewRunSummaryResultValue(t *testing.T) {
+	for _, tc := range []struct {
+		value v1beta1.ResultValue
+		want  interface{}
+	}{{
+		value: *v1beta1.NewStructuredValues("v"),
+		want:  "v",
+	}, {
+		value: *v1beta1.NewStructuredValues("a", "b"),
+		want:  []string{"a", "b"},
+	}, {
+		value: *v1beta1.NewObject(map[string]string{"k": "v"}),
+		want:  map[string]string{"k": "v"},
+	}} {
+		if d := cmp.Diff(tc.want, newRunSummaryResultValue(tc.value)); d != "" {
+			t.Errorf("Diff %s", diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestEventForObjectWithConditionFull(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "ns"},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse}},
+			},
+		},
+	}
+	event, err := eventForObjectWithCondition(tr, config.CloudEventsFormatFull)
+	if err != nil {
+		t.Fatalf("eventForObjectWithCondition: %v", err)
+	}
+	if event.DataSchema() != "" {
+		t.Errorf("expected no dataschema, got %q", event.DataSchema())
+	}
+	var got TektonCloudEventData
+	if err := event.DataAs(&got); err != nil {
+		t.Fatalf("error decoding event data: %v", err)
+	}
+	if got.TaskRun == nil || got.TaskRun.Name != "tr" {
+		t.Errorf("expected the event data to include the TaskRun, got %v", got)
+	}
+}
+
+// TestRunSummarySchemaV1 verifies that the JSON representation of RunSummary
+// matches the published schema, so that changes to one cannot be made without
+// the other.
+func TestRunSummarySchemaV1(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("schema", RunSummarySchemaVersionV1, "run-summary.json"))
+	if err != nil {
+		t.Fatalf("error reading schema: %v", err)
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("error parsing schema: %v", err)
+	}
+	if schema.ID != RunSummarySchemaV1 {
+		t.Errorf("expected schema $id %q, got %q", RunSummarySchemaV1, schema.ID)
+	}
+
+	summary := RunSummary{
+		SchemaVersion:  RunSummarySchemaVersionV1,
+		Kind:           "TaskRun",
+		Name:           "tr",
+		Namespace:      "ns",
+		UID:            "uid",
+		Condition:      &RunSummaryCondition{Status: "True", Reason: "Succeeded", Message: "done"},
+		StartTime:      &startTime,
+		CompletionTime: &completionTime,
+		Results:        []RunSummaryResult{{Name: "r", Value: "v"}},
+		Provenance:     &RunSummaryProvenance{URI: "uri", Digest: map[string]string{"sha256": "abc"}, EntryPoint: "task.yaml"},
+	}
+	b, err = json.Marshal(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	compareWithSchema(t, "", schema, data)
+}
+
+// TestStepSummarySchemaV1 verifies that the JSON representation of StepSummary
+// matches the published schema.
+func TestStepSummarySchemaV1(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("schema", RunSummarySchemaVersionV1, "step-summary.json"))
+	if err != nil {
+		t.Fatalf("error reading schema: %v", err)
+	}
+	var schema jsonSchema
+	if err := json.Unmarshal(b, &schema); err != nil {
+		t.Fatalf("error parsing schema: %v", err)
+	}
+	if schema.ID != StepSummarySchemaV1 {
+		t.Errorf("expected schema $id %q, got %q", StepSummarySchemaV1, schema.ID)
+	}
+
+	exitCode := int32(0)
+	summary := StepSummary{
+		SchemaVersion:  RunSummarySchemaVersionV1,
+		TaskRun:        "tr",
+		Namespace:      "ns",
+		UID:            "uid",
+		Name:           "build",
+		Container:      "step-build",
+		StartTime:      &startTime,
+		CompletionTime: &completionTime,
+		Duration:       &metav1.Duration{Duration: 5 * time.Minute},
+		ExitCode:       &exitCode,
+		Reason:         "Completed",
+	}
+	b, err = json.Marshal(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		t.Fatal(err)
+	}
+	compareWithSchema(t, "", schema, data)
+}
+
+type jsonSchema struct {
+	ID         string                `json:"$id"`
+	Required   []string              `json:"required"`
+	Properties map[string]jsonSchema `json:"properties"`
+	Items      *jsonSchema           `json:"items"`
+}
+
+func compareWithSchema(t *testing.T, path string, schema jsonSchema, data map[string]interface{}) {
+	t.Helper()
+	var fields, properties []string
+	for f := range data {
+		fields = append(fields, f)
+	}
+	for p := range schema.Properties {
+		properties = append(properties, p)
+	}
+	sort.Strings(fields)
+	sort.Strings(properties)
+	if d := cmp.Diff(properties, fields); d != "" {
+		t.Errorf("fields of %q do not match the schema properties %s", path, diff.PrintWantGot(d))
+	}
+	for _, r := range schema.Required {
+		if _, ok := data[r]; !ok {
+			t.Errorf("required field %q missing from %q", r, path)
+		}
+	}
+	for name, property := range schema.Properties {
+		switch v := data[name].(type) {
+		case map[string]interface{}:
+			if len(property.Properties) != 0 {
+				compareWithSchema(t, path+"."+name, property, v)
+			}
+		case []interface{}:
+			if property.Items != nil && len(property.Items.Properties) != 0 {
+				for _, item := range v {
+					compareWithSchema(t, path+"."+name+"[]", *property.Items, item.(map[string]interface{}))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// defaultSinkAttempts is the number of attempts to send a CloudEvent to the sinks
+	// which don't configure it, and to the "default-cloud-events-sink".
+	defaultSinkAttempts = 10
+	// defaultSinkDelay is the delay of the backoff of the sinks which don't configure it,
+	// and of the "default-cloud-events-sink".
+	defaultSinkDelay = 10 * time.Millisecond
+
+	// oidcTokenExpiration is the lifetime requested for the OIDC tokens of the sinks.
+	oidcTokenExpiration = time.Hour
+)
+
+// Sinks are the CloudEventSinks the CloudEvents about the runs are sent to, along
+// with the clients used to authenticate to them.
+type Sinks struct {
+	lister          alpha1listers.CloudEventSinkLister
+	kubeclient      kubernetes.Interface
+	systemNamespace string
+	tokens          *tokenCache
+}
+
+// NewSinks returns the Sinks listed by the lister, whose credentials are read with
+// the kubeclient.
+func NewSinks(lister alpha1listers.CloudEventSinkLister, kubeclient kubernetes.Interface) *Sinks {
+	return newSinks(lister, kubeclient, system.Namespace())
+}
+
+func newSinks(lister alpha1listers.CloudEventSinkLister, kubeclient kubernetes.Interface, systemNamespace string) *Sinks {
+	return &Sinks{
+		lister:          lister,
+		kubeclient:      kubeclient,
+		systemNamespace: systemNamespace,
+		tokens:          &tokenCache{tokens: map[tokenKey]token{}},
+	}
+}
+
+// sinksKey is used to associate the Sinks inside the context.Context
+type sinksKey struct{}
+
+// WithSinks adds the Sinks to the context, so that the CloudEvents are sent to them.
+func WithSinks(ctx context.Context, s *Sinks) context.Context {
+	return context.WithValue(ctx, sinksKey{}, s)
+}
+
+// sinkAuthKey is used to associate the authentication of the sink an event is sent
+// to inside the context.Context
+type sinkAuthKey struct{}
+
+// sinkAuth returns the headers authenticating the requests to a sink.
+type sinkAuth func(ctx context.Context) (http.Header, error)
+
+// sinksFor returns the sinks of the CloudEvents about the runs of the namespace: the
+// "default-cloud-events-sink" if it is set, and the CloudEventSinks selecting the
+// namespace if the context has Sinks.
+func sinksFor(ctx context.Context, namespace string) []*v1alpha1.CloudEventSink {
+	var sinks []*v1alpha1.CloudEventSink
+	if uri := config.FromContextOrDefaults(ctx).Defaults.DefaultCloudEventsSink; uri != "" {
+		sinks = append(sinks, &v1alpha1.CloudEventSink{Spec: v1alpha1.CloudEventSinkSpec{URI: uri}})
+	}
+	s, _ := ctx.Value(sinksKey{}).(*Sinks)
+	if s == nil || s.lister == nil {
+		return sinks
+	}
+	namespaces := []string{namespace}
+	if s.systemNamespace != "" && s.systemNamespace != namespace {
+		namespaces = append(namespaces, s.systemNamespace)
+	}
+	for _, ns := range namespaces {
+		listed, err := s.lister.CloudEventSinks(ns).List(labels.Everything())
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Failed to list the CloudEventSinks of namespace %s: %v", ns, err)
+			continue
+		}
+		for _, sink := range listed {
+			if sink.Selects(namespace, s.systemNamespace) {
+				sinks = append(sinks, sink)
+			}
+		}
+	}
+	return sinks
+}
+
+// withSink returns the context to send a CloudEvent to the sink with, which holds
+// its target, its retry policy and its authentication.
+func withSink(ctx context.Context, sink *v1alpha1.CloudEventSink) context.Context {
+	ctx = cloudevents.ContextWithTarget(ctx, sink.Spec.URI)
+	attempts, backoff, delay := defaultSinkAttempts, v1alpha1.CloudEventSinkBackoffExponential, defaultSinkDelay
+	if r := sink.Spec.Retry; r != nil {
+		if r.Attempts > 0 {
+			attempts = int(r.Attempts)
+		}
+		if r.Backoff != "" {
+			backoff = r.Backoff
+		}
+		if r.Delay != nil {
+			delay = r.Delay.Duration
+		}
+	}
+	switch backoff {
+	case v1alpha1.CloudEventSinkBackoffConstant:
+		ctx = cloudevents.ContextWithRetriesConstantBackoff(ctx, delay, attempts)
+	case v1alpha1.CloudEventSinkBackoffLinear:
+		ctx = cloudevents.ContextWithRetriesLinearBackoff(ctx, delay, attempts)
+	default:
+		ctx = cloudevents.ContextWithRetriesExponentialBackoff(ctx, delay, attempts)
+	}
+	if sink.Spec.Auth != nil {
+		if s, _ := ctx.Value(sinksKey{}).(*Sinks); s != nil {
+			ctx = context.WithValue(ctx, sinkAuthKey{}, sinkAuth(func(ctx context.Context) (http.Header, error) {
+				return s.header(ctx, sink)
+			}))
+		}
+	}
+	return ctx
+}
+
+// header returns the headers authenticating the requests to the sink.
+func (s *Sinks) header(ctx context.Context, sink *v1alpha1.CloudEventSink) (http.Header, error) {
+	header := http.Header{}
+	switch auth := sink.Spec.Auth; {
+	case auth.BasicAuth != nil:
+		secret, err := s.kubeclient.CoreV1().Secrets(sink.Namespace).Get(ctx, auth.BasicAuth.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the basic auth secret of CloudEventSink %s/%s: %w", sink.Namespace, sink.Name, err)
+		}
+		// The credentials are sent to the URI chosen by the creator of the sink, so
+		// the secret must opt in to be used by it.
+		if !sink.AllowedBy(secret) {
+			return nil, fmt.Errorf("secret %s/%s does not allow CloudEventSink %s to use it with the %s annotation", secret.Namespace, secret.Name, sink.Name, v1alpha1.CloudEventSinksAnnotation)
+		}
+		credentials := string(secret.Data[corev1.BasicAuthUsernameKey]) + ":" + string(secret.Data[corev1.BasicAuthPasswordKey])
+		header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(credentials)))
+	case auth.OIDCToken != nil:
+		audience := auth.OIDCToken.Audience
+		if audience == "" {
+			audience = sink.Spec.URI
+		}
+		if !config.FromContextOrDefaults(ctx).Events.AllowsSinkAudience(audience, sink.Spec.URI) {
+			return nil, fmt.Errorf("audience %q of CloudEventSink %s/%s is neither its URI nor one of the sink audiences of the events config", audience, sink.Namespace, sink.Name)
+		}
+		sa, err := s.kubeclient.CoreV1().ServiceAccounts(sink.Namespace).Get(ctx, auth.OIDCToken.ServiceAccountName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the service account of CloudEventSink %s/%s: %w", sink.Namespace, sink.Name, err)
+		}
+		if !sink.AllowedBy(sa) {
+			return nil, fmt.Errorf("service account %s/%s does not allow CloudEventSink %s to use it with the %s annotation", sa.Namespace, sa.Name, sink.Name, v1alpha1.CloudEventSinksAnnotation)
+		}
+		t, err := s.tokens.get(ctx, s.kubeclient, tokenKey{namespace: sink.Namespace, serviceAccount: auth.OIDCToken.ServiceAccountName, audience: audience})
+		if err != nil {
+			return nil, fmt.Errorf("failed to request the OIDC token of CloudEventSink %s/%s: %w", sink.Namespace, sink.Name, err)
+		}
+		header.Set("Authorization", "Bearer "+t)
+	}
+	return header, nil
+}
+
+type tokenKey struct {
+	namespace      string
+	serviceAccount string
+	audience       string
+}
+
+type token struct {
+	value   string
+	refresh time.Time
+}
+
+// tokenCache caches the OIDC tokens requested for the service accounts of the sinks
+// until most of their lifetime has passed.
+type tokenCache struct {
+	mu     sync.Mutex
+	tokens map[tokenKey]token
+}
+
+func (c *tokenCache) get(ctx context.Context, kubeclient kubernetes.Interface, key tokenKey) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if t, ok := c.tokens[key]; ok && now.Before(t.refresh) {
+		return t.value, nil
+	}
+	expiration := int64(oidcTokenExpiration.Seconds())
+	tr, err := kubeclient.CoreV1().ServiceAccounts(key.namespace).CreateToken(ctx, key.serviceAccount, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{key.audience},
+			ExpirationSeconds: &expiration,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", err
+	}
+	// Refresh the token once 80% of its lifetime has passed.
+	refresh := now.Add(tr.Status.ExpirationTimestamp.Sub(now) * 4 / 5)
+	c.tokens[key] = token{value: tr.Status.Token, refresh: refresh}
+	return tr.Status.Token, nil
+}
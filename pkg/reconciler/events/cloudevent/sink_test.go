@@ -0,0 +1,273 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cecontext "github.com/cloudevents/sdk-go/v2/context"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	logtesting "knative.dev/pkg/logging/testing"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const testSystemNamespace = "tekton-pipelines"
+
+func cloudEventSink(namespace, name, uri string, spec v1alpha1.CloudEventSinkSpec) *v1alpha1.CloudEventSink {
+	spec.URI = uri
+	return &v1alpha1.CloudEventSink{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Spec: spec}
+}
+
+func testSinks(t *testing.T, kubeclient *fakekubeclientset.Clientset, sinks ...*v1alpha1.CloudEventSink) *Sinks {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, sink := range sinks {
+		if err := indexer.Add(sink); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return newSinks(alpha1listers.NewCloudEventSinkLister(indexer), kubeclient, testSystemNamespace)
+}
+
+func TestSinksFor(t *testing.T) {
+	sinks := testSinks(t, fakekubeclientset.NewSimpleClientset(),
+		cloudEventSink("foo", "own", "http://foo", v1alpha1.CloudEventSinkSpec{}),
+		cloudEventSink("bar", "other", "http://bar", v1alpha1.CloudEventSinkSpec{}),
+		cloudEventSink(testSystemNamespace, "all", "http://all", v1alpha1.CloudEventSinkSpec{}),
+		cloudEventSink(testSystemNamespace, "selected", "http://selected", v1alpha1.CloudEventSinkSpec{Namespaces: []string{"bar"}}),
+	)
+	for _, tc := range []struct {
+		desc        string
+		namespace   string
+		defaultSink string
+		want        []string
+	}{{
+		desc:      "sinks of the namespace and of the system namespace",
+		namespace: "foo",
+		want:      []string{"http://foo", "http://all"},
+	}, {
+		desc:      "sinks selecting the namespace",
+		namespace: "bar",
+		want:      []string{"http://bar", "http://all", "http://selected"},
+	}, {
+		desc:        "default sink",
+		namespace:   "baz",
+		defaultSink: "http://default",
+		want:        []string{"http://default", "http://all"},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			defaults, _ := config.NewDefaultsFromMap(map[string]string{"default-cloud-events-sink": tc.defaultSink})
+			ctx := config.ToContext(context.Background(), &config.Config{Defaults: defaults})
+			ctx = WithSinks(ctx, sinks)
+			var got []string
+			for _, sink := range sinksFor(ctx, tc.namespace) {
+				got = append(got, sink.Spec.URI)
+			}
+			// The order of the listed CloudEventSinks isn't deterministic.
+			if d := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+				t.Errorf("sinksFor() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestWithSink(t *testing.T) {
+	for _, tc := range []struct {
+		desc string
+		sink *v1alpha1.CloudEventSink
+		want cecontext.RetryParams
+	}{{
+		desc: "default retries",
+		sink: cloudEventSink("foo", "sink", "http://foo", v1alpha1.CloudEventSinkSpec{}),
+		want: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyExponential, Period: 10 * time.Millisecond, MaxTries: 10},
+	}, {
+		desc: "retries of the sink",
+		sink: cloudEventSink("foo", "sink", "http://foo", v1alpha1.CloudEventSinkSpec{Retry: &v1alpha1.CloudEventSinkRetry{
+			Attempts: 3,
+			Backoff:  v1alpha1.CloudEventSinkBackoffLinear,
+			Delay:    &metav1.Duration{Duration: time.Second},
+		}}),
+		want: cecontext.RetryParams{Strategy: cecontext.BackoffStrategyLinear, Period: time.Second, MaxTries: 3},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := withSink(context.Background(), tc.sink)
+			if got := cecontext.TargetFrom(ctx).String(); got != tc.sink.Spec.URI {
+				t.Errorf("expected the target %q but got %q", tc.sink.Spec.URI, got)
+			}
+			if d := cmp.Diff(tc.want, *cecontext.RetriesFrom(ctx)); d != "" {
+				t.Errorf("withSink() retries %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSinksHeader(t *testing.T) {
+	optIn := map[string]string{v1alpha1.CloudEventSinksAnnotation: "other, sink"}
+	kubeclient := fakekubeclientset.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "creds", Annotations: optIn},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "other-creds", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "other"}},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "private"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "sa", Annotations: optIn},
+	}, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "all", Annotations: map[string]string{v1alpha1.CloudEventSinksAnnotation: "*"}},
+	}, &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "default"},
+	})
+	tokenRequests := 0
+	kubeclient.PrependReactor("create", "serviceaccounts", func(action ktesting.Action) (bool, runtime.Object, error) {
+		tokenRequests++
+		tr := action.(ktesting.CreateAction).GetObject().(*authenticationv1.TokenRequest)
+		tr.Status = authenticationv1.TokenRequestStatus{
+			Token:               "token-for-" + tr.Spec.Audiences[0],
+			ExpirationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		}
+		return true, tr, nil
+	})
+	sinks := testSinks(t, kubeclient)
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetEventsConfigName()},
+		Data:       map[string]string{"sink-audiences": "events"},
+	})
+	configCtx := store.ToContext(context.Background())
+	for _, tc := range []struct {
+		desc string
+		auth *v1alpha1.CloudEventSinkAuth
+		want string
+	}{{
+		desc: "basic auth",
+		auth: &v1alpha1.CloudEventSinkAuth{BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{SecretName: "creds"}},
+		want: "Basic dXNlcjpwYXNz",
+	}, {
+		desc: "oidc token with the default audience",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "sa"}},
+		want: "Bearer token-for-http://foo",
+	}, {
+		desc: "oidc token with an audience",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "sa", Audience: "events"}},
+		want: "Bearer token-for-events",
+	}, {
+		desc: "oidc token of a service account allowing all the sinks",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "all"}},
+		want: "Bearer token-for-http://foo",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sink := cloudEventSink("foo", "sink", "http://foo", v1alpha1.CloudEventSinkSpec{Auth: tc.auth})
+			ctx := withSink(WithSinks(configCtx, sinks), sink)
+			auth, _ := ctx.Value(sinkAuthKey{}).(sinkAuth)
+			if auth == nil {
+				t.Fatal("expected the context to hold the authentication of the sink")
+			}
+			header, err := auth(ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := header.Get("Authorization"); got != tc.want {
+				t.Errorf("expected the Authorization header %q but got %q", tc.want, got)
+			}
+		})
+	}
+
+	// The tokens are cached until they need to be refreshed.
+	sink := cloudEventSink("foo", "sink", "http://foo", v1alpha1.CloudEventSinkSpec{Auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "sa"}}})
+	if _, err := sinks.header(context.Background(), sink); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokenRequests != 3 {
+		t.Errorf("expected 3 token requests but got %d", tokenRequests)
+	}
+
+	// The credentials which don't opt in to be used by the sink are refused.
+	for _, tc := range []struct {
+		desc string
+		auth *v1alpha1.CloudEventSinkAuth
+	}{{
+		desc: "missing basic auth secret",
+		auth: &v1alpha1.CloudEventSinkAuth{BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{SecretName: "missing"}},
+	}, {
+		desc: "basic auth secret without the annotation",
+		auth: &v1alpha1.CloudEventSinkAuth{BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{SecretName: "private"}},
+	}, {
+		desc: "basic auth secret allowing another sink",
+		auth: &v1alpha1.CloudEventSinkAuth{BasicAuth: &v1alpha1.CloudEventSinkBasicAuth{SecretName: "other-creds"}},
+	}, {
+		desc: "missing service account",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "missing"}},
+	}, {
+		desc: "service account without the annotation",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "default"}},
+	}, {
+		desc: "audience which is not allowed",
+		auth: &v1alpha1.CloudEventSinkAuth{OIDCToken: &v1alpha1.CloudEventSinkOIDCToken{ServiceAccountName: "sa", Audience: "kubernetes.default.svc"}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			sink := cloudEventSink("foo", "sink", "http://foo", v1alpha1.CloudEventSinkSpec{Auth: tc.auth})
+			if _, err := sinks.header(configCtx, sink); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	if tokenRequests != 3 {
+		t.Errorf("expected no token request for the refused sinks but got %d", tokenRequests-3)
+	}
+}
+
+func TestEmitCloudEventsToSinks(t *testing.T) {
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "test1", SelfLink: "/taskruns/test1"}}
+	tr.Status.InitializeConditions()
+	sinks := testSinks(t, fakekubeclientset.NewSimpleClientset(),
+		cloudEventSink("foo", "taskruns", "http://taskruns", v1alpha1.CloudEventSinkSpec{Types: []string{"dev.tekton.event.taskrun.*"}}),
+		cloudEventSink("foo", "pipelineruns", "http://pipelineruns", v1alpha1.CloudEventSinkSpec{Types: []string{"dev.tekton.event.pipelinerun.*"}}),
+		cloudEventSink(testSystemNamespace, "all", "http://all", v1alpha1.CloudEventSinkSpec{}),
+		cloudEventSink(testSystemNamespace, "bar", "http://bar", v1alpha1.CloudEventSinkSpec{Namespaces: []string{"bar"}}),
+	)
+	want := []string{
+		`(?s)dev.tekton.event.taskrun.started.v1.*test1`,
+		`(?s)dev.tekton.event.taskrun.started.v1.*test1`,
+	}
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx = WithFakeClient(ctx, &FakeClientBehaviour{SendSuccessfully: true}, len(want))
+	fakeClient := Get(ctx).(FakeClient)
+	defaults, _ := config.NewDefaultsFromMap(map[string]string{})
+	ctx = config.ToContext(ctx, &config.Config{Defaults: defaults, FeatureFlags: config.DefaultFeatureFlags.DeepCopy()})
+	ctx = WithSinks(ctx, sinks)
+
+	EmitCloudEvents(ctx, tr)
+	fakeClient.CheckCloudEventsUnordered(t, "sinks", want)
+}
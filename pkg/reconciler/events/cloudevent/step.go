@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/uuid"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/logging"
+)
+
+// StepSummarySchemaV1 identifies the JSON schema of StepSummary in version v1.
+// It is set as the "dataschema" attribute of the CloudEvents sent for steps.
+// The schema is available in schema/v1/step-summary.json.
+const StepSummarySchemaV1 = "https://tekton.dev/schemas/events/v1/step-summary.json"
+
+// StepSummary is the payload of the CloudEvents sent for the steps of TaskRuns.
+type StepSummary struct {
+	// SchemaVersion is the version of the schema of the summary.
+	SchemaVersion string `json:"schemaVersion"`
+	// TaskRun is the name of the TaskRun running the step.
+	TaskRun string `json:"taskRun"`
+	// Namespace is the namespace of the TaskRun.
+	Namespace string `json:"namespace"`
+	// UID is the unique identifier of the TaskRun.
+	UID string `json:"uid"`
+	// Name is the name of the step.
+	Name string `json:"name"`
+	// Container is the name of the container of the step.
+	Container string `json:"container"`
+	// StartTime is the time the step started, unless it never did.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// CompletionTime is the time the step terminated.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// Duration is how long the step ran, once it has terminated.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// ExitCode is the exit code of the step, once it has terminated.
+	ExitCode *int32 `json:"exitCode,omitempty"`
+	// Reason is why the step terminated.
+	Reason string `json:"reason,omitempty"`
+}
+
+// EmitStepCloudEvents emits a CloudEvent for each step of the TaskRun which has started
+// or terminated since the previous steps, when "send-cloudevents-for-steps" is set
+// and a sink selects the namespace of the TaskRun or a transport is configured.
+func EmitStepCloudEvents(ctx context.Context, previous []v1beta1.StepState, tr *v1beta1.TaskRun) {
+	if !config.FromContextOrDefaults(ctx).FeatureFlags.SendCloudEventsForSteps {
+		return
+	}
+	sinks := sinksFor(ctx, tr.Namespace)
+	if len(sinks) == 0 && !hasTransports(ctx) {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	for _, event := range stepEvents(previous, tr) {
+		if err := sendStepCloudEvent(ctx, tr, event, sinks); err != nil {
+			logger.Warnf("Failed to emit cloud events %v", err.Error())
+		}
+	}
+}
+
+func sendStepCloudEvent(ctx context.Context, tr *v1beta1.TaskRun, event *cloudevents.Event, sinks []*v1alpha1.CloudEventSink) error {
+	ceClient := Get(ctx)
+	if ceClient == nil {
+		return errors.New("no cloud events client found in the context")
+	}
+	return sendToSinks(ctx, ceClient, tr, event, false, sinks)
+}
+
+// stepEvents returns the events of the steps of the TaskRun which have started or
+// terminated since the previous steps. A step is identified by its container and
+// its start or completion time, so that the steps of a retried TaskRun are new.
+func stepEvents(previous []v1beta1.StepState, tr *v1beta1.TaskRun) []*cloudevents.Event {
+	before := map[string]v1beta1.StepState{}
+	for _, step := range previous {
+		before[step.ContainerName] = step
+	}
+	var events []*cloudevents.Event
+	for _, step := range tr.Status.Steps {
+		prev := before[step.ContainerName]
+		if started := stepStartTime(step); started != nil && !started.Equal(stepStartTime(prev)) {
+			events = append(events, newStepEvent(tr, step, StepStartedEventV1))
+		}
+		if step.Terminated == nil || (prev.Terminated != nil && prev.Terminated.FinishedAt.Equal(&step.Terminated.FinishedAt)) {
+			continue
+		}
+		eventType := StepSuccessfulEventV1
+		if step.Terminated.ExitCode != 0 {
+			eventType = StepFailedEventV1
+		}
+		events = append(events, newStepEvent(tr, step, eventType))
+	}
+	return events
+}
+
+func newStepEvent(tr *v1beta1.TaskRun, step v1beta1.StepState, eventType TektonEventType) *cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(uuid.New().String())
+	event.SetSource(eventSource(tr))
+	event.SetSubject(step.Name)
+	event.SetType(eventType.String())
+	event.SetDataSchema(StepSummarySchemaV1)
+	// The summary has no fields which can fail to be marshalled.
+	_ = event.SetData(cloudevents.ApplicationJSON, newStepSummary(tr, step))
+	return &event
+}
+
+func newStepSummary(tr *v1beta1.TaskRun, step v1beta1.StepState) StepSummary {
+	summary := StepSummary{
+		SchemaVersion: RunSummarySchemaVersionV1,
+		TaskRun:       tr.Name,
+		Namespace:     tr.Namespace,
+		UID:           string(tr.UID),
+		Name:          step.Name,
+		Container:     step.ContainerName,
+		StartTime:     stepStartTime(step),
+	}
+	if t := step.Terminated; t != nil {
+		finishedAt := t.FinishedAt
+		exitCode := t.ExitCode
+		summary.CompletionTime = &finishedAt
+		summary.ExitCode = &exitCode
+		summary.Reason = t.Reason
+		if summary.StartTime != nil {
+			summary.Duration = &metav1.Duration{Duration: finishedAt.Sub(summary.StartTime.Time)}
+		}
+	}
+	return summary
+}
+
+// stepStartTime returns the time the step started, or nil if it never did.
+func stepStartTime(step v1beta1.StepState) *metav1.Time {
+	var startedAt metav1.Time
+	switch {
+	case step.Running != nil:
+		startedAt = step.Running.StartedAt
+	case step.Terminated != nil:
+		startedAt = step.Terminated.StartedAt
+	}
+	if startedAt.IsZero() {
+		return nil
+	}
+	return &startedAt
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+func stepsTaskRun(steps ...v1beta1.StepState) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "ns", UID: types.UID("tr-uid"), SelfLink: "/taskruns/tr"},
+		Status:     v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{Steps: steps}},
+	}
+}
+
+func runningStep(name string, startedAt metav1.Time) v1beta1.StepState {
+	return v1beta1.StepState{
+		Name:           name,
+		ContainerName:  "step-" + name,
+		ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: startedAt}},
+	}
+}
+
+func terminatedStep(name string, startedAt, finishedAt metav1.Time, exitCode int32) v1beta1.StepState {
+	return v1beta1.StepState{
+		Name:          name,
+		ContainerName: "step-" + name,
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			StartedAt:  startedAt,
+			FinishedAt: finishedAt,
+			ExitCode:   exitCode,
+			Reason:     "Completed",
+		}},
+	}
+}
+
+func TestStepEvents(t *testing.T) {
+	waiting := v1beta1.StepState{
+		Name:           "build",
+		ContainerName:  "step-build",
+		ContainerState: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}},
+	}
+	retriedAt := metav1.NewTime(completionTime.Add(time.Minute))
+	for _, tc := range []struct {
+		desc     string
+		previous []v1beta1.StepState
+		steps    []v1beta1.StepState
+		want     []string
+	}{{
+		desc:     "step started",
+		previous: []v1beta1.StepState{waiting},
+		steps:    []v1beta1.StepState{runningStep("build", startTime)},
+		want:     []string{"build " + StepStartedEventV1.String()},
+	}, {
+		desc:     "step still running",
+		previous: []v1beta1.StepState{runningStep("build", startTime)},
+		steps:    []v1beta1.StepState{runningStep("build", startTime)},
+	}, {
+		desc:     "step succeeded",
+		previous: []v1beta1.StepState{runningStep("build", startTime)},
+		steps:    []v1beta1.StepState{terminatedStep("build", startTime, completionTime, 0)},
+		want:     []string{"build " + StepSuccessfulEventV1.String()},
+	}, {
+		desc:  "short steps started and terminated",
+		steps: []v1beta1.StepState{terminatedStep("build", startTime, completionTime, 0), terminatedStep("test", startTime, completionTime, 1)},
+		want: []string{
+			"build " + StepStartedEventV1.String(),
+			"build " + StepSuccessfulEventV1.String(),
+			"test " + StepStartedEventV1.String(),
+			"test " + StepFailedEventV1.String(),
+		},
+	}, {
+		desc:     "step which never started failed",
+		previous: []v1beta1.StepState{waiting},
+		steps:    []v1beta1.StepState{terminatedStep("build", metav1.Time{}, completionTime, 1)},
+		want:     []string{"build " + StepFailedEventV1.String()},
+	}, {
+		desc:     "step of a retried TaskRun started",
+		previous: []v1beta1.StepState{terminatedStep("build", startTime, completionTime, 1)},
+		steps:    []v1beta1.StepState{runningStep("build", retriedAt)},
+		want:     []string{"build " + StepStartedEventV1.String()},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			var got []string
+			for _, event := range stepEvents(tc.previous, stepsTaskRun(tc.steps...)) {
+				got = append(got, event.Subject()+" "+event.Type())
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("events %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestStepEventData(t *testing.T) {
+	events := stepEvents(nil, stepsTaskRun(terminatedStep("build", startTime, completionTime, 2)))
+	if len(events) != 2 {
+		t.Fatalf("expected a started and a failed event but got %d events", len(events))
+	}
+	event := events[1]
+	if event.Source() != "/taskruns/tr" || event.DataSchema() != StepSummarySchemaV1 {
+		t.Errorf("unexpected source %q or data schema %q", event.Source(), event.DataSchema())
+	}
+	var got StepSummary
+	if err := json.Unmarshal(event.Data(), &got); err != nil {
+		t.Fatalf("error unmarshalling the event data: %v", err)
+	}
+	exitCode := int32(2)
+	want := StepSummary{
+		SchemaVersion:  RunSummarySchemaVersionV1,
+		TaskRun:        "tr",
+		Namespace:      "ns",
+		UID:            "tr-uid",
+		Name:           "build",
+		Container:      "step-build",
+		StartTime:      &startTime,
+		CompletionTime: &completionTime,
+		Duration:       &metav1.Duration{Duration: 5 * time.Minute},
+		ExitCode:       &exitCode,
+		Reason:         "Completed",
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("step summary %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEmitStepCloudEvents(t *testing.T) {
+	tr := stepsTaskRun(runningStep("build", startTime))
+	defaults, _ := config.NewDefaultsFromMap(map[string]string{"default-cloud-events-sink": "http://mysink"})
+	for _, tc := range []struct {
+		desc    string
+		enabled bool
+		want    []string
+	}{{
+		desc: "disabled",
+	}, {
+		desc:    "enabled",
+		enabled: true,
+		want:    []string{`(?s)dev.tekton.event.step.started.v1.*step-build`},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			ctx = WithFakeClient(ctx, &FakeClientBehaviour{SendSuccessfully: true}, len(tc.want))
+			fakeClient := Get(ctx).(FakeClient)
+			featureFlags := config.DefaultFeatureFlags.DeepCopy()
+			featureFlags.SendCloudEventsForSteps = tc.enabled
+			ctx = config.ToContext(ctx, &config.Config{Defaults: defaults, FeatureFlags: featureFlags})
+
+			EmitStepCloudEvents(ctx, nil, tr)
+			fakeClient.CheckCloudEventsUnordered(t, tc.desc, tc.want)
+		})
+	}
+}
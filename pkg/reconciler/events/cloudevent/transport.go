@@ -0,0 +1,221 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/nats-io/nats.go"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	controller "knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// structuredContentType is the content type of the CloudEvents published in the
+// structured mode, where the message holds the whole event encoded in JSON.
+const structuredContentType = "application/cloudevents+json"
+
+// publisher publishes CloudEvents to a topic or a subject of a messaging system.
+type publisher interface {
+	publish(ctx context.Context, event *cloudevents.Event) error
+	close()
+}
+
+// newKafkaPublisher and newNATSPublisher create the publishers of the transports,
+// and are replaced in tests.
+var (
+	newKafkaPublisher = func(brokers []string, topic string) (publisher, error) {
+		return newSaramaPublisher(brokers, topic)
+	}
+	newNATSPublisher = func(url, subject string) (publisher, error) {
+		return newNATSConnPublisher(url, subject)
+	}
+)
+
+// transports holds the publishers of the transports configured in the events
+// config map, which are shared by the reconcilers.
+var transports = &publishers{}
+
+// publishers creates the publishers of the configured transports on first use, and
+// creates them again when their configuration changes.
+type publishers struct {
+	mu       sync.Mutex
+	kafka    publisher
+	kafkaKey string
+	nats     publisher
+	natsKey  string
+}
+
+// get returns the publishers of the transports enabled by the events config.
+func (p *publishers) get(events *config.Events) ([]publisher, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var (
+		enabled []publisher
+		errs    []string
+	)
+	if events.KafkaEnabled() {
+		key := strings.Join(events.KafkaBrokers, ",") + "/" + events.KafkaTopic
+		if err := p.update(&p.kafka, &p.kafkaKey, key, func() (publisher, error) {
+			return newKafkaPublisher(events.KafkaBrokers, events.KafkaTopic)
+		}); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to create the Kafka producer: %v", err))
+		} else {
+			enabled = append(enabled, p.kafka)
+		}
+	}
+	if events.NATSEnabled() {
+		key := events.NATSURL + "/" + events.NATSSubject
+		if err := p.update(&p.nats, &p.natsKey, key, func() (publisher, error) {
+			return newNATSPublisher(events.NATSURL, events.NATSSubject)
+		}); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to connect to NATS: %v", err))
+		} else {
+			enabled = append(enabled, p.nats)
+		}
+	}
+	if len(errs) > 0 {
+		return enabled, errors.New(strings.Join(errs, "; "))
+	}
+	return enabled, nil
+}
+
+// update replaces the publisher when its configuration, identified by key, changed.
+// The publisher is left unset when it fails to be created, to be created again the
+// next time it is used.
+func (p *publishers) update(current *publisher, currentKey *string, key string, create func() (publisher, error)) error {
+	if *current != nil && *currentKey == key {
+		return nil
+	}
+	if *current != nil {
+		(*current).close()
+		*current = nil
+	}
+	created, err := create()
+	if err != nil {
+		return err
+	}
+	*current, *currentKey = created, key
+	return nil
+}
+
+// hasTransports returns true if the events config map configures a transport.
+func hasTransports(ctx context.Context) bool {
+	events := config.FromContextOrDefaults(ctx).Events
+	return events.KafkaEnabled() || events.NATSEnabled()
+}
+
+// publishToTransports publishes the event about object in the background to the
+// Kafka topic and the NATS subject configured in the events config map, if any.
+func publishToTransports(ctx context.Context, ceClient CEClient, object runtime.Object, event *cloudevents.Event) {
+	if !hasTransports(ctx) {
+		return
+	}
+	events := config.FromContextOrDefaults(ctx).Events
+	logger := logging.FromContext(ctx)
+	ceClient.addCount()
+	go func() {
+		defer ceClient.decreaseCount()
+		enabled, err := transports.get(events)
+		for _, p := range enabled {
+			if publishErr := p.publish(ctx, event); publishErr != nil && err == nil {
+				err = publishErr
+			}
+		}
+		if err != nil {
+			logger.Warnf("Failed to publish cloudevent: %s", err.Error())
+			if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+				recorder.Event(object, corev1.EventTypeWarning, "Cloud Event Failure", err.Error())
+			}
+		}
+	}()
+}
+
+// saramaPublisher publishes CloudEvents to a Kafka topic. The events of a run share
+// their source, which is used as the key of the messages so that they are published
+// to the same partition, in order.
+type saramaPublisher struct {
+	producer sarama.SyncProducer
+	topic    string
+}
+
+func newSaramaPublisher(brokers []string, topic string) (*saramaPublisher, error) {
+	cfg := sarama.NewConfig()
+	// Kafka supports the headers of the messages since 0.11.
+	cfg.Version = sarama.V0_11_0_0
+	cfg.ClientID = "tekton-pipelines"
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Return.Successes = true
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &saramaPublisher{producer: producer, topic: topic}, nil
+}
+
+func (p *saramaPublisher) publish(_ context.Context, event *cloudevents.Event) error {
+	value, err := event.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   p.topic,
+		Key:     sarama.StringEncoder(event.Source()),
+		Value:   sarama.ByteEncoder(value),
+		Headers: []sarama.RecordHeader{{Key: []byte("content-type"), Value: []byte(structuredContentType)}},
+	})
+	return err
+}
+
+func (p *saramaPublisher) close() {
+	_ = p.producer.Close()
+}
+
+// natsConnPublisher publishes CloudEvents to a NATS subject. The connection
+// reconnects to the servers, buffering the events published in the meantime.
+type natsConnPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSConnPublisher(url, subject string) (*natsConnPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("tekton-pipelines"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, err
+	}
+	return &natsConnPublisher{conn: conn, subject: subject}, nil
+}
+
+func (p *natsConnPublisher) publish(_ context.Context, event *cloudevents.Event) error {
+	data, err := event.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.subject, data)
+}
+
+func (p *natsConnPublisher) close() {
+	p.conn.Close()
+}
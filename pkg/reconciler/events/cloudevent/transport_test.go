@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevent
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/controller"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+type fakePublisher struct {
+	mu        sync.Mutex
+	name      string
+	published []string
+	closed    bool
+	err       error
+}
+
+func (p *fakePublisher) publish(_ context.Context, event *cloudevents.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, event.Type())
+	return nil
+}
+
+func (p *fakePublisher) close() {
+	p.closed = true
+}
+
+// withFakePublishers replaces the publishers of the transports with fakes, which
+// are returned by name as they are created.
+func withFakePublishers(t *testing.T, err error) map[string]*fakePublisher {
+	t.Helper()
+	created := map[string]*fakePublisher{}
+	kafka, nats, previous := newKafkaPublisher, newNATSPublisher, transports
+	newKafkaPublisher = func(brokers []string, topic string) (publisher, error) {
+		p := &fakePublisher{name: "kafka/" + topic, err: err}
+		created[p.name] = p
+		return p, nil
+	}
+	newNATSPublisher = func(url, subject string) (publisher, error) {
+		p := &fakePublisher{name: "nats/" + subject, err: err}
+		created[p.name] = p
+		return p, nil
+	}
+	transports = &publishers{}
+	t.Cleanup(func() {
+		newKafkaPublisher, newNATSPublisher, transports = kafka, nats, previous
+	})
+	return created
+}
+
+func eventsContext(t *testing.T, cfg map[string]string) context.Context {
+	t.Helper()
+	ctx, _ := rtesting.SetupFakeContext(t)
+	ctx = WithFakeClient(ctx, &FakeClientBehaviour{SendSuccessfully: true}, 0)
+	events, err := config.NewEventsFromMap(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults, _ := config.NewDefaultsFromMap(map[string]string{})
+	return config.ToContext(ctx, &config.Config{Defaults: defaults, FeatureFlags: config.DefaultFeatureFlags.DeepCopy(), Events: events})
+}
+
+func TestEmitCloudEventsToTransports(t *testing.T) {
+	created := withFakePublishers(t, nil)
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "test1", SelfLink: "/taskruns/test1"}}
+	tr.Status.InitializeConditions()
+
+	ctx := eventsContext(t, map[string]string{
+		"kafka-brokers": "kafka:9092",
+		"kafka-topic":   "tekton",
+		"nats-url":      "nats://nats:4222",
+		"nats-subject":  "tekton.events",
+	})
+	fakeClient := Get(ctx).(FakeClient)
+
+	EmitCloudEvents(ctx, tr)
+	// No http sink is configured, the event is only published to the transports.
+	fakeClient.CheckCloudEventsUnordered(t, "transports", nil)
+	for _, name := range []string{"kafka/tekton", "nats/tekton.events"} {
+		p := created[name]
+		if p == nil {
+			t.Fatalf("expected the publisher %s to be created", name)
+		}
+		if d := cmp.Diff([]string{"dev.tekton.event.taskrun.started.v1"}, p.published); d != "" {
+			t.Errorf("published to %s %s", name, diff.PrintWantGot(d))
+		}
+	}
+
+	// The publishers are created again when their configuration changes.
+	ctx = eventsContext(t, map[string]string{
+		"kafka-brokers": "kafka:9092",
+		"kafka-topic":   "tekton-v2",
+	})
+	fakeClient = Get(ctx).(FakeClient)
+	EmitCloudEvents(ctx, tr)
+	fakeClient.CheckCloudEventsUnordered(t, "new topic", nil)
+	if !created["kafka/tekton"].closed {
+		t.Error("expected the publisher of the previous topic to be closed")
+	}
+	if d := cmp.Diff([]string{"dev.tekton.event.taskrun.started.v1"}, created["kafka/tekton-v2"].published); d != "" {
+		t.Errorf("published to the new topic %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestEmitCloudEventsToTransportsFailure(t *testing.T) {
+	withFakePublishers(t, errors.New("broker unavailable"))
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "test1", SelfLink: "/taskruns/test1"}}
+	tr.Status.InitializeConditions()
+
+	ctx := eventsContext(t, map[string]string{"nats-url": "nats://nats:4222", "nats-subject": "tekton.events"})
+	fakeClient := Get(ctx).(FakeClient)
+	EmitCloudEvents(ctx, tr)
+	fakeClient.CheckCloudEventsUnordered(t, "failure", nil)
+
+	recorder := controller.GetEventRecorder(ctx).(*record.FakeRecorder)
+	select {
+	case event := <-recorder.Events:
+		if want := "Warning Cloud Event Failure broker unavailable"; event != want {
+			t.Errorf("expected the event %q but got %q", want, event)
+		}
+	default:
+		t.Error("expected a Cloud Event Failure event")
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/k8sevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/notification"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 )
@@ -30,9 +31,11 @@ import (
 //
 // k8s events are always sent if afterCondition is different from beforeCondition
 // Cloud events are always sent if enabled, i.e. if a sink is available
+// The NotificationPolicies notifying the change are posted too.
 func Emit(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
 	k8sevent.EmitK8sEvents(ctx, beforeCondition, afterCondition, object)
 	cloudevent.EmitCloudEventsWhenConditionChange(ctx, beforeCondition, afterCondition, object)
+	notification.NotifyWhenConditionChange(ctx, beforeCondition, afterCondition, object)
 }
 
 // EmitCloudEvents is refactored to cloudevent, this is to avoid breaking change
@@ -19,6 +19,7 @@ package k8sevent
 import (
 	"context"
 
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,8 +40,12 @@ const (
 )
 
 // EmitK8sEvents emits kubernetes events for object
-// k8s events are always sent if afterCondition is different from beforeCondition
+// k8s events are sent if afterCondition is different from beforeCondition, unless
+// the filters of the events config map exclude them
 func EmitK8sEvents(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
+	if !cloudevent.Emits(ctx, object) {
+		return
+	}
 	recorder := controller.GetEventRecorder(ctx)
 	// Events that are going to be sent
 	//
@@ -242,6 +242,69 @@ func TestEmitK8sEvents(t *testing.T) {
 	}
 }
 
+func TestEmitK8sEventsFiltered(t *testing.T) {
+	object := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1",
+			Namespace: "ci",
+			Labels:    map[string]string{"team": "platform"},
+		},
+		Status: v1beta1.PipelineRunStatus{Status: duckv1.Status{
+			Conditions: []apis.Condition{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionUnknown,
+				Reason: v1beta1.PipelineRunReasonStarted.String(),
+			}},
+		}},
+	}
+	after := object.Status.GetCondition(apis.ConditionSucceeded)
+	testcases := []struct {
+		name       string
+		data       map[string]string
+		wantEvents []string
+	}{{
+		name:       "matching type",
+		data:       map[string]string{"filter-event-types": "dev.tekton.event.pipelinerun.started.v1"},
+		wantEvents: []string{"Normal Started"},
+	}, {
+		name:       "other type",
+		data:       map[string]string{"filter-event-types": "dev.tekton.event.pipelinerun.failed.v1"},
+		wantEvents: []string{},
+	}, {
+		name:       "other namespace",
+		data:       map[string]string{"filter-namespaces": "release"},
+		wantEvents: []string{},
+	}, {
+		name:       "matching labels",
+		data:       map[string]string{"filter-namespaces": "ci", "filter-label-selector": "team=platform"},
+		wantEvents: []string{"Normal Started"},
+	}, {
+		name:       "other labels",
+		data:       map[string]string{"filter-label-selector": "team!=platform"},
+		wantEvents: []string{},
+	}}
+
+	for _, tc := range testcases {
+		ctx, _ := rtesting.SetupFakeContext(t)
+		events, err := config.NewEventsFromMap(tc.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defaults, _ := config.NewDefaultsFromMap(map[string]string{})
+		ctx = config.ToContext(ctx, &config.Config{
+			Defaults:     defaults,
+			FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+			Events:       events,
+		})
+
+		recorder := controller.GetEventRecorder(ctx).(*record.FakeRecorder)
+		k8sevents.EmitK8sEvents(ctx, nil, after, object)
+		if err := k8sevents.CheckEventsOrdered(t, recorder.Events, tc.name, tc.wantEvents); err != nil {
+			t.Errorf(err.Error())
+		}
+	}
+}
+
 func TestEmitError(t *testing.T) {
 	testcases := []struct {
 		name       string
@@ -0,0 +1,421 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notification posts the messages of the NotificationPolicies about the
+// status changes of the PipelineRuns and TaskRuns to chat services and webhooks.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// defaultTemplate is the template of the messages of the receivers which don't
+	// configure one.
+	defaultTemplate = `{{.Kind}} {{.Namespace}}/{{.Name}} {{.Event}}` +
+		`{{if eq .Event "Failed"}} ({{.Reason}}){{end}}{{with .Duration}} after {{.}}{{end}}.` +
+		`{{with .FailedTasks}} Failed tasks:{{range .}} {{.}}{{end}}.{{end}}` +
+		`{{with .FailedSteps}} Failed steps:{{range .}} {{.}}{{end}}.{{end}}` +
+		`{{with .LogURL}} Logs: {{.}}{{end}}`
+
+	// postAttempts is the number of attempts to post a message.
+	postAttempts = 3
+	// postTimeout is the timeout of each attempt to post a message.
+	postTimeout = 10 * time.Second
+	// postDeadline is the timeout of posting a message, including the retries.
+	postDeadline = time.Minute
+	// postDelay is the delay between the attempts to post a message, multiplied by
+	// the number of attempts.
+	postDelay = time.Second
+
+	// failureReason is the reason of the Kubernetes events about the messages which
+	// failed to be posted.
+	failureReason = "NotificationFailure"
+)
+
+// Data is what the messages and the log URLs are rendered from. It is also posted
+// as JSON to the receivers of type "webhook", along with the message.
+type Data struct {
+	// Kind is the kind of the run, "PipelineRun" or "TaskRun".
+	Kind string `json:"kind"`
+	// Name is the name of the run.
+	Name string `json:"name"`
+	// Namespace is the namespace of the run.
+	Namespace string `json:"namespace"`
+	// Labels are the labels of the run.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Event is the status change notified, "Started", "Succeeded" or "Failed".
+	Event string `json:"event"`
+	// Reason is the reason of the condition of the run, e.g. "PipelineRunTimeout".
+	Reason string `json:"reason,omitempty"`
+	// Message is the message of the condition of the run.
+	Message string `json:"message,omitempty"`
+	// Duration is how long a completed run ran for, e.g. "3m2s".
+	Duration string `json:"duration,omitempty"`
+	// FailedTasks are the names of the PipelineTasks whose runs failed.
+	FailedTasks []string `json:"failedTasks,omitempty"`
+	// FailedSteps are the names of the steps of a TaskRun which failed.
+	FailedSteps []string `json:"failedSteps,omitempty"`
+	// LogURL is the link to the logs of the run, rendered from the logURL of the policy.
+	LogURL string `json:"logURL,omitempty"`
+}
+
+// Notifier posts the messages of the NotificationPolicies listed by its lister.
+type Notifier struct {
+	policies   alpha1listers.NotificationPolicyLister
+	taskRuns   listers.TaskRunLister
+	customRuns listers.CustomRunLister
+	kubeclient kubernetes.Interface
+	client     *http.Client
+	delay      time.Duration
+}
+
+// NewNotifier returns a Notifier posting the messages of the NotificationPolicies
+// listed by the lister. The TaskRuns and CustomRuns of the PipelineRuns are listed to
+// find their failed tasks, and the URLs of the receivers are read from the secrets with
+// the kubeclient.
+func NewNotifier(policies alpha1listers.NotificationPolicyLister, taskRuns listers.TaskRunLister, customRuns listers.CustomRunLister, kubeclient kubernetes.Interface) *Notifier {
+	return &Notifier{
+		policies:   policies,
+		taskRuns:   taskRuns,
+		customRuns: customRuns,
+		kubeclient: kubeclient,
+		client:     newClient(),
+		delay:      postDelay,
+	}
+}
+
+// newClient returns the client posting the messages. The URLs of the receivers are
+// set by the users of the namespaces, so it refuses to connect to the loopback,
+// link-local and unspecified addresses, which would let them reach the endpoints of
+// the controller pod or the metadata services of the cloud providers. The check is
+// made on the resolved addresses, so that it can't be bypassed with DNS.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: postTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowedAddress(ip) {
+				return fmt.Errorf("refusing to post to the address %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: postTimeout, Transport: transport}
+}
+
+// allowedAddress returns false for the addresses the messages can't be posted to.
+func allowedAddress(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsUnspecified()
+}
+
+// notifierKey is used to associate the Notifier inside the context.Context
+type notifierKey struct{}
+
+// WithNotifier adds the Notifier to the context, so that the status changes of the
+// runs are notified.
+func WithNotifier(ctx context.Context, n *Notifier) context.Context {
+	return context.WithValue(ctx, notifierKey{}, n)
+}
+
+// NotifyWhenConditionChange posts the messages of the NotificationPolicies of the
+// namespace of object which notify the change from beforeCondition to afterCondition,
+// if the context has a Notifier. The messages are posted in the background, with a
+// context of their own, as the context of the reconciliation is canceled when it
+// returns.
+func NotifyWhenConditionChange(ctx context.Context, beforeCondition *apis.Condition, afterCondition *apis.Condition, object runtime.Object) {
+	n, _ := ctx.Value(notifierKey{}).(*Notifier)
+	if n == nil || n.policies == nil || equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return
+	}
+	event, ok := eventFor(beforeCondition, afterCondition)
+	if !ok {
+		return
+	}
+	var kind string
+	var meta metav1.Object
+	switch o := object.(type) {
+	case *v1beta1.PipelineRun:
+		kind, meta = pipeline.PipelineRunControllerName, o
+	case *v1beta1.TaskRun:
+		kind, meta = pipeline.TaskRunControllerName, o
+	default:
+		return
+	}
+	logger := logging.FromContext(ctx)
+	policies, err := n.policies.NotificationPolicies(meta.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		logger.Warnf("Failed to list the NotificationPolicies of namespace %s: %v", meta.GetNamespace(), err)
+		return
+	}
+	postCtx := logging.WithLogger(context.Background(), logger)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		postCtx = controller.WithEventRecorder(postCtx, recorder)
+	}
+	var data *Data
+	for _, p := range policies {
+		if !p.NotifiesKind(kind) || !p.NotifiesEvent(event) || !selects(p, meta) {
+			continue
+		}
+		if data == nil {
+			data = n.dataFor(object, kind, event, afterCondition)
+		}
+		d := *data
+		if p.Spec.LogURL != "" {
+			logURL, err := render("logURL", p.Spec.LogURL, data)
+			if err != nil {
+				logger.Warnf("Failed to render the log URL of NotificationPolicy %s/%s: %v", p.Namespace, p.Name, err)
+			}
+			d.LogURL = logURL
+		}
+		for _, r := range p.Spec.Receivers {
+			go n.post(postCtx, logger, p, r, object, &d)
+		}
+	}
+}
+
+// eventFor returns the event notified for the change from before to after, and false
+// if the change isn't notified. Only the changes of the status are notified, not the
+// changes of the reason or the message of a run whose status is unchanged.
+func eventFor(before, after *apis.Condition) (v1alpha1.NotificationEvent, bool) {
+	switch {
+	case after == nil:
+		return "", false
+	case before != nil && before.Status == after.Status:
+		return "", false
+	case after.IsTrue():
+		return v1alpha1.NotificationEventSucceeded, true
+	case after.IsFalse():
+		return v1alpha1.NotificationEventFailed, true
+	case before == nil:
+		return v1alpha1.NotificationEventStarted, true
+	}
+	return "", false
+}
+
+// selects returns true if the selector of the policy selects the run.
+func selects(p *v1alpha1.NotificationPolicy, meta metav1.Object) bool {
+	if p.Spec.Selector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(meta.GetLabels()))
+}
+
+// dataFor returns the data the messages about the event of object are rendered from.
+func (n *Notifier) dataFor(object runtime.Object, kind string, event v1alpha1.NotificationEvent, condition *apis.Condition) *Data {
+	data := &Data{Kind: kind, Event: string(event), Reason: condition.Reason, Message: condition.Message}
+	var start, completion *metav1.Time
+	switch o := object.(type) {
+	case *v1beta1.PipelineRun:
+		data.Name, data.Namespace, data.Labels = o.Name, o.Namespace, o.Labels
+		start, completion = o.Status.StartTime, o.Status.CompletionTime
+		if event == v1alpha1.NotificationEventFailed {
+			data.FailedTasks = n.failedTasks(o)
+		}
+	case *v1beta1.TaskRun:
+		data.Name, data.Namespace, data.Labels = o.Name, o.Namespace, o.Labels
+		start, completion = o.Status.StartTime, o.Status.CompletionTime
+		if event == v1alpha1.NotificationEventFailed {
+			for _, s := range o.Status.Steps {
+				if s.Terminated != nil && s.Terminated.ExitCode != 0 {
+					data.FailedSteps = append(data.FailedSteps, s.Name)
+				}
+			}
+		}
+	}
+	if start != nil && completion != nil {
+		data.Duration = completion.Sub(start.Time).Round(time.Second).String()
+	}
+	return data
+}
+
+// failedTasks returns the names of the PipelineTasks of the PipelineRun whose
+// TaskRuns or CustomRuns failed.
+func (n *Notifier) failedTasks(pr *v1beta1.PipelineRun) []string {
+	var failed []string
+	for _, child := range pr.Status.ChildReferences {
+		switch child.Kind {
+		case pipeline.TaskRunControllerName:
+			if n.taskRuns == nil {
+				continue
+			}
+			if tr, err := n.taskRuns.TaskRuns(pr.Namespace).Get(child.Name); err == nil && tr.IsDone() && !tr.IsSuccessful() {
+				failed = append(failed, child.PipelineTaskName)
+			}
+		case pipeline.CustomRunControllerName:
+			if n.customRuns == nil {
+				continue
+			}
+			if cr, err := n.customRuns.CustomRuns(pr.Namespace).Get(child.Name); err == nil && cr.IsDone() && !cr.IsSuccessful() {
+				failed = append(failed, child.PipelineTaskName)
+			}
+		}
+	}
+	return failed
+}
+
+// post posts the message rendered from data to the receiver of the policy, with
+// retries, within postDeadline. A Kubernetes event is emitted about object if it fails.
+func (n *Notifier) post(ctx context.Context, logger *zap.SugaredLogger, p *v1alpha1.NotificationPolicy, r v1alpha1.NotificationReceiver, object runtime.Object, data *Data) {
+	postCtx, cancel := context.WithTimeout(ctx, postDeadline)
+	defer cancel()
+	err := n.tryPost(postCtx, p, r, data)
+	if err == nil {
+		return
+	}
+	logger.Warnf("Failed to post the notification of NotificationPolicy %s/%s to receiver %s: %v", p.Namespace, p.Name, r.Name, err)
+	if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+		recorder.Eventf(object, corev1.EventTypeWarning, failureReason,
+			"Failed to post the notification of NotificationPolicy %s to receiver %s: %v", p.Name, r.Name, err)
+	}
+}
+
+func (n *Notifier) tryPost(ctx context.Context, p *v1alpha1.NotificationPolicy, r v1alpha1.NotificationReceiver, data *Data) error {
+	url := r.URL
+	if r.URLFrom != nil {
+		secret, err := n.kubeclient.CoreV1().Secrets(p.Namespace).Get(ctx, r.URLFrom.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get the secret holding the URL: %w", err)
+		}
+		url = strings.TrimSpace(string(secret.Data[r.URLFrom.Key]))
+		if url == "" {
+			return fmt.Errorf("secret %s has no key %s", r.URLFrom.Name, r.URLFrom.Key)
+		}
+	}
+	body, err := payload(r, data)
+	if err != nil {
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		err = n.send(ctx, url, body)
+		if err == nil || attempt == postAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(n.delay * time.Duration(attempt)):
+		}
+	}
+}
+
+// send posts the body to the URL, within postTimeout.
+func (n *Notifier) send(ctx context.Context, url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, postTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+// payload returns the JSON body posted to the receiver.
+func payload(r v1alpha1.NotificationReceiver, data *Data) ([]byte, error) {
+	tmpl := r.Template
+	if tmpl == "" {
+		tmpl = defaultTemplate
+	}
+	message, err := render(r.Name, tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Type {
+	case v1alpha1.NotificationReceiverSlack:
+		return json.Marshal(map[string]string{"text": message})
+	case v1alpha1.NotificationReceiverTeams:
+		return json.Marshal(map[string]string{
+			"@type":      "MessageCard",
+			"@context":   "https://schema.org/extensions",
+			"summary":    fmt.Sprintf("%s %s %s", data.Kind, data.Name, data.Event),
+			"themeColor": themeColor(data.Event),
+			"text":       message,
+		})
+	default:
+		return json.Marshal(struct {
+			*Data
+			Text string `json:"text"`
+		}{Data: data, Text: message})
+	}
+}
+
+// themeColor returns the color of the cards posted to Teams about the event.
+func themeColor(event string) string {
+	switch v1alpha1.NotificationEvent(event) {
+	case v1alpha1.NotificationEventSucceeded:
+		return "2EB886"
+	case v1alpha1.NotificationEventFailed:
+		return "D00000"
+	}
+	return "439FE0"
+}
+
+// render executes the template with the data.
+func render(name, text string, data *Data) (string, error) {
+	t, err := template.New(name).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
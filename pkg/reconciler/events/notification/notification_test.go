@@ -0,0 +1,366 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+)
+
+type post struct {
+	path string
+	body map[string]interface{}
+}
+
+// newServer returns a server sending the requests it receives on the channel,
+// answering them with the status.
+func newServer(t *testing.T, status int) (*httptest.Server, chan post) {
+	t.Helper()
+	posts := make(chan post, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("reading the request body: %v", err)
+		}
+		body := map[string]interface{}{}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("unmarshalling the request body %q: %v", b, err)
+		}
+		posts <- post{path: r.URL.Path, body: body}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, posts
+}
+
+// receive returns the posts received by the server, failing if it doesn't receive
+// count of them.
+func receive(t *testing.T, posts chan post, count int) []post {
+	t.Helper()
+	var got []post
+	for len(got) < count {
+		select {
+		case p := <-posts:
+			got = append(got, p)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d posts, want %d", len(got), count)
+		}
+	}
+	select {
+	case p := <-posts:
+		t.Fatalf("received unexpected post %v", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+	return got
+}
+
+func newNotifier(t *testing.T, policies []*v1alpha1.NotificationPolicy, taskRuns []*v1beta1.TaskRun, objects ...runtime.Object) *Notifier {
+	t.Helper()
+	policyIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, p := range policies {
+		if err := policyIndexer.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	taskRunIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, tr := range taskRuns {
+		if err := taskRunIndexer.Add(tr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := NewNotifier(alpha1listers.NewNotificationPolicyLister(policyIndexer), listers.NewTaskRunLister(taskRunIndexer), nil, fakek8s.NewSimpleClientset(objects...))
+	n.delay = 0
+	// The test servers listen on the loopback address, which the client of the
+	// Notifier refuses.
+	n.client = &http.Client{Timeout: postTimeout}
+	return n
+}
+
+func condition(status corev1.ConditionStatus, reason string) *apis.Condition {
+	return &apis.Condition{Type: apis.ConditionSucceeded, Status: status, Reason: reason}
+}
+
+func failedPipelineRun() *v1beta1.PipelineRun {
+	start := metav1.NewTime(time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(start.Add(3*time.Minute + 2*time.Second))
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-1", Namespace: "team-a", Labels: map[string]string{"team": "build"}},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{*condition(corev1.ConditionFalse, "Failed")}},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime:      &start,
+				CompletionTime: &completion,
+				ChildReferences: []v1beta1.ChildStatusReference{{
+					TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+					Name:             "build-1-compile",
+					PipelineTaskName: "compile",
+				}, {
+					TypeMeta:         runtime.TypeMeta{Kind: "TaskRun"},
+					Name:             "build-1-test",
+					PipelineTaskName: "test",
+				}},
+			},
+		},
+	}
+}
+
+func taskRun(name string, status corev1.ConditionStatus) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{*condition(status, "")}},
+		},
+	}
+}
+
+func TestNotifyWhenConditionChange(t *testing.T) {
+	server, posts := newServer(t, http.StatusOK)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "slack-webhook", Namespace: "team-a"},
+		Data:       map[string][]byte{"url": []byte(server.URL + "/slack\n")},
+	}
+	policy := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "team-a"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "build"}},
+			LogURL:   "https://dashboard.example.com/#/namespaces/{{.Namespace}}/pipelineruns/{{.Name}}",
+			Receivers: []v1alpha1.NotificationReceiver{{
+				Name: "slack",
+				Type: v1alpha1.NotificationReceiverSlack,
+				URLFrom: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "slack-webhook"},
+					Key:                  "url",
+				},
+			}, {
+				Name:     "hook",
+				Type:     v1alpha1.NotificationReceiverWebhook,
+				URL:      server.URL + "/hook",
+				Template: "{{.Name}}:{{range .FailedTasks}} {{.}}{{end}}",
+			}},
+		},
+	}
+	taskRuns := []*v1beta1.TaskRun{taskRun("build-1-compile", corev1.ConditionFalse), taskRun("build-1-test", corev1.ConditionTrue)}
+	n := newNotifier(t, []*v1alpha1.NotificationPolicy{policy}, taskRuns, secret)
+	ctx := WithNotifier(context.Background(), n)
+
+	pr := failedPipelineRun()
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionFalse, "Failed"), pr)
+	got := map[string]map[string]interface{}{}
+	for _, p := range receive(t, posts, 2) {
+		got[p.path] = p.body
+	}
+	want := map[string]map[string]interface{}{
+		"/slack": {"text": "PipelineRun team-a/build-1 Failed (Failed) after 3m2s. Failed tasks: compile. " +
+			"Logs: https://dashboard.example.com/#/namespaces/team-a/pipelineruns/build-1"},
+		"/hook": {
+			"kind":        "PipelineRun",
+			"name":        "build-1",
+			"namespace":   "team-a",
+			"labels":      map[string]interface{}{"team": "build"},
+			"event":       "Failed",
+			"reason":      "Failed",
+			"duration":    "3m2s",
+			"failedTasks": []interface{}{"compile"},
+			"logURL":      "https://dashboard.example.com/#/namespaces/team-a/pipelineruns/build-1",
+			"text":        "build-1: compile",
+		},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected posts %s", diff.PrintWantGot(d))
+	}
+
+	// The starts aren't notified by default.
+	NotifyWhenConditionChange(ctx, nil, condition(corev1.ConditionUnknown, "Started"), pr)
+	// Nor are the changes of running runs.
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Started"), condition(corev1.ConditionUnknown, "Running"), pr)
+	// Nor are the changes of the reason of failed runs.
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionFalse, "Failed"), condition(corev1.ConditionFalse, "PipelineRunTimeout"), pr)
+	// Nor are the TaskRuns.
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionFalse, "Failed"), taskRuns[0])
+	// Nor are the runs the selector doesn't select.
+	unselected := pr.DeepCopy()
+	unselected.Labels = nil
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionFalse, "Failed"), unselected)
+	receive(t, posts, 0)
+}
+
+func TestNotifyWhenConditionChange_TaskRun(t *testing.T) {
+	server, posts := newServer(t, http.StatusOK)
+	policy := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "team-a"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Kinds:     []string{"TaskRun"},
+			Events:    []v1alpha1.NotificationEvent{v1alpha1.NotificationEventStarted, v1alpha1.NotificationEventFailed},
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "teams", Type: v1alpha1.NotificationReceiverTeams, URL: server.URL}},
+		},
+	}
+	ctx := WithNotifier(context.Background(), newNotifier(t, []*v1alpha1.NotificationPolicy{policy}, nil))
+
+	tr := taskRun("unit-tests", corev1.ConditionUnknown)
+	NotifyWhenConditionChange(ctx, nil, condition(corev1.ConditionUnknown, "Started"), tr)
+	got := []map[string]interface{}{receive(t, posts, 1)[0].body}
+	tr.Status.Steps = []v1beta1.StepState{{
+		Name:           "setup",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+	}, {
+		Name:           "test",
+		ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+	}}
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionFalse, "Failed"), tr)
+	got = append(got, receive(t, posts, 1)[0].body)
+	want := []map[string]interface{}{{
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    "TaskRun unit-tests Started",
+		"themeColor": "439FE0",
+		"text":       "TaskRun team-a/unit-tests Started.",
+	}, {
+		"@type":      "MessageCard",
+		"@context":   "https://schema.org/extensions",
+		"summary":    "TaskRun unit-tests Failed",
+		"themeColor": "D00000",
+		"text":       "TaskRun team-a/unit-tests Failed (Failed). Failed steps: test.",
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected posts %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestNotifyWhenConditionChange_Failure(t *testing.T) {
+	server, posts := newServer(t, http.StatusInternalServerError)
+	policy := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "team-a"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "slack", Type: v1alpha1.NotificationReceiverSlack, URL: server.URL}},
+		},
+	}
+	recorder := record.NewFakeRecorder(1)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	ctx = WithNotifier(ctx, newNotifier(t, []*v1alpha1.NotificationPolicy{policy}, nil))
+
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionTrue, "Succeeded"), failedPipelineRun())
+	receive(t, posts, postAttempts)
+	select {
+	case event := <-recorder.Events:
+		want := "Warning NotificationFailure Failed to post the notification of NotificationPolicy notify to receiver slack: " +
+			"unexpected response status 500 Internal Server Error"
+		if event != want {
+			t.Errorf("unexpected event %q, want %q", event, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an event about the failure")
+	}
+}
+
+func TestNotifyWhenConditionChange_DetachedContext(t *testing.T) {
+	server, posts := newServer(t, http.StatusOK)
+	policy := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "team-a"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "slack", Type: v1alpha1.NotificationReceiverSlack, URL: server.URL}},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = WithNotifier(ctx, newNotifier(t, []*v1alpha1.NotificationPolicy{policy}, nil))
+
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionTrue, "Succeeded"), failedPipelineRun())
+	// The reconciliation returns before the message is posted.
+	cancel()
+	receive(t, posts, 1)
+}
+
+func TestNotifyWhenConditionChange_RefusedAddress(t *testing.T) {
+	server, posts := newServer(t, http.StatusOK)
+	policy := &v1alpha1.NotificationPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "notify", Namespace: "team-a"},
+		Spec: v1alpha1.NotificationPolicySpec{
+			Receivers: []v1alpha1.NotificationReceiver{{Name: "slack", Type: v1alpha1.NotificationReceiverSlack, URL: server.URL}},
+		},
+	}
+	n := newNotifier(t, []*v1alpha1.NotificationPolicy{policy}, nil)
+	n.client = newClient()
+	recorder := record.NewFakeRecorder(1)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	ctx = WithNotifier(ctx, n)
+
+	NotifyWhenConditionChange(ctx, condition(corev1.ConditionUnknown, "Running"), condition(corev1.ConditionTrue, "Succeeded"), failedPipelineRun())
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, "refusing to post to the address 127.0.0.1") {
+			t.Errorf("unexpected event %q", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an event about the refused address")
+	}
+	receive(t, posts, 0)
+}
+
+func TestAllowedAddress(t *testing.T) {
+	for _, tc := range []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "10.0.0.1", want: true},
+		{ip: "203.0.113.10", want: true},
+		{ip: "2001:db8::1", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "0.0.0.0", want: false},
+	} {
+		if got := allowedAddress(net.ParseIP(tc.ip)); got != tc.want {
+			t.Errorf("allowedAddress(%s) = %t, want %t", tc.ip, got, tc.want)
+		}
+	}
+}
+
+func TestNotifyWhenConditionChange_NoNotifier(t *testing.T) {
+	// Doesn't panic without a Notifier in the context.
+	NotifyWhenConditionChange(context.Background(), nil, condition(corev1.ConditionFalse, "Failed"), failedPipelineRun())
+}
+
+func TestPayload_InvalidTemplate(t *testing.T) {
+	r := v1alpha1.NotificationReceiver{Name: "hook", Type: v1alpha1.NotificationReceiverWebhook, Template: "{{.Name.Missing}}"}
+	_, err := payload(r, &Data{Name: "build-1"})
+	if err == nil || !strings.Contains(err.Error(), "can't evaluate field Missing") {
+		t.Errorf("expected an error executing the template, got %v", err)
+	}
+}
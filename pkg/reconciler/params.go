@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+var (
+	// ErrParamValueSourceNotFound is returned when the ConfigMap or the key a param takes
+	// its value from doesn't exist.
+	ErrParamValueSourceNotFound = errors.New("the source of the value of the param doesn't exist")
+	// ErrParamValueSourceNotSecret is returned when a param taking its value from a Secret
+	// isn't declared as a secret param.
+	ErrParamValueSourceNotSecret = errors.New("the param taking its value from a Secret must be of type secret")
+)
+
+// ResolveConfigMapParams returns the params taking their values from the keys of ConfigMaps,
+// with the values of the keys read through the lister in the namespace. The params selecting
+// an optional key which doesn't exist are left out, so that they take their default values.
+// The values are resolved once, when the run starts, and recorded in its status.
+func ResolveConfigMapParams(lister corev1listers.ConfigMapLister, namespace string, params v1beta1.Params) (v1beta1.Params, error) {
+	var resolved v1beta1.Params
+	for _, p := range params {
+		if p.ValueFrom == nil || p.ValueFrom.ConfigMapKeyRef == nil {
+			continue
+		}
+		ref := p.ValueFrom.ConfigMapKeyRef
+		var data map[string]string
+		cm, err := lister.ConfigMaps(namespace).Get(ref.Name)
+		switch {
+		case err == nil:
+			data = cm.Data
+		case !k8serrors.IsNotFound(err):
+			return nil, fmt.Errorf("failed to resolve the value of the param %q: %w", p.Name, err)
+		}
+		value, ok := data[ref.Key]
+		if !ok {
+			if ref.Optional != nil && *ref.Optional {
+				continue
+			}
+			return nil, fmt.Errorf("failed to resolve the value of the param %q: %w: key %q of ConfigMap %s/%s", p.Name, ErrParamValueSourceNotFound, ref.Key, namespace, ref.Name)
+		}
+		resolved = append(resolved, v1beta1.Param{Name: p.Name, Value: *v1beta1.NewStructuredValues(value)})
+	}
+	return resolved, nil
+}
+
+// ValidateSecretParamSources checks that the params taking their values from the keys of
+// Secrets are declared as secret params, whose values are mounted in the steps rather than
+// substituted.
+func ValidateSecretParamSources(paramSpecs v1beta1.ParamSpecs, params v1beta1.Params) error {
+	types := map[string]v1beta1.ParamType{}
+	for _, ps := range paramSpecs {
+		types[ps.Name] = ps.Type
+	}
+	for _, p := range params {
+		if p.ValueFrom == nil || p.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		if t, ok := types[p.Name]; ok && t != v1beta1.ParamTypeSecret {
+			return fmt.Errorf("%w: param %q is of type %q", ErrParamValueSourceNotSecret, p.Name, t)
+		}
+	}
+	return nil
+}
+
+// ApplyParamValueSources returns the params with the params taking their values from the keys
+// of ConfigMaps set to the values resolved when the run started, and the params taking their
+// values from the keys of Secrets set to the references to the keys, as the values of secret
+// params. The values of the Secrets are thus never read nor substituted by the reconciler.
+func ApplyParamValueSources(params v1beta1.Params, resolved v1beta1.Params) v1beta1.Params {
+	values := map[string]v1beta1.ParamValue{}
+	for _, p := range resolved {
+		values[p.Name] = p.Value
+	}
+	var applied v1beta1.Params
+	for _, p := range params {
+		switch {
+		case p.ValueFrom == nil:
+			applied = append(applied, p)
+		case p.ValueFrom.SecretKeyRef != nil:
+			ref := p.ValueFrom.SecretKeyRef
+			applied = append(applied, v1beta1.Param{Name: p.Name, Value: *v1beta1.NewObject(map[string]string{"name": ref.Name, "key": ref.Key})})
+		default:
+			if value, ok := values[p.Name]; ok {
+				applied = append(applied, v1beta1.Param{Name: p.Name, Value: value})
+			}
+		}
+	}
+	return applied
+}
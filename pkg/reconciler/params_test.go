@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func configMapKeyRef(name, key string, optional bool) *v1beta1.ParamValueSource {
+	return &v1beta1.ParamValueSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+		Optional:             &optional,
+	}}
+}
+
+func secretKeyRef(name, key string, optional bool) *v1beta1.ParamValueSource {
+	return &v1beta1.ParamValueSource{SecretKeyRef: &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+		Optional:             &optional,
+	}}
+}
+
+func configMapLister(t *testing.T, cms ...*corev1.ConfigMap) corev1listers.ConfigMapLister {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, cm := range cms {
+		if err := indexer.Add(cm); err != nil {
+			t.Fatalf("Failed to add the ConfigMap %s to the indexer: %v", cm.Name, err)
+		}
+	}
+	return corev1listers.NewConfigMapLister(indexer)
+}
+
+func TestResolveConfigMapParams(t *testing.T) {
+	lister := configMapLister(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "foo"},
+		Data:       map[string]string{"branch": "main"},
+	})
+	for _, tc := range []struct {
+		name   string
+		params v1beta1.Params
+		want   v1beta1.Params
+	}{{
+		name:   "literal value",
+		params: v1beta1.Params{{Name: "p", Value: *v1beta1.NewStructuredValues("v")}},
+	}, {
+		name: "configMapKeyRef",
+		params: v1beta1.Params{{
+			Name: "branch", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("config", "branch", false),
+		}},
+		want: v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main")}},
+	}, {
+		name: "secretKeyRef isn't read",
+		params: v1beta1.Params{{
+			Name: "token", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: secretKeyRef("creds", "token", false),
+		}},
+	}, {
+		name: "optional missing key",
+		params: v1beta1.Params{{
+			Name: "branch", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("config", "branch", false),
+		}, {
+			Name: "missing", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("config", "missing", true),
+		}, {
+			Name: "missing-configmap", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("missing", "branch", true),
+		}},
+		want: v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main")}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := reconciler.ResolveConfigMapParams(lister, "foo", tc.params)
+			if err != nil {
+				t.Fatalf("ResolveConfigMapParams() returned an unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("ResolveConfigMapParams() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveConfigMapParams_NotFound(t *testing.T) {
+	lister := configMapLister(t, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "foo"},
+		Data:       map[string]string{"branch": "main"},
+	}, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "bar"},
+		Data:       map[string]string{"branch": "main"},
+	})
+	for _, tc := range []struct {
+		name  string
+		param v1beta1.Param
+	}{{
+		name:  "missing key",
+		param: v1beta1.Param{Name: "p", ValueFrom: configMapKeyRef("config", "missing", false)},
+	}, {
+		name:  "missing configmap in the namespace",
+		param: v1beta1.Param{Name: "p", ValueFrom: configMapKeyRef("other", "branch", false)},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := reconciler.ResolveConfigMapParams(lister, "foo", v1beta1.Params{tc.param})
+			if !errors.Is(err, reconciler.ErrParamValueSourceNotFound) {
+				t.Errorf("ResolveConfigMapParams() returned error %v, want %v", err, reconciler.ErrParamValueSourceNotFound)
+			}
+		})
+	}
+}
+
+func TestApplyParamValueSources(t *testing.T) {
+	params := v1beta1.Params{{
+		Name: "p", Value: *v1beta1.NewStructuredValues("v"),
+	}, {
+		Name: "branch", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("config", "branch", false),
+	}, {
+		Name: "missing", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: configMapKeyRef("config", "missing", true),
+	}, {
+		Name: "token", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: secretKeyRef("creds", "token", false),
+	}}
+	resolved := v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main")}}
+	want := v1beta1.Params{{
+		Name: "p", Value: *v1beta1.NewStructuredValues("v"),
+	}, {
+		Name: "branch", Value: *v1beta1.NewStructuredValues("main"),
+	}, {
+		Name: "token", Value: *v1beta1.NewObject(map[string]string{"name": "creds", "key": "token"}),
+	}}
+	if d := cmp.Diff(want, reconciler.ApplyParamValueSources(params, resolved)); d != "" {
+		t.Errorf("ApplyParamValueSources() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidateSecretParamSources(t *testing.T) {
+	params := v1beta1.Params{{
+		Name: "token", Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeString}, ValueFrom: secretKeyRef("creds", "token", false),
+	}}
+	if err := reconciler.ValidateSecretParamSources(v1beta1.ParamSpecs{{Name: "token", Type: v1beta1.ParamTypeSecret}}, params); err != nil {
+		t.Errorf("ValidateSecretParamSources() returned an unexpected error: %v", err)
+	}
+	err := reconciler.ValidateSecretParamSources(v1beta1.ParamSpecs{{Name: "token", Type: v1beta1.ParamTypeString}}, params)
+	if !errors.Is(err, reconciler.ErrParamValueSourceNotSecret) {
+		t.Errorf("ValidateSecretParamSources() returned error %v, want %v", err, reconciler.ErrParamValueSourceNotSecret)
+	}
+}
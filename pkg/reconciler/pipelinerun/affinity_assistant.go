@@ -53,6 +53,11 @@ func (c *Reconciler) createOrUpdateAffinityAssistants(ctx context.Context, wb []
 	logger := logging.FromContext(ctx)
 	cfg := config.FromContextOrDefaults(ctx)
 
+	pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(pr), namespace)
+	if err != nil {
+		return err
+	}
+
 	var errs []error
 	var unschedulableNodes sets.Set[string] = nil
 	for _, w := range wb {
@@ -60,6 +65,9 @@ func (c *Reconciler) createOrUpdateAffinityAssistants(ctx context.Context, wb []
 			affinityAssistantName := getAffinityAssistantName(w.Name, pr.Name)
 			a, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Get(ctx, affinityAssistantName, metav1.GetOptions{})
 			claimName := getClaimName(w, *kmeta.NewControllerRef(pr))
+			if pooledName, ok := pooledClaimNames[claimName]; ok {
+				claimName = pooledName
+			}
 			switch {
 			// check whether the affinity assistant (StatefulSet) exists or not, create one if it does not exist
 			case apierrors.IsNotFound(err):
@@ -26,17 +26,23 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/parse"
+	"go.uber.org/zap"
 	v1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/typed/core/v1/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	testing2 "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	logtesting "knative.dev/pkg/logging/testing"
 	"knative.dev/pkg/system"
 	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
@@ -44,6 +50,13 @@ import (
 
 var workspaceName = "test-workspace"
 
+// newTestPVCHandler returns a PVC handler whose listers cache no PVC and no WorkspacePool.
+func newTestPVCHandler(kubeClientSet kubernetes.Interface) volumeclaim.PvcHandler {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	poolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	return volumeclaim.NewPVCHandler(kubeClientSet, corev1listers.NewPersistentVolumeClaimLister(indexer), alpha1listers.NewWorkspacePoolLister(poolIndexer), zap.NewNop().Sugar())
+}
+
 var testPipelineRun = &v1beta1.PipelineRun{
 	TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
 	ObjectMeta: metav1.ObjectMeta{
@@ -66,9 +79,11 @@ func TestCreateAndDeleteOfAffinityAssistant(t *testing.T) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	kubeClientSet := fakek8s.NewSimpleClientset()
 	c := Reconciler{
-		KubeClientSet: fakek8s.NewSimpleClientset(),
+		KubeClientSet: kubeClientSet,
 		Images:        pipeline.Images{},
+		pvcHandler:    newTestPVCHandler(kubeClientSet),
 	}
 
 	err := c.createOrUpdateAffinityAssistants(ctx, testPipelineRun.Spec.Workspaces, testPipelineRun, testPipelineRun.Namespace)
@@ -93,6 +108,46 @@ func TestCreateAndDeleteOfAffinityAssistant(t *testing.T) {
 	}
 }
 
+// TestNoAffinityAssistantForCSIAndProjectedWorkspaces tests that no Affinity Assistant is created
+// for workspaces bound to CSI or projected volumes, which are not shared between TaskRun pods
+func TestNoAffinityAssistantForCSIAndProjectedWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	kubeClientSet := fakek8s.NewSimpleClientset()
+	c := Reconciler{
+		KubeClientSet: kubeClientSet,
+		Images:        pipeline.Images{},
+		pvcHandler:    newTestPVCHandler(kubeClientSet),
+	}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "csi",
+		CSI: &corev1.CSIVolumeSource{
+			Driver: "secrets-store.csi.k8s.io",
+		},
+	}, {
+		Name: "projected",
+		Projected: &corev1.ProjectedVolumeSource{
+			Sources: []corev1.VolumeProjection{{
+				ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+			}},
+		},
+	}}
+
+	if err := c.createOrUpdateAffinityAssistants(ctx, workspaces, testPipelineRun, testPipelineRun.Namespace); err != nil {
+		t.Errorf("unexpected error from createOrUpdateAffinityAssistants: %v", err)
+	}
+
+	statefulSets, err := c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing StatefulSets: %v", err)
+	}
+	if len(statefulSets.Items) != 0 {
+		t.Errorf("expected no Affinity Assistant but got %d", len(statefulSets.Items))
+	}
+}
+
 // TestCreateAffinityAssistantWhenNodeIsCordoned tests an existing Affinity Assistant can identify the node failure and
 // can migrate the affinity assistant pod to a healthy node so that the existing pipelineRun runs to competition
 func TestCreateOrUpdateAffinityAssistantWhenNodeIsCordoned(t *testing.T) {
@@ -637,8 +692,10 @@ func seedTestData(d Data) (context.Context, Reconciler, func()) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 
+	kubeClientSet := fakek8s.NewSimpleClientset()
 	c := Reconciler{
-		KubeClientSet: fakek8s.NewSimpleClientset(),
+		KubeClientSet: kubeClientSet,
+		pvcHandler:    newTestPVCHandler(kubeClientSet),
 	}
 	for _, s := range d.StatefulSets {
 		c.KubeClientSet.AppsV1().StatefulSets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
@@ -36,7 +36,7 @@ import (
 	"knative.dev/pkg/apis"
 )
 
-var cancelTaskRunPatchBytes, cancelCustomRunPatchBytes []byte
+var cancelTaskRunPatchBytes, cancelCustomRunPatchBytes, cancelPipelineRunPatchBytes []byte
 
 func init() {
 	var err error
@@ -68,6 +68,27 @@ func init() {
 	if err != nil {
 		log.Fatalf("failed to marshal CustomRun cancel patch bytes: %v", err)
 	}
+	cancelPipelineRunPatchBytes, err = json.Marshal([]jsonpatch.JsonPatchOperation{
+		{
+			Operation: "add",
+			Path:      "/spec/status",
+			Value:     v1beta1.PipelineRunSpecStatusCancelled,
+		}})
+	if err != nil {
+		log.Fatalf("failed to marshal PipelineRun cancel patch bytes: %v", err)
+	}
+}
+
+// cancelChildPipelineRun cancels the child PipelineRun of a PipelineTask. It's used both when the
+// parent PipelineRun is cancelled and when it times out, the child PipelineRun has no status message.
+func cancelChildPipelineRun(ctx context.Context, pipelineRunName string, namespace string, clientSet clientset.Interface) error {
+	_, err := clientSet.TektonV1beta1().PipelineRuns(namespace).Patch(ctx, pipelineRunName, types.JSONPatchType, cancelPipelineRunPatchBytes, metav1.PatchOptions{}, "")
+	if errors.IsNotFound(err) {
+		// The resource may have been deleted in the meanwhile, but we should
+		// still be able to cancel the PipelineRun
+		return nil
+	}
+	return err
 }
 
 func cancelCustomRun(ctx context.Context, runName string, namespace string, clientSet clientset.Interface) error {
@@ -129,7 +150,7 @@ func cancelPipelineTaskRuns(ctx context.Context, logger *zap.SugaredLogger, pr *
 func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) []string {
 	errs := []string{}
 
-	trNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
+	trNames, customRunNames, pipelineRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -151,14 +172,24 @@ func cancelPipelineTaskRunsForTaskNames(ctx context.Context, logger *zap.Sugared
 			continue
 		}
 	}
+
+	for _, pipelineRunName := range pipelineRunNames {
+		logger.Infof("cancelling PipelineRun %s", pipelineRunName)
+
+		if err := cancelChildPipelineRun(ctx, pipelineRunName, pr.Namespace, clientSet); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %w", pipelineRunName, err).Error())
+			continue
+		}
+	}
 	return errs
 }
 
-// getChildObjectsFromPRStatusForTaskNames returns taskruns and customruns in the PipelineRunStatus's ChildReferences,
-// based on the given set of PipelineTask names. If that set is empty, all are returned.
-func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.PipelineRunStatus, taskNames sets.String) ([]string, []string, error) {
+// getChildObjectsFromPRStatusForTaskNames returns taskruns, customruns and child pipelineruns in the PipelineRunStatus's
+// ChildReferences, based on the given set of PipelineTask names. If that set is empty, all are returned.
+func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.PipelineRunStatus, taskNames sets.String) ([]string, []string, []string, error) {
 	var trNames []string
 	var customRunNames []string
+	var pipelineRunNames []string
 	unknownChildKinds := make(map[string]string)
 
 	for _, cr := range prs.ChildReferences {
@@ -168,6 +199,8 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.Pi
 				trNames = append(trNames, cr.Name)
 			case customRun:
 				customRunNames = append(customRunNames, cr.Name)
+			case pipelineRun:
+				pipelineRunNames = append(pipelineRunNames, cr.Name)
 			default:
 				unknownChildKinds[cr.Name] = cr.Kind
 			}
@@ -179,7 +212,7 @@ func getChildObjectsFromPRStatusForTaskNames(ctx context.Context, prs v1beta1.Pi
 		err = fmt.Errorf("found child objects of unknown kinds: %v", unknownChildKinds)
 	}
 
-	return trNames, customRunNames, err
+	return trNames, customRunNames, pipelineRunNames, err
 }
 
 // gracefullyCancelPipelineRun marks any non-final resolved TaskRun(s) as cancelled and runs finally.
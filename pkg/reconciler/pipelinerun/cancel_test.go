@@ -359,13 +359,14 @@ func TestCancelPipelineRun(t *testing.T) {
 
 func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 	testCases := []struct {
-		name                   string
-		prStatus               v1beta1.PipelineRunStatus
-		taskNames              sets.String
-		expectedTRNames        []string
-		expectedRunNames       []string
-		expectedCustomRunNames []string
-		hasError               bool
+		name                     string
+		prStatus                 v1beta1.PipelineRunStatus
+		taskNames                sets.String
+		expectedTRNames          []string
+		expectedRunNames         []string
+		expectedCustomRunNames   []string
+		expectedPipelineRunNames []string
+		hasError                 bool
 	}{{
 		name: "beta custom tasks",
 		prStatus: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
@@ -380,6 +381,19 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 		}},
 		expectedCustomRunNames: []string{"r1"},
 		hasError:               false,
+	}, {
+		name: "child pipelines",
+		prStatus: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			ChildReferences: []v1beta1.ChildStatusReference{{
+				TypeMeta: runtime.TypeMeta{
+					APIVersion: v1beta1.SchemeGroupVersion.String(),
+					Kind:       pipelineRun,
+				},
+				Name:             "pr1",
+				PipelineTaskName: "pipeline-1",
+			}},
+		}},
+		expectedPipelineRunNames: []string{"pr1"},
 	}, {
 		name: "unknown kind",
 		prStatus: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
@@ -402,7 +416,7 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx, _ := ttesting.SetupFakeContext(t)
-			trNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, tc.prStatus, tc.taskNames)
+			trNames, customRunNames, pipelineRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, tc.prStatus, tc.taskNames)
 
 			if tc.hasError {
 				if err == nil {
@@ -418,6 +432,9 @@ func TestGetChildObjectsFromPRStatusForTaskNames(t *testing.T) {
 			if d := cmp.Diff(tc.expectedCustomRunNames, customRunNames); d != "" {
 				t.Errorf("expected to see CustomRun names %v. Diff %s", tc.expectedCustomRunNames, diff.PrintWantGot(d))
 			}
+			if d := cmp.Diff(tc.expectedPipelineRunNames, pipelineRunNames); d != "" {
+				t.Errorf("expected to see PipelineRun names %v. Diff %s", tc.expectedPipelineRunNames, diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -18,28 +18,42 @@ package pipelinerun
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	cloudeventsinkinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/cloudeventsink"
+	executionwindowpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/executionwindowpolicy"
+	notificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/notificationpolicy"
+	serviceaccountpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/serviceaccountpolicy"
 	verificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy"
+	workspacepoolinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/workspacepool"
 	customruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun"
 	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/notification"
+	"github.com/tektoncd/pipeline/pkg/reconciler/runnamespace"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/sharding"
+	"github.com/tektoncd/pipeline/pkg/statusoffload"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
+	persistentvolumeclaiminformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 )
 
@@ -54,32 +68,63 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock, tracerProvi
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
 		verificationpolicyInformer := verificationpolicyinformer.Get(ctx)
+		serviceaccountpolicyInformer := serviceaccountpolicyinformer.Get(ctx)
+		executionwindowpolicyInformer := executionwindowpolicyinformer.Get(ctx)
 		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger))
 		configStore.WatchConfigs(cmw)
 
 		c := &Reconciler{
-			KubeClientSet:            kubeclientset,
-			PipelineClientSet:        pipelineclientset,
-			Images:                   opts.Images,
-			Clock:                    clock,
-			pipelineRunLister:        pipelineRunInformer.Lister(),
-			taskRunLister:            taskRunInformer.Lister(),
-			customRunLister:          customRunInformer.Lister(),
-			verificationPolicyLister: verificationpolicyInformer.Lister(),
-			cloudEventClient:         cloudeventclient.Get(ctx),
-			metrics:                  pipelinerunmetrics.Get(ctx),
-			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
-			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
-			tracerProvider:           tracerProvider,
+			KubeClientSet:               kubeclientset,
+			PipelineClientSet:           pipelineclientset,
+			Images:                      opts.Images,
+			Clock:                       clock,
+			pipelineRunLister:           pipelineRunInformer.Lister(),
+			taskRunLister:               taskRunInformer.Lister(),
+			customRunLister:             customRunInformer.Lister(),
+			verificationPolicyLister:    verificationpolicyInformer.Lister(),
+			serviceAccountPolicyLister:  serviceaccountpolicyInformer.Lister(),
+			executionWindowPolicyLister: executionwindowpolicyInformer.Lister(),
+			configMapLister:             configmapinformer.Get(ctx).Lister(),
+			cloudEventClient:            cloudeventclient.Get(ctx),
+			cloudEventSinks:             cloudeventclient.NewSinks(cloudeventsinkinformer.Get(ctx).Lister(), kubeclientset),
+			notifier:                    notification.NewNotifier(notificationpolicyinformer.Get(ctx).Lister(), taskRunInformer.Lister(), customRunInformer.Lister(), kubeclientset),
+			metrics:                     pipelinerunmetrics.Get(ctx),
+			pvcHandler:                  volumeclaim.NewPVCHandler(kubeclientset, persistentvolumeclaiminformer.Get(ctx).Lister(), workspacepoolinformer.Get(ctx).Lister(), logger),
+			runNamespaceHandler:         runnamespace.NewHandler(kubeclientset, logger),
+			resultRefReports:            resultRefReportsFromContext(ctx),
+			resolutionRequester:         resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			statusOffloader:             statusoffload.NewOffloader(kubeclientset, http.DefaultClient),
+			tracerProvider:              tracerProvider,
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
 				AgentName:   pipeline.PipelineRunControllerName,
 				ConfigStore: configStore,
+				// The reconciler applies the status itself.
+				SkipStatusUpdates: true,
 			}
 		})
 
+		sharding.Apply(ctx, impl, pipelineRunInformer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, pipelineRunInformer.Informer(), "pipelinerun")
+
 		pipelineRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: volumeclaim.ReturnPooledPersistentVolumeClaimsOfDeletedRun(ctx, c.pvcHandler),
+		})
+		if c.resultRefReports != nil {
+			pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+				DeleteFunc: c.resultRefReports.deletePipelineRun,
+			})
+		}
+		pipelineRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: deleteRunNamespace(ctx, c.runNamespaceHandler),
+		})
+		// Reconcile the parents of the child PipelineRuns of PipelineTasks when their status changes
+		pipelineRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
+			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+		})
 
 		taskRunInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.PipelineRun{}),
@@ -94,6 +139,19 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock, tracerProvi
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		// Reconcile the PipelineRuns which haven't started when the ExecutionWindowPolicies of their
+		// namespace change, which may allow them to start or hold them until another window.
+		executionwindowpolicyInformer.Informer().AddEventHandler(controller.HandleAll(func(obj interface{}) {
+			policy, err := kmeta.DeletionHandlingAccessor(obj)
+			if err != nil {
+				return
+			}
+			impl.FilteredGlobalResync(func(obj interface{}) bool {
+				pr, ok := obj.(*v1beta1.PipelineRun)
+				return ok && pr.Namespace == policy.GetNamespace() && !pr.HasStarted()
+			}, pipelineRunInformer.Informer())
+		}))
+
 		return impl
 	}
 }
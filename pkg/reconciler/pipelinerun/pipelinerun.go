@@ -23,29 +23,39 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	pipelinerunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/pipelinerun"
 	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/executionwindow"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/loglevel"
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/notification"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/runnamespace"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun"
 	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/serviceaccountpolicy"
+	"github.com/tektoncd/pipeline/pkg/slsa"
+	"github.com/tektoncd/pipeline/pkg/statusoffload"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
@@ -53,13 +63,17 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/controller"
@@ -88,6 +102,9 @@ const (
 	// ReasonObjectParameterMissKeys indicates that the object param value provided from PipelineRun spec
 	// misses some keys required for the object param declared in Pipeline spec.
 	ReasonObjectParameterMissKeys = "ObjectParameterMissKeys"
+	// ReasonParameterValueInvalid indicates that the value of a parameter doesn't satisfy the enum,
+	// the pattern, the minimum or the maximum of the parameter declared in the Pipeline or the Task.
+	ReasonParameterValueInvalid = "ParameterValueInvalid"
 	// ReasonParamArrayIndexingInvalid indicates that the use of param array indexing is not under correct api fields feature gate
 	// or the array is out of bound.
 	ReasonParamArrayIndexingInvalid = "ParamArrayIndexingInvalid"
@@ -97,6 +114,9 @@ const (
 	// ReasonParameterMissing indicates that the reason for the failure status is that the
 	// associated PipelineRun didn't provide all the required parameters
 	ReasonParameterMissing = "ParameterMissing"
+	// ReasonInvalidParamValueSource indicates that the reason for the failure status is that
+	// the ConfigMap, the Secret or the key a param of the PipelineRun takes its value from doesn't exist
+	ReasonInvalidParamValueSource = "InvalidParamValueSource"
 	// ReasonFailedValidation indicates that the reason for failure status is
 	// that pipelinerun failed runtime validation
 	ReasonFailedValidation = "PipelineValidationFailed"
@@ -115,12 +135,30 @@ const (
 	ReasonCouldntTimeOut = "PipelineRunCouldntTimeOut"
 	// ReasonInvalidMatrixParameterTypes indicates a matrix contains invalid parameter types
 	ReasonInvalidMatrixParameterTypes = "ReasonInvalidMatrixParameterTypes"
+	// ReasonInvalidLoopItems indicates the items of a looping PipelineTask are not an
+	// array once its parameters and task results are substituted
+	ReasonInvalidLoopItems = "InvalidLoopItems"
+	// ReasonInvalidWhenExpression indicates a CEL when expression of a PipelineTask failed
+	// to be evaluated once its variables were replaced
+	ReasonInvalidWhenExpression = "InvalidWhenExpression"
+	// ReasonInvalidGeneratedTasks indicates the tasks generated by a PipelineTask from
+	// one of its results can't be parsed or make the pipeline invalid
+	ReasonInvalidGeneratedTasks = "InvalidGeneratedTasks"
 	// ReasonInvalidTaskResultReference indicates a task result was declared
 	// but was not initialized by that task
 	ReasonInvalidTaskResultReference = "InvalidTaskResultReference"
+	// ReasonInvalidRetriesOrTimeout indicates the retries or the timeout of a
+	// PipelineTask are invalid once their parameters and task results are substituted
+	ReasonInvalidRetriesOrTimeout = "InvalidRetriesOrTimeout"
+	// ReasonInvalidWorkspaceSubPath indicates the subPath of a workspace of a PipelineTask
+	// is invalid once its parameters and task results are substituted
+	ReasonInvalidWorkspaceSubPath = "InvalidWorkspaceSubPath"
 	// ReasonRequiredWorkspaceMarkedOptional indicates an optional workspace
 	// has been passed to a Task that is expecting a non-optional workspace
 	ReasonRequiredWorkspaceMarkedOptional = "RequiredWorkspaceMarkedOptional"
+	// ReasonWorkspaceModeMismatch indicates a ReadOnly workspace of the pipeline
+	// is used by a task that does not declare it readOnly
+	ReasonWorkspaceModeMismatch = "WorkspaceModeMismatch"
 	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for
 	// its pipelineRef to be asynchronously resolved.
 	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
@@ -129,15 +167,31 @@ const (
 	ReasonResourceVerificationFailed = "ResourceVerificationFailed"
 	// ReasonCreateRunFailed indicates that the pipeline fails to create the taskrun or other run resources
 	ReasonCreateRunFailed = "CreateRunFailed"
+	// ReasonServiceAccountNotAllowed indicates that a service account of the PipelineRun
+	// is not allowed for its Pipeline by the ServiceAccountPolicies of its namespace
+	ReasonServiceAccountNotAllowed = "ServiceAccountNotAllowed"
+	// ReasonOutsideExecutionWindow indicates that a PipelineRun is held until the
+	// ExecutionWindowPolicies of its namespace allow it to start
+	ReasonOutsideExecutionWindow = "PipelineRunOutsideExecutionWindow"
+	// ReasonResultsVerificationFailed indicates that a task references the results of a
+	// TaskRun whose signatures weren't verified by SPIRE
+	ReasonResultsVerificationFailed = "ResultsVerificationFailed"
+	// ReasonPipelineRecursion indicates that a PipelineTask runs a Pipeline already run by
+	// the PipelineRun or one of its ancestors, or nests child PipelineRuns too deeply
+	ReasonPipelineRecursion = "PipelineRecursion"
 )
 
+// maxChildPipelineRunDepth is the maximum number of ancestors of a child PipelineRun.
+const maxChildPipelineRunDepth = 10
+
 // constants used as kind descriptors for various types of runs; these constants
 // match their corresponding controller names. Given that it's odd to use a
 // "ControllerName" const in describing the type of run, we import these
 // constants (for consistency) but rename them (for ergonomic semantics).
 const (
-	taskRun   = pipeline.TaskRunControllerName
-	customRun = pipeline.CustomRunControllerName
+	taskRun     = pipeline.TaskRunControllerName
+	customRun   = pipeline.CustomRunControllerName
+	pipelineRun = pipeline.PipelineRunControllerName
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -148,28 +202,43 @@ type Reconciler struct {
 	Clock             clock.PassiveClock
 
 	// listers index properties about resources
-	pipelineRunLister        listers.PipelineRunLister
-	taskRunLister            listers.TaskRunLister
-	customRunLister          listers.CustomRunLister
-	verificationPolicyLister alpha1listers.VerificationPolicyLister
-	cloudEventClient         cloudevent.CEClient
-	metrics                  *pipelinerunmetrics.Recorder
-	pvcHandler               volumeclaim.PvcHandler
-	resolutionRequester      resolution.Requester
-	tracerProvider           trace.TracerProvider
+	pipelineRunLister           listers.PipelineRunLister
+	taskRunLister               listers.TaskRunLister
+	customRunLister             listers.CustomRunLister
+	verificationPolicyLister    alpha1listers.VerificationPolicyLister
+	serviceAccountPolicyLister  alpha1listers.ServiceAccountPolicyLister
+	executionWindowPolicyLister alpha1listers.ExecutionWindowPolicyLister
+	configMapLister             corev1listers.ConfigMapLister
+	cloudEventClient            cloudevent.CEClient
+	cloudEventSinks             *cloudevent.Sinks
+	notifier                    *notification.Notifier
+	metrics                     *pipelinerunmetrics.Recorder
+	pvcHandler                  volumeclaim.PvcHandler
+	runNamespaceHandler         runnamespace.Handler
+	// resultRefReports records the result reference reports of the running PipelineRuns, if not nil.
+	resultRefReports    *ResultRefReports
+	resolutionRequester resolution.Requester
+	statusOffloader     *statusoffload.Offloader
+	tracerProvider      trace.TracerProvider
 }
 
 var (
 	// Check that our Reconciler implements pipelinerunreconciler.Interface
 	_ pipelinerunreconciler.Interface = (*Reconciler)(nil)
+
+	// invalidLabelValueChars matches the characters not allowed in label values
+	invalidLabelValueChars = regexp.MustCompile(`[^-A-Za-z0-9_.]+`)
 )
 
 // ReconcileKind compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Pipeline Run
 // resource with the current status of the resource.
-func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
+func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) (event pkgreconciler.Event) {
+	ctx = loglevel.WithLogger(ctx, pr)
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx = cloudevent.WithSinks(ctx, c.cloudEventSinks)
+	ctx = notification.WithNotifier(ctx, c.notifier)
 	ctx = initTracing(ctx, c.tracerProvider, pr)
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:ReconcileKind")
 	defer span.End()
@@ -178,6 +247,35 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		attribute.String("pipelinerun", pr.Name), attribute.String("namespace", pr.Namespace),
 	)
 
+	// Apply the status once reconciled, when it changed, rather than have the generated
+	// reconciler update it and retry on the conflicts with the other controllers updating
+	// the PipelineRun.
+	originalStatus := pr.Status.DeepCopy()
+	defer func() {
+		if equality.Semantic.DeepEqual(originalStatus, &pr.Status) {
+			return
+		}
+		if err := tknreconciler.ApplyPipelineRunStatus(ctx, c.PipelineClientSet, pr, originalStatus); err != nil {
+			logger.Warnw("Failed to apply the status of the PipelineRun", zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(pr, corev1.EventTypeWarning, "UpdateFailed", "Failed to update status for %q: %v", pr.Name, err)
+			event = err
+		}
+	}()
+
+	// Restore the fields of the status offloaded to ConfigMaps or to an object store,
+	// and offload them again once reconciled, before the status is applied.
+	if err := c.statusOffloader.Rehydrate(ctx, pr); err != nil {
+		return err
+	}
+	defer func() {
+		if err := c.statusOffloader.Offload(ctx, pr); err != nil {
+			logger.Errorf("Failed to offload the status of PipelineRun %s: %v", pr.Name, err)
+			if event == nil {
+				event = err
+			}
+		}
+	}()
+
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
 
@@ -195,6 +293,25 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 		return controller.NewPermanentError(errors.New("PipelineRun has timed out for a long time"))
 	}
 
+	// Hold the PipelineRun until the ExecutionWindowPolicies of its namespace allow it to start.
+	if !pr.HasStarted() && !pr.IsPending() && !pr.IsDone() && !pr.IsCancelled() {
+		policies, err := c.executionWindowPolicyLister.ExecutionWindowPolicies(pr.Namespace).List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list ExecutionWindowPolicies from namespace %s with error %w", pr.Namespace, err)
+		}
+		if hold := executionwindow.Check(policies, executionwindow.PipelineName(pr), c.Clock.Now()); hold != nil {
+			pr.Status.MarkRunning(ReasonOutsideExecutionWindow, hold.Message())
+			if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil); err != nil {
+				return err
+			}
+			// The PipelineRun is also reconciled when the policies change.
+			if hold.Until.IsZero() {
+				return nil
+			}
+			return controller.NewRequeueAfter(hold.Until.Sub(c.Clock.Now()))
+		}
+	}
+
 	if !pr.HasStarted() && !pr.IsPending() {
 		pr.Status.InitializeConditions(c.Clock)
 		// In case node time was not synchronized, when controller has been scheduled to other nodes.
@@ -213,6 +330,22 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 
 		// We already sent an event for start, so update `before` with the current status
 		before = pr.Status.GetCondition(apis.ConditionSucceeded)
+
+		// The params taking their values from ConfigMaps are resolved once, when the PipelineRun
+		// starts, so that all its TaskRuns take the same values.
+		resolved, err := tknreconciler.ResolveConfigMapParams(c.configMapLister, pr.Namespace, pr.Spec.Params)
+		switch {
+		case errors.Is(err, tknreconciler.ErrParamValueSourceNotFound):
+			// This Run has failed, so we need to mark it as failed and stop reconciling it
+			pr.Status.MarkFailed(ReasonInvalidParamValueSource,
+				"PipelineRun %s/%s parameters can't be resolved: %s",
+				pr.Namespace, pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, controller.NewPermanentError(err))
+		case err != nil:
+			return err
+		default:
+			pr.Status.ResolvedParams = resolved
+		}
 	}
 
 	// list VerificationPolicies for trusted resources
@@ -224,10 +357,27 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 
 	if pr.IsDone() {
 		pr.SetDefaults(ctx)
+		c.recordSLSAProvenance(ctx, pr)
+		if c.resultRefReports != nil {
+			c.resultRefReports.delete(types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name})
+		}
 		err := c.cleanupAffinityAssistants(ctx, pr)
 		if err != nil {
 			logger.Errorf("Failed to delete StatefulSet for PipelineRun %s: %v", pr.Name, err)
 		}
+		if runnamespace.IsRequested(pr) {
+			if err := c.runNamespaceHandler.Delete(ctx, pr); err != nil {
+				logger.Errorf("Failed to delete run namespace of PipelineRun %s: %v", pr.Name, err)
+			}
+		}
+		if err := c.snapshotArtifactWorkspaces(ctx, pr); err != nil {
+			logger.Errorf("Failed to snapshot artifact workspaces of PipelineRun %s: %v", pr.Name, err)
+		}
+		if pr.HasVolumeClaimTemplate() {
+			if err := c.pvcHandler.ReturnPooledPersistentVolumeClaims(ctx, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+				logger.Errorf("Failed to return pooled PVCs for PipelineRun %s: %v", pr.Name, err)
+			}
+		}
 		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 	}
 
@@ -278,6 +428,28 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 	return nil
 }
 
+// recordSLSAProvenance generates the SLSA provenance of the done PipelineRun, with
+// the dependencies of its TaskRuns, when the "slsa-builder-id" feature flag is set.
+func (c *Reconciler) recordSLSAProvenance(ctx context.Context, pr *v1beta1.PipelineRun) {
+	builderID := config.FromContextOrDefaults(ctx).FeatureFlags.SLSABuilderID
+	if builderID == "" || pr.Status.Provenance == nil || pr.Status.Provenance.SLSA != nil {
+		return
+	}
+	var taskRuns []*v1beta1.TaskRun
+	for _, cr := range pr.Status.ChildReferences {
+		if cr.Kind != taskRun {
+			continue
+		}
+		tr, err := c.taskRunLister.TaskRuns(pr.Namespace).Get(cr.Name)
+		if err != nil {
+			logging.FromContext(ctx).Warnf("Failed to get the TaskRun %s of PipelineRun %s for its SLSA provenance: %v", cr.Name, pr.Name, err)
+			continue
+		}
+		taskRuns = append(taskRuns, tr)
+	}
+	pr.Status.Provenance.SLSA = slsa.ForPipelineRun(pr, taskRuns, builderID)
+}
+
 func (c *Reconciler) durationAndCountMetrics(ctx context.Context, pr *v1beta1.PipelineRun, beforeCondition *apis.Condition) {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "durationAndCountMetrics")
 	defer span.End()
@@ -297,6 +469,8 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, pr *v1
 
 	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
 	events.Emit(ctx, beforeCondition, afterCondition, pr)
+	recordSpans(c.tracerProvider, pr, beforeCondition)
+
 	_, err := c.updateLabelsAndAnnotations(ctx, pr)
 	if err != nil {
 		logger.Warn("Failed to update PipelineRun labels/annotations", zap.Error(err))
@@ -353,6 +527,9 @@ func (c *Reconciler) resolvePipelineState(
 				return c.taskRunLister.TaskRuns(pr.Namespace).Get(name)
 			},
 			getRunObjectFunc,
+			func(name string) (*v1beta1.PipelineRun, error) {
+				return c.pipelineRunLister.PipelineRuns(pr.Namespace).Get(name)
+			},
 			task,
 		)
 		if err != nil {
@@ -364,7 +541,7 @@ func (c *Reconciler) resolvePipelineState(
 			}
 			var nfErr *resources.TaskNotFoundError
 			if errors.As(err, &nfErr) {
-				pr.Status.MarkFailed(ReasonCouldntGetTask,
+				pr.Status.MarkFailed(resolutionFailureReason(err, ReasonCouldntGetTask),
 					"Pipeline %s/%s can't be Run; it contains Tasks that don't exist: %s",
 					pipelineMeta.Namespace, pipelineMeta.Name, nfErr)
 			} else {
@@ -387,6 +564,17 @@ func (c *Reconciler) resolvePipelineState(
 	return pst, nil
 }
 
+// resolutionFailureReason returns the reason of the failed remote resolution
+// which caused err, e.g. ResolutionTimedOut, or defaultReason if err isn't
+// caused by a remote resolution which failed for a specific reason.
+func resolutionFailureReason(err error, defaultReason string) string {
+	var resolutionErr *resolutioncommon.Error
+	if errors.As(err, &resolutionErr) && resolutionErr.Reason != resolutioncommon.ReasonResolutionFailed {
+		return resolutionErr.Reason
+	}
+	return defaultReason
+}
+
 func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, getPipelineFunc rprp.GetPipeline, beforeCondition *apis.Condition) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "reconcile")
 	defer span.End()
@@ -412,7 +600,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	case err != nil:
 		logger.Errorf("Failed to determine Pipeline spec to use for pipelinerun %s: %v", pr.Name, err)
-		pr.Status.MarkFailed(ReasonCouldntGetPipeline,
+		pr.Status.MarkFailed(resolutionFailureReason(err, ReasonCouldntGetPipeline),
 			"Error retrieving pipeline for pipelinerun %s/%s: %s",
 			pr.Namespace, pr.Name, err)
 		return controller.NewPermanentError(err)
@@ -423,6 +611,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 	}
 
+	// The variables of the pipeline are replaced before the DAG is built, so that the tasks
+	// depend on the tasks producing the results their variables reference
+	pipelineSpec = resources.ApplyVars(pipelineSpec)
+
+	// The tasks generated by the tasks of the pipeline from their results are part of the pipeline
+	if len(pr.Status.GeneratedTasks) > 0 {
+		tasks := append([]v1beta1.PipelineTask{}, pipelineSpec.Tasks...)
+		pipelineSpec.Tasks = append(tasks, pr.Status.GeneratedTasks...)
+	}
+
 	d, err := dag.Build(v1beta1.PipelineTaskList(pipelineSpec.Tasks), v1beta1.PipelineTaskList(pipelineSpec.Tasks).Deps())
 	if err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -453,6 +651,19 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// The params taking their values from ConfigMaps take the values resolved when the
+	// PipelineRun started, and the params taking their values from Secrets are passed to the
+	// TaskRuns as the references to the keys of the Secrets, so that the values of the Secrets
+	// are never read nor stored by the controller.
+	if err := tknreconciler.ValidateSecretParamSources(pipelineSpec.Params, pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonInvalidParamValueSource,
+			"PipelineRun %s/%s parameters can't be resolved: %s",
+			pr.Namespace, pr.Name, err)
+		return controller.NewPermanentError(err)
+	}
+	pr.Spec.Params = tknreconciler.ApplyParamValueSources(pr.Spec.Params, pr.Status.ResolvedParams)
+
 	// Ensure that the PipelineRun provides all the parameters required by the Pipeline
 	if err := resources.ValidateRequiredParametersProvided(&pipelineSpec.Params, &pr.Spec.Params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -481,6 +692,15 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the values of the params from the PipelineRun satisfy the constraints of the Pipeline parameters
+	if err = pipelineSpec.Params.ValidateValues(pr.Spec.Params); err != nil {
+		// This Run has failed, so we need to mark it as failed and stop reconciling it
+		pr.Status.MarkFailed(ReasonParameterValueInvalid,
+			"PipelineRun %s/%s parameters have invalid values for Pipeline %s/%s's parameters: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+
 	// Ensure that the array reference is not out of bound
 	if err := pipelineSpec.ValidateParamArrayIndex(ctx, pr.Spec.Params); err != nil {
 		// This Run has failed, so we need to mark it as failed and stop reconciling it
@@ -506,12 +726,39 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		return controller.NewPermanentError(err)
 	}
 
+	// Ensure that the ServiceAccountPolicies allow the service accounts of the PipelineRun,
+	// before any of its tasks is run with them.
+	if len(pr.Status.ChildReferences) == 0 {
+		policies, err := c.serviceAccountPolicyLister.ServiceAccountPolicies(pr.Namespace).List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list ServiceAccountPolicies from namespace %s with error %w", pr.Namespace, err)
+		}
+		grants, err := serviceaccountpolicy.Grants(policies, pr)
+		if err != nil {
+			pr.Status.MarkFailed(ReasonServiceAccountNotAllowed,
+				"PipelineRun %s/%s uses a service account not allowed for Pipeline %s/%s: %s",
+				pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+			return controller.NewPermanentError(err)
+		}
+		if pr.Status.Provenance != nil {
+			pr.Status.Provenance.ServiceAccounts = grants
+		}
+	}
+
+	// Label and annotate the PipelineRun with its display name and description
+	if pr.Spec.DisplayName != "" || pr.Spec.Description != "" {
+		displayName, description := resources.GetDisplayNameAndDescription(ctx, pipelineSpec, pipelineMeta.Name, pr)
+		setDisplayNameAndDescription(pr, displayName, description)
+	}
+
 	// Apply parameter substitution from the PipelineRun
 	pipelineSpec = resources.ApplyParameters(ctx, pipelineSpec, pr)
 	pipelineSpec = resources.ApplyContexts(pipelineSpec, pipelineMeta.Name, pr)
 	pipelineSpec = resources.ApplyWorkspaces(pipelineSpec, pr)
 	// Update pipelinespec of pipelinerun's status field
 	pr.Status.PipelineSpec = pipelineSpec
+	// The looping tasks are fanned out like matrixed tasks, which is not reflected in the status
+	pipelineSpec = resources.ApplyLoops(ctx, pipelineSpec, pr)
 
 	// pipelineState holds a list of pipeline tasks after fetching their resolved Task specs.
 	// pipelineState also holds a taskRun for each pipeline task after the taskRun is created
@@ -532,11 +779,16 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	default:
 	}
 
+	if config.FromContextOrDefaults(ctx).FeatureFlags.PropagateParamsToReferencedTasks {
+		resources.PropagateParamsToReferencedTasks(pipelineSpec, pr, pipelineRunState)
+	}
+
 	// Build PipelineRunFacts with a list of resolved pipeline tasks,
 	// dag tasks graph and final tasks graph
 	pipelineRunFacts := &resources.PipelineRunFacts{
 		State:           pipelineRunState,
 		SpecStatus:      pr.Spec.Status,
+		FailurePolicy:   pr.Spec.FailurePolicy,
 		TasksGraph:      d,
 		FinalTasksGraph: dfinally,
 		TimeoutsState: resources.PipelineRunTimeoutsState{
@@ -560,8 +812,13 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	for _, rpt := range pipelineRunFacts.State {
-		if !rpt.IsCustomTask() {
-			err := taskrun.ValidateResolvedTask(ctx, rpt.PipelineTask.Params, rpt.PipelineTask.Matrix, rpt.ResolvedTask)
+		if !rpt.IsCustomTask() && !rpt.IsChildPipeline() {
+			matrix := rpt.PipelineTask.Matrix
+			if rpt.PipelineTask.IsLooped() {
+				// The matrix of a looping task holds its items, which are not params of its task
+				matrix = nil
+			}
+			err := taskrun.ValidateResolvedTask(ctx, rpt.PipelineTask.Params, matrix, rpt.ResolvedTask)
 			if err != nil {
 				logger.Errorf("Failed to validate pipelinerun %q with error %v", pr.Name, err)
 				pr.Status.MarkFailed(ReasonFailedValidation, err.Error())
@@ -599,15 +856,39 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 			return controller.NewPermanentError(err)
 		}
 
+		if err := resources.ValidateWorkspaceModes(pipelineSpec.Workspaces, pipelineRunFacts.State); err != nil {
+			logger.Errorf("Workspace mode not supported by task: %v", err)
+			pr.Status.MarkFailed(ReasonWorkspaceModeMismatch, err.Error())
+			return controller.NewPermanentError(err)
+		}
+
+		if runnamespace.IsRequested(pr) {
+			if err := c.runNamespaceHandler.Provision(ctx, pr, config.FromContextOrDefaults(ctx).RunNamespace); err != nil {
+				logger.Errorf("Failed to provision run namespace for PipelineRun %s: %v", pr.Name, err)
+				pr.Status.MarkFailed(runnamespace.ReasonCouldntCreateRunNamespace,
+					"Failed to provision run namespace for PipelineRun %s/%s: %s",
+					pr.Namespace, pr.Name, err)
+				return controller.NewPermanentError(err)
+			}
+		}
+
 		if pr.HasVolumeClaimTemplate() {
 			// create workspace PVC from template
-			if err = c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, pr.Spec.Workspaces, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+			if err = c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, typedWorkspaceBindings(pipelineSpec, pr.Spec.Workspaces), *kmeta.NewControllerRef(pr), volumeclaim.WorkspacePoolConsumer(pr.Labels), pr.Namespace); err != nil {
 				logger.Errorf("Failed to create PVC for PipelineRun %s: %v", pr.Name, err)
 				pr.Status.MarkFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
 					"Failed to create PVC for PipelineRun %s/%s Workspaces correctly: %s",
 					pr.Namespace, pr.Name, err)
 				return controller.NewPermanentError(err)
 			}
+			// create the PVCs of cache workspaces, shared with the other PipelineRuns using the same cache key
+			if err = c.pvcHandler.CreatePersistentVolumeClaimsForCacheWorkspaces(ctx, pr.Spec.Workspaces, cacheWorkspaceKeys(pipelineSpec), pr.Namespace); err != nil {
+				logger.Errorf("Failed to create cache PVC for PipelineRun %s: %v", pr.Name, err)
+				pr.Status.MarkFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
+					"Failed to create PVC for PipelineRun %s/%s Workspaces correctly: %s",
+					pr.Namespace, pr.Name, err)
+				return controller.NewPermanentError(err)
+			}
 		}
 	}
 
@@ -615,7 +896,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	// if the Affinity Assistant already exists, handle the possibility of assigned node becoming unschedulable by deleting the pod
 	if !c.isAffinityAssistantDisabled(ctx) {
 		// create Affinity Assistant (StatefulSet) so that taskRun pods that share workspace PVC achieve Node Affinity
-		if err = c.createOrUpdateAffinityAssistants(ctx, pr.Spec.Workspaces, pr, pr.Namespace); err != nil {
+		if err = c.createOrUpdateAffinityAssistants(ctx, typedWorkspaceBindings(pipelineSpec, pr.Spec.Workspaces), pr, pr.Namespace); err != nil {
 			logger.Errorf("Failed to create affinity assistant StatefulSet for PipelineRun %s: %v", pr.Name, err)
 			pr.Status.MarkFailed(ReasonCouldntCreateOrUpdateAffinityAssistantStatefulSet,
 				"Failed to create StatefulSet or update affinity assistant replicas for PipelineRun %s/%s correctly: %s",
@@ -659,6 +940,26 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 			}
 		}
 	}
+
+	// The tasks generated by the tasks which succeeded are stored in the status, and are
+	// added to the pipeline on the next reconcile, triggered by the update of the status.
+	generatedTasks, err := pipelineRunFacts.GenerateTasks(pr.Status.GeneratedTasks)
+	if err == nil && len(generatedTasks) > 0 {
+		generatedTasks, err = resources.ValidateGeneratedTasks(ctx, pr.Status.PipelineSpec, generatedTasks)
+	}
+	if err != nil {
+		logger.Errorf("Failed to generate tasks for pipelinerun %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidGeneratedTasks,
+			"PipelineRun %s/%s failed to add the generated tasks to Pipeline %s/%s: %s",
+			pr.Namespace, pr.Name, pr.Namespace, pipelineMeta.Name, err)
+		return controller.NewPermanentError(err)
+	}
+	if len(generatedTasks) > 0 {
+		pr.Status.GeneratedTasks = append(pr.Status.GeneratedTasks, generatedTasks...)
+		pr.Status.MarkRunning(v1beta1.PipelineRunReasonRunning.String(), "Added %d generated tasks to the pipeline", len(generatedTasks))
+		return nil
+	}
+
 	if err := c.runNextSchedulableTask(ctx, pr, pipelineRunFacts); err != nil {
 		return err
 	}
@@ -689,6 +990,13 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pr.Status.ChildReferences = pipelineRunFacts.State.GetChildReferences()
 
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	switch {
+	case c.resultRefReports == nil:
+	case after.Status == corev1.ConditionUnknown:
+		c.resultRefReports.set(types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name}, pipelineRunFacts.ResultRefReports())
+	default:
+		c.resultRefReports.delete(types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name})
+	}
 	if after.Status == corev1.ConditionTrue || after.Status == corev1.ConditionFalse {
 		pr.Status.PipelineResults, err = resources.ApplyTaskResultsToPipelineResults(ctx, pipelineSpec.Results,
 			pipelineRunFacts.State.GetTaskRunsResults(), pipelineRunFacts.State.GetRunsResults(), pipelineRunFacts.GetPipelineTaskStatus())
@@ -725,8 +1033,21 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 		pr.Status.MarkFailed(ReasonInvalidTaskResultReference, err.Error())
 		return controller.NewPermanentError(err)
 	}
+	if config.IsSpireEnabled(ctx) {
+		if err := resources.CheckResultsVerified(pipelineRunFacts.State, resolvedResultRefs); err != nil {
+			logger.Infof("Failed to verify the task results referenced by %q with error %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonResultsVerificationFailed, err.Error())
+			return controller.NewPermanentError(err)
+		}
+	}
 
+	// The provenance of the consumed results is computed before the result references are replaced
+	resultProvenance := map[*resources.ResolvedPipelineTask][]v1beta1.ResultProvenance{}
+	for _, rpt := range nextRpts {
+		resultProvenance[rpt] = resolvedResultRefs.Provenance(pipelineRunFacts.State, rpt.PipelineTask)
+	}
 	resources.ApplyTaskResults(nextRpts, resolvedResultRefs)
+	resources.SetLoopRunNames(nextRpts, pr)
 	// After we apply Task Results, we may be able to evaluate more
 	// when expressions, so reset the skipped cache
 	pipelineRunFacts.ResetSkippedCache()
@@ -735,7 +1056,11 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 	fNextRpts := pipelineRunFacts.GetFinalTasks()
 	if len(fNextRpts) != 0 {
 		// apply the runtime context just before creating taskRuns for final tasks in queue
-		resources.ApplyPipelineTaskStateContext(fNextRpts, pipelineRunFacts.GetPipelineTaskStatus())
+		taskStatus, arrayTaskStatus := pipelineRunFacts.GetPipelineTaskStatusDetails()
+		for k, v := range pipelineRunFacts.GetPipelineTaskStatus() {
+			taskStatus[k] = v
+		}
+		resources.ApplyPipelineTaskStateContext(fNextRpts, taskStatus, arrayTaskStatus)
 
 		// Before creating TaskRun for scheduled final task, check if it's consuming a task result
 		// Resolve and apply task result wherever applicable, report warning in case resolution fails
@@ -745,11 +1070,21 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 				logger.Infof("Final task %q is not executed as it could not resolve task params for %q: %v", rpt.PipelineTask.Name, pr.Name, err)
 				continue
 			}
+			resultProvenance[rpt] = resolvedResultRefs.Provenance(pipelineRunFacts.State, rpt.PipelineTask)
 			resources.ApplyTaskResults(resources.PipelineRunState{rpt}, resolvedResultRefs)
+			resources.SetLoopRunNames(resources.PipelineRunState{rpt}, pr)
 			nextRpts = append(nextRpts, rpt)
 		}
 	}
 
+	// The tasks whose CEL when expressions fail to be evaluated are skipped and fail the PipelineRun,
+	// rather than being silently skipped as if the expressions evaluated to false
+	if err := pipelineRunFacts.WhenExpressionsEvaluationError(); err != nil {
+		logger.Errorf("Failed to evaluate the when expressions of %q with error %v", pr.Name, err)
+		pr.Status.MarkFailed(ReasonInvalidWhenExpression, err.Error())
+		return controller.NewPermanentError(err)
+	}
+
 	for _, rpt := range nextRpts {
 		if rpt.IsFinalTask(pipelineRunFacts) {
 			c.setFinallyStartedTimeIfNeeded(pr, pipelineRunFacts)
@@ -767,6 +1102,48 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 			}
 		}
 
+		// Validate the items of looping tasks after applying the substitutions from Task Results
+		if rpt.PipelineTask.IsLooped() {
+			if err := resources.ValidateLoopItems(rpt); err != nil {
+				logger.Errorf("Failed to validate the items of the loop of %q with error %v", pr.Name, err)
+				pr.Status.MarkFailed(ReasonInvalidLoopItems, err.Error())
+				return controller.NewPermanentError(err)
+			}
+		}
+
+		// Validate the retries and the timeout after applying the substitutions from Task Results
+		if err := rpt.PipelineTask.ValidateRetriesAndTimeout(); err != nil {
+			logger.Errorf("Failed to validate the retries and the timeout of %q with error %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonInvalidRetriesOrTimeout, err.Error())
+			return controller.NewPermanentError(err)
+		}
+
+		// Validate the subPaths of the workspaces after applying the substitutions from Task Results,
+		// which could otherwise make the TaskRun mount a directory outside of the workspace
+		if err := rpt.PipelineTask.ValidateWorkspaceSubPaths(); err != nil {
+			logger.Errorf("Failed to validate the workspace subPaths of %q with error %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonInvalidWorkspaceSubPath, err.Error())
+			return controller.NewPermanentError(err)
+		}
+
+		// Validate the values of the params against the constraints of the params of the Task
+		// after applying the substitutions from Task Results
+		if rpt.ResolvedTask != nil && rpt.ResolvedTask.TaskSpec != nil {
+			if err := rpt.ResolvedTask.TaskSpec.Params.ValidateValues(rpt.PipelineTask.Params); err != nil {
+				logger.Errorf("Failed to validate the param values of %q with error %v", pr.Name, err)
+				pr.Status.MarkFailed(ReasonParameterValueInvalid, err.Error())
+				return controller.NewPermanentError(err)
+			}
+		}
+
+		if rpt.IsChildPipeline() {
+			if _, err := getChildPipelineAncestry(pr, rpt.PipelineTask); err != nil {
+				logger.Errorf("Failed to run the pipeline task %q of %q with error %v", rpt.PipelineTask.Name, pr.Name, err)
+				pr.Status.MarkFailed(ReasonPipelineRecursion, err.Error())
+				return controller.NewPermanentError(err)
+			}
+		}
+
 		defer func() {
 			// If it is a permanent error, set pipelinerun to a failure state directly to avoid unnecessary retries.
 			if err != nil && controller.IsPermanentError(err) {
@@ -774,14 +1151,22 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 			}
 		}()
 
-		if rpt.IsCustomTask() {
+		switch {
+		case rpt.IsChildPipeline():
+			rpt.ChildPipelineRun, err = c.createChildPipelineRun(ctx, rpt, pr)
+			if err != nil {
+				recorder.Eventf(pr, corev1.EventTypeWarning, "PipelineRunCreationFailed", "Failed to create PipelineRun %q: %v", rpt.ChildPipelineRunName, err)
+				err = fmt.Errorf("error creating PipelineRun called %s for PipelineTask %s from PipelineRun %s: %w", rpt.ChildPipelineRunName, rpt.PipelineTask.Name, pr.Name, err)
+				return err
+			}
+		case rpt.IsCustomTask():
 			rpt.RunObjects, err = c.createRunObjects(ctx, rpt, pr)
 			if err != nil {
 				recorder.Eventf(pr, corev1.EventTypeWarning, "RunsCreationFailed", "Failed to create Runs %q: %v", rpt.RunObjectNames, err)
 				err = fmt.Errorf("error creating Runs called %s for PipelineTask %s from PipelineRun %s: %w", rpt.RunObjectNames, rpt.PipelineTask.Name, pr.Name, err)
 				return err
 			}
-		} else {
+		default:
 			rpt.TaskRuns, err = c.createTaskRuns(ctx, rpt, pr)
 			if err != nil {
 				recorder.Eventf(pr, corev1.EventTypeWarning, "TaskRunsCreationFailed", "Failed to create TaskRuns %q: %v", rpt.TaskRunNames, err)
@@ -789,10 +1174,26 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 				return err
 			}
 		}
+		recordResultProvenance(pr, rpt.PipelineTask.Name, resultProvenance[rpt])
 	}
 	return nil
 }
 
+// recordResultProvenance records in the provenance of the PipelineRun the results consumed by the
+// runs created for a PipelineTask, replacing the ones recorded when they were created before.
+func recordResultProvenance(pr *v1beta1.PipelineRun, pipelineTask string, consumed []v1beta1.ResultProvenance) {
+	if pr.Status.Provenance == nil || len(consumed) == 0 {
+		return
+	}
+	var consumedResults []v1beta1.ResultProvenance
+	for _, r := range pr.Status.Provenance.ConsumedResults {
+		if r.PipelineTask != pipelineTask {
+			consumedResults = append(consumedResults, r)
+		}
+	}
+	pr.Status.Provenance.ConsumedResults = append(consumedResults, consumed...)
+}
+
 // setFinallyStartedTimeIfNeeded sets the PipelineRun.Status.FinallyStartedTime to the current time if it's nil.
 func (c *Reconciler) setFinallyStartedTimeIfNeeded(pr *v1beta1.PipelineRun, facts *resources.PipelineRunFacts) {
 	if pr.Status.FinallyStartTime == nil {
@@ -832,8 +1233,16 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	defer span.End()
 	logger := logging.FromContext(ctx)
 	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
-	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
-	params = append(params, rpt.PipelineTask.Params...)
+	taskRunSpec := getTaskRunSpec(ctx, pr, rpt.PipelineTask.Name)
+	combination := params
+	if rpt.PipelineTask.IsLooped() {
+		params = resources.ApplyLoopItem(rpt.PipelineTask.Params, params)
+	} else {
+		params = append(params, rpt.PipelineTask.Params...)
+	}
+	// The retries and the timeout are validated in runNextSchedulableTask.
+	retries, _ := rpt.PipelineTask.GetRetries()
+	timeout, _ := rpt.PipelineTask.GetTimeout()
 	tr := &v1beta1.TaskRun{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:            taskRunName,
@@ -843,23 +1252,50 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 			Annotations:     combineTaskRunAndTaskSpecAnnotations(pr, rpt.PipelineTask),
 		},
 		Spec: v1beta1.TaskRunSpec{
-			Retries:            rpt.PipelineTask.Retries,
+			Retries:            retries,
+			RepeatUntil:        rpt.PipelineTask.RepeatUntil,
 			Params:             params,
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
 			StepOverrides:      taskRunSpec.StepOverrides,
 			SidecarOverrides:   taskRunSpec.SidecarOverrides,
 			ComputeResources:   taskRunSpec.ComputeResources,
+			ResultFiles:        rpt.PipelineTask.ResultFiles,
 		}}
 
-	// Add current spanContext as annotations to TaskRun
-	// so that tracing can be continued under the same traceId
-	if spanContext, err := getMarshalledSpanFromContext(ctx); err == nil {
+	// Add the spanContext of the PipelineRun as annotations to TaskRun
+	// so that its span is a child of the one of the PipelineRun
+	if spanContext, err := getMarshalledSpanContext(pr.Status.SpanContext); err == nil {
 		tr.Annotations[TaskRunSpanContextAnnotation] = spanContext
 	}
 
-	if rpt.PipelineTask.Timeout != nil {
-		tr.Spec.Timeout = rpt.PipelineTask.Timeout
+	// The creator of the PipelineRun is the creator of its TaskRuns, whatever their metadata
+	if creator, ok := pr.Annotations[pipeline.CreatorAnnotationKey]; ok {
+		tr.Annotations[pipeline.CreatorAnnotationKey] = creator
+	} else {
+		delete(tr.Annotations, pipeline.CreatorAnnotationKey)
+	}
+
+	// Record the combination of the matrix the TaskRun runs, exposed to its steps as
+	// $(context.pipelineTask.matrixCombination.<param>)
+	if rpt.PipelineTask.IsMatrixed() {
+		tr.Annotations[pipeline.MatrixCombinationAnnotationKey] = matrixCombinationAnnotation(combination)
+	} else {
+		delete(tr.Annotations, pipeline.MatrixCombinationAnnotationKey)
+	}
+
+	if timeout != nil {
+		tr.Spec.Timeout = timeout
+	}
+
+	// Spread the TaskRuns of the combinations of the matrix across failure domains,
+	// unless the pod template of the PipelineRun sets a constraint for the same topology.
+	if rpt.PipelineTask.Matrix.HasSpread() {
+		spread := &pod.Template{TopologySpreadConstraints: rpt.PipelineTask.Matrix.TopologySpreadConstraints(map[string]string{
+			pipeline.PipelineRunLabelKey:  pr.Name,
+			pipeline.PipelineTaskLabelKey: rpt.PipelineTask.Name,
+		})}
+		tr.Spec.PodTemplate = pod.MergePodTemplateWithOverride(spread, tr.Spec.PodTemplate)
 	}
 
 	if rpt.ResolvedTask.TaskName != "" {
@@ -875,6 +1311,11 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	if err != nil {
 		return nil, err
 	}
+	pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(pr), pr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	tr.Spec.Workspaces = volumeclaim.ApplyPooledPersistentVolumeClaimNames(tr.Spec.Workspaces, pooledClaimNames)
 
 	if !c.isAffinityAssistantDisabled(ctx) && pipelinePVCWorkspaceName != "" {
 		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
@@ -884,6 +1325,20 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
+// matrixCombinationAnnotation returns the string params of a combination of a matrix encoded
+// as a JSON object, as recorded in the annotations of the TaskRun running it.
+func matrixCombinationAnnotation(params v1beta1.Params) string {
+	combination := map[string]string{}
+	for _, p := range params {
+		if p.Value.Type == v1beta1.ParamTypeString {
+			combination[p.Name] = p.Value.StringVal
+		}
+	}
+	// A map of strings is always encoded.
+	b, _ := json.Marshal(combination)
+	return string(b)
+}
+
 func (c *Reconciler) createRunObjects(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) ([]v1beta1.RunObject, error) {
 	var runObjects []v1beta1.RunObject
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createRunObjects")
@@ -913,10 +1368,16 @@ func (c *Reconciler) createRunObject(ctx context.Context, runName string, params
 	defer span.End()
 	logger := logging.FromContext(ctx)
 	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
-	taskRunSpec := pr.GetTaskRunSpec(rpt.PipelineTask.Name)
-	params = append(params, rpt.PipelineTask.Params...)
+	taskRunSpec := getTaskRunSpec(ctx, pr, rpt.PipelineTask.Name)
+	if rpt.PipelineTask.IsLooped() {
+		params = resources.ApplyLoopItem(rpt.PipelineTask.Params, params)
+	} else {
+		params = append(params, rpt.PipelineTask.Params...)
+	}
 
-	taskTimeout := rpt.PipelineTask.Timeout
+	// The retries and the timeout are validated in runNextSchedulableTask.
+	retries, _ := rpt.PipelineTask.GetRetries()
+	taskTimeout, _ := rpt.PipelineTask.GetTimeout()
 	var pipelinePVCWorkspaceName string
 	var err error
 	var workspaces []v1beta1.WorkspaceBinding
@@ -924,6 +1385,11 @@ func (c *Reconciler) createRunObject(ctx context.Context, runName string, params
 	if err != nil {
 		return nil, err
 	}
+	pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(pr), pr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	workspaces = volumeclaim.ApplyPooledPersistentVolumeClaimNames(workspaces, pooledClaimNames)
 
 	objectMeta := metav1.ObjectMeta{
 		Name:            runName,
@@ -936,7 +1402,7 @@ func (c *Reconciler) createRunObject(ctx context.Context, runName string, params
 	r := &v1beta1.CustomRun{
 		ObjectMeta: objectMeta,
 		Spec: v1beta1.CustomRunSpec{
-			Retries:            rpt.PipelineTask.Retries,
+			Retries:            retries,
 			CustomRef:          rpt.PipelineTask.TaskRef,
 			Params:             params,
 			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
@@ -970,6 +1436,97 @@ func (c *Reconciler) createRunObject(ctx context.Context, runName string, params
 	return c.PipelineClientSet.TektonV1beta1().CustomRuns(pr.Namespace).Create(ctx, r, metav1.CreateOptions{})
 }
 
+// createChildPipelineRun creates the child PipelineRun running the Pipeline referenced by the PipelineTask,
+// passing it the params and the workspaces of the PipelineTask.
+func (c *Reconciler) createChildPipelineRun(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createChildPipelineRun")
+	defer span.End()
+	logger := logging.FromContext(ctx)
+	rpt.PipelineTask = resources.ApplyPipelineTaskContexts(rpt.PipelineTask)
+	taskRunSpec := getTaskRunSpec(ctx, pr, rpt.PipelineTask.Name)
+	// The timeout is validated in runNextSchedulableTask.
+	timeout, _ := rpt.PipelineTask.GetTimeout()
+
+	workspaces, _, err := getTaskrunWorkspaces(ctx, pr, rpt)
+	if err != nil {
+		return nil, err
+	}
+	pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(pr), pr.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	workspaces = volumeclaim.ApplyPooledPersistentVolumeClaimNames(workspaces, pooledClaimNames)
+	ancestry, err := getChildPipelineAncestry(pr, rpt.PipelineTask)
+	if err != nil {
+		return nil, controller.NewPermanentError(err)
+	}
+	encodedAncestry, err := json.Marshal(ancestry)
+	if err != nil {
+		return nil, err
+	}
+
+	child := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rpt.ChildPipelineRunName,
+			Namespace:       pr.Namespace,
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			Labels:          getTaskrunLabels(pr, rpt.PipelineTask.Name, true),
+			Annotations:     getTaskrunAnnotations(pr),
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        rpt.PipelineTask.PipelineRef,
+			Params:             rpt.PipelineTask.Params,
+			ServiceAccountName: taskRunSpec.TaskServiceAccountName,
+			Workspaces:         workspaces,
+		},
+	}
+	child.Annotations[pipeline.PipelineAncestryAnnotationKey] = string(encodedAncestry)
+	if timeout != nil {
+		child.Spec.Timeouts = &v1beta1.TimeoutFields{Pipeline: timeout}
+	}
+
+	logger.Infof("Creating a new PipelineRun object %s for pipeline task %s", rpt.ChildPipelineRunName, rpt.PipelineTask.Name)
+	return c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Create(ctx, child, metav1.CreateOptions{})
+}
+
+// getChildPipelineAncestry returns the Pipelines run by the ancestors of the child PipelineRun
+// of the PipelineTask, from the root PipelineRun to the PipelineRun, identified by pipelineRefKey.
+// It returns an error if the PipelineTask runs one of these Pipelines, or if the child PipelineRun
+// would have more than maxChildPipelineRunDepth ancestors.
+func getChildPipelineAncestry(pr *v1beta1.PipelineRun, pt *v1beta1.PipelineTask) ([]string, error) {
+	var ancestry []string
+	if encoded, ok := pr.Annotations[pipeline.PipelineAncestryAnnotationKey]; ok {
+		if err := json.Unmarshal([]byte(encoded), &ancestry); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation %q: %w", pipeline.PipelineAncestryAnnotationKey, encoded, err)
+		}
+	}
+	ancestry = append(ancestry, pipelineRefKey(pr.Spec.PipelineRef))
+	if len(ancestry) > maxChildPipelineRunDepth {
+		return nil, fmt.Errorf("pipeline task %q would nest more than %d PipelineRuns", pt.Name, maxChildPipelineRunDepth)
+	}
+	if key := pipelineRefKey(pt.PipelineRef); key != "" {
+		for _, ancestor := range ancestry {
+			if ancestor == key {
+				return nil, fmt.Errorf("pipeline task %q runs %s, which is already run by PipelineRun %q or one of its ancestors", pt.Name, key, pr.Name)
+			}
+		}
+	}
+	return ancestry, nil
+}
+
+// pipelineRefKey identifies the Pipeline referenced by ref as its kind and its name, or returns
+// "" if the Pipeline is embedded, remote or in a bundle, as its name doesn't identify it.
+func pipelineRefKey(ref *v1beta1.PipelineRef) string {
+	if ref == nil || ref.Name == "" || ref.Bundle != "" || ref.Resolver != "" {
+		return ""
+	}
+	kind := ref.Kind
+	if kind == "" {
+		kind = v1beta1.NamespacedPipelineKind
+	}
+	return string(kind) + "/" + ref.Name
+}
+
 // propagateWorkspaces identifies the workspaces that the pipeline task usess
 // It adds the additional workspaces to the pipeline task's workspaces after
 // creating workspace bindings. Finally, it returns the updated resolved pipeline task.
@@ -994,14 +1551,40 @@ func propagateWorkspaces(rpt *resources.ResolvedPipelineTask) (*resources.Resolv
 	return rpt, nil
 }
 
+// getTaskRunSpec returns the spec of the TaskRun of the PipelineTask, with the
+// taskRunTemplate of the Pipeline used for what the PipelineRun doesn't set.
+func getTaskRunSpec(ctx context.Context, pr *v1beta1.PipelineRun, pipelineTaskName string) v1beta1.PipelineTaskRunSpec {
+	s := pr.GetTaskRunSpec(pipelineTaskName)
+	if pr.Status.PipelineSpec == nil || pr.Status.PipelineSpec.TaskRunTemplate == nil {
+		return s
+	}
+	template := pr.Status.PipelineSpec.TaskRunTemplate
+	// PipelineRuns are defaulted with the default service account, which doesn't
+	// override the one of the Pipeline.
+	if template.ServiceAccountName != "" && s.TaskServiceAccountName == pr.Spec.ServiceAccountName &&
+		(s.TaskServiceAccountName == "" || s.TaskServiceAccountName == config.DefaultsForNamespace(ctx, pr.Namespace).DefaultServiceAccount) {
+		s.TaskServiceAccountName = template.ServiceAccountName
+	}
+	s.TaskPodTemplate = pod.MergePodTemplateWithOverride(template.PodTemplate, s.TaskPodTemplate)
+	return s
+}
+
 func getTaskrunWorkspaces(ctx context.Context, pr *v1beta1.PipelineRun, rpt *resources.ResolvedPipelineTask) ([]v1beta1.WorkspaceBinding, string, error) {
 	var err error
 	var workspaces []v1beta1.WorkspaceBinding
 	var pipelinePVCWorkspaceName string
 	pipelineRunWorkspaces := make(map[string]v1beta1.WorkspaceBinding)
-	for _, binding := range pr.Spec.Workspaces {
+	for _, binding := range typedWorkspaceBindings(pr.Status.PipelineSpec, pr.Spec.Workspaces) {
 		pipelineRunWorkspaces[binding.Name] = binding
 	}
+	readOnlyWorkspaces := sets.NewString()
+	if pr.Status.PipelineSpec != nil {
+		for _, ws := range pr.Status.PipelineSpec.Workspaces {
+			if ws.Mode == v1beta1.WorkspaceModeReadOnly {
+				readOnlyWorkspaces.Insert(ws.Name)
+			}
+		}
+	}
 
 	// Propagate required workspaces from pipelineRun to the pipelineTasks
 	if rpt.PipelineTask.TaskSpec != nil {
@@ -1024,7 +1607,11 @@ func getTaskrunWorkspaces(ctx context.Context, pr *v1beta1.PipelineRun, rpt *res
 			if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil {
 				pipelinePVCWorkspaceName = pipelineWorkspace
 			}
-			workspaces = append(workspaces, taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, *kmeta.NewControllerRef(pr)))
+			binding := taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, *kmeta.NewControllerRef(pr))
+			if readOnlyWorkspaces.Has(pipelineWorkspace) {
+				binding = readOnlyWorkspaceBinding(binding)
+			}
+			workspaces = append(workspaces, binding)
 		} else {
 			workspaceIsOptional := false
 			if rpt.ResolvedTask != nil && rpt.ResolvedTask.TaskSpec != nil {
@@ -1076,6 +1663,20 @@ func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorks
 	return binding
 }
 
+// readOnlyWorkspaceBinding returns the WorkspaceBinding with its volume source mounted read-only, for the
+// volume sources supporting it. ConfigMaps, Secrets and projected volumes are always mounted read-only.
+func readOnlyWorkspaceBinding(wb v1beta1.WorkspaceBinding) v1beta1.WorkspaceBinding {
+	binding := *wb.DeepCopy()
+	switch {
+	case binding.PersistentVolumeClaim != nil:
+		binding.PersistentVolumeClaim.ReadOnly = true
+	case binding.CSI != nil:
+		readOnly := true
+		binding.CSI.ReadOnly = &readOnly
+	}
+	return binding
+}
+
 // combinedSubPath returns the combined value of the optional subPath from workspaceBinding and the optional
 // subPath from pipelineTask. If both is set, they are joined with a slash.
 func combinedSubPath(workspaceSubPath string, pipelineTaskSubPath string) string {
@@ -1208,6 +1809,24 @@ func (c *Reconciler) updateLabelsAndAnnotations(ctx context.Context, pr *v1beta1
 	return newPr, nil
 }
 
+// setDisplayNameAndDescription annotates the PipelineRun with its display name and description.
+// The display name is also set as a label, after replacing the characters not allowed in label
+// values with "-" and truncating it, so that PipelineRuns can be searched by display name.
+func setDisplayNameAndDescription(pr *v1beta1.PipelineRun, displayName, description string) {
+	if displayName != "" {
+		metav1.SetMetaDataAnnotation(&pr.ObjectMeta, pipeline.DisplayNameKey, displayName)
+		labelValue := invalidLabelValueChars.ReplaceAllString(displayName, "-")
+		if len(labelValue) > validation.LabelValueMaxLength {
+			labelValue = labelValue[:validation.LabelValueMaxLength]
+		}
+		// Label values must start and end with an alphanumeric character.
+		metav1.SetMetaDataLabel(&pr.ObjectMeta, pipeline.DisplayNameKey, strings.Trim(labelValue, "-_."))
+	}
+	if description != "" {
+		metav1.SetMetaDataAnnotation(&pr.ObjectMeta, pipeline.DescriptionAnnotationKey, description)
+	}
+}
+
 func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1beta1.PipelineRun, ps *v1beta1.PipelineSpec, meta *resolutionutil.ResolvedObjectMeta) error {
 	// Only store the PipelineSpec once, if it has never been set before.
 	if pr.Status.PipelineSpec == nil {
@@ -1219,6 +1838,9 @@ func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1beta1.PipelineRun,
 		// Propagate labels from Pipeline to PipelineRun. PipelineRun labels take precedences over Pipeline.
 		pr.ObjectMeta.Labels = kmap.Union(meta.Labels, pr.ObjectMeta.Labels)
 		pr.ObjectMeta.Labels[pipeline.PipelineLabelKey] = meta.Name
+		if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Kind == v1beta1.ClusterPipelineKind {
+			pr.ObjectMeta.Labels[pipeline.ClusterPipelineLabelKey] = meta.Name
+		}
 
 		// Propagate annotations from Pipeline to PipelineRun. PipelineRun annotations take precedences over Pipeline.
 		pr.ObjectMeta.Annotations = kmap.Union(kmap.ExcludeKeys(meta.Annotations, tknreconciler.KubectlLastAppliedAnnotationKey), pr.ObjectMeta.Annotations)
@@ -1240,6 +1862,15 @@ func storePipelineSpecAndMergeMeta(ctx context.Context, pr *v1beta1.PipelineRun,
 		if meta != nil && meta.RefSource != nil && pr.Status.Provenance.ConfigSource == nil {
 			pr.Status.Provenance.ConfigSource = (*v1beta1.ConfigSource)(meta.RefSource)
 		}
+		// Sign the PipelineSpec as resolved, before the parameters of the PipelineRun are
+		// substituted, so that audits can prove which definition the PipelineRun executed.
+		if cfg.FeatureFlags.ProvenanceSigningKey != "" && ps != nil && pr.Status.Provenance.SpecAttestation == nil {
+			attestation, err := trustedresources.AttestSpec(ctx, ps, cfg.FeatureFlags.ProvenanceSigningKey)
+			if err != nil {
+				return fmt.Errorf("failed to attest the PipelineSpec: %w", err)
+			}
+			pr.Status.Provenance.SpecAttestation = attestation
+		}
 	}
 
 	return nil
@@ -1284,7 +1915,7 @@ func validateChildObjectsInPipelineRunStatus(ctx context.Context, prs v1beta1.Pi
 
 	for _, cr := range prs.ChildReferences {
 		switch cr.Kind {
-		case taskRun, customRun:
+		case taskRun, customRun, pipelineRun:
 			continue
 		default:
 			err = multierror.Append(err, fmt.Errorf("child with name %s has unknown kind %s", cr.Name, cr.Kind))
@@ -18,6 +18,10 @@ package pipelinerun
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -33,18 +37,24 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/sigstore/sigstore/pkg/signature"
+	fakekms "github.com/sigstore/sigstore/pkg/signature/kms/fake"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/k8sevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/pkg/reconciler/runnamespace"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	remoteresource "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test"
 	"github.com/tektoncd/pipeline/test/diff"
 	"github.com/tektoncd/pipeline/test/names"
@@ -53,6 +63,8 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -652,6 +664,98 @@ spec:
 	verifyTaskRunStatusesNames(t, reconciledRun.Status, trName)
 }
 
+func TestReconcile_ParamValueSources(t *testing.T) {
+	// TestReconcile_ParamValueSources runs "Reconcile" on a PipelineRun whose params take their values
+	// from a ConfigMap and a Secret. It verifies that the value of the ConfigMap is recorded in the status
+	// and that the TaskRun is passed the reference to the key of the Secret rather than its value.
+	names.TestingSeed()
+
+	namespace := "foo"
+	prName := "test-pipeline-run-param-value-sources"
+	trName := "test-pipeline-run-param-value-sources-unit-test-task-spec"
+
+	pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-param-value-sources
+  namespace: foo
+spec:
+  params:
+  - name: branch
+    valueFrom:
+      configMapKeyRef:
+        name: build-config
+        key: branch
+  - name: token
+    valueFrom:
+      secretKeyRef:
+        name: creds
+        key: token
+  pipelineSpec:
+    params:
+    - name: branch
+      type: string
+    - name: token
+      type: secret
+    tasks:
+    - name: unit-test-task-spec
+      params:
+      - name: branch
+        value: $(params.branch)
+      - name: token
+        value: $(params.token[*])
+      taskSpec:
+        params:
+        - name: branch
+          type: string
+        - name: token
+          type: secret
+        steps:
+        - name: mystep
+          image: myimage
+          script: echo $(params.branch)
+`)
+	// The params taking their values from ConfigMaps and Secrets are typed by the webhook.
+	pr.SetDefaults(context.Background())
+
+	d := test.Data{
+		PipelineRuns: []*v1beta1.PipelineRun{pr},
+		ConfigMaps: []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), {
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "build-config"},
+			Data:       map[string]string{"branch": "main"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun(namespace, prName, wantEvents, false)
+
+	wantResolved := v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main")}}
+	if d := cmp.Diff(wantResolved, reconciledRun.Status.ResolvedParams); d != "" {
+		t.Errorf("expected Status.ResolvedParams to match, but differed: %s", diff.PrintWantGot(d))
+	}
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prName)
+	validateTaskRunsCount(t, taskRuns, 1)
+	actual := getTaskRunByName(t, taskRuns, trName)
+	wantParams := v1beta1.Params{{
+		Name: "branch", Value: *v1beta1.NewStructuredValues("main"),
+	}, {
+		Name: "token", Value: *v1beta1.NewObject(map[string]string{"name": "creds", "key": "token"}),
+	}}
+	if d := cmp.Diff(wantParams, actual.Spec.Params); d != "" {
+		t.Errorf("expected the params of the TaskRun to match, but differed: %s", diff.PrintWantGot(d))
+	}
+	for _, action := range clients.Kube.Actions() {
+		if action.GetResource().Resource == "secrets" {
+			t.Errorf("expected the Secrets not to be read, but got the action %v", action)
+		}
+	}
+}
+
 // TestReconcile_InvalidPipelineRuns runs "Reconcile" on several PipelineRuns that are invalid in different ways.
 // It verifies that reconcile fails, how it fails and which events are triggered.
 func TestReconcile_InvalidPipelineRuns(t *testing.T) {
@@ -767,6 +871,20 @@ spec:
       taskRef:
         name: a-task-that-needs-object-params
 `, v1beta1.ParamTypeObject)),
+		parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: a-pipeline-with-enum-params
+  namespace: foo
+spec:
+  params:
+    - name: some-param
+      type: string
+      enum: [staging, prod]
+  tasks:
+    - name: some-task
+      taskRef:
+        name: a-task-that-exists
+`),
 	}
 
 	for _, tc := range []struct {
@@ -864,6 +982,25 @@ spec:
 			"Normal Started",
 			"Warning Failed PipelineRun foo/pipeline-missing-object-param-keys parameters is missing object keys required by Pipeline foo/a-pipeline-with-object-params's parameters: PipelineRun missing object keys for parameters",
 		},
+	}, {
+		name: "invalid-pipeline-param-value-not-in-enum",
+		pipelineRun: parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pipeline-param-value-not-in-enum
+  namespace: foo
+spec:
+  pipelineRef:
+    name: a-pipeline-with-enum-params
+  params:
+    - name: some-param
+      value: qa
+`),
+		reason:         ReasonParameterValueInvalid,
+		permanentError: true,
+		wantEvents: []string{
+			"Normal Started",
+			`Warning Failed PipelineRun foo/pipeline-param-value-not-in-enum parameters have invalid values for Pipeline foo/a-pipeline-with-enum-params's parameters: param "some-param" value "qa" is not one of the allowed values [staging prod]`,
+		},
 	}, {
 		name: "invalid-pipeline-array-index-out-of-bound",
 		pipelineRun: parse.MustParseV1beta1PipelineRun(t, `
@@ -1043,6 +1180,253 @@ spec:
 	}
 }
 
+func TestReconcile_ServiceAccountPolicy(t *testing.T) {
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: deploy
+  namespace: foo
+spec:
+  steps:
+    - name: deploy
+      image: busybox
+`)}
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: release
+  namespace: foo
+spec:
+  tasks:
+    - name: deploy
+      taskRef:
+        name: deploy
+`)}
+	saps := []*v1alpha1.ServiceAccountPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "foo"},
+		Spec: v1alpha1.ServiceAccountPolicySpec{
+			Pipelines:       []v1alpha1.ResourcePattern{{Pattern: "^release$"}},
+			ServiceAccounts: []string{"default", "deployer"},
+		},
+	}}
+
+	t.Run("allowed", func(t *testing.T) {
+		prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: release-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: release
+  serviceAccountName: default
+  taskRunSpecs:
+    - pipelineTaskName: deploy
+      taskServiceAccountName: deployer
+`)}
+		d := test.Data{
+			PipelineRuns:           prs,
+			Pipelines:              ps,
+			Tasks:                  ts,
+			ServiceAccountPolicies: saps,
+			ConfigMaps:             []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+		}
+		prt := newPipelineRunTest(t, d)
+		defer prt.Cancel()
+
+		reconciledRun, clients := prt.reconcileRun("foo", "release-run", []string{}, false)
+		want := []v1beta1.ServiceAccountGrant{{
+			ServiceAccount: "default",
+			Policy:         "release",
+		}, {
+			PipelineTask:   "deploy",
+			ServiceAccount: "deployer",
+			Policy:         "release",
+		}}
+		if d := cmp.Diff(want, reconciledRun.Status.Provenance.ServiceAccounts); d != "" {
+			t.Errorf("expected the service accounts to be recorded in the provenance %s", diff.PrintWantGot(d))
+		}
+		taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(taskRuns.Items) != 1 || taskRuns.Items[0].Spec.ServiceAccountName != "deployer" {
+			t.Errorf("expected a TaskRun run with service account deployer but got %v", taskRuns.Items)
+		}
+	})
+
+	t.Run("not allowed", func(t *testing.T) {
+		prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: release-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: release
+  serviceAccountName: default
+  taskRunSpecs:
+    - pipelineTaskName: deploy
+      taskServiceAccountName: cluster-admin
+`)}
+		d := test.Data{
+			PipelineRuns:           prs,
+			Pipelines:              ps,
+			Tasks:                  ts,
+			ServiceAccountPolicies: saps,
+			ConfigMaps:             []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+		}
+		prt := newPipelineRunTest(t, d)
+		defer prt.Cancel()
+
+		wantEvents := []string{
+			"Normal Started",
+			"Warning Failed PipelineRun foo/release-run uses a service account not allowed for Pipeline foo/release",
+			"Warning InternalError 1 error occurred",
+		}
+		reconciledRun, clients := prt.reconcileRun("foo", "release-run", wantEvents, true)
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonServiceAccountNotAllowed)
+		taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(taskRuns.Items) != 0 {
+			t.Errorf("expected no TaskRun to be created but got %v", taskRuns.Items)
+		}
+	})
+
+	t.Run("embedded pipeline named after an allowed pipeline", func(t *testing.T) {
+		prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: release
+  namespace: foo
+spec:
+  pipelineSpec:
+    tasks:
+      - name: deploy
+        taskRef:
+          name: deploy
+  serviceAccountName: deployer
+`)}
+		d := test.Data{
+			PipelineRuns:           prs,
+			Tasks:                  ts,
+			ServiceAccountPolicies: saps,
+			ConfigMaps:             []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+		}
+		prt := newPipelineRunTest(t, d)
+		defer prt.Cancel()
+
+		wantEvents := []string{
+			"Normal Started",
+			"Warning Failed PipelineRun foo/release uses a service account not allowed for Pipeline foo/release",
+			"Warning InternalError 1 error occurred",
+		}
+		reconciledRun, _ := prt.reconcileRun("foo", "release", wantEvents, true)
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonServiceAccountNotAllowed)
+	})
+}
+
+func TestReconcile_ExecutionWindowPolicy(t *testing.T) {
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: deploy
+  namespace: foo
+spec:
+  steps:
+    - name: deploy
+      image: busybox
+`)}
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: deploy-prod
+  namespace: foo
+spec:
+  tasks:
+    - name: deploy
+      taskRef:
+        name: deploy
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: deploy-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: deploy-prod
+`)}
+	// The test clock is on a Saturday.
+	for _, tc := range []struct {
+		name     string
+		policies []*v1alpha1.ExecutionWindowPolicy
+		held     bool
+	}{{
+		name: "within a blocked window",
+		policies: []*v1alpha1.ExecutionWindowPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-weekend-deploys", Namespace: "foo"},
+			Spec: v1alpha1.ExecutionWindowPolicySpec{
+				Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^deploy-"}},
+				Blocked:   []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+			},
+		}},
+		held: true,
+	}, {
+		name: "policy applying to other pipelines",
+		policies: []*v1alpha1.ExecutionWindowPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "no-weekend-builds", Namespace: "foo"},
+			Spec: v1alpha1.ExecutionWindowPolicySpec{
+				Pipelines: []v1alpha1.ResourcePattern{{Pattern: "^build-"}},
+				Blocked:   []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+			},
+		}},
+	}, {
+		name: "within an allowed window",
+		policies: []*v1alpha1.ExecutionWindowPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "weekend-deploys", Namespace: "foo"},
+			Spec: v1alpha1.ExecutionWindowPolicySpec{
+				Allowed: []v1alpha1.ExecutionWindow{{Name: "weekend", Days: []string{"Saturday", "Sunday"}}},
+			},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := test.Data{
+				PipelineRuns:            prs,
+				Pipelines:               ps,
+				Tasks:                   ts,
+				ExecutionWindowPolicies: tc.policies,
+				ConfigMaps:              []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			reconciledRun, clients := prt.reconcileRun("foo", "deploy-run", []string{}, false)
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tc.held {
+				if reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Reason != v1beta1.PipelineRunReasonRunning.String() || len(taskRuns.Items) != 1 {
+					t.Errorf("expected the PipelineRun to start and create a TaskRun but got %v", taskRuns.Items)
+				}
+				return
+			}
+			checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, ReasonOutsideExecutionWindow)
+			wantMessage := `ExecutionWindowPolicy "no-weekend-deploys" holds the PipelineRun within its blocked window "weekend" until 2022-01-03T00:00:00Z`
+			if got := reconciledRun.Status.GetCondition(apis.ConditionSucceeded).Message; got != wantMessage {
+				t.Errorf("expected the condition message %q but got %q", wantMessage, got)
+			}
+			if reconciledRun.Status.StartTime != nil {
+				t.Errorf("expected the held PipelineRun not to start but it started at %v", reconciledRun.Status.StartTime)
+			}
+			if len(taskRuns.Items) != 0 {
+				t.Errorf("expected no TaskRun to be created but got %v", taskRuns.Items)
+			}
+
+			// The PipelineRun is reconciled again when the window opens.
+			err = prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/deploy-run")
+			if ok, delay := controller.IsRequeueKey(err); !ok || delay != 48*time.Hour {
+				t.Errorf("expected the PipelineRun to be requeued in 48h but got %v", err)
+			}
+		})
+	}
+}
+
 func TestReconcile_InvalidPipelineRunNames(t *testing.T) {
 	// TestReconcile_InvalidPipelineRunNames runs "Reconcile" on several PipelineRuns that have invalid names.
 	// It verifies that reconcile fails, how it fails and which events are triggered.
@@ -1269,6 +1653,9 @@ func TestReconcileOnCancelledPipelineRun(t *testing.T) {
 			// Check that no TaskRun is created or run
 			for _, action := range actions {
 				actionType := fmt.Sprintf("%T", action)
+				if action.GetSubresource() == "status" {
+					continue
+				}
 				if !(actionType == "testing.UpdateActionImpl" || actionType == "testing.GetActionImpl") {
 					t.Errorf("Expected a TaskRun to be get/updated, but it was %s", actionType)
 				}
@@ -1686,7 +2073,7 @@ spec:
 	actions := clients.Pipeline.Actions()
 	patchActions := make([]ktesting.PatchAction, 0)
 	for _, action := range actions {
-		if patchAction, ok := action.(ktesting.PatchAction); ok {
+		if patchAction, ok := action.(ktesting.PatchAction); ok && patchAction.GetSubresource() != "status" {
 			patchActions = append(patchActions, patchAction)
 		}
 	}
@@ -1996,7 +2383,7 @@ status:
 			actions := clients.Pipeline.Actions()
 			patchCount := 0
 			for _, action := range actions {
-				if _, ok := action.(ktesting.PatchAction); ok {
+				if patchAction, ok := action.(ktesting.PatchAction); ok && patchAction.GetSubresource() != "status" {
 					patchCount++
 				}
 			}
@@ -2357,7 +2744,7 @@ spec:
 
 			// this limit is just enough to set the timeout condition, but not enough for extra metadata.
 			etcdRequestSizeLimit := 650
-			prt.TestAssets.Clients.Pipeline.PrependReactor("update", "pipelineruns", withEtcdRequestSizeLimit(t, etcdRequestSizeLimit))
+			prt.TestAssets.Clients.Pipeline.PrependReactor("patch", "pipelineruns", withEtcdRequestSizeLimit(t, etcdRequestSizeLimit))
 
 			c := prt.TestAssets.Controller
 			clients := prt.TestAssets.Clients
@@ -2393,17 +2780,12 @@ spec:
 	}
 }
 
-// withEtcdRequestSizeLimit calculates the yaml marshal of the payload and gives an `etcdserver: request too large` when
+// withEtcdRequestSizeLimit calculates the size of the applied status and gives an `etcdserver: request too large` when
 // the limit is reached
 func withEtcdRequestSizeLimit(t *testing.T, limitBytes int) ktesting.ReactionFunc {
 	t.Helper()
 	return func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
-		obj := action.(ktesting.UpdateAction).GetObject()
-		bytes, err := yaml.Marshal(obj)
-		if err != nil {
-			t.Fatalf("returned a unserializable status: %+v", obj)
-		}
-
+		bytes := action.(ktesting.PatchAction).GetPatch()
 		if len(bytes) > limitBytes {
 			t.Logf("request size: %d\nrequest limit: %d\n", len(bytes), limitBytes)
 			t.Logf("payload:\n%s\n", string(bytes))
@@ -2676,6 +3058,63 @@ spec:
 	}
 }
 
+func TestReconcile_StatusOffload(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  uid: "0123"
+spec:
+  pipelineRef:
+    name: test-pipeline
+`)}
+	cm := newFeatureFlagsConfigMap()
+	cm.Data["status-offload"] = config.StatusOffloadConfigMap
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+	if reconciledRun.Status.OffloadedStatus == nil {
+		t.Fatal("Expected the status of the PipelineRun to be offloaded")
+	}
+	if d := cmp.Diff([]string{"test-pipeline-run-status-0-0"}, reconciledRun.Status.OffloadedStatus.ConfigMaps); d != "" {
+		t.Errorf("offloaded to ConfigMaps %s", diff.PrintWantGot(d))
+	}
+	if reconciledRun.Status.PipelineSpec != nil {
+		t.Errorf("Expected the pipelineSpec to be offloaded, got %v", reconciledRun.Status.PipelineSpec)
+	}
+	var childNames []string
+	for _, cr := range reconciledRun.Status.ChildReferences {
+		childNames = append(childNames, cr.PipelineTaskName)
+	}
+	if d := cmp.Diff([]string{"hello-world-1", "hello-world-2"}, childNames); d != "" {
+		t.Errorf("child references %s", diff.PrintWantGot(d))
+	}
+	if _, err := clients.Kube.CoreV1().ConfigMaps("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-status-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("Expected the ConfigMap holding the offloaded status: %v", err)
+	}
+}
+
 func TestReconcileCancelledFailsTaskRunCancellation(t *testing.T) {
 	prName := "test-pipeline-fails-to-cancel"
 
@@ -2983,37 +3422,111 @@ spec:
 	}
 }
 
-func TestReconcilePropagateLabelsWithSpecStatus(t *testing.T) {
-	testCases := []struct {
-		name       string
-		specStatus v1beta1.PipelineRunSpecStatus
-	}{
-		{
-			name:       "pending",
-			specStatus: v1beta1.PipelineRunSpecStatusPending,
-		}, {
-			name:       "cancelled",
-			specStatus: v1beta1.PipelineRunSpecStatusCancelled,
-		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.name, func(t *testing.T) {
-			names.TestingSeed()
+func TestReconcilePropagateParamsToReferencedTasks(t *testing.T) {
+	names.TestingSeed()
 
-			ps := []*v1beta1.Pipeline{simpleHelloWorldPipeline}
-			prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, fmt.Sprintf(`
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
-  annotations:
-    PipelineRunAnnotation: PipelineRunValue
-  labels:
-    PipelineRunLabel: PipelineRunValue
-    tekton.dev/pipeline: WillNotBeUsed
-  name: test-pipeline-run-with-labels
+  name: test-pipeline
   namespace: foo
 spec:
-  pipelineRef:
-    name: test-pipeline
+  params:
+  - name: revision
+    type: string
+    default: main
+  - name: url
+    type: string
+  tasks:
+  - name: clone
+    taskRef:
+      name: git-clone
+    params:
+    - name: revision
+      value: v1
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  params:
+  - name: url
+    value: https://github.com/tektoncd/pipeline
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1beta1.Task{parse.MustParseV1beta1Task(t, `
+metadata:
+  name: git-clone
+  namespace: foo
+spec:
+  params:
+  - name: url
+    type: string
+  - name: revision
+    type: string
+  steps:
+  - name: clone
+    image: alpine/git
+    script: git clone $(params.url) --branch $(params.revision)
+`)}
+	cm := newFeatureFlagsConfigMap()
+	cm.Data["propagate-params-to-referenced-tasks"] = "true"
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, "foo", "test-pipeline-run")
+	validateTaskRunsCount(t, taskRuns, 1)
+	want := v1beta1.Params{
+		{Name: "revision", Value: *v1beta1.NewStructuredValues("v1")},
+		{Name: "url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/pipeline")},
+	}
+	actual := getTaskRunByName(t, taskRuns, "test-pipeline-run-clone")
+	if d := cmp.Diff(want, actual.Spec.Params); d != "" {
+		t.Errorf("params of the TaskRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcilePropagateLabelsWithSpecStatus(t *testing.T) {
+	testCases := []struct {
+		name       string
+		specStatus v1beta1.PipelineRunSpecStatus
+	}{
+		{
+			name:       "pending",
+			specStatus: v1beta1.PipelineRunSpecStatusPending,
+		}, {
+			name:       "cancelled",
+			specStatus: v1beta1.PipelineRunSpecStatusCancelled,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+
+			ps := []*v1beta1.Pipeline{simpleHelloWorldPipeline}
+			prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  annotations:
+    PipelineRunAnnotation: PipelineRunValue
+  labels:
+    PipelineRunLabel: PipelineRunValue
+    tekton.dev/pipeline: WillNotBeUsed
+  name: test-pipeline-run-with-labels
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
   serviceAccountName: test-sa
   status: %s
 `, tc.specStatus))}
@@ -3044,6 +3557,62 @@ spec:
 	}
 }
 
+func TestReconcileWithDisplayNameAndDescription(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1beta1.Pipeline{simpleHelloWorldPipeline}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-x7k2p
+  namespace: foo
+spec:
+  displayName: "Nightly build: $(params.branch) / #$(params.build)"
+  description: Triggered by $(context.pipelineRun.name)
+  params:
+  - name: branch
+    value: release-v0.47.x
+  - name: build
+    value: "42"
+  pipelineRef:
+    name: test-pipeline
+`)}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-x7k2p", []string{}, false)
+
+	wantAnnotations := map[string]string{
+		"tekton.dev/displayName": "Nightly build: release-v0.47.x / #42",
+		"tekton.dev/description": "Triggered by test-pipeline-run-x7k2p",
+	}
+	for k, want := range wantAnnotations {
+		if d := cmp.Diff(want, reconciledRun.Annotations[k]); d != "" {
+			t.Errorf("annotation %s %s", k, diff.PrintWantGot(d))
+		}
+	}
+	if d := cmp.Diff("Nightly-build-release-v0.47.x-42", reconciledRun.Labels["tekton.dev/displayName"]); d != "" {
+		t.Errorf("label tekton.dev/displayName %s", diff.PrintWantGot(d))
+	}
+
+	// The PipelineRuns can be searched by display name, and so can their TaskRuns.
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/displayName=Nightly-build-release-v0.47.x-42",
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Errorf("Expected 1 TaskRun labelled with the display name but got %d", len(taskRuns.Items))
+	}
+}
+
 func TestReconcileWithDifferentServiceAccounts(t *testing.T) {
 	names.TestingSeed()
 
@@ -3251,6 +3820,91 @@ spec:
 	}
 }
 
+func TestGetTaskRunSpec_PipelineTaskRunTemplate(t *testing.T) {
+	pipelineSpec := `
+status:
+  pipelineSpec:
+    tasks:
+    - name: hello-world-1
+      taskRef:
+        name: hello-world
+    taskRunTemplate:
+      serviceAccountName: pipeline-sa
+      podTemplate:
+        env:
+        - name: FOO
+          value: bar
+        nodeSelector:
+          disktype: ssd
+`
+	for _, tc := range []struct {
+		name   string
+		pr     string
+		wantSA string
+		want   *pod.Template
+	}{{
+		name: "defaults of the pipeline",
+		pr: `
+metadata:
+  name: test-pipeline-run
+spec:
+  serviceAccountName: default
+`,
+		wantSA: "pipeline-sa",
+		want: &pod.Template{
+			Env:          []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			NodeSelector: map[string]string{"disktype": "ssd"},
+		},
+	}, {
+		name: "pipelinerun overrides",
+		pr: `
+metadata:
+  name: test-pipeline-run
+spec:
+  serviceAccountName: run-sa
+  podTemplate:
+    nodeSelector:
+      zone: west
+`,
+		wantSA: "run-sa",
+		want: &pod.Template{
+			Env:          []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+			NodeSelector: map[string]string{"disktype": "ssd", "zone": "west"},
+		},
+	}, {
+		name: "taskRunSpecs override",
+		pr: `
+metadata:
+  name: test-pipeline-run
+spec:
+  serviceAccountName: default
+  taskRunSpecs:
+  - pipelineTaskName: hello-world-1
+    taskServiceAccountName: task-sa
+    taskPodTemplate:
+      env:
+      - name: FOO
+        value: baz
+`,
+		wantSA: "task-sa",
+		want: &pod.Template{
+			Env:          []corev1.EnvVar{{Name: "FOO", Value: "baz"}},
+			NodeSelector: map[string]string{"disktype": "ssd"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := parse.MustParseV1beta1PipelineRun(t, tc.pr+pipelineSpec)
+			got := getTaskRunSpec(context.Background(), pr, "hello-world-1")
+			if got.TaskServiceAccountName != tc.wantSA {
+				t.Errorf("expected service account %q but got %q", tc.wantSA, got.TaskServiceAccountName)
+			}
+			if d := cmp.Diff(tc.want, got.TaskPodTemplate); d != "" {
+				t.Errorf("unexpected pod template %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileCustomTasksWithTaskRunSpec(t *testing.T) {
 	names.TestingSeed()
 	prName := "test-pipeline-run"
@@ -3481,205 +4135,105 @@ spec:
 	}
 }
 
-func TestReconcileWithWhenExpressions(t *testing.T) {
-	//		(b)
-	//		/
-	//	(a) ———— (c) ———— (d)
-	//		\
-	//		(e) ———— (f)
+func TestReconcileWithCELWhenExpressions(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
+  params:
+  - name: branch
+    type: string
   tasks:
-# a-task is skipped because its when expressions evaluate to false
-  - name: a-task
-    taskRef:
-      name: a-task
-    when:
-    - input: foo
-      operator: in
-      values:
-      - bar
-# b-task is executed regardless of running after skipped a-task because when expressions are scoped to task
-  - name: b-task
-    runAfter:
-    - a-task
+  - name: scan
     taskRef:
-      name: b-task
-# c-task is skipped because its when expressions evaluate to false (not because it's parent a-task is skipped)
-  - name: c-task
-    runAfter:
-    - a-task
+      name: scan
+  - name: deploy
     taskRef:
-      name: c-task
+      name: deploy
     when:
-    - input: foo
-      operator: in
-      values:
-      - bar
-# d-task is executed regardless of running after skipped parent c-task (and skipped grandparent a-task)
-# because when expressions are scoped to task
-  - name: d-task
-    runAfter:
-    - c-task
-    taskRef:
-      name: d-task
-# e-task is attempted regardless of running after skipped a-task because when expressions are scoped to task
-# but then get skipped because of missing result references from a-task
-  - name: e-task
+    - cel: "int(tasks.scan.results.critical) < 3 && params.branch.matches('^release-')"
+  - name: block
     taskRef:
-      name: e-task
+      name: block
     when:
-    - input: $(tasks.a-task.results.aResult)
-      operator: in
-      values:
-      - aResultValue
-# f-task is skipped because its parent task e-task is skipped because of missing result reference from a-task
-  - name: f-task
-    runAfter:
-    - e-task
-    taskRef:
-      name: f-task
+    - cel: "int(tasks.scan.results.critical) >= 3"
 `)}
-	// initialize the pipelinerun with the skipped a-task
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-different-service-accs
+  name: test-pipeline-run
   namespace: foo
 spec:
+  params:
+  - name: branch
+    value: release-1
   pipelineRef:
     name: test-pipeline
-  serviceAccountName: test-sa-0
-status:
-  skippedTasks:
-  - name: a-task
-    whenExpressions:
-    - input: foo
-      operator: in
-      values:
-      - bar
 `)}
-	// initialize the tasks used in the pipeline
 	ts := []*v1beta1.Task{
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: a-task
-  namespace: foo
-spec:
-  results:
-  - description: a result
-    name: aResult
-`),
-		{ObjectMeta: baseObjectMeta("b-task", "foo")},
-		{ObjectMeta: baseObjectMeta("c-task", "foo")},
-		{ObjectMeta: baseObjectMeta("d-task", "foo")},
-		{ObjectMeta: baseObjectMeta("e-task", "foo")},
-		{ObjectMeta: baseObjectMeta("f-task", "foo")},
+		{ObjectMeta: baseObjectMeta("scan", "foo")},
+		{ObjectMeta: baseObjectMeta("deploy", "foo")},
+		{ObjectMeta: baseObjectMeta("block", "foo")},
 	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-scan", "foo", "test-pipeline-run", "test-pipeline", "scan", true),
+		`
+spec:
+  taskRef:
+    name: scan
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: critical
+    value: "2"
+`)}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
 	wantEvents := []string{
 		"Normal Started",
-		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 4",
+		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
 	}
-	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
-
-	taskRunExists := func(taskName string, taskRunName string) {
-		expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
-			taskRunObjectMeta(taskRunName, "foo", "test-pipeline-run-different-service-accs",
-				"test-pipeline", taskName, false),
-			fmt.Sprintf(`
-spec:
-  serviceAccountName: test-sa-0
-  taskRef:
-    name: %s
-    kind: Task
-`, taskName))
-
-		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-			LabelSelector: fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", taskName),
-			Limit:         1,
-		})
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
 
-		if err != nil {
-			t.Fatalf("Failure to list TaskRuns %s", err)
-		}
-		if len(actual.Items) != 1 {
-			t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
-		}
-		actualTaskRun := actual.Items[0]
-		if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
-			t.Errorf("expected to see TaskRun %v created. Diff %s", taskRunName, diff.PrintWantGot(d))
-		}
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=deploy,tekton.dev/pipelineRun=test-pipeline-run",
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
 	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	verifyTaskRunStatusesWhenExpressions(t, pipelineRun.Status, "test-pipeline-run-deploy", []v1beta1.WhenExpression{{
+		CEL: `int("2") < 3 && "release-1".matches('^release-')`,
+	}})
 
-	taskRunExists("b-task", "test-pipeline-run-different-service-accs-b-task")
-	taskRunExists("d-task", "test-pipeline-run-different-service-accs-d-task")
-
-	actualSkippedTasks := pipelineRun.Status.SkippedTasks
 	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		// its when expressions evaluate to false
-		Name:   "a-task",
-		Reason: v1beta1.WhenExpressionsSkip,
-		WhenExpressions: v1beta1.WhenExpressions{{
-			Input:    "foo",
-			Operator: "in",
-			Values:   []string{"bar"},
-		}},
-	}, {
-		// its when expressions evaluate to false
-		Name:   "c-task",
+		Name:   "block",
 		Reason: v1beta1.WhenExpressionsSkip,
 		WhenExpressions: v1beta1.WhenExpressions{{
-			Input:    "foo",
-			Operator: "in",
-			Values:   []string{"bar"},
-		}},
-	}, {
-		// was attempted, but has missing results references
-		Name:   "e-task",
-		Reason: v1beta1.MissingResultsSkip,
-		WhenExpressions: v1beta1.WhenExpressions{{
-			Input:    "$(tasks.a-task.results.aResult)",
-			Operator: "in",
-			Values:   []string{"aResultValue"},
+			CEL: `int("2") >= 3`,
 		}},
-	}, {
-		Name:   "f-task",
-		Reason: v1beta1.ParentTasksSkip,
 	}}
-	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
+	if d := cmp.Diff(expectedSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
 		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
 	}
-
-	// confirm that there are no taskruns created for the skipped tasks
-	skippedTasks := []string{"a-task", "c-task", "e-task", "f-task"}
-	for _, skippedTask := range skippedTasks {
-		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
-		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-			LabelSelector: labelSelector,
-			Limit:         1,
-		})
-		if err != nil {
-			t.Fatalf("Failure to list TaskRun's %s", err)
-		}
-		if len(actualSkippedTask.Items) != 0 {
-			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
-		}
-	}
 }
 
-func TestReconcileWithWhenExpressionsWithResultRefs(t *testing.T) {
+func TestReconcileWithCELWhenExpressions_EvaluationError(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
@@ -3687,65 +4241,40 @@ metadata:
   namespace: foo
 spec:
   tasks:
-# a-task is executed and produces a result aResult with value aResultValue
-  - name: a-task
+  - name: scan
     taskRef:
-      name: a-task
-# b-task is skipped because it has when expressions, with result reference to a-task, that evaluate to false
-  - name: b-task
+      name: scan
+  - name: deploy
     taskRef:
-      name: b-task
+      name: deploy
     when:
-    - input: $(tasks.a-task.results.aResult)
-      operator: in
-      values:
-      - notResultValue
-# c-task is executed regardless of running after skipped b-task because when expressions are scoped to task
-  - name: c-task
-    runAfter:
-    - b-task
-    taskRef:
-      name: c-task
+    - cel: "int(tasks.scan.results.critical) < 3"
 `)}
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-different-service-accs
+  name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
-  serviceAccountName: test-sa-0
 `)}
 	ts := []*v1beta1.Task{
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: a-task
-  namespace: foo
-spec:
-  results:
-  - description: a result
-    name: aResult
-`),
-		{ObjectMeta: baseObjectMeta("b-task", "foo")},
-		{ObjectMeta: baseObjectMeta("c-task", "foo")},
+		{ObjectMeta: baseObjectMeta("scan", "foo")},
+		{ObjectMeta: baseObjectMeta("deploy", "foo")},
 	}
 	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task",
-			true),
+		taskRunObjectMeta("test-pipeline-run-scan", "foo", "test-pipeline-run", "test-pipeline", "scan", true),
 		`
 spec:
-  serviceAccountName: test-sa
   taskRef:
-    name: hello-world
-  timeout: 1h0m0s
+    name: scan
 status:
   conditions:
   - status: "True"
     type: Succeeded
   taskResults:
-  - name: aResult
-    value: aResultValue
+  - name: critical
+    value: "many"
 `)}
 
 	d := test.Data{
@@ -3753,97 +4282,48 @@ status:
 		Pipelines:    ps,
 		Tasks:        ts,
 		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	wantEvents := []string{
-		"Normal Started",
-		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
-	}
-	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, true)
 
+	if c := pipelineRun.Status.GetCondition(apis.ConditionSucceeded); c == nil || !c.IsFalse() || c.Reason != ReasonInvalidWhenExpression {
+		t.Errorf("expected the PipelineRun to fail with the %s reason, got %v", ReasonInvalidWhenExpression, c)
+	}
 	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineTask=c-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
-		Limit:         1,
+		LabelSelector: "tekton.dev/pipelineTask=deploy,tekton.dev/pipelineRun=test-pipeline-run",
 	})
-
 	if err != nil {
-		t.Fatalf("Failure to list TaskRuns %s", err)
-	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
-	}
-
-	actualSkippedTasks := pipelineRun.Status.SkippedTasks
-	expectedSkippedTasks := []v1beta1.SkippedTask{{
-		// its when expressions evaluate to false
-		Name:   "b-task",
-		Reason: v1beta1.WhenExpressionsSkip,
-		WhenExpressions: v1beta1.WhenExpressions{{
-			Input:    "aResultValue",
-			Operator: "in",
-			Values:   []string{"notResultValue"},
-		}},
-	}}
-	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
-		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+		t.Fatalf("Failure to list TaskRun's %s", err)
 	}
-
-	// confirm that there are no taskruns created for the skipped tasks
-	skippedTasks := []string{"b-task"}
-	for _, skippedTask := range skippedTasks {
-		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
-		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-			LabelSelector: labelSelector,
-			Limit:         1,
-		})
-		if err != nil {
-			t.Fatalf("Failure to list TaskRun's %s", err)
-		}
-		if len(actualSkippedTask.Items) != 0 {
-			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
-		}
+	if len(actual.Items) != 0 {
+		t.Errorf("Expected no TaskRun for the deploy task, got %d", len(actual.Items))
 	}
 }
 
-// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
-// an Affinity Assistant StatefulSet is created for each PVC workspace and
-// that the Affinity Assistant names is propagated to TaskRuns.
-func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
-	workspaceName := "ws1"
-	workspaceName2 := "ws2"
-	pipelineRunName := "test-pipeline-run"
+func TestReconcileWithWorkspaceSubPathFromResults_Invalid(t *testing.T) {
+	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
+  workspaces:
+  - name: source
   tasks:
-  - name: hello-world-1
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws1
-  - name: hello-world-2
+  - name: checkout
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws2
-  - name: hello-world-3
+      name: checkout
+  - name: build
     taskRef:
-      name: hello-world
+      name: build
     workspaces:
-    - name: taskWorkspaceName
-      workspace: emptyDirWorkspace
-  workspaces:
-  - name: ws1
-  - name: ws2
-  - name: emptyDirWorkspace
+    - name: source
+      workspace: source
+      subPath: $(tasks.checkout.results.dir)
 `)}
-
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
   name: test-pipeline-run
@@ -3852,384 +4332,264 @@ spec:
   pipelineRef:
     name: test-pipeline
   workspaces:
-  - name: ws1
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
-  - name: ws2
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim2
-  - emptyDir: {}
-    name: emptyDirWorkspace
+  - name: source
+    emptyDir: {}
+`)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("checkout", "foo")},
+		{ObjectMeta: baseObjectMeta("build", "foo"), Spec: v1beta1.TaskSpec{
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+		}},
+	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-checkout", "foo", "test-pipeline-run", "test-pipeline", "checkout", true),
+		`
+spec:
+  taskRef:
+    name: checkout
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: dir
+    value: "../other-run"
 `)}
-	ts := []*v1beta1.Task{simpleHelloWorldTask}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
-
-	// Check that the expected StatefulSet was created
-	stsNames := make([]string, 0)
-	for _, a := range clients.Kube.Actions() {
-		if ca, ok := a.(ktesting.CreateAction); ok {
-			obj := ca.GetObject()
-			if sts, ok := obj.(*appsv1.StatefulSet); ok {
-				stsNames = append(stsNames, sts.Name)
-			}
-		}
-	}
-
-	if len(stsNames) != 2 {
-		t.Fatalf("expected one StatefulSet created. %d was created", len(stsNames))
-	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", nil, true)
 
-	expectedAffinityAssistantName1 := getAffinityAssistantName(workspaceName, pipelineRunName)
-	expectedAffinityAssistantName2 := getAffinityAssistantName(workspaceName2, pipelineRunName)
-	expectedAffinityAssistantStsNames := make(map[string]bool)
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName1] = true
-	expectedAffinityAssistantStsNames[expectedAffinityAssistantName2] = true
-	for _, stsName := range stsNames {
-		_, found := expectedAffinityAssistantStsNames[stsName]
-		if !found {
-			t.Errorf("unexpected StatefulSet created, named %s", stsName)
-		}
+	if c := pipelineRun.Status.GetCondition(apis.ConditionSucceeded); c == nil || !c.IsFalse() || c.Reason != ReasonInvalidWorkspaceSubPath {
+		t.Errorf("expected the PipelineRun to fail with the %s reason, got %v", ReasonInvalidWorkspaceSubPath, c)
 	}
-
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=build,tekton.dev/pipelineRun=test-pipeline-run",
+	})
 	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		t.Fatalf("Failure to list TaskRun's %s", err)
 	}
-
-	if len(taskRuns.Items) != 3 {
-		t.Errorf("expected two TaskRuns created. %d was created", len(taskRuns.Items))
+	if len(actual.Items) != 0 {
+		t.Errorf("Expected no TaskRun for the build task, got %d", len(actual.Items))
 	}
+}
 
-	taskRunsWithPropagatedAffinityAssistantName := 0
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			propagatedAffinityAssistantName := tr.Annotations["pipeline.tekton.dev/affinity-assistant"]
-			if ws.PersistentVolumeClaim != nil {
-				if propagatedAffinityAssistantName != expectedAffinityAssistantName1 && propagatedAffinityAssistantName != expectedAffinityAssistantName2 {
-					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s or %s, got %s", expectedAffinityAssistantName1, expectedAffinityAssistantName2, propagatedAffinityAssistantName)
-				}
-				taskRunsWithPropagatedAffinityAssistantName++
-			}
-
-			if ws.PersistentVolumeClaim == nil {
-				if propagatedAffinityAssistantName != "" {
-					t.Fatalf("found taskRun workspace that is not PVC workspace, but with unexpected AffinityAssistantAnnotation; expected NO AffinityAssistantAnnotation, got %s", propagatedAffinityAssistantName)
-				}
-			}
-		}
-	}
-
-	if taskRunsWithPropagatedAffinityAssistantName != 2 {
-		t.Errorf("expected only one of two TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
-	}
-
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
-	}
-}
-
-// TestReconcileWithVolumeClaimTemplateWorkspace tests that given a pipeline with volumeClaimTemplate workspace,
-// a PVC is created and that the workspace appears as a PersistentVolumeClaim workspace for TaskRuns.
-func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
-	pipelineRunName := "test-pipeline-run"
+func TestReconcileWithOnError(t *testing.T) {
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: hello-world-1
+  - name: lint
+    onError: continue
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws1
-  - name: hello-world-2
+      name: lint
+  - name: build
+    runAfter:
+    - lint
     taskRef:
-      name: hello-world
-  workspaces:
-  - name: ws1
+      name: build
+  - name: publish
+    params:
+    - name: report
+      value: $(tasks.lint.results.report)
+    taskRef:
+      name: publish
 `)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("lint", "foo")},
+		{ObjectMeta: baseObjectMeta("build", "foo")},
+		{ObjectMeta: baseObjectMeta("publish", "foo"), Spec: v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{{Name: "report", Type: v1beta1.ParamTypeString}},
+		}},
+	}
+	lintTaskRun := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-lint", "foo", "test-pipeline-run", "test-pipeline", "lint", true),
+		`
+spec:
+  taskRef:
+    name: lint
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+`)
+	buildTaskRun := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-build", "foo", "test-pipeline-run", "test-pipeline", "build", true),
+		`
+spec:
+  taskRef:
+    name: build
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+`)
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name:   "publish",
+		Reason: v1beta1.MissingResultsSkip,
+	}}
 
-	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+	for _, tc := range []struct {
+		name           string
+		trs            []*v1beta1.TaskRun
+		wantEvents     []string
+		expectedReason v1beta1.PipelineRunReason
+	}{{
+		name: "tasks after the failed task run",
+		trs:  []*v1beta1.TaskRun{lintTaskRun},
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Running Tasks Completed: 1 \\(Failed: 1, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+		},
+		expectedReason: v1beta1.PipelineRunReasonRunning,
+	}, {
+		name: "pipeline completes with warnings",
+		trs:  []*v1beta1.TaskRun{lintTaskRun, buildTaskRun},
+		wantEvents: []string{
+			"Normal Started",
+			"Normal Succeeded Tasks Completed: 2 \\(Failed: 1, Cancelled 0\\), Skipped: 1",
+		},
+		expectedReason: v1beta1.PipelineRunReasonCompletedWithWarnings,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
   name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
-  workspaces:
-  - name: ws1
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
 `)}
-	ts := []*v1beta1.Task{simpleHelloWorldTask}
-
-	d := test.Data{
-		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-	}
-	prt := newPipelineRunTest(t, d)
-	defer prt.Cancel()
-
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
-
-	// Check that the expected PVC was created
-	pvcNames := make([]string, 0)
-	for _, a := range clients.Kube.Actions() {
-		if ca, ok := a.(ktesting.CreateAction); ok {
-			obj := ca.GetObject()
-			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
-				pvcNames = append(pvcNames, pvc.Name)
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    ps,
+				Tasks:        ts,
+				TaskRuns:     tc.trs,
+				ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
 			}
-		}
-	}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
 
-	if len(pvcNames) != 1 {
-		t.Errorf("expected one PVC created. %d was created", len(pvcNames))
-	}
+			pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", tc.wantEvents, false)
 
-	for _, pr := range prs {
-		for _, w := range pr.Spec.Workspaces {
-			expectedPVCName := volumeclaim.GetPersistentVolumeClaimName(&corev1.PersistentVolumeClaim{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: w.VolumeClaimTemplate.Name,
-				},
-			}, w, *kmeta.NewControllerRef(pr))
-			_, err := clients.Kube.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{})
+			actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: "tekton.dev/pipelineTask=build,tekton.dev/pipelineRun=test-pipeline-run",
+				Limit:         1,
+			})
 			if err != nil {
-				t.Fatalf("expected PVC %s to exist but instead got error when getting it: %v", expectedPVCName, err)
+				t.Fatalf("Failure to list TaskRun's %s", err)
 			}
-		}
-	}
-
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
-	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
-	}
-
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			if ws.VolumeClaimTemplate != nil {
-				t.Fatalf("found volumeClaimTemplate workspace. Did not expect to find any taskruns with volumeClaimTemplate workspaces")
+			if len(actual.Items) != 1 {
+				t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
 			}
-
-			if ws.PersistentVolumeClaim == nil {
-				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			if d := cmp.Diff(expectedSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
+				t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
 			}
-		}
-	}
-
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+			if reason := pipelineRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.expectedReason.String() {
+				t.Errorf("expected the PipelineRun reason to be %s but was %s", tc.expectedReason, reason)
+			}
+		})
 	}
 }
 
-// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
-// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
-func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
-	subPath1 := "customdirectory"
-	subPath2 := "otherdirecory"
-	pipelineRunWsSubPath := "mypath"
-	pipelineRunName := "test-pipeline-run"
+func TestReconcileWithFailAtEndFailurePolicy(t *testing.T) {
+	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: hello-world-1
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws1
-  - name: hello-world-2
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: otherdirecory
-      workspace: ws1
-  - name: hello-world-3
+  - name: lint
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws1
-  - name: hello-world-4
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws2
-  - name: hello-world-5
-    taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws2
-  - name: hello-world-6
+      name: lint
+  - name: unit
+    runAfter:
+    - lint
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      workspace: ws3
-  - name: hello-world-7
+      name: unit
+  - name: e2e
     taskRef:
-      name: hello-world
-    workspaces:
-    - name: taskWorkspaceName
-      subPath: customdirectory
-      workspace: ws3
-  workspaces:
-  - name: ws1
-  - name: ws2
-  - name: ws3
+      name: e2e
 `)}
-
-	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, fmt.Sprintf(`
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: %s
+  name: test-pipeline-run
   namespace: foo
 spec:
+  failurePolicy: FailAtEnd
   pipelineRef:
     name: test-pipeline
-  workspaces:
-  - name: ws1
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
-  - name: ws2
-    subPath: mypath
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
-  - name: ws3
-    subPath: $(context.pipelineRun.name)
-    volumeClaimTemplate:
-      metadata:
-        creationTimestamp: null
-        name: myclaim
-`, pipelineRunName))}
-	ts := []*v1beta1.Task{simpleHelloWorldTask}
+`)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("lint", "foo")},
+		{ObjectMeta: baseObjectMeta("unit", "foo")},
+		{ObjectMeta: baseObjectMeta("e2e", "foo")},
+	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-lint", "foo", "test-pipeline-run", "test-pipeline", "lint", true),
+		`
+spec:
+  taskRef:
+    name: lint
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+`)}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 \\(Failed: 1, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
 
-	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=e2e,tekton.dev/pipelineRun=test-pipeline-run",
+		Limit:         1,
+	})
 	if err != nil {
-		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
 	}
 
-	if len(taskRuns.Items) != 7 {
-		t.Fatalf("unexpected number of taskRuns found, expected 7, but found %d", len(taskRuns.Items))
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name:   "unit",
+		Reason: v1beta1.ParentTasksFailedSkip,
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, pipelineRun.Status.SkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
 	}
+}
 
-	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
-	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
-	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
-	hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath := false
-	hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath := false
-
-	for _, tr := range taskRuns.Items {
-		for _, ws := range tr.Spec.Workspaces {
-			if ws.PersistentVolumeClaim == nil {
-				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
-			}
-
-			if ws.SubPath == subPath1 {
-				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
-			}
-
-			if ws.SubPath == subPath2 {
-				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
-			}
-
-			if ws.SubPath == "" {
-				hasSeenWorkspaceWithEmptyPipelineTaskSubPath = true
-			}
-
-			if ws.SubPath == pipelineRunWsSubPath {
-				hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath = true
-			}
-
-			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunWsSubPath, subPath1) {
-				hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 = true
-			}
-
-			if ws.SubPath == pipelineRunName {
-				hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath = true
-			}
-			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunName, subPath1) {
-				hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath = true
-			}
-		}
-	}
-
-	if !hasSeenWorkspaceWithPipelineTaskSubPath1 {
-		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath1")
-	}
-
-	if !hasSeenWorkspaceWithPipelineTaskSubPath2 {
-		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath2")
-	}
-
-	if !hasSeenWorkspaceWithEmptyPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with a workspace using empty pipelineTask subPath")
-	}
-
-	if !hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with workspace using empty pipelineTask subPath and a subPath from pipelineRun")
-	}
-
-	if !hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 {
-		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun")
-	}
-
-	if !hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with workspace using empty pipelineTaks subPath and a subPath from pipelineRun using context variables")
-	}
-
-	if !hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath {
-		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun using context variables")
-	}
-
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
-	}
-}
-
-func TestReconcileWithTaskResults(t *testing.T) {
+func TestReconcileWithWhenExpressions(t *testing.T) {
+	//		(b)
+	//		/
+	//	(a) ———— (c) ———— (d)
+	//		\
+	//		(e) ———— (f)
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
@@ -4237,16 +4597,57 @@ metadata:
   namespace: foo
 spec:
   tasks:
+# a-task is skipped because its when expressions evaluate to false
   - name: a-task
     taskRef:
       name: a-task
+    when:
+    - input: foo
+      operator: in
+      values:
+      - bar
+# b-task is executed regardless of running after skipped a-task because when expressions are scoped to task
   - name: b-task
-    params:
-    - name: bParam
-      value: $(tasks.a-task.results.aResult)
+    runAfter:
+    - a-task
     taskRef:
       name: b-task
+# c-task is skipped because its when expressions evaluate to false (not because it's parent a-task is skipped)
+  - name: c-task
+    runAfter:
+    - a-task
+    taskRef:
+      name: c-task
+    when:
+    - input: foo
+      operator: in
+      values:
+      - bar
+# d-task is executed regardless of running after skipped parent c-task (and skipped grandparent a-task)
+# because when expressions are scoped to task
+  - name: d-task
+    runAfter:
+    - c-task
+    taskRef:
+      name: d-task
+# e-task is attempted regardless of running after skipped a-task because when expressions are scoped to task
+# but then get skipped because of missing result references from a-task
+  - name: e-task
+    taskRef:
+      name: e-task
+    when:
+    - input: $(tasks.a-task.results.aResult)
+      operator: in
+      values:
+      - aResultValue
+# f-task is skipped because its parent task e-task is skipped because of missing result reference from a-task
+  - name: f-task
+    runAfter:
+    - e-task
+    taskRef:
+      name: f-task
 `)}
+	// initialize the pipelinerun with the skipped a-task
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
   name: test-pipeline-run-different-service-accs
@@ -4255,252 +4656,168 @@ spec:
   pipelineRef:
     name: test-pipeline
   serviceAccountName: test-sa-0
+status:
+  skippedTasks:
+  - name: a-task
+    whenExpressions:
+    - input: foo
+      operator: in
+      values:
+      - bar
 `)}
+	// initialize the tasks used in the pipeline
 	ts := []*v1beta1.Task{
 		parse.MustParseV1beta1Task(t, `
 metadata:
   name: a-task
   namespace: foo
-spec: {}
-`),
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: b-task
-  namespace: foo
 spec:
-  params:
-  - name: bParam
-    type: string
+  results:
+  - description: a result
+    name: aResult
 `),
+		{ObjectMeta: baseObjectMeta("b-task", "foo")},
+		{ObjectMeta: baseObjectMeta("c-task", "foo")},
+		{ObjectMeta: baseObjectMeta("d-task", "foo")},
+		{ObjectMeta: baseObjectMeta("e-task", "foo")},
+		{ObjectMeta: baseObjectMeta("f-task", "foo")},
 	}
-	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task", true),
-		`
-spec:
-  serviceAccountName: test-sa
-  taskRef:
-    name: hello-world
-  timeout: 1h0m0s
-status:
-  conditions:
-  - lastTransitionTime: null
-    status: "True"
-    type: Succeeded
-  taskResults:
-  - name: aResult
-    value: aResultValue
-`)}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
-		TaskRuns:     trs,
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	_, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 0 \\(Failed: 0, Cancelled 0\\), Incomplete: 2, Skipped: 4",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
 
-	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task"
-	expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-b-task", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline", "b-task", false),
-		`
+	taskRunExists := func(taskName string, taskRunName string) {
+		expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta(taskRunName, "foo", "test-pipeline-run-different-service-accs",
+				"test-pipeline", taskName, false),
+			fmt.Sprintf(`
 spec:
-  params:
-  - name: bParam
-    value: aResultValue
   serviceAccountName: test-sa-0
   taskRef:
-    name: b-task
+    name: %s
     kind: Task
-`)
-	// Check that the expected TaskRun was created
-	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
-		Limit:         1,
-	})
+`, taskName))
 
-	if err != nil {
-		t.Fatalf("Failure to list TaskRun's %s", err)
-	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
-	}
-	actualTaskRun := actual.Items[0]
-	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+		actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", taskName),
+			Limit:         1,
+		})
+
+		if err != nil {
+			t.Fatalf("Failure to list TaskRuns %s", err)
+		}
+		if len(actual.Items) != 1 {
+			t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
+		}
+		actualTaskRun := actual.Items[0]
+		if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
+			t.Errorf("expected to see TaskRun %v created. Diff %s", taskRunName, diff.PrintWantGot(d))
+		}
 	}
-}
 
-func TestReconcileWithTaskResultsEmbeddedNoneStarted(t *testing.T) {
-	names.TestingSeed()
-	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
-metadata:
-  name: test-pipeline-run-different-service-accs
-  namespace: foo
-spec:
-  params:
-  - name: foo
-    value: bar
-  pipelineSpec:
-    params:
-    - name: foo
-      type: string
-    tasks:
-    - name: a-task
-      taskRef:
-        name: a-task
-    - name: b-task
-      params:
-      - name: bParam
-        value: $(params.foo)/baz@$(tasks.a-task.results.A_RESULT)
-      taskRef:
-        name: b-task
-  serviceAccountName: test-sa-0
-`)}
-	ts := []*v1beta1.Task{
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: a-task
-  namespace: foo
-spec:
-  results:
-  - name: A_RESULT
-`),
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: b-task
-  namespace: foo
-spec:
-  params:
-  - name: bParam
-    type: string
-`),
-	}
-
-	d := test.Data{
-		PipelineRuns: prs,
-		Tasks:        ts,
-	}
-	prt := newPipelineRunTest(t, d)
-	defer prt.Cancel()
-
-	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+	taskRunExists("b-task", "test-pipeline-run-different-service-accs-b-task")
+	taskRunExists("d-task", "test-pipeline-run-different-service-accs-d-task")
 
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	actualSkippedTasks := pipelineRun.Status.SkippedTasks
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		// its when expressions evaluate to false
+		Name:   "a-task",
+		Reason: v1beta1.WhenExpressionsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "foo",
+			Operator: "in",
+			Values:   []string{"bar"},
+		}},
+	}, {
+		// its when expressions evaluate to false
+		Name:   "c-task",
+		Reason: v1beta1.WhenExpressionsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "foo",
+			Operator: "in",
+			Values:   []string{"bar"},
+		}},
+	}, {
+		// was attempted, but has missing results references
+		Name:   "e-task",
+		Reason: v1beta1.MissingResultsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "$(tasks.a-task.results.aResult)",
+			Operator: "in",
+			Values:   []string{"aResultValue"},
+		}},
+	}, {
+		Name:   "f-task",
+		Reason: v1beta1.ParentTasksSkip,
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
 	}
 
-	// Since b-task is dependent on a-task, via the results, only a-task should run
-	expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task", "foo",
-			"test-pipeline-run-different-service-accs", "test-pipeline-run-different-service-accs", "a-task", false),
-		`
-spec:
-  serviceAccountName: test-sa-0
-  taskRef:
-    kind: Task
-    name: a-task
-`)
-	// Check that the expected TaskRun was created (only)
-	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
-	if err != nil {
-		t.Fatalf("Failure to list TaskRun's %s", err)
-	}
-	if len(actual.Items) != 1 {
-		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
-	}
-	actualTaskRun := actual.Items[0]
-	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	// confirm that there are no taskruns created for the skipped tasks
+	skippedTasks := []string{"a-task", "c-task", "e-task", "f-task"}
+	for _, skippedTask := range skippedTasks {
+		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
+		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+			Limit:         1,
+		})
+		if err != nil {
+			t.Fatalf("Failure to list TaskRun's %s", err)
+		}
+		if len(actualSkippedTask.Items) != 0 {
+			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
+		}
 	}
 }
 
-func TestReconcileWithFinallyResults(t *testing.T) {
+func TestReconcileWithWhenExpressionsWithResultRefs(t *testing.T) {
 	names.TestingSeed()
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
-  results:
-    - description: pipeline result
-      name: result
-      value: $(finally.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(finally.b-task.results.b-Result)
   tasks:
-    - name: c-task
-      taskRef:
-        name: c-task
-  finally:
-    - name: a-task
-      taskRef:
-        name: a-task
-    - name: b-task
-      taskRef:
-        apiVersion: example.dev/v0
-        kind: Example
-        name: b-task
-`)}
-	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-a", "foo",
-			"test-pipeline-run-finally-results", "test-pipeline", "a-task", true),
-		`
-spec:
-  taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  taskResults:
-  - name: a-Result
-    value: aResultValue
-`), mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-c", "foo",
-			"test-pipeline-run-finally-results", "test-pipeline", "c-task", true),
-		`
-spec:
-  taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-`)}
-	crs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-b", "foo",
-			"test-pipeline-run-finally-results", "test-pipeline", "b-task", true),
-		`
-spec:
-  customRef:
-    apiVersion: example.dev/v0
-    kind: Example
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  results:
-  - name: b-Result
-    value: bResultValue
+# a-task is executed and produces a result aResult with value aResultValue
+  - name: a-task
+    taskRef:
+      name: a-task
+# b-task is skipped because it has when expressions, with result reference to a-task, that evaluate to false
+  - name: b-task
+    taskRef:
+      name: b-task
+    when:
+    - input: $(tasks.a-task.results.aResult)
+      operator: in
+      values:
+      - notResultValue
+# c-task is executed regardless of running after skipped b-task because when expressions are scoped to task
+  - name: c-task
+    runAfter:
+    - b-task
+    taskRef:
+      name: c-task
 `)}
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-finally-results
+  name: test-pipeline-run-different-service-accs
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
-status:
-  conditions:
-  - status: "Unknown"
-    type: Succeeded
-    reason: Succeeded
+  serviceAccountName: test-sa-0
 `)}
 	ts := []*v1beta1.Task{
 		parse.MustParseV1beta1Task(t, `
@@ -4509,649 +4826,1856 @@ metadata:
   namespace: foo
 spec:
   results:
-  - name: a-Result
-`),
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: c-task
-  namespace: foo
-spec:
-  steps:
-  - image: ubuntu
-    script: |
-      #!/usr/bin/env bash
-      echo "Hello from bash!"
-
+  - description: a result
+    name: aResult
 `),
+		{ObjectMeta: baseObjectMeta("b-task", "foo")},
+		{ObjectMeta: baseObjectMeta("c-task", "foo")},
 	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task",
+			true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+  timeout: 1h0m0s
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: aResult
+    value: aResultValue
+`)}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
 		TaskRuns:     trs,
-		CustomRuns:   crs,
-		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
-	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-finally-results", []string{}, false)
 
-	expectedPrStatus := parse.MustParseV1beta1PipelineRun(t, `
-metadata:
-  name: test-pipeline-run-finally-results
-  namespace: foo
-  labels:
-    tekton.dev/pipeline: test-pipeline
-  annotations: {}
-spec:
-  pipelineRef:
-    name: test-pipeline
-status:
-  pipelineSpec:
-    results:
-    - description: pipeline result
-      name: result
-      value: $(finally.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(finally.b-task.results.b-Result)
-    tasks:
-    - name: c-task
-      taskRef:
-        name: c-task
-        kind: Task
-    finally:
-    - name: a-task
-      taskRef:
-        name: a-task
-        kind: Task
-    - name: b-task
-      taskRef:
-        name: b-task
-        apiVersion: example.dev/v0
-        kind: Example
-  conditions:
-  - status: "True"
-    type: Succeeded
-    reason: Succeeded
-    message: "Tasks Completed: 3 (Failed: 0, Cancelled 0), Skipped: 0"
-  pipelineResults:
-  - name: result
-    value: aResultValue
-  - name: custom-result
-    value: bResultValue
-  childReferences:
-  - apiVersion: tekton.dev/v1beta1
-    kind: TaskRun
-    name: test-pipeline-run-finally-results-task-run-c
-    pipelineTaskName: c-task
-  - apiVersion: tekton.dev/v1beta1
-    kind: TaskRun
-    name: test-pipeline-run-finally-results-task-run-a
-    pipelineTaskName: a-task
-  - apiVersion: tekton.dev/v1beta1
-    kind: CustomRun
-    name: test-pipeline-run-finally-results-task-run-b
-    pipelineTaskName: b-task
-  provenance:
-    featureFlags:
-      RunningInEnvWithInjectedSidecars: true
-      EnableAPIFields: "stable"
-      AwaitSidecarReadiness: true
-      VerificationNoMatchPolicy: "ignore"
-      EnableProvenanceInStatus: true
-      ResultExtractionMethod: "termination-message"
-      MaxResultSize: 4096
-`)
+	wantEvents := []string{
+		"Normal Started",
+		"Normal Running Tasks Completed: 1 \\(Failed: 0, Cancelled 0\\), Incomplete: 1, Skipped: 1",
+	}
+	pipelineRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", wantEvents, false)
 
-	expectedPr := expectedPrStatus
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=c-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
+		Limit:         1,
+	})
 
-	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime, cmpopts.EquateEmpty()); d != "" {
-		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	if err != nil {
+		t.Fatalf("Failure to list TaskRuns %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRun got %d", len(actual.Items))
+	}
+
+	actualSkippedTasks := pipelineRun.Status.SkippedTasks
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		// its when expressions evaluate to false
+		Name:   "b-task",
+		Reason: v1beta1.WhenExpressionsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "aResultValue",
+			Operator: "in",
+			Values:   []string{"notResultValue"},
+		}},
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, actualSkippedTasks); d != "" {
+		t.Errorf("expected to find Skipped Tasks %v. Diff %s", expectedSkippedTasks, diff.PrintWantGot(d))
+	}
+
+	// confirm that there are no taskruns created for the skipped tasks
+	skippedTasks := []string{"b-task"}
+	for _, skippedTask := range skippedTasks {
+		labelSelector := fmt.Sprintf("tekton.dev/pipelineTask=%s,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs", skippedTask)
+		actualSkippedTask, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+			Limit:         1,
+		})
+		if err != nil {
+			t.Fatalf("Failure to list TaskRun's %s", err)
+		}
+		if len(actualSkippedTask.Items) != 0 {
+			t.Fatalf("Expected 0 TaskRuns got %d", len(actualSkippedTask.Items))
+		}
 	}
 }
 
-func TestReconcileWithPipelineResults(t *testing.T) {
-	names.TestingSeed()
+// TestReconcileWithAffinityAssistantStatefulSet tests that given a pipelineRun with workspaces,
+// an Affinity Assistant StatefulSet is created for each PVC workspace and
+// that the Affinity Assistant names is propagated to TaskRuns.
+func TestReconcileWithAffinityAssistantStatefulSet(t *testing.T) {
+	workspaceName := "ws1"
+	workspaceName2 := "ws2"
+	pipelineRunName := "test-pipeline-run"
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
-  results:
-    - description: pipeline result
-      name: result
-      value: $(tasks.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(tasks.b-task.results.b-Result)
   tasks:
-  - name: b-task
+  - name: hello-world-1
     taskRef:
-      apiVersion: example.dev/v0
-      kind: Example
-      name: b-task
-  - name: a-task
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-2
     taskRef:
-      name: a-task
-`)}
-	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-results-task-run-a", "foo",
-			"test-pipeline-run-results", "test-pipeline", "a-task", true),
-		`
-spec:
-  taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  taskResults:
-  - name: a-Result
-    value: aResultValue
-`)}
-	rs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-results-task-run-b", "foo",
-			"test-pipeline-run-results", "test-pipeline", "b-task", true),
-		`
-spec:
-  customRef:
-    apiVersion: example.dev/v0
-    kind: Example
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  results:
-  - name: b-Result
-    value: bResultValue
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws2
+  - name: hello-world-3
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: emptyDirWorkspace
+  workspaces:
+  - name: ws1
+  - name: ws2
+  - name: emptyDirWorkspace
 `)}
+
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-results
+  name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
-status:
-  conditions:
-  - status: "Unknown"
-    type: Succeeded
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+  - name: ws2
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim2
+  - emptyDir: {}
+    name: emptyDirWorkspace
 `)}
-	ts := []*v1beta1.Task{
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: a-task
-  namespace: foo
-spec:
-  results:
-  - name: a-Result
-`),
-	}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
-		TaskRuns:     trs,
-		CustomRuns:   rs,
-		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
 	}
-
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
-	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-results", []string{}, false)
-
-	expectedPrStatus := parse.MustParseV1beta1PipelineRun(t, `
-metadata:
-  name: test-pipeline-run-results
-  namespace: foo
-  labels:
-    tekton.dev/pipeline: test-pipeline
-  annotations: {}
-spec:
-  pipelineRef:
-    name: test-pipeline
-status:
-  pipelineSpec:
-    results:
-    - description: pipeline result
-      name: result
-      value: $(tasks.a-task.results.a-Result)
-    - description: custom task pipeline result
-      name: custom-result
-      value: $(tasks.b-task.results.b-Result)
-    tasks:
-    - name: b-task
-      taskRef:
-        name: b-task
-        apiVersion: example.dev/v0
-        kind: Example
-    - name: a-task
-      taskRef:
-        name: a-task
-        kind: Task
-  conditions:
-  - status: "True"
-    type: Succeeded
-    reason: Succeeded
-    message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0"
-  pipelineResults:
-  - name: result
-    value: aResultValue
-  - name: custom-result
-    value: bResultValue
-  childReferences:
-  - apiVersion: tekton.dev/v1beta1
-    kind: CustomRun
-    name: test-pipeline-run-results-task-run-b
-    pipelineTaskName: b-task
-  - apiVersion: tekton.dev/v1beta1
-    kind: TaskRun
-    name: test-pipeline-run-results-task-run-a
-    pipelineTaskName: a-task
-  provenance:
-    featureFlags:
-      RunningInEnvWithInjectedSidecars: true
-      EnableAPIFields: "stable"
-      AwaitSidecarReadiness: true
-      VerificationNoMatchPolicy: "ignore"
-      EnableProvenanceInStatus: true
-      ResultExtractionMethod: "termination-message"
-      MaxResultSize: 4096
-`)
-
-	expectedPr := expectedPrStatus
 
-	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime, cmpopts.EquateEmpty()); d != "" {
-		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
-	}
-}
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
 
-func TestReconcileWithPipelineResults_OnFailedPipelineRun(t *testing.T) {
-	names.TestingSeed()
+	// Check that the expected StatefulSet was created
+	stsNames := make([]string, 0)
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			obj := ca.GetObject()
+			if sts, ok := obj.(*appsv1.StatefulSet); ok {
+				stsNames = append(stsNames, sts.Name)
+			}
+		}
+	}
+
+	if len(stsNames) != 2 {
+		t.Fatalf("expected one StatefulSet created. %d was created", len(stsNames))
+	}
+
+	expectedAffinityAssistantName1 := getAffinityAssistantName(workspaceName, pipelineRunName)
+	expectedAffinityAssistantName2 := getAffinityAssistantName(workspaceName2, pipelineRunName)
+	expectedAffinityAssistantStsNames := make(map[string]bool)
+	expectedAffinityAssistantStsNames[expectedAffinityAssistantName1] = true
+	expectedAffinityAssistantStsNames[expectedAffinityAssistantName2] = true
+	for _, stsName := range stsNames {
+		_, found := expectedAffinityAssistantStsNames[stsName]
+		if !found {
+			t.Errorf("unexpected StatefulSet created, named %s", stsName)
+		}
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	if len(taskRuns.Items) != 3 {
+		t.Errorf("expected two TaskRuns created. %d was created", len(taskRuns.Items))
+	}
+
+	taskRunsWithPropagatedAffinityAssistantName := 0
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			propagatedAffinityAssistantName := tr.Annotations["pipeline.tekton.dev/affinity-assistant"]
+			if ws.PersistentVolumeClaim != nil {
+				if propagatedAffinityAssistantName != expectedAffinityAssistantName1 && propagatedAffinityAssistantName != expectedAffinityAssistantName2 {
+					t.Fatalf("found taskRun with PVC workspace, but with unexpected AffinityAssistantAnnotation value; expected %s or %s, got %s", expectedAffinityAssistantName1, expectedAffinityAssistantName2, propagatedAffinityAssistantName)
+				}
+				taskRunsWithPropagatedAffinityAssistantName++
+			}
+
+			if ws.PersistentVolumeClaim == nil {
+				if propagatedAffinityAssistantName != "" {
+					t.Fatalf("found taskRun workspace that is not PVC workspace, but with unexpected AffinityAssistantAnnotation; expected NO AffinityAssistantAnnotation, got %s", propagatedAffinityAssistantName)
+				}
+			}
+		}
+	}
+
+	if taskRunsWithPropagatedAffinityAssistantName != 2 {
+		t.Errorf("expected only one of two TaskRuns to have Affinity Assistant affinity. %d was detected", taskRunsWithPropagatedAffinityAssistantName)
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// TestReconcileWithVolumeClaimTemplateWorkspace tests that given a pipeline with volumeClaimTemplate workspace,
+// a PVC is created and that the workspace appears as a PersistentVolumeClaim workspace for TaskRuns.
+func TestReconcileWithVolumeClaimTemplateWorkspace(t *testing.T) {
+	pipelineRunName := "test-pipeline-run"
 	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
-  results:
-  - description: pipeline result
-    name: result
-    value: $(tasks.a-task.results.aResult)
   tasks:
-  - name: a-task
+  - name: hello-world-1
     taskRef:
-      name: a-task
-  - name: b-task
-    params:
-    - name: bParam
-      value: $(tasks.a-task.results.aResult)
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-2
     taskRef:
-      name: b-task
-`)}
-	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-failed-pr-with-task-results-a-task", "foo",
-			"test-failed-pr-with-task-results", "test-pipeline", "a-task", true),
-		`
-spec:
-  serviceAccountName: test-sa
-  taskRef:
-    name: hello-world
-  timeout: 1h0m0s
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-  taskResults:
-  - name: aResult
-    value: aResultValue
-`), mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-failed-pr-with-task-results-b-task", "foo",
-			"test-failed-pr-with-task-results", "test-pipeline", "b-task", true),
-		`
-spec:
-  serviceAccountName: test-sa
-  taskRef:
-     name: hello-world
-  timeout: 1h0m0s
-status:
-  conditions:
-  - status: "False"
-    type: Succeeded
-  taskResults:
-  - name: bResult
-    value: bResultValue
+      name: hello-world
+  workspaces:
+  - name: ws1
 `)}
+
 	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-failed-pr-with-task-results
-  namespace: foo
-spec:
-  pipelineRef:
-    name: test-pipeline
-  serviceAccountName: test-sa-0
-  timeouts:
-    pipeline: "0"
-status:
-  conditions:
-  - message: Message
-    reason: Running
-    status: "Unknown"
-    type: Succeeded
-  startTime: "2021-12-31T00:00:00Z"
-`)}
-	ts := []*v1beta1.Task{
-		{ObjectMeta: baseObjectMeta("a-task", "foo")},
-		parse.MustParseV1beta1Task(t, `
-metadata:
-  name: b-task
-  namespace: foo
-spec:
-  params:
-  - name: bParam
-    type: string
-`),
-	}
-	wantPrs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
-metadata:
-  name: test-failed-pr-with-task-results
+  name: test-pipeline-run
   namespace: foo
 spec:
   pipelineRef:
     name: test-pipeline
-  serviceAccountName: test-sa-0
-  timeouts:
-    pipeline: "0"
-status:
-  conditions:
-  - message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"
-    reason: Failed
-    status: "False"
-    type: Succeeded
-  pipelineResults:
-    - name: result
-      value: aResultValue
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
 `)}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
 
 	d := test.Data{
 		PipelineRuns: prs,
 		Pipelines:    ps,
 		Tasks:        ts,
-		TaskRuns:     trs,
-		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	reconciledRun, _ := prt.reconcileRun("foo", "test-failed-pr-with-task-results", []string{}, false)
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
 
-	if d := cmp.Diff(reconciledRun.Status.Conditions, wantPrs[0].Status.Conditions, ignoreResourceVersion, ignoreLastTransitionTime); d != "" {
-		t.Errorf("expected to see pipeline run marked as failed. Diff %s", diff.PrintWantGot(d))
+	// Check that the expected PVC was created
+	pvcNames := make([]string, 0)
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			obj := ca.GetObject()
+			if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+				pvcNames = append(pvcNames, pvc.Name)
+			}
+		}
 	}
-	if d := cmp.Diff(reconciledRun.Status.PipelineResults, wantPrs[0].Status.PipelineResults, ignoreResourceVersion, ignoreLastTransitionTime); d != "" {
-		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+
+	if len(pvcNames) != 1 {
+		t.Errorf("expected one PVC created. %d was created", len(pvcNames))
 	}
-}
 
-func Test_storePipelineSpecAndRefSource(t *testing.T) {
-	pr := parse.MustParseV1beta1PipelineRun(t, `
-metadata:
-  name: test-pipeline-run-success
-  labels:
-    lbl: value
-  annotations:
-    io.annotation: value
-`)
-	refSource := &v1beta1.RefSource{
-		URI: "abc.com",
-		Digest: map[string]string{
-			"sha1": "a123",
-		},
-		EntryPoint: "foo/bar",
+	for _, pr := range prs {
+		for _, w := range pr.Spec.Workspaces {
+			expectedPVCName := volumeclaim.GetPersistentVolumeClaimName(&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: w.VolumeClaimTemplate.Name,
+				},
+			}, w, *kmeta.NewControllerRef(pr))
+			_, err := clients.Kube.CoreV1().PersistentVolumeClaims(pr.Namespace).Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("expected PVC %s to exist but instead got error when getting it: %v", expectedPVCName, err)
+			}
+		}
 	}
 
-	ps := v1beta1.PipelineSpec{Description: "foo-pipeline"}
-	ps1 := v1beta1.PipelineSpec{Description: "bar-pipeline"}
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
 
-	want := pr.DeepCopy()
-	want.Status = v1beta1.PipelineRunStatus{
-		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
-			PipelineSpec: ps.DeepCopy(),
-			Provenance: &v1beta1.Provenance{
-				RefSource:    refSource.DeepCopy(),
-				ConfigSource: (*v1beta1.ConfigSource)(refSource.DeepCopy()),
-				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
-			},
-		},
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.VolumeClaimTemplate != nil {
+				t.Fatalf("found volumeClaimTemplate workspace. Did not expect to find any taskruns with volumeClaimTemplate workspaces")
+			}
+
+			if ws.PersistentVolumeClaim == nil {
+				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			}
+		}
 	}
-	want.ObjectMeta.Labels["tekton.dev/pipeline"] = pr.ObjectMeta.Name
 
-	type args struct {
-		pipelineSpec       *v1beta1.PipelineSpec
-		resolvedObjectMeta *resolutionutil.ResolvedObjectMeta
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
+}
 
-	var tests = []struct {
-		name            string
-		reconcile1Args  *args
-		reconcile2Args  *args
-		wantPipelineRun *v1beta1.PipelineRun
-	}{
-		{
-			name: "spec and source are available in the same reconcile",
-			reconcile1Args: &args{
-				pipelineSpec: &ps,
-				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
-					ObjectMeta: &pr.ObjectMeta,
-					RefSource:  refSource.DeepCopy(),
-				},
-			},
-			reconcile2Args: &args{
-				pipelineSpec:       &ps1,
-				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{},
-			},
-			wantPipelineRun: want,
-		},
-		{
-			name: "spec comes in the first reconcile and source comes in next reconcile",
-			reconcile1Args: &args{
-				pipelineSpec: &ps,
-				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
-					ObjectMeta: &pr.ObjectMeta,
-				},
-			},
-			reconcile2Args: &args{
-				pipelineSpec: &ps,
-				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
-					RefSource: refSource.DeepCopy(),
-				},
-			},
-			wantPipelineRun: want,
+// TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
+// multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
+func TestReconcileWithRunNamespace(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: integration-test
+    taskRef:
+      name: hello-world
+    params:
+    - name: namespace
+      value: $(context.pipelineRun.runNamespace)
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  uid: test-pipeline-run-uid
+  annotations:
+    pipeline.tekton.dev/run-namespace: "true"
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+`)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetRunNamespaceConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"enabled":        "true",
+			"resource-quota": "hard:\n  pods: \"10\"\n",
 		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   cms,
 	}
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			// mock first reconcile
-			if err := storePipelineSpecAndMergeMeta(context.Background(), pr, tc.reconcile1Args.pipelineSpec, tc.reconcile1Args.resolvedObjectMeta); err != nil {
-				t.Errorf("storePipelineSpec() error = %v", err)
-			}
-			if d := cmp.Diff(pr, tc.wantPipelineRun); d != "" {
-				t.Fatalf(diff.PrintWantGot(d))
-			}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
 
-			// mock second reconcile
-			if err := storePipelineSpecAndMergeMeta(context.Background(), pr, tc.reconcile2Args.pipelineSpec, tc.reconcile2Args.resolvedObjectMeta); err != nil {
-				t.Errorf("storePipelineSpec() error = %v", err)
-			}
-			if d := cmp.Diff(pr, tc.wantPipelineRun); d != "" {
-				t.Fatalf(diff.PrintWantGot(d))
-			}
-		})
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
 	}
-}
-
-func Test_storePipelineSpec_metadata(t *testing.T) {
-	pipelinerunlabels := map[string]string{"lbl1": "value1", "lbl2": "value2"}
-	pipelinerunannotations := map[string]string{"io.annotation.1": "value1", "io.annotation.2": "value2"}
-	pipelinelabels := map[string]string{"lbl1": "another value", "lbl2": "another value", "lbl3": "value3"}
-	pipelineannotations := map[string]string{"io.annotation.1": "another value", "io.annotation.2": "another value", "io.annotation.3": "value3", "kubectl.kubernetes.io/last-applied-configuration": "foo-is-bar"}
-	wantedlabels := map[string]string{"lbl1": "value1", "lbl2": "value2", "lbl3": "value3", pipeline.PipelineLabelKey: "bar"}
-	wantedannotations := map[string]string{"io.annotation.1": "value1", "io.annotation.2": "value2", "io.annotation.3": "value3"}
 
-	pr := &v1beta1.PipelineRun{
-		ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: pipelinerunlabels, Annotations: pipelinerunannotations},
+	runNamespace := runnamespace.GetName(prs[0])
+	ns, err := clients.Kube.CoreV1().Namespaces().Get(prt.TestAssets.Ctx, runNamespace, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected run namespace %s to exist but got error when getting it: %v", runNamespace, err)
 	}
-	meta := metav1.ObjectMeta{Name: "bar", Labels: pipelinelabels, Annotations: pipelineannotations}
-	if err := storePipelineSpecAndMergeMeta(context.Background(), pr, &v1beta1.PipelineSpec{}, &resolutionutil.ResolvedObjectMeta{
-		ObjectMeta: &meta,
-	}); err != nil {
-		t.Errorf("storePipelineSpecAndMergeMeta error = %v", err)
+	if d := cmp.Diff("test-pipeline-run-uid", ns.Annotations[runnamespace.OwnerAnnotationKey]); d != "" {
+		t.Errorf("unexpected run namespace owner %s", diff.PrintWantGot(d))
 	}
-	if d := cmp.Diff(pr.ObjectMeta.Labels, wantedlabels); d != "" {
-		t.Fatalf(diff.PrintWantGot(d))
+	rb, err := clients.Kube.RbacV1().RoleBindings(runNamespace).Get(prt.TestAssets.Ctx, "tekton-run", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected RoleBinding in run namespace but got error when getting it: %v", err)
 	}
-	if d := cmp.Diff(pr.ObjectMeta.Annotations, wantedannotations); d != "" {
-		t.Fatalf(diff.PrintWantGot(d))
+	wantSubjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "test-sa", Namespace: "foo"}}
+	if d := cmp.Diff(wantSubjects, rb.Subjects); d != "" {
+		t.Errorf("unexpected RoleBinding subjects %s", diff.PrintWantGot(d))
+	}
+	if _, err := clients.Kube.CoreV1().ResourceQuotas(runNamespace).Get(prt.TestAssets.Ctx, "tekton-run", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected ResourceQuota in run namespace but got error when getting it: %v", err)
 	}
-}
-
-func TestReconcileOutOfSyncPipelineRun(t *testing.T) {
-	// It may happen that a PipelineRun creates one or more TaskRuns during reconcile
-	// but it fails to sync the update on the status back. This test verifies that
-	// the reconciler is able to coverge back to a consistent state with the orphaned
-	// TaskRuns back in the PipelineRun status.
-	// For more details, see https://github.com/tektoncd/pipeline/issues/2558
-	ctx := context.Background()
 
-	namespace := "foo"
-	prOutOfSyncName := "test-pipeline-run-out-of-sync"
-	helloWorldTask := simpleHelloWorldTask
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 1 {
+		t.Fatalf("expected one TaskRun but got %d", len(taskRuns.Items))
+	}
+	if d := cmp.Diff(runNamespace, taskRuns.Items[0].Spec.Params[0].Value.StringVal); d != "" {
+		t.Errorf("unexpected run namespace param %s", diff.PrintWantGot(d))
+	}
+}
 
-	testPipeline := parse.MustParseV1beta1Pipeline(t, `
+func TestReconcileWithRunNamespaceDisabled(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
 metadata:
   name: test-pipeline
   namespace: foo
 spec:
   tasks:
-  - name: hello-world-1
-    taskRef:
-      name: hello-world
-  - name: hello-world-2
+  - name: integration-test
     taskRef:
       name: hello-world
-  - name: hello-world-5
-    taskRef:
-      apiVersion: example.dev/v0
-      kind: Example
-`)
-
-	// This taskrun is in the pipelinerun status. It completed successfully.
-	taskRunDone := mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-1", "foo", prOutOfSyncName, testPipeline.Name, "hello-world-1", false),
-		`
-spec:
-  taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: "True"
-    type: Succeeded
-`)
-
-	// This taskrun is *not* in the pipelinerun status. It's still running.
-	taskRunOrphaned := mustParseTaskRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-2", "foo", prOutOfSyncName, testPipeline.Name, "hello-world-2", false),
-		`
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+  annotations:
+    pipeline.tekton.dev/run-namespace: "true"
 spec:
-  taskRef:
-    name: hello-world
-status:
-  conditions:
-  - status: Unknown
-    type: Succeeded
-`)
+  pipelineRef:
+    name: test-pipeline
+`)}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
 
-	orphanedCustomRun := mustParseCustomRunWithObjectMeta(t,
-		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-5", "foo", prOutOfSyncName, testPipeline.Name,
-			"hello-world-5", true),
-		`
-spec:
-  customRef:
-    apiVersion: example.dev/v0
-    kind: Example
-status:
-  conditions:
-  - status: Unknown
-    type: Succeeded
-`)
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, true)
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != runnamespace.ReasonCouldntCreateRunNamespace {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", runnamespace.ReasonCouldntCreateRunNamespace, condition)
+	}
+	namespaces, err := clients.Kube.CoreV1().Namespaces().List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing namespaces: %v", err)
+	}
+	for _, ns := range namespaces.Items {
+		if ns.Name == runnamespace.GetName(prs[0]) {
+			t.Errorf("expected no run namespace but found %s", ns.Name)
+		}
+	}
+}
 
-	prOutOfSync := parse.MustParseV1beta1PipelineRun(t, `
+func TestReconcileDoneDeletesRunNamespace(t *testing.T) {
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
-  name: test-pipeline-run-out-of-sync
+  name: test-pipeline-run
   namespace: foo
+  uid: test-pipeline-run-uid
+  annotations:
+    pipeline.tekton.dev/run-namespace: "true"
 spec:
   pipelineRef:
     name: test-pipeline
-  serviceAccountName: test-sa
 status:
   conditions:
-  - status: Unknown
+  - reason: Succeeded
+    status: "True"
     type: Succeeded
-`)
-
-	prOutOfSync.Status.ChildReferences = []v1beta1.ChildStatusReference{
-		{
-			TypeMeta: runtime.TypeMeta{
-				APIVersion: v1beta1.SchemeGroupVersion.String(),
-				Kind:       taskRun,
-			},
-			Name:             taskRunDone.Name,
-			PipelineTaskName: "hello-world-1",
-		},
-	}
-
-	prs := []*v1beta1.PipelineRun{prOutOfSync}
-	ps := []*v1beta1.Pipeline{testPipeline}
-	ts := []*v1beta1.Task{helloWorldTask}
-	trs := []*v1beta1.TaskRun{taskRunDone, taskRunOrphaned}
-	customRuns := []*v1beta1.CustomRun{orphanedCustomRun}
-
-	cms := []*corev1.ConfigMap{newFeatureFlagsConfigMap()}
-
+`)}
+	runNamespace := runnamespace.GetName(prs[0])
 	d := test.Data{
 		PipelineRuns: prs,
-		Pipelines:    ps,
-		Tasks:        ts,
-		TaskRuns:     trs,
-		CustomRuns:   customRuns,
-		ConfigMaps:   cms,
+		Namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        runNamespace,
+				Annotations: map[string]string{runnamespace.OwnerAnnotationKey: "test-pipeline-run-uid"},
+			},
+		}},
 	}
 	prt := newPipelineRunTest(t, d)
 	defer prt.Cancel()
 
-	reconciledRun, clients := prt.reconcileRun(namespace, prOutOfSyncName, []string{}, false)
-	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prOutOfSyncName)
-	validateTaskRunsCount(t, taskRuns, 2)
-
-	// This PipelineRun should still be running and the status should reflect that
-	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
-		t.Errorf("Expected PipelineRun status to be running, but was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	_, clients := prt.reconcileRun("foo", "test-pipeline-run", []string{}, false)
+	if _, err := clients.Kube.CoreV1().Namespaces().Get(prt.TestAssets.Ctx, runNamespace, metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected run namespace %s to be deleted but got: %v", runNamespace, err)
 	}
+}
 
-	expectedTaskRunsStatus := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
-	expectedRunsStatus := make(map[string]*v1beta1.PipelineRunRunStatus)
-	// taskRunDone did not change
-	expectedTaskRunsStatus[taskRunDone.Name] = &v1beta1.PipelineRunTaskRunStatus{
-		PipelineTaskName: "hello-world-1",
-		Status: &v1beta1.TaskRunStatus{
-			Status: duckv1.Status{
-				Conditions: []apis.Condition{
-					{
-						Type:   apis.ConditionSucceeded,
-						Status: corev1.ConditionTrue,
-					},
+func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
+	subPath1 := "customdirectory"
+	subPath2 := "otherdirecory"
+	pipelineRunWsSubPath := "mypath"
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws1
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: otherdirecory
+      workspace: ws1
+  - name: hello-world-3
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-4
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws2
+  - name: hello-world-5
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws2
+  - name: hello-world-6
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws3
+  - name: hello-world-7
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      subPath: customdirectory
+      workspace: ws3
+  workspaces:
+  - name: ws1
+  - name: ws2
+  - name: ws3
+`)}
+
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+  - name: ws2
+    subPath: mypath
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+  - name: ws3
+    subPath: $(context.pipelineRun.name)
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: myclaim
+`, pipelineRunName))}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+
+	if len(taskRuns.Items) != 7 {
+		t.Fatalf("unexpected number of taskRuns found, expected 7, but found %d", len(taskRuns.Items))
+	}
+
+	hasSeenWorkspaceWithPipelineTaskSubPath1 := false
+	hasSeenWorkspaceWithPipelineTaskSubPath2 := false
+	hasSeenWorkspaceWithEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 := false
+	hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath := false
+	hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath := false
+
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.PersistentVolumeClaim == nil {
+				t.Fatalf("found taskRun workspace that is not PersistentVolumeClaim workspace. Did only expect PersistentVolumeClaims workspaces")
+			}
+
+			if ws.SubPath == subPath1 {
+				hasSeenWorkspaceWithPipelineTaskSubPath1 = true
+			}
+
+			if ws.SubPath == subPath2 {
+				hasSeenWorkspaceWithPipelineTaskSubPath2 = true
+			}
+
+			if ws.SubPath == "" {
+				hasSeenWorkspaceWithEmptyPipelineTaskSubPath = true
+			}
+
+			if ws.SubPath == pipelineRunWsSubPath {
+				hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath = true
+			}
+
+			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunWsSubPath, subPath1) {
+				hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 = true
+			}
+
+			if ws.SubPath == pipelineRunName {
+				hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath = true
+			}
+			if ws.SubPath == fmt.Sprintf("%s/%s", pipelineRunName, subPath1) {
+				hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath = true
+			}
+		}
+	}
+
+	if !hasSeenWorkspaceWithPipelineTaskSubPath1 {
+		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath1")
+	}
+
+	if !hasSeenWorkspaceWithPipelineTaskSubPath2 {
+		t.Fatalf("did not see a taskRun with a workspace using pipelineTask subPath2")
+	}
+
+	if !hasSeenWorkspaceWithEmptyPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with a workspace using empty pipelineTask subPath")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathAndEmptyPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with workspace using empty pipelineTask subPath and a subPath from pipelineRun")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathAndPipelineTaskSubPath1 {
+		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathContextAndEmptyPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with workspace using empty pipelineTaks subPath and a subPath from pipelineRun using context variables")
+	}
+
+	if !hasSeenWorkspaceWithRunSubPathContextAndPipelineTaskSubPath {
+		t.Fatalf("did not see a taskRun with workspace using pipelineTaks subPath1 and a subPath from pipelineRun using context variables")
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+func TestReconcileWithTaskResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    params:
+    - name: bParam
+      value: $(tasks.a-task.results.aResult)
+    taskRef:
+      name: b-task
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-different-service-accs
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa-0
+`)}
+	ts := []*v1beta1.Task{
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec: {}
+`),
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+`),
+	}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task-xxyyy", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline", "a-task", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+  timeout: 1h0m0s
+status:
+  conditions:
+  - lastTransitionTime: null
+    status: "True"
+    type: Succeeded
+  taskResults:
+  - name: aResult
+    value: aResultValue
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+
+	expectedTaskRunName := "test-pipeline-run-different-service-accs-b-task"
+	expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-b-task", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline", "b-task", false),
+		`
+spec:
+  params:
+  - name: bParam
+    value: aResultValue
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: b-task
+    kind: Task
+`)
+	// Check that the expected TaskRun was created
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=b-task,tekton.dev/pipelineRun=test-pipeline-run-different-service-accs",
+		Limit:         1,
+	})
+
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRunName, diff.PrintWantGot(d))
+	}
+
+	// Check that the provenance of the consumed result was recorded
+	wantConsumedResults := []v1beta1.ResultProvenance{{
+		PipelineTask: "b-task",
+		Result:       "tasks.a-task.results.aResult",
+		Run:          "test-pipeline-run-different-service-accs-a-task-xxyyy",
+		Digest:       "sha256:3c4a37c52e172fb4da0044ac7306a3018b9b8ab28c4d7730d065ea8c523a7de1",
+	}}
+	if d := cmp.Diff(wantConsumedResults, reconciledRun.Status.Provenance.ConsumedResults); d != "" {
+		t.Errorf("expected the consumed results to be recorded in the provenance %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithTaskResultsEmbeddedNoneStarted(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-different-service-accs
+  namespace: foo
+spec:
+  params:
+  - name: foo
+    value: bar
+  pipelineSpec:
+    params:
+    - name: foo
+      type: string
+    tasks:
+    - name: a-task
+      taskRef:
+        name: a-task
+    - name: b-task
+      params:
+      - name: bParam
+        value: $(params.foo)/baz@$(tasks.a-task.results.A_RESULT)
+      taskRef:
+        name: b-task
+  serviceAccountName: test-sa-0
+`)}
+	ts := []*v1beta1.Task{
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: A_RESULT
+`),
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+`),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Tasks:        ts,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-different-service-accs", []string{}, false)
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+
+	// Since b-task is dependent on a-task, via the results, only a-task should run
+	expectedTaskRun := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-different-service-accs-a-task", "foo",
+			"test-pipeline-run-different-service-accs", "test-pipeline-run-different-service-accs", "a-task", false),
+		`
+spec:
+  serviceAccountName: test-sa-0
+  taskRef:
+    kind: Task
+    name: a-task
+`)
+	// Check that the expected TaskRun was created (only)
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	actualTaskRun := actual.Items[0]
+	if d := cmp.Diff(expectedTaskRun, &actualTaskRun, ignoreResourceVersion, ignoreTypeMeta); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithFinallyResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+    - description: pipeline result
+      name: result
+      value: $(finally.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(finally.b-task.results.b-Result)
+  tasks:
+    - name: c-task
+      taskRef:
+        name: c-task
+  finally:
+    - name: a-task
+      taskRef:
+        name: a-task
+    - name: b-task
+      taskRef:
+        apiVersion: example.dev/v0
+        kind: Example
+        name: b-task
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-a", "foo",
+			"test-pipeline-run-finally-results", "test-pipeline", "a-task", true),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: a-Result
+    value: aResultValue
+`), mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-c", "foo",
+			"test-pipeline-run-finally-results", "test-pipeline", "c-task", true),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+`)}
+	crs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-finally-results-task-run-b", "foo",
+			"test-pipeline-run-finally-results", "test-pipeline", "b-task", true),
+		`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: b-Result
+    value: bResultValue
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-finally-results
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+    reason: Succeeded
+`)}
+	ts := []*v1beta1.Task{
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: a-Result
+`),
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: c-task
+  namespace: foo
+spec:
+  steps:
+  - image: ubuntu
+    script: |
+      #!/usr/bin/env bash
+      echo "Hello from bash!"
+
+`),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		CustomRuns:   crs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-finally-results", []string{}, false)
+
+	expectedPrStatus := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-finally-results
+  namespace: foo
+  labels:
+    tekton.dev/pipeline: test-pipeline
+  annotations: {}
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  pipelineSpec:
+    results:
+    - description: pipeline result
+      name: result
+      value: $(finally.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(finally.b-task.results.b-Result)
+    tasks:
+    - name: c-task
+      taskRef:
+        name: c-task
+        kind: Task
+    finally:
+    - name: a-task
+      taskRef:
+        name: a-task
+        kind: Task
+    - name: b-task
+      taskRef:
+        name: b-task
+        apiVersion: example.dev/v0
+        kind: Example
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+    message: "Tasks Completed: 3 (Failed: 0, Cancelled 0), Skipped: 0"
+  pipelineResults:
+  - name: result
+    value: aResultValue
+  - name: custom-result
+    value: bResultValue
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-finally-results-task-run-c
+    pipelineTaskName: c-task
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-finally-results-task-run-a
+    pipelineTaskName: a-task
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipeline-run-finally-results-task-run-b
+    pipelineTaskName: b-task
+  provenance:
+    featureFlags:
+      RunningInEnvWithInjectedSidecars: true
+      EnableAPIFields: "stable"
+      AwaitSidecarReadiness: true
+      VerificationNoMatchPolicy: "ignore"
+      EnableProvenanceInStatus: true
+      ResultExtractionMethod: "termination-message"
+      MaxResultSize: 4096
+`)
+
+	expectedPr := expectedPrStatus
+
+	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime, cmpopts.EquateEmpty()); d != "" {
+		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileWithPipelineResults(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+    - description: pipeline result
+      name: result
+      value: $(tasks.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(tasks.b-task.results.b-Result)
+  tasks:
+  - name: b-task
+    taskRef:
+      apiVersion: example.dev/v0
+      kind: Example
+      name: b-task
+  - name: a-task
+    taskRef:
+      name: a-task
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-results-task-run-a", "foo",
+			"test-pipeline-run-results", "test-pipeline", "a-task", true),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: a-Result
+    value: aResultValue
+`)}
+	rs := []*v1beta1.CustomRun{mustParseCustomRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-results-task-run-b", "foo",
+			"test-pipeline-run-results", "test-pipeline", "b-task", true),
+		`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  results:
+  - name: b-Result
+    value: bResultValue
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-results
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  conditions:
+  - status: "Unknown"
+    type: Succeeded
+`)}
+	ts := []*v1beta1.Task{
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: a-task
+  namespace: foo
+spec:
+  results:
+  - name: a-Result
+`),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		CustomRuns:   rs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	}
+
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+	reconciledRun, _ := prt.reconcileRun("foo", "test-pipeline-run-results", []string{}, false)
+
+	expectedPrStatus := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-results
+  namespace: foo
+  labels:
+    tekton.dev/pipeline: test-pipeline
+  annotations: {}
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  pipelineSpec:
+    results:
+    - description: pipeline result
+      name: result
+      value: $(tasks.a-task.results.a-Result)
+    - description: custom task pipeline result
+      name: custom-result
+      value: $(tasks.b-task.results.b-Result)
+    tasks:
+    - name: b-task
+      taskRef:
+        name: b-task
+        apiVersion: example.dev/v0
+        kind: Example
+    - name: a-task
+      taskRef:
+        name: a-task
+        kind: Task
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+    message: "Tasks Completed: 2 (Failed: 0, Cancelled 0), Skipped: 0"
+  pipelineResults:
+  - name: result
+    value: aResultValue
+  - name: custom-result
+    value: bResultValue
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: CustomRun
+    name: test-pipeline-run-results-task-run-b
+    pipelineTaskName: b-task
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-results-task-run-a
+    pipelineTaskName: a-task
+  provenance:
+    featureFlags:
+      RunningInEnvWithInjectedSidecars: true
+      EnableAPIFields: "stable"
+      AwaitSidecarReadiness: true
+      VerificationNoMatchPolicy: "ignore"
+      EnableProvenanceInStatus: true
+      ResultExtractionMethod: "termination-message"
+      MaxResultSize: 4096
+`)
+
+	expectedPr := expectedPrStatus
+
+	if d := cmp.Diff(expectedPr, reconciledRun, ignoreResourceVersion, ignoreLastTransitionTime, ignoreCompletionTime, ignoreStartTime, cmpopts.EquateEmpty()); d != "" {
+		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_ChildPipeline(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: parent-pipeline
+  namespace: foo
+spec:
+  params:
+  - name: revision
+    type: string
+  workspaces:
+  - name: source
+  results:
+  - name: digest
+    value: $(tasks.build.results.digest)
+  tasks:
+  - name: build
+    pipelineRef:
+      name: build-pipeline
+    params:
+    - name: revision
+      value: $(params.revision)
+    workspaces:
+    - name: src
+      workspace: source
+`)}
+	parent := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: parent
+  namespace: foo
+  uid: parent
+spec:
+  pipelineRef:
+    name: parent-pipeline
+  params:
+  - name: revision
+    value: main
+  workspaces:
+  - name: source
+    emptyDir: {}
+`)
+
+	t.Run("creates the child PipelineRun", func(t *testing.T) {
+		prt := newPipelineRunTest(t, test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{parent.DeepCopy()},
+			Pipelines:    ps,
+			ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+		})
+		defer prt.Cancel()
+		reconciledRun, clients := prt.reconcileRun("foo", "parent", []string{}, false)
+
+		child, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "parent-build", metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("Failed to get the child PipelineRun: %v", err)
+		}
+		expectedSpec := v1beta1.PipelineRunSpec{
+			PipelineRef:        &v1beta1.PipelineRef{Name: "build-pipeline"},
+			Params:             v1beta1.Params{{Name: "revision", Value: *v1beta1.NewStructuredValues("main")}},
+			Workspaces:         []v1beta1.WorkspaceBinding{{Name: "src", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			ServiceAccountName: "default",
+		}
+		if d := cmp.Diff(expectedSpec, child.Spec); d != "" {
+			t.Errorf("Unexpected child PipelineRun spec %s", diff.PrintWantGot(d))
+		}
+		if owner := metav1.GetControllerOf(child); owner == nil || owner.Name != "parent" || owner.Kind != "PipelineRun" {
+			t.Errorf("Expected the child PipelineRun to be controlled by its parent, got %v", owner)
+		}
+		if got := child.Labels[pipeline.PipelineTaskLabelKey]; got != "build" {
+			t.Errorf("Expected the child PipelineRun to be labeled with its pipeline task, got %q", got)
+		}
+		if got := child.Annotations[pipeline.PipelineAncestryAnnotationKey]; got != `["Pipeline/parent-pipeline"]` {
+			t.Errorf("Expected the child PipelineRun to be annotated with its ancestry, got %q", got)
+		}
+		expectedChildRefs := []v1beta1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+			Name:             "parent-build",
+			PipelineTaskName: "build",
+		}}
+		if d := cmp.Diff(expectedChildRefs, reconciledRun.Status.ChildReferences); d != "" {
+			t.Errorf("Unexpected child references %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("aggregates the status and the results of the child PipelineRun", func(t *testing.T) {
+		running := parent.DeepCopy()
+		running.Status.ChildReferences = []v1beta1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+			Name:             "parent-build",
+			PipelineTaskName: "build",
+		}}
+		child := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: parent-build
+  namespace: foo
+  labels:
+    tekton.dev/pipelineRun: parent
+    tekton.dev/pipelineTask: build
+  ownerReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: PipelineRun
+    name: parent
+    uid: parent
+    controller: true
+spec:
+  pipelineRef:
+    name: build-pipeline
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+    reason: Succeeded
+  pipelineResults:
+  - name: digest
+    value: sha256:abc
+`)
+		prt := newPipelineRunTest(t, test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{running, child},
+			Pipelines:    ps,
+			ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+		})
+		defer prt.Cancel()
+		reconciledRun, _ := prt.reconcileRun("foo", "parent", []string{}, false)
+
+		if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsTrue() {
+			t.Errorf("Expected the PipelineRun to succeed, got %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+		}
+		expectedResults := []v1beta1.PipelineRunResult{{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:abc")}}
+		if d := cmp.Diff(expectedResults, reconciledRun.Status.PipelineResults); d != "" {
+			t.Errorf("Unexpected pipeline results %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("fails on a self-referencing Pipeline", func(t *testing.T) {
+		recursive := parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: recursive-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: recurse
+    pipelineRef:
+      name: recursive-pipeline
+`)
+		pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: recursive
+  namespace: foo
+spec:
+  pipelineRef:
+    name: recursive-pipeline
+`)
+		prt := newPipelineRunTest(t, test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{pr},
+			Pipelines:    []*v1beta1.Pipeline{recursive},
+			ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+		})
+		defer prt.Cancel()
+		reconciledRun, clients := prt.reconcileRun("foo", "recursive", []string{}, true)
+
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonPipelineRecursion)
+		if _, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "recursive-recurse", metav1.GetOptions{}); !k8serrors.IsNotFound(err) {
+			t.Errorf("Expected no child PipelineRun to be created, got %v", err)
+		}
+	})
+
+	t.Run("fails when the child PipelineRuns are nested too deeply", func(t *testing.T) {
+		var ancestry []string
+		for i := 0; i < maxChildPipelineRunDepth; i++ {
+			ancestry = append(ancestry, "")
+		}
+		encoded, err := json.Marshal(ancestry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nested := parent.DeepCopy()
+		nested.Annotations = map[string]string{pipeline.PipelineAncestryAnnotationKey: string(encoded)}
+		prt := newPipelineRunTest(t, test.Data{
+			PipelineRuns: []*v1beta1.PipelineRun{nested},
+			Pipelines:    ps,
+			ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+		})
+		defer prt.Cancel()
+		reconciledRun, _ := prt.reconcileRun("foo", "parent", []string{}, true)
+
+		checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, ReasonPipelineRecursion)
+	})
+}
+
+func TestReconcileWithPipelineResults_OnFailedPipelineRun(t *testing.T) {
+	names.TestingSeed()
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  results:
+  - description: pipeline result
+    name: result
+    value: $(tasks.a-task.results.aResult)
+  tasks:
+  - name: a-task
+    taskRef:
+      name: a-task
+  - name: b-task
+    params:
+    - name: bParam
+      value: $(tasks.a-task.results.aResult)
+    taskRef:
+      name: b-task
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-failed-pr-with-task-results-a-task", "foo",
+			"test-failed-pr-with-task-results", "test-pipeline", "a-task", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: hello-world
+  timeout: 1h0m0s
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: aResult
+    value: aResultValue
+`), mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-failed-pr-with-task-results-b-task", "foo",
+			"test-failed-pr-with-task-results", "test-pipeline", "b-task", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+     name: hello-world
+  timeout: 1h0m0s
+status:
+  conditions:
+  - status: "False"
+    type: Succeeded
+  taskResults:
+  - name: bResult
+    value: bResultValue
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-failed-pr-with-task-results
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa-0
+  timeouts:
+    pipeline: "0"
+status:
+  conditions:
+  - message: Message
+    reason: Running
+    status: "Unknown"
+    type: Succeeded
+  startTime: "2021-12-31T00:00:00Z"
+`)}
+	ts := []*v1beta1.Task{
+		{ObjectMeta: baseObjectMeta("a-task", "foo")},
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: b-task
+  namespace: foo
+spec:
+  params:
+  - name: bParam
+    type: string
+`),
+	}
+	wantPrs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-failed-pr-with-task-results
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa-0
+  timeouts:
+    pipeline: "0"
+status:
+  conditions:
+  - message: "Tasks Completed: 2 (Failed: 1, Cancelled 0), Skipped: 0"
+    reason: Failed
+    status: "False"
+    type: Succeeded
+  pipelineResults:
+    - name: result
+      value: aResultValue
+`)}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		ConfigMaps:   []*corev1.ConfigMap{newFeatureFlagsConfigMap()},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, _ := prt.reconcileRun("foo", "test-failed-pr-with-task-results", []string{}, false)
+
+	if d := cmp.Diff(reconciledRun.Status.Conditions, wantPrs[0].Status.Conditions, ignoreResourceVersion, ignoreLastTransitionTime); d != "" {
+		t.Errorf("expected to see pipeline run marked as failed. Diff %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(reconciledRun.Status.PipelineResults, wantPrs[0].Status.PipelineResults, ignoreResourceVersion, ignoreLastTransitionTime); d != "" {
+		t.Errorf("expected to see pipeline run results created. Diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func Test_storePipelineSpecAndRefSource(t *testing.T) {
+	pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-success
+  labels:
+    lbl: value
+  annotations:
+    io.annotation: value
+`)
+	refSource := &v1beta1.RefSource{
+		URI: "abc.com",
+		Digest: map[string]string{
+			"sha1": "a123",
+		},
+		EntryPoint: "foo/bar",
+	}
+
+	ps := v1beta1.PipelineSpec{Description: "foo-pipeline"}
+	ps1 := v1beta1.PipelineSpec{Description: "bar-pipeline"}
+
+	want := pr.DeepCopy()
+	want.Status = v1beta1.PipelineRunStatus{
+		PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			PipelineSpec: ps.DeepCopy(),
+			Provenance: &v1beta1.Provenance{
+				RefSource:    refSource.DeepCopy(),
+				ConfigSource: (*v1beta1.ConfigSource)(refSource.DeepCopy()),
+				FeatureFlags: config.DefaultFeatureFlags.DeepCopy(),
+			},
+		},
+	}
+	want.ObjectMeta.Labels["tekton.dev/pipeline"] = pr.ObjectMeta.Name
+
+	type args struct {
+		pipelineSpec       *v1beta1.PipelineSpec
+		resolvedObjectMeta *resolutionutil.ResolvedObjectMeta
+	}
+
+	var tests = []struct {
+		name            string
+		reconcile1Args  *args
+		reconcile2Args  *args
+		wantPipelineRun *v1beta1.PipelineRun
+	}{
+		{
+			name: "spec and source are available in the same reconcile",
+			reconcile1Args: &args{
+				pipelineSpec: &ps,
+				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
+					ObjectMeta: &pr.ObjectMeta,
+					RefSource:  refSource.DeepCopy(),
+				},
+			},
+			reconcile2Args: &args{
+				pipelineSpec:       &ps1,
+				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{},
+			},
+			wantPipelineRun: want,
+		},
+		{
+			name: "spec comes in the first reconcile and source comes in next reconcile",
+			reconcile1Args: &args{
+				pipelineSpec: &ps,
+				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
+					ObjectMeta: &pr.ObjectMeta,
+				},
+			},
+			reconcile2Args: &args{
+				pipelineSpec: &ps,
+				resolvedObjectMeta: &resolutionutil.ResolvedObjectMeta{
+					RefSource: refSource.DeepCopy(),
+				},
+			},
+			wantPipelineRun: want,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// mock first reconcile
+			if err := storePipelineSpecAndMergeMeta(context.Background(), pr, tc.reconcile1Args.pipelineSpec, tc.reconcile1Args.resolvedObjectMeta); err != nil {
+				t.Errorf("storePipelineSpec() error = %v", err)
+			}
+			if d := cmp.Diff(pr, tc.wantPipelineRun); d != "" {
+				t.Fatalf(diff.PrintWantGot(d))
+			}
+
+			// mock second reconcile
+			if err := storePipelineSpecAndMergeMeta(context.Background(), pr, tc.reconcile2Args.pipelineSpec, tc.reconcile2Args.resolvedObjectMeta); err != nil {
+				t.Errorf("storePipelineSpec() error = %v", err)
+			}
+			if d := cmp.Diff(pr, tc.wantPipelineRun); d != "" {
+				t.Fatalf(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func Test_storePipelineSpec_metadata(t *testing.T) {
+	pipelinerunlabels := map[string]string{"lbl1": "value1", "lbl2": "value2"}
+	pipelinerunannotations := map[string]string{"io.annotation.1": "value1", "io.annotation.2": "value2"}
+	pipelinelabels := map[string]string{"lbl1": "another value", "lbl2": "another value", "lbl3": "value3"}
+	pipelineannotations := map[string]string{"io.annotation.1": "another value", "io.annotation.2": "another value", "io.annotation.3": "value3", "kubectl.kubernetes.io/last-applied-configuration": "foo-is-bar"}
+	wantedlabels := map[string]string{"lbl1": "value1", "lbl2": "value2", "lbl3": "value3", pipeline.PipelineLabelKey: "bar"}
+	wantedannotations := map[string]string{"io.annotation.1": "value1", "io.annotation.2": "value2", "io.annotation.3": "value3"}
+
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo", Labels: pipelinerunlabels, Annotations: pipelinerunannotations},
+	}
+	meta := metav1.ObjectMeta{Name: "bar", Labels: pipelinelabels, Annotations: pipelineannotations}
+	if err := storePipelineSpecAndMergeMeta(context.Background(), pr, &v1beta1.PipelineSpec{}, &resolutionutil.ResolvedObjectMeta{
+		ObjectMeta: &meta,
+	}); err != nil {
+		t.Errorf("storePipelineSpecAndMergeMeta error = %v", err)
+	}
+	if d := cmp.Diff(pr.ObjectMeta.Labels, wantedlabels); d != "" {
+		t.Fatalf(diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(pr.ObjectMeta.Annotations, wantedannotations); d != "" {
+		t.Fatalf(diff.PrintWantGot(d))
+	}
+}
+
+func Test_storePipelineSpec_SpecAttestation(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the key: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), fakekms.KmsCtxKey{}, crypto.PrivateKey(key))
+	ctx = config.ToContext(ctx, &config.Config{FeatureFlags: &config.FeatureFlags{
+		EnableProvenanceInStatus: true,
+		ProvenanceSigningKey:     "fakekms://pipelinerun",
+	}})
+	verifier, err := signature.LoadVerifier(key.Public(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to load the verifier: %v", err)
+	}
+
+	ps := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{Name: "revision", Type: v1beta1.ParamTypeString}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:    "clone",
+			TaskRef: &v1beta1.TaskRef{Name: "git-clone"},
+			Params:  v1beta1.Params{{Name: "revision", Value: *v1beta1.NewStructuredValues("$(params.revision)")}},
+		}},
+	}
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	if err := storePipelineSpecAndMergeMeta(ctx, pr, ps, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{Name: "bar"}}); err != nil {
+		t.Fatalf("storePipelineSpecAndMergeMeta error = %v", err)
+	}
+	attestation := pr.Status.Provenance.SpecAttestation
+	if attestation == nil {
+		t.Fatal("expected the PipelineSpec to be attested in the provenance")
+	}
+	if err := trustedresources.VerifySpecAttestation(ps, attestation, verifier); err != nil {
+		t.Errorf("expected the attestation of the resolved PipelineSpec, got %v", err)
+	}
+
+	// The attestation is recorded once.
+	if err := storePipelineSpecAndMergeMeta(ctx, pr, &v1beta1.PipelineSpec{}, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{Name: "bar"}}); err != nil {
+		t.Fatalf("storePipelineSpecAndMergeMeta error = %v", err)
+	}
+	if d := cmp.Diff(attestation, pr.Status.Provenance.SpecAttestation); d != "" {
+		t.Errorf("expected the attestation not to change %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestStorePipelineSpec_ClusterPipelineLabel(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "bar", Kind: v1beta1.ClusterPipelineKind},
+		},
+	}
+	if err := storePipelineSpecAndMergeMeta(context.Background(), pr, &v1beta1.PipelineSpec{}, &resolutionutil.ResolvedObjectMeta{
+		ObjectMeta: &metav1.ObjectMeta{Name: "bar"},
+	}); err != nil {
+		t.Errorf("storePipelineSpecAndMergeMeta error = %v", err)
+	}
+	wantedlabels := map[string]string{pipeline.PipelineLabelKey: "bar", pipeline.ClusterPipelineLabelKey: "bar"}
+	if d := cmp.Diff(wantedlabels, pr.ObjectMeta.Labels); d != "" {
+		t.Fatalf(diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcileOutOfSyncPipelineRun(t *testing.T) {
+	// It may happen that a PipelineRun creates one or more TaskRuns during reconcile
+	// but it fails to sync the update on the status back. This test verifies that
+	// the reconciler is able to coverge back to a consistent state with the orphaned
+	// TaskRuns back in the PipelineRun status.
+	// For more details, see https://github.com/tektoncd/pipeline/issues/2558
+	ctx := context.Background()
+
+	namespace := "foo"
+	prOutOfSyncName := "test-pipeline-run-out-of-sync"
+	helloWorldTask := simpleHelloWorldTask
+
+	testPipeline := parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+  - name: hello-world-5
+    taskRef:
+      apiVersion: example.dev/v0
+      kind: Example
+`)
+
+	// This taskrun is in the pipelinerun status. It completed successfully.
+	taskRunDone := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-1", "foo", prOutOfSyncName, testPipeline.Name, "hello-world-1", false),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+`)
+
+	// This taskrun is *not* in the pipelinerun status. It's still running.
+	taskRunOrphaned := mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-2", "foo", prOutOfSyncName, testPipeline.Name, "hello-world-2", false),
+		`
+spec:
+  taskRef:
+    name: hello-world
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+`)
+
+	orphanedCustomRun := mustParseCustomRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-out-of-sync-hello-world-5", "foo", prOutOfSyncName, testPipeline.Name,
+			"hello-world-5", true),
+		`
+spec:
+  customRef:
+    apiVersion: example.dev/v0
+    kind: Example
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+`)
+
+	prOutOfSync := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-out-of-sync
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+`)
+
+	prOutOfSync.Status.ChildReferences = []v1beta1.ChildStatusReference{
+		{
+			TypeMeta: runtime.TypeMeta{
+				APIVersion: v1beta1.SchemeGroupVersion.String(),
+				Kind:       taskRun,
+			},
+			Name:             taskRunDone.Name,
+			PipelineTaskName: "hello-world-1",
+		},
+	}
+
+	prs := []*v1beta1.PipelineRun{prOutOfSync}
+	ps := []*v1beta1.Pipeline{testPipeline}
+	ts := []*v1beta1.Task{helloWorldTask}
+	trs := []*v1beta1.TaskRun{taskRunDone, taskRunOrphaned}
+	customRuns := []*v1beta1.CustomRun{orphanedCustomRun}
+
+	cms := []*corev1.ConfigMap{newFeatureFlagsConfigMap()}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+		CustomRuns:   customRuns,
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun(namespace, prOutOfSyncName, []string{}, false)
+	taskRuns := getTaskRunsForPipelineRun(prt.TestAssets.Ctx, t, clients, namespace, prOutOfSyncName)
+	validateTaskRunsCount(t, taskRuns, 2)
+
+	// This PipelineRun should still be running and the status should reflect that
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun status to be running, but was %v", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+
+	expectedTaskRunsStatus := make(map[string]*v1beta1.PipelineRunTaskRunStatus)
+	expectedRunsStatus := make(map[string]*v1beta1.PipelineRunRunStatus)
+	// taskRunDone did not change
+	expectedTaskRunsStatus[taskRunDone.Name] = &v1beta1.PipelineRunTaskRunStatus{
+		PipelineTaskName: "hello-world-1",
+		Status: &v1beta1.TaskRunStatus{
+			Status: duckv1.Status{
+				Conditions: []apis.Condition{
+					{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					},
 				},
 			},
 		},
@@ -5740,16 +7264,16 @@ func TestReconcilePipeline_FinalTasks(t *testing.T) {
 				t.Fatalf("Expected client to have at least two action implementation but it has %d", len(actions))
 			}
 
-			// The first update action should be updating the PipelineRun.
-			var actual *v1beta1.PipelineRun
+			// The status of the PipelineRun should be applied.
+			applied := false
 			for _, action := range actions {
-				if actualPrime, ok := action.(ktesting.UpdateAction); ok {
-					actual = actualPrime.GetObject().(*v1beta1.PipelineRun)
+				if action.Matches("patch", "pipelineruns") && action.GetSubresource() == "status" {
+					applied = true
 					break
 				}
 			}
 
-			if actual == nil {
+			if !applied {
 				t.Errorf("Expected a PipelineRun to be updated, but it wasn't for %s", tt.name)
 			}
 
@@ -6399,6 +7923,164 @@ spec:
 	}
 }
 
+func TestReconcileWithExecutionStatusDetailsInFinalTasks(t *testing.T) {
+	names.TestingSeed()
+
+	ps := []*v1beta1.Pipeline{parse.MustParseV1beta1Pipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: build
+    taskRef:
+      name: dag-task
+  - name: lint
+    taskRef:
+      name: dag-task
+    when:
+    - input: "no"
+      operator: in
+      values: ["yes"]
+  finally:
+  - name: notify
+    params:
+    - name: reason
+      value: $(tasks.build.reason)
+    - name: failed
+      value: ["$(tasks.failed[*])"]
+    taskRef:
+      name: final-task
+    when:
+    - input: $(tasks.failed.count)
+      operator: notin
+      values: ["0"]
+    - input: lint
+      operator: in
+      values: ["$(tasks.skipped[*])"]
+  - name: celebrate
+    params:
+    - name: reason
+      value: $(tasks.build.reason)
+    - name: failed
+      value: []
+    taskRef:
+      name: final-task
+    when:
+    - input: $(tasks.failed.count)
+      operator: in
+      values: ["0"]
+`)}
+
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-final-task-status
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  serviceAccountName: test-sa-0
+`)}
+
+	ts := []*v1beta1.Task{
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: dag-task
+  namespace: foo
+`),
+		parse.MustParseV1beta1Task(t, `
+metadata:
+  name: final-task
+  namespace: foo
+spec:
+  params:
+  - name: reason
+    type: string
+  - name: failed
+    type: array
+`),
+	}
+
+	trs := []*v1beta1.TaskRun{
+		mustParseTaskRunWithObjectMeta(t,
+			taskRunObjectMeta("test-pipeline-run-final-task-status-build", "foo",
+				"test-pipeline-run-final-task-status", "test-pipeline", "build", false),
+			`
+spec:
+  serviceAccountName: test-sa
+  taskRef:
+    name: dag-task
+status:
+  conditions:
+  - reason: TaskRunTimeout
+    status: "False"
+    type: Succeeded
+`),
+	}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		TaskRuns:     trs,
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run-final-task-status", []string{}, false)
+
+	expectedTaskRunObjectMeta := taskRunObjectMeta("test-pipeline-run-final-task-status-notify", "foo",
+		"test-pipeline-run-final-task-status", "test-pipeline", "notify", true)
+	expectedTaskRunObjectMeta.Labels[pipeline.MemberOfLabelKey] = v1beta1.PipelineFinallyTasks
+	expectedTaskRun := mustParseTaskRunWithObjectMeta(t, expectedTaskRunObjectMeta, `
+spec:
+  params:
+  - name: reason
+    value: TaskRunTimeout
+  - name: failed
+    value: ["build"]
+  serviceAccountName: test-sa-0
+  taskRef:
+    name: final-task
+    kind: Task
+`)
+
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineTask=notify,tekton.dev/pipelineRun=test-pipeline-run-final-task-status",
+		Limit:         1,
+	})
+	if err != nil {
+		t.Fatalf("Failure to list TaskRun's %s", err)
+	}
+	if len(actual.Items) != 1 {
+		t.Fatalf("Expected 1 TaskRuns got %d", len(actual.Items))
+	}
+	if d := cmp.Diff(*expectedTaskRun, actual.Items[0], ignoreResourceVersion, ignoreTypeMeta); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun.Name, diff.PrintWantGot(d))
+	}
+
+	expectedSkippedTasks := []v1beta1.SkippedTask{{
+		Name:   "lint",
+		Reason: v1beta1.StoppingSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "no",
+			Operator: "in",
+			Values:   []string{"yes"},
+		}},
+	}, {
+		Name:   "celebrate",
+		Reason: v1beta1.WhenExpressionsSkip,
+		WhenExpressions: v1beta1.WhenExpressions{{
+			Input:    "1",
+			Operator: "in",
+			Values:   []string{"0"},
+		}},
+	}}
+	if d := cmp.Diff(expectedSkippedTasks, reconciledRun.Status.SkippedTasks); d != "" {
+		t.Fatalf("Didn't get the expected list of skipped tasks. Diff: %s", diff.PrintWantGot(d))
+	}
+}
+
 // newPipelineRunTest returns PipelineRunTest with a new PipelineRun controller created with specified state through data
 // This PipelineRunTest can be reused for multiple PipelineRuns by calling reconcileRun for each pipelineRun
 func newPipelineRunTest(t *testing.T, data test.Data) *PipelineRunTest {
@@ -6618,20 +8300,116 @@ spec:
   taskSpec:
     steps:
     - image: foo:latest
-    workspaces:
-    - name: ws
-      optional: true
-`)
+    workspaces:
+    - name: ws
+      optional: true
+`)
+
+	if d := cmp.Diff(expectedTaskRun, actual, ignoreTypeMeta, ignoreResourceVersion); d != "" {
+		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	}
+
+	// This PipelineRun is in progress now and the status should reflect that
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+
+	verifyTaskRunStatusesCount(t, reconciledRun.Status, 1)
+	verifyTaskRunStatusesNames(t, reconciledRun.Status, "test-pipeline-run-success-unit-test-1")
+}
+
+func TestReconcile_RetriesAndTimeoutFromParamsAndResults(t *testing.T) {
+	names.TestingSeed()
+	prName := "test-pipeline-run-retries-timeout"
+	trName := "test-pipeline-run-retries-timeout-unit-test-2"
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: test-pipeline-run-retries-timeout
+  namespace: foo
+spec:
+  params:
+  - name: retry-count
+    value: "2"
+  pipelineSpec:
+    params:
+    - name: retry-count
+    tasks:
+    - name: unit-test-1
+      taskSpec:
+        results:
+        - name: minutes
+        steps:
+        - image: foo:latest
+    - name: unit-test-2
+      retriesFrom: $(params.retry-count)
+      timeoutFrom: $(tasks.unit-test-1.results.minutes)m
+      taskSpec:
+        steps:
+        - image: foo:latest
+  serviceAccountName: test-sa
+status:
+  pipelineSpec:
+    params:
+    - name: retry-count
+    tasks:
+    - name: unit-test-1
+      taskSpec:
+        results:
+        - name: minutes
+        steps:
+        - image: foo:latest
+    - name: unit-test-2
+      retriesFrom: $(params.retry-count)
+      timeoutFrom: $(tasks.unit-test-1.results.minutes)m
+      taskSpec:
+        steps:
+        - image: foo:latest
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: test-pipeline-run-retries-timeout-unit-test-1
+    pipelineTaskName: unit-test-1
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("test-pipeline-run-retries-timeout-unit-test-1", "foo", prName,
+			"test-pipeline-run-retries-timeout", "unit-test-1", true),
+		`
+spec:
+  serviceAccountName: test-sa
+  taskSpec:
+    results:
+    - name: minutes
+    steps:
+    - image: foo:latest
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  taskResults:
+  - name: minutes
+    value: "90"
+`)}
 
-	if d := cmp.Diff(expectedTaskRun, actual, ignoreTypeMeta, ignoreResourceVersion); d != "" {
-		t.Errorf("expected to see TaskRun %v created. Diff %s", expectedTaskRun, diff.PrintWantGot(d))
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
 	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
 
-	// This PipelineRun is in progress now and the status should reflect that
-	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String())
+	_, clients := prt.reconcileRun("foo", prName, nil, false)
 
-	verifyTaskRunStatusesCount(t, reconciledRun.Status, 1)
-	verifyTaskRunStatusesNames(t, reconciledRun.Status, "test-pipeline-run-success-unit-test-1")
+	actual, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected to see TaskRun %s created: %v", trName, err)
+	}
+	if actual.Spec.Retries != 2 {
+		t.Errorf("expected the TaskRun to be retried 2 times but got %d", actual.Spec.Retries)
+	}
+	if actual.Spec.Timeout == nil || actual.Spec.Timeout.Duration != 90*time.Minute {
+		t.Errorf("expected the TaskRun to time out after 90m but got %v", actual.Spec.Timeout)
+	}
 }
 
 func TestReconcile_DependencyValidationsImmediatelyFailPipelineRun(t *testing.T) {
@@ -6728,6 +8506,25 @@ spec:
         params:
           - name: platform
             value: linux
+`),
+		parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pipelinerun-invalid-retries
+  namespace: foo
+spec:
+  params:
+  - name: retry-count
+    value: three
+  pipelineSpec:
+    params:
+    - name: retry-count
+    tasks:
+    - name: pt0
+      retriesFrom: $(params.retry-count)
+      taskSpec:
+        steps:
+        - image: foo:latest
+  serviceAccountName: test-sa
 `),
 	}
 
@@ -6759,6 +8556,9 @@ spec:
 		}, {
 			name:   "pipelinerun-matrix-param-invalid-type",
 			reason: ReasonInvalidMatrixParameterTypes,
+		}, {
+			name:   "pipelinerun-invalid-retries",
+			reason: ReasonInvalidRetriesOrTimeout,
 		},
 	}
 	for _, tc := range testCases {
@@ -6904,9 +8704,35 @@ spec:
 		t.Fatalf("unexpected error updating resource request with resolved pipeline data: %v", err)
 	}
 
-	// Check that the pipeline fails with the appropriate reason.
+	// Check that the pipeline fails with the reason of the failed resolution.
 	updatedPipelineRun, _ := prt.reconcileRun("default", "pr", nil, true)
-	checkPipelineRunConditionStatusAndReason(t, updatedPipelineRun, corev1.ConditionFalse, ReasonCouldntGetPipeline)
+	checkPipelineRunConditionStatusAndReason(t, updatedPipelineRun, corev1.ConditionFalse, resolutioncommon.ReasonResolutionTimedOut)
+}
+
+func TestResolutionFailureReason(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want string
+	}{{
+		name: "not a resolution error",
+		err:  errors.New("pipeline not found"),
+		want: ReasonCouldntGetPipeline,
+	}, {
+		name: "failed resolution",
+		err:  fmt.Errorf("error requesting remote resource: %w", resolutioncommon.NewError(resolutioncommon.ReasonResolutionFailed, errors.New("fake failure"))),
+		want: ReasonCouldntGetPipeline,
+	}, {
+		name: "invalid resolution request",
+		err:  fmt.Errorf("error requesting remote resource: %w", resolutioncommon.NewError(resolutioncommon.ReasonResolutionInvalidRequest, errors.New("missing url"))),
+		want: resolutioncommon.ReasonResolutionInvalidRequest,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := resolutionFailureReason(tc.err, ReasonCouldntGetPipeline); got != tc.want {
+				t.Errorf("expected reason %q, got %q", tc.want, got)
+			}
+		})
+	}
 }
 
 // TestReconcileWithFailingTaskResolver checks that a PipelineRun with a failing Resolver
@@ -6963,9 +8789,9 @@ spec:
 		t.Fatalf("unexpected error updating resource request with resolved pipeline data: %v", err)
 	}
 
-	// Check that the pipeline fails.
+	// Check that the pipeline fails with the reason of the failed resolution.
 	updatedPipelineRun, _ := prt.reconcileRun("default", "pr", nil, true)
-	checkPipelineRunConditionStatusAndReason(t, updatedPipelineRun, corev1.ConditionFalse, ReasonCouldntGetTask)
+	checkPipelineRunConditionStatusAndReason(t, updatedPipelineRun, corev1.ConditionFalse, resolutioncommon.ReasonResolutionTimedOut)
 }
 
 // TestReconcileWithTaskResolver checks that a PipelineRun with a populated Resolver
@@ -7117,6 +8943,26 @@ func taskRunObjectMetaWithAnnotations(trName, ns, prName, pipelineName, pipeline
 	return om
 }
 
+// withMatrixCombination sets the matrix combination annotation of the TaskRun created for a
+// combination of a matrix from its params, except the params of the PipelineTask.
+func withMatrixCombination(t *testing.T, tr *v1beta1.TaskRun, pipelineTaskParams ...string) *v1beta1.TaskRun {
+	t.Helper()
+	combination := map[string]string{}
+	for _, p := range tr.Spec.Params {
+		combination[p.Name] = p.Value.StringVal
+	}
+	for _, name := range pipelineTaskParams {
+		delete(combination, name)
+	}
+	b, err := json.Marshal(combination)
+	if err != nil {
+		t.Fatalf("failed to marshal the matrix combination: %v", err)
+	}
+	tr = tr.DeepCopy()
+	tr.Annotations[pipeline.MatrixCombinationAnnotationKey] = string(b)
+	return tr
+}
+
 func createHelloWorldTaskRunWithStatus(
 	t *testing.T,
 	trName, ns, prName, pName, podName string,
@@ -7501,6 +9347,119 @@ spec:
 	}
 }
 
+func TestGetTaskrunWorkspaces_ReadOnlyMode(t *testing.T) {
+	pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pipeline
+spec:
+  workspaces:
+  - name: source
+    persistentVolumeClaim:
+      claimName: source-pvc
+  - name: output
+    persistentVolumeClaim:
+      claimName: output-pvc
+  - name: config
+    configMap:
+      name: my-config
+status:
+  pipelineSpec:
+    workspaces:
+    - name: source
+      mode: ReadOnly
+    - name: output
+      mode: ReadWrite
+    - name: config
+      mode: ReadOnly
+`)
+	rpt := &resources.ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "resolved-pipelinetask",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+				Name:      "src",
+				Workspace: "source",
+			}, {
+				Name:      "out",
+				Workspace: "output",
+			}, {
+				Name:      "cfg",
+				Workspace: "config",
+			}},
+		},
+	}
+	want := []v1beta1.WorkspaceBinding{{
+		Name:                  "src",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source-pvc", ReadOnly: true},
+	}, {
+		Name:                  "out",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "output-pvc"},
+	}, {
+		Name:      "cfg",
+		ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "my-config"}},
+	}}
+
+	got, _, err := getTaskrunWorkspaces(context.Background(), pr, rpt)
+	if err != nil {
+		t.Fatalf("Pipeline.getTaskrunWorkspaces() returned error for valid pipeline: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Pipeline.getTaskrunWorkspaces() workspaces diff %s", diff.PrintWantGot(d))
+	}
+	if pr.Spec.Workspaces[0].PersistentVolumeClaim.ReadOnly {
+		t.Errorf("expected the PipelineRun workspace bindings to be left unmodified")
+	}
+}
+
+func TestGetTaskrunWorkspaces_WorkspaceTypes(t *testing.T) {
+	pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pipeline
+spec:
+  workspaces:
+  - name: cache
+    subPath: go
+    volumeClaimTemplate:
+      metadata:
+        name: pvc
+status:
+  pipelineSpec:
+    workspaces:
+    - name: cache
+      type: cache
+      cacheKey: go-1.19
+    - name: scratch
+      type: ephemeral
+`)
+	rpt := &resources.ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "resolved-pipelinetask",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+				Name:      "cache",
+				Workspace: "cache",
+			}, {
+				Name:      "scratch",
+				Workspace: "scratch",
+			}},
+		},
+	}
+	want := []v1beta1.WorkspaceBinding{{
+		Name:                  "cache",
+		SubPath:               "go",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-cache-1eb3392c76"},
+	}, {
+		Name:     "scratch",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+
+	got, _, err := getTaskrunWorkspaces(context.Background(), pr, rpt)
+	if err != nil {
+		t.Fatalf("Pipeline.getTaskrunWorkspaces() returned error for valid pipeline: %v", err)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Pipeline.getTaskrunWorkspaces() workspaces diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestReconcile_PropagatePipelineTaskRunSpecMetadata(t *testing.T) {
 	names.TestingSeed()
 
@@ -7931,6 +9890,8 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    serviceAccounts:
+    - serviceAccount: test-sa
 `),
 	}, {
 		name:     "p-finally",
@@ -8134,7 +10095,7 @@ spec:
 			}
 
 			for i := range taskRuns.Items {
-				expectedTaskRun := expectedTaskRuns[i]
+				expectedTaskRun := withMatrixCombination(t, expectedTaskRuns[i], "version")
 				expectedTaskRun.Labels["tekton.dev/pipeline"] = tt.name
 				expectedTaskRun.Labels["tekton.dev/memberOf"] = tt.memberOf
 				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta); d != "" {
@@ -8301,6 +10262,8 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    serviceAccounts:
+    - serviceAccount: test-sa
 `),
 	}}
 	for _, tt := range tests {
@@ -8709,6 +10672,8 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    serviceAccounts:
+    - serviceAccount: test-sa
 `),
 	}, {
 		name:     "p-finally",
@@ -8950,7 +10915,7 @@ spec:
 			}
 
 			for i := range taskRuns.Items {
-				expectedTaskRun := expectedTaskRuns[i]
+				expectedTaskRun := withMatrixCombination(t, expectedTaskRuns[i])
 				expectedTaskRun.Labels["tekton.dev/pipeline"] = tt.name
 				expectedTaskRun.Labels["tekton.dev/memberOf"] = tt.memberOf
 				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta); d != "" {
@@ -9143,6 +11108,8 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    serviceAccounts:
+    - serviceAccount: test-sa
 `),
 	},
 	}
@@ -9183,7 +11150,7 @@ spec:
 			}
 
 			for i := range taskRuns.Items {
-				expectedTaskRun := expectedTaskRuns[i]
+				expectedTaskRun := withMatrixCombination(t, expectedTaskRuns[i])
 				expectedTaskRun.Labels["tekton.dev/pipeline"] = tt.name
 				expectedTaskRun.Labels["tekton.dev/memberOf"] = tt.memberOf
 				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta); d != "" {
@@ -9583,6 +11550,42 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    consumedResults:
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-1
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:56b03468e2dc3d8b071ecd0933d30940af2ec7464e7dced69f70aa41687a581d
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-2
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:75a17f6a63bf4cdea91b12d7b691d32131d6a844fe79fd49f72f855dc8749643
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-3
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:52af6d3c42284db9b7284ceb7c7441fb54035e0194bcbbdaac22b2863a26c820
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-1
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:f32af962aa06e4f20ceda568d6493687257ce3aff79853b582d13ee6fbf5171b
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-2
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:152effae60017a9f2998cc1cac3e49e117076a8ba50e9747555f7cc2f9d4ede8
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-3
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:e2946cabbb26da3c076d98f3395e9fe1e0725ba39df2fe6e79f955408c07ed90
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.version
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:a89b6eb460a48714eae21bbbe828f5025a4ef17425d0a2590f093e5d1bb75ca5
 `),
 	}, {
 		name:     "p-finally",
@@ -9758,6 +11761,42 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    consumedResults:
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-1
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:56b03468e2dc3d8b071ecd0933d30940af2ec7464e7dced69f70aa41687a581d
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-2
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:75a17f6a63bf4cdea91b12d7b691d32131d6a844fe79fd49f72f855dc8749643
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.browser-3
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:52af6d3c42284db9b7284ceb7c7441fb54035e0194bcbbdaac22b2863a26c820
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-1
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:f32af962aa06e4f20ceda568d6493687257ce3aff79853b582d13ee6fbf5171b
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-2
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:152effae60017a9f2998cc1cac3e49e117076a8ba50e9747555f7cc2f9d4ede8
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.platform-3
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:e2946cabbb26da3c076d98f3395e9fe1e0725ba39df2fe6e79f955408c07ed90
+    - pipelineTask: platforms-and-browsers
+      result: tasks.pt-with-result.results.version
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:a89b6eb460a48714eae21bbbe828f5025a4ef17425d0a2590f093e5d1bb75ca5
 `),
 	}}
 	for _, tt := range tests {
@@ -9797,7 +11836,7 @@ spec:
 			}
 
 			for i := range taskRuns.Items {
-				expectedTaskRun := expectedTaskRuns[i]
+				expectedTaskRun := withMatrixCombination(t, expectedTaskRuns[i], "version")
 				expectedTaskRun.Labels["tekton.dev/pipeline"] = tt.name
 				expectedTaskRun.Labels["tekton.dev/memberOf"] = tt.memberOf
 				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta); d != "" {
@@ -9980,6 +12019,12 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    consumedResults:
+    - pipelineTask: echo-platforms
+      result: tasks.pt-with-result.results.platforms
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:5a2e638bb7fb62e14824d9b476b8a3f75fdfbcc1fe97dff7675283faa9f431ea
 `),
 	}, {
 		name:  "indexing results in matrix.params",
@@ -10142,6 +12187,12 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    consumedResults:
+    - pipelineTask: echo-platforms
+      result: tasks.pt-with-result.results.platforms
+      run: pr-pt-with-result
+      attempt: 0
+      digest: sha256:5a2e638bb7fb62e14824d9b476b8a3f75fdfbcc1fe97dff7675283faa9f431ea
 `),
 	}}
 	for _, tt := range tests {
@@ -10404,10 +12455,158 @@ status:
   - type: Succeeded
     status: "Unknown"
 `),
-		},
-	}, {
-		name: "matrixed pipelinetask with retries, where both taskruns have failed",
-		trs: []*v1beta1.TaskRun{
+		},
+	}, {
+		name: "matrixed pipelinetask with retries, where both taskruns have failed",
+		trs: []*v1beta1.TaskRun{
+			mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("pr-platforms-and-browsers-0", "foo",
+					"pr", "p", "platforms-and-browsers", false),
+				`
+spec:
+  retries: 1
+  params:
+  - name: platform
+    value: linux
+  - name: browser
+    value: chrome
+  serviceAccountName: test-sa
+  taskRef:
+    name: mytask
+  timeout: 1h0m0s
+status:
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+  retriesStatus:
+  - conditions:
+    - status: "False"
+      type: Succeeded
+`),
+			mustParseTaskRunWithObjectMeta(t,
+				taskRunObjectMeta("pr-platforms-and-browsers-1", "foo",
+					"pr", "p", "platforms-and-browsers", false),
+				`
+spec:
+  retries: 1
+  params:
+  - name: platform
+    value: mac
+  - name: browser
+    value: chrome
+  serviceAccountName: test-sa
+  taskRef:
+    name: mytask
+  timeout: 1h0m0s
+status:
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+  retriesStatus:
+  - conditions:
+    - status: "False"
+      type: Succeeded
+`),
+		},
+		prs: []*v1beta1.PipelineRun{
+			parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+  annotations: {}
+  labels:
+    tekton.dev/pipeline: p
+spec:
+  serviceAccountName: test-sa
+  pipelineRef:
+    name: p
+status:
+  pipelineSpec:
+    tasks:
+    - name: platforms-and-browsers
+      retries: 1
+      taskRef:
+        name: mytask
+        kind: Task
+      matrix:
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+      params:
+        - name: browser
+          value: chrome
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+    reason: "Running"
+    message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-platforms-and-browsers-0
+    pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-platforms-and-browsers-1
+    pipelineTaskName: platforms-and-browsers
+`),
+		},
+		expectedPipelineRun: parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+  annotations: {}
+  labels:
+    tekton.dev/pipeline: p
+spec:
+  serviceAccountName: test-sa
+  pipelineRef:
+    name: p
+status:
+  pipelineSpec:
+    tasks:
+    - name: platforms-and-browsers
+      retries: 1
+      taskRef:
+        name: mytask
+        kind: Task
+      matrix:
+        params:
+          - name: platform
+            value:
+              - linux
+              - mac
+      params:
+        - name: browser
+          value: chrome
+  conditions:
+  - type: Succeeded
+    status: "Unknown"
+    reason: "Running"
+    message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-platforms-and-browsers-0
+    pipelineTaskName: platforms-and-browsers
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-platforms-and-browsers-1
+    pipelineTaskName: platforms-and-browsers
+  provenance:
+    featureFlags:
+      RunningInEnvWithInjectedSidecars: true
+      EnableTektonOCIBundles: true
+      EnableAPIFields: "alpha"
+      AwaitSidecarReadiness: true
+      VerificationNoMatchPolicy: "ignore"
+      EnableProvenanceInStatus: true
+      ResultExtractionMethod: "termination-message"
+      MaxResultSize: 4096
+`),
+		expectedTaskRuns: []*v1beta1.TaskRun{
 			mustParseTaskRunWithObjectMeta(t,
 				taskRunObjectMeta("pr-platforms-and-browsers-0", "foo",
 					"pr", "p", "platforms-and-browsers", false),
@@ -10457,196 +12656,525 @@ status:
       type: Succeeded
 `),
 		},
-		prs: []*v1beta1.PipelineRun{
-			parse.MustParseV1beta1PipelineRun(t, `
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := test.Data{
+				Tasks:        []*v1beta1.Task{task},
+				TaskRuns:     tt.trs,
+				PipelineRuns: tt.prs,
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(t, d)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "pr", []string{}, false)
+			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
+				LabelSelector: "tekton.dev/pipelineRun=pr,tekton.dev/pipelineTask=platforms-and-browsers",
+				Limit:         1,
+			})
+			if err != nil {
+				t.Fatalf("Failure to list TaskRun's %s", err)
+			}
+
+			if len(taskRuns.Items) != 2 {
+				t.Fatalf("Expected 2 TaskRuns got %d", len(taskRuns.Items))
+			}
+
+			for i := range taskRuns.Items {
+				expectedTaskRun := tt.expectedTaskRuns[i]
+				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime); d != "" {
+					t.Errorf("expected to see TaskRun %v created. Diff %s", tt.expectedTaskRuns[i].Name, diff.PrintWantGot(d))
+				}
+			}
+
+			pipelineRun, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "pr", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
+			}
+
+			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestReconciler_PipelineTaskMatrixWithSpread(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  taskRunSpecs:
+  - pipelineTaskName: platforms
+    taskPodTemplate:
+      topologySpreadConstraints:
+      - topologyKey: kubernetes.io/hostname
+        maxSkew: 3
+        whenUnsatisfiable: ScheduleAnyway
+  pipelineSpec:
+    tasks:
+    - name: platforms
+      matrix:
+        params:
+        - name: platform
+          value:
+          - linux
+          - mac
+        spread:
+        - topologyKey: topology.kubernetes.io/zone
+          whenUnsatisfiable: DoNotSchedule
+        - topologyKey: kubernetes.io/hostname
+      taskSpec:
+        params:
+        - name: platform
+        steps:
+        - image: foo:latest
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{
+		"tekton.dev/pipelineRun":  "pr",
+		"tekton.dev/pipelineTask": "platforms",
+	}}
+	// The constraint of the PipelineRun for the same topology overrides the spread of the matrix
+	want := []corev1.TopologySpreadConstraint{{
+		TopologyKey:       "topology.kubernetes.io/zone",
+		MaxSkew:           1,
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+		LabelSelector:     selector,
+	}, {
+		TopologyKey:       "kubernetes.io/hostname",
+		MaxSkew:           3,
+		WhenUnsatisfiable: corev1.ScheduleAnyway,
+	}}
+	for _, trName := range []string{"pr-platforms-0", "pr-platforms-1"} {
+		tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected to see TaskRun %s created: %v", trName, err)
+		}
+		if tr.Spec.PodTemplate == nil {
+			t.Fatalf("expected TaskRun %s to have a pod template", trName)
+		}
+		if d := cmp.Diff(want, tr.Spec.PodTemplate.TopologySpreadConstraints); d != "" {
+			t.Errorf("topology spread constraints of TaskRun %s %s", trName, diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestReconciler_PipelineTaskLoop(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  params:
+  - name: browsers
+    value:
+    - chrome
+    - firefox
+    - safari
+  pipelineSpec:
+    params:
+    - name: browsers
+      type: array
+    tasks:
+    - name: platforms
+      withItems:
+      - linux
+      - mac
+      params:
+      - name: platform
+        value: $(item)
+      - name: arch
+        value: amd64
+      taskSpec:
+        params:
+        - name: platform
+        - name: arch
+        steps:
+        - image: foo:latest
+    - name: browsers
+      withParam: $(params.browsers[*])
+      params:
+      - name: browser
+        value: $(item)
+      taskSpec:
+        params:
+        - name: browser
+        steps:
+        - image: foo:latest
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	pr, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	want := map[string]v1beta1.Params{
+		"pr-platforms-0": {{Name: "platform", Value: *v1beta1.NewStructuredValues("linux")}, {Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")}},
+		"pr-platforms-1": {{Name: "platform", Value: *v1beta1.NewStructuredValues("mac")}, {Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")}},
+		"pr-browsers-0":  {{Name: "browser", Value: *v1beta1.NewStructuredValues("chrome")}},
+		"pr-browsers-1":  {{Name: "browser", Value: *v1beta1.NewStructuredValues("firefox")}},
+		"pr-browsers-2":  {{Name: "browser", Value: *v1beta1.NewStructuredValues("safari")}},
+	}
+	for trName, params := range want {
+		tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected to see TaskRun %s created: %v", trName, err)
+		}
+		if d := cmp.Diff(params, tr.Spec.Params); d != "" {
+			t.Errorf("params of TaskRun %s %s", trName, diff.PrintWantGot(d))
+		}
+	}
+	// The loops are not fanned out into matrices in the status of the PipelineRun
+	for _, pt := range pr.Status.PipelineSpec.Tasks {
+		if pt.Matrix != nil {
+			t.Errorf("expected the pipeline task %s to have no matrix in the status, got %v", pt.Name, pt.Matrix)
+		}
+	}
+}
+
+func TestReconciler_PipelineTaskLoopWithResults(t *testing.T) {
+	names.TestingSeed()
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  pipelineSpec:
+    tasks:
+    - name: list
+      taskSpec:
+        results:
+        - name: files
+          type: array
+        steps:
+        - image: foo:latest
+    - name: lint
+      withParam: $(tasks.list.results.files[*])
+      params:
+      - name: file
+        value: $(item)
+      taskSpec:
+        params:
+        - name: file
+        steps:
+        - image: foo:latest
+status:
+  pipelineSpec:
+    tasks:
+    - name: list
+      taskSpec:
+        results:
+        - name: files
+          type: array
+        steps:
+        - image: foo:latest
+    - name: lint
+      withParam: $(tasks.list.results.files[*])
+      params:
+      - name: file
+        value: $(item)
+      taskSpec:
+        params:
+        - name: file
+        steps:
+        - image: foo:latest
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-list
+    pipelineTaskName: list
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("pr-list", "foo", "pr", "pr", "list", true),
+		`
+spec:
+  serviceAccountName: test-sa
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+    reason: Succeeded
+  taskResults:
+  - name: files
+    type: array
+    value:
+    - main.go
+    - main_test.go
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	_, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	for i, file := range []string{"main.go", "main_test.go"} {
+		trName := fmt.Sprintf("pr-lint-%d", i)
+		tr, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, trName, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("expected to see TaskRun %s created: %v", trName, err)
+		}
+		want := v1beta1.Params{{Name: "file", Value: *v1beta1.NewStructuredValues(file)}}
+		if d := cmp.Diff(want, tr.Spec.Params); d != "" {
+			t.Errorf("params of TaskRun %s %s", trName, diff.PrintWantGot(d))
+		}
+	}
+}
+
+func TestReconciler_GeneratedTasks(t *testing.T) {
+	names.TestingSeed()
+	pipelineSpec := `
+    tasks:
+    - name: affected-modules
+      tasksFromResult: tasks
+      taskSpec:
+        results:
+        - name: tasks
+        steps:
+        - image: foo:latest
+`
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: pr
+  namespace: foo
+spec:
+  serviceAccountName: test-sa
+  pipelineSpec:`+pipelineSpec+`
+status:
+  pipelineSpec:`+pipelineSpec+`
+  childReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: TaskRun
+    name: pr-affected-modules
+    pipelineTaskName: affected-modules
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("pr-affected-modules", "foo", "pr", "pr", "affected-modules", true),
+		`
+spec:
+  serviceAccountName: test-sa
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+    reason: Succeeded
+  taskResults:
+  - name: tasks
+    type: string
+    value: |
+      - name: build-api
+        taskSpec:
+          steps:
+          - image: foo:latest
+      - name: build-web
+        runAfter: [build-api]
+        taskSpec:
+          steps:
+          - image: foo:latest
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	pr, _ := prt.reconcileRun("foo", "pr", nil, false)
+
+	// The generated tasks are added to the status, and the PipelineRun keeps running
+	if !pr.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("expected the PipelineRun to be running, got %v", pr.Status.GetCondition(apis.ConditionSucceeded))
+	}
+	var generated []string
+	for _, pt := range pr.Status.GeneratedTasks {
+		generated = append(generated, fmt.Sprintf("%s after %v", pt.Name, pt.RunAfter))
+	}
+	want := []string{"build-api after [affected-modules]", "build-web after [build-api affected-modules]"}
+	if d := cmp.Diff(want, generated); d != "" {
+		t.Errorf("generated tasks %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconciler_RunGeneratedTasks(t *testing.T) {
+	names.TestingSeed()
+	pipelineSpec := `
+    tasks:
+    - name: affected-modules
+      tasksFromResult: tasks
+      taskSpec:
+        results:
+        - name: tasks
+        steps:
+        - image: foo:latest
+`
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
   name: pr
   namespace: foo
-  annotations: {}
-  labels:
-    tekton.dev/pipeline: p
 spec:
   serviceAccountName: test-sa
-  pipelineRef:
-    name: p
+  pipelineSpec:`+pipelineSpec+`
 status:
-  pipelineSpec:
-    tasks:
-    - name: platforms-and-browsers
-      retries: 1
-      taskRef:
-        name: mytask
-        kind: Task
-      matrix:
-        params:
-          - name: platform
-            value:
-              - linux
-              - mac
-      params:
-        - name: browser
-          value: chrome
-  conditions:
-  - type: Succeeded
-    status: "Unknown"
-    reason: "Running"
-    message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
+  pipelineSpec:`+pipelineSpec+`
   childReferences:
   - apiVersion: tekton.dev/v1beta1
     kind: TaskRun
-    name: pr-platforms-and-browsers-0
-    pipelineTaskName: platforms-and-browsers
-  - apiVersion: tekton.dev/v1beta1
-    kind: TaskRun
-    name: pr-platforms-and-browsers-1
-    pipelineTaskName: platforms-and-browsers
-`),
-		},
-		expectedPipelineRun: parse.MustParseV1beta1PipelineRun(t, `
+    name: pr-affected-modules
+    pipelineTaskName: affected-modules
+  generatedTasks:
+  - name: build-api
+    runAfter: [affected-modules]
+    taskSpec:
+      steps:
+      - image: foo:latest
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("pr-affected-modules", "foo", "pr", "pr", "affected-modules", true),
+		`
+spec:
+  serviceAccountName: test-sa
+status:
+  conditions:
+  - type: Succeeded
+    status: "True"
+    reason: Succeeded
+  taskResults:
+  - name: tasks
+    type: string
+    value: |
+      - name: build-api
+        taskSpec:
+          steps:
+          - image: foo:latest
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
+
+	pr, clients := prt.reconcileRun("foo", "pr", nil, false)
+
+	if _, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(prt.TestAssets.Ctx, "pr-build-api", metav1.GetOptions{}); err != nil {
+		t.Fatalf("expected to see the TaskRun of the generated task created: %v", err)
+	}
+	if len(pr.Status.GeneratedTasks) != 1 {
+		t.Errorf("expected the generated task not to be generated again, got %v", pr.Status.GeneratedTasks)
+	}
+	if got := len(pr.Status.PipelineSpec.Tasks); got != 2 {
+		t.Errorf("expected the generated task in the pipeline spec of the status, got %d tasks", got)
+	}
+}
+
+func TestReconciler_InvalidGeneratedTasks(t *testing.T) {
+	names.TestingSeed()
+	pipelineSpec := `
+    tasks:
+    - name: affected-modules
+      tasksFromResult: tasks
+      taskSpec:
+        results:
+        - name: tasks
+        steps:
+        - image: foo:latest
+`
+	prs := []*v1beta1.PipelineRun{parse.MustParseV1beta1PipelineRun(t, `
 metadata:
   name: pr
   namespace: foo
-  annotations: {}
-  labels:
-    tekton.dev/pipeline: p
 spec:
   serviceAccountName: test-sa
-  pipelineRef:
-    name: p
+  pipelineSpec:`+pipelineSpec+`
 status:
-  pipelineSpec:
-    tasks:
-    - name: platforms-and-browsers
-      retries: 1
-      taskRef:
-        name: mytask
-        kind: Task
-      matrix:
-        params:
-          - name: platform
-            value:
-              - linux
-              - mac
-      params:
-        - name: browser
-          value: chrome
-  conditions:
-  - type: Succeeded
-    status: "Unknown"
-    reason: "Running"
-    message: "Tasks Completed: 0 (Failed: 0, Cancelled 0), Incomplete: 1, Skipped: 0"
+  pipelineSpec:`+pipelineSpec+`
   childReferences:
   - apiVersion: tekton.dev/v1beta1
     kind: TaskRun
-    name: pr-platforms-and-browsers-0
-    pipelineTaskName: platforms-and-browsers
-  - apiVersion: tekton.dev/v1beta1
-    kind: TaskRun
-    name: pr-platforms-and-browsers-1
-    pipelineTaskName: platforms-and-browsers
-  provenance:
-    featureFlags:
-      RunningInEnvWithInjectedSidecars: true
-      EnableTektonOCIBundles: true
-      EnableAPIFields: "alpha"
-      AwaitSidecarReadiness: true
-      VerificationNoMatchPolicy: "ignore"
-      EnableProvenanceInStatus: true
-      ResultExtractionMethod: "termination-message"
-      MaxResultSize: 4096
-`),
-		expectedTaskRuns: []*v1beta1.TaskRun{
-			mustParseTaskRunWithObjectMeta(t,
-				taskRunObjectMeta("pr-platforms-and-browsers-0", "foo",
-					"pr", "p", "platforms-and-browsers", false),
-				`
-spec:
-  retries: 1
-  params:
-  - name: platform
-    value: linux
-  - name: browser
-    value: chrome
-  serviceAccountName: test-sa
-  taskRef:
-    name: mytask
-  timeout: 1h0m0s
-status:
-  conditions:
-  - type: Succeeded
-    status: "Unknown"
-  retriesStatus:
-  - conditions:
-    - status: "False"
-      type: Succeeded
-`),
-			mustParseTaskRunWithObjectMeta(t,
-				taskRunObjectMeta("pr-platforms-and-browsers-1", "foo",
-					"pr", "p", "platforms-and-browsers", false),
-				`
+    name: pr-affected-modules
+    pipelineTaskName: affected-modules
+`)}
+	trs := []*v1beta1.TaskRun{mustParseTaskRunWithObjectMeta(t,
+		taskRunObjectMeta("pr-affected-modules", "foo", "pr", "pr", "affected-modules", true),
+		`
 spec:
-  retries: 1
-  params:
-  - name: platform
-    value: mac
-  - name: browser
-    value: chrome
   serviceAccountName: test-sa
-  taskRef:
-    name: mytask
-  timeout: 1h0m0s
 status:
   conditions:
   - type: Succeeded
-    status: "Unknown"
-  retriesStatus:
-  - conditions:
-    - status: "False"
-      type: Succeeded
-`),
-		},
-	}}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			d := test.Data{
-				Tasks:        []*v1beta1.Task{task},
-				TaskRuns:     tt.trs,
-				PipelineRuns: tt.prs,
-				ConfigMaps:   cms,
-			}
-			prt := newPipelineRunTest(t, d)
-			defer prt.Cancel()
-
-			_, clients := prt.reconcileRun("foo", "pr", []string{}, false)
-			taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{
-				LabelSelector: "tekton.dev/pipelineRun=pr,tekton.dev/pipelineTask=platforms-and-browsers",
-				Limit:         1,
-			})
-			if err != nil {
-				t.Fatalf("Failure to list TaskRun's %s", err)
-			}
-
-			if len(taskRuns.Items) != 2 {
-				t.Fatalf("Expected 2 TaskRuns got %d", len(taskRuns.Items))
-			}
-
-			for i := range taskRuns.Items {
-				expectedTaskRun := tt.expectedTaskRuns[i]
-				if d := cmp.Diff(expectedTaskRun, &taskRuns.Items[i], ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime); d != "" {
-					t.Errorf("expected to see TaskRun %v created. Diff %s", tt.expectedTaskRuns[i].Name, diff.PrintWantGot(d))
-				}
-			}
+    status: "True"
+    reason: Succeeded
+  taskResults:
+  - name: tasks
+    type: string
+    value: |
+      - name: affected-modules
+        taskSpec:
+          steps:
+          - image: foo:latest
+`)}
+	cms := []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())}
+	d := test.Data{
+		PipelineRuns: prs,
+		TaskRuns:     trs,
+		ConfigMaps:   cms,
+		ServiceAccounts: []*corev1.ServiceAccount{{
+			ObjectMeta: metav1.ObjectMeta{Name: prs[0].Spec.ServiceAccountName, Namespace: "foo"},
+		}},
+	}
+	prt := newPipelineRunTest(t, d)
+	defer prt.Cancel()
 
-			pipelineRun, err := clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "pr", metav1.GetOptions{})
-			if err != nil {
-				t.Fatalf("Got an error getting reconciled run out of fake client: %s", err)
-			}
+	pr, _ := prt.reconcileRun("foo", "pr", nil, true)
 
-			if d := cmp.Diff(tt.expectedPipelineRun, pipelineRun, ignoreResourceVersion, ignoreTypeMeta, ignoreLastTransitionTime, ignoreStartTime, cmpopts.SortSlices(lessChildReferences), cmpopts.EquateEmpty()); d != "" {
-				t.Errorf("expected PipelineRun was not created. Diff %s", diff.PrintWantGot(d))
-			}
-		})
+	checkPipelineRunConditionStatusAndReason(t, pr, corev1.ConditionFalse, ReasonInvalidGeneratedTasks)
+	if len(pr.Status.GeneratedTasks) != 0 {
+		t.Errorf("expected no generated task, got %v", pr.Status.GeneratedTasks)
 	}
 }
 
@@ -10967,6 +13495,8 @@ status:
       EnableProvenanceInStatus: true
       ResultExtractionMethod: "termination-message"
       MaxResultSize: 4096
+    serviceAccounts:
+    - serviceAccount: test-sa
 `),
 	}, {
 		name:     "p-finally",
@@ -18,11 +18,14 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/runnamespace"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/substitution"
 )
@@ -47,6 +50,23 @@ var (
 
 // ApplyParameters applies the params from a PipelineRun.Params to a PipelineSpec.
 func ApplyParameters(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
+	stringReplacements, arrayReplacements, objectReplacements := paramReplacements(ctx, p, pr)
+	return ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
+}
+
+// GetDisplayNameAndDescription returns the display name and the description of the PipelineRun
+// with its params and context variables substituted.
+func GetDisplayNameAndDescription(ctx context.Context, p *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.PipelineRun) (string, string) {
+	stringReplacements, _, _ := paramReplacements(ctx, p, pr)
+	for k, v := range GetContextReplacements(pipelineName, pr) {
+		stringReplacements[k] = v
+	}
+	return substitution.ApplyReplacements(pr.Spec.DisplayName, stringReplacements), substitution.ApplyReplacements(pr.Spec.Description, stringReplacements)
+}
+
+// paramReplacements returns the replacements of the params of the PipelineSpec, with the values
+// from the PipelineRun overriding their defaults.
+func paramReplacements(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) (map[string]string, map[string][]string, map[string]map[string]string) {
 	// This assumes that the PipelineRun inputs have been validated against what the Pipeline requests.
 
 	// stringReplacements is used for standard single-string stringReplacements,
@@ -95,7 +115,7 @@ func ApplyParameters(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.P
 		objectReplacements[k] = v
 	}
 
-	return ApplyReplacements(p, stringReplacements, arrayReplacements, objectReplacements)
+	return stringReplacements, arrayReplacements, objectReplacements
 }
 
 func paramsFromPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) (map[string]string, map[string][]string, map[string]map[string]string) {
@@ -135,11 +155,17 @@ func paramsFromPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) (map[st
 
 // GetContextReplacements returns the pipelineRun context which can be used to replace context variables in the specifications
 func GetContextReplacements(pipelineName string, pr *v1beta1.PipelineRun) map[string]string {
+	var runNamespace string
+	if runnamespace.IsRequested(pr) {
+		runNamespace = runnamespace.GetName(pr)
+	}
 	return map[string]string{
-		"context.pipelineRun.name":      pr.Name,
-		"context.pipeline.name":         pipelineName,
-		"context.pipelineRun.namespace": pr.Namespace,
-		"context.pipelineRun.uid":       string(pr.ObjectMeta.UID),
+		"context.pipelineRun.name":         pr.Name,
+		"context.pipeline.name":            pipelineName,
+		"context.pipelineRun.namespace":    pr.Namespace,
+		"context.pipelineRun.uid":          string(pr.ObjectMeta.UID),
+		"context.pipelineRun.runNamespace": runNamespace,
+		"context.pipelineRun.creator":      pr.Annotations[pipeline.CreatorAnnotationKey],
 	}
 }
 
@@ -153,8 +179,10 @@ func ApplyContexts(spec *v1beta1.PipelineSpec, pipelineName string, pr *v1beta1.
 // Uses "0" as a default if a value is not available.
 func ApplyPipelineTaskContexts(pt *v1beta1.PipelineTask) *v1beta1.PipelineTask {
 	pt = pt.DeepCopy()
+	// The retries are validated before the PipelineTask runs, the invalid ones default to 0.
+	retries, _ := pt.GetRetries()
 	replacements := map[string]string{
-		"context.pipelineTask.retries": strconv.Itoa(pt.Retries),
+		"context.pipelineTask.retries": strconv.Itoa(retries),
 	}
 	pt.Params = pt.Params.ReplaceVariables(replacements, map[string][]string{}, map[string]map[string]string{})
 	if pt.IsMatrixed() {
@@ -166,7 +194,8 @@ func ApplyPipelineTaskContexts(pt *v1beta1.PipelineTask) *v1beta1.PipelineTask {
 	return pt
 }
 
-// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params and Pipeline.WhenExpressions in targets
+// ApplyTaskResults applies the ResolvedResultRef to each PipelineTask.Params, PipelineTask.Workspaces' subPath,
+// PipelineTask.ResultFiles' value, PipelineTask.RetriesFrom, PipelineTask.TimeoutFrom and Pipeline.WhenExpressions in targets
 func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResultRefs) {
 	stringReplacements := resolvedResultRefs.getStringReplacements()
 	arrayReplacements := resolvedResultRefs.getArrayReplacements()
@@ -175,7 +204,10 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
 			pipelineTask.Params = pipelineTask.Params.ReplaceVariables(stringReplacements, arrayReplacements, objectReplacements)
-			if pipelineTask.IsMatrixed() {
+			if pipelineTask.IsLooped() {
+				// The items of a looping task can be the elements of an array result
+				pipelineTask.Matrix.Params = pipelineTask.Matrix.Params.ReplaceVariables(stringReplacements, arrayReplacements, nil)
+			} else if pipelineTask.IsMatrixed() {
 				// Only string replacements from string, array or object results are supported
 				// We plan to support array replacements from array results soon (#5925)
 				pipelineTask.Matrix.Params = pipelineTask.Matrix.Params.ReplaceVariables(stringReplacements, nil, nil)
@@ -184,6 +216,12 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 					pipelineTask.Matrix.Include[i].Params = pipelineTask.Matrix.Include[i].Params.ReplaceVariables(stringReplacements, nil, nil)
 				}
 			}
+			for i := range pipelineTask.Workspaces {
+				pipelineTask.Workspaces[i].SubPath = substitution.ApplyReplacements(pipelineTask.Workspaces[i].SubPath, stringReplacements)
+			}
+			applyResultFilesReplacements(pipelineTask.ResultFiles, stringReplacements, arrayReplacements, objectReplacements)
+			pipelineTask.RetriesFrom = substitution.ApplyReplacements(pipelineTask.RetriesFrom, stringReplacements)
+			pipelineTask.TimeoutFrom = substitution.ApplyReplacements(pipelineTask.TimeoutFrom, stringReplacements)
 			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceVariables(stringReplacements, arrayReplacements)
 			if pipelineTask.TaskRef != nil && pipelineTask.TaskRef.Params != nil {
 				pipelineTask.TaskRef.Params = pipelineTask.TaskRef.Params.ReplaceVariables(stringReplacements, arrayReplacements, objectReplacements)
@@ -193,13 +231,96 @@ func ApplyTaskResults(targets PipelineRunState, resolvedResultRefs ResolvedResul
 	}
 }
 
+// ApplyLoops fans out the PipelineTasks looping over items like matrixed PipelineTasks, with a
+// matrix holding the items in a single param, so that a run is created per item. The array params
+// withParam refers to are substituted here, and the array results when the PipelineTask is scheduled.
+func ApplyLoops(ctx context.Context, p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.PipelineSpec {
+	p = p.DeepCopy()
+	_, arrayReplacements, _ := paramReplacements(ctx, p, pr)
+	for i := range p.Tasks {
+		applyLoop(&p.Tasks[i], arrayReplacements)
+	}
+	for i := range p.Finally {
+		applyLoop(&p.Finally[i], arrayReplacements)
+	}
+	return p
+}
+
+func applyLoop(pt *v1beta1.PipelineTask, arrayReplacements map[string][]string) {
+	if !pt.IsLooped() {
+		return
+	}
+	items := v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: pt.WithItems}
+	if pt.WithParam != "" {
+		items = v1beta1.ParamValue{Type: v1beta1.ParamTypeString, StringVal: pt.WithParam}
+		items.ApplyReplacements(nil, arrayReplacements, nil)
+	}
+	pt.Matrix = &v1beta1.Matrix{Params: v1beta1.Params{{Name: v1beta1.LoopItemVariable, Value: items}}}
+}
+
+// ApplyLoopItem replaces the references to $(item) in the params of a looping PipelineTask with the
+// item of one of its runs, held by the param of the combination of its matrix.
+func ApplyLoopItem(params v1beta1.Params, combination v1beta1.Params) v1beta1.Params {
+	replacements := map[string]string{}
+	for _, p := range combination {
+		if p.Name == v1beta1.LoopItemVariable {
+			replacements[v1beta1.LoopItemVariable] = p.Value.StringVal
+		}
+	}
+	return params.ReplaceVariables(replacements, nil, nil)
+}
+
+// PropagateParamsToReferencedTasks passes the params of the PipelineRun to the referenced Tasks
+// of the state declaring params of the same names and types, which their PipelineTasks don't
+// pass. The params passed by the PipelineTasks take precedence over the ones of the PipelineRun,
+// which take precedence over the defaults of the params of the Pipeline, which take precedence
+// over the defaults of the params of the Tasks.
+func PropagateParamsToReferencedTasks(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun, state PipelineRunState) {
+	values := map[string]v1beta1.ParamValue{}
+	for _, param := range p.Params {
+		if param.Default != nil {
+			values[param.Name] = *param.Default
+		}
+	}
+	for _, param := range pr.Spec.Params {
+		values[param.Name] = param.Value
+	}
+	for _, rpt := range state {
+		if rpt.PipelineTask == nil || rpt.PipelineTask.TaskRef == nil || rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+			continue
+		}
+		passed := rpt.PipelineTask.Params.ExtractNames().Union(rpt.PipelineTask.Matrix.GetAllParams().ExtractNames())
+		var propagated v1beta1.Params
+		for _, param := range rpt.ResolvedTask.TaskSpec.Params {
+			value, ok := values[param.Name]
+			if !ok || passed.Has(param.Name) || value.Type != paramType(param) {
+				continue
+			}
+			propagated = append(propagated, v1beta1.Param{Name: param.Name, Value: value})
+		}
+		if len(propagated) > 0 {
+			pipelineTask := rpt.PipelineTask.DeepCopy()
+			pipelineTask.Params = append(pipelineTask.Params, propagated...)
+			rpt.PipelineTask = pipelineTask
+		}
+	}
+}
+
+// paramType returns the type of a param spec, which is a string when unset.
+func paramType(param v1beta1.ParamSpec) v1beta1.ParamType {
+	if param.Type == "" {
+		return v1beta1.ParamTypeString
+	}
+	return param.Type
+}
+
 // ApplyPipelineTaskStateContext replaces context variables referring to execution status with the specified status
-func ApplyPipelineTaskStateContext(state PipelineRunState, replacements map[string]string) {
+func ApplyPipelineTaskStateContext(state PipelineRunState, replacements map[string]string, arrayReplacements map[string][]string) {
 	for _, resolvedPipelineRunTask := range state {
 		if resolvedPipelineRunTask.PipelineTask != nil {
 			pipelineTask := resolvedPipelineRunTask.PipelineTask.DeepCopy()
-			pipelineTask.Params = pipelineTask.Params.ReplaceVariables(replacements, nil, nil)
-			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceVariables(replacements, nil)
+			pipelineTask.Params = pipelineTask.Params.ReplaceVariables(replacements, arrayReplacements, nil)
+			pipelineTask.WhenExpressions = pipelineTask.WhenExpressions.ReplaceVariables(replacements, arrayReplacements)
 			if pipelineTask.TaskRef != nil && pipelineTask.TaskRef.Params != nil {
 				pipelineTask.TaskRef.Params = pipelineTask.TaskRef.Params.ReplaceVariables(replacements, nil, nil)
 			}
@@ -215,7 +336,8 @@ func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	replacements := map[string]string{}
 	for _, declaredWorkspace := range p.Workspaces {
 		key := fmt.Sprintf("workspaces.%s.bound", declaredWorkspace.Name)
-		replacements[key] = "false"
+		// ephemeral workspaces are always bound, to an emptyDir when not provided
+		replacements[key] = strconv.FormatBool(declaredWorkspace.Type == v1beta1.WorkspaceTypeEphemeral)
 	}
 	for _, boundWorkspace := range pr.Spec.Workspaces {
 		key := fmt.Sprintf("workspaces.%s.bound", boundWorkspace.Name)
@@ -224,6 +346,44 @@ func ApplyWorkspaces(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) *v1beta1.
 	return ApplyReplacements(p, replacements, map[string][]string{}, map[string]map[string]string{})
 }
 
+// ApplyVars replaces the references to the variables of the given pipeline spec with their
+// values, so that the params and the results the variables reference are substituted and
+// resolved like the ones the tasks reference directly.
+func ApplyVars(p *v1beta1.PipelineSpec) *v1beta1.PipelineSpec {
+	if len(p.Vars) == 0 {
+		return p
+	}
+	replacements := map[string]string{}
+	for name, value := range p.Vars {
+		replacements["vars."+name] = value
+	}
+	return ApplyReplacements(p, replacements, map[string][]string{}, map[string]map[string]string{})
+}
+
+// applyResultFilesReplacements replaces the variables in the values of the result files. The
+// arrays and objects referenced as a whole, e.g. $(tasks.a.results.images[*]), are written as JSON.
+func applyResultFilesReplacements(rfs []v1beta1.ResultFile, stringReplacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) {
+	if len(rfs) == 0 {
+		return
+	}
+	replacements := make(map[string]string, len(stringReplacements)+len(arrayReplacements)+len(objectReplacements))
+	for k, v := range stringReplacements {
+		replacements[k] = v
+	}
+	for k, v := range arrayReplacements {
+		// Marshalling strings never fails
+		b, _ := json.Marshal(v)
+		replacements[k+"[*]"] = string(b)
+	}
+	for k, v := range objectReplacements {
+		b, _ := json.Marshal(v)
+		replacements[k+"[*]"] = string(b)
+	}
+	for i := range rfs {
+		rfs[i].Value = substitution.ApplyReplacements(rfs[i].Value, replacements)
+	}
+}
+
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
 func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string, arrayReplacements map[string][]string, objectReplacements map[string]map[string]string) *v1beta1.PipelineSpec {
 	p = p.DeepCopy()
@@ -239,7 +399,16 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 		for j := range p.Tasks[i].Workspaces {
 			p.Tasks[i].Workspaces[j].SubPath = substitution.ApplyReplacements(p.Tasks[i].Workspaces[j].SubPath, replacements)
 		}
+		for j := range p.Tasks[i].WithItems {
+			p.Tasks[i].WithItems[j] = substitution.ApplyReplacements(p.Tasks[i].WithItems[j], replacements)
+		}
+		applyResultFilesReplacements(p.Tasks[i].ResultFiles, replacements, arrayReplacements, objectReplacements)
+		p.Tasks[i].RetriesFrom = substitution.ApplyReplacements(p.Tasks[i].RetriesFrom, replacements)
+		p.Tasks[i].TimeoutFrom = substitution.ApplyReplacements(p.Tasks[i].TimeoutFrom, replacements)
 		p.Tasks[i].WhenExpressions = p.Tasks[i].WhenExpressions.ReplaceVariables(replacements, arrayReplacements)
+		if p.Tasks[i].RepeatUntil != nil {
+			p.Tasks[i].RepeatUntil.When = p.Tasks[i].RepeatUntil.When.ReplaceVariables(replacements, arrayReplacements)
+		}
 		if p.Tasks[i].TaskRef != nil && p.Tasks[i].TaskRef.Params != nil {
 			p.Tasks[i].TaskRef.Params = p.Tasks[i].TaskRef.Params.ReplaceVariables(replacements, arrayReplacements, objectReplacements)
 		}
@@ -257,13 +426,26 @@ func ApplyReplacements(p *v1beta1.PipelineSpec, replacements map[string]string,
 		for j := range p.Finally[i].Workspaces {
 			p.Finally[i].Workspaces[j].SubPath = substitution.ApplyReplacements(p.Finally[i].Workspaces[j].SubPath, replacements)
 		}
+		for j := range p.Finally[i].WithItems {
+			p.Finally[i].WithItems[j] = substitution.ApplyReplacements(p.Finally[i].WithItems[j], replacements)
+		}
+		applyResultFilesReplacements(p.Finally[i].ResultFiles, replacements, arrayReplacements, objectReplacements)
+		p.Finally[i].RetriesFrom = substitution.ApplyReplacements(p.Finally[i].RetriesFrom, replacements)
+		p.Finally[i].TimeoutFrom = substitution.ApplyReplacements(p.Finally[i].TimeoutFrom, replacements)
 		p.Finally[i].WhenExpressions = p.Finally[i].WhenExpressions.ReplaceVariables(replacements, arrayReplacements)
+		if p.Finally[i].RepeatUntil != nil {
+			p.Finally[i].RepeatUntil.When = p.Finally[i].RepeatUntil.When.ReplaceVariables(replacements, arrayReplacements)
+		}
 		if p.Finally[i].TaskRef != nil && p.Finally[i].TaskRef.Params != nil {
 			p.Finally[i].TaskRef.Params = p.Finally[i].TaskRef.Params.ReplaceVariables(replacements, arrayReplacements, objectReplacements)
 		}
 		p.Finally[i] = propagateParams(p.Finally[i], replacements, arrayReplacements, objectReplacements)
 	}
 
+	for i := range p.Workspaces {
+		p.Workspaces[i].CacheKey = substitution.ApplyReplacements(p.Workspaces[i].CacheKey, replacements)
+	}
+
 	return p
 }
 
@@ -25,6 +25,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resources "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/test/diff"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
@@ -66,6 +67,94 @@ func TestApplyParameters(t *testing.T) {
 				},
 			}},
 		},
+	}, {
+		name: "parameters in result files",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "greeting", Type: v1beta1.ParamTypeString},
+				{Name: "names", Type: v1beta1.ParamTypeArray},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/greeting",
+					Value: "$(params.greeting)",
+				}, {
+					Path:  "/inputs/names.json",
+					Value: "$(params.names[*])",
+				}},
+			}},
+		},
+		params: v1beta1.Params{
+			{Name: "greeting", Value: *v1beta1.NewStructuredValues("hello")},
+			{Name: "names", Value: *v1beta1.NewStructuredValues("a", "b")},
+		},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "greeting", Type: v1beta1.ParamTypeString},
+				{Name: "names", Type: v1beta1.ParamTypeArray},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/greeting",
+					Value: "hello",
+				}, {
+					Path:  "/inputs/names.json",
+					Value: `["a","b"]`,
+				}},
+			}},
+		},
+	}, {
+		name: "parameters in retries and timeout",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "retry-count", Type: v1beta1.ParamTypeString, Default: v1beta1.NewStructuredValues("2")},
+				{Name: "timeout", Type: v1beta1.ParamTypeString},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				RetriesFrom: "$(params.retry-count)",
+				TimeoutFrom: "$(params.timeout)",
+			}},
+			Finally: []v1beta1.PipelineTask{{
+				RetriesFrom: "$(params.retry-count)",
+			}},
+		},
+		params: v1beta1.Params{{Name: "timeout", Value: *v1beta1.NewStructuredValues("10m")}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "retry-count", Type: v1beta1.ParamTypeString, Default: v1beta1.NewStructuredValues("2")},
+				{Name: "timeout", Type: v1beta1.ParamTypeString},
+			},
+			Tasks: []v1beta1.PipelineTask{{
+				RetriesFrom: "2",
+				TimeoutFrom: "10m",
+			}},
+			Finally: []v1beta1.PipelineTask{{
+				RetriesFrom: "2",
+			}},
+		},
+	}, {
+		name: "parameter in cache key of workspace",
+		original: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "go-version", Type: v1beta1.ParamTypeString},
+			},
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name:     "go-cache",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "go-$(params.go-version)",
+			}},
+		},
+		params: v1beta1.Params{{Name: "go-version", Value: *v1beta1.NewStructuredValues("1.19")}},
+		expected: v1beta1.PipelineSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "go-version", Type: v1beta1.ParamTypeString},
+			},
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name:     "go-cache",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "go-1.19",
+			}},
+		},
 	}, {
 		name: "parameter propagation string no task or task default winner pipeline",
 		original: v1beta1.PipelineSpec{
@@ -2319,6 +2408,39 @@ func TestApplyTaskResults_MinimalExpression(t *testing.T) {
 				}},
 			},
 		}},
+	}, {
+		name: "Test result substitution on minimal variable substitution expression - retries and timeout",
+		resolvedResultRefs: resources.ResolvedResultRefs{{
+			Value: *v1beta1.NewStructuredValues("3"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "retries",
+			},
+			FromTaskRun: "aTaskRun",
+		}, {
+			Value: *v1beta1.NewStructuredValues("90"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "minutes",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "bTask",
+				TaskRef:     &v1beta1.TaskRef{Name: "bTask"},
+				RetriesFrom: "$(tasks.aTask.results.retries)",
+				TimeoutFrom: "$(tasks.aTask.results.minutes)m",
+			},
+		}},
+		want: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:        "bTask",
+				TaskRef:     &v1beta1.TaskRef{Name: "bTask"},
+				RetriesFrom: "3",
+				TimeoutFrom: "90m",
+			},
+		}},
 	}, {
 		name: "Test array indexing result substitution on minimal variable substitution expression - params",
 		resolvedResultRefs: resources.ResolvedResultRefs{{
@@ -2788,6 +2910,85 @@ func TestApplyTaskResults_MinimalExpression(t *testing.T) {
 				},
 			},
 		}},
+	}, {
+		name: "Test result substitution on minimal variable substitution expression - result files",
+		resolvedResultRefs: resources.ResolvedResultRefs{{
+			Value: *v1beta1.NewStructuredValues(`{"replicas": 3}`),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/config.json",
+					Value: "$(tasks.aTask.results.aResult)",
+				}},
+			},
+		}},
+		want: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/config.json",
+					Value: `{"replicas": 3}`,
+				}},
+			},
+		}},
+	}, {
+		name: "Test result substitution of whole arrays and objects in result files",
+		resolvedResultRefs: resources.ResolvedResultRefs{{
+			Value: *v1beta1.NewStructuredValues("a", "b"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "images",
+			},
+			FromTaskRun: "aTaskRun",
+		}, {
+			Value: *v1beta1.NewObject(map[string]string{"replicas": "3"}),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "config",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/images.json",
+					Value: "$(tasks.aTask.results.images[*])",
+				}, {
+					Path:  "/inputs/config.json",
+					Value: "$(tasks.aTask.results.config[*])",
+				}, {
+					Path:  "/inputs/first",
+					Value: "$(tasks.aTask.results.images[0]) $(tasks.aTask.results.config.replicas)",
+				}},
+			},
+		}},
+		want: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				ResultFiles: []v1beta1.ResultFile{{
+					Path:  "/inputs/images.json",
+					Value: `["a","b"]`,
+				}, {
+					Path:  "/inputs/config.json",
+					Value: `{"replicas":"3"}`,
+				}, {
+					Path:  "/inputs/first",
+					Value: "a 3",
+				}},
+			},
+		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			resources.ApplyTaskResults(tt.targets, tt.resolvedResultRefs)
@@ -3148,6 +3349,38 @@ func TestApplyTaskResults_EmbeddedExpression(t *testing.T) {
 				},
 			},
 		}},
+	}, {
+		name: "Test result substitution on embedded variable substitution expression - workspace subPath",
+		resolvedResultRefs: resources.ResolvedResultRefs{{
+			Value: *v1beta1.NewStructuredValues("aResultValue"),
+			ResultReference: v1beta1.ResultRef{
+				PipelineTask: "aTask",
+				Result:       "aResult",
+			},
+			FromTaskRun: "aTaskRun",
+		}},
+		targets: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+					Name:      "source",
+					Workspace: "shared",
+					SubPath:   "builds/$(tasks.aTask.results.aResult)",
+				}},
+			},
+		}},
+		want: resources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "bTask",
+				TaskRef: &v1beta1.TaskRef{Name: "bTask"},
+				Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+					Name:      "source",
+					Workspace: "shared",
+					SubPath:   "builds/aResultValue",
+				}},
+			},
+		}},
 	}} {
 		t.Run(tt.name, func(t *testing.T) {
 			resources.ApplyTaskResults(tt.targets, tt.resolvedResultRefs)
@@ -3213,6 +3446,25 @@ func TestContext(t *testing.T) {
 		},
 		original: v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.uid)-1")},
 		expected: v1beta1.Param{Value: *v1beta1.NewStructuredValues("-1")},
+	}, {
+		description: "context.pipelineRun.runNamespace defined",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "name",
+				Namespace:   "namespace",
+				UID:         "uid",
+				Annotations: map[string]string{"pipeline.tekton.dev/run-namespace": "true"},
+			},
+		},
+		original: v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.runNamespace)")},
+		expected: v1beta1.Param{Value: *v1beta1.NewStructuredValues("tekton-run-uid")},
+	}, {
+		description: "context.pipelineRun.runNamespace undefined",
+		pr: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+		},
+		original: v1beta1.Param{Value: *v1beta1.NewStructuredValues("$(context.pipelineRun.runNamespace)-1")},
+		expected: v1beta1.Param{Value: *v1beta1.NewStructuredValues("-1")},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			orig := &v1beta1.Pipeline{
@@ -3236,6 +3488,43 @@ func TestContext(t *testing.T) {
 	}
 }
 
+func TestGetDisplayNameAndDescription(t *testing.T) {
+	ps := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "branch",
+			Type:    v1beta1.ParamTypeString,
+			Default: v1beta1.NewStructuredValues("main"),
+		}, {
+			Name: "repo",
+			Type: v1beta1.ParamTypeObject,
+		}, {
+			Name: "targets",
+			Type: v1beta1.ParamTypeArray,
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-x7k2p", Namespace: "ci"},
+		Spec: v1beta1.PipelineRunSpec{
+			DisplayName: "Nightly build of $(params.repo.name) $(params.branch)",
+			Description: "Built for $(params.targets[1]) by $(context.pipelineRun.name) of $(context.pipeline.name), leaving $(params.unknown)",
+			Params: v1beta1.Params{{
+				Name:  "repo",
+				Value: *v1beta1.NewObject(map[string]string{"name": "pipeline"}),
+			}, {
+				Name:  "targets",
+				Value: *v1beta1.NewStructuredValues("linux", "darwin"),
+			}},
+		},
+	}
+	displayName, description := resources.GetDisplayNameAndDescription(context.Background(), ps, "nightly", pr)
+	if d := cmp.Diff("Nightly build of pipeline main", displayName); d != "" {
+		t.Errorf("displayName %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff("Built for darwin by nightly-x7k2p of nightly, leaving $(params.unknown)", description); d != "" {
+		t.Errorf("description %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyPipelineTaskContexts(t *testing.T) {
 	for _, tc := range []struct {
 		description string
@@ -3333,6 +3622,136 @@ func TestApplyPipelineTaskContexts(t *testing.T) {
 	}
 }
 
+func TestApplyLoops(t *testing.T) {
+	p := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{{Name: "browsers", Type: v1beta1.ParamTypeArray}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:      "platforms",
+			WithItems: []string{"linux", "mac"},
+		}, {
+			Name:      "browsers",
+			WithParam: "$(params.browsers[*])",
+		}, {
+			Name:      "lint",
+			WithParam: "$(tasks.list.results.files[*])",
+		}, {
+			Name: "not-looped",
+		}},
+	}
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Params: v1beta1.Params{{Name: "browsers", Value: *v1beta1.NewStructuredValues("chrome", "firefox")}},
+		},
+	}
+	want := []*v1beta1.Matrix{{
+		Params: v1beta1.Params{{Name: "item", Value: *v1beta1.NewStructuredValues("linux", "mac")}},
+	}, {
+		Params: v1beta1.Params{{Name: "item", Value: *v1beta1.NewStructuredValues("chrome", "firefox")}},
+	}, {
+		Params: v1beta1.Params{{Name: "item", Value: *v1beta1.NewStructuredValues("$(tasks.list.results.files[*])")}},
+	}, nil}
+	got := resources.ApplyLoops(context.Background(), p, pr)
+	for i, pt := range got.Tasks {
+		if d := cmp.Diff(want[i], pt.Matrix); d != "" {
+			t.Errorf("matrix of pipeline task %s %s", pt.Name, diff.PrintWantGot(d))
+		}
+	}
+	if p.Tasks[0].Matrix != nil {
+		t.Errorf("expected ApplyLoops not to modify the pipeline spec")
+	}
+}
+
+func TestApplyLoopItem(t *testing.T) {
+	params := v1beta1.Params{
+		{Name: "platform", Value: *v1beta1.NewStructuredValues("$(item)")},
+		{Name: "target", Value: *v1beta1.NewStructuredValues("build-$(item)", "test-$(item)")},
+		{Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")},
+	}
+	combination := v1beta1.Params{{Name: "item", Value: *v1beta1.NewStructuredValues("linux")}}
+	want := v1beta1.Params{
+		{Name: "platform", Value: *v1beta1.NewStructuredValues("linux")},
+		{Name: "target", Value: *v1beta1.NewStructuredValues("build-linux", "test-linux")},
+		{Name: "arch", Value: *v1beta1.NewStructuredValues("amd64")},
+	}
+	if d := cmp.Diff(want, resources.ApplyLoopItem(params, combination)); d != "" {
+		t.Errorf("ApplyLoopItem() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPropagateParamsToReferencedTasks(t *testing.T) {
+	ps := &v1beta1.PipelineSpec{
+		Params: []v1beta1.ParamSpec{
+			{Name: "revision", Type: v1beta1.ParamTypeString, Default: v1beta1.NewStructuredValues("main")},
+			{Name: "flags", Type: v1beta1.ParamTypeArray},
+			{Name: "url", Type: v1beta1.ParamTypeString},
+			{Name: "platform", Type: v1beta1.ParamTypeString},
+		},
+	}
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			Params: v1beta1.Params{
+				{Name: "flags", Value: *v1beta1.NewStructuredValues("-v", "-race")},
+				{Name: "url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/pipeline")},
+				{Name: "platform", Value: *v1beta1.NewStructuredValues("linux")},
+			},
+		},
+	}
+	resolvedTask := &tresources.ResolvedTask{
+		TaskSpec: &v1beta1.TaskSpec{
+			Params: []v1beta1.ParamSpec{
+				{Name: "revision", Type: v1beta1.ParamTypeString},
+				{Name: "flags", Type: v1beta1.ParamTypeString},
+				{Name: "url", Type: v1beta1.ParamTypeString},
+				{Name: "platform", Type: v1beta1.ParamTypeString},
+				{Name: "context", Type: v1beta1.ParamTypeString, Default: v1beta1.NewStructuredValues(".")},
+			},
+		},
+	}
+	state := resources.PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "referenced",
+			TaskRef: &v1beta1.TaskRef{Name: "build"},
+			Params:  v1beta1.Params{{Name: "url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/triggers")}},
+			Matrix: &v1beta1.Matrix{
+				Params: v1beta1.Params{{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac")}},
+			},
+		},
+		ResolvedTask: resolvedTask,
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "embedded",
+			TaskSpec: &v1beta1.EmbeddedTask{
+				TaskSpec: *resolvedTask.TaskSpec,
+			},
+		},
+		ResolvedTask: resolvedTask,
+	}}
+	want := []*v1beta1.PipelineTask{{
+		Name:    "referenced",
+		TaskRef: &v1beta1.TaskRef{Name: "build"},
+		Params: v1beta1.Params{
+			{Name: "url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/triggers")},
+			{Name: "revision", Value: *v1beta1.NewStructuredValues("main")},
+		},
+		Matrix: &v1beta1.Matrix{
+			Params: v1beta1.Params{{Name: "platform", Value: *v1beta1.NewStructuredValues("linux", "mac")}},
+		},
+	}, {
+		Name: "embedded",
+		TaskSpec: &v1beta1.EmbeddedTask{
+			TaskSpec: *resolvedTask.TaskSpec,
+		},
+	}}
+	resources.PropagateParamsToReferencedTasks(ps, pr, state)
+	var got []*v1beta1.PipelineTask
+	for _, rpt := range state {
+		got = append(got, rpt.PipelineTask)
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("PropagateParamsToReferencedTasks() %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyWorkspaces(t *testing.T) {
 	for _, tc := range []struct {
 		description         string
@@ -3359,6 +3778,15 @@ func TestApplyWorkspaces(t *testing.T) {
 		bindings:            []v1beta1.WorkspaceBinding{},
 		variableUsage:       "$(workspaces.foo.bound)",
 		expectedReplacement: "false",
+	}, {
+		description: "ephemeral workspace declared not bound",
+		declarations: []v1beta1.PipelineWorkspaceDeclaration{{
+			Name: "foo",
+			Type: v1beta1.WorkspaceTypeEphemeral,
+		}},
+		bindings:            []v1beta1.WorkspaceBinding{},
+		variableUsage:       "$(workspaces.foo.bound)",
+		expectedReplacement: "true",
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			p1 := v1beta1.PipelineSpec{
@@ -3384,6 +3812,62 @@ func TestApplyWorkspaces(t *testing.T) {
 	}
 }
 
+func TestApplyVars(t *testing.T) {
+	p := &v1beta1.PipelineSpec{
+		Vars: map[string]string{
+			"image": "$(params.registry)/app@$(tasks.build.results.digest)",
+		},
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "deploy",
+			Params: v1beta1.Params{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues("$(vars.image)"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(vars.image)",
+				Operator: selection.NotIn,
+				Values:   []string{""},
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			Params: v1beta1.Params{{
+				Name:  "message",
+				Value: *v1beta1.NewStructuredValues("deployed $(vars.image)"),
+			}},
+		}},
+	}
+	want := &v1beta1.PipelineSpec{
+		Vars: p.Vars,
+		Tasks: []v1beta1.PipelineTask{{
+			Name: "deploy",
+			Params: v1beta1.Params{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues("$(params.registry)/app@$(tasks.build.results.digest)"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "$(params.registry)/app@$(tasks.build.results.digest)",
+				Operator: selection.NotIn,
+				Values:   []string{""},
+			}},
+		}},
+		Finally: []v1beta1.PipelineTask{{
+			Name: "notify",
+			Params: v1beta1.Params{{
+				Name:  "message",
+				Value: *v1beta1.NewStructuredValues("deployed $(params.registry)/app@$(tasks.build.results.digest)"),
+			}},
+		}},
+	}
+	got := resources.ApplyVars(p)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplyVars() %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]string{"build"}, got.Tasks[0].Deps()); d != "" {
+		t.Errorf("Deps() of the task using the var %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyFinallyResultsToPipelineResults(t *testing.T) {
 	for _, tc := range []struct {
 		description   string
@@ -4096,7 +4580,67 @@ func TestApplyTaskRunContext(t *testing.T) {
 			}},
 		},
 	}}
-	resources.ApplyPipelineTaskStateContext(state, r)
+	resources.ApplyPipelineTaskStateContext(state, r, nil)
+	if d := cmp.Diff(expectedState, state); d != "" {
+		t.Fatalf("ApplyTaskRunContext() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyTaskRunContext_AggregateDetails(t *testing.T) {
+	r := map[string]string{
+		"tasks.task1.reason":  "TaskRunTimeout",
+		"tasks.failed.count":  "1",
+		"tasks.skipped.count": "1",
+	}
+	ar := map[string][]string{
+		"tasks.failed":  {"task1"},
+		"tasks.skipped": {"task2"},
+	}
+	state := resources.PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "notify",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: v1beta1.Params{{
+				Name:  "reason",
+				Value: *v1beta1.NewStructuredValues("$(tasks.task1.reason)"),
+			}, {
+				Name:  "failed",
+				Value: *v1beta1.NewStructuredValues("$(tasks.failed[*])"),
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "task2",
+				Operator: selection.In,
+				Values:   []string{"$(tasks.skipped[*])"},
+			}, {
+				Input:    "$(tasks.failed.count)",
+				Operator: selection.NotIn,
+				Values:   []string{"0"},
+			}},
+		},
+	}}
+	expectedState := resources.PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "notify",
+			TaskRef: &v1beta1.TaskRef{Name: "task"},
+			Params: v1beta1.Params{{
+				Name:  "reason",
+				Value: *v1beta1.NewStructuredValues("TaskRunTimeout"),
+			}, {
+				Name:  "failed",
+				Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: []string{"task1"}},
+			}},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "task2",
+				Operator: selection.In,
+				Values:   []string{"task2"},
+			}, {
+				Input:    "1",
+				Operator: selection.NotIn,
+				Values:   []string{"0"},
+			}},
+		},
+	}}
+	resources.ApplyPipelineTaskStateContext(state, r, ar)
 	if d := cmp.Diff(expectedState, state); d != "" {
 		t.Fatalf("ApplyTaskRunContext() %s", diff.PrintWantGot(d))
 	}
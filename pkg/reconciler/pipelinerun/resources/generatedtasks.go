@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// GenerateTasks returns the pipeline tasks generated by the tasks which succeeded and generate
+// tasks from one of their results with tasksFromResult, skipping the ones already generated.
+// The generated tasks run after the task generating them. No task is generated once the
+// PipelineRun is stopping or cancelled, as the generated tasks would not be scheduled.
+func (facts *PipelineRunFacts) GenerateTasks(generated []v1beta1.PipelineTask) ([]v1beta1.PipelineTask, error) {
+	if facts.IsCancelled() || facts.IsGracefullyCancelled() || facts.IsGracefullyStopped() || facts.IsStopping() {
+		return nil, nil
+	}
+	var tasks []v1beta1.PipelineTask
+	for _, rpt := range facts.State {
+		if rpt.PipelineTask.TasksFromResult == "" || !rpt.isSuccessful() || len(rpt.TaskRuns) == 0 {
+			continue
+		}
+		generatedTasks, err := tasksFromResult(rpt.PipelineTask, rpt.TaskRuns[0].Status.TaskRunResults)
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range generatedTasks {
+			if isGeneratedBy(generated, task.Name, rpt.PipelineTask.Name) {
+				continue
+			}
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// tasksFromResult parses the pipeline tasks held by the result of the pipeline task named by
+// its tasksFromResult, making them run after the pipeline task.
+func tasksFromResult(pt *v1beta1.PipelineTask, results []v1beta1.TaskRunResult) ([]v1beta1.PipelineTask, error) {
+	var value *v1beta1.ResultValue
+	for i := range results {
+		if results[i].Name == pt.TasksFromResult {
+			value = &results[i].Value
+		}
+	}
+	if value == nil {
+		return nil, fmt.Errorf("the task %s didn't emit the result %s holding the tasks it generates", pt.Name, pt.TasksFromResult)
+	}
+	if value.Type != v1beta1.ParamTypeString {
+		return nil, fmt.Errorf("the result %s of the task %s must be a string holding the tasks it generates, but is of type %s", pt.TasksFromResult, pt.Name, value.Type)
+	}
+	var tasks []v1beta1.PipelineTask
+	if err := yaml.UnmarshalStrict([]byte(value.StringVal), &tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse the tasks generated by the task %s from its result %s: %w", pt.Name, pt.TasksFromResult, err)
+	}
+	for i := range tasks {
+		if !sets.NewString(tasks[i].RunAfter...).Has(pt.Name) {
+			tasks[i].RunAfter = append(tasks[i].RunAfter, pt.Name)
+		}
+	}
+	return tasks, nil
+}
+
+// isGeneratedBy returns whether a task with the given name was already generated by the given
+// pipeline task, which the generated tasks run after.
+func isGeneratedBy(generated []v1beta1.PipelineTask, name, generator string) bool {
+	for _, task := range generated {
+		if task.Name == name && sets.NewString(task.RunAfter...).Has(generator) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateGeneratedTasks validates the pipeline once the generated tasks are added to its tasks,
+// and returns the generated tasks with their defaults set.
+func ValidateGeneratedTasks(ctx context.Context, ps *v1beta1.PipelineSpec, tasks []v1beta1.PipelineTask) ([]v1beta1.PipelineTask, error) {
+	spec := ps.DeepCopy()
+	spec.Tasks = append(spec.Tasks, tasks...)
+	spec.SetDefaults(ctx)
+	if err := spec.Validate(ctx); err != nil {
+		return nil, err
+	}
+	return spec.Tasks[len(ps.Tasks):], nil
+}
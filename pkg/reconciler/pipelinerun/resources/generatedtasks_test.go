@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+const generatedTasks = `
+- name: build-api
+  taskRef:
+    name: build
+- name: build-web
+  runAfter: [build-api]
+  taskRef:
+    name: build
+`
+
+func generatorTaskRun(results ...v1beta1.TaskRunResult) *v1beta1.TaskRun {
+	tr := makeSucceeded(trs[0])
+	tr.Status.TaskRunResults = results
+	return tr
+}
+
+func generatorFacts(t *testing.T, state PipelineRunState, specStatus v1beta1.PipelineRunSpecStatus) PipelineRunFacts {
+	t.Helper()
+	var tasks v1beta1.PipelineTaskList
+	for _, rpt := range state {
+		tasks = append(tasks, *rpt.PipelineTask)
+	}
+	d, err := dag.Build(tasks, tasks.Deps())
+	if err != nil {
+		t.Fatalf("failed to build the DAG: %v", err)
+	}
+	return PipelineRunFacts{State: state, SpecStatus: specStatus, TasksGraph: d, FinalTasksGraph: &dag.Graph{}}
+}
+
+func TestGenerateTasks(t *testing.T) {
+	generator := v1beta1.PipelineTask{
+		Name:            "affected-modules",
+		TaskRef:         &v1beta1.TaskRef{Name: "task"},
+		TasksFromResult: "tasks",
+	}
+	for _, tc := range []struct {
+		name       string
+		state      PipelineRunState
+		generated  []v1beta1.PipelineTask
+		specStatus v1beta1.PipelineRunSpecStatus
+		want       []v1beta1.PipelineTask
+	}{{
+		name: "generator succeeded",
+		state: PipelineRunState{{
+			PipelineTask: &generator,
+			TaskRuns:     []*v1beta1.TaskRun{generatorTaskRun(v1beta1.TaskRunResult{Name: "tasks", Value: *v1beta1.NewStructuredValues(generatedTasks)})},
+		}},
+		want: []v1beta1.PipelineTask{{
+			Name:     "build-api",
+			TaskRef:  &v1beta1.TaskRef{Name: "build"},
+			RunAfter: []string{"affected-modules"},
+		}, {
+			Name:     "build-web",
+			TaskRef:  &v1beta1.TaskRef{Name: "build"},
+			RunAfter: []string{"build-api", "affected-modules"},
+		}},
+	}, {
+		name: "tasks already generated",
+		state: PipelineRunState{{
+			PipelineTask: &generator,
+			TaskRuns:     []*v1beta1.TaskRun{generatorTaskRun(v1beta1.TaskRunResult{Name: "tasks", Value: *v1beta1.NewStructuredValues(generatedTasks)})},
+		}},
+		generated: []v1beta1.PipelineTask{{
+			Name:     "build-api",
+			TaskRef:  &v1beta1.TaskRef{Name: "build"},
+			RunAfter: []string{"affected-modules"},
+		}},
+		want: []v1beta1.PipelineTask{{
+			Name:     "build-web",
+			TaskRef:  &v1beta1.TaskRef{Name: "build"},
+			RunAfter: []string{"build-api", "affected-modules"},
+		}},
+	}, {
+		name: "generator running",
+		state: PipelineRunState{{
+			PipelineTask: &generator,
+			TaskRuns:     []*v1beta1.TaskRun{makeStarted(trs[0])},
+		}},
+	}, {
+		name: "pipelinerun cancelled",
+		state: PipelineRunState{{
+			PipelineTask: &generator,
+			TaskRuns:     []*v1beta1.TaskRun{generatorTaskRun(v1beta1.TaskRunResult{Name: "tasks", Value: *v1beta1.NewStructuredValues(generatedTasks)})},
+		}},
+		specStatus: v1beta1.PipelineRunSpecStatusCancelled,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := generatorFacts(t, tc.state, tc.specStatus)
+			got, err := facts.GenerateTasks(tc.generated)
+			if err != nil {
+				t.Fatalf("GenerateTasks() returned an unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("GenerateTasks() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestGenerateTasks_Error(t *testing.T) {
+	generator := v1beta1.PipelineTask{
+		Name:            "affected-modules",
+		TaskRef:         &v1beta1.TaskRef{Name: "task"},
+		TasksFromResult: "tasks",
+	}
+	for _, tc := range []struct {
+		name    string
+		results []v1beta1.TaskRunResult
+		wantErr string
+	}{{
+		name:    "missing result",
+		wantErr: "the task affected-modules didn't emit the result tasks holding the tasks it generates",
+	}, {
+		name:    "array result",
+		results: []v1beta1.TaskRunResult{{Name: "tasks", Type: v1beta1.ResultsTypeArray, Value: *v1beta1.NewStructuredValues("build-api", "build-web")}},
+		wantErr: "the result tasks of the task affected-modules must be a string holding the tasks it generates, but is of type array",
+	}, {
+		name:    "unknown field",
+		results: []v1beta1.TaskRunResult{{Name: "tasks", Value: *v1beta1.NewStructuredValues(`[{"name": "build-api", "taskReference": {"name": "build"}}]`)}},
+		wantErr: `failed to parse the tasks generated by the task affected-modules from its result tasks: error unmarshaling JSON: while decoding JSON: json: unknown field "taskReference"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			facts := generatorFacts(t, PipelineRunState{{
+				PipelineTask: &generator,
+				TaskRuns:     []*v1beta1.TaskRun{generatorTaskRun(tc.results...)},
+			}}, "")
+			_, err := facts.GenerateTasks(nil)
+			if err == nil {
+				t.Fatal("GenerateTasks() didn't return an error")
+			}
+			if d := cmp.Diff(tc.wantErr, err.Error()); d != "" {
+				t.Errorf("GenerateTasks() error %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateGeneratedTasks(t *testing.T) {
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	ps := &v1beta1.PipelineSpec{
+		Tasks: []v1beta1.PipelineTask{{
+			Name:            "affected-modules",
+			TaskRef:         &v1beta1.TaskRef{Name: "task"},
+			TasksFromResult: "tasks",
+		}},
+	}
+	got, err := ValidateGeneratedTasks(ctx, ps, []v1beta1.PipelineTask{{
+		Name:     "build-api",
+		TaskRef:  &v1beta1.TaskRef{Name: "build"},
+		RunAfter: []string{"affected-modules"},
+	}})
+	if err != nil {
+		t.Fatalf("ValidateGeneratedTasks() returned an unexpected error: %v", err)
+	}
+	want := []v1beta1.PipelineTask{{
+		Name:     "build-api",
+		TaskRef:  &v1beta1.TaskRef{Name: "build", Kind: v1beta1.NamespacedTaskKind},
+		RunAfter: []string{"affected-modules"},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ValidateGeneratedTasks() %s", diff.PrintWantGot(d))
+	}
+	if len(ps.Tasks) != 1 {
+		t.Errorf("ValidateGeneratedTasks() modified the pipeline spec, got %d tasks", len(ps.Tasks))
+	}
+
+	if _, err := ValidateGeneratedTasks(ctx, ps, []v1beta1.PipelineTask{{
+		Name:    "affected-modules",
+		TaskRef: &v1beta1.TaskRef{Name: "build"},
+	}}); err == nil {
+		t.Error("ValidateGeneratedTasks() didn't return an error for a generated task named like a task of the pipeline")
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/clustervisibility"
 	rprp "github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/pipelinespec"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
@@ -96,6 +97,9 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 			Namespace:    namespace,
 			Tektonclient: tekton,
 		}
+		if pr != nil {
+			local.Kind = pr.Kind
+		}
 		return local.GetPipeline
 	}
 }
@@ -103,14 +107,36 @@ func GetPipelineFunc(ctx context.Context, k8s kubernetes.Interface, tekton clien
 // LocalPipelineRefResolver uses the current cluster to resolve a pipeline reference.
 type LocalPipelineRefResolver struct {
 	Namespace    string
+	Kind         v1beta1.PipelineKind
 	Tektonclient clientset.Interface
 }
 
-// GetPipeline will resolve a Pipeline from the local cluster using a versioned Tekton client. It will
-// return an error if it can't find an appropriate Pipeline for any reason.
+// GetPipeline will resolve either a Pipeline or ClusterPipeline from the local cluster using a versioned Tekton
+// client. It will return an error if it can't find an appropriate Pipeline for any reason, or if the ClusterPipeline
+// isn't visible in the namespace.
 // TODO: if we want to set RefSource for in-cluster pipeline, set it here.
 // https://github.com/tektoncd/pipeline/issues/5522
 func (l *LocalPipelineRefResolver) GetPipeline(ctx context.Context, name string) (*v1beta1.Pipeline, *v1beta1.RefSource, error) {
+	if l.Kind == v1beta1.ClusterPipelineKind {
+		cp, err := l.Tektonclient.TektonV1().ClusterPipelines().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := clustervisibility.ClusterPipelineVisible(cp, l.Namespace); err != nil {
+			return nil, nil, err
+		}
+		pipeline := &v1beta1.Pipeline{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Pipeline",
+				APIVersion: "tekton.dev/v1beta1",
+			},
+		}
+		if err := pipeline.ConvertFrom(ctx, cp.Pipeline()); err != nil {
+			return nil, nil, err
+		}
+		return pipeline, nil, nil
+	}
+
 	// If we are going to resolve this reference locally, we need a namespace scope.
 	if l.Namespace == "" {
 		return nil, nil, fmt.Errorf("Must specify namespace to resolve reference to pipeline %s", name)
@@ -142,7 +168,7 @@ func resolvePipeline(ctx context.Context, resolver remote.Resolver, name string,
 // readRuntimeObjectAsPipeline tries to convert a generic runtime.Object
 // into a *v1beta1.Pipeline type so that its meta and spec fields
 // can be read. v1 object will be converted to v1beta1 and returned.
-// v1beta1 Pipeline will be verified if trusted resources is enabled
+// v1beta1 and v1 Pipelines will be verified if trusted resources is enabled
 // An error is returned if the given object is not a
 // PipelineObject, trusted resources verification fails or if there is an error validating or upgrading an
 // older PipelineObject into its v1beta1 equivalent.
@@ -160,7 +186,11 @@ func readRuntimeObjectAsPipeline(ctx context.Context, obj runtime.Object, k8s ku
 		}
 		return obj, nil
 	case *v1.Pipeline:
-		// TODO(#6356): Support V1 Task verification
+		// Verify the Pipeline before it is converted, since signatures are based on the remote v1 pipeline contents
+		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
+		if vr.VerificationResultType == trustedresources.VerificationError {
+			return nil, fmt.Errorf("Pipeline verification failed for object %s: %w", obj.GetName(), vr.Err)
+		}
 		// Validation of beta fields must happen before the V1 Pipeline is converted into the storage version of the API.
 		// TODO(#6592): Decouple API versioning from feature versioning
 		if err := obj.Spec.ValidateBetaFields(ctx); err != nil {
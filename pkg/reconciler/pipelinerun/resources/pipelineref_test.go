@@ -29,9 +29,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/registry"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/clustervisibility"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/test"
@@ -125,6 +127,67 @@ func TestLocalPipelineRef(t *testing.T) {
 	}
 }
 
+func TestLocalPipelineRef_ClusterPipeline(t *testing.T) {
+	clusterPipeline := func(visibility *pipelinev1.ClusterVisibility) *pipelinev1.ClusterPipeline {
+		return &pipelinev1.ClusterPipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-pipeline"},
+			Spec: pipelinev1.ClusterPipelineSpec{
+				PipelineSpec: pipelinev1.PipelineSpec{
+					Tasks: []pipelinev1.PipelineTask{{
+						Name:    "build",
+						TaskRef: &pipelinev1.TaskRef{Name: "build", Kind: pipelinev1.ClusterTaskRefKind},
+					}},
+				},
+				Visibility: visibility,
+			},
+		}
+	}
+	expected := &v1beta1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-pipeline"},
+		Spec: v1beta1.PipelineSpec{
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1beta1.TaskRef{Name: "build", Kind: v1beta1.ClusterTaskKind},
+			}},
+		},
+	}
+
+	for _, tc := range []struct {
+		name            string
+		clusterPipeline *pipelinev1.ClusterPipeline
+		wantErr         error
+	}{{
+		name:            "visible in all namespaces",
+		clusterPipeline: clusterPipeline(nil),
+	}, {
+		name:            "visible in the namespace",
+		clusterPipeline: clusterPipeline(&pipelinev1.ClusterVisibility{Namespaces: []string{"ci", "default"}}),
+	}, {
+		name:            "not visible in the namespace",
+		clusterPipeline: clusterPipeline(&pipelinev1.ClusterVisibility{Namespaces: []string{"ci"}}),
+		wantErr:         clustervisibility.ErrNotVisible,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			lc := &resources.LocalPipelineRefResolver{
+				Namespace:    "default",
+				Kind:         v1beta1.ClusterPipelineKind,
+				Tektonclient: fake.NewSimpleClientset(tc.clusterPipeline),
+			}
+			got, _, err := lc.GetPipeline(context.Background(), "cluster-pipeline")
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+			if tc.wantErr != nil {
+				return
+			}
+			if d := cmp.Diff(expected, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetPipelineFunc(t *testing.T) {
 	// Set up a fake registry to push an image to.
 	s := httptest.NewServer(registry.New())
@@ -679,6 +742,20 @@ func TestGetPipelineFunc_VerifyError(t *testing.T) {
 	resolvedUnsigned := test.NewResolvedResource(unsignedPipelineBytes, nil, matchPolicyRefSource, nil)
 	requesterUnsigned := test.NewRequester(resolvedUnsigned, nil)
 
+	unsignedV1Pipeline := &pipelinev1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: "trusted-resources"},
+		Spec: pipelinev1.PipelineSpec{
+			Tasks: []pipelinev1.PipelineTask{{Name: "task", TaskRef: &pipelinev1.TaskRef{Name: "task"}}},
+		},
+	}
+	unsignedV1PipelineBytes, err := json.Marshal(unsignedV1Pipeline)
+	if err != nil {
+		t.Fatal("fail to marshal pipeline", err)
+	}
+	resolvedUnsignedV1 := test.NewResolvedResource(unsignedV1PipelineBytes, nil, matchPolicyRefSource, nil)
+	requesterUnsignedV1 := test.NewRequester(resolvedUnsignedV1, nil)
+
 	signedPipeline, err := test.GetSignedPipeline(unsignedPipeline, signer, "signed")
 	if err != nil {
 		t.Fatal("fail to sign pipeline", err)
@@ -730,6 +807,11 @@ func TestGetPipelineFunc_VerifyError(t *testing.T) {
 			requester:                 requesterUnsigned,
 			verificationNoMatchPolicy: config.IgnoreNoMatchPolicy,
 			expectedErr:               trustedresources.ErrResourceVerificationFailed,
+		}, {
+			name:                      "unsigned v1 pipeline fails verification with missing signature",
+			requester:                 requesterUnsignedV1,
+			verificationNoMatchPolicy: config.FailNoMatchPolicy,
+			expectedErr:               trustedresources.ErrSignatureMissing,
 		}, {
 			name:                      "modified pipeline fails verification with fail no match policy",
 			requester:                 requesterModified,
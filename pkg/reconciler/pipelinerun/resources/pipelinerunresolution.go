@@ -47,12 +47,19 @@ type TaskSkipStatus struct {
 type TaskNotFoundError struct {
 	Name string
 	Msg  string
+
+	original error
 }
 
 func (e *TaskNotFoundError) Error() string {
 	return fmt.Sprintf("Couldn't retrieve Task %q: %s", e.Name, e.Msg)
 }
 
+// Unwrap returns the error which caused the Task not to be retrieved.
+func (e *TaskNotFoundError) Unwrap() error {
+	return e.original
+}
+
 // ResolvedPipelineTask contains a PipelineTask and its associated TaskRun(s) or RunObjects, if they exist.
 type ResolvedPipelineTask struct {
 	TaskRunNames []string
@@ -61,8 +68,11 @@ type ResolvedPipelineTask struct {
 	CustomTask     bool
 	RunObjectNames []string
 	RunObjects     []v1beta1.RunObject
-	PipelineTask   *v1beta1.PipelineTask
-	ResolvedTask   *resources.ResolvedTask
+	// If the PipelineTask is a child Pipeline, ChildPipelineRunName and ChildPipelineRun will be set.
+	ChildPipelineRunName string
+	ChildPipelineRun     *v1beta1.PipelineRun
+	PipelineTask         *v1beta1.PipelineTask
+	ResolvedTask         *resources.ResolvedTask
 }
 
 // isDone returns true only if the task is skipped, succeeded or failed
@@ -72,6 +82,9 @@ func (t ResolvedPipelineTask) isDone(facts *PipelineRunFacts) bool {
 
 // IsRunning returns true only if the task is neither succeeded, cancelled nor failed
 func (t ResolvedPipelineTask) IsRunning() bool {
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil && !t.isSuccessful() && !t.isFailure()
+	}
 	if t.IsCustomTask() && len(t.RunObjects) == 0 {
 		return false
 	}
@@ -86,9 +99,25 @@ func (t ResolvedPipelineTask) IsCustomTask() bool {
 	return t.CustomTask
 }
 
+// IsChildPipeline returns true if the PipelineTask references a Pipeline run by a child PipelineRun.
+func (t ResolvedPipelineTask) IsChildPipeline() bool {
+	return t.PipelineTask != nil && t.PipelineTask.IsChildPipeline()
+}
+
+// childPipelineRunCondition returns the Succeeded condition of the child PipelineRun, if it exists.
+func (t ResolvedPipelineTask) childPipelineRunCondition() *apis.Condition {
+	if t.ChildPipelineRun == nil {
+		return nil
+	}
+	return t.ChildPipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+}
+
 // isSuccessful returns true only if the run has completed successfully
 // If the PipelineTask has a Matrix, isSuccessful returns true if all runs have completed successfully
 func (t ResolvedPipelineTask) isSuccessful() bool {
+	if t.IsChildPipeline() {
+		return t.childPipelineRunCondition().IsTrue()
+	}
 	if t.IsCustomTask() {
 		if len(t.RunObjects) == 0 {
 			return false
@@ -124,6 +153,9 @@ func (t ResolvedPipelineTask) isFailure() bool {
 	if t.isSuccessful() {
 		return false
 	}
+	if t.IsChildPipeline() {
+		return t.childPipelineRunCondition().IsFalse()
+	}
 	var isDone bool
 	if t.IsCustomTask() {
 		if len(t.RunObjects) == 0 {
@@ -154,6 +186,9 @@ func (t ResolvedPipelineTask) isFailure() bool {
 // isCancelledForTimeOut returns true only if the run is cancelled due to PipelineRun-controlled timeout
 // If the PipelineTask has a Matrix, isCancelled returns true if any run is cancelled due to PipelineRun-controlled timeout and all other runs are done.
 func (t ResolvedPipelineTask) isCancelledForTimeOut() bool {
+	if t.IsChildPipeline() {
+		return false
+	}
 	if t.IsCustomTask() {
 		if len(t.RunObjects) == 0 {
 			return false
@@ -189,6 +224,10 @@ func (t ResolvedPipelineTask) isCancelledForTimeOut() bool {
 // isCancelled returns true only if the run is cancelled
 // If the PipelineTask has a Matrix, isCancelled returns true if any run is cancelled and all other runs are done.
 func (t ResolvedPipelineTask) isCancelled() bool {
+	if t.IsChildPipeline() {
+		c := t.childPipelineRunCondition()
+		return c.IsFalse() && c.Reason == v1beta1.PipelineRunReasonCancelled.String()
+	}
 	if t.IsCustomTask() {
 		if len(t.RunObjects) == 0 {
 			return false
@@ -220,6 +259,9 @@ func (t ResolvedPipelineTask) isCancelled() bool {
 // isScheduled returns true when the PipelineRunTask itself has any TaskRuns/CustomRuns
 // or a singular TaskRun/CustomRun associated.
 func (t ResolvedPipelineTask) isScheduled() bool {
+	if t.IsChildPipeline() {
+		return t.ChildPipelineRun != nil
+	}
 	if t.IsCustomTask() {
 		return len(t.RunObjects) > 0
 	}
@@ -249,12 +291,46 @@ func (t ResolvedPipelineTask) hasRunObjectsStarted() bool {
 // isConditionStatusFalse returns true when any of the taskRuns/customRuns have succeeded condition with status set to false
 // it includes task failed after retries are exhausted, cancelled tasks, and time outs
 func (t ResolvedPipelineTask) isConditionStatusFalse() bool {
+	if t.IsChildPipeline() {
+		return t.childPipelineRunCondition().IsFalse()
+	}
 	if t.IsCustomTask() {
 		return t.areRunObjectsConditionStatusFalse()
 	}
 	return t.areTaskRunsConditionStatusFalse()
 }
 
+// conditionReason returns the reason of the succeeded condition of the runs of the task, the reason of the
+// first run which failed if any, or an empty string if none of its runs has a succeeded condition yet
+func (t ResolvedPipelineTask) conditionReason() string {
+	var conditions []*apis.Condition
+	switch {
+	case t.IsChildPipeline():
+		conditions = append(conditions, t.childPipelineRunCondition())
+	case t.IsCustomTask():
+		for _, runObject := range t.RunObjects {
+			conditions = append(conditions, runObject.GetStatusCondition().GetCondition(apis.ConditionSucceeded))
+		}
+	default:
+		for _, taskRun := range t.TaskRuns {
+			conditions = append(conditions, taskRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded))
+		}
+	}
+	reason := ""
+	for _, c := range conditions {
+		if c == nil {
+			continue
+		}
+		if c.IsFalse() {
+			return c.Reason
+		}
+		if reason == "" {
+			reason = c.Reason
+		}
+	}
+	return reason
+}
+
 // areTaskRunsConditionStatusFalse returns true when any of the taskRuns have succeeded condition with status set to false
 // it includes task failed after retries are exhausted, cancelled tasks, and time outs
 func (t ResolvedPipelineTask) areTaskRunsConditionStatusFalse() bool {
@@ -309,8 +385,12 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 		skippingReason = v1beta1.GracefullyStoppedSkip
 	case t.skipBecauseParentTaskWasSkipped(facts):
 		skippingReason = v1beta1.ParentTasksSkip
+	case t.skipBecauseParentTaskFailed(facts):
+		skippingReason = v1beta1.ParentTasksFailedSkip
 	case t.skipBecauseResultReferencesAreMissing(facts):
 		skippingReason = v1beta1.MissingResultsSkip
+	case t.skipBecauseWhenExpressionsFailedToEvaluate(facts):
+		skippingReason = v1beta1.WhenExpressionsErrorSkip
 	case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 		skippingReason = v1beta1.WhenExpressionsSkip
 	case t.skipBecausePipelineRunPipelineTimeoutReached(facts):
@@ -335,6 +415,7 @@ func (t *ResolvedPipelineTask) skip(facts *PipelineRunFacts) TaskSkipStatus {
 // (3) its parent task was skipped
 // (4) Pipeline is in stopping state (one of the PipelineTasks failed)
 // (5) Pipeline is gracefully cancelled or stopped
+// (6) one of its parent tasks failed, with the FailAtEnd failure policy
 func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	if facts.SkipCache == nil {
 		facts.SkipCache = make(map[string]TaskSkipStatus)
@@ -345,6 +426,12 @@ func (t *ResolvedPipelineTask) Skip(facts *PipelineRunFacts) TaskSkipStatus {
 	return facts.SkipCache[t.PipelineTask.Name]
 }
 
+// skipBecauseWhenExpressionsFailedToEvaluate returns true if one of the CEL when expressions fails to
+// be evaluated once the parent tasks are done, which fails the pipeline run
+func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsFailedToEvaluate(facts *PipelineRunFacts) bool {
+	return t.checkParentsDone(facts) && t.PipelineTask.WhenExpressions.EvaluationError() != nil
+}
+
 // skipBecauseWhenExpressionsEvaluatedToFalse confirms that the when expressions have completed evaluating, and
 // it returns true if any of the when expressions evaluate to false
 func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts *PipelineRunFacts) bool {
@@ -359,7 +446,8 @@ func (t *ResolvedPipelineTask) skipBecauseWhenExpressionsEvaluatedToFalse(facts
 // skipBecauseParentTaskWasSkipped loops through the parent tasks and checks if the parent task skipped:
 //
 //	if yes, is it because of when expressions?
-//	    if yes, it ignores this parent skip and continue evaluating other parent tasks
+//	    if yes, and its skip policy doesn't skip its dependents, it ignores this parent skip and continue
+//	    evaluating other parent tasks
 //	    if no, it returns true to skip the current task because this parent task was skipped
 //	if no, it continues checking the other parent tasks
 func (t *ResolvedPipelineTask) skipBecauseParentTaskWasSkipped(facts *PipelineRunFacts) bool {
@@ -368,9 +456,9 @@ func (t *ResolvedPipelineTask) skipBecauseParentTaskWasSkipped(facts *PipelineRu
 	for _, p := range node.Prev {
 		parentTask := stateMap[p.Key]
 		if parentSkipStatus := parentTask.Skip(facts); parentSkipStatus.IsSkipped {
-			// if the parent task was skipped due to its `when` expressions,
+			// if the parent task was skipped due to its `when` expressions, and doesn't skip its dependents,
 			// then we should ignore that and continue evaluating if we should skip because of other parent tasks
-			if parentSkipStatus.SkippingReason == v1beta1.WhenExpressionsSkip {
+			if parentSkipStatus.SkippingReason == v1beta1.WhenExpressionsSkip && parentTask.PipelineTask.SkipPolicy != v1beta1.PipelineTaskSkipDependents {
 				continue
 			}
 			return true
@@ -379,6 +467,19 @@ func (t *ResolvedPipelineTask) skipBecauseParentTaskWasSkipped(facts *PipelineRu
 	return false
 }
 
+// skipBecauseParentTaskFailed returns true if a parent task failed and doesn't continue on error,
+// which happens when the PipelineRun keeps running the other tasks with the FailAtEnd failure policy
+func (t *ResolvedPipelineTask) skipBecauseParentTaskFailed(facts *PipelineRunFacts) bool {
+	stateMap := facts.State.ToMap()
+	node := facts.TasksGraph.Nodes[t.PipelineTask.Name]
+	for _, p := range node.Prev {
+		if parentTask := stateMap[p.Key]; parentTask.isFailure() && !parentTask.PipelineTask.ContinuesOnError() {
+			return true
+		}
+	}
+	return false
+}
+
 // skipBecauseResultReferencesAreMissing checks if the task references results that cannot be resolved, which is a
 // reason for skipping the task, and applies result references if found
 func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *PipelineRunFacts) bool {
@@ -386,7 +487,7 @@ func (t *ResolvedPipelineTask) skipBecauseResultReferencesAreMissing(facts *Pipe
 		resolvedResultRefs, pt, err := ResolveResultRefs(facts.State, PipelineRunState{t})
 		rpt := facts.State.ToMap()[pt]
 		if rpt != nil {
-			if err != nil && (t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1beta1.WhenExpressionsSkip) {
+			if err != nil && (t.IsFinalTask(facts) || rpt.Skip(facts).SkippingReason == v1beta1.WhenExpressionsSkip || rpt.isFailure()) {
 				return true
 			}
 		}
@@ -464,6 +565,8 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 		switch {
 		case t.skipBecauseResultReferencesAreMissing(facts):
 			skippingReason = v1beta1.MissingResultsSkip
+		case t.skipBecauseWhenExpressionsFailedToEvaluate(facts):
+			skippingReason = v1beta1.WhenExpressionsErrorSkip
 		case t.skipBecauseWhenExpressionsEvaluatedToFalse(facts):
 			skippingReason = v1beta1.WhenExpressionsSkip
 		case t.skipBecausePipelineRunPipelineTimeoutReached(facts):
@@ -488,6 +591,9 @@ func (t *ResolvedPipelineTask) IsFinallySkipped(facts *PipelineRunFacts) TaskSki
 // GetRun is a function that will retrieve a Run by name.
 type GetRun func(name string) (v1beta1.RunObject, error)
 
+// GetPipelineRun is a function that will retrieve a child PipelineRun by name.
+type GetPipelineRun func(name string) (*v1beta1.PipelineRun, error)
+
 // ValidateWorkspaceBindings validates that the Workspaces expected by a Pipeline are provided by a PipelineRun.
 func ValidateWorkspaceBindings(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) error {
 	pipelineRunWorkspaces := make(map[string]v1beta1.WorkspaceBinding)
@@ -496,12 +602,24 @@ func ValidateWorkspaceBindings(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun)
 	}
 
 	for _, ws := range p.Workspaces {
-		if ws.Optional {
-			continue
-		}
-		if _, ok := pipelineRunWorkspaces[ws.Name]; !ok {
+		binding, ok := pipelineRunWorkspaces[ws.Name]
+		if !ok {
+			// ephemeral workspaces which are not provided are bound to an emptyDir
+			if ws.Optional || ws.Type == v1beta1.WorkspaceTypeEphemeral {
+				continue
+			}
 			return fmt.Errorf("pipeline requires workspace with name %q be provided by pipelinerun", ws.Name)
 		}
+		switch ws.Type {
+		case v1beta1.WorkspaceTypeCache, v1beta1.WorkspaceTypeArtifact:
+			if binding.VolumeClaimTemplate == nil && binding.PersistentVolumeClaim == nil {
+				return fmt.Errorf("%s workspace %q must be provided by a volumeClaimTemplate or a persistentVolumeClaim", ws.Type, ws.Name)
+			}
+		case v1beta1.WorkspaceTypeSecret:
+			if binding.Secret == nil {
+				return fmt.Errorf("secret workspace %q must be provided by a secret", ws.Name)
+			}
+		}
 	}
 	return nil
 }
@@ -534,17 +652,33 @@ func ValidateTaskRunSpecs(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun) erro
 //
 // If the Pipeline Task is a Custom Task, it retrieves any CustomRuns and updates the ResolvedPipelineTask with this information.
 // It also sets the ResolvedPipelineTask's RunName(s) with the names of CustomRuns that should be or already have been created.
+//
+// If the Pipeline Task is a child Pipeline, it retrieves the child PipelineRun and updates the ResolvedPipelineTask with it.
+// It also sets the ResolvedPipelineTask's ChildPipelineRunName with the name of the PipelineRun that should be or already has
+// been created.
 func ResolvePipelineTask(
 	ctx context.Context,
 	pipelineRun v1beta1.PipelineRun,
 	getTask resources.GetTask,
 	getTaskRun resources.GetTaskRun,
 	getRun GetRun,
+	getPipelineRun GetPipelineRun,
 	pipelineTask v1beta1.PipelineTask,
 ) (*ResolvedPipelineTask, error) {
 	rpt := ResolvedPipelineTask{
 		PipelineTask: &pipelineTask,
 	}
+	if rpt.IsChildPipeline() {
+		rpt.ChildPipelineRunName = getChildPipelineRunName(pipelineRun.Status.ChildReferences, pipelineTask.Name, pipelineRun.Name)
+		childPipelineRun, err := getPipelineRun(rpt.ChildPipelineRunName)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error retrieving child PipelineRun %s: %w", rpt.ChildPipelineRunName, err)
+		}
+		if err == nil {
+			rpt.ChildPipelineRun = childPipelineRun
+		}
+		return &rpt, nil
+	}
 	rpt.CustomTask = rpt.PipelineTask.TaskRef.IsCustomTask() || rpt.PipelineTask.TaskSpec.IsCustomTask()
 	numCombinations := 1
 	if rpt.PipelineTask.IsMatrixed() {
@@ -568,10 +702,35 @@ func ResolvePipelineTask(
 				return nil, err
 			}
 		}
+		if len(rpt.TaskRunNames) == 0 && rpt.PipelineTask.IsLooped() {
+			// The items of the looping task are only known once they are substituted from the
+			// results of other tasks, the task is resolved for the TaskRuns created then.
+			rt, err := resolveTask(ctx, nil, getTask, pipelineTask)
+			if err != nil {
+				return nil, err
+			}
+			rpt.ResolvedTask = rt
+		}
 	}
 	return &rpt, nil
 }
 
+// SetLoopRunNames sets the names of the runs to create for the looping PipelineTasks in targets,
+// whose number is only known once their items are substituted from the results of other tasks.
+func SetLoopRunNames(targets PipelineRunState, pipelineRun *v1beta1.PipelineRun) {
+	for _, rpt := range targets {
+		if rpt.PipelineTask == nil || !rpt.PipelineTask.IsLooped() {
+			continue
+		}
+		numItems := rpt.PipelineTask.Matrix.CountCombinations()
+		if rpt.IsCustomTask() {
+			rpt.RunObjectNames = getNamesOfRuns(pipelineRun.Status.ChildReferences, rpt.PipelineTask.Name, pipelineRun.Name, numItems)
+		} else {
+			rpt.TaskRunNames = GetNamesOfTaskRuns(pipelineRun.Status.ChildReferences, rpt.PipelineTask.Name, pipelineRun.Name, numItems)
+		}
+	}
+}
+
 // setTaskRunsAndResolvedTask fetches the named TaskRun using the input function getTaskRun,
 // and the resolved Task spec of the Pipeline Task using the input function getTask.
 // It updates the ResolvedPipelineTask with the ResolvedTask and a pointer to the fetched TaskRun.
@@ -624,8 +783,9 @@ func resolveTask(
 				return rt, err
 			case err != nil:
 				return rt, &TaskNotFoundError{
-					Name: pipelineTask.TaskRef.Name,
-					Msg:  err.Error(),
+					Name:     pipelineTask.TaskRef.Name,
+					Msg:      err.Error(),
+					original: err,
 				}
 			default:
 				spec := t.TaskSpec()
@@ -700,6 +860,17 @@ func getRunName(childRefs []v1beta1.ChildStatusReference, ptName, prName string)
 	return kmeta.ChildName(prName, fmt.Sprintf("-%s", ptName))
 }
 
+// getChildPipelineRunName should return a unique name for a child `PipelineRun` if one has not
+// already been defined, and the existing one otherwise.
+func getChildPipelineRunName(childRefs []v1beta1.ChildStatusReference, ptName, prName string) string {
+	for _, cr := range childRefs {
+		if cr.Kind == pipeline.PipelineRunControllerName && cr.PipelineTaskName == ptName {
+			return cr.Name
+		}
+	}
+	return kmeta.ChildName(prName, fmt.Sprintf("-%s", ptName))
+}
+
 // getNamesOfRuns should return a unique names for `RunObjects` if they have not already been defined,
 // and the existing ones otherwise.
 func getNamesOfRuns(childRefs []v1beta1.ChildStatusReference, ptName, prName string, numberOfRuns int) []string {
@@ -46,6 +46,9 @@ import (
 func nopGetRun(string) (v1beta1.RunObject, error) {
 	return nil, errors.New("GetRun should not be called")
 }
+func nopGetPipelineRun(string) (*v1beta1.PipelineRun, error) {
+	return nil, errors.New("GetPipelineRun should not be called")
+}
 func nopGetTask(context.Context, string) (*v1beta1.Task, *v1beta1.RefSource, *trustedresources.VerificationResult, error) {
 	return nil, nil, nil, errors.New("GetTask should not be called")
 }
@@ -365,6 +368,11 @@ func withPipelineTaskRetries(pt v1beta1.PipelineTask, retries int) *v1beta1.Pipe
 	return &pt
 }
 
+func withPipelineTaskSkipPolicy(pt v1beta1.PipelineTask, policy v1beta1.PipelineTaskSkipPolicy) *v1beta1.PipelineTask {
+	pt.SkipPolicy = policy
+	return &pt
+}
+
 func newCustomRun(run v1beta1.CustomRun) *v1beta1.CustomRun {
 	return &v1beta1.CustomRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -2437,6 +2445,46 @@ func TestSkipBecauseParentTaskWasSkipped(t *testing.T) {
 			"mytask18": false,
 			"mytask19": false,
 		},
+	}, {
+		name: "tasks-when-expressions-skip-dependents",
+		state: PipelineRunState{{
+			// parent task is skipped because when expressions evaluate to false, and skips its dependents
+			PipelineTask: withPipelineTaskSkipPolicy(pts[10], v1beta1.PipelineTaskSkipDependents),
+			TaskRunNames: []string{"pipelinerun-guardedtask"},
+			TaskRuns:     nil,
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			// child task is skipped because its parent task skips its dependents
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:     "mytask18",
+				TaskRef:  &v1beta1.TaskRef{Name: "task"},
+				RunAfter: []string{"mytask11"},
+			},
+			TaskRunNames: []string{"pipelinerun-ordering-dependent-task-1"},
+			TaskRuns:     nil,
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}, {
+			// grandchild task is skipped transitively because its parent task was skipped
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:     "mytask19",
+				TaskRef:  &v1beta1.TaskRef{Name: "task"},
+				RunAfter: []string{"mytask18"},
+			},
+			TaskRunNames: []string{"pipelinerun-ordering-dependent-task-2"},
+			TaskRuns:     nil,
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &task.Spec,
+			},
+		}},
+		expected: map[string]bool{
+			"mytask11": false,
+			"mytask18": true,
+			"mytask19": true,
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			d, err := dagFromState(tc.state)
@@ -2512,7 +2560,7 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	cfg := config.NewStore(logtesting.TestLogger(t))
 	ctx = cfg.ToContext(ctx)
 	for _, task := range pts {
-		ps, err := ResolvePipelineTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, task)
+		ps, err := ResolvePipelineTask(ctx, pr, nopGetTask, nopGetTaskRun, getRun, nopGetPipelineRun, task)
 		if err != nil {
 			t.Fatalf("ResolvePipelineTask: %v", err)
 		}
@@ -2540,6 +2588,138 @@ func TestResolvePipelineRun_CustomTask(t *testing.T) {
 	}
 }
 
+func TestResolvePipelineRun_ChildPipeline(t *testing.T) {
+	pts := []v1beta1.PipelineTask{{
+		Name:        "child",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child-pipeline"},
+	}, {
+		Name:        "child-exists",
+		PipelineRef: &v1beta1.PipelineRef{Name: "child-pipeline"},
+	}}
+	pr := v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun"},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			ChildReferences: []v1beta1.ChildStatusReference{{
+				TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+				Name:             "child-exists-abcde",
+				PipelineTaskName: "child-exists",
+			}},
+		}},
+	}
+	child := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "child-exists-abcde"}}
+	getPipelineRun := func(name string) (*v1beta1.PipelineRun, error) {
+		if name == child.Name {
+			return child, nil
+		}
+		return nil, kerrors.NewNotFound(v1beta1.Resource("pipelinerun"), name)
+	}
+	var pipelineState PipelineRunState
+	for _, task := range pts {
+		ps, err := ResolvePipelineTask(context.Background(), pr, nopGetTask, nopGetTaskRun, nopGetRun, getPipelineRun, task)
+		if err != nil {
+			t.Fatalf("ResolvePipelineTask: %v", err)
+		}
+		pipelineState = append(pipelineState, ps)
+	}
+
+	expectedState := PipelineRunState{{
+		PipelineTask:         &pts[0],
+		ChildPipelineRunName: "pipelinerun-child",
+	}, {
+		PipelineTask:         &pts[1],
+		ChildPipelineRunName: "child-exists-abcde",
+		ChildPipelineRun:     child,
+	}}
+	if d := cmp.Diff(expectedState, pipelineState); d != "" {
+		t.Errorf("Unexpected pipeline state: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedPipelineTask_ChildPipeline(t *testing.T) {
+	childPipelineRun := func(status corev1.ConditionStatus, reason string, results ...v1beta1.PipelineRunResult) *v1beta1.PipelineRun {
+		pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-child"}}
+		pr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: status, Reason: reason})
+		pr.Status.PipelineResults = results
+		return pr
+	}
+	pt := &v1beta1.PipelineTask{Name: "child", PipelineRef: &v1beta1.PipelineRef{Name: "child-pipeline"}}
+	for _, tc := range []struct {
+		name          string
+		child         *v1beta1.PipelineRun
+		wantScheduled bool
+		wantRunning   bool
+		wantSucceeded bool
+		wantFailed    bool
+		wantCancelled bool
+	}{{
+		name: "not started",
+	}, {
+		name:          "running",
+		child:         childPipelineRun(corev1.ConditionUnknown, v1beta1.PipelineRunReasonRunning.String()),
+		wantScheduled: true,
+		wantRunning:   true,
+	}, {
+		name:          "succeeded",
+		child:         childPipelineRun(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String()),
+		wantScheduled: true,
+		wantSucceeded: true,
+	}, {
+		name:          "failed",
+		child:         childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonFailed.String()),
+		wantScheduled: true,
+		wantFailed:    true,
+	}, {
+		name:          "cancelled",
+		child:         childPipelineRun(corev1.ConditionFalse, v1beta1.PipelineRunReasonCancelled.String()),
+		wantScheduled: true,
+		wantFailed:    true,
+		wantCancelled: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rpt := ResolvedPipelineTask{PipelineTask: pt, ChildPipelineRunName: "pipelinerun-child", ChildPipelineRun: tc.child}
+			if got := rpt.isScheduled(); got != tc.wantScheduled {
+				t.Errorf("isScheduled() = %t, want %t", got, tc.wantScheduled)
+			}
+			if got := rpt.IsRunning(); got != tc.wantRunning {
+				t.Errorf("IsRunning() = %t, want %t", got, tc.wantRunning)
+			}
+			if got := rpt.isSuccessful(); got != tc.wantSucceeded {
+				t.Errorf("isSuccessful() = %t, want %t", got, tc.wantSucceeded)
+			}
+			if got := rpt.isFailure(); got != tc.wantFailed {
+				t.Errorf("isFailure() = %t, want %t", got, tc.wantFailed)
+			}
+			if got := rpt.isCancelled(); got != tc.wantCancelled {
+				t.Errorf("isCancelled() = %t, want %t", got, tc.wantCancelled)
+			}
+		})
+	}
+
+	t.Run("results and child references", func(t *testing.T) {
+		child := childPipelineRun(corev1.ConditionTrue, v1beta1.PipelineRunReasonSuccessful.String(), v1beta1.PipelineRunResult{
+			Name:  "digest",
+			Value: *v1beta1.NewStructuredValues("sha256:abc"),
+		})
+		state := PipelineRunState{{PipelineTask: pt, ChildPipelineRunName: child.Name, ChildPipelineRun: child}}
+		wantResults := map[string][]v1beta1.TaskRunResult{"child": {{
+			Name:  "digest",
+			Type:  v1beta1.ResultsTypeString,
+			Value: *v1beta1.NewStructuredValues("sha256:abc"),
+		}}}
+		if d := cmp.Diff(wantResults, state.GetTaskRunsResults()); d != "" {
+			t.Errorf("GetTaskRunsResults() %s", diff.PrintWantGot(d))
+		}
+		wantChildRefs := []v1beta1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"},
+			Name:             "pipelinerun-child",
+			PipelineTaskName: "child",
+		}}
+		if d := cmp.Diff(wantChildRefs, state.GetChildReferences()); d != "" {
+			t.Errorf("GetChildReferences() %s", diff.PrintWantGot(d))
+		}
+	})
+}
+
 func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	pts := []v1beta1.PipelineTask{{
 		Name:    "mytask1",
@@ -2563,7 +2743,7 @@ func TestResolvePipelineRun_PipelineTaskHasNoResources(t *testing.T) {
 	}
 	pipelineState := PipelineRunState{}
 	for _, task := range pts {
-		ps, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, task)
+		ps, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, task)
 		if err != nil {
 			t.Errorf("Error getting tasks for fake pipeline %s: %s", p.ObjectMeta.Name, err)
 		}
@@ -2614,7 +2794,7 @@ func TestResolvePipelineRun_TaskDoesntExist(t *testing.T) {
 		},
 	}
 	for _, pt := range pts {
-		_, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, pt)
+		_, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, pt)
 		var tnf *TaskNotFoundError
 		switch {
 		case err == nil:
@@ -2654,7 +2834,7 @@ func TestResolvePipelineRun_VerificationFailed(t *testing.T) {
 		},
 	}
 	for _, pt := range pts {
-		rt, _ := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, pt)
+		rt, _ := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, pt)
 		if d := cmp.Diff(verificationResult, rt.ResolvedTask.VerificationResult, cmpopts.EquateErrors()); d != "" {
 			t.Errorf(diff.PrintWantGot(d))
 		}
@@ -2695,6 +2875,48 @@ func TestValidateWorkspaceBindingsWithValidWorkspaces(t *testing.T) {
 				Workspaces: []v1beta1.WorkspaceBinding{},
 			},
 		},
+	}, {
+		name: "omit ephemeral workspace",
+		spec: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+				Type: v1beta1.WorkspaceTypeEphemeral,
+			}},
+		},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{},
+			},
+		},
+	}, {
+		name: "cache, artifact and secret workspaces",
+		spec: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name:     "cache",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "key",
+			}, {
+				Name: "artifact",
+				Type: v1beta1.WorkspaceTypeArtifact,
+			}, {
+				Name: "secret",
+				Type: v1beta1.WorkspaceTypeSecret,
+			}},
+		},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:                "cache",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}, {
+					Name:                  "artifact",
+					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc"},
+				}, {
+					Name:   "secret",
+					Secret: &corev1.SecretVolumeSource{SecretName: "secret"},
+				}},
+			},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := ValidateWorkspaceBindings(tc.spec, tc.pr); err != nil {
@@ -2722,10 +2944,64 @@ func TestValidateWorkspaceBindingsWithInvalidWorkspaces(t *testing.T) {
 				Workspaces: []v1beta1.WorkspaceBinding{},
 			},
 		},
+		err: `pipeline requires workspace with name "foo" be provided by pipelinerun`,
+	}, {
+		name: "cache workspace bound to an emptyDir",
+		spec: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name:     "foo",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "key",
+			}},
+		},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:     "foo",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+			},
+		},
+		err: `cache workspace "foo" must be provided by a volumeClaimTemplate or a persistentVolumeClaim`,
+	}, {
+		name: "missing artifact workspace",
+		spec: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+				Type: v1beta1.WorkspaceTypeArtifact,
+			}},
+		},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{},
+			},
+		},
+		err: `pipeline requires workspace with name "foo" be provided by pipelinerun`,
+	}, {
+		name: "secret workspace bound to a configMap",
+		spec: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "foo",
+				Type: v1beta1.WorkspaceTypeSecret,
+			}},
+		},
+		pr: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:      "foo",
+					ConfigMap: &corev1.ConfigMapVolumeSource{},
+				}},
+			},
+		},
+		err: `secret workspace "foo" must be provided by a secret`,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			if err := ValidateWorkspaceBindings(tc.spec, tc.pr); err == nil {
-				t.Fatalf("Expected error indicating `foo` workspace was not provided but got no error")
+			err := ValidateWorkspaceBindings(tc.spec, tc.pr)
+			if err == nil {
+				t.Fatalf("Expected error %q but got no error", tc.err)
+			}
+			if d := cmp.Diff(tc.err, err.Error()); d != "" {
+				t.Errorf("Unexpected error %s", diff.PrintWantGot(d))
 			}
 		})
 	}
@@ -2898,7 +3174,7 @@ func TestResolvePipeline_WhenExpressions(t *testing.T) {
 	}
 
 	t.Run("When Expressions exist", func(t *testing.T) {
-		_, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, pt)
+		_, err := ResolvePipelineTask(context.Background(), pr, getTask, getTaskRun, nopGetRun, nopGetPipelineRun, pt)
 		if err != nil {
 			t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 		}
@@ -3000,7 +3276,7 @@ func TestIsCustomTask(t *testing.T) {
 			ctx := context.Background()
 			cfg := config.NewStore(logtesting.TestLogger(t))
 			ctx = cfg.ToContext(ctx)
-			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, tc.pt)
+			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, tc.pt)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -3743,7 +4019,7 @@ func TestIsMatrixed(t *testing.T) {
 				},
 			})
 			ctx = cfg.ToContext(ctx)
-			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, tc.pt)
+			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, tc.pt)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -3851,7 +4127,7 @@ func TestResolvePipelineRunTask_WithMatrix(t *testing.T) {
 				},
 			})
 			ctx = cfg.ToContext(ctx)
-			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, tc.pt)
+			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, getRun, nopGetPipelineRun, tc.pt)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
@@ -3971,7 +4247,7 @@ func TestResolvePipelineRunTask_WithMatrixedCustomTask(t *testing.T) {
 			if tc.getRun == nil {
 				tc.getRun = getRun
 			}
-			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, tc.getRun, tc.pt)
+			rpt, err := ResolvePipelineTask(ctx, pr, getTask, getTaskRun, tc.getRun, nopGetPipelineRun, tc.pt)
 			if err != nil {
 				t.Fatalf("Did not expect error when resolving PipelineRun: %v", err)
 			}
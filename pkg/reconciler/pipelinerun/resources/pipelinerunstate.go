@@ -19,6 +19,7 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -40,6 +41,8 @@ const (
 	PipelineTaskStatusPrefix = "tasks."
 	// PipelineTaskStatusSuffix is a suffix of the param representing execution state of pipelineTask
 	PipelineTaskStatusSuffix = ".status"
+	// PipelineTaskReasonSuffix is a suffix of the param representing the reason of the execution state of pipelineTask
+	PipelineTaskReasonSuffix = ".reason"
 )
 
 // PipelineRunState is a slice of ResolvedPipelineRunTasks the represents the current execution
@@ -53,6 +56,7 @@ type PipelineRunState []*ResolvedPipelineTask
 type PipelineRunFacts struct {
 	State           PipelineRunState
 	SpecStatus      v1beta1.PipelineRunSpecStatus
+	FailurePolicy   v1beta1.PipelineRunFailurePolicy
 	TasksGraph      *dag.Graph
 	FinalTasksGraph *dag.Graph
 	TimeoutsState   PipelineRunTimeoutsState
@@ -93,6 +97,10 @@ type pipelineRunStatusCount struct {
 	Incomplete int
 	// count of tasks skipped due to the relevant timeout having elapsed before the task is launched
 	SkippedDueToTimeout int
+	// count of failed tasks which let the tasks depending on them run, with onError continue or continueAndFail
+	ContinuedOnError int
+	// count of failed tasks whose failure doesn't fail the pipeline, with onError continue
+	IgnoredFailures int
 }
 
 // ResetSkippedCache resets the skipped cache in the facts map
@@ -112,7 +120,7 @@ func (state PipelineRunState) ToMap() map[string]*ResolvedPipelineTask {
 // IsBeforeFirstTaskRun returns true if the PipelineRun has not yet started its first TaskRun
 func (state PipelineRunState) IsBeforeFirstTaskRun() bool {
 	for _, t := range state {
-		if len(t.RunObjects) > 0 || len(t.TaskRuns) > 0 {
+		if len(t.RunObjects) > 0 || len(t.TaskRuns) > 0 || t.ChildPipelineRun != nil {
 			return false
 		}
 	}
@@ -141,22 +149,37 @@ func (state PipelineRunState) AdjustStartTime(unadjustedStartTime *metav1.Time)
 				adjustedStartTime = &taskRun.CreationTimestamp
 			}
 		}
+		if rpt.ChildPipelineRun != nil && rpt.ChildPipelineRun.CreationTimestamp.Time.Before(adjustedStartTime.Time) {
+			adjustedStartTime = &rpt.ChildPipelineRun.CreationTimestamp
+		}
 	}
 	return adjustedStartTime.DeepCopy()
 }
 
 // GetTaskRunsResults returns a map of all successfully completed TaskRuns in the state, with the pipeline task name as
 // the key and the results from the corresponding TaskRun as the value. It only includes tasks which have completed successfully.
+// The results of matrixed tasks, including matrixed custom tasks, are aggregated into array results ordered by combination.
+// The results of child Pipelines are the results of their child PipelineRuns.
 func (state PipelineRunState) GetTaskRunsResults() map[string][]v1beta1.TaskRunResult {
 	results := make(map[string][]v1beta1.TaskRunResult)
 	for _, rpt := range state {
-		if rpt.IsCustomTask() {
+		if !rpt.isSuccessful() {
 			continue
 		}
-		if !rpt.isSuccessful() {
+		if rpt.IsChildPipeline() {
+			results[rpt.PipelineTask.Name] = rpt.childPipelineRunResults()
+			continue
+		}
+		if rpt.PipelineTask.IsMatrixed() {
+			// Results which cannot be aggregated are left out, making the pipeline results using them invalid.
+			if aggregated, err := rpt.aggregateMatrixResults(); err == nil {
+				results[rpt.PipelineTask.Name] = aggregated
+			}
+			continue
+		}
+		if rpt.IsCustomTask() {
 			continue
 		}
-		// Currently a Matrix cannot produce results so this is for a singular TaskRun
 		if len(rpt.TaskRuns) == 1 {
 			results[rpt.PipelineTask.Name] = rpt.TaskRuns[0].Status.TaskRunResults
 		}
@@ -164,6 +187,19 @@ func (state PipelineRunState) GetTaskRunsResults() map[string][]v1beta1.TaskRunR
 	return results
 }
 
+// childPipelineRunResults returns the results of the child PipelineRun as the results of the PipelineTask.
+func (t *ResolvedPipelineTask) childPipelineRunResults() []v1beta1.TaskRunResult {
+	var results []v1beta1.TaskRunResult
+	for _, result := range t.ChildPipelineRun.Status.PipelineResults {
+		results = append(results, v1beta1.TaskRunResult{
+			Name:  result.Name,
+			Type:  v1beta1.ResultsType(result.Value.Type),
+			Value: result.Value,
+		})
+	}
+	return results
+}
+
 // GetRunsResults returns a map of all successfully completed Runs in the state, with the pipeline task name as the key
 // and the results from the corresponding TaskRun as the value. It only includes runs which have completed successfully.
 func (state PipelineRunState) GetRunsResults() map[string][]v1beta1.CustomRunResult {
@@ -175,8 +211,8 @@ func (state PipelineRunState) GetRunsResults() map[string][]v1beta1.CustomRunRes
 		if !rpt.isSuccessful() {
 			continue
 		}
-		// Currently a Matrix cannot produce results so this is for a singular CustomRun
-		if len(rpt.RunObjects) == 1 {
+		// The results of matrixed custom tasks are aggregated by GetTaskRunsResults
+		if len(rpt.RunObjects) == 1 && !rpt.PipelineTask.IsMatrixed() {
 			cr := rpt.RunObjects[0].(*v1beta1.CustomRun)
 			results[rpt.PipelineTask.Name] = cr.Status.Results
 		}
@@ -186,7 +222,7 @@ func (state PipelineRunState) GetRunsResults() map[string][]v1beta1.CustomRunRes
 }
 
 // GetChildReferences returns a slice of references, including version, kind, name, and pipeline task name, for all
-// TaskRuns and Runs in the state.
+// TaskRuns, Runs and child PipelineRuns in the state.
 func (state PipelineRunState) GetChildReferences() []v1beta1.ChildStatusReference {
 	var childRefs []v1beta1.ChildStatusReference
 
@@ -204,6 +240,8 @@ func (state PipelineRunState) GetChildReferences() []v1beta1.ChildStatusReferenc
 					childRefs = append(childRefs, rpt.getChildRefForRun(run))
 				}
 			}
+		case rpt.ChildPipelineRun != nil:
+			childRefs = append(childRefs, rpt.getChildRefForPipelineRun(rpt.ChildPipelineRun))
 		}
 	}
 	return childRefs
@@ -221,6 +259,18 @@ func (t *ResolvedPipelineTask) getChildRefForRun(runObj v1beta1.RunObject) v1bet
 	}
 }
 
+func (t *ResolvedPipelineTask) getChildRefForPipelineRun(pipelineRun *v1beta1.PipelineRun) v1beta1.ChildStatusReference {
+	return v1beta1.ChildStatusReference{
+		TypeMeta: runtime.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       pipeline.PipelineRunControllerName,
+		},
+		Name:             pipelineRun.Name,
+		PipelineTaskName: t.PipelineTask.Name,
+		WhenExpressions:  t.PipelineTask.WhenExpressions,
+	}
+}
+
 func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1beta1.TaskRun) v1beta1.ChildStatusReference {
 	return v1beta1.ChildStatusReference{
 		TypeMeta: runtime.TypeMeta{
@@ -240,7 +290,7 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 	tasks := []*ResolvedPipelineTask{}
 	for _, t := range state {
 		if _, ok := candidateTasks[t.PipelineTask.Name]; ok {
-			if len(t.TaskRuns) == 0 && len(t.RunObjects) == 0 {
+			if len(t.TaskRuns) == 0 && len(t.RunObjects) == 0 && t.ChildPipelineRun == nil {
 				tasks = append(tasks, t)
 			}
 		}
@@ -249,11 +299,16 @@ func (state PipelineRunState) getNextTasks(candidateTasks sets.String) []*Resolv
 }
 
 // IsStopping returns true if the PipelineRun won't be scheduling any new Task because
-// at least one task already failed or was cancelled in the specified dag
+// at least one task already failed or was cancelled in the specified dag. A PipelineRun
+// with the FailAtEnd failure policy keeps scheduling the tasks which don't depend on the
+// failed tasks, so it never stops.
 func (facts *PipelineRunFacts) IsStopping() bool {
+	if facts.FailurePolicy == v1beta1.PipelineRunFailAtEnd {
+		return false
+	}
 	for _, t := range facts.State {
 		if facts.isDAGTask(t.PipelineTask.Name) {
-			if t.isFailure() {
+			if t.isFailure() && !t.PipelineTask.ContinuesOnError() {
 				return true
 			}
 		}
@@ -358,6 +413,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 	// 1. Timed out -> Failed
 	// 2. All tasks are done and at least one has failed or has been cancelled -> Failed
 	// 3. All tasks are done or are skipped (i.e. condition check failed).-> Success
+	//    (with warnings when tasks with onError continue failed)
 	// 4. A Task or Condition is running right now or there are things left to run -> Running
 	if pr.HasTimedOut(ctx, c) {
 		return &apis.Condition{
@@ -390,7 +446,7 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 		}
 
 		switch {
-		case s.Failed > 0 || s.SkippedDueToTimeout > 0:
+		case s.Failed > s.IgnoredFailures || s.SkippedDueToTimeout > 0:
 			// Set reason to ReasonFailed - At least one failed
 			reason = v1beta1.PipelineRunReasonFailed.String()
 			status = corev1.ConditionFalse
@@ -403,6 +459,9 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 			// Set reason to ReasonCancelled - At least one is cancelled and no failure yet
 			reason = v1beta1.PipelineRunReasonCancelled.String()
 			status = corev1.ConditionFalse
+		case s.IgnoredFailures > 0:
+			// Set reason to ReasonCompletedWithWarnings - Only tasks with onError continue failed
+			reason = v1beta1.PipelineRunReasonCompletedWithWarnings.String()
 		}
 		logger.Infof("All TaskRuns have finished for PipelineRun %s so it has finished", pr.Name)
 		return &apis.Condition{
@@ -421,10 +480,11 @@ func (facts *PipelineRunFacts) GetPipelineConditionStatus(ctx context.Context, p
 	case pr.IsGracefullyStopped():
 		// Transition pipeline into running finally state, when graceful stop is in progress
 		reason = v1beta1.PipelineRunReasonStoppedRunningFinally.String()
-	case s.Cancelled > 0 || (s.Failed > 0 && facts.checkFinalTasksDone()):
+	case s.Cancelled > 0 || (s.Failed > s.ContinuedOnError && facts.checkFinalTasksDone() && facts.FailurePolicy != v1beta1.PipelineRunFailAtEnd):
 		// Transition pipeline into stopping state when one of the tasks(dag/final) cancelled or one of the dag tasks failed
 		// for a pipeline with final tasks, single dag task failure does not transition to interim stopping state
 		// pipeline stays in running state until all final tasks are done before transitioning to failed state
+		// a pipeline with the FailAtEnd failure policy stays in running state while running the other tasks
 		reason = v1beta1.PipelineRunReasonStopping.String()
 	}
 
@@ -456,8 +516,8 @@ func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 				Reason: rpt.IsFinallySkipped(facts).SkippingReason,
 			}
 			// include the when expressions only when the finally task was skipped because
-			// its when expressions evaluated to false (not because results variables were missing)
-			if rpt.IsFinallySkipped(facts).SkippingReason == v1beta1.WhenExpressionsSkip {
+			// of its when expressions (not because results variables were missing)
+			if reason := rpt.IsFinallySkipped(facts).SkippingReason; reason == v1beta1.WhenExpressionsSkip || reason == v1beta1.WhenExpressionsErrorSkip {
 				skippedTask.WhenExpressions = rpt.PipelineTask.WhenExpressions
 			}
 			skipped = append(skipped, skippedTask)
@@ -466,6 +526,23 @@ func (facts *PipelineRunFacts) GetSkippedTasks() []v1beta1.SkippedTask {
 	return skipped
 }
 
+// WhenExpressionsEvaluationError returns the error of the first task skipped because its CEL when
+// expressions failed to be evaluated, e.g. comparing a string result to a number.
+func (facts *PipelineRunFacts) WhenExpressionsEvaluationError() error {
+	for _, rpt := range facts.State {
+		if rpt.Skip(facts).SkippingReason != v1beta1.WhenExpressionsErrorSkip && rpt.IsFinallySkipped(facts).SkippingReason != v1beta1.WhenExpressionsErrorSkip {
+			continue
+		}
+		// The results are applied to a copy of the task when its skipping reason is computed
+		t := *rpt
+		if resolvedResultRefs, _, err := ResolveResultRefs(facts.State, PipelineRunState{&t}); err == nil {
+			ApplyTaskResults(PipelineRunState{&t}, resolvedResultRefs)
+		}
+		return fmt.Errorf("the when expressions of pipeline task %s failed to be evaluated: %w", t.PipelineTask.Name, t.PipelineTask.WhenExpressions.EvaluationError())
+	}
+	return nil
+}
+
 // GetPipelineTaskStatus returns the status of a PipelineTask depending on its taskRun
 // the checks are implemented such that the finally tasks are requesting status of the dag tasks
 func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
@@ -497,7 +574,7 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 		for _, t := range facts.State {
 			if facts.isDAGTask(t.PipelineTask.Name) {
 				// if any of the dag task failed, change the aggregate status to failed and return
-				if !t.IsCustomTask() && t.areTaskRunsConditionStatusFalse() || t.IsCustomTask() && t.areRunObjectsConditionStatusFalse() {
+				if t.isConditionStatusFalse() {
 					aggregateStatus = v1beta1.PipelineRunReasonFailed.String()
 					break
 				}
@@ -513,6 +590,37 @@ func (facts *PipelineRunFacts) GetPipelineTaskStatus() map[string]string {
 	return tStatus
 }
 
+// GetPipelineTaskStatusDetails returns the details of the execution of the dag tasks requested by the finally tasks,
+// along with their status: the reason of the status of each dag task, tasks.<pipelineTask>.reason, and the numbers
+// of dag tasks which failed and were skipped, tasks.failed.count and tasks.skipped.count, as well as the lists of
+// their names, tasks.failed and tasks.skipped
+func (facts *PipelineRunFacts) GetPipelineTaskStatusDetails() (map[string]string, map[string][]string) {
+	details := make(map[string]string)
+	failed, skipped := []string{}, []string{}
+	for _, t := range facts.State {
+		if !facts.isDAGTask(t.PipelineTask.Name) {
+			continue
+		}
+		reason := PipelineTaskStateNone
+		switch {
+		case t.Skip(facts).IsSkipped:
+			skipped = append(skipped, t.PipelineTask.Name)
+		case t.isSuccessful() || t.isConditionStatusFalse():
+			reason = t.conditionReason()
+			if t.isConditionStatusFalse() {
+				failed = append(failed, t.PipelineTask.Name)
+			}
+		}
+		details[PipelineTaskStatusPrefix+t.PipelineTask.Name+PipelineTaskReasonSuffix] = reason
+	}
+	details[v1beta1.PipelineTasksFailedCount] = strconv.Itoa(len(failed))
+	details[v1beta1.PipelineTasksSkippedCount] = strconv.Itoa(len(skipped))
+	return details, map[string][]string{
+		v1beta1.PipelineTasksFailed:  failed,
+		v1beta1.PipelineTasksSkipped: skipped,
+	}
+}
+
 // completedOrSkippedTasks returns a list of the names of all of the PipelineTasks in state
 // which have completed or skipped
 func (facts *PipelineRunFacts) completedOrSkippedDAGTasks() []string {
@@ -559,6 +667,8 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		Cancelled:           0,
 		Incomplete:          0,
 		SkippedDueToTimeout: 0,
+		ContinuedOnError:    0,
+		IgnoredFailures:     0,
 	}
 	for _, t := range facts.State {
 		switch {
@@ -568,12 +678,14 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 		// increment failure counter since the task is cancelled due to a timeout
 		case t.isCancelledForTimeOut():
 			s.Failed++
+			s.countFailureOnError(t.PipelineTask)
 		// increment cancelled counter since the task is cancelled
 		case t.isCancelled():
 			s.Cancelled++
 		// increment failure counter since the task has failed
 		case t.isFailure():
 			s.Failed++
+			s.countFailureOnError(t.PipelineTask)
 		// increment skipped and skipped due to timeout counters since the task was skipped due to the pipeline, tasks, or finally timeout being reached before the task was launched
 		case t.Skip(facts).SkippingReason == v1beta1.PipelineTimedOutSkip ||
 			t.Skip(facts).SkippingReason == v1beta1.TasksTimedOutSkip ||
@@ -594,6 +706,16 @@ func (facts *PipelineRunFacts) getPipelineTasksCount() pipelineRunStatusCount {
 	return s
 }
 
+// countFailureOnError counts the failure of a pipeline task which continues on error
+func (s *pipelineRunStatusCount) countFailureOnError(pt *v1beta1.PipelineTask) {
+	if pt.ContinuesOnError() {
+		s.ContinuedOnError++
+	}
+	if pt.OnError == v1beta1.PipelineTaskContinue {
+		s.IgnoredFailures++
+	}
+}
+
 // check if a specified pipelineTask is defined under tasks(DAG) section
 func (facts *PipelineRunFacts) isDAGTask(pipelineTaskName string) bool {
 	if _, ok := facts.TasksGraph.Nodes[pipelineTaskName]; ok {
@@ -1213,6 +1213,87 @@ func TestDAGExecutionQueueSequentialRuns(t *testing.T) {
 	}
 }
 
+func TestDAGExecutionQueue_FailAtEnd(t *testing.T) {
+	state := PipelineRunState{{
+		PipelineTask: &v1beta1.PipelineTask{Name: "lint", TaskRef: &v1beta1.TaskRef{Name: "task"}},
+		TaskRunNames: []string{"lint"},
+		TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0])},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "unit", TaskRef: &v1beta1.TaskRef{Name: "task"}, RunAfter: []string{"lint"}},
+		TaskRunNames: []string{"unit"},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "deploy", TaskRef: &v1beta1.TaskRef{Name: "task"}, RunAfter: []string{"unit"}},
+		TaskRunNames: []string{"deploy"},
+	}, {
+		PipelineTask: &v1beta1.PipelineTask{Name: "e2e", TaskRef: &v1beta1.TaskRef{Name: "task"}},
+		TaskRunNames: []string{"e2e"},
+	}}
+	for _, tc := range []struct {
+		name          string
+		failurePolicy v1beta1.PipelineRunFailurePolicy
+		want          []string
+		wantSkipped   map[string]v1beta1.SkippingReason
+		wantReason    v1beta1.PipelineRunReason
+	}{{
+		name:          "FailFast",
+		failurePolicy: v1beta1.PipelineRunFailFast,
+		wantSkipped: map[string]v1beta1.SkippingReason{
+			"unit":   v1beta1.StoppingSkip,
+			"deploy": v1beta1.StoppingSkip,
+			"e2e":    v1beta1.StoppingSkip,
+		},
+		wantReason: v1beta1.PipelineRunReasonFailed,
+	}, {
+		name:          "FailAtEnd",
+		failurePolicy: v1beta1.PipelineRunFailAtEnd,
+		want:          []string{"e2e"},
+		wantSkipped: map[string]v1beta1.SkippingReason{
+			"unit":   v1beta1.ParentTasksFailedSkip,
+			"deploy": v1beta1.ParentTasksSkip,
+		},
+		wantReason: v1beta1.PipelineRunReasonRunning,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           state,
+				FailurePolicy:   tc.failurePolicy,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState:   PipelineRunTimeoutsState{Clock: testClock},
+			}
+			queue, err := facts.DAGExecutionQueue()
+			if err != nil {
+				t.Fatalf("Unexpected error getting DAG execution queue: %s", err)
+			}
+			var got []string
+			for _, rpt := range queue {
+				got = append(got, rpt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Didn't get expected execution queue: %s", diff.PrintWantGot(d))
+			}
+			skipped := map[string]v1beta1.SkippingReason{}
+			for _, rpt := range state {
+				if s := rpt.Skip(&facts); s.IsSkipped {
+					skipped[rpt.PipelineTask.Name] = s.SkippingReason
+				}
+			}
+			if d := cmp.Diff(tc.wantSkipped, skipped); d != "" {
+				t.Errorf("Didn't get expected skipped tasks: %s", diff.PrintWantGot(d))
+			}
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"}}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar(), testClock)
+			if c.Reason != tc.wantReason.String() {
+				t.Errorf("Expected the reason %s but got %s", tc.wantReason, c.Reason)
+			}
+		})
+	}
+}
+
 func TestPipelineRunState_CompletedOrSkippedDAGTasks(t *testing.T) {
 	largePipelineState := buildPipelineStateWithLargeDependencyGraph(t)
 	tcs := []struct {
@@ -2004,6 +2085,103 @@ func TestGetPipelineConditionStatus_WithFinalTasks(t *testing.T) {
 }
 
 // pipeline should result in timeout if its runtime exceeds its spec.Timeout based on its status.Timeout
+func TestGetPipelineConditionStatus_OnError(t *testing.T) {
+	// state with a failed task, lint, and a task running after it, build
+	onErrorState := func(onError v1beta1.PipelineTaskOnErrorType, build *v1beta1.TaskRun) PipelineRunState {
+		var buildTaskRuns []*v1beta1.TaskRun
+		if build != nil {
+			buildTaskRuns = []*v1beta1.TaskRun{build}
+		}
+		return PipelineRunState{{
+			TaskRunNames: []string{"lint-taskrun"},
+			PipelineTask: &v1beta1.PipelineTask{Name: "lint", TaskRef: &v1beta1.TaskRef{Name: "task"}, OnError: onError},
+			TaskRuns:     []*v1beta1.TaskRun{makeFailed(trs[0])},
+		}, {
+			TaskRunNames: []string{"build-taskrun"},
+			PipelineTask: &v1beta1.PipelineTask{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "task"}, RunAfter: []string{"lint"}},
+			TaskRuns:     buildTaskRuns,
+		}}
+	}
+
+	tcs := []struct {
+		name               string
+		state              PipelineRunState
+		expectedStatus     corev1.ConditionStatus
+		expectedReason     string
+		expectedSucceeded  int
+		expectedIncomplete int
+		expectedSkipped    int
+		expectedFailed     int
+	}{{
+		name:            "stopAndFail skips the tasks after the failed task",
+		state:           onErrorState(v1beta1.PipelineTaskStopAndFail, nil),
+		expectedStatus:  corev1.ConditionFalse,
+		expectedReason:  v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed:  1,
+		expectedSkipped: 1,
+	}, {
+		name:               "continue keeps running the tasks after the failed task",
+		state:              onErrorState(v1beta1.PipelineTaskContinue, nil),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedFailed:     1,
+		expectedIncomplete: 1,
+	}, {
+		name:              "continue completes with warnings",
+		state:             onErrorState(v1beta1.PipelineTaskContinue, makeSucceeded(trs[1])),
+		expectedStatus:    corev1.ConditionTrue,
+		expectedReason:    v1beta1.PipelineRunReasonCompletedWithWarnings.String(),
+		expectedSucceeded: 1,
+		expectedFailed:    1,
+	}, {
+		name:           "continue fails when another task fails",
+		state:          onErrorState(v1beta1.PipelineTaskContinue, makeFailed(trs[1])),
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: v1beta1.PipelineRunReasonFailed.String(),
+		expectedFailed: 2,
+	}, {
+		name:               "continueAndFail keeps running the tasks after the failed task",
+		state:              onErrorState(v1beta1.PipelineTaskContinueAndFail, makeStarted(trs[1])),
+		expectedStatus:     corev1.ConditionUnknown,
+		expectedReason:     v1beta1.PipelineRunReasonRunning.String(),
+		expectedFailed:     1,
+		expectedIncomplete: 1,
+	}, {
+		name:              "continueAndFail fails once the tasks are done",
+		state:             onErrorState(v1beta1.PipelineTaskContinueAndFail, makeSucceeded(trs[1])),
+		expectedStatus:    corev1.ConditionFalse,
+		expectedReason:    v1beta1.PipelineRunReasonFailed.String(),
+		expectedSucceeded: 1,
+		expectedFailed:    1,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "somepipelinerun"}}
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error while building DAG for state %v: %v", tc.state, err)
+			}
+			facts := PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState:   PipelineRunTimeoutsState{Clock: testClock},
+			}
+			c := facts.GetPipelineConditionStatus(context.Background(), pr, zap.NewNop().Sugar(), testClock)
+			wantCondition := &apis.Condition{
+				Type:   apis.ConditionSucceeded,
+				Status: tc.expectedStatus,
+				Reason: tc.expectedReason,
+				Message: getExpectedMessage(pr.Name, "", tc.expectedStatus, tc.expectedSucceeded,
+					tc.expectedIncomplete, tc.expectedSkipped, tc.expectedFailed, 0),
+			}
+			if d := cmp.Diff(wantCondition, c); d != "" {
+				t.Fatalf("Mismatch in condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetPipelineConditionStatus_PipelineTimeoutDeprecated(t *testing.T) {
 	d, err := dagFromState(oneFinishedState)
 	if err != nil {
@@ -2374,6 +2552,63 @@ func TestPipelineRunFacts_GetPipelineTaskStatus(t *testing.T) {
 	}
 }
 
+func TestPipelineRunFacts_GetPipelineTaskStatusDetails(t *testing.T) {
+	succeeded := makeSucceeded(trs[1])
+	succeeded.Status.Conditions[0].Reason = v1beta1.TaskRunReasonSuccessful.String()
+	state := PipelineRunState{{
+		PipelineTask: &pts[0],
+		TaskRunNames: []string{"pipelinerun-mytask1"},
+		TaskRuns:     []*v1beta1.TaskRun{withCancelled(makeFailed(trs[0]))},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &task.Spec,
+		},
+	}, {
+		PipelineTask: &pts[1],
+		TaskRunNames: []string{"pipelinerun-mytask2"},
+		TaskRuns:     []*v1beta1.TaskRun{succeeded},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &task.Spec,
+		},
+	}, {
+		PipelineTask: &pts[10],
+		TaskRunNames: []string{"pr-guardedtask-skipped"},
+		ResolvedTask: &resources.ResolvedTask{
+			TaskSpec: &task.Spec,
+		},
+	}}
+	dagTasks := []v1beta1.PipelineTask{pts[0], pts[1], pts[10]}
+	d, err := dag.Build(v1beta1.PipelineTaskList(dagTasks), v1beta1.PipelineTaskList(dagTasks).Deps())
+	if err != nil {
+		t.Fatalf("Unexpected error while building graph for DAG tasks %v: %v", dagTasks, err)
+	}
+	facts := PipelineRunFacts{
+		State:           state,
+		TasksGraph:      d,
+		FinalTasksGraph: &dag.Graph{},
+		TimeoutsState: PipelineRunTimeoutsState{
+			Clock: testClock,
+		},
+	}
+	details, arrayDetails := facts.GetPipelineTaskStatusDetails()
+	expectedDetails := map[string]string{
+		PipelineTaskStatusPrefix + pts[0].Name + PipelineTaskReasonSuffix:  v1beta1.TaskRunSpecStatusCancelled,
+		PipelineTaskStatusPrefix + pts[1].Name + PipelineTaskReasonSuffix:  v1beta1.TaskRunReasonSuccessful.String(),
+		PipelineTaskStatusPrefix + pts[10].Name + PipelineTaskReasonSuffix: PipelineTaskStateNone,
+		v1beta1.PipelineTasksFailedCount:                                   "1",
+		v1beta1.PipelineTasksSkippedCount:                                  "1",
+	}
+	if d := cmp.Diff(expectedDetails, details); d != "" {
+		t.Errorf("Mismatch in the details of the pipelineTasks execution %s", diff.PrintWantGot(d))
+	}
+	expectedArrayDetails := map[string][]string{
+		v1beta1.PipelineTasksFailed:  {pts[0].Name},
+		v1beta1.PipelineTasksSkipped: {pts[10].Name},
+	}
+	if d := cmp.Diff(expectedArrayDetails, arrayDetails); d != "" {
+		t.Errorf("Mismatch in the lists of failed and skipped pipelineTasks %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	for _, tc := range []struct {
 		name                 string
@@ -2424,6 +2659,28 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 				Values:   []string{"foo", "bar"},
 			}},
 		}},
+	}, {
+		name: "when-expressions-error-skip",
+		state: PipelineRunState{{
+			PipelineTask: &celErrorTask,
+		}},
+		dagTasks: []v1beta1.PipelineTask{celErrorTask},
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:            celErrorTask.Name,
+			Reason:          v1beta1.WhenExpressionsErrorSkip,
+			WhenExpressions: celErrorTask.WhenExpressions,
+		}},
+	}, {
+		name: "when-expressions-error-skip-finally",
+		state: PipelineRunState{{
+			PipelineTask: &celErrorTask,
+		}},
+		finallyTasks: []v1beta1.PipelineTask{celErrorTask},
+		expectedSkippedTasks: []v1beta1.SkippedTask{{
+			Name:            celErrorTask.Name,
+			Reason:          v1beta1.WhenExpressionsErrorSkip,
+			WhenExpressions: celErrorTask.WhenExpressions,
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			d, err := dag.Build(v1beta1.PipelineTaskList(tc.dagTasks), v1beta1.PipelineTaskList(tc.dagTasks).Deps())
@@ -2450,6 +2707,52 @@ func TestPipelineRunFacts_GetSkippedTasks(t *testing.T) {
 	}
 }
 
+// celErrorTask is a task whose CEL when expression fails to be evaluated
+var celErrorTask = v1beta1.PipelineTask{
+	Name:            "cel-error",
+	TaskRef:         &v1beta1.TaskRef{Name: "task"},
+	WhenExpressions: v1beta1.WhenExpressions{{CEL: `int("many") < 3`}},
+}
+
+func TestPipelineRunFacts_WhenExpressionsEvaluationError(t *testing.T) {
+	celTask := celErrorTask
+	celTask.WhenExpressions = v1beta1.WhenExpressions{{CEL: `int("2") < 3`}}
+	for _, tc := range []struct {
+		name      string
+		task      v1beta1.PipelineTask
+		wantError bool
+	}{{
+		name: "cel expression evaluated",
+		task: celTask,
+	}, {
+		name:      "cel expression failing to be evaluated",
+		task:      celErrorTask,
+		wantError: true,
+	}, {
+		name: "no when expressions",
+		task: pts[0],
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			task := tc.task
+			d, err := dag.Build(v1beta1.PipelineTaskList{task}, map[string][]string{})
+			if err != nil {
+				t.Fatalf("Unexpected error while building graph for DAG tasks: %v", err)
+			}
+			facts := PipelineRunFacts{
+				State:           PipelineRunState{{PipelineTask: &task}},
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+				TimeoutsState: PipelineRunTimeoutsState{
+					Clock: testClock,
+				},
+			}
+			if err := facts.WhenExpressionsEvaluationError(); (err != nil) != tc.wantError {
+				t.Errorf("WhenExpressionsEvaluationError() = %v, want error %t", err, tc.wantError)
+			}
+		})
+	}
+}
+
 func TestPipelineRunFacts_IsRunning(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -2842,6 +3145,8 @@ func TestPipelineRunState_GetResultsFuncs(t *testing.T) {
 			Value: *v1beta1.NewStructuredValues("rab"),
 		}},
 		"successful-task-without-results-1": nil,
+		// The TaskRuns of the matrixed task produce no results to aggregate.
+		"matrixed-task": nil,
 	}
 	expectedRunResults := map[string][]v1beta1.CustomRunResult{
 		"successful-run-with-results-1": {{
@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// ResultRefState is the state of the resolution of a result reference consumed by a PipelineTask.
+type ResultRefState string
+
+const (
+	// ResultRefResolvable indicates that the result reference can be resolved now.
+	ResultRefResolvable ResultRefState = "Resolvable"
+	// ResultRefPending indicates that the PipelineTask producing the result has not finished yet.
+	ResultRefPending ResultRefState = "Pending"
+	// ResultRefUnresolvable indicates that the result reference will never resolve, e.g. because the
+	// PipelineTask producing the result was skipped, or finished without producing it.
+	ResultRefUnresolvable ResultRefState = "Unresolvable"
+)
+
+// ResultRefReport reports whether a result reference consumed by a PipelineTask can be resolved.
+type ResultRefReport struct {
+	// PipelineTask is the name of the PipelineTask consuming the result.
+	PipelineTask    string            `json:"pipelineTask"`
+	ResultReference v1beta1.ResultRef `json:"resultReference"`
+	State           ResultRefState    `json:"state"`
+	// Message explains why the result reference is Pending or Unresolvable.
+	Message string `json:"message,omitempty"`
+}
+
+// ResultRefReports resolves, without applying them, the result references consumed by the PipelineTasks that
+// have not been scheduled yet, and reports which of them are resolvable, which are pending and which will never
+// resolve. The reports are ordered by PipelineTask as in the PipelineRunState, then by result reference.
+func (facts *PipelineRunFacts) ResultRefReports() []ResultRefReport {
+	var reports []ResultRefReport
+	stateMap := facts.State.ToMap()
+	for _, target := range facts.State {
+		if target.isScheduled() {
+			continue
+		}
+		refs := v1beta1.PipelineTaskResultRefs(target.PipelineTask)
+		seen := make(map[v1beta1.ResultRef]bool, len(refs))
+		var targetReports []ResultRefReport
+		for _, ref := range refs {
+			if seen[*ref] {
+				continue
+			}
+			seen[*ref] = true
+			report := facts.resultRefReport(stateMap[ref.PipelineTask], ref)
+			report.PipelineTask = target.PipelineTask.Name
+			targetReports = append(targetReports, report)
+		}
+		sort.SliceStable(targetReports, func(i, j int) bool {
+			a, b := targetReports[i].ResultReference, targetReports[j].ResultReference
+			if a.PipelineTask != b.PipelineTask {
+				return a.PipelineTask < b.PipelineTask
+			}
+			return a.Result < b.Result
+		})
+		reports = append(reports, targetReports...)
+	}
+	return reports
+}
+
+func (facts *PipelineRunFacts) resultRefReport(producer *ResolvedPipelineTask, ref *v1beta1.ResultRef) ResultRefReport {
+	report := ResultRefReport{ResultReference: *ref}
+	switch {
+	case producer == nil:
+		report.State = ResultRefUnresolvable
+		report.Message = fmt.Sprintf("could not find task %q referenced by result", ref.PipelineTask)
+	case producer.isSuccessful() || producer.isFailure():
+		resolved, _, err := resolveResultRef(facts.State, ref)
+		if err == nil {
+			_, _, err = validateArrayResultsIndex(ResolvedResultRefs{resolved})
+		}
+		switch {
+		case err != nil && producer.isFailure():
+			report.State = ResultRefUnresolvable
+			report.Message = fmt.Sprintf("task %q referenced by result failed: %v", ref.PipelineTask, err)
+		case err != nil:
+			report.State = ResultRefUnresolvable
+			report.Message = err.Error()
+		default:
+			report.State = ResultRefResolvable
+		}
+	case producer.Skip(facts).IsSkipped:
+		report.State = ResultRefUnresolvable
+		report.Message = fmt.Sprintf("task %q referenced by result was skipped: %s", ref.PipelineTask, producer.Skip(facts).SkippingReason)
+	default:
+		report.State = ResultRefPending
+		report.Message = fmt.Sprintf("task %q referenced by result was not finished", ref.PipelineTask)
+	}
+	return report
+}
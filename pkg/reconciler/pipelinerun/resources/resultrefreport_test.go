@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func resultRefReportTaskRun(name string, conditions duckv1.Conditions, results ...v1beta1.TaskRunResult) *v1beta1.TaskRun {
+	return &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: conditions},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				TaskRunResults: results,
+			},
+		},
+	}
+}
+
+func resultRefReportConsumer(name string, refs ...string) *ResolvedPipelineTask {
+	pt := &v1beta1.PipelineTask{Name: name, TaskRef: &v1beta1.TaskRef{Name: name}}
+	for _, ref := range refs {
+		pt.Params = append(pt.Params, v1beta1.Param{Name: ref, Value: *v1beta1.NewStructuredValues("$(tasks." + ref + ")")})
+	}
+	return &ResolvedPipelineTask{PipelineTask: pt}
+}
+
+func TestResultRefReports(t *testing.T) {
+	succeeded := &ResolvedPipelineTask{
+		TaskRuns: []*v1beta1.TaskRun{resultRefReportTaskRun("aTaskRun", duckv1.Conditions{successCondition}, v1beta1.TaskRunResult{
+			Name:  "aResult",
+			Value: *v1beta1.NewStructuredValues("aResultValue"),
+		})},
+		PipelineTask: &v1beta1.PipelineTask{Name: "aTask", TaskRef: &v1beta1.TaskRef{Name: "aTask"}},
+	}
+	running := &ResolvedPipelineTask{
+		TaskRuns:     []*v1beta1.TaskRun{resultRefReportTaskRun("rTaskRun", nil)},
+		PipelineTask: &v1beta1.PipelineTask{Name: "rTask", TaskRef: &v1beta1.TaskRef{Name: "rTask"}},
+	}
+	whenSkipped := &ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "sTask",
+			TaskRef: &v1beta1.TaskRef{Name: "sTask"},
+			WhenExpressions: v1beta1.WhenExpressions{{
+				Input:    "foo",
+				Operator: selection.In,
+				Values:   []string{"bar"},
+			}},
+		},
+	}
+	failed := &ResolvedPipelineTask{
+		TaskRuns:     []*v1beta1.TaskRun{resultRefReportTaskRun("fTaskRun", duckv1.Conditions{failedCondition})},
+		PipelineTask: &v1beta1.PipelineTask{Name: "fTask", TaskRef: &v1beta1.TaskRef{Name: "fTask"}},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		state PipelineRunState
+		want  []ResultRefReport
+	}{{
+		name: "running pipelinerun",
+		state: PipelineRunState{
+			succeeded, running, whenSkipped,
+			resultRefReportConsumer("consumer", "rTask.results.rResult", "aTask.results.missing", "aTask.results.aResult", "sTask.results.sResult", "aTask.results.aResult"),
+		},
+		want: []ResultRefReport{{
+			PipelineTask:    "consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "aTask", Result: "aResult"},
+			State:           ResultRefResolvable,
+		}, {
+			PipelineTask:    "consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "aTask", Result: "missing"},
+			State:           ResultRefUnresolvable,
+			Message:         "Could not find result with name missing for task aTask",
+		}, {
+			PipelineTask:    "consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "rTask", Result: "rResult"},
+			State:           ResultRefPending,
+			Message:         `task "rTask" referenced by result was not finished`,
+		}, {
+			PipelineTask:    "consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "sTask", Result: "sResult"},
+			State:           ResultRefUnresolvable,
+			Message:         `task "sTask" referenced by result was skipped: When Expressions evaluated to false`,
+		}},
+	}, {
+		name: "stopping pipelinerun",
+		state: PipelineRunState{
+			failed, whenSkipped,
+			resultRefReportConsumer("consumer", "fTask.results.fResult"),
+			resultRefReportConsumer("another-consumer", "consumer.results.cResult"),
+		},
+		want: []ResultRefReport{{
+			PipelineTask:    "consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "fTask", Result: "fResult"},
+			State:           ResultRefUnresolvable,
+			Message:         `task "fTask" referenced by result failed: Could not find result with name fResult for task fTask`,
+		}, {
+			PipelineTask:    "another-consumer",
+			ResultReference: v1beta1.ResultRef{PipelineTask: "consumer", Result: "cResult"},
+			State:           ResultRefUnresolvable,
+			Message:         `task "consumer" referenced by result was skipped: PipelineRun was stopping`,
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := dagFromState(tc.state)
+			if err != nil {
+				t.Fatalf("Could not get a dag from the TC state %#v: %v", tc.state, err)
+			}
+			facts := &PipelineRunFacts{
+				State:           tc.state,
+				TasksGraph:      d,
+				FinalTasksGraph: &dag.Graph{},
+			}
+			if d := cmp.Diff(tc.want, facts.ResultRefReports()); d != "" {
+				t.Errorf("ResultRefReports() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package resources
 
 import (
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"sort"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/apis"
 )
 
 // ResolvedResultRefs represents all of the ResolvedResultRef for a pipeline task
@@ -58,6 +62,30 @@ func ResolveResultRefs(pipelineRunState PipelineRunState, targets PipelineRunSta
 	return validateArrayResultsIndex(removeDup(allResolvedResultRefs))
 }
 
+// CheckResultsVerified returns an error if a TaskRun producing the results referenced by
+// resolvedResultRefs doesn't have its results verified by SPIRE, i.e. its SignedResultsVerified
+// condition isn't True. The results of CustomRuns and child PipelineRuns aren't signed.
+func CheckResultsVerified(pipelineRunState PipelineRunState, resolvedResultRefs ResolvedResultRefs) error {
+	stateMap := pipelineRunState.ToMap()
+	for _, r := range resolvedResultRefs {
+		referencedPipelineTask := stateMap[r.ResultReference.PipelineTask]
+		if referencedPipelineTask == nil {
+			continue
+		}
+		for _, taskRun := range referencedPipelineTask.TaskRuns {
+			if taskRun.IsTaskRunResultVerified() {
+				continue
+			}
+			reason := "not verified yet"
+			if c := taskRun.Status.GetCondition(apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String())); c != nil && c.Message != "" {
+				reason = c.Message
+			}
+			return fmt.Errorf("results of TaskRun %s of task %q are not verified by SPIRE: %s", taskRun.Name, r.ResultReference.PipelineTask, reason)
+		}
+	}
+	return nil
+}
+
 // validateArrayResultsIndex checks if the result array indexing reference is out of bound of the array size
 func validateArrayResultsIndex(allResolvedResultRefs ResolvedResultRefs) (ResolvedResultRefs, string, error) {
 	for _, r := range allResolvedResultRefs {
@@ -129,7 +157,23 @@ func resolveResultRef(pipelineState PipelineRunState, resultRef *v1beta1.ResultR
 	var runName, runValue, taskRunName string
 	var resultValue v1beta1.ResultValue
 	var err error
-	if referencedPipelineTask.IsCustomTask() {
+	if referencedPipelineTask.PipelineTask.IsMatrixed() {
+		// The results of the fanned out runs are aggregated into array results.
+		results, err := referencedPipelineTask.aggregateMatrixResults()
+		if err != nil {
+			return nil, resultRef.PipelineTask, err
+		}
+		resultValue, err = findResultValue(results, resultRef)
+		if err != nil {
+			return nil, resultRef.PipelineTask, err
+		}
+	} else if referencedPipelineTask.IsChildPipeline() {
+		runName = referencedPipelineTask.ChildPipelineRun.Name
+		resultValue, err = findResultValue(referencedPipelineTask.childPipelineRunResults(), resultRef)
+		if err != nil {
+			return nil, resultRef.PipelineTask, err
+		}
+	} else if referencedPipelineTask.IsCustomTask() {
 		if len(referencedPipelineTask.RunObjects) != 1 {
 			return nil, resultRef.PipelineTask, fmt.Errorf("referenced tasks can only have length of 1 since a matrixed task does not support producing results, but was length %d", len(referencedPipelineTask.TaskRuns))
 		}
@@ -172,7 +216,10 @@ func findRunResultForParam(runObj v1beta1.RunObject, reference *v1beta1.ResultRe
 }
 
 func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultRef) (v1beta1.ResultValue, error) {
-	results := taskRun.Status.TaskRunStatusFields.TaskRunResults
+	return findResultValue(taskRun.Status.TaskRunStatusFields.TaskRunResults, reference)
+}
+
+func findResultValue(results []v1beta1.TaskRunResult, reference *v1beta1.ResultRef) (v1beta1.ResultValue, error) {
 	for _, result := range results {
 		if result.Name == reference.Result {
 			return result.Value, nil
@@ -181,6 +228,74 @@ func findTaskResultForParam(taskRun *v1beta1.TaskRun, reference *v1beta1.ResultR
 	return v1beta1.ResultValue{}, fmt.Errorf("Could not find result with name %s for task %s", reference.Result, reference.PipelineTask)
 }
 
+// aggregateMatrixResults returns the results of the TaskRuns or CustomRuns fanned out from a
+// matrixed PipelineTask, aggregated into array results holding the values of the results of each
+// combination, ordered by the index of the combination in the Matrix. Only string results can be
+// aggregated, and results which are missing from any of the combinations are omitted.
+func (t ResolvedPipelineTask) aggregateMatrixResults() ([]v1beta1.TaskRunResult, error) {
+	var combinations []map[string]string
+	var names []string
+	seen := sets.NewString()
+	addCombination := func(values map[string]string, order []string) {
+		combinations = append(combinations, values)
+		for _, name := range order {
+			if !seen.Has(name) {
+				seen.Insert(name)
+				names = append(names, name)
+			}
+		}
+	}
+	if t.IsCustomTask() {
+		for _, runObject := range t.RunObjects {
+			run, ok := runObject.(*v1beta1.CustomRun)
+			if !ok {
+				return nil, fmt.Errorf("unsupported run object %T for matrixed task %q", runObject, t.PipelineTask.Name)
+			}
+			values := map[string]string{}
+			var order []string
+			for _, result := range run.Status.Results {
+				values[result.Name] = result.Value
+				order = append(order, result.Name)
+			}
+			addCombination(values, order)
+		}
+	} else {
+		for _, taskRun := range t.TaskRuns {
+			values := map[string]string{}
+			var order []string
+			for _, result := range taskRun.Status.TaskRunResults {
+				if result.Value.Type != "" && result.Value.Type != v1beta1.ParamTypeString {
+					return nil, fmt.Errorf("result %q of matrixed task %q is of type %s, but only string results can be aggregated", result.Name, t.PipelineTask.Name, result.Value.Type)
+				}
+				values[result.Name] = result.Value.StringVal
+				order = append(order, result.Name)
+			}
+			addCombination(values, order)
+		}
+	}
+
+	var results []v1beta1.TaskRunResult
+	for _, name := range names {
+		aggregated := make([]string, 0, len(combinations))
+		for _, values := range combinations {
+			value, ok := values[name]
+			if !ok {
+				break
+			}
+			aggregated = append(aggregated, value)
+		}
+		if len(aggregated) != len(combinations) {
+			continue
+		}
+		results = append(results, v1beta1.TaskRunResult{
+			Name:  name,
+			Type:  v1beta1.ResultsTypeArray,
+			Value: v1beta1.ResultValue{Type: v1beta1.ParamTypeArray, ArrayVal: aggregated},
+		})
+	}
+	return results, nil
+}
+
 func (rs ResolvedResultRefs) getStringReplacements() map[string]string {
 	replacements := map[string]string{}
 	for _, r := range rs {
@@ -256,3 +371,76 @@ func (r *ResolvedResultRef) getReplaceTargetfromObjectKey(key string) []string {
 		fmt.Sprintf("%s.%s.%s['%s'][%s]", v1beta1.ResultTaskPart, r.ResultReference.PipelineTask, v1beta1.ResultResultPart, r.ResultReference.Result, key),
 	}
 }
+
+// Provenance returns, for each result consumed by the PipelineTask, the run which produced it, its
+// attempt and the digest of the value resolved for it. It must be called before the resolved
+// references are applied to the PipelineTask, since ApplyTaskResults replaces the references.
+// A result produced by a matrixed PipelineTask is recorded once for each of its runs.
+func (rs ResolvedResultRefs) Provenance(pipelineRunState PipelineRunState, pt *v1beta1.PipelineTask) []v1beta1.ResultProvenance {
+	resolvedResultRefByRef := make(map[v1beta1.ResultRef]*ResolvedResultRef, len(rs))
+	for _, r := range rs {
+		resolvedResultRefByRef[r.ResultReference] = r
+	}
+	state := pipelineRunState.ToMap()
+	seen := sets.NewString()
+	var provenance []v1beta1.ResultProvenance
+	for _, ref := range v1beta1.PipelineTaskResultRefs(pt) {
+		resolved, ok := resolvedResultRefByRef[*ref]
+		producer := state[ref.PipelineTask]
+		result := fmt.Sprintf("%s.%s.%s.%s", v1beta1.ResultTaskPart, ref.PipelineTask, v1beta1.ResultResultPart, ref.Result)
+		if !ok || producer == nil || seen.Has(result) {
+			continue
+		}
+		seen.Insert(result)
+		digest, err := resultDigest(resolved.Value)
+		if err != nil {
+			continue
+		}
+		for _, run := range producer.producingRuns() {
+			provenance = append(provenance, v1beta1.ResultProvenance{
+				PipelineTask: pt.Name,
+				Result:       result,
+				Run:          run.name,
+				Attempt:      run.attempt,
+				Digest:       digest,
+			})
+		}
+	}
+	sort.Slice(provenance, func(i, j int) bool {
+		if provenance[i].Result != provenance[j].Result {
+			return provenance[i].Result < provenance[j].Result
+		}
+		return provenance[i].Run < provenance[j].Run
+	})
+	return provenance
+}
+
+type producingRun struct {
+	name    string
+	attempt int
+}
+
+// producingRuns returns the names of the runs of the ResolvedPipelineTask, with the number of
+// times they were retried.
+func (t ResolvedPipelineTask) producingRuns() []producingRun {
+	var runs []producingRun
+	for _, taskRun := range t.TaskRuns {
+		runs = append(runs, producingRun{name: taskRun.Name, attempt: len(taskRun.Status.RetriesStatus)})
+	}
+	for _, runObject := range t.RunObjects {
+		runs = append(runs, producingRun{name: runObject.GetObjectMeta().GetName(), attempt: runObject.GetRetryCount()})
+	}
+	if t.ChildPipelineRun != nil {
+		runs = append(runs, producingRun{name: t.ChildPipelineRun.Name})
+	}
+	return runs
+}
+
+// resultDigest returns the sha256 digest of the JSON encoding of a result value.
+func resultDigest(value v1beta1.ResultValue) (string, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b)), nil
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package resources
 
 import (
+	"crypto/sha256"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -553,3 +555,282 @@ func lessResolvedResultRefs(i, j *ResolvedResultRef) bool {
 	}
 	return strings.Compare(fromI, fromJ) < 0
 }
+
+func TestResolveResultRef_MatrixResults(t *testing.T) {
+	matrix := &v1beta1.Matrix{
+		Params: v1beta1.Params{{
+			Name:  "platform",
+			Value: *v1beta1.NewStructuredValues("linux", "mac", "windows"),
+		}},
+	}
+	taskRun := func(name string, results ...v1beta1.TaskRunResult) *v1beta1.TaskRun {
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1beta1.TaskRunStatus{
+				Status:              duckv1.Status{Conditions: duckv1.Conditions{successCondition}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{TaskRunResults: results},
+			},
+		}
+	}
+	customRun := func(name string, results ...v1beta1.CustomRunResult) v1beta1.RunObject {
+		return &v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1beta1.CustomRunStatus{
+				Status:                duckv1.Status{Conditions: duckv1.Conditions{successCondition}},
+				CustomRunStatusFields: v1beta1.CustomRunStatusFields{Results: results},
+			},
+		}
+	}
+	consumer := func(expression string) *ResolvedPipelineTask {
+		return &ResolvedPipelineTask{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name:    "consumer",
+				TaskRef: &v1beta1.TaskRef{Name: "consumer"},
+				Params: v1beta1.Params{{
+					Name:  "p",
+					Value: v1beta1.ParamValue{Type: v1beta1.ParamTypeArray, ArrayVal: []string{expression}},
+				}},
+			},
+		}
+	}
+	state := PipelineRunState{{
+		TaskRunNames: []string{"build-0", "build-1", "build-2"},
+		TaskRuns: []*v1beta1.TaskRun{
+			taskRun("build-0",
+				v1beta1.TaskRunResult{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:linux")},
+				v1beta1.TaskRunResult{Name: "partial", Value: *v1beta1.NewStructuredValues("only-linux")}),
+			taskRun("build-1", v1beta1.TaskRunResult{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:mac")}),
+			taskRun("build-2", v1beta1.TaskRunResult{Name: "digest", Value: *v1beta1.NewStructuredValues("sha256:windows")}),
+		},
+		PipelineTask: &v1beta1.PipelineTask{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}, Matrix: matrix},
+	}, {
+		CustomTask:     true,
+		RunObjectNames: []string{"approve-0", "approve-1", "approve-2"},
+		RunObjects: []v1beta1.RunObject{
+			customRun("approve-0", v1beta1.CustomRunResult{Name: "approver", Value: "alice"}),
+			customRun("approve-1", v1beta1.CustomRunResult{Name: "approver", Value: "bob"}),
+			customRun("approve-2", v1beta1.CustomRunResult{Name: "approver", Value: "carol"}),
+		},
+		PipelineTask: &v1beta1.PipelineTask{Name: "approve", TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Approval"}, Matrix: matrix},
+	}, {
+		TaskRunNames: []string{"scan-0"},
+		TaskRuns: []*v1beta1.TaskRun{
+			taskRun("scan-0", v1beta1.TaskRunResult{Name: "findings", Type: v1beta1.ResultsTypeArray, Value: *v1beta1.NewStructuredValues("a", "b")}),
+		},
+		PipelineTask: &v1beta1.PipelineTask{Name: "scan", TaskRef: &v1beta1.TaskRef{Name: "scan"}, Matrix: matrix},
+	}}
+
+	for _, tc := range []struct {
+		name       string
+		expression string
+		want       v1beta1.ResultValue
+		wantErr    bool
+	}{{
+		name:       "string results of TaskRuns ordered by combination",
+		expression: "$(tasks.build.results.digest[*])",
+		want:       *v1beta1.NewStructuredValues("sha256:linux", "sha256:mac", "sha256:windows"),
+	}, {
+		name:       "results of CustomRuns ordered by combination",
+		expression: "$(tasks.approve.results.approver[1])",
+		want:       *v1beta1.NewStructuredValues("alice", "bob", "carol"),
+	}, {
+		name:       "result missing from a combination",
+		expression: "$(tasks.build.results.partial[*])",
+		wantErr:    true,
+	}, {
+		name:       "array results cannot be aggregated",
+		expression: "$(tasks.scan.results.findings[*])",
+		wantErr:    true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, _, err := ResolveResultRef(state, consumer(tc.expression))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error but got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveResultRef: %v", err)
+			}
+			if len(got) != 1 {
+				t.Fatalf("expected a single resolved result reference but got %v", got)
+			}
+			if d := cmp.Diff(tc.want, got[0].Value); d != "" {
+				t.Errorf("ResolveResultRef %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	wantTaskRunsResults := map[string][]v1beta1.TaskRunResult{
+		"build": {{
+			Name:  "digest",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewStructuredValues("sha256:linux", "sha256:mac", "sha256:windows"),
+		}},
+		"approve": {{
+			Name:  "approver",
+			Type:  v1beta1.ResultsTypeArray,
+			Value: *v1beta1.NewStructuredValues("alice", "bob", "carol"),
+		}},
+	}
+	if d := cmp.Diff(wantTaskRunsResults, state.GetTaskRunsResults()); d != "" {
+		t.Errorf("GetTaskRunsResults %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestResolvedResultRefs_Provenance(t *testing.T) {
+	state := PipelineRunState{{
+		TaskRunNames: []string{"build-run"},
+		TaskRuns: []*v1beta1.TaskRun{{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-run"},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{successCondition}},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					RetriesStatus:  []v1beta1.TaskRunStatus{{}},
+					TaskRunResults: []v1beta1.TaskRunResult{{Name: "image", Value: *v1beta1.NewStructuredValues("registry/image")}},
+				},
+			},
+		}},
+		PipelineTask: &v1beta1.PipelineTask{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}},
+	}, {
+		CustomTask:     true,
+		RunObjectNames: []string{"approve-0", "approve-1"},
+		RunObjects: []v1beta1.RunObject{&v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "approve-0"},
+			Status: v1beta1.CustomRunStatus{
+				Status:                duckv1.Status{Conditions: duckv1.Conditions{successCondition}},
+				CustomRunStatusFields: v1beta1.CustomRunStatusFields{Results: []v1beta1.CustomRunResult{{Name: "approver", Value: "alice"}}},
+			},
+		}, &v1beta1.CustomRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "approve-1"},
+			Status: v1beta1.CustomRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{successCondition}},
+				CustomRunStatusFields: v1beta1.CustomRunStatusFields{
+					RetriesStatus: []v1beta1.CustomRunStatus{{}, {}},
+					Results:       []v1beta1.CustomRunResult{{Name: "approver", Value: "bob"}},
+				},
+			},
+		}},
+		PipelineTask: &v1beta1.PipelineTask{
+			Name:    "approve",
+			TaskRef: &v1beta1.TaskRef{APIVersion: "example.dev/v0", Kind: "Approval"},
+			Matrix:  &v1beta1.Matrix{Params: v1beta1.Params{{Name: "env", Value: *v1beta1.NewStructuredValues("staging", "prod")}}},
+		},
+	}}
+	consumer := &ResolvedPipelineTask{PipelineTask: &v1beta1.PipelineTask{
+		Name:    "deploy",
+		TaskRef: &v1beta1.TaskRef{Name: "deploy"},
+		Params: v1beta1.Params{{
+			Name:  "image",
+			Value: *v1beta1.NewStructuredValues("$(tasks.build.results.image)"),
+		}, {
+			Name:  "tag",
+			Value: *v1beta1.NewStructuredValues("$(tasks.build.results.image):latest"),
+		}, {
+			Name:  "approvers",
+			Value: *v1beta1.NewStructuredValues("$(tasks.approve.results.approver[*])"),
+		}},
+	}}
+	refs, _, err := ResolveResultRef(state, consumer)
+	if err != nil {
+		t.Fatalf("ResolveResultRef: %v", err)
+	}
+
+	imageDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(`"registry/image"`)))
+	approverDigest := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(`["alice","bob"]`)))
+	want := []v1beta1.ResultProvenance{{
+		PipelineTask: "deploy",
+		Result:       "tasks.approve.results.approver",
+		Run:          "approve-0",
+		Attempt:      0,
+		Digest:       approverDigest,
+	}, {
+		PipelineTask: "deploy",
+		Result:       "tasks.approve.results.approver",
+		Run:          "approve-1",
+		Attempt:      2,
+		Digest:       approverDigest,
+	}, {
+		PipelineTask: "deploy",
+		Result:       "tasks.build.results.image",
+		Run:          "build-run",
+		Attempt:      1,
+		Digest:       imageDigest,
+	}}
+	if d := cmp.Diff(want, refs.Provenance(state, consumer.PipelineTask)); d != "" {
+		t.Errorf("Provenance %s", diff.PrintWantGot(d))
+	}
+
+	ApplyTaskResults(PipelineRunState{consumer}, refs)
+	if got := refs.Provenance(state, consumer.PipelineTask); len(got) != 0 {
+		t.Errorf("expected no provenance once the results are applied, got %v", got)
+	}
+}
+
+func TestCheckResultsVerified(t *testing.T) {
+	resultsVerified := func(status corev1.ConditionStatus, message string) apis.Condition {
+		return apis.Condition{
+			Type:    apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()),
+			Status:  status,
+			Message: message,
+		}
+	}
+	taskRunState := func(name string, conditions ...apis.Condition) *ResolvedPipelineTask {
+		return &ResolvedPipelineTask{
+			TaskRunNames: []string{name + "-run"},
+			TaskRuns: []*v1beta1.TaskRun{{
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-run"},
+				Status: v1beta1.TaskRunStatus{
+					Status: duckv1.Status{Conditions: append(duckv1.Conditions{successCondition}, conditions...)},
+					TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+						TaskRunResults: []v1beta1.TaskRunResult{{Name: "image", Value: *v1beta1.NewStructuredValues("registry/image")}},
+					},
+				},
+			}},
+			PipelineTask: &v1beta1.PipelineTask{Name: name, TaskRef: &v1beta1.TaskRef{Name: name}},
+		}
+	}
+	state := PipelineRunState{
+		taskRunState("verified", resultsVerified(corev1.ConditionTrue, "")),
+		taskRunState("failed", resultsVerified(corev1.ConditionFalse, "no SVID found")),
+		taskRunState("unsigned"),
+	}
+	consumer := func(pipelineTask string) *ResolvedPipelineTask {
+		return &ResolvedPipelineTask{PipelineTask: &v1beta1.PipelineTask{
+			Name:    "deploy",
+			TaskRef: &v1beta1.TaskRef{Name: "deploy"},
+			Params: v1beta1.Params{{
+				Name:  "image",
+				Value: *v1beta1.NewStructuredValues(fmt.Sprintf("$(tasks.%s.results.image)", pipelineTask)),
+			}},
+		}}
+	}
+
+	for _, tc := range []struct {
+		pipelineTask string
+		wantErr      string
+	}{{
+		pipelineTask: "verified",
+	}, {
+		pipelineTask: "failed",
+		wantErr:      `results of TaskRun failed-run of task "failed" are not verified by SPIRE: no SVID found`,
+	}, {
+		pipelineTask: "unsigned",
+		wantErr:      `results of TaskRun unsigned-run of task "unsigned" are not verified by SPIRE: not verified yet`,
+	}} {
+		t.Run(tc.pipelineTask, func(t *testing.T) {
+			refs, _, err := ResolveResultRef(state, consumer(tc.pipelineTask))
+			if err != nil {
+				t.Fatalf("ResolveResultRef: %v", err)
+			}
+			err = CheckResultsVerified(state, refs)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("CheckResultsVerified() = %v", err)
+			case tc.wantErr != "" && (err == nil || err.Error() != tc.wantErr):
+				t.Errorf("expected the error %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -65,10 +65,10 @@ func validateResultRef(ref *v1beta1.ResultRef, ptMap map[string]*ResolvedPipelin
 		return fmt.Errorf("referenced pipeline task %q does not exist", ref.PipelineTask)
 	}
 	taskProvidesResult := false
-	if ptMap[ref.PipelineTask].CustomTask {
-		// We're not able to validate results pointing to custom tasks because
-		// there's no facility to check what the result names will be before the
-		// custom task executes.
+	if ptMap[ref.PipelineTask].CustomTask || ptMap[ref.PipelineTask].IsChildPipeline() {
+		// We're not able to validate results pointing to custom tasks or child
+		// pipelines because there's no facility to check what the result names
+		// will be before the custom task or the child PipelineRun executes.
 		return nil
 	}
 	if ptMap[ref.PipelineTask].ResolvedTask == nil || ptMap[ref.PipelineTask].ResolvedTask.TaskSpec == nil {
@@ -99,6 +99,9 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1beta1.PipelineWorkspaceDe
 	}
 
 	for _, rpt := range state {
+		if rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+			continue
+		}
 		for _, pws := range rpt.PipelineTask.Workspaces {
 			if optionalWorkspaces.Has(pws.Workspace) {
 				for _, tws := range rpt.ResolvedTask.TaskSpec.Workspaces {
@@ -113,3 +116,37 @@ func ValidateOptionalWorkspaces(pipelineWorkspaces []v1beta1.PipelineWorkspaceDe
 	}
 	return nil
 }
+
+// ValidateWorkspaceModes validates that the tasks using ReadOnly pipeline workspaces declare them readOnly
+func ValidateWorkspaceModes(pipelineWorkspaces []v1beta1.PipelineWorkspaceDeclaration, state PipelineRunState) error {
+	readOnlyWorkspaces := sets.NewString()
+	for _, ws := range pipelineWorkspaces {
+		if ws.Mode == v1beta1.WorkspaceModeReadOnly {
+			readOnlyWorkspaces.Insert(ws.Name)
+		}
+	}
+	if readOnlyWorkspaces.Len() == 0 {
+		return nil
+	}
+
+	for _, rpt := range state {
+		if rpt.ResolvedTask == nil || rpt.ResolvedTask.TaskSpec == nil {
+			continue
+		}
+		for _, pws := range rpt.PipelineTask.Workspaces {
+			pipelineWorkspace := pws.Workspace
+			if pipelineWorkspace == "" {
+				pipelineWorkspace = pws.Name
+			}
+			if !readOnlyWorkspaces.Has(pipelineWorkspace) {
+				continue
+			}
+			for _, tws := range rpt.ResolvedTask.TaskSpec.Workspaces {
+				if tws.Name == pws.Name && !tws.ReadOnly {
+					return fmt.Errorf("pipeline workspace %q is ReadOnly but pipeline task %q does not declare workspace %q readOnly", pipelineWorkspace, rpt.PipelineTask.Name, pws.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
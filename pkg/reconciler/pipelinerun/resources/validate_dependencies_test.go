@@ -460,3 +460,49 @@ func TestValidateOptionalWorkspaces_NonOptionalTaskWorkspace(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+// TestValidateWorkspaceModes tests that an error is generated if a ReadOnly pipeline
+// workspace is bound to a task workspace that is not declared readOnly.
+func TestValidateWorkspaceModes(t *testing.T) {
+	workspaces := []v1beta1.PipelineWorkspaceDeclaration{{
+		Name: "ws1",
+		Mode: v1beta1.WorkspaceModeReadOnly,
+	}, {
+		Name: "ws2",
+		Mode: v1beta1.WorkspaceModeReadWrite,
+	}}
+	state := func(readOnly bool) prresources.PipelineRunState {
+		return prresources.PipelineRunState{{
+			PipelineTask: &v1beta1.PipelineTask{
+				Name: "pt1",
+				Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+					Name:      "foo",
+					Workspace: "ws1",
+				}, {
+					Name:      "bar",
+					Workspace: "ws2",
+				}},
+			},
+			ResolvedTask: &resources.ResolvedTask{
+				TaskSpec: &v1beta1.TaskSpec{
+					Workspaces: []v1beta1.WorkspaceDeclaration{{
+						Name:     "foo",
+						ReadOnly: readOnly,
+					}, {
+						Name: "bar",
+					}},
+				},
+			},
+		}, {
+			PipelineTask: &v1beta1.PipelineTask{Name: "custom-task"},
+		}}
+	}
+
+	if err := prresources.ValidateWorkspaceModes(workspaces, state(true)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := prresources.ValidateWorkspaceModes(workspaces, state(false))
+	if err == nil || !strings.Contains(err.Error(), `pipeline workspace "ws1" is ReadOnly but pipeline task "pt1" does not declare workspace "foo" readOnly`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -29,7 +29,7 @@ func ValidateParamTypesMatching(p *v1beta1.PipelineSpec, pr *v1beta1.PipelineRun
 	// Build a map of parameter names/types declared in p.
 	paramTypes := make(map[string]v1beta1.ParamType)
 	for _, param := range p.Params {
-		paramTypes[param.Name] = param.Type
+		paramTypes[param.Name] = param.Type.ValueType()
 	}
 
 	// Build a list of parameter names from pr that have mismatching types with the map created above.
@@ -90,6 +90,11 @@ func ValidateObjectParamRequiredKeys(pipelineParameters []v1beta1.ParamSpec, pip
 // Matrix.Params must be of type array. Matrix.Include.Params must be of type string.
 func ValidateParameterTypesInMatrix(state PipelineRunState) error {
 	for _, rpt := range state {
+		if rpt.PipelineTask.IsLooped() {
+			// The items of looping tasks are validated by ValidateLoopItems when they are scheduled,
+			// since they may be substituted from array results only then.
+			continue
+		}
 		m := rpt.PipelineTask.Matrix
 		if m.HasInclude() {
 			for _, include := range m.Include {
@@ -110,3 +115,14 @@ func ValidateParameterTypesInMatrix(state PipelineRunState) error {
 	}
 	return nil
 }
+
+// ValidateLoopItems validates that the items of a looping PipelineTask, held by the param of its
+// matrix, are an array after any replacements are made from Task parameters or results.
+func ValidateLoopItems(rpt *ResolvedPipelineTask) error {
+	for _, param := range rpt.PipelineTask.Matrix.GetAllParams() {
+		if param.Value.Type != v1beta1.ParamTypeArray {
+			return fmt.Errorf("the items of the looping task %s must be an array, but %s is of type %s", rpt.PipelineTask.Name, rpt.PipelineTask.WithParam, string(param.Value.Type))
+		}
+	}
+	return nil
+}
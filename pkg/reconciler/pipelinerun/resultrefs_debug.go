@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ResultRefsDebugPath is the path the handler of ResultRefReports is served on by the controller.
+const ResultRefsDebugPath = "/debug/pipelineruns/resultrefs"
+
+// ResultRefReports holds the result reference reports of the running PipelineRuns, as of their
+// last reconcile by the Reconciler of this controller replica.
+type ResultRefReports struct {
+	mu      sync.RWMutex
+	reports map[types.NamespacedName][]resources.ResultRefReport
+}
+
+// NewResultRefReports returns an empty ResultRefReports.
+func NewResultRefReports() *ResultRefReports {
+	return &ResultRefReports{reports: map[types.NamespacedName][]resources.ResultRefReport{}}
+}
+
+type resultRefReportsKey struct{}
+
+// WithResultRefReports returns a context in which the Reconciler records the result reference
+// reports of the running PipelineRuns in reports. The reports are not recorded otherwise.
+func WithResultRefReports(ctx context.Context, reports *ResultRefReports) context.Context {
+	return context.WithValue(ctx, resultRefReportsKey{}, reports)
+}
+
+// resultRefReportsFromContext returns the ResultRefReports of the context, or nil if the reports
+// are not recorded.
+func resultRefReportsFromContext(ctx context.Context) *ResultRefReports {
+	reports, _ := ctx.Value(resultRefReportsKey{}).(*ResultRefReports)
+	return reports
+}
+
+func (s *ResultRefReports) set(key types.NamespacedName, reports []resources.ResultRefReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[key] = reports
+}
+
+func (s *ResultRefReports) get(key types.NamespacedName) ([]resources.ResultRefReport, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	reports, ok := s.reports[key]
+	return reports, ok
+}
+
+func (s *ResultRefReports) delete(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reports, key)
+}
+
+// deletePipelineRun removes the reports of a deleted PipelineRun.
+func (s *ResultRefReports) deletePipelineRun(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if pr, ok := obj.(*v1beta1.PipelineRun); ok {
+		s.delete(types.NamespacedName{Namespace: pr.Namespace, Name: pr.Name})
+	}
+}
+
+// Handler returns an http.Handler serving, as JSON, the result reference reports of the running
+// PipelineRun named by the "namespace" and "name" query parameters, as of its last reconcile.
+// Reports are only available from the controller replica reconciling the PipelineRun. They don't
+// include the values of the results, but the handler must still only be served to cluster admins.
+func (s *ResultRefReports) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := types.NamespacedName{Namespace: r.URL.Query().Get("namespace"), Name: r.URL.Query().Get("name")}
+		if key.Namespace == "" || key.Name == "" {
+			http.Error(w, "the namespace and name query parameters are required", http.StatusBadRequest)
+			return
+		}
+		reports, ok := s.get(key)
+		if !ok {
+			http.Error(w, "no result reference reports for running PipelineRun "+key.String(), http.StatusNotFound)
+			return
+		}
+		if reports == nil {
+			reports = []resources.ResultRefReport{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(reports); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
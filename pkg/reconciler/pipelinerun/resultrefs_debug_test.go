@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestResultRefsDebugHandler(t *testing.T) {
+	key := types.NamespacedName{Namespace: "foo", Name: "pr"}
+	reports := []resources.ResultRefReport{{
+		PipelineTask:    "consumer",
+		ResultReference: v1beta1.ResultRef{PipelineTask: "producer", Result: "result"},
+		State:           resources.ResultRefPending,
+		Message:         `task "producer" referenced by result was not finished`,
+	}}
+	resultRefReports := NewResultRefReports()
+	resultRefReports.set(key, reports)
+	resultRefReports.set(types.NamespacedName{Namespace: "foo", Name: "no-refs"}, nil)
+
+	for _, tc := range []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       []resources.ResultRefReport
+	}{{
+		name:       "running pipelinerun",
+		query:      "?namespace=foo&name=pr",
+		wantStatus: http.StatusOK,
+		want:       reports,
+	}, {
+		name:       "running pipelinerun without result references",
+		query:      "?namespace=foo&name=no-refs",
+		wantStatus: http.StatusOK,
+		want:       []resources.ResultRefReport{},
+	}, {
+		name:       "unknown pipelinerun",
+		query:      "?namespace=foo&name=unknown",
+		wantStatus: http.StatusNotFound,
+	}, {
+		name:       "missing name",
+		query:      "?namespace=foo",
+		wantStatus: http.StatusBadRequest,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			resultRefReports.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ResultRefsDebugPath+tc.query, nil))
+			if rec.Code != tc.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tc.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tc.want == nil {
+				return
+			}
+			var got []resources.ResultRefReport
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("error decoding the response: %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("unexpected reports %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	resultRefReports.deletePipelineRun(cache.DeletedFinalStateUnknown{Obj: &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "no-refs"}}})
+	if _, ok := resultRefReports.get(types.NamespacedName{Namespace: "foo", Name: "no-refs"}); ok {
+		t.Errorf("expected the reports of the deleted PipelineRun to be removed")
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/runnamespace"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/logging"
+)
+
+// deleteRunNamespace returns a handler deleting the run namespace of a deleted PipelineRun, so that
+// the run namespaces of PipelineRuns deleted before they are done are cleaned up too.
+func deleteRunNamespace(ctx context.Context, handler runnamespace.Handler) func(obj interface{}) {
+	logger := logging.FromContext(ctx)
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		pr, ok := obj.(*v1beta1.PipelineRun)
+		if !ok || !runnamespace.IsRequested(pr) {
+			return
+		}
+		if err := handler.Delete(ctx, pr); err != nil {
+			logger.Errorf("Failed to delete run namespace of deleted PipelineRun %s/%s: %v", pr.Namespace, pr.Name, err)
+		}
+	}
+}
@@ -103,7 +103,7 @@ func timeoutPipelineTasks(ctx context.Context, logger *zap.SugaredLogger, pr *v1
 func timeoutPipelineTasksForTaskNames(ctx context.Context, logger *zap.SugaredLogger, pr *v1beta1.PipelineRun, clientSet clientset.Interface, taskNames sets.String) []string {
 	errs := []string{}
 
-	trNames, customRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
+	trNames, customRunNames, pipelineRunNames, err := getChildObjectsFromPRStatusForTaskNames(ctx, pr.Status, taskNames)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -125,5 +125,14 @@ func timeoutPipelineTasksForTaskNames(ctx context.Context, logger *zap.SugaredLo
 			continue
 		}
 	}
+
+	for _, pipelineRunName := range pipelineRunNames {
+		logger.Infof("cancelling PipelineRun %s for timeout", pipelineRunName)
+
+		if err := cancelChildPipelineRun(ctx, pipelineRunName, pr.Namespace, clientSet); err != nil {
+			errs = append(errs, fmt.Errorf("Failed to patch PipelineRun `%s` with cancellation: %w", pipelineRunName, err).Error())
+			continue
+		}
+	}
 	return errs
 }
@@ -22,10 +22,12 @@ import (
 	"fmt"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
 
@@ -36,10 +38,16 @@ const (
 	SpanContextAnnotation = "tekton.dev/pipelinerunSpanContext"
 	// TaskRunSpanContextAnnotation is the name of the Annotation used for propogating SpanContext to TaskRun
 	TaskRunSpanContextAnnotation = "tekton.dev/taskrunSpanContext"
+	// TraceParentAnnotation is the name of the Annotation holding the W3C traceparent of an
+	// upstream span, e.g. of the trigger or of the CI job which created the PipelineRun
+	TraceParentAnnotation = "tekton.dev/traceparent"
+	// TraceStateAnnotation is the name of the Annotation holding the W3C tracestate of the
+	// upstream span in the TraceParentAnnotation
+	TraceStateAnnotation = "tekton.dev/tracestate"
 )
 
-// initialize tracing by creating the root span and injecting the
-// spanContext is propogated through annotations in the CR
+// initialize tracing by allocating the span context of the run and storing it in its
+// status, as a child of the spanContext propogated through annotations in the CR if any
 func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, pr *v1beta1.PipelineRun) context.Context {
 	logger := logging.FromContext(ctx)
 	pro := otel.GetTextMapPropagator()
@@ -57,38 +65,43 @@ func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, pr *v
 		if err != nil {
 			logger.Error("unable to unmarshal spancontext, err: %s", err)
 		}
-
-		pr.Status.SpanContext = spanContext
-		return pro.Extract(ctx, propagation.MapCarrier(pr.Status.SpanContext))
+		ctx = pro.Extract(ctx, propagation.MapCarrier(spanContext))
 	}
 
-	// Create a new root span since there was no parent spanContext provided through annotations
-	ctxWithTrace, span := tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:Reconciler")
-	defer span.End()
-	span.SetAttributes(attribute.String("taskrun", pr.Name), attribute.String("namespace", pr.Namespace))
+	// SpanContext was propogated as a W3C traceparent, e.g. by the trigger of the PipelineRun
+	if traceParent := pr.Annotations[TraceParentAnnotation]; len(spanContext) == 0 && traceParent != "" {
+		spanContext = tracing.CarrierFromTraceParent(traceParent, pr.Annotations[TraceStateAnnotation])
+		if spanContext == nil {
+			logger.Errorf("ignoring invalid traceparent %q", traceParent)
+		}
+		ctx = pro.Extract(ctx, propagation.MapCarrier(spanContext))
+	}
 
-	pro.Inject(ctxWithTrace, propagation.MapCarrier(spanContext))
+	if !tracing.Enabled(tracerProvider) {
+		logger.Debug("tracerProvider doesn't provide a traceId, tracing is disabled")
+		if len(spanContext) > 0 {
+			pr.Status.SpanContext = spanContext
+		}
+		return ctx
+	}
 
+	// Allocate the span context of the PipelineRun, child of the propagated one if any.
+	// Its span is recorded once the PipelineRun completes, see recordSpans.
+	ctx = tracing.NewRunContext(ctx)
+	spanContext = make(map[string]string)
+	pro.Inject(ctx, propagation.MapCarrier(spanContext))
 	logger.Debug("got tracing carrier", spanContext)
 	if len(spanContext) == 0 {
-		logger.Debug("tracerProvider doesn't provide a traceId, tracing is disabled")
 		return ctx
 	}
-
-	span.AddEvent("updating PipelineRun status with SpanContext")
 	pr.Status.SpanContext = spanContext
-	return ctxWithTrace
+	return ctx
 }
 
-// Extract spanContext from the context and return it as json encoded string
-func getMarshalledSpanFromContext(ctx context.Context) (string, error) {
-	carrier := make(map[string]string)
-	pro := otel.GetTextMapPropagator()
-
-	pro.Inject(ctx, propagation.MapCarrier(carrier))
-
+// Return the spanContext carrier as json encoded string
+func getMarshalledSpanContext(carrier map[string]string) (string, error) {
 	if len(carrier) == 0 {
-		return "", fmt.Errorf("spanContext not present in the context, unable to marshall")
+		return "", fmt.Errorf("spanContext not present, unable to marshall")
 	}
 
 	marshalled, err := json.Marshal(carrier)
@@ -100,3 +113,43 @@ func getMarshalledSpanFromContext(ctx context.Context) (string, error) {
 	}
 	return string(marshalled), nil
 }
+
+// recordSpans records the span of the PipelineRun once it completes, with the span
+// context allocated by initTracing, as a child of the one propagated through annotations if any.
+func recordSpans(tracerProvider trace.TracerProvider, pr *v1beta1.PipelineRun, beforeCondition *apis.Condition) {
+	afterCondition := pr.Status.GetCondition(apis.ConditionSucceeded)
+	if afterCondition == nil || afterCondition.IsUnknown() || (beforeCondition != nil && !beforeCondition.IsUnknown()) {
+		return
+	}
+	span := tracing.Span{
+		Name:        "PipelineRun:" + pr.Name,
+		SpanContext: pr.Status.SpanContext,
+		Parent:      parentCarrier(pr),
+		End:         afterCondition.LastTransitionTime.Inner.Time,
+		Attributes:  []attribute.KeyValue{attribute.String("pipelinerun", pr.Name), attribute.String("namespace", pr.Namespace)},
+	}
+	if pr.Status.CompletionTime != nil {
+		span.End = pr.Status.CompletionTime.Time
+	}
+	span.Start = span.End
+	if pr.Status.StartTime != nil {
+		span.Start = pr.Status.StartTime.Time
+	}
+	if afterCondition.IsFalse() {
+		span.Error = afterCondition.Message
+	}
+	tracing.RecordRunSpan(tracerProvider, span)
+}
+
+// parentCarrier returns the carrier of the span context propagated to the PipelineRun
+// through its annotations, if any. The SpanContextAnnotation takes precedence over
+// the TraceParentAnnotation.
+func parentCarrier(pr *v1beta1.PipelineRun) map[string]string {
+	if carrier := tracing.CarrierFromAnnotation(pr.Annotations[SpanContextAnnotation]); len(carrier) > 0 {
+		return carrier
+	}
+	if traceParent := pr.Annotations[TraceParentAnnotation]; traceParent != "" {
+		return tracing.CarrierFromTraceParent(traceParent, pr.Annotations[TraceStateAnnotation])
+	}
+	return nil
+}
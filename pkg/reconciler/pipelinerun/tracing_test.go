@@ -16,13 +16,21 @@ package pipelinerun
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func TestInitTracing(t *testing.T) {
@@ -35,6 +43,7 @@ func TestInitTracing(t *testing.T) {
 		expectSpanContextStatus bool
 		expectValidSpanContext  bool
 		parentTraceID           string
+		expectSpanContext       map[string]string
 	}{{
 		name: "with-tracerprovider-no-parent-trace",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -61,6 +70,53 @@ func TestInitTracing(t *testing.T) {
 		expectSpanContextStatus: true,
 		expectValidSpanContext:  true,
 		parentTraceID:           "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01",
+	}, {
+		name: "with-tracerprovider-with-traceparent",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "testns",
+				Annotations: map[string]string{
+					"tekton.dev/traceparent": "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01",
+				},
+			},
+		},
+		tracerProvider:          tracesdk.NewTracerProvider(),
+		expectSpanContextStatus: true,
+		expectValidSpanContext:  true,
+		parentTraceID:           "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01",
+	}, {
+		name: "without-tracerprovider-with-traceparent",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "testns",
+				Annotations: map[string]string{
+					"tekton.dev/traceparent": "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01",
+					"tekton.dev/tracestate":  "vendor=value",
+				},
+			},
+		},
+		tracerProvider:          trace.NewNoopTracerProvider(),
+		expectSpanContextStatus: true,
+		expectSpanContext: map[string]string{
+			"traceparent": "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01",
+			"tracestate":  "vendor=value",
+		},
+	}, {
+		name: "without-tracerprovider-with-invalid-traceparent",
+		pipelineRun: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "testns",
+				Annotations: map[string]string{
+					"tekton.dev/traceparent": "invalid",
+				},
+			},
+		},
+		tracerProvider:          trace.NewNoopTracerProvider(),
+		expectSpanContextStatus: false,
+		expectValidSpanContext:  false,
 	}, {
 		name: "without-tracerprovider",
 		pipelineRun: &v1beta1.PipelineRun{
@@ -101,6 +157,16 @@ func TestInitTracing(t *testing.T) {
 				t.Fatalf("spanContext is empty after initializing tracing")
 			}
 
+			if !tc.expectSpanContextStatus && len(pr.Status.SpanContext) > 0 {
+				t.Errorf("expected no spanContext, got %v", pr.Status.SpanContext)
+			}
+
+			if tc.expectSpanContext != nil {
+				if d := cmp.Diff(tc.expectSpanContext, pr.Status.SpanContext); d != "" {
+					t.Errorf("spanContext %s", diff.PrintWantGot(d))
+				}
+			}
+
 			if tc.expectValidSpanContext {
 				if len(pr.Status.SpanContext) == 0 {
 					t.Fatalf("spanContext not added to annotations")
@@ -111,10 +177,73 @@ func TestInitTracing(t *testing.T) {
 					t.Errorf("invalid trace Id")
 				}
 
-				if tc.parentTraceID != "" && parentID != tc.parentTraceID {
+				// The span of the run is a child of the propagated one: same trace, new span
+				if tc.parentTraceID != "" && (parentID[:36] != tc.parentTraceID[:36] || parentID == tc.parentTraceID) {
 					t.Errorf("invalid trace Id propagated, %s", parentID)
 				}
 			}
 		})
 	}
 }
+
+type spanExporter struct {
+	spans []tracesdk.ReadOnlySpan
+}
+
+func (e *spanExporter) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *spanExporter) Shutdown(context.Context) error { return nil }
+
+func TestRecordSpans(t *testing.T) {
+	e := &spanExporter{}
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(e), tracesdk.WithIDGenerator(tracing.NewIDGenerator()))
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "testns",
+		},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type:   apis.ConditionSucceeded,
+				Status: corev1.ConditionTrue,
+			}}},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: start.Add(time.Hour)},
+				SpanContext:    map[string]string{"traceparent": "00-0f57e147e992b304d977436289d10628-1a2b3c4d5e6f7081-01"},
+			},
+		},
+	}
+
+	// The span is only recorded once the PipelineRun completes
+	recordSpans(tp, pr, pr.Status.GetCondition(apis.ConditionSucceeded))
+	if len(e.spans) != 0 {
+		t.Fatalf("expected no span to be recorded for a PipelineRun which completed already, got %d", len(e.spans))
+	}
+
+	recordSpans(tp, pr, nil)
+	if len(e.spans) != 1 {
+		t.Fatalf("expected the span of the PipelineRun to be recorded, got %d", len(e.spans))
+	}
+	s := e.spans[0]
+	if s.Name() != "PipelineRun:test" || s.SpanContext().SpanID().String() != "1a2b3c4d5e6f7081" || s.Parent().IsValid() {
+		t.Errorf("expected the root span of the PipelineRun, got %q %v with parent %v", s.Name(), s.SpanContext(), s.Parent())
+	}
+	if !s.StartTime().Equal(start) || !s.EndTime().Equal(start.Add(time.Hour)) || s.Status().Code != codes.Ok {
+		t.Errorf("unexpected span from %v to %v with status %v", s.StartTime(), s.EndTime(), s.Status())
+	}
+
+	// The span is a child of the span propagated as a W3C traceparent
+	pr.Annotations = map[string]string{"tekton.dev/traceparent": "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01"}
+	recordSpans(tp, pr, nil)
+	if len(e.spans) != 2 {
+		t.Fatalf("expected the span of the PipelineRun to be recorded, got %d", len(e.spans))
+	}
+	if parent := e.spans[1].Parent(); parent.SpanID().String() != "73d5909e31793992" || !parent.IsRemote() {
+		t.Errorf("expected the span of the PipelineRun to be a child of the propagated span, got parent %v", parent)
+	}
+}
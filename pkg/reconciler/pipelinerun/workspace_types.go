@@ -0,0 +1,133 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	corev1 "k8s.io/api/core/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/kmeta"
+)
+
+// ArtifactWorkspacesSnapshottedAnnotation is the status annotation recording that the artifact workspaces of a
+// done PipelineRun were snapshotted, so that they are not snapshotted again when it is reconciled later on.
+const ArtifactWorkspacesSnapshottedAnnotation = "tekton.dev/artifact-workspaces-snapshotted"
+
+// typedWorkspaceBindings returns the workspace bindings of the PipelineRun with the lifecycle of the typed workspaces
+// of the Pipeline applied: unbound ephemeral workspaces are bound to an emptyDir, and cache workspaces bound to a
+// volumeClaimTemplate are bound to the PersistentVolumeClaim of their cache key instead.
+func typedWorkspaceBindings(ps *v1beta1.PipelineSpec, wbs []v1beta1.WorkspaceBinding) []v1beta1.WorkspaceBinding {
+	if ps == nil {
+		return wbs
+	}
+	declarations := make(map[string]v1beta1.PipelineWorkspaceDeclaration, len(ps.Workspaces))
+	for _, ws := range ps.Workspaces {
+		declarations[ws.Name] = ws
+	}
+	bound := sets.NewString()
+	typed := make([]v1beta1.WorkspaceBinding, 0, len(wbs))
+	for _, wb := range wbs {
+		if ws := declarations[wb.Name]; ws.Type == v1beta1.WorkspaceTypeCache && wb.VolumeClaimTemplate != nil {
+			wb = v1beta1.WorkspaceBinding{
+				Name:    wb.Name,
+				SubPath: wb.SubPath,
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volumeclaim.GetCachePersistentVolumeClaimName(wb.VolumeClaimTemplate, ws.CacheKey),
+				},
+			}
+		}
+		typed = append(typed, wb)
+		bound.Insert(wb.Name)
+	}
+	for _, ws := range ps.Workspaces {
+		if ws.Type == v1beta1.WorkspaceTypeEphemeral && !bound.Has(ws.Name) {
+			typed = append(typed, v1beta1.WorkspaceBinding{Name: ws.Name, EmptyDir: &corev1.EmptyDirVolumeSource{}})
+		}
+	}
+	return typed
+}
+
+// cacheWorkspaceKeys returns the cache keys of the cache workspaces of the Pipeline, by workspace name.
+func cacheWorkspaceKeys(ps *v1beta1.PipelineSpec) map[string]string {
+	cacheKeys := map[string]string{}
+	for _, ws := range ps.Workspaces {
+		if ws.Type == v1beta1.WorkspaceTypeCache {
+			cacheKeys[ws.Name] = ws.CacheKey
+		}
+	}
+	return cacheKeys
+}
+
+// snapshotArtifactWorkspaces snapshots the PersistentVolumeClaims of the artifact workspaces of the PipelineRun into
+// PersistentVolumeClaims named <pipelinerun-name>-<workspace-name>-artifact, which are retained after the PipelineRun.
+// Once all the snapshots are taken, if any, the ArtifactWorkspacesSnapshottedAnnotation is recorded in the status and later
+// calls return early.
+func (c *Reconciler) snapshotArtifactWorkspaces(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if pr.Status.PipelineSpec == nil || pr.Status.Annotations[ArtifactWorkspacesSnapshottedAnnotation] == "true" {
+		return nil
+	}
+	pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(pr), pr.Namespace)
+	if err != nil {
+		return err
+	}
+	bindings := make(map[string]v1beta1.WorkspaceBinding, len(pr.Spec.Workspaces))
+	for _, wb := range pr.Spec.Workspaces {
+		bindings[wb.Name] = wb
+	}
+	var errs []error
+	snapshots := 0
+	for _, ws := range pr.Status.PipelineSpec.Workspaces {
+		if ws.Type != v1beta1.WorkspaceTypeArtifact {
+			continue
+		}
+		wb, ok := bindings[ws.Name]
+		if !ok {
+			continue
+		}
+		claimName := getClaimName(wb, *kmeta.NewControllerRef(pr))
+		if claimName == "" {
+			continue
+		}
+		if pooledName, ok := pooledClaimNames[claimName]; ok {
+			claimName = pooledName
+		}
+		snapshotName := kmeta.ChildName(pr.Name, fmt.Sprintf("-%s-artifact", ws.Name))
+		labels := map[string]string{
+			pipeline.PipelineRunLabelKey:          pr.Name,
+			volumeclaim.WorkspaceArtifactLabelKey: ws.Name,
+		}
+		if err := c.pvcHandler.SnapshotPersistentVolumeClaim(ctx, claimName, snapshotName, labels, pr.Namespace); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		snapshots++
+	}
+	if len(errs) > 0 || snapshots == 0 {
+		return errorutils.NewAggregate(errs)
+	}
+	if pr.Status.Annotations == nil {
+		pr.Status.Annotations = map[string]string{}
+	}
+	pr.Status.Annotations[ArtifactWorkspacesSnapshottedAnnotation] = "true"
+	return nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/kmeta"
+)
+
+func TestTypedWorkspaceBindings(t *testing.T) {
+	template := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}}
+	for _, tc := range []struct {
+		name string
+		ps   *v1beta1.PipelineSpec
+		wbs  []v1beta1.WorkspaceBinding
+		want []v1beta1.WorkspaceBinding
+	}{{
+		name: "no pipeline spec",
+		wbs:  []v1beta1.WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		want: []v1beta1.WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}, {
+		name: "untyped workspaces",
+		ps: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "source"}},
+		},
+		wbs:  []v1beta1.WorkspaceBinding{{Name: "source", VolumeClaimTemplate: template}},
+		want: []v1beta1.WorkspaceBinding{{Name: "source", VolumeClaimTemplate: template}},
+	}, {
+		name: "unbound ephemeral workspace",
+		ps: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "scratch",
+				Type: v1beta1.WorkspaceTypeEphemeral,
+			}},
+		},
+		want: []v1beta1.WorkspaceBinding{{Name: "scratch", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}, {
+		name: "bound ephemeral workspace",
+		ps: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name: "scratch",
+				Type: v1beta1.WorkspaceTypeEphemeral,
+			}},
+		},
+		wbs:  []v1beta1.WorkspaceBinding{{Name: "scratch", VolumeClaimTemplate: template}},
+		want: []v1beta1.WorkspaceBinding{{Name: "scratch", VolumeClaimTemplate: template}},
+	}, {
+		name: "cache workspaces",
+		ps: &v1beta1.PipelineSpec{
+			Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+				Name:     "cache",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "go-1.19",
+			}, {
+				Name:     "own-cache",
+				Type:     v1beta1.WorkspaceTypeCache,
+				CacheKey: "go-1.19",
+			}},
+		},
+		wbs: []v1beta1.WorkspaceBinding{{
+			Name:                "cache",
+			SubPath:             "go",
+			VolumeClaimTemplate: template,
+		}, {
+			Name:                  "own-cache",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myown"},
+		}},
+		want: []v1beta1.WorkspaceBinding{{
+			Name:                  "cache",
+			SubPath:               "go",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-cache-1eb3392c76"},
+		}, {
+			Name:                  "own-cache",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myown"},
+		}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := typedWorkspaceBindings(tc.ps, tc.wbs)
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCacheWorkspaceKeys(t *testing.T) {
+	ps := &v1beta1.PipelineSpec{
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+			Name: "source",
+		}, {
+			Name:     "cache",
+			Type:     v1beta1.WorkspaceTypeCache,
+			CacheKey: "go-1.19",
+		}},
+	}
+	want := map[string]string{"cache": "go-1.19"}
+	if d := cmp.Diff(want, cacheWorkspaceKeys(ps)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestSnapshotArtifactWorkspaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", UID: "uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:                "output",
+				VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}},
+			}, {
+				Name:                  "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "source"},
+			}},
+		},
+		Status: v1beta1.PipelineRunStatus{
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineSpec: &v1beta1.PipelineSpec{
+					Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{
+						Name: "output",
+						Type: v1beta1.WorkspaceTypeArtifact,
+					}, {
+						Name: "source",
+					}},
+				},
+			},
+		},
+	}
+	claimName := getClaimName(pr.Spec.Workspaces[0], *kmeta.NewControllerRef(pr))
+	kubeClientSet := fakek8s.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: claimName, Namespace: "ns"},
+	}, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "ns"},
+	})
+	c := Reconciler{
+		KubeClientSet: kubeClientSet,
+		pvcHandler:    newTestPVCHandler(kubeClientSet),
+	}
+
+	if err := c.snapshotArtifactWorkspaces(ctx, pr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pvcs, err := kubeClientSet.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{
+		LabelSelector: volumeclaim.WorkspaceArtifactLabelKey,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pvcs.Items) != 1 {
+		t.Fatalf("expected 1 artifact snapshot but got %d", len(pvcs.Items))
+	}
+	snapshot := pvcs.Items[0]
+	if d := cmp.Diff("pr-output-artifact", snapshot.Name); d != "" {
+		t.Errorf("unexpected snapshot name %s", diff.PrintWantGot(d))
+	}
+	wantLabels := map[string]string{
+		pipeline.PipelineRunLabelKey:          "pr",
+		volumeclaim.WorkspaceArtifactLabelKey: "output",
+	}
+	if d := cmp.Diff(wantLabels, snapshot.Labels); d != "" {
+		t.Errorf("unexpected snapshot labels %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(claimName, snapshot.Spec.DataSource.Name); d != "" {
+		t.Errorf("unexpected snapshot source %s", diff.PrintWantGot(d))
+	}
+	if got := pr.Status.Annotations[ArtifactWorkspacesSnapshottedAnnotation]; got != "true" {
+		t.Errorf("expected the snapshot to be recorded in the status, got %q", got)
+	}
+
+	// A later reconcile of the done PipelineRun does not snapshot the workspaces again.
+	if err := kubeClientSet.CoreV1().PersistentVolumeClaims("ns").Delete(ctx, snapshot.Name, metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	kubeClientSet.ClearActions()
+	if err := c.snapshotArtifactWorkspaces(ctx, pr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actions := kubeClientSet.Actions(); len(actions) != 0 {
+		t.Errorf("expected no API calls once the snapshot is recorded, got %v", actions)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// archive archives the PipelineRun, with its TaskRuns, before it is deleted, as
+// set in "run-archive": in a PipelineRunArchive, or in the http(s) object store
+// with a PUT request at <store>/<namespace>/<name>-<uid>.json.
+func (r *PipelineRunPruner) archive(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	store := config.FromContextOrDefaults(ctx).FeatureFlags.RunArchive
+	if store == "" {
+		return nil
+	}
+	pr, err := r.rehydrate(ctx, pr)
+	if err != nil {
+		return err
+	}
+	a, err := r.pipelineRunArchive(pr)
+	if err != nil {
+		return err
+	}
+	if store == config.RunArchiveCRD {
+		_, err := r.PipelineClientSet.TektonV1alpha1().PipelineRunArchives(pr.Namespace).Create(ctx, a, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/%s/%s.json", store, a.Namespace, a.Name)
+	put, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	put.Header.Set("Content-Type", "application/json")
+	resp, err := r.HTTPClient.Do(put)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("uploading to %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// pipelineRunArchive returns the PipelineRunArchive of the PipelineRun and of the
+// TaskRuns labeled with its name, named after the name and UID of the PipelineRun
+// so that a PipelineRun replacing another one with the same name is archived apart.
+func (r *PipelineRunPruner) pipelineRunArchive(pr *v1beta1.PipelineRun) (*v1alpha1.PipelineRunArchive, error) {
+	taskRuns, err := r.taskRunLister.TaskRuns(pr.Namespace).List(labels.SelectorFromSet(labels.Set{pipeline.PipelineRunLabelKey: pr.Name}))
+	if err != nil {
+		return nil, err
+	}
+
+	a := &v1alpha1.PipelineRunArchive{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       "PipelineRunArchive",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", pr.Name, pr.UID),
+			Namespace: pr.Namespace,
+			Labels:    map[string]string{pipeline.PipelineRunLabelKey: pr.Name},
+		},
+		Spec: v1alpha1.PipelineRunArchiveSpec{
+			ArchiveTime: metav1.NewTime(r.Clock.Now().Truncate(time.Second)),
+		},
+	}
+	if p := pr.Labels[pipeline.PipelineLabelKey]; p != "" {
+		a.Labels[pipeline.PipelineLabelKey] = p
+	}
+	if a.Spec.PipelineRun, err = archived(pr.DeepCopy(), "PipelineRun"); err != nil {
+		return nil, err
+	}
+	for _, tr := range taskRuns {
+		if !metav1.IsControlledBy(tr, pr) {
+			continue
+		}
+		raw, err := archived(tr.DeepCopy(), "TaskRun")
+		if err != nil {
+			return nil, err
+		}
+		a.Spec.TaskRuns = append(a.Spec.TaskRuns, raw)
+	}
+	return a, nil
+}
+
+// archived returns the JSON of the v1beta1 run, with its kind and without its
+// managed fields, which are of no use once it is deleted.
+func archived(run interface {
+	metav1.Object
+	runtime.Object
+}, kind string) (runtime.RawExtension, error) {
+	run.SetManagedFields(nil)
+	run.GetObjectKind().SetGroupVersionKind(v1beta1.SchemeGroupVersion.WithKind(kind))
+	raw, err := json.Marshal(run)
+	return runtime.RawExtension{Raw: raw}, err
+}
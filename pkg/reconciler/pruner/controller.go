@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
+	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
+	"github.com/tektoncd/pipeline/pkg/sharding"
+	"github.com/tektoncd/pipeline/pkg/statusoffload"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// NewPipelineRunController returns a func that returns a knative controller for
+// pruning the finished PipelineRuns.
+func NewPipelineRunController(clock clock.PassiveClock) func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		logger := logging.FromContext(ctx)
+		informer := pipelineruninformer.Get(ctx)
+		configStore := config.NewStore(logger.Named("config-store"))
+		configStore.WatchConfigs(cmw)
+		r := &PipelineRunPruner{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			HTTPClient:        http.DefaultClient,
+			Clock:             clock,
+			pipelineRunLister: informer.Lister(),
+			taskRunLister:     taskruninformer.Get(ctx).Lister(),
+			configStore:       configStore,
+			statusOffloader:   statusoffload.NewOffloader(kubeclient.Get(ctx), http.DefaultClient),
+		}
+		impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+			WorkQueueName: "PipelineRunPruner",
+			Logger:        logger,
+		})
+		r.LeaderAwareFuncs = leaderAwareFuncs(informer.Informer())
+		sharding.Apply(ctx, impl, informer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, informer.Informer(), "pipelinerun-pruner")
+
+		informer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				pr, ok := obj.(*v1beta1.PipelineRun)
+				return ok && pr.IsDone()
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    impl.Enqueue,
+				UpdateFunc: controller.PassNew(impl.Enqueue),
+			},
+		})
+
+		return impl
+	}
+}
+
+// NewTaskRunController returns a func that returns a knative controller for
+// pruning the finished TaskRuns.
+func NewTaskRunController(clock clock.PassiveClock) func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		informer := taskruninformer.Get(ctx)
+		r := &TaskRunPruner{
+			PipelineClientSet: pipelineclient.Get(ctx),
+			Clock:             clock,
+			taskRunLister:     informer.Lister(),
+		}
+		impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+			WorkQueueName: "TaskRunPruner",
+			Logger:        logging.FromContext(ctx),
+		})
+		r.LeaderAwareFuncs = leaderAwareFuncs(informer.Informer())
+		sharding.Apply(ctx, impl, informer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, informer.Informer(), "taskrun-pruner")
+
+		informer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				tr, ok := obj.(*v1beta1.TaskRun)
+				return ok && tr.IsDone() && tr.Spec.TTLSecondsAfterFinished != nil
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    impl.Enqueue,
+				UpdateFunc: controller.PassNew(impl.Enqueue),
+			},
+		})
+
+		return impl
+	}
+}
+
+// leaderAwareFuncs enqueues the runs of the buckets the pruner is promoted to
+// the leader of.
+func leaderAwareFuncs(informer cache.SharedInformer) reconciler.LeaderAwareFuncs {
+	return reconciler.LeaderAwareFuncs{
+		PromoteFunc: func(bkt reconciler.Bucket, enq func(reconciler.Bucket, types.NamespacedName)) error {
+			for _, obj := range informer.GetStore().List() {
+				if o, err := kmeta.DeletionHandlingAccessor(obj); err == nil {
+					enq(bkt, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()})
+				}
+			}
+			return nil
+		},
+	}
+}
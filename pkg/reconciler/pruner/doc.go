@@ -0,0 +1,24 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package pruner provides the reconcilers deleting the finished PipelineRuns and
+TaskRuns which are no longer retained:
+  - The runs whose `ttlSecondsAfterFinished` elapsed since they finished.
+  - The oldest successful and failed PipelineRuns of a Pipeline beyond the
+    `successfulRunsHistoryLimit` and `failedRunsHistoryLimit` of the Pipeline.
+*/
+package pruner
@@ -0,0 +1,266 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/statusoffload"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+// PipelineRunPruner deletes the finished PipelineRuns which are no longer
+// retained, with their TaskRuns.
+type PipelineRunPruner struct {
+	// Implements reconciler.LeaderAware
+	reconciler.LeaderAwareFuncs
+
+	PipelineClientSet clientset.Interface
+	HTTPClient        *http.Client
+	Clock             clock.PassiveClock
+
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	configStore       *config.Store
+	statusOffloader   *statusoffload.Offloader
+}
+
+var _ controller.Reconciler = (*PipelineRunPruner)(nil)
+
+// Reconcile deletes the PipelineRuns of the Pipeline of a finished PipelineRun
+// beyond the history limits of the Pipeline, then deletes the PipelineRun when
+// its TTL elapsed or requeues it until it does.
+func (r *PipelineRunPruner) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+	if !r.IsLeaderFor(types.NamespacedName{Namespace: namespace, Name: name}) {
+		return controller.NewSkipKey(key)
+	}
+	if r.configStore != nil {
+		ctx = r.configStore.ToContext(ctx)
+	}
+	pr, err := r.pipelineRunLister.PipelineRuns(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !finished(pr.Status.GetCondition(apis.ConditionSucceeded), pr.Status.CompletionTime) || pr.DeletionTimestamp != nil {
+		return nil
+	}
+	if pr, err = r.rehydrate(ctx, pr); err != nil {
+		return err
+	}
+
+	deleted, err := r.pruneHistory(ctx, pr)
+	if err != nil || deleted {
+		return err
+	}
+	remaining := timeToLive(pr.Spec.TTLSecondsAfterFinished, pr.Status.CompletionTime, r.Clock.Now())
+	if remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	if remaining == 0 {
+		logging.FromContext(ctx).Infof("Deleting the PipelineRun %s, finished at %s, after its TTL", key, pr.Status.CompletionTime)
+		return r.delete(ctx, pr)
+	}
+	return nil
+}
+
+// pruneHistory deletes the oldest finished PipelineRuns of the Pipeline of the
+// PipelineRun beyond the history limits of the Pipeline. The PipelineRuns of a
+// Pipeline are the ones labeled with its name, and its history limits are the
+// ones of the spec of the Pipeline the PipelineRun ran. It returns true when it
+// deletes the PipelineRun itself.
+func (r *PipelineRunPruner) pruneHistory(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	spec := pr.Status.PipelineSpec
+	pipelineName := pr.Labels[pipeline.PipelineLabelKey]
+	if spec == nil || pipelineName == "" || (spec.SuccessfulRunsHistoryLimit == nil && spec.FailedRunsHistoryLimit == nil) {
+		return false, nil
+	}
+	runs, err := r.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.SelectorFromSet(labels.Set{pipeline.PipelineLabelKey: pipelineName}))
+	if err != nil {
+		return false, err
+	}
+
+	var succeeded, failed []*v1beta1.PipelineRun
+	for _, run := range runs {
+		c := run.Status.GetCondition(apis.ConditionSucceeded)
+		switch {
+		case !finished(c, run.Status.CompletionTime) || run.DeletionTimestamp != nil:
+		case isChildPipelineRun(run):
+			// The child PipelineRuns of PipelineTasks are deleted with their parent.
+		case c.IsTrue():
+			succeeded = append(succeeded, run)
+		default:
+			failed = append(failed, run)
+		}
+	}
+
+	deleted := false
+	for _, run := range append(beyondLimit(succeeded, spec.SuccessfulRunsHistoryLimit), beyondLimit(failed, spec.FailedRunsHistoryLimit)...) {
+		logging.FromContext(ctx).Infof("Deleting the PipelineRun %s/%s beyond the history limits of the Pipeline %s", run.Namespace, run.Name, pipelineName)
+		if err := r.delete(ctx, run); err != nil {
+			return deleted, err
+		}
+		deleted = deleted || run.UID == pr.UID
+	}
+	return deleted, nil
+}
+
+// delete archives the PipelineRun, then deletes it unless it was replaced by
+// another one with the same name. It is not deleted when it can't be archived.
+func (r *PipelineRunPruner) delete(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if err := r.archive(ctx, pr); err != nil {
+		return fmt.Errorf("failed to archive the PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	err := r.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, deleteOptions(pr.UID))
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// rehydrate returns a copy of the PipelineRun with the fields of its status offloaded
+// to ConfigMaps or to an object store restored, or the PipelineRun when there are none.
+func (r *PipelineRunPruner) rehydrate(ctx context.Context, pr *v1beta1.PipelineRun) (*v1beta1.PipelineRun, error) {
+	if pr.Status.OffloadedStatus == nil {
+		return pr, nil
+	}
+	pr = pr.DeepCopy()
+	return pr, r.statusOffloader.Rehydrate(ctx, pr)
+}
+
+// isChildPipelineRun returns whether the PipelineRun was created by another
+// PipelineRun for one of its PipelineTasks.
+func isChildPipelineRun(pr *v1beta1.PipelineRun) bool {
+	owner := metav1.GetControllerOf(pr)
+	return owner != nil && owner.Kind == pipeline.PipelineRunControllerName
+}
+
+// beyondLimit returns the PipelineRuns beyond the limit, the ones which finished
+// last being kept.
+func beyondLimit(runs []*v1beta1.PipelineRun, limit *int32) []*v1beta1.PipelineRun {
+	if limit == nil || len(runs) <= int(*limit) {
+		return nil
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		ti, tj := runs[i].Status.CompletionTime.Time, runs[j].Status.CompletionTime.Time
+		if ti.Equal(tj) {
+			return runs[i].Name > runs[j].Name
+		}
+		return ti.After(tj)
+	})
+	return runs[*limit:]
+}
+
+// TaskRunPruner deletes the finished TaskRuns whose TTL elapsed.
+type TaskRunPruner struct {
+	// Implements reconciler.LeaderAware
+	reconciler.LeaderAwareFuncs
+
+	PipelineClientSet clientset.Interface
+	Clock             clock.PassiveClock
+
+	taskRunLister listers.TaskRunLister
+}
+
+var _ controller.Reconciler = (*TaskRunPruner)(nil)
+
+// Reconcile deletes a finished TaskRun when its TTL elapsed, or requeues it
+// until it does.
+func (r *TaskRunPruner) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return controller.NewPermanentError(err)
+	}
+	if !r.IsLeaderFor(types.NamespacedName{Namespace: namespace, Name: name}) {
+		return controller.NewSkipKey(key)
+	}
+	tr, err := r.taskRunLister.TaskRuns(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !finished(tr.Status.GetCondition(apis.ConditionSucceeded), tr.Status.CompletionTime) || tr.DeletionTimestamp != nil {
+		return nil
+	}
+
+	remaining := timeToLive(tr.Spec.TTLSecondsAfterFinished, tr.Status.CompletionTime, r.Clock.Now())
+	if remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	if remaining < 0 {
+		return nil
+	}
+	logging.FromContext(ctx).Infof("Deleting the TaskRun %s, finished at %s, after its TTL", key, tr.Status.CompletionTime)
+	err = r.PipelineClientSet.TektonV1beta1().TaskRuns(namespace).Delete(ctx, name, deleteOptions(tr.UID))
+	if apierrors.IsNotFound(err) || apierrors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
+
+// finished returns whether a run with the given Succeeded condition and
+// completion time finished.
+func finished(c *apis.Condition, completionTime *metav1.Time) bool {
+	return c != nil && !c.IsUnknown() && completionTime != nil
+}
+
+// timeToLive returns the time left before the TTL of a run which finished at
+// the completion time elapses, 0 when it elapsed and -1 when the run has no TTL.
+func timeToLive(ttl *int32, completionTime *metav1.Time, now time.Time) time.Duration {
+	if ttl == nil {
+		return -1
+	}
+	remaining := completionTime.Add(time.Duration(*ttl) * time.Second).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// deleteOptions deletes a run in the background, with its children, unless it
+// was replaced by another one with the same name.
+func deleteOptions(uid types.UID) metav1.DeleteOptions {
+	propagation := metav1.DeletePropagationBackground
+	return metav1.DeleteOptions{
+		Preconditions:     &metav1.Preconditions{UID: &uid},
+		PropagationPolicy: &propagation,
+	}
+}
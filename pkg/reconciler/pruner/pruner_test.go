@@ -0,0 +1,326 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pruner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/reconciler"
+)
+
+var now = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func runStatus(status corev1.ConditionStatus) duckv1.Status {
+	return duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: status}}}
+}
+
+func pipelineRun(name string, status corev1.ConditionStatus, finishedAgo time.Duration, ttl *int32, spec *v1beta1.PipelineSpec) *v1beta1.PipelineRun {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "foo",
+			UID:       types.UID(name),
+			Labels:    map[string]string{pipeline.PipelineLabelKey: "build"},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:             &v1beta1.PipelineRef{Name: "build"},
+			TTLSecondsAfterFinished: ttl,
+		},
+	}
+	pr.Status.Status = runStatus(status)
+	pr.Status.PipelineSpec = spec
+	if status != corev1.ConditionUnknown {
+		pr.Status.CompletionTime = &metav1.Time{Time: now.Add(-finishedAgo)}
+	}
+	return pr
+}
+
+func childPipelineRun(pr *v1beta1.PipelineRun) *v1beta1.PipelineRun {
+	parent := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "parent", Namespace: pr.Namespace, UID: "parent"}}
+	pr.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(parent)}
+	return pr
+}
+
+func deletedNames(actions []ktesting.Action) []string {
+	var names []string
+	for _, a := range actions {
+		if d, ok := a.(ktesting.DeleteAction); ok {
+			names = append(names, d.GetName())
+		}
+	}
+	return names
+}
+
+func newPipelineRunPruner(t *testing.T, prs []*v1beta1.PipelineRun, trs ...*v1beta1.TaskRun) (*PipelineRunPruner, *fakepipelineclient.Clientset) {
+	t.Helper()
+	prIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	trIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	objs := make([]runtime.Object, 0, len(prs)+len(trs))
+	for _, pr := range prs {
+		if err := prIndexer.Add(pr); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, pr)
+	}
+	for _, tr := range trs {
+		if err := trIndexer.Add(tr); err != nil {
+			t.Fatal(err)
+		}
+		objs = append(objs, tr)
+	}
+	client := fakepipelineclient.NewSimpleClientset(objs...)
+	r := &PipelineRunPruner{
+		PipelineClientSet: client,
+		HTTPClient:        http.DefaultClient,
+		Clock:             testclock.NewFakePassiveClock(now),
+		pipelineRunLister: listers.NewPipelineRunLister(prIndexer),
+		taskRunLister:     listers.NewTaskRunLister(trIndexer),
+	}
+	if err := r.Promote(reconciler.UniversalBucket(), func(reconciler.Bucket, types.NamespacedName) {}); err != nil {
+		t.Fatal(err)
+	}
+	return r, client
+}
+
+func TestPipelineRunPruner(t *testing.T) {
+	limits := &v1beta1.PipelineSpec{SuccessfulRunsHistoryLimit: int32Ptr(1), FailedRunsHistoryLimit: int32Ptr(1)}
+	for _, tc := range []struct {
+		name        string
+		runs        []*v1beta1.PipelineRun
+		wantDeleted []string
+		wantRequeue time.Duration
+	}{{
+		name:        "TTL elapsed",
+		runs:        []*v1beta1.PipelineRun{pipelineRun("pr", corev1.ConditionTrue, time.Hour, int32Ptr(60), nil)},
+		wantDeleted: []string{"pr"},
+	}, {
+		name:        "TTL not elapsed",
+		runs:        []*v1beta1.PipelineRun{pipelineRun("pr", corev1.ConditionFalse, 20*time.Second, int32Ptr(60), nil)},
+		wantRequeue: 40 * time.Second,
+	}, {
+		name: "not finished",
+		runs: []*v1beta1.PipelineRun{pipelineRun("pr", corev1.ConditionUnknown, 0, int32Ptr(0), limits)},
+	}, {
+		name: "no TTL",
+		runs: []*v1beta1.PipelineRun{pipelineRun("pr", corev1.ConditionTrue, time.Hour, nil, nil)},
+	}, {
+		name: "history limits",
+		runs: []*v1beta1.PipelineRun{
+			pipelineRun("pr", corev1.ConditionTrue, time.Minute, nil, limits),
+			pipelineRun("succeeded-1", corev1.ConditionTrue, 2*time.Minute, nil, limits),
+			pipelineRun("succeeded-2", corev1.ConditionTrue, 3*time.Minute, nil, limits),
+			pipelineRun("failed-1", corev1.ConditionFalse, 2*time.Minute, nil, limits),
+			pipelineRun("failed-2", corev1.ConditionFalse, 3*time.Minute, nil, limits),
+			pipelineRun("running", corev1.ConditionUnknown, 0, nil, limits),
+		},
+		wantDeleted: []string{"succeeded-1", "succeeded-2", "failed-2"},
+	}, {
+		name: "history limits skip child PipelineRuns",
+		runs: []*v1beta1.PipelineRun{
+			pipelineRun("pr", corev1.ConditionTrue, time.Minute, nil, limits),
+			childPipelineRun(pipelineRun("child", corev1.ConditionTrue, 2*time.Minute, nil, limits)),
+		},
+	}, {
+		name: "beyond the history limits itself",
+		runs: []*v1beta1.PipelineRun{
+			pipelineRun("pr", corev1.ConditionFalse, time.Minute, int32Ptr(3600), &v1beta1.PipelineSpec{FailedRunsHistoryLimit: int32Ptr(0)}),
+		},
+		wantDeleted: []string{"pr"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, client := newPipelineRunPruner(t, tc.runs)
+			err := r.Reconcile(context.Background(), "foo/pr")
+			if tc.wantRequeue != 0 {
+				if ok, delay := controller.IsRequeueKey(err); !ok || delay != tc.wantRequeue {
+					t.Errorf("Reconcile() = %v, want a requeue after %s", err, tc.wantRequeue)
+				}
+			} else if err != nil {
+				t.Errorf("Reconcile() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantDeleted, deletedNames(client.Actions())); d != "" {
+				t.Errorf("deleted PipelineRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestPipelineRunPrunerArchive(t *testing.T) {
+	pr := pipelineRun("pr", corev1.ConditionTrue, time.Hour, int32Ptr(60), nil)
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "pr-build",
+			Namespace:       "foo",
+			Labels:          map[string]string{pipeline.PipelineRunLabelKey: "pr"},
+			OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			ManagedFields:   []metav1.ManagedFieldsEntry{{Manager: "controller"}},
+		},
+	}
+	other := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pr-other",
+			Namespace: "foo",
+			Labels:    map[string]string{pipeline.PipelineRunLabelKey: "pr"},
+		},
+	}
+	checkArchive := func(t *testing.T, a *v1alpha1.PipelineRunArchive) {
+		t.Helper()
+		if a.Name != "pr-pr" || a.Namespace != "foo" {
+			t.Errorf("archived as %s/%s, want foo/pr-pr", a.Namespace, a.Name)
+		}
+		var archivedPR v1beta1.PipelineRun
+		if err := json.Unmarshal(a.Spec.PipelineRun.Raw, &archivedPR); err != nil {
+			t.Fatal(err)
+		}
+		if archivedPR.Kind != "PipelineRun" || archivedPR.Name != "pr" || archivedPR.Status.CompletionTime == nil {
+			t.Errorf("archived PipelineRun %s", a.Spec.PipelineRun.Raw)
+		}
+		if len(a.Spec.TaskRuns) != 1 {
+			t.Fatalf("archived %d TaskRuns, want the one of the PipelineRun", len(a.Spec.TaskRuns))
+		}
+		var archivedTR v1beta1.TaskRun
+		if err := json.Unmarshal(a.Spec.TaskRuns[0].Raw, &archivedTR); err != nil {
+			t.Fatal(err)
+		}
+		if archivedTR.Kind != "TaskRun" || archivedTR.Name != "pr-build" || archivedTR.ManagedFields != nil {
+			t.Errorf("archived TaskRun %s", a.Spec.TaskRuns[0].Raw)
+		}
+	}
+
+	t.Run("crd", func(t *testing.T) {
+		r, client := newPipelineRunPruner(t, []*v1beta1.PipelineRun{pr}, tr, other)
+		ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{RunArchive: config.RunArchiveCRD}})
+		if err := r.Reconcile(ctx, "foo/pr"); err != nil {
+			t.Fatalf("Reconcile() = %v", err)
+		}
+		a, err := client.TektonV1alpha1().PipelineRunArchives("foo").Get(ctx, "pr-pr", metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkArchive(t, a)
+		if d := cmp.Diff([]string{"pr"}, deletedNames(client.Actions())); d != "" {
+			t.Errorf("deleted PipelineRuns %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("object store", func(t *testing.T) {
+		var archive v1alpha1.PipelineRunArchive
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			path = req.URL.Path
+			if err := json.NewDecoder(req.Body).Decode(&archive); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+			}
+		}))
+		defer server.Close()
+		r, client := newPipelineRunPruner(t, []*v1beta1.PipelineRun{pr}, tr, other)
+		ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{RunArchive: server.URL + "/tekton"}})
+		if err := r.Reconcile(ctx, "foo/pr"); err != nil {
+			t.Fatalf("Reconcile() = %v", err)
+		}
+		if path != "/tekton/foo/pr-pr.json" {
+			t.Errorf("archived at %s, want /tekton/foo/pr-pr.json", path)
+		}
+		checkArchive(t, &archive)
+		if d := cmp.Diff([]string{"pr"}, deletedNames(client.Actions())); d != "" {
+			t.Errorf("deleted PipelineRuns %s", diff.PrintWantGot(d))
+		}
+	})
+
+	t.Run("object store failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+		r, client := newPipelineRunPruner(t, []*v1beta1.PipelineRun{pr}, tr)
+		ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{RunArchive: server.URL}})
+		if err := r.Reconcile(ctx, "foo/pr"); err == nil {
+			t.Error("Reconcile() = nil, want an error archiving the PipelineRun")
+		}
+		if names := deletedNames(client.Actions()); len(names) != 0 {
+			t.Errorf("deleted PipelineRuns %v which could not be archived", names)
+		}
+	})
+}
+
+func TestTaskRunPruner(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		ttl         *int32
+		wantDeleted []string
+	}{{
+		name:        "TTL elapsed",
+		ttl:         int32Ptr(0),
+		wantDeleted: []string{"tr"},
+	}, {
+		name: "no TTL",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "foo", UID: "tr"},
+				Spec:       v1beta1.TaskRunSpec{TTLSecondsAfterFinished: tc.ttl},
+			}
+			tr.Status.Status = runStatus(corev1.ConditionTrue)
+			tr.Status.CompletionTime = &metav1.Time{Time: now.Add(-time.Minute)}
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := indexer.Add(tr); err != nil {
+				t.Fatal(err)
+			}
+			client := fakepipelineclient.NewSimpleClientset(tr)
+			r := &TaskRunPruner{
+				PipelineClientSet: client,
+				Clock:             testclock.NewFakePassiveClock(now),
+				taskRunLister:     listers.NewTaskRunLister(indexer),
+			}
+			if err := r.Promote(reconciler.UniversalBucket(), func(reconciler.Bucket, types.NamespacedName) {}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := r.Reconcile(context.Background(), "foo/tr"); err != nil {
+				t.Errorf("Reconcile() = %v", err)
+			}
+			if d := cmp.Diff(tc.wantDeleted, deletedNames(client.Actions())); d != "" {
+				t.Errorf("deleted TaskRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 
 	resolutionrequestinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
 	resolutionrequestreconciler "github.com/tektoncd/pipeline/pkg/client/resolution/injection/reconciler/resolution/v1beta1/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
+	"github.com/tektoncd/pipeline/pkg/sharding"
 	"k8s.io/utils/clock"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -36,6 +38,9 @@ func NewController(clock clock.PassiveClock) func(ctx context.Context, cmw confi
 		impl := resolutionrequestreconciler.NewImpl(ctx, r)
 
 		reqinformer := resolutionrequestinformer.Get(ctx)
+		sharding.Apply(ctx, impl, reqinformer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, reqinformer.Informer(), "resolutionrequest")
+
 		reqinformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
 		return impl
@@ -0,0 +1,182 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnamespace
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clientset "k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ReasonCouldntCreateRunNamespace indicates that a PipelineRun requests a run namespace
+	// but it couldn't be provisioned.
+	ReasonCouldntCreateRunNamespace = "CouldntCreateRunNamespace"
+
+	// AnnotationKey is the PipelineRun annotation requesting a run namespace when set to "true".
+	AnnotationKey = "pipeline.tekton.dev/run-namespace"
+	// OwnerAnnotationKey is the annotation set on a run namespace, holding the UID of the
+	// PipelineRun it is provisioned for.
+	OwnerAnnotationKey = "pipeline.tekton.dev/run-namespace-owner"
+
+	// objectName is the name of the RoleBinding, ResourceQuota and LimitRange created in run namespaces.
+	objectName = "tekton-run"
+)
+
+// Handler provisions and deletes the run namespaces of PipelineRuns
+type Handler interface {
+	Provision(ctx context.Context, pr *v1beta1.PipelineRun, cfg *config.RunNamespace) error
+	Delete(ctx context.Context, pr *v1beta1.PipelineRun) error
+}
+
+type defaultHandler struct {
+	clientset clientset.Interface
+	logger    *zap.SugaredLogger
+}
+
+// NewHandler returns a new defaultHandler
+func NewHandler(clientset clientset.Interface, logger *zap.SugaredLogger) Handler {
+	return &defaultHandler{clientset, logger}
+}
+
+// IsRequested returns whether the PipelineRun requests a run namespace.
+func IsRequested(pr *v1beta1.PipelineRun) bool {
+	return pr.Annotations[AnnotationKey] == "true"
+}
+
+// GetName returns the name of the run namespace of the PipelineRun, derived from its UID so
+// that it is unique in the cluster and valid as a namespace name.
+func GetName(pr *v1beta1.PipelineRun) string {
+	return fmt.Sprintf("tekton-run-%s", pr.UID)
+}
+
+// Provision creates the run namespace of the PipelineRun, binding its service accounts to the
+// configured ClusterRole and setting the configured ResourceQuota and LimitRange in it. Objects
+// which already exist are left as is, so Provision can be called on every reconcile.
+func (h *defaultHandler) Provision(ctx context.Context, pr *v1beta1.PipelineRun, cfg *config.RunNamespace) error {
+	if !cfg.Enabled {
+		return fmt.Errorf("run namespaces are not enabled in %s", config.GetRunNamespaceConfigName())
+	}
+	name := GetName(pr)
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{pipeline.PipelineRunLabelKey: pr.Name},
+			Annotations: map[string]string{OwnerAnnotationKey: string(pr.UID)},
+		},
+	}
+	_, err := h.clientset.CoreV1().Namespaces().Create(ctx, namespace, metav1.CreateOptions{})
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		existing, err := h.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to retrieve run namespace %s: %w", name, err)
+		}
+		if existing.Annotations[OwnerAnnotationKey] != string(pr.UID) {
+			return fmt.Errorf("namespace %s already exists and is not the run namespace of PipelineRun %s/%s", name, pr.Namespace, pr.Name)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to create run namespace %s: %w", name, err)
+	default:
+		h.logger.Infof("Created run namespace %s for PipelineRun %s/%s", name, pr.Namespace, pr.Name)
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: name},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     cfg.ClusterRole,
+		},
+		Subjects: serviceAccountSubjects(pr),
+	}
+	if _, err := h.clientset.RbacV1().RoleBindings(name).Create(ctx, roleBinding, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to bind ClusterRole %s in run namespace %s: %w", cfg.ClusterRole, name, err)
+	}
+	if cfg.ResourceQuota != nil {
+		resourceQuota := &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: name},
+			Spec:       *cfg.ResourceQuota,
+		}
+		if _, err := h.clientset.CoreV1().ResourceQuotas(name).Create(ctx, resourceQuota, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create ResourceQuota in run namespace %s: %w", name, err)
+		}
+	}
+	if cfg.LimitRange != nil {
+		limitRange := &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: name},
+			Spec:       *cfg.LimitRange,
+		}
+		if _, err := h.clientset.CoreV1().LimitRanges(name).Create(ctx, limitRange, metav1.CreateOptions{}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create LimitRange in run namespace %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Delete deletes the run namespace of the PipelineRun, with all the resources in it. Namespaces
+// which are not the run namespace of the PipelineRun are never deleted.
+func (h *defaultHandler) Delete(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	name := GetName(pr)
+	namespace, err := h.clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to retrieve run namespace %s: %w", name, err)
+	case namespace.Annotations[OwnerAnnotationKey] != string(pr.UID) || namespace.DeletionTimestamp != nil:
+		return nil
+	}
+	if err := h.clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete run namespace %s: %w", name, err)
+	}
+	h.logger.Infof("Deleted run namespace %s of PipelineRun %s/%s", name, pr.Namespace, pr.Name)
+	return nil
+}
+
+// serviceAccountSubjects returns the service accounts the TaskRuns of the PipelineRun run as.
+func serviceAccountSubjects(pr *v1beta1.PipelineRun) []rbacv1.Subject {
+	serviceAccountName := pr.Spec.ServiceAccountName
+	if serviceAccountName == "" {
+		serviceAccountName = config.DefaultServiceAccountValue
+	}
+	serviceAccountNames := sets.NewString(serviceAccountName)
+	for _, trs := range pr.Spec.TaskRunSpecs {
+		if trs.TaskServiceAccountName != "" {
+			serviceAccountNames.Insert(trs.TaskServiceAccountName)
+		}
+	}
+	subjects := make([]rbacv1.Subject, 0, serviceAccountNames.Len())
+	for _, name := range serviceAccountNames.List() {
+		subjects = append(subjects, rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: pr.Namespace,
+		})
+	}
+	return subjects
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runnamespace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func testPipelineRun() *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pr",
+			Namespace:   "ns",
+			UID:         "uid",
+			Annotations: map[string]string{AnnotationKey: "true"},
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "test-sa",
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName:       "deploy",
+				TaskServiceAccountName: "deployer",
+			}, {
+				PipelineTaskName: "test",
+			}},
+		},
+	}
+}
+
+func TestIsRequested(t *testing.T) {
+	pr := testPipelineRun()
+	if !IsRequested(pr) {
+		t.Errorf("expected run namespace to be requested by %v", pr.Annotations)
+	}
+	pr.Annotations[AnnotationKey] = "false"
+	if IsRequested(pr) {
+		t.Errorf("expected run namespace not to be requested by %v", pr.Annotations)
+	}
+}
+
+func TestGetName(t *testing.T) {
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", UID: "6f1c5b3e-8a2d-4e5f-9b7a-1c2d3e4f5a6b"}}
+	if d := cmp.Diff("tekton-run-6f1c5b3e-8a2d-4e5f-9b7a-1c2d3e4f5a6b", GetName(pr)); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+	if errs := validation.IsDNS1123Label(GetName(pr)); len(errs) != 0 {
+		t.Errorf("expected a valid namespace name but got %v", errs)
+	}
+
+	// PipelineRuns whose namespace and name concatenate to the same string get different run namespaces.
+	other := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "b-c", Namespace: "a", UID: "uid-1"}}
+	another := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "c", Namespace: "a-b", UID: "uid-2"}}
+	if GetName(other) == GetName(another) {
+		t.Errorf("expected different run namespaces but got %q for both", GetName(other))
+	}
+}
+
+func TestProvision(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pr := testPipelineRun()
+	cfg := &config.RunNamespace{
+		Enabled:     true,
+		ClusterRole: "integration-tests",
+		ResourceQuota: &corev1.ResourceQuotaSpec{
+			Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("10")},
+		},
+		LimitRange: &corev1.LimitRangeSpec{
+			Limits: []corev1.LimitRangeItem{{
+				Type:           corev1.LimitTypeContainer,
+				DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}},
+		},
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	handler := NewHandler(fakekubeclient, zap.NewExample().Sugar())
+
+	// provisioning twice, as done when a PipelineRun is reconciled again, must succeed
+	for i := 0; i < 2; i++ {
+		if err := handler.Provision(ctx, pr, cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	namespace, err := fakekubeclient.CoreV1().Namespaces().Get(ctx, "tekton-run-uid", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{pipeline.PipelineRunLabelKey: "pr"}, namespace.Labels); d != "" {
+		t.Errorf("unexpected namespace labels %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(map[string]string{OwnerAnnotationKey: "uid"}, namespace.Annotations); d != "" {
+		t.Errorf("unexpected namespace annotations %s", diff.PrintWantGot(d))
+	}
+
+	roleBinding, err := fakekubeclient.RbacV1().RoleBindings("tekton-run-uid").Get(ctx, objectName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantRoleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "integration-tests"}
+	if d := cmp.Diff(wantRoleRef, roleBinding.RoleRef); d != "" {
+		t.Errorf("unexpected RoleBinding role %s", diff.PrintWantGot(d))
+	}
+	wantSubjects := []rbacv1.Subject{{
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "deployer",
+		Namespace: "ns",
+	}, {
+		Kind:      rbacv1.ServiceAccountKind,
+		Name:      "test-sa",
+		Namespace: "ns",
+	}}
+	if d := cmp.Diff(wantSubjects, roleBinding.Subjects); d != "" {
+		t.Errorf("unexpected RoleBinding subjects %s", diff.PrintWantGot(d))
+	}
+
+	resourceQuota, err := fakekubeclient.CoreV1().ResourceQuotas("tekton-run-uid").Get(ctx, objectName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(*cfg.ResourceQuota, resourceQuota.Spec); d != "" {
+		t.Errorf("unexpected ResourceQuota spec %s", diff.PrintWantGot(d))
+	}
+	limitRange, err := fakekubeclient.CoreV1().LimitRanges("tekton-run-uid").Get(ctx, objectName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(*cfg.LimitRange, limitRange.Spec); d != "" {
+		t.Errorf("unexpected LimitRange spec %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestProvisionWithDefaultServiceAccount(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pr := &v1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", UID: "uid"}}
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	handler := NewHandler(fakekubeclient, zap.NewExample().Sugar())
+	if err := handler.Provision(ctx, pr, &config.RunNamespace{Enabled: true, ClusterRole: "edit"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roleBinding, err := fakekubeclient.RbacV1().RoleBindings("tekton-run-uid").Get(ctx, objectName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wantSubjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "default", Namespace: "ns"}}
+	if d := cmp.Diff(wantSubjects, roleBinding.Subjects); d != "" {
+		t.Errorf("unexpected RoleBinding subjects %s", diff.PrintWantGot(d))
+	}
+	resourceQuotas, err := fakekubeclient.CoreV1().ResourceQuotas("tekton-run-uid").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resourceQuotas.Items) != 0 {
+		t.Errorf("expected no ResourceQuota but got %v", resourceQuotas.Items)
+	}
+}
+
+func TestProvisionError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tc := range []struct {
+		name       string
+		cfg        *config.RunNamespace
+		namespaces []*corev1.Namespace
+	}{{
+		name: "run namespaces disabled",
+		cfg:  config.DefaultRunNamespace,
+	}, {
+		name: "namespace of another PipelineRun",
+		cfg:  &config.RunNamespace{Enabled: true, ClusterRole: "edit"},
+		namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "tekton-run-uid",
+				Annotations: map[string]string{OwnerAnnotationKey: "other-uid"},
+			},
+		}},
+	}, {
+		name:       "namespace not provisioned by Tekton",
+		cfg:        &config.RunNamespace{Enabled: true, ClusterRole: "edit"},
+		namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "tekton-run-uid"}}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, ns := range tc.namespaces {
+				if _, err := fakekubeclient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			handler := NewHandler(fakekubeclient, zap.NewExample().Sugar())
+			if err := handler.Provision(ctx, testPipelineRun(), tc.cfg); err == nil {
+				t.Fatal("expected error provisioning the run namespace")
+			}
+			roleBindings, err := fakekubeclient.RbacV1().RoleBindings("tekton-run-uid").List(ctx, metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(roleBindings.Items) != 0 {
+				t.Errorf("expected no RoleBinding but got %v", roleBindings.Items)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, tc := range []struct {
+		name        string
+		namespaces  []*corev1.Namespace
+		wantDeleted bool
+	}{{
+		name: "run namespace",
+		namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "tekton-run-uid",
+				Annotations: map[string]string{OwnerAnnotationKey: "uid"},
+			},
+		}},
+		wantDeleted: true,
+	}, {
+		name: "namespace of another PipelineRun",
+		namespaces: []*corev1.Namespace{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "tekton-run-uid",
+				Annotations: map[string]string{OwnerAnnotationKey: "other-uid"},
+			},
+		}},
+	}, {
+		name:       "namespace not provisioned by Tekton",
+		namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "tekton-run-uid"}}},
+	}, {
+		name:        "namespace not found",
+		wantDeleted: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, ns := range tc.namespaces {
+				if _, err := fakekubeclient.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			handler := NewHandler(fakekubeclient, zap.NewExample().Sugar())
+			if err := handler.Delete(ctx, testPipelineRun()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			_, err := fakekubeclient.CoreV1().Namespaces().Get(ctx, "tekton-run-uid", metav1.GetOptions{})
+			if d := cmp.Diff(tc.wantDeleted, apierrors.IsNotFound(err)); d != "" {
+				t.Errorf("unexpected namespace deletion %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
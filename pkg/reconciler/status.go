@@ -0,0 +1,229 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
+)
+
+// StatusFieldManager is the field manager of the statuses of the runs applied by the
+// controller.
+const StatusFieldManager = "tekton-pipelines-controller"
+
+// maxStatusApplyAttempts is the number of times the status of a run is applied when the
+// upgrades of its managed fields conflict with the updates of the other fields of the run.
+const maxStatusApplyAttempts = 5
+
+// statusApplyConfiguration is the configuration applied to the status subresource of
+// a run: its identity and its status only, so that the controller only owns the fields
+// of the status.
+type statusApplyConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        statusApplyMetadata `json:"metadata"`
+	Status          interface{}         `json:"status"`
+}
+
+// statusApplyMetadata identifies the run, its UID failing the apply when the run was
+// replaced by another one with the same name. It has no resourceVersion: the apply isn't
+// conditioned on the version of the run which was reconciled.
+type statusApplyMetadata struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	UID       types.UID `json:"uid,omitempty"`
+}
+
+// jsonPatchOperation is an operation of a JSON patch.
+type jsonPatchOperation struct {
+	Operation string      `json:"op"`
+	Path      string      `json:"path"`
+	Value     interface{} `json:"value"`
+}
+
+// patchFunc patches the run being applied, or its given subresource.
+type patchFunc func(patchType types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (metav1.Object, error)
+
+// latestFunc gets the latest version of the run being applied, and returns whether its
+// status is still the one the run was reconciled from.
+type latestFunc func() (metav1.Object, bool, error)
+
+// ApplyPipelineRunStatus applies the status of the PipelineRun with a server-side apply,
+// forcing the ownership of its fields. Unlike an update, it doesn't conflict with the
+// other controllers updating the PipelineRun, such as the ones annotating it, since the
+// apply has no resourceVersion precondition. Only the upgrade of the managed fields of the
+// PipelineRun, made before its first apply, can conflict: it is retried on the latest
+// PipelineRun as long as its status is still the original one, the one the PipelineRun
+// was reconciled from. Otherwise the conflict is returned, for the PipelineRun to be
+// reconciled again.
+func ApplyPipelineRunStatus(ctx context.Context, client clientset.Interface, pr *v1beta1.PipelineRun, original *v1beta1.PipelineRunStatus) error {
+	runs := client.TektonV1beta1().PipelineRuns(pr.Namespace)
+	return applyStatus("PipelineRun", pr, pr.Status,
+		func(patchType types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (metav1.Object, error) {
+			patched, err := runs.Patch(ctx, pr.Name, patchType, data, opts, subresources...)
+			if err != nil {
+				return nil, err
+			}
+			return patched, nil
+		},
+		func() (metav1.Object, bool, error) {
+			latest, err := runs.Get(ctx, pr.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, false, err
+			}
+			return latest, equality.Semantic.DeepEqual(&latest.Status, original), nil
+		})
+}
+
+// ApplyTaskRunStatus applies the status of the TaskRun with a server-side apply, forcing
+// the ownership of its fields, like ApplyPipelineRunStatus.
+func ApplyTaskRunStatus(ctx context.Context, client clientset.Interface, tr *v1beta1.TaskRun, original *v1beta1.TaskRunStatus) error {
+	runs := client.TektonV1beta1().TaskRuns(tr.Namespace)
+	return applyStatus("TaskRun", tr, tr.Status,
+		func(patchType types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (metav1.Object, error) {
+			patched, err := runs.Patch(ctx, tr.Name, patchType, data, opts, subresources...)
+			if err != nil {
+				return nil, err
+			}
+			return patched, nil
+		},
+		func() (metav1.Object, bool, error) {
+			latest, err := runs.Get(ctx, tr.Name, metav1.GetOptions{})
+			if err != nil {
+				return nil, false, err
+			}
+			return latest, equality.Semantic.DeepEqual(&latest.Status, original), nil
+		})
+}
+
+func applyStatus(kind string, run metav1.Object, status interface{}, patch patchFunc, latest latestFunc) error {
+	current := run
+	for attempt := 1; ; attempt++ {
+		err := applyStatusOnce(kind, current, status, patch)
+		if !k8serrors.IsConflict(err) || attempt == maxStatusApplyAttempts {
+			return err
+		}
+		obj, unchanged, getErr := latest()
+		if getErr != nil {
+			return getErr
+		}
+		if obj.GetUID() != run.GetUID() || !unchanged {
+			return err
+		}
+		current = obj
+	}
+}
+
+// applyStatusOnce applies the status of the run, upgrading the managed fields of the
+// given version of the run first if needed.
+func applyStatusOnce(kind string, current metav1.Object, status interface{}, patch patchFunc) error {
+	upgrade, err := upgradeManagedFieldsPatch(current)
+	if err != nil {
+		return err
+	}
+	if upgrade != nil {
+		if _, err := patch(types.JSONPatchType, upgrade, metav1.PatchOptions{}); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(statusApplyConfiguration{
+		TypeMeta: metav1.TypeMeta{APIVersion: v1beta1.SchemeGroupVersion.String(), Kind: kind},
+		Metadata: statusApplyMetadata{
+			Name:      current.GetName(),
+			Namespace: current.GetNamespace(),
+			UID:       current.GetUID(),
+		},
+		Status: status,
+	})
+	if err != nil {
+		return err
+	}
+	force := true
+	_, err = patch(types.ApplyPatchType, data, metav1.PatchOptions{FieldManager: StatusFieldManager, Force: &force}, "status")
+	return err
+}
+
+// upgradeManagedFieldsPatch returns a JSON patch moving the fields of the status owned by
+// the updates of the status subresource, which the controller made before it applied the
+// status, to the apply of the StatusFieldManager. Otherwise the fields of the status the
+// controller stops applying, e.g. a removed child reference, would be kept by their former
+// managers rather than removed. It returns nil if the run has no such managed fields. The
+// patch fails with a conflict if the run was updated since its given version.
+func upgradeManagedFieldsPatch(run metav1.Object) ([]byte, error) {
+	apiVersion := v1beta1.SchemeGroupVersion.String()
+	var managedFields []metav1.ManagedFieldsEntry
+	var applied *metav1.ManagedFieldsEntry
+	fields := &fieldpath.Set{}
+	upgrade := false
+	for _, entry := range run.GetManagedFields() {
+		if entry.Subresource != "status" || entry.APIVersion != apiVersion || entry.FieldsV1 == nil {
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		switch {
+		case entry.Operation == metav1.ManagedFieldsOperationUpdate:
+			upgrade = true
+		case entry.Operation == metav1.ManagedFieldsOperationApply && entry.Manager == StatusFieldManager:
+			entry := entry
+			applied = &entry
+		default:
+			managedFields = append(managedFields, entry)
+			continue
+		}
+		entryFields := &fieldpath.Set{}
+		if err := entryFields.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			return nil, err
+		}
+		fields = fields.Union(entryFields)
+	}
+	if !upgrade {
+		return nil, nil
+	}
+	raw, err := fields.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	if applied == nil {
+		applied = &metav1.ManagedFieldsEntry{
+			Manager:     StatusFieldManager,
+			Operation:   metav1.ManagedFieldsOperationApply,
+			APIVersion:  apiVersion,
+			FieldsType:  "FieldsV1",
+			Subresource: "status",
+		}
+	}
+	applied.FieldsV1 = &metav1.FieldsV1{Raw: raw}
+	managedFields = append(managedFields, *applied)
+	return json.Marshal([]jsonPatchOperation{{
+		// Setting the resourceVersion makes the patch conditional on it.
+		Operation: "replace",
+		Path:      "/metadata/resourceVersion",
+		Value:     run.GetResourceVersion(),
+	}, {
+		Operation: "replace",
+		Path:      "/metadata/managedFields",
+		Value:     managedFields,
+	}})
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	reconciler "github.com/tektoncd/pipeline/pkg/reconciler"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestApplyStatus(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:            "run",
+		Namespace:       "foo",
+		UID:             "0123",
+		ResourceVersion: "1",
+		Labels:          map[string]string{"app": "test"},
+		Annotations:     map[string]string{"chains.tekton.dev/signed": "true"},
+	}
+	status := duckv1.Status{Conditions: duckv1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: "Running",
+	}}}
+
+	for _, tc := range []struct {
+		name     string
+		resource string
+		apply    func(context.Context, *fake.Clientset) error
+		want     string
+	}{{
+		name:     "PipelineRun",
+		resource: "pipelineruns",
+		apply: func(ctx context.Context, c *fake.Clientset) error {
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: objectMeta,
+				Spec:       v1beta1.PipelineRunSpec{PipelineRef: &v1beta1.PipelineRef{Name: "pipeline"}},
+				Status:     v1beta1.PipelineRunStatus{Status: status},
+			}
+			return reconciler.ApplyPipelineRunStatus(ctx, c, pr, &v1beta1.PipelineRunStatus{})
+		},
+		want: `{"kind":"PipelineRun","apiVersion":"tekton.dev/v1beta1","metadata":{"name":"run","namespace":"foo","uid":"0123"},` +
+			`"status":{"conditions":[{"type":"Succeeded","status":"Unknown","lastTransitionTime":null,"reason":"Running"}]}}`,
+	}, {
+		name:     "TaskRun",
+		resource: "taskruns",
+		apply: func(ctx context.Context, c *fake.Clientset) error {
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: objectMeta,
+				Spec:       v1beta1.TaskRunSpec{TaskRef: &v1beta1.TaskRef{Name: "task"}},
+				Status:     v1beta1.TaskRunStatus{Status: status},
+			}
+			return reconciler.ApplyTaskRunStatus(ctx, c, tr, &v1beta1.TaskRunStatus{})
+		},
+		want: `{"kind":"TaskRun","apiVersion":"tekton.dev/v1beta1","metadata":{"name":"run","namespace":"foo","uid":"0123"},` +
+			`"status":{"conditions":[{"type":"Succeeded","status":"Unknown","lastTransitionTime":null,"reason":"Running"}],` +
+			`"podName":""}}`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewSimpleClientset()
+			var patch ktesting.PatchAction
+			c.PrependReactor("patch", tc.resource, func(action ktesting.Action) (bool, runtime.Object, error) {
+				patch = action.(ktesting.PatchAction)
+				return true, nil, nil
+			})
+
+			if err := tc.apply(context.Background(), c); err != nil {
+				t.Fatalf("unexpected error applying the status: %v", err)
+			}
+			if patch == nil {
+				t.Fatal("expected the status to be applied")
+			}
+			if patch.GetPatchType() != types.ApplyPatchType || patch.GetSubresource() != "status" {
+				t.Errorf("expected an apply of the status subresource but got a %s patch of %q", patch.GetPatchType(), patch.GetSubresource())
+			}
+			var got, want interface{}
+			if err := json.Unmarshal(patch.GetPatch(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal([]byte(tc.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("unexpected applied configuration %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyStatus_Conflict(t *testing.T) {
+	original := v1beta1.PipelineRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+		Type:   apis.ConditionSucceeded,
+		Status: corev1.ConditionUnknown,
+		Reason: "Started",
+	}}}}
+	statusUpdate := metav1.ManagedFieldsEntry{
+		Manager:     "controller",
+		Operation:   metav1.ManagedFieldsOperationUpdate,
+		APIVersion:  "tekton.dev/v1beta1",
+		FieldsType:  "FieldsV1",
+		FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{}}}`)},
+		Subresource: "status",
+	}
+
+	for _, tc := range []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		latestStatus  v1beta1.PipelineRunStatus
+		wantPatches   []string
+		wantConflict  bool
+	}{{
+		name:         "no managed fields to upgrade",
+		latestStatus: original,
+		wantPatches:  []string{"apply"},
+	}, {
+		name:          "only the metadata changed",
+		managedFields: []metav1.ManagedFieldsEntry{statusUpdate},
+		latestStatus:  original,
+		wantPatches:   []string{"upgrade 1", "upgrade 2", "apply"},
+	}, {
+		name:          "the status changed",
+		managedFields: []metav1.ManagedFieldsEntry{statusUpdate},
+		latestStatus: v1beta1.PipelineRunStatus{Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+			Reason: "Succeeded",
+		}}}},
+		wantPatches:  []string{"upgrade 1"},
+		wantConflict: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			reconciled := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "run", Namespace: "foo", UID: "0123", ResourceVersion: "1", ManagedFields: tc.managedFields},
+				Status:     *original.DeepCopy(),
+			}
+			reconciled.Status.Conditions[0].Reason = "Running"
+			latest := reconciled.DeepCopy()
+			latest.ResourceVersion = "2"
+			latest.Annotations = map[string]string{"chains.tekton.dev/signed": "true"}
+			latest.Status = tc.latestStatus
+			c := fake.NewSimpleClientset(latest)
+			var patches []string
+			c.PrependReactor("patch", "pipelineruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+				patch := action.(ktesting.PatchAction)
+				if patch.GetPatchType() == types.ApplyPatchType {
+					var applied struct {
+						Metadata metav1.ObjectMeta `json:"metadata"`
+					}
+					if err := json.Unmarshal(patch.GetPatch(), &applied); err != nil {
+						t.Fatal(err)
+					}
+					if applied.Metadata.ResourceVersion != "" {
+						t.Errorf("expected the status to be applied without a resourceVersion but got %q", applied.Metadata.ResourceVersion)
+					}
+					patches = append(patches, "apply")
+					return true, latest, nil
+				}
+				var operations []struct {
+					Path  string      `json:"path"`
+					Value interface{} `json:"value"`
+				}
+				if err := json.Unmarshal(patch.GetPatch(), &operations); err != nil {
+					t.Fatal(err)
+				}
+				resourceVersion := operations[0].Value.(string)
+				patches = append(patches, "upgrade "+resourceVersion)
+				if resourceVersion != "2" {
+					return true, nil, k8serrors.NewConflict(action.GetResource().GroupResource(), "run", errors.New("resourceVersion mismatch"))
+				}
+				return true, latest, nil
+			})
+
+			err := reconciler.ApplyPipelineRunStatus(context.Background(), c, reconciled, &original)
+			if tc.wantConflict != k8serrors.IsConflict(err) || (!tc.wantConflict && err != nil) {
+				t.Errorf("expected a conflict: %t, but got %v", tc.wantConflict, err)
+			}
+			if d := cmp.Diff(tc.wantPatches, patches); d != "" {
+				t.Errorf("unexpected patches of the PipelineRun %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestApplyStatus_UpgradeManagedFields(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "run",
+			Namespace:       "foo",
+			UID:             "0123",
+			ResourceVersion: "1",
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "kubectl",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				APIVersion: "tekton.dev/v1beta1",
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:taskRef":{}}}`)},
+			}, {
+				Manager:     "controller",
+				Operation:   metav1.ManagedFieldsOperationUpdate,
+				APIVersion:  "tekton.dev/v1beta1",
+				FieldsType:  "FieldsV1",
+				FieldsV1:    &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:podName":{},"f:steps":{}}}`)},
+				Subresource: "status",
+			}},
+		},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{PodName: "run-pod"}},
+	}
+	c := fake.NewSimpleClientset()
+	var patches []ktesting.PatchAction
+	c.PrependReactor("patch", "taskruns", func(action ktesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, action.(ktesting.PatchAction))
+		upgraded := tr.DeepCopy()
+		upgraded.ResourceVersion = "2"
+		return true, upgraded, nil
+	})
+
+	if err := reconciler.ApplyTaskRunStatus(context.Background(), c, tr, &v1beta1.TaskRunStatus{}); err != nil {
+		t.Fatalf("unexpected error applying the status: %v", err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("expected the managed fields to be upgraded before the status is applied but got %d patches", len(patches))
+	}
+	if patches[0].GetPatchType() != types.JSONPatchType || patches[0].GetSubresource() != "" {
+		t.Errorf("expected a JSON patch of the TaskRun but got a %s patch of %q", patches[0].GetPatchType(), patches[0].GetSubresource())
+	}
+	want := `[{"op":"replace","path":"/metadata/resourceVersion","value":"1"},` +
+		`{"op":"replace","path":"/metadata/managedFields","value":[` +
+		`{"manager":"kubectl","operation":"Update","apiVersion":"tekton.dev/v1beta1","fieldsType":"FieldsV1","fieldsV1":{"f:spec":{"f:taskRef":{}}}},` +
+		`{"manager":"tekton-pipelines-controller","operation":"Apply","apiVersion":"tekton.dev/v1beta1","fieldsType":"FieldsV1","fieldsV1":{"f:status":{"f:podName":{},"f:steps":{}}},"subresource":"status"}]}]`
+	if d := cmp.Diff(want, string(patches[0].GetPatch())); d != "" {
+		t.Errorf("unexpected upgrade of the managed fields %s", diff.PrintWantGot(d))
+	}
+	var applied struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	if err := json.Unmarshal(patches[1].GetPatch(), &applied); err != nil {
+		t.Fatal(err)
+	}
+	if patches[1].GetPatchType() != types.ApplyPatchType || applied.Metadata.ResourceVersion != "" {
+		t.Errorf("expected the status to be applied without a resourceVersion but got a %s patch of resourceVersion %q", patches[1].GetPatchType(), applied.Metadata.ResourceVersion)
+	}
+}
@@ -23,22 +23,32 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	cloudeventsinkinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/cloudeventsink"
+	notificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/notificationpolicy"
+	serviceaccountpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/serviceaccountpolicy"
 	verificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy"
+	workspacepoolinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/workspacepool"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	resolutionclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
 	resolutioninformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/notification"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/sharding"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	"go.opentelemetry.io/otel/trace"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/clock"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	configmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
+	persistentvolumeclaiminformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
@@ -66,30 +76,44 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock, tracerProvi
 		}
 
 		c := &Reconciler{
-			KubeClientSet:            kubeclientset,
-			PipelineClientSet:        pipelineclientset,
-			Images:                   opts.Images,
-			Clock:                    clock,
-			spireClient:              spireClient,
-			taskRunLister:            taskRunInformer.Lister(),
-			limitrangeLister:         limitrangeInformer.Lister(),
-			verificationPolicyLister: verificationpolicyInformer.Lister(),
-			cloudEventClient:         cloudeventclient.Get(ctx),
-			metrics:                  taskrunmetrics.Get(ctx),
-			entrypointCache:          entrypointCache,
-			podLister:                podInformer.Lister(),
-			pvcHandler:               volumeclaim.NewPVCHandler(kubeclientset, logger),
-			resolutionRequester:      resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
-			tracerProvider:           tracerProvider,
+			KubeClientSet:              kubeclientset,
+			PipelineClientSet:          pipelineclientset,
+			Images:                     opts.Images,
+			Clock:                      clock,
+			spireClient:                spireClient,
+			taskRunLister:              taskRunInformer.Lister(),
+			limitrangeLister:           limitrangeInformer.Lister(),
+			configMapLister:            configmapinformer.Get(ctx).Lister(),
+			verificationPolicyLister:   verificationpolicyInformer.Lister(),
+			serviceAccountPolicyLister: serviceaccountpolicyinformer.Get(ctx).Lister(),
+			pipelineRunLister:          pipelineruninformer.Get(ctx).Lister(),
+			cloudEventClient:           cloudeventclient.Get(ctx),
+			cloudEventSinks:            cloudeventclient.NewSinks(cloudeventsinkinformer.Get(ctx).Lister(), kubeclientset),
+			notifier:                   notification.NewNotifier(notificationpolicyinformer.Get(ctx).Lister(), taskRunInformer.Lister(), nil, kubeclientset),
+			metrics:                    taskrunmetrics.Get(ctx),
+			entrypointCache:            entrypointCache,
+			podLister:                  podInformer.Lister(),
+			pvcHandler:                 volumeclaim.NewPVCHandler(kubeclientset, persistentvolumeclaiminformer.Get(ctx).Lister(), workspacepoolinformer.Get(ctx).Lister(), logger),
+			resolutionRequester:        resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			tracerProvider:             tracerProvider,
+			resourceUsageReader:        pod.NewResourceUsageReader(kubeclientset.CoreV1().RESTClient()),
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
 				AgentName:   pipeline.TaskRunControllerName,
 				ConfigStore: configStore,
+				// The reconciler applies the status itself.
+				SkipStatusUpdates: true,
 			}
 		})
 
+		sharding.Apply(ctx, impl, taskRunInformer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, taskRunInformer.Informer(), "taskrun")
+
 		taskRunInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+		taskRunInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			DeleteFunc: volumeclaim.ReturnPooledPersistentVolumeClaimsOfDeletedRun(ctx, c.pvcHandler),
+		})
 
 		podInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: controller.FilterController(&v1beta1.TaskRun{}),
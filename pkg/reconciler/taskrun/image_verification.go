@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/trustedresources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// verifyPodImages verifies the cosign signatures of the images of the steps and the sidecars
+// of the pod, pinned to their digests by the pod builder, against the VerificationPolicies
+// of kind "images" of the namespace of the TaskRun. The images of the Tekton sidecars are not verified.
+func (c *Reconciler) verifyPodImages(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	policies, err := c.verificationPolicyLister.VerificationPolicies(tr.Namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list VerificationPolicies from namespace %s with error %w", tr.Namespace, err)
+	}
+	pullSecretsNames := make([]string, 0, len(pod.Spec.ImagePullSecrets))
+	for _, ps := range pod.Spec.ImagePullSecrets {
+		pullSecretsNames = append(pullSecretsNames, ps.Name)
+	}
+	kc, err := k8schain.New(ctx, c.KubeClientSet, k8schain.Options{
+		Namespace:          tr.Namespace,
+		ServiceAccountName: pod.Spec.ServiceAccountName,
+		ImagePullSecrets:   pullSecretsNames,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating k8schain: %w", err)
+	}
+
+	verified := map[string]bool{}
+	for _, container := range pod.Spec.Containers {
+		if !isUserContainer(container.Name) || verified[container.Image] {
+			continue
+		}
+		verified[container.Image] = true
+		ref, err := name.NewDigest(container.Image, name.WeakValidation)
+		if err != nil {
+			return fmt.Errorf("%w: the image %q of container %q isn't referenced by digest: %v", trustedresources.ErrImageVerificationFailed, container.Image, container.Name, err) //nolint:errorlint
+		}
+		result := trustedresources.VerifyImage(ctx, ref, c.KubeClientSet, policies, remote.WithAuthFromKeychain(kc), remote.WithContext(ctx))
+		if result.VerificationResultType == trustedresources.VerificationError {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// isUserContainer returns whether the container runs a step or a sidecar of the Task, rather
+// than a sidecar added by Tekton.
+func isUserContainer(name string) bool {
+	switch name {
+	case pipeline.ReservedResultsSidecarContainerName, pipeline.ReservedLogForwarderSidecarContainerName:
+		return false
+	}
+	return podconvert.IsContainerStep(name) || podconvert.IsContainerSidecar(name)
+}
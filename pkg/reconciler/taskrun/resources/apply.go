@@ -18,9 +18,12 @@ package resources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -45,6 +48,9 @@ var (
 		// FIXME(vdemeester) Remove that with deprecating v1beta1
 		"inputs.params.%s",
 	}
+	// nonEnvVarCharRegex matches the characters of the names of the params which can't be
+	// used in the names of environment variables.
+	nonEnvVarCharRegex = regexp.MustCompile(`[^A-Za-z0-9_]`)
 )
 
 // ApplyParameters applies the params from a TaskRun.Input.Parameters to a TaskSpec
@@ -124,13 +130,27 @@ func paramsFromTaskRun(ctx context.Context, tr *v1beta1.TaskRun) (map[string]str
 }
 
 func getContextReplacements(taskName string, tr *v1beta1.TaskRun) map[string]string {
-	return map[string]string{
-		"context.taskRun.name":      tr.Name,
-		"context.task.name":         taskName,
-		"context.taskRun.namespace": tr.Namespace,
-		"context.taskRun.uid":       string(tr.ObjectMeta.UID),
-		"context.task.retry-count":  strconv.Itoa(len(tr.Status.RetriesStatus)),
+	retryCount := strconv.Itoa(len(tr.Status.RetriesStatus))
+	replacements := map[string]string{
+		"context.taskRun.name":        tr.Name,
+		"context.task.name":           taskName,
+		"context.taskRun.namespace":   tr.Namespace,
+		"context.taskRun.uid":         string(tr.ObjectMeta.UID),
+		"context.task.retry-count":    retryCount,
+		"context.taskRun.retryCount":  retryCount,
+		"context.pipelineRun.creator": tr.Annotations[pipeline.CreatorAnnotationKey],
 	}
+	// The params of the combination of the matrix the TaskRun runs are recorded by the
+	// PipelineRun creating it; the invalid records are ignored.
+	if combination, ok := tr.Annotations[pipeline.MatrixCombinationAnnotationKey]; ok {
+		params := map[string]string{}
+		if err := json.Unmarshal([]byte(combination), &params); err == nil {
+			for name, value := range params {
+				replacements["context.pipelineTask.matrixCombination."+name] = value
+			}
+		}
+	}
+	return replacements
 }
 
 // ApplyContexts applies the substitution from $(context.(taskRun|task).*) with the specified values.
@@ -140,8 +160,9 @@ func ApplyContexts(spec *v1beta1.TaskSpec, taskName string, tr *v1beta1.TaskRun)
 }
 
 // ApplyWorkspaces applies the substitution from paths that the workspaces in declarations mounted to, the
-// volumes that bindings are realized with in the task spec and the PersistentVolumeClaim names for the
-// workspaces.
+// volumes that bindings are realized with in the task spec, the PersistentVolumeClaim names and the names
+// of the objects backing the volumes for the workspaces. The variables of the optional workspaces which
+// aren't bound are replaced with empty strings.
 func ApplyWorkspaces(ctx context.Context, spec *v1beta1.TaskSpec, declarations []v1beta1.WorkspaceDeclaration, bindings []v1beta1.WorkspaceBinding, vols map[string]corev1.Volume) *v1beta1.TaskSpec {
 	stringReplacements := map[string]string{}
 
@@ -155,6 +176,9 @@ func ApplyWorkspaces(ctx context.Context, spec *v1beta1.TaskSpec, declarations [
 		if declaration.Optional && !bindNames.Has(declaration.Name) {
 			stringReplacements[prefix+"bound"] = "false"
 			stringReplacements[prefix+"path"] = ""
+			stringReplacements[prefix+"claim"] = ""
+			stringReplacements[prefix+"volume"] = ""
+			stringReplacements[prefix+"volumeName"] = ""
 		} else {
 			stringReplacements[prefix+"bound"] = "true"
 			alphaAPIEnabled := config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields
@@ -175,10 +199,26 @@ func ApplyWorkspaces(ctx context.Context, spec *v1beta1.TaskSpec, declarations [
 		} else {
 			stringReplacements[fmt.Sprintf("workspaces.%s.claim", binding.Name)] = ""
 		}
+		stringReplacements[fmt.Sprintf("workspaces.%s.volumeName", binding.Name)] = workspaceVolumeName(binding)
 	}
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
+// workspaceVolumeName returns the name of the PersistentVolumeClaim, the ConfigMap or the Secret backing
+// the volume of the binding, or an empty string for the other volume sources.
+func workspaceVolumeName(binding v1beta1.WorkspaceBinding) string {
+	switch {
+	case binding.PersistentVolumeClaim != nil:
+		return binding.PersistentVolumeClaim.ClaimName
+	case binding.ConfigMap != nil:
+		return binding.ConfigMap.Name
+	case binding.Secret != nil:
+		return binding.Secret.SecretName
+	default:
+		return ""
+	}
+}
+
 // applyWorkspaceMountPath accepts a workspace path variable of the form $(workspaces.foo.path) and replaces
 // it in the fields of the TaskSpec. A new updated TaskSpec is returned. Steps or Sidecars in the TaskSpec
 // that override the mountPath will receive that mountPath in place of the variable's value. Other Steps and
@@ -253,6 +293,95 @@ func ApplyCredentialsPath(spec *v1beta1.TaskSpec, path string) *v1beta1.TaskSpec
 	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
 }
 
+// ApplySecretParams replaces the references to the secret params by the paths of the files
+// holding their values, at pod.SecretParamPath, rather than by their values, which thus never
+// appear in the TaskSpec. The files are mounted by MountSecretParams.
+func ApplySecretParams(spec *v1beta1.TaskSpec) *v1beta1.TaskSpec {
+	stringReplacements := map[string]string{}
+	for _, p := range spec.Params {
+		if p.Type != v1beta1.ParamTypeSecret {
+			continue
+		}
+		for _, pattern := range paramPatterns {
+			stringReplacements[fmt.Sprintf(pattern, p.Name)] = pod.SecretParamPath(p.Name)
+		}
+	}
+	if len(stringReplacements) == 0 {
+		return spec
+	}
+	return ApplyReplacements(spec, stringReplacements, map[string][]string{})
+}
+
+// MountSecretParams mounts the keys of the Secrets referenced by the secret params in the steps,
+// at pod.SecretParamPath, and exposes them as the PARAM_<NAME> environment variables of the
+// steps. The mounts are under /tekton/, so they are added once the TaskSpec is validated.
+func MountSecretParams(spec *v1beta1.TaskSpec, tr *v1beta1.TaskRun) *v1beta1.TaskSpec {
+	values := map[string]map[string]string{}
+	for _, p := range spec.Params {
+		if p.Type != v1beta1.ParamTypeSecret {
+			continue
+		}
+		values[p.Name] = map[string]string{}
+		if p.Default != nil {
+			for k, v := range p.Default.ObjectVal {
+				values[p.Name][k] = v
+			}
+		}
+	}
+	if len(values) == 0 {
+		return spec
+	}
+	for _, p := range tr.Spec.Params {
+		if _, ok := values[p.Name]; ok {
+			for k, v := range p.Value.ObjectVal {
+				values[p.Name][k] = v
+			}
+		}
+	}
+
+	spec = spec.DeepCopy()
+	for i, p := range spec.Params {
+		value, ok := values[p.Name]
+		if !ok {
+			continue
+		}
+		volumeName := fmt.Sprintf("tekton-secret-param-%d", i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: value["name"],
+					Items:      []corev1.KeyToPath{{Key: value["key"], Path: pod.SecretParamFile}},
+				},
+			},
+		})
+		env := corev1.EnvVar{
+			Name: secretParamEnvVar(p.Name),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: value["name"]},
+					Key:                  value["key"],
+				},
+			},
+		}
+		for j := range spec.Steps {
+			spec.Steps[j].VolumeMounts = append(spec.Steps[j].VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: filepath.Dir(pod.SecretParamPath(p.Name)),
+				ReadOnly:  true,
+			})
+			spec.Steps[j].Env = append(spec.Steps[j].Env, env)
+		}
+	}
+	return spec
+}
+
+// secretParamEnvVar returns the name of the environment variable exposing the value of the
+// secret param, e.g. PARAM_API_TOKEN for the param api-token.
+func secretParamEnvVar(name string) string {
+	return "PARAM_" + strings.ToUpper(nonEnvVarCharRegex.ReplaceAllString(name, "_"))
+}
+
 // ApplyReplacements replaces placeholders for declared parameters with the specified replacements.
 func ApplyReplacements(spec *v1beta1.TaskSpec, stringReplacements map[string]string, arrayReplacements map[string][]string) *v1beta1.TaskSpec {
 	spec = spec.DeepCopy()
@@ -22,6 +22,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
@@ -1085,6 +1086,60 @@ func TestApplyWorkspaces(t *testing.T) {
 		want: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
 			Script: `test "false" = "true" && echo ""`,
 		}}},
+	}, {
+		name: "optional-workspace-omitted-volume-variable-replacement",
+		spec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Script: `echo "$(workspaces.ows.claim)" "$(workspaces.ows.volume)" "$(workspaces.ows.volumeName)"`,
+		}}},
+		decls: []v1beta1.WorkspaceDeclaration{{
+			Name:     "ows",
+			Optional: true,
+		}},
+		binds: []v1beta1.WorkspaceBinding{}, // intentionally omitted ows binding
+		want: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Script: `echo "" "" ""`,
+		}}},
+	}, {
+		name: "workspace-volume-name-replacement",
+		spec: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Args: []string{
+				"$(workspaces.pvc.volumeName)",
+				"$(workspaces.cm.volumeName)",
+				"$(workspaces.secret.volumeName)",
+				"$(workspaces.empty.volumeName)",
+			},
+		}}},
+		decls: []v1beta1.WorkspaceDeclaration{{
+			Name: "pvc",
+		}, {
+			Name: "cm",
+		}, {
+			Name: "secret",
+		}, {
+			Name: "empty",
+		}},
+		binds: []v1beta1.WorkspaceBinding{{
+			Name: "pvc",
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "my-claim",
+			},
+		}, {
+			Name: "cm",
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "my-configmap"},
+			},
+		}, {
+			Name: "secret",
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "my-secret",
+			},
+		}, {
+			Name:     "empty",
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}},
+		want: &v1beta1.TaskSpec{Steps: []v1beta1.Step{{
+			Args: []string{"my-claim", "my-configmap", "my-secret", ""},
+		}}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			vols := workspace.CreateVolumes(tc.binds)
@@ -1370,6 +1425,79 @@ func TestContext(t *testing.T) {
 				Image: "0-1",
 			}},
 		},
+	}, {
+		description: "context taskRun retryCount replacement",
+		tr: v1beta1.TaskRun{
+			Status: v1beta1.TaskRunStatus{
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					RetriesStatus: []v1beta1.TaskRunStatus{{
+						Status: duckv1.Status{
+							Conditions: []apis.Condition{{
+								Type:   apis.ConditionSucceeded,
+								Status: corev1.ConditionFalse,
+							}},
+						},
+					}},
+				},
+			},
+		},
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{"--attempt=$(context.taskRun.retryCount)"},
+			}},
+		},
+		want: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{"--attempt=1"},
+			}},
+		},
+	}, {
+		description: "context pipelineRun creator and matrix combination replacement",
+		tr: v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					pipeline.CreatorAnnotationKey:           "jane@example.com",
+					pipeline.MatrixCombinationAnnotationKey: `{"browser":"chrome","platform":"linux"}`,
+				},
+			},
+		},
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{
+					"--creator=$(context.pipelineRun.creator)",
+					"--browser=$(context.pipelineTask.matrixCombination.browser)",
+					"--platform=$(context.pipelineTask.matrixCombination.platform)",
+				},
+			}},
+		},
+		want: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{
+					"--creator=jane@example.com",
+					"--browser=chrome",
+					"--platform=linux",
+				},
+			}},
+		},
+	}, {
+		description: "context matrix combination isn't replaced without a matrix",
+		tr:          v1beta1.TaskRun{},
+		spec: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{"--browser=$(context.pipelineTask.matrixCombination.browser)"},
+			}},
+		},
+		want: v1beta1.TaskSpec{
+			Steps: []v1beta1.Step{{
+				Name: "ImageName",
+				Args: []string{"--browser=$(context.pipelineTask.matrixCombination.browser)"},
+			}},
+		},
 	}} {
 		t.Run(tc.description, func(t *testing.T) {
 			got := resources.ApplyContexts(&tc.spec, tc.taskName, &tc.tr)
@@ -1443,6 +1571,84 @@ func TestApplyStepExitCodePath(t *testing.T) {
 	}
 }
 
+func TestApplySecretParams(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "api-token",
+			Type:    v1beta1.ParamTypeSecret,
+			Default: v1beta1.NewObject(map[string]string{"name": "default-credentials", "key": "token"}),
+		}, {
+			Name: "branch",
+			Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{
+			Image:  "curl",
+			Script: "curl -H @$(params.api-token) https://example.com/$(params.branch)",
+		}},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Steps[0].Script = "curl -H @/tekton/secret-params/api-token/value https://example.com/$(params.branch)"
+	})
+	got := resources.ApplySecretParams(ts)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ApplySecretParams() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMountSecretParams(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{
+			Name:    "api-token",
+			Type:    v1beta1.ParamTypeSecret,
+			Default: v1beta1.NewObject(map[string]string{"name": "default-credentials", "key": "token"}),
+		}, {
+			Name: "branch",
+			Type: v1beta1.ParamTypeString,
+		}},
+		Steps: []v1beta1.Step{{
+			Image:  "curl",
+			Script: "curl -H @/tekton/secret-params/api-token/value https://example.com/main",
+		}},
+	}
+	tr := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			Params: v1beta1.Params{{
+				Name:  "api-token",
+				Value: *v1beta1.NewObject(map[string]string{"name": "credentials"}),
+			}},
+		},
+	}
+	want := applyMutation(ts, func(spec *v1beta1.TaskSpec) {
+		spec.Volumes = []corev1.Volume{{
+			Name: "tekton-secret-param-0",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: "credentials",
+					Items:      []corev1.KeyToPath{{Key: "token", Path: "value"}},
+				},
+			},
+		}}
+		spec.Steps[0].VolumeMounts = []corev1.VolumeMount{{
+			Name:      "tekton-secret-param-0",
+			MountPath: "/tekton/secret-params/api-token",
+			ReadOnly:  true,
+		}}
+		spec.Steps[0].Env = []corev1.EnvVar{{
+			Name: "PARAM_API_TOKEN",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"},
+					Key:                  "token",
+				},
+			},
+		}}
+	})
+	got := resources.MountSecretParams(ts, tr)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("MountSecretParams() got diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestApplyCredentialsPath(t *testing.T) {
 	for _, tc := range []struct {
 		description string
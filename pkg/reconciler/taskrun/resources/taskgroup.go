@@ -0,0 +1,222 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/pod"
+	"github.com/tektoncd/pipeline/pkg/substitution"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// getTaskGroup resolves the TaskGroup with the given name and its member Tasks
+// from the local cluster, and composes them into a single Task.
+func (l *LocalTaskRefResolver) getTaskGroup(ctx context.Context, name string) (*v1beta1.Task, error) {
+	if l.Namespace == "" {
+		return nil, fmt.Errorf("must specify namespace to resolve reference to task group %s", name)
+	}
+	group, err := l.Tektonclient.TektonV1alpha1().TaskGroups(l.Namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*v1beta1.Task, 0, len(group.Spec.Tasks))
+	for _, member := range group.Spec.Tasks {
+		kind := v1beta1.NamespacedTaskKind
+		if member.TaskRef.Kind != "" {
+			kind = member.TaskRef.Kind
+		}
+		if kind == v1beta1.TaskGroupKind {
+			return nil, fmt.Errorf("the member %s of the TaskGroup %s can't be a TaskGroup", member.Name, name)
+		}
+		resolver := &LocalTaskRefResolver{Namespace: l.Namespace, Kind: kind, Tektonclient: l.Tektonclient}
+		task, _, _, err := resolver.GetTask(ctx, member.TaskRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the %s %s of the member %s of the TaskGroup %s: %w", kind, member.TaskRef.Name, member.Name, name, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return ComposeTaskGroup(group, tasks)
+}
+
+// ComposeTaskGroup inlines the specs of the member Tasks of the TaskGroup, in
+// the order of its members, into the spec of a single Task named after the
+// TaskGroup. The params, results, steps and sidecars of the members are
+// prefixed with the names of the members, and the references to them are
+// substituted accordingly. The workspaces of the members are shared by name,
+// and so are their volumes if they are identical.
+func ComposeTaskGroup(group *v1alpha1.TaskGroup, tasks []*v1beta1.Task) (*v1beta1.Task, error) {
+	if len(tasks) != len(group.Spec.Tasks) {
+		return nil, fmt.Errorf("the TaskGroup %s has %d members but %d Tasks were given", group.Name, len(group.Spec.Tasks), len(tasks))
+	}
+	spec := v1beta1.TaskSpec{Description: group.Spec.Description}
+	declared := map[string]sets.String{}
+	declare := func(what, name string) error {
+		if declared[what] == nil {
+			declared[what] = sets.NewString()
+		}
+		if declared[what].Has(name) {
+			return fmt.Errorf("the %s %s is declared twice by the members of the TaskGroup %s", what, name, group.Name)
+		}
+		declared[what].Insert(name)
+		return nil
+	}
+	workspaces := map[string]int{}
+	volumes := map[string]corev1.Volume{}
+
+	for i, member := range group.Spec.Tasks {
+		ts := tasks[i].Spec.DeepCopy()
+		steps, err := v1beta1.MergeStepsWithStepTemplate(ts.StepTemplate, ts.Steps)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge the steps of the member %s of the TaskGroup %s with its step template: %w", member.Name, group.Name, err)
+		}
+		ts.Steps, ts.StepTemplate = steps, nil
+
+		stepNames := make([]string, len(ts.Steps))
+		for j, s := range ts.Steps {
+			if s.Name != "" {
+				stepNames[j] = member.Prefix(s.Name)
+			} else {
+				stepNames[j] = member.Prefix(fmt.Sprintf("unnamed-%d", j))
+			}
+		}
+		stringReplacements, arrayReplacements := taskGroupMemberReplacements(&member, ts, stepNames)
+		ts = ApplyReplacements(ts, stringReplacements, arrayReplacements)
+
+		for _, p := range ts.Params {
+			p.Name = member.Prefix(p.Name)
+			if err := declare("param", p.Name); err != nil {
+				return nil, err
+			}
+			spec.Params = append(spec.Params, p)
+		}
+		for _, r := range ts.Results {
+			r.Name = member.Prefix(r.Name)
+			if err := declare("result", r.Name); err != nil {
+				return nil, err
+			}
+			spec.Results = append(spec.Results, r)
+		}
+		for j, s := range ts.Steps {
+			s.Name = stepNames[j]
+			if err := declare("step", s.Name); err != nil {
+				return nil, err
+			}
+			spec.Steps = append(spec.Steps, s)
+		}
+		for _, s := range ts.Sidecars {
+			if s.Name != "" {
+				s.Name = member.Prefix(s.Name)
+			}
+			spec.Sidecars = append(spec.Sidecars, s)
+		}
+		for _, w := range ts.Workspaces {
+			// The members share the workspaces with the same name, which are only
+			// read-only or optional if they are for all the members declaring them.
+			if k, ok := workspaces[w.Name]; ok {
+				spec.Workspaces[k].ReadOnly = spec.Workspaces[k].ReadOnly && w.ReadOnly
+				spec.Workspaces[k].Optional = spec.Workspaces[k].Optional && w.Optional
+				continue
+			}
+			workspaces[w.Name] = len(spec.Workspaces)
+			spec.Workspaces = append(spec.Workspaces, w)
+		}
+		for _, v := range ts.Volumes {
+			if existing, ok := volumes[v.Name]; ok {
+				if !reflect.DeepEqual(existing, v) {
+					return nil, fmt.Errorf("the volume %s is declared differently by several members of the TaskGroup %s", v.Name, group.Name)
+				}
+				continue
+			}
+			volumes[v.Name] = v
+			spec.Volumes = append(spec.Volumes, v)
+		}
+	}
+
+	return &v1beta1.Task{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Task",
+			APIVersion: "tekton.dev/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        group.Name,
+			Namespace:   group.Namespace,
+			Labels:      group.Labels,
+			Annotations: group.Annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// taskGroupMemberReplacements returns the replacements of the references to the
+// params, results and steps of a member of a TaskGroup by references to their
+// prefixed names.
+func taskGroupMemberReplacements(member *v1alpha1.TaskGroupTask, ts *v1beta1.TaskSpec, stepNames []string) (map[string]string, map[string][]string) {
+	stringReplacements := map[string]string{}
+	arrayReplacements := map[string][]string{}
+	arrays := sets.NewString()
+
+	for _, p := range ts.Params {
+		name := member.Prefix(p.Name)
+		switch p.Type {
+		case v1beta1.ParamTypeArray:
+			arrays.Insert(p.Name)
+			for _, pattern := range paramPatterns {
+				arrayReplacements[fmt.Sprintf(pattern, p.Name)] = []string{fmt.Sprintf("$(params.%s[*])", name)}
+			}
+		case v1beta1.ParamTypeObject:
+			for k := range p.Properties {
+				stringReplacements[fmt.Sprintf(objectIndividualVariablePattern, p.Name, k)] = fmt.Sprintf("$(params.%s.%s)", name, k)
+			}
+		case v1beta1.ParamTypeString:
+			fallthrough
+		default:
+			for _, pattern := range paramPatterns {
+				stringReplacements[fmt.Sprintf(pattern, p.Name)] = fmt.Sprintf("$(params.%s)", name)
+			}
+		}
+	}
+	// The elements of the array params are referred to by their indexes, which
+	// are collected from the whole spec of the member.
+	if b, err := json.Marshal(ts); err == nil {
+		for _, ref := range substitution.ExtractParamsExpressions(strings.ReplaceAll(string(b), `\"`, `"`)) {
+			names, _, _ := substitution.ExtractVariablesFromString(substitution.TrimArrayIndex(ref), "params")
+			if len(names) == 0 || !arrays.Has(names[0]) {
+				continue
+			}
+			stringReplacements[strings.TrimSuffix(strings.TrimPrefix(ref, "$("), ")")] = fmt.Sprintf("$(params.%s%s)", member.Prefix(names[0]), substitution.ExtractIndexString(ref))
+		}
+	}
+
+	for _, r := range ts.Results {
+		for _, pattern := range []string{"results.%s.path", "results[%q].path", "results['%s'].path"} {
+			stringReplacements[fmt.Sprintf(pattern, r.Name)] = fmt.Sprintf("$(results.%s.path)", member.Prefix(r.Name))
+		}
+	}
+	for i, s := range ts.Steps {
+		stringReplacements[fmt.Sprintf("steps.%s.exitCode.path", pod.StepName(s.Name, i))] = fmt.Sprintf("$(steps.%s.exitCode.path)", pod.StepName(stepNames[i], i))
+	}
+	return stringReplacements, arrayReplacements
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/parse"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var cloneAndBuild = &v1alpha1.TaskGroup{
+	ObjectMeta: metav1.ObjectMeta{Name: "clone-and-build", Namespace: "default"},
+	Spec: v1alpha1.TaskGroupSpec{
+		Description: "Clone and build",
+		Tasks: []v1alpha1.TaskGroupTask{
+			{Name: "clone", TaskRef: &v1beta1.TaskRef{Name: "git-clone", Kind: v1beta1.ClusterTaskKind}},
+			{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "golang-build"}},
+		},
+	},
+}
+
+func TestComposeTaskGroup(t *testing.T) {
+	clone := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: git-clone
+spec:
+  params:
+  - name: url
+  - name: depth
+    default: "1"
+  results:
+  - name: commit
+  workspaces:
+  - name: source
+  stepTemplate:
+    env:
+    - name: HOME
+      value: /tekton/home
+  steps:
+  - name: clone
+    image: git
+    script: git clone --depth $(params.depth) $(params.url) $(workspaces.source.path) && git rev-parse HEAD > $(results.commit.path)
+`)
+	build := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: golang-build
+  namespace: default
+spec:
+  params:
+  - name: packages
+    type: array
+  - name: env
+    type: object
+    properties:
+      GOOS: {}
+  workspaces:
+  - name: source
+    readOnly: true
+  - name: cache
+    optional: true
+  sidecars:
+  - name: registry
+    image: registry
+  steps:
+  - image: golang
+    env:
+    - name: GOOS
+      value: $(params.env.GOOS)
+    command: ["go", "build"]
+    args: ["$(params.packages[*])", "$(params.packages[0])"]
+  - name: report
+    image: bash
+    script: cat $(steps.step-unnamed-0.exitCode.path)
+`)
+
+	got, err := resources.ComposeTaskGroup(cloneAndBuild, []*v1beta1.Task{clone, build})
+	if err != nil {
+		t.Fatalf("ComposeTaskGroup() = %v", err)
+	}
+	want := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: clone-and-build
+  namespace: default
+spec:
+  description: Clone and build
+  params:
+  - name: clone-url
+  - name: clone-depth
+    default: "1"
+  - name: build-packages
+    type: array
+  - name: build-env
+    type: object
+    properties:
+      GOOS: {}
+  results:
+  - name: clone-commit
+  workspaces:
+  - name: source
+  - name: cache
+    optional: true
+  sidecars:
+  - name: build-registry
+    image: registry
+  steps:
+  - name: clone-clone
+    image: git
+    env:
+    - name: HOME
+      value: /tekton/home
+    script: git clone --depth $(params.clone-depth) $(params.clone-url) $(workspaces.source.path) && git rev-parse HEAD > $(results.clone-commit.path)
+  - name: build-unnamed-0
+    image: golang
+    env:
+    - name: GOOS
+      value: $(params.build-env.GOOS)
+    command: ["go", "build"]
+    args: ["$(params.build-packages[*])", "$(params.build-packages[0])"]
+  - name: build-report
+    image: bash
+    script: cat $(steps.step-build-unnamed-0.exitCode.path)
+`)
+	if d := cmp.Diff(want, got); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+}
+
+func TestComposeTaskGroup_Conflict(t *testing.T) {
+	group := &v1alpha1.TaskGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "conflict"},
+		Spec: v1alpha1.TaskGroupSpec{
+			Tasks: []v1alpha1.TaskGroupTask{
+				{Name: "a", TaskRef: &v1beta1.TaskRef{Name: "a"}},
+				{Name: "a-b", TaskRef: &v1beta1.TaskRef{Name: "b"}},
+			},
+		},
+	}
+	a := &v1beta1.Task{Spec: v1beta1.TaskSpec{Params: v1beta1.ParamSpecs{{Name: "b-c"}}}}
+	b := &v1beta1.Task{Spec: v1beta1.TaskSpec{Params: v1beta1.ParamSpecs{{Name: "c"}}}}
+	_, err := resources.ComposeTaskGroup(group, []*v1beta1.Task{a, b})
+	if err == nil || err.Error() != "the param a-b-c is declared twice by the members of the TaskGroup conflict" {
+		t.Errorf("ComposeTaskGroup() = %v, want an error for the param declared twice", err)
+	}
+}
+
+func TestLocalTaskRef_TaskGroup(t *testing.T) {
+	ctx := context.Background()
+	tektonclient := fake.NewSimpleClientset(
+		cloneAndBuild,
+		&v1beta1.ClusterTask{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone"},
+			Spec:       v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "clone", Image: "git"}}},
+		},
+		&v1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "golang-build", Namespace: "default"},
+			Spec:       v1beta1.TaskSpec{Steps: []v1beta1.Step{{Name: "build", Image: "golang"}}},
+		},
+	)
+	lc := &resources.LocalTaskRefResolver{
+		Namespace:    "default",
+		Kind:         v1beta1.TaskGroupKind,
+		Tektonclient: tektonclient,
+	}
+
+	task, _, _, err := lc.GetTask(ctx, "clone-and-build")
+	if err != nil {
+		t.Fatalf("GetTask() = %v", err)
+	}
+	want := []v1beta1.Step{{Name: "clone-clone", Image: "git"}, {Name: "build-build", Image: "golang"}}
+	if d := cmp.Diff(want, task.Spec.Steps); d != "" {
+		t.Error(diff.PrintWantGot(d))
+	}
+
+	if _, _, _, err := lc.GetTask(ctx, "missing"); err == nil {
+		t.Error("GetTask() of a missing TaskGroup should fail")
+	}
+	tektonclient = fake.NewSimpleClientset(cloneAndBuild)
+	lc.Tektonclient = tektonclient
+	if _, _, _, err := lc.GetTask(ctx, "clone-and-build"); err == nil {
+		t.Error("GetTask() of a TaskGroup with a missing member should fail")
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/tektoncd/pipeline/pkg/clustervisibility"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	"github.com/tektoncd/pipeline/pkg/remote/oci"
 	"github.com/tektoncd/pipeline/pkg/remote/resolution"
@@ -168,7 +169,7 @@ func resolveTask(ctx context.Context, resolver remote.Resolver, name string, kin
 // or if there is an error validating or upgrading an older TaskObject into
 // its v1beta1 equivalent.
 // A VerificationResult is returned if trusted resources is enabled, VerificationResult contains the result type and err.
-// v1beta1 task will be verified by trusted resources if the feature is enabled
+// v1beta1 and v1 tasks will be verified by trusted resources if the feature is enabled
 // TODO(#5541): convert v1beta1 obj to v1 once we use v1 as the stored version
 func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object, k8s kubernetes.Interface, refSource *v1beta1.RefSource, verificationPolicies []*v1alpha1.VerificationPolicy) (*v1beta1.Task, *trustedresources.VerificationResult, error) {
 	switch obj := obj.(type) {
@@ -179,7 +180,8 @@ func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object, k8s kubern
 	case *v1beta1.ClusterTask:
 		return convertClusterTaskToTask(*obj), nil, nil
 	case *v1.Task:
-		// TODO(#6356): Support V1 Task verification
+		// Verify the Task before it is converted, since signatures are based on the remote v1 task contents
+		vr := trustedresources.VerifyResource(ctx, obj, k8s, refSource, verificationPolicies)
 		// Validation of beta fields must happen before the V1 Task is converted into the storage version of the API.
 		// TODO(#6592): Decouple API versioning from feature versioning
 		if err := obj.Spec.ValidateBetaFields(ctx); err != nil {
@@ -194,7 +196,7 @@ func readRuntimeObjectAsTask(ctx context.Context, obj runtime.Object, k8s kubern
 		if err := t.ConvertFrom(ctx, obj); err != nil {
 			return nil, nil, fmt.Errorf("failed to convert obj %s into Task", obj.GetObjectKind().GroupVersionKind().String())
 		}
-		return t, nil, nil
+		return t, &vr, nil
 	}
 	return nil, nil, errors.New("resource is not a task")
 }
@@ -206,15 +208,26 @@ type LocalTaskRefResolver struct {
 	Tektonclient clientset.Interface
 }
 
-// GetTask will resolve either a Task or ClusterTask from the local cluster using a versioned Tekton client. It will
-// return an error if it can't find an appropriate Task for any reason.
+// GetTask will resolve either a Task, ClusterTask or TaskGroup from the local cluster using a versioned Tekton client.
+// It will return an error if it can't find an appropriate Task for any reason, or if the ClusterTask isn't visible in
+// the namespace. The member Tasks of a TaskGroup are composed into a single Task.
 // TODO(#6666): support local task verification
 func (l *LocalTaskRefResolver) GetTask(ctx context.Context, name string) (*v1beta1.Task, *v1beta1.RefSource, *trustedresources.VerificationResult, error) {
+	if l.Kind == v1beta1.TaskGroupKind {
+		task, err := l.getTaskGroup(ctx, name)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return task, nil, nil, nil
+	}
 	if l.Kind == v1beta1.ClusterTaskKind {
 		task, err := l.Tektonclient.TektonV1beta1().ClusterTasks().Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			return nil, nil, nil, err
 		}
+		if err := clustervisibility.ClusterTaskVisible(task, l.Namespace); err != nil {
+			return nil, nil, nil, err
+		}
 		return convertClusterTaskToTask(*task), nil, nil, nil
 	}
 
@@ -29,6 +29,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-containerregistry/pkg/registry"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
@@ -195,6 +196,48 @@ func TestLocalTaskRef(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "local-clustertask-visible-in-namespace",
+			tasks: []runtime.Object{
+				&v1beta1.ClusterTask{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cluster-task",
+						Annotations: map[string]string{v1beta1.VisibleNamespacesAnnotationKey: "ci, default"},
+					},
+				},
+			},
+			ref: &v1beta1.TaskRef{
+				Name: "cluster-task",
+				Kind: "ClusterTask",
+			},
+			expected: &v1beta1.Task{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "tekton.dev/v1beta1",
+					Kind:       "Task",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster-task",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "local-clustertask-not-visible-in-namespace",
+			tasks: []runtime.Object{
+				&v1beta1.ClusterTask{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cluster-task",
+						Annotations: map[string]string{v1beta1.VisibleNamespacesAnnotationKey: "ci"},
+					},
+				},
+			},
+			ref: &v1beta1.TaskRef{
+				Name: "cluster-task",
+				Kind: "ClusterTask",
+			},
+			expected: nil,
+			wantErr:  true,
+		},
 		{
 			name:  "task-not-found",
 			tasks: []runtime.Object{},
@@ -905,6 +948,20 @@ func TestGetTaskFunc_VerifyError(t *testing.T) {
 	resolvedUnsigned := test.NewResolvedResource(unsignedTaskBytes, nil, matchPolicyRefSource, nil)
 	requesterUnsigned := test.NewRequester(resolvedUnsigned, nil)
 
+	unsignedV1Task := &pipelinev1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: "trusted-resources"},
+		Spec: pipelinev1.TaskSpec{
+			Steps: []pipelinev1.Step{{Image: "ubuntu", Name: "echo"}},
+		},
+	}
+	unsignedV1TaskBytes, err := json.Marshal(unsignedV1Task)
+	if err != nil {
+		t.Fatal("fail to marshal task", err)
+	}
+	resolvedUnsignedV1 := test.NewResolvedResource(unsignedV1TaskBytes, nil, matchPolicyRefSource, nil)
+	requesterUnsignedV1 := test.NewRequester(resolvedUnsignedV1, nil)
+
 	signedTask, err := test.GetSignedTask(unsignedTask, signer, "signed")
 	if err != nil {
 		t.Fatal("fail to sign task", err)
@@ -963,6 +1020,13 @@ func TestGetTaskFunc_VerifyError(t *testing.T) {
 		expected:                       nil,
 		expectedErr:                    trustedresources.ErrResourceVerificationFailed,
 		expectedVerificationResultType: trustedresources.VerificationError,
+	}, {
+		name:                           "unsigned v1 task fails verification with missing signature",
+		requester:                      requesterUnsignedV1,
+		verificationNoMatchPolicy:      config.FailNoMatchPolicy,
+		expected:                       nil,
+		expectedErr:                    trustedresources.ErrSignatureMissing,
+		expectedVerificationResultType: trustedresources.VerificationError,
 	}, {
 		name:                           "modified task fails verification with fail no match policy",
 		requester:                      requesterModified,
@@ -18,8 +18,10 @@ package taskrun
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
@@ -34,20 +36,27 @@ import (
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	alphalisters "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/faultinjection"
 	"github.com/tektoncd/pipeline/pkg/internal/affinityassistant"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
+	"github.com/tektoncd/pipeline/pkg/loglevel"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	tknreconciler "github.com/tektoncd/pipeline/pkg/reconciler"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
+	"github.com/tektoncd/pipeline/pkg/reconciler/events/notification"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolution "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/serviceaccountpolicy"
+	"github.com/tektoncd/pipeline/pkg/slsa"
 	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"go.opentelemetry.io/otel/attribute"
@@ -55,6 +64,7 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -79,17 +89,23 @@ type Reconciler struct {
 	Clock             clock.PassiveClock
 
 	// listers index properties about resources
-	spireClient              spire.ControllerAPIClient
-	taskRunLister            listers.TaskRunLister
-	limitrangeLister         corev1Listers.LimitRangeLister
-	podLister                corev1Listers.PodLister
-	verificationPolicyLister alphalisters.VerificationPolicyLister
-	cloudEventClient         cloudevent.CEClient
-	entrypointCache          podconvert.EntrypointCache
-	metrics                  *taskrunmetrics.Recorder
-	pvcHandler               volumeclaim.PvcHandler
-	resolutionRequester      resolution.Requester
-	tracerProvider           trace.TracerProvider
+	spireClient                spire.ControllerAPIClient
+	taskRunLister              listers.TaskRunLister
+	limitrangeLister           corev1Listers.LimitRangeLister
+	configMapLister            corev1Listers.ConfigMapLister
+	podLister                  corev1Listers.PodLister
+	verificationPolicyLister   alphalisters.VerificationPolicyLister
+	serviceAccountPolicyLister alphalisters.ServiceAccountPolicyLister
+	pipelineRunLister          listers.PipelineRunLister
+	cloudEventClient           cloudevent.CEClient
+	cloudEventSinks            *cloudevent.Sinks
+	notifier                   *notification.Notifier
+	entrypointCache            podconvert.EntrypointCache
+	metrics                    *taskrunmetrics.Recorder
+	pvcHandler                 volumeclaim.PvcHandler
+	resolutionRequester        resolution.Requester
+	tracerProvider             trace.TracerProvider
+	resourceUsageReader        podconvert.ResourceUsageReader
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -100,19 +116,40 @@ var (
 // ReconcileKind compares the actual state with the desired, and attempts to
 // converge the two. It then updates the Status block of the Task Run
 // resource with the current status of the resource.
-func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkgreconciler.Event {
+func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) (event pkgreconciler.Event) {
+	ctx = loglevel.WithLogger(ctx, tr)
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx = cloudevent.WithSinks(ctx, c.cloudEventSinks)
+	ctx = notification.WithNotifier(ctx, c.notifier)
 	ctx = initTracing(ctx, c.tracerProvider, tr)
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:ReconcileKind")
 	defer span.End()
 
 	span.SetAttributes(attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace))
+
+	// Apply the status once reconciled, when it changed, rather than have the generated
+	// reconciler update it and retry on the conflicts with the other controllers updating
+	// the TaskRun.
+	originalStatus := tr.Status.DeepCopy()
+	defer func() {
+		if equality.Semantic.DeepEqual(originalStatus, &tr.Status) {
+			return
+		}
+		if err := tknreconciler.ApplyTaskRunStatus(ctx, c.PipelineClientSet, tr, originalStatus); err != nil {
+			logger.Warnw("Failed to apply the status of the TaskRun", zap.Error(err))
+			controller.GetEventRecorder(ctx).Eventf(tr, corev1.EventTypeWarning, "UpdateFailed", "Failed to update status for %q: %v", tr.Name, err)
+			event = err
+		}
+	}()
+
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
 
 	// Record the duration and count after the reconcile cycle.
 	defer c.durationAndCountMetrics(ctx, tr, before)
+	// Send the cloud events of the steps which started or terminated during the reconcile cycle.
+	defer cloudevent.EmitStepCloudEvents(ctx, append([]v1beta1.StepState{}, tr.Status.Steps...), tr)
 
 	// If the TaskRun is just starting, this will also set the starttime,
 	// from which the timeout will immediately begin counting down.
@@ -130,6 +167,20 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		// on the event to perform user facing initialisations, such has reset a CI check status
 		afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
 		events.Emit(ctx, nil, afterCondition, tr)
+
+		// The params taking their values from ConfigMaps are resolved once, when the TaskRun
+		// starts.
+		resolved, err := tknreconciler.ResolveConfigMapParams(c.configMapLister, tr.Namespace, tr.Spec.Params)
+		switch {
+		case errors.Is(err, tknreconciler.ErrParamValueSourceNotFound):
+			logger.Errorf("TaskRun %q params are invalid: %v", tr.Name, err)
+			tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, tr, before, controller.NewPermanentError(err))
+		case err != nil:
+			return err
+		default:
+			tr.Status.ResolvedParams = resolved
+		}
 	}
 
 	// If the TaskRun is complete, run some post run fixtures when applicable
@@ -140,10 +191,22 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		// and may not have had all of the assumed default specified.
 		tr.SetDefaults(ctx)
 
+		// Generate the SLSA provenance once the results of the TaskRun and the images of
+		// its steps are known.
+		if builderID := config.FromContextOrDefaults(ctx).FeatureFlags.SLSABuilderID; builderID != "" && tr.Status.Provenance != nil && tr.Status.Provenance.SLSA == nil {
+			tr.Status.Provenance.SLSA = slsa.ForTaskRun(tr, builderID)
+		}
+
 		if err := c.stopSidecars(ctx, tr); err != nil {
 			return err
 		}
 
+		if tr.HasVolumeClaimTemplate() {
+			if err := c.pvcHandler.ReturnPooledPersistentVolumeClaims(ctx, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
+				logger.Errorf("Failed to return pooled PVCs for TaskRun %s: %v", tr.Name, err)
+			}
+		}
+
 		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
 	}
 
@@ -204,7 +267,24 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 		// Compute the time since the task started.
 		elapsed := c.Clock.Since(tr.Status.StartTime.Time)
 		// Snooze this resource until the timeout has elapsed.
-		return controller.NewRequeueAfter(tr.GetTimeout(ctx) - elapsed)
+		requeueAfter := tr.GetTimeout(ctx) - elapsed
+		// Wake up earlier to sample the resource usage of the steps if it is recorded.
+		if recordsStepResourceUsage(ctx) && !tr.IsDone() && (tr.GetTimeout(ctx) == config.NoTimeoutDuration || requeueAfter > podconvert.ResourceUsageSamplingPeriod) {
+			requeueAfter = podconvert.ResourceUsageSamplingPeriod
+		}
+		// Wake up earlier to read the progress of the steps if it is reported.
+		if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepProgress && !tr.IsDone() && (tr.GetTimeout(ctx) == config.NoTimeoutDuration || requeueAfter > podconvert.StepProgressPollingPeriod) {
+			requeueAfter = podconvert.StepProgressPollingPeriod
+		}
+		// Wake up earlier to create the pod once an injected pod start delay has elapsed.
+		if delay := c.remainingPodStartDelay(ctx, tr); delay > 0 && (tr.GetTimeout(ctx) == config.NoTimeoutDuration || delay < requeueAfter) {
+			requeueAfter = delay
+		}
+		// Wake up earlier to create the pod of the next run of a repeated TaskRun once its delay has elapsed.
+		if delay := c.remainingRepeatDelay(tr); delay > 0 && (tr.GetTimeout(ctx) == config.NoTimeoutDuration || delay < requeueAfter) {
+			requeueAfter = delay
+		}
+		return controller.NewRequeueAfter(requeueAfter)
 	}
 	return nil
 }
@@ -284,12 +364,24 @@ func (c *Reconciler) finishReconcileUpdateEmitEvents(ctx context.Context, tr *v1
 	logger := logging.FromContext(ctx)
 
 	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
-	if afterCondition.IsFalse() && !tr.IsCancelled() && tr.IsRetriable() {
+	if afterCondition.IsFalse() && !tr.IsCancelled() && tr.IsRetriable() && afterCondition.Reason != v1beta1.TaskRunReasonRepeatLimitExceeded.String() {
 		retryTaskRun(tr, afterCondition.Message)
 		afterCondition = tr.Status.GetCondition(apis.ConditionSucceeded)
+	} else if afterCondition.IsTrue() && tr.Spec.RepeatUntil != nil && !tr.Spec.RepeatUntil.Passes(tr.Status.TaskRunResults) {
+		if tr.IsRepeatable() {
+			repeatTaskRun(tr, fmt.Sprintf("TaskRun %q is repeated until its results pass its condition", tr.Name))
+		} else {
+			tr.Status.MarkResourceFailed(v1beta1.TaskRunReasonRepeatLimitExceeded,
+				fmt.Errorf("TaskRun %q was repeated %d times, but its results still don't pass its condition", tr.Name, tr.RepeatCount()))
+		}
+		afterCondition = tr.Status.GetCondition(apis.ConditionSucceeded)
 	}
 	// Send k8s events and cloud events (when configured)
-	events.Emit(ctx, beforeCondition, afterCondition, tr)
+	if !c.dropsEvents(ctx, tr, beforeCondition, afterCondition) {
+		events.Emit(ctx, beforeCondition, afterCondition, tr)
+	}
+
+	recordSpans(c.tracerProvider, tr, beforeCondition)
 
 	_, err := c.updateLabelsAndAnnotations(ctx, tr)
 	if err != nil {
@@ -351,12 +443,43 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(taskMeta.VerificationResult.Err)
 	}
 
+	// Ensure that the ServiceAccountPolicies allow the service account of the TaskRun,
+	// before its pod is created with it.
+	if tr.Status.PodName == "" {
+		policies, err := c.serviceAccountPolicyLister.ServiceAccountPolicies(tr.Namespace).List(labels.Everything())
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list ServiceAccountPolicies from namespace %s with error %w", tr.Namespace, err)
+		}
+		var pr *v1beta1.PipelineRun
+		if owner := metav1.GetControllerOf(tr); owner != nil && owner.Kind == pipeline.PipelineRunControllerName {
+			pr, err = c.pipelineRunLister.PipelineRuns(tr.Namespace).Get(owner.Name)
+			if err != nil && !k8serrors.IsNotFound(err) {
+				return nil, nil, fmt.Errorf("failed to get PipelineRun %s/%s with error %w", tr.Namespace, owner.Name, err)
+			}
+		}
+		if err := serviceaccountpolicy.CheckTaskRun(policies, pr, tr); err != nil {
+			logger.Errorf("TaskRun %s/%s uses a service account which is not allowed: %v", tr.Namespace, tr.Name, err)
+			tr.Status.MarkResourceFailed(podconvert.ReasonServiceAccountNotAllowed, err)
+			return nil, nil, controller.NewPermanentError(err)
+		}
+	}
+
 	rtr := &resources.ResolvedTask{
 		TaskName: taskMeta.Name,
 		TaskSpec: taskSpec,
 		Kind:     resources.GetTaskKind(tr),
 	}
 
+	// The params taking their values from ConfigMaps take the values resolved when the TaskRun
+	// started, and the params taking their values from Secrets are secret params, mounted in
+	// the steps, so that the values of the Secrets are never read by the controller.
+	if err := tknreconciler.ValidateSecretParamSources(taskSpec.Params, tr.Spec.Params); err != nil {
+		logger.Errorf("TaskRun %q params are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+	tr.Spec.Params = tknreconciler.ApplyParamValueSources(tr.Spec.Params, tr.Status.ResolvedParams)
+
 	if err := validateTaskSpecRequestResources(taskSpec); err != nil {
 		logger.Errorf("TaskRun %s taskSpec request resources are invalid: %v", tr.Name, err)
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -400,6 +523,12 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
+	if err := validateResultFileMountPaths(taskSpec, workspaceDeclarations, tr.Spec.ResultFiles); err != nil {
+		logger.Errorf("TaskRun %q result files are invalid: %v", tr.Name, err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+		return nil, nil, controller.NewPermanentError(err)
+	}
+
 	if _, usesAssistant := tr.Annotations[workspace.AnnotationAffinityAssistantName]; usesAssistant {
 		if err := workspace.ValidateOnlyOnePVCIsUsed(tr.Spec.Workspaces); err != nil {
 			logger.Errorf("TaskRun %q workspaces incompatible with Affinity Assistant: %v", tr.Name, err)
@@ -466,7 +595,7 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	// Please note that this block is required to run before `applyParamsContextsResultsAndWorkspaces` is called the first time,
 	// and that `applyParamsContextsResultsAndWorkspaces` _must_ be called on every reconcile.
 	if pod == nil && tr.HasVolumeClaimTemplate() {
-		if err := c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
+		if err := c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), volumeclaim.WorkspacePoolConsumer(tr.Labels), tr.Namespace); err != nil {
 			logger.Errorf("Failed to create PVC for TaskRun %s: %v", tr.Name, err)
 			tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
 				fmt.Errorf("Failed to create PVC for TaskRun %s workspaces correctly: %w",
@@ -474,7 +603,13 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 			return controller.NewPermanentError(err)
 		}
 
-		taskRunWorkspaces := applyVolumeClaimTemplates(tr.Spec.Workspaces, *kmeta.NewControllerRef(tr))
+		pooledClaimNames, err := c.pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, *kmeta.NewControllerRef(tr), tr.Namespace)
+		if err != nil {
+			logger.Errorf("Failed to get pooled PVCs for TaskRun %s: %v", tr.Name, err)
+			return err
+		}
+		taskRunWorkspaces := volumeclaim.ApplyPooledPersistentVolumeClaimNames(
+			applyVolumeClaimTemplates(tr.Spec.Workspaces, *kmeta.NewControllerRef(tr)), pooledClaimNames)
 		// This is used by createPod below. Changes to the Spec are not updated.
 		tr.Spec.Workspaces = taskRunWorkspaces
 	}
@@ -493,6 +628,16 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		logger.Debugf("set taskspec for %s/%s - script: %s", tr.Namespace, tr.Name, tr.Status.TaskSpec.Steps[0].Script)
 	}
 
+	if pod == nil && c.remainingPodStartDelay(ctx, tr) > 0 {
+		logger.Infof("Delaying the creation of the pod of TaskRun %s/%s with an injected fault", tr.Namespace, tr.Name)
+		return nil
+	}
+
+	if pod == nil && c.remainingRepeatDelay(tr) > 0 {
+		logger.Infof("Delaying the creation of the pod of the next run of TaskRun %s/%s", tr.Namespace, tr.Name)
+		return nil
+	}
+
 	if pod == nil {
 		pod, err = c.createPod(ctx, ts, tr, rtr, workspaceVolumes)
 		if err != nil {
@@ -500,6 +645,16 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 			logger.Errorf("Failed to create task run pod for taskrun %q: %v", tr.Name, newErr)
 			return newErr
 		}
+		if err := c.metrics.RecordTimeToFirstPod(ctx, pod, tr); err != nil {
+			logger.Warnf("Failed to log the metrics : %v", err)
+		}
+	}
+
+	if config.IsSpireEnabled(ctx) {
+		if err := c.registerSpireWorkload(ctx, tr, pod); err != nil {
+			logger.Errorf("Failed to register the SPIFFE identity of taskrun %q: %v", tr.Name, err)
+			return err
+		}
 	}
 
 	if podconvert.IsPodExceedingNodeResources(pod) {
@@ -515,11 +670,45 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 		}
 	}
 
+	if err := podconvert.UpdateDefaultContainer(ctx, c.KubeClientSet, tr, pod); err != nil {
+		logger.Warnf("Failed to update the default container of pod %s: %v", pod.Name, err)
+	}
+
 	// Convert the Pod's status to the equivalent TaskRun Status.
+	beforeSteps := tr.Status.Steps
 	tr.Status, err = podconvert.MakeTaskRunStatus(ctx, logger, *tr, pod, c.KubeClientSet, rtr.TaskSpec)
 	if err != nil {
 		return err
 	}
+	if err := c.metrics.RecordPodStartupLatency(ctx, pod, tr, beforeSteps); err != nil {
+		logger.Warnf("Failed to log the metrics : %v", err)
+	}
+
+	// The sidecars writing results or shut down gracefully are stopped before the TaskRun completes.
+	if podconvert.IsAwaitingSidecars(pod, rtr.TaskSpec) {
+		if _, err := podconvert.StopSidecars(ctx, c.Images.NopImage, c.KubeClientSet, tr.Namespace, pod.Name); err != nil {
+			return err
+		}
+	}
+
+	if recordsStepResourceUsage(ctx) {
+		c.recordStepResourceUsage(ctx, tr, pod)
+	}
+
+	if config.FromContextOrDefaults(ctx).FeatureFlags.EnableStepProgress {
+		c.recordStepProgress(ctx, tr)
+	}
+
+	if tr.IsDone() {
+		c.archiveStepLogs(ctx, tr)
+		if config.IsSpireEnabled(ctx) {
+			c.verifySpireResults(ctx, tr, pod)
+		}
+	}
+
+	if failed, message := c.injectStepFailure(ctx, tr); failed {
+		return c.failTaskRun(ctx, tr, v1beta1.TaskRunReasonFaultInjected, message)
+	}
 
 	if err := validateTaskRunResults(tr, rtr.TaskSpec); err != nil {
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -530,6 +719,268 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 	return nil
 }
 
+// archiveStepLogs archives the logs of the steps of the TaskRun pod to the object
+// store set in "step-log-store", once, and records where they are archived in the
+// TaskRun status. Failures to archive the logs are logged and don't fail the TaskRun.
+func (c *Reconciler) archiveStepLogs(ctx context.Context, tr *v1beta1.TaskRun) {
+	store := config.FromContextOrDefaults(ctx).FeatureFlags.StepLogStore
+	if store == "" || tr.Status.PodName == "" || len(tr.Status.StepLogs) > 0 {
+		return
+	}
+	stepLogs, err := podconvert.ArchiveStepLogs(ctx, c.KubeClientSet, http.DefaultClient, store, tr)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to archive the logs of the steps of TaskRun %s: %v", tr.Name, err)
+		return
+	}
+	tr.Status.StepLogs = stepLogs
+}
+
+// recordStepProgress reads the progress markers of the steps of the TaskRun pod, emits
+// an event for each change of their progress and reports it in the StepProgress
+// condition. Failures to read the markers are logged and don't fail the TaskRun.
+func (c *Reconciler) recordStepProgress(ctx context.Context, tr *v1beta1.TaskRun) {
+	if tr.Status.PodName == "" {
+		return
+	}
+	progress, err := podconvert.ReadStepProgress(ctx, c.KubeClientSet, tr)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to read the progress of the steps of TaskRun %s: %v", tr.Name, err)
+		return
+	}
+	previous := map[string]v1beta1.StepProgress{}
+	for _, p := range tr.Status.StepProgress {
+		previous[p.ContainerName] = p
+	}
+	recorder := controller.GetEventRecorder(ctx)
+	for _, p := range progress {
+		before := previous[p.ContainerName]
+		switch {
+		case p.Phase == v1beta1.StepProgressFinished && before.Phase != p.Phase:
+			recorder.Eventf(tr, corev1.EventTypeNormal, "StepFinished", "Step %q finished", p.Name)
+		case p.Percent != nil && (before.Percent == nil || *before.Percent != *p.Percent):
+			recorder.Eventf(tr, corev1.EventTypeNormal, "StepProgress", "Step %q is %d%% complete", p.Name, *p.Percent)
+		case before.Phase == "":
+			recorder.Eventf(tr, corev1.EventTypeNormal, "StepStarted", "Step %q started", p.Name)
+		}
+	}
+	tr.Status.StepProgress = progress
+	tr.Status.SetCondition(stepProgressCondition(tr))
+}
+
+// stepProgressCondition returns the StepProgress condition of the TaskRun: True once all
+// its steps have finished, False if it is done before, and Unknown while they run.
+func stepProgressCondition(tr *v1beta1.TaskRun) *apis.Condition {
+	finished := 0
+	running := ""
+	for _, p := range tr.Status.StepProgress {
+		switch {
+		case p.Phase == v1beta1.StepProgressFinished:
+			finished++
+		case p.Percent != nil:
+			running = fmt.Sprintf(", step %q is %d%% complete", p.Name, *p.Percent)
+		default:
+			running = fmt.Sprintf(", step %q is running", p.Name)
+		}
+	}
+	condition := &apis.Condition{
+		Type:    apis.ConditionType(v1beta1.TaskRunConditionStepProgress.String()),
+		Status:  corev1.ConditionUnknown,
+		Reason:  "Running",
+		Message: fmt.Sprintf("%d/%d steps finished%s", finished, len(tr.Status.Steps), running),
+	}
+	switch {
+	case finished == len(tr.Status.Steps):
+		condition.Status, condition.Reason = corev1.ConditionTrue, "Finished"
+	case tr.IsDone():
+		condition.Status, condition.Reason = corev1.ConditionFalse, "NotFinished"
+	}
+	return condition
+}
+
+// registerSpireWorkload registers the SPIFFE identity of the TaskRun pod with the SPIRE
+// server once the pod is scheduled on a node, so that the entrypoint can sign the results
+// with the SVID of the TaskRun. The SignedResultsVerified condition is Unknown from then
+// on until the results are verified.
+func (c *Reconciler) registerSpireWorkload(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) error {
+	if pod.Spec.NodeName == "" || tr.IsDone() || tr.Status.GetCondition(apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String())) != nil {
+		return nil
+	}
+	ttl := tr.GetTimeout(ctx)
+	if ttl == config.NoTimeoutDuration {
+		ttl = time.Duration(config.FromContextOrDefaults(ctx).Defaults.DefaultTimeoutMinutes) * time.Minute
+	}
+	if err := c.spireClient.CreateEntries(ctx, tr, pod, ttl); err != nil {
+		return fmt.Errorf("failed to create the SPIRE entries of pod %s: %w", pod.Name, err)
+	}
+	tr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()),
+		Status:  corev1.ConditionUnknown,
+		Reason:  v1beta1.AwaitingTaskRunResults.String(),
+		Message: "Waiting for the results signed with the SVID of the TaskRun",
+	})
+	return nil
+}
+
+// verifySpireResults verifies the signatures of the results of the TaskRun, once done,
+// against its SVID and reports the outcome in the SignedResultsVerified condition. The
+// PipelineRuns don't resolve references to the results of a TaskRun which aren't verified.
+// The SPIRE entries of the TaskRun pod are deleted afterwards.
+func (c *Reconciler) verifySpireResults(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) {
+	if tr.IsTaskRunResultDone() {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	condition := &apis.Condition{
+		Type:    apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()),
+		Status:  corev1.ConditionTrue,
+		Reason:  v1beta1.TaskRunReasonResultsVerified.String(),
+		Message: "The results are signed with the SVID of the TaskRun",
+	}
+	if len(tr.Status.TaskRunResults) == 0 {
+		condition.Message = "The TaskRun has no results"
+	} else if err := c.spireClient.VerifyTaskRunResults(ctx, spireRunResults(tr.Status.TaskRunResults), tr); err != nil {
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = v1beta1.TaskRunReasonsResultsVerificationFailed.String()
+		condition.Message = fmt.Sprintf("Failed to verify the signatures of the results: %v", err)
+		if recorder := controller.GetEventRecorder(ctx); recorder != nil {
+			recorder.Event(tr, corev1.EventTypeWarning, condition.Reason, condition.Message)
+		}
+	}
+	tr.Status.SetCondition(condition)
+
+	if err := c.spireClient.DeleteEntry(ctx, tr, pod); err != nil {
+		logger.Warnf("Failed to delete the SPIRE entries of pod %s of taskrun %q: %v", pod.Name, tr.Name, err)
+	}
+}
+
+// spireRunResults returns the results of the TaskRun as written by the entrypoint, which
+// signed them along with the SVID and the manifest of the results.
+func spireRunResults(results []v1beta1.TaskRunResult) []result.RunResult {
+	runResults := make([]result.RunResult, 0, len(results))
+	for _, r := range results {
+		value := r.Value.StringVal
+		if r.Value.Type != v1beta1.ParamTypeString {
+			if b, err := json.Marshal(r.Value); err == nil {
+				value = string(b)
+			}
+		}
+		runResults = append(runResults, result.RunResult{
+			Key:        r.Name,
+			Value:      value,
+			ResultType: result.TaskRunResultType,
+		})
+	}
+	return runResults
+}
+
+// faultPlan returns the faults injected in the current attempt of the TaskRun
+// when the "enable-fault-injection" feature flag is set. The seed of the faults
+// is recorded in the status of the TaskRun the first time it is called before
+// the TaskRun is done.
+func (c *Reconciler) faultPlan(ctx context.Context, tr *v1beta1.TaskRun) (faultinjection.Plan, bool) {
+	cfg := config.FromContextOrDefaults(ctx)
+	if !cfg.FeatureFlags.EnableFaultInjection || cfg.FaultInjection == nil {
+		return faultinjection.Plan{}, false
+	}
+	if tr.Status.FaultInjection == nil {
+		if tr.IsDone() {
+			return faultinjection.Plan{}, false
+		}
+		tr.Status.FaultInjection = &v1beta1.FaultInjectionStatus{Seed: faultinjection.NewSeed(cfg.FaultInjection, c.Clock.Now())}
+	}
+	steps := 0
+	if tr.Status.TaskSpec != nil {
+		steps = len(tr.Status.TaskSpec.Steps)
+	}
+	return faultinjection.NewPlan(cfg.FaultInjection, tr, steps), true
+}
+
+// remainingPodStartDelay returns the time left before the pod of the TaskRun is
+// created, when its creation is delayed by an injected fault.
+func (c *Reconciler) remainingPodStartDelay(ctx context.Context, tr *v1beta1.TaskRun) time.Duration {
+	plan, ok := c.faultPlan(ctx, tr)
+	if !ok || plan.PodStartDelay == 0 || tr.IsDone() || tr.Status.PodName != "" || tr.Status.StartTime == nil {
+		return 0
+	}
+	remaining := plan.PodStartDelay - c.Clock.Since(tr.Status.StartTime.Time)
+	if remaining > 0 {
+		faultinjection.Record(tr, plan, config.FaultPodStartDelay, fmt.Sprintf("pod start delayed by %s", plan.PodStartDelay))
+	}
+	return remaining
+}
+
+// remainingRepeatDelay returns the time left before the pod of the next run of a
+// repeated TaskRun is created, counted from the completion of its previous run.
+func (c *Reconciler) remainingRepeatDelay(tr *v1beta1.TaskRun) time.Duration {
+	if tr.Spec.RepeatUntil == nil || tr.IsDone() || tr.Status.PodName != "" || len(tr.Status.RetriesStatus) == 0 {
+		return 0
+	}
+	previous := tr.Status.RetriesStatus[len(tr.Status.RetriesStatus)-1]
+	if !previous.GetCondition(apis.ConditionSucceeded).IsTrue() || previous.CompletionTime == nil {
+		return 0
+	}
+	return tr.Spec.RepeatUntil.GetDelay() - c.Clock.Since(previous.CompletionTime.Time)
+}
+
+// injectStepFailure returns true, with a message, if an injected fault fails
+// the TaskRun because the step it fails has started.
+func (c *Reconciler) injectStepFailure(ctx context.Context, tr *v1beta1.TaskRun) (bool, string) {
+	plan, ok := c.faultPlan(ctx, tr)
+	if !ok || plan.FailedStep < 0 || tr.IsDone() || plan.FailedStep >= len(tr.Status.Steps) {
+		return false, ""
+	}
+	step := tr.Status.Steps[plan.FailedStep]
+	if step.Running == nil && step.Terminated == nil {
+		return false, ""
+	}
+	message := fmt.Sprintf("TaskRun %q failed because of a fault injected in step %q", tr.Name, step.Name)
+	faultinjection.Record(tr, plan, config.FaultStepFailure, message)
+	return true, message
+}
+
+// dropsEvents returns true if the events of the transition of the TaskRun to
+// afterCondition are dropped by an injected fault.
+func (c *Reconciler) dropsEvents(ctx context.Context, tr *v1beta1.TaskRun, beforeCondition, afterCondition *apis.Condition) bool {
+	if afterCondition == nil || equality.Semantic.DeepEqual(beforeCondition, afterCondition) {
+		return false
+	}
+	plan, ok := c.faultPlan(ctx, tr)
+	if !ok || !plan.DropsEvents(afterCondition.Reason) {
+		return false
+	}
+	faultinjection.Record(tr, plan, config.FaultDroppedEvents, fmt.Sprintf("events of the transition to %q dropped", afterCondition.Reason))
+	return true
+}
+
+// recordsStepResourceUsage returns whether the resource usage of the steps is recorded in the status of TaskRuns.
+func recordsStepResourceUsage(ctx context.Context) bool {
+	return config.FromContextOrDefaults(ctx).FeatureFlags.StepResourceUsageSource != config.StepResourceUsageSourceNone
+}
+
+// recordStepResourceUsage samples the resource usage of the steps while the pod runs, and
+// records the requests recommended for them in an annotation once the TaskRun is done.
+// Failures to read the usage are logged and don't fail the TaskRun.
+func (c *Reconciler) recordStepResourceUsage(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod) {
+	logger := logging.FromContext(ctx)
+	if pod.Status.Phase == corev1.PodRunning {
+		sample, err := c.resourceUsageReader.ContainerUsage(ctx, pod)
+		if err != nil {
+			logger.Warnf("Failed to read the resource usage of pod %s: %v", pod.Name, err)
+		} else {
+			tr.Status.StepResourceUsage = podconvert.UpdateStepResourceUsage(tr.Status.StepResourceUsage, sample)
+		}
+	}
+	if !tr.IsDone() || len(tr.Status.StepResourceUsage) == 0 {
+		return
+	}
+	recommendations, err := podconvert.StepResourceRecommendations(tr.Status.StepResourceUsage)
+	if err != nil {
+		logger.Warnf("Failed to compute the resource recommendations of TaskRun %s: %v", tr.Name, err)
+		return
+	}
+	metav1.SetMetaDataAnnotation(&tr.ObjectMeta, podconvert.ResourceRecommendationsAnnotation, recommendations)
+}
+
 func (c *Reconciler) updateTaskRunWithDefaultWorkspaces(ctx context.Context, tr *v1beta1.TaskRun, taskSpec *v1beta1.TaskSpec) error {
 	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "updateTaskRunWithDefaultWorkspaces")
 	defer span.End()
@@ -604,6 +1055,9 @@ func (c *Reconciler) handlePodCreationError(tr *v1beta1.TaskRun, err error) erro
 		tr.Status.StartTime = nil
 		tr.Status.MarkResourceOngoing(podconvert.ReasonExceededResourceQuota, fmt.Sprint("TaskRun Pod exceeded available resources: ", err))
 		return controller.NewRequeueAfter(time.Minute)
+	case errors.Is(err, trustedresources.ErrImageVerificationFailed):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonImageVerificationFailed, err)
 	case isTaskRunValidationFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
 	case k8serrors.IsAlreadyExists(err):
@@ -647,6 +1101,9 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 		return nil
 	}
 
+	// The logs of the steps are lost once the pod is deleted.
+	c.archiveStepLogs(ctx, tr)
+
 	// tr.Status.PodName will be empty if the pod was never successfully created. This condition
 	// can be reached, for example, by the pod never being schedulable due to limits imposed by
 	// a namespace's ResourceQuota.
@@ -702,6 +1159,9 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 		return nil, validateErr
 	}
 
+	// The secret params are mounted under /tekton/, where the volumeMounts of the steps can't be.
+	ts = resources.MountSecretParams(ts, tr)
+
 	var err error
 	ts, err = workspace.Apply(ctx, *ts, tr.Spec.Workspaces, workspaceVolumes)
 
@@ -713,6 +1173,13 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 	// Apply path substitutions for the legacy credentials helper (aka "creds-init")
 	ts = resources.ApplyCredentialsPath(ts, pipeline.CredsDir)
 
+	if len(tr.Spec.ResultFiles) > 0 {
+		if err := c.createResultFilesConfigMap(ctx, tr); err != nil {
+			logger.Errorf("Failed to create the ConfigMap of the result files of taskrun %s: %v", tr.Name, err)
+			return nil, err
+		}
+	}
+
 	podbuilder := podconvert.Builder{
 		Images:          c.Images,
 		KubeClient:      c.KubeClientSet,
@@ -721,11 +1188,19 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 	pod, err := podbuilder.Build(ctx, tr, *ts,
 		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister),
 		affinityassistant.NewTransformer(ctx, tr.Annotations),
+		tracing.NewTransformer(c.tracerProvider, tr.Status.SpanContext),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("translating TaskSpec to Pod: %w", err)
 	}
 
+	if config.FromContextOrDefaults(ctx).FeatureFlags.VerifyStepImageSignatures {
+		if err := c.verifyPodImages(ctx, tr, pod); err != nil {
+			logger.Errorf("Failed to create a pod for taskrun: %s due to image verification error %v", tr.Name, err)
+			return nil, err
+		}
+	}
+
 	// Stash the podname in case there's create conflict so that we can try
 	// to fetch it.
 	podName := pod.Name
@@ -747,6 +1222,19 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 	return pod, err
 }
 
+// createResultFilesConfigMap creates the ConfigMap holding the values of the result files of
+// the TaskRun, which is mounted in its steps.
+func (c *Reconciler) createResultFilesConfigMap(ctx context.Context, tr *v1beta1.TaskRun) error {
+	cm := podconvert.ResultFilesConfigMap(tr)
+	_, err := c.KubeClientSet.CoreV1().ConfigMaps(tr.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		// The ConfigMap was created by a previous attempt to create the Pod, the values of
+		// the result files of a TaskRun don't change.
+		return nil
+	}
+	return err
+}
+
 // applyParamsContextsResultsAndWorkspaces applies paramater, context, results and workspace substitutions to the TaskSpec.
 func applyParamsContextsResultsAndWorkspaces(ctx context.Context, tr *v1beta1.TaskRun, rtr *resources.ResolvedTask, workspaceVolumes map[string]corev1.Volume) (*v1beta1.TaskSpec, error) {
 	ts := rtr.TaskSpec.DeepCopy()
@@ -754,6 +1242,10 @@ func applyParamsContextsResultsAndWorkspaces(ctx context.Context, tr *v1beta1.Ta
 	if len(ts.Params) > 0 {
 		defaults = append(defaults, ts.Params...)
 	}
+	// Replace the references to the secret params by the paths of their files, mounted once
+	// the TaskSpec is validated.
+	ts = resources.ApplySecretParams(ts)
+
 	// Apply parameter substitution from the taskrun.
 	ts = resources.ApplyParameters(ctx, ts, tr, defaults...)
 
@@ -897,6 +1389,17 @@ func storeTaskSpecAndMergeMeta(ctx context.Context, tr *v1beta1.TaskRun, ts *v1b
 		if meta != nil && meta.RefSource != nil && tr.Status.Provenance.ConfigSource == nil {
 			tr.Status.Provenance.ConfigSource = (*v1beta1.ConfigSource)(meta.RefSource)
 		}
+		// Record whether the pod of the TaskRun is built hermetic, by its spec or its annotation.
+		tr.Status.Provenance.Hermetic = podconvert.IsHermetic(ctx, tr)
+		// Sign the TaskSpec as resolved, before the parameters of the TaskRun are substituted,
+		// so that audits can prove which definition the TaskRun executed.
+		if cfg.FeatureFlags.ProvenanceSigningKey != "" && ts != nil && tr.Status.Provenance.SpecAttestation == nil {
+			attestation, err := trustedresources.AttestSpec(ctx, ts, cfg.FeatureFlags.ProvenanceSigningKey)
+			if err != nil {
+				return fmt.Errorf("failed to attest the TaskSpec: %w", err)
+			}
+			tr.Status.Provenance.SpecAttestation = attestation
+		}
 	}
 
 	return nil
@@ -929,12 +1432,30 @@ func isResourceQuotaConflictError(err error) bool {
 // retryTaskRun archives taskRun.Status to taskRun.Status.RetriesStatus, and set
 // taskRun status to Unknown with Reason v1beta1.TaskRunReasonToBeRetried.
 func retryTaskRun(tr *v1beta1.TaskRun, message string) {
+	rerunTaskRun(tr, v1beta1.TaskRunReasonToBeRetried, message)
+}
+
+// repeatTaskRun archives taskRun.Status to taskRun.Status.RetriesStatus, and set
+// taskRun status to Unknown with Reason v1beta1.TaskRunReasonToBeRepeated. The
+// results of the archived run are cleared, so that the condition of the repetitions
+// is evaluated against the results of the next run.
+func repeatTaskRun(tr *v1beta1.TaskRun, message string) {
+	rerunTaskRun(tr, v1beta1.TaskRunReasonToBeRepeated, message)
+	tr.Status.TaskRunResults = nil
+}
+
+func rerunTaskRun(tr *v1beta1.TaskRun, reason v1beta1.TaskRunReason, message string) {
 	newStatus := tr.Status.DeepCopy()
 	newStatus.RetriesStatus = nil
+	newStatus.FaultInjection = nil
 	tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, *newStatus)
 	tr.Status.StartTime = nil
 	tr.Status.CompletionTime = nil
 	tr.Status.PodName = ""
+	tr.Status.StepLogs = nil
+	tr.Status.StepProgress = nil
 	taskRunCondSet := apis.NewBatchConditionSet()
-	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, v1beta1.TaskRunReasonToBeRetried.String(), message)
+	_ = taskRunCondSet.Manage(&tr.Status).ClearCondition(apis.ConditionType(v1beta1.TaskRunConditionStepProgress.String()))
+	_ = taskRunCondSet.Manage(&tr.Status).ClearCondition(apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String()))
+	taskRunCondSet.Manage(&tr.Status).MarkUnknown(apis.ConditionSucceeded, reason.String(), message)
 }
@@ -18,9 +18,14 @@ package taskrun
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
@@ -33,9 +38,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/sigstore/sigstore/pkg/signature"
+	fakekms "github.com/sigstore/sigstore/pkg/signature/kms/fake"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resolutionv1beta1 "github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
 	resolutionutil "github.com/tektoncd/pipeline/pkg/internal/resolution"
@@ -47,6 +55,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
 	remoteresource "github.com/tektoncd/pipeline/pkg/resolution/resource"
+	"github.com/tektoncd/pipeline/pkg/result"
+	"github.com/tektoncd/pipeline/pkg/spire"
 	"github.com/tektoncd/pipeline/pkg/trustedresources"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	"github.com/tektoncd/pipeline/test"
@@ -67,6 +77,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	clock "k8s.io/utils/clock/testing"
 	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 	cminformer "knative.dev/pkg/configmap/informer"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmeta"
@@ -1853,6 +1864,146 @@ status:
 	}
 }
 
+func TestReconcileRepeatUntil(t *testing.T) {
+	taskRun := func(conditionStatus, reason, status string, repeats int) *v1beta1.TaskRun {
+		tr := parse.MustParseV1beta1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun-repeat
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  repeatUntil:
+    when:
+    - input: $(results.status)
+      operator: in
+      values: ["ready"]
+    delay: 1h
+    limit: 2
+status:
+  startTime: "2021-12-31T23:59:00Z"
+  completionTime: "2021-12-31T23:59:59Z"
+  conditions:
+  - reason: %s
+    status: %q
+    type: Succeeded
+  taskResults:
+  - name: status
+    type: string
+    value: %s
+`, reason, conditionStatus, status))
+		for i := 0; i < repeats; i++ {
+			tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, v1beta1.TaskRunStatus{
+				Status: duckv1.Status{Conditions: duckv1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+					Reason: v1beta1.TaskRunReasonSuccessful.String(),
+				}}},
+			})
+		}
+		return tr
+	}
+
+	for _, tc := range []struct {
+		name        string
+		tr          *v1beta1.TaskRun
+		wantReason  v1beta1.TaskRunReason
+		wantRepeats int
+		wantResults bool
+	}{{
+		name:        "condition passes",
+		tr:          taskRun("True", "Succeeded", "ready", 0),
+		wantReason:  v1beta1.TaskRunReasonSuccessful,
+		wantResults: true,
+	}, {
+		name:        "condition doesn't pass",
+		tr:          taskRun("True", "Succeeded", "pending", 0),
+		wantReason:  v1beta1.TaskRunReasonToBeRepeated,
+		wantRepeats: 1,
+	}, {
+		name:        "limit exceeded",
+		tr:          taskRun("True", "Succeeded", "pending", 2),
+		wantReason:  v1beta1.TaskRunReasonRepeatLimitExceeded,
+		wantRepeats: 2,
+		wantResults: true,
+	}, {
+		name:        "failed runs are not repeated",
+		tr:          taskRun("False", "Failed", "pending", 0),
+		wantReason:  v1beta1.TaskRunReasonFailed,
+		wantResults: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			testAssets, cancel := getTaskRunController(t, test.Data{
+				TaskRuns: []*v1beta1.TaskRun{tc.tr},
+				Tasks:    []*v1beta1.Task{simpleTask},
+			})
+			defer cancel()
+			createServiceAccount(t, testAssets, "default", tc.tr.Namespace)
+
+			if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tc.tr)); err != nil {
+				if ok, _ := controller.IsRequeueKey(err); !ok {
+					t.Fatalf("Reconcile(): %v", err)
+				}
+			}
+			reconciledTaskRun, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns("foo").Get(testAssets.Ctx, tc.tr.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("got %v; want nil", err)
+			}
+			if reason := reconciledTaskRun.Status.GetCondition(apis.ConditionSucceeded).Reason; reason != tc.wantReason.String() {
+				t.Errorf("expected the reason %q but got %q", tc.wantReason, reason)
+			}
+			if repeats := reconciledTaskRun.RepeatCount(); repeats != tc.wantRepeats {
+				t.Errorf("expected %d repeats but got %d", tc.wantRepeats, repeats)
+			}
+			if hasResults := len(reconciledTaskRun.Status.TaskRunResults) > 0; hasResults != tc.wantResults {
+				t.Errorf("expected the results to be kept: %t, but got %v", tc.wantResults, reconciledTaskRun.Status.TaskRunResults)
+			}
+		})
+	}
+}
+
+func TestRemainingRepeatDelay(t *testing.T) {
+	c := &Reconciler{Clock: testClock}
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-repeat
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  repeatUntil:
+    when:
+    - input: $(results.status)
+      operator: in
+      values: ["ready"]
+    limit: 2
+status:
+  conditions:
+  - reason: ToBeRepeated
+    status: Unknown
+    type: Succeeded
+`)
+	if delay := c.remainingRepeatDelay(tr); delay != 0 {
+		t.Errorf("expected no delay before the first run but got %s", delay)
+	}
+	tr.Status.RetriesStatus = []v1beta1.TaskRunStatus{{
+		Status: duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:   apis.ConditionSucceeded,
+			Status: corev1.ConditionTrue,
+		}}},
+		TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			CompletionTime: &metav1.Time{Time: now.Add(-4 * time.Second)},
+		},
+	}}
+	if delay := c.remainingRepeatDelay(tr); delay != v1beta1.DefaultRepeatDelay-4*time.Second {
+		t.Errorf("expected the next run to be delayed by %s but got %s", v1beta1.DefaultRepeatDelay-4*time.Second, delay)
+	}
+	tr.Status.PodName = "test-taskrun-repeat-pod-retry1"
+	if delay := c.remainingRepeatDelay(tr); delay != 0 {
+		t.Errorf("expected no delay once the pod of the next run is created but got %s", delay)
+	}
+}
+
 func TestReconcileGetTaskError(t *testing.T) {
 	tr := parse.MustParseV1beta1TaskRun(t, `
 metadata:
@@ -2653,7 +2804,7 @@ spec:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PersistentVolumeClaim.Lister(), testAssets.Informers.WorkspacePool.Lister(), testAssets.Logger),
 		tracerProvider:    trace.NewNoopTracerProvider(),
 	}
 
@@ -2762,7 +2913,7 @@ spec:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PersistentVolumeClaim.Lister(), testAssets.Informers.WorkspacePool.Lister(), testAssets.Logger),
 		tracerProvider:    trace.NewNoopTracerProvider(),
 	}
 
@@ -2815,7 +2966,7 @@ status:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PersistentVolumeClaim.Lister(), testAssets.Informers.WorkspacePool.Lister(), testAssets.Logger),
 		tracerProvider:    trace.NewNoopTracerProvider(),
 	}
 
@@ -2849,6 +3000,12 @@ status:
 		expectedType:   apis.ConditionSucceeded,
 		expectedStatus: corev1.ConditionFalse,
 		expectedReason: podconvert.ReasonPodCreationFailed,
+	}, {
+		description:    "image verification errors fail the taskrun",
+		err:            fmt.Errorf("%w: image gcr.io/foo/bar@sha256:abc fails verification against policy vp", trustedresources.ErrImageVerificationFailed),
+		expectedType:   apis.ConditionSucceeded,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: podconvert.ReasonImageVerificationFailed,
 	}, {
 		description: "errors violating PodSecurity fail the taskrun",
 		err: k8sapierrors.NewForbidden(k8sruntimeschema.GroupResource{Group: "foo", Resource: "bar"}, "baz",
@@ -3743,7 +3900,7 @@ status:
 				cloudEventClient:  testAssets.Clients.CloudEvents,
 				metrics:           nil, // Not used
 				entrypointCache:   nil, // Not used
-				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PersistentVolumeClaim.Lister(), testAssets.Informers.WorkspacePool.Lister(), testAssets.Logger),
 				tracerProvider:    trace.NewNoopTracerProvider(),
 			}
 
@@ -3897,6 +4054,46 @@ func Test_storeTaskSpec_metadata(t *testing.T) {
 	}
 }
 
+func Test_storeTaskSpec_SpecAttestation(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the key: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), fakekms.KmsCtxKey{}, crypto.PrivateKey(key))
+	ctx = config.ToContext(ctx, &config.Config{FeatureFlags: &config.FeatureFlags{
+		EnableProvenanceInStatus: true,
+		ProvenanceSigningKey:     "fakekms://taskrun",
+	}})
+	verifier, err := signature.LoadVerifier(key.Public(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to load the verifier: %v", err)
+	}
+
+	ts := &v1beta1.TaskSpec{
+		Params: []v1beta1.ParamSpec{{Name: "url", Type: v1beta1.ParamTypeString}},
+		Steps:  []v1beta1.Step{{Name: "fetch", Image: "curlimages/curl", Script: "curl $(params.url)"}},
+	}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	if err := storeTaskSpecAndMergeMeta(ctx, tr, ts, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{Name: "bar"}}); err != nil {
+		t.Fatalf("storeTaskSpecAndMergeMeta error = %v", err)
+	}
+	attestation := tr.Status.Provenance.SpecAttestation
+	if attestation == nil {
+		t.Fatal("expected the TaskSpec to be attested in the provenance")
+	}
+	if err := trustedresources.VerifySpecAttestation(ts, attestation, verifier); err != nil {
+		t.Errorf("expected the attestation of the resolved TaskSpec, got %v", err)
+	}
+
+	// The attestation is recorded once.
+	if err := storeTaskSpecAndMergeMeta(ctx, tr, &v1beta1.TaskSpec{}, &resolutionutil.ResolvedObjectMeta{ObjectMeta: &metav1.ObjectMeta{Name: "bar"}}); err != nil {
+		t.Fatalf("storeTaskSpecAndMergeMeta error = %v", err)
+	}
+	if d := cmp.Diff(attestation, tr.Status.Provenance.SpecAttestation); d != "" {
+		t.Errorf("expected the attestation not to change %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestWillOverwritePodAffinity(t *testing.T) {
 	affinity := &corev1.Affinity{
 		PodAffinity: &corev1.PodAffinity{
@@ -4093,6 +4290,81 @@ status:
 	}
 }
 
+func TestReconcile_SLSAProvenance(t *testing.T) {
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-slsa
+  namespace: foo
+  uid: 6e8b1c4d
+spec:
+  params:
+  - name: url
+    value: https://github.com/tektoncd/pipeline
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  startTime: "2000-01-01T01:01:01Z"
+  completionTime: "2000-01-01T01:02:01Z"
+  steps:
+  - name: build
+    imageID: docker-pullable://docker.io/library/golang@sha256:05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5
+  taskResults:
+  - name: IMAGE_URL
+    type: string
+    value: gcr.io/foo/bar
+  - name: IMAGE_DIGEST
+    type: string
+    value: sha256:2b8a8eb1b7b0e6e8bd94d27dc6e1cbe5f0a08e3dfb03b2f52d8b6d3b4f2e6c11
+  provenance:
+    featureFlags:
+      enableProvenanceInStatus: true
+`)
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-provenance-in-status": "true",
+				"slsa-builder-id":             "https://tekton.dev/pipelines/controller",
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err != nil {
+		t.Fatalf("Expected no error to be returned by reconciler: %v", err)
+	}
+	reconciledRun, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	p := reconciledRun.Status.Provenance.SLSA
+	if p == nil {
+		t.Fatal("expected the SLSA provenance to be generated for the done TaskRun")
+	}
+	if p.Predicate.RunDetails.Builder.ID != "https://tekton.dev/pipelines/controller" {
+		t.Errorf("expected the builder id of the feature flag, got %q", p.Predicate.RunDetails.Builder.ID)
+	}
+	if p.Predicate.RunDetails.Metadata.InvocationID != "6e8b1c4d" {
+		t.Errorf("expected the UID of the TaskRun as the invocation id, got %q", p.Predicate.RunDetails.Metadata.InvocationID)
+	}
+	wantSubject := []v1beta1.SLSAResourceDescriptor{{
+		Name:   "gcr.io/foo/bar",
+		Digest: map[string]string{"sha256": "2b8a8eb1b7b0e6e8bd94d27dc6e1cbe5f0a08e3dfb03b2f52d8b6d3b4f2e6c11"},
+	}}
+	if d := cmp.Diff(wantSubject, p.Subject); d != "" {
+		t.Errorf("Unexpected subject %s", diff.PrintWantGot(d))
+	}
+	wantDependencies := []v1beta1.SLSAResourceDescriptor{{
+		URI:    "oci://docker.io/library/golang",
+		Digest: map[string]string{"sha256": "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"},
+	}}
+	if d := cmp.Diff(wantDependencies, p.Predicate.BuildDefinition.ResolvedDependencies); d != "" {
+		t.Errorf("Unexpected resolved dependencies %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestStopSidecars_WithInjectedSidecarsNoTaskSpecSidecars(t *testing.T) {
 	taskRun := parse.MustParseV1beta1TaskRun(t, `
 metadata:
@@ -4488,6 +4760,11 @@ func expectedPod(podName, taskName, taskRunName, ns, saName string, isClusterTas
 		},
 	}
 
+	if len(stepNames) > 0 {
+		p.Annotations[podconvert.DefaultContainerAnnotation] = stepNames[0]
+		p.Annotations[podconvert.StepOrderAnnotation] = strings.Join(stepNames, ",")
+	}
+
 	for idx, s := range steps {
 		p.Spec.Volumes = append(p.Spec.Volumes, corev1.Volume{
 			Name:         fmt.Sprintf("tekton-creds-init-home-%d", idx),
@@ -4898,6 +5175,290 @@ status:
 	}
 }
 
+func TestReconcile_ParamValueSources(t *testing.T) {
+	task := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: test-task-with-param-value-sources
+  namespace: foo
+spec:
+  params:
+  - name: branch
+    type: string
+  - name: token
+    type: secret
+  steps:
+  - script: echo $(params.branch) $(params.token)
+    image: myimage
+    name: mycontainer
+`)
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-with-param-value-sources
+  namespace: foo
+spec:
+  params:
+  - name: branch
+    valueFrom:
+      configMapKeyRef:
+        name: build-config
+        key: branch
+  - name: token
+    valueFrom:
+      secretKeyRef:
+        name: creds
+        key: token
+  taskRef:
+    name: test-task-with-param-value-sources
+`)
+	// The params taking their values from ConfigMaps and Secrets are typed by the webhook.
+	tr.SetDefaults(context.Background())
+
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		Tasks:    []*v1beta1.Task{task},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}, {
+			ObjectMeta: metav1.ObjectMeta{Namespace: "foo", Name: "build-config"},
+			Data:       map[string]string{"branch": "main"},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", tr.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+
+	updatedTR, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	wantResolved := v1beta1.Params{{Name: "branch", Value: *v1beta1.NewStructuredValues("main")}}
+	if d := cmp.Diff(wantResolved, updatedTR.Status.ResolvedParams); d != "" {
+		t.Errorf("expected Status.ResolvedParams to match, but differed: %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff("echo main /tekton/secret-params/token/value", updatedTR.Status.TaskSpec.Steps[0].Script); d != "" {
+		t.Errorf("expected the script of the step to match, but differed: %s", diff.PrintWantGot(d))
+	}
+	for _, action := range testAssets.Clients.Kube.Actions() {
+		if action.GetResource().Resource == "secrets" {
+			t.Errorf("expected the Secrets not to be read, but got the action %v", action)
+		}
+	}
+}
+
+func TestReconcile_ResultFiles(t *testing.T) {
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-with-result-files
+  namespace: foo
+spec:
+  resultFiles:
+  - path: /inputs/config.json
+    value: '{"replicas": 3}'
+  taskSpec:
+    steps:
+    - script: cat /inputs/config.json
+      image: myimage
+      name: mycontainer
+`)
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", tr.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error. Got error %v", err)
+	}
+
+	cm, err := testAssets.Clients.Kube.CoreV1().ConfigMaps(tr.Namespace).Get(testAssets.Ctx, "test-taskrun-with-result-files-result-files", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting the ConfigMap of the result files: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"result-file-0": `{"replicas": 3}`}, cm.Data); d != "" {
+		t.Errorf("expected the values of the result files to match, but differed: %s", diff.PrintWantGot(d))
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].Name != tr.Name {
+		t.Errorf("expected the ConfigMap to be owned by the TaskRun, got %v", cm.OwnerReferences)
+	}
+}
+
+func TestReconcile_ResultFilesMountPathCollision(t *testing.T) {
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-with-colliding-result-files
+  namespace: foo
+spec:
+  resultFiles:
+  - path: /workspace/source/config.json
+    value: '{"replicas": 3}'
+  workspaces:
+  - name: source
+    emptyDir: {}
+  taskSpec:
+    workspaces:
+    - name: source
+    steps:
+    - script: cat /workspace/source/config.json
+      image: myimage
+      name: mycontainer
+`)
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, "default", tr.Namespace)
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr)); !controller.IsPermanentError(err) {
+		t.Errorf("expected a permanent error, got %v", err)
+	}
+	updatedTR, err := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	condition := updatedTR.Status.GetCondition(apis.ConditionSucceeded)
+	if condition == nil || condition.Reason != podconvert.ReasonFailedValidation {
+		t.Errorf("expected the TaskRun to fail with %s, got %v", podconvert.ReasonFailedValidation, condition)
+	}
+}
+
+func TestReconcile_ServiceAccountPolicy(t *testing.T) {
+	task := parse.MustParseV1beta1Task(t, `
+metadata:
+  name: kaniko
+  namespace: foo
+spec:
+  steps:
+  - image: myimage
+    name: build
+    command: ['/mycmd']
+`)
+	saps := []*v1alpha1.ServiceAccountPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "foo"},
+		Spec: v1alpha1.ServiceAccountPolicySpec{
+			Tasks:           []v1alpha1.ResourcePattern{{Pattern: "^kaniko$"}},
+			ServiceAccounts: []string{"builder"},
+		},
+	}}
+	pr := parse.MustParseV1beta1PipelineRun(t, `
+metadata:
+  name: release
+  namespace: foo
+  uid: release-uid
+spec:
+  pipelineRef:
+    name: release
+  serviceAccountName: deployer
+`)
+
+	for _, tc := range []struct {
+		desc       string
+		tr         *v1beta1.TaskRun
+		wantFailed bool
+	}{{
+		desc: "allowed for the task",
+		tr: parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  serviceAccountName: builder
+  taskRef:
+    name: kaniko
+`),
+	}, {
+		desc: "not allowed for the task",
+		tr: parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  serviceAccountName: deployer
+  taskRef:
+    name: kaniko
+`),
+		wantFailed: true,
+	}, {
+		desc: "service account of the PipelineRun",
+		tr: parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun
+  namespace: foo
+  ownerReferences:
+  - apiVersion: tekton.dev/v1beta1
+    kind: PipelineRun
+    name: release
+    uid: release-uid
+    controller: true
+spec:
+  serviceAccountName: deployer
+  taskRef:
+    name: kaniko
+`),
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := test.Data{
+				TaskRuns:               []*v1beta1.TaskRun{tc.tr},
+				Tasks:                  []*v1beta1.Task{task},
+				PipelineRuns:           []*v1beta1.PipelineRun{pr},
+				ServiceAccountPolicies: saps,
+			}
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			createServiceAccount(t, testAssets, tc.tr.Spec.ServiceAccountName, tc.tr.Namespace)
+
+			err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tc.tr))
+			reconciledRun, getErr := testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tc.tr.Namespace).Get(testAssets.Ctx, tc.tr.Name, metav1.GetOptions{})
+			if getErr != nil {
+				t.Fatalf("getting updated taskrun: %v", getErr)
+			}
+			if tc.wantFailed {
+				if !controller.IsPermanentError(err) {
+					t.Errorf("expected a permanent error but got %v", err)
+				}
+				condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+				if condition == nil || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonServiceAccountNotAllowed {
+					t.Errorf("expected the TaskRun to fail with reason %s but got condition %v", podconvert.ReasonServiceAccountNotAllowed, condition)
+				}
+				if reconciledRun.Status.PodName != "" {
+					t.Errorf("expected no pod to be created but got %s", reconciledRun.Status.PodName)
+				}
+				return
+			}
+			if ok, _ := controller.IsRequeueKey(err); !ok {
+				t.Errorf("expected a requeue error but got %v", err)
+			}
+			if reconciledRun.Status.PodName == "" {
+				t.Error("expected a pod to be created")
+			}
+		})
+	}
+}
+
 func TestReconcile_verifyResolvedTask_Success(t *testing.T) {
 	resolverName := "foobar"
 	ts := parse.MustParseV1beta1Task(t, `
@@ -5082,6 +5643,66 @@ status:
 	}
 }
 
+func TestReconcile_verifyStepImages_Error(t *testing.T) {
+	// Set up a fake registry to push an unsigned image to.
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := test.CreateImage(u.Host+"/unsigned", simpleTypedTask)
+	if err != nil {
+		t.Fatalf("failed to upload image: %s", err.Error())
+	}
+
+	tr := parse.MustParseV1beta1TaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun
+  namespace: foo
+spec:
+  taskSpec:
+    steps:
+    - command:
+      - /mycmd
+      image: %s
+      name: mycontainer
+`, ref))
+	_, _, vps := test.SetupMatchAllVerificationPolicies(t, tr.Namespace)
+	for _, vp := range vps {
+		vp.Spec.Kind = v1alpha1.PolicyKindImages
+	}
+	d := test.Data{
+		TaskRuns: []*v1beta1.TaskRun{tr},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+			Data: map[string]string{
+				"verify-step-image-signatures": "true",
+			},
+		}},
+		VerificationPolicies: vps,
+	}
+
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	createServiceAccount(t, testAssets, tr.Spec.ServiceAccountName, tr.Namespace)
+	err = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(tr))
+	if !errors.Is(err, trustedresources.ErrImageVerificationFailed) {
+		t.Errorf("Reconcile got %v but want %v", err, trustedresources.ErrImageVerificationFailed)
+	}
+	tr, err = testAssets.Clients.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Get(testAssets.Ctx, tr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated taskrun: %v", err)
+	}
+	condition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.Type != apis.ConditionSucceeded || condition.Status != corev1.ConditionFalse || condition.Reason != podconvert.ReasonImageVerificationFailed {
+		t.Errorf("Expected TaskRun to fail with reason \"%s\" but it did not. Final conditions were:\n%#v", podconvert.ReasonImageVerificationFailed, tr.Status.Conditions)
+	}
+	if tr.Status.PodName != "" {
+		t.Errorf("Expected no pod to be created but got %q", tr.Status.PodName)
+	}
+}
+
 // getResolvedResolutionRequest is a helper function to return the ResolutionRequest and the data is filled with resourceBytes,
 // the ResolutionRequest's name is generated by resolverName, namespace and runName.
 func getResolvedResolutionRequest(t *testing.T, resolverName string, resourceBytes []byte, namespace string, runName string) resolutionv1beta1.ResolutionRequest {
@@ -5101,3 +5722,343 @@ func getResolvedResolutionRequest(t *testing.T, resolverName string, resourceByt
 	rr.Status.MarkSucceeded()
 	return rr
 }
+
+// fakeResourceUsageReader returns the next of its samples at each read.
+type fakeResourceUsageReader struct {
+	samples []map[string]corev1.ResourceList
+}
+
+func (r *fakeResourceUsageReader) ContainerUsage(_ context.Context, _ *corev1.Pod) (map[string]corev1.ResourceList, error) {
+	if len(r.samples) == 0 {
+		return nil, errors.New("no more samples")
+	}
+	sample := r.samples[0]
+	r.samples = r.samples[1:]
+	return sample, nil
+}
+
+func TestRecordStepResourceUsage(t *testing.T) {
+	c := &Reconciler{
+		resourceUsageReader: &fakeResourceUsageReader{samples: []map[string]corev1.ResourceList{{
+			"step-build": {corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("100Mi")},
+		}, {
+			"step-build": {corev1.ResourceCPU: resource.MustParse("250m"), corev1.ResourceMemory: resource.MustParse("200Mi")},
+		}}},
+	}
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-resource-usage
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+`)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-resource-usage-pod", Namespace: "foo"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	ctx := context.Background()
+
+	c.recordStepResourceUsage(ctx, tr, pod)
+	c.recordStepResourceUsage(ctx, tr, pod)
+	if _, ok := tr.Annotations[podconvert.ResourceRecommendationsAnnotation]; ok {
+		t.Errorf("expected no recommendations while the TaskRun runs but got %v", tr.Annotations)
+	}
+	wantUsage := []v1beta1.StepResourceUsage{{
+		Name:          "build",
+		ContainerName: "step-build",
+		Peak:          corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("200Mi")},
+	}}
+	if d := cmp.Diff(wantUsage, tr.Status.StepResourceUsage, resourceQuantityCmp); d != "" {
+		t.Errorf("StepResourceUsage %s", diff.PrintWantGot(d))
+	}
+
+	// The pod is done: the usage is not read anymore, and the recommendations are recorded.
+	pod.Status.Phase = corev1.PodSucceeded
+	tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	c.recordStepResourceUsage(ctx, tr, pod)
+	want := `{"build":{"cpu":"600m","memory":"240Mi"}}`
+	if d := cmp.Diff(want, tr.Annotations[podconvert.ResourceRecommendationsAnnotation]); d != "" {
+		t.Errorf("recommendations %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	seed := int64(42)
+	cfg := &config.Config{
+		FeatureFlags: &config.FeatureFlags{EnableFaultInjection: true},
+		FaultInjection: &config.FaultInjection{
+			Probability:      1,
+			Faults:           []string{config.FaultPodStartDelay, config.FaultStepFailure, config.FaultDroppedEvents},
+			MaxPodStartDelay: time.Minute,
+			Seed:             &seed,
+		},
+	}
+	ctx := config.ToContext(context.Background(), cfg)
+	c := &Reconciler{Clock: testClock}
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-fault-injection
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  taskSpec:
+    steps:
+    - name: build
+      image: foo
+`)
+	tr.Status.StartTime = &metav1.Time{Time: now}
+
+	delay := c.remainingPodStartDelay(ctx, tr)
+	if delay <= 0 || delay > time.Minute {
+		t.Errorf("expected the pod start to be delayed by up to a minute but got %s", delay)
+	}
+	if tr.Status.FaultInjection == nil || tr.Status.FaultInjection.Seed != seed {
+		t.Fatalf("expected the seed %d to be recorded but got %v", seed, tr.Status.FaultInjection)
+	}
+
+	if failed, _ := c.injectStepFailure(ctx, tr); failed {
+		t.Error("expected no step failure before the step has started")
+	}
+	tr.Status.Steps = []v1beta1.StepState{{
+		Name:           "build",
+		ContainerState: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}}
+	failed, message := c.injectStepFailure(ctx, tr)
+	if !failed {
+		t.Error("expected the started step to fail")
+	}
+
+	running := &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown, Reason: v1beta1.TaskRunReasonRunning.String()}
+	if c.dropsEvents(ctx, tr, running, running) {
+		t.Error("expected no events to be dropped without a transition")
+	}
+	if !c.dropsEvents(ctx, tr, nil, running) {
+		t.Error("expected the events of the transition to be dropped")
+	}
+
+	want := &v1beta1.FaultInjectionStatus{
+		Seed: seed,
+		Faults: []v1beta1.InjectedFault{{
+			Type:    config.FaultPodStartDelay,
+			Message: fmt.Sprintf("pod start delayed by %s", delay),
+		}, {
+			Type:    config.FaultStepFailure,
+			Message: message,
+		}, {
+			Type:    config.FaultDroppedEvents,
+			Message: `events of the transition to "Running" dropped`,
+		}},
+	}
+	if d := cmp.Diff(want, tr.Status.FaultInjection); d != "" {
+		t.Errorf("fault injection status %s", diff.PrintWantGot(d))
+	}
+
+	// The faults are not injected when the feature flag is not set.
+	tr.Status.FaultInjection = nil
+	ctx = config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{}, FaultInjection: cfg.FaultInjection})
+	if c.remainingPodStartDelay(ctx, tr) != 0 || c.dropsEvents(ctx, tr, nil, running) {
+		t.Error("expected no faults to be injected")
+	}
+	if failed, _ := c.injectStepFailure(ctx, tr); failed {
+		t.Error("expected no step failure to be injected")
+	}
+	if tr.Status.FaultInjection != nil {
+		t.Errorf("expected no fault injection status but got %v", tr.Status.FaultInjection)
+	}
+}
+
+func TestArchiveStepLogs(t *testing.T) {
+	puts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		puts++
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := &Reconciler{KubeClientSet: fakekubeclientset.NewSimpleClientset()}
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-step-logs
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - status: "True"
+    type: Succeeded
+  podName: test-taskrun-step-logs-pod
+  steps:
+  - name: build
+    container: step-build
+    terminated:
+      exitCode: 0
+`)
+
+	// The logs are not archived when "step-log-store" is not set.
+	c.archiveStepLogs(context.Background(), tr)
+	if tr.Status.StepLogs != nil || puts != 0 {
+		t.Fatalf("expected no logs to be archived but got %v", tr.Status.StepLogs)
+	}
+
+	ctx := config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{StepLogStore: server.URL}})
+	c.archiveStepLogs(ctx, tr)
+	c.archiveStepLogs(ctx, tr)
+	want := []v1beta1.StepLog{{
+		Name:          "build",
+		ContainerName: "step-build",
+		URL:           server.URL + "/foo/test-taskrun-step-logs-pod/step-build.log",
+	}}
+	if d := cmp.Diff(want, tr.Status.StepLogs); d != "" {
+		t.Errorf("step logs %s", diff.PrintWantGot(d))
+	}
+	if puts != 1 {
+		t.Errorf("expected the logs to be archived once but they were archived %d times", puts)
+	}
+}
+
+func TestStepProgressCondition(t *testing.T) {
+	forty := int32(40)
+	steps := []v1beta1.StepState{{Name: "build"}, {Name: "test"}}
+	for _, tc := range []struct {
+		desc       string
+		done       bool
+		progress   []v1beta1.StepProgress
+		want       corev1.ConditionStatus
+		wantMsg    string
+		wantReason string
+	}{{
+		desc:       "step running",
+		progress:   []v1beta1.StepProgress{{Name: "build", Phase: v1beta1.StepProgressFinished}, {Name: "test", Phase: v1beta1.StepProgressStarted}},
+		want:       corev1.ConditionUnknown,
+		wantReason: "Running",
+		wantMsg:    `1/2 steps finished, step "test" is running`,
+	}, {
+		desc:       "step percent complete",
+		progress:   []v1beta1.StepProgress{{Name: "build", Phase: v1beta1.StepProgressStarted, Percent: &forty}},
+		want:       corev1.ConditionUnknown,
+		wantReason: "Running",
+		wantMsg:    `0/2 steps finished, step "build" is 40% complete`,
+	}, {
+		desc:       "all steps finished",
+		done:       true,
+		progress:   []v1beta1.StepProgress{{Name: "build", Phase: v1beta1.StepProgressFinished}, {Name: "test", Phase: v1beta1.StepProgressFinished}},
+		want:       corev1.ConditionTrue,
+		wantReason: "Finished",
+		wantMsg:    "2/2 steps finished",
+	}, {
+		desc:       "done before the steps finished",
+		done:       true,
+		progress:   []v1beta1.StepProgress{{Name: "build", Phase: v1beta1.StepProgressFinished}},
+		want:       corev1.ConditionFalse,
+		wantReason: "NotFinished",
+		wantMsg:    "1/2 steps finished",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			tr := &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				Steps:        steps,
+				StepProgress: tc.progress,
+			}}}
+			if tc.done {
+				tr.Status.MarkResourceFailed(v1beta1.TaskRunReasonFailed, errors.New("failed"))
+			}
+			want := &apis.Condition{
+				Type:    apis.ConditionType(v1beta1.TaskRunConditionStepProgress.String()),
+				Status:  tc.want,
+				Reason:  tc.wantReason,
+				Message: tc.wantMsg,
+			}
+			if d := cmp.Diff(want, stepProgressCondition(tr)); d != "" {
+				t.Errorf("condition %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestSpireResults(t *testing.T) {
+	mock := &spire.MockClient{}
+	c := &Reconciler{spireClient: mock}
+	recorder := record.NewFakeRecorder(10)
+	ctx := controller.WithEventRecorder(context.Background(), recorder)
+	tr := parse.MustParseV1beta1TaskRun(t, `
+metadata:
+  name: test-taskrun-spire
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  podName: test-taskrun-spire-pod
+`)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun-spire-pod", Namespace: "foo"}}
+	resultsVerified := apis.ConditionType(v1beta1.TaskRunConditionResultsVerified.String())
+
+	// The identity of the pod is registered once it is scheduled.
+	if err := c.registerSpireWorkload(ctx, tr, pod); err != nil {
+		t.Fatalf("registerSpireWorkload() = %v", err)
+	}
+	if len(mock.Entries) != 0 || tr.Status.GetCondition(resultsVerified) != nil {
+		t.Fatalf("expected the identity of the pod not to be registered before it is scheduled")
+	}
+	pod.Spec.NodeName = "node"
+	if err := c.registerSpireWorkload(ctx, tr, pod); err != nil {
+		t.Fatalf("registerSpireWorkload() = %v", err)
+	}
+	if !mock.Entries[mock.GetIdentity(tr)] {
+		t.Errorf("expected the identity of the TaskRun to be registered, got %v", mock.Entries)
+	}
+	if cond := tr.Status.GetCondition(resultsVerified); !cond.IsUnknown() || cond.Reason != v1beta1.AwaitingTaskRunResults.String() {
+		t.Errorf("expected the results to be awaited, got %v", cond)
+	}
+
+	// The entrypoint signs the results with the SVID of the TaskRun.
+	mock.SignIdentities = []string{mock.GetIdentity(tr)}
+	written := []result.RunResult{
+		{Key: "digest", Value: "sha256:abc", ResultType: result.TaskRunResultType},
+		{Key: "tags", Value: `["v1", "latest"]`, ResultType: result.TaskRunResultType},
+	}
+	signed, err := mock.Sign(ctx, written)
+	if err != nil {
+		t.Fatalf("Sign() = %v", err)
+	}
+	for _, r := range append(written, signed...) {
+		v := v1beta1.ResultValue{}
+		if err := v.UnmarshalJSON([]byte(r.Value)); err != nil {
+			t.Fatal(err)
+		}
+		tr.Status.TaskRunResults = append(tr.Status.TaskRunResults, v1beta1.TaskRunResult{Name: r.Key, Type: v1beta1.ResultsType(v.Type), Value: v})
+	}
+	tampered := tr.DeepCopy()
+	tampered.Status.TaskRunResults[0].Value = *v1beta1.NewStructuredValues("sha256:def")
+	tr.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+	tampered.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+
+	c.verifySpireResults(ctx, tr, pod)
+	if !tr.IsTaskRunResultVerified() {
+		t.Errorf("expected the results to be verified, got %v", tr.Status.GetCondition(resultsVerified))
+	}
+	if len(mock.Entries) != 0 {
+		t.Errorf("expected the identity of the TaskRun to be deleted once done, got %v", mock.Entries)
+	}
+
+	c.verifySpireResults(ctx, tampered, pod)
+	cond := tampered.Status.GetCondition(resultsVerified)
+	if !cond.IsFalse() || cond.Reason != v1beta1.TaskRunReasonsResultsVerificationFailed.String() {
+		t.Errorf("expected the verification of the tampered results to fail, got %v", cond)
+	}
+	if err := k8sevent.CheckEventsOrdered(t, recorder.Events, "", []string{"Warning TaskRunResultsVerificationFailed Failed to verify the signatures of the results: failed to verify field: digest"}); err != nil {
+		t.Error(err)
+	}
+}
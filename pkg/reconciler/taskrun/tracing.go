@@ -19,12 +19,16 @@ package taskrun
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/apis"
 	"knative.dev/pkg/logging"
 )
 
@@ -35,8 +39,8 @@ const (
 	SpanContextAnnotation = "tekton.dev/taskrunSpanContext"
 )
 
-// initialize tracing by creating the root span and injecting the
-// spanContext is propogated through annotations in the CR
+// initialize tracing by allocating the span context of the run and storing it in its
+// status, as a child of the spanContext propogated through annotations in the CR if any
 func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, tr *v1beta1.TaskRun) context.Context {
 	logger := logging.FromContext(ctx)
 	pro := otel.GetTextMapPropagator()
@@ -54,25 +58,79 @@ func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, tr *v
 		if err != nil {
 			logger.Error("unable to unmarshal spancontext, err: %s", err)
 		}
-
-		tr.Status.SpanContext = spanContext
-		return pro.Extract(ctx, propagation.MapCarrier(tr.Status.SpanContext))
+		ctx = pro.Extract(ctx, propagation.MapCarrier(spanContext))
 	}
 
-	// Create a new root span since there was no parent spanContext provided through annotations
-	ctxWithTrace, span := tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:Reconciler")
-	defer span.End()
-	span.SetAttributes(attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace))
-
-	pro.Inject(ctxWithTrace, propagation.MapCarrier(spanContext))
+	if !tracing.Enabled(tracerProvider) {
+		logger.Debug("tracerProvider doesn't provide a traceId, tracing is disabled")
+		if len(spanContext) > 0 {
+			tr.Status.SpanContext = spanContext
+		}
+		return ctx
+	}
 
+	// Allocate the span context of the TaskRun, child of the propagated one if any.
+	// Its span is recorded once the TaskRun completes, see recordSpans.
+	ctx = tracing.NewRunContext(ctx)
+	spanContext = make(map[string]string)
+	pro.Inject(ctx, propagation.MapCarrier(spanContext))
 	logger.Debug("got tracing carrier", spanContext)
 	if len(spanContext) == 0 {
-		logger.Debug("tracerProvider doesn't provide a traceId, tracing is disabled")
 		return ctx
 	}
-
-	span.AddEvent("updating TaskRun status with SpanContext")
 	tr.Status.SpanContext = spanContext
-	return ctxWithTrace
+	return ctx
+}
+
+// recordSpans records the span of the TaskRun once it completes, with the span context
+// allocated by initTracing, as a child of the one propagated through annotations if any,
+// and the spans of its steps as its children.
+func recordSpans(tracerProvider trace.TracerProvider, tr *v1beta1.TaskRun, beforeCondition *apis.Condition) {
+	afterCondition := tr.Status.GetCondition(apis.ConditionSucceeded)
+	if afterCondition == nil || afterCondition.IsUnknown() || (beforeCondition != nil && !beforeCondition.IsUnknown()) {
+		return
+	}
+	attributes := []attribute.KeyValue{attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace)}
+	if pipelineTask := tr.Labels[pipeline.PipelineTaskLabelKey]; pipelineTask != "" {
+		attributes = append(attributes, attribute.String("pipelineTask", pipelineTask))
+	}
+	span := tracing.Span{
+		Name:        "TaskRun:" + tr.Name,
+		SpanContext: tr.Status.SpanContext,
+		Parent:      tracing.CarrierFromAnnotation(tr.Annotations[SpanContextAnnotation]),
+		End:         afterCondition.LastTransitionTime.Inner.Time,
+		Attributes:  attributes,
+	}
+	if tr.Status.CompletionTime != nil {
+		span.End = tr.Status.CompletionTime.Time
+	}
+	span.Start = span.End
+	// The span covers all the attempts of the TaskRun
+	if len(tr.Status.RetriesStatus) > 0 && tr.Status.RetriesStatus[0].StartTime != nil {
+		span.Start = tr.Status.RetriesStatus[0].StartTime.Time
+	} else if tr.Status.StartTime != nil {
+		span.Start = tr.Status.StartTime.Time
+	}
+	if afterCondition.IsFalse() {
+		span.Error = afterCondition.Message
+	}
+	tracing.RecordRunSpan(tracerProvider, span)
+
+	for _, step := range tr.Status.Steps {
+		terminated := step.Terminated
+		if terminated == nil || terminated.StartedAt.IsZero() {
+			continue
+		}
+		stepSpan := tracing.Span{
+			Name:       "Step:" + step.Name,
+			Parent:     tr.Status.SpanContext,
+			Start:      terminated.StartedAt.Time,
+			End:        terminated.FinishedAt.Time,
+			Attributes: append(attributes, attribute.String("step", step.Name)),
+		}
+		if terminated.ExitCode != 0 {
+			stepSpan.Error = fmt.Sprintf("step %q exited with code %d", step.Name, terminated.ExitCode)
+		}
+		tracing.RecordStepSpan(tracerProvider, step.ContainerName, stepSpan)
+	}
 }
@@ -16,13 +16,19 @@ package taskrun
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
 func TestInitTracing(t *testing.T) {
@@ -115,10 +121,88 @@ func TestInitTracing(t *testing.T) {
 					t.Errorf("invalid trace Id")
 				}
 
-				if tc.parentTraceID != "" && parentID != tc.parentTraceID {
+				// The span of the run is a child of the propagated one: same trace, new span
+				if tc.parentTraceID != "" && (parentID[:36] != tc.parentTraceID[:36] || parentID == tc.parentTraceID) {
 					t.Errorf("invalid trace Id propagated, %s", parentID)
 				}
 			}
 		})
 	}
 }
+
+type spanExporter struct {
+	spans []tracesdk.ReadOnlySpan
+}
+
+func (e *spanExporter) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *spanExporter) Shutdown(context.Context) error { return nil }
+
+func TestRecordSpans(t *testing.T) {
+	e := &spanExporter{}
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(e), tracesdk.WithIDGenerator(tracing.NewIDGenerator()))
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "testns",
+			Annotations: map[string]string{
+				"tekton.dev/taskrunSpanContext": "{\"traceparent\":\"00-0f57e147e992b304d977436289d10628-73d5909e31793992-01\"}",
+			},
+		},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1.Status{Conditions: duckv1.Conditions{{
+				Type:    apis.ConditionSucceeded,
+				Status:  corev1.ConditionFalse,
+				Message: "step compile failed",
+			}}},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime:      &metav1.Time{Time: start},
+				CompletionTime: &metav1.Time{Time: start.Add(time.Minute)},
+				SpanContext:    map[string]string{"traceparent": "00-0f57e147e992b304d977436289d10628-1a2b3c4d5e6f7081-01"},
+				Steps: []v1beta1.StepState{{
+					Name:          "compile",
+					ContainerName: "step-compile",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode:   1,
+						StartedAt:  metav1.Time{Time: start.Add(time.Second)},
+						FinishedAt: metav1.Time{Time: start.Add(time.Minute)},
+					}},
+				}, {
+					Name:          "test",
+					ContainerName: "step-test",
+					ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+						ExitCode: 1,
+						Reason:   "Skipped",
+					}},
+				}},
+			},
+		},
+	}
+
+	// The spans are only recorded once the TaskRun completes
+	recordSpans(tp, tr, tr.Status.GetCondition(apis.ConditionSucceeded))
+	if len(e.spans) != 0 {
+		t.Fatalf("expected no span to be recorded for a TaskRun which completed already, got %d", len(e.spans))
+	}
+
+	recordSpans(tp, tr, &apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown})
+	if len(e.spans) != 2 {
+		t.Fatalf("expected the spans of the TaskRun and of its step to be recorded, got %d", len(e.spans))
+	}
+	taskRunSpan, stepSpan := e.spans[0], e.spans[1]
+	if taskRunSpan.Name() != "TaskRun:test" || taskRunSpan.SpanContext().SpanID().String() != "1a2b3c4d5e6f7081" ||
+		taskRunSpan.Parent().SpanID().String() != "73d5909e31793992" || !taskRunSpan.StartTime().Equal(start) {
+		t.Errorf("unexpected span of the TaskRun %q %v with parent %v", taskRunSpan.Name(), taskRunSpan.SpanContext(), taskRunSpan.Parent())
+	}
+	if taskRunSpan.Status().Code != codes.Error || taskRunSpan.Status().Description != "step compile failed" {
+		t.Errorf("expected the span of the TaskRun to be failed, got %v", taskRunSpan.Status())
+	}
+	if stepSpan.Name() != "Step:compile" || stepSpan.SpanContext().SpanID() != tracing.StepSpanContext(tr.Status.SpanContext, "step-compile").SpanID() ||
+		stepSpan.Parent().SpanID().String() != "1a2b3c4d5e6f7081" || stepSpan.Status().Code != codes.Error {
+		t.Errorf("unexpected span of the step %q %v with parent %v", stepSpan.Name(), stepSpan.SpanContext(), stepSpan.Parent())
+	}
+}
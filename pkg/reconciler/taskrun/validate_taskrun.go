@@ -19,14 +19,16 @@ package taskrun
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
-
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -49,6 +51,9 @@ func validateParams(ctx context.Context, paramSpecs []v1beta1.ParamSpec, params
 	if missingKeysObjectParamNames := MissingKeysObjectParamNames(paramSpecs, params); len(missingKeysObjectParamNames) != 0 {
 		return fmt.Errorf("missing keys for these params which are required in ParamSpec's properties %v", missingKeysObjectParamNames)
 	}
+	if err := v1beta1.ParamSpecs(paramSpecs).ValidateValues(params); err != nil {
+		return fmt.Errorf("param values don't satisfy the constraints of the params: %w", err)
+	}
 	return nil
 }
 
@@ -89,10 +94,11 @@ func wrongTypeParamsNames(params []v1beta1.Param, matrix v1beta1.Params, neededP
 		// to pass array result to array param, yet in yaml format this will be
 		// unmarshalled to string for ParamValues. So we need to check and skip this validation.
 		// Please refer issue #4879 for more details and examples.
-		if param.Value.Type == v1beta1.ParamTypeString && (neededParamsTypes[param.Name] == v1beta1.ParamTypeArray || neededParamsTypes[param.Name] == v1beta1.ParamTypeObject) && v1beta1.VariableSubstitutionRegex.MatchString(param.Value.StringVal) {
+		neededType := neededParamsTypes[param.Name].ValueType()
+		if param.Value.Type == v1beta1.ParamTypeString && (neededType == v1beta1.ParamTypeArray || neededType == v1beta1.ParamTypeObject) && v1beta1.VariableSubstitutionRegex.MatchString(param.Value.StringVal) {
 			continue
 		}
-		if param.Value.Type != neededParamsTypes[param.Name] {
+		if param.Value.Type != neededType {
 			wrongTypeParamNames = append(wrongTypeParamNames, param.Name)
 		}
 	}
@@ -116,11 +122,15 @@ func MissingKeysObjectParamNames(paramSpecs []v1beta1.ParamSpec, params v1beta1.
 	providedKeys := make(map[string][]string)
 
 	for _, spec := range paramSpecs {
-		if spec.Type == v1beta1.ParamTypeObject {
+		if spec.Type == v1beta1.ParamTypeObject || spec.Type == v1beta1.ParamTypeSecret {
 			// collect required keys from properties section
 			for key := range spec.Properties {
 				neededKeys[spec.Name] = append(neededKeys[spec.Name], key)
 			}
+			// the values of secret params reference the keys of Secrets
+			if spec.Type == v1beta1.ParamTypeSecret {
+				neededKeys[spec.Name] = []string{"name", "key"}
+			}
 
 			// collect provided keys from default
 			if spec.Default != nil && spec.Default.ObjectVal != nil {
@@ -233,6 +243,38 @@ func validateSidecarOverrides(ts *v1beta1.TaskSpec, trs *v1beta1.TaskRunSpec) er
 	return err
 }
 
+// validateResultFileMountPaths validates that the result files are not mounted at the mount
+// paths of the workspaces and of the volumes of the steps, nor over or under them.
+func validateResultFileMountPaths(ts *v1beta1.TaskSpec, workspaces []v1beta1.WorkspaceDeclaration, resultFiles []v1beta1.ResultFile) error {
+	mountPaths := map[string]string{}
+	for i := range workspaces {
+		mountPaths[workspaces[i].GetMountPath()] = fmt.Sprintf("workspace %q", workspaces[i].Name)
+	}
+	var volumeMounts []corev1.VolumeMount
+	if ts.StepTemplate != nil {
+		volumeMounts = append(volumeMounts, ts.StepTemplate.VolumeMounts...)
+	}
+	for _, step := range ts.Steps {
+		volumeMounts = append(volumeMounts, step.VolumeMounts...)
+	}
+	for _, vm := range volumeMounts {
+		mountPaths[filepath.Clean(vm.MountPath)] = fmt.Sprintf("volume %q", vm.Name)
+	}
+
+	var err error
+	for _, rf := range resultFiles {
+		if rf.Path == pipeline.WorkspaceDir {
+			err = multierror.Append(err, fmt.Errorf("result file %q collides with the %s directory", rf.Path, pipeline.WorkspaceDir))
+		}
+		for mountPath, source := range mountPaths {
+			if rf.Path == mountPath || strings.HasPrefix(rf.Path, mountPath+"/") || strings.HasPrefix(mountPath, rf.Path+"/") {
+				err = multierror.Append(err, fmt.Errorf("result file %q collides with the mount path %q of the %s", rf.Path, mountPath, source))
+			}
+		}
+	}
+	return err
+}
+
 // validateResults checks the emitted results type and object properties against the ones defined in spec.
 func validateTaskRunResults(tr *v1beta1.TaskRun, resolvedTaskSpec *v1beta1.TaskSpec) error {
 	specResults := []v1beta1.TaskResult{}
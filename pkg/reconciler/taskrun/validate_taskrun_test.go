@@ -20,6 +20,7 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/google/go-cmp/cmp"
@@ -425,6 +426,24 @@ func TestValidateResolvedTask_InvalidParams(t *testing.T) {
 		}},
 		matrix:  &v1beta1.Matrix{},
 		wantErr: "invalid input params for task : missing keys for these params which are required in ParamSpec's properties map[myObjWithoutDefault:[key2]]",
+	}, {
+		name: "param value not satisfying the constraints",
+		task: v1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: v1beta1.TaskSpec{
+				Params: []v1beta1.ParamSpec{{
+					Name:    "version",
+					Type:    v1beta1.ParamTypeString,
+					Pattern: "^v[0-9]+$",
+				}},
+			},
+		},
+		params: v1beta1.Params{{
+			Name:  "version",
+			Value: *v1beta1.NewStructuredValues("latest"),
+		}},
+		matrix:  &v1beta1.Matrix{},
+		wantErr: `invalid input params for task : param values don't satisfy the constraints of the params: param "version" value "latest" doesn't match the pattern "^v[0-9]+$"`,
 	}}
 	for _, tc := range tcs {
 		rtr := &resources.ResolvedTask{
@@ -502,6 +521,41 @@ func TestValidateOverrides(t *testing.T) {
 	}
 }
 
+func TestValidateResultFileMountPaths(t *testing.T) {
+	ts := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:         "step1",
+			VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/config"}},
+		}},
+		StepTemplate: &v1beta1.StepTemplate{
+			VolumeMounts: []corev1.VolumeMount{{Name: "cache", MountPath: "/cache/"}},
+		},
+	}
+	workspaces := []v1beta1.WorkspaceDeclaration{{Name: "source"}, {Name: "output", MountPath: "/output"}}
+	for _, tc := range []struct {
+		path    string
+		wantErr bool
+	}{
+		{path: "/inputs/config.json"},
+		{path: "/workspace/config.json"},
+		{path: "/workspace", wantErr: true},
+		{path: "/workspace/source", wantErr: true},
+		{path: "/workspace/source/config.json", wantErr: true},
+		{path: "/output/config.json", wantErr: true},
+		{path: "/etc/config", wantErr: true},
+		{path: "/etc", wantErr: true},
+		{path: "/cache/config.json", wantErr: true},
+		{path: "/etc/configuration.json"},
+	} {
+		t.Run(tc.path, func(t *testing.T) {
+			err := validateResultFileMountPaths(ts, workspaces, []v1beta1.ResultFile{{Path: tc.path, Value: "{}"}})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("expected err: %t, but got err %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestValidateResult(t *testing.T) {
 	tcs := []struct {
 		name    string
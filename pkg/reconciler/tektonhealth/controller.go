@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tektonhealth
+
+import (
+	"context"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	tektonhealthinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/tektonhealth"
+	tektonhealthreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/tektonhealth"
+	"github.com/tektoncd/pipeline/pkg/leaderelection"
+	"github.com/tektoncd/pipeline/pkg/sharding"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// createRetryPeriod is the period the controller retries creating the
+// TektonHealth at, e.g. until its CRD is installed.
+const createRetryPeriod = 10 * time.Second
+
+// NewController returns a func that returns a knative controller for maintaining
+// the TektonHealth of the installation.
+func NewController(clock clock.PassiveClock) func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+		client := pipelineclient.Get(ctx)
+		r := &Reconciler{
+			KubeClientSet:     kubeclient.Get(ctx),
+			PipelineClientSet: client,
+			Clock:             clock,
+		}
+		impl := tektonhealthreconciler.NewImpl(ctx, r)
+
+		healthInformer := tektonhealthinformer.Get(ctx)
+		sharding.Apply(ctx, impl, healthInformer.Informer())
+		leaderelection.Apply(ctx, cmw, impl, healthInformer.Informer(), "tektonhealth")
+
+		healthInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    impl.Enqueue,
+			UpdateFunc: controller.PassNew(impl.Enqueue),
+			// the TektonHealth of the installation is recreated when it is deleted.
+			DeleteFunc: func(interface{}) { go create(ctx, client) },
+		})
+		go create(ctx, client)
+
+		return impl
+	}
+}
+
+// create creates the TektonHealth of the installation unless it exists. It
+// retries until it succeeds or the context is done.
+func create(ctx context.Context, client clientset.Interface) {
+	logger := logging.FromContext(ctx)
+	health := &v1alpha1.TektonHealth{ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.TektonHealthName}}
+	_ = wait.PollImmediateUntilWithContext(ctx, createRetryPeriod, func(ctx context.Context) (bool, error) {
+		_, err := client.TektonV1alpha1().TektonHealths().Create(ctx, health, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			logger.Warnw("Failed to create the TektonHealth of the installation", zap.Error(err))
+			return false, nil
+		}
+		return true, nil
+	})
+}
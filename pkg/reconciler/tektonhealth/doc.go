@@ -0,0 +1,26 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*
+Package tektonhealth provides a reconciler for the TektonHealth of the
+installation. The reconciler creates the TektonHealth named `tekton` and
+periodically summarizes in its status:
+  - Whether the API server reaches the admission webhooks.
+  - Whether the remote resolvers are available.
+  - Whether the configmaps of the installation are valid.
+  - Whether the deployments of the installation are of the same version.
+*/
+package tektonhealth
@@ -0,0 +1,249 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tektonhealth
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	clientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	tektonhealthreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1alpha1/tektonhealth"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/clock"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// ResolversNamespace is the namespace of the deployment of the remote resolvers.
+	ResolversNamespace = "tekton-pipelines-resolvers"
+	// ResolversDeploymentName is the name of the deployment of the remote resolvers.
+	ResolversDeploymentName = "tekton-pipelines-remote-resolvers"
+	// InfoConfigMapName is the name of the configmap holding the version of the installation.
+	InfoConfigMapName = "pipelines-info"
+
+	// ReasonChecked indicates that a check of the installation passed.
+	ReasonChecked = "Checked"
+	// ReasonWebhookUnreachable indicates that the API server fails to call the admission webhooks.
+	ReasonWebhookUnreachable = "WebhookUnreachable"
+	// ReasonResolversUnavailable indicates that the deployment of the remote resolvers isn't available.
+	ReasonResolversUnavailable = "ResolversUnavailable"
+	// ReasonInvalidConfig indicates that configmaps of the installation fail to parse.
+	ReasonInvalidConfig = "InvalidConfig"
+	// ReasonVersionSkew indicates that deployments aren't of the version of the installation.
+	ReasonVersionSkew = "VersionSkew"
+	// ReasonCheckFailed indicates that the controller failed to run a check.
+	ReasonCheckFailed = "CheckFailed"
+
+	// webhookProbeName prefixes the name of the Task the controller creates in dry
+	// run to check that the API server reaches the webhooks.
+	webhookProbeName = "tekton-health-probe-"
+	// releaseLabelKey labels the deployments of the installation with its release.
+	releaseLabelKey = "pipeline.tekton.dev/release"
+)
+
+// Reconciler maintains the status of the TektonHealth of the installation.
+type Reconciler struct {
+	KubeClientSet     kubernetes.Interface
+	PipelineClientSet clientset.Interface
+	Clock             clock.PassiveClock
+}
+
+var _ tektonhealthreconciler.Interface = (*Reconciler)(nil)
+
+// components returns the deployments of the installation.
+func components() []v1alpha1.ComponentHealth {
+	return []v1alpha1.ComponentHealth{
+		{Name: "tekton-pipelines-controller", Namespace: system.Namespace()},
+		{Name: "tekton-pipelines-webhook", Namespace: system.Namespace()},
+		{Name: "tekton-events-controller", Namespace: system.Namespace()},
+		{Name: ResolversDeploymentName, Namespace: ResolversNamespace},
+	}
+}
+
+// ReconcileKind checks the installation, sets the status of the TektonHealth and
+// requeues it to check the installation again after its check period.
+func (r *Reconciler) ReconcileKind(ctx context.Context, h *v1alpha1.TektonHealth) reconciler.Event {
+	if h.Name != v1alpha1.TektonHealthName {
+		return nil
+	}
+	h.Status.InitializeConditions()
+
+	r.checkWebhook(ctx, &h.Status)
+	r.checkComponents(ctx, &h.Status)
+	r.checkConfig(ctx, &h.Status)
+	h.Status.LastCheckTime = &metav1.Time{Time: r.Clock.Now()}
+
+	period := v1alpha1.DefaultTektonHealthCheckPeriod
+	if h.Spec.CheckPeriod != nil && h.Spec.CheckPeriod.Duration > 0 {
+		period = h.Spec.CheckPeriod.Duration
+	}
+	return controller.NewRequeueAfter(period)
+}
+
+// checkWebhook creates a Task in dry run, which the API server admits through the
+// webhooks without persisting it.
+func (r *Reconciler) checkWebhook(ctx context.Context, s *v1alpha1.TektonHealthStatus) {
+	probe := &v1.Task{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: webhookProbeName, Namespace: system.Namespace()},
+		Spec: v1.TaskSpec{
+			Steps: []v1.Step{{Name: "probe", Image: "busybox", Script: "true"}},
+		},
+	}
+	_, err := r.PipelineClientSet.TektonV1().Tasks(system.Namespace()).Create(ctx, probe, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}})
+	switch {
+	case err == nil, apierrors.IsAlreadyExists(err):
+		s.MarkCheck(v1alpha1.TektonHealthConditionWebhookReachable, true, ReasonChecked, "The API server reaches the admission webhooks")
+	case strings.Contains(err.Error(), "failed calling webhook"):
+		s.MarkCheck(v1alpha1.TektonHealthConditionWebhookReachable, false, ReasonWebhookUnreachable, err.Error())
+	default:
+		s.MarkCheckUnknown(v1alpha1.TektonHealthConditionWebhookReachable, ReasonCheckFailed, fmt.Sprintf("Failed to create a Task in dry run: %v", err))
+	}
+}
+
+// checkComponents sets the health of the deployments of the installation, and
+// checks the remote resolvers and the versions of the deployments.
+func (r *Reconciler) checkComponents(ctx context.Context, s *v1alpha1.TektonHealthStatus) {
+	s.Components = components()
+	for i := range s.Components {
+		r.checkComponent(ctx, &s.Components[i])
+	}
+
+	resolvers := s.Components[len(s.Components)-1]
+	if resolvers.Available {
+		s.MarkCheck(v1alpha1.TektonHealthConditionResolversHealthy, true, ReasonChecked, "The remote resolvers are available")
+	} else {
+		s.MarkCheck(v1alpha1.TektonHealthConditionResolversHealthy, false, ReasonResolversUnavailable,
+			fmt.Sprintf("The deployment %s/%s isn't available: %s", resolvers.Namespace, resolvers.Name, resolvers.Message))
+	}
+
+	info, err := r.KubeClientSet.CoreV1().ConfigMaps(system.Namespace()).Get(ctx, InfoConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		s.Version = ""
+		s.MarkCheckUnknown(v1alpha1.TektonHealthConditionVersionsConsistent, ReasonCheckFailed, fmt.Sprintf("Failed to get the version of the installation: %v", err))
+		return
+	}
+	s.Version = info.Data["version"]
+	var skewed []string
+	for _, c := range s.Components {
+		if c.Version != "" && c.Version != s.Version {
+			skewed = append(skewed, fmt.Sprintf("%s is at %s", c.Name, c.Version))
+		}
+	}
+	if len(skewed) > 0 {
+		s.MarkCheck(v1alpha1.TektonHealthConditionVersionsConsistent, false, ReasonVersionSkew,
+			fmt.Sprintf("The installation is at %s but %s", s.Version, strings.Join(skewed, ", ")))
+		return
+	}
+	s.MarkCheck(v1alpha1.TektonHealthConditionVersionsConsistent, true, ReasonChecked, fmt.Sprintf("The deployments are at %s", s.Version))
+}
+
+func (r *Reconciler) checkComponent(ctx context.Context, c *v1alpha1.ComponentHealth) {
+	d, err := r.KubeClientSet.AppsV1().Deployments(c.Namespace).Get(ctx, c.Name, metav1.GetOptions{})
+	if err != nil {
+		c.Message = err.Error()
+		return
+	}
+	c.Version = d.Labels[releaseLabelKey]
+	c.Replicas = d.Status.Replicas
+	c.ReadyReplicas = d.Status.ReadyReplicas
+	c.Message = "the deployment has no Available condition"
+	for _, cond := range d.Status.Conditions {
+		if cond.Type == appsv1.DeploymentAvailable {
+			c.Available = cond.Status == corev1.ConditionTrue
+			c.Message = cond.Message
+			if c.Available {
+				c.Message = ""
+			}
+		}
+	}
+}
+
+// configConstructors returns the constructors of the configmaps the controller validates.
+func configConstructors() configmap.Constructors {
+	constructors := config.Constructors()
+	constructors[config.GetLeaderElectionConfigName()] = config.NewLeaderElectionFromConfigMap
+	constructors[logging.ConfigMapName()] = logging.NewConfigFromConfigMap
+	constructors[metrics.ConfigMapName()] = metrics.NewObservabilityConfigFromConfigMap
+	return constructors
+}
+
+// checkConfig parses the configmaps of the installation. The configmaps which
+// don't exist default and are valid.
+func (r *Reconciler) checkConfig(ctx context.Context, s *v1alpha1.TektonHealthStatus) {
+	constructors := configConstructors()
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	s.InvalidConfigs = nil
+	var failed []string
+	for _, name := range names {
+		cm, err := r.KubeClientSet.CoreV1().ConfigMaps(system.Namespace()).Get(ctx, name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			failed = append(failed, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		if err := parse(constructors[name], cm); err != nil {
+			s.InvalidConfigs = append(s.InvalidConfigs, v1alpha1.InvalidConfig{Name: name, Error: err.Error()})
+		}
+	}
+
+	switch {
+	case len(s.InvalidConfigs) > 0:
+		invalid := make([]string, 0, len(s.InvalidConfigs))
+		for _, c := range s.InvalidConfigs {
+			invalid = append(invalid, c.Name)
+		}
+		s.MarkCheck(v1alpha1.TektonHealthConditionConfigValid, false, ReasonInvalidConfig,
+			fmt.Sprintf("The configmaps %s are invalid", strings.Join(invalid, ", ")))
+	case len(failed) > 0:
+		s.MarkCheckUnknown(v1alpha1.TektonHealthConditionConfigValid, ReasonCheckFailed,
+			fmt.Sprintf("Failed to get the configmaps %s", strings.Join(failed, "; ")))
+	default:
+		s.MarkCheck(v1alpha1.TektonHealthConditionConfigValid, true, ReasonChecked, "The configmaps are valid")
+	}
+}
+
+// parse calls a constructor of configmap.Constructors, which takes a configmap
+// and returns a config and an error, and returns its error.
+func parse(constructor interface{}, cm *corev1.ConfigMap) error {
+	out := reflect.ValueOf(constructor).Call([]reflect.Value{reflect.ValueOf(cm)})
+	if err, ok := out[len(out)-1].Interface().(error); ok {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tektonhealth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"github.com/tektoncd/pipeline/test/diff"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	testclock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+var (
+	now                      = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	ignoreLastTransitionTime = cmpopts.IgnoreFields(apis.Condition{}, "LastTransitionTime.Inner.Time")
+)
+
+func deployment(namespace, name, version string, available bool) *appsv1.Deployment {
+	status := corev1.ConditionTrue
+	if !available {
+		status = corev1.ConditionFalse
+	}
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{releaseLabelKey: version}},
+		Status: appsv1.DeploymentStatus{
+			Replicas:      1,
+			ReadyReplicas: 1,
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentAvailable,
+				Status:  status,
+				Message: "Deployment does not have minimum availability.",
+			}},
+		},
+	}
+}
+
+func configMap(name string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.Namespace()}, Data: data}
+}
+
+func TestReconcileKind(t *testing.T) {
+	healthy := []runtime.Object{
+		deployment(system.Namespace(), "tekton-pipelines-controller", "v0.50.0", true),
+		deployment(system.Namespace(), "tekton-pipelines-webhook", "v0.50.0", true),
+		deployment(system.Namespace(), "tekton-events-controller", "v0.50.0", true),
+		deployment(ResolversNamespace, ResolversDeploymentName, "v0.50.0", true),
+		configMap(InfoConfigMapName, map[string]string{"version": "v0.50.0"}),
+		configMap("feature-flags", map[string]string{"enable-api-fields": "beta"}),
+	}
+
+	for _, tc := range []struct {
+		name           string
+		objects        []runtime.Object
+		webhookErr     error
+		wantConditions []apis.Condition
+		wantStatus     v1alpha1.TektonHealthStatusFields
+	}{{
+		name:    "healthy",
+		objects: healthy,
+		wantConditions: []apis.Condition{{
+			Type: v1alpha1.TektonHealthConditionConfigValid, Status: corev1.ConditionTrue, Reason: ReasonChecked, Message: "The configmaps are valid",
+		}, {
+			Type: apis.ConditionReady, Status: corev1.ConditionTrue,
+		}, {
+			Type: v1alpha1.TektonHealthConditionResolversHealthy, Status: corev1.ConditionTrue, Reason: ReasonChecked, Message: "The remote resolvers are available",
+		}, {
+			Type: v1alpha1.TektonHealthConditionVersionsConsistent, Status: corev1.ConditionTrue, Reason: ReasonChecked, Message: "The deployments are at v0.50.0",
+		}, {
+			Type: v1alpha1.TektonHealthConditionWebhookReachable, Status: corev1.ConditionTrue, Reason: ReasonChecked, Message: "The API server reaches the admission webhooks",
+		}},
+		wantStatus: v1alpha1.TektonHealthStatusFields{
+			Version: "v0.50.0",
+			Components: []v1alpha1.ComponentHealth{
+				{Name: "tekton-pipelines-controller", Namespace: system.Namespace(), Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+				{Name: "tekton-pipelines-webhook", Namespace: system.Namespace(), Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+				{Name: "tekton-events-controller", Namespace: system.Namespace(), Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+				{Name: ResolversDeploymentName, Namespace: ResolversNamespace, Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+			},
+			LastCheckTime: &metav1.Time{Time: now},
+		},
+	}, {
+		name: "unhealthy",
+		objects: []runtime.Object{
+			deployment(system.Namespace(), "tekton-pipelines-controller", "v0.50.0", true),
+			deployment(system.Namespace(), "tekton-pipelines-webhook", "v0.49.0", true),
+			deployment(ResolversNamespace, ResolversDeploymentName, "v0.50.0", false),
+			configMap(InfoConfigMapName, map[string]string{"version": "v0.50.0"}),
+			configMap("feature-flags", map[string]string{"enable-api-fields": "gamma"}),
+		},
+		webhookErr: errors.New(`Internal error occurred: failed calling webhook "validation.webhook.pipeline.tekton.dev": connection refused`),
+		wantConditions: []apis.Condition{{
+			Type: v1alpha1.TektonHealthConditionConfigValid, Status: corev1.ConditionFalse, Reason: ReasonInvalidConfig, Message: "The configmaps feature-flags are invalid",
+		}, {
+			Type: apis.ConditionReady, Status: corev1.ConditionFalse, Reason: ReasonInvalidConfig, Message: "The configmaps feature-flags are invalid",
+		}, {
+			Type: v1alpha1.TektonHealthConditionResolversHealthy, Status: corev1.ConditionFalse, Reason: ReasonResolversUnavailable,
+			Message: "The deployment tekton-pipelines-resolvers/tekton-pipelines-remote-resolvers isn't available: Deployment does not have minimum availability.",
+		}, {
+			Type: v1alpha1.TektonHealthConditionVersionsConsistent, Status: corev1.ConditionFalse, Reason: ReasonVersionSkew,
+			Message: "The installation is at v0.50.0 but tekton-pipelines-webhook is at v0.49.0",
+		}, {
+			Type: v1alpha1.TektonHealthConditionWebhookReachable, Status: corev1.ConditionFalse, Reason: ReasonWebhookUnreachable,
+			Message: `Internal error occurred: failed calling webhook "validation.webhook.pipeline.tekton.dev": connection refused`,
+		}},
+		wantStatus: v1alpha1.TektonHealthStatusFields{
+			Version: "v0.50.0",
+			Components: []v1alpha1.ComponentHealth{
+				{Name: "tekton-pipelines-controller", Namespace: system.Namespace(), Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+				{Name: "tekton-pipelines-webhook", Namespace: system.Namespace(), Version: "v0.49.0", Replicas: 1, ReadyReplicas: 1, Available: true},
+				{Name: "tekton-events-controller", Namespace: system.Namespace(), Message: `deployments.apps "tekton-events-controller" not found`},
+				{Name: ResolversDeploymentName, Namespace: ResolversNamespace, Version: "v0.50.0", Replicas: 1, ReadyReplicas: 1, Message: "Deployment does not have minimum availability."},
+			},
+			InvalidConfigs: []v1alpha1.InvalidConfig{{
+				Name:  "feature-flags",
+				Error: `invalid value for feature flag "enable-api-fields": "gamma"`,
+			}},
+			LastCheckTime: &metav1.Time{Time: now},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pipelineClient := fakepipelineclient.NewSimpleClientset()
+			if tc.webhookErr != nil {
+				pipelineClient.PrependReactor("create", "tasks", func(ktesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tc.webhookErr
+				})
+			}
+			r := &Reconciler{
+				KubeClientSet:     fakekubeclient.NewSimpleClientset(tc.objects...),
+				PipelineClientSet: pipelineClient,
+				Clock:             testclock.NewFakePassiveClock(now),
+			}
+			h := &v1alpha1.TektonHealth{
+				ObjectMeta: metav1.ObjectMeta{Name: v1alpha1.TektonHealthName},
+				Spec:       v1alpha1.TektonHealthSpec{CheckPeriod: &metav1.Duration{Duration: 5 * time.Minute}},
+			}
+
+			err := r.ReconcileKind(context.Background(), h)
+			if ok, delay := controller.IsRequeueKey(err); !ok || delay != 5*time.Minute {
+				t.Errorf("ReconcileKind() = %v, want a requeue after the check period", err)
+			}
+			if d := cmp.Diff(tc.wantConditions, []apis.Condition(h.Status.Conditions), ignoreLastTransitionTime); d != "" {
+				t.Errorf("conditions %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.wantStatus, h.Status.TektonHealthStatusFields); d != "" {
+				t.Errorf("status %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -20,48 +20,110 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
 
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
 	clientset "k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/logging"
 )
 
 const (
 	// ReasonCouldntCreateWorkspacePVC indicates that a Pipeline expects a workspace from a
 	// volumeClaimTemplate but couldn't create a claim.
 	ReasonCouldntCreateWorkspacePVC = "CouldntCreateWorkspacePVC"
+
+	// WorkspacePoolLabelKey is the label identifying the workspace pool a pre-provisioned
+	// PersistentVolumeClaim belongs to.
+	WorkspacePoolLabelKey = "tekton.dev/workspace-pool"
+	// WorkspacePoolClaimAnnotationKey is the annotation set on a pooled PersistentVolumeClaim while it is
+	// checked out, holding the name the claim would have had if it had been created from the volumeClaimTemplate.
+	WorkspacePoolClaimAnnotationKey = "tekton.dev/workspace-pool-claim"
+	// WorkspacePoolOwnerAnnotationKey is the annotation set on a pooled PersistentVolumeClaim while it is
+	// checked out, holding the UID of the PipelineRun or TaskRun it is checked out by.
+	WorkspacePoolOwnerAnnotationKey = "tekton.dev/workspace-pool-owner"
+	// WorkspacePoolConsumerAnnotationKey is the annotation set on a pooled PersistentVolumeClaim the first time it is
+	// checked out, holding the Pipeline or Task of the run it was checked out by. It is kept when the claim is
+	// returned, and the claim is then only checked out again by runs of the same Pipeline or Task, since its
+	// content is not scrubbed.
+	WorkspacePoolConsumerAnnotationKey = "tekton.dev/workspace-pool-consumer"
 )
 
 // PvcHandler is used to create PVCs for workspaces
 type PvcHandler interface {
-	CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, consumer string, namespace string) error
+	ReturnPooledPersistentVolumeClaims(ctx context.Context, ownerReference metav1.OwnerReference, namespace string) error
+	GetPooledPersistentVolumeClaimNames(ctx context.Context, ownerReference metav1.OwnerReference, namespace string) (map[string]string, error)
+	CreatePersistentVolumeClaimsForCacheWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, cacheKeys map[string]string, namespace string) error
+	SnapshotPersistentVolumeClaim(ctx context.Context, claimName, snapshotName string, labels map[string]string, namespace string) error
 }
 
 type defaultPVCHandler struct {
-	clientset clientset.Interface
-	logger    *zap.SugaredLogger
+	clientset           clientset.Interface
+	pvcLister           corev1listers.PersistentVolumeClaimLister
+	workspacePoolLister alpha1listers.WorkspacePoolLister
+	logger              *zap.SugaredLogger
 }
 
 // NewPVCHandler returns a new defaultPVCHandler
-func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) PvcHandler {
-	return &defaultPVCHandler{clientset, logger}
+func NewPVCHandler(clientset clientset.Interface, pvcLister corev1listers.PersistentVolumeClaimLister, workspacePoolLister alpha1listers.WorkspacePoolLister, logger *zap.SugaredLogger) PvcHandler {
+	return &defaultPVCHandler{clientset, pvcLister, workspacePoolLister, logger}
+}
+
+// WorkspacePoolConsumer returns the consumer of the pooled PersistentVolumeClaims checked out by the PipelineRun or
+// TaskRun with the given labels: the Pipeline, or the Task for a TaskRun that is not part of a PipelineRun. It
+// returns "" if the run has no such label, in which case no pooled claim is checked out for it.
+func WorkspacePoolConsumer(runLabels map[string]string) string {
+	if name := runLabels[pipeline.PipelineLabelKey]; name != "" {
+		return "pipeline/" + name
+	}
+	if name := runLabels[pipeline.TaskLabelKey]; name != "" {
+		return "task/" + name
+	}
+	return ""
 }
 
 // CreatePersistentVolumeClaimsForWorkspaces checks if a PVC named <claim-name>-<workspace-name>-<owner-name> exists;
 // where claim-name is provided by the user in the volumeClaimTemplate, and owner-name is the name of the
 // resource with the volumeClaimTemplate declared, a PipelineRun or TaskRun. If the PVC did not exist, a new PVC
 // with that name is created with the provided OwnerReference.
-func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
+// If the storage class of the volumeClaimTemplate is served by a WorkspacePool, a free PVC of the pool in the
+// namespace, last used by the same consumer or never used, is checked out instead, and a new PVC is only created
+// when the pool has none left.
+func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, consumer string, namespace string) error {
 	var errs []error
 	for _, claim := range getPersistentVolumeClaims(wb, ownerReference, namespace) {
 		_, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Get(ctx, claim.Name, metav1.GetOptions{})
 		switch {
 		case apierrors.IsNotFound(err):
-			_, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+			pool, err := c.poolForStorageClass(storageClassName(claim))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if pool != "" && consumer != "" {
+				checkedOut, err := c.checkOutPooledPersistentVolumeClaim(ctx, pool, claim, ownerReference, consumer)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if checkedOut {
+					continue
+				}
+				c.logger.Infof("No free PersistentVolumeClaim in workspace pool %s in namespace %s, creating %s",
+					pool, claim.Namespace, claim.Name)
+			}
+			_, err = c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
 			if err != nil && !apierrors.IsAlreadyExists(err) {
 				errs = append(errs, fmt.Errorf("failed to create PVC %s: %w", claim.Name, err))
 			}
@@ -81,6 +143,206 @@ func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx contex
 	return errorutils.NewAggregate(errs)
 }
 
+// poolForStorageClass returns the name of the WorkspacePool serving the StorageClass, or "" if there is none. If
+// several pools serve the StorageClass, the first one by name is used.
+func (c *defaultPVCHandler) poolForStorageClass(storageClassName string) (string, error) {
+	if storageClassName == "" {
+		return "", nil
+	}
+	pools, err := c.workspacePoolLister.List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf("failed to list workspace pools: %w", err)
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	for _, pool := range pools {
+		if pool.DeletionTimestamp == nil && pool.Spec.StorageClassName == storageClassName {
+			return pool.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// checkOutPooledPersistentVolumeClaim checks out a free PVC of the workspace pool for the claim, by annotating it
+// with the claim name, the owner UID and the consumer. It returns false if the pool has no free PVC of the consumer
+// satisfying the claim.
+func (c *defaultPVCHandler) checkOutPooledPersistentVolumeClaim(ctx context.Context, pool string, claim *corev1.PersistentVolumeClaim, ownerReference metav1.OwnerReference, consumer string) (bool, error) {
+	pvcs, err := c.pvcLister.PersistentVolumeClaims(claim.Namespace).List(labels.Set{WorkspacePoolLabelKey: pool}.AsSelector())
+	if err != nil {
+		return false, fmt.Errorf("failed to list PVCs of workspace pool %s: %w", pool, err)
+	}
+	for _, pvc := range pvcs {
+		if isCheckedOutBy(pvc, ownerReference) && pvc.Annotations[WorkspacePoolClaimAnnotationKey] == claim.Name {
+			// already checked out for this claim by a previous reconcile
+			return true, nil
+		}
+	}
+	for _, pvc := range pvcs {
+		if !isFreePooledClaimFor(pvc, claim, consumer) {
+			continue
+		}
+		// The lister returns shared copies of the cached PVCs.
+		pvc = pvc.DeepCopy()
+		if pvc.Annotations == nil {
+			pvc.Annotations = map[string]string{}
+		}
+		pvc.Annotations[WorkspacePoolClaimAnnotationKey] = claim.Name
+		pvc.Annotations[WorkspacePoolOwnerAnnotationKey] = string(ownerReference.UID)
+		pvc.Annotations[WorkspacePoolConsumerAnnotationKey] = consumer
+		_, err := c.clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(ctx, pvc, metav1.UpdateOptions{})
+		switch {
+		case apierrors.IsConflict(err):
+			// checked out concurrently by another run, or changed since it was cached, try the next one
+			continue
+		case err != nil:
+			return false, fmt.Errorf("failed to check out PVC %s of workspace pool %s: %w", pvc.Name, pool, err)
+		}
+		c.logger.Infof("Checked out PersistentVolumeClaim %s of workspace pool %s in namespace %s for %s",
+			pvc.Name, pool, pvc.Namespace, claim.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// isFreePooledClaimFor returns true if the pooled PVC is not checked out, was never used or last used by the
+// consumer, and satisfies the access modes and the storage request of the claim.
+func isFreePooledClaimFor(pvc, claim *corev1.PersistentVolumeClaim, consumer string) bool {
+	if pvc.DeletionTimestamp != nil || pvc.Annotations[WorkspacePoolOwnerAnnotationKey] != "" {
+		return false
+	}
+	if last := pvc.Annotations[WorkspacePoolConsumerAnnotationKey]; last != "" && last != consumer {
+		return false
+	}
+	if storageClassName(pvc) != storageClassName(claim) {
+		return false
+	}
+	for _, mode := range claim.Spec.AccessModes {
+		found := false
+		for _, m := range pvc.Spec.AccessModes {
+			if m == mode {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if want, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+		got, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if !ok || got.Cmp(want) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ReturnPooledPersistentVolumeClaims returns the PVCs of workspace pools checked out by the owner to their pool,
+// so that later runs of the same consumer can check them out. The content of the PVCs is left as is. The PVCs are
+// returned even if their WorkspacePool no longer exists.
+func (c *defaultPVCHandler) ReturnPooledPersistentVolumeClaims(ctx context.Context, ownerReference metav1.OwnerReference, namespace string) error {
+	pvcs, err := c.listPooledPersistentVolumeClaims(namespace)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pvc := range pvcs {
+		if !isCheckedOutBy(pvc, ownerReference) {
+			continue
+		}
+		pvc = pvc.DeepCopy()
+		delete(pvc.Annotations, WorkspacePoolClaimAnnotationKey)
+		delete(pvc.Annotations, WorkspacePoolOwnerAnnotationKey)
+		if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Update(ctx, pvc, metav1.UpdateOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("failed to return PVC %s to workspace pool %s: %w", pvc.Name, pvc.Labels[WorkspacePoolLabelKey], err))
+			continue
+		}
+		c.logger.Infof("Returned PersistentVolumeClaim %s to workspace pool %s in namespace %s",
+			pvc.Name, pvc.Labels[WorkspacePoolLabelKey], namespace)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// GetPooledPersistentVolumeClaimNames returns the names of the PVCs of workspace pools checked out by the owner in the
+// namespace, keyed by the name returned by GetPersistentVolumeClaimName for the claim they are checked out for.
+func (c *defaultPVCHandler) GetPooledPersistentVolumeClaimNames(ctx context.Context, ownerReference metav1.OwnerReference, namespace string) (map[string]string, error) {
+	pvcs, err := c.listPooledPersistentVolumeClaims(namespace)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for _, pvc := range pvcs {
+		if claim := pvc.Annotations[WorkspacePoolClaimAnnotationKey]; claim != "" && isCheckedOutBy(pvc, ownerReference) {
+			names[claim] = pvc.Name
+		}
+	}
+	return names, nil
+}
+
+// listPooledPersistentVolumeClaims lists the cached PVCs of the workspace pools in the namespace.
+func (c *defaultPVCHandler) listPooledPersistentVolumeClaims(namespace string) ([]*corev1.PersistentVolumeClaim, error) {
+	selector, err := labels.Parse(WorkspacePoolLabelKey)
+	if err != nil {
+		return nil, err
+	}
+	pvcs, err := c.pvcLister.PersistentVolumeClaims(namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PVCs of workspace pools: %w", err)
+	}
+	return pvcs, nil
+}
+
+// isCheckedOutBy returns true if the pooled PVC is checked out by the owner.
+func isCheckedOutBy(pvc *corev1.PersistentVolumeClaim, ownerReference metav1.OwnerReference) bool {
+	return ownerReference.UID != "" && pvc.Annotations[WorkspacePoolOwnerAnnotationKey] == string(ownerReference.UID)
+}
+
+// ReturnPooledPersistentVolumeClaimsOfDeletedRun returns a handler returning the PVCs of workspace pools checked
+// out by a deleted PipelineRun or TaskRun to their pool, as they are only returned by the reconciler when the run
+// is done.
+func ReturnPooledPersistentVolumeClaimsOfDeletedRun(ctx context.Context, handler PvcHandler) func(obj interface{}) {
+	logger := logging.FromContext(ctx)
+	return func(obj interface{}) {
+		if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		run, ok := obj.(kmeta.OwnerRefable)
+		if !ok {
+			return
+		}
+		ownerRef := *kmeta.NewControllerRef(run)
+		namespace := run.GetObjectMeta().GetNamespace()
+		if err := handler.ReturnPooledPersistentVolumeClaims(ctx, ownerRef, namespace); err != nil {
+			logger.Errorf("Failed to return pooled PVCs of deleted %s %s/%s: %v", ownerRef.Kind, namespace, ownerRef.Name, err)
+		}
+	}
+}
+
+// ApplyPooledPersistentVolumeClaimNames returns the WorkspaceBindings with the claim names of PersistentVolumeClaim
+// volume sources replaced by the names of the pooled PVCs checked out for them.
+func ApplyPooledPersistentVolumeClaimNames(workspaceBindings []v1beta1.WorkspaceBinding, pooledNames map[string]string) []v1beta1.WorkspaceBinding {
+	if len(pooledNames) == 0 {
+		return workspaceBindings
+	}
+	bindings := make([]v1beta1.WorkspaceBinding, 0, len(workspaceBindings))
+	for _, wb := range workspaceBindings {
+		if wb.PersistentVolumeClaim != nil {
+			if name, ok := pooledNames[wb.PersistentVolumeClaim.ClaimName]; ok {
+				wb = *wb.DeepCopy()
+				wb.PersistentVolumeClaim.ClaimName = name
+			}
+		}
+		bindings = append(bindings, wb)
+	}
+	return bindings
+}
+
+func storageClassName(claim *corev1.PersistentVolumeClaim) string {
+	if claim.Spec.StorageClassName == nil {
+		return ""
+	}
+	return *claim.Spec.StorageClassName
+}
+
 func getPersistentVolumeClaims(workspaceBindings []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) map[string]*corev1.PersistentVolumeClaim {
 	claims := make(map[string]*corev1.PersistentVolumeClaim)
 	for _, workspaceBinding := range workspaceBindings {
@@ -21,16 +21,25 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	client_go_testing "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -41,6 +50,37 @@ const (
 // check that defaultPVCHandler implements PvcHandler
 var _ PvcHandler = (*defaultPVCHandler)(nil)
 
+// newTestPVCHandler returns a defaultPVCHandler whose listers cache the PVCs of the fake clientset and the given
+// WorkspacePools.
+func newTestPVCHandler(t *testing.T, fakekubeclient *fakek8s.Clientset, pools ...*v1alpha1.WorkspacePool) *defaultPVCHandler {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	pvcs, err := fakekubeclient.Tracker().List(corev1.SchemeGroupVersion.WithResource("persistentvolumeclaims"), corev1.SchemeGroupVersion.WithKind("PersistentVolumeClaim"), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range pvcs.(*corev1.PersistentVolumeClaimList).Items {
+		if err := indexer.Add(&pvcs.(*corev1.PersistentVolumeClaimList).Items[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	fakekubeclient.PrependReactor("*", "persistentvolumeclaims", test.AddToInformer(t, indexer))
+	poolIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, pool := range pools {
+		if err := poolIndexer.Add(pool); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return &defaultPVCHandler{fakekubeclient, corev1listers.NewPersistentVolumeClaimLister(indexer), alpha1listers.NewWorkspacePoolLister(poolIndexer), zap.NewExample().Sugar()}
+}
+
+func workspacePool(name, storageClassName string) *v1alpha1.WorkspacePool {
+	return &v1alpha1.WorkspacePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.WorkspacePoolSpec{StorageClassName: storageClassName},
+	}
+}
+
 // TestCreatePersistentVolumeClaimsForWorkspaces tests that given a TaskRun with volumeClaimTemplate workspace,
 // a PVC is created, with the expected name and that it has the expected OwnerReference.
 func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
@@ -79,11 +119,11 @@ func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
 
 	// when
 
-	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace)
+	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, "", namespace)
 	if err != nil {
 		t.Fatalf("unexpexted error: %v", err)
 	}
@@ -143,11 +183,11 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
 
 	// when
 
-	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace)
+	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, "", namespace)
 	if err != nil {
 		t.Fatalf("unexpexted error: %v", err)
 	}
@@ -184,7 +224,7 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
 
 	for _, claim := range getPersistentVolumeClaims(workspaces, ownerRef, namespace) {
 		_, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, claim, metav1.CreateOptions{})
@@ -205,7 +245,7 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 	}
 	fakekubeclient.Fake.PrependReactor(actionGet, "*", fn)
 
-	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, namespace)
+	err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, "", namespace)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -233,3 +273,376 @@ func TestCreateExistPersistentVolumeClaims(t *testing.T) {
 		t.Fatalf("unexpected PVC name on created PVC; expected: %s got: %s", expectedPVCName, pvcList.Items[0].Name)
 	}
 }
+
+func pooledPVC(name, pool, storageClass, size string, accessModes []corev1.PersistentVolumeAccessMode, annotations map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "ns",
+			Labels:      map[string]string{WorkspacePoolLabelKey: pool},
+			Annotations: annotations,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			StorageClassName: &storageClass,
+			AccessModes:      accessModes,
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+}
+
+// TestCreatePersistentVolumeClaimsForWorkspacesFromPool tests that a volumeClaimTemplate workspace whose storage
+// class is served by a WorkspacePool checks out a free PVC of the pool satisfying the template and last used by
+// the same consumer, and that a PVC is only created when the pool has none.
+func TestCreatePersistentVolumeClaimsForWorkspacesFromPool(t *testing.T) {
+	storageClass := "fast-ssd"
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	rwx := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "source",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				AccessModes:      rwo,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}}
+	claimName := GetPersistentVolumeClaimName(workspaces[0].VolumeClaimTemplate, workspaces[0], ownerRef)
+
+	for _, tc := range []struct {
+		desc        string
+		consumer    string
+		pvcs        []*corev1.PersistentVolumeClaim
+		wantPooled  string
+		wantCreated bool
+	}{{
+		desc:     "free pooled pvc is checked out",
+		consumer: "pipeline/build",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("busy", "fast-pool", storageClass, "1Gi", rwo, map[string]string{WorkspacePoolOwnerAnnotationKey: "other"}),
+			pooledPVC("too-small", "fast-pool", storageClass, "500Mi", rwo, nil),
+			pooledPVC("wrong-mode", "fast-pool", storageClass, "1Gi", rwx, nil),
+			pooledPVC("free", "fast-pool", storageClass, "2Gi", rwo, nil),
+		},
+		wantPooled: "free",
+	}, {
+		desc:     "pooled pvc already checked out for the claim",
+		consumer: "pipeline/build",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("free", "fast-pool", storageClass, "1Gi", rwo, nil),
+			pooledPVC("mine", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+				WorkspacePoolClaimAnnotationKey:    claimName,
+				WorkspacePoolOwnerAnnotationKey:    string(ownerRef.UID),
+				WorkspacePoolConsumerAnnotationKey: "pipeline/build",
+			}),
+		},
+		wantPooled: "mine",
+	}, {
+		desc:     "pooled pvc last used by the consumer is checked out",
+		consumer: "pipeline/build",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("used", "fast-pool", storageClass, "1Gi", rwo, map[string]string{WorkspacePoolConsumerAnnotationKey: "pipeline/build"}),
+		},
+		wantPooled: "used",
+	}, {
+		desc:     "pooled pvc last used by another consumer is not checked out",
+		consumer: "pipeline/build",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("used", "fast-pool", storageClass, "1Gi", rwo, map[string]string{WorkspacePoolConsumerAnnotationKey: "pipeline/deploy"}),
+			pooledPVC("used-by-task", "fast-pool", storageClass, "1Gi", rwo, map[string]string{WorkspacePoolConsumerAnnotationKey: "task/build"}),
+		},
+		wantCreated: true,
+	}, {
+		desc: "run without consumer does not check out pooled pvcs",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("free", "fast-pool", storageClass, "1Gi", rwo, nil),
+		},
+		wantCreated: true,
+	}, {
+		desc:     "no free pooled pvc",
+		consumer: "pipeline/build",
+		pvcs: []*corev1.PersistentVolumeClaim{
+			pooledPVC("other-pool", "standard-pool", storageClass, "1Gi", rwo, nil),
+		},
+		wantCreated: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			fakekubeclient := fakek8s.NewSimpleClientset()
+			for _, pvc := range tc.pvcs {
+				if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			pvcHandler := newTestPVCHandler(t, fakekubeclient, workspacePool("fast-pool", storageClass), workspacePool("standard-pool", "standard"))
+
+			if err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, tc.consumer, "ns"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, claimName, metav1.GetOptions{})
+			if created := err == nil; created != tc.wantCreated {
+				t.Errorf("expected PVC %s to be created: %t, got: %t", claimName, tc.wantCreated, created)
+			}
+
+			names, err := pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, ownerRef, "ns")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if names[claimName] != tc.wantPooled {
+				t.Errorf("expected pooled PVC %q for claim %s, got %q", tc.wantPooled, claimName, names[claimName])
+			}
+			if tc.wantPooled != "" {
+				pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, tc.wantPooled, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if pvc.Annotations[WorkspacePoolOwnerAnnotationKey] != string(ownerRef.UID) {
+					t.Errorf("expected pooled PVC %s to be checked out by %s, got %q", pvc.Name, ownerRef.UID, pvc.Annotations[WorkspacePoolOwnerAnnotationKey])
+				}
+				if pvc.Annotations[WorkspacePoolConsumerAnnotationKey] != tc.consumer {
+					t.Errorf("expected pooled PVC %s to be used by %s, got %q", pvc.Name, tc.consumer, pvc.Annotations[WorkspacePoolConsumerAnnotationKey])
+				}
+				if len(pvc.OwnerReferences) != 0 {
+					t.Errorf("expected pooled PVC %s to have no OwnerReferences, got %v", pvc.Name, pvc.OwnerReferences)
+				}
+			}
+		})
+	}
+}
+
+func TestReturnPooledPersistentVolumeClaims(t *testing.T) {
+	storageClass := "fast-ssd"
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	ctx := context.Background()
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		pooledPVC("mine", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+			WorkspacePoolClaimAnnotationKey:    "pvc-mine",
+			WorkspacePoolOwnerAnnotationKey:    string(ownerRef.UID),
+			WorkspacePoolConsumerAnnotationKey: "pipeline/build",
+			"keep":                             "me",
+		}),
+		pooledPVC("other", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+			WorkspacePoolClaimAnnotationKey: "pvc-other",
+			WorkspacePoolOwnerAnnotationKey: "pipelinerun2",
+		}),
+	)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	if err := pvcHandler.ReturnPooledPersistentVolumeClaims(ctx, ownerRef, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	names, err := pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, ownerRef, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{}, names); d != "" {
+		t.Errorf("unexpected pooled PVC names %s", diff.PrintWantGot(d))
+	}
+	names, err = pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, metav1.OwnerReference{UID: "pipelinerun2"}, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"pvc-other": "other"}, names); d != "" {
+		t.Errorf("unexpected pooled PVC names %s", diff.PrintWantGot(d))
+	}
+	pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "mine", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{WorkspacePoolConsumerAnnotationKey: "pipeline/build", "keep": "me"}, pvc.Annotations); d != "" {
+		t.Errorf("unexpected annotations on returned PVC %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPooledPersistentVolumeClaimsFromLister(t *testing.T) {
+	storageClass := "fast-ssd"
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	fakekubeclient := fakek8s.NewSimpleClientset(pooledPVC("mine", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+		WorkspacePoolClaimAnnotationKey: "pvc-mine",
+		WorkspacePoolOwnerAnnotationKey: "taskrun1",
+	}))
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+	ctx := context.Background()
+
+	names, err := pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, metav1.OwnerReference{UID: "taskrun1"}, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{"pvc-mine": "mine"}, names); d != "" {
+		t.Errorf("unexpected pooled PVC names %s", diff.PrintWantGot(d))
+	}
+	for _, action := range fakekubeclient.Fake.Actions() {
+		if action.GetVerb() == "list" {
+			t.Errorf("expected the pooled PVCs to be listed from the lister, got %v", action)
+		}
+	}
+}
+
+// TestCheckOutPooledPersistentVolumeClaimOfAnotherOwner tests that a pooled PVC checked out by another run for a
+// claim of the same name is not reused.
+func TestCheckOutPooledPersistentVolumeClaimOfAnotherOwner(t *testing.T) {
+	storageClass := "fast-ssd"
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	ctx := context.Background()
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "source",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc"},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClass,
+				AccessModes:      rwo,
+			},
+		},
+	}}
+	claimName := GetPersistentVolumeClaimName(workspaces[0].VolumeClaimTemplate, workspaces[0], ownerRef)
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		pooledPVC("theirs", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+			WorkspacePoolClaimAnnotationKey:    claimName,
+			WorkspacePoolOwnerAnnotationKey:    "pipelinerun2",
+			WorkspacePoolConsumerAnnotationKey: "pipeline/build",
+		}),
+		pooledPVC("free", "fast-pool", storageClass, "1Gi", rwo, nil),
+	)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient, workspacePool("fast-pool", storageClass))
+
+	if err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, ownerRef, "pipeline/build", "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names, err := pvcHandler.GetPooledPersistentVolumeClaimNames(ctx, ownerRef, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d := cmp.Diff(map[string]string{claimName: "free"}, names); d != "" {
+		t.Errorf("unexpected pooled PVC names %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestPoolForStorageClass tests that the first WorkspacePool by name serving a StorageClass serves its claims.
+func TestPoolForStorageClass(t *testing.T) {
+	deleted := workspacePool("a-deleted-pool", "fast-ssd")
+	deleted.DeletionTimestamp = &metav1.Time{}
+	pvcHandler := newTestPVCHandler(t, fakek8s.NewSimpleClientset(),
+		workspacePool("fast-pool-2", "fast-ssd"),
+		workspacePool("fast-pool-1", "fast-ssd"),
+		workspacePool("standard-pool", "standard"),
+		deleted,
+	)
+	for _, tc := range []struct {
+		storageClassName string
+		want             string
+	}{{
+		storageClassName: "fast-ssd",
+		want:             "fast-pool-1",
+	}, {
+		storageClassName: "standard",
+		want:             "standard-pool",
+	}, {
+		storageClassName: "slow-hdd",
+		want:             "",
+	}, {
+		storageClassName: "",
+		want:             "",
+	}} {
+		t.Run(tc.storageClassName, func(t *testing.T) {
+			got, err := pvcHandler.poolForStorageClass(tc.storageClassName)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("poolForStorageClass(%q) = %q, want %q", tc.storageClassName, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWorkspacePoolConsumer(t *testing.T) {
+	for _, tc := range []struct {
+		desc   string
+		labels map[string]string
+		want   string
+	}{{
+		desc:   "pipelinerun",
+		labels: map[string]string{pipeline.PipelineLabelKey: "build"},
+		want:   "pipeline/build",
+	}, {
+		desc:   "taskrun of a pipelinerun",
+		labels: map[string]string{pipeline.PipelineLabelKey: "build", pipeline.TaskLabelKey: "compile"},
+		want:   "pipeline/build",
+	}, {
+		desc:   "standalone taskrun",
+		labels: map[string]string{pipeline.TaskLabelKey: "compile"},
+		want:   "task/compile",
+	}, {
+		desc: "no label",
+		want: "",
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if got := WorkspacePoolConsumer(tc.labels); got != tc.want {
+				t.Errorf("WorkspacePoolConsumer(%v) = %q, want %q", tc.labels, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReturnPooledPersistentVolumeClaimsOfDeletedRun(t *testing.T) {
+	storageClass := "fast-ssd"
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	tr := &v1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "taskrun", Namespace: "ns", UID: "taskrun1"}}
+	fakekubeclient := fakek8s.NewSimpleClientset(pooledPVC("mine", "fast-pool", storageClass, "1Gi", rwo, map[string]string{
+		WorkspacePoolClaimAnnotationKey: "pvc-mine",
+		WorkspacePoolOwnerAnnotationKey: string(tr.UID),
+	}))
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+	ctx := context.Background()
+
+	ReturnPooledPersistentVolumeClaimsOfDeletedRun(ctx, pvcHandler)(cache.DeletedFinalStateUnknown{Obj: tr})
+
+	pvc, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").Get(ctx, "mine", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if owner := pvc.Annotations[WorkspacePoolOwnerAnnotationKey]; owner != "" {
+		t.Errorf("expected the PVC checked out by the deleted TaskRun to be returned, got owner %q", owner)
+	}
+}
+
+func TestApplyPooledPersistentVolumeClaimNames(t *testing.T) {
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name:                  "pooled",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pvc-1234"},
+	}, {
+		Name:                  "bring-my-own-pvc",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myown"},
+	}, {
+		Name:     "empty",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	want := []v1beta1.WorkspaceBinding{{
+		Name:                  "pooled",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pool-pvc-a"},
+	}, {
+		Name:                  "bring-my-own-pvc",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myown"},
+	}, {
+		Name:     "empty",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+
+	got := ApplyPooledPersistentVolumeClaimNames(workspaces, map[string]string{"pvc-1234": "pool-pvc-a"})
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected workspace bindings %s", diff.PrintWantGot(d))
+	}
+	if workspaces[0].PersistentVolumeClaim.ClaimName != "pvc-1234" {
+		t.Errorf("expected the workspace bindings to be left unmodified, got claim name %s", workspaces[0].PersistentVolumeClaim.ClaimName)
+	}
+}
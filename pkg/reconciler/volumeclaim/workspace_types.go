@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	errorutils "k8s.io/apimachinery/pkg/util/errors"
+)
+
+const (
+	// WorkspaceCacheKeyAnnotationKey is the annotation set on the PersistentVolumeClaim of a cache
+	// workspace, holding its cache key.
+	WorkspaceCacheKeyAnnotationKey = "tekton.dev/workspace-cache-key"
+	// WorkspaceArtifactLabelKey is the label set on the PersistentVolumeClaim snapshotting an artifact
+	// workspace, holding the name of the workspace.
+	WorkspaceArtifactLabelKey = "tekton.dev/workspace-artifact"
+)
+
+// CreatePersistentVolumeClaimsForCacheWorkspaces creates, for each workspace bound to a volumeClaimTemplate with a
+// cache key in cacheKeys, a PVC named by GetCachePersistentVolumeClaimName unless it already exists. The PVCs have no
+// owner, so that they outlive the run and are used by all the runs with the same cache key.
+func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForCacheWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, cacheKeys map[string]string, namespace string) error {
+	var errs []error
+	for _, binding := range wb {
+		cacheKey, ok := cacheKeys[binding.Name]
+		if !ok || binding.VolumeClaimTemplate == nil {
+			continue
+		}
+		claim := binding.VolumeClaimTemplate.DeepCopy()
+		claim.Name = GetCachePersistentVolumeClaimName(binding.VolumeClaimTemplate, cacheKey)
+		claim.Namespace = namespace
+		if claim.Annotations == nil {
+			claim.Annotations = map[string]string{}
+		}
+		claim.Annotations[WorkspaceCacheKeyAnnotationKey] = cacheKey
+		_, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, claim, metav1.CreateOptions{})
+		switch {
+		case apierrors.IsAlreadyExists(err):
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to create PVC %s for cache key %q: %w", claim.Name, cacheKey, err))
+		default:
+			c.logger.Infof("Created PersistentVolumeClaim %s in namespace %s for cache key %q", claim.Name, namespace, cacheKey)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// SnapshotPersistentVolumeClaim creates a PVC named snapshotName cloning the PVC named claimName, with the given
+// labels, unless it already exists. The snapshot has no owner, so that it is retained after the run.
+func (c *defaultPVCHandler) SnapshotPersistentVolumeClaim(ctx context.Context, claimName, snapshotName string, labels map[string]string, namespace string) error {
+	if _, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, snapshotName, metav1.GetOptions{}); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to retrieve PVC %s: %w", snapshotName, err)
+	}
+	source, err := c.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, claimName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve PVC %s to snapshot: %w", claimName, err)
+	}
+	snapshot := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshotName,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      source.Spec.AccessModes,
+			Resources:        source.Spec.Resources,
+			StorageClassName: source.Spec.StorageClassName,
+			VolumeMode:       source.Spec.VolumeMode,
+			DataSource: &corev1.TypedLocalObjectReference{
+				Kind: "PersistentVolumeClaim",
+				Name: source.Name,
+			},
+		},
+	}
+	_, err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Create(ctx, snapshot, metav1.CreateOptions{})
+	switch {
+	case apierrors.IsAlreadyExists(err):
+	case err != nil:
+		return fmt.Errorf("failed to snapshot PVC %s to %s: %w", claimName, snapshotName, err)
+	default:
+		c.logger.Infof("Snapshotted PersistentVolumeClaim %s to %s in namespace %s", claimName, snapshotName, namespace)
+	}
+	return nil
+}
+
+// GetCachePersistentVolumeClaimName gets the name of the PersistentVolumeClaim of a cache workspace. claim must be a
+// PersistentVolumeClaim from a volumeClaimTemplate. The returned name only depends on the name of the claim and the
+// cache key, so that runs with the same cache key use the same PersistentVolumeClaim.
+func GetCachePersistentVolumeClaimName(claim *corev1.PersistentVolumeClaim, cacheKey string) string {
+	hashBytes := sha256.Sum256([]byte(cacheKey))
+	hashString := fmt.Sprintf("%x", hashBytes)
+	if claim.Name == "" {
+		return fmt.Sprintf("%s-%s", "cache", hashString[:10])
+	}
+	return fmt.Sprintf("%s-cache-%s", claim.Name, hashString[:10])
+}
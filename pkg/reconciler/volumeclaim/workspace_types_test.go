@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package volumeclaim
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+// TestCreatePersistentVolumeClaimsForCacheWorkspaces tests that a PVC without owner is created for the
+// volumeClaimTemplate workspaces with a cache key, and that an existing cache PVC is reused.
+func TestCreatePersistentVolumeClaimsForCacheWorkspaces(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	namespace := "ns"
+	template := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name:                "cache",
+		VolumeClaimTemplate: template,
+	}, {
+		Name:                "source",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "source"}},
+	}, {
+		Name:                  "own-cache",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "myown"},
+	}}
+	cacheKeys := map[string]string{"cache": "go-1.19", "own-cache": "go-1.19"}
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	// creating the cache PVCs twice, as done by two runs with the same cache key, must succeed
+	for i := 0; i < 2; i++ {
+		if err := pvcHandler.CreatePersistentVolumeClaimsForCacheWorkspaces(ctx, workspaces, cacheKeys, namespace); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	pvcs, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pvcs.Items) != 1 {
+		t.Fatalf("expected 1 cache PVC but got %d", len(pvcs.Items))
+	}
+	pvc := pvcs.Items[0]
+	if d := cmp.Diff("pvc-cache-1eb3392c76", pvc.Name); d != "" {
+		t.Errorf("unexpected cache PVC name %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff("go-1.19", pvc.Annotations[WorkspaceCacheKeyAnnotationKey]); d != "" {
+		t.Errorf("unexpected cache key annotation %s", diff.PrintWantGot(d))
+	}
+	if len(pvc.OwnerReferences) != 0 {
+		t.Errorf("expected cache PVC without owner but got %v", pvc.OwnerReferences)
+	}
+}
+
+func TestGetCachePersistentVolumeClaimName(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		claim    *corev1.PersistentVolumeClaim
+		cacheKey string
+		want     string
+	}{{
+		name:     "named claim",
+		claim:    &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}},
+		cacheKey: "go-1.19",
+		want:     "pvc-cache-1eb3392c76",
+	}, {
+		name:     "claim without name",
+		claim:    &corev1.PersistentVolumeClaim{},
+		cacheKey: "go-1.19",
+		want:     "cache-1eb3392c76",
+	}, {
+		name:     "other cache key",
+		claim:    &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc"}},
+		cacheKey: "go-1.20",
+		want:     "pvc-cache-df8a260c62",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, GetCachePersistentVolumeClaimName(tc.claim, tc.cacheKey)); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+// TestSnapshotPersistentVolumeClaim tests that the snapshot of a PVC is a PVC cloning it, and that
+// an existing snapshot is left as is.
+func TestSnapshotPersistentVolumeClaim(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	namespace := "ns"
+	storageClassName := "csi"
+	source := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: namespace},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: &storageClassName,
+		},
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(source)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+	labels := map[string]string{WorkspaceArtifactLabelKey: "output"}
+
+	for i := 0; i < 2; i++ {
+		if err := pvcHandler.SnapshotPersistentVolumeClaim(ctx, "source", "pr-output-artifact", labels, namespace); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshot, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, "pr-output-artifact", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := corev1.PersistentVolumeClaimSpec{
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		StorageClassName: &storageClassName,
+		DataSource: &corev1.TypedLocalObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: "source",
+		},
+	}
+	if d := cmp.Diff(want, snapshot.Spec); d != "" {
+		t.Errorf("unexpected snapshot spec %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(labels, snapshot.Labels); d != "" {
+		t.Errorf("unexpected snapshot labels %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSnapshotPersistentVolumeClaimWithoutSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pvcHandler := newTestPVCHandler(t, fakek8s.NewSimpleClientset())
+	if err := pvcHandler.SnapshotPersistentVolumeClaim(ctx, "missing", "pr-output-artifact", nil, "ns"); err == nil {
+		t.Error("expected error snapshotting a missing PVC")
+	}
+}
@@ -19,3 +19,19 @@ package common
 // LabelKeyResolverType is the label that determines which resolver will
 // ultimately receive the request for a resource.
 const LabelKeyResolverType string = "resolution.tekton.dev/type"
+
+// LabelKeyPriority is the label that determines the order in which a
+// resolver processes a request relative to other pending requests.
+const LabelKeyPriority string = "resolution.tekton.dev/priority"
+
+const (
+	// PriorityHigh requests are always placed on the resolver's fast
+	// queue and are exempt from per-namespace concurrency limits.
+	PriorityHigh = "high"
+	// PriorityNormal is the priority given to requests without a
+	// priority label.
+	PriorityNormal = "normal"
+	// PriorityLow requests are always placed on the resolver's slow
+	// queue and are only processed when no other work is pending.
+	PriorityLow = "low"
+)
@@ -40,4 +40,8 @@ const (
 	// ReasonResolutionTimedOut indicates that a resolver did not
 	// manage to respond to a ResolutionRequest within a timeout.
 	ReasonResolutionTimedOut = "ResolutionTimedOut"
+
+	// ReasonResolutionInvalidRequest indicates that the params of a
+	// ResolutionRequest were rejected by its resolver.
+	ReasonResolutionInvalidRequest = "ResolutionInvalidRequest"
 )
@@ -41,4 +41,9 @@ var (
 	// ResolverAnnotationAPIVersion is the resolver annotation used to
 	// indicate the "apiVersion" of resource.
 	ResolverAnnotationAPIVersion = resolution.GroupName + "/" + BundleAnnotationAPIVersion
+
+	// ResolverAnnotationReference is the resolver annotation used to
+	// indicate the reference of the bundle pinned to the digest its tag
+	// resolved to.
+	ResolverAnnotationReference = resolution.GroupName + "/dev.tekton.image.reference"
 )
@@ -70,10 +70,82 @@ func (br *ResolvedResource) RefSource() *pipelinev1beta1.RefSource {
 	return br.source
 }
 
+// Entry describes an object stored in a layer of a bundle.
+type Entry struct {
+	Kind       string
+	Name       string
+	APIVersion string
+}
+
+// ListEntries accepts a keychain and a bundle reference and returns the
+// objects stored in the bundle, in the order of its layers.
+func ListEntries(ctx context.Context, keychain authn.Keychain, bundle string) ([]Entry, error) {
+	b, err := fetchBundle(ctx, keychain, bundle)
+	if err != nil {
+		return nil, err
+	}
+	return b.entries(), nil
+}
+
 // GetEntry accepts a keychain and options for the request and returns
 // either a successfully resolved bundle entry or an error.
 func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions) (*ResolvedResource, error) {
-	uri, img, err := retrieveImage(ctx, keychain, opts.Bundle)
+	b, err := fetchBundle(ctx, keychain, opts.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, l := range b.manifest.Layers {
+		lKind := l.Annotations[BundleAnnotationKind]
+		lName := l.Annotations[BundleAnnotationName]
+
+		if strings.ToLower(opts.Kind) == strings.ToLower(lKind) && opts.EntryName == lName {
+			obj, err := readTarLayer(b.layerMap[l.Digest.String()])
+			if err != nil {
+				// This could still be a raw layer so try to read it as that instead.
+				obj, _ = readRawLayer(b.layers[idx])
+			}
+			return &ResolvedResource{
+				data: obj,
+				annotations: map[string]string{
+					ResolverAnnotationKind:       lKind,
+					ResolverAnnotationName:       lName,
+					ResolverAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
+					ResolverAnnotationReference:  fmt.Sprintf("%s@%s", b.uri, b.digest),
+				},
+				source: &v1beta1.RefSource{
+					URI: b.uri,
+					Digest: map[string]string{
+						b.digest.Algorithm: b.digest.Hex,
+					},
+					EntryPoint: opts.EntryName,
+				},
+			}, nil
+		}
+	}
+
+	var available []string
+	for _, e := range b.entries() {
+		available = append(available, fmt.Sprintf("%s/%s", e.Kind, e.Name))
+	}
+	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s, the image contains: %s", opts.Kind, opts.EntryName, strings.Join(available, ", "))
+}
+
+// bundleImage is a compliant bundle fetched from a registry.
+type bundleImage struct {
+	// uri is the repository of the bundle, without its tag or digest.
+	uri string
+	// digest is the digest the reference of the bundle resolved to.
+	digest   v1.Hash
+	manifest *v1.Manifest
+	layers   []v1.Layer
+	layerMap map[string]v1.Layer
+}
+
+// fetchBundle retrieves the bundle, resolving its tag to a digest, and checks
+// that it complies with the bundle spec.
+func fetchBundle(ctx context.Context, keychain authn.Keychain, bundle string) (*bundleImage, error) {
+	uri, img, err := retrieveImage(ctx, keychain, bundle)
 	if err != nil {
 		return nil, fmt.Errorf("cannot retrieve the oci image: %w", err)
 	}
@@ -89,7 +161,7 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 	}
 
 	if err := checkImageCompliance(manifest); err != nil {
-		return nil, fmt.Errorf("invalid tekton bundle %s, error: %w", opts.Bundle, err)
+		return nil, fmt.Errorf("invalid tekton bundle %s, error: %w", bundle, err)
 	}
 
 	layers, err := img.Layers()
@@ -106,34 +178,26 @@ func GetEntry(ctx context.Context, keychain authn.Keychain, opts RequestOptions)
 		layerMap[digest.String()] = l
 	}
 
-	for idx, l := range manifest.Layers {
-		lKind := l.Annotations[BundleAnnotationKind]
-		lName := l.Annotations[BundleAnnotationName]
+	return &bundleImage{
+		uri:      uri,
+		digest:   h,
+		manifest: manifest,
+		layers:   layers,
+		layerMap: layerMap,
+	}, nil
+}
 
-		if strings.ToLower(opts.Kind) == strings.ToLower(lKind) && opts.EntryName == lName {
-			obj, err := readTarLayer(layerMap[l.Digest.String()])
-			if err != nil {
-				// This could still be a raw layer so try to read it as that instead.
-				obj, _ = readRawLayer(layers[idx])
-			}
-			return &ResolvedResource{
-				data: obj,
-				annotations: map[string]string{
-					ResolverAnnotationKind:       lKind,
-					ResolverAnnotationName:       lName,
-					ResolverAnnotationAPIVersion: l.Annotations[BundleAnnotationAPIVersion],
-				},
-				source: &v1beta1.RefSource{
-					URI: uri,
-					Digest: map[string]string{
-						h.Algorithm: h.Hex,
-					},
-					EntryPoint: opts.EntryName,
-				},
-			}, nil
-		}
+// entries returns the objects described by the annotations of the layers of the bundle.
+func (b *bundleImage) entries() []Entry {
+	entries := make([]Entry, 0, len(b.manifest.Layers))
+	for _, l := range b.manifest.Layers {
+		entries = append(entries, Entry{
+			Kind:       l.Annotations[BundleAnnotationKind],
+			Name:       l.Annotations[BundleAnnotationName],
+			APIVersion: l.Annotations[BundleAnnotationAPIVersion],
+		})
 	}
-	return nil, fmt.Errorf("could not find object in image with kind: %s and name: %s", opts.Kind, opts.EntryName)
+	return entries
 }
 
 // retrieveImage will fetch the image's url, contents and manifest.
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bundle_test
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/registry"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	bundle "github.com/tektoncd/pipeline/pkg/resolution/resolver/bundle"
+	"github.com/tektoncd/pipeline/test"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func pushMultipleResources(t *testing.T) *imageRef {
+	t.Helper()
+	s := httptest.NewServer(registry.New())
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pushToRegistry(t, fmt.Sprintf("%s/%s", u.Host, "testbundle"), "multiple-resources", []runtime.Object{
+		&pipelinev1beta1.Task{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Task"},
+			ObjectMeta: metav1.ObjectMeta{Name: "example-task"},
+		},
+		&pipelinev1beta1.Pipeline{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "Pipeline"},
+			ObjectMeta: metav1.ObjectMeta{Name: "example-pipeline"},
+		},
+	}, test.DefaultObjectAnnotationMapper)
+}
+
+func TestListEntries(t *testing.T) {
+	ref := pushMultipleResources(t)
+
+	entries, err := bundle.ListEntries(context.Background(), authn.DefaultKeychain, ref.uri+":latest")
+	if err != nil {
+		t.Fatalf("unexpected error listing the entries: %v", err)
+	}
+	want := []bundle.Entry{{
+		Kind:       "task",
+		Name:       "example-task",
+		APIVersion: "v1beta1",
+	}, {
+		Kind:       "pipeline",
+		Name:       "example-pipeline",
+		APIVersion: "v1beta1",
+	}}
+	if d := cmp.Diff(want, entries); d != "" {
+		t.Errorf("unexpected entries: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetEntry_NotFound(t *testing.T) {
+	ref := pushMultipleResources(t)
+
+	_, err := bundle.GetEntry(context.Background(), authn.DefaultKeychain, bundle.RequestOptions{
+		Bundle:    ref.uri + ":latest",
+		EntryName: "example-task",
+		Kind:      "pipeline",
+	})
+	if err == nil {
+		t.Fatal("expected an error getting an entry which is not in the bundle")
+	}
+	want := "could not find object in image with kind: pipeline and name: example-task, the image contains: task/example-task, pipeline/example-pipeline"
+	if d := cmp.Diff(want, err.Error()); d != "" {
+		t.Errorf("unexpected error: %s", diff.PrintWantGot(d))
+	}
+}
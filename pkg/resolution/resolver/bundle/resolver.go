@@ -94,10 +94,18 @@ func (r *Resolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param)
 		return nil, err
 	}
 	namespace := common.RequestNamespace(ctx)
-	kc, _ := k8schain.New(ctx, r.kubeClientSet, k8schain.Options{
+	kc, err := k8schain.New(ctx, r.kubeClientSet, k8schain.Options{
 		Namespace:          namespace,
 		ServiceAccountName: opts.ServiceAccount,
 	})
+	if err != nil {
+		// The pull secrets of the service account can't be read, the bundle is still
+		// fetched with the docker config and the cloud keychains (ECR, GCR and ACR) of
+		// the resolver.
+		if kc, err = k8schain.NewNoClient(ctx); err != nil {
+			return nil, err
+		}
+	}
 	ctx, cancelFn := context.WithTimeout(ctx, timeoutDuration)
 	defer cancelFn()
 	return GetEntry(ctx, kc, opts)
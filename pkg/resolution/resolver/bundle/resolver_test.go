@@ -445,6 +445,7 @@ func TestResolve(t *testing.T) {
 
 					expectedStatus.Annotations[bundle.ResolverAnnotationName] = tc.args.name
 					expectedStatus.Annotations[bundle.ResolverAnnotationAPIVersion] = "v1beta1"
+					expectedStatus.Annotations[bundle.ResolverAnnotationReference] = fmt.Sprintf("%s@%s:%s", testImages[tc.imageName].uri, testImages[tc.imageName].algo, testImages[tc.imageName].hex)
 
 					expectedStatus.RefSource = &pipelinev1beta1.RefSource{
 						URI: testImages[tc.imageName].uri,
@@ -366,7 +366,7 @@ func TestResolve(t *testing.T) {
 			resourceName:      exampleTask.Name,
 			namespace:         "other-ns",
 			allowedNamespaces: "foo,bar",
-			expectedStatus:    internal.CreateResolutionRequestFailureStatus(),
+			expectedStatus:    internal.CreateResolutionRequestInvalidRequestStatus(),
 			expectedErr: &resolutioncommon.InvalidRequestError{
 				ResolutionRequestKey: "foo/rr",
 				Message:              "access to specified namespace other-ns is not allowed",
@@ -377,7 +377,7 @@ func TestResolve(t *testing.T) {
 			resourceName:      exampleTask.Name,
 			namespace:         "other-ns",
 			blockedNamespaces: "foo,other-ns,bar",
-			expectedStatus:    internal.CreateResolutionRequestFailureStatus(),
+			expectedStatus:    internal.CreateResolutionRequestInvalidRequestStatus(),
 			expectedErr: &resolutioncommon.InvalidRequestError{
 				ResolutionRequestKey: "foo/rr",
 				Message:              "access to specified namespace other-ns is blocked",
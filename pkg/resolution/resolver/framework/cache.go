@@ -0,0 +1,167 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	utilcache "k8s.io/apimachinery/pkg/util/cache"
+	"knative.dev/pkg/logging"
+)
+
+// ConfigCacheTTL is the key in a resolver's configmap which overrides
+// the duration for which the resolver's results are cached, e.g. "5m".
+// A duration of "0" disables the cache.
+const ConfigCacheTTL = "cache-ttl"
+
+// defaultResultCacheSize is the maximum number of results a resolver
+// caches.
+const defaultResultCacheSize = 1000
+
+// cachedResource is a copy of a ResolvedResource kept in the result
+// cache.
+type cachedResource struct {
+	data        []byte
+	annotations map[string]string
+	refSource   *pipelinev1beta1.RefSource
+}
+
+var _ ResolvedResource = &cachedResource{}
+
+func (c *cachedResource) Data() []byte {
+	return c.data
+}
+
+func (c *cachedResource) Annotations() map[string]string {
+	return c.annotations
+}
+
+func (c *cachedResource) RefSource() *pipelinev1beta1.RefSource {
+	return c.refSource
+}
+
+// resultCache keeps the results of a resolver so that identical requests
+// made within the TTL reuse the previous result instead of reaching the
+// resolver's backend again. A result is identified by the namespace of
+// the request, its params and the resolver's configuration, so that a
+// namespace never reuses a result resolved with another namespace's
+// credentials.
+//
+// The cache also tracks the digest most recently resolved from each
+// source, as given by the URI and entrypoint of a result's RefSource. A
+// cached result whose source has since been resolved to another digest,
+// e.g. because a branch moved to a new commit, is stale and is resolved
+// again.
+type resultCache struct {
+	results *utilcache.LRUExpireCache
+	digests *utilcache.LRUExpireCache
+}
+
+func newResultCache(size int, clock utilcache.Clock) *resultCache {
+	return &resultCache{
+		results: utilcache.NewLRUExpireCacheWithClock(size, clock),
+		digests: utilcache.NewLRUExpireCacheWithClock(size, clock),
+	}
+}
+
+// resultCacheKey returns the key identifying the result of a request
+// from namespace with the given params and resolver configuration.
+func resultCacheKey(namespace string, params []pipelinev1beta1.Param, conf map[string]string) (string, error) {
+	sorted := make([]pipelinev1beta1.Param, len(params))
+	copy(sorted, params)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	b, err := json.Marshal(struct {
+		Namespace string                  `json:"namespace"`
+		Params    []pipelinev1beta1.Param `json:"params"`
+		Config    map[string]string       `json:"config"`
+	}{namespace, sorted, conf})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// sourceKey returns the key of the source of a resource and its digest,
+// or false if the resource doesn't record a digest of its source.
+func sourceKey(refSource *pipelinev1beta1.RefSource) (string, string, bool) {
+	if refSource == nil || refSource.URI == "" || len(refSource.Digest) == 0 {
+		return "", "", false
+	}
+	algorithms := make([]string, 0, len(refSource.Digest))
+	for algorithm := range refSource.Digest {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	digest := make([]string, 0, len(algorithms))
+	for _, algorithm := range algorithms {
+		digest = append(digest, algorithm+":"+refSource.Digest[algorithm])
+	}
+	return refSource.URI + "#" + refSource.EntryPoint, strings.Join(digest, ","), true
+}
+
+// get returns the result cached under key, unless it expired or its
+// source has since been resolved to another digest.
+func (c *resultCache) get(key string) (ResolvedResource, bool) {
+	v, ok := c.results.Get(key)
+	if !ok {
+		return nil, false
+	}
+	resource, _ := v.(*cachedResource)
+	if source, digest, ok := sourceKey(resource.refSource); ok {
+		if latest, ok := c.digests.Get(source); ok && latest != digest {
+			c.results.Remove(key)
+			return nil, false
+		}
+	}
+	return resource, true
+}
+
+// add caches resource under key for ttl, and records the digest of its
+// source so that results resolved from an older digest are invalidated.
+func (c *resultCache) add(key string, resource ResolvedResource, ttl time.Duration) {
+	cached := &cachedResource{
+		data:        resource.Data(),
+		annotations: resource.Annotations(),
+		refSource:   resource.RefSource(),
+	}
+	if source, digest, ok := sourceKey(cached.refSource); ok {
+		c.digests.Add(source, digest, ttl)
+	}
+	c.results.Add(key, cached, ttl)
+}
+
+// cacheTTL returns the duration for which the results of the resolver
+// are cached, as configured in the resolver's configmap or else by the
+// reconciler.
+func (r *Reconciler) cacheTTL(ctx context.Context) time.Duration {
+	if v, ok := GetResolverConfigFromContext(ctx)[ConfigCacheTTL]; ok {
+		ttl, err := time.ParseDuration(v)
+		if err == nil {
+			return ttl
+		}
+		logging.FromContext(ctx).Warnf("invalid %s %q in the resolver config: %v", ConfigCacheTTL, v, err)
+	}
+	return r.CacheTTL
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	clock "k8s.io/utils/clock/testing"
+)
+
+func resource(content, commit string) *FakeResolvedResource {
+	return &FakeResolvedResource{
+		Content: content,
+		ContentSource: &pipelinev1beta1.RefSource{
+			URI:        "git+https://github.com/tektoncd/catalog.git",
+			Digest:     map[string]string{"sha1": commit},
+			EntryPoint: "task/git-clone/0.9/git-clone.yaml",
+		},
+	}
+}
+
+func params(kv ...string) []pipelinev1beta1.Param {
+	var ps []pipelinev1beta1.Param
+	for i := 0; i < len(kv); i += 2 {
+		ps = append(ps, pipelinev1beta1.Param{Name: kv[i], Value: *pipelinev1beta1.NewStructuredValues(kv[i+1])})
+	}
+	return ps
+}
+
+func mustCacheKey(t *testing.T, namespace string, ps []pipelinev1beta1.Param, conf map[string]string) string {
+	t.Helper()
+	key, err := resultCacheKey(namespace, ps, conf)
+	if err != nil {
+		t.Fatalf("unexpected error computing the cache key: %v", err)
+	}
+	return key
+}
+
+func TestResultCacheKey(t *testing.T) {
+	conf := map[string]string{"default-revision": "main"}
+	key := mustCacheKey(t, "foo", params("url", "https://github.com/tektoncd/catalog.git", "revision", "main"), conf)
+
+	if other := mustCacheKey(t, "foo", params("revision", "main", "url", "https://github.com/tektoncd/catalog.git"), conf); other != key {
+		t.Error("expected the order of the params not to change the cache key")
+	}
+	for name, other := range map[string]string{
+		"namespace": mustCacheKey(t, "bar", params("url", "https://github.com/tektoncd/catalog.git", "revision", "main"), conf),
+		"params":    mustCacheKey(t, "foo", params("url", "https://github.com/tektoncd/catalog.git", "revision", "v1"), conf),
+		"config":    mustCacheKey(t, "foo", params("url", "https://github.com/tektoncd/catalog.git", "revision", "main"), map[string]string{}),
+	} {
+		if other == key {
+			t.Errorf("expected another %s to change the cache key", name)
+		}
+	}
+}
+
+func TestResultCache(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clock.NewFakeClock(now)
+	c := newResultCache(defaultResultCacheSize, fakeClock)
+
+	c.add("main", resource("main content", "abc"), time.Minute)
+	c.add("tag", resource("tag content", "abc"), 10*time.Minute)
+	if got, ok := c.get("main"); !ok || string(got.Data()) != "main content" {
+		t.Fatalf("expected the cached result, got %v, %t", got, ok)
+	}
+
+	// The result expires after its TTL.
+	fakeClock.Step(2 * time.Minute)
+	if _, ok := c.get("main"); ok {
+		t.Error("expected the result to expire")
+	}
+	if _, ok := c.get("tag"); !ok {
+		t.Error("expected the result with a longer TTL to be cached")
+	}
+
+	// Resolving the source to a new digest invalidates the results of
+	// the older digest.
+	c.add("main", resource("new content", "def"), time.Minute)
+	if _, ok := c.get("tag"); ok {
+		t.Error("expected the result resolved from an older digest to be invalidated")
+	}
+	if got, ok := c.get("main"); !ok || string(got.Data()) != "new content" {
+		t.Errorf("expected the new result, got %v, %t", got, ok)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	r := &Reconciler{CacheTTL: time.Minute}
+	for _, tc := range []struct {
+		name string
+		conf map[string]string
+		want time.Duration
+	}{{
+		name: "default",
+		want: time.Minute,
+	}, {
+		name: "configured",
+		conf: map[string]string{ConfigCacheTTL: "5m"},
+		want: 5 * time.Minute,
+	}, {
+		name: "disabled",
+		conf: map[string]string{ConfigCacheTTL: "0"},
+		want: 0,
+	}, {
+		name: "invalid",
+		conf: map[string]string{ConfigCacheTTL: "forever"},
+		want: time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := InjectResolverConfigToContext(context.Background(), tc.conf)
+			if got := r.cacheTTL(ctx); got != tc.want {
+				t.Errorf("expected a TTL of %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -65,6 +65,7 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 			resolutionRequestLister:    rrInformer.Lister(),
 			resolutionRequestClientSet: rrclientset,
 			resolver:                   resolver,
+			retries:                    newRetryTracker(),
 		}
 
 		watchConfigChanges(ctx, r, cmw)
@@ -76,17 +77,29 @@ func NewController(ctx context.Context, resolver Resolver, modifiers ...Reconcil
 
 		applyModifiersAndDefaults(ctx, r, modifiers)
 
+		if err := registerViews(); err != nil {
+			logger.Errorf("Failed to register resolver metrics views: %v", err)
+		}
+		r.queue = newFairQueue(r.MaxPendingRequestsPerNamespace)
+		r.metrics = &queueMetrics{resolverName: resolverName}
+		r.cache = newResultCache(defaultResultCacheSize, r.Clock)
+
 		impl := controller.NewContext(ctx, r, controller.ControllerOptions{
 			WorkQueueName: "TektonResolverFramework." + resolverName,
 			Logger:        logger,
 		})
 
+		enqueue := r.queue.enqueueFunc(impl, func(rr *v1beta1.ResolutionRequest, lane string) {
+			r.metrics.recordEnqueued(ctx, requestPriority(rr), lane)
+			r.metrics.recordPending(ctx, r.queue.len())
+		})
+
 		rrInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 			FilterFunc: filterResolutionRequestsBySelector(resolver.GetSelector(ctx)),
 			Handler: cache.ResourceEventHandlerFuncs{
-				AddFunc: impl.Enqueue,
+				AddFunc: enqueue,
 				UpdateFunc: func(oldObj, newObj interface{}) {
-					impl.Enqueue(newObj)
+					enqueue(newObj)
 				},
 				// TODO(sbwsg): should we deliver delete events
 				// to the resolver?
@@ -190,4 +203,8 @@ func applyModifiersAndDefaults(ctx context.Context, r *Reconciler, modifiers []R
 	if r.Clock == nil {
 		r.Clock = clock.RealClock{}
 	}
+
+	if r.MaxPendingRequestsPerNamespace == 0 {
+		r.MaxPendingRequestsPerNamespace = defaultMaximumPendingRequestsPerNamespace
+	}
 }
@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// defaultMaximumPendingRequestsPerNamespace is the number of requests
+// a single namespace may have waiting for a resolver before any
+// further requests from that namespace are moved to the slow queue.
+const defaultMaximumPendingRequestsPerNamespace = 10
+
+const (
+	laneFast = "fast"
+	laneSlow = "slow"
+)
+
+// enqueuer is the subset of *controller.Impl used to place requests
+// on the resolver's two-lane work queue.
+type enqueuer interface {
+	Enqueue(obj interface{})
+	EnqueueSlow(obj interface{})
+}
+
+// requestPriority returns the priority of a ResolutionRequest as given
+// by its priority label, defaulting to normal priority if the label is
+// missing or has an unknown value.
+func requestPriority(rr *v1beta1.ResolutionRequest) string {
+	switch p := rr.Labels[common.LabelKeyPriority]; p {
+	case common.PriorityHigh, common.PriorityLow:
+		return p
+	default:
+		return common.PriorityNormal
+	}
+}
+
+// fairQueue tracks the ResolutionRequests that are waiting for a
+// resolver, grouped by namespace, and decides which lane of the work
+// queue each one is placed on. High priority requests always use the
+// fast lane and low priority requests always use the slow lane. Normal
+// priority requests use the fast lane until their namespace has more
+// than maxPendingPerNamespace requests waiting, at which point they are
+// moved to the slow lane so that one busy namespace cannot starve the
+// others.
+type fairQueue struct {
+	mu                     sync.Mutex
+	maxPendingPerNamespace int
+	pending                map[string]map[string]struct{}
+}
+
+func newFairQueue(maxPendingPerNamespace int) *fairQueue {
+	return &fairQueue{
+		maxPendingPerNamespace: maxPendingPerNamespace,
+		pending:                map[string]map[string]struct{}{},
+	}
+}
+
+// add records rr as pending and returns the lane it should be placed
+// on.
+func (q *fairQueue) add(rr *v1beta1.ResolutionRequest) string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names, ok := q.pending[rr.Namespace]
+	if !ok {
+		names = map[string]struct{}{}
+		q.pending[rr.Namespace] = names
+	}
+	names[rr.Name] = struct{}{}
+
+	switch requestPriority(rr) {
+	case common.PriorityHigh:
+		return laneFast
+	case common.PriorityLow:
+		return laneSlow
+	}
+	if q.maxPendingPerNamespace > 0 && len(names) > q.maxPendingPerNamespace {
+		return laneSlow
+	}
+	return laneFast
+}
+
+// done removes a request from the set of pending requests.
+func (q *fairQueue) done(key types.NamespacedName) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	names, ok := q.pending[key.Namespace]
+	if !ok {
+		return
+	}
+	delete(names, key.Name)
+	if len(names) == 0 {
+		delete(q.pending, key.Namespace)
+	}
+}
+
+// len returns the total number of pending requests.
+func (q *fairQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	total := 0
+	for _, names := range q.pending {
+		total += len(names)
+	}
+	return total
+}
+
+// enqueueFunc returns an informer handler func that places incoming
+// ResolutionRequests on the fast or slow lane of impl's work queue.
+func (q *fairQueue) enqueueFunc(impl enqueuer, onEnqueue func(rr *v1beta1.ResolutionRequest, lane string)) func(obj interface{}) {
+	return func(obj interface{}) {
+		rr, ok := obj.(*v1beta1.ResolutionRequest)
+		if !ok {
+			impl.Enqueue(obj)
+			return
+		}
+		if rr.IsDone() {
+			q.done(types.NamespacedName{Namespace: rr.Namespace, Name: rr.Name})
+			return
+		}
+		lane := q.add(rr)
+		if onEnqueue != nil {
+			onEnqueue(rr, lane)
+		}
+		if lane == laneSlow {
+			impl.EnqueueSlow(obj)
+			return
+		}
+		impl.Enqueue(obj)
+	}
+}
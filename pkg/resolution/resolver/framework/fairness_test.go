@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resolution/v1beta1"
+	rrclient "github.com/tektoncd/pipeline/pkg/client/resolution/injection/client"
+	rrinformer "github.com/tektoncd/pipeline/pkg/client/resolution/injection/informers/resolution/v1beta1/resolutionrequest"
+	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/test"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clock "k8s.io/utils/clock/testing"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+type fakeEnqueuer struct {
+	lanes []string
+}
+
+func (f *fakeEnqueuer) Enqueue(obj interface{}) {
+	f.lanes = append(f.lanes, name(obj)+"/"+laneFast)
+}
+
+func (f *fakeEnqueuer) EnqueueSlow(obj interface{}) {
+	f.lanes = append(f.lanes, name(obj)+"/"+laneSlow)
+}
+
+func name(obj interface{}) string {
+	return obj.(*v1beta1.ResolutionRequest).Name
+}
+
+func request(namespace, name, priority string) *v1beta1.ResolutionRequest {
+	rr := &v1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{},
+		},
+	}
+	if priority != "" {
+		rr.Labels[common.LabelKeyPriority] = priority
+	}
+	return rr
+}
+
+func TestFairQueue_Lanes(t *testing.T) {
+	q := newFairQueue(2)
+	impl := &fakeEnqueuer{}
+	enqueue := q.enqueueFunc(impl, nil)
+
+	for i := 0; i < 4; i++ {
+		enqueue(request("busy", fmt.Sprintf("busy-%d", i), ""))
+	}
+	enqueue(request("busy", "urgent", common.PriorityHigh))
+	enqueue(request("quiet", "quiet-0", ""))
+	enqueue(request("quiet", "background", common.PriorityLow))
+	// Re-enqueueing an existing request must not count it twice.
+	enqueue(request("quiet", "quiet-0", ""))
+
+	want := []string{
+		"busy-0/fast",
+		"busy-1/fast",
+		"busy-2/slow",
+		"busy-3/slow",
+		"urgent/fast",
+		"quiet-0/fast",
+		"background/slow",
+		"quiet-0/fast",
+	}
+	if d := cmp.Diff(want, impl.lanes); d != "" {
+		t.Errorf("unexpected lanes (-want, +got): %s", d)
+	}
+	if got := q.len(); got != 7 {
+		t.Errorf("expected 7 pending requests, got %d", got)
+	}
+}
+
+func TestFairQueue_Done(t *testing.T) {
+	q := newFairQueue(1)
+	impl := &fakeEnqueuer{}
+	enqueue := q.enqueueFunc(impl, nil)
+
+	enqueue(request("ns", "a", ""))
+	q.done(types.NamespacedName{Namespace: "ns", Name: "a"})
+	enqueue(request("ns", "b", ""))
+
+	done := request("ns", "b", "")
+	done.Status.Status = duckv1.Status{Conditions: duckv1.Conditions{{Type: apis.ConditionSucceeded, Status: "True"}}}
+	enqueue(done)
+	enqueue(request("ns", "c", ""))
+
+	want := []string{"a/fast", "b/fast", "c/fast"}
+	if d := cmp.Diff(want, impl.lanes); d != "" {
+		t.Errorf("unexpected lanes (-want, +got): %s", d)
+	}
+	if got := q.len(); got != 1 {
+		t.Errorf("expected 1 pending request, got %d", got)
+	}
+}
+
+func TestFairQueue_Unlimited(t *testing.T) {
+	q := newFairQueue(-1)
+	impl := &fakeEnqueuer{}
+	enqueue := q.enqueueFunc(impl, nil)
+
+	for i := 0; i < 3; i++ {
+		enqueue(request("ns", fmt.Sprintf("rr-%d", i), ""))
+	}
+	want := []string{"rr-0/fast", "rr-1/fast", "rr-2/fast"}
+	if d := cmp.Diff(want, impl.lanes); d != "" {
+		t.Errorf("unexpected lanes (-want, +got): %s", d)
+	}
+}
+
+func TestRequestPriority(t *testing.T) {
+	for _, tc := range []struct {
+		label string
+		want  string
+	}{
+		{label: "", want: common.PriorityNormal},
+		{label: "bogus", want: common.PriorityNormal},
+		{label: common.PriorityHigh, want: common.PriorityHigh},
+		{label: common.PriorityLow, want: common.PriorityLow},
+	} {
+		if got := requestPriority(request("ns", "rr", tc.label)); got != tc.want {
+			t.Errorf("requestPriority(%q) = %q, want %q", tc.label, got, tc.want)
+		}
+	}
+}
+
+func TestReconcile_RequeuedRequestStaysPending(t *testing.T) {
+	rr := request("ns", "rr", "")
+	rr.Spec.Params = pipelinev1beta1.Params{{
+		Name:  FakeParamName,
+		Value: *pipelinev1beta1.NewStructuredValues("bar"),
+	}, {
+		Name:  ConfigResolutionRetries,
+		Value: *pipelinev1beta1.NewStructuredValues("1"),
+	}, {
+		Name:  ConfigResolutionRetryBackoff,
+		Value: *pipelinev1beta1.NewStructuredValues("10s"),
+	}}
+	ctx, _ := ttesting.SetupFakeContext(t)
+	test.SeedTestData(t, ctx, test.Data{ResolutionRequests: []*v1beta1.ResolutionRequest{rr}})
+	fakeClock := clock.NewFakePassiveClock(time.Now())
+	r := &Reconciler{
+		Clock: fakeClock,
+		resolver: &FakeResolver{ForParam: map[string]*FakeResolvedResource{
+			"bar": {ErrorWith: "fake failure"},
+		}},
+		resolutionRequestLister:    rrinformer.Get(ctx).Lister(),
+		resolutionRequestClientSet: rrclient.Get(ctx),
+		queue:                      newFairQueue(defaultMaximumPendingRequestsPerNamespace),
+		retries:                    newRetryTracker(),
+	}
+	r.queue.add(rr)
+
+	err := r.Reconcile(ctx, "ns/rr")
+	if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Fatalf("expected the request to be requeued, got %v", err)
+	}
+	if got := r.queue.len(); got != 1 {
+		t.Errorf("expected the requeued request to still be pending, got %d pending requests", got)
+	}
+
+	fakeClock.SetTime(fakeClock.Now().Add(10 * time.Second))
+	if err := r.Reconcile(ctx, "ns/rr"); !controller.IsPermanentError(err) {
+		t.Fatalf("expected the request to fail, got %v", err)
+	}
+	if got := r.queue.len(); got != 0 {
+		t.Errorf("expected the failed request to be done, got %d pending requests", got)
+	}
+}
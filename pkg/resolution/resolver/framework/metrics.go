@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	statusSuccess = "success"
+	statusFailed  = "failed"
+)
+
+var (
+	resolverTag = tag.MustNewKey("resolver")
+	priorityTag = tag.MustNewKey("priority")
+	laneTag     = tag.MustNewKey("lane")
+	statusTag   = tag.MustNewKey("status")
+
+	rrEnqueuedCount = stats.Float64("resolutionrequest_enqueued_count",
+		"number of resolutionrequests placed on a resolver's queue",
+		stats.UnitDimensionless)
+
+	rrPendingCount = stats.Float64("resolutionrequest_pending_count",
+		"number of resolutionrequests waiting to be resolved",
+		stats.UnitDimensionless)
+
+	rrCacheHitCount = stats.Float64("resolutionrequest_cache_hit_count",
+		"number of resolutionrequests resolved from a resolver's result cache",
+		stats.UnitDimensionless)
+
+	rrRetryCount = stats.Float64("resolutionrequest_retry_count",
+		"number of failed resolutions of resolutionrequests retried by a resolver",
+		stats.UnitDimensionless)
+
+	rrDuration = stats.Float64("resolutionrequest_duration_seconds",
+		"the time taken by a resolver to resolve a resolutionrequest in seconds",
+		stats.UnitDimensionless)
+
+	registerViewsOnce sync.Once
+	errRegistering    error
+)
+
+// registerViews registers the resolver framework's metric views. It is
+// safe to call multiple times.
+func registerViews() error {
+	registerViewsOnce.Do(func() {
+		errRegistering = view.Register(
+			&view.View{
+				Description: rrEnqueuedCount.Description(),
+				Measure:     rrEnqueuedCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{resolverTag, priorityTag, laneTag},
+			},
+			&view.View{
+				Description: rrPendingCount.Description(),
+				Measure:     rrPendingCount,
+				Aggregation: view.LastValue(),
+				TagKeys:     []tag.Key{resolverTag},
+			},
+			&view.View{
+				Description: rrCacheHitCount.Description(),
+				Measure:     rrCacheHitCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{resolverTag},
+			},
+			&view.View{
+				Description: rrRetryCount.Description(),
+				Measure:     rrRetryCount,
+				Aggregation: view.Count(),
+				TagKeys:     []tag.Key{resolverTag, priorityTag},
+			},
+			&view.View{
+				Description: rrDuration.Description(),
+				Measure:     rrDuration,
+				Aggregation: view.Distribution(0.1, 0.5, 1, 5, 10, 30, 60),
+				TagKeys:     []tag.Key{resolverTag, priorityTag, statusTag},
+			},
+		)
+	})
+	return errRegistering
+}
+
+// queueMetrics records queue and latency metrics for a single resolver.
+type queueMetrics struct {
+	resolverName string
+}
+
+func (m *queueMetrics) recordEnqueued(ctx context.Context, priority, lane string) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(resolverTag, m.resolverName),
+		tag.Insert(priorityTag, priority),
+		tag.Insert(laneTag, lane))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rrEnqueuedCount.M(1))
+}
+
+func (m *queueMetrics) recordPending(ctx context.Context, pending int) {
+	ctx, err := tag.New(ctx, tag.Insert(resolverTag, m.resolverName))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rrPendingCount.M(float64(pending)))
+}
+
+func (m *queueMetrics) recordCacheHit(ctx context.Context) {
+	ctx, err := tag.New(ctx, tag.Insert(resolverTag, m.resolverName))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rrCacheHitCount.M(1))
+}
+
+func (m *queueMetrics) recordRetry(ctx context.Context, priority string) {
+	ctx, err := tag.New(ctx,
+		tag.Insert(resolverTag, m.resolverName),
+		tag.Insert(priorityTag, priority))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rrRetryCount.M(1))
+}
+
+func (m *queueMetrics) recordDuration(ctx context.Context, priority string, duration time.Duration, failed bool) {
+	status := statusSuccess
+	if failed {
+		status = statusFailed
+	}
+	ctx, err := tag.New(ctx,
+		tag.Insert(resolverTag, m.resolverName),
+		tag.Insert(priorityTag, priority),
+		tag.Insert(statusTag, status))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, rrDuration.M(duration.Seconds()))
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/logging"
+)
+
+// The keys of a resolver's configmap, which are also the names of the
+// params of a request, configuring how the framework resolves the
+// requests. The params of a request take precedence over the configmap,
+// and are removed from the params given to the resolver.
+const (
+	// ConfigResolutionTimeout is the timeout of each attempt to
+	// resolve a request, e.g. "30s".
+	ConfigResolutionTimeout = "resolution-timeout"
+
+	// ConfigResolutionRetries is the number of times a failed
+	// resolution is retried, e.g. "3". Invalid requests are never
+	// retried.
+	ConfigResolutionRetries = "resolution-retries"
+
+	// ConfigResolutionRetryBackoff is the time waited before the first
+	// retry, e.g. "1s". It doubles with every further retry.
+	ConfigResolutionRetryBackoff = "resolution-retry-backoff"
+)
+
+// defaultResolutionRetryBackoff is the time waited before the first
+// retry of a failed resolution.
+const defaultResolutionRetryBackoff = time.Second
+
+// resolutionPolicy is the timeout, retries and backoff with which a
+// request is resolved.
+type resolutionPolicy struct {
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+}
+
+// backoffAfter returns the time to wait before retrying after the given
+// number of failed attempts.
+func (p resolutionPolicy) backoffAfter(attempts int) time.Duration {
+	backoff := p.backoff
+	for i := 1; i < attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	return backoff
+}
+
+// resolutionPolicy returns the policy with which the request is resolved,
+// from the resolver's configmap overridden by the params of the request,
+// along with the params of the request without the policy params.
+func (r *Reconciler) resolutionPolicy(ctx context.Context, params []pipelinev1beta1.Param) (resolutionPolicy, []pipelinev1beta1.Param, error) {
+	policy := resolutionPolicy{
+		timeout: defaultMaximumResolutionDuration,
+		backoff: defaultResolutionRetryBackoff,
+	}
+	if timed, ok := r.resolver.(TimedResolution); ok {
+		policy.timeout = timed.GetResolutionTimeout(ctx, defaultMaximumResolutionDuration)
+	}
+
+	conf := GetResolverConfigFromContext(ctx)
+	for _, key := range []string{ConfigResolutionTimeout, ConfigResolutionRetries, ConfigResolutionRetryBackoff} {
+		if v, ok := conf[key]; ok {
+			if err := policy.set(key, v); err != nil {
+				logging.FromContext(ctx).Warnf("invalid %s in the resolver config: %v", key, err)
+			}
+		}
+	}
+
+	var resolverParams []pipelinev1beta1.Param
+	for _, p := range params {
+		switch p.Name {
+		case ConfigResolutionTimeout, ConfigResolutionRetries, ConfigResolutionRetryBackoff:
+			if err := policy.set(p.Name, p.Value.StringVal); err != nil {
+				return policy, nil, fmt.Errorf("invalid param %s: %w", p.Name, err)
+			}
+		default:
+			resolverParams = append(resolverParams, p)
+		}
+	}
+	return policy, resolverParams, nil
+}
+
+func (p *resolutionPolicy) set(key, value string) error {
+	switch key {
+	case ConfigResolutionRetries:
+		retries, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if retries < 0 {
+			return fmt.Errorf("%d must not be negative", retries)
+		}
+		p.retries = retries
+		return nil
+	default:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s must be positive", value)
+		}
+		if key == ConfigResolutionTimeout {
+			p.timeout = d
+		} else {
+			p.backoff = d
+		}
+		return nil
+	}
+}
+
+// retryTracker keeps the number of failed attempts to resolve each
+// request, and the time before which it must not be attempted again.
+type retryTracker struct {
+	mu       sync.Mutex
+	requests map[types.NamespacedName]*retryState
+}
+
+type retryState struct {
+	attempts int
+	next     time.Time
+}
+
+func newRetryTracker() *retryTracker {
+	return &retryTracker{requests: map[types.NamespacedName]*retryState{}}
+}
+
+// wait returns how long the request must still wait before it is
+// attempted again.
+func (t *retryTracker) wait(key types.NamespacedName, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if s, ok := t.requests[key]; ok && s.next.After(now) {
+		return s.next.Sub(now)
+	}
+	return 0
+}
+
+// fail records a failed attempt to resolve the request and returns the
+// time to wait before retrying it, or false if it ran out of retries.
+func (t *retryTracker) fail(key types.NamespacedName, policy resolutionPolicy, now time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	s, ok := t.requests[key]
+	if !ok {
+		s = &retryState{}
+		t.requests[key] = s
+	}
+	s.attempts++
+	if s.attempts > policy.retries {
+		return 0, false
+	}
+	backoff := policy.backoffAfter(s.attempts)
+	s.next = now.Add(backoff)
+	return backoff, true
+}
+
+// done forgets the request and returns the number of attempts made to
+// resolve it.
+func (t *retryTracker) done(key types.NamespacedName) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	attempts := 1
+	if s, ok := t.requests[key]; ok {
+		attempts = s.attempts
+	}
+	delete(t.requests, key)
+	return attempts
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	pipelinev1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResolutionPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		resolver       Resolver
+		conf           map[string]string
+		params         []pipelinev1beta1.Param
+		expectedPolicy resolutionPolicy
+		expectedParams []pipelinev1beta1.Param
+		expectedErr    string
+	}{{
+		name:           "defaults",
+		resolver:       &FakeResolver{},
+		params:         params(FakeParamName, "bar"),
+		expectedPolicy: resolutionPolicy{timeout: time.Minute, backoff: time.Second},
+		expectedParams: params(FakeParamName, "bar"),
+	}, {
+		name:           "timed resolution",
+		resolver:       &FakeResolver{Timeout: 10 * time.Second},
+		params:         params(FakeParamName, "bar"),
+		expectedPolicy: resolutionPolicy{timeout: 10 * time.Second, backoff: time.Second},
+		expectedParams: params(FakeParamName, "bar"),
+	}, {
+		name:     "resolver config",
+		resolver: &FakeResolver{Timeout: 10 * time.Second},
+		conf: map[string]string{
+			ConfigResolutionTimeout:      "20s",
+			ConfigResolutionRetries:      "3",
+			ConfigResolutionRetryBackoff: "5s",
+		},
+		params:         params(FakeParamName, "bar"),
+		expectedPolicy: resolutionPolicy{timeout: 20 * time.Second, retries: 3, backoff: 5 * time.Second},
+		expectedParams: params(FakeParamName, "bar"),
+	}, {
+		name:     "invalid resolver config is ignored",
+		resolver: &FakeResolver{},
+		conf: map[string]string{
+			ConfigResolutionRetries: "-1",
+		},
+		params:         params(FakeParamName, "bar"),
+		expectedPolicy: resolutionPolicy{timeout: time.Minute, backoff: time.Second},
+		expectedParams: params(FakeParamName, "bar"),
+	}, {
+		name:     "request params take precedence and are removed",
+		resolver: &FakeResolver{},
+		conf: map[string]string{
+			ConfigResolutionRetries: "3",
+		},
+		params:         params(FakeParamName, "bar", ConfigResolutionRetries, "1", ConfigResolutionTimeout, "5s"),
+		expectedPolicy: resolutionPolicy{timeout: 5 * time.Second, retries: 1, backoff: time.Second},
+		expectedParams: params(FakeParamName, "bar"),
+	}, {
+		name:        "invalid request param",
+		resolver:    &FakeResolver{},
+		params:      params(FakeParamName, "bar", ConfigResolutionRetryBackoff, "0s"),
+		expectedErr: "invalid param resolution-retry-backoff: 0s must be positive",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &Reconciler{resolver: tc.resolver}
+			ctx := InjectResolverConfigToContext(context.Background(), tc.conf)
+			policy, resolverParams, err := r.resolutionPolicy(ctx, tc.params)
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("expected error %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedPolicy, policy, cmp.AllowUnexported(resolutionPolicy{})); d != "" {
+				t.Errorf("unexpected policy: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedParams, resolverParams); d != "" {
+				t.Errorf("unexpected params: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestRetryTracker(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	key := types.NamespacedName{Namespace: "foo", Name: "rr"}
+	policy := resolutionPolicy{retries: 2, backoff: time.Second}
+	tracker := newRetryTracker()
+
+	if wait := tracker.wait(key, now); wait != 0 {
+		t.Errorf("expected a new request not to wait, got %v", wait)
+	}
+	for i, want := range []time.Duration{time.Second, 2 * time.Second} {
+		backoff, ok := tracker.fail(key, policy, now)
+		if !ok || backoff != want {
+			t.Fatalf("expected retry %d after %v, got %v, %t", i+1, want, backoff, ok)
+		}
+		if wait := tracker.wait(key, now.Add(backoff/2)); wait != backoff/2 {
+			t.Errorf("expected to wait %v before retry %d, got %v", backoff/2, i+1, wait)
+		}
+		now = now.Add(backoff)
+	}
+	if _, ok := tracker.fail(key, policy, now); ok {
+		t.Error("expected the request to run out of retries")
+	}
+	if attempts := tracker.done(key); attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+	if attempts := tracker.done(key); attempts != 1 {
+		t.Errorf("expected the request to be forgotten, got %d attempts", attempts)
+	}
+}
@@ -50,12 +50,29 @@ type Reconciler struct {
 	// and can be overridden for tests.
 	Clock clock.PassiveClock
 
+	// MaxPendingRequestsPerNamespace is the number of requests a
+	// namespace may have waiting for this resolver before any further
+	// normal priority requests from it are moved to the slow queue.
+	// A negative value disables the limit.
+	MaxPendingRequestsPerNamespace int
+
+	// CacheTTL is the duration for which the results of this resolver
+	// are reused by identical requests. It can be overridden by the
+	// cache-ttl key of the resolver's configmap. Zero disables the
+	// cache.
+	CacheTTL time.Duration
+
 	resolver                   Resolver
 	kubeClientSet              kubernetes.Interface
 	resolutionRequestLister    rrv1beta1.ResolutionRequestLister
 	resolutionRequestClientSet rrclient.Interface
 
 	configStore *ConfigStore
+
+	queue   *fairQueue
+	metrics *queueMetrics
+	cache   *resultCache
+	retries *retryTracker
 }
 
 var _ reconciler.LeaderAware = &Reconciler{}
@@ -73,13 +90,28 @@ const defaultMaximumResolutionDuration = time.Minute
 // resolver-specific functionality to the reconciler's embedded
 // type-specific resolver. Any errors that occur during validation or
 // resolution are handled by updating or failing the ResolutionRequest.
-func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+func (r *Reconciler) Reconcile(ctx context.Context, key string) (err error) {
 	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
 		err = &resolutioncommon.InvalidResourceKeyError{Key: key, Original: err}
 		return controller.NewPermanentError(err)
 	}
 
+	if r.queue != nil {
+		defer func() {
+			// A request which is requeued, e.g. to be retried after a
+			// backoff, is still pending and keeps counting towards the
+			// requests of its namespace.
+			if !isTerminal(err) {
+				return
+			}
+			r.queue.done(types.NamespacedName{Namespace: namespace, Name: name})
+			if r.metrics != nil {
+				r.metrics.recordPending(ctx, r.queue.len())
+			}
+		}()
+	}
+
 	rr, err := r.resolutionRequestLister.ResolutionRequests(namespace).Get(name)
 	if err != nil {
 		err := &resolutioncommon.GetResourceError{ResolverName: "resolutionrequest", Key: key, Original: err}
@@ -87,6 +119,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	}
 
 	if rr.IsDone() {
+		r.retries.done(types.NamespacedName{Namespace: namespace, Name: name})
 		return nil
 	}
 
@@ -102,31 +135,68 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	return r.resolve(ctx, key, rr)
 }
 
+// isTerminal returns true if the reconciliation of a ResolutionRequest
+// which returned err is over, i.e. the request isn't requeued.
+func isTerminal(err error) bool {
+	if ok, _ := controller.IsRequeueKey(err); ok {
+		return false
+	}
+	return err == nil || controller.IsPermanentError(err)
+}
+
 func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.ResolutionRequest) error {
-	errChan := make(chan error)
-	resourceChan := make(chan ResolvedResource)
+	name := types.NamespacedName{Namespace: rr.Namespace, Name: rr.Name}
+	policy, params, err := r.resolutionPolicy(ctx, rr.Spec.Params)
+	if err != nil {
+		return r.OnError(ctx, rr, resolutioncommon.NewError(resolutioncommon.ReasonResolutionInvalidRequest, &resolutioncommon.InvalidRequestError{
+			ResolutionRequestKey: key,
+			Message:              err.Error(),
+		}))
+	}
+	// A request waiting to be retried may be reconciled early, e.g.
+	// when it's updated.
+	if wait := r.retries.wait(name, r.Clock.Now()); wait > 0 {
+		return controller.NewRequeueAfter(wait)
+	}
 
-	timeoutDuration := defaultMaximumResolutionDuration
-	if timed, ok := r.resolver.(TimedResolution); ok {
-		timeoutDuration = timed.GetResolutionTimeout(ctx, defaultMaximumResolutionDuration)
+	// Identical requests within the cache TTL reuse the previous
+	// result without reaching the resolver's backend.
+	var cacheKey string
+	cacheTTL := r.cacheTTL(ctx)
+	if r.cache != nil && cacheTTL > 0 {
+		cacheKey, err = resultCacheKey(rr.Namespace, params, GetResolverConfigFromContext(ctx))
+		if err != nil {
+			logging.FromContext(ctx).Warnf("error computing the cache key of resolutionrequest %q: %v", key, err)
+		} else if resource, ok := r.cache.get(cacheKey); ok {
+			if r.metrics != nil {
+				r.metrics.recordCacheHit(ctx)
+			}
+			r.retries.done(name)
+			return r.writeResolvedData(ctx, rr, resource)
+		}
 	}
 
+	// The channels are buffered so that the resolution doesn't block
+	// forever once it has timed out.
+	errChan := make(chan error, 1)
+	resourceChan := make(chan ResolvedResource, 1)
+
 	// A new context is created for resolution so that timeouts can
 	// be enforced without affecting other uses of ctx (e.g. sending
 	// Updates to ResolutionRequest objects).
-	resolutionCtx, cancelFn := context.WithTimeout(ctx, timeoutDuration)
+	resolutionCtx, cancelFn := context.WithTimeout(ctx, policy.timeout)
 	defer cancelFn()
 
 	go func() {
-		validationError := r.resolver.ValidateParams(resolutionCtx, rr.Spec.Params)
+		validationError := r.resolver.ValidateParams(resolutionCtx, params)
 		if validationError != nil {
-			errChan <- &resolutioncommon.InvalidRequestError{
+			errChan <- resolutioncommon.NewError(resolutioncommon.ReasonResolutionInvalidRequest, &resolutioncommon.InvalidRequestError{
 				ResolutionRequestKey: key,
 				Message:              validationError.Error(),
-			}
+			})
 			return
 		}
-		resource, resolveErr := r.resolver.Resolve(resolutionCtx, rr.Spec.Params)
+		resource, resolveErr := r.resolver.Resolve(resolutionCtx, params)
 		if resolveErr != nil {
 			errChan <- &resolutioncommon.GetResourceError{
 				ResolverName: r.resolver.GetName(resolutionCtx),
@@ -138,22 +208,59 @@ func (r *Reconciler) resolve(ctx context.Context, key string, rr *v1beta1.Resolu
 		resourceChan <- resource
 	}()
 
+	start := r.Clock.Now()
 	select {
 	case err := <-errChan:
 		if err != nil {
-			return r.OnError(ctx, rr, err)
+			r.recordDuration(ctx, rr, start, true)
+			return r.retryOrFail(ctx, rr, policy, err)
 		}
 	case <-resolutionCtx.Done():
 		if err := resolutionCtx.Err(); err != nil {
-			return r.OnError(ctx, rr, err)
+			r.recordDuration(ctx, rr, start, true)
+			return r.retryOrFail(ctx, rr, policy, resolutioncommon.NewError(resolutioncommon.ReasonResolutionTimedOut, err))
 		}
 	case resource := <-resourceChan:
+		r.recordDuration(ctx, rr, start, false)
+		r.retries.done(name)
+		if cacheKey != "" {
+			r.cache.add(cacheKey, resource, cacheTTL)
+		}
 		return r.writeResolvedData(ctx, rr, resource)
 	}
 
 	return errors.New("unknown error")
 }
 
+// retryOrFail requeues a request whose resolution failed to be retried
+// after a backoff, or fails it when it's invalid or has run out of
+// retries.
+func (r *Reconciler) retryOrFail(ctx context.Context, rr *v1beta1.ResolutionRequest, policy resolutionPolicy, err error) error {
+	name := types.NamespacedName{Namespace: rr.Namespace, Name: rr.Name}
+	var invalidErr *resolutioncommon.InvalidRequestError
+	if !errors.As(err, &invalidErr) {
+		if backoff, ok := r.retries.fail(name, policy, r.Clock.Now()); ok {
+			logging.FromContext(ctx).Infof("retrying resolutionrequest %q in %s: %v", name, backoff, err)
+			if r.metrics != nil {
+				r.metrics.recordRetry(ctx, requestPriority(rr))
+			}
+			return controller.NewRequeueAfter(backoff)
+		}
+	}
+	if attempts := r.retries.done(name); attempts > 1 {
+		reason, original := resolutioncommon.ReasonError(err)
+		err = resolutioncommon.NewError(reason, fmt.Errorf("%w (after %d attempts)", original, attempts))
+	}
+	return r.OnError(ctx, rr, err)
+}
+
+func (r *Reconciler) recordDuration(ctx context.Context, rr *v1beta1.ResolutionRequest, start time.Time, failed bool) {
+	if r.metrics == nil {
+		return
+	}
+	r.metrics.recordDuration(ctx, requestPriority(rr), r.Clock.Since(start), failed)
+}
+
 // OnError is used to handle any situation where a ResolutionRequest has
 // reached a terminal situation that cannot be recovered from.
 func (r *Reconciler) OnError(ctx context.Context, rr *v1beta1.ResolutionRequest, err error) error {
@@ -287,3 +287,156 @@ func setClockOnReconciler(r *framework.Reconciler) {
 		r.Clock = testClock
 	}
 }
+
+func TestReconcile_CachedResult(t *testing.T) {
+	newRequest := func(name string) *v1beta1.ResolutionRequest {
+		return &v1beta1.ResolutionRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "foo",
+				CreationTimestamp: metav1.Time{Time: time.Now()},
+				Labels: map[string]string{
+					resolutioncommon.LabelKeyResolverType: framework.LabelValueFakeResolverType,
+				},
+			},
+			Spec: v1beta1.ResolutionRequestSpec{
+				Params: []pipelinev1beta1.Param{{
+					Name:  framework.FakeParamName,
+					Value: *pipelinev1beta1.NewStructuredValues("bar"),
+				}},
+			},
+		}
+	}
+	first, second := newRequest("first"), newRequest("second")
+	d := test.Data{
+		ResolutionRequests: []*v1beta1.ResolutionRequest{first, second},
+	}
+	fakeResolver := &framework.FakeResolver{ForParam: map[string]*framework.FakeResolvedResource{
+		"bar": {Content: "some content"},
+	}}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := getResolverFrameworkController(ctx, t, d, fakeResolver, setClockOnReconciler, func(r *framework.Reconciler) {
+		r.CacheTTL = time.Minute
+	})
+	defer cancel()
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(first)); err != nil {
+		t.Fatalf("did not expect an error, but got %v", err)
+	}
+	// The identical request reuses the result without calling the
+	// resolver again.
+	fakeResolver.ForParam["bar"] = &framework.FakeResolvedResource{ErrorWith: "fake failure"}
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(second)); err != nil {
+		t.Fatalf("did not expect an error, but got %v", err)
+	}
+
+	reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("foo").Get(testAssets.Ctx, "second", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated ResolutionRequest: %v", err)
+	}
+	if d := cmp.Diff(base64.StdEncoding.Strict().EncodeToString([]byte("some content")), reconciledRR.Status.Data); d != "" {
+		t.Errorf("ResolutionRequest data doesn't match %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestReconcile_Retry(t *testing.T) {
+	rr := &v1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "rr",
+			Namespace:         "foo",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+			Labels: map[string]string{
+				resolutioncommon.LabelKeyResolverType: framework.LabelValueFakeResolverType,
+			},
+		},
+		Spec: v1beta1.ResolutionRequestSpec{
+			Params: []pipelinev1beta1.Param{{
+				Name:  framework.FakeParamName,
+				Value: *pipelinev1beta1.NewStructuredValues("bar"),
+			}, {
+				Name:  framework.ConfigResolutionRetries,
+				Value: *pipelinev1beta1.NewStructuredValues("1"),
+			}, {
+				Name:  framework.ConfigResolutionRetryBackoff,
+				Value: *pipelinev1beta1.NewStructuredValues("10s"),
+			}},
+		},
+	}
+	d := test.Data{
+		ResolutionRequests: []*v1beta1.ResolutionRequest{rr},
+	}
+	fakeResolver := &framework.FakeResolver{ForParam: map[string]*framework.FakeResolvedResource{
+		"bar": {ErrorWith: "fake failure"},
+	}}
+	fakeClock := clock.NewFakePassiveClock(now)
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := getResolverFrameworkController(ctx, t, d, fakeResolver, func(r *framework.Reconciler) {
+		r.Clock = fakeClock
+	})
+	defer cancel()
+
+	// The first failure is retried after the backoff, even if the
+	// request is reconciled again before.
+	for i := 0; i < 2; i++ {
+		err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+		if ok, delay := controller.IsRequeueKey(err); !ok || delay != 10*time.Second {
+			t.Fatalf("expected the request to be requeued after 10s, got %v", err)
+		}
+	}
+
+	fakeClock.SetTime(now.Add(10 * time.Second))
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+	expectedErr := `error getting "Fake" "foo/rr": fake failure (after 2 attempts)`
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected to get error %v, but got %v", expectedErr, err)
+	}
+	reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("foo").Get(testAssets.Ctx, "rr", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated ResolutionRequest: %v", err)
+	}
+	if reason := reconciledRR.Status.GetCondition(apis.ConditionSucceeded).GetReason(); reason != resolutioncommon.ReasonResolutionFailed {
+		t.Errorf("expected the ResolutionRequest to fail with reason %s, got %s", resolutioncommon.ReasonResolutionFailed, reason)
+	}
+}
+
+func TestReconcile_InvalidRequestReason(t *testing.T) {
+	rr := &v1beta1.ResolutionRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "rr",
+			Namespace:         "foo",
+			CreationTimestamp: metav1.Time{Time: time.Now()},
+			Labels: map[string]string{
+				resolutioncommon.LabelKeyResolverType: framework.LabelValueFakeResolverType,
+			},
+		},
+		Spec: v1beta1.ResolutionRequestSpec{
+			Params: []pipelinev1beta1.Param{{
+				Name:  framework.ConfigResolutionRetries,
+				Value: *pipelinev1beta1.NewStructuredValues("3"),
+			}},
+		},
+	}
+	d := test.Data{
+		ResolutionRequests: []*v1beta1.ResolutionRequest{rr},
+	}
+
+	ctx, _ := ttesting.SetupFakeContext(t)
+	testAssets, cancel := getResolverFrameworkController(ctx, t, d, &framework.FakeResolver{}, setClockOnReconciler)
+	defer cancel()
+
+	// Invalid requests are never retried.
+	err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRequestName(rr))
+	expectedErr := `invalid resource request "foo/rr": missing fake-key`
+	if err == nil || err.Error() != expectedErr {
+		t.Fatalf("expected to get error %v, but got %v", expectedErr, err)
+	}
+	reconciledRR, err := testAssets.Clients.ResolutionRequests.ResolutionV1beta1().ResolutionRequests("foo").Get(testAssets.Ctx, "rr", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("getting updated ResolutionRequest: %v", err)
+	}
+	if reason := reconciledRR.Status.GetCondition(apis.ConditionSucceeded).GetReason(); reason != resolutioncommon.ReasonResolutionInvalidRequest {
+		t.Errorf("expected the ResolutionRequest to fail with reason %s, got %s", resolutioncommon.ReasonResolutionInvalidRequest, reason)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"knative.dev/pkg/injection"
+)
+
+// ResolverInit is a function that initializes a resolver compiled into
+// the resolvers' deployment. It receives the deployment's context and
+// returns the resolver, or any error that was encountered.
+type ResolverInit func(context.Context) (Resolver, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]ResolverInit{}
+)
+
+// AddResolver registers a resolver to be run by the resolvers'
+// deployment along with the built-in resolvers. It is meant to be called
+// from the init function of the package implementing the resolver, so
+// that a distribution compiles the resolver in by importing its package
+// in the deployment's main package. It panics if a resolver is already
+// registered with the same name.
+func AddResolver(name string, init ResolverInit) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("resolver %q is already registered", name))
+	}
+	registry[name] = init
+}
+
+// RegisteredResolvers returns the names of the registered resolvers.
+func RegisteredResolvers() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewRegisteredControllers initializes the registered resolvers and
+// returns their controllers, in the order of their names.
+func NewRegisteredControllers(ctx context.Context) ([]injection.ControllerConstructor, error) {
+	var controllers []injection.ControllerConstructor
+	for _, name := range RegisteredResolvers() {
+		registryMu.Lock()
+		init := registry[name]
+		registryMu.Unlock()
+
+		resolver, err := init(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing resolver %q: %w", name, err)
+		}
+		if resolver == nil {
+			return nil, fmt.Errorf("resolver %q initialized to nil", name)
+		}
+		if err := validateResolver(ctx, resolver); err != nil {
+			return nil, fmt.Errorf("resolver %q: %w", name, err)
+		}
+		controllers = append(controllers, NewController(ctx, resolver))
+	}
+	return controllers, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// resetRegistry empties the registry for the duration of the test.
+func resetRegistry(t *testing.T) {
+	t.Helper()
+	registryMu.Lock()
+	saved := registry
+	registry = map[string]ResolverInit{}
+	registryMu.Unlock()
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registry = saved
+		registryMu.Unlock()
+	})
+}
+
+type noSelectorResolver struct {
+	FakeResolver
+}
+
+func (r *noSelectorResolver) GetSelector(context.Context) map[string]string {
+	return nil
+}
+
+func TestAddResolver(t *testing.T) {
+	resetRegistry(t)
+	newFake := func(context.Context) (Resolver, error) { return &FakeResolver{}, nil }
+
+	AddResolver("fake", newFake)
+	AddResolver("artifacts", newFake)
+	if d := cmp.Diff([]string{"artifacts", "fake"}, RegisteredResolvers()); d != "" {
+		t.Errorf("unexpected registered resolvers: %s", diff.PrintWantGot(d))
+	}
+
+	controllers, err := NewRegisteredControllers(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(controllers) != 2 {
+		t.Errorf("expected 2 controllers, got %d", len(controllers))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected registering a resolver twice to panic")
+		}
+	}()
+	AddResolver("fake", newFake)
+}
+
+func TestNewRegisteredControllers_Error(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		init        ResolverInit
+		expectedErr string
+	}{{
+		name:        "init error",
+		init:        func(context.Context) (Resolver, error) { return nil, errors.New("missing endpoint") },
+		expectedErr: `error initializing resolver "artifacts": missing endpoint`,
+	}, {
+		name:        "nil resolver",
+		init:        func(context.Context) (Resolver, error) { return nil, nil },
+		expectedErr: `resolver "artifacts" initialized to nil`,
+	}, {
+		name:        "invalid resolver",
+		init:        func(context.Context) (Resolver, error) { return &noSelectorResolver{}, nil },
+		expectedErr: `resolver "artifacts": invalid resolver: minimum selector must include "resolution.tekton.dev/type"`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resetRegistry(t)
+			AddResolver("artifacts", tc.init)
+			_, err := NewRegisteredControllers(context.Background())
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Errorf("expected error %q, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AuthTypeKey is the key of the namespace auth config map for the type of the authentication,
+	// either github-app or workload-identity.
+	AuthTypeKey = "auth-type"
+	// AuthUsernameKey is the key of the namespace auth config map for the username sent with the token
+	// when cloning. Defaults to x-access-token.
+	AuthUsernameKey = "username"
+
+	// GitHubAppIDKey is the key of the namespace auth config map for the ID of the GitHub App.
+	GitHubAppIDKey = "github-app-id"
+	// GitHubAppInstallationIDKey is the key of the namespace auth config map for the ID of the
+	// installation of the GitHub App.
+	GitHubAppInstallationIDKey = "github-app-installation-id"
+	// GitHubAppPrivateKeySecretNameKey is the key of the namespace auth config map for the name of the
+	// secret, in the same namespace, containing the private key of the GitHub App.
+	GitHubAppPrivateKeySecretNameKey = "github-app-private-key-secret-name"
+	// GitHubAppPrivateKeySecretKeyKey is the key of the namespace auth config map for the key containing
+	// the private key within the private key secret.
+	GitHubAppPrivateKeySecretKeyKey = "github-app-private-key-secret-key"
+	// GitHubAPIURLKey is the key of the namespace auth config map for the URL of the GitHub API.
+	// Defaults to https://api.github.com.
+	GitHubAPIURLKey = "github-api-url"
+
+	// ServiceAccountKey is the key of the namespace auth config map for the service account whose
+	// token is exchanged. Defaults to default.
+	ServiceAccountKey = "service-account"
+	// AudienceKey is the key of the namespace auth config map for the audience of the service account
+	// token.
+	AudienceKey = "audience"
+	// TokenExchangeURLKey is the key of the namespace auth config map for the URL of the OAuth 2.0
+	// token exchange endpoint issuing the git provider tokens.
+	TokenExchangeURLKey = "token-exchange-url"
+
+	authTypeGitHubApp        = "github-app"
+	authTypeWorkloadIdentity = "workload-identity"
+
+	defaultAuthUsername   = "x-access-token"
+	defaultGitHubAPIURL   = "https://api.github.com"
+	defaultServiceAccount = "default"
+
+	// gitHubAppJWTDuration is the validity of the JWT authenticating as the GitHub App, which GitHub
+	// limits to 10 minutes.
+	gitHubAppJWTDuration = 9 * time.Minute
+	// serviceAccountTokenExpirationSeconds is the validity of the service account tokens requested
+	// for the exchange.
+	serviceAccountTokenExpirationSeconds = int64(10 * 60)
+)
+
+// namespaceAuth is the token the git provider is authenticated with for the requests of a namespace.
+type namespaceAuth struct {
+	username string
+	token    string
+}
+
+type namespaceAuthCacheKey struct {
+	ns              string
+	name            string
+	resourceVersion string
+}
+
+// getNamespaceAuth returns the token issued for the namespace of the request as configured by the
+// namespace auth config map of the namespace. It returns nil when the namespace doesn't configure
+// its authentication, or when the namespace auth config map isn't set in the resolver's config.
+func (r *Resolver) getNamespaceAuth(ctx context.Context) (*namespaceAuth, error) {
+	conf := framework.GetResolverConfigFromContext(ctx)
+	name := conf[NamespaceAuthConfigMapKey]
+	if name == "" {
+		return nil, nil
+	}
+	namespace := resolutioncommon.RequestNamespace(ctx)
+
+	cm, err := r.kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading the git auth config map %s in namespace %s: %w", name, namespace, err)
+	}
+
+	cacheKey := namespaceAuthCacheKey{ns: namespace, name: name, resourceVersion: cm.ResourceVersion}
+	if val, ok := r.cache.Get(cacheKey); ok {
+		return val.(*namespaceAuth), nil
+	}
+
+	var token string
+	switch authType := cm.Data[AuthTypeKey]; authType {
+	case authTypeGitHubApp:
+		token, err = r.getGitHubAppToken(ctx, namespace, cm.Data)
+	case authTypeWorkloadIdentity:
+		token, err = r.exchangeServiceAccountToken(ctx, namespace, cm.Data)
+	default:
+		err = fmt.Errorf("unsupported %s %q, must be one of %s, %s", AuthTypeKey, authType, authTypeGitHubApp, authTypeWorkloadIdentity)
+	}
+	if err != nil {
+		wrappedErr := fmt.Errorf("cannot authenticate with the git auth config map %s in namespace %s: %w", name, namespace, err)
+		r.logger.Info(wrappedErr)
+		return nil, wrappedErr
+	}
+
+	auth := &namespaceAuth{username: cm.Data[AuthUsernameKey], token: token}
+	if auth.username == "" {
+		auth.username = defaultAuthUsername
+	}
+	r.cache.Add(cacheKey, auth, r.ttl)
+	return auth, nil
+}
+
+// getGitHubAppToken returns an installation access token of the GitHub App, authenticating as the
+// GitHub App with a JWT signed by its private key.
+func (r *Resolver) getGitHubAppToken(ctx context.Context, namespace string, conf map[string]string) (string, error) {
+	for _, key := range []string{GitHubAppIDKey, GitHubAppInstallationIDKey, GitHubAppPrivateKeySecretNameKey, GitHubAppPrivateKeySecretKeyKey} {
+		if conf[key] == "" {
+			return "", fmt.Errorf("'%s' not specified", key)
+		}
+	}
+	secretName, secretKey := conf[GitHubAppPrivateKeySecretNameKey], conf[GitHubAppPrivateKeySecretKeyKey]
+	secret, err := r.kubeClient.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("error reading the private key from secret %s: %w", secretName, err)
+	}
+	pemKey, ok := secret.Data[secretKey]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", secretKey, secretName)
+	}
+	privateKey, err := parseRSAPrivateKey(pemKey)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key in secret %s: %w", secretName, err)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: privateKey}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		return "", err
+	}
+	// The JWT is issued in the past to allow for the clock drift with GitHub.
+	now := time.Now()
+	appJWT, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Issuer:   conf[GitHubAppIDKey],
+		IssuedAt: jwt.NewNumericDate(now.Add(-time.Minute)),
+		Expiry:   jwt.NewNumericDate(now.Add(gitHubAppJWTDuration)),
+	}).CompactSerialize()
+	if err != nil {
+		return "", err
+	}
+
+	apiURL := conf[GitHubAPIURLKey]
+	if apiURL == "" {
+		apiURL = defaultGitHubAPIURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/app/installations/%s/access_tokens", strings.TrimSuffix(apiURL, "/"), url.PathEscape(conf[GitHubAppInstallationIDKey])), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+appJWT)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := doTokenRequest(req, &resp); err != nil {
+		return "", fmt.Errorf("cannot create the installation access token: %w", err)
+	}
+	if resp.Token == "" {
+		return "", fmt.Errorf("no installation access token returned by %s", apiURL)
+	}
+	return resp.Token, nil
+}
+
+// exchangeServiceAccountToken returns the git provider token issued by the OAuth 2.0 token exchange
+// endpoint for a token of the service account of the namespace.
+func (r *Resolver) exchangeServiceAccountToken(ctx context.Context, namespace string, conf map[string]string) (string, error) {
+	for _, key := range []string{AudienceKey, TokenExchangeURLKey} {
+		if conf[key] == "" {
+			return "", fmt.Errorf("'%s' not specified", key)
+		}
+	}
+	serviceAccount := conf[ServiceAccountKey]
+	if serviceAccount == "" {
+		serviceAccount = defaultServiceAccount
+	}
+	expirationSeconds := serviceAccountTokenExpirationSeconds
+	tokenRequest, err := r.kubeClient.CoreV1().ServiceAccounts(namespace).CreateToken(ctx, serviceAccount, &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			Audiences:         []string{conf[AudienceKey]},
+			ExpirationSeconds: &expirationSeconds,
+		},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot create a token for service account %s: %w", serviceAccount, err)
+	}
+
+	form := url.Values{
+		"grant_type":           {"urn:ietf:params:oauth:grant-type:token-exchange"},
+		"subject_token":        {tokenRequest.Status.Token},
+		"subject_token_type":   {"urn:ietf:params:oauth:token-type:jwt"},
+		"requested_token_type": {"urn:ietf:params:oauth:token-type:access_token"},
+		"audience":             {conf[AudienceKey]},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, conf[TokenExchangeURLKey], strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doTokenRequest(req, &resp); err != nil {
+		return "", fmt.Errorf("cannot exchange the token of service account %s: %w", serviceAccount, err)
+	}
+	if resp.AccessToken == "" {
+		return "", fmt.Errorf("no access token returned by %s", conf[TokenExchangeURLKey])
+	}
+	return resp.AccessToken, nil
+}
+
+// doTokenRequest sends the request and decodes the JSON body of its successful response into out.
+func doTokenRequest(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s returned status %s", req.Method, req.URL.Redacted(), resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// parseRSAPrivateKey parses the PEM encoded PKCS #1 or PKCS #8 RSA private key.
+func parseRSAPrivateKey(pemKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA private key")
+	}
+	return rsaKey, nil
+}
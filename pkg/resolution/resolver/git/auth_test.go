@@ -0,0 +1,234 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	resolutioncommon "github.com/tektoncd/pipeline/pkg/resolution/common"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2/jwt"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/cache"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+)
+
+func newAuthTestResolver(objects ...runtime.Object) *Resolver {
+	return &Resolver{
+		kubeClient: fakek8s.NewSimpleClientset(objects...),
+		logger:     zap.NewNop().Sugar(),
+		cache:      cache.NewLRUExpireCache(cacheSize),
+		ttl:        ttl,
+	}
+}
+
+func namespaceAuthContext(namespace string) context.Context {
+	ctx := framework.InjectResolverConfigToContext(context.Background(), map[string]string{
+		NamespaceAuthConfigMapKey: "git-auth",
+	})
+	return resolutioncommon.InjectRequestNamespace(ctx, namespace)
+}
+
+func authConfigMap(namespace string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-auth", Namespace: namespace},
+		Data:       data,
+	}
+}
+
+func TestGetNamespaceAuth_NotConfigured(t *testing.T) {
+	r := newAuthTestResolver(authConfigMap("other", map[string]string{AuthTypeKey: authTypeGitHubApp}))
+
+	for _, tc := range []struct {
+		name string
+		ctx  context.Context
+	}{{
+		name: "no namespace auth config map in the resolver config",
+		ctx:  resolutioncommon.InjectRequestNamespace(context.Background(), "other"),
+	}, {
+		name: "no namespace auth config map in the namespace",
+		ctx:  namespaceAuthContext("foo"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			auth, err := r.getNamespaceAuth(tc.ctx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if auth != nil {
+				t.Errorf("expected no namespace auth, got %+v", auth)
+			}
+		})
+	}
+}
+
+func TestGetNamespaceAuth_GitHubApp(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate the private key: %v", err)
+	}
+	pemKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/app/installations/42/access_tokens" {
+			http.NotFound(w, req)
+			return
+		}
+		token, err := jwt.ParseSigned(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		claims := jwt.Claims{}
+		if err := token.Claims(&privateKey.PublicKey, &claims); err != nil || claims.Issuer != "123" {
+			http.Error(w, fmt.Sprintf("invalid claims %+v: %v", claims, err), http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"token": "installation-token"}`)
+	}))
+	defer server.Close()
+
+	r := newAuthTestResolver(authConfigMap("foo", map[string]string{
+		AuthTypeKey:                      authTypeGitHubApp,
+		GitHubAppIDKey:                   "123",
+		GitHubAppInstallationIDKey:       "42",
+		GitHubAppPrivateKeySecretNameKey: "github-app",
+		GitHubAppPrivateKeySecretKeyKey:  "private-key",
+		GitHubAPIURLKey:                  server.URL,
+	}), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "foo"},
+		Data:       map[string][]byte{"private-key": pemKey},
+	})
+
+	auth, err := r.getNamespaceAuth(namespaceAuthContext("foo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := &namespaceAuth{username: defaultAuthUsername, token: "installation-token"}
+	if d := cmp.Diff(want, auth, cmp.AllowUnexported(namespaceAuth{})); d != "" {
+		t.Errorf("unexpected namespace auth: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetNamespaceAuth_WorkloadIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.PostForm.Get("subject_token") != "service-account-token" || req.PostForm.Get("audience") != "git.example.com" {
+			http.Error(w, "invalid token exchange request", http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "exchanged-token", "token_type": "Bearer"}`)
+	}))
+	defer server.Close()
+
+	r := newAuthTestResolver(authConfigMap("foo", map[string]string{
+		AuthTypeKey:         authTypeWorkloadIdentity,
+		AuthUsernameKey:     "oauth2",
+		ServiceAccountKey:   "builder",
+		AudienceKey:         "git.example.com",
+		TokenExchangeURLKey: server.URL,
+	}))
+	var tokenRequests []string
+	r.kubeClient.(*fakek8s.Clientset).PrependReactor("create", "serviceaccounts", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "token" {
+			return false, nil, nil
+		}
+		tokenRequests = append(tokenRequests, action.(ktesting.CreateAction).GetNamespace())
+		return true, &authenticationv1.TokenRequest{Status: authenticationv1.TokenRequestStatus{Token: "service-account-token"}}, nil
+	})
+
+	for i := 0; i < 2; i++ {
+		auth, err := r.getNamespaceAuth(namespaceAuthContext("foo"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := &namespaceAuth{username: "oauth2", token: "exchanged-token"}
+		if d := cmp.Diff(want, auth, cmp.AllowUnexported(namespaceAuth{})); d != "" {
+			t.Errorf("unexpected namespace auth: %s", diff.PrintWantGot(d))
+		}
+	}
+	// The exchanged token is cached.
+	if d := cmp.Diff([]string{"foo"}, tokenRequests); d != "" {
+		t.Errorf("unexpected token requests: %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGetNamespaceAuth_Failure(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		data        map[string]string
+		expectedErr string
+	}{{
+		name:        "unsupported auth type",
+		data:        map[string]string{AuthTypeKey: "ssh"},
+		expectedErr: `cannot authenticate with the git auth config map git-auth in namespace foo: unsupported auth-type "ssh", must be one of github-app, workload-identity`,
+	}, {
+		name: "missing github app installation",
+		data: map[string]string{
+			AuthTypeKey:    authTypeGitHubApp,
+			GitHubAppIDKey: "123",
+		},
+		expectedErr: "cannot authenticate with the git auth config map git-auth in namespace foo: 'github-app-installation-id' not specified",
+	}, {
+		name: "missing github app private key secret",
+		data: map[string]string{
+			AuthTypeKey:                      authTypeGitHubApp,
+			GitHubAppIDKey:                   "123",
+			GitHubAppInstallationIDKey:       "42",
+			GitHubAppPrivateKeySecretNameKey: "github-app",
+			GitHubAppPrivateKeySecretKeyKey:  "private-key",
+		},
+		expectedErr: `cannot authenticate with the git auth config map git-auth in namespace foo: error reading the private key from secret github-app: secrets "github-app" not found`,
+	}, {
+		name: "missing token exchange url",
+		data: map[string]string{
+			AuthTypeKey: authTypeWorkloadIdentity,
+			AudienceKey: "git.example.com",
+		},
+		expectedErr: "cannot authenticate with the git auth config map git-auth in namespace foo: 'token-exchange-url' not specified",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newAuthTestResolver(authConfigMap("foo", tc.data))
+			_, err := r.getNamespaceAuth(namespaceAuthContext("foo"))
+			if err == nil {
+				t.Fatalf("got no error, but expected: %s", tc.expectedErr)
+			}
+			if d := cmp.Diff(tc.expectedErr, err.Error()); d != "" {
+				t.Errorf("error did not match: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
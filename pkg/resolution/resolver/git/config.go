@@ -42,4 +42,7 @@ const (
 	APISecretKeyKey = "api-token-secret-key"
 	// APISecretNamespaceKey is the config map key for the token secret's namespace
 	APISecretNamespaceKey = "api-token-secret-namespace"
+	// NamespaceAuthConfigMapKey is the config map key for the name of the config map configuring, in the
+	// namespace of a request, the authentication to the SCM provider for the requests of that namespace
+	NamespaceAuthConfigMapKey = "namespace-auth-config-map"
 )
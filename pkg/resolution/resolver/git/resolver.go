@@ -30,6 +30,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	gitcfg "github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/go-scm/scm/factory"
@@ -147,10 +148,16 @@ func (r *Resolver) resolveAPIGit(ctx context.Context, params map[string]string)
 	if err != nil {
 		return nil, err
 	}
-	apiToken, err := r.getAPIToken(ctx)
+	var apiToken []byte
+	auth, err := r.getNamespaceAuth(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if auth != nil {
+		apiToken = []byte(auth.token)
+	} else if apiToken, err = r.getAPIToken(ctx); err != nil {
+		return nil, err
+	}
 	scmClient, err := r.clientFunc(scmType, serverURL, string(apiToken))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create SCM client: %w", err)
@@ -213,6 +220,13 @@ func (r *Resolver) resolveAnonymousGit(ctx context.Context, params map[string]st
 	cloneOpts := &git.CloneOptions{
 		URL: repo,
 	}
+	auth, err := r.getNamespaceAuth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if auth != nil {
+		cloneOpts.Auth = &githttp.BasicAuth{Username: auth.username, Password: auth.token}
+	}
 	filesystem := memfs.New()
 	repository, err := git.Clone(memory.NewStorage(), filesystem, cloneOpts)
 	if err != nil {
@@ -223,6 +237,7 @@ func (r *Resolver) resolveAnonymousGit(ctx context.Context, params map[string]st
 	refSpec := gitcfg.RefSpec(fmt.Sprintf("+refs/heads/%s:refs/remotes/%s", revision, revision))
 	err = repository.Fetch(&git.FetchOptions{
 		RefSpecs: []gitcfg.RefSpec{refSpec},
+		Auth:     cloneOpts.Auth,
 	})
 	if err != nil {
 		var fetchErr git.NoMatchingRefSpecError
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// offlineCache is the directory keeping the resources fetched from the hubs, in
+// <type>/<catalog>/<kind>/<name>/<version>.yaml files, so that they are resolved
+// without reaching the hubs. It is disabled when empty.
+type offlineCache string
+
+// dir returns the directory of the versions of the resource, or an error if a param
+// isn't a valid path segment.
+func (c offlineCache) dir(paramsMap map[string]string) (string, error) {
+	segments := []string{string(c)}
+	for _, p := range []string{ParamType, ParamCatalog, ParamKind, ParamName} {
+		if !validPathSegment(paramsMap[p]) {
+			return "", fmt.Errorf("invalid %s %q", p, paramsMap[p])
+		}
+		segments = append(segments, paramsMap[p])
+	}
+	return filepath.Join(segments...), nil
+}
+
+// path returns the path of the file of the version of the resource.
+func (c offlineCache) path(paramsMap map[string]string) (string, error) {
+	dir, err := c.dir(paramsMap)
+	if err != nil {
+		return "", err
+	}
+	if !validPathSegment(paramsMap[ParamVersion]) {
+		return "", fmt.Errorf("invalid %s %q", ParamVersion, paramsMap[ParamVersion])
+	}
+	return filepath.Join(dir, paramsMap[ParamVersion]+".yaml"), nil
+}
+
+// get returns the content of the resource kept in the cache.
+func (c offlineCache) get(paramsMap map[string]string) ([]byte, bool) {
+	if c == "" {
+		return nil, false
+	}
+	path, err := c.path(paramsMap)
+	if err != nil {
+		return nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// put keeps the content of the resource in the cache. The cache is best-effort, the
+// resource isn't kept when the directory isn't writable.
+func (c offlineCache) put(paramsMap map[string]string, content []byte) {
+	if c == "" {
+		return
+	}
+	path, err := c.path(paramsMap)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0o644) //nolint:gosec // the catalogs are public
+}
+
+// versions returns the versions of the resource kept in the cache.
+func (c offlineCache) versions(paramsMap map[string]string) ([]string, error) {
+	if c == "" {
+		return nil, nil
+	}
+	dir, err := c.dir(paramsMap)
+	if err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	var versions []string
+	for _, f := range files {
+		versions = append(versions, strings.TrimSuffix(filepath.Base(f), ".yaml"))
+	}
+	return versions, nil
+}
+
+func validPathSegment(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`)
+}
@@ -35,3 +35,16 @@ const ConfigKind = "default-kind"
 // ConfigType is the configuration field name for controlling
 // the hub type to pull the resource from.
 const ConfigType = "default-type"
+
+// ConfigTektonHubMirrorURL is the configuration field name for the URL of
+// a mirror of the Tekton Hub API to fetch the remote resource from instead.
+const ConfigTektonHubMirrorURL = "tekton-hub-mirror-url"
+
+// ConfigArtifactHubMirrorURL is the configuration field name for the URL of
+// a mirror of the Artifact Hub API to fetch the remote resource from instead.
+const ConfigArtifactHubMirrorURL = "artifact-hub-mirror-url"
+
+// ConfigOfflineCacheDir is the configuration field name for the directory
+// keeping the resources fetched from the hubs, to resolve them without
+// reaching the hubs.
+const ConfigOfflineCacheDir = "offline-cache-dir"
@@ -121,6 +121,19 @@ func (r *Resolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param)
 		return nil, fmt.Errorf("failed to validate params: %w", err)
 	}
 
+	conf := framework.GetResolverConfigFromContext(ctx)
+	if isVersionConstraint(paramsMap[ParamVersion]) {
+		versions, err := r.listVersions(ctx, conf, paramsMap)
+		if err != nil {
+			return nil, err
+		}
+		version, err := newestMatchingVersion(paramsMap[ParamVersion], versions)
+		if err != nil {
+			return nil, err
+		}
+		paramsMap[ParamVersion] = version
+	}
+
 	resVer, err := resolveVersion(paramsMap[ParamVersion], paramsMap[ParamType])
 	if err != nil {
 		return nil, err
@@ -128,30 +141,69 @@ func (r *Resolver) Resolve(ctx context.Context, params []pipelinev1beta1.Param)
 	paramsMap[ParamVersion] = resVer
 
 	// call hub API
+	var url string
+	var fetch func() (string, error)
 	switch paramsMap[ParamType] {
 	case ArtifactHubType:
-		url := fmt.Sprintf(r.ArtifactHubURL, paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName], paramsMap[ParamVersion])
-		resp := artifactHubResponse{}
-		if err := fetchHubResource(ctx, url, &resp); err != nil {
-			return nil, fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
+		url = fmt.Sprintf(r.artifactHubURL(conf), paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName], paramsMap[ParamVersion])
+		fetch = func() (string, error) {
+			resp := artifactHubResponse{}
+			if err := fetchHubResource(ctx, url, &resp); err != nil {
+				return "", fmt.Errorf("fail to fetch Artifact Hub resource: %w", err)
+			}
+			return resp.Data.YAML, nil
 		}
-		return &ResolvedHubResource{
-			URL:     url,
-			Content: []byte(resp.Data.YAML),
-		}, nil
 	case TektonHubType:
-		url := fmt.Sprintf(r.TektonHubURL, paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName], paramsMap[ParamVersion])
-		resp := tektonHubResponse{}
-		if err := fetchHubResource(ctx, url, &resp); err != nil {
-			return nil, fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
+		url = fmt.Sprintf(r.tektonHubURL(conf), paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName], paramsMap[ParamVersion])
+		fetch = func() (string, error) {
+			resp := tektonHubResponse{}
+			if err := fetchHubResource(ctx, url, &resp); err != nil {
+				return "", fmt.Errorf("fail to fetch Tekton Hub resource: %w", err)
+			}
+			return resp.Data.YAML, nil
 		}
+	default:
+		return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
+	}
+
+	// The versions of the resources are immutable, so the resources kept in the
+	// offline cache are resolved without reaching the hub.
+	cache := offlineCache(conf[ConfigOfflineCacheDir])
+	if content, ok := cache.get(paramsMap); ok {
 		return &ResolvedHubResource{
 			URL:     url,
-			Content: []byte(resp.Data.YAML),
+			Content: content,
 		}, nil
 	}
+	content, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if content != "" {
+		cache.put(paramsMap, []byte(content))
+	}
+	return &ResolvedHubResource{
+		URL:     url,
+		Content: []byte(content),
+	}, nil
+}
 
-	return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
+// tektonHubURL returns the URL of the yaml endpoint of the Tekton Hub API, or of its
+// mirror if one is configured.
+func (r *Resolver) tektonHubURL(conf map[string]string) string {
+	if mirror := conf[ConfigTektonHubMirrorURL]; mirror != "" {
+		return strings.TrimSuffix(mirror, "/") + "/" + TektonHubYamlEndpoint
+	}
+	return r.TektonHubURL
+}
+
+// artifactHubURL returns the URL of the yaml endpoint of the Artifact Hub API, or of
+// its mirror if one is configured.
+func (r *Resolver) artifactHubURL(conf map[string]string) string {
+	if mirror := conf[ConfigArtifactHubMirrorURL]; mirror != "" {
+		return strings.TrimSuffix(mirror, "/") + "/" + ArtifactHubYamlEndpoint
+	}
+	return r.ArtifactHubURL
 }
 
 // ResolvedHubResource wraps the data we want to return to Pipelines
@@ -291,7 +343,7 @@ func (r *Resolver) validateParams(ctx context.Context, paramsMap map[string]stri
 			return fmt.Errorf(fmt.Sprintf("type param must be %s or %s", ArtifactHubType, TektonHubType))
 		}
 
-		if hubType == TektonHubType && r.TektonHubURL == "" {
+		if hubType == TektonHubType && r.tektonHubURL(framework.GetResolverConfigFromContext(ctx)) == "" {
 			return fmt.Errorf("pleaes configure TEKTON_HUB_API env variable to use tekton type")
 		}
 	}
@@ -299,6 +351,11 @@ func (r *Resolver) validateParams(ctx context.Context, paramsMap map[string]stri
 	if len(missingParams) > 0 {
 		return fmt.Errorf("missing required hub resolver params: %s", strings.Join(missingParams, ", "))
 	}
+	if isVersionConstraint(paramsMap[ParamVersion]) {
+		if _, err := parseVersionConstraint(paramsMap[ParamVersion]); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
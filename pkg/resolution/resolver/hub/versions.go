@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// TektonHubVersionsEndpoint is the suffix listing the versions of a resource of a Tekton hub instance
+const TektonHubVersionsEndpoint = "v1/resource/%s/%s/%s/versions"
+
+// ArtifactHubPackageEndpoint is the suffix describing a package, with its versions, of an Artifact hub instance
+const ArtifactHubPackageEndpoint = "api/v1/packages/tekton-%s/%s/%s"
+
+type tektonHubVersionsResponse struct {
+	Data struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"data"`
+}
+
+type artifactHubPackageResponse struct {
+	AvailableVersions []struct {
+		Version string `json:"version"`
+	} `json:"available_versions"`
+}
+
+// isVersionConstraint returns whether the version param is a semver range constraint,
+// e.g. ">=0.5 <1.0", rather than a version.
+func isVersionConstraint(version string) bool {
+	return strings.ContainsAny(version, "<>=! |")
+}
+
+// parseVersionConstraint parses the semver range constraint, whose versions may omit their
+// minor and patch versions as the catalogs' versions do.
+func parseVersionConstraint(constraint string) (semver.Range, error) {
+	var parts []string
+	for _, part := range strings.Fields(constraint) {
+		if part == "||" {
+			parts = append(parts, part)
+			continue
+		}
+		i := strings.IndexAny(part, "0123456789")
+		if i < 0 || strings.Trim(part[i:], "0123456789.") != "" {
+			parts = append(parts, part)
+			continue
+		}
+		parts = append(parts, part[:i]+completeVersion(part[i:]))
+	}
+	r, err := semver.ParseRange(strings.Join(parts, " "))
+	if err != nil {
+		return nil, fmt.Errorf("invalid version constraint %q: %w", constraint, err)
+	}
+	return r, nil
+}
+
+// completeVersion appends the missing minor and patch versions to the version.
+func completeVersion(version string) string {
+	for n := strings.Count(version, "."); n < 2; n++ {
+		version += ".0"
+	}
+	return version
+}
+
+// newestMatchingVersion returns the newest of the versions matching the constraint.
+func newestMatchingVersion(constraint string, versions []string) (string, error) {
+	r, err := parseVersionConstraint(constraint)
+	if err != nil {
+		return "", err
+	}
+	var newest string
+	var newestVersion semver.Version
+	for _, v := range versions {
+		parsed, err := semver.ParseTolerant(completeVersion(v))
+		if err != nil || !r(parsed) {
+			continue
+		}
+		if newest == "" || parsed.GT(newestVersion) {
+			newest, newestVersion = v, parsed
+		}
+	}
+	if newest == "" {
+		return "", fmt.Errorf("no version matching the constraint %q in %v", constraint, versions)
+	}
+	return newest, nil
+}
+
+// listVersions returns the versions of the resource published by the hub, or kept in the
+// offline cache when the hub can't list them.
+func (r *Resolver) listVersions(ctx context.Context, conf, paramsMap map[string]string) ([]string, error) {
+	var versions []string
+	var err error
+	switch paramsMap[ParamType] {
+	case ArtifactHubType:
+		url := fmt.Sprintf(strings.TrimSuffix(r.artifactHubURL(conf), ArtifactHubYamlEndpoint)+ArtifactHubPackageEndpoint,
+			paramsMap[ParamKind], paramsMap[ParamCatalog], paramsMap[ParamName])
+		resp := artifactHubPackageResponse{}
+		if err = fetchHubResource(ctx, url, &resp); err == nil {
+			for _, v := range resp.AvailableVersions {
+				versions = append(versions, v.Version)
+			}
+		}
+	case TektonHubType:
+		url := fmt.Sprintf(strings.TrimSuffix(r.tektonHubURL(conf), TektonHubYamlEndpoint)+TektonHubVersionsEndpoint,
+			paramsMap[ParamCatalog], paramsMap[ParamKind], paramsMap[ParamName])
+		resp := tektonHubVersionsResponse{}
+		if err = fetchHubResource(ctx, url, &resp); err == nil {
+			for _, v := range resp.Data.Versions {
+				versions = append(versions, v.Version)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("hub resolver type: %s is not supported", paramsMap[ParamType])
+	}
+	if err != nil {
+		cached, cacheErr := offlineCache(conf[ConfigOfflineCacheDir]).versions(paramsMap)
+		if cacheErr != nil || len(cached) == 0 {
+			return nil, fmt.Errorf("fail to list the versions of the hub resource: %w", err)
+		}
+		return cached, nil
+	}
+	return versions, nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2023 The Tekton Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/resolution/resolver/framework"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewestMatchingVersion(t *testing.T) {
+	versions := []string{"0.1", "0.5", "0.9", "1.0", "1.2"}
+	testCases := []struct {
+		constraint  string
+		expectedVer string
+		expectedErr error
+	}{{
+		constraint:  ">=0.5 <1.0",
+		expectedVer: "0.9",
+	}, {
+		constraint:  ">=0.5",
+		expectedVer: "1.2",
+	}, {
+		constraint:  "<0.5 || =1.0",
+		expectedVer: "1.0",
+	}, {
+		constraint:  "<=0.5.0",
+		expectedVer: "0.5",
+	}, {
+		constraint:  ">2.0",
+		expectedErr: fmt.Errorf(`no version matching the constraint ">2.0" in [0.1 0.5 0.9 1.0 1.2]`),
+	}, {
+		constraint:  ">=a.b",
+		expectedErr: fmt.Errorf(`invalid version constraint ">=a.b": Could not get version from string: ">=a.b"`),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.constraint, func(t *testing.T) {
+			version, err := newestMatchingVersion(tc.constraint, versions)
+			if tc.expectedErr != nil {
+				checkExpectedErr(t, tc.expectedErr, err)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedVer, version); d != "" {
+				t.Errorf("unexpected version: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func newHubServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/resource/Tekton/task/foo/versions":
+			fmt.Fprint(w, `{"data":{"versions":[{"version":"0.4"},{"version":"0.7"},{"version":"1.0"}]}}`)
+		case "/v1/resource/Tekton/task/foo/0.7/yaml":
+			fmt.Fprint(w, `{"data":{"yaml":"tekton hub 0.7"}}`)
+		case "/api/v1/packages/tekton-task/tekton-catalog-tasks/foo":
+			fmt.Fprint(w, `{"available_versions":[{"version":"0.4.0"},{"version":"0.7.0"},{"version":"1.0.0"}]}`)
+		case "/api/v1/packages/tekton-task/tekton-catalog-tasks/foo/0.7.0":
+			fmt.Fprint(w, `{"data":{"manifestRaw":"artifact hub 0.7.0"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(svr.Close)
+	return svr
+}
+
+func contextWithHubConfig(extra map[string]string) context.Context {
+	config := map[string]string{
+		"default-tekton-hub-catalog":            "Tekton",
+		"default-artifact-hub-task-catalog":     "tekton-catalog-tasks",
+		"default-artifact-hub-pipeline-catalog": "tekton-catalog-pipelines",
+		"default-type":                          "artifact",
+		"default-kind":                          "task",
+	}
+	for k, v := range extra {
+		config[k] = v
+	}
+	return framework.InjectResolverConfigToContext(context.Background(), config)
+}
+
+func TestResolveVersionConstraint(t *testing.T) {
+	svr := newHubServer(t)
+
+	for _, tc := range []struct {
+		name        string
+		resolver    *Resolver
+		config      map[string]string
+		hubType     string
+		expectedURL string
+		expectedRes string
+	}{{
+		name: "Tekton Hub",
+		resolver: &Resolver{
+			TektonHubURL: svr.URL + "/" + TektonHubYamlEndpoint,
+		},
+		hubType:     TektonHubType,
+		expectedURL: svr.URL + "/v1/resource/Tekton/task/foo/0.7/yaml",
+		expectedRes: "tekton hub 0.7",
+	}, {
+		name: "Artifact Hub",
+		resolver: &Resolver{
+			ArtifactHubURL: svr.URL + "/" + ArtifactHubYamlEndpoint,
+		},
+		hubType:     ArtifactHubType,
+		expectedURL: svr.URL + "/api/v1/packages/tekton-task/tekton-catalog-tasks/foo/0.7.0",
+		expectedRes: "artifact hub 0.7.0",
+	}, {
+		name:        "Tekton Hub mirror",
+		resolver:    &Resolver{},
+		config:      map[string]string{ConfigTektonHubMirrorURL: svr.URL + "/"},
+		hubType:     TektonHubType,
+		expectedURL: svr.URL + "/v1/resource/Tekton/task/foo/0.7/yaml",
+		expectedRes: "tekton hub 0.7",
+	}, {
+		name: "Artifact Hub mirror",
+		resolver: &Resolver{
+			ArtifactHubURL: DefaultArtifactHubURL,
+		},
+		config:      map[string]string{ConfigArtifactHubMirrorURL: svr.URL},
+		hubType:     ArtifactHubType,
+		expectedURL: svr.URL + "/api/v1/packages/tekton-task/tekton-catalog-tasks/foo/0.7.0",
+		expectedRes: "artifact hub 0.7.0",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := map[string]string{
+				ParamName:    "foo",
+				ParamVersion: ">=0.5 <1.0",
+				ParamType:    tc.hubType,
+			}
+			ctx := contextWithHubConfig(tc.config)
+			if err := tc.resolver.ValidateParams(ctx, toParams(params)); err != nil {
+				t.Fatalf("unexpected error validating params: %v", err)
+			}
+			output, err := tc.resolver.Resolve(ctx, toParams(params))
+			if err != nil {
+				t.Fatalf("unexpected error resolving: %v", err)
+			}
+			if d := cmp.Diff(tc.expectedRes, string(output.Data())); d != "" {
+				t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(tc.expectedURL, output.RefSource().URI); d != "" {
+				t.Errorf("unexpected URI from Resolve: %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestResolveOfflineCache(t *testing.T) {
+	svr := newHubServer(t)
+	cacheDir := t.TempDir()
+	ctx := contextWithHubConfig(map[string]string{ConfigOfflineCacheDir: cacheDir})
+	params := map[string]string{
+		ParamName:    "foo",
+		ParamVersion: ">=0.5 <1.0",
+		ParamType:    TektonHubType,
+	}
+
+	// The resource fetched from the hub is kept in the cache.
+	online := &Resolver{TektonHubURL: svr.URL + "/" + TektonHubYamlEndpoint}
+	if _, err := online.Resolve(ctx, toParams(params)); err != nil {
+		t.Fatalf("unexpected error resolving: %v", err)
+	}
+
+	// The cached resource is resolved, and its version listed, without the hub.
+	offline := &Resolver{TektonHubURL: "http://127.0.0.1:1/" + TektonHubYamlEndpoint}
+	output, err := offline.Resolve(ctx, toParams(params))
+	if err != nil {
+		t.Fatalf("unexpected error resolving from the cache: %v", err)
+	}
+	if d := cmp.Diff("tekton hub 0.7", string(output.Data())); d != "" {
+		t.Errorf("unexpected resource from Resolve: %s", diff.PrintWantGot(d))
+	}
+
+	params[ParamName] = "../foo"
+	params[ParamVersion] = "0.7"
+	if _, err := offline.Resolve(ctx, toParams(params)); err == nil {
+		t.Error("expected an error resolving a resource which isn't in the cache")
+	}
+}
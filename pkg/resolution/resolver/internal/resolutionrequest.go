@@ -48,3 +48,11 @@ func CreateResolutionRequestFailureStatus() *v1beta1.ResolutionRequestStatus {
 		},
 	}
 }
+
+// CreateResolutionRequestInvalidRequestStatus returns a ResolutionRequestStatus with failure
+// because its params were rejected by the resolver.
+func CreateResolutionRequestInvalidRequestStatus() *v1beta1.ResolutionRequestStatus {
+	status := CreateResolutionRequestFailureStatus()
+	status.Conditions[0].Reason = resolutioncommon.ReasonResolutionInvalidRequest
+	return status
+}
@@ -79,8 +79,14 @@ func (r *CRDRequester) Submit(ctx context.Context, resolver ResolverName, req Re
 		return crdIntoResource(rr), nil
 	}
 
-	message := rr.Status.GetCondition(apis.ConditionSucceeded).GetMessage()
-	err := resolutioncommon.NewError(resolutioncommon.ReasonResolutionFailed, errors.New(message))
+	// The reason of the failure, e.g. a timeout or an invalid request, is
+	// kept so that it can be surfaced to the owner of the request.
+	condition := rr.Status.GetCondition(apis.ConditionSucceeded)
+	reason := condition.GetReason()
+	if reason == "" {
+		reason = resolutioncommon.ReasonResolutionFailed
+	}
+	err := resolutioncommon.NewError(reason, errors.New(condition.GetMessage()))
 	return nil, err
 }
 
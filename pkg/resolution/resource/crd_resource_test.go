@@ -117,6 +117,16 @@ conditions:
     status: "Failed"
     type: Succeeded
     message: "error message"
+`)
+	//
+	timedOutRR := baseRR.DeepCopy()
+	timedOutRR.Status = *mustParseResolutionRequestStatus(t, `
+conditions:
+  - lastTransitionTime: "2023-03-26T10:31:29Z"
+    status: "Failed"
+    type: Succeeded
+    reason: "ResolutionTimedOut"
+    message: "context deadline exceeded"
 `)
 	//
 	successRR := baseRR.DeepCopy()
@@ -190,6 +200,14 @@ conditions:
 			expectedResolvedResource:  nil,
 			expectedErr:               resolutioncommon.NewError(resolutioncommon.ReasonResolutionFailed, errors.New("error message")),
 		},
+		{
+			name:                      "resolution request exist and status is timed out",
+			inputRequest:              request,
+			inputResolutionRequest:    timedOutRR.DeepCopy(),
+			expectedResolutionRequest: nil,
+			expectedResolvedResource:  nil,
+			expectedErr:               resolutioncommon.NewError(resolutioncommon.ReasonResolutionTimedOut, errors.New("context deadline exceeded")),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -219,6 +237,10 @@ conditions:
 				} else if err.Error() != tc.expectedErr.Error() {
 					t.Errorf("expected error %v, but got %v", tc.expectedErr, err)
 				}
+				var expectedResolutionErr, resolutionErr *resolutioncommon.Error
+				if errors.As(tc.expectedErr, &expectedResolutionErr) && errors.As(err, &resolutionErr) && expectedResolutionErr.Reason != resolutionErr.Reason {
+					t.Errorf("expected error reason %s, but got %s", expectedResolutionErr.Reason, resolutionErr.Reason)
+				}
 			}
 
 			// check the resolved resource
@@ -0,0 +1,597 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resourcemigration rewrites the PipelineResources of the v1beta1 Tasks,
+// Pipelines, TaskRuns and PipelineRuns stored before their removal into
+// workspaces, params and results, where it can be done mechanically.
+//
+// The input resources of the types which were fetched by the PipelineResource,
+// e.g. git, become workspaces mounted where the content used to be, whose
+// content has to be provided, e.g. by the git-clone Task. The output resources
+// of these types become workspaces too. The image and cloudEvent resources
+// don't need a workspace, the paths of their directory are kept. The fields of
+// the resources referenced by the steps, e.g. $(resources.inputs.source.url),
+// become params named after the resource and the field, e.g. "source-url".
+package resourcemigration
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// The types of the PipelineResources which don't have any content.
+	resourceTypeImage      = "image"
+	resourceTypeCloudEvent = "cloudEvent"
+
+	inputs  = "inputs"
+	outputs = "outputs"
+)
+
+// resourceVariable matches the references to the fields of the PipelineResources
+// of a Task, e.g. $(resources.inputs.source.path), and their older form, e.g.
+// $(inputs.resources.source.path).
+var resourceVariable = regexp.MustCompile(`\$\((?:resources\.(inputs|outputs)|(inputs|outputs)\.resources)\.([^.)]+)\.([^.)]+)\)`)
+
+// Change is a PipelineResource which was rewritten, or which has to be migrated manually.
+type Change struct {
+	// Path is the path of the PipelineResource, e.g. "spec.resources.inputs[0]".
+	Path string
+	// Message describes the rewrite, or why it wasn't done.
+	Message string
+	// Manual is true if the PipelineResource, or a part of it, has to be migrated manually.
+	Manual bool
+}
+
+// String returns the Change in a human readable form.
+func (c Change) String() string {
+	if c.Manual {
+		return fmt.Sprintf("%s: %s (manual migration required)", c.Path, c.Message)
+	}
+	return fmt.Sprintf("%s: %s", c.Path, c.Message)
+}
+
+// Complete returns true if none of the changes has to be migrated manually.
+func Complete(changes []Change) bool {
+	for _, c := range changes {
+		if c.Manual {
+			return false
+		}
+	}
+	return true
+}
+
+// Migrate rewrites the PipelineResources of a v1beta1 Task, Pipeline, TaskRun or
+// PipelineRun in place, and returns the changes. The PipelineResources which
+// have to be migrated manually are left as they are.
+func Migrate(obj runtime.Object) ([]Change, error) {
+	switch o := obj.(type) {
+	case *v1beta1.Task:
+		return MigrateTaskSpec(&o.Spec, "spec")
+	case *v1beta1.ClusterTask:
+		return MigrateTaskSpec(&o.Spec, "spec")
+	case *v1beta1.Pipeline:
+		return MigratePipelineSpec(&o.Spec, "spec")
+	case *v1beta1.TaskRun:
+		return MigrateTaskRunSpec(&o.Spec, "spec")
+	case *v1beta1.PipelineRun:
+		return MigratePipelineRunSpec(&o.Spec, "spec")
+	default:
+		return nil, fmt.Errorf("unsupported type %T", obj)
+	}
+}
+
+// migratedResource is a PipelineResource of a Task which was rewritten.
+type migratedResource struct {
+	// workspace is true if the resource was rewritten into a workspace named after it.
+	workspace bool
+	// fields are the fields of the resource which were rewritten into params.
+	fields []string
+}
+
+// MigrateTaskSpec rewrites the PipelineResources of the TaskSpec in place, and
+// returns the changes. The paths of the changes are prefixed with the path.
+func MigrateTaskSpec(ts *v1beta1.TaskSpec, path string) ([]Change, error) {
+	changes, _, err := migrateTaskSpec(ts, path)
+	return changes, err
+}
+
+func migrateTaskSpec(ts *v1beta1.TaskSpec, fieldPath string) ([]Change, map[string]migratedResource, error) {
+	if ts.Resources == nil {
+		return nil, nil, nil
+	}
+	resources := ts.Resources
+	ts.Resources = nil
+	raw, err := json.Marshal(ts)
+	if err != nil {
+		ts.Resources = resources
+		return nil, nil, fmt.Errorf("failed to marshal the TaskSpec: %w", err)
+	}
+
+	// The fields referenced by the steps, by direction and name of resource.
+	referenced := map[string]sets.String{}
+	for _, m := range resourceVariable.FindAllStringSubmatch(string(raw), -1) {
+		key := variableDirection(m) + "." + m[3]
+		if referenced[key] == nil {
+			referenced[key] = sets.NewString()
+		}
+		referenced[key].Insert(m[4])
+	}
+
+	workspaceNames := sets.NewString()
+	for _, w := range ts.Workspaces {
+		workspaceNames.Insert(w.Name)
+	}
+	paramNames := sets.NewString()
+	for _, p := range ts.Params {
+		paramNames.Insert(p.Name)
+	}
+	resultNames := sets.NewString()
+	for _, r := range ts.Results {
+		resultNames.Insert(r.Name)
+	}
+
+	var (
+		changes    []Change
+		workspaces []v1beta1.WorkspaceDeclaration
+		params     v1beta1.ParamSpecs
+		results    []v1beta1.TaskResult
+		remaining  v1beta1.TaskResources
+	)
+	migrated := map[string]migratedResource{}
+	replacements := map[string]string{}
+	migrate := func(r v1beta1.TaskResource, direction, p string) bool {
+		fields := referenced[direction+"."+r.Name].List()
+		withWorkspace := hasContent(r.Type)
+		resourcePath := path.Join("/workspace", r.Name)
+		if direction == inputs && r.TargetPath != "" {
+			resourcePath = path.Join("/workspace", r.TargetPath)
+		} else if direction == outputs {
+			resourcePath = path.Join("/workspace/output", r.Name)
+		}
+
+		if withWorkspace && workspaceNames.Has(r.Name) {
+			changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the workspace %q already exists", r.Name), Manual: true})
+			return false
+		}
+		var paramFields, newParams []string
+		for _, f := range fields {
+			if f == "path" {
+				continue
+			}
+			name := paramName(r.Name, f)
+			if paramNames.Has(name) {
+				changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the param %q already exists", name), Manual: true})
+				return false
+			}
+			paramFields = append(paramFields, f)
+			newParams = append(newParams, name)
+		}
+		digestResult := ""
+		if direction == outputs && r.Type == resourceTypeImage {
+			digestResult = r.Name + "_IMAGE_DIGEST"
+			if resultNames.Has(digestResult) {
+				changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the result %q already exists", digestResult), Manual: true})
+				return false
+			}
+		}
+
+		var message string
+		if withWorkspace {
+			w := v1beta1.WorkspaceDeclaration{Name: r.Name, Description: r.Description, Optional: r.Optional}
+			if resourcePath != path.Join("/workspace", r.Name) {
+				w.MountPath = resourcePath
+			}
+			workspaces = append(workspaces, w)
+			workspaceNames.Insert(r.Name)
+			replacements[direction+"."+r.Name+".path"] = fmt.Sprintf("$(workspaces.%s.path)", r.Name)
+			message = fmt.Sprintf("the %s resource %q of type %q is replaced by the workspace %q mounted at %q", strings.TrimSuffix(direction, "s"), r.Name, r.Type, r.Name, resourcePath)
+		} else {
+			replacements[direction+"."+r.Name+".path"] = resourcePath
+			message = fmt.Sprintf("the %s resource %q of type %q is removed", strings.TrimSuffix(direction, "s"), r.Name, r.Type)
+		}
+		for _, f := range paramFields {
+			ps := v1beta1.ParamSpec{
+				Name:        paramName(r.Name, f),
+				Type:        v1beta1.ParamTypeString,
+				Description: fmt.Sprintf("The %s of the %s resource %q.", f, r.Type, r.Name),
+			}
+			if r.Optional {
+				ps.Default = v1beta1.NewStructuredValues("")
+			}
+			params = append(params, ps)
+			paramNames.Insert(ps.Name)
+			replacements[direction+"."+r.Name+"."+f] = fmt.Sprintf("$(params.%s)", ps.Name)
+		}
+		if len(newParams) > 0 {
+			message += fmt.Sprintf(", its fields by the params %s", quote(newParams))
+		}
+		switch {
+		case digestResult != "":
+			results = append(results, v1beta1.TaskResult{
+				Name:        digestResult,
+				Type:        v1beta1.ResultsTypeString,
+				Description: fmt.Sprintf("The digest of the image of the resource %q.", r.Name),
+			})
+			resultNames.Insert(digestResult)
+			message += fmt.Sprintf("; the steps have to write the digest of the image to the result %q", digestResult)
+		case withWorkspace && direction == inputs:
+			message += "; its content isn't fetched anymore and has to be provided in the workspace, e.g. by a Task of the catalog"
+		}
+		changes = append(changes, Change{Path: p, Message: message})
+		migrated[r.Name] = migratedResource{workspace: withWorkspace, fields: paramFields}
+		return true
+	}
+	for i, r := range resources.Inputs {
+		if !migrate(r, inputs, fmt.Sprintf("%s.resources.inputs[%d]", fieldPath, i)) {
+			remaining.Inputs = append(remaining.Inputs, r)
+		}
+	}
+	for i, r := range resources.Outputs {
+		if !migrate(r, outputs, fmt.Sprintf("%s.resources.outputs[%d]", fieldPath, i)) {
+			remaining.Outputs = append(remaining.Outputs, r)
+		}
+	}
+
+	rewritten := resourceVariable.ReplaceAllStringFunc(string(raw), func(v string) string {
+		m := resourceVariable.FindStringSubmatch(v)
+		if r, ok := replacements[variableDirection(m)+"."+m[3]+"."+m[4]]; ok {
+			return r
+		}
+		return v
+	})
+	migratedSpec := v1beta1.TaskSpec{}
+	if err := json.Unmarshal([]byte(rewritten), &migratedSpec); err != nil {
+		ts.Resources = resources
+		return nil, nil, fmt.Errorf("failed to unmarshal the migrated TaskSpec: %w", err)
+	}
+	*ts = migratedSpec
+	ts.Workspaces = append(ts.Workspaces, workspaces...)
+	ts.Params = append(ts.Params, params...)
+	ts.Results = append(ts.Results, results...)
+	if len(remaining.Inputs) > 0 || len(remaining.Outputs) > 0 {
+		ts.Resources = &remaining
+	}
+	return changes, migrated, nil
+}
+
+// MigratePipelineSpec rewrites the PipelineResources of the PipelineSpec, and of
+// the Tasks embedded in it, in place and returns the changes. The paths of the
+// changes are prefixed with the path.
+func MigratePipelineSpec(ps *v1beta1.PipelineSpec, fieldPath string) ([]Change, error) {
+	var changes []Change
+	workspaceNames := sets.NewString()
+	for _, w := range ps.Workspaces {
+		workspaceNames.Insert(w.Name)
+	}
+	// The types of the resources of the Pipeline which were rewritten.
+	migrated := map[string]string{}
+	var remaining []v1beta1.PipelineDeclaredResource
+	for i, r := range ps.Resources {
+		p := fmt.Sprintf("%s.resources[%d]", fieldPath, i)
+		if !hasContent(r.Type) {
+			migrated[r.Name] = r.Type
+			changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the resource %q of type %q is removed", r.Name, r.Type)})
+			continue
+		}
+		if workspaceNames.Has(r.Name) {
+			changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the workspace %q already exists", r.Name), Manual: true})
+			remaining = append(remaining, r)
+			continue
+		}
+		ps.Workspaces = append(ps.Workspaces, v1beta1.PipelineWorkspaceDeclaration{Name: r.Name, Optional: r.Optional})
+		workspaceNames.Insert(r.Name)
+		migrated[r.Name] = r.Type
+		changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the resource %q of type %q is replaced by the workspace %q", r.Name, r.Type, r.Name)})
+	}
+	ps.Resources = remaining
+
+	for i := range ps.Tasks {
+		c, err := migratePipelineTask(ps, &ps.Tasks[i], fmt.Sprintf("%s.tasks[%d]", fieldPath, i), migrated)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c...)
+	}
+	for i := range ps.Finally {
+		c, err := migratePipelineTask(ps, &ps.Finally[i], fmt.Sprintf("%s.finally[%d]", fieldPath, i), migrated)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c...)
+	}
+	return changes, nil
+}
+
+// migratePipelineTask rewrites the PipelineResources of the PipelineTask, and of
+// its embedded Task, given the types of the resources of the Pipeline which
+// were rewritten.
+func migratePipelineTask(ps *v1beta1.PipelineSpec, pt *v1beta1.PipelineTask, fieldPath string, migrated map[string]string) ([]Change, error) {
+	var changes []Change
+	var taskResources map[string]migratedResource
+	if pt.TaskSpec != nil {
+		c, m, err := migrateTaskSpec(&pt.TaskSpec.TaskSpec, fieldPath+".taskSpec")
+		if err != nil {
+			return nil, err
+		}
+		changes, taskResources = c, m
+	}
+	if pt.Resources == nil {
+		return changes, nil
+	}
+
+	workspaceNames := sets.NewString()
+	for _, w := range pt.Workspaces {
+		workspaceNames.Insert(w.Name)
+	}
+	paramNames := sets.NewString()
+	for _, p := range pt.Params {
+		paramNames.Insert(p.Name)
+	}
+	pipelineParamNames := sets.NewString()
+	for _, p := range ps.Params {
+		pipelineParamNames.Insert(p.Name)
+	}
+
+	bind := func(name, resource, p string) bool {
+		resourceType, ok := migrated[resource]
+		if !ok {
+			changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the resource %q of the Pipeline isn't migrated", resource), Manual: true})
+			return false
+		}
+		withWorkspace := hasContent(resourceType)
+		var fields []string
+		if pt.TaskSpec != nil {
+			tr, ok := taskResources[name]
+			if !ok {
+				changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the resource %q of the Task isn't migrated", name), Manual: true})
+				return false
+			}
+			withWorkspace, fields = tr.workspace, tr.fields
+		}
+		if withWorkspace && workspaceNames.Has(name) {
+			changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the workspace %q is already bound", name), Manual: true})
+			return false
+		}
+		for _, f := range fields {
+			if paramNames.Has(paramName(name, f)) {
+				changes = append(changes, Change{Path: p, Message: fmt.Sprintf("the param %q is already set", paramName(name, f)), Manual: true})
+				return false
+			}
+		}
+
+		var message string
+		if withWorkspace {
+			pt.Workspaces = append(pt.Workspaces, v1beta1.WorkspacePipelineTaskBinding{Name: name, Workspace: resource})
+			workspaceNames.Insert(name)
+			message = fmt.Sprintf("the resource %q is replaced by the workspace %q bound to the workspace %q of the Pipeline", name, name, resource)
+		} else {
+			message = fmt.Sprintf("the resource %q is removed", name)
+		}
+		var params []string
+		for _, f := range fields {
+			pipelineParam := paramName(resource, f)
+			if !pipelineParamNames.Has(pipelineParam) {
+				ps.Params = append(ps.Params, v1beta1.ParamSpec{
+					Name:        pipelineParam,
+					Type:        v1beta1.ParamTypeString,
+					Description: fmt.Sprintf("The %s of the %s resource %q.", f, resourceType, resource),
+				})
+				pipelineParamNames.Insert(pipelineParam)
+			}
+			pt.Params = append(pt.Params, v1beta1.Param{
+				Name:  paramName(name, f),
+				Value: *v1beta1.NewStructuredValues(fmt.Sprintf("$(params.%s)", pipelineParam)),
+			})
+			paramNames.Insert(paramName(name, f))
+			params = append(params, paramName(name, f))
+		}
+		switch {
+		case len(params) > 0:
+			message += fmt.Sprintf(", its fields are passed in the params %s", quote(params))
+		case pt.TaskSpec == nil:
+			message += fmt.Sprintf("; the params of the migrated Task for the fields of the resource, e.g. %q, have to be passed", paramName(name, "url"))
+		}
+		changes = append(changes, Change{Path: p, Message: message})
+		return true
+	}
+
+	remaining := v1beta1.PipelineTaskResources{}
+	for i, r := range pt.Resources.Inputs {
+		if !bind(r.Name, r.Resource, fmt.Sprintf("%s.resources.inputs[%d]", fieldPath, i)) {
+			remaining.Inputs = append(remaining.Inputs, r)
+			continue
+		}
+		// The Tasks producing the resource ran before, they have to keep doing so.
+		for _, from := range r.From {
+			if !sets.NewString(pt.RunAfter...).Has(from) {
+				pt.RunAfter = append(pt.RunAfter, from)
+			}
+		}
+	}
+	for i, r := range pt.Resources.Outputs {
+		if !bind(r.Name, r.Resource, fmt.Sprintf("%s.resources.outputs[%d]", fieldPath, i)) {
+			remaining.Outputs = append(remaining.Outputs, r)
+		}
+	}
+	pt.Resources = nil
+	if len(remaining.Inputs) > 0 || len(remaining.Outputs) > 0 {
+		pt.Resources = &remaining
+	}
+	return changes, nil
+}
+
+// MigrateTaskRunSpec rewrites the PipelineResources of the TaskRunSpec, and of
+// its embedded Task, in place and returns the changes. The paths of the changes
+// are prefixed with the path.
+func MigrateTaskRunSpec(trs *v1beta1.TaskRunSpec, fieldPath string) ([]Change, error) {
+	var changes []Change
+	if trs.TaskSpec != nil {
+		c, err := MigrateTaskSpec(trs.TaskSpec, fieldPath+".taskSpec")
+		if err != nil {
+			return nil, err
+		}
+		changes = c
+	}
+	if trs.Resources == nil {
+		return changes, nil
+	}
+	b := newBinder(trs.Workspaces, trs.Params)
+	remaining := v1beta1.TaskRunResources{}
+	for i, r := range trs.Resources.Inputs {
+		if !b.bind(r.PipelineResourceBinding, fmt.Sprintf("%s.resources.inputs[%d]", fieldPath, i)) {
+			remaining.Inputs = append(remaining.Inputs, r)
+		}
+	}
+	for i, r := range trs.Resources.Outputs {
+		if !b.bind(r.PipelineResourceBinding, fmt.Sprintf("%s.resources.outputs[%d]", fieldPath, i)) {
+			remaining.Outputs = append(remaining.Outputs, r)
+		}
+	}
+	trs.Workspaces, trs.Params = b.workspaces, b.params
+	trs.Resources = nil
+	if len(remaining.Inputs) > 0 || len(remaining.Outputs) > 0 {
+		trs.Resources = &remaining
+	}
+	return append(changes, b.changes...), nil
+}
+
+// MigratePipelineRunSpec rewrites the PipelineResources of the PipelineRunSpec,
+// and of its embedded Pipeline, in place and returns the changes. The paths of
+// the changes are prefixed with the path.
+func MigratePipelineRunSpec(prs *v1beta1.PipelineRunSpec, fieldPath string) ([]Change, error) {
+	var changes []Change
+	if prs.PipelineSpec != nil {
+		c, err := MigratePipelineSpec(prs.PipelineSpec, fieldPath+".pipelineSpec")
+		if err != nil {
+			return nil, err
+		}
+		changes = c
+	}
+	b := newBinder(prs.Workspaces, prs.Params)
+	var remaining []v1beta1.PipelineResourceBinding
+	for i, r := range prs.Resources {
+		if !b.bind(r, fmt.Sprintf("%s.resources[%d]", fieldPath, i)) {
+			remaining = append(remaining, r)
+		}
+	}
+	prs.Workspaces, prs.Params, prs.Resources = b.workspaces, b.params, remaining
+	return append(changes, b.changes...), nil
+}
+
+// binder rewrites the bindings of PipelineResources of runs into bindings of
+// workspaces and params.
+type binder struct {
+	workspaces []v1beta1.WorkspaceBinding
+	params     v1beta1.Params
+	changes    []Change
+}
+
+func newBinder(workspaces []v1beta1.WorkspaceBinding, params v1beta1.Params) *binder {
+	return &binder{workspaces: workspaces, params: params}
+}
+
+func (b *binder) bind(r v1beta1.PipelineResourceBinding, p string) bool {
+	if r.ResourceRef != nil {
+		b.changes = append(b.changes, Change{
+			Path:    p,
+			Message: fmt.Sprintf("the resource %q refers to the PipelineResource %q, whose fields have to be passed as params", r.Name, r.ResourceRef.Name),
+			Manual:  true,
+		})
+		return false
+	}
+	withWorkspace := r.ResourceSpec == nil || hasContent(r.ResourceSpec.Type)
+	if withWorkspace {
+		for _, w := range b.workspaces {
+			if w.Name == r.Name {
+				b.changes = append(b.changes, Change{Path: p, Message: fmt.Sprintf("the workspace %q is already bound", r.Name), Manual: true})
+				return false
+			}
+		}
+	}
+	var params v1beta1.Params
+	if r.ResourceSpec != nil {
+		existing := b.params.ExtractNames()
+		for _, rp := range r.ResourceSpec.Params {
+			name := paramName(r.Name, strings.ToLower(rp.Name))
+			if existing.Has(name) {
+				b.changes = append(b.changes, Change{Path: p, Message: fmt.Sprintf("the param %q is already set", name), Manual: true})
+				return false
+			}
+			params = append(params, v1beta1.Param{Name: name, Value: *v1beta1.NewStructuredValues(rp.Value)})
+		}
+	}
+
+	var message string
+	if withWorkspace {
+		b.workspaces = append(b.workspaces, v1beta1.WorkspaceBinding{Name: r.Name, EmptyDir: &corev1.EmptyDirVolumeSource{}})
+		message = fmt.Sprintf("the resource %q is replaced by the workspace %q bound to an emptyDir", r.Name, r.Name)
+	} else {
+		message = fmt.Sprintf("the resource %q is removed", r.Name)
+	}
+	if len(params) > 0 {
+		b.params = append(b.params, params...)
+		names := make([]string, 0, len(params))
+		for _, param := range params {
+			names = append(names, param.Name)
+		}
+		message += fmt.Sprintf(", its fields by the params %s", quote(names))
+	}
+	b.changes = append(b.changes, Change{Path: p, Message: message})
+	if r.ResourceSpec != nil && len(r.ResourceSpec.SecretParams) > 0 {
+		b.changes = append(b.changes, Change{
+			Path:    p + ".resourceSpec.secrets",
+			Message: fmt.Sprintf("the fields of the resource %q read from secrets have to be passed as params or workspaces", r.Name),
+			Manual:  true,
+		})
+	}
+	return true
+}
+
+// hasContent returns true if the PipelineResources of the type have a content,
+// which is rewritten into a workspace.
+func hasContent(resourceType string) bool {
+	return resourceType != resourceTypeImage && resourceType != resourceTypeCloudEvent
+}
+
+// paramName returns the name of the param replacing the field of the resource.
+func paramName(resource, field string) string {
+	return resource + "-" + field
+}
+
+// variableDirection returns the direction, inputs or outputs, of a match of resourceVariable.
+func variableDirection(m []string) string {
+	if m[1] != "" {
+		return m[1]
+	}
+	return m[2]
+}
+
+func quote(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		quoted = append(quoted, fmt.Sprintf("%q", n))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,397 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcemigration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/resourcemigration"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestMigrateTask(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-push", Namespace: "foo"},
+		Spec: v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+					Name: "source", Type: "git", TargetPath: "src",
+				}}},
+				Outputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+					Name: "builtImage", Type: "image",
+				}}},
+			},
+			Steps: []v1beta1.Step{{
+				Name:  "build-and-push",
+				Image: "gcr.io/kaniko-project/executor",
+				Args: []string{
+					"--context=$(resources.inputs.source.path)",
+					"--destination=$(outputs.resources.builtImage.url)",
+					"--oci-layout-path=$(resources.outputs.builtImage.path)",
+				},
+				Script: "echo $(resources.inputs.source.revision)",
+			}},
+		},
+	}
+	want := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build-push", Namespace: "foo"},
+		Spec: v1beta1.TaskSpec{
+			Params: v1beta1.ParamSpecs{{
+				Name:        "source-revision",
+				Type:        v1beta1.ParamTypeString,
+				Description: `The revision of the git resource "source".`,
+			}, {
+				Name:        "builtImage-url",
+				Type:        v1beta1.ParamTypeString,
+				Description: `The url of the image resource "builtImage".`,
+			}},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source", MountPath: "/workspace/src"}},
+			Results: []v1beta1.TaskResult{{
+				Name:        "builtImage_IMAGE_DIGEST",
+				Type:        v1beta1.ResultsTypeString,
+				Description: `The digest of the image of the resource "builtImage".`,
+			}},
+			Steps: []v1beta1.Step{{
+				Name:  "build-and-push",
+				Image: "gcr.io/kaniko-project/executor",
+				Args: []string{
+					"--context=$(workspaces.source.path)",
+					"--destination=$(params.builtImage-url)",
+					"--oci-layout-path=/workspace/output/builtImage",
+				},
+				Script: "echo $(params.source-revision)",
+			}},
+		},
+	}
+	wantChanges := []resourcemigration.Change{{
+		Path: "spec.resources.inputs[0]",
+		Message: `the input resource "source" of type "git" is replaced by the workspace "source" mounted at "/workspace/src", its fields by the params "source-revision"; ` +
+			"its content isn't fetched anymore and has to be provided in the workspace, e.g. by a Task of the catalog",
+	}, {
+		Path: "spec.resources.outputs[0]",
+		Message: `the output resource "builtImage" of type "image" is removed, its fields by the params "builtImage-url"; ` +
+			`the steps have to write the digest of the image to the result "builtImage_IMAGE_DIGEST"`,
+	}}
+
+	changes, err := resourcemigration.Migrate(task)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if d := cmp.Diff(want, task); d != "" {
+		t.Errorf("migrated Task %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantChanges, changes); d != "" {
+		t.Errorf("changes %s", diff.PrintWantGot(d))
+	}
+	if !resourcemigration.Complete(changes) {
+		t.Errorf("expected the migration to be complete")
+	}
+	if err := task.Validate(apis.WithinCreate(context.Background())); err != nil {
+		t.Errorf("expected the migrated Task to be valid but got %v", err)
+	}
+}
+
+func TestMigrateTask_Manual(t *testing.T) {
+	task := &v1beta1.Task{
+		Spec: v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+					Name: "source", Type: "git",
+				}}, {ResourceDeclaration: v1beta1.ResourceDeclaration{
+					Name: "config", Type: "storage", Optional: true,
+				}}},
+			},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+			Steps: []v1beta1.Step{{
+				Image:  "alpine",
+				Script: "ls $(resources.inputs.source.path) $(resources.inputs.config.location)",
+			}},
+		},
+	}
+	want := v1beta1.TaskSpec{
+		Resources: &v1beta1.TaskResources{
+			Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+				Name: "source", Type: "git",
+			}}},
+		},
+		Params: v1beta1.ParamSpecs{{
+			Name:        "config-location",
+			Type:        v1beta1.ParamTypeString,
+			Description: `The location of the storage resource "config".`,
+			Default:     v1beta1.NewStructuredValues(""),
+		}},
+		Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}, {Name: "config", Optional: true}},
+		Steps: []v1beta1.Step{{
+			Image:  "alpine",
+			Script: "ls $(resources.inputs.source.path) $(params.config-location)",
+		}},
+	}
+
+	changes, err := resourcemigration.Migrate(task)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if d := cmp.Diff(want, task.Spec); d != "" {
+		t.Errorf("migrated TaskSpec %s", diff.PrintWantGot(d))
+	}
+	if resourcemigration.Complete(changes) {
+		t.Errorf("expected the migration to be incomplete")
+	}
+	if want := `spec.resources.inputs[0]: the workspace "source" already exists (manual migration required)`; changes[0].String() != want {
+		t.Errorf("expected the change %q but got %q", want, changes[0].String())
+	}
+}
+
+func TestMigratePipeline(t *testing.T) {
+	pipeline := &v1beta1.Pipeline{
+		Spec: v1beta1.PipelineSpec{
+			Resources: []v1beta1.PipelineDeclaredResource{{Name: "repo", Type: "git"}, {Name: "image", Type: "image"}},
+			Tasks: []v1beta1.PipelineTask{{
+				Name:    "build",
+				TaskRef: &v1beta1.TaskRef{Name: "build-push"},
+				Resources: &v1beta1.PipelineTaskResources{
+					Inputs:  []v1beta1.PipelineTaskInputResource{{Name: "source", Resource: "repo"}},
+					Outputs: []v1beta1.PipelineTaskOutputResource{{Name: "builtImage", Resource: "image"}},
+				},
+			}, {
+				Name: "deploy",
+				TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+					Resources: &v1beta1.TaskResources{
+						Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+							Name: "manifests", Type: "git",
+						}}, {ResourceDeclaration: v1beta1.ResourceDeclaration{
+							Name: "image", Type: "image",
+						}}},
+					},
+					Steps: []v1beta1.Step{{
+						Image:  "kubectl",
+						Script: "deploy $(resources.inputs.manifests.path) $(resources.inputs.image.url)",
+					}},
+				}},
+				Resources: &v1beta1.PipelineTaskResources{
+					Inputs: []v1beta1.PipelineTaskInputResource{
+						{Name: "manifests", Resource: "repo"},
+						{Name: "image", Resource: "image", From: []string{"build"}},
+					},
+				},
+			}},
+		},
+	}
+	want := v1beta1.PipelineSpec{
+		Workspaces: []v1beta1.PipelineWorkspaceDeclaration{{Name: "repo"}},
+		Params: v1beta1.ParamSpecs{{
+			Name:        "image-url",
+			Type:        v1beta1.ParamTypeString,
+			Description: `The url of the image resource "image".`,
+		}},
+		Tasks: []v1beta1.PipelineTask{{
+			Name:       "build",
+			TaskRef:    &v1beta1.TaskRef{Name: "build-push"},
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "repo"}},
+		}, {
+			Name: "deploy",
+			TaskSpec: &v1beta1.EmbeddedTask{TaskSpec: v1beta1.TaskSpec{
+				Params: v1beta1.ParamSpecs{{
+					Name:        "image-url",
+					Type:        v1beta1.ParamTypeString,
+					Description: `The url of the image resource "image".`,
+				}},
+				Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "manifests"}},
+				Steps: []v1beta1.Step{{
+					Image:  "kubectl",
+					Script: "deploy $(workspaces.manifests.path) $(params.image-url)",
+				}},
+			}},
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "manifests", Workspace: "repo"}},
+			Params:     v1beta1.Params{{Name: "image-url", Value: *v1beta1.NewStructuredValues("$(params.image-url)")}},
+			RunAfter:   []string{"build"},
+		}},
+	}
+	wantPaths := []string{
+		"spec.resources[0]",
+		"spec.resources[1]",
+		"spec.tasks[0].resources.inputs[0]",
+		"spec.tasks[0].resources.outputs[0]",
+		"spec.tasks[1].taskSpec.resources.inputs[0]",
+		"spec.tasks[1].taskSpec.resources.inputs[1]",
+		"spec.tasks[1].resources.inputs[0]",
+		"spec.tasks[1].resources.inputs[1]",
+	}
+
+	changes, err := resourcemigration.Migrate(pipeline)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if d := cmp.Diff(want, pipeline.Spec); d != "" {
+		t.Errorf("migrated PipelineSpec %s", diff.PrintWantGot(d))
+	}
+	var paths []string
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	if d := cmp.Diff(wantPaths, paths); d != "" {
+		t.Errorf("paths of the changes %s", diff.PrintWantGot(d))
+	}
+	if want := `the resource "source" is replaced by the workspace "source" bound to the workspace "repo" of the Pipeline; ` +
+		`the params of the migrated Task for the fields of the resource, e.g. "source-url", have to be passed`; changes[2].Message != want {
+		t.Errorf("expected the message %q but got %q", want, changes[2].Message)
+	}
+	if !resourcemigration.Complete(changes) {
+		t.Errorf("expected the migration to be complete but got %v", changes)
+	}
+}
+
+func TestMigrateTaskRun(t *testing.T) {
+	taskRun := &v1beta1.TaskRun{
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "build-push"},
+			Params:  v1beta1.Params{{Name: "verbose", Value: *v1beta1.NewStructuredValues("true")}},
+			Resources: &v1beta1.TaskRunResources{
+				Inputs: []v1beta1.TaskResourceBinding{{PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+					Name: "source",
+					ResourceSpec: &v1alpha1.PipelineResourceSpec{
+						Type:         "git",
+						Params:       []v1alpha1.ResourceParam{{Name: "URL", Value: "https://github.com/tektoncd/pipeline"}},
+						SecretParams: []v1alpha1.SecretParam{{FieldName: "token", SecretKey: "token", SecretName: "github"}},
+					},
+				}}},
+				Outputs: []v1beta1.TaskResourceBinding{{PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+					Name:        "builtImage",
+					ResourceRef: &v1beta1.PipelineResourceRef{Name: "my-image"},
+				}}},
+			},
+		},
+	}
+	want := v1beta1.TaskRunSpec{
+		TaskRef: &v1beta1.TaskRef{Name: "build-push"},
+		Params: v1beta1.Params{
+			{Name: "verbose", Value: *v1beta1.NewStructuredValues("true")},
+			{Name: "source-url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/pipeline")},
+		},
+		Workspaces: []v1beta1.WorkspaceBinding{{Name: "source", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		Resources: &v1beta1.TaskRunResources{
+			Outputs: []v1beta1.TaskResourceBinding{{PipelineResourceBinding: v1beta1.PipelineResourceBinding{
+				Name:        "builtImage",
+				ResourceRef: &v1beta1.PipelineResourceRef{Name: "my-image"},
+			}}},
+		},
+	}
+	wantChanges := []resourcemigration.Change{{
+		Path:    "spec.resources.inputs[0]",
+		Message: `the resource "source" is replaced by the workspace "source" bound to an emptyDir, its fields by the params "source-url"`,
+	}, {
+		Path:    "spec.resources.inputs[0].resourceSpec.secrets",
+		Message: `the fields of the resource "source" read from secrets have to be passed as params or workspaces`,
+		Manual:  true,
+	}, {
+		Path:    "spec.resources.outputs[0]",
+		Message: `the resource "builtImage" refers to the PipelineResource "my-image", whose fields have to be passed as params`,
+		Manual:  true,
+	}}
+
+	changes, err := resourcemigration.Migrate(taskRun)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if d := cmp.Diff(want, taskRun.Spec); d != "" {
+		t.Errorf("migrated TaskRunSpec %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantChanges, changes); d != "" {
+		t.Errorf("changes %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestMigratePipelineRun(t *testing.T) {
+	pipelineRun := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "build-deploy"},
+			Resources: []v1beta1.PipelineResourceBinding{{
+				Name: "repo",
+				ResourceSpec: &v1alpha1.PipelineResourceSpec{
+					Type:   "git",
+					Params: []v1alpha1.ResourceParam{{Name: "revision", Value: "main"}},
+				},
+			}, {
+				Name: "image",
+				ResourceSpec: &v1alpha1.PipelineResourceSpec{
+					Type:   "image",
+					Params: []v1alpha1.ResourceParam{{Name: "url", Value: "gcr.io/foo/bar"}},
+				},
+			}},
+		},
+	}
+	want := v1beta1.PipelineRunSpec{
+		PipelineRef: &v1beta1.PipelineRef{Name: "build-deploy"},
+		Params: v1beta1.Params{
+			{Name: "repo-revision", Value: *v1beta1.NewStructuredValues("main")},
+			{Name: "image-url", Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar")},
+		},
+		Workspaces: []v1beta1.WorkspaceBinding{{Name: "repo", EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+
+	changes, err := resourcemigration.Migrate(pipelineRun)
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if d := cmp.Diff(want, pipelineRun.Spec); d != "" {
+		t.Errorf("migrated PipelineRunSpec %s", diff.PrintWantGot(d))
+	}
+	if !resourcemigration.Complete(changes) || len(changes) != 2 {
+		t.Errorf("expected two changes migrated automatically but got %v", changes)
+	}
+}
+
+func TestMigrate_Unsupported(t *testing.T) {
+	if _, err := resourcemigration.Migrate(&v1beta1.CustomRun{}); err == nil {
+		t.Errorf("expected an error migrating a CustomRun")
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	task := &v1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "task"},
+		Spec: v1beta1.TaskSpec{
+			Resources: &v1beta1.TaskResources{
+				Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{Name: "source", Type: "git"}}},
+			},
+			Workspaces: []v1beta1.WorkspaceDeclaration{{Name: "source"}},
+			Steps:      []v1beta1.Step{{Image: "alpine"}},
+		},
+	}
+	got := resourcemigration.Warnings(context.Background(), task)
+	want := `PipelineResources are not supported anymore and this one has to be migrated manually, the workspace "source" already exists: spec.resources.inputs[0]`
+	if got.Error() != want {
+		t.Errorf("expected the warning %q but got %q", want, got.Error())
+	}
+	if got.Filter(apis.ErrorLevel) != nil {
+		t.Errorf("expected warnings only but got %v", got)
+	}
+	if task.Spec.Resources == nil {
+		t.Errorf("expected the Task to be left as it is")
+	}
+
+	if got := resourcemigration.Warnings(context.Background(), &v1beta1.Task{Spec: v1beta1.TaskSpec{Steps: []v1beta1.Step{{Image: "alpine"}}}}); got != nil {
+		t.Errorf("expected no warnings for a Task without PipelineResources but got %v", got)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcemigration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+// Warnings returns how the PipelineResources of a v1beta1 Task, Pipeline, TaskRun
+// or PipelineRun, which are rejected by the validation webhook, can be migrated,
+// as warning level diagnostics. It is one of the validate.WarningsFunc of the
+// validation webhook.
+func Warnings(ctx context.Context, obj runtime.Object) *apis.FieldError {
+	switch obj.(type) {
+	case *v1beta1.Task, *v1beta1.ClusterTask, *v1beta1.Pipeline, *v1beta1.TaskRun, *v1beta1.PipelineRun:
+	default:
+		return nil
+	}
+	changes, err := Migrate(obj.DeepCopyObject())
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to migrate the PipelineResources of %T: %v", obj, err)
+		return nil
+	}
+	var errs *apis.FieldError
+	for _, c := range changes {
+		message := fmt.Sprintf("PipelineResources are not supported anymore, %s", c.Message)
+		if c.Manual {
+			message = fmt.Sprintf("PipelineResources are not supported anymore and this one has to be migrated manually, %s", c.Message)
+		}
+		errs = errs.Also(apis.ErrGeneric(message, c.Path).At(apis.WarningLevel))
+	}
+	return errs
+}
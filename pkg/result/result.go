@@ -35,6 +35,34 @@ const (
 	UnknownResultType = 10
 )
 
+const (
+	// WorkspaceStartDigestKeyPrefix is the prefix of the key of an internal
+	// result holding the digest of a workspace's contents before the first
+	// step of a TaskRun ran. It is followed by the workspace name.
+	WorkspaceStartDigestKeyPrefix = "WorkspaceStartDigest."
+	// WorkspaceEndDigestKeyPrefix is the prefix of the key of an internal
+	// result holding the digest of a workspace's contents after the last
+	// step of a TaskRun ran. It is followed by the workspace name.
+	WorkspaceEndDigestKeyPrefix = "WorkspaceEndDigest."
+
+	// ExecutionArgvKey is the key of an internal result holding the JSON
+	// encoded command and arguments executed by a step.
+	ExecutionArgvKey = "Argv"
+	// ExecutionArgvTruncatedKey is the key of an internal result reported when
+	// the trailing arguments of a step were dropped from ExecutionArgvKey.
+	ExecutionArgvTruncatedKey = "ArgvTruncated"
+	// ExecutionFinishedAtKey is the key of an internal result holding the time
+	// the command of a step finished.
+	ExecutionFinishedAtKey = "FinishedAt"
+
+	// StatusReasonKey is the key of an internal result holding the reason a
+	// step wrote to its status reason file.
+	StatusReasonKey = "StatusReason"
+	// StatusMessageKey is the key of an internal result holding the message a
+	// step wrote to its status message file.
+	StatusMessageKey = "StatusMessage"
+)
+
 // RunResult is used to write key/value pairs to TaskRun pod termination messages.
 // The key/value pairs may come from the entrypoint binary, or represent a TaskRunResult.
 // If they represent a TaskRunResult, the key is the name of the result and the value is the
@@ -0,0 +1,181 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package serviceaccountpolicy enforces the ServiceAccountPolicies, which restrict
+// the service accounts the PipelineRuns of a Pipeline and the TaskRuns of a Task
+// may use.
+package serviceaccountpolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	alpha1listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ErrServiceAccountNotAllowed is returned when no ServiceAccountPolicy applying
+// to a Pipeline or a Task allows a service account.
+var ErrServiceAccountNotAllowed = errors.New("service account not allowed")
+
+// Allowed returns the name of the first ServiceAccountPolicy, ordered by name,
+// which applies to the Pipeline and allows the service account. It returns
+// ErrServiceAccountNotAllowed if none of the policies which apply to the
+// Pipeline allows the service account, including when there are policies but
+// none of them applies to the Pipeline. It returns an empty name if there are
+// no policies at all.
+func Allowed(policies []*v1alpha1.ServiceAccountPolicy, pipeline, serviceAccount string) (string, error) {
+	return allowed(policies, "Pipeline", pipeline, serviceAccount, (*v1alpha1.ServiceAccountPolicy).AppliesTo)
+}
+
+// AllowedForTask is like Allowed for the TaskRuns of the Task which are not run
+// by a PipelineRun.
+func AllowedForTask(policies []*v1alpha1.ServiceAccountPolicy, task, serviceAccount string) (string, error) {
+	return allowed(policies, "Task", task, serviceAccount, (*v1alpha1.ServiceAccountPolicy).AppliesToTask)
+}
+
+func allowed(policies []*v1alpha1.ServiceAccountPolicy, kind, name, serviceAccount string, appliesTo func(*v1alpha1.ServiceAccountPolicy, string) bool) (string, error) {
+	if len(policies) == 0 {
+		return "", nil
+	}
+	sorted := make([]*v1alpha1.ServiceAccountPolicy, len(policies))
+	copy(sorted, policies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var applied []string
+	for _, p := range sorted {
+		if !appliesTo(p, name) {
+			continue
+		}
+		if p.Allows(serviceAccount) {
+			return p.Name, nil
+		}
+		applied = append(applied, p.Name)
+	}
+	if name == "" {
+		name = "embedded or remote " + kind
+	} else {
+		name = fmt.Sprintf("%s %q", kind, name)
+	}
+	if len(applied) == 0 {
+		return "", fmt.Errorf("%w: %q may not be used by the runs of %s as none of the ServiceAccountPolicies of the namespace applies to it",
+			ErrServiceAccountNotAllowed, serviceAccount, name)
+	}
+	return "", fmt.Errorf("%w: %q may not be used by the runs of %s according to ServiceAccountPolicies %s",
+		ErrServiceAccountNotAllowed, serviceAccount, name, strings.Join(applied, ", "))
+}
+
+// PipelineName returns the name of the Pipeline the ServiceAccountPolicies are
+// matched against for the PipelineRun, which is empty unless the PipelineRun
+// references a Pipeline by name.
+func PipelineName(pr *v1beta1.PipelineRun) string {
+	if pr.Spec.PipelineRef != nil && pr.Spec.PipelineRef.Resolver == "" {
+		return pr.Spec.PipelineRef.Name
+	}
+	return ""
+}
+
+// TaskName returns the name of the Task the ServiceAccountPolicies are matched
+// against for the TaskRun, which is empty unless the TaskRun references a Task
+// by name.
+func TaskName(tr *v1beta1.TaskRun) string {
+	if tr.Spec.TaskRef != nil && tr.Spec.TaskRef.Resolver == "" {
+		return tr.Spec.TaskRef.Name
+	}
+	return ""
+}
+
+// Grants checks the service account of the PipelineRun and the ones of its
+// taskRunSpecs against the policies applying to its Pipeline, and returns the
+// record of the service accounts and of the policies which allowed them.
+func Grants(policies []*v1alpha1.ServiceAccountPolicy, pr *v1beta1.PipelineRun) ([]v1beta1.ServiceAccountGrant, error) {
+	pipeline := PipelineName(pr)
+	var grants []v1beta1.ServiceAccountGrant
+	add := func(pipelineTask, serviceAccount string) error {
+		if serviceAccount == "" {
+			return nil
+		}
+		policy, err := Allowed(policies, pipeline, serviceAccount)
+		if err != nil {
+			return err
+		}
+		grants = append(grants, v1beta1.ServiceAccountGrant{
+			PipelineTask:   pipelineTask,
+			ServiceAccount: serviceAccount,
+			Policy:         policy,
+		})
+		return nil
+	}
+	if err := add("", pr.Spec.ServiceAccountName); err != nil {
+		return nil, err
+	}
+	for _, trs := range pr.Spec.TaskRunSpecs {
+		if err := add(trs.PipelineTaskName, trs.TaskServiceAccountName); err != nil {
+			return nil, err
+		}
+	}
+	return grants, nil
+}
+
+// CheckTaskRun checks the service account of the TaskRun. The service account of
+// a TaskRun run by the PipelineRun pr, which may be nil, must be one of the ones
+// of the PipelineRun, which Grants checked. The service account of any other
+// TaskRun is checked against the policies applying to its Task.
+func CheckTaskRun(policies []*v1alpha1.ServiceAccountPolicy, pr *v1beta1.PipelineRun, tr *v1beta1.TaskRun) error {
+	sa := tr.Spec.ServiceAccountName
+	if sa == "" {
+		return nil
+	}
+	if pr != nil && metav1.IsControlledBy(tr, pr) {
+		if sa == pr.Spec.ServiceAccountName {
+			return nil
+		}
+		for _, trs := range pr.Spec.TaskRunSpecs {
+			if sa == trs.TaskServiceAccountName {
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %q is not one of the service accounts of PipelineRun %q",
+			ErrServiceAccountNotAllowed, sa, pr.Name)
+	}
+	_, err := AllowedForTask(policies, TaskName(tr), sa)
+	return err
+}
+
+// NewValidateFunc returns a validate.ServiceAccountPolicyFunc checking the
+// service accounts against the ServiceAccountPolicies listed by the lister.
+func NewValidateFunc(lister alpha1listers.ServiceAccountPolicyLister) validate.ServiceAccountPolicyFunc {
+	return func(ctx context.Context, namespace, kind, name, serviceAccount string) error {
+		policies, err := lister.ServiceAccountPolicies(namespace).List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list ServiceAccountPolicies from namespace %s: %w", namespace, err)
+		}
+		if kind == pipeline.TaskControllerName {
+			_, err = AllowedForTask(policies, name, serviceAccount)
+		} else {
+			_, err = Allowed(policies, name, serviceAccount)
+		}
+		return err
+	}
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceaccountpolicy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/serviceaccountpolicy"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func policy(name string, pipelines []string, serviceAccounts ...string) *v1alpha1.ServiceAccountPolicy {
+	p := &v1alpha1.ServiceAccountPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+		Spec:       v1alpha1.ServiceAccountPolicySpec{ServiceAccounts: serviceAccounts},
+	}
+	for _, pattern := range pipelines {
+		p.Spec.Pipelines = append(p.Spec.Pipelines, v1alpha1.ResourcePattern{Pattern: pattern})
+	}
+	return p
+}
+
+func TestAllowed(t *testing.T) {
+	policies := []*v1alpha1.ServiceAccountPolicy{
+		policy("release", []string{"^release-"}, "default", "deployer"),
+		policy("publish", []string{"^release-publish$"}, "default", "publisher"),
+	}
+	for _, tc := range []struct {
+		desc           string
+		policies       []*v1alpha1.ServiceAccountPolicy
+		pipeline       string
+		serviceAccount string
+		want           string
+		wantErr        bool
+	}{{
+		desc:           "no policies",
+		policies:       []*v1alpha1.ServiceAccountPolicy{},
+		pipeline:       "build",
+		serviceAccount: "deployer",
+	}, {
+		desc:           "no policy applies",
+		pipeline:       "build",
+		serviceAccount: "deployer",
+		wantErr:        true,
+	}, {
+		desc:           "unnamed pipeline",
+		pipeline:       "",
+		serviceAccount: "default",
+		wantErr:        true,
+	}, {
+		desc:           "allowed by a policy",
+		pipeline:       "release-images",
+		serviceAccount: "deployer",
+		want:           "release",
+	}, {
+		desc:           "allowed by one of the policies which apply",
+		pipeline:       "release-publish",
+		serviceAccount: "publisher",
+		want:           "publish",
+	}, {
+		desc:           "allowed by the first policy by name",
+		pipeline:       "release-publish",
+		serviceAccount: "default",
+		want:           "publish",
+	}, {
+		desc:           "not allowed",
+		pipeline:       "release-images",
+		serviceAccount: "publisher",
+		wantErr:        true,
+	}, {
+		desc:           "a policy with an invalid pattern applies to no pipeline",
+		policies:       []*v1alpha1.ServiceAccountPolicy{policy("invalid", []string{"^["}, "builder")},
+		pipeline:       "^[",
+		serviceAccount: "builder",
+		wantErr:        true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			if tc.policies == nil {
+				tc.policies = policies
+			}
+			got, err := serviceaccountpolicy.Allowed(tc.policies, tc.pipeline, tc.serviceAccount)
+			if tc.wantErr {
+				if !errors.Is(err, serviceaccountpolicy.ErrServiceAccountNotAllowed) {
+					t.Fatalf("expected ErrServiceAccountNotAllowed but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Allowed: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("got policy %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGrants(t *testing.T) {
+	policies := []*v1alpha1.ServiceAccountPolicy{
+		policy("release", []string{"^release-"}, "default", "deployer"),
+	}
+	pr := &v1beta1.PipelineRun{
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef:        &v1beta1.PipelineRef{Name: "release-images"},
+			ServiceAccountName: "default",
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "build",
+			}, {
+				PipelineTaskName:       "deploy",
+				TaskServiceAccountName: "deployer",
+			}},
+		},
+	}
+	got, err := serviceaccountpolicy.Grants(policies, pr)
+	if err != nil {
+		t.Fatalf("Grants: %v", err)
+	}
+	want := []v1beta1.ServiceAccountGrant{{
+		ServiceAccount: "default",
+		Policy:         "release",
+	}, {
+		PipelineTask:   "deploy",
+		ServiceAccount: "deployer",
+		Policy:         "release",
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("grants %s", diff.PrintWantGot(d))
+	}
+
+	pr.Spec.TaskRunSpecs[1].TaskServiceAccountName = "cluster-admin"
+	if _, err := serviceaccountpolicy.Grants(policies, pr); !errors.Is(err, serviceaccountpolicy.ErrServiceAccountNotAllowed) {
+		t.Errorf("expected ErrServiceAccountNotAllowed but got %v", err)
+	}
+}
+
+func TestAllowedForTask(t *testing.T) {
+	p := policy("build", []string{"^release-"}, "deployer")
+	p.Spec.Tasks = []v1alpha1.ResourcePattern{{Pattern: "^kaniko$"}}
+	policies := []*v1alpha1.ServiceAccountPolicy{p}
+
+	got, err := serviceaccountpolicy.AllowedForTask(policies, "kaniko", "deployer")
+	if err != nil {
+		t.Fatalf("AllowedForTask: %v", err)
+	}
+	if got != "build" {
+		t.Errorf("got policy %q, want %q", got, "build")
+	}
+	for _, task := range []string{"release-images", "kaniko-build", ""} {
+		if _, err := serviceaccountpolicy.AllowedForTask(policies, task, "deployer"); !errors.Is(err, serviceaccountpolicy.ErrServiceAccountNotAllowed) {
+			t.Errorf("expected ErrServiceAccountNotAllowed for Task %q but got %v", task, err)
+		}
+	}
+}
+
+func TestCheckTaskRun(t *testing.T) {
+	p := policy("build", nil, "builder")
+	p.Spec.Tasks = []v1alpha1.ResourcePattern{{Pattern: "^kaniko$"}}
+	policies := []*v1alpha1.ServiceAccountPolicy{p}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "ns", UID: "pr-uid"},
+		Spec: v1beta1.PipelineRunSpec{
+			ServiceAccountName: "default",
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName:       "deploy",
+				TaskServiceAccountName: "deployer",
+			}},
+		},
+	}
+	taskRun := func(task, serviceAccount string, owner *v1beta1.PipelineRun) *v1beta1.TaskRun {
+		tr := &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "tr", Namespace: "ns"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef:            &v1beta1.TaskRef{Name: task},
+				ServiceAccountName: serviceAccount,
+			},
+		}
+		if owner != nil {
+			tr.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(owner, v1beta1.SchemeGroupVersion.WithKind("PipelineRun"))}
+		}
+		return tr
+	}
+	otherPR := pr.DeepCopy()
+	otherPR.UID = "other-uid"
+	for _, tc := range []struct {
+		desc    string
+		pr      *v1beta1.PipelineRun
+		tr      *v1beta1.TaskRun
+		wantErr bool
+	}{{
+		desc: "allowed for the task",
+		tr:   taskRun("kaniko", "builder", nil),
+	}, {
+		desc:    "not allowed for the task",
+		tr:      taskRun("kaniko", "deployer", nil),
+		wantErr: true,
+	}, {
+		desc: "service account of the PipelineRun",
+		pr:   pr,
+		tr:   taskRun("deploy", "deployer", pr),
+	}, {
+		desc:    "not a service account of the PipelineRun",
+		pr:      pr,
+		tr:      taskRun("deploy", "cluster-admin", pr),
+		wantErr: true,
+	}, {
+		desc:    "not controlled by the PipelineRun",
+		pr:      pr,
+		tr:      taskRun("deploy", "deployer", otherPR),
+		wantErr: true,
+	}, {
+		desc:    "PipelineRun not found",
+		tr:      taskRun("deploy", "deployer", pr),
+		wantErr: true,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := serviceaccountpolicy.CheckTaskRun(policies, tc.pr, tc.tr)
+			if tc.wantErr {
+				if !errors.Is(err, serviceaccountpolicy.ErrServiceAccountNotAllowed) {
+					t.Fatalf("expected ErrServiceAccountNotAllowed but got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("CheckTaskRun: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits the reconciliation of the runs between the replicas of
+// the controller. Each replica is a shard owning the namespaces whose shard label
+// hashes to its index, instead of a single leader reconciling all of them.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+)
+
+// DefaultLabel is the label of the namespaces whose value is hashed to select their
+// shard. The namespaces without it are selected by the hash of their name, e.g. the
+// namespaces of a tenant are reconciled by the same replica if they have the same value.
+const DefaultLabel = "tekton.dev/shard"
+
+// Shard is the subset of the namespaces reconciled by a replica of the controller.
+type Shard struct {
+	// Index is the index of the shard, between 0 and Count-1.
+	Index int
+	// Count is the number of shards.
+	Count int
+	// Label is the label of the namespaces whose value is hashed.
+	Label string
+
+	namespaces corev1listers.NamespaceLister
+
+	mu sync.Mutex
+	// resyncs reconcile the objects in a namespace the shard starts owning.
+	resyncs []func(namespace string)
+}
+
+// New returns the shard of the given index, listing the namespaces with the lister.
+func New(index, count int, label string, namespaces corev1listers.NamespaceLister) (*Shard, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("the number of shards must be at least 1, got %d", count)
+	}
+	if index < 0 || index >= count {
+		return nil, fmt.Errorf("the index of the shard must be between 0 and %d, got %d", count-1, index)
+	}
+	if label == "" {
+		label = DefaultLabel
+	}
+	return &Shard{Index: index, Count: count, Label: label, namespaces: namespaces}, nil
+}
+
+// Start returns the shard of the given index, watching the namespaces with the
+// client until ctx is done. It returns once the namespaces are listed.
+func Start(ctx context.Context, client kubernetes.Interface, index, count int, label string) (*Shard, error) {
+	factory := informers.NewSharedInformerFactory(client, 0)
+	namespaceInformer := factory.Core().V1().Namespaces()
+	s, err := New(index, count, label, namespaceInformer.Lister())
+	if err != nil {
+		return nil, err
+	}
+	namespaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: s.namespaceUpdated,
+	})
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, fmt.Errorf("failed to sync the informer of %v", informerType)
+		}
+	}
+	return s, nil
+}
+
+// Of returns the index of the shard owning the namespace, among count shards.
+func Of(namespace *corev1.Namespace, label string, count int) int {
+	key := namespace.Name
+	if value, ok := namespace.Labels[label]; ok {
+		key = value
+	}
+	h := fnv.New32a()
+	// The writes to a hash never fail.
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(count))
+}
+
+// Owns returns true if the shard owns the namespace. The namespaces which aren't
+// listed yet are selected by the hash of their name.
+func (s *Shard) Owns(namespace string) bool {
+	ns, err := s.namespaces.Get(namespace)
+	switch {
+	case apierrors.IsNotFound(err):
+		ns = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	case err != nil:
+		return false
+	}
+	return s.owns(ns)
+}
+
+func (s *Shard) owns(ns *corev1.Namespace) bool {
+	return Of(ns, s.Label, s.Count) == s.Index
+}
+
+// namespaceUpdated reconciles the objects in the namespace if the shard starts
+// owning it, e.g. because its shard label changed.
+func (s *Shard) namespaceUpdated(oldObj, newObj interface{}) {
+	oldNamespace, ok := oldObj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	newNamespace, ok := newObj.(*corev1.Namespace)
+	if !ok || s.owns(oldNamespace) || !s.owns(newNamespace) {
+		return
+	}
+	s.mu.Lock()
+	resyncs := append([]func(string){}, s.resyncs...)
+	s.mu.Unlock()
+	for _, resync := range resyncs {
+		resync(newNamespace.Name)
+	}
+}
+
+// IndexFromPodName returns the index of the shard of a replica from the name of
+// its pod, which ends with its ordinal in a StatefulSet, e.g. 2 for
+// "tekton-pipelines-controller-2".
+func IndexFromPodName(name string) (int, error) {
+	index, err := strconv.Atoi(name[strings.LastIndex(name, "-")+1:])
+	if err != nil {
+		return 0, fmt.Errorf("the name of the pod %q doesn't end with an ordinal: %w", name, err)
+	}
+	return index, nil
+}
+
+type shardKey struct{}
+
+// WithShard returns a context in which the controllers only reconcile the
+// namespaces owned by the shard.
+func WithShard(ctx context.Context, s *Shard) context.Context {
+	return context.WithValue(ctx, shardKey{}, s)
+}
+
+// FromContext returns the shard of the context, or nil if the controller isn't sharded.
+func FromContext(ctx context.Context) *Shard {
+	s, _ := ctx.Value(shardKey{}).(*Shard)
+	return s
+}
+
+// Apply restricts the reconciliation of the controller to the namespaces owned by
+// the shard of the context, if any. The objects of the informer in a namespace are
+// reconciled when the shard starts owning it.
+func Apply(ctx context.Context, impl *controller.Impl, informer cache.SharedInformer) {
+	s := FromContext(ctx)
+	if s == nil {
+		return
+	}
+	r := &shardedReconciler{Reconciler: impl.Reconciler, shard: s}
+	if la, ok := impl.Reconciler.(reconciler.LeaderAware); ok {
+		impl.Reconciler = &leaderAwareShardedReconciler{shardedReconciler: r, LeaderAware: la}
+	} else {
+		impl.Reconciler = r
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resyncs = append(s.resyncs, func(namespace string) {
+		impl.FilteredGlobalResync(func(obj interface{}) bool {
+			o, err := meta.Accessor(obj)
+			return err == nil && o.GetNamespace() == namespace
+		}, informer)
+	})
+}
+
+// shardedReconciler skips the keys of the objects in the namespaces owned by the
+// other shards.
+type shardedReconciler struct {
+	controller.Reconciler
+	shard *Shard
+}
+
+// Reconcile implements controller.Reconciler
+func (r *shardedReconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, _, err := cache.SplitMetaNamespaceKey(key)
+	if err == nil && namespace != "" && !r.shard.Owns(namespace) {
+		return controller.NewSkipKey(key)
+	}
+	return r.Reconciler.Reconcile(ctx, key)
+}
+
+// leaderAwareShardedReconciler is a shardedReconciler of a leader aware reconciler,
+// which keeps being promoted and demoted by the leader election of the controller.
+type leaderAwareShardedReconciler struct {
+	*shardedReconciler
+	reconciler.LeaderAware
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+)
+
+func namespaceLister(t *testing.T, namespaces ...*corev1.Namespace) corev1listers.NamespaceLister {
+	t.Helper()
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, ns := range namespaces {
+		if err := indexer.Add(ns); err != nil {
+			t.Fatalf("failed to add namespace %s: %v", ns.Name, err)
+		}
+	}
+	return corev1listers.NewNamespaceLister(indexer)
+}
+
+func namespace(name string, labels map[string]string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		index, count int
+	}{{index: 0, count: 0}, {index: -1, count: 2}, {index: 2, count: 2}} {
+		if _, err := New(tc.index, tc.count, "", nil); err == nil {
+			t.Errorf("expected an error for the shard %d of %d", tc.index, tc.count)
+		}
+	}
+}
+
+func TestOwns(t *testing.T) {
+	var namespaces []*corev1.Namespace
+	for i := 0; i < 20; i++ {
+		namespaces = append(namespaces, namespace(fmt.Sprintf("ns-%d", i), nil))
+	}
+	for i := 0; i < 5; i++ {
+		namespaces = append(namespaces, namespace(fmt.Sprintf("tenant-%d", i), map[string]string{DefaultLabel: "tenant"}))
+	}
+	lister := namespaceLister(t, namespaces...)
+
+	const count = 3
+	var shards []*Shard
+	for i := 0; i < count; i++ {
+		s, err := New(i, count, "", lister)
+		if err != nil {
+			t.Fatalf("New: %v", err)
+		}
+		shards = append(shards, s)
+	}
+	owned := map[string]int{}
+	for _, ns := range append(namespaces, namespace("not-listed-yet", nil)) {
+		owners := 0
+		for _, s := range shards {
+			if s.Owns(ns.Name) {
+				owners++
+				owned[ns.Name] = s.Index
+			}
+		}
+		if owners != 1 {
+			t.Errorf("expected namespace %s to be owned by one shard but got %d", ns.Name, owners)
+		}
+	}
+	tenantShard := Of(namespace("", map[string]string{DefaultLabel: "tenant"}), DefaultLabel, count)
+	for i := 0; i < 5; i++ {
+		if got := owned[fmt.Sprintf("tenant-%d", i)]; got != tenantShard {
+			t.Errorf("expected namespace tenant-%d to be owned by the shard of its label %d but got %d", i, tenantShard, got)
+		}
+	}
+}
+
+func TestIndexFromPodName(t *testing.T) {
+	if got, err := IndexFromPodName("tekton-pipelines-controller-2"); err != nil || got != 2 {
+		t.Errorf("expected index 2 but got %d, %v", got, err)
+	}
+	for _, name := range []string{"tekton-pipelines-controller-5d8f7c9b4-x2x7q", "controller"} {
+		if _, err := IndexFromPodName(name); err == nil {
+			t.Errorf("expected an error for pod %s", name)
+		}
+	}
+}
+
+type fakeReconciler struct {
+	reconciled []string
+}
+
+func (r *fakeReconciler) Reconcile(ctx context.Context, key string) error {
+	r.reconciled = append(r.reconciled, key)
+	return nil
+}
+
+type fakeLeaderAwareReconciler struct {
+	fakeReconciler
+	reconciler.LeaderAwareFuncs
+}
+
+func TestApply(t *testing.T) {
+	owned, other := namespace("owned", map[string]string{DefaultLabel: "a"}), namespace("other", map[string]string{DefaultLabel: "b"})
+	count := 2
+	for Of(owned, DefaultLabel, count) == Of(other, DefaultLabel, count) {
+		count++
+	}
+	s, err := New(Of(owned, DefaultLabel, count), count, "", namespaceLister(t, owned, other))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	ctx := WithShard(logging.WithLogger(context.Background(), logging.FromContext(context.Background())), s)
+
+	r := &fakeLeaderAwareReconciler{}
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{WorkQueueName: "test", Logger: logging.FromContext(ctx)})
+	informer := cache.NewSharedInformer(&cache.ListWatch{}, &v1beta1.TaskRun{}, 0)
+	Apply(ctx, impl, informer)
+
+	if _, ok := impl.Reconciler.(reconciler.LeaderAware); !ok {
+		t.Errorf("expected the reconciler to remain leader aware")
+	}
+	if err := impl.Reconciler.Reconcile(ctx, "owned/tr"); err != nil {
+		t.Errorf("unexpected error reconciling a TaskRun of the shard: %v", err)
+	}
+	if err := impl.Reconciler.Reconcile(ctx, "other/tr"); !controller.IsSkipKey(err) {
+		t.Errorf("expected the TaskRun of another shard to be skipped but got %v", err)
+	}
+	if len(r.reconciled) != 1 || r.reconciled[0] != "owned/tr" {
+		t.Errorf("expected only owned/tr to be reconciled but got %v", r.reconciled)
+	}
+
+	// The TaskRuns of a namespace moving to the shard are reconciled.
+	for _, tr := range []*v1beta1.TaskRun{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "tr"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "owned", Name: "tr"}},
+	} {
+		if err := informer.GetStore().Add(tr); err != nil {
+			t.Fatalf("failed to add TaskRun: %v", err)
+		}
+	}
+	moved := other.DeepCopy()
+	moved.Labels[DefaultLabel] = "a"
+	s.namespaceUpdated(owned, owned.DeepCopy())
+	s.namespaceUpdated(other, moved)
+
+	got := make(chan interface{})
+	go func() {
+		item, _ := impl.WorkQueue().Get()
+		got <- item
+	}()
+	select {
+	case item := <-got:
+		if want := (types.NamespacedName{Namespace: "other", Name: "tr"}); item != want {
+			t.Errorf("expected %v to be enqueued but got %v", want, item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the TaskRun of the namespace moving to the shard to be enqueued")
+	}
+	if l := impl.WorkQueue().Len(); l != 0 {
+		t.Errorf("expected a single TaskRun to be enqueued but got %d more", l)
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package slsa generates the SLSA v1 provenance of the TaskRuns and PipelineRuns
+// recorded in their status, so that signers such as Tekton Chains can sign it
+// without reconstructing it from the runs.
+//
+// The artifacts the runs produced are read from their type-hinted results:
+//   - the *IMAGE_URL and *IMAGE_DIGEST string results, paired by their prefix,
+//   - the IMAGES string result, a comma or newline separated list of image references
+//     with their digests,
+//   - the *ARTIFACT_URI and *ARTIFACT_DIGEST string results, paired by their prefix,
+//   - the *ARTIFACT_OUTPUTS object results, with the "uri" and "digest" keys.
+package slsa
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"knative.dev/pkg/changeset"
+)
+
+const (
+	// TaskRunBuildType is the build type of the SLSA provenance of TaskRuns.
+	TaskRunBuildType = "https://tekton.dev/slsa-provenance/taskrun@v1"
+	// PipelineRunBuildType is the build type of the SLSA provenance of PipelineRuns.
+	PipelineRunBuildType = "https://tekton.dev/slsa-provenance/pipelinerun@v1"
+
+	imagesResult          = "IMAGES"
+	imageURLSuffix        = "IMAGE_URL"
+	imageDigestSuffix     = "IMAGE_DIGEST"
+	artifactURISuffix     = "ARTIFACT_URI"
+	artifactDigestSuffix  = "ARTIFACT_DIGEST"
+	artifactOutputsSuffix = "ARTIFACT_OUTPUTS"
+
+	// dockerPullablePrefix prefixes the image IDs reported by some container runtimes.
+	dockerPullablePrefix = "docker-pullable://"
+)
+
+// ForTaskRun returns the SLSA v1 provenance of the TaskRun, executed by the builder
+// identified by builderID.
+func ForTaskRun(tr *v1beta1.TaskRun, builderID string) *v1beta1.SLSAProvenance {
+	results := map[string]v1beta1.ResultValue{}
+	for _, r := range tr.Status.TaskRunResults {
+		results[r.Name] = r.Value
+	}
+	p := newProvenance(TaskRunBuildType, builderID, tr.Spec.Params, results)
+	p.Predicate.BuildDefinition.ResolvedDependencies = taskRunDependencies(tr)
+	p.Predicate.RunDetails.Metadata = v1beta1.SLSABuildMetadata{
+		InvocationID: string(tr.UID),
+		StartedOn:    tr.Status.StartTime,
+		FinishedOn:   tr.Status.CompletionTime,
+	}
+	return p
+}
+
+// ForPipelineRun returns the SLSA v1 provenance of the PipelineRun, executed by the
+// builder identified by builderID. The dependencies of its TaskRuns are resolved
+// dependencies of the PipelineRun.
+func ForPipelineRun(pr *v1beta1.PipelineRun, taskRuns []*v1beta1.TaskRun, builderID string) *v1beta1.SLSAProvenance {
+	results := map[string]v1beta1.ResultValue{}
+	for _, r := range pr.Status.PipelineResults {
+		results[r.Name] = r.Value
+	}
+	p := newProvenance(PipelineRunBuildType, builderID, pr.Spec.Params, results)
+	var dependencies []v1beta1.SLSAResourceDescriptor
+	if pr.Status.Provenance != nil && pr.Status.Provenance.RefSource != nil {
+		dependencies = append(dependencies, refSourceDependency("pipeline", pr.Status.Provenance.RefSource))
+	}
+	for _, tr := range taskRuns {
+		dependencies = append(dependencies, taskRunDependencies(tr)...)
+	}
+	p.Predicate.BuildDefinition.ResolvedDependencies = dedupe(dependencies)
+	p.Predicate.RunDetails.Metadata = v1beta1.SLSABuildMetadata{
+		InvocationID: string(pr.UID),
+		StartedOn:    pr.Status.StartTime,
+		FinishedOn:   pr.Status.CompletionTime,
+	}
+	return p
+}
+
+func newProvenance(buildType, builderID string, params v1beta1.Params, results map[string]v1beta1.ResultValue) *v1beta1.SLSAProvenance {
+	p := &v1beta1.SLSAProvenance{
+		Subject:       subjects(results),
+		PredicateType: v1beta1.SLSAProvenancePredicateType,
+	}
+	p.Predicate.BuildDefinition.BuildType = buildType
+	if len(params) > 0 {
+		p.Predicate.BuildDefinition.ExternalParameters = map[string]v1beta1.ParamValue{}
+		for _, param := range params {
+			p.Predicate.BuildDefinition.ExternalParameters[param.Name] = param.Value
+		}
+	}
+	p.Predicate.RunDetails.Builder = v1beta1.SLSABuilder{
+		ID:      builderID,
+		Version: map[string]string{"tekton-pipelines": changeset.Get()},
+	}
+	return p
+}
+
+// taskRunDependencies returns the source of the Task and the images of the steps
+// and the sidecars the TaskRun executed.
+func taskRunDependencies(tr *v1beta1.TaskRun) []v1beta1.SLSAResourceDescriptor {
+	var dependencies []v1beta1.SLSAResourceDescriptor
+	if tr.Status.Provenance != nil && tr.Status.Provenance.RefSource != nil {
+		dependencies = append(dependencies, refSourceDependency("task", tr.Status.Provenance.RefSource))
+	}
+	for _, s := range tr.Status.Steps {
+		if d, ok := imageDependency(s.ImageID); ok {
+			dependencies = append(dependencies, d)
+		}
+	}
+	for _, s := range tr.Status.Sidecars {
+		if d, ok := imageDependency(s.ImageID); ok {
+			dependencies = append(dependencies, d)
+		}
+	}
+	return dedupe(dependencies)
+}
+
+func refSourceDependency(name string, source *v1beta1.RefSource) v1beta1.SLSAResourceDescriptor {
+	return v1beta1.SLSAResourceDescriptor{Name: name, URI: source.URI, Digest: source.Digest}
+}
+
+// imageDependency returns the image of the ID reported by the container runtime,
+// e.g. "docker-pullable://gcr.io/foo/bar@sha256:<hex>", if it holds a digest.
+func imageDependency(imageID string) (v1beta1.SLSAResourceDescriptor, bool) {
+	name, digest, ok := splitImage(strings.TrimPrefix(imageID, dockerPullablePrefix))
+	if !ok {
+		return v1beta1.SLSAResourceDescriptor{}, false
+	}
+	return v1beta1.SLSAResourceDescriptor{URI: "oci://" + name, Digest: digest}, true
+}
+
+// subjects returns the artifacts declared by the type-hinted results, sorted by their
+// names.
+func subjects(results map[string]v1beta1.ResultValue) []v1beta1.SLSAResourceDescriptor {
+	var subjects []v1beta1.SLSAResourceDescriptor
+	for name, value := range results {
+		switch {
+		case name == imagesResult:
+			for _, ref := range strings.FieldsFunc(value.StringVal, func(r rune) bool { return r == ',' || r == '\n' }) {
+				if n, d, ok := splitImage(strings.TrimSpace(ref)); ok {
+					subjects = append(subjects, v1beta1.SLSAResourceDescriptor{Name: n, Digest: d})
+				}
+			}
+		case strings.HasSuffix(name, imageURLSuffix):
+			if d, ok := results[strings.TrimSuffix(name, imageURLSuffix)+imageDigestSuffix]; ok {
+				if s, ok := subject(value.StringVal, d.StringVal); ok {
+					subjects = append(subjects, s)
+				}
+			}
+		case strings.HasSuffix(name, artifactURISuffix):
+			if d, ok := results[strings.TrimSuffix(name, artifactURISuffix)+artifactDigestSuffix]; ok {
+				if s, ok := subject(value.StringVal, d.StringVal); ok {
+					subjects = append(subjects, s)
+				}
+			}
+		case strings.HasSuffix(name, artifactOutputsSuffix):
+			if s, ok := subject(value.ObjectVal["uri"], value.ObjectVal["digest"]); ok {
+				subjects = append(subjects, s)
+			}
+		}
+	}
+	return dedupe(subjects)
+}
+
+func subject(name, digest string) (v1beta1.SLSAResourceDescriptor, bool) {
+	d, ok := parseDigest(strings.TrimSpace(digest))
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return v1beta1.SLSAResourceDescriptor{}, false
+	}
+	// The image URLs may hold the digest too.
+	if n, _, ok := splitImage(name); ok {
+		name = n
+	}
+	return v1beta1.SLSAResourceDescriptor{Name: name, Digest: d}, true
+}
+
+// splitImage splits an image reference with a digest, e.g. "gcr.io/foo/bar@sha256:<hex>",
+// into its name and its digest.
+func splitImage(ref string) (string, map[string]string, bool) {
+	name, digest, ok := strings.Cut(ref, "@")
+	if !ok || name == "" {
+		return "", nil, false
+	}
+	d, ok := parseDigest(digest)
+	return name, d, ok
+}
+
+// parseDigest parses a digest in the form "<algorithm>:<hex>".
+func parseDigest(digest string) (map[string]string, bool) {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok || algorithm == "" || hex == "" {
+		return nil, false
+	}
+	return map[string]string{algorithm: hex}, true
+}
+
+// dedupe removes the duplicated descriptors and sorts the others by their names and URIs.
+func dedupe(descriptors []v1beta1.SLSAResourceDescriptor) []v1beta1.SLSAResourceDescriptor {
+	seen := map[string]bool{}
+	var deduped []v1beta1.SLSAResourceDescriptor
+	for _, d := range descriptors {
+		digests := make([]string, 0, len(d.Digest))
+		for algorithm, hex := range d.Digest {
+			digests = append(digests, algorithm+":"+hex)
+		}
+		sort.Strings(digests)
+		key := d.Name + " " + d.URI + " " + strings.Join(digests, " ")
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		deduped = append(deduped, d)
+	}
+	sort.SliceStable(deduped, func(i, j int) bool {
+		if deduped[i].Name != deduped[j].Name {
+			return deduped[i].Name < deduped[j].Name
+		}
+		return deduped[i].URI < deduped[j].URI
+	})
+	return deduped
+}
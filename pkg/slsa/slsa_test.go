@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package slsa
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/changeset"
+)
+
+const (
+	builderID = "https://tekton.dev/pipelines/controller"
+	digestA   = "05f95b26ed10668b7183c1e2da98610e91372fa9f510046d4ce5812addad86b5"
+	digestB   = "2b8a8eb1b7b0e6e8bd94d27dc6e1cbe5f0a08e3dfb03b2f52d8b6d3b4f2e6c11"
+	digestC   = "c4e6b6a2f0f1a1d0e9b5f2d8c3a7b6e5d4c3b2a1f0e9d8c7b6a5f4e3d2c1b0a9"
+)
+
+var (
+	startTime      = metav1.NewTime(time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC))
+	completionTime = metav1.NewTime(time.Date(2023, 5, 1, 10, 5, 0, 0, time.UTC))
+)
+
+func TestForTaskRun(t *testing.T) {
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", UID: "e1b2c3"},
+		Spec: v1beta1.TaskRunSpec{
+			Params: v1beta1.Params{{Name: "url", Value: *v1beta1.NewStructuredValues("https://github.com/tektoncd/pipeline")}},
+		},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			StartTime:      &startTime,
+			CompletionTime: &completionTime,
+			Steps: []v1beta1.StepState{{
+				Name:    "clone",
+				ImageID: "docker-pullable://gcr.io/tekton-releases/git-init@sha256:" + digestA,
+			}, {
+				Name:    "build",
+				ImageID: "docker.io/library/golang@sha256:" + digestB,
+			}, {
+				Name:    "unpinned",
+				ImageID: "docker.io/library/alpine:3.18",
+			}},
+			Sidecars: []v1beta1.SidecarState{{
+				Name:    "registry",
+				ImageID: "docker-pullable://gcr.io/tekton-releases/git-init@sha256:" + digestA,
+			}},
+			TaskRunResults: []v1beta1.TaskRunResult{{
+				Name:  "IMAGE_URL",
+				Value: *v1beta1.NewStructuredValues("gcr.io/foo/bar"),
+			}, {
+				Name:  "IMAGE_DIGEST",
+				Value: *v1beta1.NewStructuredValues("sha256:" + digestA),
+			}, {
+				Name:  "BINARY_ARTIFACT_OUTPUTS",
+				Value: *v1beta1.NewObject(map[string]string{"uri": "https://example.com/bin/foo", "digest": "sha512:" + digestB}),
+			}, {
+				Name:  "IMAGES",
+				Value: *v1beta1.NewStructuredValues("gcr.io/foo/baz@sha256:" + digestC + ",\ngcr.io/foo/bar@sha256:" + digestA),
+			}, {
+				Name:  "commit",
+				Value: *v1beta1.NewStructuredValues("f99d13e554ffcb696dee719fa85b695cb5b0f428"),
+			}},
+			Provenance: &v1beta1.Provenance{
+				RefSource: &v1beta1.RefSource{
+					URI:        "git+https://github.com/tektoncd/catalog.git",
+					Digest:     map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+					EntryPoint: "task/golang-build/0.3/golang-build.yaml",
+				},
+			},
+		}},
+	}
+
+	want := &v1beta1.SLSAProvenance{
+		Subject: []v1beta1.SLSAResourceDescriptor{
+			{Name: "gcr.io/foo/bar", Digest: map[string]string{"sha256": digestA}},
+			{Name: "gcr.io/foo/baz", Digest: map[string]string{"sha256": digestC}},
+			{Name: "https://example.com/bin/foo", Digest: map[string]string{"sha512": digestB}},
+		},
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Predicate: v1beta1.SLSAPredicate{
+			BuildDefinition: v1beta1.SLSABuildDefinition{
+				BuildType: TaskRunBuildType,
+				ExternalParameters: map[string]v1beta1.ParamValue{
+					"url": *v1beta1.NewStructuredValues("https://github.com/tektoncd/pipeline"),
+				},
+				ResolvedDependencies: []v1beta1.SLSAResourceDescriptor{
+					{URI: "oci://docker.io/library/golang", Digest: map[string]string{"sha256": digestB}},
+					{URI: "oci://gcr.io/tekton-releases/git-init", Digest: map[string]string{"sha256": digestA}},
+					{Name: "task", URI: "git+https://github.com/tektoncd/catalog.git", Digest: map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"}},
+				},
+			},
+			RunDetails: v1beta1.SLSARunDetails{
+				Builder: v1beta1.SLSABuilder{
+					ID:      builderID,
+					Version: map[string]string{"tekton-pipelines": changeset.Get()},
+				},
+				Metadata: v1beta1.SLSABuildMetadata{
+					InvocationID: "e1b2c3",
+					StartedOn:    &startTime,
+					FinishedOn:   &completionTime,
+				},
+			},
+		},
+	}
+	if d := cmp.Diff(want, ForTaskRun(tr, builderID)); d != "" {
+		t.Errorf("ForTaskRun() %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestForPipelineRun(t *testing.T) {
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", UID: "a4d5e6"},
+		Spec: v1beta1.PipelineRunSpec{
+			Params: v1beta1.Params{{Name: "version", Value: *v1beta1.NewStructuredValues("v0.1.0")}},
+		},
+		Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+			StartTime:      &startTime,
+			CompletionTime: &completionTime,
+			PipelineResults: []v1beta1.PipelineRunResult{{
+				Name:  "RELEASE_ARTIFACT_URI",
+				Value: *v1beta1.NewStructuredValues("https://example.com/releases/v0.1.0.tar.gz"),
+			}, {
+				Name:  "RELEASE_ARTIFACT_DIGEST",
+				Value: *v1beta1.NewStructuredValues("sha256:" + digestC),
+			}, {
+				Name:  "UNPAIRED_IMAGE_URL",
+				Value: *v1beta1.NewStructuredValues("gcr.io/foo/unpaired"),
+			}},
+			Provenance: &v1beta1.Provenance{
+				RefSource: &v1beta1.RefSource{
+					URI:    "git+https://github.com/tektoncd/pipeline.git",
+					Digest: map[string]string{"sha1": "0f5a7a8c0fc1d1b1e4d5bbdbb5a6f7c2a7d3b4c5"},
+				},
+			},
+		}},
+	}
+	taskRuns := []*v1beta1.TaskRun{{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-build"},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{Name: "build", ImageID: "docker.io/library/golang@sha256:" + digestB}},
+			Provenance: &v1beta1.Provenance{
+				RefSource: &v1beta1.RefSource{
+					URI:    "git+https://github.com/tektoncd/catalog.git",
+					Digest: map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"},
+				},
+			},
+		}},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "release-test"},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{Name: "test", ImageID: "docker.io/library/golang@sha256:" + digestB}},
+		}},
+	}}
+
+	want := &v1beta1.SLSAProvenance{
+		Subject: []v1beta1.SLSAResourceDescriptor{
+			{Name: "https://example.com/releases/v0.1.0.tar.gz", Digest: map[string]string{"sha256": digestC}},
+		},
+		PredicateType: "https://slsa.dev/provenance/v1",
+		Predicate: v1beta1.SLSAPredicate{
+			BuildDefinition: v1beta1.SLSABuildDefinition{
+				BuildType: PipelineRunBuildType,
+				ExternalParameters: map[string]v1beta1.ParamValue{
+					"version": *v1beta1.NewStructuredValues("v0.1.0"),
+				},
+				ResolvedDependencies: []v1beta1.SLSAResourceDescriptor{
+					{URI: "oci://docker.io/library/golang", Digest: map[string]string{"sha256": digestB}},
+					{Name: "pipeline", URI: "git+https://github.com/tektoncd/pipeline.git", Digest: map[string]string{"sha1": "0f5a7a8c0fc1d1b1e4d5bbdbb5a6f7c2a7d3b4c5"}},
+					{Name: "task", URI: "git+https://github.com/tektoncd/catalog.git", Digest: map[string]string{"sha1": "f99d13e554ffcb696dee719fa85b695cb5b0f428"}},
+				},
+			},
+			RunDetails: v1beta1.SLSARunDetails{
+				Builder: v1beta1.SLSABuilder{
+					ID:      builderID,
+					Version: map[string]string{"tekton-pipelines": changeset.Get()},
+				},
+				Metadata: v1beta1.SLSABuildMetadata{
+					InvocationID: "a4d5e6",
+					StartedOn:    &startTime,
+					FinishedOn:   &completionTime,
+				},
+			},
+		},
+	}
+	if d := cmp.Diff(want, ForPipelineRun(pr, taskRuns, builderID)); d != "" {
+		t.Errorf("ForPipelineRun() %s", diff.PrintWantGot(d))
+	}
+}
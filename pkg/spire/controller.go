@@ -201,14 +201,14 @@ func (sc *spireControllerAPIClient) workloadEntry(tr *v1beta1.TaskRun, pod *core
 	}
 }
 
-// ttl is the TTL for the SPIRE entry in seconds, not the SVID TTL
+// ttl is the TTL for the SPIRE entry, not the SVID TTL
 func (sc *spireControllerAPIClient) CreateEntries(ctx context.Context, tr *v1beta1.TaskRun, pod *corev1.Pod, ttl time.Duration) error {
 	err := sc.setupClient(ctx)
 	if err != nil {
 		return err
 	}
 
-	expiryTime := time.Now().Unix() + int64(ttl)
+	expiryTime := time.Now().Add(ttl).Unix()
 	entries := []*spiffetypes.Entry{
 		sc.nodeEntry(pod.Spec.NodeName),
 		sc.workloadEntry(tr, pod, expiryTime),
@@ -0,0 +1,314 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statusoffload offloads the largest fields of the status of the
+// PipelineRuns to ConfigMaps or to an object store, as set in "status-offload",
+// so that the status of the PipelineRuns of large Pipelines fits in etcd.
+package statusoffload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// chunkSize is the size of the chunk of the offloaded fields held by each
+	// ConfigMap, within the 1MiB limit on the size of ConfigMaps.
+	chunkSize = 768 * 1024
+	// dataKey is the key of the chunk in the binary data of the ConfigMaps.
+	dataKey = "status.json.gz"
+	// cacheSize is the number of PipelineRuns whose offloaded fields are cached.
+	cacheSize = 128
+)
+
+// fields are the offloaded fields of the status of a PipelineRun.
+type fields struct {
+	PipelineSpec    *v1beta1.PipelineSpec          `json:"pipelineSpec,omitempty"`
+	ChildReferences []v1beta1.ChildStatusReference `json:"childReferences,omitempty"`
+	SkippedTasks    []v1beta1.SkippedTask          `json:"skippedTasks,omitempty"`
+}
+
+// cached are the offloaded fields of a PipelineRun, compressed, with their digest.
+type cached struct {
+	digest string
+	data   []byte
+}
+
+// Offloader offloads the fields of the statuses of the PipelineRuns and rehydrates
+// them. The offloaded fields are cached, so that they are only read back once
+// offloaded by another replica or before the controller restarted.
+//
+// The fields are offloaded to two slots in turn, so that the slot referenced by
+// the status stored in etcd is never overwritten: when the status update following
+// an offload fails, the PipelineRun is rehydrated from the previous slot.
+type Offloader struct {
+	kubeclient kubernetes.Interface
+	client     *http.Client
+	cache      *lru.Cache
+}
+
+// NewOffloader returns an Offloader writing the ConfigMaps with the kube client and
+// the objects of the object store with the http client.
+func NewOffloader(kubeclient kubernetes.Interface, client *http.Client) *Offloader {
+	// lru.New only fails for a non-positive size.
+	cache, _ := lru.New(cacheSize)
+	return &Offloader{kubeclient: kubeclient, client: client, cache: cache}
+}
+
+// Rehydrate restores the offloaded fields of the status of the PipelineRun, if any.
+func (o *Offloader) Rehydrate(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	ref := pr.Status.OffloadedStatus
+	if ref == nil {
+		return nil
+	}
+	var data []byte
+	if c, ok := o.cache.Get(pr.UID); ok && c.(cached).digest == ref.Digest {
+		data = c.(cached).data
+	} else {
+		var err error
+		if data, err = o.read(ctx, pr.Namespace, ref); err != nil {
+			return fmt.Errorf("failed to read the offloaded status of PipelineRun %s: %w", pr.Name, err)
+		}
+		if d := digest(data); d != ref.Digest {
+			return fmt.Errorf("the offloaded status of PipelineRun %s has digest %s, not %s", pr.Name, d, ref.Digest)
+		}
+		o.cache.Add(pr.UID, cached{digest: ref.Digest, data: data})
+	}
+
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	var f fields
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return fmt.Errorf("failed to decode the offloaded status of PipelineRun %s: %w", pr.Name, err)
+	}
+	pr.Status.PipelineSpec = f.PipelineSpec
+	pr.Status.ChildReferences = f.ChildReferences
+	pr.Status.SkippedTasks = f.SkippedTasks
+	return nil
+}
+
+// Offload offloads the pipelineSpec, childReferences and skippedTasks of the status
+// of the PipelineRun as set in "status-offload", unless they already are, and only
+// leaves the names of the children and of the skipped tasks in the status. The status
+// is left whole when "status-offload" is unset.
+func (o *Offloader) Offload(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	store := config.FromContextOrDefaults(ctx).FeatureFlags.StatusOffload
+	f := fields{
+		PipelineSpec:    pr.Status.PipelineSpec,
+		ChildReferences: pr.Status.ChildReferences,
+		SkippedTasks:    pr.Status.SkippedTasks,
+	}
+	if store == "" || (pr.Status.OffloadedStatus == nil && reflect.DeepEqual(f, fields{})) {
+		pr.Status.OffloadedStatus = nil
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(w).Encode(f); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+
+	ref := pr.Status.OffloadedStatus
+	var err error
+	if slot := slotOf(pr, store, ref); slot < 0 || !reflect.DeepEqual(ref, reference(pr, store, slot, data)) {
+		if written, werr := o.write(ctx, pr, store, data); werr != nil {
+			// Keep referencing the fields offloaded before, if any, rather than
+			// growing the status.
+			err = fmt.Errorf("failed to offload the status of PipelineRun %s: %w", pr.Name, werr)
+			if ref == nil {
+				return err
+			}
+		} else {
+			ref = written
+			o.cache.Add(pr.UID, cached{digest: ref.Digest, data: data})
+		}
+	}
+
+	pr.Status.OffloadedStatus = ref
+	pr.Status.PipelineSpec = nil
+	childRefs := make([]v1beta1.ChildStatusReference, 0, len(f.ChildReferences))
+	for _, cr := range f.ChildReferences {
+		childRefs = append(childRefs, v1beta1.ChildStatusReference{TypeMeta: cr.TypeMeta, Name: cr.Name, PipelineTaskName: cr.PipelineTaskName})
+	}
+	pr.Status.ChildReferences = childRefs
+	skippedTasks := make([]v1beta1.SkippedTask, 0, len(f.SkippedTasks))
+	for _, st := range f.SkippedTasks {
+		skippedTasks = append(skippedTasks, v1beta1.SkippedTask{Name: st.Name, Reason: st.Reason})
+	}
+	pr.Status.SkippedTasks = skippedTasks
+	return err
+}
+
+// write writes the offloaded fields to the slot of the store which the status
+// doesn't reference, and returns the reference to it.
+func (o *Offloader) write(ctx context.Context, pr *v1beta1.PipelineRun, store string, data []byte) (*v1beta1.OffloadedStatus, error) {
+	slot := 0
+	if slotOf(pr, store, pr.Status.OffloadedStatus) == 0 {
+		slot = 1
+	}
+	ref := reference(pr, store, slot, data)
+	if ref.URL != "" {
+		return ref, o.put(ctx, ref.URL, data)
+	}
+
+	for i, name := range ref.ConfigMaps {
+		end := (i + 1) * chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       pr.Namespace,
+				Labels:          map[string]string{pipeline.PipelineRunLabelKey: pr.Name},
+				OwnerReferences: []metav1.OwnerReference{*kmeta.NewControllerRef(pr)},
+			},
+			BinaryData: map[string][]byte{dataKey: data[i*chunkSize : end]},
+		}
+		_, err := o.kubeclient.CoreV1().ConfigMaps(pr.Namespace).Create(ctx, cm, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			_, err = o.kubeclient.CoreV1().ConfigMaps(pr.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Delete the chunks left from a larger status offloaded to the slot before.
+	for i := len(ref.ConfigMaps); ; i++ {
+		err := o.kubeclient.CoreV1().ConfigMaps(pr.Namespace).Delete(ctx, chunkName(pr, slot, i), metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return ref, nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// read reads the offloaded fields referenced by the status.
+func (o *Offloader) read(ctx context.Context, namespace string, ref *v1beta1.OffloadedStatus) ([]byte, error) {
+	if ref.URL != "" {
+		return o.get(ctx, ref.URL)
+	}
+	var data []byte
+	for _, name := range ref.ConfigMaps {
+		cm, err := o.kubeclient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, cm.BinaryData[dataKey]...)
+	}
+	return data, nil
+}
+
+func (o *Offloader) put(ctx context.Context, url string, data []byte) error {
+	put, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	put.Header.Set("Content-Type", "application/json")
+	put.Header.Set("Content-Encoding", "gzip")
+	resp, err := o.client.Do(put)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("uploading to %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+func (o *Offloader) get(ctx context.Context, url string) ([]byte, error) {
+	get, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Read the object as stored rather than have the client decompress it.
+	get.Header.Set("Accept-Encoding", "gzip")
+	resp, err := o.client.Do(get)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("downloading %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// reference returns the reference to the offloaded fields written to the slot of
+// the store: the object <store>/<namespace>/<name>-<uid>/status-<slot>.json.gz in
+// an object store, or the ConfigMaps <name>-status-<slot>-<chunk>.
+func reference(pr *v1beta1.PipelineRun, store string, slot int, data []byte) *v1beta1.OffloadedStatus {
+	ref := &v1beta1.OffloadedStatus{Digest: digest(data)}
+	if store != config.StatusOffloadConfigMap {
+		ref.URL = fmt.Sprintf("%s/%s/%s-%s/status-%d.json.gz", store, pr.Namespace, pr.Name, pr.UID, slot)
+		return ref
+	}
+	for i := 0; i*chunkSize < len(data); i++ {
+		ref.ConfigMaps = append(ref.ConfigMaps, chunkName(pr, slot, i))
+	}
+	return ref
+}
+
+// slotOf returns the slot of the store the reference is to, or -1 when there is
+// no reference or it is to another store.
+func slotOf(pr *v1beta1.PipelineRun, store string, ref *v1beta1.OffloadedStatus) int {
+	if ref == nil {
+		return -1
+	}
+	for slot := 0; slot < 2; slot++ {
+		if store != config.StatusOffloadConfigMap && ref.URL == reference(pr, store, slot, nil).URL {
+			return slot
+		}
+		if store == config.StatusOffloadConfigMap && len(ref.ConfigMaps) > 0 && ref.ConfigMaps[0] == chunkName(pr, slot, 0) {
+			return slot
+		}
+	}
+	return -1
+}
+
+func chunkName(pr *v1beta1.PipelineRun, slot, chunk int) string {
+	return kmeta.ChildName(pr.Name, fmt.Sprintf("-status-%d-%d", slot, chunk))
+}
+
+func digest(data []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(data))
+}
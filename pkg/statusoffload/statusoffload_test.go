@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statusoffload_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/statusoffload"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakekubeclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func withStatusOffload(store string) context.Context {
+	return config.ToContext(context.Background(), &config.Config{FeatureFlags: &config.FeatureFlags{StatusOffload: store}})
+}
+
+func pipelineRun() *v1beta1.PipelineRun {
+	when := []v1beta1.WhenExpression{{Input: "$(params.branch)", Operator: "in", Values: []string{"main"}}}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "0123"},
+	}
+	pr.Status.PipelineSpec = &v1beta1.PipelineSpec{Tasks: []v1beta1.PipelineTask{{Name: "build", TaskRef: &v1beta1.TaskRef{Name: "build"}}}}
+	pr.Status.ChildReferences = []v1beta1.ChildStatusReference{{
+		TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+		Name:             "pr-build",
+		PipelineTaskName: "build",
+		WhenExpressions:  when,
+	}}
+	pr.Status.SkippedTasks = []v1beta1.SkippedTask{{Name: "deploy", Reason: v1beta1.WhenExpressionsSkip, WhenExpressions: when}}
+	return pr
+}
+
+func wantOffloaded(ref *v1beta1.OffloadedStatus) v1beta1.PipelineRunStatusFields {
+	return v1beta1.PipelineRunStatusFields{
+		ChildReferences: []v1beta1.ChildStatusReference{{
+			TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+			Name:             "pr-build",
+			PipelineTaskName: "build",
+		}},
+		SkippedTasks:    []v1beta1.SkippedTask{{Name: "deploy", Reason: v1beta1.WhenExpressionsSkip}},
+		OffloadedStatus: ref,
+	}
+}
+
+func TestOffloadToConfigMaps(t *testing.T) {
+	ctx := withStatusOffload(config.StatusOffloadConfigMap)
+	kubeclient := fakekubeclient.NewSimpleClientset()
+	pr := pipelineRun()
+	if err := statusoffload.NewOffloader(kubeclient, http.DefaultClient).Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	if d := cmp.Diff([]string{"pr-status-0-0"}, pr.Status.OffloadedStatus.ConfigMaps); d != "" {
+		t.Errorf("offloaded to ConfigMaps %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(wantOffloaded(pr.Status.OffloadedStatus), pr.Status.PipelineRunStatusFields); d != "" {
+		t.Errorf("offloaded status %s", diff.PrintWantGot(d))
+	}
+	cm, err := kubeclient.CoreV1().ConfigMaps("foo").Get(ctx, "pr-status-0-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cm.OwnerReferences) != 1 || cm.OwnerReferences[0].UID != pr.UID {
+		t.Errorf("ConfigMap owned by %v, want the PipelineRun", cm.OwnerReferences)
+	}
+
+	// Another replica, without the offloaded fields cached, reads them back.
+	if err := statusoffload.NewOffloader(kubeclient, http.DefaultClient).Rehydrate(ctx, pr); err != nil {
+		t.Fatalf("Rehydrate() = %v", err)
+	}
+	want := pipelineRun()
+	want.Status.OffloadedStatus = pr.Status.OffloadedStatus
+	if d := cmp.Diff(want, pr); d != "" {
+		t.Errorf("rehydrated PipelineRun %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestOffloadAlternatesSlots(t *testing.T) {
+	ctx := withStatusOffload(config.StatusOffloadConfigMap)
+	kubeclient := fakekubeclient.NewSimpleClientset()
+	offloader := statusoffload.NewOffloader(kubeclient, http.DefaultClient)
+	pr := pipelineRun()
+	if err := offloader.Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	first := pr.Status.OffloadedStatus
+
+	// The fields are not written again while they don't change.
+	kubeclient.ClearActions()
+	if err := offloader.Rehydrate(ctx, pr); err != nil {
+		t.Fatalf("Rehydrate() = %v", err)
+	}
+	if err := offloader.Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	if len(kubeclient.Actions()) != 0 {
+		t.Errorf("unchanged status offloaded again with %v", kubeclient.Actions())
+	}
+
+	// Changed fields are written to the other slot, keeping the previous one.
+	if err := offloader.Rehydrate(ctx, pr); err != nil {
+		t.Fatalf("Rehydrate() = %v", err)
+	}
+	pr.Status.ChildReferences = append(pr.Status.ChildReferences, v1beta1.ChildStatusReference{Name: "pr-test", PipelineTaskName: "test"})
+	if err := offloader.Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	if d := cmp.Diff([]string{"pr-status-1-0"}, pr.Status.OffloadedStatus.ConfigMaps); d != "" {
+		t.Errorf("offloaded to ConfigMaps %s", diff.PrintWantGot(d))
+	}
+	if pr.Status.OffloadedStatus.Digest == first.Digest {
+		t.Errorf("changed status offloaded with the same digest %s", first.Digest)
+	}
+	if _, err := kubeclient.CoreV1().ConfigMaps("foo").Get(ctx, "pr-status-0-0", metav1.GetOptions{}); err != nil {
+		t.Errorf("previous slot: %v", err)
+	}
+}
+
+func TestOffloadToObjectStore(t *testing.T) {
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch req.Method {
+		case http.MethodPut:
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			objects[req.URL.Path] = body
+		case http.MethodGet:
+			body, ok := objects[req.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	ctx := withStatusOffload(server.URL + "/tekton")
+	pr := pipelineRun()
+	if err := statusoffload.NewOffloader(fakekubeclient.NewSimpleClientset(), server.Client()).Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	if want := server.URL + "/tekton/foo/pr-0123/status-0.json.gz"; pr.Status.OffloadedStatus.URL != want {
+		t.Errorf("offloaded to %s, want %s", pr.Status.OffloadedStatus.URL, want)
+	}
+	if d := cmp.Diff(wantOffloaded(pr.Status.OffloadedStatus), pr.Status.PipelineRunStatusFields); d != "" {
+		t.Errorf("offloaded status %s", diff.PrintWantGot(d))
+	}
+
+	if err := statusoffload.NewOffloader(fakekubeclient.NewSimpleClientset(), server.Client()).Rehydrate(ctx, pr); err != nil {
+		t.Fatalf("Rehydrate() = %v", err)
+	}
+	want := pipelineRun()
+	want.Status.OffloadedStatus = pr.Status.OffloadedStatus
+	if d := cmp.Diff(want, pr); d != "" {
+		t.Errorf("rehydrated PipelineRun %s", diff.PrintWantGot(d))
+	}
+
+	// A tampered object is not rehydrated.
+	mu.Lock()
+	objects["/tekton/foo/pr-0123/status-0.json.gz"] = []byte("tampered")
+	mu.Unlock()
+	err := statusoffload.NewOffloader(fakekubeclient.NewSimpleClientset(), server.Client()).Rehydrate(ctx, pr)
+	if err == nil || !strings.Contains(err.Error(), "digest") {
+		t.Errorf("Rehydrate() = %v, want a digest mismatch", err)
+	}
+}
+
+func TestOffloadDisabled(t *testing.T) {
+	kubeclient := fakekubeclient.NewSimpleClientset()
+	offloader := statusoffload.NewOffloader(kubeclient, http.DefaultClient)
+	pr := pipelineRun()
+	if err := offloader.Offload(withStatusOffload(config.StatusOffloadConfigMap), pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+
+	// Once "status-offload" is unset, the status is rehydrated and left whole.
+	ctx := context.Background()
+	if err := offloader.Rehydrate(ctx, pr); err != nil {
+		t.Fatalf("Rehydrate() = %v", err)
+	}
+	if err := offloader.Offload(ctx, pr); err != nil {
+		t.Fatalf("Offload() = %v", err)
+	}
+	if d := cmp.Diff(pipelineRun(), pr); d != "" {
+		t.Errorf("PipelineRun %s", diff.PrintWantGot(d))
+	}
+}
@@ -27,6 +27,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/internal/cardinality"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
@@ -49,6 +51,7 @@ var (
 	taskTag        = tag.MustNewKey("task")
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
+	reasonTag      = tag.MustNewKey("reason")
 	podTag         = tag.MustNewKey("pod")
 
 	trDurationView      *view.View
@@ -58,6 +61,13 @@ var (
 	podLatencyView      *view.View
 	cloudEventsView     *view.View
 
+	podSchedulingLatencyView *view.View
+	podStartupLatencyView    *view.View
+	timeToFirstPodView       *view.View
+
+	runningTRsWaitingOnTaskResolutionCountView *view.View
+	runningTRsWaitingOnPodCountView            *view.View
+
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
 		"The taskrun's execution time in seconds",
@@ -83,8 +93,36 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	podSchedulingLatency = stats.Float64("taskrun_pod_scheduling_latency_seconds",
+		"The time in seconds between the creation of the taskrun's pod and its scheduling on a node",
+		stats.UnitDimensionless)
+
+	podStartupLatency = stats.Float64("taskrun_pod_startup_latency_seconds",
+		"The time in seconds between the creation of the taskrun's pod and the start of its first step",
+		stats.UnitDimensionless)
+
+	timeToFirstPod = stats.Float64("taskrun_time_to_first_pod_seconds",
+		"The time in seconds between the creation of the taskrun and the creation of its first pod",
+		stats.UnitDimensionless)
+
+	runningTRsWaitingOnTaskResolutionCount = stats.Float64("running_taskruns_waiting_on_task_resolution_count",
+		"Number of taskruns executing currently that are waiting on resolution requests for their task references.",
+		stats.UnitDimensionless)
+
+	runningTRsWaitingOnPodCount = stats.Float64("running_taskruns_waiting_on_pod_count",
+		"Number of taskruns executing currently whose pod can't be created, or isn't running yet",
+		stats.UnitDimensionless)
 )
 
+// podWaitingReasons are the reasons of the TaskRuns whose pod can't be created
+// because of a quota, can't be scheduled on a node, or is pending.
+var podWaitingReasons = []string{
+	podconvert.ReasonExceededResourceQuota,
+	podconvert.ReasonExceededNodeResources,
+	podconvert.ReasonPending,
+}
+
 // Recorder is used to actually record TaskRun metrics
 type Recorder struct {
 	mutex       sync.Mutex
@@ -92,11 +130,19 @@ type Recorder struct {
 
 	ReportingPeriod time.Duration
 
-	insertTaskTag func(task,
+	insertTaskTag func(limiter *cardinality.Limiter, task,
 		taskrun string) []tag.Mutator
 
-	insertPipelineTag func(pipeline,
+	insertPipelineTag func(limiter *cardinality.Limiter, pipeline,
 		pipelinerun string) []tag.Mutator
+
+	// namespaceLabel and reasonLabel are true if the views have the namespace and
+	// the reason tags
+	namespaceLabel bool
+	reasonLabel    bool
+
+	// limiter bounds the number of distinct values of the tags
+	limiter *cardinality.Limiter
 }
 
 // We cannot register the view multiple times, so NewRecorder lazily
@@ -165,11 +211,32 @@ func viewRegister(cfg *config.Metrics) error {
 		return errors.New("invalid config for TaskrunLevel: " + cfg.TaskrunLevel)
 	}
 
+	if cfg.DisablePipelineLabel {
+		prunTag = []tag.Key{}
+		r.insertPipelineTag = nilInsertTag
+	}
+	if cfg.DisableTaskLabel {
+		trunTag = []tag.Key{}
+		r.insertTaskTag = nilInsertTag
+	}
+	var nsTag, reasonTags []tag.Key
+	r.namespaceLabel = !cfg.DisableNamespaceLabel
+	if r.namespaceLabel {
+		nsTag = []tag.Key{namespaceTag}
+	}
+	r.reasonLabel = cfg.ReasonLabel
+	if r.reasonLabel {
+		reasonTags = []tag.Key{reasonTag}
+	}
+	r.limiter = cardinality.NewLimiter(cfg.LabelCardinalityLimit)
+
 	distribution := view.Distribution(10, 30, 60, 300, 900, 1800, 3600, 5400, 10800, 21600, 43200, 86400)
+	latencyDistribution := view.Distribution(0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1800)
 
-	if cfg.TaskrunLevel == config.TaskrunLevelAtTaskrun ||
-		cfg.PipelinerunLevel == config.PipelinerunLevelAtPipelinerun {
+	if (cfg.TaskrunLevel == config.TaskrunLevelAtTaskrun && !cfg.DisableTaskLabel) ||
+		(cfg.PipelinerunLevel == config.PipelinerunLevelAtPipelinerun && !cfg.DisablePipelineLabel) {
 		distribution = view.LastValue()
+		latencyDistribution = view.LastValue()
 	} else {
 		switch cfg.DurationTaskrunType {
 		case config.DurationTaskrunTypeHistogram:
@@ -184,19 +251,19 @@ func viewRegister(cfg *config.Metrics) error {
 		Description: trDuration.Description(),
 		Measure:     trDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, trunTag...),
+		TagKeys:     tagKeys([]tag.Key{statusTag}, nsTag, reasonTags, trunTag),
 	}
 	prTRDurationView = &view.View{
 		Description: prTRDuration.Description(),
 		Measure:     prTRDuration,
 		Aggregation: distribution,
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
+		TagKeys:     tagKeys([]tag.Key{statusTag}, nsTag, reasonTags, trunTag, prunTag),
 	}
 	trCountView = &view.View{
 		Description: trCount.Description(),
 		Measure:     trCount,
 		Aggregation: view.Count(),
-		TagKeys:     []tag.Key{statusTag},
+		TagKeys:     tagKeys([]tag.Key{statusTag}, reasonTags),
 	}
 	runningTRsCountView = &view.View{
 		Description: runningTRsCount.Description(),
@@ -207,13 +274,42 @@ func viewRegister(cfg *config.Metrics) error {
 		Description: podLatency.Description(),
 		Measure:     podLatency,
 		Aggregation: view.LastValue(),
-		TagKeys:     append([]tag.Key{namespaceTag, podTag}, trunTag...),
+		TagKeys:     tagKeys(nsTag, []tag.Key{podTag}, trunTag),
 	}
 	cloudEventsView = &view.View{
 		Description: cloudEvents.Description(),
 		Measure:     cloudEvents,
 		Aggregation: view.Sum(),
-		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
+		TagKeys:     tagKeys([]tag.Key{statusTag}, nsTag, trunTag, prunTag),
+	}
+	podSchedulingLatencyView = &view.View{
+		Description: podSchedulingLatency.Description(),
+		Measure:     podSchedulingLatency,
+		Aggregation: latencyDistribution,
+		TagKeys:     tagKeys(nsTag, trunTag, prunTag),
+	}
+	podStartupLatencyView = &view.View{
+		Description: podStartupLatency.Description(),
+		Measure:     podStartupLatency,
+		Aggregation: latencyDistribution,
+		TagKeys:     tagKeys(nsTag, trunTag, prunTag),
+	}
+	timeToFirstPodView = &view.View{
+		Description: timeToFirstPod.Description(),
+		Measure:     timeToFirstPod,
+		Aggregation: latencyDistribution,
+		TagKeys:     tagKeys(nsTag, trunTag, prunTag),
+	}
+	runningTRsWaitingOnTaskResolutionCountView = &view.View{
+		Description: runningTRsWaitingOnTaskResolutionCount.Description(),
+		Measure:     runningTRsWaitingOnTaskResolutionCount,
+		Aggregation: view.LastValue(),
+	}
+	runningTRsWaitingOnPodCountView = &view.View{
+		Description: runningTRsWaitingOnPodCount.Description(),
+		Measure:     runningTRsWaitingOnPodCount,
+		Aggregation: view.LastValue(),
+		TagKeys:     []tag.Key{reasonTag},
 	}
 	return view.Register(
 		trDurationView,
@@ -222,9 +318,23 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		podSchedulingLatencyView,
+		podStartupLatencyView,
+		timeToFirstPodView,
+		runningTRsWaitingOnTaskResolutionCountView,
+		runningTRsWaitingOnPodCountView,
 	)
 }
 
+// tagKeys returns the tag keys of the groups, in a new slice.
+func tagKeys(groups ...[]tag.Key) []tag.Key {
+	keys := []tag.Key{}
+	for _, g := range groups {
+		keys = append(keys, g...)
+	}
+	return keys
+}
+
 func viewUnregister() {
 	view.Unregister(
 		trDurationView,
@@ -233,6 +343,11 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		podSchedulingLatencyView,
+		podStartupLatencyView,
+		timeToFirstPodView,
+		runningTRsWaitingOnTaskResolutionCountView,
+		runningTRsWaitingOnPodCountView,
 	)
 }
 
@@ -256,28 +371,42 @@ func MetricsOnStore(logger *zap.SugaredLogger) func(name string,
 	}
 }
 
-func pipelinerunInsertTag(pipeline, pipelinerun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(pipelineTag, pipeline),
-		tag.Insert(pipelinerunTag, pipelinerun)}
+func pipelinerunInsertTag(limiter *cardinality.Limiter, pipeline, pipelinerun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(pipelineTag, pipeline),
+		limiter.Insert(pipelinerunTag, pipelinerun)}
 }
 
-func pipelineInsertTag(pipeline, pipelinerun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(pipelineTag, pipeline)}
+func pipelineInsertTag(limiter *cardinality.Limiter, pipeline, pipelinerun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(pipelineTag, pipeline)}
 }
 
-func taskrunInsertTag(task, taskrun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(taskTag, task),
-		tag.Insert(taskrunTag, taskrun)}
+func taskrunInsertTag(limiter *cardinality.Limiter, task, taskrun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(taskTag, task),
+		limiter.Insert(taskrunTag, taskrun)}
 }
 
-func taskInsertTag(task, taskrun string) []tag.Mutator {
-	return []tag.Mutator{tag.Insert(taskTag, task)}
+func taskInsertTag(limiter *cardinality.Limiter, task, taskrun string) []tag.Mutator {
+	return []tag.Mutator{limiter.Insert(taskTag, task)}
 }
 
-func nilInsertTag(task, taskrun string) []tag.Mutator {
+func nilInsertTag(limiter *cardinality.Limiter, task, taskrun string) []tag.Mutator {
 	return []tag.Mutator{}
 }
 
+func (r *Recorder) insertNamespaceTag(namespace string) []tag.Mutator {
+	if !r.namespaceLabel {
+		return []tag.Mutator{}
+	}
+	return []tag.Mutator{r.limiter.Insert(namespaceTag, namespace)}
+}
+
+func (r *Recorder) insertReasonTag(condition *apis.Condition) []tag.Mutator {
+	if !r.reasonLabel || condition == nil {
+		return []tag.Mutator{}
+	}
+	return []tag.Mutator{r.limiter.Insert(reasonTag, condition.Reason)}
+}
+
 // DurationAndCount logs the duration of TaskRun execution and
 // count for number of TaskRuns succeed or failed
 // returns an error if its failed to log the metrics
@@ -310,12 +439,13 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 	}
 
 	durationStat := trDuration
-	tags := []tag.Mutator{tag.Insert(namespaceTag, tr.Namespace), tag.Insert(statusTag, status)}
+	tags := append([]tag.Mutator{tag.Insert(statusTag, status)}, r.insertNamespaceTag(tr.Namespace)...)
+	tags = append(tags, r.insertReasonTag(afterCondition)...)
 	if ok, pipeline, pipelinerun := IsPartOfPipeline(tr); ok {
 		durationStat = prTRDuration
-		tags = append(tags, r.insertPipelineTag(pipeline, pipelinerun)...)
+		tags = append(tags, r.insertPipelineTag(r.limiter, pipeline, pipelinerun)...)
 	}
-	tags = append(tags, r.insertTaskTag(taskName, tr.Name)...)
+	tags = append(tags, r.insertTaskTag(r.limiter, taskName, tr.Name)...)
 
 	ctx, err := tag.New(ctx, tags...)
 	if err != nil {
@@ -328,7 +458,8 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 	return nil
 }
 
-// RunningTaskRuns logs the number of TaskRuns running right now
+// RunningTaskRuns logs the number of TaskRuns running right now, and the number of
+// them waiting on the resolution of their Task or on their pod
 // returns an error if its failed to log the metrics
 func (r *Recorder) RunningTaskRuns(ctx context.Context, lister listers.TaskRunLister) error {
 	r.mutex.Lock()
@@ -343,10 +474,19 @@ func (r *Recorder) RunningTaskRuns(ctx context.Context, lister listers.TaskRunLi
 		return err
 	}
 
-	var runningTrs int
-	for _, pr := range trs {
-		if !pr.IsDone() {
-			runningTrs++
+	var runningTrs, waitingOnTaskResolution int
+	waitingOnPod := map[string]int{}
+	for _, tr := range trs {
+		if tr.IsDone() {
+			continue
+		}
+		runningTrs++
+		if cond := tr.Status.GetCondition(apis.ConditionSucceeded); cond != nil && cond.Status == corev1.ConditionUnknown {
+			if cond.Reason == v1beta1.TaskRunReasonResolvingTaskRef {
+				waitingOnTaskResolution++
+			} else {
+				waitingOnPod[cond.Reason]++
+			}
 		}
 	}
 
@@ -355,6 +495,15 @@ func (r *Recorder) RunningTaskRuns(ctx context.Context, lister listers.TaskRunLi
 		return err
 	}
 	metrics.Record(ctx, runningTRsCount.M(float64(runningTrs)))
+	metrics.Record(ctx, runningTRsWaitingOnTaskResolutionCount.M(float64(waitingOnTaskResolution)))
+	// Record every reason, so that the gauges drop to 0 once the TaskRuns stop waiting
+	for _, reason := range podWaitingReasons {
+		reasonCtx, err := tag.New(ctx, tag.Insert(reasonTag, reason))
+		if err != nil {
+			return err
+		}
+		metrics.Record(reasonCtx, runningTRsWaitingOnPodCount.M(float64(waitingOnPod[reason])))
+	}
 
 	return nil
 }
@@ -405,9 +554,9 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 
 	ctx, err := tag.New(
 		ctx,
-		append([]tag.Mutator{tag.Insert(namespaceTag, tr.Namespace),
-			tag.Insert(podTag, pod.Name)},
-			r.insertTaskTag(taskName, tr.Name)...)...)
+		append(append(r.insertNamespaceTag(tr.Namespace),
+			r.limiter.Insert(podTag, pod.Name)),
+			r.insertTaskTag(r.limiter, taskName, tr.Name)...)...)
 	if err != nil {
 		return err
 	}
@@ -417,6 +566,84 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 	return nil
 }
 
+// RecordPodStartupLatency logs the time it took to schedule the pod of the TaskRun and
+// to start its first step, once the first step started, i.e. when no step had started
+// yet in beforeSteps, the steps of the TaskRun before the reconcile cycle.
+// returns an error if its failed to log the metrics
+func (r *Recorder) RecordPodStartupLatency(ctx context.Context, pod *corev1.Pod, tr *v1beta1.TaskRun, beforeSteps []v1beta1.StepState) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording for pod , failed to initialize the metrics recorder")
+	}
+
+	started := firstStepStartTime(tr.Status.Steps)
+	if !firstStepStartTime(beforeSteps).IsZero() || started.IsZero() {
+		return nil
+	}
+
+	taskName := anonymous
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	tags := r.insertNamespaceTag(tr.Namespace)
+	if ok, pipeline, pipelinerun := IsPartOfPipeline(tr); ok {
+		tags = append(tags, r.insertPipelineTag(r.limiter, pipeline, pipelinerun)...)
+	}
+	tags = append(tags, r.insertTaskTag(r.limiter, taskName, tr.Name)...)
+
+	ctx, err := tag.New(ctx, tags...)
+	if err != nil {
+		return err
+	}
+
+	if scheduledTime := getScheduledTime(pod); !scheduledTime.IsZero() {
+		metrics.Record(ctx, podSchedulingLatency.M(scheduledTime.Sub(pod.CreationTimestamp.Time).Seconds()))
+	}
+	metrics.Record(ctx, podStartupLatency.M(started.Sub(pod.CreationTimestamp.Time).Seconds()))
+
+	return nil
+}
+
+// RecordTimeToFirstPod logs the time between the creation of the TaskRun and the
+// creation of its pod, when the pod is the first one of the TaskRun, i.e. it isn't
+// the pod of a retry.
+// returns an error if its failed to log the metrics
+func (r *Recorder) RecordTimeToFirstPod(ctx context.Context, pod *corev1.Pod, tr *v1beta1.TaskRun) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return errors.New("ignoring the metrics recording for pod , failed to initialize the metrics recorder")
+	}
+
+	if len(tr.Status.RetriesStatus) > 0 || pod.CreationTimestamp.IsZero() {
+		return nil
+	}
+
+	taskName := anonymous
+	if tr.Spec.TaskRef != nil {
+		taskName = tr.Spec.TaskRef.Name
+	}
+
+	tags := r.insertNamespaceTag(tr.Namespace)
+	if ok, pipeline, pipelinerun := IsPartOfPipeline(tr); ok {
+		tags = append(tags, r.insertPipelineTag(r.limiter, pipeline, pipelinerun)...)
+	}
+	tags = append(tags, r.insertTaskTag(r.limiter, taskName, tr.Name)...)
+
+	ctx, err := tag.New(ctx, tags...)
+	if err != nil {
+		return err
+	}
+
+	metrics.Record(ctx, timeToFirstPod.M(pod.CreationTimestamp.Sub(tr.CreationTimestamp.Time).Seconds()))
+
+	return nil
+}
+
 // CloudEvents logs the number of cloud events sent for TaskRun
 // returns an error if it fails to log the metrics
 func (r *Recorder) CloudEvents(ctx context.Context, tr *v1beta1.TaskRun) error {
@@ -437,11 +664,11 @@ func (r *Recorder) CloudEvents(ctx context.Context, tr *v1beta1.TaskRun) error {
 		status = "failed"
 	}
 
-	tags := []tag.Mutator{tag.Insert(namespaceTag, tr.Namespace), tag.Insert(statusTag, status)}
+	tags := append([]tag.Mutator{tag.Insert(statusTag, status)}, r.insertNamespaceTag(tr.Namespace)...)
 	if ok, pipeline, pipelinerun := IsPartOfPipeline(tr); ok {
-		tags = append(tags, r.insertPipelineTag(pipeline, pipelinerun)...)
+		tags = append(tags, r.insertPipelineTag(r.limiter, pipeline, pipelinerun)...)
 	}
-	tags = append(tags, r.insertTaskTag(taskName, tr.Name)...)
+	tags = append(tags, r.insertTaskTag(r.limiter, taskName, tr.Name)...)
 
 	ctx, err := tag.New(ctx, tags...)
 	if err != nil {
@@ -476,6 +703,25 @@ func sentCloudEvents(tr *v1beta1.TaskRun) int64 {
 	return sent
 }
 
+// firstStepStartTime returns the time the first of the steps started, or the zero
+// time if none of them started.
+func firstStepStartTime(steps []v1beta1.StepState) time.Time {
+	var first time.Time
+	for _, s := range steps {
+		var started metav1.Time
+		switch {
+		case s.Running != nil:
+			started = s.Running.StartedAt
+		case s.Terminated != nil:
+			started = s.Terminated.StartedAt
+		}
+		if !started.IsZero() && (first.IsZero() || started.Time.Before(first)) {
+			first = started.Time
+		}
+	}
+	return first
+}
+
 func getScheduledTime(pod *corev1.Pod) metav1.Time {
 	for _, c := range pod.Status.Conditions {
 		if c.Type == corev1.PodScheduled {
@@ -28,7 +28,9 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	faketaskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun/fake"
 	"github.com/tektoncd/pipeline/pkg/names"
+	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"go.opencensus.io/stats/view"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -75,6 +77,9 @@ func TestUninitializedMetrics(t *testing.T) {
 	if err := metrics.RecordPodLatency(ctx, nil, nil); err == nil {
 		t.Error("Pod Latency recording expected to return error but got nil")
 	}
+	if err := metrics.RecordPodStartupLatency(ctx, nil, nil, nil); err == nil {
+		t.Error("Pod Startup Latency recording expected to return error but got nil")
+	}
 	if err := metrics.CloudEvents(ctx, &v1beta1.TaskRun{}); err == nil {
 		t.Error("Cloud Events recording expected to return error but got nil")
 	}
@@ -379,7 +384,7 @@ func TestRecordTaskRunDurationCount(t *testing.T) {
 
 func TestRecordRunningTaskRunsCount(t *testing.T) {
 	unregisterMetrics()
-	newTaskRun := func(status corev1.ConditionStatus) *v1beta1.TaskRun {
+	newTaskRun := func(status corev1.ConditionStatus, reason string) *v1beta1.TaskRun {
 		return &v1beta1.TaskRun{
 			ObjectMeta: metav1.ObjectMeta{Name: names.SimpleNameGenerator.RestrictLengthWithRandomSuffix("taskrun-")},
 			Status: v1beta1.TaskRunStatus{
@@ -387,6 +392,7 @@ func TestRecordRunningTaskRunsCount(t *testing.T) {
 					Conditions: duckv1.Conditions{{
 						Type:   apis.ConditionSucceeded,
 						Status: status,
+						Reason: reason,
 					}},
 				},
 			},
@@ -397,9 +403,13 @@ func TestRecordRunningTaskRunsCount(t *testing.T) {
 	informer := faketaskruninformer.Get(ctx)
 	// Add N randomly-named TaskRuns with differently-succeeded statuses.
 	for _, tr := range []*v1beta1.TaskRun{
-		newTaskRun(corev1.ConditionTrue),
-		newTaskRun(corev1.ConditionUnknown),
-		newTaskRun(corev1.ConditionFalse),
+		newTaskRun(corev1.ConditionTrue, ""),
+		newTaskRun(corev1.ConditionUnknown, v1beta1.TaskRunReasonRunning.String()),
+		newTaskRun(corev1.ConditionUnknown, v1beta1.TaskRunReasonResolvingTaskRef),
+		newTaskRun(corev1.ConditionUnknown, podconvert.ReasonExceededNodeResources),
+		newTaskRun(corev1.ConditionUnknown, podconvert.ReasonPending),
+		newTaskRun(corev1.ConditionUnknown, podconvert.ReasonPending),
+		newTaskRun(corev1.ConditionFalse, ""),
 	} {
 		if err := informer.Informer().GetIndexer().Add(tr); err != nil {
 			t.Fatalf("Adding TaskRun to informer: %v", err)
@@ -415,7 +425,11 @@ func TestRecordRunningTaskRunsCount(t *testing.T) {
 	if err := metrics.RunningTaskRuns(ctx, informer.Lister()); err != nil {
 		t.Errorf("RunningTaskRuns: %v", err)
 	}
-	metricstest.CheckLastValueData(t, "running_taskruns_count", map[string]string{}, 1)
+	metricstest.CheckLastValueData(t, "running_taskruns_count", map[string]string{}, 5)
+	metricstest.CheckLastValueData(t, "running_taskruns_waiting_on_task_resolution_count", map[string]string{}, 1)
+	checkLastValueRow(t, "running_taskruns_waiting_on_pod_count", map[string]string{"reason": podconvert.ReasonExceededResourceQuota}, 0)
+	checkLastValueRow(t, "running_taskruns_waiting_on_pod_count", map[string]string{"reason": podconvert.ReasonExceededNodeResources}, 1)
+	checkLastValueRow(t, "running_taskruns_waiting_on_pod_count", map[string]string{"reason": podconvert.ReasonPending}, 2)
 }
 
 func TestRecordPodLatency(t *testing.T) {
@@ -488,6 +502,243 @@ func TestRecordPodLatency(t *testing.T) {
 	}
 }
 
+func TestRecordPodStartupLatency(t *testing.T) {
+	creationTime := metav1.Now()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-taskrun-pod-123456",
+			Namespace:         "foo",
+			CreationTimestamp: creationTime,
+		},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodScheduled,
+				LastTransitionTime: metav1.Time{Time: creationTime.Add(4 * time.Second)},
+			}},
+		},
+	}
+	step := func(started time.Duration) v1beta1.StepState {
+		return v1beta1.StepState{Name: "build", ContainerState: corev1.ContainerState{
+			Running: &corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: creationTime.Add(started)}},
+		}}
+	}
+	taskRun := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo", Labels: map[string]string{
+			pipeline.PipelineLabelKey:    "pipeline",
+			pipeline.PipelineRunLabelKey: "pipelinerun",
+		}},
+		Spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+		},
+		Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+			Steps: []v1beta1.StepState{{Name: "wait"}, step(12 * time.Second), step(10 * time.Second)},
+		}},
+	}
+	expectedTags := map[string]string{
+		"namespace": "foo",
+		"pipeline":  "pipeline",
+		"task":      "task-1",
+	}
+
+	for _, tc := range []struct {
+		name           string
+		beforeSteps    []v1beta1.StepState
+		expectRecorded bool
+	}{{
+		name:           "first step started",
+		beforeSteps:    []v1beta1.StepState{{Name: "build"}},
+		expectRecorded: true,
+	}, {
+		name:        "step started before",
+		beforeSteps: []v1beta1.StepState{step(10 * time.Second)},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			ctx := config.ToContext(context.Background(), &config.Config{Metrics: &config.Metrics{
+				TaskrunLevel:        config.TaskrunLevelAtTask,
+				PipelinerunLevel:    config.PipelinerunLevelAtPipeline,
+				DurationTaskrunType: config.DurationTaskrunTypeHistogram,
+			}})
+			metrics, err := NewRecorder(ctx)
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			if err := metrics.RecordPodStartupLatency(ctx, pod, taskRun, tc.beforeSteps); err != nil {
+				t.Fatalf("RecordPodStartupLatency: %v", err)
+			}
+			if !tc.expectRecorded {
+				metricstest.CheckStatsNotReported(t, "taskrun_pod_scheduling_latency_seconds", "taskrun_pod_startup_latency_seconds")
+				return
+			}
+			metricstest.CheckDistributionData(t, "taskrun_pod_scheduling_latency_seconds", expectedTags, 1, 4, 4)
+			metricstest.CheckDistributionData(t, "taskrun_pod_startup_latency_seconds", expectedTags, 1, 10, 10)
+		})
+	}
+}
+
+func TestRecordTimeToFirstPod(t *testing.T) {
+	creationTime := metav1.Now()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-taskrun-pod-123456",
+			Namespace:         "foo",
+			CreationTimestamp: metav1.Time{Time: creationTime.Add(3 * time.Second)},
+		},
+	}
+	taskRun := func(retries int) *v1beta1.TaskRun {
+		tr := &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-taskrun", Namespace: "foo", CreationTimestamp: creationTime},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+			},
+		}
+		for i := 0; i < retries; i++ {
+			tr.Status.RetriesStatus = append(tr.Status.RetriesStatus, v1beta1.TaskRunStatus{})
+		}
+		return tr
+	}
+
+	for _, tc := range []struct {
+		name           string
+		taskRun        *v1beta1.TaskRun
+		expectRecorded bool
+	}{{
+		name:           "first pod",
+		taskRun:        taskRun(0),
+		expectRecorded: true,
+	}, {
+		name:    "pod of a retry",
+		taskRun: taskRun(1),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			ctx := config.ToContext(context.Background(), &config.Config{Metrics: &config.Metrics{
+				TaskrunLevel:        config.TaskrunLevelAtTask,
+				PipelinerunLevel:    config.PipelinerunLevelAtPipeline,
+				DurationTaskrunType: config.DurationTaskrunTypeHistogram,
+			}})
+			metrics, err := NewRecorder(ctx)
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			if err := metrics.RecordTimeToFirstPod(ctx, pod, tc.taskRun); err != nil {
+				t.Fatalf("RecordTimeToFirstPod: %v", err)
+			}
+			if !tc.expectRecorded {
+				metricstest.CheckStatsNotReported(t, "taskrun_time_to_first_pod_seconds")
+				return
+			}
+			metricstest.CheckDistributionData(t, "taskrun_time_to_first_pod_seconds", map[string]string{"namespace": "foo", "task": "task-1"}, 1, 3, 3)
+		})
+	}
+}
+
+func TestRecordTaskRunDurationCountLabels(t *testing.T) {
+	taskRun := func(name, task string) *v1beta1.TaskRun {
+		return &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: task},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionFalse,
+						Reason: "TaskRunTimeout",
+					}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+				},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name                 string
+		cfg                  *config.Metrics
+		expectedDurationTags map[string]string
+		expectedCountTags    map[string]string
+	}{{
+		name: "reason label without namespace label",
+		cfg: &config.Metrics{
+			TaskrunLevel:          config.TaskrunLevelAtTaskrun,
+			PipelinerunLevel:      config.PipelinerunLevelAtPipeline,
+			DisableNamespaceLabel: true,
+			ReasonLabel:           true,
+		},
+		expectedDurationTags: map[string]string{"task": "task-2", "taskrun": "taskrun-2", "status": "failed", "reason": "TaskRunTimeout"},
+		expectedCountTags:    map[string]string{"status": "failed", "reason": "TaskRunTimeout"},
+	}, {
+		name: "task label disabled",
+		cfg: &config.Metrics{
+			TaskrunLevel:        config.TaskrunLevelAtTask,
+			PipelinerunLevel:    config.PipelinerunLevelAtPipeline,
+			DurationTaskrunType: config.DurationTaskrunTypeLastValue,
+			DisableTaskLabel:    true,
+		},
+		expectedDurationTags: map[string]string{"namespace": "ns", "status": "failed"},
+		expectedCountTags:    map[string]string{"status": "failed"},
+	}, {
+		name: "task values beyond the cardinality limit",
+		cfg: &config.Metrics{
+			TaskrunLevel:          config.TaskrunLevelAtTask,
+			PipelinerunLevel:      config.PipelinerunLevelAtPipeline,
+			DurationTaskrunType:   config.DurationTaskrunTypeLastValue,
+			LabelCardinalityLimit: 1,
+		},
+		expectedDurationTags: map[string]string{"task": "other", "namespace": "ns", "status": "failed"},
+		expectedCountTags:    map[string]string{"status": "failed"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unregisterMetrics()
+
+			ctx := config.ToContext(context.Background(), &config.Config{Metrics: tc.cfg})
+			metrics, err := NewRecorder(ctx)
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			for _, tr := range []*v1beta1.TaskRun{taskRun("taskrun-1", "task-1"), taskRun("taskrun-2", "task-2")} {
+				if err := metrics.DurationAndCount(ctx, tr, nil); err != nil {
+					t.Errorf("DurationAndCount: %v", err)
+				}
+			}
+			checkLastValueRow(t, "taskrun_duration_seconds", tc.expectedDurationTags, 60)
+			metricstest.CheckCountData(t, "taskrun_count", tc.expectedCountTags, 2)
+		})
+	}
+}
+
+// checkLastValueRow checks the value of the row of a LastValue view with the tags, unlike
+// metricstest.CheckLastValueData which checks its last row, in no particular order.
+func checkLastValueRow(t *testing.T, name string, wantTags map[string]string, wantValue float64) {
+	t.Helper()
+	rows, err := view.RetrieveData(name)
+	if err != nil {
+		t.Fatalf("RetrieveData(%s): %v", name, err)
+	}
+	for _, row := range rows {
+		tags := map[string]string{}
+		for _, tg := range row.Tags {
+			tags[tg.Key.Name()] = tg.Value
+		}
+		if !reflect.DeepEqual(wantTags, tags) {
+			continue
+		}
+		if got := row.Data.(*view.LastValueData).Value; got != wantValue {
+			t.Errorf("expected the value of %s with tags %v to be %v, got %v", name, wantTags, wantValue, got)
+		}
+		return
+	}
+	t.Errorf("expected a row of %s with tags %v, got %v", name, wantTags, rows)
+}
+
 func TestTaskRunIsOfPipelinerun(t *testing.T) {
 	tests := []struct {
 		name                  string
@@ -685,7 +936,9 @@ func TestRecordCloudEvents(t *testing.T) {
 }
 
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count",
+		"taskrun_pod_scheduling_latency_seconds", "taskrun_pod_startup_latency_seconds", "taskrun_time_to_first_pod_seconds",
+		"running_taskruns_waiting_on_task_resolution_count", "running_taskruns_waiting_on_pod_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
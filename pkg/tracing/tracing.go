@@ -0,0 +1,255 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records the spans of the PipelineRuns, the TaskRuns and their steps,
+// so that a trace follows a PipelineRun through its DAG: the PipelineRun is the root
+// span, each TaskRun a child span and each step a grandchild span.
+//
+// The controller can't keep a span open while a run executes, across many reconciles
+// and restarts. The span context of a run is therefore allocated when the run starts,
+// stored in its status and propagated to its children, and the span itself is only
+// recorded once the run completes, with the same IDs and the timestamps of the run.
+// This requires the TracerProvider to use the IDGenerator of this package.
+package tracing
+
+import (
+	"context"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TracerName is the name of the tracer recording the spans of the runs
+	TracerName = "tekton.dev/runs"
+	// TraceParentEnvVar is the env var holding the W3C traceparent of the span of a
+	// step in its container, so that the code of the step can attach spans to it.
+	TraceParentEnvVar = "TRACEPARENT"
+	// TraceStateEnvVar is the env var holding the W3C tracestate of the span of a step
+	TraceStateEnvVar = "TRACESTATE"
+)
+
+var propagator = propagation.TraceContext{}
+
+type spanContextKey struct{}
+
+// idGenerator generates random IDs, unless the span is started with a context
+// holding the span context to record, see withRecordedSpanContext.
+type idGenerator struct{}
+
+var _ tracesdk.IDGenerator = idGenerator{}
+
+// NewIDGenerator returns the IDGenerator to configure the TracerProvider of the
+// controller with, which lets the spans of the runs be recorded with the IDs
+// allocated when they started.
+func NewIDGenerator() tracesdk.IDGenerator {
+	return idGenerator{}
+}
+
+// NewIDs implements tracesdk.IDGenerator
+func (idGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok {
+		return sc.TraceID(), sc.SpanID()
+	}
+	return newTraceID(), newSpanID()
+}
+
+// NewSpanID implements tracesdk.IDGenerator
+func (idGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	if sc, ok := ctx.Value(spanContextKey{}).(trace.SpanContext); ok && sc.TraceID() == traceID {
+		return sc.SpanID()
+	}
+	return newSpanID()
+}
+
+func newTraceID() (id trace.TraceID) {
+	for !id.IsValid() {
+		_, _ = crand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() (id trace.SpanID) {
+	for !id.IsValid() {
+		_, _ = crand.Read(id[:])
+	}
+	return id
+}
+
+func withRecordedSpanContext(ctx context.Context, sc trace.SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// Enabled returns true if the TracerProvider records spans, i.e. it isn't a no-op one.
+func Enabled(tp trace.TracerProvider) bool {
+	_, ok := tp.(*tracesdk.TracerProvider)
+	return ok
+}
+
+// NewRunContext returns a context holding the span context allocated to a run which
+// starts, child of the span context in ctx if any. The span itself is recorded by
+// RecordRunSpan once the run completes.
+func NewRunContext(ctx context.Context) context.Context {
+	parent := trace.SpanContextFromContext(ctx)
+	traceID, flags := parent.TraceID(), parent.TraceFlags()
+	if !parent.IsValid() {
+		traceID, flags = newTraceID(), trace.FlagsSampled
+	}
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     newSpanID(),
+		TraceFlags: flags,
+		TraceState: parent.TraceState(),
+	}))
+}
+
+// Span describes the span of a run, or of a step, which completed.
+type Span struct {
+	// Name is the name of the span, e.g. "PipelineRun:build"
+	Name string
+	// SpanContext is the carrier of the span context allocated to the run
+	SpanContext map[string]string
+	// Parent is the carrier of the span context of the parent of the run, if any
+	Parent map[string]string
+	// Start and End are the times the run started and completed
+	Start, End time.Time
+	// Error is the message of the failure of the run, if it failed
+	Error string
+	// Attributes are the attributes of the span
+	Attributes []attribute.KeyValue
+}
+
+// RecordRunSpan records the span of a run which completed, with the IDs allocated
+// when it started. It does nothing if no span context was allocated to the run.
+func RecordRunSpan(tp trace.TracerProvider, s Span) {
+	sc := spanContextFromCarrier(s.SpanContext)
+	if !sc.IsValid() {
+		return
+	}
+	recordSpan(tp, sc, spanContextFromCarrier(s.Parent), s)
+}
+
+// StepSpanContext returns the span context of the step running in the container of a
+// TaskRun with the span context carrier. It is derived from the span context of the
+// TaskRun, so that it is the same when the pod is created and when the step completes.
+func StepSpanContext(taskRunSpanContext map[string]string, container string) trace.SpanContext {
+	parent := spanContextFromCarrier(taskRunSpanContext)
+	if !parent.IsValid() {
+		return trace.SpanContext{}
+	}
+	parentID := parent.SpanID()
+	sum := sha256.Sum256(append(parentID[:], container...))
+	var id trace.SpanID
+	copy(id[:], sum[:])
+	if !id.IsValid() {
+		id = parentID
+	}
+	return parent.WithSpanID(id)
+}
+
+// StepEnv returns the env vars propagating the span context of the step running in
+// the container to it, or nil if no span context was allocated to the TaskRun.
+func StepEnv(taskRunSpanContext map[string]string, container string) []corev1.EnvVar {
+	return env(StepSpanContext(taskRunSpanContext, container))
+}
+
+// PropagatedEnv returns the env vars propagating the span context of the carrier as is,
+// or nil if it isn't a valid one. It is used when the controller records no span, so
+// that the steps attach their spans to the span propagated to the run instead.
+func PropagatedEnv(carrier map[string]string) []corev1.EnvVar {
+	return env(spanContextFromCarrier(carrier))
+}
+
+func env(sc trace.SpanContext) []corev1.EnvVar {
+	if !sc.IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(trace.ContextWithSpanContext(context.Background(), sc), carrier)
+	env := []corev1.EnvVar{{Name: TraceParentEnvVar, Value: carrier.Get("traceparent")}}
+	if state := carrier.Get("tracestate"); state != "" {
+		env = append(env, corev1.EnvVar{Name: TraceStateEnvVar, Value: state})
+	}
+	return env
+}
+
+// RecordStepSpan records the span of a step which completed, child of the span of
+// its TaskRun whose span context carrier is s.Parent.
+func RecordStepSpan(tp trace.TracerProvider, container string, s Span) {
+	sc := StepSpanContext(s.Parent, container)
+	if !sc.IsValid() {
+		return
+	}
+	recordSpan(tp, sc, spanContextFromCarrier(s.Parent), s)
+}
+
+func recordSpan(tp trace.TracerProvider, sc, parent trace.SpanContext, s Span) {
+	ctx := withRecordedSpanContext(context.Background(), sc)
+	opts := []trace.SpanStartOption{trace.WithTimestamp(s.Start), trace.WithAttributes(s.Attributes...)}
+	if parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+	} else {
+		opts = append(opts, trace.WithNewRoot())
+	}
+	_, span := tp.Tracer(TracerName).Start(ctx, s.Name, opts...)
+	if s.Error != "" {
+		span.SetStatus(codes.Error, s.Error)
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.End(trace.WithTimestamp(s.End))
+}
+
+// CarrierFromAnnotation returns the span context carrier marshalled in the value of
+// an annotation, or nil if it isn't a valid one.
+func CarrierFromAnnotation(value string) map[string]string {
+	if value == "" {
+		return nil
+	}
+	carrier := map[string]string{}
+	if err := json.Unmarshal([]byte(value), &carrier); err != nil {
+		return nil
+	}
+	return carrier
+}
+
+// CarrierFromTraceParent returns the span context carrier of a W3C traceparent, and of
+// its tracestate if any, or nil if the traceparent isn't a valid one.
+func CarrierFromTraceParent(traceParent, traceState string) map[string]string {
+	carrier := map[string]string{"traceparent": traceParent}
+	if traceState != "" {
+		carrier["tracestate"] = traceState
+	}
+	if !spanContextFromCarrier(carrier).IsValid() {
+		return nil
+	}
+	return carrier
+}
+
+func spanContextFromCarrier(carrier map[string]string) trace.SpanContext {
+	if len(carrier) == 0 {
+		return trace.SpanContext{}
+	}
+	return trace.SpanContextFromContext(propagator.Extract(context.Background(), propagation.MapCarrier(carrier)))
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	parentTraceParent = "00-0f57e147e992b304d977436289d10628-73d5909e31793992-01"
+	runTraceParent    = "00-0f57e147e992b304d977436289d10628-1a2b3c4d5e6f7081-01"
+)
+
+type exporter struct {
+	spans []tracesdk.ReadOnlySpan
+}
+
+func (e *exporter) ExportSpans(_ context.Context, spans []tracesdk.ReadOnlySpan) error {
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *exporter) Shutdown(context.Context) error { return nil }
+
+func newTracerProvider() (*tracesdk.TracerProvider, *exporter) {
+	e := &exporter{}
+	return tracesdk.NewTracerProvider(tracesdk.WithSyncer(e), tracesdk.WithIDGenerator(tracing.NewIDGenerator())), e
+}
+
+func spanContext(traceParent string) trace.SpanContext {
+	ctx := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceParent})
+	return trace.SpanContextFromContext(ctx)
+}
+
+func TestEnabled(t *testing.T) {
+	if tracing.Enabled(trace.NewNoopTracerProvider()) {
+		t.Errorf("expected tracing to be disabled with a no-op TracerProvider")
+	}
+	if !tracing.Enabled(tracesdk.NewTracerProvider()) {
+		t.Errorf("expected tracing to be enabled with the TracerProvider of the SDK")
+	}
+}
+
+func TestNewRunContext(t *testing.T) {
+	parent := spanContext(parentTraceParent)
+	got := trace.SpanContextFromContext(tracing.NewRunContext(trace.ContextWithRemoteSpanContext(context.Background(), parent)))
+	if !got.IsValid() || got.TraceID() != parent.TraceID() || got.SpanID() == parent.SpanID() || !got.IsSampled() {
+		t.Errorf("expected a new sampled span in the trace of the parent, got %v", got)
+	}
+
+	root := trace.SpanContextFromContext(tracing.NewRunContext(context.Background()))
+	if !root.IsValid() || root.TraceID() == parent.TraceID() || !root.IsSampled() {
+		t.Errorf("expected a new sampled span in a new trace, got %v", root)
+	}
+}
+
+func TestRecordRunSpan(t *testing.T) {
+	tp, e := newTracerProvider()
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	tracing.RecordRunSpan(tp, tracing.Span{
+		Name:        "TaskRun:build",
+		SpanContext: map[string]string{"traceparent": runTraceParent},
+		Parent:      map[string]string{"traceparent": parentTraceParent},
+		Start:       start,
+		End:         end,
+		Error:       "build failed",
+	})
+	tracing.RecordRunSpan(tp, tracing.Span{Name: "TaskRun:no-span-context", Start: start, End: end})
+
+	if len(e.spans) != 1 {
+		t.Fatalf("expected 1 span to be recorded, got %d", len(e.spans))
+	}
+	s := e.spans[0]
+	if s.Name() != "TaskRun:build" || !s.StartTime().Equal(start) || !s.EndTime().Equal(end) {
+		t.Errorf("unexpected span %q from %v to %v", s.Name(), s.StartTime(), s.EndTime())
+	}
+	if want := spanContext(runTraceParent); s.SpanContext().TraceID() != want.TraceID() || s.SpanContext().SpanID() != want.SpanID() {
+		t.Errorf("expected the span to be recorded with the span context of the run, got %v", s.SpanContext())
+	}
+	if s.Parent().SpanID() != spanContext(parentTraceParent).SpanID() {
+		t.Errorf("expected the span to be a child of the parent span, got %v", s.Parent())
+	}
+	if s.Status().Code != codes.Error || s.Status().Description != "build failed" {
+		t.Errorf("expected the span to be failed, got %v", s.Status())
+	}
+}
+
+func TestStepSpans(t *testing.T) {
+	carrier := map[string]string{"traceparent": runTraceParent}
+	step := tracing.StepSpanContext(carrier, "step-compile")
+	if !step.IsValid() || step.TraceID() != spanContext(runTraceParent).TraceID() {
+		t.Fatalf("expected a span in the trace of the TaskRun, got %v", step)
+	}
+	if other := tracing.StepSpanContext(carrier, "step-test"); other.SpanID() == step.SpanID() {
+		t.Errorf("expected the steps to have distinct spans")
+	}
+	if again := tracing.StepSpanContext(carrier, "step-compile"); again.SpanID() != step.SpanID() {
+		t.Errorf("expected the span of a step to be stable")
+	}
+
+	want := []corev1.EnvVar{{
+		Name:  "TRACEPARENT",
+		Value: "00-" + step.TraceID().String() + "-" + step.SpanID().String() + "-01",
+	}}
+	if d := cmp.Diff(want, tracing.StepEnv(carrier, "step-compile")); d != "" {
+		t.Errorf("StepEnv() %s", diff.PrintWantGot(d))
+	}
+	if env := tracing.StepEnv(nil, "step-compile"); env != nil {
+		t.Errorf("expected no env without span context, got %v", env)
+	}
+
+	tp, e := newTracerProvider()
+	start := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	tracing.RecordStepSpan(tp, "step-compile", tracing.Span{Name: "Step:compile", Parent: carrier, Start: start, End: start.Add(time.Second)})
+	if len(e.spans) != 1 {
+		t.Fatalf("expected 1 span to be recorded, got %d", len(e.spans))
+	}
+	if got := e.spans[0]; got.SpanContext().SpanID() != step.SpanID() || got.Parent().SpanID() != spanContext(runTraceParent).SpanID() {
+		t.Errorf("expected the span of the step to be a child of the one of the TaskRun, got %v with parent %v", got.SpanContext(), got.Parent())
+	}
+}
+
+func TestNewTransformer(t *testing.T) {
+	carrier := map[string]string{"traceparent": runTraceParent}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name: "step-compile",
+		Env:  []corev1.EnvVar{{Name: "GOFLAGS", Value: "-mod=vendor"}},
+	}, {
+		Name: "sidecar-db",
+	}}}}
+	for _, tc := range []struct {
+		name string
+		tp   trace.TracerProvider
+		want []corev1.EnvVar
+	}{{
+		name: "span of the step",
+		tp:   tracesdk.NewTracerProvider(),
+		want: tracing.StepEnv(carrier, "step-compile"),
+	}, {
+		name: "propagated span without tracing",
+		tp:   trace.NewNoopTracerProvider(),
+		want: []corev1.EnvVar{{Name: tracing.TraceParentEnvVar, Value: runTraceParent}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tracing.NewTransformer(tc.tp, carrier)(pod.DeepCopy())
+			if err != nil {
+				t.Fatalf("NewTransformer() = %v", err)
+			}
+			want := append(tc.want, corev1.EnvVar{Name: "GOFLAGS", Value: "-mod=vendor"})
+			if d := cmp.Diff(want, got.Spec.Containers[0].Env); d != "" {
+				t.Errorf("step env %s", diff.PrintWantGot(d))
+			}
+			if len(got.Spec.Containers[1].Env) != 0 {
+				t.Errorf("expected no env in the sidecar, got %v", got.Spec.Containers[1].Env)
+			}
+		})
+	}
+}
+
+func TestCarrierFromTraceParent(t *testing.T) {
+	for _, tc := range []struct {
+		traceParent, traceState string
+		want                    map[string]string
+	}{
+		{parentTraceParent, "", map[string]string{"traceparent": parentTraceParent}},
+		{parentTraceParent, "vendor=value", map[string]string{"traceparent": parentTraceParent, "tracestate": "vendor=value"}},
+		{"00-00000000000000000000000000000000-73d5909e31793992-01", "", nil},
+		{"not-a-traceparent", "", nil},
+	} {
+		if d := cmp.Diff(tc.want, tracing.CarrierFromTraceParent(tc.traceParent, tc.traceState)); d != "" {
+			t.Errorf("CarrierFromTraceParent(%q, %q) %s", tc.traceParent, tc.traceState, diff.PrintWantGot(d))
+		}
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"github.com/tektoncd/pipeline/pkg/pod"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// NewTransformer returns a pod.Transformer propagating the span context of each step
+// of the TaskRun with the span context carrier to its container, so that the code of
+// the step can attach spans to the trace of the TaskRun. When the TracerProvider
+// records no span, the span context propagated to the TaskRun is passed as is instead.
+// The env vars come first, so that they can be overridden by the env of the step.
+func NewTransformer(tp trace.TracerProvider, taskRunSpanContext map[string]string) pod.Transformer {
+	return func(p *corev1.Pod) (*corev1.Pod, error) {
+		for i, c := range p.Spec.Containers {
+			if !pod.IsContainerStep(c.Name) {
+				continue
+			}
+			env := PropagatedEnv(taskRunSpanContext)
+			if Enabled(tp) {
+				env = StepEnv(taskRunSpanContext, c.Name)
+			}
+			if len(env) > 0 {
+				p.Spec.Containers[i].Env = append(env, c.Env...)
+			}
+		}
+		return p, nil
+	}
+}
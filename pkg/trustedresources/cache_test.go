@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	test "github.com/tektoncd/pipeline/test"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVerifyResource_Cache(t *testing.T) {
+	signer, _, k8sclient, vps := test.SetupVerificationPolicies(t)
+	signedTask, err := test.GetSignedTask(test.GetUnsignedTask("test-task"), signer, "signed")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+	ctx := test.SetupTrustedResourceConfig(context.Background(), config.FailNoMatchPolicy)
+	source := &v1beta1.RefSource{URI: "gcr.io/tekton-releases/catalog/upstream/git-clone"}
+	// keyInSecretVp reads its public key from a secret
+	policies := []*v1alpha1.VerificationPolicy{vps[1].DeepCopy()}
+
+	if vr := VerifyResource(ctx, signedTask, k8sclient, source, policies); vr.VerificationResultType != VerificationPass {
+		t.Fatalf("expected verification to pass but got %v", vr)
+	}
+
+	// the public key secret isn't read again when verifying the same content against the same policies
+	if vr := VerifyResource(ctx, signedTask, fakek8s.NewSimpleClientset(), source, policies); vr.VerificationResultType != VerificationPass {
+		t.Errorf("expected cached verification to pass but got %v", vr)
+	}
+
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Description = "tampered"
+	if vr := VerifyResource(ctx, tamperedTask, k8sclient, source, policies); vr.VerificationResultType != VerificationError {
+		t.Errorf("expected verification of tampered content to fail but got %v", vr)
+	}
+
+	// a new policy generation invalidates the cached verification
+	policies[0].Generation++
+	if vr := VerifyResource(ctx, signedTask, fakek8s.NewSimpleClientset(), source, policies); vr.VerificationResultType != VerificationError {
+		t.Errorf("expected verification against a new policy generation to fail without the public key but got %v", vr)
+	}
+}
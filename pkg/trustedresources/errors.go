@@ -15,11 +15,19 @@ limitations under the License.
 */
 package trustedresources
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrResourceVerificationFailed is returned when trusted resources fails verification.
 	ErrResourceVerificationFailed = errors.New("resource verification failed")
+	// ErrSignatureMissing is returned when a resource matching verification policies isn't signed.
+	// It wraps ErrResourceVerificationFailed so that it is handled as any other verification failure.
+	ErrSignatureMissing = fmt.Errorf("%w: signature missing", ErrResourceVerificationFailed)
+	// ErrImageVerificationFailed is returned when a step or sidecar image fails verification.
+	ErrImageVerificationFailed = errors.New("image verification failed")
 	// ErrNoMatchedPolicies is returned when no policies are matched
 	ErrNoMatchedPolicies = errors.New("no policies are matched")
 	// ErrRegexMatch is returned when regex match returns error
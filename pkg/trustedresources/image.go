@@ -0,0 +1,261 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/trustedresources/verifier"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// CosignSignatureAnnotation is the annotation of the layers of a cosign signature image
+	// holding the base64-encoded signature of the layer payload.
+	CosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// CosignCertificateAnnotation is the annotation of the layers of a cosign signature image
+	// holding the PEM-encoded signing certificate of a keyless signature.
+	CosignCertificateAnnotation = "dev.sigstore.cosign/certificate"
+	// CosignBundleAnnotation is the annotation of the layers of a cosign signature image
+	// holding the transparency log entry of a keyless signature.
+	CosignBundleAnnotation = "dev.sigstore.cosign/bundle"
+
+	// cosignSignatureTagSuffix suffixes the tags of the cosign signature images, which are
+	// named after the digest of the signed image.
+	cosignSignatureTagSuffix = ".sig"
+	// maxSignaturePayloadSize bounds the size of the signature payloads read from the registry.
+	maxSignaturePayloadSize = 1 << 20
+)
+
+// imageSignature is a cosign signature of an image.
+type imageSignature struct {
+	// payload is the simple signing payload which is signed, holding the digest of the image.
+	payload   []byte
+	signature []byte
+	keyless   *verifier.KeylessSignature
+}
+
+// simpleSigningPayload is the part of the cosign simple signing payload binding the
+// signature to the image.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifyImage verifies the cosign signatures of the image, referenced by its digest, against
+// the VerificationPolicies of kind "images" whose resources patterns match the repository of the image,
+// e.g. "gcr.io/tekton-releases/git-init". The signatures are read from the signature image
+// cosign pushes next to the image with the tag "sha256-<hex>.sig", using the remote options.
+// The policies are evaluated as for VerifyResource: the image must pass all the matched
+// "enforce" policies, and passes a policy if any of its signatures is valid for any of the
+// authorities of the policy.
+// VerificationResult is returned with the same types as VerifyResource.
+func VerifyImage(ctx context.Context, image name.Digest, k8s kubernetes.Interface, verificationpolicies []*v1alpha1.VerificationPolicy, opts ...remote.Option) VerificationResult {
+	logger := logging.FromContext(ctx)
+	matchedPolicies, err := getMatchedPolicies(image.String(), image.Context().Name(), v1alpha1.PolicyKindImages, verificationpolicies)
+	if err != nil {
+		return noMatchedPoliciesResult(ctx, err)
+	}
+
+	// Only passed verifications are cached, the signatures of the image may be pushed after
+	// a failure.
+	cacheKey, err := imageVerificationCacheKey(image, matchedPolicies)
+	if err != nil {
+		logger.Warnf("Failed to compute the verification cache key of image %s: %v", image, err)
+	} else if _, ok := verificationCache.Get(cacheKey); ok {
+		return VerificationResult{VerificationResultType: VerificationPass}
+	}
+
+	var warnPolicies []*v1alpha1.VerificationPolicy
+	var enforcePolicies []*v1alpha1.VerificationPolicy
+	for _, p := range matchedPolicies {
+		if p.Spec.Mode == v1alpha1.ModeWarn {
+			warnPolicies = append(warnPolicies, p)
+		} else {
+			enforcePolicies = append(enforcePolicies, p)
+		}
+	}
+
+	signatures, err := imageSignatures(image, opts...)
+	if err != nil {
+		return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: failed to get the signatures of image %s: %v", ErrImageVerificationFailed, image, err)} //nolint:errorlint
+	}
+	if len(signatures) == 0 {
+		err := fmt.Errorf("%w: image %s has no cosign signature but matches policies %s", ErrImageVerificationFailed, image, policyNames(matchedPolicies))
+		if len(enforcePolicies) > 0 {
+			return VerificationResult{VerificationResultType: VerificationError, Err: err}
+		}
+		logger.Warnf(err.Error())
+		return VerificationResult{VerificationResultType: VerificationWarn, Err: err}
+	}
+
+	for _, p := range enforcePolicies {
+		passVerification, reason, err := verifyImagePolicy(ctx, k8s, signatures, p)
+		if err != nil {
+			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy: %w", err)}
+		}
+		if !passVerification {
+			return VerificationResult{VerificationResultType: VerificationError, Err: imageVerificationFailure(image, p, reason)}
+		}
+	}
+
+	for _, p := range warnPolicies {
+		passVerification, reason, err := verifyImagePolicy(ctx, k8s, signatures, p)
+		if err != nil {
+			warn := fmt.Errorf("fails to get verifiers for image %s: %w", image, err)
+			logger.Warnf(warn.Error())
+			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
+		}
+		if !passVerification {
+			warn := imageVerificationFailure(image, p, reason)
+			logger.Warnf(warn.Error())
+			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
+		}
+	}
+
+	if cacheKey != "" {
+		verificationCache.Add(cacheKey, struct{}{}, verificationCacheTTL)
+	}
+	return VerificationResult{VerificationResultType: VerificationPass}
+}
+
+// verifyImagePolicy returns whether any of the signatures is valid for any public key of
+// the policy, or matches any keyless authority of the policy. The reason why the keyless
+// signatures don't match is returned along with failures. An error is returned if the
+// verifiers can't be loaded from the policy.
+func verifyImagePolicy(ctx context.Context, k8s kubernetes.Interface, signatures []imageSignature, p *v1alpha1.VerificationPolicy) (bool, error, error) {
+	verifiers, err := verifier.FromPolicy(ctx, k8s, p)
+	if err != nil {
+		return false, nil, err
+	}
+	for _, s := range signatures {
+		for _, v := range verifiers {
+			if err := v.VerifySignature(bytes.NewReader(s.signature), bytes.NewReader(s.payload)); err == nil {
+				return true, nil, nil
+			}
+		}
+	}
+
+	keylessVerifiers, err := verifier.KeylessFromPolicy(ctx, k8s, p)
+	if err != nil {
+		return false, nil, err
+	}
+	var reason error
+	for _, s := range signatures {
+		if s.keyless == nil {
+			continue
+		}
+		for _, v := range keylessVerifiers {
+			if reason = v.Verify(s.payload, s.signature, s.keyless); reason == nil {
+				return true, nil, nil
+			}
+		}
+	}
+	return false, reason, nil
+}
+
+// imageVerificationFailure returns the error of the image failing the policy for the given reason.
+func imageVerificationFailure(image name.Digest, p *v1alpha1.VerificationPolicy, reason error) error {
+	if reason != nil {
+		return fmt.Errorf("%w: image %s fails verification against policy %s: %v", ErrImageVerificationFailed, image, p.Name, reason) //nolint:errorlint
+	}
+	return fmt.Errorf("%w: image %s fails verification against policy %s", ErrImageVerificationFailed, image, p.Name)
+}
+
+// imageSignatures returns the cosign signatures of the image, read from the layers of its
+// signature image. The signatures whose payload doesn't hold the digest of the image are
+// ignored, they sign another image. No signatures are returned if the image isn't signed.
+func imageSignatures(image name.Digest, opts ...remote.Option) ([]imageSignature, error) {
+	h, err := v1.NewHash(image.DigestStr())
+	if err != nil {
+		return nil, err
+	}
+	sigImage, err := remote.Image(image.Context().Tag(fmt.Sprintf("%s-%s%s", h.Algorithm, h.Hex, cosignSignatureTagSuffix)), opts...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	manifest, err := sigImage.Manifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var signatures []imageSignature
+	for _, desc := range manifest.Layers {
+		encoded, ok := desc.Annotations[CosignSignatureAnnotation]
+		if !ok {
+			continue
+		}
+		sig, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := layerPayload(sigImage, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		var p simpleSigningPayload
+		if err := json.Unmarshal(payload, &p); err != nil || p.Critical.Image.DockerManifestDigest != image.DigestStr() {
+			continue
+		}
+		s := imageSignature{payload: payload, signature: sig}
+		if cert, ok := desc.Annotations[CosignCertificateAnnotation]; ok {
+			s.keyless = &verifier.KeylessSignature{Certificate: []byte(cert)}
+			if bundle, ok := desc.Annotations[CosignBundleAnnotation]; ok {
+				s.keyless.Bundle = &verifier.RekorBundle{}
+				if err := json.Unmarshal([]byte(bundle), s.keyless.Bundle); err != nil {
+					s.keyless.Bundle = nil
+				}
+			}
+		}
+		signatures = append(signatures, s)
+	}
+	return signatures, nil
+}
+
+// layerPayload reads the payload of the layer of the signature image.
+func layerPayload(img v1.Image, digest v1.Hash) ([]byte, error) {
+	layer, err := img.LayerByDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxSignaturePayloadSize))
+}
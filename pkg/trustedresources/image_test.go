@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trustedresources
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/elliptic"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	test "github.com/tektoncd/pipeline/test"
+	"go.uber.org/zap/zaptest"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/logging"
+)
+
+// payloadLayer is the layer of a cosign signature image holding a signature payload.
+type payloadLayer []byte
+
+func (l payloadLayer) Digest() (v1.Hash, error) {
+	h, _, err := v1.SHA256(bytes.NewReader(l))
+	return h, err
+}
+
+func (l payloadLayer) DiffID() (v1.Hash, error) { return l.Digest() }
+
+func (l payloadLayer) Compressed() (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(l)), nil
+}
+
+func (l payloadLayer) Uncompressed() (io.ReadCloser, error) { return l.Compressed() }
+
+func (l payloadLayer) Size() (int64, error) { return int64(len(l)), nil }
+
+func (l payloadLayer) MediaType() (types.MediaType, error) {
+	return "application/vnd.dev.cosign.simplesigning.v1+json", nil
+}
+
+// simpleSigningPayloadOf returns the cosign simple signing payload of the image.
+func simpleSigningPayloadOf(image name.Digest) payloadLayer {
+	return payloadLayer(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":%q},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, image.Context().Name(), image.DigestStr()))
+}
+
+func pushRandomImage(t *testing.T, repository string) name.Digest {
+	t.Helper()
+	img, err := random.Image(128, 1)
+	if err != nil {
+		t.Fatalf("random.Image: %v", err)
+	}
+	ref, err := name.ParseReference(repository + ":latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatalf("remote.Write: %v", err)
+	}
+	h, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ref.Context().Digest(h.String())
+}
+
+// pushSignature pushes the cosign signature image of the image with the signatures of the
+// payloads by the signers.
+func pushSignature(t *testing.T, image name.Digest, payload payloadLayer, signers ...signature.Signer) {
+	t.Helper()
+	sigImage := empty.Image
+	for _, s := range signers {
+		sig, err := s.SignMessage(bytes.NewReader(payload))
+		if err != nil {
+			t.Fatalf("SignMessage: %v", err)
+		}
+		sigImage, err = mutate.Append(sigImage, mutate.Addendum{
+			Layer:       payload,
+			Annotations: map[string]string{CosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)},
+		})
+		if err != nil {
+			t.Fatalf("mutate.Append: %v", err)
+		}
+	}
+	h, err := v1.NewHash(image.DigestStr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(image.Context().Tag(fmt.Sprintf("%s-%s.sig", h.Algorithm, h.Hex)), sigImage); err != nil {
+		t.Fatalf("remote.Write: %v", err)
+	}
+}
+
+func TestVerifyImage(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, config.IgnoreNoMatchPolicy)
+
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, _, pub, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys %v", err)
+	}
+	otherSigner, _, _, err := test.GenerateKeys(elliptic.P256(), crypto.SHA256)
+	if err != nil {
+		t.Fatalf("failed to generate keys %v", err)
+	}
+	authorities := []v1alpha1.Authority{{Name: "key", Key: &v1alpha1.KeyRef{Data: string(pub), HashAlgorithm: "sha256"}}}
+	policies := []*v1alpha1.VerificationPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Name: "enforce", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: "/enforced/"}},
+			Authorities: authorities,
+			Kind:        v1alpha1.PolicyKindImages,
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "warn", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: "/warned/"}},
+			Authorities: authorities,
+			Mode:        v1alpha1.ModeWarn,
+			Kind:        v1alpha1.PolicyKindImages,
+		},
+	}, {
+		ObjectMeta: metav1.ObjectMeta{Name: "resources", Namespace: namespace},
+		Spec: v1alpha1.VerificationPolicySpec{
+			Resources:   []v1alpha1.ResourcePattern{{Pattern: ".*"}},
+			Authorities: []v1alpha1.Authority{{Name: "other-key", Key: &v1alpha1.KeyRef{Data: "not a key"}}},
+		},
+	}}
+	k8sclient := fakek8s.NewSimpleClientset()
+
+	tcs := []struct {
+		name         string
+		repository   string
+		sign         func(image name.Digest)
+		expectedType VerificationResultType
+	}{{
+		name:       "signed image passes",
+		repository: "enforced/signed",
+		sign: func(image name.Digest) {
+			pushSignature(t, image, simpleSigningPayloadOf(image), otherSigner, signer)
+		},
+		expectedType: VerificationPass,
+	}, {
+		name:       "image signed by another key fails",
+		repository: "enforced/other-key",
+		sign: func(image name.Digest) {
+			pushSignature(t, image, simpleSigningPayloadOf(image), otherSigner)
+		},
+		expectedType: VerificationError,
+	}, {
+		name:       "signature of another image fails",
+		repository: "enforced/other-image",
+		sign: func(image name.Digest) {
+			other := pushRandomImage(t, image.Context().Name())
+			pushSignature(t, image, simpleSigningPayloadOf(other), signer)
+		},
+		expectedType: VerificationError,
+	}, {
+		name:         "unsigned image fails enforce policies",
+		repository:   "enforced/unsigned",
+		sign:         func(name.Digest) {},
+		expectedType: VerificationError,
+	}, {
+		name:         "unsigned image warns with only warn policies",
+		repository:   "warned/unsigned",
+		sign:         func(name.Digest) {},
+		expectedType: VerificationWarn,
+	}, {
+		name:         "image matching only policies of resources is skipped",
+		repository:   "unmatched",
+		sign:         func(name.Digest) {},
+		expectedType: VerificationSkip,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			image := pushRandomImage(t, u.Host+"/"+tc.repository)
+			tc.sign(image)
+			vr := VerifyImage(ctx, image, k8sclient, policies)
+			if vr.VerificationResultType != tc.expectedType {
+				t.Errorf("VerificationResultType mismatch: want %v, got %v (%v)", tc.expectedType, vr.VerificationResultType, vr.Err)
+			}
+			if (tc.expectedType == VerificationError || tc.expectedType == VerificationWarn) && !errors.Is(vr.Err, ErrImageVerificationFailed) {
+				t.Errorf("VerifyImage got: %v, want: %v", vr.Err, ErrImageVerificationFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,495 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package azure implements the Azure Key Vault KMS provider of the signatures of
+// trusted resources. Unlike the provider of sigstore, the references of its keys can
+// pin a version of the key, i.e. azurekms://[VAULT_NAME][VAULT_URI]/[KEY]/[KEY_VERSION],
+// so that signing and verification keep using the same key when it is rotated.
+package azure
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/sigstore/sigstore/pkg/signature"
+	sigkms "github.com/sigstore/sigstore/pkg/signature/kms"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+)
+
+// ReferenceScheme is the scheme of the references of Azure Key Vault keys.
+const ReferenceScheme = "azurekms://"
+
+// DefaultPublicKeyTTL is how long the public key of a key which isn't pinned to a
+// version is cached by default, after which the public key of its current version is
+// fetched again. It is overridden by AZURE_KMS_PUBLIC_KEY_TTL and WithPublicKeyTTL.
+const DefaultPublicKeyTTL = 5 * time.Minute
+
+var (
+	// ErrInvalidReference is returned when the reference of a key isn't in the
+	// format azurekms://[VAULT_NAME][VAULT_URI]/[KEY] or azurekms://[VAULT_NAME][VAULT_URI]/[KEY]/[KEY_VERSION].
+	ErrInvalidReference = errors.New("kms specification should be in the format azurekms://[VAULT_NAME][VAULT_URI]/[KEY] or azurekms://[VAULT_NAME][VAULT_URI]/[KEY]/[KEY_VERSION]")
+
+	referenceRegex = regexp.MustCompile(`^azurekms://([^/]+)/([^/]+)(?:/([^/]+))?$`)
+
+	supportedHashFuncs = []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512}
+)
+
+func init() {
+	sigkms.AddProvider(ReferenceScheme, func(ctx context.Context, keyResourceID string, hashFunc crypto.Hash, opts ...signature.RPCOption) (sigkms.SignerVerifier, error) {
+		return LoadSignerVerifier(ctx, keyResourceID, hashFunc, opts...)
+	})
+}
+
+// publicKeyTTLOption is the RPCOption returned by WithPublicKeyTTL.
+type publicKeyTTLOption struct {
+	options.NoOpOptionImpl
+	ttl time.Duration
+}
+
+// WithPublicKeyTTL returns an RPCOption setting how long the public key of a key which
+// isn't pinned to a version is cached, e.g. passed to kms.Get.
+func WithPublicKeyTTL(ttl time.Duration) signature.RPCOption {
+	return publicKeyTTLOption{ttl: ttl}
+}
+
+// publicKeyTTL returns the TTL set by the last WithPublicKeyTTL option, or else by
+// AZURE_KMS_PUBLIC_KEY_TTL, or else DefaultPublicKeyTTL.
+func publicKeyTTL(opts []signature.RPCOption) (time.Duration, error) {
+	ttl := DefaultPublicKeyTTL
+	if v := os.Getenv(publicKeyTTLEnv); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < 0 {
+			return 0, fmt.Errorf("invalid %s %q, must be a non-negative duration", publicKeyTTLEnv, v)
+		}
+		ttl = d
+	}
+	for _, opt := range opts {
+		if o, ok := opt.(publicKeyTTLOption); ok {
+			ttl = o.ttl
+		}
+	}
+	return ttl, nil
+}
+
+const (
+	// authMethodEnv selects the credential of the Key Vault client, one of
+	// "environment", "workloadidentity", "managedidentity" or "cli". All of them are
+	// tried in this order when it isn't set.
+	authMethodEnv = "AZURE_AUTH_METHOD"
+	// federatedTokenFileEnv is the path of the service account token exchanged for an
+	// Azure AD token, set along with AZURE_CLIENT_ID and AZURE_TENANT_ID by the AKS
+	// workload identity webhook.
+	federatedTokenFileEnv = "AZURE_FEDERATED_TOKEN_FILE"
+	// clientIDEnv is the client ID of the service principal of the environment or of
+	// the workload identity, and of the user-assigned managed identity.
+	clientIDEnv = "AZURE_CLIENT_ID"
+	tenantIDEnv = "AZURE_TENANT_ID"
+	// managedIdentityResourceIDEnv selects a user-assigned managed identity by its
+	// resource ID rather than by its client ID.
+	managedIdentityResourceIDEnv = "AZURE_MANAGED_IDENTITY_RESOURCE_ID"
+	// publicKeyTTLEnv overrides DefaultPublicKeyTTL, e.g. "1m".
+	publicKeyTTLEnv = "AZURE_KMS_PUBLIC_KEY_TTL"
+)
+
+// kvClient is the subset of the Key Vault client used by the SignerVerifier.
+type kvClient interface {
+	GetKey(ctx context.Context, name, version string, options *azkeys.GetKeyOptions) (azkeys.GetKeyResponse, error)
+	Sign(ctx context.Context, name, version string, parameters azkeys.SignParameters, options *azkeys.SignOptions) (azkeys.SignResponse, error)
+}
+
+// Reference is a parsed reference of an Azure Key Vault key.
+type Reference struct {
+	// VaultURL is the URL of the vault, e.g. "https://tekton.vault.azure.net/".
+	VaultURL string
+	// KeyName is the name of the key in the vault.
+	KeyName string
+	// KeyVersion is the version of the key, empty for its current version.
+	KeyVersion string
+}
+
+// ParseReference parses the reference of an Azure Key Vault key, in the format
+// azurekms://[VAULT_NAME][VAULT_URI]/[KEY] or azurekms://[VAULT_NAME][VAULT_URI]/[KEY]/[KEY_VERSION].
+func ParseReference(ref string) (Reference, error) {
+	v := referenceRegex.FindStringSubmatch(ref)
+	if v == nil {
+		return Reference{}, fmt.Errorf("%w: %q", ErrInvalidReference, ref)
+	}
+	return Reference{
+		VaultURL:   fmt.Sprintf("https://%s/", v[1]),
+		KeyName:    v[2],
+		KeyVersion: v[3],
+	}, nil
+}
+
+// SignerVerifier signs messages with a key of Azure Key Vault, and verifies their
+// signatures with its public key.
+type SignerVerifier struct {
+	ref      Reference
+	hashFunc crypto.Hash
+	client   kvClient
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	publicKey crypto.PublicKey
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+// LoadSignerVerifier returns the SignerVerifier of the key referenced by ref, using
+// the hash function to compute the digests of the messages. The client authenticates
+// to Azure without client secrets when it runs with an AKS workload identity or a
+// managed identity, see credential. The public key is cached as set by WithPublicKeyTTL.
+func LoadSignerVerifier(_ context.Context, ref string, hashFunc crypto.Hash, opts ...signature.RPCOption) (*SignerVerifier, error) {
+	r, err := ParseReference(ref)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := publicKeyTTL(opts)
+	if err != nil {
+		return nil, err
+	}
+	client, err := keysClient(r.VaultURL)
+	if err != nil {
+		return nil, fmt.Errorf("new azure kms client: %w", err)
+	}
+	sv, err := newSignerVerifier(r, hashFunc, client)
+	if err != nil {
+		return nil, err
+	}
+	sv.ttl = ttl
+	return sv, nil
+}
+
+func newSignerVerifier(ref Reference, hashFunc crypto.Hash, client kvClient) (*SignerVerifier, error) {
+	switch hashFunc {
+	case 0:
+		hashFunc = crypto.SHA256
+	case crypto.SHA256, crypto.SHA384, crypto.SHA512:
+	default:
+		return nil, fmt.Errorf("hash function %v not supported by Azure Key Vault", hashFunc)
+	}
+	return &SignerVerifier{
+		ref:      ref,
+		hashFunc: hashFunc,
+		client:   client,
+		ttl:      DefaultPublicKeyTTL,
+		now:      time.Now,
+	}, nil
+}
+
+// SignMessage signs the digest of the message with the key, returning an ASN.1
+// encoded ECDSA signature.
+func (a *SignerVerifier) SignMessage(message io.Reader, opts ...signature.SignOption) ([]byte, error) {
+	ctx := context.Background()
+	var signerOpts crypto.SignerOpts = a.hashFunc
+	for _, opt := range opts {
+		opt.ApplyContext(&ctx)
+		opt.ApplyCryptoSignerOpts(&signerOpts)
+	}
+	digest, hashFunc, err := signature.ComputeDigestForSigning(message, signerOpts.HashFunc(), supportedHashFuncs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := signatureAlgorithm(hashFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.client.Sign(ctx, a.ref.KeyName, a.ref.KeyVersion, azkeys.SignParameters{
+		Algorithm: &algorithm,
+		Value:     digest,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("signing the payload: %w", err)
+	}
+	raw := result.Result
+	if len(raw) == 0 {
+		return nil, errors.New("signing the payload: empty result")
+	}
+
+	// Key Vault returns the concatenated r||s, which is converted to an ASN.1 sequence.
+	l := len(raw)
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(raw[:l/2]),
+		S: new(big.Int).SetBytes(raw[l/2:]),
+	})
+}
+
+// VerifySignature verifies the signature of the message with the public key of the key.
+// The signature is verified locally, without calling Key Vault.
+func (a *SignerVerifier) VerifySignature(sig, message io.Reader, opts ...signature.VerifyOption) error {
+	ctx := context.Background()
+	for _, opt := range opts {
+		opt.ApplyContext(&ctx)
+	}
+	pub, err := a.public(ctx)
+	if err != nil {
+		return err
+	}
+	v, err := signature.LoadVerifier(pub, a.hashFunc)
+	if err != nil {
+		return err
+	}
+	return v.VerifySignature(sig, message, opts...)
+}
+
+// PublicKey returns the public key of the key. The public key of a key pinned to a
+// version is fetched once, the one of the current version of the key is refreshed
+// once its TTL expires, or once it is invalidated.
+func (a *SignerVerifier) PublicKey(opts ...signature.PublicKeyOption) (crypto.PublicKey, error) {
+	ctx := context.Background()
+	for _, opt := range opts {
+		opt.ApplyContext(&ctx)
+	}
+	return a.public(ctx)
+}
+
+// CreateKey returns the public key of the key. Keys are never created by Tekton, they
+// must be created in Key Vault beforehand.
+func (a *SignerVerifier) CreateKey(ctx context.Context, _ string) (crypto.PublicKey, error) {
+	return a.public(ctx)
+}
+
+// CryptoSigner returns a crypto.Signer signing with the key.
+func (a *SignerVerifier) CryptoSigner(ctx context.Context, errFunc func(error)) (crypto.Signer, crypto.SignerOpts, error) {
+	return &cryptoSigner{ctx: ctx, sv: a, errFunc: errFunc}, a.hashFunc, nil
+}
+
+// SupportedAlgorithms returns the signature algorithms supported by Azure Key Vault.
+func (*SignerVerifier) SupportedAlgorithms() []string {
+	return []string{string(azkeys.SignatureAlgorithmES256), string(azkeys.SignatureAlgorithmES384), string(azkeys.SignatureAlgorithmES512)}
+}
+
+// DefaultAlgorithm returns the default signature algorithm of Azure Key Vault.
+func (*SignerVerifier) DefaultAlgorithm() string {
+	return string(azkeys.SignatureAlgorithmES256)
+}
+
+// Invalidate drops the cached public key, so that the next verification fetches it
+// again, e.g. when the key is known to be rotated.
+func (a *SignerVerifier) Invalidate() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.publicKey = nil
+}
+
+func (a *SignerVerifier) public(ctx context.Context) (crypto.PublicKey, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.publicKey != nil && (a.ref.KeyVersion != "" || a.now().Sub(a.fetchedAt) < a.ttl) {
+		return a.publicKey, nil
+	}
+	resp, err := a.client.GetKey(ctx, a.ref.KeyName, a.ref.KeyVersion, nil)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	pub, err := publicKey(resp.Key)
+	if err != nil {
+		return nil, err
+	}
+	a.publicKey, a.fetchedAt = pub, a.now()
+	return pub, nil
+}
+
+// publicKey returns the ECDSA public key of the JSON web key.
+func publicKey(key *azkeys.JSONWebKey) (crypto.PublicKey, error) {
+	if key == nil {
+		return nil, errors.New("public key: the key bundle has no key")
+	}
+	// The type of the keys stored in managed HSMs is suffixed with "-HSM", which
+	// isn't a type of JSON web key.
+	if key.Kty == nil || strings.TrimSuffix(string(*key.Kty), "-HSM") != string(azkeys.KeyTypeEC) {
+		return nil, fmt.Errorf("public key was not ECDSA: %v", key.Kty)
+	}
+	var curve elliptic.Curve
+	switch {
+	case key.Crv == nil:
+		return nil, errors.New("public key: the key has no curve")
+	case *key.Crv == azkeys.CurveNameP256:
+		curve = elliptic.P256()
+	case *key.Crv == azkeys.CurveNameP384:
+		curve = elliptic.P384()
+	case *key.Crv == azkeys.CurveNameP521:
+		curve = elliptic.P521()
+	default:
+		return nil, fmt.Errorf("public key: unsupported curve %s", *key.Crv)
+	}
+	pub := &ecdsa.PublicKey{
+		Curve: curve,
+		X:     new(big.Int).SetBytes(key.X),
+		Y:     new(big.Int).SetBytes(key.Y),
+	}
+	if !curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("public key: the point is not on the curve")
+	}
+	return pub, nil
+}
+
+func signatureAlgorithm(hashFunc crypto.Hash) (azkeys.SignatureAlgorithm, error) {
+	switch hashFunc {
+	case crypto.SHA256:
+		return azkeys.SignatureAlgorithmES256, nil
+	case crypto.SHA384:
+		return azkeys.SignatureAlgorithmES384, nil
+	case crypto.SHA512:
+		return azkeys.SignatureAlgorithmES512, nil
+	default:
+		return "", fmt.Errorf("unsupported algorithm: %s", hashFunc)
+	}
+}
+
+// keysClient returns a client of the Key Vault at vaultURL, authenticated with credential.
+func keysClient(vaultURL string) (*azkeys.Client, error) {
+	cred, err := credential()
+	if err != nil {
+		return nil, err
+	}
+	return azkeys.NewClient(vaultURL, cred, &azkeys.ClientOptions{
+		ClientOptions: azcore.ClientOptions{
+			Telemetry: policy.TelemetryOptions{ApplicationID: "tekton-pipelines"},
+		},
+	})
+}
+
+// credential returns the credential selected by AZURE_AUTH_METHOD, or when it isn't
+// set, the chain of the credentials available to the controller, tried in order:
+//   - the service principal of the environment, i.e. AZURE_TENANT_ID, AZURE_CLIENT_ID
+//     and AZURE_CLIENT_SECRET or AZURE_CLIENT_CERTIFICATE_PATH;
+//   - the AKS workload identity, when AZURE_FEDERATED_TOKEN_FILE is set;
+//   - the managed identity of the node, the user-assigned one selected by
+//     AZURE_MANAGED_IDENTITY_RESOURCE_ID or AZURE_CLIENT_ID, the system-assigned one
+//     otherwise;
+//   - the Azure CLI.
+func credential() (azcore.TokenCredential, error) {
+	sources, err := credentials()
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 1 {
+		return sources[0], nil
+	}
+	return azidentity.NewChainedTokenCredential(sources, nil)
+}
+
+func credentials() ([]azcore.TokenCredential, error) {
+	switch method := strings.ToLower(os.Getenv(authMethodEnv)); method {
+	case "environment":
+		return single(azidentity.NewEnvironmentCredential(nil))
+	case "workloadidentity":
+		return single(workloadIdentityCredential())
+	case "managedidentity":
+		return single(managedIdentityCredential())
+	case "cli":
+		return single(azidentity.NewAzureCLICredential(nil))
+	case "":
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, must be one of environment, workloadidentity, managedidentity or cli", authMethodEnv, method)
+	}
+
+	var sources []azcore.TokenCredential
+	// The environment credential fails when the environment has no service principal,
+	// in which case it is skipped.
+	if c, err := azidentity.NewEnvironmentCredential(nil); err == nil {
+		sources = append(sources, c)
+	}
+	if os.Getenv(federatedTokenFileEnv) != "" {
+		c, err := workloadIdentityCredential()
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, c)
+	}
+	mi, err := managedIdentityCredential()
+	if err != nil {
+		return nil, err
+	}
+	cli, err := azidentity.NewAzureCLICredential(nil)
+	if err != nil {
+		return nil, err
+	}
+	return append(sources, mi, cli), nil
+}
+
+func workloadIdentityCredential() (*azidentity.WorkloadIdentityCredential, error) {
+	for _, env := range []string{clientIDEnv, tenantIDEnv, federatedTokenFileEnv} {
+		if os.Getenv(env) == "" {
+			return nil, fmt.Errorf("workload identity: %s is not set, is the pod labelled with azure.workload.identity/use?", env)
+		}
+	}
+	return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+		ClientID:      os.Getenv(clientIDEnv),
+		TenantID:      os.Getenv(tenantIDEnv),
+		TokenFilePath: os.Getenv(federatedTokenFileEnv),
+	})
+}
+
+func managedIdentityCredential() (*azidentity.ManagedIdentityCredential, error) {
+	var id azidentity.ManagedIDKind
+	if resourceID := os.Getenv(managedIdentityResourceIDEnv); resourceID != "" {
+		id = azidentity.ResourceID(resourceID)
+	} else if clientID := os.Getenv(clientIDEnv); clientID != "" {
+		id = azidentity.ClientID(clientID)
+	}
+	return azidentity.NewManagedIdentityCredential(&azidentity.ManagedIdentityCredentialOptions{ID: id})
+}
+
+// single returns the credential as the only source of the credential of the client.
+func single[T azcore.TokenCredential](c T, err error) ([]azcore.TokenCredential, error) {
+	if err != nil {
+		return nil, err
+	}
+	return []azcore.TokenCredential{c}, nil
+}
+
+// cryptoSigner is the crypto.Signer of a SignerVerifier.
+type cryptoSigner struct {
+	ctx     context.Context
+	sv      *SignerVerifier
+	errFunc func(error)
+}
+
+func (c *cryptoSigner) Public() crypto.PublicKey {
+	pub, err := c.sv.public(c.ctx)
+	if err != nil && c.errFunc != nil {
+		c.errFunc(err)
+	}
+	return pub
+}
+
+func (c *cryptoSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	hashFunc := c.sv.hashFunc
+	if opts != nil {
+		hashFunc = opts.HashFunc()
+	}
+	return c.sv.SignMessage(nil, options.WithContext(c.ctx), options.WithDigest(digest), options.WithCryptoSignerOpts(hashFunc))
+}
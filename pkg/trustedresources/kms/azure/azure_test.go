@@ -0,0 +1,380 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package azure
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azkeys"
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/sigstore/sigstore/pkg/signature/options"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+// fakeVault is a Key Vault holding the versions of a key.
+type fakeVault struct {
+	versions map[string]*ecdsa.PrivateKey
+	current  string
+	// gets and signs record the versions requested by the calls to GetKey and Sign.
+	gets, signs []string
+}
+
+func newFakeVault(t *testing.T, versions ...string) *fakeVault {
+	t.Helper()
+	v := &fakeVault{versions: map[string]*ecdsa.PrivateKey{}}
+	for _, version := range versions {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate the key: %v", err)
+		}
+		v.versions[version] = key
+		v.current = version
+	}
+	return v
+}
+
+func (v *fakeVault) key(keyVersion string) (*ecdsa.PrivateKey, error) {
+	if keyVersion == "" {
+		keyVersion = v.current
+	}
+	key, ok := v.versions[keyVersion]
+	if !ok {
+		return nil, fmt.Errorf("key version %q not found", keyVersion)
+	}
+	return key, nil
+}
+
+func (v *fakeVault) GetKey(_ context.Context, _, version string, _ *azkeys.GetKeyOptions) (azkeys.GetKeyResponse, error) {
+	v.gets = append(v.gets, version)
+	key, err := v.key(version)
+	if err != nil {
+		return azkeys.GetKeyResponse{}, err
+	}
+	kty, crv := azkeys.KeyTypeECHSM, azkeys.CurveNameP256
+	return azkeys.GetKeyResponse{KeyBundle: azkeys.KeyBundle{Key: &azkeys.JSONWebKey{
+		Kty: &kty,
+		Crv: &crv,
+		X:   key.X.FillBytes(make([]byte, 32)),
+		Y:   key.Y.FillBytes(make([]byte, 32)),
+	}}}, nil
+}
+
+func (v *fakeVault) Sign(_ context.Context, _, version string, parameters azkeys.SignParameters, _ *azkeys.SignOptions) (azkeys.SignResponse, error) {
+	v.signs = append(v.signs, version)
+	key, err := v.key(version)
+	if err != nil {
+		return azkeys.SignResponse{}, err
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, key, parameters.Value)
+	if err != nil {
+		return azkeys.SignResponse{}, err
+	}
+	raw := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return azkeys.SignResponse{KeyOperationResult: azkeys.KeyOperationResult{Result: raw}}, nil
+}
+
+func TestParseReference(t *testing.T) {
+	for _, tc := range []struct {
+		ref  string
+		want Reference
+	}{{
+		ref:  "azurekms://tekton.vault.azure.net/signing",
+		want: Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing"},
+	}, {
+		ref:  "azurekms://tekton.vault.azure.net/signing/78deebed173b48e48f55abf87ed4cf71",
+		want: Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing", KeyVersion: "78deebed173b48e48f55abf87ed4cf71"},
+	}} {
+		t.Run(tc.ref, func(t *testing.T) {
+			got, err := ParseReference(tc.ref)
+			if err != nil {
+				t.Fatalf("ParseReference() = %v", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+
+	for _, ref := range []string{
+		"azurekms://tekton.vault.azure.net",
+		"azurekms://tekton.vault.azure.net/",
+		"azurekms://tekton.vault.azure.net/signing/",
+		"azurekms://tekton.vault.azure.net/signing/78deebed/extra",
+		"gcpkms://projects/tekton/locations/global/keyRings/tekton/cryptoKeys/signing",
+	} {
+		t.Run(ref, func(t *testing.T) {
+			if _, err := ParseReference(ref); !errors.Is(err, ErrInvalidReference) {
+				t.Errorf("expected ErrInvalidReference, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSignerVerifier_KeyVersion(t *testing.T) {
+	vault := newFakeVault(t, "v1", "v2")
+	ref, err := ParseReference("azurekms://tekton.vault.azure.net/signing/v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sv, err := newSignerVerifier(ref, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := []byte(`{"steps":[{"name":"build"}]}`)
+	sig, err := sv.SignMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("SignMessage() = %v", err)
+	}
+	if err := sv.VerifySignature(bytes.NewReader(sig), bytes.NewReader(message)); err != nil {
+		t.Errorf("VerifySignature() = %v", err)
+	}
+	// The key is pinned to v1 although v2 is its current version.
+	if d := cmp.Diff([]string{"v1"}, vault.signs); d != "" {
+		t.Errorf("expected the pinned version to sign %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff([]string{"v1"}, vault.gets); d != "" {
+		t.Errorf("expected the public key of the pinned version %s", diff.PrintWantGot(d))
+	}
+
+	// The signatures of the current version don't verify with the pinned version.
+	current, err := newSignerVerifier(Reference{VaultURL: ref.VaultURL, KeyName: ref.KeyName}, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig, err = current.SignMessage(bytes.NewReader(message))
+	if err != nil {
+		t.Fatalf("SignMessage() = %v", err)
+	}
+	if err := sv.VerifySignature(bytes.NewReader(sig), bytes.NewReader(message)); err == nil {
+		t.Error("expected the signature of another version not to verify")
+	}
+	if err := current.VerifySignature(bytes.NewReader(sig), bytes.NewReader(message)); err != nil {
+		t.Errorf("VerifySignature() = %v", err)
+	}
+}
+
+func TestSignerVerifier_PublicKeyCache(t *testing.T) {
+	vault := newFakeVault(t, "v1")
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	pinned, err := newSignerVerifier(Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing", KeyVersion: "v1"}, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := newSignerVerifier(Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing"}, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pinned.now = func() time.Time { return now }
+	current.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if _, err := pinned.PublicKey(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := current.PublicKey(); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(DefaultPublicKeyTTL)
+	}
+	// The public key of the current version is fetched again once it expires, the one
+	// of a pinned version never changes.
+	if d := cmp.Diff([]string{"v1", "", ""}, vault.gets); d != "" {
+		t.Errorf("unexpected calls to GetKey %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestSignerVerifier_Invalidate(t *testing.T) {
+	vault := newFakeVault(t, "v1")
+	pinned, err := newSignerVerifier(Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing", KeyVersion: "v1"}, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := newSignerVerifier(Reference{VaultURL: "https://tekton.vault.azure.net/", KeyName: "signing"}, crypto.SHA256, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, sv := range []*SignerVerifier{pinned, current, pinned, current} {
+		if _, err := sv.PublicKey(); err != nil {
+			t.Fatal(err)
+		}
+		sv.Invalidate()
+	}
+	// The public keys are fetched again after they are invalidated, before they expire.
+	if d := cmp.Diff([]string{"v1", "", "v1", ""}, vault.gets); d != "" {
+		t.Errorf("unexpected calls to GetKey %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestPublicKeyTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		env     string
+		opts    []signature.RPCOption
+		want    time.Duration
+		wantErr bool
+	}{{
+		name: "default",
+		want: DefaultPublicKeyTTL,
+	}, {
+		name: "environment",
+		env:  "1m",
+		want: time.Minute,
+	}, {
+		name: "option overrides the environment",
+		env:  "1m",
+		opts: []signature.RPCOption{options.WithContext(context.Background()), WithPublicKeyTTL(10 * time.Second)},
+		want: 10 * time.Second,
+	}, {
+		name:    "invalid environment",
+		env:     "soon",
+		wantErr: true,
+	}, {
+		name:    "negative environment",
+		env:     "-1m",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(publicKeyTTLEnv, tc.env)
+			got, err := publicKeyTTL(tc.opts)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %t, got %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected TTL %s, got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestNewSignerVerifier_UnsupportedHash(t *testing.T) {
+	if _, err := newSignerVerifier(Reference{}, crypto.MD5, newFakeVault(t, "v1")); err == nil {
+		t.Error("expected an error for a hash function not supported by Azure Key Vault")
+	}
+}
+
+func TestCredentials(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+		want []string
+	}{{
+		name: "chain without service principal",
+		want: []string{"*azidentity.ManagedIdentityCredential", "*azidentity.AzureCLICredential"},
+	}, {
+		name: "chain with service principal",
+		env:  map[string]string{"AZURE_TENANT_ID": "tenant", "AZURE_CLIENT_ID": "client", "AZURE_CLIENT_SECRET": "secret"},
+		want: []string{"*azidentity.EnvironmentCredential", "*azidentity.ManagedIdentityCredential", "*azidentity.AzureCLICredential"},
+	}, {
+		name: "chain with workload identity",
+		env:  map[string]string{"AZURE_TENANT_ID": "tenant", "AZURE_CLIENT_ID": "client", "AZURE_FEDERATED_TOKEN_FILE": tokenFile},
+		want: []string{"*azidentity.WorkloadIdentityCredential", "*azidentity.ManagedIdentityCredential", "*azidentity.AzureCLICredential"},
+	}, {
+		name: "workload identity",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "workloadidentity", "AZURE_TENANT_ID": "tenant", "AZURE_CLIENT_ID": "client", "AZURE_FEDERATED_TOKEN_FILE": tokenFile},
+		want: []string{"*azidentity.WorkloadIdentityCredential"},
+	}, {
+		name: "user-assigned managed identity",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "ManagedIdentity", "AZURE_MANAGED_IDENTITY_RESOURCE_ID": "/subscriptions/sub/resourceGroups/tekton/providers/Microsoft.ManagedIdentity/userAssignedIdentities/controller"},
+		want: []string{"*azidentity.ManagedIdentityCredential"},
+	}, {
+		name: "cli",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "cli"},
+		want: []string{"*azidentity.AzureCLICredential"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unsetAzureEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			sources, err := credentials()
+			if err != nil {
+				t.Fatalf("credentials() = %v", err)
+			}
+			var got []string
+			for _, c := range sources {
+				got = append(got, fmt.Sprintf("%T", c))
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Error(diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestCredentials_Invalid(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		env  map[string]string
+	}{{
+		name: "unknown auth method",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "password"},
+	}, {
+		name: "workload identity without token file",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "workloadidentity", "AZURE_TENANT_ID": "tenant", "AZURE_CLIENT_ID": "client"},
+	}, {
+		name: "environment without service principal",
+		env:  map[string]string{"AZURE_AUTH_METHOD": "environment"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			unsetAzureEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			if _, err := credentials(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+	// The credential of the client is a chain unless a single credential is selected.
+	unsetAzureEnv(t)
+	c, err := credential()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.(*azidentity.ChainedTokenCredential); !ok {
+		t.Errorf("expected a chain of credentials, got %T", c)
+	}
+}
+
+// unsetAzureEnv clears the Azure environment variables of the test, restoring them
+// once it completes.
+func unsetAzureEnv(t *testing.T) {
+	t.Helper()
+	for _, k := range []string{
+		"AZURE_AUTH_METHOD", "AZURE_TENANT_ID", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET",
+		"AZURE_CLIENT_CERTIFICATE_PATH", "AZURE_USERNAME", "AZURE_PASSWORD",
+		"AZURE_FEDERATED_TOKEN_FILE", "AZURE_MANAGED_IDENTITY_RESOURCE_ID",
+	} {
+		t.Setenv(k, "")
+	}
+}
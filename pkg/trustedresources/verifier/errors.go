@@ -38,4 +38,16 @@ var (
 	ErrLoadVerifier = errors.New("verifier cannot to be loaded")
 	// ErrAlgorithmInvalid is returned the hash algorithm is not supported
 	ErrAlgorithmInvalid = errors.New("unknown digest algorithm")
+	// ErrInvalidCARoots is returned when the CA roots of a keyless authority don't contain certificates
+	ErrInvalidCARoots = errors.New("caRoots doesn't contain PEM-encoded certificates")
+	// ErrMissingCertificate is returned when a keyless signature doesn't have a valid certificate
+	ErrMissingCertificate = errors.New("keyless signature has no valid certificate")
+	// ErrMissingTransparencyLogEntry is returned when a keyless signature isn't recorded in a transparency log
+	ErrMissingTransparencyLogEntry = errors.New("keyless signature has no transparency log entry")
+	// ErrInvalidTransparencyLogEntry is returned when the transparency log entry of a keyless signature fails verification
+	ErrInvalidTransparencyLogEntry = errors.New("invalid transparency log entry")
+	// ErrCertificateNotTrusted is returned when the certificate of a keyless signature isn't issued by the CA roots
+	ErrCertificateNotTrusted = errors.New("certificate isn't trusted")
+	// ErrIdentityMismatch is returned when the certificate of a keyless signature doesn't match any identity
+	ErrIdentityMismatch = errors.New("certificate doesn't match any identity")
 )
@@ -0,0 +1,418 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	// oidIssuer is the deprecated Fulcio certificate extension holding the OIDC
+	// issuer which authenticated the signer as a raw string.
+	oidIssuer = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// oidIssuerV2 is the Fulcio certificate extension holding the OIDC issuer which
+	// authenticated the signer as a DER-encoded UTF8String.
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// KeylessSignature contains the material of a keyless signature besides the
+// signature itself: the short-lived signing certificate and the entry of the
+// transparency log recording the signature.
+type KeylessSignature struct {
+	// Certificate is the PEM-encoded signing certificate.
+	Certificate []byte `json:"certificate"`
+	// Bundle is the entry of the transparency log recording the signature.
+	Bundle *RekorBundle `json:"bundle"`
+}
+
+// RekorBundle is an entry of a Rekor transparency log along with the proofs
+// that the log recorded it: the signed entry timestamp promised by the log, and
+// the inclusion proof of the entry in the log's Merkle tree.
+type RekorBundle struct {
+	SignedEntryTimestamp []byte          `json:"SignedEntryTimestamp"`
+	Payload              RekorPayload    `json:"Payload"`
+	InclusionProof       *InclusionProof `json:"InclusionProof,omitempty"`
+}
+
+// RekorPayload is the entry of the transparency log which the signed entry
+// timestamp is computed over. Its fields are in the order of the canonical
+// JSON encoding which is signed.
+type RekorPayload struct {
+	// Body is the base64-encoded entry.
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// InclusionProof proves that an entry is included in the Merkle tree of the log,
+// at the checkpoint signed by the log, as defined by RFC 6962.
+type InclusionProof struct {
+	// LogIndex is the index of the entry in the tree of the shard of the log, which
+	// differs from the index of the RekorPayload once the log has several shards.
+	LogIndex int64 `json:"logIndex"`
+	// RootHash is the hex-encoded root hash of the tree.
+	RootHash string `json:"rootHash"`
+	// TreeSize is the size of the tree.
+	TreeSize int64 `json:"treeSize"`
+	// Hashes are the hex-encoded hashes of the audit path of the entry.
+	Hashes []string `json:"hashes"`
+	// Checkpoint is the signed note of the log committing to the tree.
+	Checkpoint string `json:"checkpoint"`
+}
+
+// hashedRekord is the body of a Rekor "hashedrekord" entry, recording the signature
+// of an artifact by its digest.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Signature struct {
+			Content   string `json:"content"`
+			PublicKey struct {
+				Content string `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+	} `json:"spec"`
+}
+
+// Keyless verifies keyless signatures against a keyless authority of a VerificationPolicy.
+type Keyless struct {
+	name          string
+	identities    []v1alpha1.Identity
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+	log           signature.Verifier
+	origin        string
+}
+
+// KeylessFromPolicy returns the keyless verifiers of the keyless authorities of the policy.
+func KeylessFromPolicy(ctx context.Context, k8s kubernetes.Interface, policy *v1alpha1.VerificationPolicy) ([]*Keyless, error) {
+	verifiers := []*Keyless{}
+	for _, a := range policy.Spec.Authorities {
+		if a.Keyless == nil {
+			continue
+		}
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(a.Keyless.CARoots))
+		if err != nil || len(certs) == 0 {
+			return nil, fmt.Errorf("authority %q: %w", a.Name, ErrInvalidCARoots)
+		}
+		roots, intermediates := x509.NewCertPool(), x509.NewCertPool()
+		for _, c := range certs {
+			if bytes.Equal(c.RawIssuer, c.RawSubject) {
+				roots.AddCert(c)
+			} else {
+				intermediates.AddCert(c)
+			}
+		}
+		if a.Keyless.TransparencyLog == nil {
+			return nil, fmt.Errorf("authority %q: %w", a.Name, ErrEmptyKey)
+		}
+		log, err := fromKey(ctx, k8s, a.Name, a.Keyless.TransparencyLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the transparency log verifier: %w", err)
+		}
+		verifiers = append(verifiers, &Keyless{
+			name:          a.Name,
+			identities:    a.Keyless.Identities,
+			roots:         roots,
+			intermediates: intermediates,
+			log:           log,
+			origin:        a.Keyless.TransparencyLogOrigin,
+		})
+	}
+	return verifiers, nil
+}
+
+// Verify verifies that sig is a signature of message by the certificate of the
+// keyless signature, that the certificate was issued by the certificate authority
+// to one of the identities of the authority, and that the transparency log
+// recorded the signature while the certificate was valid.
+func (k *Keyless) Verify(message, sig []byte, keyless *KeylessSignature) error {
+	if keyless == nil || len(keyless.Certificate) == 0 {
+		return ErrMissingCertificate
+	}
+	if keyless.Bundle == nil {
+		return ErrMissingTransparencyLogEntry
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(keyless.Certificate)
+	if err != nil || len(certs) == 0 {
+		return fmt.Errorf("%w: %v", ErrMissingCertificate, err) //nolint:errorlint
+	}
+	cert := certs[0]
+
+	body, err := k.verifyBundle(keyless.Bundle)
+	if err != nil {
+		return err
+	}
+	if err := verifyEntry(body, message, sig, cert); err != nil {
+		return err
+	}
+
+	// Keyless certificates are short-lived, they must have been valid when the
+	// transparency log recorded the signature.
+	integratedTime := time.Unix(keyless.Bundle.Payload.IntegratedTime, 0)
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         k.roots,
+		Intermediates: k.intermediates,
+		CurrentTime:   integratedTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrCertificateNotTrusted, err) //nolint:errorlint
+	}
+	if err := k.matchIdentity(cert); err != nil {
+		return err
+	}
+
+	v, err := signature.LoadVerifier(cert.PublicKey, crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLoadVerifier, err) //nolint:errorlint
+	}
+	return v.VerifySignature(bytes.NewReader(sig), bytes.NewReader(message))
+}
+
+// verifyBundle verifies the signed entry timestamp and the inclusion proof of the
+// entry of the bundle, and returns the entry.
+func (k *Keyless) verifyBundle(bundle *RekorBundle) ([]byte, error) {
+	payload, err := json.Marshal(bundle.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	if err := k.log.VerifySignature(bytes.NewReader(bundle.SignedEntryTimestamp), bytes.NewReader(payload)); err != nil {
+		return nil, fmt.Errorf("%w: invalid signed entry timestamp: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	body, err := base64.StdEncoding.DecodeString(bundle.Payload.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+
+	proof := bundle.InclusionProof
+	if proof == nil {
+		return nil, fmt.Errorf("%w: missing inclusion proof", ErrInvalidTransparencyLogEntry)
+	}
+	// The index of the proof isn't compared to the index of the payload: the log is
+	// sharded, and the proof is for the index of the entry in the tree of its shard
+	// while the payload holds its index across all the shards.
+	hashes := make([][]byte, 0, len(proof.Hashes))
+	for _, h := range proof.Hashes {
+		b, err := hex.DecodeString(h)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+		}
+		hashes = append(hashes, b)
+	}
+	rootHash, err := hex.DecodeString(proof.RootHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	root, err := rootFromInclusionProof(proof.LogIndex, proof.TreeSize, hashLeaf(body), hashes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	if !bytes.Equal(root, rootHash) {
+		return nil, fmt.Errorf("%w: the inclusion proof doesn't match the root hash", ErrInvalidTransparencyLogEntry)
+	}
+	if err := k.verifyCheckpoint(proof.Checkpoint, proof.TreeSize, rootHash); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	return body, nil
+}
+
+// verifyCheckpoint verifies that the checkpoint is for the origin of the log, is
+// signed by the log and commits to the tree of the given size and root hash. The
+// origin rejects the checkpoints of the other trees signed by the same key.
+func (k *Keyless) verifyCheckpoint(checkpoint string, size int64, rootHash []byte) error {
+	i := strings.Index(checkpoint, "\n\n")
+	if i < 0 {
+		return errors.New("malformed checkpoint")
+	}
+	text := checkpoint[:i+1]
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) < 3 {
+		return errors.New("malformed checkpoint")
+	}
+	if lines[0] != k.origin {
+		return fmt.Errorf("the checkpoint is for the origin %q instead of %q", lines[0], k.origin)
+	}
+	if lines[1] != strconv.FormatInt(size, 10) || lines[2] != base64.StdEncoding.EncodeToString(rootHash) {
+		return errors.New("the checkpoint doesn't match the inclusion proof")
+	}
+	for _, line := range strings.Split(checkpoint[i+2:], "\n") {
+		fields := strings.Fields(strings.TrimPrefix(line, "— "))
+		if !strings.HasPrefix(line, "— ") || len(fields) != 2 {
+			continue
+		}
+		// The signature is prefixed with a 4 bytes hint of the key.
+		sig, err := base64.StdEncoding.DecodeString(fields[1])
+		if err != nil || len(sig) <= 4 {
+			continue
+		}
+		if err := k.log.VerifySignature(bytes.NewReader(sig[4:]), strings.NewReader(text)); err == nil {
+			return nil
+		}
+	}
+	return errors.New("the checkpoint isn't signed by the transparency log")
+}
+
+// verifyEntry verifies that the entry of the transparency log records the signature
+// of message by the certificate.
+func verifyEntry(body, message, sig []byte, cert *x509.Certificate) error {
+	var entry hashedRekord
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	if entry.Kind != "hashedrekord" {
+		return fmt.Errorf("%w: unsupported entry kind %q", ErrInvalidTransparencyLogEntry, entry.Kind)
+	}
+	entrySig, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.Content)
+	if err != nil || !bytes.Equal(entrySig, sig) {
+		return fmt.Errorf("%w: the entry doesn't record the signature", ErrInvalidTransparencyLogEntry)
+	}
+	entryCert, err := base64.StdEncoding.DecodeString(entry.Spec.Signature.PublicKey.Content)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTransparencyLogEntry, err) //nolint:errorlint
+	}
+	entryCerts, err := cryptoutils.UnmarshalCertificatesFromPEM(entryCert)
+	if err != nil || len(entryCerts) == 0 || !entryCerts[0].Equal(cert) {
+		return fmt.Errorf("%w: the entry doesn't record the certificate", ErrInvalidTransparencyLogEntry)
+	}
+	digest := sha256.Sum256(message)
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(digest[:]) {
+		return fmt.Errorf("%w: the entry doesn't record the digest of the resource", ErrInvalidTransparencyLogEntry)
+	}
+	return nil
+}
+
+// matchIdentity returns an error unless the certificate was issued to one of the
+// identities of the authority.
+func (k *Keyless) matchIdentity(cert *x509.Certificate) error {
+	issuer, err := certificateIssuer(cert)
+	if err != nil {
+		return err
+	}
+	subjects := cryptoutils.GetSubjectAlternateNames(cert)
+	for _, id := range k.identities {
+		if !matchIdentityField(id.Issuer, id.IssuerRegExp, issuer) {
+			continue
+		}
+		for _, s := range subjects {
+			if matchIdentityField(id.Subject, id.SubjectRegExp, s) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%w: certificate of %v issued by %q", ErrIdentityMismatch, subjects, issuer)
+}
+
+// matchIdentityField returns whether value is exact, or entirely matches regExp if set.
+func matchIdentityField(exact, regExp, value string) bool {
+	if regExp != "" {
+		matched, err := regexp.MatchString("^(?:"+regExp+")$", value)
+		return err == nil && matched
+	}
+	return exact == value
+}
+
+// certificateIssuer returns the OIDC issuer recorded in the certificate by Fulcio.
+func certificateIssuer(cert *x509.Certificate) (string, error) {
+	var issuer string
+	for _, ext := range cert.Extensions {
+		switch {
+		case ext.Id.Equal(oidIssuerV2):
+			var v string
+			if _, err := asn1.Unmarshal(ext.Value, &v); err != nil {
+				return "", fmt.Errorf("%w: invalid issuer extension: %v", ErrIdentityMismatch, err) //nolint:errorlint
+			}
+			return v, nil
+		case ext.Id.Equal(oidIssuer):
+			issuer = string(ext.Value)
+		}
+	}
+	return issuer, nil
+}
+
+// hashLeaf returns the hash of a leaf of a Merkle tree, as defined by RFC 6962.
+func hashLeaf(leaf []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0})
+	h.Write(leaf)
+	return h.Sum(nil)
+}
+
+// hashChildren returns the hash of an inner node of a Merkle tree, as defined by RFC 6962.
+func hashChildren(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{1})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// rootFromInclusionProof computes the root hash of a Merkle tree of the given size
+// from the hash of its leaf at index and the audit path of the leaf, following the
+// verification algorithm of RFC 9162 section 2.1.3.2.
+func rootFromInclusionProof(index, size int64, leafHash []byte, proof [][]byte) ([]byte, error) {
+	if index < 0 || index >= size {
+		return nil, fmt.Errorf("index %d is out of the tree of size %d", index, size)
+	}
+	fn, sn := index, size-1
+	r := leafHash
+	for _, p := range proof {
+		if sn == 0 {
+			return nil, errors.New("the inclusion proof is too long")
+		}
+		if fn&1 == 1 || fn == sn {
+			r = hashChildren(p, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = hashChildren(r, p)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	if sn != 0 {
+		return nil, errors.New("the inclusion proof is too short")
+	}
+	return r, nil
+}
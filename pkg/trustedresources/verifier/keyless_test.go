@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestKeylessFromPolicy(t *testing.T) {
+	signer := test.NewKeylessSigner(t, "https://accounts.google.com", "release@tekton.dev")
+	authority := signer.Authority("keyless")
+
+	tcs := []struct {
+		name          string
+		authority     v1alpha1.Authority
+		expectedCount int
+		expectedError error
+	}{{
+		name:          "keyless authority",
+		authority:     authority,
+		expectedCount: 1,
+	}, {
+		name: "key authority",
+		authority: v1alpha1.Authority{
+			Name: "key",
+			Key:  &v1alpha1.KeyRef{Data: signer.LogPublicKey},
+		},
+	}, {
+		name: "invalid CA roots",
+		authority: func() v1alpha1.Authority {
+			a := *authority.DeepCopy()
+			a.Keyless.CARoots = "not a certificate"
+			return a
+		}(),
+		expectedError: ErrInvalidCARoots,
+	}, {
+		name: "missing transparency log key",
+		authority: func() v1alpha1.Authority {
+			a := *authority.DeepCopy()
+			a.Keyless.TransparencyLog = nil
+			return a
+		}(),
+		expectedError: ErrEmptyKey,
+	}, {
+		name: "invalid transparency log key",
+		authority: func() v1alpha1.Authority {
+			a := *authority.DeepCopy()
+			a.Keyless.TransparencyLog.Data = "not a key"
+			return a
+		}(),
+		expectedError: ErrDecodeKey,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &v1alpha1.VerificationPolicy{
+				Spec: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.Authority{tc.authority}},
+			}
+			verifiers, err := KeylessFromPolicy(context.Background(), fakek8s.NewSimpleClientset(), policy)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("KeylessFromPolicy got: %v, want: %v", err, tc.expectedError)
+			}
+			if len(verifiers) != tc.expectedCount {
+				t.Errorf("expected %d verifiers, got %d", tc.expectedCount, len(verifiers))
+			}
+		})
+	}
+}
+
+func TestFromPolicy_Keyless(t *testing.T) {
+	signer := test.NewKeylessSigner(t, "https://accounts.google.com", "release@tekton.dev")
+	policy := &v1alpha1.VerificationPolicy{
+		Spec: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.Authority{signer.Authority("keyless")}},
+	}
+	verifiers, err := FromPolicy(context.Background(), fakek8s.NewSimpleClientset(), policy)
+	if err != nil {
+		t.Fatalf("FromPolicy got error: %v", err)
+	}
+	if len(verifiers) != 0 {
+		t.Errorf("expected no public key verifiers for a keyless authority, got %d", len(verifiers))
+	}
+}
+
+func TestVerifyBundle(t *testing.T) {
+	signer := test.NewKeylessSigner(t, "https://accounts.google.com", "release@tekton.dev")
+	policy := &v1alpha1.VerificationPolicy{
+		Spec: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.Authority{signer.Authority("keyless")}},
+	}
+	verifiers, err := KeylessFromPolicy(context.Background(), fakek8s.NewSimpleClientset(), policy)
+	if err != nil {
+		t.Fatalf("KeylessFromPolicy got error: %v", err)
+	}
+	task, err := test.GetKeylessSignedTask(&v1beta1.Task{}, signer, "signed")
+	if err != nil {
+		t.Fatalf("failed to sign the task: %v", err)
+	}
+
+	tcs := []struct {
+		name          string
+		mutate        func(k *Keyless, b *RekorBundle)
+		expectedError error
+	}{{
+		name:   "valid bundle",
+		mutate: func(*Keyless, *RekorBundle) {},
+	}, {
+		name: "valid bundle of a sharded log",
+		mutate: func(_ *Keyless, b *RekorBundle) {
+			// The proof is for the index in the tree of the shard, while the
+			// signed payload holds the index across all the shards.
+			if b.Payload.LogIndex == b.InclusionProof.LogIndex {
+				t.Fatalf("expected the index %d of the payload to differ from the index of the proof", b.Payload.LogIndex)
+			}
+		},
+	}, {
+		name: "inclusion proof of another index",
+		mutate: func(_ *Keyless, b *RekorBundle) {
+			b.InclusionProof.LogIndex--
+		},
+		expectedError: ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "checkpoint of a foreign origin",
+		mutate: func(k *Keyless, _ *RekorBundle) {
+			k.origin = "rekor.example.com - 2"
+		},
+		expectedError: ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "missing inclusion proof",
+		mutate: func(_ *Keyless, b *RekorBundle) {
+			b.InclusionProof = nil
+		},
+		expectedError: ErrInvalidTransparencyLogEntry,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			bundle := &RekorBundle{}
+			if err := json.Unmarshal([]byte(task.Annotations["tekton.dev/rekor-bundle"]), bundle); err != nil {
+				t.Fatalf("failed to unmarshal the bundle: %v", err)
+			}
+			k := *verifiers[0]
+			tc.mutate(&k, bundle)
+			if _, err := k.verifyBundle(bundle); !errors.Is(err, tc.expectedError) {
+				t.Errorf("verifyBundle got: %v, want: %v", err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestMatchIdentityField(t *testing.T) {
+	tcs := []struct {
+		exact, regExp, value string
+		expected             bool
+	}{
+		{exact: "release@tekton.dev", value: "release@tekton.dev", expected: true},
+		{exact: "release@tekton.dev", value: "attacker@tekton.dev", expected: false},
+		{regExp: `.*@tekton\.dev`, value: "release@tekton.dev", expected: true},
+		{regExp: `.*@tekton\.dev`, value: "release@tekton.dev.example.com", expected: false},
+		{regExp: `https://github\.com/tektoncd/.*`, value: "https://github.com/tektoncd/catalog/.github/workflows/release.yaml@refs/heads/main", expected: true},
+		{regExp: "[", value: "[", expected: false},
+	}
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("%s%s~%s", tc.exact, tc.regExp, tc.value), func(t *testing.T) {
+			if got := matchIdentityField(tc.exact, tc.regExp, tc.value); got != tc.expected {
+				t.Errorf("matchIdentityField(%q, %q, %q) = %t, want %t", tc.exact, tc.regExp, tc.value, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRootFromInclusionProof(t *testing.T) {
+	// Build trees of every size up to 8 and check the proof of every leaf against the root.
+	for size := int64(1); size <= 8; size++ {
+		leaves := make([][]byte, size)
+		for i := range leaves {
+			leaves[i] = hashLeaf([]byte{byte(i)})
+		}
+		root := treeHash(leaves)
+		for index := int64(0); index < size; index++ {
+			proof := auditPath(int(index), leaves)
+			got, err := rootFromInclusionProof(index, size, leaves[index], proof)
+			if err != nil {
+				t.Fatalf("size %d, index %d: unexpected error: %v", size, index, err)
+			}
+			if !bytes.Equal(got, root) {
+				t.Errorf("size %d, index %d: root mismatch", size, index)
+			}
+			if len(proof) > 0 {
+				if got, err := rootFromInclusionProof(index, size, leaves[index], proof[1:]); err == nil && bytes.Equal(got, root) {
+					t.Errorf("size %d, index %d: a truncated proof matches the root", size, index)
+				}
+			}
+		}
+	}
+	if _, err := rootFromInclusionProof(5, 5, hashLeaf(nil), nil); err == nil {
+		t.Error("expected an error for an index out of the tree")
+	}
+}
+
+func treeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return hashChildren(treeHash(leaves[:k]), treeHash(leaves[k:]))
+}
+
+func auditPath(index int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return nil
+	}
+	k := split(len(leaves))
+	if index < k {
+		return append(auditPath(index, leaves[:k]), treeHash(leaves[k:]))
+	}
+	return append(auditPath(index-k, leaves[k:]), treeHash(leaves[:k]))
+}
+
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
@@ -30,10 +30,10 @@ import (
 
 	// TODO(#5976): consider move these registration to cmd/controller/main.go
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/aws"        // imported to execute init function to register aws kms
-	_ "github.com/sigstore/sigstore/pkg/signature/kms/azure"      // imported to execute init function to register azure kms
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/gcp"        // imported to execute init function to register gcp kms
 	_ "github.com/sigstore/sigstore/pkg/signature/kms/hashivault" // imported to execute init function to register hashivault kms
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	_ "github.com/tektoncd/pipeline/pkg/trustedresources/kms/azure" // imported to execute init function to register azure kms, supporting key versions
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -46,44 +46,63 @@ const (
 
 // FromPolicy get all verifiers from VerificationPolicy.
 // For each policy, loop the Authorities of the VerificationPolicy to fetch public key
-// from either inline Data or from a SecretRef.
+// from either inline Data or from a SecretRef. Keyless authorities don't have a public
+// key and are verified by the verifiers from KeylessFromPolicy.
 func FromPolicy(ctx context.Context, k8s kubernetes.Interface, policy *v1alpha1.VerificationPolicy) ([]signature.Verifier, error) {
 	verifiers := []signature.Verifier{}
+	keyless := 0
 	for _, a := range policy.Spec.Authorities {
-		algorithm, err := matchHashAlgorithm(a.Key.HashAlgorithm)
-		if err != nil {
-			return nil, fmt.Errorf("authority %q contains an invalid hash algorithm: %w", a.Name, err)
+		if a.Keyless != nil {
+			keyless++
+			continue
 		}
-
-		switch {
-		case a.Key.Data != "":
-			v, err := fromData([]byte(a.Key.Data), algorithm)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get verifier from data: %w", err)
-			}
-			verifiers = append(verifiers, v)
-		case a.Key.SecretRef != nil:
-			v, err := fromSecret(ctx, fmt.Sprintf("%s%s/%s", keyReference, a.Key.SecretRef.Namespace, a.Key.SecretRef.Name), algorithm, k8s)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get verifier from secret: %w", err)
-			}
-			verifiers = append(verifiers, v)
-		case a.Key.KMS != "":
-			v, err := kms.Get(ctx, a.Key.KMS, algorithm)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get verifier from kms: %w", err)
-			}
-			verifiers = append(verifiers, v)
-		default:
+		if a.Key == nil {
 			return nil, ErrEmptyKey
 		}
+		v, err := fromKey(ctx, k8s, a.Name, a.Key)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, v)
 	}
-	if len(verifiers) == 0 {
+	if len(verifiers) == 0 && keyless == 0 {
 		return verifiers, ErrEmptyPublicKeys
 	}
 	return verifiers, nil
 }
 
+// fromKey returns the verifier of the public key referenced by key, from either
+// inline Data, a SecretRef or a KMS.
+func fromKey(ctx context.Context, k8s kubernetes.Interface, name string, key *v1alpha1.KeyRef) (signature.Verifier, error) {
+	algorithm, err := matchHashAlgorithm(key.HashAlgorithm)
+	if err != nil {
+		return nil, fmt.Errorf("authority %q contains an invalid hash algorithm: %w", name, err)
+	}
+
+	switch {
+	case key.Data != "":
+		v, err := fromData([]byte(key.Data), algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get verifier from data: %w", err)
+		}
+		return v, nil
+	case key.SecretRef != nil:
+		v, err := fromSecret(ctx, fmt.Sprintf("%s%s/%s", keyReference, key.SecretRef.Namespace, key.SecretRef.Name), algorithm, k8s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get verifier from secret: %w", err)
+		}
+		return v, nil
+	case key.KMS != "":
+		v, err := kms.Get(ctx, key.KMS, algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get verifier from kms: %w", err)
+		}
+		return v, nil
+	default:
+		return nil, ErrEmptyKey
+	}
+}
+
 // fromKeyRef parses the given keyRef, loads the key and returns an appropriate
 // verifier using the provided hash algorithm
 func fromKeyRef(ctx context.Context, keyRef string, hashAlgorithm crypto.Hash, k8s kubernetes.Interface) (signature.Verifier, error) {
@@ -28,6 +28,7 @@ import (
 
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/trustedresources/verifier"
@@ -39,6 +40,10 @@ import (
 const (
 	// SignatureAnnotation is the key of signature in annotation map
 	SignatureAnnotation = "tekton.dev/signature"
+	// CertificateAnnotation is the key of the PEM-encoded signing certificate of a keyless signature in annotation map
+	CertificateAnnotation = "tekton.dev/certificate"
+	// RekorBundleAnnotation is the key of the transparency log entry of a keyless signature in annotation map
+	RekorBundleAnnotation = "tekton.dev/rekor-bundle"
 )
 
 const (
@@ -63,34 +68,27 @@ type VerificationResult struct {
 	Err error
 }
 
-// VerifyResource verifies the signature and public key against resource (v1beta1 task and pipeline).
+// VerifyResource verifies the signature and public key against resource (v1beta1 and v1 task and pipeline).
 // VerificationResult is returned with different types for different cases:
 // 1) Return VerificationResult with VerificationSkip type, when no policies are found and no-match-policy is set to ignore
 // 2) Return VerificationResult with VerificationPass type when verification passed;
 // 3) Return VerificationResult with VerificationWarn type, when no matching policies and feature flag "no-match-policy" is "warn", or only Warn mode verification policies fail. Err field is filled with the warning;
 // 4) Return VerificationResult with VerificationError type when no policies are found and no-match-policy is set to fail, the resource fails to pass matched enforce verification policy, or there are errors during verification. Err is filled with the err.
 // refSource contains the source information of the resource.
-// TODO(#6729): Support v1 task and pipeline
 func VerifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.Interface, refSource *v1beta1.RefSource, verificationpolicies []*v1alpha1.VerificationPolicy) VerificationResult {
 	var refSourceURI string
 	if refSource != nil {
 		refSourceURI = refSource.URI
 	}
 
-	matchedPolicies, err := getMatchedPolicies(resource.GetName(), refSourceURI, verificationpolicies)
+	matchedPolicies, err := getMatchedPolicies(resource.GetName(), refSourceURI, v1alpha1.PolicyKindResources, verificationpolicies)
 	if err != nil {
-		if errors.Is(err, ErrNoMatchedPolicies) {
-			switch config.GetVerificationNoMatchPolicy(ctx) {
-			case config.IgnoreNoMatchPolicy:
-				return VerificationResult{VerificationResultType: VerificationSkip}
-			case config.WarnNoMatchPolicy:
-				logger := logging.FromContext(ctx)
-				warning := fmt.Errorf("failed to get matched policies: %w", err)
-				logger.Warnf(warning.Error())
-				return VerificationResult{VerificationResultType: VerificationWarn, Err: warning}
-			}
-		}
-		return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get matched policies: %w", err)}
+		return noMatchedPoliciesResult(ctx, err)
+	}
+
+	keyless, err := keylessSignature(resource.GetAnnotations())
+	if err != nil {
+		return VerificationResult{VerificationResultType: VerificationError, Err: err}
 	}
 
 	switch v := resource.(type) {
@@ -106,7 +104,7 @@ func VerifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 			ObjectMeta: tm,
 			Spec:       v.TaskSpec(),
 		}
-		return verifyResource(ctx, &task, k8s, signature, matchedPolicies)
+		return verifyResource(ctx, &task, k8s, signature, keyless, matchedPolicies)
 	case *v1beta1.Pipeline:
 		pm, signature, err := prepareObjectMeta(v.ObjectMeta)
 		if err != nil {
@@ -119,9 +117,35 @@ func VerifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 			ObjectMeta: pm,
 			Spec:       v.PipelineSpec(),
 		}
-		return verifyResource(ctx, &pipeline, k8s, signature, matchedPolicies)
+		return verifyResource(ctx, &pipeline, k8s, signature, keyless, matchedPolicies)
+	case *v1.Task:
+		tm, signature, err := prepareObjectMeta(v.ObjectMeta)
+		if err != nil {
+			return VerificationResult{VerificationResultType: VerificationError, Err: err}
+		}
+		task := v1.Task{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tekton.dev/v1",
+				Kind:       "Task"},
+			ObjectMeta: tm,
+			Spec:       v.Spec,
+		}
+		return verifyResource(ctx, &task, k8s, signature, keyless, matchedPolicies)
+	case *v1.Pipeline:
+		pm, signature, err := prepareObjectMeta(v.ObjectMeta)
+		if err != nil {
+			return VerificationResult{VerificationResultType: VerificationError, Err: err}
+		}
+		pipeline := v1.Pipeline{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "tekton.dev/v1",
+				Kind:       "Pipeline"},
+			ObjectMeta: pm,
+			Spec:       v.Spec,
+		}
+		return verifyResource(ctx, &pipeline, k8s, signature, keyless, matchedPolicies)
 	}
-	return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: got resource %v but v1beta1.Task and v1beta1.Pipeline are currently supported", ErrResourceNotSupported, resource)}
+	return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: got resource %v but v1beta1 and v1 Task and Pipeline are currently supported", ErrResourceNotSupported, resource)}
 }
 
 // VerifyTask is the deprecated, this is to keep backward compatibility
@@ -135,9 +159,13 @@ func VerifyPipeline(ctx context.Context, pipelineObj *v1beta1.Pipeline, k8s kube
 }
 
 // getMatchedPolicies filters out the policies by checking if the resource url (source) is matching any of the `patterns` in the `resources` list.
-func getMatchedPolicies(resourceName string, source string, policies []*v1alpha1.VerificationPolicy) ([]*v1alpha1.VerificationPolicy, error) {
+// Only the policies of the given kind are matched, so that the policies of images don't apply to resources and vice versa.
+func getMatchedPolicies(resourceName string, source string, kind v1alpha1.PolicyKind, policies []*v1alpha1.VerificationPolicy) ([]*v1alpha1.VerificationPolicy, error) {
 	matchedPolicies := []*v1alpha1.VerificationPolicy{}
 	for _, p := range policies {
+		if p.Spec.GetKind() != kind {
+			continue
+		}
 		for _, r := range p.Spec.Resources {
 			matching, err := regexp.MatchString(r.Pattern, source)
 			if err != nil {
@@ -156,6 +184,23 @@ func getMatchedPolicies(resourceName string, source string, policies []*v1alpha1
 	return matchedPolicies, nil
 }
 
+// noMatchedPoliciesResult returns the result of a verification which failed to match
+// policies, following the feature flag "no-match-policy" when no policies match.
+func noMatchedPoliciesResult(ctx context.Context, err error) VerificationResult {
+	if errors.Is(err, ErrNoMatchedPolicies) {
+		switch config.GetVerificationNoMatchPolicy(ctx) {
+		case config.IgnoreNoMatchPolicy:
+			return VerificationResult{VerificationResultType: VerificationSkip}
+		case config.WarnNoMatchPolicy:
+			logger := logging.FromContext(ctx)
+			warning := fmt.Errorf("failed to get matched policies: %w", err)
+			logger.Warnf(warning.Error())
+			return VerificationResult{VerificationResultType: VerificationWarn, Err: warning}
+		}
+	}
+	return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get matched policies: %w", err)}
+}
+
 // verifyResource verifies resource which implements metav1.Object by provided signature and public keys from verification policies.
 // For matched policies, `verifyResource“ will adopt the following rules to do verification:
 //  1. If multiple policies match, the resource must satisfy all the "enforce" policies to pass verification. The matching "enforce" policies are evaluated using AND logic.
@@ -163,8 +208,17 @@ func getMatchedPolicies(resourceName string, source string, policies []*v1alpha1
 //  2. To pass one policy, the resource can pass any public keys in the policy. We use OR logic on public keys of one policy.
 //
 // TODO(#6683): return all failed policies in error.
-func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.Interface, signature []byte, matchedPolicies []*v1alpha1.VerificationPolicy) VerificationResult {
+func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.Interface, signature []byte, keyless *verifier.KeylessSignature, matchedPolicies []*v1alpha1.VerificationPolicy) VerificationResult {
 	logger := logging.FromContext(ctx)
+	// Only passed verifications are cached, failures may be caused by transient errors
+	// getting the keys and are verified again.
+	cacheKey, err := verificationCacheKey(resource, signature, keyless, matchedPolicies)
+	if err != nil {
+		logger.Warnf("Failed to compute the verification cache key of resource %s in namespace %s: %v", resource.GetName(), resource.GetNamespace(), err)
+	} else if _, ok := verificationCache.Get(cacheKey); ok {
+		return VerificationResult{VerificationResultType: VerificationPass}
+	}
+
 	var warnPolicies []*v1alpha1.VerificationPolicy
 	var enforcePolicies []*v1alpha1.VerificationPolicy
 	for _, p := range matchedPolicies {
@@ -175,37 +229,107 @@ func verifyResource(ctx context.Context, resource metav1.Object, k8s kubernetes.
 		}
 	}
 
+	// A resource without a signature can't pass any policy, report it as such
+	// rather than as a signature mismatch.
+	if len(signature) == 0 {
+		err := fmt.Errorf("%w: resource %s in namespace %s has no %s annotation but matches policies %s", ErrSignatureMissing, resource.GetName(), resource.GetNamespace(), SignatureAnnotation, policyNames(matchedPolicies))
+		if len(enforcePolicies) > 0 {
+			return VerificationResult{VerificationResultType: VerificationError, Err: err}
+		}
+		logger.Warnf(err.Error())
+		return VerificationResult{VerificationResultType: VerificationWarn, Err: err}
+	}
+
 	// first evaluate all enforce policies. Return VerificationError type of VerificationResult if any policy fails.
 	for _, p := range enforcePolicies {
-		verifiers, err := verifier.FromPolicy(ctx, k8s, p)
+		result, err := verifyPolicy(ctx, k8s, resource, signature, keyless, p)
 		if err != nil {
 			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("failed to get verifiers from policy: %w", err)}
 		}
-		passVerification := doesAnyVerifierPass(resource, signature, verifiers)
-		if !passVerification {
-			return VerificationResult{VerificationResultType: VerificationError, Err: fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())}
+		if !result.passed {
+			return VerificationResult{VerificationResultType: VerificationError, Err: verificationFailure(resource, p, result.reason)}
 		}
 	}
 
 	// then evaluate all warn policies. Return VerificationWarn type of VerificationResult if any warn policies fails.
 	for _, p := range warnPolicies {
-		verifiers, err := verifier.FromPolicy(ctx, k8s, p)
+		result, err := verifyPolicy(ctx, k8s, resource, signature, keyless, p)
 		if err != nil {
 			warn := fmt.Errorf("fails to get verifiers for resource %s from namespace %s: %w", resource.GetName(), resource.GetNamespace(), err)
 			logger.Warnf(warn.Error())
 			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
 		}
-		passVerification := doesAnyVerifierPass(resource, signature, verifiers)
-		if !passVerification {
-			warn := fmt.Errorf("%w: resource %s in namespace %s fails verification", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace())
+		if !result.passed {
+			warn := verificationFailure(resource, p, result.reason)
 			logger.Warnf(warn.Error())
 			return VerificationResult{VerificationResultType: VerificationWarn, Err: warn}
 		}
 	}
 
+	if cacheKey != "" {
+		verificationCache.Add(cacheKey, struct{}{}, verificationCacheTTL)
+	}
 	return VerificationResult{VerificationResultType: VerificationPass}
 }
 
+// policyResult is the result of the verification of a resource against a policy.
+type policyResult struct {
+	// passed is true if the resource passes the policy.
+	passed bool
+	// reason is why the resource fails the policy, if it is known.
+	reason error
+}
+
+// verifyPolicy returns whether the resource passes the policy: its signature must be valid
+// for any public key of the policy, or be a keyless signature matching any keyless authority
+// of the policy. The reason why the keyless signature doesn't match is returned along with
+// failures. An error is returned if the verifiers can't be loaded from the policy.
+func verifyPolicy(ctx context.Context, k8s kubernetes.Interface, resource metav1.Object, signature []byte, keyless *verifier.KeylessSignature, p *v1alpha1.VerificationPolicy) (policyResult, error) {
+	verifiers, err := verifier.FromPolicy(ctx, k8s, p)
+	if err != nil {
+		return policyResult{}, err
+	}
+	if doesAnyVerifierPass(resource, signature, verifiers) {
+		return policyResult{passed: true}, nil
+	}
+
+	keylessVerifiers, err := verifier.KeylessFromPolicy(ctx, k8s, p)
+	if err != nil {
+		return policyResult{}, err
+	}
+	if len(keylessVerifiers) == 0 {
+		return policyResult{}, nil
+	}
+	message, err := digest(resource)
+	if err != nil {
+		return policyResult{reason: err}, nil
+	}
+	var reason error
+	for _, v := range keylessVerifiers {
+		if reason = v.Verify(message, signature, keyless); reason == nil {
+			return policyResult{passed: true}, nil
+		}
+	}
+	return policyResult{reason: reason}, nil
+}
+
+// verificationFailure returns the error of the resource failing the policy for the given reason.
+func verificationFailure(resource metav1.Object, p *v1alpha1.VerificationPolicy, reason error) error {
+	if reason != nil {
+		return fmt.Errorf("%w: resource %s in namespace %s fails verification against policy %s: %v", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace(), p.Name, reason) //nolint:errorlint
+	}
+	return fmt.Errorf("%w: resource %s in namespace %s fails verification against policy %s", ErrResourceVerificationFailed, resource.GetName(), resource.GetNamespace(), p.Name)
+}
+
+// policyNames returns the names of the policies, for error messages.
+func policyNames(policies []*v1alpha1.VerificationPolicy) []string {
+	names := make([]string, 0, len(policies))
+	for _, p := range policies {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
 // doesAnyVerifierPass loop over verifiers to verify the resource, return true if any verifier pass verification.
 func doesAnyVerifierPass(resource metav1.Object, signature []byte, verifiers []signature.Verifier) bool {
 	passVerification := false
@@ -221,15 +345,12 @@ func doesAnyVerifierPass(resource metav1.Object, signature []byte, verifiers []s
 
 // verifyInterface get the checksum of json marshalled object and verify it.
 func verifyInterface(obj interface{}, verifier signature.Verifier, signature []byte) error {
-	ts, err := json.Marshal(obj)
+	message, err := digest(obj)
 	if err != nil {
-		return fmt.Errorf("failed to marshal the object: %w", err)
+		return err
 	}
 
-	h := sha256.New()
-	h.Write(ts)
-
-	if err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(h.Sum(nil))); err != nil {
+	if err := verifier.VerifySignature(bytes.NewReader(signature), bytes.NewReader(message)); err != nil {
 		// FixMe: changing %v to %w breaks integration tests.
 		return fmt.Errorf("%w:%v", ErrResourceVerificationFailed, err.Error())
 	}
@@ -237,6 +358,35 @@ func verifyInterface(obj interface{}, verifier signature.Verifier, signature []b
 	return nil
 }
 
+// digest returns the checksum of json marshalled object, which is the message signed.
+func digest(obj interface{}) ([]byte, error) {
+	ts, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the object: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write(ts)
+	return h.Sum(nil), nil
+}
+
+// keylessSignature returns the certificate and transparency log entry of a keyless
+// signature from the annotations, or nil if the resource isn't signed keyless.
+func keylessSignature(annotations map[string]string) (*verifier.KeylessSignature, error) {
+	cert, ok := annotations[CertificateAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	keyless := &verifier.KeylessSignature{Certificate: []byte(cert)}
+	if b, ok := annotations[RekorBundleAnnotation]; ok {
+		keyless.Bundle = &verifier.RekorBundle{}
+		if err := json.Unmarshal([]byte(b), keyless.Bundle); err != nil {
+			return nil, fmt.Errorf("%w: invalid %s annotation: %v", ErrResourceVerificationFailed, RekorBundleAnnotation, err) //nolint:errorlint
+		}
+	}
+	return keyless, nil
+}
+
 // prepareObjectMeta will remove annotations not configured from user side -- "kubectl-client-side-apply" and "kubectl.kubernetes.io/last-applied-configuration"
 // (added when an object is created with `kubectl apply`) to avoid verification failure and extract the signature.
 // Returns a copy of the input object metadata with the annotations removed and the object's signature,
@@ -267,6 +417,9 @@ func prepareObjectMeta(in metav1.ObjectMeta) (metav1.ObjectMeta, []byte, error)
 	// like resolver doesn't modify the annotations, otherwise the verification will fail
 	delete(out.Annotations, "kubectl-client-side-apply")
 	delete(out.Annotations, "kubectl.kubernetes.io/last-applied-configuration")
+	// the material of keyless signatures is added once the resource is signed
+	delete(out.Annotations, CertificateAnnotation)
+	delete(out.Annotations, RekorBundleAnnotation)
 
 	// signature should be contained in annotation
 	sig, ok := in.Annotations[SignatureAnnotation]
@@ -21,12 +21,15 @@ import (
 	"crypto"
 	"crypto/elliptic"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/trustedresources/verifier"
@@ -208,6 +211,11 @@ func TestVerifyResource_Task_Success(t *testing.T) {
 		},
 	}
 
+	imagesPolicy := warnPolicy.DeepCopy()
+	imagesPolicy.Name = "imagesPolicy"
+	imagesPolicy.Spec.Mode = v1alpha1.ModeEnforce
+	imagesPolicy.Spec.Kind = v1alpha1.PolicyKindImages
+
 	signedTask384, err := test.GetSignedTask(unsignedTask, signer384, "signed384")
 	if err != nil {
 		t.Fatal("fail to sign task", err)
@@ -276,6 +284,13 @@ func TestVerifyResource_Task_Success(t *testing.T) {
 		verificationNoMatchPolicy:  config.FailNoMatchPolicy,
 		verificationPolicies:       []*v1alpha1.VerificationPolicy{warnNoKeyPolicy},
 		expectedVerificationResult: VerificationResult{VerificationResultType: VerificationWarn, Err: verifier.ErrEmptyPublicKeys},
+	}, {
+		name:                       "unsigned task matching only policies of images skips verification",
+		task:                       unsignedTask,
+		source:                     &v1beta1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"},
+		verificationNoMatchPolicy:  config.IgnoreNoMatchPolicy,
+		verificationPolicies:       []*v1alpha1.VerificationPolicy{imagesPolicy},
+		expectedVerificationResult: VerificationResult{VerificationResultType: VerificationSkip},
 	}}
 
 	for _, tc := range tcs {
@@ -502,6 +517,261 @@ func TestVerifyResource_Pipeline_Error(t *testing.T) {
 	}
 }
 
+func TestVerifyResource_V1(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, config.FailNoMatchPolicy)
+	sv, _, k8sclient, vps := test.SetupVerificationPolicies(t)
+
+	unsignedTask := &v1.Task{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Task"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-task", Namespace: namespace},
+		Spec: v1.TaskSpec{
+			Steps: []v1.Step{{Image: "ubuntu", Name: "echo"}},
+		},
+	}
+	signedTask, err := test.GetSignedV1Task(unsignedTask, sv, "signed")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Steps[0].Image = "attack"
+
+	unsignedPipeline := &v1.Pipeline{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "Pipeline"},
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pipeline", Namespace: namespace},
+		Spec: v1.PipelineSpec{
+			Tasks: []v1.PipelineTask{{Name: "task"}},
+		},
+	}
+	signedPipeline, err := test.GetSignedV1Pipeline(unsignedPipeline, sv, "signed")
+	if err != nil {
+		t.Fatal("fail to sign pipeline", err)
+	}
+	tamperedPipeline := signedPipeline.DeepCopy()
+	tamperedPipeline.Spec.Tasks[0].Name = "attack"
+
+	source := &v1beta1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"}
+	tcs := []struct {
+		name          string
+		resource      metav1.Object
+		expectedType  VerificationResultType
+		expectedError error
+	}{{
+		name:         "signed v1 Task passes verification",
+		resource:     signedTask,
+		expectedType: VerificationPass,
+	}, {
+		name:          "modified v1 Task fails verification",
+		resource:      tamperedTask,
+		expectedType:  VerificationError,
+		expectedError: ErrResourceVerificationFailed,
+	}, {
+		name:          "unsigned v1 Task fails verification",
+		resource:      unsignedTask,
+		expectedType:  VerificationError,
+		expectedError: ErrSignatureMissing,
+	}, {
+		name:         "signed v1 Pipeline passes verification",
+		resource:     signedPipeline,
+		expectedType: VerificationPass,
+	}, {
+		name:          "modified v1 Pipeline fails verification",
+		resource:      tamperedPipeline,
+		expectedType:  VerificationError,
+		expectedError: ErrResourceVerificationFailed,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyResource(ctx, tc.resource, k8sclient, source, vps)
+			if vr.VerificationResultType != tc.expectedType {
+				t.Fatalf("VerificationResultType mismatch: want %v, got %v (%v)", tc.expectedType, vr.VerificationResultType, vr.Err)
+			}
+			if !errors.Is(vr.Err, tc.expectedError) {
+				t.Errorf("VerifyResource got: %v, want: %v", vr.Err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestVerifyResource_SignatureMissing(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, config.FailNoMatchPolicy)
+	_, _, k8sclient, vps := test.SetupVerificationPolicies(t)
+
+	tcs := []struct {
+		name         string
+		source       *v1beta1.RefSource
+		expectedType VerificationResultType
+	}{{
+		name:         "unsigned Task fails enforce policies",
+		source:       &v1beta1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"},
+		expectedType: VerificationError,
+	}, {
+		name:         "unsigned Task warns with only warn policies",
+		source:       &v1beta1.RefSource{URI: "warnVP"},
+		expectedType: VerificationWarn,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyResource(ctx, test.GetUnsignedTask("test-task"), k8sclient, tc.source, vps)
+			if vr.VerificationResultType != tc.expectedType {
+				t.Errorf("VerificationResultType mismatch: want %v, got %v", tc.expectedType, vr.VerificationResultType)
+			}
+			if !errors.Is(vr.Err, ErrSignatureMissing) || !errors.Is(vr.Err, ErrResourceVerificationFailed) {
+				t.Errorf("VerifyResource got: %v, want: %v", vr.Err, ErrSignatureMissing)
+			}
+		})
+	}
+}
+
+func TestVerifyResource_Keyless(t *testing.T) {
+	ctx := logging.WithLogger(context.Background(), zaptest.NewLogger(t).Sugar())
+	ctx = test.SetupTrustedResourceConfig(ctx, config.FailNoMatchPolicy)
+	_, _, k8sclient, _ := test.SetupVerificationPolicies(t)
+
+	signer := test.NewKeylessSigner(t, "https://token.actions.githubusercontent.com", "release@tekton.dev")
+	otherSigner := test.NewKeylessSigner(t, "https://token.actions.githubusercontent.com", "release@tekton.dev")
+	signedTask, err := test.GetKeylessSignedTask(test.GetUnsignedTask("test-task"), signer, "signed")
+	if err != nil {
+		t.Fatal("fail to sign task", err)
+	}
+
+	policy := func(mutate func(*v1alpha1.KeylessRef)) []*v1alpha1.VerificationPolicy {
+		authority := signer.Authority("keyless")
+		if mutate != nil {
+			mutate(authority.Keyless)
+		}
+		return []*v1alpha1.VerificationPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Name: "keyless", Namespace: namespace},
+			Spec: v1alpha1.VerificationPolicySpec{
+				Resources:   []v1alpha1.ResourcePattern{{Pattern: ".*"}},
+				Authorities: []v1alpha1.Authority{authority},
+			},
+		}}
+	}
+	mutateBundle := func(mutate func(*verifier.RekorBundle)) *v1beta1.Task {
+		task := signedTask.DeepCopy()
+		bundle := &verifier.RekorBundle{}
+		if err := json.Unmarshal([]byte(task.Annotations[RekorBundleAnnotation]), bundle); err != nil {
+			t.Fatal(err)
+		}
+		mutate(bundle)
+		b, err := json.Marshal(bundle)
+		if err != nil {
+			t.Fatal(err)
+		}
+		task.Annotations[RekorBundleAnnotation] = string(b)
+		return task
+	}
+	tamperedTask := signedTask.DeepCopy()
+	tamperedTask.Spec.Steps[0].Image = "attack"
+	noBundleTask := signedTask.DeepCopy()
+	delete(noBundleTask.Annotations, RekorBundleAnnotation)
+
+	source := &v1beta1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"}
+	tcs := []struct {
+		name          string
+		task          *v1beta1.Task
+		policies      []*v1alpha1.VerificationPolicy
+		expectedType  VerificationResultType
+		expectedError error
+	}{{
+		name:         "keyless signed Task passes verification",
+		task:         signedTask,
+		policies:     policy(nil),
+		expectedType: VerificationPass,
+	}, {
+		name: "keyless signed Task passes verification with identity regular expressions",
+		task: signedTask,
+		policies: policy(func(k *v1alpha1.KeylessRef) {
+			k.Identities = []v1alpha1.Identity{{IssuerRegExp: `https://token\.actions\..*`, SubjectRegExp: `.*@tekton\.dev`}}
+		}),
+		expectedType: VerificationPass,
+	}, {
+		name:          "modified Task fails verification",
+		task:          tamperedTask,
+		policies:      policy(nil),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "Task signed by another subject fails verification",
+		task: signedTask,
+		policies: policy(func(k *v1alpha1.KeylessRef) {
+			k.Identities = []v1alpha1.Identity{{Issuer: signer.Issuer, Subject: "attacker@example.com"}}
+		}),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrIdentityMismatch,
+	}, {
+		name: "Task signed with another issuer fails verification",
+		task: signedTask,
+		policies: policy(func(k *v1alpha1.KeylessRef) {
+			k.Identities = []v1alpha1.Identity{{Issuer: "https://accounts.google.com", SubjectRegExp: ".*"}}
+		}),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrIdentityMismatch,
+	}, {
+		name: "certificate from an untrusted CA fails verification",
+		task: signedTask,
+		policies: policy(func(k *v1alpha1.KeylessRef) {
+			k.CARoots = otherSigner.CARoots
+		}),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrCertificateNotTrusted,
+	}, {
+		name: "entry of an untrusted transparency log fails verification",
+		task: signedTask,
+		policies: policy(func(k *v1alpha1.KeylessRef) {
+			k.TransparencyLog.Data = otherSigner.LogPublicKey
+		}),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "tampered inclusion proof fails verification",
+		task: mutateBundle(func(b *verifier.RekorBundle) {
+			b.InclusionProof.Hashes = b.InclusionProof.Hashes[1:]
+		}),
+		policies:      policy(nil),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "missing inclusion proof fails verification",
+		task: mutateBundle(func(b *verifier.RekorBundle) {
+			b.InclusionProof = nil
+		}),
+		policies:      policy(nil),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrInvalidTransparencyLogEntry,
+	}, {
+		name: "tampered integrated time fails verification",
+		task: mutateBundle(func(b *verifier.RekorBundle) {
+			b.Payload.IntegratedTime += 3600
+		}),
+		policies:      policy(nil),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrInvalidTransparencyLogEntry,
+	}, {
+		name:          "Task without transparency log entry fails verification",
+		task:          noBundleTask,
+		policies:      policy(nil),
+		expectedType:  VerificationError,
+		expectedError: verifier.ErrMissingTransparencyLogEntry,
+	}}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			vr := VerifyResource(ctx, tc.task, k8sclient, source, tc.policies)
+			if vr.VerificationResultType != tc.expectedType {
+				t.Fatalf("VerificationResultType mismatch: want %v, got %v (%v)", tc.expectedType, vr.VerificationResultType, vr.Err)
+			}
+			if tc.expectedError != nil && !errors.Is(vr.Err, ErrResourceVerificationFailed) {
+				t.Errorf("VerifyResource got: %v, want: %v", vr.Err, ErrResourceVerificationFailed)
+			}
+			if tc.expectedError != nil && !strings.Contains(vr.Err.Error(), tc.expectedError.Error()) {
+				t.Errorf("VerifyResource got: %v, want it to contain: %v", vr.Err, tc.expectedError)
+			}
+		})
+	}
+}
+
 func TestVerifyResource_TypeNotSupported(t *testing.T) {
 	resource := v1beta1.ClusterTask{}
 	refSource := &v1beta1.RefSource{URI: "git+https://github.com/tektoncd/catalog.git"}
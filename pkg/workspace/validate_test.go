@@ -199,6 +199,23 @@ func TestValidateOnlyOnePVCIsUsed_Valid(t *testing.T) {
 				ClaimName: "foo",
 			},
 		}},
+	}, {
+		name: "an error is not returned when csi and projected volumes are used with one PV claim",
+		bindings: []v1beta1.WorkspaceBinding{{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "foo",
+			},
+		}, {
+			CSI: &corev1.CSIVolumeSource{
+				Driver: "secrets-store.csi.k8s.io",
+			},
+		}, {
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{
+					ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"},
+				}},
+			},
+		}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			if err := workspace.ValidateOnlyOnePVCIsUsed(tc.bindings); err != nil {
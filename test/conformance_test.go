@@ -24,10 +24,12 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/conformance"
 	"github.com/tektoncd/pipeline/test/parse"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -193,3 +195,29 @@ spec:
 		})
 	}
 }
+
+// TestConformanceCorpus runs the conformance corpus of pkg/conformance, which
+// vendors can run against their own implementations.
+func TestConformanceCorpus(t *testing.T) {
+	ctx := context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	c, namespace := setup(ctx, t)
+
+	knativetest.CleanupOnInterrupt(func() { tearDown(ctx, t, c, namespace) }, t.Logf)
+	defer tearDown(ctx, t, c, namespace)
+
+	runner := conformance.Runner{
+		Executor: conformance.NewClusterExecutor(c.V1TaskRunClient, c.V1PipelineRunClient, time.Second),
+		Timeout:  timeout,
+	}
+	report := runner.Run(ctx, conformance.Corpus(getTestImage(busyboxImage)))
+	for _, result := range report.Results {
+		if result.Error != nil {
+			t.Errorf("Case %s: %v", result.Name, result.Error)
+		}
+		for _, f := range result.Failures {
+			t.Errorf("Case %s: %v", result.Name, f)
+		}
+	}
+}
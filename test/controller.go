@@ -18,7 +18,9 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"sync/atomic"
 	"testing"
 
@@ -31,7 +33,12 @@ import (
 	informersv1alpha1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1alpha1"
 	informersv1beta1 "github.com/tektoncd/pipeline/pkg/client/informers/externalversions/pipeline/v1beta1"
 	fakepipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client/fake"
+	fakecloudeventsinkinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/cloudeventsink/fake"
+	fakeexecutionwindowpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/executionwindowpolicy/fake"
+	fakenotificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/notificationpolicy/fake"
+	fakeserviceaccountpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/serviceaccountpolicy/fake"
 	fakeverificationpolicyinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/verificationpolicy/fake"
+	fakeworkspacepoolinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1alpha1/workspacepool/fake"
 	fakeclustertaskinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/clustertask/fake"
 	fakecustomruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/customrun/fake"
 	fakepipelineinformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipeline/fake"
@@ -49,6 +56,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	fakekubeclientset "k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
@@ -57,6 +65,7 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	fakelimitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange/fake"
+	fakepersistentvolumeclaiminformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
@@ -77,9 +86,15 @@ type Data struct {
 	ConfigMaps              []*corev1.ConfigMap
 	ServiceAccounts         []*corev1.ServiceAccount
 	LimitRange              []*corev1.LimitRange
+	PersistentVolumeClaims  []*corev1.PersistentVolumeClaim
 	ResolutionRequests      []*resolutionv1alpha1.ResolutionRequest
 	ExpectedCloudEventCount int
 	VerificationPolicies    []*v1alpha1.VerificationPolicy
+	ServiceAccountPolicies  []*v1alpha1.ServiceAccountPolicy
+	CloudEventSinks         []*v1alpha1.CloudEventSink
+	NotificationPolicies    []*v1alpha1.NotificationPolicy
+	ExecutionWindowPolicies []*v1alpha1.ExecutionWindowPolicy
+	WorkspacePools          []*v1alpha1.WorkspacePool
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -92,19 +107,25 @@ type Clients struct {
 
 // Informers holds references to informers which are useful for reconciler tests.
 type Informers struct {
-	PipelineRun        informersv1beta1.PipelineRunInformer
-	Pipeline           informersv1beta1.PipelineInformer
-	TaskRun            informersv1beta1.TaskRunInformer
-	Run                informersv1alpha1.RunInformer
-	CustomRun          informersv1beta1.CustomRunInformer
-	Task               informersv1beta1.TaskInformer
-	ClusterTask        informersv1beta1.ClusterTaskInformer
-	Pod                coreinformers.PodInformer
-	ConfigMap          coreinformers.ConfigMapInformer
-	ServiceAccount     coreinformers.ServiceAccountInformer
-	LimitRange         coreinformers.LimitRangeInformer
-	ResolutionRequest  resolutioninformersv1alpha1.ResolutionRequestInformer
-	VerificationPolicy informersv1alpha1.VerificationPolicyInformer
+	PipelineRun           informersv1beta1.PipelineRunInformer
+	Pipeline              informersv1beta1.PipelineInformer
+	TaskRun               informersv1beta1.TaskRunInformer
+	Run                   informersv1alpha1.RunInformer
+	CustomRun             informersv1beta1.CustomRunInformer
+	Task                  informersv1beta1.TaskInformer
+	ClusterTask           informersv1beta1.ClusterTaskInformer
+	Pod                   coreinformers.PodInformer
+	ConfigMap             coreinformers.ConfigMapInformer
+	ServiceAccount        coreinformers.ServiceAccountInformer
+	LimitRange            coreinformers.LimitRangeInformer
+	PersistentVolumeClaim coreinformers.PersistentVolumeClaimInformer
+	ResolutionRequest     resolutioninformersv1alpha1.ResolutionRequestInformer
+	VerificationPolicy    informersv1alpha1.VerificationPolicyInformer
+	ServiceAccountPolicy  informersv1alpha1.ServiceAccountPolicyInformer
+	CloudEventSink        informersv1alpha1.CloudEventSinkInformer
+	NotificationPolicy    informersv1alpha1.NotificationPolicyInformer
+	ExecutionWindowPolicy informersv1alpha1.ExecutionWindowPolicyInformer
+	WorkspacePool         informersv1alpha1.WorkspacePoolInformer
 }
 
 // Assets holds references to the controller, logs, clients, and informers.
@@ -162,6 +183,99 @@ func AddToInformer(t *testing.T, store cache.Store) func(ktesting.Action) (bool,
 	}
 }
 
+// ApplyStatus returns a reactor for the server-side applies of the status of the runs,
+// which the fake clients don't implement. Since the controller is the only manager of the
+// fields of the status, it replaces the status of the run with the applied one, without
+// the null fields which an apply omits, and updates the informer index. Like the API
+// server, it fails the applies with a conflict when the UID or the resourceVersion of
+// the run don't match.
+func ApplyStatus(t *testing.T, tracker ktesting.ObjectTracker, store cache.Store) func(ktesting.Action) (bool, runtime.Object, error) {
+	t.Helper()
+	return func(action ktesting.Action) (bool, runtime.Object, error) {
+		a, ok := action.(ktesting.PatchActionImpl)
+		if !ok || a.GetPatchType() != types.ApplyPatchType || a.GetSubresource() != "status" {
+			return false, nil, nil
+		}
+		obj, err := tracker.Get(a.GetResource(), a.GetNamespace(), a.GetName())
+		if err != nil {
+			return true, nil, err
+		}
+		var applied struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+			Status   json.RawMessage   `json:"status"`
+		}
+		if err := json.Unmarshal(a.GetPatch(), &applied); err != nil {
+			return true, nil, err
+		}
+		objMeta, err := meta.Accessor(obj)
+		if err != nil {
+			return true, nil, err
+		}
+		if applied.Metadata.UID != "" && applied.Metadata.UID != objMeta.GetUID() {
+			return true, nil, apierrs.NewConflict(a.GetResource().GroupResource(), a.GetName(),
+				fmt.Errorf("uid mismatch, got: %v, wanted: %v", applied.Metadata.UID, objMeta.GetUID()))
+		}
+		if applied.Metadata.ResourceVersion != "" && applied.Metadata.ResourceVersion != objMeta.GetResourceVersion() {
+			return true, nil, apierrs.NewConflict(a.GetResource().GroupResource(), a.GetName(),
+				fmt.Errorf("resourceVersion mismatch, got: %v, wanted: %v", applied.Metadata.ResourceVersion, objMeta.GetResourceVersion()))
+		}
+		var status interface{}
+		if err := json.Unmarshal(applied.Status, &status); err != nil {
+			return true, nil, err
+		}
+		appliedStatus, err := json.Marshal(withoutNulls(status))
+		if err != nil {
+			return true, nil, err
+		}
+
+		current, err := json.Marshal(obj)
+		if err != nil {
+			return true, nil, err
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(current, &fields); err != nil {
+			return true, nil, err
+		}
+		fields["status"] = appliedStatus
+		merged, err := json.Marshal(fields)
+		if err != nil {
+			return true, nil, err
+		}
+		// Reset the object, since unmarshalling doesn't clear the fields missing from the applied status.
+		value := reflect.ValueOf(obj)
+		value.Elem().Set(reflect.New(value.Type().Elem()).Elem())
+		if err := json.Unmarshal(merged, obj); err != nil {
+			return true, nil, err
+		}
+		if err := tracker.Update(a.GetResource(), obj, a.GetNamespace()); err != nil {
+			return true, nil, err
+		}
+		if err := store.Update(obj); err != nil {
+			t.Fatal(err)
+		}
+		return true, obj, nil
+	}
+}
+
+// withoutNulls returns the JSON value without its null fields.
+func withoutNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if field == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = withoutNulls(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = withoutNulls(item)
+		}
+	}
+	return value
+}
+
 // SeedTestData returns Clients and Informers populated with the
 // given Data.
 //
@@ -178,24 +292,31 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 	PrependResourceVersionReactor(&c.Pipeline.Fake)
 
 	i := Informers{
-		PipelineRun:        fakepipelineruninformer.Get(ctx),
-		Pipeline:           fakepipelineinformer.Get(ctx),
-		TaskRun:            faketaskruninformer.Get(ctx),
-		CustomRun:          fakecustomruninformer.Get(ctx),
-		Task:               faketaskinformer.Get(ctx),
-		ClusterTask:        fakeclustertaskinformer.Get(ctx),
-		Pod:                fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
-		ConfigMap:          fakeconfigmapinformer.Get(ctx),
-		ServiceAccount:     fakeserviceaccountinformer.Get(ctx),
-		LimitRange:         fakelimitrangeinformer.Get(ctx),
-		ResolutionRequest:  fakeresolutionrequestinformer.Get(ctx),
-		VerificationPolicy: fakeverificationpolicyinformer.Get(ctx),
+		PipelineRun:           fakepipelineruninformer.Get(ctx),
+		Pipeline:              fakepipelineinformer.Get(ctx),
+		TaskRun:               faketaskruninformer.Get(ctx),
+		CustomRun:             fakecustomruninformer.Get(ctx),
+		Task:                  faketaskinformer.Get(ctx),
+		ClusterTask:           fakeclustertaskinformer.Get(ctx),
+		Pod:                   fakefilteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey),
+		ConfigMap:             fakeconfigmapinformer.Get(ctx),
+		ServiceAccount:        fakeserviceaccountinformer.Get(ctx),
+		LimitRange:            fakelimitrangeinformer.Get(ctx),
+		PersistentVolumeClaim: fakepersistentvolumeclaiminformer.Get(ctx),
+		ResolutionRequest:     fakeresolutionrequestinformer.Get(ctx),
+		VerificationPolicy:    fakeverificationpolicyinformer.Get(ctx),
+		ServiceAccountPolicy:  fakeserviceaccountpolicyinformer.Get(ctx),
+		CloudEventSink:        fakecloudeventsinkinformer.Get(ctx),
+		NotificationPolicy:    fakenotificationpolicyinformer.Get(ctx),
+		ExecutionWindowPolicy: fakeexecutionwindowpolicyinformer.Get(ctx),
+		WorkspacePool:         fakeworkspacepoolinformer.Get(ctx),
 	}
 
 	// Attach reactors that add resource mutations to the appropriate
 	// informer index, and simulate optimistic concurrency failures when
 	// the resource version is mismatched.
 	c.Pipeline.PrependReactor("*", "pipelineruns", AddToInformer(t, i.PipelineRun.Informer().GetIndexer()))
+	c.Pipeline.PrependReactor("patch", "pipelineruns", ApplyStatus(t, c.Pipeline.Tracker(), i.PipelineRun.Informer().GetIndexer()))
 	for _, pr := range d.PipelineRuns {
 		pr := pr.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Pipeline.TektonV1beta1().PipelineRuns(pr.Namespace).Create(ctx, pr, metav1.CreateOptions{}); err != nil {
@@ -210,6 +331,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		}
 	}
 	c.Pipeline.PrependReactor("*", "taskruns", AddToInformer(t, i.TaskRun.Informer().GetIndexer()))
+	c.Pipeline.PrependReactor("patch", "taskruns", ApplyStatus(t, c.Pipeline.Tracker(), i.TaskRun.Informer().GetIndexer()))
 	for _, tr := range d.TaskRuns {
 		tr := tr.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Pipeline.TektonV1beta1().TaskRuns(tr.Namespace).Create(ctx, tr, metav1.CreateOptions{}); err != nil {
@@ -264,6 +386,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "persistentvolumeclaims", AddToInformer(t, i.PersistentVolumeClaim.Informer().GetIndexer()))
+	for _, pvc := range d.PersistentVolumeClaims {
+		pvc := pvc.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Kube.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.ResolutionRequests.PrependReactor("*", "resolutionrequests", AddToInformer(t, i.ResolutionRequest.Informer().GetIndexer()))
 	for _, rr := range d.ResolutionRequests {
 		rr := rr.DeepCopy() // Avoid assumptions that the informer's copy is modified.
@@ -279,6 +408,41 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Pipeline.PrependReactor("*", "serviceaccountpolicies", AddToInformer(t, i.ServiceAccountPolicy.Informer().GetIndexer()))
+	for _, sap := range d.ServiceAccountPolicies {
+		sap := sap.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().ServiceAccountPolicies(sap.Namespace).Create(ctx, sap, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Pipeline.PrependReactor("*", "cloudeventsinks", AddToInformer(t, i.CloudEventSink.Informer().GetIndexer()))
+	for _, sink := range d.CloudEventSinks {
+		sink := sink.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().CloudEventSinks(sink.Namespace).Create(ctx, sink, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Pipeline.PrependReactor("*", "notificationpolicies", AddToInformer(t, i.NotificationPolicy.Informer().GetIndexer()))
+	for _, p := range d.NotificationPolicies {
+		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().NotificationPolicies(p.Namespace).Create(ctx, p, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Pipeline.PrependReactor("*", "executionwindowpolicies", AddToInformer(t, i.ExecutionWindowPolicy.Informer().GetIndexer()))
+	for _, p := range d.ExecutionWindowPolicies {
+		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().ExecutionWindowPolicies(p.Namespace).Create(ctx, p, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Pipeline.PrependReactor("*", "workspacepools", AddToInformer(t, i.WorkspacePool.Informer().GetIndexer()))
+	for _, p := range d.WorkspacePools {
+		p := p.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Pipeline.TektonV1alpha1().WorkspacePools().Create(ctx, p, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	c.Pipeline.ClearActions()
 	c.Kube.ClearActions()
 	c.ResolutionRequests.ClearActions()
@@ -328,7 +492,7 @@ func PrependResourceVersionReactor(f *ktesting.Fake) {
 
 // EnsureConfigurationConfigMapsExist makes sure all the configmaps exists.
 func EnsureConfigurationConfigMapsExist(d *Data) {
-	var defaultsExists, featureFlagsExists, metricsExists, spireconfigExists bool
+	var defaultsExists, featureFlagsExists, metricsExists, spireconfigExists, runNamespaceExists, logForwardingExists, faultInjectionExists, eventsExists, imageMirrorsExists, imagePullSecretsExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -342,6 +506,24 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 		if cm.Name == config.GetSpireConfigName() {
 			spireconfigExists = true
 		}
+		if cm.Name == config.GetRunNamespaceConfigName() {
+			runNamespaceExists = true
+		}
+		if cm.Name == config.GetLogForwardingConfigName() {
+			logForwardingExists = true
+		}
+		if cm.Name == config.GetFaultInjectionConfigName() {
+			faultInjectionExists = true
+		}
+		if cm.Name == config.GetEventsConfigName() {
+			eventsExists = true
+		}
+		if cm.Name == config.GetImageMirrorsConfigName() {
+			imageMirrorsExists = true
+		}
+		if cm.Name == config.GetImagePullSecretsConfigName() {
+			imagePullSecretsExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -367,4 +549,40 @@ func EnsureConfigurationConfigMapsExist(d *Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !runNamespaceExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetRunNamespaceConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !logForwardingExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetLogForwardingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !faultInjectionExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetFaultInjectionConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !eventsExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetEventsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !imageMirrorsExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetImageMirrorsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !imagePullSecretsExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetImagePullSecretsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
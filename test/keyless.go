@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+const (
+	// certificateAnnotation is the key of the signing certificate of a keyless signature in annotation map
+	certificateAnnotation = "tekton.dev/certificate"
+	// rekorBundleAnnotation is the key of the transparency log entry of a keyless signature in annotation map
+	rekorBundleAnnotation = "tekton.dev/rekor-bundle"
+	// logOrigin is the origin of the checkpoints of the test transparency log
+	logOrigin = "test-rekor - 1"
+)
+
+// oidIssuerV2 is the Fulcio certificate extension holding the OIDC issuer of the signer.
+var oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+
+// KeylessSigner signs resources keyless like cosign: with a short-lived certificate
+// issued to an identity by a test certificate authority, recording the signature in
+// a test transparency log.
+type KeylessSigner struct {
+	// Issuer is the OIDC issuer of the identity of the signer.
+	Issuer string
+	// Subject is the email of the identity of the signer.
+	Subject string
+	// CARoots is the PEM-encoded root certificate of the certificate authority.
+	CARoots string
+	// LogPublicKey is the PEM-encoded public key of the transparency log.
+	LogPublicKey string
+
+	caKey  *ecdsa.PrivateKey
+	caCert *x509.Certificate
+	log    signature.SignerVerifier
+}
+
+// NewKeylessSigner returns a KeylessSigner for the identity with its own
+// certificate authority and transparency log.
+func NewKeylessSigner(t *testing.T, issuer, subject string) *KeylessSigner {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate the CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("failed to create the CA certificate: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse the CA certificate: %v", err)
+	}
+	caRoots, err := cryptoutils.MarshalCertificateToPEM(caCert)
+	if err != nil {
+		t.Fatalf("failed to marshal the CA certificate: %v", err)
+	}
+
+	log, _, err := signature.NewDefaultECDSASignerVerifier()
+	if err != nil {
+		t.Fatalf("failed to generate the transparency log key: %v", err)
+	}
+	logPub, err := log.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to get the transparency log public key: %v", err)
+	}
+	logPublicKey, err := cryptoutils.MarshalPublicKeyToPEM(logPub)
+	if err != nil {
+		t.Fatalf("failed to marshal the transparency log public key: %v", err)
+	}
+
+	return &KeylessSigner{
+		Issuer:       issuer,
+		Subject:      subject,
+		CARoots:      string(caRoots),
+		LogPublicKey: string(logPublicKey),
+		caKey:        caKey,
+		caCert:       caCert,
+		log:          log,
+	}
+}
+
+// Authority returns the keyless authority of a VerificationPolicy trusting the
+// signer's identity, certificate authority and transparency log.
+func (k *KeylessSigner) Authority(name string) v1alpha1.Authority {
+	return v1alpha1.Authority{
+		Name: name,
+		Keyless: &v1alpha1.KeylessRef{
+			Identities: []v1alpha1.Identity{{
+				Issuer:  k.Issuer,
+				Subject: k.Subject,
+			}},
+			CARoots: k.CARoots,
+			TransparencyLog: &v1alpha1.KeyRef{
+				Data: k.LogPublicKey,
+			},
+			TransparencyLogOrigin: logOrigin,
+		},
+	}
+}
+
+// GetKeylessSignedTask signs the given task keyless and renames it with given name
+func GetKeylessSignedTask(unsigned *v1beta1.Task, signer *KeylessSigner, name string) (*v1beta1.Task, error) {
+	signedTask := unsigned.DeepCopy()
+	signedTask.Name = name
+	if signedTask.Annotations == nil {
+		signedTask.Annotations = map[string]string{}
+	}
+	annotations, err := signer.sign(signedTask)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range annotations {
+		signedTask.Annotations[k] = v
+	}
+	return signedTask, nil
+}
+
+// sign returns the annotations of the keyless signature of the object.
+func (k *KeylessSigner) sign(obj interface{}) (map[string]string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	issuer, err := asn1.MarshalWithParams(k.Issuer, "utf8")
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:    big.NewInt(now.UnixNano()),
+		NotBefore:       now.Add(-time.Minute),
+		NotAfter:        now.Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{k.Subject},
+		ExtraExtensions: []pkix.Extension{{Id: oidIssuerV2, Value: issuer}},
+	}, k.caCert, key.Public(), k.caKey)
+	if err != nil {
+		return nil, err
+	}
+	cert := cryptoutils.PEMEncode(cryptoutils.CertificatePEMType, der)
+
+	signer, err := signature.LoadECDSASignerVerifier(key, crypto.SHA256)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signInterface(signer, obj)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	message := sha256.Sum256(b)
+	digest := sha256.Sum256(message[:])
+
+	body, err := json.Marshal(map[string]interface{}{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]interface{}{
+			"signature": map[string]interface{}{
+				"content":   base64.StdEncoding.EncodeToString(sig),
+				"publicKey": map[string]string{"content": base64.StdEncoding.EncodeToString(cert)},
+			},
+			"data": map[string]interface{}{
+				"hash": map[string]string{"algorithm": "sha256", "value": hex.EncodeToString(digest[:])},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := k.logEntry(body, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		signatureAnnotation:   base64.StdEncoding.EncodeToString(sig),
+		certificateAnnotation: string(cert),
+		rekorBundleAnnotation: string(bundle),
+	}, nil
+}
+
+// logEntry records the body in a transparency log among other entries and
+// returns the bundle proving it.
+func (k *KeylessSigner) logEntry(body []byte, integratedTime time.Time) ([]byte, error) {
+	// The log is sharded: the index of the entry in the tree of its shard, which
+	// the inclusion proof is for, is offset from its index in the whole log.
+	const index, size, shardOffset = 3, 5, 1000
+	leaves := make([][]byte, size)
+	for i := range leaves {
+		leaves[i] = merkleLeafHash([]byte(fmt.Sprintf("entry %d", i)))
+	}
+	leaves[index] = merkleLeafHash(body)
+	root := merkleTreeHash(leaves)
+	var hashes []string
+	for _, h := range merkleAuditPath(index, leaves) {
+		hashes = append(hashes, hex.EncodeToString(h))
+	}
+
+	note := fmt.Sprintf("%s\n%d\n%s\n", logOrigin, size, base64.StdEncoding.EncodeToString(root))
+	noteSig, err := k.log.SignMessage(bytes.NewReader([]byte(note)))
+	if err != nil {
+		return nil, err
+	}
+	checkpoint := fmt.Sprintf("%s\n— test-rekor %s\n", note, base64.StdEncoding.EncodeToString(append([]byte{0, 0, 0, 0}, noteSig...)))
+
+	payload := struct {
+		Body           string `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{base64.StdEncoding.EncodeToString(body), integratedTime.Unix(), "test-rekor", shardOffset + index}
+	p, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	set, err := k.log.SignMessage(bytes.NewReader(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"SignedEntryTimestamp": set,
+		"Payload":              payload,
+		"InclusionProof": map[string]interface{}{
+			"logIndex":   index,
+			"rootHash":   hex.EncodeToString(root),
+			"treeSize":   size,
+			"hashes":     hashes,
+			"checkpoint": checkpoint,
+		},
+	})
+}
+
+func merkleLeafHash(leaf []byte) []byte {
+	h := sha256.Sum256(append([]byte{0}, leaf...))
+	return h[:]
+}
+
+func merkleNodeHash(left, right []byte) []byte {
+	h := sha256.Sum256(append(append([]byte{1}, left...), right...))
+	return h[:]
+}
+
+// merkleSplit returns the largest power of two smaller than n.
+func merkleSplit(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+// merkleTreeHash returns the root hash of the tree of the leaf hashes, as defined by RFC 6962.
+func merkleTreeHash(leaves [][]byte) []byte {
+	if len(leaves) == 1 {
+		return leaves[0]
+	}
+	k := merkleSplit(len(leaves))
+	return merkleNodeHash(merkleTreeHash(leaves[:k]), merkleTreeHash(leaves[k:]))
+}
+
+// merkleAuditPath returns the audit path of the leaf at index, as defined by RFC 6962.
+func merkleAuditPath(index int, leaves [][]byte) [][]byte {
+	if len(leaves) == 1 {
+		return nil
+	}
+	k := merkleSplit(len(leaves))
+	if index < k {
+		return append(merkleAuditPath(index, leaves[:k]), merkleTreeHash(leaves[k:]))
+	}
+	return append(merkleAuditPath(index-k, leaves[k:]), merkleTreeHash(leaves[:k]))
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
+	pipelinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -354,6 +355,36 @@ func GetSignedTask(unsigned *v1beta1.Task, signer signature.Signer, name string)
 	return signedTask, nil
 }
 
+// GetSignedV1Task signed the given v1 task and rename it with given name
+func GetSignedV1Task(unsigned *pipelinev1.Task, signer signature.Signer, name string) (*pipelinev1.Task, error) {
+	signedTask := unsigned.DeepCopy()
+	signedTask.Name = name
+	if signedTask.Annotations == nil {
+		signedTask.Annotations = map[string]string{}
+	}
+	signature, err := signInterface(signer, signedTask)
+	if err != nil {
+		return nil, err
+	}
+	signedTask.Annotations[signatureAnnotation] = base64.StdEncoding.EncodeToString(signature)
+	return signedTask, nil
+}
+
+// GetSignedV1Pipeline signed the given v1 pipeline and rename it with given name
+func GetSignedV1Pipeline(unsigned *pipelinev1.Pipeline, signer signature.Signer, name string) (*pipelinev1.Pipeline, error) {
+	signedPipeline := unsigned.DeepCopy()
+	signedPipeline.Name = name
+	if signedPipeline.Annotations == nil {
+		signedPipeline.Annotations = map[string]string{}
+	}
+	signature, err := signInterface(signer, signedPipeline)
+	if err != nil {
+		return nil, err
+	}
+	signedPipeline.Annotations[signatureAnnotation] = base64.StdEncoding.EncodeToString(signature)
+	return signedPipeline, nil
+}
+
 func getPass(confirm bool) ([]byte, error) {
 	read := read(confirm)
 	return read()